@@ -0,0 +1,707 @@
+package bql
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/sensorbee/sensorbee.v0/bql/parser"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// definition has the statements which defined a node or a state. It's
+// recorded by AddStmt so that PlanApply can compare running nodes with
+// desired ones.
+type definition struct {
+	// node or state is the instance created by stmt. A definition is only
+	// valid while the topology has the same instance.
+	node  core.Node
+	state core.SharedState
+
+	// stmt is the CREATE statement of the node or the state. Parameters
+	// given to UPDATE statements are merged into it.
+	stmt interface{}
+
+	// inserts has INSERT INTO statements of a sink having a single input.
+	// Keys are names of inputs in lower case.
+	inserts map[string]parser.InsertIntoFromStmt
+}
+
+// recordDefinition records the definition of the node or the state created,
+// updated, or dropped by the statement.
+func (tb *TopologyBuilder) recordDefinition(stmt interface{}, n core.Node) {
+	tb.defMutex.Lock()
+	defer tb.defMutex.Unlock()
+	if tb.nodeDefs == nil {
+		tb.nodeDefs = map[string]*definition{}
+		tb.stateDefs = map[string]*definition{}
+	}
+
+	switch stmt := stmt.(type) {
+	case parser.CreateSourceStmt:
+		tb.nodeDefs[strings.ToLower(string(stmt.Name))] = &definition{node: n, stmt: stmt}
+	case parser.ReplayArchiveStmt:
+		tb.nodeDefs[strings.ToLower(string(stmt.Stream))] = &definition{node: n, stmt: replayArchiveSource(&stmt)}
+	case parser.CreateStreamAsSelectStmt:
+		tb.nodeDefs[strings.ToLower(string(stmt.Name))] = &definition{node: n, stmt: stmt}
+	case parser.CreateStreamAsSelectUnionStmt:
+		tb.nodeDefs[strings.ToLower(string(stmt.Name))] = &definition{node: n, stmt: stmt}
+	case parser.CreateStreamAsEnrichStmt:
+		tb.nodeDefs[strings.ToLower(string(stmt.Name))] = &definition{node: n, stmt: stmt}
+	case parser.CreateSinkStmt:
+		tb.nodeDefs[strings.ToLower(string(stmt.Name))] = &definition{
+			node:    n,
+			stmt:    stmt,
+			inserts: map[string]parser.InsertIntoFromStmt{},
+		}
+	case parser.CreateStateStmt:
+		tb.recordState(stmt)
+	case parser.LoadStateOrCreateStmt:
+		c := parser.CreateStateStmt{Name: stmt.Name, Type: stmt.Type}
+		c.Params = stmt.CreateSpecs.Params
+		tb.recordState(c)
+
+	case parser.InsertIntoFromStmt:
+		d, ok := tb.nodeDefs[strings.ToLower(string(stmt.Sink))]
+		if !ok {
+			return
+		}
+		for _, in := range stmt.Inputs {
+			s := stmt
+			s.Inputs = []parser.StreamIdentifier{in}
+			d.inserts[strings.ToLower(string(in))] = s
+		}
+
+	case parser.UpdateSourceStmt:
+		if d, ok := tb.nodeDefs[strings.ToLower(string(stmt.Name))]; ok {
+			if c, ok := d.stmt.(parser.CreateSourceStmt); ok {
+				c.Params = mergeParams(c.Params, stmt.Params)
+				d.stmt = c
+			}
+		}
+	case parser.UpdateSinkStmt:
+		if d, ok := tb.nodeDefs[strings.ToLower(string(stmt.Name))]; ok {
+			if c, ok := d.stmt.(parser.CreateSinkStmt); ok {
+				c.Params = mergeParams(c.Params, stmt.Params)
+				d.stmt = c
+			}
+		}
+	case parser.UpdateStateStmt:
+		if d, ok := tb.stateDefs[strings.ToLower(string(stmt.Name))]; ok {
+			c := d.stmt.(parser.CreateStateStmt)
+			c.Params = mergeParams(c.Params, stmt.Params)
+			d.stmt = c
+		}
+
+	case parser.DropSourceStmt:
+		delete(tb.nodeDefs, strings.ToLower(string(stmt.Source)))
+	case parser.DropStreamStmt:
+		delete(tb.nodeDefs, strings.ToLower(string(stmt.Stream)))
+	case parser.DropSinkStmt:
+		delete(tb.nodeDefs, strings.ToLower(string(stmt.Sink)))
+	case parser.DropStateStmt:
+		delete(tb.stateDefs, strings.ToLower(string(stmt.State)))
+	}
+}
+
+func (tb *TopologyBuilder) recordState(stmt parser.CreateStateStmt) {
+	s, err := tb.topology.Context().SharedStates.Get(string(stmt.Name))
+	if err != nil {
+		return
+	}
+	tb.stateDefs[strings.ToLower(string(stmt.Name))] = &definition{state: s, stmt: stmt}
+}
+
+// mergeParams returns parameters having updates applied to params.
+func mergeParams(params, updates []parser.SourceSinkParamAST) []parser.SourceSinkParamAST {
+	res := append([]parser.SourceSinkParamAST{}, params...)
+	for _, u := range updates {
+		found := false
+		for i, p := range res {
+			if p.Key == u.Key {
+				res[i] = u
+				found = true
+				break
+			}
+		}
+		if !found {
+			res = append(res, u)
+		}
+	}
+	return res
+}
+
+// definitions returns definitions of nodes and states which still exist in
+// the topology.
+func (tb *TopologyBuilder) definitions() (map[string]*definition, map[string]*definition) {
+	tb.defMutex.Lock()
+	defer tb.defMutex.Unlock()
+	nodes := map[string]*definition{}
+	for name, d := range tb.nodeDefs {
+		if n, err := tb.topology.Node(name); err == nil && n == d.node {
+			nodes[name] = d
+		}
+	}
+	states := map[string]*definition{}
+	for name, d := range tb.stateDefs {
+		if s, err := tb.topology.Context().SharedStates.Get(name); err == nil && s == d.state {
+			states[name] = d
+		}
+	}
+	return nodes, states
+}
+
+// ApplyAction is an action of an ApplyOperation.
+type ApplyAction string
+
+const (
+	// ApplyCreate creates a node or a state.
+	ApplyCreate ApplyAction = "create"
+
+	// ApplyDrop drops a node or a state.
+	ApplyDrop ApplyAction = "drop"
+
+	// ApplyUpdate updates parameters of a source, a sink, or a state.
+	ApplyUpdate ApplyAction = "update"
+
+	// ApplyConnect connects a sink to an input with INSERT INTO.
+	ApplyConnect ApplyAction = "connect"
+)
+
+// ApplyOperation is an operation in an ApplyPlan. A node being recreated has
+// an ApplyDrop operation and an ApplyCreate operation.
+type ApplyOperation struct {
+	Action ApplyAction
+
+	// Kind is "source", "stream", "sink", or "state".
+	Kind string
+
+	// Name is the name of the node or the state.
+	Name string
+
+	// Stmt is the statement executing the operation.
+	Stmt interface{}
+
+	// Reason describes why the operation is necessary.
+	Reason string
+}
+
+func (o *ApplyOperation) String() string {
+	return fmt.Sprint(o.Stmt)
+}
+
+// ApplyPlan is a list of operations which makes a topology have the desired
+// nodes and states. It's created by TopologyBuilder.PlanApply.
+type ApplyPlan struct {
+	Operations []*ApplyOperation
+}
+
+// desiredNode is a node or a state defined in desired statements.
+type desiredNode struct {
+	kind  string
+	name  string
+	index int
+	stmt  interface{}
+
+	// inserts has INSERT INTO statements of a sink by names of inputs in
+	// lower case.
+	inserts    map[string]parser.InsertIntoFromStmt
+	insertKeys []string
+}
+
+// nodeDecision is the decision of how a node or a state is reconciled.
+type nodeDecision struct {
+	kind    string
+	name    string
+	drop    bool
+	create  bool
+	update  []parser.SourceSinkParamAST
+	reason  string
+	desired *desiredNode
+	running *definition
+}
+
+// PlanApply compares nodes and states in the topology with the ones defined
+// by the desired statements and returns operations necessary to make the
+// topology have the desired ones. It doesn't modify the topology. Desired
+// statements can only be CREATE SOURCE, CREATE STREAM, CREATE SINK, CREATE
+// STATE, and INSERT INTO whose sink is created in the statements.
+//
+// A node or a state is created when it doesn't exist. When it exists but is
+// defined differently, its parameters are updated if only parameters of
+// a source, a sink, or a state supporting core.Updater are changed and
+// no parameter is removed. Otherwise, it's recreated. Streams and sinks
+// reading from a recreated node are also recreated. A node
+// whose definition isn't known, e.g. a node added by Go code or by another
+// TopologyBuilder, is always recreated when the desired statements have it.
+//
+// When prune is true, nodes and states created by statements of the
+// TopologyBuilder but not defined in the desired statements are dropped.
+// Other nodes and states are never dropped.
+func (tb *TopologyBuilder) PlanApply(stmts []interface{}, prune bool) (*ApplyPlan, error) {
+	desired, err := desiredNodes(stmts)
+	if err != nil {
+		return nil, err
+	}
+	nodeDefs, stateDefs := tb.definitions()
+
+	decisions := map[string]*nodeDecision{}
+	for key, d := range desired {
+		decisions[key] = tb.decide(d, nodeDefs, stateDefs)
+	}
+	if prune {
+		for name, def := range nodeDefs {
+			if _, ok := desired["node:"+name]; !ok {
+				decisions["node:"+name] = &nodeDecision{
+					kind: definitionKind(def.stmt), name: nodeName(def.stmt),
+					drop: true, reason: "not defined", running: def,
+				}
+			}
+		}
+		for name, def := range stateDefs {
+			if _, ok := desired["state:"+name]; !ok {
+				decisions["state:"+name] = &nodeDecision{
+					kind: "state", name: nodeName(def.stmt),
+					drop: true, reason: "not defined", running: def,
+				}
+			}
+		}
+	}
+
+	// Recreate streams reading from dropped nodes until nothing changes.
+	for changed := true; changed; {
+		changed = false
+		for _, d := range decisions {
+			if d.drop || d.running == nil || d.kind != "stream" {
+				continue
+			}
+			for _, in := range stmtInputs(d.running.stmt) {
+				if in := decisions["node:"+in]; in != nil && in.drop {
+					d.drop, d.create, d.update = true, true, nil
+					d.reason = fmt.Sprintf("input '%v' is recreated", in.name)
+					changed = true
+					break
+				}
+			}
+		}
+	}
+
+	// Sinks can't be disconnected from their inputs, and a sink is
+	// disconnected from a dropped input asynchronously. So, a sink connected
+	// to an input which isn't desired or which is recreated is also
+	// recreated. Only the input being dropped without being recreated is
+	// just left.
+	for _, d := range decisions {
+		if d.kind != "sink" || d.drop || d.running == nil {
+			continue
+		}
+		for in, r := range d.running.inserts {
+			if dec := decisions["node:"+in]; dec != nil && dec.drop {
+				if !dec.create {
+					continue
+				}
+				d.drop, d.create, d.update = true, true, nil
+				d.reason = fmt.Sprintf("input '%v' is recreated", r.Inputs[0])
+				break
+			}
+			if s, ok := d.desired.inserts[in]; ok && fmt.Sprint(s) == fmt.Sprint(r) {
+				continue
+			}
+			d.drop, d.create, d.update = true, true, nil
+			d.reason = fmt.Sprintf("input '%v' is disconnected", r.Inputs[0])
+			break
+		}
+	}
+
+	return tb.buildApplyPlan(desired, decisions), nil
+}
+
+func desiredNodes(stmts []interface{}) (map[string]*desiredNode, error) {
+	desired := map[string]*desiredNode{}
+	for i, stmt := range stmts {
+		switch s := stmt.(type) {
+		case parser.CreateSourceStmt, parser.CreateStreamAsSelectStmt,
+			parser.CreateStreamAsSelectUnionStmt, parser.CreateStreamAsEnrichStmt,
+			parser.CreateSinkStmt, parser.CreateStateStmt:
+			d := &desiredNode{
+				kind:  definitionKind(s),
+				name:  nodeName(s),
+				index: i,
+				stmt:  s,
+			}
+			key := "node:" + strings.ToLower(d.name)
+			if d.kind == "state" {
+				key = "state:" + strings.ToLower(d.name)
+			}
+			if _, ok := desired[key]; ok {
+				return nil, fmt.Errorf("statement #%v: '%v' is defined more than once", i+1, d.name)
+			}
+			if d.kind == "sink" {
+				d.inserts = map[string]parser.InsertIntoFromStmt{}
+			}
+			desired[key] = d
+
+		case parser.InsertIntoFromStmt:
+			d, ok := desired["node:"+strings.ToLower(string(s.Sink))]
+			if !ok || d.kind != "sink" {
+				return nil, fmt.Errorf("statement #%v: sink '%v' must be created before INSERT INTO", i+1, s.Sink)
+			}
+			for _, in := range s.Inputs {
+				key := strings.ToLower(string(in))
+				if _, ok := d.inserts[key]; ok {
+					return nil, fmt.Errorf("statement #%v: input %v is specified more than once", i+1, in)
+				}
+				single := s
+				single.Inputs = []parser.StreamIdentifier{in}
+				d.inserts[key] = single
+				d.insertKeys = append(d.insertKeys, key)
+			}
+
+		default:
+			return nil, fmt.Errorf("statement #%v: statement of type %T cannot be applied", i+1, stmt)
+		}
+	}
+	return desired, nil
+}
+
+func definitionKind(stmt interface{}) string {
+	switch stmt.(type) {
+	case parser.CreateSourceStmt:
+		return "source"
+	case parser.CreateStreamAsSelectStmt, parser.CreateStreamAsSelectUnionStmt,
+		parser.CreateStreamAsEnrichStmt:
+		return "stream"
+	case parser.CreateSinkStmt:
+		return "sink"
+	case parser.CreateStateStmt:
+		return "state"
+	}
+	return ""
+}
+
+func nodeName(stmt interface{}) string {
+	switch s := stmt.(type) {
+	case parser.CreateSourceStmt:
+		return string(s.Name)
+	case parser.CreateStreamAsSelectStmt:
+		return string(s.Name)
+	case parser.CreateStreamAsSelectUnionStmt:
+		return string(s.Name)
+	case parser.CreateStreamAsEnrichStmt:
+		return string(s.Name)
+	case parser.CreateSinkStmt:
+		return string(s.Name)
+	case parser.CreateStateStmt:
+		return string(s.Name)
+	}
+	return ""
+}
+
+// stmtInputs returns names of nodes in lower case from which a stream
+// created by the statement reads tuples.
+func stmtInputs(stmt interface{}) []string {
+	var selects []parser.SelectStmt
+	switch s := stmt.(type) {
+	case parser.CreateStreamAsSelectStmt:
+		selects = []parser.SelectStmt{s.Select}
+	case parser.CreateStreamAsSelectUnionStmt:
+		selects = s.Selects
+	case parser.CreateStreamAsEnrichStmt:
+		return []string{strings.ToLower(string(s.Input))}
+	}
+	var inputs []string
+	for _, sel := range selects {
+		for _, rel := range sel.Relations {
+			if rel.Type == parser.ActualStream {
+				inputs = append(inputs, strings.ToLower(rel.Name))
+			}
+		}
+	}
+	return inputs
+}
+
+// decide compares the desired node or state with the running one.
+func (tb *TopologyBuilder) decide(d *desiredNode, nodeDefs, stateDefs map[string]*definition) *nodeDecision {
+	dec := &nodeDecision{kind: d.kind, name: d.name, desired: d}
+	key := strings.ToLower(d.name)
+	if d.kind == "state" {
+		if _, err := tb.topology.Context().SharedStates.Get(d.name); err != nil {
+			dec.create, dec.reason = true, "not found"
+			return dec
+		}
+		dec.running = stateDefs[key]
+	} else {
+		if _, err := tb.topology.Node(d.name); err != nil {
+			dec.create, dec.reason = true, "not found"
+			return dec
+		}
+		dec.running = nodeDefs[key]
+	}
+
+	recreate := func(reason string) *nodeDecision {
+		dec.drop, dec.create, dec.reason = true, true, reason
+		return dec
+	}
+	if dec.running == nil {
+		return recreate("the definition is unknown")
+	}
+	if definitionKind(dec.running.stmt) != d.kind {
+		return recreate("the kind is changed")
+	}
+
+	var (
+		typ, runningTyp       parser.SourceSinkType
+		params, runningParams []parser.SourceSinkParamAST
+		updatable             bool
+		reserved              []string
+	)
+	switch s := d.stmt.(type) {
+	case parser.CreateSourceStmt:
+		r := dec.running.stmt.(parser.CreateSourceStmt)
+		if (s.Paused == parser.Yes) != (r.Paused == parser.Yes) {
+			return recreate("PAUSED is changed")
+		}
+		typ, runningTyp, params, runningParams = s.Type, r.Type, s.Params, r.Params
+		_, updatable = dec.running.node.(core.SourceNode).Source().(core.Updater)
+		reserved = []string{"labels"}
+	case parser.CreateSinkStmt:
+		r := dec.running.stmt.(parser.CreateSinkStmt)
+		typ, runningTyp, params, runningParams = s.Type, r.Type, s.Params, r.Params
+		_, updatable = dec.running.node.(core.SinkNode).Sink().(core.Updater)
+		reserved = append([]string{"labels"}, sinkSchemaParams...)
+	case parser.CreateStateStmt:
+		r := dec.running.stmt.(parser.CreateStateStmt)
+		typ, runningTyp, params, runningParams = s.Type, r.Type, s.Params, r.Params
+		_, updatable = dec.running.state.(core.Updater)
+	default:
+		if fmt.Sprint(d.stmt) != fmt.Sprint(dec.running.stmt) {
+			return recreate("the statement is changed")
+		}
+		return dec
+	}
+
+	if !strings.EqualFold(string(typ), string(runningTyp)) {
+		return recreate("the type is changed")
+	}
+	pm, err := tb.mkParamsMap(params)
+	if err != nil {
+		return recreate(err.Error())
+	}
+	rm, err := tb.mkParamsMap(runningParams)
+	if err != nil {
+		return recreate(err.Error())
+	}
+	if data.Equal(pm, rm) {
+		return dec
+	}
+	for k := range rm {
+		if _, ok := pm[k]; !ok {
+			return recreate(fmt.Sprintf("parameter '%v' is removed", k))
+		}
+	}
+	var changed []string
+	for _, p := range params {
+		if v, ok := rm[string(p.Key)]; !ok || !data.Equal(v, pm[string(p.Key)]) {
+			for _, r := range reserved {
+				if string(p.Key) == r {
+					return recreate(fmt.Sprintf("parameter '%v' is changed", r))
+				}
+			}
+			dec.update = append(dec.update, p)
+			changed = append(changed, string(p.Key))
+		}
+	}
+	if !updatable {
+		dec.update = nil
+		return recreate("parameters are changed but it cannot be updated")
+	}
+	dec.reason = fmt.Sprintf("parameters are changed: %v", strings.Join(changed, ", "))
+	return dec
+}
+
+// buildApplyPlan orders operations of decisions. Nodes are dropped before
+// their inputs, updated, created in the order of the desired statements, and
+// then sinks are connected to their inputs.
+func (tb *TopologyBuilder) buildApplyPlan(desired map[string]*desiredNode, decisions map[string]*nodeDecision) *ApplyPlan {
+	plan := &ApplyPlan{}
+	add := func(d *nodeDecision, action ApplyAction, stmt interface{}, reason string) {
+		plan.Operations = append(plan.Operations, &ApplyOperation{
+			Action: action,
+			Kind:   d.kind,
+			Name:   d.name,
+			Stmt:   stmt,
+			Reason: reason,
+		})
+	}
+
+	// drops
+	var drops []*nodeDecision
+	for _, d := range decisions {
+		if d.drop {
+			drops = append(drops, d)
+		}
+	}
+	for _, d := range orderDrops(drops) {
+		var stmt interface{}
+		name := parser.StreamIdentifier(d.name)
+		switch d.kind {
+		case "source":
+			stmt = parser.DropSourceStmt{Source: name}
+		case "stream":
+			stmt = parser.DropStreamStmt{Stream: name}
+		case "sink":
+			stmt = parser.DropSinkStmt{Sink: name}
+		case "state":
+			stmt = parser.DropStateStmt{State: name}
+		}
+		add(d, ApplyDrop, stmt, d.reason)
+	}
+
+	// updates and creates in the order of the desired statements
+	ordered := make([]*desiredNode, 0, len(desired))
+	for _, d := range desired {
+		ordered = append(ordered, d)
+	}
+	sort.Sort(desiredNodesByIndex(ordered))
+	for _, d := range ordered {
+		dec := decisions[desiredKey(d)]
+		if dec.update == nil {
+			continue
+		}
+		name := parser.StreamIdentifier(d.name)
+		specs := parser.SourceSinkSpecsAST{Params: dec.update}
+		var stmt interface{}
+		switch d.kind {
+		case "source":
+			stmt = parser.UpdateSourceStmt{Name: name, SourceSinkSpecsAST: specs}
+		case "sink":
+			stmt = parser.UpdateSinkStmt{Name: name, SourceSinkSpecsAST: specs}
+		case "state":
+			stmt = parser.UpdateStateStmt{Name: name, SourceSinkSpecsAST: specs}
+		}
+		add(dec, ApplyUpdate, stmt, dec.reason)
+	}
+	for _, d := range ordered {
+		if dec := decisions[desiredKey(d)]; dec.create {
+			add(dec, ApplyCreate, d.stmt, dec.reason)
+		}
+	}
+
+	// connections of sinks
+	for _, d := range ordered {
+		if d.kind != "sink" {
+			continue
+		}
+		dec := decisions[desiredKey(d)]
+		for _, in := range d.insertKeys {
+			stmt := d.inserts[in]
+			reason := ""
+			switch {
+			case dec.create:
+				reason = "the sink is created"
+			case decisions["node:"+in] != nil && decisions["node:"+in].create:
+				reason = fmt.Sprintf("input '%v' is created", stmt.Inputs[0])
+			default:
+				r, ok := dec.running.inserts[in]
+				if ok && fmt.Sprint(r) == fmt.Sprint(stmt) {
+					continue
+				}
+				reason = "not connected"
+			}
+			add(dec, ApplyConnect, stmt, reason)
+		}
+	}
+	return plan
+}
+
+func desiredKey(d *desiredNode) string {
+	if d.kind == "state" {
+		return "state:" + strings.ToLower(d.name)
+	}
+	return "node:" + strings.ToLower(d.name)
+}
+
+type desiredNodesByIndex []*desiredNode
+
+func (s desiredNodesByIndex) Len() int           { return len(s) }
+func (s desiredNodesByIndex) Less(i, j int) bool { return s[i].index < s[j].index }
+func (s desiredNodesByIndex) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+
+// orderDrops orders nodes so that sinks are dropped first, a stream is
+// dropped before streams it reads from, and states are dropped last.
+func orderDrops(drops []*nodeDecision) []*nodeDecision {
+	sort.Sort(decisionsByKind(drops))
+
+	// readers counts streams being dropped which read from each stream.
+	readers := map[string]int{}
+	var streams []*nodeDecision
+	for _, d := range drops {
+		if d.kind != "stream" {
+			continue
+		}
+		streams = append(streams, d)
+		if d.running != nil {
+			for _, in := range stmtInputs(d.running.stmt) {
+				readers[in]++
+			}
+		}
+	}
+
+	res := make([]*nodeDecision, 0, len(drops))
+	for _, d := range drops {
+		if d.kind == "sink" {
+			res = append(res, d)
+		}
+	}
+	for len(streams) > 0 {
+		// Pick the first stream which no remaining stream reads from. Streams
+		// can't have cycles, but the first one is picked if they had.
+		i := 0
+		for j, d := range streams {
+			if readers[strings.ToLower(d.name)] == 0 {
+				i = j
+				break
+			}
+		}
+		d := streams[i]
+		streams = append(streams[:i], streams[i+1:]...)
+		if d.running != nil {
+			for _, in := range stmtInputs(d.running.stmt) {
+				readers[in]--
+			}
+		}
+		res = append(res, d)
+	}
+	for _, d := range drops {
+		if d.kind == "source" || d.kind == "state" {
+			res = append(res, d)
+		}
+	}
+	return res
+}
+
+var dropRanks = map[string]int{"sink": 0, "stream": 1, "source": 2, "state": 3}
+
+type decisionsByKind []*nodeDecision
+
+func (s decisionsByKind) Len() int      { return len(s) }
+func (s decisionsByKind) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s decisionsByKind) Less(i, j int) bool {
+	if dropRanks[s[i].kind] != dropRanks[s[j].kind] {
+		return dropRanks[s[i].kind] < dropRanks[s[j].kind]
+	}
+	return strings.ToLower(s[i].name) < strings.ToLower(s[j].name)
+}
+
+// Apply executes operations of the plan in order. It stops at the first
+// operation which fails and returns an error. Operations executed before
+// the failure aren't rolled back.
+func (tb *TopologyBuilder) Apply(p *ApplyPlan) error {
+	for i, op := range p.Operations {
+		if _, err := tb.AddStmt(op.Stmt); err != nil {
+			return fmt.Errorf("operation #%v (%v %v '%v') failed: %v", i+1, op.Action, op.Kind, op.Name, err)
+		}
+	}
+	return nil
+}
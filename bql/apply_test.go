@@ -0,0 +1,159 @@
+package bql
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/parser"
+)
+
+func TestTopologyBuilderApply(t *testing.T) {
+	Convey("Given a BQL TopologyBuilder having nodes", t, func() {
+		dt := newTestTopology()
+		Reset(func() {
+			dt.Stop()
+		})
+		tb, err := NewTopologyBuilder(dt)
+		So(err, ShouldBeNil)
+		So(addBQLToTopology(tb, `CREATE PAUSED SOURCE s TYPE dummy_updatable WITH num=4;
+			CREATE STREAM a AS SELECT ISTREAM int FROM s [RANGE 1 TUPLES];
+			CREATE STREAM b AS SELECT ISTREAM int FROM a [RANGE 1 TUPLES];
+			CREATE SINK snk TYPE collector;
+			INSERT INTO snk FROM a, b;
+			CREATE STATE st TYPE dummy_updatable_uds;`), ShouldBeNil)
+
+		current := `CREATE PAUSED SOURCE s TYPE dummy_updatable WITH num=4;
+			CREATE STREAM a AS SELECT ISTREAM int FROM s [RANGE 1 TUPLES];
+			CREATE STREAM b AS SELECT ISTREAM int FROM a [RANGE 1 TUPLES];
+			CREATE SINK snk TYPE collector;
+			INSERT INTO snk FROM a, b;
+			CREATE STATE st TYPE dummy_updatable_uds;`
+
+		plan := func(bql string, prune bool) []string {
+			stmts, err := parser.New().ParseStmts(bql)
+			So(err, ShouldBeNil)
+			p, err := tb.PlanApply(stmts, prune)
+			So(err, ShouldBeNil)
+			ops := make([]string, len(p.Operations))
+			for i, op := range p.Operations {
+				ops[i] = fmt.Sprintf("%v %v %v", op.Action, op.Kind, op.Name)
+			}
+			return ops
+		}
+
+		Convey("When planning the same statements", func() {
+			ops := plan(current, true)
+
+			Convey("Then there should be no operation", func() {
+				So(ops, ShouldBeEmpty)
+			})
+		})
+
+		Convey("When parameters of a source are changed", func() {
+			ops := plan(`CREATE PAUSED SOURCE s TYPE dummy_updatable WITH num=5;
+				CREATE STREAM a AS SELECT ISTREAM int FROM s [RANGE 1 TUPLES];
+				CREATE STREAM b AS SELECT ISTREAM int FROM a [RANGE 1 TUPLES];
+				CREATE SINK snk TYPE collector;
+				INSERT INTO snk FROM a, b;
+				CREATE STATE st TYPE dummy_updatable_uds;`, false)
+
+			Convey("Then it should be updated", func() {
+				So(ops, ShouldResemble, []string{"update source s"})
+			})
+		})
+
+		Convey("When a stream is changed", func() {
+			ops := plan(`CREATE PAUSED SOURCE s TYPE dummy_updatable WITH num=4;
+				CREATE STREAM a AS SELECT ISTREAM int, 1 AS x FROM s [RANGE 1 TUPLES];
+				CREATE STREAM b AS SELECT ISTREAM int FROM a [RANGE 1 TUPLES];
+				CREATE SINK snk TYPE collector;
+				INSERT INTO snk FROM a, b;
+				CREATE STATE st TYPE dummy_updatable_uds;`, false)
+
+			Convey("Then it and nodes reading from it should be recreated", func() {
+				So(ops, ShouldResemble, []string{
+					"drop sink snk",
+					"drop stream b",
+					"drop stream a",
+					"create stream a",
+					"create stream b",
+					"create sink snk",
+					"connect sink snk",
+					"connect sink snk",
+				})
+			})
+
+			Convey("Then applying the plan should make the topology have the new stream", func() {
+				stmts, err := parser.New().ParseStmts(`CREATE PAUSED SOURCE s TYPE dummy_updatable WITH num=4;
+					CREATE STREAM a AS SELECT ISTREAM int, 1 AS x FROM s [RANGE 1 TUPLES];
+					CREATE STREAM b AS SELECT ISTREAM int FROM a [RANGE 1 TUPLES];
+					CREATE SINK snk TYPE collector;
+					INSERT INTO snk FROM a, b;
+					CREATE STATE st TYPE dummy_updatable_uds;`)
+				So(err, ShouldBeNil)
+				p, err := tb.PlanApply(stmts, false)
+				So(err, ShouldBeNil)
+				So(tb.Apply(p), ShouldBeNil)
+
+				p, err = tb.PlanApply(stmts, false)
+				So(err, ShouldBeNil)
+				So(p.Operations, ShouldBeEmpty)
+			})
+		})
+
+		Convey("When a sink is disconnected from an input", func() {
+			ops := plan(`CREATE PAUSED SOURCE s TYPE dummy_updatable WITH num=4;
+				CREATE STREAM a AS SELECT ISTREAM int FROM s [RANGE 1 TUPLES];
+				CREATE STREAM b AS SELECT ISTREAM int FROM a [RANGE 1 TUPLES];
+				CREATE SINK snk TYPE collector;
+				INSERT INTO snk FROM a;
+				CREATE STATE st TYPE dummy_updatable_uds;`, false)
+
+			Convey("Then the sink should be recreated", func() {
+				So(ops, ShouldResemble, []string{"drop sink snk", "create sink snk", "connect sink snk"})
+			})
+		})
+
+		Convey("When nodes are removed from statements", func() {
+			stmts := `CREATE PAUSED SOURCE s TYPE dummy_updatable WITH num=4;
+				CREATE STREAM a AS SELECT ISTREAM int FROM s [RANGE 1 TUPLES];
+				CREATE SINK snk TYPE collector;
+				INSERT INTO snk FROM a;
+				CREATE SOURCE s2 TYPE dummy;`
+
+			Convey("Then they should be dropped with prune", func() {
+				So(plan(stmts, true), ShouldResemble, []string{
+					"drop stream b",
+					"drop state st",
+					"create source s2",
+				})
+			})
+
+			Convey("Then they should be kept but the sink should be recreated without prune", func() {
+				So(plan(stmts, false), ShouldResemble, []string{
+					"drop sink snk",
+					"create sink snk",
+					"create source s2",
+					"connect sink snk",
+				})
+			})
+		})
+
+		Convey("When planning unsupported statements", func() {
+			for _, s := range []string{
+				`DROP SOURCE s;`,
+				`CREATE SINK x TYPE collector; CREATE SINK x TYPE collector;`,
+				`INSERT INTO snk FROM a;`,
+			} {
+				stmts, err := parser.New().ParseStmts(s)
+				So(err, ShouldBeNil)
+				_, err = tb.PlanApply(stmts, false)
+
+				Convey("Then it should fail: "+s, func() {
+					So(err, ShouldNotBeNil)
+				})
+			}
+		})
+	})
+}
@@ -0,0 +1,455 @@
+package bql
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/sensorbee/sensorbee.v0/bql/parser"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// archiveTimeLayout is the layout of times in names of archive files.
+const archiveTimeLayout = "20060102T150405Z"
+
+// archiveSink writes tuples to files in a directory partitioned by their
+// timestamps in UTC:
+//
+//	dir/dt=2024-05-01/hour=05/part-20240501T050000Z_20240501T060000Z.jsonl
+//
+// Each file has tuples whose timestamps are in the window written in its
+// name. The length of windows is given by rotate. Each line of a file is a
+// JSON object having "timestamp" and "data" of a tuple so that the archive
+// source can restore the tuple.
+type archiveSink struct {
+	dir    string
+	rotate time.Duration
+
+	// window is the start of the window of the open file.
+	window time.Time
+	file   *os.File
+	buf    *bufio.Writer
+	closed bool
+}
+
+func (s *archiveSink) Write(ctx *core.Context, t *core.Tuple) error {
+	if s.closed {
+		return errors.New("the sink is already closed")
+	}
+	b, err := json.Marshal(data.Map{
+		"timestamp": data.Timestamp(t.Timestamp),
+		"data":      t.Data,
+	})
+	if err != nil {
+		return err
+	}
+
+	ts := t.Timestamp.UTC()
+	w := ts.Truncate(s.rotate)
+	if s.file == nil || !w.Equal(s.window) {
+		// A tuple which arrives late reopens the file of its window.
+		if err := s.closeFile(); err != nil {
+			return err
+		}
+		if err := s.openFile(w); err != nil {
+			return err
+		}
+	}
+	if _, err := s.buf.Write(append(b, '\n')); err != nil {
+		return err
+	}
+	return nil
+}
+
+// openFile opens the file of the window for appending.
+func (s *archiveSink) openFile(w time.Time) error {
+	path := filepath.Join(s.dir, archivePartition(w), archiveFileName(w, s.rotate))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+	s.window = w
+	s.file = f
+	s.buf = bufio.NewWriter(f)
+	return nil
+}
+
+// closeFile flushes, syncs, and closes the open file.
+func (s *archiveSink) closeFile() error {
+	if s.file == nil {
+		return nil
+	}
+	err := s.flush()
+	if cerr := s.file.Close(); err == nil {
+		err = cerr
+	}
+	s.file = nil
+	s.buf = nil
+	return err
+}
+
+func (s *archiveSink) flush() error {
+	if err := s.buf.Flush(); err != nil {
+		return err
+	}
+	return s.file.Sync()
+}
+
+// Flush writes buffered tuples to the open file and syncs it.
+func (s *archiveSink) Flush(ctx *core.Context) error {
+	if s.file == nil {
+		return nil
+	}
+	return s.flush()
+}
+
+func (s *archiveSink) Close(ctx *core.Context) error {
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	return s.closeFile()
+}
+
+// archivePartition returns the partition of the window, which is a path
+// relative to the root of an archive.
+func archivePartition(w time.Time) string {
+	return w.Format("dt=2006-01-02") + "/" + w.Format("hour=15")
+}
+
+func archiveFileName(w time.Time, rotate time.Duration) string {
+	return "part-" + w.Format(archiveTimeLayout) + "_" + w.Add(rotate).Format(archiveTimeLayout) + ".jsonl"
+}
+
+// parseArchiveFileName returns the window of an archive file. ok is false
+// when the name wasn't created by archiveFileName.
+func parseArchiveFileName(name string) (start, end time.Time, ok bool) {
+	if !strings.HasPrefix(name, "part-") || !strings.HasSuffix(name, ".jsonl") {
+		return
+	}
+	ts := strings.Split(strings.TrimSuffix(strings.TrimPrefix(name, "part-"), ".jsonl"), "_")
+	if len(ts) != 2 {
+		return
+	}
+	var err error
+	if start, err = time.Parse(archiveTimeLayout, ts[0]); err != nil {
+		return
+	}
+	if end, err = time.Parse(archiveTimeLayout, ts[1]); err != nil {
+		return
+	}
+	return start, end, true
+}
+
+// createArchiveSink creates a sink archiving tuples to a directory given by
+// "path" parameter so that they can be replayed by the archive source later:
+//
+//	CREATE SINK a TYPE archive WITH path="/data/archive/s", rotate="1h";
+//
+// Tuples are partitioned by their timestamps in UTC into files each of which
+// has tuples in a window of "rotate" (1h by default). "format" parameter
+// must be "jsonl", which is the only format supported at the moment. Files
+// are synced when the sink switches to a file of another window, when it's
+// flushed, and when it's closed. A file is appended when it already exists,
+// so the sink can be created again with the same path after a restart. A
+// directory should only be written by one sink at a time.
+//
+// ARCHIVE STREAM statement creates this sink for a stream.
+func createArchiveSink(ctx *core.Context, ioParams *IOParams, params data.Map) (core.Sink, error) {
+	v := &struct {
+		Path   string `bql:",required"`
+		Format string
+		Rotate time.Duration
+	}{
+		Format: "jsonl",
+		Rotate: time.Hour,
+	}
+	if err := data.NewDecoder(nil).Decode(params, v); err != nil {
+		return nil, err
+	}
+	if strings.ToLower(v.Format) != "jsonl" {
+		return nil, fmt.Errorf("'format' parameter must be jsonl: %v", v.Format)
+	}
+	if v.Rotate <= 0 {
+		return nil, fmt.Errorf("'rotate' parameter must be greater than 0: %v", v.Rotate)
+	}
+	if err := os.MkdirAll(v.Path, 0755); err != nil {
+		return nil, fmt.Errorf("cannot create 'path': %v", err)
+	}
+	return &archiveSink{
+		dir:    v.Path,
+		rotate: v.Rotate,
+	}, nil
+}
+
+// archiveSinkName returns the name of the hidden sink created by ARCHIVE
+// STREAM statement for the stream.
+func archiveSinkName(stream string) string {
+	return "sensorbee_tmp_archive_" + strings.ToLower(stream)
+}
+
+// archiveSinkParams returns parameters of the archive sink created by the
+// ARCHIVE STREAM statement.
+func (tb *TopologyBuilder) archiveSinkParams(stmt *parser.ArchiveStreamStmt) (data.Map, error) {
+	params, err := tb.mkParamsMap(stmt.Params)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := params["path"]; ok {
+		return nil, errors.New("'path' parameter cannot be given to ARCHIVE STREAM")
+	}
+	params["path"] = data.String(stmt.Dir)
+	return params, nil
+}
+
+// archiveStream connects a hidden archive sink to the stream. The sink is
+// removed when the stream is dropped. It can also be stopped by dropping the
+// sink named by archiveSinkName.
+func (tb *TopologyBuilder) archiveStream(stmt *parser.ArchiveStreamStmt) (core.Node, error) {
+	stream := string(stmt.Stream)
+	params, err := tb.archiveSinkParams(stmt)
+	if err != nil {
+		return nil, err
+	}
+	name := archiveSinkName(stream)
+	sink, err := createArchiveSink(tb.topology.Context(), &IOParams{
+		TypeName: "archive",
+		Name:     name,
+	}, params)
+	if err != nil {
+		return nil, err
+	}
+	sn, err := tb.topology.AddSink(name, sink, nil)
+	if err != nil {
+		sink.Close(tb.topology.Context())
+		if _, e := tb.topology.Sink(name); e == nil {
+			return nil, fmt.Errorf("stream '%v' is already archived", stream)
+		}
+		return nil, err
+	}
+	if err := sn.Input(stream, nil); err != nil {
+		tb.topology.Remove(name)
+		return nil, err
+	}
+	sn.StopOnDisconnect()
+	sn.RemoveOnStop()
+	return sn, nil
+}
+
+// replayArchiveSource returns the CREATE PAUSED SOURCE statement equivalent
+// to the REPLAY ARCHIVE statement. The source is paused so that streams
+// reading it can be created before it's resumed by RESUME SOURCE.
+func replayArchiveSource(stmt *parser.ReplayArchiveStmt) parser.CreateSourceStmt {
+	params := []parser.SourceSinkParamAST{{Key: "path", Value: data.String(stmt.Dir)}}
+	if stmt.From != "" {
+		params = append(params, parser.SourceSinkParamAST{Key: "from", Value: data.String(stmt.From)})
+	}
+	if stmt.To != "" {
+		params = append(params, parser.SourceSinkParamAST{Key: "to", Value: data.String(stmt.To)})
+	}
+	s := parser.CreateSourceStmt{
+		Paused: parser.Yes,
+		Name:   stmt.Stream,
+		Type:   "archive",
+	}
+	s.Params = append(params, stmt.Params...)
+	return s
+}
+
+// archiveSource emits tuples archived by archiveSink in the order of files.
+// Tuples whose timestamps aren't in [from, to) are skipped.
+type archiveSource struct {
+	root       string
+	ioParams   *IOParams
+	from, to   time.Time
+	jsonLimits *data.JSONLimits
+
+	m          sync.Mutex
+	numEmitted int64
+	numErrors  int64
+}
+
+func (s *archiveSource) GenerateStream(ctx *core.Context, w core.Writer) error {
+	ps, err := listPartitions(s.root, "")
+	if err != nil {
+		return err
+	}
+	for _, p := range ps {
+		files, err := listEntries(filepath.Join(s.root, p), false, "part-")
+		if err != nil {
+			return err
+		}
+		for _, name := range files {
+			if start, end, ok := parseArchiveFileName(name); ok {
+				if !s.from.IsZero() && !end.After(s.from) ||
+					!s.to.IsZero() && !start.Before(s.to) {
+					continue
+				}
+			}
+			if err := s.readFile(ctx, w, filepath.Join(s.root, p, name)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *archiveSource) readFile(ctx *core.Context, w core.Writer, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	dec := newJSONLinesDecoder(bufio.NewReader(f), s.jsonLimits)
+	for {
+		l, err := readJSONLine(dec)
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		var tuple *core.Tuple
+		err = l.err
+		if err == nil {
+			tuple, err = archivedTuple(l.m)
+		}
+		if err != nil {
+			ctx.ErrLog(err).WithField("node_name", s.ioParams.Name).
+				WithField("path", path).
+				WithField("line", l.number).Warning("Ignoring the archived tuple")
+			s.m.Lock()
+			s.numErrors++
+			s.m.Unlock()
+			continue
+		}
+		if !s.from.IsZero() && tuple.Timestamp.Before(s.from) ||
+			!s.to.IsZero() && !tuple.Timestamp.Before(s.to) {
+			continue
+		}
+		if err := w.Write(ctx, tuple); err != nil {
+			return err
+		}
+		s.m.Lock()
+		s.numEmitted++
+		s.m.Unlock()
+	}
+}
+
+// archivedTuple restores a tuple from a line written by archiveSink.
+func archivedTuple(m data.Map) (*core.Tuple, error) {
+	v, ok := m["timestamp"]
+	if !ok {
+		return nil, errors.New("the line doesn't have a timestamp")
+	}
+	ts, err := data.ToTimestamp(v)
+	if err != nil {
+		return nil, err
+	}
+	v, ok = m["data"]
+	if !ok {
+		return nil, errors.New("the line doesn't have data")
+	}
+	d, err := data.AsMap(v)
+	if err != nil {
+		return nil, err
+	}
+	t := core.NewTuple(d)
+	t.Timestamp = ts
+	return t, nil
+}
+
+func (s *archiveSource) Stop(ctx *core.Context) error {
+	return nil
+}
+
+func (s *archiveSource) Status() data.Map {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return data.Map{
+		"num_emitted": data.Int(s.numEmitted),
+		"num_errors":  data.Int(s.numErrors),
+	}
+}
+
+// createArchiveSource creates a source emitting tuples archived by the
+// archive sink in a directory given by "path" parameter. Tuples have the
+// timestamps which they had when they were archived. "from" and "to"
+// parameters limit tuples to ones whose timestamps are in [from, to). They
+// are interpreted in "timezone" parameter (the time zone of the topology by
+// default) when they don't have a time zone. Lines are limited by
+// "max_document_size", "max_depth", and "max_string_length" parameters as in
+// the file source. The source can be rewound when "rewindable" is true.
+//
+// REPLAY ARCHIVE statement creates this source in the paused state:
+//
+//	REPLAY ARCHIVE "/data/archive/s" INTO s2 FROM "2024-05-01T00:00";
+//	CREATE STREAM ...  FROM s2 ...;
+//	RESUME SOURCE s2;
+func createArchiveSource(ctx *core.Context, ioParams *IOParams, params data.Map) (core.Source, error) {
+	v := &struct {
+		Path       string `bql:",required"`
+		From       string
+		To         string
+		Timezone   string
+		Rewindable bool
+		jsonLimitParams
+	}{
+		jsonLimitParams: defaultJSONLimitParams(),
+	}
+	if err := data.NewDecoder(nil).Decode(params, v); err != nil {
+		return nil, err
+	}
+	if info, err := os.Stat(v.Path); err != nil {
+		return nil, fmt.Errorf("cannot access 'path': %v", err)
+	} else if !info.IsDir() {
+		return nil, fmt.Errorf("'path' parameter must be a directory: %v", v.Path)
+	}
+	loc, err := sourceLocation(ctx, v.Timezone)
+	if err != nil {
+		return nil, err
+	}
+	limits, err := v.limits()
+	if err != nil {
+		return nil, err
+	}
+
+	s := &archiveSource{
+		root:       v.Path,
+		ioParams:   ioParams,
+		jsonLimits: limits,
+	}
+	if v.From != "" {
+		if s.from, err = data.ToTimestampIn(data.String(v.From), loc); err != nil {
+			return nil, fmt.Errorf("'from' parameter must be a timestamp: %v", err)
+		}
+	}
+	if v.To != "" {
+		if s.to, err = data.ToTimestampIn(data.String(v.To), loc); err != nil {
+			return nil, fmt.Errorf("'to' parameter must be a timestamp: %v", err)
+		}
+	}
+	if !s.from.IsZero() && !s.to.IsZero() && !s.from.Before(s.to) {
+		return nil, fmt.Errorf("'from' parameter must be before 'to': %v, %v", v.From, v.To)
+	}
+	if v.Rewindable {
+		return core.NewRewindableSource(s), nil
+	}
+	return core.ImplementSourceStop(s), nil
+}
+
+func init() {
+	MustRegisterGlobalSourceCreator("archive", SourceCreatorFunc(createArchiveSource))
+	MustRegisterGlobalSinkCreator("archive", SinkCreatorFunc(createArchiveSink))
+}
@@ -0,0 +1,236 @@
+package bql
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/parser"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+func TestArchive(t *testing.T) {
+	Convey("Given an archive sink", t, func() {
+		dir, err := ioutil.TempDir("", "sbtest_bql_archive")
+		So(err, ShouldBeNil)
+		Reset(func() {
+			os.RemoveAll(dir)
+		})
+		ctx := core.NewContext(nil)
+		sink, err := createArchiveSink(ctx, &IOParams{Name: "a"}, data.Map{
+			"path":   data.String(dir),
+			"rotate": data.String("30m"),
+		})
+		So(err, ShouldBeNil)
+
+		base := time.Date(2024, time.May, 1, 10, 0, 0, 0, time.UTC)
+		for i, m := range []int{0, 10, 40, 70, 20} {
+			t := core.NewTuple(data.Map{"int": data.Int(i)})
+			t.Timestamp = base.Add(time.Duration(m) * time.Minute)
+			So(sink.Write(ctx, t), ShouldBeNil)
+		}
+		So(sink.Close(ctx), ShouldBeNil)
+
+		Convey("When listing archived files", func() {
+			files, err := filepath.Glob(filepath.Join(dir, "*", "*", "*"))
+			So(err, ShouldBeNil)
+
+			Convey("Then tuples should be partitioned by their timestamps", func() {
+				for i, f := range files {
+					files[i], _ = filepath.Rel(dir, f)
+				}
+				So(files, ShouldResemble, []string{
+					"dt=2024-05-01/hour=10/part-20240501T100000Z_20240501T103000Z.jsonl",
+					"dt=2024-05-01/hour=10/part-20240501T103000Z_20240501T110000Z.jsonl",
+					"dt=2024-05-01/hour=11/part-20240501T110000Z_20240501T113000Z.jsonl",
+				})
+			})
+		})
+
+		replay := func(params data.Map) []*core.Tuple {
+			params["path"] = data.String(dir)
+			params["timezone"] = data.String("UTC")
+			s, err := createArchiveSource(ctx, &IOParams{Name: "s"}, params)
+			So(err, ShouldBeNil)
+			var ts []*core.Tuple
+			So(s.GenerateStream(ctx, core.WriterFunc(func(ctx *core.Context, t *core.Tuple) error {
+				ts = append(ts, t)
+				return nil
+			})), ShouldBeNil)
+			return ts
+		}
+
+		Convey("When replaying the archive", func() {
+			ts := replay(data.Map{})
+
+			Convey("Then all tuples should be emitted with their timestamps", func() {
+				So(len(ts), ShouldEqual, 5)
+				for i, m := range []int{0, 10, 20, 40, 70} {
+					So(ts[i].Timestamp.Equal(base.Add(time.Duration(m)*time.Minute)), ShouldBeTrue)
+				}
+				So(ts[2].Data, ShouldResemble, data.Map{"int": data.Int(4)})
+			})
+		})
+
+		Convey("When replaying the archive in a range", func() {
+			ts := replay(data.Map{
+				"from": data.String("2024-05-01T10:10"),
+				"to":   data.String("2024-05-01 10:40"),
+			})
+
+			Convey("Then tuples in the range should be emitted", func() {
+				So(len(ts), ShouldEqual, 2)
+				So(ts[0].Data["int"], ShouldEqual, data.Int(1))
+				So(ts[1].Data["int"], ShouldEqual, data.Int(4))
+			})
+		})
+
+		Convey("When the archive has a broken line", func() {
+			p := filepath.Join(dir, "dt=2024-05-01/hour=11/part-20240501T110000Z_20240501T113000Z.jsonl")
+			f, err := os.OpenFile(p, os.O_APPEND|os.O_WRONLY, 0644)
+			So(err, ShouldBeNil)
+			_, err = f.WriteString("{\"data\":{}}\n{\"timestamp\n")
+			So(err, ShouldBeNil)
+			So(f.Close(), ShouldBeNil)
+
+			Convey("Then the line should be skipped", func() {
+				So(len(replay(data.Map{})), ShouldEqual, 5)
+			})
+		})
+
+		for _, params := range []data.Map{
+			{"from": data.String("yesterday")},
+			{"from": data.String("2024-05-02"), "to": data.String("2024-05-01")},
+			{"max_depth": data.Int(-1)},
+		} {
+			Convey("When creating a source with invalid parameters "+params.String(), func() {
+				params["path"] = data.String(dir)
+				_, err := createArchiveSource(ctx, &IOParams{Name: "s"}, params)
+
+				Convey("Then it should fail", func() {
+					So(err, ShouldNotBeNil)
+				})
+			})
+		}
+	})
+
+	Convey("Given invalid parameters of an archive sink", t, func() {
+		ctx := core.NewContext(nil)
+		for _, params := range []data.Map{
+			{},
+			{"path": data.String(os.TempDir()), "format": data.String("csv")},
+			{"path": data.String(os.TempDir()), "rotate": data.String("0s")},
+		} {
+			Convey("When creating a sink with "+params.String(), func() {
+				_, err := createArchiveSink(ctx, &IOParams{Name: "a"}, params)
+
+				Convey("Then it should fail", func() {
+					So(err, ShouldNotBeNil)
+				})
+			})
+		}
+	})
+
+	Convey("Given a BQL TopologyBuilder with a source", t, func() {
+		dir, err := ioutil.TempDir("", "sbtest_bql_archive")
+		So(err, ShouldBeNil)
+		dt := newTestTopology()
+		Reset(func() {
+			dt.Stop()
+			os.RemoveAll(dir)
+		})
+		tb, err := NewTopologyBuilder(dt)
+		So(err, ShouldBeNil)
+		So(addBQLToTopology(tb, `CREATE PAUSED SOURCE s TYPE dummy WITH num=4;`), ShouldBeNil)
+
+		Convey("When archiving the source", func() {
+			So(addBQLToTopology(tb, `ARCHIVE STREAM s TO "`+dir+`" WITH format="jsonl", rotate="1h";`), ShouldBeNil)
+			_, err := dt.Sink("sensorbee_tmp_archive_s")
+			So(err, ShouldBeNil)
+
+			Convey("Then archiving it again should fail", func() {
+				So(addBQLToTopology(tb, `ARCHIVE STREAM s TO "`+dir+`";`), ShouldNotBeNil)
+			})
+
+			Convey("And replaying the archive after the source is dropped", func() {
+				So(addBQLToTopology(tb, `
+					CREATE SINK c TYPE collector;
+					INSERT INTO c FROM s;
+					RESUME SOURCE s;`), ShouldBeNil)
+				c, err := dt.Sink("c")
+				So(err, ShouldBeNil)
+				c.Sink().(*tupleCollectorSink).Wait(4)
+				So(addBQLToTopology(tb, `DROP SOURCE s;`), ShouldBeNil)
+				waitForExpectedCondition(func() bool {
+					_, err := dt.Sink("sensorbee_tmp_archive_s")
+					return err != nil
+				})
+
+				So(addBQLToTopology(tb, `
+					REPLAY ARCHIVE "`+dir+`" INTO s2 FROM "2015-04-10T10:23:01Z";
+					CREATE SINK snk TYPE collector;
+					INSERT INTO snk FROM s2;
+					RESUME SOURCE s2;`), ShouldBeNil)
+				sn, err := dt.Sink("snk")
+				So(err, ShouldBeNil)
+				si := sn.Sink().(*tupleCollectorSink)
+				si.Wait(3)
+
+				Convey("Then archived tuples should be emitted from the source", func() {
+					So(si.len(), ShouldEqual, 3)
+					for i := 0; i < 3; i++ {
+						t := si.get(i)
+						So(t.Data["int"], ShouldEqual, data.Int(i+2))
+						So(t.Timestamp.Equal(time.Date(2015, time.April, 10, 10, 23, i+1, 0, time.UTC)), ShouldBeTrue)
+					}
+				})
+			})
+		})
+
+		Convey("When archiving a nonexistent stream", func() {
+			err := addBQLToTopology(tb, `ARCHIVE STREAM nonexistent TO "`+dir+`";`)
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+				_, err := dt.Sink("sensorbee_tmp_archive_nonexistent")
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When archiving it with path parameter", func() {
+			err := addBQLToTopology(tb, `ARCHIVE STREAM s TO "`+dir+`" WITH path="/tmp";`)
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When planning statements archiving and replaying", func() {
+			stmts, err := parser.New().ParseStmts(`
+				ARCHIVE STREAM s TO "` + dir + `";
+				ARCHIVE STREAM s TO "` + dir + `";
+				REPLAY ARCHIVE "` + dir + `" INTO s2;
+				REPLAY ARCHIVE "` + dir + `" INTO s2;`)
+			So(err, ShouldBeNil)
+			plan := tb.Plan(stmts)
+
+			Convey("Then the hidden sink and the source should be planned", func() {
+				So(len(plan.Nodes), ShouldEqual, 3)
+				So(plan.Nodes[1].Name, ShouldEqual, "sensorbee_tmp_archive_s")
+				So(plan.Nodes[1].Inputs, ShouldResemble, []string{"s"})
+				So(plan.Nodes[2].Name, ShouldEqual, "s2")
+				So(plan.Nodes[2].TypeName, ShouldEqual, "archive")
+			})
+
+			Convey("Then duplicated statements should be errors", func() {
+				So(len(plan.Errors), ShouldEqual, 2)
+				So(plan.Errors[0].Index, ShouldEqual, 1)
+				So(plan.Errors[1].Index, ShouldEqual, 3)
+			})
+		})
+	})
+}
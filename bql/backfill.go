@@ -0,0 +1,356 @@
+package bql
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// backfillSource replays a bounded historical source and then switches to
+// a live source. Tuples emitted from the historical source have
+// core.TFBackfill flag.
+type backfillSource struct {
+	ioParams *IOParams
+	history  core.Source
+	live     core.Source
+
+	// since and until are the range of timestamps of historical tuples.
+	// Each of them is disabled when it's zero.
+	since, until time.Time
+
+	// bufferSize is the maximum number of live tuples buffered while the
+	// historical source is being replayed.
+	bufferSize int
+
+	// m protects fields below and cond is signaled when the source is
+	// switched or stopped.
+	m              sync.Mutex
+	cond           *sync.Cond
+	switched       bool
+	stopped        bool
+	historyStarted bool
+	liveStarted    bool
+	buffer         []*core.Tuple
+
+	// boundary is the timestamp up to which the history is supposed to
+	// cover. Live tuples not newer than it are dropped. inclusive is true
+	// when a live tuple having exactly the same timestamp is also dropped.
+	boundary  time.Time
+	inclusive bool
+
+	numBackfilled int64
+	numLive       int64
+	numDropped    int64
+
+	// wm serializes writes of live tuples so that buffered tuples are
+	// written before any live tuple written after the switch.
+	wm sync.Mutex
+
+	stopLiveOnce sync.Once
+	stopLiveErr  error
+}
+
+func (s *backfillSource) GenerateStream(ctx *core.Context, w core.Writer) error {
+	s.m.Lock()
+	if s.stopped {
+		s.m.Unlock()
+		return nil
+	}
+	s.historyStarted = true
+	s.liveStarted = s.live != nil
+	s.m.Unlock()
+
+	// The live source is started first so that no tuple is missed between
+	// the end of the history and the start of the live source.
+	liveCh := make(chan error, 1)
+	if s.live != nil {
+		go func() {
+			liveCh <- s.live.GenerateStream(ctx, core.WriterFunc(func(ctx *core.Context, t *core.Tuple) error {
+				return s.writeLive(ctx, w, t)
+			}))
+		}()
+	}
+
+	err := s.history.GenerateStream(ctx, core.WriterFunc(func(ctx *core.Context, t *core.Tuple) error {
+		return s.writeHistory(ctx, w, t)
+	}))
+	if err == nil {
+		err = s.switchToLive(ctx, w)
+	}
+	if err != nil {
+		if s.live != nil {
+			// The live source may be waiting for the buffer to be flushed.
+			s.m.Lock()
+			s.stopped = true
+			s.cond.Broadcast()
+			s.m.Unlock()
+			if e := s.stopLive(ctx); e != nil {
+				ctx.ErrLog(e).WithField("node_name", s.ioParams.Name).
+					Warning("Cannot stop the live source")
+			}
+			<-liveCh
+		}
+		return err
+	}
+
+	if s.live == nil {
+		return nil
+	}
+	return <-liveCh
+}
+
+func (s *backfillSource) writeHistory(ctx *core.Context, w core.Writer, t *core.Tuple) error {
+	s.m.Lock()
+	if s.stopped {
+		s.m.Unlock()
+		return core.ErrSourceStopped
+	}
+	if (!s.since.IsZero() && t.Timestamp.Before(s.since)) ||
+		(!s.until.IsZero() && !t.Timestamp.Before(s.until)) {
+		s.m.Unlock()
+		return nil
+	}
+	if s.until.IsZero() && t.Timestamp.After(s.boundary) {
+		s.boundary = t.Timestamp
+	}
+	s.numBackfilled++
+	s.m.Unlock()
+
+	t.Flags.Set(core.TFBackfill)
+	return w.Write(ctx, t)
+}
+
+func (s *backfillSource) writeLive(ctx *core.Context, w core.Writer, t *core.Tuple) error {
+	s.m.Lock()
+	for !s.switched && !s.stopped && len(s.buffer) >= s.bufferSize {
+		s.cond.Wait()
+	}
+	if s.stopped {
+		s.m.Unlock()
+		return core.ErrSourceStopped
+	}
+	if !s.switched {
+		s.buffer = append(s.buffer, t)
+		s.m.Unlock()
+		return nil
+	}
+	covered := s.coveredByHistory(t)
+	s.m.Unlock()
+	if covered {
+		return nil
+	}
+
+	s.wm.Lock()
+	defer s.wm.Unlock()
+	return w.Write(ctx, t)
+}
+
+// coveredByHistory returns true when the live tuple is supposed to have
+// already been emitted from the history. It also counts the tuple. The
+// caller must hold s.m.
+func (s *backfillSource) coveredByHistory(t *core.Tuple) bool {
+	if !s.boundary.IsZero() && (t.Timestamp.Before(s.boundary) ||
+		(s.inclusive && t.Timestamp.Equal(s.boundary))) {
+		s.numDropped++
+		return true
+	}
+	s.numLive++
+	return false
+}
+
+// switchToLive writes buffered live tuples and then lets the live source
+// write tuples directly. No live tuple is written before all historical
+// tuples are written, and buffered tuples are written before any tuple the
+// live source writes after the switch.
+func (s *backfillSource) switchToLive(ctx *core.Context, w core.Writer) error {
+	s.wm.Lock()
+	defer s.wm.Unlock()
+
+	s.m.Lock()
+	if s.stopped {
+		s.m.Unlock()
+		return nil
+	}
+	if s.until.IsZero() {
+		s.inclusive = true
+	} else {
+		s.boundary = s.until
+	}
+	var buf []*core.Tuple
+	for _, t := range s.buffer {
+		if !s.coveredByHistory(t) {
+			buf = append(buf, t)
+		}
+	}
+	s.buffer = nil
+	s.switched = true
+	s.cond.Broadcast()
+	s.m.Unlock()
+
+	for _, t := range buf {
+		if err := w.Write(ctx, t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *backfillSource) stopLive(ctx *core.Context) error {
+	s.stopLiveOnce.Do(func() {
+		s.stopLiveErr = s.live.Stop(ctx)
+	})
+	return s.stopLiveErr
+}
+
+func (s *backfillSource) Stop(ctx *core.Context) error {
+	s.m.Lock()
+	s.stopped = true
+	s.cond.Broadcast()
+	historyStarted, liveStarted := s.historyStarted, s.liveStarted
+	s.m.Unlock()
+
+	var errs []string
+	if historyStarted {
+		if err := s.history.Stop(ctx); err != nil {
+			errs = append(errs, fmt.Sprintf("cannot stop the history source: %v", err))
+		}
+	}
+	if liveStarted {
+		if err := s.stopLive(ctx); err != nil {
+			errs = append(errs, fmt.Sprintf("cannot stop the live source: %v", err))
+		}
+	}
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+func (s *backfillSource) Status() data.Map {
+	s.m.Lock()
+	defer s.m.Unlock()
+	mode := "backfill"
+	if s.switched {
+		mode = "live"
+	}
+	return data.Map{
+		"mode":           data.String(mode),
+		"num_backfilled": data.Int(s.numBackfilled),
+		"num_live":       data.Int(s.numLive),
+		"num_dropped":    data.Int(s.numDropped),
+		"num_buffered":   data.Int(len(s.buffer)),
+	}
+}
+
+// createBackfillSourceCreator returns a creator of a source which replays a
+// bounded historical source as fast as the source emits tuples and then
+// switches to a live source:
+//
+//	CREATE SOURCE s TYPE backfill WITH
+//	    history={"type": "file", "path": "history.jsonl", "timestamp_field": "ts"},
+//	    live={"type": "file", "path": "/var/log/app.jsonl", "timestamp_field": "ts"},
+//	    since="2016-01-01T00:00:00Z";
+//
+// "history" and "live" have the type of each source in "type" and other
+// parameters are passed to the creator of the type registered in the
+// registry. Tuples emitted from the historical source have core.TFBackfill
+// flag, which is copied to tuples derived from them by streams and can be
+// referred by is_backfill() in BQL. Time-based windows are computed from
+// timestamps of tuples, so windows over historical tuples have the same
+// results as if they were processed live.
+//
+// Historical tuples whose timestamps are out of ["since", "until") are
+// dropped. Each of them is unbounded when it's omitted.
+//
+// The live source is started at the same time as the historical source and
+// its tuples are buffered up to "buffer_size" (10000 by default) tuples
+// until the history is completely replayed. The live source is blocked while
+// the buffer is full. Then the source atomically switches to the live
+// source: buffered tuples are emitted first, and live tuples covered by the
+// history are dropped. A live tuple is covered when its timestamp is before
+// "until", or is not after the timestamp of the last historical tuple when
+// "until" isn't given. The source stops after replaying the history when
+// "live" is omitted.
+func createBackfillSourceCreator(reg SourceCreatorRegistry) SourceCreator {
+	return SourceCreatorFunc(func(ctx *core.Context, ioParams *IOParams, params data.Map) (core.Source, error) {
+		v := &struct {
+			History    data.Map `bql:",required"`
+			Live       data.Map
+			Since      time.Time
+			Until      time.Time
+			BufferSize int
+		}{
+			BufferSize: 10000,
+		}
+		dec := data.NewDecoder(nil)
+		if err := dec.Decode(params, v); err != nil {
+			return nil, err
+		}
+		if v.BufferSize <= 0 {
+			return nil, fmt.Errorf("'buffer_size' parameter must be greater than 0: %v", v.BufferSize)
+		}
+		if !v.Since.IsZero() && !v.Until.IsZero() && !v.Since.Before(v.Until) {
+			return nil, fmt.Errorf("'since' parameter must be before 'until' parameter")
+		}
+
+		history, err := createBackfillSubSource(ctx, reg, ioParams, "history", v.History)
+		if err != nil {
+			return nil, err
+		}
+		var live core.Source
+		if v.Live != nil {
+			if live, err = createBackfillSubSource(ctx, reg, ioParams, "live", v.Live); err != nil {
+				return nil, err
+			}
+		}
+
+		s := &backfillSource{
+			ioParams:   ioParams,
+			history:    history,
+			live:       live,
+			since:      v.Since,
+			until:      v.Until,
+			bufferSize: v.BufferSize,
+		}
+		s.cond = sync.NewCond(&s.m)
+		return core.ImplementSourceStop(s), nil
+	})
+}
+
+// createBackfillSubSource creates a source from a parameter having the type
+// of the source in "type".
+func createBackfillSubSource(ctx *core.Context, reg SourceCreatorRegistry, ioParams *IOParams,
+	name string, m data.Map) (core.Source, error) {
+	v, ok := m["type"]
+	if !ok {
+		return nil, fmt.Errorf("'%v' parameter must have 'type'", name)
+	}
+	typeName, err := data.AsString(v)
+	if err != nil {
+		return nil, fmt.Errorf("'type' of '%v' parameter must be a string: %v", name, err)
+	}
+	c, err := reg.Lookup(typeName)
+	if err != nil {
+		return nil, err
+	}
+
+	params := make(data.Map, len(m)-1)
+	for k, p := range m {
+		if k != "type" {
+			params[k] = p
+		}
+	}
+	s, err := c.CreateSource(ctx, &IOParams{
+		TypeName: typeName,
+		Name:     ioParams.Name,
+	}, params)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create the %v source: %v", name, err)
+	}
+	return s, nil
+}
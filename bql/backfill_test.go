@@ -0,0 +1,188 @@
+package bql
+
+import (
+	"fmt"
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBackfillSource(t *testing.T) {
+	base := time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(sec int) data.Value {
+		return data.Timestamp(base.Add(time.Duration(sec) * time.Second))
+	}
+	staticParams := func(secs ...int) data.Map {
+		ts := data.Array{}
+		for _, s := range secs {
+			ts = append(ts, data.Map{"v": data.Int(s), "ts": at(s)})
+		}
+		return data.Map{
+			"type":            data.String("static"),
+			"tuples":          ts,
+			"timestamp_field": data.String("ts"),
+		}
+	}
+
+	Convey("Given a backfill source creator", t, func() {
+		ctx := core.NewContext(nil)
+		reg, err := CopyGlobalSourceCreatorRegistry()
+		So(err, ShouldBeNil)
+		c := createBackfillSourceCreator(reg)
+		params := data.Map{
+			"history": staticParams(1, 2, 3, 4),
+			"live":    staticParams(3, 4, 5),
+			"since":   at(2),
+		}
+		w := &testTupleCollector{}
+		w.c = sync.NewCond(&w.m)
+
+		values := func() []int64 {
+			var vs []int64
+			for _, t := range w.tuples {
+				v, _ := data.AsInt(t.Data["v"])
+				vs = append(vs, v)
+			}
+			return vs
+		}
+
+		Convey("When running the source without until", func() {
+			s, err := c.CreateSource(ctx, &IOParams{Name: "s"}, params)
+			So(err, ShouldBeNil)
+			So(s.GenerateStream(ctx, w), ShouldBeNil)
+
+			Convey("Then it should emit the history and then live tuples newer than it", func() {
+				So(values(), ShouldResemble, []int64{2, 3, 4, 5})
+			})
+
+			Convey("Then only historical tuples should have the backfill flag", func() {
+				for i, t := range w.tuples {
+					So(t.Flags.IsSet(core.TFBackfill), ShouldEqual, i < 3)
+				}
+			})
+
+			Convey("Then its status should have the numbers of tuples", func() {
+				st, err := data.AsMap(s.(core.Statuser).Status()["internal_source"])
+				So(err, ShouldBeNil)
+				So(st["mode"], ShouldEqual, "live")
+				So(st["num_backfilled"], ShouldEqual, 3)
+				So(st["num_live"], ShouldEqual, 1)
+				So(st["num_dropped"], ShouldEqual, 2)
+			})
+		})
+
+		Convey("When running the source with until", func() {
+			params["until"] = at(4)
+			s, err := c.CreateSource(ctx, &IOParams{Name: "s"}, params)
+			So(err, ShouldBeNil)
+			So(s.GenerateStream(ctx, w), ShouldBeNil)
+
+			Convey("Then it should switch to the live source at until", func() {
+				So(values(), ShouldResemble, []int64{2, 3, 4, 5})
+				So(w.tuples[1].Flags.IsSet(core.TFBackfill), ShouldBeTrue)
+				So(w.tuples[2].Flags.IsSet(core.TFBackfill), ShouldBeFalse)
+			})
+		})
+
+		Convey("When running the source with a small buffer", func() {
+			params["buffer_size"] = data.Int(1)
+			s, err := c.CreateSource(ctx, &IOParams{Name: "s"}, params)
+			So(err, ShouldBeNil)
+			So(s.GenerateStream(ctx, w), ShouldBeNil)
+
+			Convey("Then it should emit the same tuples", func() {
+				So(values(), ShouldResemble, []int64{2, 3, 4, 5})
+			})
+		})
+
+		Convey("When running the source without live", func() {
+			delete(params, "live")
+			s, err := c.CreateSource(ctx, &IOParams{Name: "s"}, params)
+			So(err, ShouldBeNil)
+			So(s.GenerateStream(ctx, w), ShouldBeNil)
+
+			Convey("Then it should stop after emitting the history", func() {
+				So(values(), ShouldResemble, []int64{2, 3, 4})
+			})
+		})
+
+		Convey("When creating a source with invalid parameters", func() {
+			Convey("Then missing history should result in an error", func() {
+				delete(params, "history")
+				_, err := c.CreateSource(ctx, &IOParams{Name: "s"}, params)
+				So(err, ShouldNotBeNil)
+			})
+
+			Convey("Then history without type should result in an error", func() {
+				delete(params["history"].(data.Map), "type")
+				_, err := c.CreateSource(ctx, &IOParams{Name: "s"}, params)
+				So(err, ShouldNotBeNil)
+			})
+
+			Convey("Then live having an unknown type should result in an error", func() {
+				params["live"].(data.Map)["type"] = data.String("no_such_source")
+				_, err := c.CreateSource(ctx, &IOParams{Name: "s"}, params)
+				So(err, ShouldNotBeNil)
+			})
+
+			Convey("Then a non-positive buffer_size should result in an error", func() {
+				params["buffer_size"] = data.Int(0)
+				_, err := c.CreateSource(ctx, &IOParams{Name: "s"}, params)
+				So(err, ShouldNotBeNil)
+			})
+
+			Convey("Then since not before until should result in an error", func() {
+				params["until"] = at(2)
+				_, err := c.CreateSource(ctx, &IOParams{Name: "s"}, params)
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+
+	Convey("Given a topology having a backfill source", t, func() {
+		dt := newTestTopology()
+		Reset(func() {
+			dt.Stop()
+		})
+		tb, err := NewTopologyBuilder(dt)
+		So(err, ShouldBeNil)
+
+		tuples := func(secs ...int) string {
+			s := ""
+			for i, sec := range secs {
+				if i > 0 {
+					s += ", "
+				}
+				s += fmt.Sprintf(`{"v": %v, "ts": "2016-01-01T00:00:0%vZ"}`, sec, sec)
+			}
+			return "[" + s + "]"
+		}
+		So(addBQLToTopology(tb, fmt.Sprintf(`
+			CREATE PAUSED SOURCE s TYPE backfill WITH
+			    history={"type": "static", "tuples": %v, "timestamp_field": "ts"},
+			    live={"type": "static", "tuples": %v, "timestamp_field": "ts"};
+			CREATE STREAM box AS SELECT RSTREAM v, is_backfill() AS b FROM s [RANGE 1 TUPLES];
+			CREATE SINK snk TYPE collector;
+			INSERT INTO snk FROM box;`, tuples(1, 2), tuples(2, 3))), ShouldBeNil)
+
+		Convey("When resuming the source", func() {
+			So(addBQLToTopology(tb, `RESUME SOURCE s;`), ShouldBeNil)
+			sin, err := dt.Sink("snk")
+			So(err, ShouldBeNil)
+			si := sin.Sink().(*tupleCollectorSink)
+			si.Wait(3)
+
+			Convey("Then is_backfill() should be true only for historical tuples", func() {
+				So(si.len(), ShouldEqual, 3)
+				for i, b := range []bool{true, true, false} {
+					t := si.get(i)
+					So(t.Data["v"], ShouldEqual, i+1)
+					So(t.Data["b"], ShouldEqual, b)
+				}
+			})
+		})
+	})
+}
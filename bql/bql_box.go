@@ -1,13 +1,15 @@
 package bql
 
 import (
+	"fmt"
 	"github.com/sirupsen/logrus"
 	"gopkg.in/sensorbee/sensorbee.v0/bql/execution"
 	"gopkg.in/sensorbee/sensorbee.v0/bql/parser"
 	"gopkg.in/sensorbee/sensorbee.v0/bql/udf"
 	"gopkg.in/sensorbee/sensorbee.v0/core"
-	"math/rand"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -18,8 +20,30 @@ type bqlBox struct {
 	reg udf.FunctionRegistry
 	// plan is the execution plan for the SELECT statement in there
 	execPlan execution.PhysicalPlan
+	// lineage is the execution plan tracking lineage of results. It's nil
+	// when lineage isn't recorded or the plan doesn't support it.
+	lineage execution.LineageTracer
+	// spill is the execution plan spilling its window to disk. It's nil
+	// when the window is kept in memory.
+	spill execution.WindowSpiller
+	// timeout has execution timeouts of this box. When it's nil, the
+	// default timeouts of the Context are used.
+	timeout *core.ExecutionTimeoutConfig
+	// numUDFTimeouts is the number of UDF calls which have timed out.
+	numUDFTimeouts int64
+	// name is the name of the stream emitted from this box.
+	name string
+	// quality has data quality rules of the stream. It's nil when
+	// the stream doesn't have any rule.
+	quality *qualityRules
+	// explain collects measurements of the execution plan when the box
+	// runs the statement of EXPLAIN ANALYZE. It's nil otherwise.
+	explain *explainAnalysis
 	// mutex protects access to shared state
 	mutex sync.Mutex
+	// planMutex protects the execution plan from being processed by
+	// an abandoned call of Process which has timed out.
+	planMutex sync.Mutex
 	// timeEmitterMutex protects access to those resources
 	// accessed by the time-based emitter
 	timeEmitterMutex sync.Mutex
@@ -32,6 +56,9 @@ type bqlBox struct {
 	// emitterSamplingType holds a value different from
 	// parser.UnspecifiedSamplingType if output sampling is active
 	emitterSamplingType parser.EmitterSamplingType
+	// random is the random number generator of this box used by
+	// randomized sampling and UDFs generating random values.
+	random *core.Random
 	// genCount holds the number of items generated so far
 	// (i.e. computed by the underlying execution plan). this is only
 	// used if the count-based sampling is active.
@@ -57,6 +84,24 @@ func NewBQLBox(stmt *parser.SelectStmt, reg udf.FunctionRegistry) *bqlBox {
 }
 
 func (b *bqlBox) Init(ctx *core.Context) error {
+	b.random = ctx.NodeRandom(core.NTBox, b.name)
+	b.reg = &randomFunctionRegistry{
+		FunctionRegistry: b.reg,
+		random:           b.random,
+	}
+	if b.timeout == nil {
+		b.timeout = ctx.ExecutionTimeout()
+	}
+	if b.timeout != nil && b.timeout.UDF > 0 {
+		b.reg = &timeoutFunctionRegistry{
+			FunctionRegistry: b.reg,
+			timeout:          b.timeout.UDF,
+			timedOut: func() {
+				atomic.AddInt64(&b.numUDFTimeouts, 1)
+			},
+		}
+	}
+
 	// create the execution plan
 	analyzedPlan, err := execution.Analyze(*b.stmt, b.reg)
 	if err != nil {
@@ -73,6 +118,30 @@ func (b *bqlBox) Init(ctx *core.Context) error {
 	if err != nil {
 		return err
 	}
+	if s := ctx.Lineage(); s != nil {
+		if lt, ok := b.execPlan.(execution.LineageTracer); ok {
+			lt.EnableLineage(s.MaxInputs())
+			b.lineage = lt
+		}
+	}
+	if c := ctx.WindowSpill(); c != nil {
+		if ws, ok := b.execPlan.(execution.WindowSpiller); ok && ws.EnableWindowSpill(c) {
+			b.spill = ws
+		}
+	}
+	if ctx.ColumnarExecution() {
+		if ce, ok := b.execPlan.(execution.ColumnarExecutor); ok {
+			ce.EnableColumnarExecution()
+		}
+	}
+	if b.explain != nil {
+		p, ok := b.execPlan.(execution.Profiler)
+		if !ok {
+			return fmt.Errorf("the execution plan of the statement cannot be profiled")
+		}
+		p.EnableProfiling()
+		b.explain.profiler = p
+	}
 	if b.emitterSamplingType == parser.TimeBasedSampling {
 		go b.timeEmitter(ctx)
 	}
@@ -94,20 +163,40 @@ func (b *bqlBox) Process(ctx *core.Context, t *core.Tuple, s core.Writer) error
 	}
 
 	// feed tuple into plan
-	resultData, err := b.execPlan.Process(t)
+	numUDFTimeouts := atomic.LoadInt64(&b.numUDFTimeouts)
+	resultData, lineage, err := b.processPlan(ctx, t)
+	if b.explain != nil {
+		b.explain.processed(len(resultData))
+	}
 	if err != nil {
+		timedOut := core.IsTimeoutError(err) ||
+			atomic.LoadInt64(&b.numUDFTimeouts) != numUDFTimeouts
+		if timedOut && !b.timeout.ErrorOutput {
+			return core.FatalError(err)
+		}
 		return err
 	}
 
 	// emit result data as tuples
-	for _, data := range resultData {
+	for i, data := range resultData {
 		tup := t.ShallowCopy()
 		tup.Data = data
+		if i < len(lineage) && lineage[i] != nil {
+			// a tuple only derived from itself doesn't need lineage
+			if l := lineage[i]; l.Truncated || len(l.Inputs) != 1 || l.Inputs[0] != tup.ID {
+				tup.SetLineage(l)
+			}
+		}
 		// This method can't tell if data was originally shared by some tuples.
 		// Therefore, TFSharedData flag cannot be cleared here. Data of some
 		// Tuples can be shared when they have reference types such as Blob,
 		// Array, or Map.
 
+		// tuples violating quality rules are never emitted
+		if b.quality != nil && !b.quality.check(ctx, b.name, tup) {
+			continue
+		}
+
 		// decide if we should emit a tuple for this item
 		shouldWriteTuple := true
 		if b.emitterSamplingType == parser.CountBasedSampling {
@@ -117,7 +206,7 @@ func (b *bqlBox) Process(ctx *core.Context, t *core.Tuple, s core.Writer) error
 			b.genCount += 1
 		} else if b.emitterSamplingType == parser.RandomizedSampling {
 			// emitterSampling is in [0,1], not [0,100] any more
-			shouldWriteTuple = rand.Float64() < b.emitterSampling
+			shouldWriteTuple = b.random.Float64() < b.emitterSampling
 		} else if b.emitterSamplingType == parser.TimeBasedSampling {
 			// we will never emit something from this function
 			// when the time-based emitter is used
@@ -163,13 +252,71 @@ func (b *bqlBox) Process(ctx *core.Context, t *core.Tuple, s core.Writer) error
 	return nil
 }
 
+// processPlan feeds the tuple into the execution plan. When the box has a
+// timeout, the plan is processed in another goroutine and processPlan returns
+// core.TimeoutError if it doesn't finish in time. The goroutine keeps running
+// in background and holds the plan until it finishes.
+func (b *bqlBox) processPlan(ctx *core.Context, t *core.Tuple) ([]data.Map, []*core.Lineage, error) {
+	process := func() ([]data.Map, []*core.Lineage, error) {
+		resultData, err := b.execPlan.Process(t)
+		if err != nil {
+			return nil, nil, err
+		}
+		var lineage []*core.Lineage
+		if b.lineage != nil && ctx.Flags.TupleTrace.Enabled() {
+			lineage = b.lineage.Lineage()
+		}
+		return resultData, lineage, nil
+	}
+	if b.timeout == nil || b.timeout.Box <= 0 {
+		return process()
+	}
+
+	type result struct {
+		resultData []data.Map
+		lineage    []*core.Lineage
+		err        error
+	}
+	var abandoned int32
+	ch := make(chan result, 1) // buffered so that an abandoned call can exit
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				ch <- result{err: fmt.Errorf("processing a tuple paniced: %v", r)}
+			}
+		}()
+		b.planMutex.Lock()
+		defer b.planMutex.Unlock()
+		// a tuple waiting for the plan held by another abandoned call
+		// isn't processed after its own call is abandoned
+		if atomic.LoadInt32(&abandoned) != 0 {
+			return
+		}
+		r, l, err := process()
+		ch <- result{r, l, err}
+	}()
+
+	timer := time.NewTimer(b.timeout.Box)
+	defer timer.Stop()
+	select {
+	case r := <-ch:
+		return r.resultData, r.lineage, r.err
+	case <-timer.C:
+		atomic.StoreInt32(&abandoned, 1)
+		return nil, nil, &core.TimeoutError{
+			Target:  "processing a tuple",
+			Timeout: b.timeout.Box,
+		}
+	}
+}
+
 func (b *bqlBox) timeEmitter(ctx *core.Context) {
 	// invariant: b.emitterSamplingType == TimeBasedSampling
 
 	// generate a ticker that will tick every time we need to emit a tuple
-	ticker := time.NewTicker(time.Duration(b.emitterSampling * float64(time.Second)))
+	ticker := ctx.Clock().NewTicker(time.Duration(b.emitterSampling * float64(time.Second)))
 	defer ticker.Stop()
-	for _ = range ticker.C {
+	for _ = range ticker.C() {
 		shouldContinue := func() bool {
 			// we need to lock here because we access the `stopped` flag, the
 			// `lastTuple` and `lastWriter` pointer, as well as`emitCount`
@@ -220,11 +367,37 @@ func (b *bqlBox) timeEmitter(ctx *core.Context) {
 	}
 }
 
+// Status returns the status of the box. When the stream has quality rules,
+// the numbers of valid and invalid tuples and violations of each rule are
+// reported in "quality" field.
+func (b *bqlBox) Status() data.Map {
+	m := data.Map{}
+	if b.quality != nil {
+		m["quality"] = b.quality.status()
+	}
+	return m
+}
+
 func (b *bqlBox) Terminate(ctx *core.Context) error {
 	// signal to the time-based emitter that it should stop
 	b.timeEmitterMutex.Lock()
 	b.stopped = true
 	b.timeEmitterMutex.Unlock()
+
+	// the quarantine stream is removed with this box
+	if b.quality != nil && b.quality.quarantine != nil {
+		b.quality.quarantine.Stop(ctx)
+	}
+
+	if b.explain != nil {
+		b.explain.finish()
+	}
+
+	if b.spill != nil {
+		b.mutex.Lock()
+		defer b.mutex.Unlock()
+		return b.spill.CloseWindowSpill()
+	}
 	return nil
 }
 
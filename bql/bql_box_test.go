@@ -6,6 +6,7 @@ import (
 	_ "gopkg.in/sensorbee/sensorbee.v0/bql/udf/builtin"
 	"gopkg.in/sensorbee/sensorbee.v0/core"
 	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -569,6 +570,39 @@ func TestBasicBQLBoxUnionCapability(t *testing.T) {
 			})
 		})
 	})
+
+	Convey("Given a UNION of streams having aligned fields in BQL", t, func() {
+		s := "CREATE STREAM box AS " +
+			`SELECT ISTREAM int, "even" AS kind FROM source [RANGE 1 TUPLES] WHERE int % 2 = 0 ` +
+			`UNION SELECT ISTREAM "odd" AS kind, int * 10 AS int FROM source [RANGE 1 TUPLES] WHERE int % 2 = 1`
+		tb, err := setupTopology(s, false)
+		So(err, ShouldBeNil)
+		dt := tb.Topology()
+		Reset(func() {
+			dt.Stop()
+		})
+
+		sin, err := dt.Sink("snk")
+		So(err, ShouldBeNil)
+		si := sin.Sink().(*tupleCollectorSink)
+
+		Convey("When 4 tuples are emitted by the source", func() {
+			si.Wait(4)
+
+			Convey("Then the sink should receive tuples having the same fields", func() {
+				found := map[int64]string{}
+				si.forEachTuple(func(t *core.Tuple) {
+					So(len(t.Data), ShouldEqual, 2)
+					i, _ := data.AsInt(t.Data["int"])
+					k, _ := data.AsString(t.Data["kind"])
+					found[i] = k
+				})
+				So(found, ShouldResemble, map[int64]string{
+					10: "odd", 2: "even", 30: "odd", 4: "even",
+				})
+			})
+		})
+	})
 }
 
 func TestBQLBoxJoinCapability(t *testing.T) {
@@ -710,3 +744,70 @@ func TestBQLBoxSourceUDSF(t *testing.T) {
 		})
 	})
 }
+
+type sequentialTupleIDGenerator struct {
+	n int64
+}
+
+func (g *sequentialTupleIDGenerator) NewID() string {
+	return fmt.Sprint(atomic.AddInt64(&g.n, 1))
+}
+
+func TestBQLBoxLineage(t *testing.T) {
+	Convey("Given a topology recording lineage", t, func() {
+		ctx := core.NewContext(&core.ContextConfig{
+			TupleIDGenerator: &sequentialTupleIDGenerator{},
+			Lineage: &core.LineageConfig{
+				MaxInputs: 2,
+			},
+		})
+		ctx.Flags.TupleTrace.Set(true)
+		dt, err := core.NewDefaultTopology(ctx, "test")
+		So(err, ShouldBeNil)
+		Reset(func() {
+			dt.Stop()
+		})
+		tb, err := NewTopologyBuilder(dt)
+		So(err, ShouldBeNil)
+
+		Convey("When aggregating tuples", func() {
+			So(addBQLToTopology(tb, `
+				CREATE PAUSED SOURCE source TYPE dummy WITH num=4;
+				CREATE STREAM box AS SELECT ISTREAM count(1) AS c FROM source [RANGE 3 TUPLES];
+				CREATE SINK snk TYPE collector;
+				INSERT INTO snk FROM box;
+				RESUME SOURCE source;`), ShouldBeNil)
+			sin, err := dt.Sink("snk")
+			So(err, ShouldBeNil)
+			si := sin.Sink().(*tupleCollectorSink)
+			si.Wait(3)
+
+			Convey("Then a tuple only derived from itself shouldn't have lineage", func() {
+				So(si.get(0).Trace[2].Inputs, ShouldBeNil)
+				So(ctx.Lineage().Lookup("1"), ShouldBeEmpty)
+			})
+
+			Convey("Then the trace should have IDs of contributing tuples", func() {
+				ev := si.get(1).Trace[2]
+				So(ev.Type, ShouldEqual, core.ETOutput)
+				So(ev.Msg, ShouldEqual, "box")
+				So(ev.Inputs, ShouldResemble, []string{"1", "2"})
+			})
+
+			Convey("Then the store should have records of aggregated tuples", func() {
+				rs := ctx.Lineage().Lookup("2")
+				So(len(rs), ShouldEqual, 1)
+				So(rs[0].Node, ShouldEqual, "box")
+				So(rs[0].Inputs, ShouldResemble, []string{"1", "2"})
+				So(rs[0].Truncated, ShouldBeFalse)
+			})
+
+			Convey("Then the lineage should be truncated by the limit", func() {
+				rs := ctx.Lineage().Lookup("3")
+				So(len(rs), ShouldEqual, 1)
+				So(rs[0].Inputs, ShouldResemble, []string{"1", "2"})
+				So(rs[0].Truncated, ShouldBeTrue)
+			})
+		})
+	})
+}
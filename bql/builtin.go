@@ -3,17 +3,20 @@ package bql
 import (
 	"bufio"
 	"bytes"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 
 	"gopkg.in/natefinch/lumberjack.v2"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/udf"
 	"gopkg.in/sensorbee/sensorbee.v0/core"
 	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"gopkg.in/sensorbee/sensorbee.v0/data/textformat"
 )
 
 // TODO: create bql/builtin directory and move components in this file to there
@@ -40,11 +43,86 @@ func init() {
 	MustRegisterGlobalSinkCreator("uds", SinkCreatorFunc(createSharedStateSink))
 }
 
+func createKeyValueState(ctx *core.Context, params data.Map) (core.SharedState, error) {
+	v := &struct {
+		Key        string
+		MaxEntries int
+		MaxAge     time.Duration
+		MaxBytes   int64
+	}{}
+	dec := data.NewDecoder(nil)
+	if err := dec.Decode(params, v); err != nil {
+		return nil, err
+	}
+
+	var keyPath data.Path
+	if v.Key != "" {
+		var err error
+		if keyPath, err = data.CompilePath(v.Key); err != nil {
+			return nil, fmt.Errorf("'key' parameter doesn't have a valid path: %v", err)
+		}
+	}
+	return core.NewKeyValueState(keyPath, &core.RetentionPolicy{
+		MaxEntries: v.MaxEntries,
+		MaxAge:     v.MaxAge,
+		MaxBytes:   v.MaxBytes,
+	})
+}
+
+func init() {
+	udf.MustRegisterGlobalUDSCreator("kv", udf.UDSCreatorFunc(createKeyValueState))
+}
+
+// offsetsStateCreator creates a core.OffsetsState, which records the
+// progress of sources so that they can resume from it:
+//
+//	CREATE STATE offsets TYPE offsets;
+//	CREATE SOURCE logs TYPE partitioned_file WITH path="/data/logs",
+//	    offsets="offsets";
+//
+// The state doesn't have any parameter. Offsets are checkpointed by SAVE
+// STATE and restored by LOAD STATE, which should be executed before sources
+// using the state are created. offsets_get and offsets_reset functions
+// inspect and reset offsets of a source.
+type offsetsStateCreator struct{}
+
+var _ udf.UDSLoader = &offsetsStateCreator{}
+
+func (c *offsetsStateCreator) CreateState(ctx *core.Context, params data.Map) (core.SharedState, error) {
+	if len(params) != 0 {
+		return nil, errors.New("offsets state doesn't have any parameter")
+	}
+	return core.NewOffsetsState(), nil
+}
+
+func (c *offsetsStateCreator) LoadState(ctx *core.Context, r io.Reader, params data.Map) (core.SharedState, error) {
+	return core.LoadOffsetsState(r)
+}
+
+func init() {
+	udf.MustRegisterGlobalUDSCreator("offsets", &offsetsStateCreator{})
+}
+
 type readerSource struct {
 	filename string
 	tsField  data.Path
 	ioParams *IOParams
 
+	// location is the time zone in which timestamps in tsField without time
+	// zones are interpreted.
+	location *time.Location
+
+	// format is the name of the format of each line and decoder decodes
+	// lines in the format. When the format is a binary one, newValueDecoder
+	// is used instead of decoder.
+	format          string
+	decoder         textformat.Decoder
+	newValueDecoder func(r io.Reader) *data.ValueDecoder
+
+	// jsonLimits limits each line of JSON Lines, which is decoded while
+	// being read rather than by decoder. It's nil for other formats.
+	jsonLimits *data.JSONLimits
+
 	// repeat is the number of times that the input data is read. When its value
 	// is less than 0, the source will read the input again and again until it's
 	// stopped. When the value is 0, the source only read the input once. When
@@ -55,7 +133,18 @@ type readerSource struct {
 	// When its value is less than or equal to 0, the source tries to emit
 	// tuples as fast as possible.
 	interval time.Duration
-	stopCh   chan struct{}
+
+	// replayTiming makes the source emit tuples spaced according to the
+	// differences of their timestamps in tsField, which are divided by
+	// speed. Tuples not having a valid timestamp or having a timestamp
+	// older than the first one are emitted immediately.
+	replayTiming bool
+	speed        float64
+
+	// offsets is used to acknowledge tuples and persist the offset of
+	// the file. It's nil when acknowledgment isn't requested.
+	offsets *fileOffsetTracker
+	stopCh  chan struct{}
 }
 
 func (s *readerSource) GenerateStream(ctx *core.Context, w core.Writer) error {
@@ -79,98 +168,315 @@ func (s *readerSource) generateStream(ctx *core.Context, w core.Writer) error {
 		}
 	}()
 
+	var offset int64
+	if s.offsets != nil {
+		if offset, err = s.offsets.start(); err != nil {
+			return err
+		}
+		if _, err := f.Seek(offset, io.SeekStart); err != nil {
+			return err
+		}
+	}
+
 	r := bufio.NewReader(f)
-	next := time.Now()
+	st := emitState{next: ctx.Clock().Now()}
+	if s.newValueDecoder != nil {
+		return s.generateValueStream(ctx, w, &st, r, offset)
+	}
+	if s.jsonLimits != nil {
+		return s.generateJSONStream(ctx, w, &st, r, offset)
+	}
 	for lineNumber := 0; ; lineNumber++ {
 		line, err := r.ReadBytes('\n')
 		if err != nil && err != io.EOF {
 			return err
 		}
+		offset += int64(len(line))
 
 		line = bytes.TrimSpace(line)
 		if len(line) == 0 {
 			if err == io.EOF {
 				break
 			}
+			if s.offsets != nil {
+				s.offsets.skip(ctx, offset)
+			}
 			continue
 		}
 
-		m := data.Map{}
-		if err := json.Unmarshal(line, &m); err != nil {
+		m, err := s.decoder.Decode(line)
+		if err != nil {
 			ctx.ErrLog(err).WithField("node_name", s.ioParams.Name).
-				WithField("jsonl_line_number", lineNumber).
-				WithField("body", string(line)).Warning("Ignoring the line due to a json parse error")
+				WithField("format", s.format).
+				WithField("line_number", lineNumber).
+				WithField("body", string(line)).Warning("Ignoring the line due to a parse error")
+			if s.offsets != nil {
+				s.offsets.skip(ctx, offset)
+			}
 			continue
 		}
 
-		t := core.NewTuple(m)
-		if s.interval > 0 {
-			// When the interval parameter is given, a proper application
-			// timestamp should be assigned to each tuple.
-			t.Timestamp = next
-		}
-		if s.tsField != nil {
-			if v, err := t.Data.Get(s.tsField); err == nil {
-				if ts, err := data.ToTimestamp(v); err != nil {
-					ctx.ErrLog(err).WithField("node_name", s.ioParams.Name).
-						WithField("jsonl_line_number", lineNumber).
-						WithField("timestamp_field", s.tsField).
-						WithField("timestamp_field_value", v).
-						Warning("Cannot convert a value in timestamp_field to a timestamp")
-				} else {
-					t.Timestamp = ts
-				}
+		if err := s.emit(ctx, w, &st, m, offset, lineNumber); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// generateJSONStream reads JSON Lines. Lines exceeding jsonLimits are
+// ignored as well as malformed ones.
+func (s *readerSource) generateJSONStream(ctx *core.Context, w core.Writer, st *emitState,
+	r *bufio.Reader, start int64) error {
+	dec := newJSONLinesDecoder(r, s.jsonLimits)
+	for {
+		l, err := readJSONLine(dec)
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		offset := start + dec.InputOffset()
+
+		if l.err != nil {
+			ctx.ErrLog(l.err).WithField("node_name", s.ioParams.Name).
+				WithField("format", s.format).
+				WithField("line_number", l.number).Warning("Ignoring the line due to a parse error")
+			if s.offsets != nil {
+				s.offsets.skip(ctx, offset)
 			}
+			continue
+		}
+		if err := s.emit(ctx, w, st, l.m, offset, int(l.number)); err != nil {
+			return err
 		}
+	}
+}
 
-		if err := w.Write(ctx, t); err != nil {
+// generateValueStream reads a stream of values in a binary format such as
+// CBOR. Unlike lines of text, the stream cannot be resynchronized after a
+// malformed value, so it results in an error.
+func (s *readerSource) generateValueStream(ctx *core.Context, w core.Writer, st *emitState,
+	r *bufio.Reader, start int64) error {
+	offset := start
+	dec := s.newValueDecoder(r)
+	for recordNumber := 0; ; recordNumber++ {
+		v, err := dec.Decode()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("cannot decode the value at offset %v in %v format: %v",
+				offset, s.format, err)
+		}
+		offset = start + dec.InputOffset()
+
+		m, err := data.AsMap(v)
+		if err != nil {
+			ctx.ErrLog(err).WithField("node_name", s.ioParams.Name).
+				WithField("format", s.format).
+				WithField("record_number", recordNumber).
+				Warning("Ignoring the value since it isn't a map")
+			if s.offsets != nil {
+				s.offsets.skip(ctx, offset)
+			}
+			continue
+		}
+		if err := s.emit(ctx, w, st, m, offset, recordNumber); err != nil {
 			return err
 		}
+	}
+}
 
-		if s.interval > 0 {
-			// wait as accurate as possible
-			now := time.Now()
-			next = next.Add(s.interval)
-			if next.Before(now) {
-				// delayed too much and should be rescheduled.
-				next = now.Add(s.interval)
+// emitState has the state of the emission shared by all tuples read in a
+// run of the source.
+type emitState struct {
+	// next is the time when the next tuple is emitted. It's used by interval.
+	next time.Time
+
+	// baseTs is the timestamp of the first tuple having one and baseWall is
+	// the time when the tuple was emitted. They're used by replayTiming.
+	baseTs, baseWall time.Time
+}
+
+// emit writes a tuple having m to w. offset is the end offset of the record
+// in the file and pos is the position of the record in the stream, which
+// is only used for logging.
+func (s *readerSource) emit(ctx *core.Context, w core.Writer, st *emitState, m data.Map, offset int64, pos int) error {
+	t := core.NewTuple(m)
+	if s.offsets != nil {
+		t.SetAckHandler(s.offsets.add(offset))
+	}
+	if s.interval > 0 {
+		// When the interval parameter is given, a proper application
+		// timestamp should be assigned to each tuple.
+		t.Timestamp = st.next
+	}
+	hasTs := false
+	if s.tsField != nil {
+		if v, err := t.Data.Get(s.tsField); err == nil {
+			if ts, err := data.ToTimestampIn(v, s.location); err != nil {
+				ctx.ErrLog(err).WithField("node_name", s.ioParams.Name).
+					WithField(s.positionField(), pos).
+					WithField("timestamp_field", s.tsField).
+					WithField("timestamp_field_value", v).
+					Warning("Cannot convert a value in timestamp_field to a timestamp")
+			} else {
+				t.Timestamp = ts
+				hasTs = true
 			}
+		}
+	}
 
-			select {
-			case <-s.stopCh:
-				// This works as long as createFileSource returns a source
-				// wrapped with core.NewRewindableSource or core.ImplementSourceStop.
-				return core.ErrSourceStopped
-			case <-time.After(next.Sub(now)):
+	if s.replayTiming && hasTs {
+		if st.baseWall.IsZero() {
+			st.baseTs, st.baseWall = t.Timestamp, ctx.Clock().Now()
+		} else if d := t.Timestamp.Sub(st.baseTs); d > 0 {
+			target := st.baseWall.Add(time.Duration(float64(d) / s.speed))
+			if now := ctx.Clock().Now(); target.After(now) {
+				select {
+				case <-s.stopCh:
+					return core.ErrSourceStopped
+				case <-ctx.Clock().After(target.Sub(now)):
+				}
 			}
 		}
 	}
+
+	if err := w.Write(ctx, t); err != nil {
+		return err
+	}
+
+	if s.interval > 0 {
+		// wait as accurate as possible
+		now := ctx.Clock().Now()
+		st.next = st.next.Add(s.interval)
+		if st.next.Before(now) {
+			// delayed too much and should be rescheduled.
+			st.next = now.Add(s.interval)
+		}
+
+		select {
+		case <-s.stopCh:
+			// This works as long as createFileSource returns a source
+			// wrapped with core.NewRewindableSource or core.ImplementSourceStop.
+			return core.ErrSourceStopped
+		case <-ctx.Clock().After(st.next.Sub(now)):
+		}
+	}
 	return nil
 }
 
+// positionField returns the name of the log field having the position of a
+// record.
+func (s *readerSource) positionField() string {
+	if s.newValueDecoder != nil {
+		return "record_number"
+	}
+	return "line_number"
+}
+
 func (s *readerSource) Stop(ctx *core.Context) error {
 	close(s.stopCh)
 	return nil
 }
 
+// createFileSource creates a source reading a file line by line. The format
+// of each line is specified by the "format" parameter:
+//
+//	- jsonl (default): a JSON object
+//	- nmea: an NMEA 0183 sentence
+//	- kv: key=value pairs
+//
+// See the textformat package for details of each format. A line of jsonl is
+// decoded while being read and the following parameters limit each line so
+// that a malformed or malicious input doesn't consume too much memory:
+//
+//	- max_document_size: the maximum number of bytes of a line (16MiB)
+//	- max_depth: the maximum nesting depth of arrays and objects (256)
+//	- max_string_length: the maximum number of bytes of a string (4MiB)
+//
+// A line exceeding the limits is ignored as a malformed line is. A limit of 0
+// disables it.
+//
+// The file can also be a stream of maps in a binary format:
+//
+//	- cbor: CBOR, see data.NewCBORDecoder
+//	- msgpack: MessagePack, see data.NewMsgpackDecoder
+//
+// A value in a binary stream which isn't a map is ignored, but a malformed
+// value stops the source because the rest of the stream cannot be read.
+//
+// When "replay_timing" is true, tuples are emitted at the same pace as they
+// were recorded, which is computed from timestamps in "timestamp_field". The
+// pace can be changed by "speed" parameter, e.g. speed=2.0 replays tuples
+// twice as fast as recorded:
+//
+//	CREATE SOURCE s TYPE file WITH path="log.jsonl", timestamp_field="ts",
+//	    replay_timing=true, speed=2.0;
+//
+// "replay_timing" cannot be used with "interval".
+//
+// A string in "timestamp_field" without a time zone, such as
+// "2016-01-02 03:04:05", is interpreted as a local time in the time zone given
+// by "timezone" parameter, e.g. timezone="Asia/Tokyo" or timezone="+09:00".
+// The time zone of the topology is used when it's omitted. See
+// data.ToTimestampIn for accepted formats.
+//
+// When "offset_file" is given, each tuple requests acknowledgment (see
+// core.AckHandler) and the offset up to which all lines have been processed
+// is persisted in the file. When the source is created again with the same
+// "offset_file", it starts reading from the persisted offset so that lines
+// are delivered at least once even if the process crashes. The offset isn't
+// committed beyond a line which failed to be processed. A rewound source
+// reads the file from the beginning and resets the offset. "offset_file"
+// cannot be used with "repeat".
 func createFileSource(ctx *core.Context, ioParams *IOParams, params data.Map) (core.Source, error) {
-	// TODO: add format parameter
-
 	v := &struct {
 		Path           string `bql:",required"`
+		Format         string
 		Rewindable     bool
 		TimestampField string
+		Timezone       string
 		Repeat         int64
 		Interval       time.Duration
+		ReplayTiming   bool
+		Speed          float64
+		OffsetFile     string
+		jsonLimitParams
 	}{
-		Rewindable:     false,
-		TimestampField: "",
-		Repeat:         0,
+		Format:          "jsonl",
+		Rewindable:      false,
+		TimestampField:  "",
+		Repeat:          0,
+		Speed:           1,
+		jsonLimitParams: defaultJSONLimitParams(),
 	}
 	dec := data.NewDecoder(nil)
 	if err := dec.Decode(params, v); err != nil {
 		return nil, err
 	}
+	if v.Speed <= 0 {
+		return nil, fmt.Errorf("'speed' parameter must be greater than 0: %v", v.Speed)
+	}
+	if v.ReplayTiming {
+		if v.TimestampField == "" {
+			return nil, fmt.Errorf("'replay_timing' parameter requires 'timestamp_field' parameter")
+		}
+		if v.Interval > 0 {
+			return nil, fmt.Errorf("'replay_timing' parameter cannot be used with 'interval' parameter")
+		}
+	}
+
+	var offsets *fileOffsetTracker
+	if v.OffsetFile != "" {
+		if v.Repeat != 0 {
+			return nil, fmt.Errorf("'offset_file' parameter cannot be used with 'repeat' parameter")
+		}
+		var err error
+		if offsets, err = newFileOffsetTracker(v.OffsetFile); err != nil {
+			return nil, fmt.Errorf("cannot load 'offset_file': %v", err)
+		}
+	}
 
 	var tsField data.Path
 	if v.TimestampField != "" {
@@ -179,10 +485,171 @@ func createFileSource(ctx *core.Context, ioParams *IOParams, params data.Map) (c
 			return nil, fmt.Errorf("'timestamp_field' parameter doesn't have a valid path: %v", err)
 		}
 	}
+	loc, err := sourceLocation(ctx, v.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	var lineDec textformat.Decoder
+	var jsonLimits *data.JSONLimits
+	newValueDec, ok := valueDecoders[strings.ToLower(v.Format)]
+	if isJSONLines(v.Format) {
+		if jsonLimits, err = v.limits(); err != nil {
+			return nil, err
+		}
+	} else if !ok {
+		var err error
+		if lineDec, err = textformat.NewDecoder(v.Format); err != nil {
+			return nil, fmt.Errorf("'format' parameter has an invalid value: %v", err)
+		}
+	}
 
 	s := &readerSource{
-		filename: v.Path,
+		filename:        v.Path,
+		tsField:         tsField,
+		ioParams:        ioParams,
+		location:        loc,
+		format:          v.Format,
+		decoder:         lineDec,
+		newValueDecoder: newValueDec,
+		jsonLimits:      jsonLimits,
+		repeat:          v.Repeat,
+		interval:        v.Interval,
+
+		replayTiming: v.ReplayTiming,
+		speed:        v.Speed,
+		offsets:      offsets,
+		stopCh:       make(chan struct{}),
+	}
+	if v.Rewindable {
+		return core.NewRewindableSource(s), nil
+	}
+	return core.ImplementSourceStop(s), nil
+}
+
+// sourceLocation returns the time zone given by "timezone" parameter of a
+// source. It returns the time zone of the topology when name is empty.
+func sourceLocation(ctx *core.Context, name string) (*time.Location, error) {
+	if name == "" {
+		return ctx.Location(), nil
+	}
+	loc, err := core.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("'timezone' parameter has an invalid value: %v", err)
+	}
+	return loc, nil
+}
+
+// valueDecoders has binary formats supported by the file source.
+var valueDecoders = map[string]func(r io.Reader) *data.ValueDecoder{
+	"cbor":    data.NewCBORDecoder,
+	"msgpack": data.NewMsgpackDecoder,
+}
+
+func init() {
+	MustRegisterGlobalSourceCreator("file", SourceCreatorFunc(createFileSource))
+}
+
+// staticSource emits tuples given as a parameter of CREATE SOURCE statement.
+type staticSource struct {
+	tuples   []data.Map
+	tsField  data.Path
+	location *time.Location
+	ioParams *IOParams
+
+	// repeat and interval have the same meaning as readerSource's.
+	repeat   int64
+	interval time.Duration
+	stopCh   chan struct{}
+}
+
+func (s *staticSource) GenerateStream(ctx *core.Context, w core.Writer) error {
+	next := ctx.Clock().Now()
+	for r := int64(0); s.repeat < 0 || r <= s.repeat; r++ {
+		for i, m := range s.tuples {
+			t := core.NewTuple(m)
+			if s.interval > 0 {
+				t.Timestamp = next
+			}
+			if s.tsField != nil {
+				if v, err := t.Data.Get(s.tsField); err == nil {
+					if ts, err := data.ToTimestampIn(v, s.location); err != nil {
+						ctx.ErrLog(err).WithField("node_name", s.ioParams.Name).
+							WithField("tuple_index", i).
+							WithField("timestamp_field", s.tsField).
+							WithField("timestamp_field_value", v).
+							Warning("Cannot convert a value in timestamp_field to a timestamp")
+					} else {
+						t.Timestamp = ts
+					}
+				}
+			}
+
+			if err := w.Write(ctx, t); err != nil {
+				return err
+			}
+
+			if s.interval > 0 {
+				now := ctx.Clock().Now()
+				next = next.Add(s.interval)
+				if next.Before(now) {
+					// delayed too much and should be rescheduled.
+					next = now.Add(s.interval)
+				}
+
+				select {
+				case <-s.stopCh:
+					return core.ErrSourceStopped
+				case <-ctx.Clock().After(next.Sub(now)):
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (s *staticSource) Stop(ctx *core.Context) error {
+	close(s.stopCh)
+	return nil
+}
+
+// createStaticSource creates a source emitting tuples given in the "tuples"
+// parameter as follows:
+//
+//	CREATE SOURCE s TYPE static WITH tuples=[{"a":1}, {"a":2}];
+//
+// It also accepts "rewindable", "timestamp_field", "timezone", "repeat", and
+// "interval" parameters like the file source.
+func createStaticSource(ctx *core.Context, ioParams *IOParams, params data.Map) (core.Source, error) {
+	v := &struct {
+		Tuples         []data.Map `bql:",required"`
+		Rewindable     bool
+		TimestampField string
+		Timezone       string
+		Repeat         int64
+		Interval       time.Duration
+	}{}
+	dec := data.NewDecoder(nil)
+	if err := dec.Decode(params, v); err != nil {
+		return nil, err
+	}
+
+	var tsField data.Path
+	if v.TimestampField != "" {
+		var err error
+		if tsField, err = data.CompilePath(v.TimestampField); err != nil {
+			return nil, fmt.Errorf("'timestamp_field' parameter doesn't have a valid path: %v", err)
+		}
+	}
+	loc, err := sourceLocation(ctx, v.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &staticSource{
+		tuples:   v.Tuples,
 		tsField:  tsField,
+		location: loc,
 		ioParams: ioParams,
 		repeat:   v.Repeat,
 		interval: v.Interval,
@@ -195,80 +662,348 @@ func createFileSource(ctx *core.Context, ioParams *IOParams, params data.Map) (c
 }
 
 func init() {
-	MustRegisterGlobalSourceCreator("file", SourceCreatorFunc(createFileSource))
+	MustRegisterGlobalSourceCreator("static", SourceCreatorFunc(createStaticSource))
 }
 
 type writerSink struct {
 	m           sync.Mutex
 	w           io.Writer
 	shouldClose bool
+
+	// serializer encodes a tuple in the output format. JSON Lines is used
+	// when it's nil.
+	serializer Serializer
+
+	// buf buffers records written to w. Records are directly written to w
+	// when it's nil.
+	buf *bufio.Writer
+
+	// file is synced according to fsync. It's nil when w cannot be synced.
+	file  *os.File
+	fsync fsyncPolicy
+
+	// stopFlush stops the goroutine periodically flushing buf. flushStopped
+	// is closed when the goroutine has stopped.
+	stopFlush    chan struct{}
+	flushStopped chan struct{}
+}
+
+// fsyncPolicy controls when the file sink calls fsync.
+type fsyncPolicy int
+
+const (
+	// fsyncNever leaves syncing files to the OS.
+	fsyncNever fsyncPolicy = iota
+
+	// fsyncInterval syncs the file every flush_interval.
+	fsyncInterval
+
+	// fsyncEveryWrite flushes the buffer and syncs the file after every
+	// tuple is written.
+	fsyncEveryWrite
+)
+
+func parseFsyncPolicy(s string) (fsyncPolicy, error) {
+	switch strings.ToLower(s) {
+	case "", "never":
+		return fsyncNever, nil
+	case "interval":
+		return fsyncInterval, nil
+	case "every_write":
+		return fsyncEveryWrite, nil
+	default:
+		return 0, fmt.Errorf("'fsync' parameter must be one of never, interval, or every_write: %v", s)
+	}
 }
 
 func (s *writerSink) Write(ctx *core.Context, t *core.Tuple) error {
-	// TODO: support custom formatting. There're several things that need to
-	// be considered such as concurrent formatting, zero-copy write, and so on.
-	// While encoding tuples outside the lock supports concurrent formatting,
-	// it makes it difficult to support zero-copy write.
+	// TODO: support zero-copy write. While encoding tuples outside the lock
+	// supports concurrent formatting, it makes it difficult to support
+	// zero-copy write.
 
-	js := t.Data.String() // Format this outside the lock
+	var b []byte
+	if s.serializer == nil {
+		// Format this outside the lock
+		b = []byte(t.Data.String() + "\n")
+	}
 
-	// This lock is required to avoid interleaving JSONs.
+	// This lock is required to avoid interleaving records.
 	s.m.Lock()
 	defer s.m.Unlock()
 	if s.w == nil {
 		return errors.New("the sink is already closed")
 	}
-	_, err := fmt.Fprintln(s.w, js)
+	if s.serializer != nil {
+		// A Serializer can have a state such as whether a header has been
+		// written, so it's called inside the lock.
+		var err error
+		if b, err = s.serializer.Serialize(t.Data); err != nil {
+			return err
+		}
+	}
+	if err := s.writeRecord(b); err != nil {
+		return err
+	}
+	if s.fsync == fsyncEveryWrite {
+		return s.flush(true)
+	}
+	return nil
+}
+
+// writeRecord writes a record through the buffer. A record isn't split into
+// multiple writes to w so that a rotated file doesn't have a partial record.
+// The caller must hold the lock.
+func (s *writerSink) writeRecord(b []byte) error {
+	if s.buf == nil {
+		_, err := s.w.Write(b)
+		return err
+	}
+	if s.buf.Available() < len(b) {
+		if err := s.buf.Flush(); err != nil {
+			return err
+		}
+	}
+	if s.buf.Available() < len(b) {
+		// the record is larger than the buffer
+		_, err := s.w.Write(b)
+		return err
+	}
+	_, err := s.buf.Write(b)
 	return err
 }
 
+// flush writes buffered records to w. It also syncs the file when sync is
+// true. The caller must hold the lock.
+func (s *writerSink) flush(sync bool) error {
+	if s.buf != nil {
+		if err := s.buf.Flush(); err != nil {
+			return err
+		}
+	}
+	if sync && s.file != nil {
+		return s.file.Sync()
+	}
+	return nil
+}
+
+func (s *writerSink) flushPeriodically(ctx *core.Context, interval time.Duration,
+	stop <-chan struct{}, stopped chan<- struct{}) {
+	defer close(stopped)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+
+		s.m.Lock()
+		var err error
+		if s.w != nil {
+			err = s.flush(s.fsync == fsyncInterval)
+		}
+		s.m.Unlock()
+		if err != nil {
+			ctx.ErrLog(err).Warn("Cannot flush the file sink")
+		}
+	}
+}
+
+// Flush writes buffered records to the writer. The file is also synced
+// unless fsync is never.
+func (s *writerSink) Flush(ctx *core.Context) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.w == nil {
+		return errors.New("the sink is already closed")
+	}
+	return s.flush(s.fsync != fsyncNever)
+}
+
+// Close flushes buffered records and closes the writer if necessary. The file
+// is also synced unless fsync is never, so that all tuples written before the
+// topology is stopped are persisted.
 func (s *writerSink) Close(ctx *core.Context) error {
+	s.m.Lock()
+	stop := s.stopFlush
+	s.stopFlush = nil
+	s.m.Unlock()
+	if stop != nil {
+		close(stop)
+		<-s.flushStopped
+	}
+
 	s.m.Lock()
 	defer s.m.Unlock()
 	if s.w == nil {
 		return nil
 	}
+	err := s.flush(s.fsync != fsyncNever)
 	if s.shouldClose {
 		if c, ok := s.w.(io.Closer); ok {
-			return c.Close()
+			if cerr := c.Close(); err == nil {
+				err = cerr
+			}
 		}
+		s.w = nil
 	}
-	return nil
+	return err
 }
 
+// createStdoutSink creates a sink writing tuples to the standard output. The
+// "format" parameter selects the output format as the file sink.
 func createStdoutSink(ctx *core.Context, ioParams *IOParams, params data.Map) (core.Sink, error) {
+	newSerializer, err := NewSerializerFactory(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	ser, err := newSerializer()
+	if err != nil {
+		return nil, err
+	}
 	return &writerSink{
-		w: os.Stdout,
+		w:          os.Stdout,
+		serializer: ser,
 	}, nil
 }
 
+// fileSinkParams has parameters of the file sink.
+type fileSinkParams struct {
+	Path     string `bql:",required"`
+	Truncate bool
+	// rotate information
+	MaxSize    int
+	MaxAge     int
+	MaxBackups int
+	// MaxOpenFiles is only used when Path is a template.
+	MaxOpenFiles int
+	// buffering and durability
+	BufferSize    int
+	FlushInterval time.Duration
+	Fsync         string
+}
+
+// createFileSink creates a sink writing tuples to a file. The "format"
+// parameter selects a serializer registered by
+// RegisterGlobalSerializerCreator, such as "jsonl" (default), "csv",
+// "template", "cbor", or "msgpack". Parameters of the serializer, such as
+// "columns" of "csv", are also given to the sink. When the path is a
+// DestinationTemplate such as "/data/{{.device_id}}.jsonl", the file is
+// chosen for each tuple and at most max_open_files files are kept open.
+//
+// Tuples are written to the file one by one by default. When "buffer_size"
+// is positive, tuples are buffered up to the number of bytes and written
+// when the buffer gets full, every "flush_interval" if it's positive, and
+// when the sink is closed. "fsync" controls when the file is synced:
+//
+//	- never (default): leave it to the OS
+//	- interval: every flush_interval
+//	- every_write: after every tuple is written, which is slow but doesn't
+//	  lose tuples which have been written when the server crashes
+//
+// The file is also synced on close unless fsync is never. fsync cannot be
+// used with max_size because rotated files don't support it.
 func createFileSink(ctx *core.Context, ioParams *IOParams, params data.Map) (core.Sink, error) {
 	// TODO: currently this sink isn't secure because it accepts any path.
-	// TODO: support buffering
-	// TODO: provide "format" parameter to support output formats other than "jsonl".
-	//       "jsonl" should be the default value.
 	// TODO: support "compression" parameter with values like "gz".
 
-	v := &struct {
-		Path     string `bql:",required"`
-		Truncate bool
-		// rotate information
-		MaxSize    int
-		MaxAge     int
-		MaxBackups int
-	}{
-		Truncate: false,
-		MaxSize:  0,
+	v := &fileSinkParams{
+		Truncate:     false,
+		MaxSize:      0,
+		MaxOpenFiles: 64,
 	}
 	dec := data.NewDecoder(nil)
 	if err := dec.Decode(params, v); err != nil {
 		return nil, err
 	}
 
-	var w io.Writer
+	newSerializer, err := NewSerializerFactory(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	if v.BufferSize < 0 {
+		return nil, fmt.Errorf("buffer_size must not be negative: %v", v.BufferSize)
+	}
+	if v.FlushInterval < 0 {
+		return nil, fmt.Errorf("flush_interval must not be negative: %v", v.FlushInterval)
+	}
+	fsync, err := parseFsyncPolicy(v.Fsync)
+	if err != nil {
+		return nil, err
+	}
+	if fsync == fsyncInterval && v.FlushInterval == 0 {
+		return nil, errors.New("fsync=interval requires a positive flush_interval")
+	}
+	if fsync != fsyncNever && v.MaxSize > 0 {
+		return nil, errors.New("fsync cannot be used with max_size")
+	}
+
+	tmpl, err := NewDestinationTemplate(v.Path)
+	if err != nil {
+		return nil, err
+	}
+	if tmpl.IsStatic() {
+		return openFileSink(ctx, v.Path, v.Truncate, v, fsync, newSerializer)
+	}
+
+	if v.MaxOpenFiles < 0 {
+		return nil, fmt.Errorf("max_open_files must not be negative: %v", v.MaxOpenFiles)
+	}
+	// A file is truncated only when it's opened for the first time so that
+	// tuples written before it's closed due to max_open_files aren't lost.
+	opened := map[string]bool{}
+	return NewDynamicSink(&DynamicSinkConfig{
+		Template: tmpl,
+		MaxOpen:  v.MaxOpenFiles,
+		Open: func(ctx *core.Context, path string) (core.Sink, error) {
+			if err := validateDestinationPath(path); err != nil {
+				return nil, err
+			}
+			if dir := filepath.Dir(path); dir != "" {
+				if err := os.MkdirAll(dir, 0755); err != nil {
+					return nil, err
+				}
+			}
+			truncate := false
+			if v.Truncate {
+				truncate = !opened[path]
+				opened[path] = true
+			}
+			return openFileSink(ctx, path, truncate, v, fsync, newSerializer)
+		},
+	})
+}
+
+// validateDestinationPath rejects a path computed from a template when it
+// goes up to a parent directory with "..", which is likely to be caused by
+// an unexpected value in a tuple.
+func validateDestinationPath(path string) error {
+	if path == "" {
+		return errors.New("the path is empty")
+	}
+	for _, e := range strings.Split(filepath.ToSlash(path), "/") {
+		if e == ".." {
+			return fmt.Errorf("the path must not contain '..': %v", path)
+		}
+	}
+	return nil
+}
+
+func openFileSink(ctx *core.Context, path string, truncate bool, v *fileSinkParams,
+	fsync fsyncPolicy, newSerializer SerializerFactory) (core.Sink, error) {
+	ser, err := newSerializer()
+	if err != nil {
+		return nil, err
+	}
+	s := &writerSink{
+		shouldClose: true,
+		serializer:  ser,
+		fsync:       fsync,
+	}
 	if v.MaxSize > 0 {
 		l := lumberjack.Logger{
-			Filename: v.Path,
+			Filename: path,
 		}
 		if v.MaxAge > 0 {
 			l.MaxAge = v.MaxAge
@@ -276,28 +1011,35 @@ func createFileSink(ctx *core.Context, ioParams *IOParams, params data.Map) (cor
 		if v.MaxBackups > 0 {
 			l.MaxBackups = v.MaxBackups
 		}
-		if _, err := os.Stat(v.Path); err == nil && v.Truncate {
-			if err := os.Truncate(v.Path, 0); err != nil {
+		if _, err := os.Stat(path); err == nil && truncate {
+			if err := os.Truncate(path, 0); err != nil {
 				return nil, err
 			}
 		}
-		w = &l
+		s.w = &l
 	} else {
 		flags := os.O_WRONLY | os.O_APPEND | os.O_CREATE
-		if v.Truncate {
+		if truncate {
 			flags |= os.O_TRUNC
 		}
 
-		file, err := os.OpenFile(v.Path, flags, 0644)
+		file, err := os.OpenFile(path, flags, 0644)
 		if err != nil {
 			return nil, err
 		}
-		w = file
+		s.w = file
+		s.file = file
 	}
-	return &writerSink{
-		w:           w,
-		shouldClose: true,
-	}, nil
+
+	if v.BufferSize > 0 {
+		s.buf = bufio.NewWriterSize(s.w, v.BufferSize)
+	}
+	if v.FlushInterval > 0 && (s.buf != nil || s.fsync == fsyncInterval) {
+		s.stopFlush = make(chan struct{})
+		s.flushStopped = make(chan struct{})
+		go s.flushPeriodically(ctx, v.FlushInterval, s.stopFlush, s.flushStopped)
+	}
+	return s, nil
 }
 
 func init() {
@@ -313,6 +1055,14 @@ func init() {
 	MustRegisterGlobalSourceCreator("dropped_tuples", SourceCreatorFunc(createDroppedTupleCollectorSource))
 }
 
+func createWatchdogEventSource(ctx *core.Context, ioParams *IOParams, params data.Map) (core.Source, error) {
+	return core.NewWatchdogEventSource(), nil
+}
+
+func init() {
+	MustRegisterGlobalSourceCreator("watchdog_events", SourceCreatorFunc(createWatchdogEventSource))
+}
+
 type nodeStatusSource struct {
 	topology core.Topology
 	interval time.Duration
@@ -320,14 +1070,14 @@ type nodeStatusSource struct {
 }
 
 func (s *nodeStatusSource) GenerateStream(ctx *core.Context, w core.Writer) error {
-	next := time.Now().Add(s.interval)
+	next := ctx.Clock().Now().Add(s.interval)
 	for {
 		select {
 		case <-s.stopCh:
 			return nil
-		case <-time.After(next.Sub(time.Now())):
+		case <-ctx.Clock().After(next.Sub(ctx.Clock().Now())):
 		}
-		now := time.Now()
+		now := ctx.Clock().Now()
 
 		for name, n := range s.topology.Nodes() {
 			t := &core.Tuple{
@@ -353,6 +1103,75 @@ func (s *nodeStatusSource) Stop(ctx *core.Context) error {
 	return nil
 }
 
+type stateStatusSource struct {
+	interval time.Duration
+	stopCh   chan struct{}
+}
+
+func (s *stateStatusSource) GenerateStream(ctx *core.Context, w core.Writer) error {
+	next := ctx.Clock().Now().Add(s.interval)
+	for {
+		select {
+		case <-s.stopCh:
+			return nil
+		case <-ctx.Clock().After(next.Sub(ctx.Clock().Now())):
+		}
+		now := ctx.Clock().Now()
+
+		states, err := ctx.SharedStates.List()
+		if err != nil {
+			return err
+		}
+		for name, st := range states {
+			typeName, err := ctx.SharedStates.Type(name)
+			if err != nil {
+				continue // the state has been removed.
+			}
+			m := data.Map{}
+			if s, ok := st.(core.Statuser); ok {
+				m = s.Status()
+			}
+			m["state_name"] = data.String(name)
+			m["state_type"] = data.String(typeName)
+			w.Write(ctx, &core.Tuple{
+				Timestamp:     now,
+				ProcTimestamp: now,
+				Data:          m,
+			})
+		}
+
+		next = next.Add(s.interval)
+		if next.Before(now) {
+			// delayed too much and should be rescheduled.
+			next = now.Add(s.interval)
+		}
+	}
+}
+
+func (s *stateStatusSource) Stop(ctx *core.Context) error {
+	close(s.stopCh)
+	return nil
+}
+
+func createStateStatusSource(ctx *core.Context, ioParams *IOParams, params data.Map) (core.Source, error) {
+	interval := 1 * time.Second
+	if v, ok := params["interval"]; !ok {
+	} else if d, err := data.ToDuration(v); err != nil {
+		return nil, err
+	} else {
+		interval = d
+	}
+
+	return &stateStatusSource{
+		interval: interval,
+		stopCh:   make(chan struct{}),
+	}, nil
+}
+
+func init() {
+	MustRegisterGlobalSourceCreator("state_statuses", SourceCreatorFunc(createStateStatusSource))
+}
+
 // createNodeStatusSourceCreator creates a SourceCreator which creates
 // nodeStatusSource. Because it requires core.Topology, it cannot be registered
 // statically. It'll be registered in a function like NewTopologyBuilder.
@@ -381,7 +1200,7 @@ type edgeStatusSource struct {
 }
 
 func (s *edgeStatusSource) GenerateStream(ctx *core.Context, w core.Writer) error {
-	next := time.Now().Add(s.interval)
+	next := ctx.Clock().Now().Add(s.interval)
 
 	inputPath := data.MustCompilePath("input_stats.inputs")
 
@@ -389,9 +1208,9 @@ func (s *edgeStatusSource) GenerateStream(ctx *core.Context, w core.Writer) erro
 		select {
 		case <-s.stopCh:
 			return nil
-		case <-time.After(next.Sub(time.Now())):
+		case <-ctx.Clock().After(next.Sub(ctx.Clock().Now())):
 		}
-		now := time.Now()
+		now := ctx.Clock().Now()
 
 		// collect all nodes that can receive data
 		receivers := map[string]core.Node{}
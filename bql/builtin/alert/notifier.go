@@ -0,0 +1,303 @@
+package alert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net"
+	"net/http"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"gopkg.in/sensorbee/sensorbee.v0/bql"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// alert is a notification rendered from a tuple.
+type alert struct {
+	key       string
+	resolved  bool
+	subject   string
+	body      string
+	data      data.Map
+	timestamp time.Time
+}
+
+func (a *alert) status() string {
+	if a.resolved {
+		return "resolved"
+	}
+	return "firing"
+}
+
+// notifier delivers alerts to a channel.
+type notifier interface {
+	notify(ctx *core.Context, a *alert) error
+}
+
+// smtpNotifier sends alerts as plain text emails.
+type smtpNotifier struct {
+	addr string
+	from string
+	to   []string
+	auth smtp.Auth
+
+	// outbound has the proxy and TLS parameters used to connect to the
+	// server.
+	outbound *bql.OutboundConfig
+
+	// send is sendMail and it's replaced in tests.
+	send func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+func newSMTPNotifier(params data.Map) (notifier, error) {
+	v := &struct {
+		SMTPAddr string   `bql:",required"`
+		From     string   `bql:",required"`
+		To       []string `bql:",required"`
+		Username string
+		Password string
+	}{}
+	if err := data.NewDecoder(nil).Decode(params, v); err != nil {
+		return nil, err
+	}
+	host, _, err := net.SplitHostPort(v.SMTPAddr)
+	if err != nil {
+		return nil, fmt.Errorf("smtp_addr must be host:port: %v", err)
+	}
+	if len(v.To) == 0 {
+		return nil, errors.New("to must have at least one address")
+	}
+	outbound, err := bql.NewOutboundConfig(params)
+	if err != nil {
+		return nil, err
+	}
+
+	n := &smtpNotifier{
+		addr:     v.SMTPAddr,
+		from:     v.From,
+		to:       v.To,
+		outbound: outbound,
+	}
+	n.send = n.sendMail
+	if v.Username != "" {
+		n.auth = smtp.PlainAuth("", v.Username, v.Password, host)
+	}
+	return n, nil
+}
+
+func (n *smtpNotifier) notify(ctx *core.Context, a *alert) error {
+	subject := a.subject
+	if a.resolved {
+		subject = "[RESOLVED] " + subject
+	}
+
+	b := bytes.NewBuffer(nil)
+	fmt.Fprintf(b, "From: %v\r\n", n.from)
+	fmt.Fprintf(b, "To: %v\r\n", strings.Join(n.to, ", "))
+	fmt.Fprintf(b, "Subject: %v\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(b, "Date: %v\r\n", a.timestamp.Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.Replace(a.body, "\n", "\r\n", -1))
+	return n.send(n.addr, n.auth, n.from, n.to, b.Bytes())
+}
+
+// sendMail is smtp.SendMail connecting to the server with n.outbound.
+func (n *smtpNotifier) sendMail(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return err
+	}
+	conn, err := n.outbound.DialContext(context.Background(), "tcp", addr)
+	if err != nil {
+		return err
+	}
+	c, err := smtp.NewClient(conn, host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		tc, err := n.outbound.TLSConfig()
+		if err != nil {
+			return err
+		}
+		if tc.ServerName == "" {
+			tc.ServerName = host
+		}
+		if err := c.StartTLS(tc); err != nil {
+			return err
+		}
+	}
+	if a != nil {
+		if ok, _ := c.Extension("AUTH"); !ok {
+			return errors.New("the SMTP server doesn't support AUTH")
+		}
+		if err := c.Auth(a); err != nil {
+			return err
+		}
+	}
+	if err := c.Mail(from); err != nil {
+		return err
+	}
+	for _, addr := range to {
+		if err := c.Rcpt(addr); err != nil {
+			return err
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+// pagerDutyNotifier sends alerts as events of PagerDuty Events API v2. The
+// key of an alert is used as the dedup_key so that a resolution resolves the
+// incident triggered by the alert.
+type pagerDutyNotifier struct {
+	url        string
+	routingKey string
+	severity   string
+	source     string
+	client     *bql.SharedHTTPClient
+}
+
+func newPagerDutyNotifier(params data.Map, source string, timeout time.Duration) (notifier, error) {
+	v := &struct {
+		RoutingKey   string `bql:",required"`
+		PagerDutyURL string `bql:"pagerduty_url"`
+		Severity     string
+	}{
+		PagerDutyURL: "https://events.pagerduty.com/v2/enqueue",
+		Severity:     "error",
+	}
+	if err := data.NewDecoder(nil).Decode(params, v); err != nil {
+		return nil, err
+	}
+	switch v.Severity {
+	case "critical", "error", "warning", "info":
+	default:
+		return nil, fmt.Errorf("severity must be one of critical, error, warning, and info: %v", v.Severity)
+	}
+	client, err := bql.NewSharedHTTPClient(v.PagerDutyURL, timeout, params)
+	if err != nil {
+		return nil, err
+	}
+	return &pagerDutyNotifier{
+		url:        v.PagerDutyURL,
+		routingKey: v.RoutingKey,
+		severity:   v.Severity,
+		source:     source,
+		client:     client,
+	}, nil
+}
+
+func (n *pagerDutyNotifier) Close() error {
+	return n.client.Close()
+}
+
+func (n *pagerDutyNotifier) notify(ctx *core.Context, a *alert) error {
+	ev := map[string]interface{}{
+		"routing_key":  n.routingKey,
+		"event_action": "trigger",
+		"dedup_key":    a.key,
+	}
+	if a.resolved {
+		ev["event_action"] = "resolve"
+	} else {
+		ev["payload"] = map[string]interface{}{
+			"summary":        a.subject,
+			"source":         n.source,
+			"severity":       n.severity,
+			"timestamp":      a.timestamp.UTC().Format(time.RFC3339Nano),
+			"custom_details": a.data,
+		}
+	}
+	return postJSON(n.client, n.url, nil, ev)
+}
+
+// webhookNotifier posts alerts as JSON to a URL.
+type webhookNotifier struct {
+	url     string
+	headers map[string]string
+	client  *bql.SharedHTTPClient
+}
+
+func newWebhookNotifier(params data.Map, timeout time.Duration) (notifier, error) {
+	v := &struct {
+		URL     string `bql:",required"`
+		Headers map[string]string
+	}{}
+	if err := data.NewDecoder(nil).Decode(params, v); err != nil {
+		return nil, err
+	}
+	client, err := bql.NewSharedHTTPClient(v.URL, timeout, params)
+	if err != nil {
+		return nil, err
+	}
+	return &webhookNotifier{
+		url:     v.URL,
+		headers: v.Headers,
+		client:  client,
+	}, nil
+}
+
+func (n *webhookNotifier) Close() error {
+	return n.client.Close()
+}
+
+func (n *webhookNotifier) notify(ctx *core.Context, a *alert) error {
+	return postJSON(n.client, n.url, n.headers, map[string]interface{}{
+		"key":       a.key,
+		"status":    a.status(),
+		"subject":   a.subject,
+		"body":      a.body,
+		"timestamp": a.timestamp.UTC().Format(time.RFC3339Nano),
+		"data":      a.data,
+	})
+}
+
+// postJSON posts v as JSON and fails when the response isn't 2xx.
+func postJSON(c *bql.SharedHTTPClient, url string, headers map[string]string, v interface{}) error {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest("POST", url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for n, v := range headers {
+		req.Header.Set(n, v)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := c.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		msg, _ := ioutil.ReadAll(io.LimitReader(res.Body, 1024))
+		return fmt.Errorf("cannot send an alert to %v: %v %v", url, res.Status, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
@@ -0,0 +1,415 @@
+// Package alert provides a sink sending tuples as alerts to SMTP, PagerDuty,
+// or a generic webhook.
+//
+// The sink isn't registered by default. To use it, add the package to the
+// plugins list of build_sensorbee:
+//
+//	plugins:
+//	  - gopkg.in/sensorbee/sensorbee.v0/bql/builtin/alert
+//
+// Then, the sink can be created as follows:
+//
+//	CREATE SINK oncall TYPE alert WITH
+//	    channel="pagerduty", routing_key="xxxxxxxx",
+//	    key="device_id", resolved="recovered",
+//	    subject="temperature of {{.device_id}} is {{.temperature}}",
+//	    dedup_window=600, rate_limit=3, rate_period=3600;
+//
+// Each tuple is an alert identified by the value at the "key" path. The
+// subject and the body of a message are rendered from the tuple with Go's
+// text/template, in which fields of the tuple can be referred like
+// {{.device_id}}. A tuple whose field at the "resolved" path is true is
+// a resolution of the alert having the same key. It's sent to the channel
+// only when the alert has been notified and hasn't been resolved yet.
+//
+// The sink has the following parameters:
+//
+//	- channel: "smtp", "pagerduty", or "webhook" (required)
+//	- key: the path to the field identifying an alert (default: all tuples
+//	  are the same alert whose key is the name of the sink)
+//	- subject: the template of subjects (default: the key)
+//	- body: the template of bodies (default: "{{json .}}", the tuple in JSON)
+//	- resolved: the path to the boolean field telling that the alert is
+//	  resolved (default: tuples are never resolutions)
+//	- send_resolved: whether resolutions are sent (default: true)
+//	- dedup_window: the duration in seconds in which an alert having the same
+//	  subject and body as the last notification of the key isn't sent again
+//	  (default: 300). 0 disables deduplication
+//	- rate_limit: the maximum number of notifications of each key in
+//	  rate_period (default: 0, unlimited)
+//	- rate_period: the duration of rate_limit in seconds (default: 60)
+//	- timeout: the timeout of a request in seconds (default: 10). It isn't
+//	  applied to the smtp channel
+//
+// Parameters of the smtp channel:
+//
+//	- smtp_addr: the address of the SMTP server like "localhost:25"
+//	  (required)
+//	- from: the sender address (required)
+//	- to: an array of recipient addresses (required)
+//	- username, password: credentials for PLAIN authentication (optional)
+//
+// Parameters of the pagerduty channel, which sends events of Events API v2
+// using the key as the dedup_key:
+//
+//	- routing_key: the integration key (required)
+//	- severity: "critical", "error", "warning", or "info" (default: "error")
+//	- pagerduty_url: the URL of the API (default:
+//	  "https://events.pagerduty.com/v2/enqueue")
+//
+// Parameters of the webhook channel, which posts a JSON object having "key",
+// "status" ("firing" or "resolved"), "subject", "body", "timestamp", and
+// "data" fields:
+//
+//	- url: the URL to which alerts are posted (required)
+//	- headers: a map of HTTP headers added to requests
+//
+// Sinks of the pagerduty and webhook channels sending alerts to the same host
+// share a connection pool, which can be configured by parameters described in
+// bql.NewSharedHTTPClient.
+//
+// All channels connect to servers through a proxy with TLS parameters given
+// by the server's config and parameters described in bql.NewOutboundConfig.
+// The smtp channel uses STARTTLS when the server supports it, and only
+// SOCKS5 proxies and HTTP proxies supporting CONNECT can be used for it.
+//
+// An alert which cannot be delivered results in an error of Write and it
+// isn't counted for deduplication or rate limiting, so the next tuple of the
+// key is sent again.
+package alert
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"gopkg.in/sensorbee/sensorbee.v0/bql"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// sinkConfig has parameters of the sink which don't depend on the channel.
+type sinkConfig struct {
+	Channel      string `bql:",required"`
+	Key          string
+	Subject      string
+	Body         string
+	Resolved     string
+	SendResolved bool
+	DedupWindow  time.Duration
+	RateLimit    int
+	RatePeriod   time.Duration
+	Timeout      time.Duration
+}
+
+func (c *sinkConfig) validate() error {
+	if c.DedupWindow < 0 {
+		return fmt.Errorf("dedup_window must not be negative: %v", c.DedupWindow)
+	}
+	if c.RateLimit < 0 {
+		return fmt.Errorf("rate_limit must not be negative: %v", c.RateLimit)
+	}
+	if c.RatePeriod <= 0 {
+		return fmt.Errorf("rate_period must be positive: %v", c.RatePeriod)
+	}
+	if c.Timeout <= 0 {
+		return fmt.Errorf("timeout must be positive: %v", c.Timeout)
+	}
+	return nil
+}
+
+// keyState is the state of alerts having the same key.
+type keyState struct {
+	// firing is true when an alert has been sent and it hasn't been resolved.
+	firing bool
+
+	// lastMessage is the subject and the body of the last notification.
+	lastMessage string
+	lastSent    time.Time
+	lastSeen    time.Time
+
+	// sent has times of notifications in the current rate_period.
+	sent []time.Time
+}
+
+type sink struct {
+	m        sync.Mutex
+	config   *sinkConfig
+	notifier notifier
+
+	// key and resolved are nil when the parameters aren't given.
+	key      data.Path
+	resolved data.Path
+
+	subject *template.Template
+	body    *template.Template
+
+	// defKey is the key of all alerts when key parameter isn't given.
+	defKey string
+
+	states map[string]*keyState
+	lastGC time.Time
+	closed bool
+
+	numSent         int64
+	numResolved     int64
+	numDeduplicated int64
+	numRateLimited  int64
+	numErrors       int64
+}
+
+var (
+	_ core.Statuser = &sink{}
+)
+
+func (s *sink) Write(ctx *core.Context, t *core.Tuple) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.closed {
+		return errors.New("the sink is already closed")
+	}
+	now := ctx.Clock().Now()
+	s.gc(now)
+
+	key, err := s.alertKey(t)
+	if err != nil {
+		return err
+	}
+	resolved := false
+	if s.resolved != nil {
+		if v, err := t.Data.Get(s.resolved); err == nil {
+			resolved, _ = data.ToBool(v)
+		}
+	}
+
+	st := s.states[key]
+	if resolved {
+		if st == nil || !st.firing {
+			return nil
+		}
+		if s.config.SendResolved {
+			a, err := s.render(t, key, true)
+			if err != nil {
+				return err
+			}
+			if err := s.notify(ctx, a); err != nil {
+				return err
+			}
+		}
+		delete(s.states, key)
+		s.numResolved++
+		return nil
+	}
+
+	a, err := s.render(t, key, false)
+	if err != nil {
+		return err
+	}
+	if st == nil {
+		st = &keyState{}
+		s.states[key] = st
+	}
+	st.lastSeen = now
+
+	msg := a.subject + "\x00" + a.body
+	if st.firing && msg == st.lastMessage && now.Sub(st.lastSent) < s.config.DedupWindow {
+		s.numDeduplicated++
+		return nil
+	}
+	if s.config.RateLimit > 0 {
+		i := 0
+		for i < len(st.sent) && now.Sub(st.sent[i]) >= s.config.RatePeriod {
+			i++
+		}
+		st.sent = st.sent[i:]
+		if len(st.sent) >= s.config.RateLimit {
+			s.numRateLimited++
+			return nil
+		}
+	}
+
+	if err := s.notify(ctx, a); err != nil {
+		return err
+	}
+	st.firing = true
+	st.lastMessage = msg
+	st.lastSent = now
+	if s.config.RateLimit > 0 {
+		st.sent = append(st.sent, now)
+	}
+	return nil
+}
+
+// alertKey returns the key of the alert. The key is the string
+// representation of the value at the key path.
+func (s *sink) alertKey(t *core.Tuple) (string, error) {
+	if s.key == nil {
+		return s.defKey, nil
+	}
+	v, err := t.Data.Get(s.key)
+	if err != nil {
+		return "", fmt.Errorf("the tuple doesn't have the key %v: %v", s.config.Key, err)
+	}
+	if v.Type() == data.TypeString {
+		str, _ := data.AsString(v)
+		return str, nil
+	}
+	return v.String(), nil
+}
+
+func (s *sink) render(t *core.Tuple, key string, resolved bool) (*alert, error) {
+	a := &alert{
+		key:       key,
+		resolved:  resolved,
+		data:      t.Data,
+		timestamp: t.Timestamp,
+	}
+	v := bql.TemplateValue(t.Data)
+	b := bytes.NewBuffer(nil)
+	if s.subject == nil {
+		a.subject = key
+	} else {
+		if err := s.subject.Execute(b, v); err != nil {
+			return nil, fmt.Errorf("cannot render the subject: %v", err)
+		}
+		a.subject = b.String()
+		b.Reset()
+	}
+	if err := s.body.Execute(b, v); err != nil {
+		return nil, fmt.Errorf("cannot render the body: %v", err)
+	}
+	a.body = b.String()
+	return a, nil
+}
+
+func (s *sink) notify(ctx *core.Context, a *alert) error {
+	if err := s.notifier.notify(ctx, a); err != nil {
+		s.numErrors++
+		ctx.ErrLog(err).WithField("key", a.key).Error("Cannot send an alert")
+		return err
+	}
+	s.numSent++
+	return nil
+}
+
+// gc removes states of keys which don't affect deduplication or rate
+// limiting anymore. States of firing alerts are kept until they're resolved
+// unless the sink doesn't have resolutions.
+func (s *sink) gc(now time.Time) {
+	d := s.config.DedupWindow
+	if d < s.config.RatePeriod {
+		d = s.config.RatePeriod
+	}
+	if now.Sub(s.lastGC) < d {
+		return
+	}
+	s.lastGC = now
+	for k, st := range s.states {
+		if now.Sub(st.lastSeen) < d {
+			continue
+		}
+		if st.firing && s.resolved != nil {
+			continue
+		}
+		delete(s.states, k)
+	}
+}
+
+func (s *sink) Close(ctx *core.Context) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	if c, ok := s.notifier.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+func (s *sink) Status() data.Map {
+	s.m.Lock()
+	defer s.m.Unlock()
+	firing := 0
+	for _, st := range s.states {
+		if st.firing {
+			firing++
+		}
+	}
+	return data.Map{
+		"channel":      data.String(s.config.Channel),
+		"sent":         data.Int(s.numSent),
+		"resolved":     data.Int(s.numResolved),
+		"deduplicated": data.Int(s.numDeduplicated),
+		"rate_limited": data.Int(s.numRateLimited),
+		"errors":       data.Int(s.numErrors),
+		"keys":         data.Int(len(s.states)),
+		"firing":       data.Int(firing),
+	}
+}
+
+func createSink(ctx *core.Context, ioParams *bql.IOParams, params data.Map) (core.Sink, error) {
+	c := &sinkConfig{
+		Body:         "{{json .}}",
+		SendResolved: true,
+		DedupWindow:  5 * time.Minute,
+		RatePeriod:   time.Minute,
+		Timeout:      10 * time.Second,
+	}
+	if err := data.NewDecoder(nil).Decode(params, c); err != nil {
+		return nil, err
+	}
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+
+	s := &sink{
+		config: c,
+		states: map[string]*keyState{},
+		defKey: ioParams.Name,
+	}
+	var err error
+	switch strings.ToLower(c.Channel) {
+	case "smtp":
+		s.notifier, err = newSMTPNotifier(params)
+	case "pagerduty":
+		s.notifier, err = newPagerDutyNotifier(params, ioParams.Name, c.Timeout)
+	case "webhook":
+		s.notifier, err = newWebhookNotifier(params, c.Timeout)
+	default:
+		return nil, fmt.Errorf("channel must be one of smtp, pagerduty, and webhook: %v", c.Channel)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if c.Key != "" {
+		if s.key, err = data.CompilePath(c.Key); err != nil {
+			return nil, fmt.Errorf("key has an invalid path: %v", err)
+		}
+	}
+	if c.Resolved != "" {
+		if s.resolved, err = data.CompilePath(c.Resolved); err != nil {
+			return nil, fmt.Errorf("resolved has an invalid path: %v", err)
+		}
+	}
+	if c.Subject != "" {
+		if s.subject, err = bql.NewTupleTemplate("subject", c.Subject); err != nil {
+			return nil, err
+		}
+	}
+	if s.body, err = bql.NewTupleTemplate("body", c.Body); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func init() {
+	bql.MustRegisterGlobalSinkCreator("alert", bql.SinkCreatorFunc(createSink))
+}
@@ -0,0 +1,445 @@
+package alert
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/bql"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// recordingNotifier records alerts instead of sending them.
+type recordingNotifier struct {
+	m      sync.Mutex
+	alerts []*alert
+	err    error
+}
+
+func (n *recordingNotifier) notify(ctx *core.Context, a *alert) error {
+	n.m.Lock()
+	defer n.m.Unlock()
+	if n.err != nil {
+		return n.err
+	}
+	n.alerts = append(n.alerts, a)
+	return nil
+}
+
+func newTestSink(ctx *core.Context, params data.Map) (*sink, *recordingNotifier) {
+	params["channel"] = data.String("webhook")
+	params["url"] = data.String("http://localhost/")
+	s, err := createSink(ctx, &bql.IOParams{Name: "snk"}, params)
+	So(err, ShouldBeNil)
+	n := &recordingNotifier{}
+	s.(*sink).notifier = n
+	return s.(*sink), n
+}
+
+func TestAlertSink(t *testing.T) {
+	clock := core.NewManualClock(time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC))
+	ctx := core.NewContext(&core.ContextConfig{Clock: clock})
+	newTuple := func(m data.Map) *core.Tuple {
+		return core.NewTuple(m)
+	}
+
+	Convey("Given an alert sink with deduplication", t, func() {
+		s, n := newTestSink(ctx, data.Map{
+			"key":          data.String("id"),
+			"subject":      data.String("{{.id}} is {{.value}}"),
+			"dedup_window": data.Int(60),
+		})
+
+		Convey("When writing the same alert twice", func() {
+			So(s.Write(ctx, newTuple(data.Map{"id": data.String("a"), "value": data.Int(1)})), ShouldBeNil)
+			So(s.Write(ctx, newTuple(data.Map{"id": data.String("a"), "value": data.Int(1)})), ShouldBeNil)
+
+			Convey("Then only the first one should be sent", func() {
+				So(len(n.alerts), ShouldEqual, 1)
+				So(n.alerts[0].key, ShouldEqual, "a")
+				So(n.alerts[0].subject, ShouldEqual, "a is 1")
+				So(n.alerts[0].body, ShouldEqual, `{"id":"a","value":1}`)
+				So(s.Status()["deduplicated"], ShouldEqual, data.Int(1))
+			})
+
+			Convey("Then it should be sent again after the window", func() {
+				clock.Advance(time.Minute)
+				So(s.Write(ctx, newTuple(data.Map{"id": data.String("a"), "value": data.Int(1)})), ShouldBeNil)
+				So(len(n.alerts), ShouldEqual, 2)
+			})
+		})
+
+		Convey("When writing alerts having different messages or keys", func() {
+			So(s.Write(ctx, newTuple(data.Map{"id": data.String("a"), "value": data.Int(1)})), ShouldBeNil)
+			So(s.Write(ctx, newTuple(data.Map{"id": data.String("a"), "value": data.Int(2)})), ShouldBeNil)
+			So(s.Write(ctx, newTuple(data.Map{"id": data.String("b"), "value": data.Int(2)})), ShouldBeNil)
+
+			Convey("Then all of them should be sent", func() {
+				So(len(n.alerts), ShouldEqual, 3)
+				So(s.Status()["keys"], ShouldEqual, data.Int(2))
+			})
+		})
+
+		Convey("When writing a tuple without the key", func() {
+			err := s.Write(ctx, newTuple(data.Map{"value": data.Int(1)}))
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+				So(n.alerts, ShouldBeEmpty)
+			})
+		})
+
+		Convey("When the notifier fails", func() {
+			n.err = errors.New("failure")
+			So(s.Write(ctx, newTuple(data.Map{"id": data.String("a"), "value": data.Int(1)})), ShouldNotBeNil)
+
+			Convey("Then the alert should be sent again with the next tuple", func() {
+				n.err = nil
+				So(s.Write(ctx, newTuple(data.Map{"id": data.String("a"), "value": data.Int(1)})), ShouldBeNil)
+				So(len(n.alerts), ShouldEqual, 1)
+				So(s.Status()["errors"], ShouldEqual, data.Int(1))
+			})
+		})
+	})
+
+	Convey("Given an alert sink with rate limiting", t, func() {
+		s, n := newTestSink(ctx, data.Map{
+			"key":          data.String("id"),
+			"dedup_window": data.Int(0),
+			"rate_limit":   data.Int(2),
+			"rate_period":  data.Int(60),
+		})
+
+		Convey("When writing more alerts than the limit", func() {
+			for i := 0; i < 3; i++ {
+				So(s.Write(ctx, newTuple(data.Map{"id": data.String("a"), "value": data.Int(i)})), ShouldBeNil)
+				clock.Advance(10 * time.Second)
+			}
+			So(s.Write(ctx, newTuple(data.Map{"id": data.String("b"), "value": data.Int(0)})), ShouldBeNil)
+
+			Convey("Then alerts exceeding the limit should be dropped per key", func() {
+				So(len(n.alerts), ShouldEqual, 3)
+				So(n.alerts[2].key, ShouldEqual, "b")
+				So(s.Status()["rate_limited"], ShouldEqual, data.Int(1))
+			})
+
+			Convey("Then alerts should be sent again after the period", func() {
+				clock.Advance(30 * time.Second)
+				So(s.Write(ctx, newTuple(data.Map{"id": data.String("a"), "value": data.Int(3)})), ShouldBeNil)
+				So(len(n.alerts), ShouldEqual, 4)
+			})
+		})
+	})
+
+	Convey("Given an alert sink with resolutions", t, func() {
+		s, n := newTestSink(ctx, data.Map{
+			"key":      data.String("id"),
+			"resolved": data.String("ok"),
+		})
+
+		Convey("When writing a resolution of an alert which isn't firing", func() {
+			So(s.Write(ctx, newTuple(data.Map{"id": data.String("a"), "ok": data.True})), ShouldBeNil)
+
+			Convey("Then it shouldn't be sent", func() {
+				So(n.alerts, ShouldBeEmpty)
+			})
+		})
+
+		Convey("When writing an alert and its resolution", func() {
+			So(s.Write(ctx, newTuple(data.Map{"id": data.String("a"), "ok": data.False})), ShouldBeNil)
+			So(s.Write(ctx, newTuple(data.Map{"id": data.String("a"), "ok": data.True})), ShouldBeNil)
+			So(s.Write(ctx, newTuple(data.Map{"id": data.String("a"), "ok": data.True})), ShouldBeNil)
+
+			Convey("Then the resolution should be sent once", func() {
+				So(len(n.alerts), ShouldEqual, 2)
+				So(n.alerts[0].resolved, ShouldBeFalse)
+				So(n.alerts[1].resolved, ShouldBeTrue)
+				So(s.Status()["resolved"], ShouldEqual, data.Int(1))
+				So(s.Status()["firing"], ShouldEqual, data.Int(0))
+			})
+
+			Convey("Then the same alert should be sent again without deduplication", func() {
+				So(s.Write(ctx, newTuple(data.Map{"id": data.String("a"), "ok": data.False})), ShouldBeNil)
+				So(len(n.alerts), ShouldEqual, 3)
+			})
+		})
+	})
+
+	Convey("Given invalid parameters", t, func() {
+		cases := []data.Map{
+			{},
+			{"channel": data.String("sms")},
+			{"channel": data.String("webhook")},
+			{"channel": data.String("smtp"), "smtp_addr": data.String("localhost"),
+				"from": data.String("a@example.com"), "to": data.Array{data.String("b@example.com")}},
+			{"channel": data.String("pagerduty"), "routing_key": data.String("k"), "severity": data.String("fatal")},
+			{"channel": data.String("webhook"), "url": data.String("http://localhost/"), "rate_period": data.Int(0)},
+			{"channel": data.String("webhook"), "url": data.String("http://localhost/"), "subject": data.String("{{.a")},
+		}
+
+		Convey("When creating sinks", func() {
+			Convey("Then all of them should fail", func() {
+				for _, params := range cases {
+					_, err := createSink(ctx, &bql.IOParams{Name: "snk"}, params)
+					So(err, ShouldNotBeNil)
+				}
+			})
+		})
+	})
+}
+
+func TestNotifiers(t *testing.T) {
+	ctx := core.NewContext(nil)
+	ts := time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+	a := &alert{
+		key:       "dev1",
+		subject:   "too hot",
+		body:      "line1\nline2",
+		data:      data.Map{"temperature": data.Float(99.5)},
+		timestamp: ts,
+	}
+
+	Convey("Given an HTTP server receiving alerts", t, func() {
+		var reqs []map[string]interface{}
+		var headers []http.Header
+		status := http.StatusOK
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			b, _ := ioutil.ReadAll(r.Body)
+			m := map[string]interface{}{}
+			json.Unmarshal(b, &m)
+			reqs = append(reqs, m)
+			headers = append(headers, r.Header)
+			w.WriteHeader(status)
+		}))
+		Reset(server.Close)
+
+		Convey("When sending an alert by a webhook notifier", func() {
+			n, err := newWebhookNotifier(data.Map{
+				"url":     data.String(server.URL),
+				"headers": data.Map{"Authorization": data.String("Bearer token")},
+			}, time.Second)
+			So(err, ShouldBeNil)
+			So(n.notify(ctx, a), ShouldBeNil)
+
+			Convey("Then the server should receive it as JSON", func() {
+				So(len(reqs), ShouldEqual, 1)
+				So(headers[0].Get("Authorization"), ShouldEqual, "Bearer token")
+				So(reqs[0]["key"], ShouldEqual, "dev1")
+				So(reqs[0]["status"], ShouldEqual, "firing")
+				So(reqs[0]["subject"], ShouldEqual, "too hot")
+				So(reqs[0]["timestamp"], ShouldEqual, "2017-01-01T00:00:00Z")
+				So(reqs[0]["data"], ShouldResemble, map[string]interface{}{"temperature": 99.5})
+			})
+		})
+
+		Convey("When the server fails", func() {
+			status = http.StatusInternalServerError
+			n, err := newWebhookNotifier(data.Map{"url": data.String(server.URL)}, time.Second)
+			So(err, ShouldBeNil)
+
+			Convey("Then the notifier should fail", func() {
+				So(n.notify(ctx, a), ShouldNotBeNil)
+			})
+		})
+
+		Convey("When sending an alert and its resolution by a PagerDuty notifier", func() {
+			n, err := newPagerDutyNotifier(data.Map{
+				"routing_key":   data.String("rkey"),
+				"pagerduty_url": data.String(server.URL),
+				"severity":      data.String("critical"),
+			}, "snk", time.Second)
+			So(err, ShouldBeNil)
+			So(n.notify(ctx, a), ShouldBeNil)
+			r := *a
+			r.resolved = true
+			So(n.notify(ctx, &r), ShouldBeNil)
+
+			Convey("Then the server should receive events having the same dedup_key", func() {
+				So(len(reqs), ShouldEqual, 2)
+				So(reqs[0]["routing_key"], ShouldEqual, "rkey")
+				So(reqs[0]["event_action"], ShouldEqual, "trigger")
+				So(reqs[0]["dedup_key"], ShouldEqual, "dev1")
+				p := reqs[0]["payload"].(map[string]interface{})
+				So(p["summary"], ShouldEqual, "too hot")
+				So(p["source"], ShouldEqual, "snk")
+				So(p["severity"], ShouldEqual, "critical")
+				So(reqs[1]["event_action"], ShouldEqual, "resolve")
+				So(reqs[1]["dedup_key"], ShouldEqual, "dev1")
+			})
+		})
+	})
+
+	Convey("Given an SMTP notifier", t, func() {
+		n, err := newSMTPNotifier(data.Map{
+			"smtp_addr": data.String("localhost:25"),
+			"from":      data.String("sensorbee@example.com"),
+			"to":        data.Array{data.String("a@example.com"), data.String("b@example.com")},
+		})
+		So(err, ShouldBeNil)
+		var to []string
+		var msg string
+		n.(*smtpNotifier).send = func(addr string, auth smtp.Auth, from string, t []string, m []byte) error {
+			to = t
+			msg = string(m)
+			return nil
+		}
+
+		Convey("When sending a resolution", func() {
+			r := *a
+			r.resolved = true
+			So(n.notify(ctx, &r), ShouldBeNil)
+
+			Convey("Then it should be sent as an email", func() {
+				So(to, ShouldResemble, []string{"a@example.com", "b@example.com"})
+				So(msg, ShouldContainSubstring, "To: a@example.com, b@example.com\r\n")
+				So(msg, ShouldContainSubstring, "Subject: [RESOLVED] too hot\r\n")
+				So(strings.HasSuffix(msg, "\r\n\r\nline1\r\nline2"), ShouldBeTrue)
+			})
+		})
+	})
+
+	Convey("Given an SMTP server behind a SOCKS5 proxy", t, func() {
+		srv := startTestSMTPServer()
+		Reset(func() {
+			srv.l.Close()
+		})
+		proxy := startTestSOCKS5Proxy(srv.l.Addr().String())
+		Reset(func() {
+			proxy.Close()
+		})
+		n, err := newSMTPNotifier(data.Map{
+			"smtp_addr": data.String("mail.example.com:25"),
+			"from":      data.String("sensorbee@example.com"),
+			"to":        data.Array{data.String("a@example.com")},
+			"proxy":     data.String("socks5h://" + proxy.Addr().String()),
+		})
+		So(err, ShouldBeNil)
+
+		Convey("When sending an alert", func() {
+			So(n.notify(ctx, a), ShouldBeNil)
+
+			Convey("Then it should be delivered through the proxy", func() {
+				cmds := <-srv.cmds
+				So(cmds, ShouldContain, "MAIL FROM:<sensorbee@example.com>")
+				So(cmds, ShouldContain, "RCPT TO:<a@example.com>")
+				So(cmds[len(cmds)-1], ShouldEqual, "QUIT")
+			})
+		})
+	})
+
+	Convey("Given an SMTP notifier with an invalid outbound parameter", t, func() {
+		_, err := newSMTPNotifier(data.Map{
+			"smtp_addr":       data.String("localhost:25"),
+			"from":            data.String("sensorbee@example.com"),
+			"to":              data.Array{data.String("a@example.com")},
+			"tls_min_version": data.String("2.0"),
+		})
+
+		Convey("Then it should fail", func() {
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+type testSMTPServer struct {
+	l net.Listener
+
+	// cmds receives commands of each session.
+	cmds chan []string
+}
+
+// startTestSMTPServer starts an SMTP server which accepts all messages
+// without STARTTLS and AUTH.
+func startTestSMTPServer() *testSMTPServer {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	So(err, ShouldBeNil)
+	s := &testSMTPServer{
+		l:    l,
+		cmds: make(chan []string, 1),
+	}
+	go func() {
+		for {
+			c, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer c.Close()
+				tc := textproto.NewConn(c)
+				var cmds []string
+				defer func() {
+					s.cmds <- cmds
+				}()
+				tc.PrintfLine("220 localhost ESMTP")
+				for {
+					l, err := tc.ReadLine()
+					if err != nil {
+						return
+					}
+					cmds = append(cmds, l)
+					switch {
+					case strings.HasPrefix(l, "EHLO"):
+						tc.PrintfLine("250 localhost")
+					case l == "DATA":
+						tc.PrintfLine("354 go ahead")
+						if _, err := tc.ReadDotLines(); err != nil {
+							return
+						}
+						tc.PrintfLine("250 OK")
+					case l == "QUIT":
+						tc.PrintfLine("221 bye")
+						return
+					default:
+						tc.PrintfLine("250 OK")
+					}
+				}
+			}()
+		}
+	}()
+	return s
+}
+
+// startTestSOCKS5Proxy starts a SOCKS5 proxy without authentication which
+// connects all requests to target.
+func startTestSOCKS5Proxy(target string) net.Listener {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	So(err, ShouldBeNil)
+	go func() {
+		for {
+			c, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer c.Close()
+				b := make([]byte, 256)
+				io.ReadFull(c, b[:2])
+				io.ReadFull(c, b[:b[1]])
+				c.Write([]byte{0x05, 0x00})
+				io.ReadFull(c, b[:5])
+				io.ReadFull(c, b[:b[4]+2]) // the domain name and the port
+				dst, err := net.Dial("tcp", target)
+				if err != nil {
+					return
+				}
+				defer dst.Close()
+				c.Write([]byte{0x05, 0x00, 0x00, 0x01, 127, 0, 0, 1, 0, 0})
+				go io.Copy(dst, c)
+				io.Copy(c, dst)
+			}()
+		}
+	}()
+	return l
+}
@@ -0,0 +1,166 @@
+package coap
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync/atomic"
+
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// payloadFormat is the format of a payload sent by a device.
+type payloadFormat int
+
+const (
+	formatJSON payloadFormat = iota
+	formatCBOR
+)
+
+func parsePayloadFormat(s string) (payloadFormat, error) {
+	switch strings.ToLower(s) {
+	case "json":
+		return formatJSON, nil
+	case "cbor":
+		return formatCBOR, nil
+	default:
+		return 0, fmt.Errorf("unsupported payload format: %v", s)
+	}
+}
+
+func (f payloadFormat) String() string {
+	switch f {
+	case formatJSON:
+		return "json"
+	case formatCBOR:
+		return "cbor"
+	default:
+		return "unknown"
+	}
+}
+
+// resource is a path of the server to which devices send payloads. A
+// payload is converted into tuples with the field mapping of the resource.
+type resource struct {
+	path string
+
+	// fields are names of fields of a tuple and paths are JSON Paths of
+	// values in a payload written to the fields. A tuple has all fields of
+	// a payload as they are when fields is empty.
+	fields []string
+	paths  []data.Path
+
+	numTuples   int64
+	numRejected int64
+}
+
+// newResource creates a resource having the field mapping. A key of the
+// mapping is the name of a field and its value is the JSON Path of a value
+// in a payload such as "readings.temp".
+func newResource(path string, mapping map[string]string) (*resource, error) {
+	if !strings.HasPrefix(path, "/") {
+		return nil, fmt.Errorf("the path of a resource must start with '/': %v", path)
+	}
+	r := &resource{
+		path: path,
+	}
+	for f := range mapping {
+		r.fields = append(r.fields, f)
+	}
+	sort.Strings(r.fields)
+	for _, f := range r.fields {
+		p, err := data.CompilePath(mapping[f])
+		if err != nil {
+			return nil, fmt.Errorf("field '%v' of resource '%v' has an invalid path: %v", f, path, err)
+		}
+		r.paths = append(r.paths, p)
+	}
+	return r, nil
+}
+
+// toTuples converts a payload into tuples. A payload is an object or an
+// array of objects, each of which is converted into a tuple. Fields whose
+// paths don't exist in an object are omitted. When resourceField isn't empty,
+// the path of the resource is written to the field.
+func (r *resource) toTuples(f payloadFormat, b []byte, resourceField string) ([]*core.Tuple, error) {
+	v, err := decodePayload(f, b)
+	if err != nil {
+		return nil, err
+	}
+
+	var ms []data.Map
+	switch v.Type() {
+	case data.TypeMap:
+		m, _ := data.AsMap(v)
+		ms = append(ms, m)
+	case data.TypeArray:
+		a, _ := data.AsArray(v)
+		for i, e := range a {
+			m, err := data.AsMap(e)
+			if err != nil {
+				return nil, fmt.Errorf("element %v of the payload isn't an object: %v", i, e.Type())
+			}
+			ms = append(ms, m)
+		}
+	default:
+		return nil, fmt.Errorf("the payload must be an object or an array of objects: %v", v.Type())
+	}
+
+	ts := make([]*core.Tuple, len(ms))
+	for i, m := range ms {
+		if len(r.fields) > 0 {
+			m = r.mapFields(m)
+		}
+		if resourceField != "" {
+			m[resourceField] = data.String(r.path)
+		}
+		ts[i] = core.NewTuple(m)
+	}
+	return ts, nil
+}
+
+// mapFields creates a map having fields of the mapping.
+func (r *resource) mapFields(m data.Map) data.Map {
+	res := make(data.Map, len(r.fields))
+	for i, f := range r.fields {
+		v, err := m.Get(r.paths[i])
+		if err != nil {
+			continue
+		}
+		res[f] = v
+	}
+	return res
+}
+
+func decodePayload(f payloadFormat, b []byte) (data.Value, error) {
+	if len(b) == 0 {
+		return nil, errors.New("the payload is empty")
+	}
+	switch f {
+	case formatCBOR:
+		v, err := data.DecodeCBOR(b)
+		if err != nil {
+			return nil, fmt.Errorf("cannot decode the payload in CBOR: %v", err)
+		}
+		return v, nil
+	default:
+		var v interface{}
+		dec := json.NewDecoder(bytes.NewReader(b))
+		dec.UseNumber()
+		if err := dec.Decode(&v); err != nil {
+			return nil, fmt.Errorf("cannot decode the payload in JSON: %v", err)
+		}
+		return data.NewValue(v)
+	}
+}
+
+func (r *resource) status() data.Map {
+	return data.Map{
+		"num_tuples":   data.Int(atomic.LoadInt64(&r.numTuples)),
+		"num_rejected": data.Int(atomic.LoadInt64(&r.numRejected)),
+	}
+}
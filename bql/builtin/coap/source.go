@@ -0,0 +1,390 @@
+// Package coap provides a source running a CoAP (RFC 7252) server which
+// accepts observations sent from constrained IoT devices.
+//
+// The source isn't registered by default because it depends on a CoAP server
+// library. To use it, add the package to the plugins list of
+// build_sensorbee:
+//
+//	plugins:
+//	  - gopkg.in/sensorbee/sensorbee.v0/bql/builtin/coap
+//
+// Then, the source can be created as follows:
+//
+//	CREATE SOURCE devices TYPE coap WITH
+//	    address=":5683",
+//	    resources={
+//	        "/sensors/env": {"device": "id", "temperature": "readings.temp"},
+//	        "/sensors/raw": {}
+//	    },
+//	    resource_field="resource";
+//
+// Devices send observations to resources of the server with POST or PUT
+// requests. A payload is an object or an array of objects in JSON or CBOR,
+// which is chosen by the Content-Format option of a request. default_format
+// parameter is used when a request doesn't have the option. Each object is
+// converted into a tuple with the field mapping of the resource. A key of
+// the mapping is the name of a field and its value is the JSON Path of the
+// value written to the field. A tuple has all fields of an object as they are
+// when the mapping is empty. When resource_field parameter is given, the path
+// of the resource is written to the field.
+//
+// The server responds with 2.04 Changed when the payload is converted into
+// tuples, and 4.00 Bad Request or 4.15 Unsupported Content-Format when it
+// isn't. Requests to paths not in resources are responded with 4.04 Not
+// Found.
+//
+// The server uses DTLS when psk or certificate_file parameter is given. psk
+// is a map from PSK identities of devices to their keys. certificate_file and
+// private_key_file are paths to the certificate and the private key of the
+// server. When client_ca_file is also given, devices must present
+// certificates signed by the CA. psk and certificate_file cannot be given
+// together.
+package coap
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	piondtls "github.com/pion/dtls/v2"
+	coapdtls "github.com/plgd-dev/go-coap/v2/dtls"
+	"github.com/plgd-dev/go-coap/v2/message"
+	"github.com/plgd-dev/go-coap/v2/message/codes"
+	"github.com/plgd-dev/go-coap/v2/mux"
+	coapnet "github.com/plgd-dev/go-coap/v2/net"
+	"github.com/plgd-dev/go-coap/v2/udp"
+	"gopkg.in/sensorbee/sensorbee.v0/bql"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// sourceConfig has parameters of the source.
+type sourceConfig struct {
+	// Address is the UDP address on which the server listens. The default
+	// value is ":5683", or ":5684" when DTLS is used.
+	Address string
+
+	// Resources is a map from paths of resources to their field mappings.
+	Resources map[string]map[string]string `bql:",required"`
+
+	// DefaultFormat is the format of a payload of a request not having the
+	// Content-Format option. It's "json" or "cbor". The default value is
+	// "json".
+	DefaultFormat string
+
+	// ResourceField is the name of the field to which the path of the
+	// resource is written. The field isn't added when it's empty.
+	ResourceField string
+
+	// PSK is a map from PSK identities to keys.
+	PSK map[string]string
+
+	// CertificateFile and PrivateKeyFile are paths to the certificate and the
+	// private key of the server. ClientCAFile is the path to the certificate
+	// of the CA which signs certificates of devices.
+	CertificateFile string
+	PrivateKeyFile  string
+	ClientCAFile    string
+}
+
+func (c *sourceConfig) validate() error {
+	if len(c.Resources) == 0 {
+		return errors.New("'resources' parameter must have at least one resource")
+	}
+	if len(c.PSK) > 0 && c.CertificateFile != "" {
+		return errors.New("'psk' and 'certificate_file' parameters cannot be given together")
+	}
+	for id, k := range c.PSK {
+		if k == "" {
+			return fmt.Errorf("the key of PSK identity '%v' is empty", id)
+		}
+	}
+	if (c.CertificateFile == "") != (c.PrivateKeyFile == "") {
+		return errors.New("'certificate_file' and 'private_key_file' parameters must be given together")
+	}
+	if c.ClientCAFile != "" && c.CertificateFile == "" {
+		return errors.New("'client_ca_file' parameter requires 'certificate_file' parameter")
+	}
+	return nil
+}
+
+// useDTLS returns true when the server uses DTLS.
+func (c *sourceConfig) useDTLS() bool {
+	return len(c.PSK) > 0 || c.CertificateFile != ""
+}
+
+func (c *sourceConfig) dtlsConfig() (*piondtls.Config, error) {
+	cfg := &piondtls.Config{
+		ExtendedMasterSecret: piondtls.RequireExtendedMasterSecret,
+	}
+	if len(c.PSK) > 0 {
+		keys := make(map[string][]byte, len(c.PSK))
+		for id, k := range c.PSK {
+			keys[id] = []byte(k)
+		}
+		cfg.PSK = func(id []byte) ([]byte, error) {
+			k, ok := keys[string(id)]
+			if !ok {
+				return nil, fmt.Errorf("unknown PSK identity: %s", id)
+			}
+			return k, nil
+		}
+		cfg.CipherSuites = []piondtls.CipherSuiteID{
+			piondtls.TLS_PSK_WITH_AES_128_CCM_8,
+			piondtls.TLS_PSK_WITH_AES_128_GCM_SHA256,
+		}
+		return cfg, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(c.CertificateFile, c.PrivateKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load the certificate: %v", err)
+	}
+	cfg.Certificates = []tls.Certificate{cert}
+	if c.ClientCAFile != "" {
+		b, err := ioutil.ReadFile(c.ClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read the client CA file: %v", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(b) {
+			return nil, fmt.Errorf("the client CA file doesn't have a certificate: %v", c.ClientCAFile)
+		}
+		cfg.ClientCAs = pool
+		cfg.ClientAuth = piondtls.RequireAndVerifyClientCert
+	}
+	return cfg, nil
+}
+
+type source struct {
+	config    *sourceConfig
+	format    payloadFormat
+	resources []*resource
+	dtls      *piondtls.Config
+
+	// wm serializes writes because requests are handled concurrently.
+	wm sync.Mutex
+
+	m       sync.Mutex
+	stop    func()
+	stopped bool
+
+	numUnsupported int64
+}
+
+func createSource(ctx *core.Context, ioParams *bql.IOParams, params data.Map) (core.Source, error) {
+	c := &sourceConfig{
+		DefaultFormat: "json",
+	}
+	if err := data.NewDecoder(nil).Decode(params, c); err != nil {
+		return nil, err
+	}
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+	format, err := parsePayloadFormat(c.DefaultFormat)
+	if err != nil {
+		return nil, fmt.Errorf("'default_format' parameter has an invalid value: %v", err)
+	}
+	if c.Address == "" {
+		if c.useDTLS() {
+			c.Address = ":5684"
+		} else {
+			c.Address = ":5683"
+		}
+	}
+
+	s := &source{
+		config: c,
+		format: format,
+	}
+	var paths []string
+	for p := range c.Resources {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	for _, p := range paths {
+		r, err := newResource(p, c.Resources[p])
+		if err != nil {
+			return nil, err
+		}
+		s.resources = append(s.resources, r)
+	}
+	if c.useDTLS() {
+		if s.dtls, err = c.dtlsConfig(); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+func (s *source) GenerateStream(ctx *core.Context, w core.Writer) error {
+	s.m.Lock()
+	if s.stopped {
+		s.m.Unlock()
+		return nil
+	}
+	router := mux.NewRouter()
+	for _, r := range s.resources {
+		r := r
+		if err := router.Handle(r.path, mux.HandlerFunc(func(rw mux.ResponseWriter, req *mux.Message) {
+			s.handle(ctx, w, r, rw, req)
+		})); err != nil {
+			s.m.Unlock()
+			return fmt.Errorf("cannot add resource '%v': %v", r.path, err)
+		}
+	}
+	serve, stop, err := s.listen(router)
+	if err != nil {
+		s.m.Unlock()
+		return err
+	}
+	s.stop = stop
+	s.m.Unlock()
+
+	err = serve()
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.stopped {
+		return nil
+	}
+	return err
+}
+
+// listen starts listening on the address and returns functions serving
+// requests and stopping the server.
+func (s *source) listen(router *mux.Router) (func() error, func(), error) {
+	if s.dtls != nil {
+		l, err := coapnet.NewDTLSListener("udp", s.config.Address, s.dtls)
+		if err != nil {
+			return nil, nil, fmt.Errorf("cannot listen on %v: %v", s.config.Address, err)
+		}
+		srv := coapdtls.NewServer(coapdtls.WithMux(router))
+		serve := func() error {
+			return srv.Serve(l)
+		}
+		stop := func() {
+			srv.Stop()
+			l.Close()
+		}
+		return serve, stop, nil
+	}
+
+	l, err := coapnet.NewListenUDP("udp", s.config.Address)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot listen on %v: %v", s.config.Address, err)
+	}
+	srv := udp.NewServer(udp.WithMux(router))
+	serve := func() error {
+		return srv.Serve(l)
+	}
+	stop := func() {
+		srv.Stop()
+		l.Close()
+	}
+	return serve, stop, nil
+}
+
+// handle converts the payload of a request into tuples and writes them.
+func (s *source) handle(ctx *core.Context, w core.Writer, r *resource, rw mux.ResponseWriter, req *mux.Message) {
+	if req.Code != codes.POST && req.Code != codes.PUT {
+		respond(ctx, rw, codes.MethodNotAllowed, "only POST and PUT are allowed")
+		return
+	}
+
+	format := s.format
+	if cf, err := req.Options.ContentFormat(); err == nil {
+		switch cf {
+		case message.AppJSON:
+			format = formatJSON
+		case message.AppCBOR:
+			format = formatCBOR
+		default:
+			atomic.AddInt64(&s.numUnsupported, 1)
+			respond(ctx, rw, codes.UnsupportedMediaType, fmt.Sprintf("unsupported content format: %v", cf))
+			return
+		}
+	}
+
+	var b []byte
+	if req.Body != nil {
+		var err error
+		if b, err = ioutil.ReadAll(req.Body); err != nil {
+			atomic.AddInt64(&r.numRejected, 1)
+			respond(ctx, rw, codes.BadRequest, fmt.Sprintf("cannot read the payload: %v", err))
+			return
+		}
+	}
+	ts, err := r.toTuples(format, b, s.config.ResourceField)
+	if err != nil {
+		atomic.AddInt64(&r.numRejected, 1)
+		respond(ctx, rw, codes.BadRequest, err.Error())
+		return
+	}
+
+	s.wm.Lock()
+	defer s.wm.Unlock()
+	for _, t := range ts {
+		if err := w.Write(ctx, t); err != nil {
+			if err == core.ErrSourceStopped {
+				respond(ctx, rw, codes.ServiceUnavailable, "the source is stopped")
+				return
+			}
+			ctx.ErrLog(err).WithField("resource", r.path).Error("Cannot write a tuple")
+			respond(ctx, rw, codes.InternalServerError, "cannot write a tuple")
+			return
+		}
+		atomic.AddInt64(&r.numTuples, 1)
+	}
+	respond(ctx, rw, codes.Changed, "")
+}
+
+// respond sets the response of a request. msg is sent as a diagnostic
+// payload when it isn't empty.
+func respond(ctx *core.Context, rw mux.ResponseWriter, code codes.Code, msg string) {
+	var err error
+	if msg == "" {
+		err = rw.SetResponse(code, message.TextPlain, nil)
+	} else {
+		err = rw.SetResponse(code, message.TextPlain, bytes.NewReader([]byte(msg)))
+	}
+	if err != nil {
+		ctx.ErrLog(err).Error("Cannot set the response of a CoAP request")
+	}
+}
+
+// Status returns the status of the source. It has the address, numbers of
+// tuples and rejected requests of each resource, and the number of requests
+// having unsupported content formats.
+func (s *source) Status() data.Map {
+	rs := data.Map{}
+	for _, r := range s.resources {
+		rs[r.path] = r.status()
+	}
+	return data.Map{
+		"address":         data.String(s.config.Address),
+		"dtls":            data.Bool(s.dtls != nil),
+		"resources":       rs,
+		"num_unsupported": data.Int(atomic.LoadInt64(&s.numUnsupported)),
+	}
+}
+
+func (s *source) Stop(ctx *core.Context) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.stopped = true
+	if s.stop != nil {
+		s.stop()
+	}
+	return nil
+}
+
+func init() {
+	bql.MustRegisterGlobalSourceCreator("coap", bql.SourceCreatorFunc(createSource))
+}
@@ -0,0 +1,183 @@
+package coap
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/bql"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+func TestCreateSource(t *testing.T) {
+	Convey("Given parameters of a CoAP source", t, func() {
+		ctx := core.NewContext(nil)
+		params := data.Map{
+			"resources": data.Map{
+				"/sensors/raw": data.Map{},
+				"/sensors/env": data.Map{
+					"device":      data.String("id"),
+					"temperature": data.String("readings.temp"),
+				},
+			},
+		}
+
+		Convey("When creating a source with valid parameters", func() {
+			s, err := createSource(ctx, &bql.IOParams{}, params)
+			So(err, ShouldBeNil)
+
+			Convey("Then resources should be sorted by path", func() {
+				src := s.(*source)
+				So(src.resources, ShouldHaveLength, 2)
+				So(src.resources[0].path, ShouldEqual, "/sensors/env")
+				So(src.resources[0].fields, ShouldResemble, []string{"device", "temperature"})
+				So(src.resources[1].path, ShouldEqual, "/sensors/raw")
+				So(src.resources[1].fields, ShouldBeEmpty)
+			})
+
+			Convey("Then it should have default parameters", func() {
+				src := s.(*source)
+				So(src.config.Address, ShouldEqual, ":5683")
+				So(src.format, ShouldEqual, formatJSON)
+				So(src.dtls, ShouldBeNil)
+			})
+		})
+
+		Convey("When creating a source with PSKs", func() {
+			params["psk"] = data.Map{"device-1": data.String("secret")}
+			s, err := createSource(ctx, &bql.IOParams{}, params)
+			So(err, ShouldBeNil)
+
+			Convey("Then it should use DTLS", func() {
+				src := s.(*source)
+				So(src.config.Address, ShouldEqual, ":5684")
+				So(src.dtls, ShouldNotBeNil)
+				k, err := src.dtls.PSK([]byte("device-1"))
+				So(err, ShouldBeNil)
+				So(string(k), ShouldEqual, "secret")
+				_, err = src.dtls.PSK([]byte("device-2"))
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		for title, ps := range map[string]data.Map{
+			"no resource":                {},
+			"a path not starting with /": {"sensors": data.Map{}},
+			"an invalid field path":      {"/sensors": data.Map{"a": data.String("b[")}},
+		} {
+			ps := ps
+			Convey("When creating a source with "+title, func() {
+				params["resources"] = ps
+				_, err := createSource(ctx, &bql.IOParams{}, params)
+
+				Convey("Then it should fail", func() {
+					So(err, ShouldNotBeNil)
+				})
+			})
+		}
+
+		for title, ps := range map[string]data.Map{
+			"an invalid default format": {"default_format": data.String("xml")},
+			"psk and a certificate": {
+				"psk":              data.Map{"device-1": data.String("secret")},
+				"certificate_file": data.String("cert.pem"),
+				"private_key_file": data.String("key.pem"),
+			},
+			"an empty psk":                      {"psk": data.Map{"device-1": data.String("")}},
+			"a certificate without a key":       {"certificate_file": data.String("cert.pem")},
+			"a client CA without a certificate": {"client_ca_file": data.String("ca.pem")},
+			"a missing certificate file": {
+				"certificate_file": data.String("/no/such/cert.pem"),
+				"private_key_file": data.String("/no/such/key.pem"),
+			},
+		} {
+			ps := ps
+			Convey("When creating a source with "+title, func() {
+				for k, v := range ps {
+					params[k] = v
+				}
+				_, err := createSource(ctx, &bql.IOParams{}, params)
+
+				Convey("Then it should fail", func() {
+					So(err, ShouldNotBeNil)
+				})
+			})
+		}
+	})
+}
+
+func TestResource(t *testing.T) {
+	Convey("Given a resource having a field mapping", t, func() {
+		r, err := newResource("/sensors/env", map[string]string{
+			"device":      "id",
+			"temperature": "readings.temp",
+		})
+		So(err, ShouldBeNil)
+
+		Convey("When converting a JSON object", func() {
+			ts, err := r.toTuples(formatJSON, []byte(`{"id": "dev-1", "readings": {"temp": 21}, "x": 1}`), "resource")
+			So(err, ShouldBeNil)
+
+			Convey("Then the tuple should only have mapped fields and the resource", func() {
+				So(ts, ShouldHaveLength, 1)
+				So(ts[0].Data, ShouldResemble, data.Map{
+					"device":      data.String("dev-1"),
+					"temperature": data.Int(21),
+					"resource":    data.String("/sensors/env"),
+				})
+			})
+		})
+
+		Convey("When converting a CBOR array of objects", func() {
+			b, err := data.EncodeCBOR(data.Array{
+				data.Map{"id": data.String("dev-1"), "readings": data.Map{"temp": data.Float(21.5)}},
+				data.Map{"id": data.String("dev-2")},
+			})
+			So(err, ShouldBeNil)
+			ts, err := r.toTuples(formatCBOR, b, "")
+
+			Convey("Then each object should be converted into a tuple", func() {
+				So(ts, ShouldHaveLength, 2)
+				So(ts[0].Data, ShouldResemble, data.Map{
+					"device":      data.String("dev-1"),
+					"temperature": data.Float(21.5),
+				})
+				So(ts[1].Data, ShouldResemble, data.Map{"device": data.String("dev-2")})
+			})
+		})
+
+		for title, b := range map[string]string{
+			"an empty payload":                   ``,
+			"a broken payload":                   `{"id":`,
+			"a scalar payload":                   `1`,
+			"an array having a non-object value": `[{"id": "dev-1"}, 1]`,
+		} {
+			b := b
+			Convey("When converting "+title, func() {
+				_, err := r.toTuples(formatJSON, []byte(b), "")
+
+				Convey("Then it should fail", func() {
+					So(err, ShouldNotBeNil)
+				})
+			})
+		}
+	})
+
+	Convey("Given a resource without a field mapping", t, func() {
+		r, err := newResource("/sensors/raw", nil)
+		So(err, ShouldBeNil)
+
+		Convey("When converting a JSON object", func() {
+			ts, err := r.toTuples(formatJSON, []byte(`{"id": "dev-1", "temp": 21.5}`), "")
+			So(err, ShouldBeNil)
+
+			Convey("Then the tuple should have all fields of the object", func() {
+				So(ts, ShouldHaveLength, 1)
+				So(ts[0].Data, ShouldResemble, data.Map{
+					"id":   data.String("dev-1"),
+					"temp": data.Float(21.5),
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,388 @@
+// Package opcua provides a source subscribing to value changes of nodes in an
+// OPC UA server.
+//
+// The source isn't registered by default because it depends on an OPC UA
+// client library. To use it, add the package to the plugins list of
+// build_sensorbee:
+//
+//	plugins:
+//	  - gopkg.in/sensorbee/sensorbee.v0/bql/builtin/opcua
+//
+// Then, the source can be created as follows:
+//
+//	CREATE SOURCE plc TYPE opcua WITH
+//	    endpoint="opc.tcp://plc.example.com:4840",
+//	    nodes={"temperature": "ns=2;s=Line1.Temperature", "pressure": "ns=2;i=1024"},
+//	    sampling_interval=0.5;
+//
+// Each tuple emitted from the source has fields of nodes whose values changed.
+// Names of the fields are keys of the nodes parameter.
+//
+// When the connection to the server cannot be established or is lost, the
+// source reconnects to it with exponential backoff. Intervals of retries are
+// controlled by retry_interval, max_retry_interval, and max_retries
+// parameters. Errors which cannot be fixed by retrying, such as rejected
+// credentials or unknown nodes, stop the source immediately. The number of
+// attempts and the time of the next retry are shown in the status of the
+// source node.
+package opcua
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gopcua/opcua"
+	"github.com/gopcua/opcua/ua"
+	"gopkg.in/sensorbee/sensorbee.v0/bql"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// sourceConfig has parameters of the source.
+type sourceConfig struct {
+	// Endpoint is the URL of the server such as "opc.tcp://host:4840".
+	Endpoint string `bql:",required"`
+
+	// Nodes is a map from names of fields to IDs of nodes such as
+	// "ns=2;s=Temperature".
+	Nodes map[string]string `bql:",required"`
+
+	// SamplingInterval is the interval at which the server samples values
+	// of nodes. The default value is 1 second.
+	SamplingInterval time.Duration
+
+	// PublishingInterval is the interval at which the server sends changes
+	// of values. The default value is 1 second.
+	PublishingInterval time.Duration
+
+	// SecurityPolicy is the security policy of the secure channel such as
+	// "None", "Basic256", or "Basic256Sha256". The default value is "None".
+	SecurityPolicy string
+
+	// SecurityMode is the security mode of the secure channel. It's one of
+	// "None", "Sign", and "SignAndEncrypt". The default value is "None".
+	SecurityMode string
+
+	// CertificateFile and PrivateKeyFile are paths to the certificate and
+	// the private key of the client. They're required when the security
+	// policy isn't "None".
+	CertificateFile string
+	PrivateKeyFile  string
+
+	// Username and Password are used for authentication. The client is
+	// authenticated anonymously when Username is empty.
+	Username string
+	Password string
+
+	// TimestampField is the name of the field to which the timestamp of the
+	// tuple is written. The timestamp is the source timestamp of the first
+	// changed value. The field isn't added when it's empty.
+	TimestampField string
+
+	// RetryInterval is the interval before the first retry after the
+	// source fails to connect to the server or loses the connection. The
+	// interval is doubled after each failed attempt up to MaxRetryInterval.
+	// The default values are 1 second and 1 minute, respectively.
+	RetryInterval    time.Duration
+	MaxRetryInterval time.Duration
+
+	// MaxRetries is the maximum number of consecutive failed attempts to
+	// connect to the server. The source stops with an error when it's
+	// reached. There's no limit when it's 0, which is the default value.
+	MaxRetries int
+}
+
+func (c *sourceConfig) validate() error {
+	if len(c.Nodes) == 0 {
+		return errors.New("'nodes' parameter must have at least one node")
+	}
+	if c.SamplingInterval < 0 {
+		return errors.New("'sampling_interval' parameter must not be negative")
+	}
+	if c.PublishingInterval <= 0 {
+		return errors.New("'publishing_interval' parameter must be positive")
+	}
+	if c.RetryInterval <= 0 {
+		return errors.New("'retry_interval' parameter must be positive")
+	}
+	if c.MaxRetryInterval < c.RetryInterval {
+		return errors.New("'max_retry_interval' parameter must not be less than 'retry_interval'")
+	}
+	if c.MaxRetries < 0 {
+		return errors.New("'max_retries' parameter must not be negative")
+	}
+	switch c.SecurityMode {
+	case "None":
+		if c.SecurityPolicy != "None" {
+			return errors.New("'security_mode' must not be None when 'security_policy' isn't None")
+		}
+	case "Sign", "SignAndEncrypt":
+		if c.SecurityPolicy == "None" {
+			return errors.New("'security_policy' must not be None when 'security_mode' isn't None")
+		}
+		if c.CertificateFile == "" || c.PrivateKeyFile == "" {
+			return errors.New("'certificate_file' and 'private_key_file' parameters are required for a secure channel")
+		}
+	default:
+		return fmt.Errorf("'security_mode' parameter has an invalid value: %v", c.SecurityMode)
+	}
+	return nil
+}
+
+func (c *sourceConfig) clientOptions() []opcua.Option {
+	opts := []opcua.Option{
+		opcua.SecurityPolicy(c.SecurityPolicy),
+		opcua.SecurityModeString(c.SecurityMode),
+		// The source reconnects by itself so that retries follow the
+		// backoff of core.Reconnector and are shown in the status.
+		opcua.AutoReconnect(false),
+	}
+	if c.CertificateFile != "" {
+		opts = append(opts, opcua.CertificateFile(c.CertificateFile))
+	}
+	if c.PrivateKeyFile != "" {
+		opts = append(opts, opcua.PrivateKeyFile(c.PrivateKeyFile))
+	}
+	if c.Username != "" {
+		opts = append(opts, opcua.AuthUsername(c.Username, c.Password))
+	} else {
+		opts = append(opts, opcua.AuthAnonymous())
+	}
+	return opts
+}
+
+type source struct {
+	config *sourceConfig
+
+	// fields has names of fields. A client handle of a monitored item is the
+	// index of its field.
+	fields  []string
+	nodeIDs []*ua.NodeID
+
+	reconnector *core.Reconnector
+
+	m       sync.Mutex
+	cancel  context.CancelFunc
+	done    chan struct{}
+	stopped bool
+}
+
+func createSource(ctx *core.Context, ioParams *bql.IOParams, params data.Map) (core.Source, error) {
+	c := &sourceConfig{
+		SamplingInterval:   time.Second,
+		PublishingInterval: time.Second,
+		SecurityPolicy:     "None",
+		SecurityMode:       "None",
+		RetryInterval:      time.Second,
+		MaxRetryInterval:   time.Minute,
+	}
+	if err := data.NewDecoder(nil).Decode(params, c); err != nil {
+		return nil, err
+	}
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+
+	s := &source{
+		config: c,
+		reconnector: core.NewReconnector(&core.Backoff{
+			InitialInterval: c.RetryInterval,
+			MaxInterval:     c.MaxRetryInterval,
+			MaxAttempts:     c.MaxRetries,
+		}),
+	}
+	for f := range c.Nodes {
+		s.fields = append(s.fields, f)
+	}
+	sort.Strings(s.fields)
+	for _, f := range s.fields {
+		id, err := ua.ParseNodeID(c.Nodes[f])
+		if err != nil {
+			return nil, fmt.Errorf("node '%v' of field '%v' has an invalid ID: %v", c.Nodes[f], f, err)
+		}
+		s.nodeIDs = append(s.nodeIDs, id)
+	}
+	return s, nil
+}
+
+func (s *source) GenerateStream(ctx *core.Context, w core.Writer) error {
+	s.m.Lock()
+	if s.stopped {
+		s.m.Unlock()
+		return nil
+	}
+	cctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	s.done = make(chan struct{})
+	done := s.done
+	s.m.Unlock()
+	defer close(done)
+	defer cancel()
+
+	return s.reconnector.Run(ctx, func() error {
+		return s.subscribe(ctx, cctx, w)
+	})
+}
+
+// subscribe connects to the server and writes tuples converted from
+// notifications until cctx is canceled or the connection is lost. Errors are
+// classified by classifyError so that the reconnector can decide whether to
+// retry.
+func (s *source) subscribe(ctx *core.Context, cctx context.Context, w core.Writer) error {
+	c := opcua.NewClient(s.config.Endpoint, s.config.clientOptions()...)
+	if err := c.Connect(cctx); err != nil {
+		if cctx.Err() != nil {
+			return nil
+		}
+		return classifyError(fmt.Errorf("cannot connect to %v: %v", s.config.Endpoint, err), err)
+	}
+	defer c.Close()
+
+	notifyCh := make(chan *opcua.PublishNotificationData)
+	sub, err := c.Subscribe(&opcua.SubscriptionParameters{
+		Interval: s.config.PublishingInterval,
+	}, notifyCh)
+	if err != nil {
+		return classifyError(fmt.Errorf("cannot create a subscription: %v", err), err)
+	}
+	defer sub.Cancel()
+
+	reqs := make([]*ua.MonitoredItemCreateRequest, len(s.nodeIDs))
+	for i, id := range s.nodeIDs {
+		req := opcua.NewMonitoredItemCreateRequestWithDefaults(id, ua.AttributeIDValue, uint32(i))
+		req.RequestedParameters.SamplingInterval = float64(s.config.SamplingInterval) / float64(time.Millisecond)
+		reqs[i] = req
+	}
+	res, err := sub.Monitor(ua.TimestampsToReturnBoth, reqs...)
+	if err != nil {
+		return classifyError(fmt.Errorf("cannot monitor nodes: %v", err), err)
+	}
+	for i, r := range res.Results {
+		if r.StatusCode != ua.StatusOK {
+			return classifyError(fmt.Errorf("cannot monitor node '%v' of field '%v': %v",
+				s.config.Nodes[s.fields[i]], s.fields[i], r.StatusCode), r.StatusCode)
+		}
+	}
+	go sub.Run(cctx)
+	s.reconnector.Connected()
+
+	for {
+		select {
+		case <-cctx.Done():
+			return nil
+		case n := <-notifyCh:
+			if n.Error != nil {
+				if st := c.State(); st == opcua.Closed || st == opcua.Disconnected {
+					return core.TemporaryError(fmt.Errorf("lost the connection to %v: %v", s.config.Endpoint, n.Error))
+				}
+				ctx.ErrLog(n.Error).WithField("endpoint", s.config.Endpoint).
+					Error("Cannot receive a notification from the OPC UA server")
+				continue
+			}
+			dc, ok := n.Value.(*ua.DataChangeNotification)
+			if !ok {
+				continue
+			}
+			if t := s.toTuple(ctx, dc); t != nil {
+				if err := w.Write(ctx, t); err == core.ErrSourceStopped {
+					return nil
+				}
+			}
+		}
+	}
+}
+
+// classifyError decorates err with core.FatalError when the cause is a status
+// code which cannot be fixed by reconnecting, such as rejected credentials or
+// unknown nodes. Otherwise, err is decorated with core.TemporaryError.
+func classifyError(err, cause error) error {
+	var code ua.StatusCode
+	if errors.As(cause, &code) {
+		switch code {
+		case ua.StatusBadUserAccessDenied, ua.StatusBadIdentityTokenInvalid,
+			ua.StatusBadIdentityTokenRejected, ua.StatusBadSecurityPolicyRejected,
+			ua.StatusBadCertificateInvalid, ua.StatusBadNodeIDInvalid,
+			ua.StatusBadNodeIDUnknown, ua.StatusBadAttributeIDInvalid:
+			return core.FatalError(err)
+		}
+	}
+	return core.TemporaryError(err)
+}
+
+// toTuple converts a notification to a tuple. It returns nil when the
+// notification doesn't have any valid value.
+func (s *source) toTuple(ctx *core.Context, dc *ua.DataChangeNotification) *core.Tuple {
+	m := data.Map{}
+	var ts time.Time
+	for _, item := range dc.MonitoredItems {
+		if int(item.ClientHandle) >= len(s.fields) || item.Value == nil {
+			continue
+		}
+		field := s.fields[item.ClientHandle]
+		if item.Value.Status != ua.StatusOK {
+			ctx.Log().WithField("field", field).WithField("status", item.Value.Status.Error()).
+				Warning("The OPC UA server returned a value with a bad status")
+			continue
+		}
+		m[field] = toValue(item.Value.Value)
+		if ts.IsZero() {
+			ts = item.Value.SourceTimestamp
+		}
+	}
+	if len(m) == 0 {
+		return nil
+	}
+
+	t := core.NewTuple(m)
+	if !ts.IsZero() {
+		t.Timestamp = ts
+	}
+	if s.config.TimestampField != "" {
+		m[s.config.TimestampField] = data.Timestamp(t.Timestamp)
+	}
+	return t
+}
+
+// toValue converts a value of OPC UA to data.Value. Values which can't be
+// converted are converted to strings.
+func toValue(v *ua.Variant) data.Value {
+	if v == nil {
+		return data.Null{}
+	}
+	x := v.Value()
+	if x == nil {
+		return data.Null{}
+	}
+	if dv, err := data.NewValue(x); err == nil {
+		return dv
+	}
+	return data.String(fmt.Sprint(x))
+}
+
+// Reconnector returns the reconnector of the source so that the status of
+// retries is shown in the status of the source node.
+func (s *source) Reconnector() *core.Reconnector {
+	return s.reconnector
+}
+
+func (s *source) Stop(ctx *core.Context) error {
+	s.m.Lock()
+	s.stopped = true
+	cancel, done := s.cancel, s.done
+	s.m.Unlock()
+
+	s.reconnector.Stop()
+	if cancel != nil {
+		cancel()
+		<-done
+	}
+	return nil
+}
+
+func init() {
+	bql.MustRegisterGlobalSourceCreator("opcua", bql.SourceCreatorFunc(createSource))
+}
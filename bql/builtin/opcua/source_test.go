@@ -0,0 +1,118 @@
+package opcua
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gopcua/opcua/ua"
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/bql"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+func TestCreateSource(t *testing.T) {
+	Convey("Given parameters of an OPC UA source", t, func() {
+		ctx := core.NewContext(nil)
+		params := data.Map{
+			"endpoint": data.String("opc.tcp://localhost:4840"),
+			"nodes": data.Map{
+				"temperature": data.String("ns=2;s=Temperature"),
+				"pressure":    data.String("ns=2;i=1024"),
+			},
+		}
+
+		Convey("When creating a source with valid parameters", func() {
+			s, err := createSource(ctx, &bql.IOParams{}, params)
+			So(err, ShouldBeNil)
+
+			Convey("Then fields should be sorted by name", func() {
+				src := s.(*source)
+				So(src.fields, ShouldResemble, []string{"pressure", "temperature"})
+				So(src.nodeIDs, ShouldHaveLength, 2)
+			})
+		})
+
+		Convey("When creating a source without retry parameters", func() {
+			s, err := createSource(ctx, &bql.IOParams{}, params)
+			So(err, ShouldBeNil)
+
+			Convey("Then it should have default retry parameters", func() {
+				src := s.(*source)
+				So(src.config.RetryInterval, ShouldEqual, time.Second)
+				So(src.config.MaxRetryInterval, ShouldEqual, time.Minute)
+				So(src.config.MaxRetries, ShouldEqual, 0)
+				So(src.Reconnector(), ShouldNotBeNil)
+			})
+		})
+
+		Convey("When the maximum retry interval is less than the retry interval", func() {
+			params["retry_interval"] = data.Int(10)
+			params["max_retry_interval"] = data.Int(5)
+			_, err := createSource(ctx, &bql.IOParams{}, params)
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When the node ID is invalid", func() {
+			params["nodes"] = data.Map{"temperature": data.String("ns=x;q=1")}
+			_, err := createSource(ctx, &bql.IOParams{}, params)
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When a secure channel is requested without a certificate", func() {
+			params["security_policy"] = data.String("Basic256Sha256")
+			params["security_mode"] = data.String("SignAndEncrypt")
+			_, err := createSource(ctx, &bql.IOParams{}, params)
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When the security mode is invalid", func() {
+			params["security_mode"] = data.String("Encrypt")
+			_, err := createSource(ctx, &bql.IOParams{}, params)
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+func TestClassifyError(t *testing.T) {
+	Convey("Given errors returned from an OPC UA client", t, func() {
+		Convey("When the access is denied", func() {
+			err := classifyError(errors.New("cannot connect"), ua.StatusBadUserAccessDenied)
+
+			Convey("Then the error should be fatal", func() {
+				So(core.IsFatalError(err), ShouldBeTrue)
+			})
+		})
+
+		Convey("When the connection is refused", func() {
+			cause := errors.New("connection refused")
+			err := classifyError(errors.New("cannot connect"), cause)
+
+			Convey("Then the error should be temporary", func() {
+				So(core.IsFatalError(err), ShouldBeFalse)
+				So(core.IsTemporaryError(err), ShouldBeTrue)
+			})
+		})
+
+		Convey("When the server times out", func() {
+			err := classifyError(errors.New("cannot monitor nodes"), ua.StatusBadTimeout)
+
+			Convey("Then the error should be temporary", func() {
+				So(core.IsTemporaryError(err), ShouldBeTrue)
+			})
+		})
+	})
+}
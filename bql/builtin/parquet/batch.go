@@ -0,0 +1,222 @@
+package parquet
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// newColumn creates a column having the SensorBee type. Arrays and maps are
+// stored as JSON strings.
+func newColumn(name, typeName string) (*column, error) {
+	c := &column{
+		name:      name,
+		converted: noConvertedType,
+		typeName:  strings.ToLower(typeName),
+	}
+	switch c.typeName {
+	case "bool":
+		c.physical = ptBoolean
+	case "int":
+		c.physical = ptInt64
+	case "float":
+		c.physical = ptDouble
+	case "string":
+		c.physical = ptByteArray
+		c.converted = ctUTF8
+	case "blob":
+		c.physical = ptByteArray
+	case "timestamp":
+		c.physical = ptInt64
+		c.converted = ctTimestampMicro
+	case "array", "map":
+		c.physical = ptByteArray
+		c.converted = ctJSON
+	default:
+		return nil, fmt.Errorf("unsupported type of column '%v': %v", name, typeName)
+	}
+	return c, nil
+}
+
+// newColumns creates columns from a map of names of fields to names of types.
+// Columns are sorted by their names.
+func newColumns(types map[string]string) ([]*column, error) {
+	names := make([]string, 0, len(types))
+	for n := range types {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	cs := make([]*column, 0, len(names))
+	for _, n := range names {
+		c, err := newColumn(n, types[n])
+		if err != nil {
+			return nil, err
+		}
+		cs = append(cs, c)
+	}
+	return cs, nil
+}
+
+// inferColumns infers types of columns from sample tuples. A field having
+// values of a single type gets the type. A field having both integers and
+// floats becomes a float column and a field having values of other mixed
+// types, or only nulls, becomes a string column.
+func inferColumns(samples []data.Map) ([]*column, error) {
+	seen := map[string]map[data.TypeID]bool{}
+	for _, m := range samples {
+		for k, v := range m {
+			ts, ok := seen[k]
+			if !ok {
+				ts = map[data.TypeID]bool{}
+				seen[k] = ts
+			}
+			if v.Type() != data.TypeNull {
+				ts[v.Type()] = true
+			}
+		}
+	}
+
+	types := make(map[string]string, len(seen))
+	for k, ts := range seen {
+		switch {
+		case len(ts) == 1:
+			for t := range ts {
+				types[k] = typeName(t)
+			}
+		case len(ts) == 2 && ts[data.TypeInt] && ts[data.TypeFloat]:
+			types[k] = "float"
+		default:
+			types[k] = "string"
+		}
+	}
+	return newColumns(types)
+}
+
+func typeName(t data.TypeID) string {
+	switch t {
+	case data.TypeBool:
+		return "bool"
+	case data.TypeInt:
+		return "int"
+	case data.TypeFloat:
+		return "float"
+	case data.TypeBlob:
+		return "blob"
+	case data.TypeTimestamp:
+		return "timestamp"
+	case data.TypeArray:
+		return "array"
+	case data.TypeMap:
+		return "map"
+	default:
+		return "string"
+	}
+}
+
+// columnBuffer has values of a column. Like a column of a record batch of
+// Apache Arrow, it has the validity of each row and non-null values of
+// a single type.
+type columnBuffer struct {
+	column *column
+	valid  []bool
+
+	// Only one of the following slices is used depending on the physical
+	// type of the column.
+	bools  []bool
+	ints   []int64
+	floats []float64
+	bytes  [][]byte
+}
+
+// append appends a value to the column. A null value is appended when v is
+// nil, Null, or cannot be converted to the type of the column. It returns an
+// error in the last case.
+func (c *columnBuffer) append(v data.Value) error {
+	if v == nil || v.Type() == data.TypeNull {
+		c.valid = append(c.valid, false)
+		return nil
+	}
+
+	var err error
+	switch c.column.typeName {
+	case "bool":
+		var b bool
+		if b, err = data.ToBool(v); err == nil {
+			c.bools = append(c.bools, b)
+		}
+	case "int":
+		var i int64
+		if i, err = data.ToInt(v); err == nil {
+			c.ints = append(c.ints, i)
+		}
+	case "float":
+		var f float64
+		if f, err = data.ToFloat(v); err == nil {
+			c.floats = append(c.floats, f)
+		}
+	case "string":
+		var s string
+		if s, err = data.ToString(v); err == nil {
+			c.bytes = append(c.bytes, []byte(s))
+		}
+	case "blob":
+		var b []byte
+		if b, err = data.ToBlob(v); err == nil {
+			c.bytes = append(c.bytes, b)
+		}
+	case "timestamp":
+		var t time.Time
+		if t, err = data.ToTimestamp(v); err == nil {
+			c.ints = append(c.ints, t.UnixNano()/int64(time.Microsecond))
+		}
+	case "array", "map":
+		c.bytes = append(c.bytes, []byte(v.String()))
+	}
+	if err != nil {
+		c.valid = append(c.valid, false)
+		return fmt.Errorf("cannot convert the value of '%v' to %v: %v", c.column.name, c.column.typeName, err)
+	}
+	c.valid = append(c.valid, true)
+	return nil
+}
+
+// recordBatch buffers tuples column by column. It's written to a Parquet
+// file as a row group.
+type recordBatch struct {
+	columns []*columnBuffer
+	numRows int
+}
+
+func newRecordBatch(columns []*column) *recordBatch {
+	b := &recordBatch{}
+	for _, c := range columns {
+		b.columns = append(b.columns, &columnBuffer{column: c})
+	}
+	return b
+}
+
+// append appends a row to the batch. Fields not having a column are ignored.
+// It returns the number of values which couldn't be converted to the types
+// of their columns.
+func (b *recordBatch) append(m data.Map) int {
+	errs := 0
+	for _, c := range b.columns {
+		if err := c.append(m[c.column.name]); err != nil {
+			errs++
+		}
+	}
+	b.numRows++
+	return errs
+}
+
+// reset clears all rows in the batch.
+func (b *recordBatch) reset() {
+	for i, c := range b.columns {
+		b.columns[i] = &columnBuffer{column: c.column}
+	}
+	b.numRows = 0
+}
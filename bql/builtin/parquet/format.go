@@ -0,0 +1,392 @@
+package parquet
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+)
+
+// This file has a minimal writer of Parquet files. It only supports flat
+// schemas whose columns are all optional, PLAIN encoding, and data pages of
+// version 1. Metadata of a file is encoded with the Thrift compact protocol
+// as the specification of Parquet requires.
+
+const parquetMagic = "PAR1"
+
+// physicalType is a physical type of Parquet.
+type physicalType int32
+
+const (
+	ptBoolean   physicalType = 0
+	ptInt64     physicalType = 2
+	ptDouble    physicalType = 5
+	ptByteArray physicalType = 6
+)
+
+// convertedType is a converted type of Parquet, which annotates how
+// a physical type should be interpreted. noConvertedType means the column
+// doesn't have one.
+type convertedType int32
+
+const (
+	noConvertedType  convertedType = -1
+	ctUTF8           convertedType = 0
+	ctTimestampMicro convertedType = 10
+	ctJSON           convertedType = 19
+)
+
+// compressionCodec is a compression codec of Parquet.
+type compressionCodec int32
+
+const (
+	codecUncompressed compressionCodec = 0
+	codecGzip         compressionCodec = 2
+)
+
+const (
+	encodingPlain = 0
+	encodingRLE   = 3
+
+	repetitionOptional = 1
+	pageTypeData       = 0
+)
+
+// Types of the Thrift compact protocol.
+const (
+	tcBinary = 8
+	tcI32    = 5
+	tcI64    = 6
+	tcList   = 9
+	tcStruct = 12
+)
+
+// thriftWriter encodes Thrift structs with the compact protocol.
+type thriftWriter struct {
+	buf bytes.Buffer
+
+	// lastID is the ID of the last field written in the current struct.
+	// lastIDs has IDs of outer structs.
+	lastID  int16
+	lastIDs []int16
+}
+
+func (w *thriftWriter) uvarint(v uint64) {
+	var b [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(b[:], v)
+	w.buf.Write(b[:n])
+}
+
+func (w *thriftWriter) zigzag(v int64) {
+	w.uvarint(uint64((v << 1) ^ (v >> 63)))
+}
+
+func (w *thriftWriter) structBegin() {
+	w.lastIDs = append(w.lastIDs, w.lastID)
+	w.lastID = 0
+}
+
+func (w *thriftWriter) structEnd() {
+	w.buf.WriteByte(0) // stop field
+	w.lastID = w.lastIDs[len(w.lastIDs)-1]
+	w.lastIDs = w.lastIDs[:len(w.lastIDs)-1]
+}
+
+func (w *thriftWriter) fieldHeader(id int16, typ byte) {
+	if d := id - w.lastID; d > 0 && d <= 15 {
+		w.buf.WriteByte(byte(d)<<4 | typ)
+	} else {
+		w.buf.WriteByte(typ)
+		w.zigzag(int64(id))
+	}
+	w.lastID = id
+}
+
+func (w *thriftWriter) i32Field(id int16, v int32) {
+	w.fieldHeader(id, tcI32)
+	w.zigzag(int64(v))
+}
+
+func (w *thriftWriter) i64Field(id int16, v int64) {
+	w.fieldHeader(id, tcI64)
+	w.zigzag(v)
+}
+
+func (w *thriftWriter) stringField(id int16, s string) {
+	w.fieldHeader(id, tcBinary)
+	w.stringElem(s)
+}
+
+func (w *thriftWriter) structField(id int16) {
+	w.fieldHeader(id, tcStruct)
+	w.structBegin()
+}
+
+func (w *thriftWriter) listField(id int16, elemType byte, n int) {
+	w.fieldHeader(id, tcList)
+	if n < 15 {
+		w.buf.WriteByte(byte(n)<<4 | elemType)
+	} else {
+		w.buf.WriteByte(0xf0 | elemType)
+		w.uvarint(uint64(n))
+	}
+}
+
+func (w *thriftWriter) i32Elem(v int32) {
+	w.zigzag(int64(v))
+}
+
+func (w *thriftWriter) stringElem(s string) {
+	w.uvarint(uint64(len(s)))
+	w.buf.WriteString(s)
+}
+
+// column is a column of a Parquet file.
+type column struct {
+	name      string
+	physical  physicalType
+	converted convertedType
+
+	// typeName is the name of the SensorBee type of the column such as
+	// "int" or "string".
+	typeName string
+}
+
+// columnChunkMeta has the metadata of a column chunk written to a file.
+type columnChunkMeta struct {
+	numValues        int64
+	uncompressedSize int64
+	compressedSize   int64
+	dataPageOffset   int64
+}
+
+// rowGroupMeta has the metadata of a row group written to a file.
+type rowGroupMeta struct {
+	numRows int64
+	columns []columnChunkMeta
+}
+
+// fileWriter writes record batches to a Parquet file. Each batch is
+// written as a row group having a data page for each column.
+type fileWriter struct {
+	w       io.Writer
+	offset  int64
+	columns []*column
+	codec   compressionCodec
+
+	rowGroups []rowGroupMeta
+	numRows   int64
+	closed    bool
+}
+
+func newFileWriter(w io.Writer, columns []*column, codec compressionCodec) (*fileWriter, error) {
+	fw := &fileWriter{
+		w:       w,
+		columns: columns,
+		codec:   codec,
+	}
+	if err := fw.write([]byte(parquetMagic)); err != nil {
+		return nil, err
+	}
+	return fw, nil
+}
+
+func (fw *fileWriter) write(b []byte) error {
+	n, err := fw.w.Write(b)
+	fw.offset += int64(n)
+	return err
+}
+
+// writeRowGroup writes the batch as a row group. The batch must have the
+// same columns as the writer.
+func (fw *fileWriter) writeRowGroup(b *recordBatch) error {
+	if fw.closed {
+		return errors.New("the file is already closed")
+	}
+	if b.numRows == 0 {
+		return nil
+	}
+
+	rg := rowGroupMeta{
+		numRows: int64(b.numRows),
+	}
+	for _, c := range b.columns {
+		m, err := fw.writeColumnChunk(c, b.numRows)
+		if err != nil {
+			return err
+		}
+		rg.columns = append(rg.columns, m)
+	}
+	fw.rowGroups = append(fw.rowGroups, rg)
+	fw.numRows += rg.numRows
+	return nil
+}
+
+func (fw *fileWriter) writeColumnChunk(c *columnBuffer, numRows int) (columnChunkMeta, error) {
+	body := encodePage(c, numRows)
+	compressed := body
+	if fw.codec == codecGzip {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		if _, err := zw.Write(body); err != nil {
+			return columnChunkMeta{}, err
+		}
+		if err := zw.Close(); err != nil {
+			return columnChunkMeta{}, err
+		}
+		compressed = buf.Bytes()
+	}
+
+	// PageHeader
+	h := &thriftWriter{}
+	h.structBegin()
+	h.i32Field(1, pageTypeData)
+	h.i32Field(2, int32(len(body)))
+	h.i32Field(3, int32(len(compressed)))
+	h.structField(5) // DataPageHeader
+	h.i32Field(1, int32(numRows))
+	h.i32Field(2, encodingPlain)
+	h.i32Field(3, encodingRLE)
+	h.i32Field(4, encodingRLE)
+	h.structEnd()
+	h.structEnd()
+
+	m := columnChunkMeta{
+		numValues:        int64(numRows),
+		uncompressedSize: int64(h.buf.Len() + len(body)),
+		compressedSize:   int64(h.buf.Len() + len(compressed)),
+		dataPageOffset:   fw.offset,
+	}
+	if err := fw.write(h.buf.Bytes()); err != nil {
+		return columnChunkMeta{}, err
+	}
+	if err := fw.write(compressed); err != nil {
+		return columnChunkMeta{}, err
+	}
+	return m, nil
+}
+
+// encodePage encodes definition levels and non-null values of the column.
+// Repetition levels are omitted because the schema is flat.
+func encodePage(c *columnBuffer, numRows int) []byte {
+	var buf bytes.Buffer
+
+	// Definition levels are encoded by the RLE/bit-packing hybrid encoding
+	// with the bit width of 1, which has a single bit-packed run here.
+	levels := &thriftWriter{}
+	levels.uvarint(uint64((numRows+7)/8)<<1 | 1)
+	levels.buf.Write(packBools(c.valid))
+	binary.Write(&buf, binary.LittleEndian, uint32(levels.buf.Len()))
+	buf.Write(levels.buf.Bytes())
+
+	var b [8]byte
+	switch c.column.physical {
+	case ptBoolean:
+		buf.Write(packBools(c.bools))
+	case ptInt64:
+		for _, v := range c.ints {
+			binary.LittleEndian.PutUint64(b[:], uint64(v))
+			buf.Write(b[:])
+		}
+	case ptDouble:
+		for _, v := range c.floats {
+			binary.LittleEndian.PutUint64(b[:], math.Float64bits(v))
+			buf.Write(b[:])
+		}
+	case ptByteArray:
+		for _, v := range c.bytes {
+			binary.LittleEndian.PutUint32(b[:4], uint32(len(v)))
+			buf.Write(b[:4])
+			buf.Write(v)
+		}
+	}
+	return buf.Bytes()
+}
+
+// packBools packs bools into bits in the LSB-first order. The last byte is
+// padded with zeros.
+func packBools(bs []bool) []byte {
+	res := make([]byte, (len(bs)+7)/8)
+	for i, b := range bs {
+		if b {
+			res[i/8] |= 1 << uint(i%8)
+		}
+	}
+	return res
+}
+
+// close writes the metadata of the file. It doesn't close the underlying
+// writer.
+func (fw *fileWriter) close() error {
+	if fw.closed {
+		return nil
+	}
+	fw.closed = true
+
+	w := &thriftWriter{}
+	w.structBegin() // FileMetaData
+	w.i32Field(1, 1)
+
+	w.listField(2, tcStruct, len(fw.columns)+1)
+	w.structBegin() // the root SchemaElement
+	w.stringField(4, "schema")
+	w.i32Field(5, int32(len(fw.columns)))
+	w.structEnd()
+	for _, c := range fw.columns {
+		w.structBegin()
+		w.i32Field(1, int32(c.physical))
+		w.i32Field(3, repetitionOptional)
+		w.stringField(4, c.name)
+		if c.converted != noConvertedType {
+			w.i32Field(6, int32(c.converted))
+		}
+		w.structEnd()
+	}
+
+	w.i64Field(3, fw.numRows)
+
+	w.listField(4, tcStruct, len(fw.rowGroups))
+	for _, rg := range fw.rowGroups {
+		w.structBegin() // RowGroup
+		w.listField(1, tcStruct, len(rg.columns))
+		var total int64
+		for i, m := range rg.columns {
+			c := fw.columns[i]
+			total += m.uncompressedSize
+
+			w.structBegin() // ColumnChunk
+			w.i64Field(2, m.dataPageOffset)
+			w.structField(3) // ColumnMetaData
+			w.i32Field(1, int32(c.physical))
+			w.listField(2, tcI32, 2)
+			w.i32Elem(encodingPlain)
+			w.i32Elem(encodingRLE)
+			w.listField(3, tcBinary, 1)
+			w.stringElem(c.name)
+			w.i32Field(4, int32(fw.codec))
+			w.i64Field(5, m.numValues)
+			w.i64Field(6, m.uncompressedSize)
+			w.i64Field(7, m.compressedSize)
+			w.i64Field(9, m.dataPageOffset)
+			w.structEnd()
+			w.structEnd()
+		}
+		w.i64Field(2, total)
+		w.i64Field(3, rg.numRows)
+		w.structEnd()
+	}
+	w.stringField(6, "SensorBee")
+	w.structEnd()
+
+	if err := fw.write(w.buf.Bytes()); err != nil {
+		return err
+	}
+	var l [4]byte
+	binary.LittleEndian.PutUint32(l[:], uint32(w.buf.Len()))
+	if err := fw.write(l[:]); err != nil {
+		return err
+	}
+	return fw.write([]byte(parquetMagic))
+}
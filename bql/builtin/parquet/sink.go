@@ -0,0 +1,444 @@
+// Package parquet provides a sink writing tuples to Parquet files for offline
+// analytics.
+//
+// The sink isn't registered by default. To use it, add the package to the
+// plugins list of build_sensorbee:
+//
+//	plugins:
+//	  - gopkg.in/sensorbee/sensorbee.v0/bql/builtin/parquet
+//
+// Then, the sink can be created as follows:
+//
+//	CREATE SINK archive TYPE parquet WITH
+//	    path="/data/archive",
+//	    columns={"id": "int", "temperature": "float", "ts": "timestamp"},
+//	    partition_by="hour",
+//	    max_rows_per_file=1000000,
+//	    rotation_interval=600;
+//
+// Tuples are buffered column by column in record batches, which have the same
+// layout as record batches of Apache Arrow, and each batch is written as
+// a row group of a Parquet file. Each column of a file corresponds to
+// a top-level field of tuples and every column is optional. Fields not having
+// a column are ignored and values which cannot be converted to the type of
+// their column are written as nulls.
+//
+// The sink has the following parameters:
+//
+//	- path: the directory to which files are written (required)
+//	- columns: a map from names of fields to names of types, which are
+//	  "bool", "int", "float", "string", "blob", "timestamp", "array", and
+//	  "map". Arrays and maps are written as JSON strings
+//	- infer_samples: the number of tuples from which types of columns are
+//	  inferred when columns parameter isn't given (default: 100)
+//	- partition_by: "none", "day", or "hour" (default: "day"). Files are
+//	  written to a subdirectory like date=2006-01-02/hour=15 computed from
+//	  the timestamp of each tuple in UTC
+//	- batch_size: the number of rows in a row group (default: 10000)
+//	- max_rows_per_file: the maximum number of rows in a file (default:
+//	  1000000)
+//	- rotation_interval: the maximum duration for which a file is kept open
+//	  in seconds (default: 600). It's checked when a tuple is written
+//	- compression: "none" or "gzip" (default: "gzip")
+//	- file_prefix: the prefix of names of files (default: the name of the
+//	  sink)
+//
+// A file being written has a name starting with "." and is renamed when it's
+// completed so that readers don't see incomplete files. Tuples requesting
+// acknowledgment (see core.AckHandler) are acknowledged when the file
+// containing them is completed. Open files are also completed when the sink
+// is flushed (see core.Flusher), e.g. when a source is paused.
+package parquet
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/sensorbee/sensorbee.v0/bql"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// sinkConfig has parameters of the sink.
+type sinkConfig struct {
+	// Path is the directory to which files are written.
+	Path string `bql:",required"`
+
+	// Columns is a map from names of fields to names of types.
+	Columns map[string]string
+
+	// InferSamples is the number of tuples from which columns are inferred
+	// when Columns isn't given.
+	InferSamples int
+
+	// PartitionBy is "none", "day", or "hour".
+	PartitionBy string
+
+	// BatchSize is the number of rows in a row group.
+	BatchSize int
+
+	// MaxRowsPerFile is the maximum number of rows in a file.
+	MaxRowsPerFile int64
+
+	// RotationInterval is the maximum duration for which a file is kept open.
+	RotationInterval time.Duration
+
+	// Compression is "none" or "gzip".
+	Compression string
+
+	// FilePrefix is the prefix of names of files.
+	FilePrefix string
+}
+
+func (c *sinkConfig) validate() error {
+	if c.InferSamples <= 0 {
+		return fmt.Errorf("infer_samples must be positive: %v", c.InferSamples)
+	}
+	switch c.PartitionBy {
+	case "none", "day", "hour":
+	default:
+		return fmt.Errorf("partition_by must be one of none, day, and hour: %v", c.PartitionBy)
+	}
+	if c.BatchSize <= 0 {
+		return fmt.Errorf("batch_size must be positive: %v", c.BatchSize)
+	}
+	if c.MaxRowsPerFile <= 0 {
+		return fmt.Errorf("max_rows_per_file must be positive: %v", c.MaxRowsPerFile)
+	}
+	if c.RotationInterval <= 0 {
+		return fmt.Errorf("rotation_interval must be positive: %v", c.RotationInterval)
+	}
+	switch c.Compression {
+	case "none", "gzip":
+	default:
+		return fmt.Errorf("compression must be one of none and gzip: %v", c.Compression)
+	}
+	if c.FilePrefix == "" || strings.ContainsAny(c.FilePrefix, `/\`) {
+		return fmt.Errorf("file_prefix must be a non-empty name without path separators: %v", c.FilePrefix)
+	}
+	return nil
+}
+
+// partitionFile is a file being written for a partition.
+type partitionFile struct {
+	path    string
+	tmpPath string
+	f       *os.File
+	bw      *bufio.Writer
+	fw      *fileWriter
+	batch   *recordBatch
+	rows    int64
+	created time.Time
+
+	// acks has functions releasing acknowledgments of tuples in the file.
+	acks []func(*core.Context, error)
+}
+
+type sink struct {
+	m      sync.Mutex
+	config *sinkConfig
+	codec  compressionCodec
+
+	// columns is nil until they're inferred from samples.
+	columns []*column
+	samples []*core.Tuple
+
+	files map[string]*partitionFile
+	seq   int64
+
+	numRows          int64
+	numFiles         int64
+	conversionErrors int64
+	closed           bool
+}
+
+var (
+	_ core.Statuser = &sink{}
+	_ core.Flusher  = &sink{}
+)
+
+func (s *sink) Write(ctx *core.Context, t *core.Tuple) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.closed {
+		return errors.New("the sink is already closed")
+	}
+	if err := s.rotate(ctx, ctx.Clock().Now()); err != nil {
+		return err
+	}
+
+	if s.columns == nil {
+		// The tuple is kept after Write returns.
+		t.Flags.Set(core.TFShared)
+		s.samples = append(s.samples, t)
+		if len(s.samples) < s.config.InferSamples {
+			return nil
+		}
+		return s.flushSamples(ctx)
+	}
+	return s.append(ctx, t)
+}
+
+// flushSamples infers columns from samples and writes them.
+func (s *sink) flushSamples(ctx *core.Context) error {
+	ms := make([]data.Map, len(s.samples))
+	for i, t := range s.samples {
+		ms[i] = t.Data
+	}
+	cs, err := inferColumns(ms)
+	if err != nil {
+		return err
+	}
+	s.columns = cs
+
+	samples := s.samples
+	s.samples = nil
+	var firstErr error
+	for _, t := range samples {
+		if err := s.append(ctx, t); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+func (s *sink) partition(ts time.Time) string {
+	ts = ts.UTC()
+	switch s.config.PartitionBy {
+	case "day":
+		return "date=" + ts.Format("2006-01-02")
+	case "hour":
+		return filepath.Join("date="+ts.Format("2006-01-02"), "hour="+ts.Format("15"))
+	default:
+		return ""
+	}
+}
+
+func (s *sink) append(ctx *core.Context, t *core.Tuple) error {
+	key := s.partition(t.Timestamp)
+	pf, ok := s.files[key]
+	if !ok {
+		var err error
+		if pf, err = s.openFile(ctx, key); err != nil {
+			t.RetainAck()(ctx, err)
+			return err
+		}
+		s.files[key] = pf
+	}
+
+	s.conversionErrors += int64(pf.batch.append(t.Data))
+	pf.rows++
+	pf.acks = append(pf.acks, t.RetainAck())
+	s.numRows++
+
+	if pf.batch.numRows >= s.config.BatchSize {
+		if err := pf.fw.writeRowGroup(pf.batch); err != nil {
+			s.abort(ctx, key, err)
+			return err
+		}
+		pf.batch.reset()
+	}
+	if pf.rows >= s.config.MaxRowsPerFile {
+		return s.finish(ctx, key)
+	}
+	return nil
+}
+
+func (s *sink) openFile(ctx *core.Context, key string) (*partitionFile, error) {
+	dir := filepath.Join(s.config.Path, key)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	now := ctx.Clock().Now()
+	s.seq++
+	name := fmt.Sprintf("%v-%v-%v.parquet", s.config.FilePrefix, now.UTC().Format("20060102T150405"), s.seq)
+	pf := &partitionFile{
+		path:    filepath.Join(dir, name),
+		tmpPath: filepath.Join(dir, "."+name+".tmp"),
+		batch:   newRecordBatch(s.columns),
+		created: now,
+	}
+
+	f, err := os.Create(pf.tmpPath)
+	if err != nil {
+		return nil, err
+	}
+	pf.f = f
+	pf.bw = bufio.NewWriter(f)
+	if pf.fw, err = newFileWriter(pf.bw, s.columns, s.codec); err != nil {
+		f.Close()
+		os.Remove(pf.tmpPath)
+		return nil, err
+	}
+	return pf, nil
+}
+
+// finish writes buffered rows and the metadata of the file of the partition
+// and renames it to the final name.
+func (s *sink) finish(ctx *core.Context, key string) error {
+	pf := s.files[key]
+	delete(s.files, key)
+
+	err := func() error {
+		if err := pf.fw.writeRowGroup(pf.batch); err != nil {
+			return err
+		}
+		if err := pf.fw.close(); err != nil {
+			return err
+		}
+		if err := pf.bw.Flush(); err != nil {
+			return err
+		}
+		if err := pf.f.Close(); err != nil {
+			return err
+		}
+		return os.Rename(pf.tmpPath, pf.path)
+	}()
+	if err != nil {
+		pf.f.Close()
+		os.Remove(pf.tmpPath)
+		ctx.ErrLog(err).WithField("file", pf.path).Error("Cannot write a parquet file")
+	} else {
+		s.numFiles++
+	}
+	for _, ack := range pf.acks {
+		ack(ctx, err)
+	}
+	return err
+}
+
+// abort discards the file of the partition.
+func (s *sink) abort(ctx *core.Context, key string, err error) {
+	pf := s.files[key]
+	delete(s.files, key)
+	pf.f.Close()
+	os.Remove(pf.tmpPath)
+	ctx.ErrLog(err).WithField("file", pf.path).Error("Cannot write a parquet file")
+	for _, ack := range pf.acks {
+		ack(ctx, err)
+	}
+}
+
+// rotate finishes files which have been open longer than the rotation
+// interval.
+func (s *sink) rotate(ctx *core.Context, now time.Time) error {
+	var firstErr error
+	for _, key := range s.sortedKeys() {
+		if now.Sub(s.files[key].created) < s.config.RotationInterval {
+			continue
+		}
+		if err := s.finish(ctx, key); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+func (s *sink) sortedKeys() []string {
+	keys := make([]string, 0, len(s.files))
+	for k := range s.files {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (s *sink) Close(ctx *core.Context) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	return s.finishAll(ctx)
+}
+
+// Flush completes all open files so that tuples written so far are durable.
+// When columns haven't been inferred yet, they're inferred from tuples
+// received so far.
+func (s *sink) Flush(ctx *core.Context) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.closed {
+		return errors.New("the sink is already closed")
+	}
+	return s.finishAll(ctx)
+}
+
+func (s *sink) finishAll(ctx *core.Context) error {
+	var firstErr error
+	if s.columns == nil && len(s.samples) > 0 {
+		firstErr = s.flushSamples(ctx)
+	}
+	for _, key := range s.sortedKeys() {
+		if err := s.finish(ctx, key); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+func (s *sink) Status() data.Map {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return data.Map{
+		"rows":              data.Int(s.numRows),
+		"files":             data.Int(s.numFiles),
+		"open_files":        data.Int(len(s.files)),
+		"conversion_errors": data.Int(s.conversionErrors),
+	}
+}
+
+func createSink(ctx *core.Context, ioParams *bql.IOParams, params data.Map) (core.Sink, error) {
+	c := &sinkConfig{
+		InferSamples:     100,
+		PartitionBy:      "day",
+		BatchSize:        10000,
+		MaxRowsPerFile:   1000000,
+		RotationInterval: 10 * time.Minute,
+		Compression:      "gzip",
+		FilePrefix:       ioParams.Name,
+	}
+	if err := data.NewDecoder(nil).Decode(params, c); err != nil {
+		return nil, err
+	}
+	c.PartitionBy = strings.ToLower(c.PartitionBy)
+	c.Compression = strings.ToLower(c.Compression)
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+
+	s := &sink{
+		config: c,
+		codec:  codecUncompressed,
+		files:  map[string]*partitionFile{},
+	}
+	if c.Compression == "gzip" {
+		s.codec = codecGzip
+	}
+	if c.Columns != nil {
+		if len(c.Columns) == 0 {
+			return nil, errors.New("columns must have at least one column")
+		}
+		cs, err := newColumns(c.Columns)
+		if err != nil {
+			return nil, err
+		}
+		s.columns = cs
+	}
+	if err := os.MkdirAll(c.Path, 0755); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func init() {
+	bql.MustRegisterGlobalSinkCreator("parquet", bql.SinkCreatorFunc(createSink))
+}
@@ -0,0 +1,302 @@
+package parquet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/bql"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// thriftReader decodes Thrift structs encoded with the compact protocol.
+// Structs are decoded as maps from field IDs to values.
+type thriftReader struct {
+	r *bytes.Reader
+}
+
+func (r *thriftReader) zigzag() int64 {
+	v, err := binary.ReadUvarint(r.r)
+	if err != nil {
+		panic(err)
+	}
+	return int64(v>>1) ^ -int64(v&1)
+}
+
+func (r *thriftReader) value(typ byte) interface{} {
+	switch typ {
+	case 1:
+		return true
+	case 2:
+		return false
+	case tcI32, tcI64:
+		return r.zigzag()
+	case tcBinary:
+		n, err := binary.ReadUvarint(r.r)
+		if err != nil {
+			panic(err)
+		}
+		b := make([]byte, n)
+		r.r.Read(b)
+		return string(b)
+	case tcList:
+		h, _ := r.r.ReadByte()
+		n := int(h >> 4)
+		if n == 15 {
+			v, _ := binary.ReadUvarint(r.r)
+			n = int(v)
+		}
+		l := make([]interface{}, n)
+		for i := range l {
+			l[i] = r.value(h & 0x0f)
+		}
+		return l
+	case tcStruct:
+		return r.readStruct()
+	}
+	panic(errors.New("unsupported type"))
+}
+
+func (r *thriftReader) readStruct() map[int16]interface{} {
+	m := map[int16]interface{}{}
+	var id int16
+	for {
+		h, err := r.r.ReadByte()
+		if err != nil {
+			panic(err)
+		}
+		if h == 0 {
+			return m
+		}
+		if d := int16(h >> 4); d != 0 {
+			id += d
+		} else {
+			id = int16(r.zigzag())
+		}
+		m[id] = r.value(h & 0x0f)
+	}
+}
+
+// readParquetFile returns the metadata of the file and the page bodies of
+// columns of the first row group.
+func readParquetFile(path string) (map[int16]interface{}, [][]byte) {
+	b, err := ioutil.ReadFile(path)
+	So(err, ShouldBeNil)
+	So(string(b[:4]), ShouldEqual, parquetMagic)
+	So(string(b[len(b)-4:]), ShouldEqual, parquetMagic)
+	l := binary.LittleEndian.Uint32(b[len(b)-8:])
+	meta := (&thriftReader{bytes.NewReader(b[len(b)-8-int(l) : len(b)-8])}).readStruct()
+
+	var pages [][]byte
+	rg := meta[4].([]interface{})[0].(map[int16]interface{})
+	for _, c := range rg[1].([]interface{}) {
+		cm := c.(map[int16]interface{})[3].(map[int16]interface{})
+		r := bytes.NewReader(b[cm[9].(int64):])
+		h := (&thriftReader{r}).readStruct()
+		body := make([]byte, h[3].(int64))
+		r.Read(body)
+		pages = append(pages, body)
+	}
+	return meta, pages
+}
+
+func TestCreateSink(t *testing.T) {
+	Convey("Given parameters of a parquet sink", t, func() {
+		ctx := core.NewContext(nil)
+		dir, err := ioutil.TempDir("", "sbtest_parquet")
+		So(err, ShouldBeNil)
+		Reset(func() {
+			os.RemoveAll(dir)
+		})
+		params := data.Map{
+			"path": data.String(dir),
+		}
+		ioParams := &bql.IOParams{Name: "snk"}
+
+		Convey("When creating a sink with valid parameters", func() {
+			params["columns"] = data.Map{"b": data.String("int"), "a": data.String("String")}
+			s, err := createSink(ctx, ioParams, params)
+			So(err, ShouldBeNil)
+
+			Convey("Then it should have default values and sorted columns", func() {
+				si := s.(*sink)
+				So(si.config.PartitionBy, ShouldEqual, "day")
+				So(si.config.FilePrefix, ShouldEqual, "snk")
+				So(si.codec, ShouldEqual, codecGzip)
+				So(si.columns, ShouldHaveLength, 2)
+				So(si.columns[0].name, ShouldEqual, "a")
+				So(si.columns[0].converted, ShouldEqual, ctUTF8)
+				So(si.columns[1].physical, ShouldEqual, ptInt64)
+			})
+		})
+
+		Convey("When parameters are invalid", func() {
+			for k, v := range map[string]data.Value{
+				"columns":           data.Map{"a": data.String("integer")},
+				"partition_by":      data.String("week"),
+				"compression":       data.String("snappy"),
+				"batch_size":        data.Int(0),
+				"max_rows_per_file": data.Int(-1),
+				"rotation_interval": data.Int(0),
+				"infer_samples":     data.Int(0),
+				"file_prefix":       data.String("a/b"),
+			} {
+				params[k] = v
+				_, err := createSink(ctx, ioParams, params)
+				delete(params, k)
+
+				Convey("Then it should fail: "+k, func() {
+					So(err, ShouldNotBeNil)
+				})
+			}
+		})
+	})
+}
+
+func TestInferColumns(t *testing.T) {
+	Convey("Given sample tuples", t, func() {
+		samples := []data.Map{
+			{"i": data.Int(1), "f": data.Int(1), "m": data.String("a"), "n": data.Null{}},
+			{"i": data.Int(2), "f": data.Float(1.5), "m": data.Int(1), "ts": data.Timestamp(time.Now())},
+		}
+
+		Convey("When inferring columns", func() {
+			cs, err := inferColumns(samples)
+			So(err, ShouldBeNil)
+
+			Convey("Then they should have types of values", func() {
+				types := map[string]string{}
+				for _, c := range cs {
+					types[c.name] = c.typeName
+				}
+				So(types, ShouldResemble, map[string]string{
+					"f":  "float",
+					"i":  "int",
+					"m":  "string",
+					"n":  "string",
+					"ts": "timestamp",
+				})
+			})
+		})
+	})
+}
+
+func TestSink(t *testing.T) {
+	Convey("Given a parquet sink", t, func() {
+		ctx := core.NewContext(nil)
+		dir, err := ioutil.TempDir("", "sbtest_parquet")
+		So(err, ShouldBeNil)
+		Reset(func() {
+			os.RemoveAll(dir)
+		})
+		params := data.Map{
+			"path":         data.String(dir),
+			"partition_by": data.String("hour"),
+			"compression":  data.String("none"),
+			"batch_size":   data.Int(2),
+		}
+		ts := time.Date(2016, 1, 2, 3, 4, 5, 0, time.UTC)
+		tuple := func(i int64) *core.Tuple {
+			t := core.NewTuple(data.Map{"i": data.Int(i), "s": data.String("x"), "x": data.True})
+			t.Timestamp = ts
+			return t
+		}
+
+		Convey("When writing tuples with declared columns", func() {
+			params["columns"] = data.Map{"i": data.String("int"), "s": data.String("int")}
+			s, err := createSink(ctx, &bql.IOParams{Name: "snk"}, params)
+			So(err, ShouldBeNil)
+			for i := int64(0); i < 3; i++ {
+				So(s.Write(ctx, tuple(i)), ShouldBeNil)
+			}
+			So(s.Close(ctx), ShouldBeNil)
+
+			Convey("Then a file should be written to the partition", func() {
+				files, err := filepath.Glob(filepath.Join(dir, "date=2016-01-02", "hour=03", "*"))
+				So(err, ShouldBeNil)
+				So(files, ShouldHaveLength, 1)
+				So(filepath.Base(files[0]), ShouldStartWith, "snk-")
+
+				meta, pages := readParquetFile(files[0])
+				So(meta[3], ShouldEqual, int64(3))
+				So(meta[4], ShouldHaveLength, 2) // row groups
+				schema := meta[2].([]interface{})
+				So(schema, ShouldHaveLength, 3)
+				So(schema[1].(map[int16]interface{})[4], ShouldEqual, "i")
+
+				Convey("And the first row group should have values", func() {
+					// 4 bytes of length, 2 bytes of RLE, and 2 int64 values
+					So(pages[0], ShouldHaveLength, 4+2+16)
+					So(binary.LittleEndian.Uint64(pages[0][14:]), ShouldEqual, 1)
+					// values of "s" can't be converted to int
+					So(pages[1], ShouldHaveLength, 4+2)
+					So(pages[1][5], ShouldEqual, 0)
+				})
+			})
+
+			Convey("Then the status should have counters", func() {
+				st := s.(core.Statuser).Status()
+				So(st["rows"], ShouldEqual, data.Int(3))
+				So(st["files"], ShouldEqual, data.Int(1))
+				So(st["conversion_errors"], ShouldEqual, data.Int(3))
+			})
+		})
+
+		Convey("When writing tuples without columns", func() {
+			params["max_rows_per_file"] = data.Int(2)
+			s, err := createSink(ctx, &bql.IOParams{Name: "snk"}, params)
+			So(err, ShouldBeNil)
+			for i := int64(0); i < 3; i++ {
+				So(s.Write(ctx, tuple(i)), ShouldBeNil)
+			}
+
+			Convey("Then nothing should be written until columns are inferred", func() {
+				files, err := filepath.Glob(filepath.Join(dir, "*", "*", "*"))
+				So(err, ShouldBeNil)
+				So(files, ShouldBeEmpty)
+			})
+
+			Convey("Then files should be rotated after closing the sink", func() {
+				So(s.Close(ctx), ShouldBeNil)
+				files, err := filepath.Glob(filepath.Join(dir, "*", "*", "*.parquet"))
+				So(err, ShouldBeNil)
+				So(files, ShouldHaveLength, 2)
+
+				meta, _ := readParquetFile(files[0])
+				So(meta[2], ShouldHaveLength, 4)
+			})
+		})
+
+		Convey("When flushing the sink", func() {
+			s, err := createSink(ctx, &bql.IOParams{Name: "snk"}, params)
+			So(err, ShouldBeNil)
+			Reset(func() {
+				s.Close(ctx)
+			})
+			So(s.Write(ctx, tuple(0)), ShouldBeNil)
+			So(s.(core.Flusher).Flush(ctx), ShouldBeNil)
+
+			Convey("Then the open file should be completed", func() {
+				files, err := filepath.Glob(filepath.Join(dir, "*", "*", "*.parquet"))
+				So(err, ShouldBeNil)
+				So(files, ShouldHaveLength, 1)
+				So(s.(core.Statuser).Status()["files"], ShouldEqual, data.Int(1))
+			})
+
+			Convey("Then tuples written after that should go to a new file", func() {
+				So(s.Write(ctx, tuple(1)), ShouldBeNil)
+				So(s.Close(ctx), ShouldBeNil)
+				files, err := filepath.Glob(filepath.Join(dir, "*", "*", "*.parquet"))
+				So(err, ShouldBeNil)
+				So(files, ShouldHaveLength, 2)
+			})
+		})
+	})
+}
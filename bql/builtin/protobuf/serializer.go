@@ -0,0 +1,87 @@
+// Package protobuf provides a serializer writing tuples as Protocol Buffers
+// messages.
+//
+// The serializer isn't registered by default because it depends on the
+// Protocol Buffers library. To use it, add the package to the plugins list of
+// build_sensorbee:
+//
+//	plugins:
+//	  - gopkg.in/sensorbee/sensorbee.v0/bql/builtin/protobuf
+//
+// Then, it can be selected by "format" parameter of sinks:
+//
+//	CREATE SINK out TYPE file WITH path="out.pb", format="protobuf";
+//
+// Each tuple is encoded as a google.protobuf.Struct message prefixed by its
+// length in a varint, which is the format read by parseDelimitedFrom of
+// Protocol Buffers libraries. Timestamps are encoded as strings in RFC 3339
+// and blobs are encoded as strings in base64 as in the JSON mapping of
+// Protocol Buffers.
+package protobuf
+
+import (
+	"bytes"
+
+	"google.golang.org/protobuf/encoding/protodelim"
+	"google.golang.org/protobuf/types/known/structpb"
+	"gopkg.in/sensorbee/sensorbee.v0/bql"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+func serialize(m data.Map) ([]byte, error) {
+	b := bytes.NewBuffer(nil)
+	if _, err := protodelim.MarshalTo(b, toStruct(m)); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+func toStruct(m data.Map) *structpb.Struct {
+	s := &structpb.Struct{
+		Fields: make(map[string]*structpb.Value, len(m)),
+	}
+	for k, v := range m {
+		s.Fields[k] = toValue(v)
+	}
+	return s
+}
+
+func toValue(v data.Value) *structpb.Value {
+	switch v.Type() {
+	case data.TypeNull:
+		return structpb.NewNullValue()
+	case data.TypeBool:
+		b, _ := data.AsBool(v)
+		return structpb.NewBoolValue(b)
+	case data.TypeInt:
+		i, _ := data.AsInt(v)
+		return structpb.NewNumberValue(float64(i))
+	case data.TypeFloat:
+		f, _ := data.AsFloat(v)
+		return structpb.NewNumberValue(f)
+	case data.TypeArray:
+		a, _ := data.AsArray(v)
+		l := &structpb.ListValue{
+			Values: make([]*structpb.Value, len(a)),
+		}
+		for i, e := range a {
+			l.Values[i] = toValue(e)
+		}
+		return structpb.NewListValue(l)
+	case data.TypeMap:
+		m, _ := data.AsMap(v)
+		return structpb.NewStructValue(toStruct(m))
+	default:
+		// strings, blobs, and timestamps
+		s, _ := data.ToString(v)
+		return structpb.NewStringValue(s)
+	}
+}
+
+func init() {
+	bql.MustRegisterGlobalSerializerCreator("protobuf", bql.SerializerCreatorFunc(
+		func(ctx *core.Context, params data.Map) (bql.Serializer, error) {
+			return bql.SerializerFunc(serialize), nil
+		}))
+}
@@ -0,0 +1,53 @@
+package protobuf
+
+import (
+	"bufio"
+	"bytes"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"google.golang.org/protobuf/encoding/protodelim"
+	"google.golang.org/protobuf/types/known/structpb"
+	"gopkg.in/sensorbee/sensorbee.v0/bql"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+func TestSerializer(t *testing.T) {
+	Convey("Given a protobuf serializer", t, func() {
+		f, err := bql.NewSerializerFactory(core.NewContext(nil), data.Map{"format": data.String("protobuf")})
+		So(err, ShouldBeNil)
+		s, err := f()
+		So(err, ShouldBeNil)
+
+		Convey("When serializing tuples", func() {
+			ts := time.Date(2017, 1, 2, 3, 4, 5, 0, time.UTC)
+			b := bytes.NewBuffer(nil)
+			for _, m := range []data.Map{
+				{"i": data.Int(1), "s": data.String("a"), "ts": data.Timestamp(ts), "n": data.Null{}},
+				{"a": data.Array{data.True, data.Float(1.5)}, "m": data.Map{"x": data.Blob("b")}},
+			} {
+				r, err := s.Serialize(m)
+				So(err, ShouldBeNil)
+				b.Write(r)
+			}
+
+			Convey("Then they should be decoded as delimited Struct messages", func() {
+				r := bufio.NewReader(b)
+				st := &structpb.Struct{}
+				So(protodelim.UnmarshalFrom(r, st), ShouldBeNil)
+				So(st.AsMap(), ShouldResemble, map[string]interface{}{
+					"i": 1.0, "s": "a", "ts": "2017-01-02T03:04:05Z", "n": nil,
+				})
+
+				st = &structpb.Struct{}
+				So(protodelim.UnmarshalFrom(r, st), ShouldBeNil)
+				So(st.AsMap(), ShouldResemble, map[string]interface{}{
+					"a": []interface{}{true, 1.5},
+					"m": map[string]interface{}{"x": "Yg=="},
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,222 @@
+package bql
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// generatorSource emits synthetic tuples for tests and demos. At each step,
+// it emits one tuple for each node ID in [nodeIDFrom, nodeIDTo]. A tuple has
+// "node_id" and "step" fields in addition to fields generated by
+// generatorFields.
+type generatorSource struct {
+	nodeIDFrom int64
+	nodeIDTo   int64
+	numSteps   int64
+	interval   time.Duration
+	seed       int64
+	fields     map[string]*generatorFieldSpec
+	stopCh     chan struct{}
+}
+
+// generatorFieldSpec has parameters of a generated field. Which parameters
+// are used depends on Type:
+//
+//	- sequence: Start + Step * step
+//	- uniform: a random value in [Min, Max)
+//	- random_walk: starts from Start and moves by a random value in
+//	  [-Step, Step] at each step. It's clamped to [Min, Max] when Min < Max.
+//	- sinusoid: Offset + Amplitude * sin(2π * step / Period + Phase)
+type generatorFieldSpec struct {
+	Type      string `bql:",required"`
+	Start     float64
+	Step      float64
+	Min       float64
+	Max       float64
+	Amplitude float64
+	Period    float64
+	Phase     float64
+	Offset    float64
+}
+
+// generatorFieldState keeps the state of a field for a node.
+type generatorFieldState struct {
+	spec  *generatorFieldSpec
+	value float64
+}
+
+func (f *generatorFieldState) next(step int64, r *rand.Rand) data.Value {
+	s := f.spec
+	switch s.Type {
+	case "sequence":
+		return data.Float(s.Start + s.Step*float64(step))
+	case "uniform":
+		return data.Float(s.Min + (s.Max-s.Min)*r.Float64())
+	case "random_walk":
+		if step > 0 {
+			f.value += s.Step * (2*r.Float64() - 1)
+		}
+		if s.Min < s.Max {
+			f.value = math.Max(s.Min, math.Min(s.Max, f.value))
+		}
+		return data.Float(f.value)
+	case "sinusoid":
+		return data.Float(s.Offset + s.Amplitude*math.Sin(2*math.Pi*float64(step)/s.Period+s.Phase))
+	}
+	return data.Null{} // never happens
+}
+
+func (s *generatorSource) GenerateStream(ctx *core.Context, w core.Writer) error {
+	// fields are generated in the order of their names so that the same seed
+	// always generates the same stream
+	names := make([]string, 0, len(s.fields))
+	for name := range s.fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	r := rand.New(rand.NewSource(s.seed))
+	numNodes := s.nodeIDTo - s.nodeIDFrom + 1
+	states := make([][]*generatorFieldState, numNodes)
+	for i := range states {
+		states[i] = make([]*generatorFieldState, len(names))
+		for j, name := range names {
+			spec := s.fields[name]
+			states[i][j] = &generatorFieldState{
+				spec:  spec,
+				value: spec.Start,
+			}
+		}
+	}
+
+	next := ctx.Clock().Now()
+	for step := int64(0); s.numSteps < 0 || step < s.numSteps; step++ {
+		now := ctx.Clock().Now()
+		ts := now
+		if s.interval > 0 {
+			ts = next
+		}
+		for i := int64(0); i < numNodes; i++ {
+			m := data.Map{
+				"node_id": data.Int(s.nodeIDFrom + i),
+				"step":    data.Int(step),
+			}
+			for j, name := range names {
+				m[name] = states[i][j].next(step, r)
+			}
+			t := &core.Tuple{
+				Data:          m,
+				Timestamp:     ts,
+				ProcTimestamp: now,
+			}
+			if err := w.Write(ctx, t); err != nil {
+				return err
+			}
+		}
+
+		if s.interval > 0 {
+			now := ctx.Clock().Now()
+			next = next.Add(s.interval)
+			if next.Before(now) {
+				// delayed too much and should be rescheduled.
+				next = now.Add(s.interval)
+			}
+
+			select {
+			case <-s.stopCh:
+				return core.ErrSourceStopped
+			case <-ctx.Clock().After(next.Sub(now)):
+			}
+		}
+	}
+	return nil
+}
+
+func (s *generatorSource) Stop(ctx *core.Context) error {
+	close(s.stopCh)
+	return nil
+}
+
+// createGeneratorSource creates a source generating synthetic tuples:
+//
+//	CREATE SOURCE g TYPE generator WITH node_id_to=9, rate=10,
+//	    fields={"temp": {"type": "random_walk", "start": 20, "step": 0.5}};
+//
+// It accepts following parameters:
+//
+//	- node_id_from, node_id_to: the range of node IDs (inclusive, default: 0)
+//	- interval: the interval between steps (default: as fast as possible)
+//	- rate: the number of steps per second, exclusive with interval
+//	- num_steps: the number of steps, negative means infinite (default: -1)
+//	- seed: the seed of the random number generator (default: derived from
+//	  the seed of the topology and the name of the source)
+//	- fields: a map from a field name to its parameters, see
+//	  generatorFieldSpec for details
+func createGeneratorSource(ctx *core.Context, ioParams *IOParams, params data.Map) (core.Source, error) {
+	v := &struct {
+		NodeIDFrom int64         `bql:"node_id_from"`
+		NodeIDTo   int64         `bql:"node_id_to"`
+		Interval   time.Duration
+		Rate       float64
+		NumSteps   int64
+		Seed       *int64
+		Fields     map[string]*generatorFieldSpec
+	}{
+		NumSteps: -1,
+	}
+	dec := data.NewDecoder(nil)
+	if err := dec.Decode(params, v); err != nil {
+		return nil, err
+	}
+
+	if v.NodeIDFrom > v.NodeIDTo {
+		return nil, fmt.Errorf("'node_id_from' must be less than or equal to 'node_id_to'")
+	}
+	if v.Interval < 0 {
+		return nil, fmt.Errorf("'interval' must not be negative")
+	}
+	if v.Rate < 0 {
+		return nil, fmt.Errorf("'rate' must not be negative")
+	}
+	if v.Rate > 0 {
+		if v.Interval > 0 {
+			return nil, fmt.Errorf("'rate' and 'interval' cannot be specified at once")
+		}
+		v.Interval = time.Duration(float64(time.Second) / v.Rate)
+	}
+	for name, f := range v.Fields {
+		switch f.Type {
+		case "sequence", "uniform", "random_walk":
+		case "sinusoid":
+			if f.Period <= 0 {
+				return nil, fmt.Errorf("'period' of field '%v' must be positive", name)
+			}
+		default:
+			return nil, fmt.Errorf("field '%v' has an unsupported type: %v", name, f.Type)
+		}
+	}
+
+	seed := ctx.NodeRandom(core.NTSource, ioParams.Name).Int63()
+	if v.Seed != nil {
+		seed = *v.Seed
+	}
+	return core.ImplementSourceStop(&generatorSource{
+		nodeIDFrom: v.NodeIDFrom,
+		nodeIDTo:   v.NodeIDTo,
+		numSteps:   v.NumSteps,
+		interval:   v.Interval,
+		seed:       seed,
+		fields:     v.Fields,
+		stopCh:     make(chan struct{}),
+	}), nil
+}
+
+func init() {
+	MustRegisterGlobalSourceCreator("generator", SourceCreatorFunc(createGeneratorSource))
+}
@@ -0,0 +1,185 @@
+package bql
+
+import (
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+type testTupleCollector struct {
+	m      sync.Mutex
+	c      *sync.Cond
+	tuples []*core.Tuple
+}
+
+func (w *testTupleCollector) Write(ctx *core.Context, t *core.Tuple) error {
+	w.m.Lock()
+	defer w.m.Unlock()
+	w.tuples = append(w.tuples, t)
+	w.c.Broadcast()
+	return nil
+}
+
+func (w *testTupleCollector) wait(n int) {
+	w.m.Lock()
+	defer w.m.Unlock()
+	for len(w.tuples) < n {
+		w.c.Wait()
+	}
+}
+
+func TestGeneratorSource(t *testing.T) {
+	Convey("Given generator source parameters", t, func() {
+		ctx := core.NewContext(nil)
+		params := data.Map{
+			"node_id_from": data.Int(1),
+			"node_id_to":   data.Int(3),
+			"num_steps":    data.Int(4),
+			"seed":         data.Int(1),
+			"fields": data.Map{
+				"seq": data.Map{
+					"type":  data.String("sequence"),
+					"start": data.Int(10),
+					"step":  data.Int(2),
+				},
+				"walk": data.Map{
+					"type":  data.String("random_walk"),
+					"start": data.Int(0),
+					"step":  data.Int(1),
+					"min":   data.Float(-0.5),
+					"max":   data.Float(0.5),
+				},
+				"wave": data.Map{
+					"type":      data.String("sinusoid"),
+					"amplitude": data.Int(2),
+					"period":    data.Int(4),
+				},
+				"noise": data.Map{
+					"type": data.String("uniform"),
+					"min":  data.Int(5),
+					"max":  data.Int(6),
+				},
+			},
+		}
+		w := &testTupleCollector{}
+		w.c = sync.NewCond(&w.m)
+
+		Convey("When generating a finite stream", func() {
+			s, err := createGeneratorSource(ctx, &IOParams{}, params)
+			So(err, ShouldBeNil)
+			Reset(func() {
+				s.Stop(ctx)
+			})
+			So(s.GenerateStream(ctx, w), ShouldBeNil)
+
+			Convey("Then it should emit a tuple for each node at each step", func() {
+				So(w.tuples, ShouldHaveLength, 12)
+				for i, t := range w.tuples {
+					So(t.Data["node_id"], ShouldEqual, data.Int(i%3+1))
+					So(t.Data["step"], ShouldEqual, data.Int(i/3))
+				}
+			})
+
+			Convey("Then fields should follow their specs", func() {
+				for i, t := range w.tuples {
+					step := float64(i / 3)
+					So(t.Data["seq"], ShouldEqual, data.Float(10+2*step))
+
+					walk, err := data.AsFloat(t.Data["walk"])
+					So(err, ShouldBeNil)
+					So(walk, ShouldBeBetweenOrEqual, -0.5, 0.5)
+
+					wave, err := data.AsFloat(t.Data["wave"])
+					So(err, ShouldBeNil)
+					So(wave, ShouldAlmostEqual, 2*math.Sin(2*math.Pi*step/4))
+
+					noise, err := data.AsFloat(t.Data["noise"])
+					So(err, ShouldBeNil)
+					So(noise, ShouldBeBetweenOrEqual, 5, 6)
+				}
+			})
+
+			Convey("Then generating again with the same seed should emit the same stream", func() {
+				s2, err := createGeneratorSource(ctx, &IOParams{}, params)
+				So(err, ShouldBeNil)
+				w2 := &testTupleCollector{}
+				w2.c = sync.NewCond(&w2.m)
+				So(s2.GenerateStream(ctx, w2), ShouldBeNil)
+
+				So(w2.tuples, ShouldHaveLength, len(w.tuples))
+				for i := range w.tuples {
+					So(w2.tuples[i].Data, ShouldResemble, w.tuples[i].Data)
+				}
+			})
+		})
+
+		Convey("When generating a stream with a rate", func() {
+			params["rate"] = data.Int(10000)
+			s, err := createGeneratorSource(ctx, &IOParams{}, params)
+			So(err, ShouldBeNil)
+			Reset(func() {
+				s.Stop(ctx)
+			})
+			So(s.GenerateStream(ctx, w), ShouldBeNil)
+
+			Convey("Then tuples' timestamps should have proper intervals", func() {
+				for i := 3; i < len(w.tuples); i++ {
+					So(w.tuples[i].Timestamp, ShouldHappenOnOrAfter,
+						w.tuples[i-3].Timestamp.Add(100*time.Microsecond))
+				}
+			})
+		})
+
+		Convey("When generating an infinite stream", func() {
+			params["num_steps"] = data.Int(-1)
+			s, err := createGeneratorSource(ctx, &IOParams{}, params)
+			So(err, ShouldBeNil)
+			Reset(func() {
+				s.Stop(ctx)
+			})
+
+			ch := make(chan error, 1)
+			go func() {
+				ch <- s.GenerateStream(ctx, w)
+			}()
+
+			Convey("Then it should be able to stop", func() {
+				w.wait(100)
+				So(s.Stop(ctx), ShouldBeNil)
+				So(<-ch, ShouldBeNil)
+			})
+		})
+
+		Convey("When creating a generator source with invalid parameters", func() {
+			Convey("Then an invalid node ID range should result in an error", func() {
+				params["node_id_from"] = data.Int(4)
+				_, err := createGeneratorSource(ctx, &IOParams{}, params)
+				So(err, ShouldNotBeNil)
+			})
+
+			Convey("Then having both rate and interval should result in an error", func() {
+				params["rate"] = data.Int(1)
+				params["interval"] = data.Int(1)
+				_, err := createGeneratorSource(ctx, &IOParams{}, params)
+				So(err, ShouldNotBeNil)
+			})
+
+			Convey("Then an unknown field type should result in an error", func() {
+				params["fields"] = data.Map{"a": data.Map{"type": data.String("gaussian")}}
+				_, err := createGeneratorSource(ctx, &IOParams{}, params)
+				So(err, ShouldNotBeNil)
+			})
+
+			Convey("Then a sinusoid without a period should result in an error", func() {
+				params["fields"] = data.Map{"a": data.Map{"type": data.String("sinusoid")}}
+				_, err := createGeneratorSource(ctx, &IOParams{}, params)
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
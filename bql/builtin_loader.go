@@ -0,0 +1,166 @@
+package bql
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// httpLoaderKeyPlaceholder is replaced with a key in url_template of
+// http_loader.
+const httpLoaderKeyPlaceholder = "{key}"
+
+// httpLoader loads a value by sending a GET request to a URL having the key.
+type httpLoader struct {
+	urlTemplate string
+	headers     map[string]string
+	client      *SharedHTTPClient
+}
+
+func (l *httpLoader) Load(ctx *core.Context, key data.Value) (data.Value, error) {
+	k, err := data.ToString(key)
+	if err != nil {
+		return nil, err
+	}
+	// QueryEscape escapes characters having special meanings in both paths
+	// and queries, but a space must not be converted to '+' in paths.
+	esc := strings.Replace(url.QueryEscape(k), "+", "%20", -1)
+	req, err := http.NewRequest("GET", strings.Replace(l.urlTemplate, httpLoaderKeyPlaceholder, esc, -1), nil)
+	if err != nil {
+		return nil, err
+	}
+	for n, v := range l.headers {
+		req.Header.Set(n, v)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	res, err := l.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	switch {
+	case res.StatusCode == http.StatusNotFound:
+		return data.Null{}, nil
+	case res.StatusCode < 200 || res.StatusCode >= 300:
+		return nil, fmt.Errorf("cannot load %v: %v", req.URL, res.Status)
+	}
+
+	var v interface{}
+	dec := json.NewDecoder(res.Body)
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
+		return nil, fmt.Errorf("cannot decode the response from %v: %v", req.URL, err)
+	}
+	return data.NewValue(v)
+}
+
+func (l *httpLoader) Close() error {
+	return l.client.Close()
+}
+
+// createHTTPLoader creates a loader which sends a GET request to the URL
+// made from "url_template" by replacing "{key}" with a key and decodes the
+// response as JSON. A key is converted to a string and URL-escaped. The
+// result is NULL when the server responds with 404 Not Found, and any other
+// status out of 2xx is an error. It has following optional parameters:
+//
+//	- timeout: the timeout of a request (10 seconds by default)
+//	- headers: a map of HTTP headers added to requests
+//
+// Loaders sending requests to the same host share a connection pool. The pool
+// can be configured by parameters described in NewSharedHTTPClient.
+func createHTTPLoader(ctx *core.Context, ioParams *IOParams, params data.Map) (EnrichmentLoader, error) {
+	v := &struct {
+		URLTemplate string `bql:",required"`
+		Timeout     time.Duration
+		Headers     map[string]string
+	}{
+		Timeout: 10 * time.Second,
+	}
+	dec := data.NewDecoder(nil)
+	if err := dec.Decode(params, v); err != nil {
+		return nil, err
+	}
+	if !strings.Contains(v.URLTemplate, httpLoaderKeyPlaceholder) {
+		return nil, fmt.Errorf("'url_template' parameter must have %v: %v", httpLoaderKeyPlaceholder, v.URLTemplate)
+	}
+	u, err := url.Parse(strings.Replace(v.URLTemplate, httpLoaderKeyPlaceholder, "key", -1))
+	if err != nil {
+		return nil, fmt.Errorf("'url_template' parameter has an invalid URL: %v", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("'url_template' parameter must be an http or https URL: %v", v.URLTemplate)
+	}
+	if v.Timeout <= 0 {
+		return nil, fmt.Errorf("'timeout' parameter must be greater than 0: %v", v.Timeout)
+	}
+
+	client, err := NewSharedHTTPClient(u.String(), v.Timeout, params)
+	if err != nil {
+		return nil, err
+	}
+	return &httpLoader{
+		urlTemplate: v.URLTemplate,
+		headers:     v.Headers,
+		client:      client,
+	}, nil
+}
+
+// udsLoader loads a value from a UDS. The UDS is looked up for each call of
+// Load so that the UDS can be replaced while the stream is running.
+type udsLoader struct {
+	name string
+}
+
+func (l *udsLoader) Load(ctx *core.Context, key data.Value) (data.Value, error) {
+	st, err := ctx.SharedStates.Get(l.name)
+	if err != nil {
+		return nil, err
+	}
+	switch s := st.(type) {
+	case EnrichmentLoader:
+		return s.Load(ctx, key)
+	case *core.KeyValueState:
+		k, err := data.ToString(key)
+		if err != nil {
+			return nil, err
+		}
+		if v, ok := s.Get(k); ok {
+			return v, nil
+		}
+		return data.Null{}, nil
+	}
+	return nil, fmt.Errorf("state '%v' cannot be used as a loader", l.name)
+}
+
+// createUDSLoader creates a loader which loads values from the UDS having
+// the name given to "state" parameter. The UDS must be a "kv" UDS or
+// implement EnrichmentLoader. Loaders for other systems such as Redis can
+// be provided by plugins as UDSs implementing EnrichmentLoader or by
+// registering EnrichmentLoaderCreators.
+func createUDSLoader(ctx *core.Context, ioParams *IOParams, params data.Map) (EnrichmentLoader, error) {
+	v, ok := params["state"]
+	if !ok {
+		return nil, errors.New("'state' parameter is missing")
+	}
+	name, err := data.AsString(v)
+	if err != nil {
+		return nil, fmt.Errorf("'state' parameter must be a string: %v", err)
+	}
+	return &udsLoader{
+		name: name,
+	}, nil
+}
+
+func init() {
+	MustRegisterGlobalEnrichmentLoaderCreator("http_loader", EnrichmentLoaderCreatorFunc(createHTTPLoader))
+	MustRegisterGlobalEnrichmentLoaderCreator("uds_loader", EnrichmentLoaderCreatorFunc(createUDSLoader))
+}
@@ -0,0 +1,324 @@
+package bql
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// cronSchedule is a parsed cron expression. Each field is a bit set of
+// allowed values.
+type cronSchedule struct {
+	seconds  uint64
+	minutes  uint64
+	hours    uint64
+	days     uint64
+	months   uint64
+	weekdays uint64
+
+	// daysRestricted and weekdaysRestricted are true when the corresponding
+	// fields aren't "*". When both are restricted, a day matches if either
+	// of them matches as in the standard cron.
+	daysRestricted     bool
+	weekdaysRestricted bool
+}
+
+type cronField struct {
+	name     string
+	min, max int
+}
+
+var cronFields = []cronField{
+	{"second", 0, 59},
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7},
+}
+
+// parseCron parses a cron expression. It has six fields of seconds, minutes,
+// hours, days of month, months, and days of week. The seconds field can be
+// omitted, in which case it's 0. Each field accepts "*", a value, a range
+// "a-b", steps "*/n" or "a-b/n", and lists of them separated by commas. Both 0
+// and 7 mean Sunday in the day of week field.
+func parseCron(expr string) (*cronSchedule, error) {
+	fs := strings.Fields(expr)
+	switch len(fs) {
+	case 5:
+		fs = append([]string{"0"}, fs...)
+	case 6:
+	default:
+		return nil, fmt.Errorf("a cron expression must have 5 or 6 fields: %v", expr)
+	}
+
+	sets := make([]uint64, len(fs))
+	for i, f := range fs {
+		s, err := parseCronField(f, cronFields[i])
+		if err != nil {
+			return nil, err
+		}
+		sets[i] = s
+	}
+	c := &cronSchedule{
+		seconds:            sets[0],
+		minutes:            sets[1],
+		hours:              sets[2],
+		days:               sets[3],
+		months:             sets[4],
+		weekdays:           sets[5],
+		daysRestricted:     fs[3] != "*",
+		weekdaysRestricted: fs[5] != "*",
+	}
+	if c.weekdays&(1<<7) != 0 {
+		c.weekdays |= 1
+	}
+	return c, nil
+}
+
+func parseCronField(s string, f cronField) (uint64, error) {
+	var set uint64
+	for _, part := range strings.Split(s, ",") {
+		rng, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			n, err := strconv.Atoi(part[i+1:])
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("the %v field has an invalid step: %v", f.name, part)
+			}
+			rng, step = part[:i], n
+		}
+
+		lo, hi := f.min, f.max
+		if rng != "*" {
+			var err error
+			if i := strings.Index(rng, "-"); i >= 0 {
+				lo, err = parseCronValue(rng[:i], f)
+				if err != nil {
+					return 0, err
+				}
+				hi, err = parseCronValue(rng[i+1:], f)
+				if err != nil {
+					return 0, err
+				}
+				if lo > hi {
+					return 0, fmt.Errorf("the %v field has an invalid range: %v", f.name, rng)
+				}
+			} else {
+				lo, err = parseCronValue(rng, f)
+				if err != nil {
+					return 0, err
+				}
+				if step == 1 {
+					hi = lo
+				}
+			}
+		}
+		for v := lo; v <= hi; v += step {
+			set |= 1 << uint(v)
+		}
+	}
+	return set, nil
+}
+
+func parseCronValue(s string, f cronField) (int, error) {
+	v, err := strconv.Atoi(s)
+	if err != nil || v < f.min || v > f.max {
+		return 0, fmt.Errorf("the %v field must be in [%v, %v]: %v", f.name, f.min, f.max, s)
+	}
+	return v, nil
+}
+
+func (c *cronSchedule) dayMatches(t time.Time) bool {
+	d := c.days&(1<<uint(t.Day())) != 0
+	w := c.weekdays&(1<<uint(t.Weekday())) != 0
+	if c.daysRestricted && c.weekdaysRestricted {
+		return d || w
+	}
+	return d && w
+}
+
+// next returns the first time matching the schedule after t. It returns the
+// zero time when no time matches within five years, e.g. for "0 0 0 30 2 *".
+func (c *cronSchedule) next(t time.Time) time.Time {
+	t = t.Truncate(time.Second).Add(time.Second)
+	limit := t.AddDate(5, 0, 0)
+	loc := t.Location()
+	for t.Before(limit) {
+		if c.months&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !c.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if c.hours&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if c.minutes&(1<<uint(t.Minute())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute()+1, 0, 0, loc)
+			continue
+		}
+		if c.seconds&(1<<uint(t.Second())) == 0 {
+			t = t.Add(time.Second)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// scheduleSource emits a tick tuple at each scheduled time. The schedule is
+// given by either a cron expression or an interval.
+type scheduleSource struct {
+	cron     *cronSchedule
+	interval time.Duration
+	jitter   time.Duration
+	numTicks int64
+	location *time.Location
+	seed     int64
+	stopCh   chan struct{}
+}
+
+// nextTime returns the scheduled time of the tick following the one
+// scheduled at prev.
+func (s *scheduleSource) nextTime(prev time.Time) time.Time {
+	if s.cron != nil {
+		return s.cron.next(prev.In(s.location))
+	}
+	return prev.Add(s.interval)
+}
+
+func (s *scheduleSource) GenerateStream(ctx *core.Context, w core.Writer) error {
+	r := rand.New(rand.NewSource(s.seed))
+	next := ctx.Clock().Now()
+	if s.cron != nil {
+		next = s.nextTime(next)
+	}
+	for tick := int64(0); s.numTicks < 0 || tick < s.numTicks; tick++ {
+		if next.IsZero() {
+			return errors.New("the cron expression doesn't match any time")
+		}
+		at := next
+		if s.jitter > 0 {
+			at = at.Add(time.Duration(r.Int63n(int64(s.jitter))))
+		}
+		if d := at.Sub(ctx.Clock().Now()); d > 0 {
+			select {
+			case <-s.stopCh:
+				return core.ErrSourceStopped
+			case <-ctx.Clock().After(d):
+			}
+		}
+
+		now := ctx.Clock().Now()
+		t := &core.Tuple{
+			Data: data.Map{
+				"tick":           data.Int(tick),
+				"scheduled_time": data.Timestamp(next),
+			},
+			Timestamp:     now,
+			ProcTimestamp: now,
+		}
+		if err := w.Write(ctx, t); err != nil {
+			return err
+		}
+
+		// Ticks missed due to a delay are skipped rather than emitted at once.
+		next = s.nextTime(next)
+		for !next.IsZero() && !next.After(now) {
+			next = s.nextTime(next)
+		}
+	}
+	return nil
+}
+
+func (s *scheduleSource) Stop(ctx *core.Context) error {
+	close(s.stopCh)
+	return nil
+}
+
+// createScheduleSource creates a source emitting tick tuples on a schedule
+// so that periodic jobs can be written in BQL:
+//
+//	CREATE SOURCE every_5s TYPE schedule WITH cron="*/5 * * * * *";
+//	CREATE SOURCE ticks TYPE schedule WITH interval=0.1, jitter=0.01;
+//
+// It accepts following parameters:
+//
+//	- cron: a cron expression with an optional seconds field, see parseCron
+//	  for the syntax
+//	- interval: the interval between ticks, which can be less than a second
+//	- jitter: the maximum random delay added to each tick (default: 0)
+//	- timezone: the time zone in which cron is evaluated (default: the time
+//	  zone of the topology)
+//	- num_ticks: the number of ticks, negative means infinite (default: -1)
+//	- seed: the seed of the random number generator for jitter
+//
+// Either cron or interval must be specified. Each tuple has "tick", which is
+// the sequence number of the tick starting from 0, and "scheduled_time",
+// which is the time the tick was scheduled at without jitter. Ticks missed
+// because of a delay are skipped.
+func createScheduleSource(ctx *core.Context, ioParams *IOParams, params data.Map) (core.Source, error) {
+	v := &struct {
+		Cron     string
+		Interval time.Duration
+		Jitter   time.Duration
+		Timezone string
+		NumTicks int64
+		Seed     *int64
+	}{
+		NumTicks: -1,
+	}
+	if err := data.NewDecoder(nil).Decode(params, v); err != nil {
+		return nil, err
+	}
+
+	s := &scheduleSource{
+		interval: v.Interval,
+		jitter:   v.Jitter,
+		numTicks: v.NumTicks,
+		stopCh:   make(chan struct{}),
+	}
+	switch {
+	case v.Cron != "" && v.Interval != 0:
+		return nil, errors.New("'cron' and 'interval' cannot be specified at once")
+	case v.Cron != "":
+		c, err := parseCron(v.Cron)
+		if err != nil {
+			return nil, fmt.Errorf("'cron' parameter has an invalid value: %v", err)
+		}
+		s.cron = c
+	case v.Interval > 0:
+		if v.Timezone != "" {
+			return nil, errors.New("'timezone' can only be used with 'cron'")
+		}
+	default:
+		return nil, errors.New("either 'cron' or a positive 'interval' is required")
+	}
+	if v.Jitter < 0 {
+		return nil, errors.New("'jitter' must not be negative")
+	}
+	loc, err := sourceLocation(ctx, v.Timezone)
+	if err != nil {
+		return nil, err
+	}
+	s.location = loc
+
+	s.seed = ctx.NodeRandom(core.NTSource, ioParams.Name).Int63()
+	if v.Seed != nil {
+		s.seed = *v.Seed
+	}
+	return core.ImplementSourceStop(s), nil
+}
+
+func init() {
+	MustRegisterGlobalSourceCreator("schedule", SourceCreatorFunc(createScheduleSource))
+}
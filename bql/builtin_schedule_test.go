@@ -0,0 +1,206 @@
+package bql
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+func TestParseCron(t *testing.T) {
+	base := time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC) // Sunday
+
+	Convey("Given cron expressions", t, func() {
+		cases := []struct {
+			expr string
+			next []time.Time
+		}{
+			{"*/5 * * * * *", []time.Time{
+				base.Add(5 * time.Second),
+				base.Add(10 * time.Second),
+			}},
+			{"30 9 * * *", []time.Time{
+				time.Date(2017, 1, 1, 9, 30, 0, 0, time.UTC),
+				time.Date(2017, 1, 2, 9, 30, 0, 0, time.UTC),
+			}},
+			{"0 0 12 * * 1-5", []time.Time{
+				time.Date(2017, 1, 2, 12, 0, 0, 0, time.UTC),
+				time.Date(2017, 1, 3, 12, 0, 0, 0, time.UTC),
+			}},
+			{"0 0 0 1,15 * 0", []time.Time{
+				time.Date(2017, 1, 8, 0, 0, 0, 0, time.UTC),
+				time.Date(2017, 1, 15, 0, 0, 0, 0, time.UTC),
+				time.Date(2017, 1, 22, 0, 0, 0, 0, time.UTC),
+				time.Date(2017, 1, 29, 0, 0, 0, 0, time.UTC),
+				time.Date(2017, 2, 1, 0, 0, 0, 0, time.UTC),
+			}},
+			{"0 0 0 29 2 *", []time.Time{
+				time.Date(2020, 2, 29, 0, 0, 0, 0, time.UTC),
+			}},
+			{"0 0 0 * * 7", []time.Time{
+				time.Date(2017, 1, 8, 0, 0, 0, 0, time.UTC),
+			}},
+		}
+
+		for _, c := range cases {
+			c := c
+			Convey("When computing next times of "+c.expr, func() {
+				s, err := parseCron(c.expr)
+				So(err, ShouldBeNil)
+
+				Convey("Then they should match the expression", func() {
+					t := base
+					for _, n := range c.next {
+						t = s.next(t)
+						So(t, ShouldResemble, n)
+					}
+				})
+			})
+		}
+
+		Convey("When the expression never matches", func() {
+			s, err := parseCron("0 0 0 30 2 *")
+			So(err, ShouldBeNil)
+
+			Convey("Then next should return the zero time", func() {
+				So(s.next(base).IsZero(), ShouldBeTrue)
+			})
+		})
+
+		for _, expr := range []string{"* * * *", "60 * * * * *", "* * 0 * *", "*/0 * * * *", "5-1 * * * *", "a * * * *"} {
+			Convey("When parsing an invalid expression "+expr, func() {
+				_, err := parseCron(expr)
+
+				Convey("Then it should fail", func() {
+					So(err, ShouldNotBeNil)
+				})
+			})
+		}
+	})
+}
+
+func TestScheduleSource(t *testing.T) {
+	Convey("Given a schedule source with a manual clock", t, func() {
+		base := time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+		clock := core.NewManualClock(base)
+		ctx := core.NewContext(&core.ContextConfig{Clock: clock, Location: time.UTC})
+		w := &testTupleCollector{}
+		w.c = sync.NewCond(&w.m)
+
+		run := func(params data.Map) <-chan error {
+			s, err := createScheduleSource(ctx, &IOParams{}, params)
+			So(err, ShouldBeNil)
+			done := make(chan error, 1)
+			go func() {
+				done <- s.GenerateStream(ctx, w)
+			}()
+			Reset(func() {
+				s.Stop(ctx)
+			})
+			return done
+		}
+
+		Convey("When emitting ticks at a sub-second interval", func() {
+			done := run(data.Map{
+				"interval":  data.Float(0.1),
+				"num_ticks": data.Int(3),
+			})
+			w.wait(1)
+			for i := 0; i < 2; i++ {
+				clock.BlockUntil(1)
+				clock.Advance(100 * time.Millisecond)
+			}
+			So(<-done, ShouldBeNil)
+
+			Convey("Then it should emit tick tuples", func() {
+				So(w.tuples, ShouldHaveLength, 3)
+				for i, t := range w.tuples {
+					So(t.Data["tick"], ShouldEqual, data.Int(i))
+					So(t.Data["scheduled_time"], ShouldResemble,
+						data.Timestamp(base.Add(time.Duration(i)*100*time.Millisecond)))
+				}
+			})
+		})
+
+		Convey("When emitting ticks on a cron schedule", func() {
+			done := run(data.Map{
+				"cron":      data.String("*/5 * * * * *"),
+				"num_ticks": data.Int(3),
+			})
+			clock.BlockUntil(1)
+			clock.Advance(5 * time.Second)
+			w.wait(1)
+
+			Convey("Then it should skip ticks missed by a delay", func() {
+				clock.BlockUntil(1)
+				clock.Advance(12 * time.Second)
+				w.wait(2)
+				clock.BlockUntil(1)
+				clock.Advance(3 * time.Second)
+				So(<-done, ShouldBeNil)
+				So(w.tuples, ShouldHaveLength, 3)
+				So(w.tuples[0].Data["scheduled_time"], ShouldResemble, data.Timestamp(base.Add(5*time.Second)))
+				So(w.tuples[1].Data["scheduled_time"], ShouldResemble, data.Timestamp(base.Add(10*time.Second)))
+				So(w.tuples[2].Data["scheduled_time"], ShouldResemble, data.Timestamp(base.Add(20*time.Second)))
+			})
+		})
+
+		Convey("When emitting ticks with jitter", func() {
+			done := run(data.Map{
+				"cron":      data.String("0 * * * * *"),
+				"jitter":    data.Int(10),
+				"num_ticks": data.Int(1),
+				"seed":      data.Int(1),
+			})
+			clock.BlockUntil(1)
+			clock.Advance(time.Minute + 10*time.Second)
+			So(<-done, ShouldBeNil)
+
+			Convey("Then the scheduled time shouldn't include the jitter", func() {
+				So(w.tuples, ShouldHaveLength, 1)
+				So(w.tuples[0].Data["scheduled_time"], ShouldResemble, data.Timestamp(base.Add(time.Minute)))
+			})
+		})
+
+		Convey("When stopping the source", func() {
+			s, err := createScheduleSource(ctx, &IOParams{}, data.Map{"interval": data.Int(1)})
+			So(err, ShouldBeNil)
+			done := make(chan error, 1)
+			go func() {
+				done <- s.GenerateStream(ctx, w)
+			}()
+			w.wait(1)
+			clock.BlockUntil(1)
+
+			Convey("Then GenerateStream should return", func() {
+				So(s.Stop(ctx), ShouldBeNil)
+				<-done
+			})
+		})
+	})
+
+	Convey("Given invalid parameters of a schedule source", t, func() {
+		ctx := core.NewContext(nil)
+		cases := map[string]data.Map{
+			"no schedule":       {},
+			"both schedules":    {"cron": data.String("* * * * *"), "interval": data.Int(1)},
+			"invalid cron":      {"cron": data.String("* * *")},
+			"negative jitter":   {"interval": data.Int(1), "jitter": data.Int(-1)},
+			"interval timezone": {"interval": data.Int(1), "timezone": data.String("UTC")},
+			"invalid timezone":  {"cron": data.String("* * * * *"), "timezone": data.String("Nowhere/City")},
+		}
+		for name, params := range cases {
+			params := params
+			Convey("When creating a source with "+name, func() {
+				_, err := createScheduleSource(ctx, &IOParams{}, params)
+
+				Convey("Then it should fail", func() {
+					So(err, ShouldNotBeNil)
+				})
+			})
+		}
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -256,6 +257,563 @@ func TestFileSource(t *testing.T) {
 				_, err := createFileSource(ctx, &IOParams{}, params)
 				So(err, ShouldNotBeNil)
 			})
+
+			Convey("Then an unsupported format should result in an error", func() {
+				params["format"] = data.String("csv")
+				_, err := createFileSource(ctx, &IOParams{}, params)
+				So(err, ShouldNotBeNil)
+			})
+
+			Convey("Then replay_timing without timestamp_field should result in an error", func() {
+				params["replay_timing"] = data.True
+				_, err := createFileSource(ctx, &IOParams{}, params)
+				So(err, ShouldNotBeNil)
+			})
+
+			Convey("Then replay_timing with interval should result in an error", func() {
+				params["replay_timing"] = data.True
+				params["timestamp_field"] = data.String("ts")
+				params["interval"] = data.Float(0.1)
+				_, err := createFileSource(ctx, &IOParams{}, params)
+				So(err, ShouldNotBeNil)
+			})
+
+			Convey("Then non-positive speed should result in an error", func() {
+				params["speed"] = data.Float(0)
+				_, err := createFileSource(ctx, &IOParams{}, params)
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+func TestFileSourceReplayTiming(t *testing.T) {
+	f, err := ioutil.TempFile("", "sbtest_bql_file_source_replay")
+	if err != nil {
+		t.Fatal("Cannot create a temp file:", err)
+	}
+	name := f.Name()
+	defer func() {
+		os.Remove(name)
+	}()
+	base := time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// the third line doesn't have a timestamp and is emitted immediately
+	_, err = io.WriteString(f, fmt.Sprintf(`{"int":1, "ts":"%v"}
+{"int":2, "ts":"%v"}
+{"int":3}
+{"int":4, "ts":"%v"}
+`, base.Format(time.RFC3339Nano), base.Add(100*time.Millisecond).Format(time.RFC3339Nano),
+		base.Add(300*time.Millisecond).Format(time.RFC3339Nano)))
+	f.Close()
+	if err != nil {
+		t.Fatal("Cannot write to the temp file:", err)
+	}
+
+	Convey("Given a file having recorded timestamps", t, func() {
+		clock := core.NewManualClock(time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC))
+		ctx := core.NewContext(&core.ContextConfig{Clock: clock})
+		params := data.Map{
+			"path":            data.String(name),
+			"timestamp_field": data.String("ts"),
+			"replay_timing":   data.True,
+			"speed":           data.Float(2),
+		}
+		var emitted []time.Time
+		w := core.WriterFunc(func(ctx *core.Context, t *core.Tuple) error {
+			emitted = append(emitted, ctx.Clock().Now())
+			return nil
+		})
+
+		Convey("When replaying the file with speed 2", func() {
+			s, err := createFileSource(ctx, &IOParams{}, params)
+			So(err, ShouldBeNil)
+			Reset(func() {
+				s.Stop(ctx)
+			})
+
+			ch := make(chan error, 1)
+			go func() {
+				ch <- s.GenerateStream(ctx, w)
+			}()
+			clock.BlockUntil(1)
+			clock.Advance(50 * time.Millisecond)
+			clock.BlockUntil(1)
+			clock.Advance(100 * time.Millisecond)
+			So(<-ch, ShouldBeNil)
+
+			Convey("Then tuples should be spaced by the halved deltas of timestamps", func() {
+				start := emitted[0]
+				So(emitted, ShouldResemble, []time.Time{start, start.Add(50 * time.Millisecond),
+					start.Add(50 * time.Millisecond), start.Add(150 * time.Millisecond)})
+			})
+		})
+
+		Convey("When stopping the source while it's waiting", func() {
+			params["speed"] = data.Float(0.001)
+			s, err := createFileSource(ctx, &IOParams{}, params)
+			So(err, ShouldBeNil)
+
+			ch := make(chan error, 1)
+			go func() {
+				ch <- s.GenerateStream(ctx, w)
+			}()
+
+			Convey("Then it should stop without waiting", func() {
+				So(s.Stop(ctx), ShouldBeNil)
+				So(<-ch, ShouldBeNil)
+			})
+		})
+	})
+}
+
+// intFailSink sends "int" field of each tuple to a channel and fails to write
+// a tuple having the value of fail.
+type intFailSink struct {
+	fail int64
+	ch   chan int64
+}
+
+func (s *intFailSink) Write(ctx *core.Context, t *core.Tuple) error {
+	i, _ := data.AsInt(t.Data["int"])
+	s.ch <- i
+	if i == s.fail {
+		return fmt.Errorf("cannot write %v", i)
+	}
+	return nil
+}
+
+func (s *intFailSink) Close(ctx *core.Context) error {
+	return nil
+}
+
+func TestFileSourceOffsetFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sbtest_bql_file_source_offset")
+	if err != nil {
+		t.Fatal("Cannot create a temp dir:", err)
+	}
+	defer os.RemoveAll(dir)
+	name := filepath.Join(dir, "in.jsonl")
+	offsetFile := filepath.Join(dir, "offset")
+	if err := ioutil.WriteFile(name, []byte(`{"int":1}
+
+{"int":2}
+{"int":3}
+{"int":4}
+`), 0644); err != nil {
+		t.Fatal("Cannot write to the temp file:", err)
+	}
+
+	readOffset := func(expected string) string {
+		// offsets are committed asynchronously after sinks write tuples.
+		var s string
+		for i := 0; i < 100; i++ {
+			b, _ := ioutil.ReadFile(offsetFile)
+			if s = string(b); s == expected {
+				break
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		return s
+	}
+
+	run := func(fail int64, n int) []int64 {
+		dt := newTestTopology()
+		defer dt.Stop()
+		tb, err := NewTopologyBuilder(dt)
+		So(err, ShouldBeNil)
+		son, err := tb.AddSource("source", "file", data.Map{
+			"path":        data.String(name),
+			"offset_file": data.String(offsetFile),
+		}, &core.SourceConfig{PausedOnStartup: true})
+		So(err, ShouldBeNil)
+		si := &intFailSink{fail: fail, ch: make(chan int64, 5)}
+		sn, err := dt.AddSink("sink", si, nil)
+		So(err, ShouldBeNil)
+		So(sn.Input("source", nil), ShouldBeNil)
+		So(son.Resume(), ShouldBeNil)
+
+		var res []int64
+		for i := 0; i < n; i++ {
+			res = append(res, <-si.ch)
+		}
+		return res
+	}
+
+	Convey("Given a file source with offset_file", t, func() {
+		Reset(func() {
+			os.Remove(offsetFile)
+		})
+
+		Convey("When a sink fails to write a tuple", func() {
+			So(run(3, 4), ShouldResemble, []int64{1, 2, 3, 4})
+
+			Convey("Then the offset should be committed up to the failed line", func() {
+				So(readOffset("21\n"), ShouldEqual, "21\n")
+			})
+
+			Convey("Then the source should start from the failed line next time", func() {
+				readOffset("21\n")
+				So(run(0, 2), ShouldResemble, []int64{3, 4})
+				So(readOffset("41\n"), ShouldEqual, "41\n")
+			})
+		})
+	})
+
+	Convey("Given invalid parameters for offset_file", t, func() {
+		ctx := core.NewContext(nil)
+
+		Convey("When it's used with repeat", func() {
+			_, err := createFileSource(ctx, &IOParams{}, data.Map{
+				"path":        data.String(name),
+				"offset_file": data.String(offsetFile),
+				"repeat":      data.Int(1),
+			})
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When the offset file is broken", func() {
+			So(ioutil.WriteFile(offsetFile, []byte("abc"), 0644), ShouldBeNil)
+			_, err := createFileSource(ctx, &IOParams{}, data.Map{
+				"path":        data.String(name),
+				"offset_file": data.String(offsetFile),
+			})
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+func TestFileSourceFormat(t *testing.T) {
+	f, err := ioutil.TempFile("", "sbtest_bql_file_source_format")
+	if err != nil {
+		t.Fatal("Cannot create a temp file:", err)
+	}
+	name := f.Name()
+	defer func() {
+		os.Remove(name)
+	}()
+
+	// the second line has a wrong checksum
+	_, err = io.WriteString(f, `$GPGGA,123519,4807.038,N,01131.000,E,1,08,0.9,545.4,M,46.9,M,,*47
+$GPGGA,123519,4807.038,N,01131.000,E,1,08,0.9,545.4,M,46.9,M,,*48
+$GPRMC,123519,A,4807.038,N,01131.000,E,022.4,084.4,230394,003.1,W*6A
+`)
+	f.Close()
+	if err != nil {
+		t.Fatal("Cannot write to the temp file:", err)
+	}
+
+	Convey("Given a file having NMEA sentences", t, func() {
+		ctx := core.NewContext(nil)
+		params := data.Map{
+			"path":            data.String(name),
+			"format":          data.String("nmea"),
+			"timestamp_field": data.String("timestamp"),
+		}
+		w := &testTupleCollector{}
+		w.c = sync.NewCond(&w.m)
+
+		Convey("When reading the file with the nmea format", func() {
+			s, err := createFileSource(ctx, &IOParams{}, params)
+			So(err, ShouldBeNil)
+			Reset(func() {
+				s.Stop(ctx)
+			})
+			So(s.GenerateStream(ctx, w), ShouldBeNil)
+
+			Convey("Then it should emit sentences having valid checksums", func() {
+				So(w.tuples, ShouldHaveLength, 2)
+				So(w.tuples[0].Data["sentence"], ShouldEqual, data.String("GGA"))
+				So(w.tuples[1].Data["sentence"], ShouldEqual, data.String("RMC"))
+			})
+
+			Convey("Then the timestamp in RMC should be used", func() {
+				So(w.tuples[1].Timestamp, ShouldResemble, time.Date(1994, 3, 23, 12, 35, 19, 0, time.UTC))
+			})
+		})
+	})
+}
+
+func TestFileSourceJSONLimits(t *testing.T) {
+	f, err := ioutil.TempFile("", "sbtest_bql_file_source_json_limits")
+	if err != nil {
+		t.Fatal("Cannot create a temp file:", err)
+	}
+	name := f.Name()
+	defer func() {
+		os.Remove(name)
+	}()
+
+	// the second line is too long and the third line is too deep
+	_, err = io.WriteString(f, `{"int":1}
+{"str":"`+strings.Repeat("x", 1000)+`"}
+{"arr":[[[[1]]]]}
+{"int":
+4}
+{"int":5}
+`)
+	f.Close()
+	if err != nil {
+		t.Fatal("Cannot write to the temp file:", err)
+	}
+
+	Convey("Given a file having lines exceeding limits", t, func() {
+		ctx := core.NewContext(nil)
+		params := data.Map{
+			"path":              data.String(name),
+			"max_document_size": data.Int(100),
+			"max_depth":         data.Int(3),
+		}
+		w := &testTupleCollector{}
+		w.c = sync.NewCond(&w.m)
+
+		Convey("When reading the file", func() {
+			s, err := createFileSource(ctx, &IOParams{}, params)
+			So(err, ShouldBeNil)
+			Reset(func() {
+				s.Stop(ctx)
+			})
+			So(s.GenerateStream(ctx, w), ShouldBeNil)
+
+			Convey("Then it should only emit lines within the limits", func() {
+				So(w.tuples, ShouldHaveLength, 2)
+				So(w.tuples[0].Data, ShouldResemble, data.Map{"int": data.Int(1)})
+				So(w.tuples[1].Data, ShouldResemble, data.Map{"int": data.Int(5)})
+			})
+		})
+
+		Convey("When disabling the limits", func() {
+			params["max_document_size"] = data.Int(0)
+			params["max_depth"] = data.Int(0)
+			s, err := createFileSource(ctx, &IOParams{}, params)
+			So(err, ShouldBeNil)
+			Reset(func() {
+				s.Stop(ctx)
+			})
+			So(s.GenerateStream(ctx, w), ShouldBeNil)
+
+			Convey("Then it should emit all valid lines", func() {
+				So(w.tuples, ShouldHaveLength, 4)
+			})
+		})
+
+		Convey("When giving a negative limit", func() {
+			params["max_string_length"] = data.Int(-1)
+			_, err := createFileSource(ctx, &IOParams{}, params)
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+func TestFileBinaryFormats(t *testing.T) {
+	for _, format := range []string{"cbor", "msgpack"} {
+		format := format
+		Convey("Given a file sink writing tuples in "+format, t, func() {
+			f, err := ioutil.TempFile("", "sbtest_bql_file_binary_format")
+			So(err, ShouldBeNil)
+			name := f.Name()
+			f.Close()
+			Reset(func() {
+				os.Remove(name)
+			})
+
+			ctx := core.NewContext(nil)
+			si, err := createFileSink(ctx, &IOParams{}, data.Map{
+				"path":   data.String(name),
+				"format": data.String(format),
+			})
+			So(err, ShouldBeNil)
+			ts := time.Date(2016, time.January, 2, 3, 4, 5, 6000, time.UTC)
+			for i := 0; i < 3; i++ {
+				So(si.Write(ctx, core.NewTuple(data.Map{
+					"int":  data.Int(i),
+					"blob": data.Blob{byte(i)},
+					"ts":   data.Timestamp(ts.Add(time.Duration(i) * time.Second)),
+				})), ShouldBeNil)
+			}
+			So(si.Close(ctx), ShouldBeNil)
+
+			Convey("When reading the file by the file source", func() {
+				s, err := createFileSource(ctx, &IOParams{}, data.Map{
+					"path":            data.String(name),
+					"format":          data.String(format),
+					"timestamp_field": data.String("ts"),
+				})
+				So(err, ShouldBeNil)
+				Reset(func() {
+					s.Stop(ctx)
+				})
+				w := &testTupleCollector{}
+				w.c = sync.NewCond(&w.m)
+				So(s.GenerateStream(ctx, w), ShouldBeNil)
+
+				Convey("Then it should emit all tuples with their types", func() {
+					So(w.tuples, ShouldHaveLength, 3)
+					for i, t := range w.tuples {
+						So(t.Data["int"], ShouldEqual, data.Int(i))
+						So(t.Data["blob"], ShouldResemble, data.Blob{byte(i)})
+						So(t.Timestamp.Equal(ts.Add(time.Duration(i)*time.Second)), ShouldBeTrue)
+					}
+				})
+			})
+
+			Convey("When the file has a truncated value", func() {
+				fi, err := os.Stat(name)
+				So(err, ShouldBeNil)
+				So(os.Truncate(name, fi.Size()-1), ShouldBeNil)
+				s, err := createFileSource(ctx, &IOParams{}, data.Map{
+					"path":   data.String(name),
+					"format": data.String(format),
+				})
+				So(err, ShouldBeNil)
+				Reset(func() {
+					s.Stop(ctx)
+				})
+				w := &testTupleCollector{}
+				w.c = sync.NewCond(&w.m)
+
+				Convey("Then the source should fail after emitting preceding tuples", func() {
+					So(s.GenerateStream(ctx, w), ShouldNotBeNil)
+					So(w.tuples, ShouldHaveLength, 2)
+				})
+			})
+		})
+	}
+
+	Convey("Given an unsupported format", t, func() {
+		ctx := core.NewContext(nil)
+
+		Convey("When creating a file sink", func() {
+			_, err := createFileSink(ctx, &IOParams{}, data.Map{
+				"path":   data.String("out.xml"),
+				"format": data.String("xml"),
+			})
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+func TestStaticSource(t *testing.T) {
+	Convey("Given static source parameters", t, func() {
+		ctx := core.NewContext(nil)
+		now := time.Now()
+		params := data.Map{
+			"tuples": data.Array{
+				data.Map{"int": data.Int(1), "ts": data.Timestamp(now)},
+				data.Map{"int": data.Int(2), "ts": data.Timestamp(now)},
+			},
+		}
+		w := &testTupleCollector{}
+		w.c = sync.NewCond(&w.m)
+
+		Convey("When creating a static source with default params", func() {
+			s, err := createStaticSource(ctx, &IOParams{}, params)
+			So(err, ShouldBeNil)
+			Reset(func() {
+				s.Stop(ctx)
+			})
+
+			So(s.GenerateStream(ctx, w), ShouldBeNil)
+
+			Convey("Then it should emit all tuples in order", func() {
+				So(w.tuples, ShouldHaveLength, 2)
+				for i, t := range w.tuples {
+					So(t.Data["int"], ShouldEqual, data.Int(i+1))
+				}
+			})
+		})
+
+		Convey("When creating a static source with custom timestamp field", func() {
+			params["timestamp_field"] = data.String("ts")
+			s, err := createStaticSource(ctx, &IOParams{}, params)
+			So(err, ShouldBeNil)
+			Reset(func() {
+				s.Stop(ctx)
+			})
+
+			So(s.GenerateStream(ctx, w), ShouldBeNil)
+
+			Convey("Then it should have custom timestamps", func() {
+				So(w.tuples, ShouldHaveLength, 2)
+				for _, t := range w.tuples {
+					So(t.Timestamp, ShouldHappenOnOrBetween, now, now)
+				}
+			})
+		})
+
+		Convey("When creating a static source with a timezone parameter", func() {
+			params["tuples"] = data.Array{
+				data.Map{"ts": data.String("2016-01-02 03:04:05")},
+				data.Map{"ts": data.String("2016-01-02T03:04:05Z")},
+			}
+			params["timestamp_field"] = data.String("ts")
+			params["timezone"] = data.String("+09:00")
+			s, err := createStaticSource(ctx, &IOParams{}, params)
+			So(err, ShouldBeNil)
+			Reset(func() {
+				s.Stop(ctx)
+			})
+
+			So(s.GenerateStream(ctx, w), ShouldBeNil)
+
+			Convey("Then timestamps without time zones should be in the time zone", func() {
+				So(w.tuples, ShouldHaveLength, 2)
+				So(w.tuples[0].Timestamp.Equal(time.Date(2016, 1, 1, 18, 4, 5, 0, time.UTC)), ShouldBeTrue)
+				So(w.tuples[1].Timestamp.Equal(time.Date(2016, 1, 2, 3, 4, 5, 0, time.UTC)), ShouldBeTrue)
+			})
+		})
+
+		Convey("When creating a static source with a repeat parameter", func() {
+			params["repeat"] = data.Int(2)
+			s, err := createStaticSource(ctx, &IOParams{}, params)
+			So(err, ShouldBeNil)
+			Reset(func() {
+				s.Stop(ctx)
+			})
+
+			So(s.GenerateStream(ctx, w), ShouldBeNil)
+
+			Convey("Then it should emit all tuples repeatedly", func() {
+				So(w.tuples, ShouldHaveLength, 6)
+			})
+		})
+
+		Convey("When creating a static source with invalid parameters", func() {
+			Convey("Then missing tuples parameter should result in an error", func() {
+				delete(params, "tuples")
+				_, err := createStaticSource(ctx, &IOParams{}, params)
+				So(err, ShouldNotBeNil)
+			})
+
+			Convey("Then tuples having a non-map element should result in an error", func() {
+				params["tuples"] = data.Array{data.Map{}, data.Int(1)}
+				_, err := createStaticSource(ctx, &IOParams{}, params)
+				So(err, ShouldNotBeNil)
+			})
+
+			Convey("Then ill-formed timestamp_path should result in an error", func() {
+				params["timestamp_field"] = data.String("/this/isnt/a/xpath")
+				_, err := createStaticSource(ctx, &IOParams{}, params)
+				So(err, ShouldNotBeNil)
+			})
+
+			Convey("Then an unknown timezone should result in an error", func() {
+				params["timezone"] = data.String("No/Such_Zone")
+				_, err := createStaticSource(ctx, &IOParams{}, params)
+				So(err, ShouldNotBeNil)
+			})
 		})
 	})
 }
@@ -426,5 +984,309 @@ func TestFileSink(t *testing.T) {
 				})
 			})
 		})
+
+		Convey("When create file sink with a path template", func() {
+			params := data.Map{
+				"path":           data.String(filepath.Join(tdir, "{{.device}}", "out.jsonl")),
+				"max_open_files": data.Int(1),
+			}
+			si, err := createFileSink(ctx, ioParams, params)
+			So(err, ShouldBeNil)
+			Reset(func() {
+				si.Close(ctx)
+			})
+
+			Convey("And when write tuples of different devices to the sink", func() {
+				for i, dev := range []string{"a", "b", "a"} {
+					tu := core.NewTuple(data.Map{"device": data.String(dev), "k": data.Int(i)})
+					So(si.Write(ctx, tu), ShouldBeNil)
+				}
+
+				Convey("Then each tuple should be written in the file of its device", func() {
+					actualByte, err := ioutil.ReadFile(filepath.Join(tdir, "a", "out.jsonl"))
+					So(err, ShouldBeNil)
+					So(string(actualByte), ShouldEqual, `{"device":"a","k":0}
+{"device":"a","k":2}
+`)
+					actualByte, err = ioutil.ReadFile(filepath.Join(tdir, "b", "out.jsonl"))
+					So(err, ShouldBeNil)
+					So(string(actualByte), ShouldEqual, `{"device":"b","k":1}
+`)
+				})
+			})
+
+			Convey("And when write a tuple going up to the parent directory", func() {
+				tu := core.NewTuple(data.Map{"device": data.String("..")})
+
+				Convey("Then it should fail", func() {
+					So(si.Write(ctx, tu), ShouldNotBeNil)
+				})
+			})
+		})
+
+		Convey("When create file sink with a buffer", func() {
+			fn := filepath.Join(tdir, "file_sink6.jsonl")
+			params := data.Map{
+				"path":        data.String(fn),
+				"buffer_size": data.Int(4096),
+			}
+			si, err := createFileSink(ctx, ioParams, params)
+			So(err, ShouldBeNil)
+			Reset(func() {
+				si.Close(ctx)
+			})
+
+			Convey("And when write a tuple to the sink", func() {
+				So(si.Write(ctx, core.NewTuple(data.Map{"k": data.Int(-1)})), ShouldBeNil)
+
+				Convey("Then the tuple should be buffered", func() {
+					actualByte, err := ioutil.ReadFile(fn)
+					So(err, ShouldBeNil)
+					So(actualByte, ShouldBeEmpty)
+				})
+
+				Convey("Then the tuple should be written in the file on close", func() {
+					So(si.Close(ctx), ShouldBeNil)
+					actualByte, err := ioutil.ReadFile(fn)
+					So(err, ShouldBeNil)
+					So(string(actualByte), ShouldEqual, `{"k":-1}
+`)
+				})
+
+				Convey("Then the tuple should be written in the file on flush", func() {
+					So(si.(core.Flusher).Flush(ctx), ShouldBeNil)
+					actualByte, err := ioutil.ReadFile(fn)
+					So(err, ShouldBeNil)
+					So(string(actualByte), ShouldEqual, `{"k":-1}
+`)
+				})
+			})
+
+			Convey("And when write a tuple larger than the buffer", func() {
+				So(si.Write(ctx, core.NewTuple(data.Map{"k": data.Int(-1)})), ShouldBeNil)
+				large := strings.Repeat("a", 5000)
+				So(si.Write(ctx, core.NewTuple(data.Map{"k": data.String(large)})), ShouldBeNil)
+
+				Convey("Then both tuples should be written in order", func() {
+					actualByte, err := ioutil.ReadFile(fn)
+					So(err, ShouldBeNil)
+					So(string(actualByte), ShouldEqual, `{"k":-1}
+{"k":"`+large+`"}
+`)
+				})
+			})
+		})
+
+		Convey("When create file sink with a buffer and a flush interval", func() {
+			fn := filepath.Join(tdir, "file_sink7.jsonl")
+			params := data.Map{
+				"path":           data.String(fn),
+				"buffer_size":    data.Int(4096),
+				"flush_interval": data.String("10ms"),
+				"fsync":          data.String("interval"),
+			}
+			si, err := createFileSink(ctx, ioParams, params)
+			So(err, ShouldBeNil)
+			Reset(func() {
+				si.Close(ctx)
+			})
+
+			Convey("And when write a tuple to the sink", func() {
+				So(si.Write(ctx, core.NewTuple(data.Map{"k": data.Int(-1)})), ShouldBeNil)
+
+				Convey("Then the tuple should be written in the file after the interval", func() {
+					var actualByte []byte
+					waitForExpectedCondition(func() bool {
+						actualByte, err = ioutil.ReadFile(fn)
+						return err == nil && len(actualByte) > 0
+					})
+					So(string(actualByte), ShouldEqual, `{"k":-1}
+`)
+				})
+			})
+		})
+
+		Convey("When create file sink syncing every write", func() {
+			fn := filepath.Join(tdir, "file_sink8.jsonl")
+			params := data.Map{
+				"path":        data.String(fn),
+				"buffer_size": data.Int(4096),
+				"fsync":       data.String("every_write"),
+			}
+			si, err := createFileSink(ctx, ioParams, params)
+			So(err, ShouldBeNil)
+			Reset(func() {
+				si.Close(ctx)
+			})
+
+			Convey("And when write a tuple to the sink", func() {
+				So(si.Write(ctx, core.NewTuple(data.Map{"k": data.Int(-1)})), ShouldBeNil)
+
+				Convey("Then the tuple should be written in the file immediately", func() {
+					actualByte, err := ioutil.ReadFile(fn)
+					So(err, ShouldBeNil)
+					So(string(actualByte), ShouldEqual, `{"k":-1}
+`)
+				})
+			})
+		})
+
+		Convey("When create file sink with invalid buffering parameters", func() {
+			fn := filepath.Join(tdir, "file_sink9.jsonl")
+
+			Convey("Then the sink should not be created", func() {
+				for _, params := range []data.Map{
+					{"buffer_size": data.Int(-1)},
+					{"flush_interval": data.String("-1s")},
+					{"fsync": data.String("always")},
+					{"fsync": data.String("interval")},
+					{"fsync": data.String("every_write"), "max_size": data.Int(10)},
+				} {
+					params["path"] = data.String(fn)
+					_, err := createFileSink(ctx, ioParams, params)
+					So(err, ShouldNotBeNil)
+				}
+			})
+		})
+	})
+}
+
+func TestKeyValueStateUDS(t *testing.T) {
+	Convey("Given a BQL TopologyBuilder", t, func() {
+		dt := newTestTopology()
+		Reset(func() {
+			dt.Stop()
+		})
+		tb, err := NewTopologyBuilder(dt)
+		So(err, ShouldBeNil)
+
+		Convey("When creating a kv state with a retention policy", func() {
+			So(addBQLToTopology(tb, `CREATE STATE devices TYPE kv
+				WITH key="id", max_entries=2, max_age=60, max_bytes=1000;`), ShouldBeNil)
+			st, err := dt.Context().SharedStates.Get("devices")
+			So(err, ShouldBeNil)
+			s, ok := st.(*core.KeyValueState)
+			So(ok, ShouldBeTrue)
+
+			Convey("Then it should have the policy", func() {
+				So(s.Status()["retention"], ShouldResemble, data.Map{
+					"max_entries": data.Int(2),
+					"max_age":     data.Float(60),
+					"max_bytes":   data.Int(1000),
+				})
+			})
+
+			Convey("And writing tuples to it", func() {
+				for i := 0; i < 3; i++ {
+					So(s.Write(dt.Context(), core.NewTuple(data.Map{"id": data.String(fmt.Sprint("d", i))})), ShouldBeNil)
+				}
+
+				Convey("Then kv_get should return values of remaining keys", func() {
+					f, err := tb.Reg.Lookup("kv_get", 2)
+					So(err, ShouldBeNil)
+					v, err := f.Call(dt.Context(), data.String("devices"), data.String("d2"))
+					So(err, ShouldBeNil)
+					So(v, ShouldResemble, data.Map{"id": data.String("d2")})
+					v, err = f.Call(dt.Context(), data.String("devices"), data.String("d0"))
+					So(err, ShouldBeNil)
+					So(v, ShouldResemble, data.Null{})
+				})
+
+				Convey("Then state_statuses should report the status", func() {
+					src, err := createStateStatusSource(dt.Context(), &IOParams{}, data.Map{"interval": data.Float(0.01)})
+					So(err, ShouldBeNil)
+					w := &testTupleCollector{}
+					w.c = sync.NewCond(&w.m)
+					go src.GenerateStream(dt.Context(), w)
+					w.wait(1)
+					So(src.Stop(dt.Context()), ShouldBeNil)
+
+					w.m.Lock()
+					defer w.m.Unlock()
+					m := w.tuples[0].Data
+					So(m["state_name"], ShouldEqual, "devices")
+					So(m["state_type"], ShouldEqual, "kv")
+					So(m["num_entries"], ShouldEqual, 2)
+					So(m["num_evicted_by_entries"], ShouldEqual, 1)
+				})
+			})
+		})
+
+		Convey("When creating a kv state with an invalid policy", func() {
+			err := addBQLToTopology(tb, `CREATE STATE devices TYPE kv WITH max_entries=-1;`)
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+func TestOffsetsStateUDS(t *testing.T) {
+	Convey("Given a BQL TopologyBuilder with an offsets state", t, func() {
+		dt := newTestTopology()
+		Reset(func() {
+			dt.Stop()
+		})
+		tb, err := NewTopologyBuilder(dt)
+		So(err, ShouldBeNil)
+		So(addBQLToTopology(tb, `CREATE STATE offsets TYPE offsets;`), ShouldBeNil)
+		st, err := dt.Context().SharedStates.Get("offsets")
+		So(err, ShouldBeNil)
+		s, ok := st.(*core.OffsetsState)
+		So(ok, ShouldBeTrue)
+		So(s.Commit("src", data.Map{"offset": data.Int(10)}), ShouldBeNil)
+
+		call := func(name string, args ...data.Value) data.Value {
+			f, err := tb.Reg.Lookup(name, len(args))
+			So(err, ShouldBeNil)
+			v, err := f.Call(dt.Context(), args...)
+			So(err, ShouldBeNil)
+			return v
+		}
+
+		Convey("When inspecting offsets by functions", func() {
+			Convey("Then offsets_get should return offsets of the source", func() {
+				So(call("offsets_get", data.String("offsets"), data.String("src")), ShouldResemble,
+					data.Map{"offset": data.Int(10)})
+				So(call("offsets_get", data.String("offsets"), data.String("other")), ShouldResemble, data.Null{})
+			})
+
+			Convey("Then offsets_list should return offsets of all sources", func() {
+				m, err := data.AsMap(call("offsets_list", data.String("offsets")))
+				So(err, ShouldBeNil)
+				So(m, ShouldHaveLength, 1)
+				So(m["src"].(data.Map)["offsets"], ShouldResemble, data.Map{"offset": data.Int(10)})
+			})
+		})
+
+		Convey("When resetting offsets by offsets_reset", func() {
+			So(call("offsets_reset", data.String("offsets"), data.String("src")), ShouldEqual, data.True)
+
+			Convey("Then the source shouldn't have offsets", func() {
+				_, ok := s.Get("src")
+				So(ok, ShouldBeFalse)
+				So(call("offsets_reset", data.String("offsets"), data.String("src")), ShouldEqual, data.False)
+			})
+		})
+
+		Convey("When saving the state and loading it after offsets are changed", func() {
+			So(addBQLToTopology(tb, `SAVE STATE offsets;`), ShouldBeNil)
+			So(s.Commit("src", data.Map{"offset": data.Int(20)}), ShouldBeNil)
+			So(addBQLToTopology(tb, `LOAD STATE offsets TYPE offsets;`), ShouldBeNil)
+
+			Convey("Then the saved offsets should be restored", func() {
+				So(call("offsets_get", data.String("offsets"), data.String("src")), ShouldResemble,
+					data.Map{"offset": data.Int(10)})
+			})
+		})
+
+		Convey("When creating an offsets state with a parameter", func() {
+			err := addBQLToTopology(tb, `CREATE STATE offsets2 TYPE offsets WITH path="/tmp";`)
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
 	})
 }
@@ -0,0 +1,107 @@
+package bql
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/parser"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+func TestTopologyConstants(t *testing.T) {
+	Convey("Given a BQL TopologyBuilder having constants", t, func() {
+		dt, err := core.NewDefaultTopology(core.NewContext(&core.ContextConfig{
+			Constants: data.Map{"num": data.Int(4)},
+		}), "testTopology")
+		So(err, ShouldBeNil)
+		Reset(func() {
+			dt.Stop()
+		})
+		tb, err := NewTopologyBuilder(dt)
+		So(err, ShouldBeNil)
+
+		Convey("When setting a constant by SET CONSTANT", func() {
+			So(addBQLToTopology(tb, `SET CONSTANT threshold = 1 + 1;`), ShouldBeNil)
+
+			Convey("Then the constant should be set to the context", func() {
+				v, err := dt.Context().Constant("threshold")
+				So(err, ShouldBeNil)
+				So(v, ShouldEqual, data.Int(2))
+			})
+		})
+
+		Convey("When using constants in statements", func() {
+			So(addBQLToTopology(tb, `
+				SET CONSTANT threshold = 2;
+				CREATE PAUSED SOURCE source TYPE dummy WITH num=$num;
+				CREATE STREAM box AS SELECT ISTREAM int FROM source [RANGE 1 TUPLES] WHERE int > $threshold;
+				CREATE SINK snk TYPE collector;
+				INSERT INTO snk FROM box;`), ShouldBeNil)
+			sin, err := dt.Sink("snk")
+			So(err, ShouldBeNil)
+			si := sin.Sink().(*tupleCollectorSink)
+
+			Convey("Then the stream should only emit tuples satisfying the condition", func() {
+				So(addBQLToTopology(tb, `RESUME SOURCE source;`), ShouldBeNil)
+				si.Wait(2)
+				So(si.len(), ShouldEqual, 2)
+				So(si.get(0).Data["int"], ShouldEqual, data.Int(3))
+				So(si.get(1).Data["int"], ShouldEqual, data.Int(4))
+			})
+
+			Convey("Then updating the constant shouldn't affect the existing stream", func() {
+				So(addBQLToTopology(tb, `SET CONSTANT threshold = 3;`), ShouldBeNil)
+				So(addBQLToTopology(tb, `RESUME SOURCE source;`), ShouldBeNil)
+				si.Wait(2)
+				So(si.len(), ShouldEqual, 2)
+				So(si.get(0).Data["int"], ShouldEqual, data.Int(3))
+			})
+		})
+
+		Convey("When using an undefined constant", func() {
+			So(addBQLToTopology(tb, `CREATE PAUSED SOURCE source TYPE dummy`), ShouldBeNil)
+			for _, s := range []string{
+				`CREATE SOURCE source2 TYPE dummy WITH num=$undefined`,
+				`CREATE STREAM box AS SELECT ISTREAM int FROM source [RANGE 1 TUPLES] WHERE int > $undefined`,
+				`SET CONSTANT a = $undefined`,
+			} {
+				err := addBQLToTopology(tb, s)
+
+				Convey("Then the statement should fail: "+s, func() {
+					So(err, ShouldNotBeNil)
+				})
+			}
+		})
+
+		Convey("When setting a constant to a non-foldable expression", func() {
+			err := addBQLToTopology(tb, `SET CONSTANT a = x + 1`)
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When planning statements using constants", func() {
+			stmts, err := parser.New().ParseStmts(`
+				SET CONSTANT path = "/tmp";
+				CREATE SOURCE source TYPE dummy WITH num=$num, path=$path;`)
+			So(err, ShouldBeNil)
+			plan := tb.Plan(stmts)
+
+			Convey("Then parameters should have values of the constants", func() {
+				So(plan.Errors, ShouldBeEmpty)
+				So(plan.Nodes, ShouldHaveLength, 1)
+				So(plan.Nodes[0].Params, ShouldResemble, data.Map{
+					"num":  data.Int(4),
+					"path": data.String("/tmp"),
+				})
+			})
+
+			Convey("Then the constant shouldn't be set to the topology", func() {
+				_, err := dt.Context().Constant("path")
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
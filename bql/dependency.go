@@ -0,0 +1,362 @@
+package bql
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/sensorbee/sensorbee.v0/bql/parser"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+)
+
+const (
+	// OrphanStreamWarning is the kind of a PlanWarning of a stream whose
+	// output isn't consumed by any stream or sink.
+	OrphanStreamWarning = "orphan_stream"
+
+	// UnreachableSinkWarning is the kind of a PlanWarning of a sink which
+	// doesn't receive tuples from any source.
+	UnreachableSinkWarning = "unreachable_sink"
+)
+
+// PlanWarning is a possible mistake in the dependency graph of nodes in a
+// plan. Unlike PlanError, it doesn't prevent statements from being
+// executed.
+type PlanWarning struct {
+	// Kind is OrphanStreamWarning or UnreachableSinkWarning.
+	Kind string
+
+	// Node is the name of the node.
+	Node string
+
+	// Message describes the warning.
+	Message string
+}
+
+func (w *PlanWarning) String() string {
+	return w.Message
+}
+
+// planWarnings returns warnings of nodes created in the plan. Nodes already
+// existing in the topology aren't reported since their inputs aren't known
+// to the plan.
+func planWarnings(nodes []*PlannedNode) []*PlanWarning {
+	byName := make(map[string]*PlannedNode, len(nodes))
+	consumed := map[string]bool{}
+	for _, n := range nodes {
+		byName[strings.ToLower(n.Name)] = n
+		for _, in := range n.Inputs {
+			consumed[strings.ToLower(in)] = true
+		}
+	}
+
+	// A node is reachable when it receives tuples from a source directly or
+	// indirectly. UDSFs generate tuples by themselves, and existing nodes
+	// are assumed to be reachable.
+	reachable := map[string]bool{}
+	for _, n := range nodes {
+		if n.Existing || n.NodeType == core.NTSource {
+			reachable[strings.ToLower(n.Name)] = true
+		}
+	}
+	for changed := true; changed; {
+		changed = false
+		for _, n := range nodes {
+			name := strings.ToLower(n.Name)
+			if reachable[name] {
+				continue
+			}
+			for _, in := range n.Inputs {
+				in = strings.ToLower(in)
+				_, isNode := byName[in]
+				if reachable[in] || (!isNode && strings.HasSuffix(in, ")")) {
+					reachable[name] = true
+					changed = true
+					break
+				}
+			}
+		}
+	}
+
+	var ws []*PlanWarning
+	for _, n := range nodes {
+		if n.Existing {
+			continue
+		}
+		switch n.NodeType {
+		case core.NTBox:
+			if !consumed[strings.ToLower(n.Name)] {
+				ws = append(ws, &PlanWarning{
+					Kind:    OrphanStreamWarning,
+					Node:    n.Name,
+					Message: fmt.Sprintf("stream '%v' isn't used by any stream or sink", n.Name),
+				})
+			}
+		case core.NTSink:
+			if !reachable[strings.ToLower(n.Name)] {
+				ws = append(ws, &PlanWarning{
+					Kind:    UnreachableSinkWarning,
+					Node:    n.Name,
+					Message: fmt.Sprintf("sink '%v' doesn't receive tuples from any source", n.Name),
+				})
+			}
+		}
+	}
+	return ws
+}
+
+// stmtNames returns names of nodes defined and referred by the statement.
+func stmtNames(stmt interface{}) (defines, refs []string) {
+	relations := func(s *parser.SelectStmt) {
+		for _, rel := range s.Relations {
+			if rel.Type == parser.ActualStream {
+				refs = append(refs, rel.Name)
+			}
+		}
+	}
+	switch stmt := stmt.(type) {
+	case parser.CreateSourceStmt:
+		defines = append(defines, string(stmt.Name))
+	case parser.CreateStreamAsSelectStmt:
+		defines = append(defines, string(stmt.Name))
+		relations(&stmt.Select)
+	case parser.CreateStreamAsSelectUnionStmt:
+		defines = append(defines, string(stmt.Name))
+		for i := range stmt.Selects {
+			relations(&stmt.Selects[i])
+		}
+	case parser.CreateStreamAsEnrichStmt:
+		defines = append(defines, string(stmt.Name))
+		refs = append(refs, string(stmt.Input))
+	case parser.CreateSinkStmt:
+		defines = append(defines, string(stmt.Name))
+	case parser.ReplayArchiveStmt:
+		defines = append(defines, string(stmt.Stream))
+	case parser.SplitStmt:
+		for _, br := range stmt.Branches {
+			defines = append(defines, string(br.Name))
+		}
+		refs = append(refs, string(stmt.Input))
+	case parser.InsertIntoFromStmt:
+		refs = append(refs, string(stmt.Sink))
+		for _, in := range stmt.Inputs {
+			refs = append(refs, string(in))
+		}
+	case parser.InsertIntoSelectStmt:
+		refs = append(refs, string(stmt.Sink))
+		relations(&stmt.Select)
+	case parser.ArchiveStreamStmt:
+		refs = append(refs, string(stmt.Stream))
+	case parser.UpdateSourceStmt:
+		refs = append(refs, string(stmt.Name))
+	case parser.UpdateSinkStmt:
+		refs = append(refs, string(stmt.Name))
+	case parser.PauseSourceStmt:
+		refs = append(refs, string(stmt.Source))
+	case parser.ResumeSourceStmt:
+		refs = append(refs, string(stmt.Source))
+	case parser.RewindSourceStmt:
+		refs = append(refs, string(stmt.Source))
+	case parser.DropSourceStmt:
+		refs = append(refs, string(stmt.Source))
+	case parser.DropStreamStmt:
+		refs = append(refs, string(stmt.Stream))
+	case parser.DropSinkStmt:
+		refs = append(refs, string(stmt.Sink))
+	}
+	return
+}
+
+// stmtOrder returns indices of statements submitted together, such as ones
+// in a BQL file, in the order of execution. A statement referring to a node
+// defined by a later statement is executed after the definition. Other
+// statements keep their order, and so do statements referring to the same
+// node. A reference to a node existing in the topology isn't regarded as a
+// forward reference.
+//
+// It returns a PlanError when nodes refer to each other cyclically.
+func (tb *TopologyBuilder) stmtOrder(stmts []interface{}) ([]int, error) {
+	type touch struct {
+		stmt    int
+		defines bool
+	}
+	touches := map[string][]touch{}
+	var names []string
+	for i, stmt := range stmts {
+		defines, refs := stmtNames(stmt)
+		for _, ns := range []struct {
+			names   []string
+			defines bool
+		}{{defines, true}, {refs, false}} {
+			for _, n := range ns.names {
+				n = strings.ToLower(n)
+				ts := touches[n]
+				if len(ts) > 0 && ts[len(ts)-1].stmt == i {
+					ts[len(ts)-1].defines = ts[len(ts)-1].defines || ns.defines
+					continue
+				}
+				if len(ts) == 0 {
+					names = append(names, n)
+				}
+				touches[n] = append(ts, touch{i, ns.defines})
+			}
+		}
+	}
+
+	// preds[i] has statements which must be executed before stmts[i].
+	preds := make([]map[int]bool, len(stmts))
+	for i := range preds {
+		preds[i] = map[int]bool{}
+	}
+	for _, n := range names {
+		ts := touches[n]
+		first := 0
+		if _, err := tb.topology.Node(n); err != nil {
+			// Statements referring to the node before its first definition
+			// are deferred until the definition.
+			for first < len(ts) && !ts[first].defines {
+				first++
+			}
+			if first == len(ts) {
+				first = 0 // Plan reports the missing node
+			}
+			for k := 0; k < first; k++ {
+				preds[ts[k].stmt][ts[first].stmt] = true
+			}
+		}
+		for k := first + 1; k < len(ts); k++ {
+			preds[ts[k].stmt][ts[k-1].stmt] = true
+		}
+	}
+
+	// Statements whose predecessors have been executed are executed in the
+	// original order.
+	order := make([]int, 0, len(stmts))
+	done := make([]bool, len(stmts))
+	for len(order) < len(stmts) {
+		next := -1
+		for i := range stmts {
+			if done[i] {
+				continue
+			}
+			ready := true
+			for p := range preds[i] {
+				if !done[p] {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				next = i
+				break
+			}
+		}
+		if next < 0 {
+			return nil, cyclicDependencyError(stmts, preds, done)
+		}
+		done[next] = true
+		order = append(order, next)
+	}
+	return order, nil
+}
+
+// cyclicDependencyError finds a cycle in statements which cannot be
+// executed and returns an error describing it. Every such statement has at
+// least one predecessor which cannot be executed either.
+func cyclicDependencyError(stmts []interface{}, preds []map[int]bool, done []bool) error {
+	start := 0
+	for done[start] {
+		start++
+	}
+	visited := map[int]int{}
+	var path []int
+	i := start
+	for {
+		if pos, ok := visited[i]; ok {
+			path = path[pos:]
+			break
+		}
+		visited[i] = len(path)
+		path = append(path, i)
+		ps := make([]int, 0, len(preds[i]))
+		for p := range preds[i] {
+			if !done[p] {
+				ps = append(ps, p)
+			}
+		}
+		sort.Ints(ps)
+		i = ps[0]
+	}
+
+	// The path follows predecessors, so it's reversed to follow the flow of
+	// tuples. The description starts from the first statement in the cycle.
+	first := 0
+	for k := range path {
+		if path[k] < path[first] {
+			first = k
+		}
+	}
+	desc := make([]string, 0, len(path)+1)
+	for k := 0; k <= len(path); k++ {
+		i := path[(first-k+len(path))%len(path)]
+		if defines, _ := stmtNames(stmts[i]); len(defines) > 0 {
+			desc = append(desc, defines[0])
+		} else {
+			desc = append(desc, fmt.Sprint(stmts[i]))
+		}
+	}
+	return &PlanError{
+		Index: path[first],
+		Stmt:  stmts[path[first]],
+		Err: core.CodedError(core.ErrCodeInvalidArgument,
+			fmt.Errorf("nodes have a cyclic dependency: %v", strings.Join(desc, " -> "))),
+	}
+}
+
+// ValidateStmts checks statements submitted together before any of them is
+// executed so that a topology isn't left half-built by a statement failing
+// in the middle. Statements are allowed to refer to nodes defined by later
+// statements in stmts, and they're reordered so that definitions come
+// first. It returns the statements in the order in which they should be
+// executed and warnings of the plan of them.
+//
+// The returned error is a PlanError whose Index is the index in stmts. It's
+// also returned when nodes refer to each other cyclically. Statements not
+// supported by Plan, such as SELECT, aren't checked.
+func (tb *TopologyBuilder) ValidateStmts(stmts []interface{}) ([]interface{}, []*PlanWarning, error) {
+	order, ws, err := tb.validateStmts(stmts)
+	if err != nil {
+		return nil, nil, err
+	}
+	ordered := make([]interface{}, len(order))
+	for i, idx := range order {
+		ordered[i] = stmts[idx]
+	}
+	return ordered, ws, nil
+}
+
+// validateStmts is ValidateStmts which returns indices of statements in the
+// order in which they should be executed.
+func (tb *TopologyBuilder) validateStmts(stmts []interface{}) ([]int, []*PlanWarning, error) {
+	order, err := tb.stmtOrder(stmts)
+	if err != nil {
+		return nil, nil, err
+	}
+	ordered := make([]interface{}, len(order))
+	for i, idx := range order {
+		ordered[i] = stmts[idx]
+	}
+
+	plan := tb.Plan(ordered)
+	for _, e := range plan.Errors {
+		if _, ok := e.Err.(*unplannableStmtError); ok {
+			continue
+		}
+		return nil, nil, &PlanError{
+			Index: order[e.Index],
+			Stmt:  e.Stmt,
+			Err:   e.Err,
+		}
+	}
+	return order, plan.Warnings, nil
+}
@@ -0,0 +1,156 @@
+package bql
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/parser"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+)
+
+func TestTopologyBuilderValidateStmts(t *testing.T) {
+	Convey("Given a BQL TopologyBuilder", t, func() {
+		dt := newTestTopology()
+		Reset(func() {
+			dt.Stop()
+		})
+		tb, err := NewTopologyBuilder(dt)
+		So(err, ShouldBeNil)
+
+		validate := func(bql string) ([]string, []*PlanWarning, error) {
+			stmts, err := parser.New().ParseStmts(bql)
+			So(err, ShouldBeNil)
+			ordered, ws, err := tb.ValidateStmts(stmts)
+			var strs []string
+			for _, s := range ordered {
+				strs = append(strs, fmt.Sprint(s))
+			}
+			return strs, ws, err
+		}
+
+		Convey("When validating statements in the order of dependencies", func() {
+			stmts, ws, err := validate(`CREATE PAUSED SOURCE s TYPE dummy;
+				CREATE STREAM a AS SELECT ISTREAM * FROM s [RANGE 1 TUPLES];
+				CREATE SINK snk TYPE collector;
+				INSERT INTO snk FROM a;`)
+
+			Convey("Then the order should be kept", func() {
+				So(err, ShouldBeNil)
+				So(ws, ShouldBeEmpty)
+				So(stmts, ShouldHaveLength, 4)
+				So(stmts[0], ShouldStartWith, "CREATE PAUSED SOURCE s")
+				So(stmts[3], ShouldStartWith, "INSERT INTO snk")
+			})
+		})
+
+		Convey("When validating statements having forward references", func() {
+			stmts, ws, err := validate(`CREATE SINK snk TYPE collector;
+				INSERT INTO snk FROM b;
+				CREATE STREAM b AS SELECT ISTREAM * FROM a [RANGE 1 TUPLES];
+				CREATE STREAM a AS SELECT ISTREAM * FROM s [RANGE 1 TUPLES];
+				CREATE PAUSED SOURCE s TYPE dummy;
+				RESUME SOURCE s;`)
+
+			Convey("Then definitions should come first", func() {
+				So(err, ShouldBeNil)
+				So(ws, ShouldBeEmpty)
+				So(stmts, ShouldHaveLength, 6)
+				So(stmts[0], ShouldStartWith, "CREATE SINK snk")
+				So(stmts[1], ShouldStartWith, "CREATE PAUSED SOURCE s")
+				So(stmts[2], ShouldStartWith, "CREATE STREAM a")
+				So(stmts[3], ShouldStartWith, "CREATE STREAM b")
+				So(stmts[4], ShouldStartWith, "INSERT INTO snk")
+				So(stmts[5], ShouldStartWith, "RESUME SOURCE s")
+			})
+
+			Convey("Then the topology should be built from them", func() {
+				for _, s := range stmts {
+					So(addBQLToTopology(tb, s+";"), ShouldBeNil)
+				}
+				So(dt.Nodes(), ShouldHaveLength, 4)
+			})
+		})
+
+		Convey("When validating statements dropping and creating a node", func() {
+			stmts, _, err := validate(`CREATE PAUSED SOURCE s TYPE dummy;
+				DROP SOURCE s;
+				CREATE PAUSED SOURCE s TYPE dummy;`)
+
+			Convey("Then the order should be kept", func() {
+				So(err, ShouldBeNil)
+				So(stmts[1], ShouldStartWith, "DROP SOURCE s")
+			})
+		})
+
+		Convey("When validating statements referring to an existing node", func() {
+			So(addBQLToTopology(tb, `CREATE PAUSED SOURCE s TYPE dummy;`), ShouldBeNil)
+			stmts, _, err := validate(`DROP SOURCE s;
+				CREATE STREAM a AS SELECT ISTREAM * FROM s [RANGE 1 TUPLES];
+				CREATE PAUSED SOURCE s TYPE dummy;`)
+
+			Convey("Then the reference shouldn't be regarded as a forward reference", func() {
+				So(err, ShouldNotBeNil)
+				So(stmts, ShouldBeNil)
+				So(err.(*PlanError).Index, ShouldEqual, 1)
+			})
+		})
+
+		Convey("When validating statements referring to a missing node", func() {
+			_, _, err := validate(`CREATE PAUSED SOURCE s TYPE dummy;
+				CREATE SINK snk TYPE collector;
+				INSERT INTO snk FROM a;`)
+
+			Convey("Then it should fail with the index of the statement", func() {
+				So(err, ShouldNotBeNil)
+				So(err.(*PlanError).Index, ShouldEqual, 2)
+			})
+
+			Convey("Then no node should be created", func() {
+				So(dt.Nodes(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When validating streams referring to each other", func() {
+			_, _, err := validate(`CREATE PAUSED SOURCE s TYPE dummy;
+				CREATE STREAM a AS SELECT ISTREAM * FROM s [RANGE 1 TUPLES], c [RANGE 1 TUPLES];
+				CREATE STREAM b AS SELECT ISTREAM * FROM a [RANGE 1 TUPLES];
+				CREATE STREAM c AS SELECT ISTREAM * FROM b [RANGE 1 TUPLES];`)
+
+			Convey("Then it should fail with the cycle", func() {
+				So(err, ShouldNotBeNil)
+				So(err.(*PlanError).Index, ShouldEqual, 1)
+				So(err.Error(), ShouldContainSubstring, "a -> b -> c -> a")
+				So(core.ErrorCode(err), ShouldEqual, core.ErrCodeInvalidArgument)
+			})
+		})
+
+		Convey("When validating statements having unused nodes", func() {
+			_, ws, err := validate(`CREATE PAUSED SOURCE s TYPE dummy;
+				CREATE STREAM a AS SELECT ISTREAM * FROM s [RANGE 1 TUPLES];
+				CREATE STREAM b AS SELECT ISTREAM * FROM a [RANGE 1 TUPLES];
+				CREATE SINK snk1 TYPE collector;
+				CREATE SINK snk2 TYPE collector;
+				INSERT INTO snk2 FROM a;`)
+
+			Convey("Then they should be reported as warnings", func() {
+				So(err, ShouldBeNil)
+				So(ws, ShouldHaveLength, 2)
+				So(ws[0].Kind, ShouldEqual, OrphanStreamWarning)
+				So(ws[0].Node, ShouldEqual, "b")
+				So(ws[1].Kind, ShouldEqual, UnreachableSinkWarning)
+				So(ws[1].Node, ShouldEqual, "snk1")
+			})
+		})
+
+		Convey("When validating statements having SELECT statements", func() {
+			stmts, _, err := validate(`CREATE PAUSED SOURCE s TYPE dummy;
+				SELECT RSTREAM * FROM s [RANGE 1 TUPLES];`)
+
+			Convey("Then they should be ignored", func() {
+				So(err, ShouldBeNil)
+				So(stmts, ShouldHaveLength, 2)
+			})
+		})
+	})
+}
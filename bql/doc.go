@@ -0,0 +1,28 @@
+// Package bql provides the BQL (Basic Query Language) processor and builtin
+// sources and sinks.
+//
+// TopologyBuilder creates nodes in a core.Topology either from parsed BQL
+// statements (AddStmt) or from Go code (AddSource, AddBQLStream, and
+// AddSink). The latter allows applications to embed SensorBee as a library
+// without going through BQL text for sources and sinks or running the server:
+//
+//	tp, err := core.NewDefaultTopology(core.NewContext(nil), "app")
+//	...
+//	tb, err := bql.NewTopologyBuilder(tp)
+//	...
+//	src, err := tb.AddSource("src", "static", data.Map{
+//		"tuples": data.Array{data.Map{"a": data.Int(1)}},
+//	}, &core.SourceConfig{PausedOnStartup: true})
+//	box, err := tb.AddBQLStream("s", "SELECT RSTREAM a FROM src [RANGE 1 TUPLES]")
+//	sink, err := tb.AddSink("out", "stdout", nil, nil)
+//	err = sink.Input(box.Name(), nil)
+//	err = src.Resume()
+//
+// Compatibility: the signatures and the behavior of NewTopologyBuilder,
+// TopologyBuilder.AddSource, AddBQLStream, AddSink, AddStmt, and Topology are
+// stable and won't be changed in a backward incompatible way within the same
+// major version (i.e. gopkg.in/sensorbee/sensorbee.v0). New methods and new
+// fields of config structs may be added. Other exported fields of
+// TopologyBuilder, such as registries, may change when it's required to
+// support new features.
+package bql
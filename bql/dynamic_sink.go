@@ -0,0 +1,295 @@
+package bql
+
+import (
+	"bytes"
+	"container/list"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// DestinationTemplate computes a destination of a sink, such as a file path
+// or a topic name, from a tuple. It's written in the syntax of Go's
+// text/template and fields of the tuple can be referred like
+// "/data/{{.device_id}}.jsonl". Referring to a missing field results in an
+// error. In addition to built-in functions of text/template, it provides
+// following functions:
+//
+//	- time_format: formats a timestamp with a Go's time layout, e.g.
+//	  {{time_format .ts "2006-01-02"}}. The timestamp of the tuple is used
+//	  when the value is omitted as {{time_format "2006-01-02"}}.
+type DestinationTemplate struct {
+	str    string
+	tmpl   *template.Template
+	static bool
+}
+
+// NewDestinationTemplate parses a template of a destination. A string
+// without any action such as "/data/out.jsonl" is a valid template and it
+// always results in the same destination.
+func NewDestinationTemplate(str string) (*DestinationTemplate, error) {
+	t, err := template.New("destination").Option("missingkey=error").
+		Funcs(template.FuncMap{
+			// The actual implementation is given in Execute since it
+			// depends on the tuple.
+			"time_format": func(args ...interface{}) (string, error) { return "", nil },
+		}).Parse(str)
+	if err != nil {
+		return nil, fmt.Errorf("invalid destination template: %v", err)
+	}
+	return &DestinationTemplate{
+		str:    str,
+		tmpl:   t,
+		static: !strings.Contains(str, "{{"),
+	}, nil
+}
+
+// String returns the template string.
+func (t *DestinationTemplate) String() string {
+	return t.str
+}
+
+// IsStatic returns true when the template doesn't depend on tuples.
+func (t *DestinationTemplate) IsStatic() bool {
+	return t.static
+}
+
+// Execute computes the destination for the tuple.
+func (t *DestinationTemplate) Execute(tu *core.Tuple) (string, error) {
+	if t.static {
+		return t.str, nil
+	}
+	tmpl, err := t.tmpl.Clone()
+	if err != nil {
+		return "", err
+	}
+	tmpl.Funcs(template.FuncMap{
+		"time_format": func(args ...interface{}) (string, error) {
+			ts := tu.Timestamp
+			switch len(args) {
+			case 1:
+			case 2:
+				if tm, ok := args[0].(time.Time); ok {
+					ts = tm
+					break
+				}
+				v, err := data.NewValue(args[0])
+				if err != nil {
+					return "", fmt.Errorf("time_format cannot format %v", args[0])
+				}
+				tm, err := data.ToTimestamp(v)
+				if err != nil {
+					return "", err
+				}
+				ts = tm
+			default:
+				return "", errors.New("time_format needs a layout and an optional timestamp")
+			}
+			layout, ok := args[len(args)-1].(string)
+			if !ok {
+				return "", fmt.Errorf("the layout must be a string: %v", args[len(args)-1])
+			}
+			return ts.In(time.UTC).Format(layout), nil
+		},
+	})
+
+	b := bytes.NewBuffer(nil)
+	if err := tmpl.Execute(b, templateValue(tu.Data)); err != nil {
+		return "", fmt.Errorf("cannot compute the destination: %v", err)
+	}
+	return b.String(), nil
+}
+
+// templateValue converts a value so that it's printed in a template as it
+// is. For example, data.String is printed with quotes without conversion.
+func templateValue(v data.Value) interface{} {
+	switch v.Type() {
+	case data.TypeBool:
+		b, _ := data.AsBool(v)
+		return b
+	case data.TypeInt:
+		i, _ := data.AsInt(v)
+		return i
+	case data.TypeFloat:
+		f, _ := data.AsFloat(v)
+		return f
+	case data.TypeString:
+		str, _ := data.AsString(v)
+		return str
+	case data.TypeTimestamp:
+		t, _ := data.AsTimestamp(v)
+		return t
+	case data.TypeArray:
+		a, _ := data.AsArray(v)
+		res := make([]interface{}, len(a))
+		for i, e := range a {
+			res[i] = templateValue(e)
+		}
+		return res
+	case data.TypeMap:
+		m, _ := data.AsMap(v)
+		res := make(map[string]interface{}, len(m))
+		for k, e := range m {
+			res[k] = templateValue(e)
+		}
+		return res
+	default:
+		return v
+	}
+}
+
+// DynamicSinkConfig has parameters of a dynamic sink.
+type DynamicSinkConfig struct {
+	// Template computes the destination of each tuple.
+	Template *DestinationTemplate
+
+	// MaxOpen is the maximum number of destinations opened at once. When a
+	// tuple is written to a new destination and there're already MaxOpen
+	// destinations, the least recently used one is closed. It's unlimited
+	// when MaxOpen is 0.
+	MaxOpen int
+
+	// Open creates a sink writing tuples to the destination. It may be
+	// called for the same destination again after the destination was
+	// closed, so it shouldn't discard data written previously.
+	Open func(ctx *core.Context, dest string) (core.Sink, error)
+}
+
+type dynamicSinkEntry struct {
+	dest string
+	sink core.Sink
+}
+
+// dynamicSink routes each tuple to a sink of the destination computed from
+// the tuple. Sinks of destinations are cached.
+type dynamicSink struct {
+	m      sync.Mutex
+	config DynamicSinkConfig
+	sinks  map[string]*list.Element
+	lru    *list.List // the front is the most recently used destination.
+	closed bool
+}
+
+// NewDynamicSink creates a sink writing tuples to destinations computed from
+// tuples, for example, a file for each device.
+func NewDynamicSink(config *DynamicSinkConfig) (core.Sink, error) {
+	if config.Template == nil {
+		return nil, errors.New("the destination template is missing")
+	}
+	if config.Open == nil {
+		return nil, errors.New("the open function is missing")
+	}
+	if config.MaxOpen < 0 {
+		return nil, fmt.Errorf("the maximum number of open destinations must not be negative: %v", config.MaxOpen)
+	}
+	return &dynamicSink{
+		config: *config,
+		sinks:  map[string]*list.Element{},
+		lru:    list.New(),
+	}, nil
+}
+
+func (s *dynamicSink) Write(ctx *core.Context, t *core.Tuple) error {
+	dest, err := s.config.Template.Execute(t)
+	if err != nil {
+		return err
+	}
+
+	// Writes are serialized so that a sink isn't closed while it's being
+	// written.
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.closed {
+		return errors.New("the sink is already closed")
+	}
+
+	if e, ok := s.sinks[dest]; ok {
+		s.lru.MoveToFront(e)
+		return e.Value.(*dynamicSinkEntry).sink.Write(ctx, t)
+	}
+
+	if s.config.MaxOpen > 0 {
+		for s.lru.Len() >= s.config.MaxOpen {
+			s.closeEntry(ctx, s.lru.Back())
+		}
+	}
+	sink, err := s.config.Open(ctx, dest)
+	if err != nil {
+		return fmt.Errorf("cannot open the destination '%v': %v", dest, err)
+	}
+	s.sinks[dest] = s.lru.PushFront(&dynamicSinkEntry{
+		dest: dest,
+		sink: sink,
+	})
+	return sink.Write(ctx, t)
+}
+
+func (s *dynamicSink) closeEntry(ctx *core.Context, e *list.Element) error {
+	ent := e.Value.(*dynamicSinkEntry)
+	s.lru.Remove(e)
+	delete(s.sinks, ent.dest)
+	err := ent.sink.Close(ctx)
+	if err != nil {
+		ctx.ErrLog(err).WithField("destination", ent.dest).
+			Error("Cannot close the destination")
+	}
+	return err
+}
+
+// Flush flushes sinks of open destinations implementing core.Flusher.
+func (s *dynamicSink) Flush(ctx *core.Context) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.closed {
+		return errors.New("the sink is already closed")
+	}
+
+	var lastErr error
+	for e := s.lru.Front(); e != nil; e = e.Next() {
+		ent := e.Value.(*dynamicSinkEntry)
+		f, ok := ent.sink.(core.Flusher)
+		if !ok {
+			continue
+		}
+		if err := f.Flush(ctx); err != nil {
+			ctx.ErrLog(err).WithField("destination", ent.dest).
+				Error("Cannot flush the destination")
+			lastErr = err
+		}
+	}
+	return lastErr
+}
+
+func (s *dynamicSink) Close(ctx *core.Context) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+
+	var lastErr error
+	for s.lru.Len() > 0 {
+		if err := s.closeEntry(ctx, s.lru.Back()); err != nil {
+			lastErr = err
+		}
+	}
+	return lastErr
+}
+
+// Status returns the number of open destinations.
+func (s *dynamicSink) Status() data.Map {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return data.Map{
+		"template":          data.String(s.config.Template.String()),
+		"open_destinations": data.Int(s.lru.Len()),
+		"max_open":          data.Int(s.config.MaxOpen),
+	}
+}
@@ -0,0 +1,160 @@
+package bql
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+func TestDestinationTemplate(t *testing.T) {
+	Convey("Given a tuple", t, func() {
+		tu := core.NewTuple(data.Map{
+			"device_id": data.String("dev1"),
+			"n":         data.Int(3),
+			"ts":        data.Timestamp(time.Date(2016, time.March, 4, 5, 6, 7, 0, time.UTC)),
+		})
+		tu.Timestamp = time.Date(2017, time.January, 2, 3, 4, 5, 0, time.UTC)
+
+		cases := []struct {
+			template string
+			dest     string
+		}{
+			{"/data/out.jsonl", "/data/out.jsonl"},
+			{"/data/{{.device_id}}/{{.n}}.jsonl", "/data/dev1/3.jsonl"},
+			{`{{time_format "2006-01-02"}}`, "2017-01-02"},
+			{`{{time_format .ts "2006-01-02"}}`, "2016-03-04"},
+		}
+		for _, c := range cases {
+			c := c
+			Convey("When executing a template "+c.template, func() {
+				tmpl, err := NewDestinationTemplate(c.template)
+				So(err, ShouldBeNil)
+
+				Convey("Then it should compute the destination", func() {
+					d, err := tmpl.Execute(tu)
+					So(err, ShouldBeNil)
+					So(d, ShouldEqual, c.dest)
+				})
+			})
+		}
+
+		Convey("When executing a template referring to a missing field", func() {
+			tmpl, err := NewDestinationTemplate("/data/{{.no_such_field}}.jsonl")
+			So(err, ShouldBeNil)
+
+			Convey("Then it should fail", func() {
+				_, err := tmpl.Execute(tu)
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When parsing an invalid template", func() {
+			_, err := NewDestinationTemplate("/data/{{.device_id")
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+type recordingSink struct {
+	dest   string
+	tuples []*core.Tuple
+	closed bool
+}
+
+func (s *recordingSink) Write(ctx *core.Context, t *core.Tuple) error {
+	if s.closed {
+		return errors.New("closed")
+	}
+	s.tuples = append(s.tuples, t)
+	return nil
+}
+
+func (s *recordingSink) Close(ctx *core.Context) error {
+	s.closed = true
+	return nil
+}
+
+func TestDynamicSink(t *testing.T) {
+	ctx := core.NewContext(nil)
+
+	Convey("Given a dynamic sink keeping two destinations open", t, func() {
+		tmpl, err := NewDestinationTemplate("{{.dev}}")
+		So(err, ShouldBeNil)
+		var sinks []*recordingSink
+		s, err := NewDynamicSink(&DynamicSinkConfig{
+			Template: tmpl,
+			MaxOpen:  2,
+			Open: func(ctx *core.Context, dest string) (core.Sink, error) {
+				if dest == "bad" {
+					return nil, errors.New("cannot open")
+				}
+				r := &recordingSink{dest: dest}
+				sinks = append(sinks, r)
+				return r, nil
+			},
+		})
+		So(err, ShouldBeNil)
+		write := func(dev string) error {
+			return s.Write(ctx, core.NewTuple(data.Map{"dev": data.String(dev)}))
+		}
+
+		Convey("When writing tuples to destinations", func() {
+			for _, dev := range []string{"a", "b", "a", "c", "b"} {
+				So(write(dev), ShouldBeNil)
+			}
+
+			Convey("Then least recently used destinations should be closed", func() {
+				So(len(sinks), ShouldEqual, 4)
+				So(sinks[0].dest, ShouldEqual, "a")
+				So(sinks[0].closed, ShouldBeTrue)
+				So(len(sinks[0].tuples), ShouldEqual, 2)
+				So(sinks[1].dest, ShouldEqual, "b")
+				So(sinks[1].closed, ShouldBeTrue)
+				So(sinks[2].dest, ShouldEqual, "c")
+				So(sinks[3].dest, ShouldEqual, "b")
+				So(s.(core.Statuser).Status()["open_destinations"], ShouldEqual, data.Int(2))
+			})
+
+			Convey("Then closing the sink should close all destinations", func() {
+				So(s.Close(ctx), ShouldBeNil)
+				for _, r := range sinks {
+					So(r.closed, ShouldBeTrue)
+				}
+				So(write("a"), ShouldNotBeNil)
+			})
+		})
+
+		Convey("When a destination cannot be opened", func() {
+			Convey("Then the write should fail", func() {
+				So(write("bad"), ShouldNotBeNil)
+				So(write("a"), ShouldBeNil)
+			})
+		})
+	})
+
+	Convey("Given an invalid dynamic sink config", t, func() {
+		tmpl, err := NewDestinationTemplate("{{.dev}}")
+		So(err, ShouldBeNil)
+
+		Convey("When creating a sink with a negative limit", func() {
+			_, err := NewDynamicSink(&DynamicSinkConfig{
+				Template: tmpl,
+				MaxOpen:  -1,
+				Open: func(ctx *core.Context, dest string) (core.Sink, error) {
+					return nil, nil
+				},
+			})
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
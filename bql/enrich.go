@@ -0,0 +1,308 @@
+package bql
+
+import (
+	"container/list"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/sensorbee/sensorbee.v0/bql/parser"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// enrichConfig has parameters of CREATE STREAM AS ENRICH statement which
+// aren't passed to the loader.
+type enrichConfig struct {
+	key        data.Path
+	into       data.Path
+	ttl        time.Duration
+	maxEntries int
+}
+
+// enrichParamKeys are keys of parameters used by an ENRICH stream itself.
+var enrichParamKeys = []string{"key", "into", "ttl", "max_entries"}
+
+// newEnrichConfig creates enrichConfig from parameters given to WITH clause
+// of CREATE STREAM AS ENRICH statement. It also returns the rest of the
+// parameters, which are passed to the loader.
+func newEnrichConfig(params data.Map) (*enrichConfig, data.Map, error) {
+	v := &struct {
+		Key        string `bql:",required"`
+		Into       string
+		TTL        time.Duration
+		MaxEntries int
+	}{
+		Into:       "enrichment",
+		TTL:        300 * time.Second,
+		MaxEntries: 10000,
+	}
+	dec := data.NewDecoder(nil)
+	if err := dec.Decode(params, v); err != nil {
+		return nil, nil, err
+	}
+	if v.TTL <= 0 {
+		return nil, nil, fmt.Errorf("'ttl' parameter must be greater than 0: %v", v.TTL)
+	}
+	if v.MaxEntries <= 0 {
+		return nil, nil, fmt.Errorf("'max_entries' parameter must be greater than 0: %v", v.MaxEntries)
+	}
+
+	c := &enrichConfig{
+		ttl:        v.TTL,
+		maxEntries: v.MaxEntries,
+	}
+	var err error
+	if c.key, err = data.CompilePath(v.Key); err != nil {
+		return nil, nil, fmt.Errorf("'key' parameter has an invalid path: %v", err)
+	}
+	if c.into, err = data.CompilePath(v.Into); err != nil {
+		return nil, nil, fmt.Errorf("'into' parameter has an invalid path: %v", err)
+	}
+
+	rest := make(data.Map, len(params))
+	for k, v := range params {
+		rest[k] = v
+	}
+	for _, k := range enrichParamKeys {
+		delete(rest, k)
+	}
+	return c, rest, nil
+}
+
+// enrichBox adds a value looked up by a loader to each tuple. Values are
+// cached in memory for TTL and the least recently used values are evicted
+// when the cache has more than maxEntries values. Concurrent lookups of the
+// same key which isn't cached are coalesced into one call of the loader.
+type enrichBox struct {
+	config *enrichConfig
+	loader EnrichmentLoader
+
+	m        sync.Mutex
+	entries  map[string]*list.Element
+	lru      *list.List // the front is the most recently used entry.
+	inflight map[string]*enrichCall
+
+	numHits       int64
+	numMisses     int64
+	numLoads      int64
+	numLoadErrors int64
+	numEvicted    int64
+}
+
+type enrichEntry struct {
+	key     string
+	value   data.Value
+	expires time.Time
+}
+
+// enrichCall is a call of the loader in progress. done is closed when the
+// call finishes.
+type enrichCall struct {
+	done  chan struct{}
+	value data.Value
+	err   error
+}
+
+var (
+	_ core.StatefulBox = &enrichBox{}
+	_ core.Statuser    = &enrichBox{}
+)
+
+func newEnrichBox(config *enrichConfig, loader EnrichmentLoader) *enrichBox {
+	return &enrichBox{
+		config:   config,
+		loader:   loader,
+		entries:  map[string]*list.Element{},
+		lru:      list.New(),
+		inflight: map[string]*enrichCall{},
+	}
+}
+
+func (b *enrichBox) Init(ctx *core.Context) error {
+	return nil
+}
+
+// Process sets the value of the key to the field at the "into" path. A tuple
+// not having the key, or having NULL as the key, gets NULL without calling
+// the loader. The tuple is dropped when the loader returns an error.
+func (b *enrichBox) Process(ctx *core.Context, t *core.Tuple, w core.Writer) error {
+	var v data.Value = data.Null{}
+	if k, err := t.Data.Get(b.config.key); err == nil && k.Type() != data.TypeNull {
+		if v, err = b.lookup(ctx, k); err != nil {
+			return err
+		}
+	}
+
+	// The value is shared with the cache, so TFSharedData flag set by
+	// ShallowCopy must be kept.
+	out := t.ShallowCopy()
+	out.Data = t.Data.Copy()
+	if err := out.Data.Set(b.config.into, v); err != nil {
+		return err
+	}
+	return w.Write(ctx, out)
+}
+
+// lookup returns the value of the key from the cache or the loader.
+func (b *enrichBox) lookup(ctx *core.Context, key data.Value) (data.Value, error) {
+	// The JSON representation distinguishes keys of different types such as
+	// 1 and "1".
+	k := key.String()
+
+	b.m.Lock()
+	if e, ok := b.entries[k]; ok {
+		ent := e.Value.(*enrichEntry)
+		if ctx.Clock().Now().Before(ent.expires) {
+			b.lru.MoveToFront(e)
+			b.numHits++
+			b.m.Unlock()
+			return ent.value, nil
+		}
+		b.remove(e)
+	}
+	b.numMisses++
+	if c, ok := b.inflight[k]; ok {
+		b.m.Unlock()
+		<-c.done
+		return c.value, c.err
+	}
+	c := &enrichCall{
+		done: make(chan struct{}),
+	}
+	b.inflight[k] = c
+	b.numLoads++
+	b.m.Unlock()
+
+	c.value, c.err = b.loader.Load(ctx, key)
+	if c.err == nil && c.value == nil {
+		c.value = data.Null{}
+	}
+
+	b.m.Lock()
+	delete(b.inflight, k)
+	if c.err != nil {
+		b.numLoadErrors++
+	} else {
+		b.add(k, c.value, ctx.Clock().Now().Add(b.config.ttl))
+	}
+	b.m.Unlock()
+	close(c.done)
+	return c.value, c.err
+}
+
+// add caches the value. The caller must hold the lock.
+func (b *enrichBox) add(key string, v data.Value, expires time.Time) {
+	if e, ok := b.entries[key]; ok {
+		b.remove(e)
+	}
+	b.entries[key] = b.lru.PushFront(&enrichEntry{
+		key:     key,
+		value:   v,
+		expires: expires,
+	})
+	for b.lru.Len() > b.config.maxEntries {
+		b.remove(b.lru.Back())
+		b.numEvicted++
+	}
+}
+
+// remove removes the entry from the cache. The caller must hold the lock.
+func (b *enrichBox) remove(e *list.Element) {
+	ent := b.lru.Remove(e).(*enrichEntry)
+	delete(b.entries, ent.key)
+}
+
+// Terminate closes the loader if it implements io.Closer.
+func (b *enrichBox) Terminate(ctx *core.Context) error {
+	if c, ok := b.loader.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// Status returns the status of the box. It has following fields:
+//
+//	- num_entries: the number of cached values including expired ones
+//	  which haven't been looked up since they expired
+//	- num_hits: the number of lookups served from the cache
+//	- num_misses: the number of lookups not served from the cache
+//	- num_loads: the number of calls of the loader. It's less than
+//	  num_misses when lookups of the same key are coalesced
+//	- num_load_errors: the number of calls of the loader which failed
+//	- num_evicted: the number of values evicted by max_entries
+func (b *enrichBox) Status() data.Map {
+	b.m.Lock()
+	defer b.m.Unlock()
+	return data.Map{
+		"num_entries":     data.Int(b.lru.Len()),
+		"num_hits":        data.Int(b.numHits),
+		"num_misses":      data.Int(b.numMisses),
+		"num_loads":       data.Int(b.numLoads),
+		"num_load_errors": data.Int(b.numLoadErrors),
+		"num_evicted":     data.Int(b.numEvicted),
+		"ttl":             data.Float(b.config.ttl.Seconds()),
+		"max_entries":     data.Int(b.config.maxEntries),
+	}
+}
+
+// enrichStmt adds a box of CREATE STREAM AS ENRICH statement to the
+// topology:
+//
+//	CREATE STREAM users_ex AS ENRICH users USING http_loader WITH
+//	    key="user_id", into="user",
+//	    url_template="http://example.com/users/{key}",
+//	    ttl=300, max_entries=10000;
+//
+// The stream looks up the value at the "key" path of each tuple by the
+// loader given to USING clause and emits the tuple having the result at the
+// "into" path ("enrichment" by default). Results are cached for "ttl"
+// seconds (300 by default) up to "max_entries" (10000 by default) keys.
+// Other parameters are passed to the creator of the loader.
+func (tb *TopologyBuilder) enrichStmt(stmt *parser.CreateStreamAsEnrichStmt) (core.Node, error) {
+	if strings.ToLower(string(stmt.Name)) == strings.ToLower(string(stmt.Input)) {
+		return nil, fmt.Errorf("a stream '%v' contains a selfloop", stmt.Name)
+	}
+	in, err := tb.topology.Node(string(stmt.Input))
+	if err != nil {
+		return nil, err
+	}
+	if in.Type() == core.NTSink {
+		return nil, fmt.Errorf("data source node %v was not found", stmt.Input)
+	}
+	c, err := tb.LoaderCreators.Lookup(string(stmt.Loader))
+	if err != nil {
+		return nil, err
+	}
+	params, err := tb.mkParamsMap(stmt.Params)
+	if err != nil {
+		return nil, err
+	}
+	config, params, err := newEnrichConfig(params)
+	if err != nil {
+		return nil, err
+	}
+	loader, err := c.CreateEnrichmentLoader(tb.topology.Context(), &IOParams{
+		TypeName: string(stmt.Loader),
+		Name:     string(stmt.Name),
+	}, params)
+	if err != nil {
+		return nil, err
+	}
+
+	b := newEnrichBox(config, loader)
+	bn, err := tb.topology.AddBox(string(stmt.Name), b, nil)
+	if err != nil {
+		b.Terminate(tb.topology.Context())
+		return nil, err
+	}
+	if err := bn.Input(string(stmt.Input), nil); err != nil {
+		tb.topology.Remove(string(stmt.Name))
+		return nil, err
+	}
+	bn.StopOnDisconnect(core.Inbound)
+	bn.RemoveOnStop()
+	return bn, nil
+}
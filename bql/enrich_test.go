@@ -0,0 +1,416 @@
+package bql
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/parser"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// countingLoader returns "v" + key and counts calls of Load. Load blocks
+// until release is closed when it isn't nil.
+type countingLoader struct {
+	m       sync.Mutex
+	calls   int
+	fail    bool
+	started chan struct{}
+	release chan struct{}
+}
+
+func (l *countingLoader) Load(ctx *core.Context, key data.Value) (data.Value, error) {
+	l.m.Lock()
+	l.calls++
+	fail := l.fail
+	l.m.Unlock()
+	if l.started != nil {
+		l.started <- struct{}{}
+	}
+	if l.release != nil {
+		<-l.release
+	}
+	if fail {
+		return nil, errors.New("load failure")
+	}
+	k, _ := data.ToString(key)
+	return data.String("v" + k), nil
+}
+
+func (l *countingLoader) numCalls() int {
+	l.m.Lock()
+	defer l.m.Unlock()
+	return l.calls
+}
+
+func TestEnrichConfig(t *testing.T) {
+	Convey("Given parameters of an ENRICH stream", t, func() {
+		params := data.Map{
+			"key":          data.String("id"),
+			"url_template": data.String("http://localhost/{key}"),
+		}
+
+		Convey("When creating a config only with key", func() {
+			c, rest, err := newEnrichConfig(params)
+			So(err, ShouldBeNil)
+
+			Convey("Then it should have default values", func() {
+				So(c.ttl, ShouldEqual, 300*time.Second)
+				So(c.maxEntries, ShouldEqual, 10000)
+			})
+
+			Convey("Then the rest of parameters should be passed to the loader", func() {
+				So(rest, ShouldResemble, data.Map{
+					"url_template": data.String("http://localhost/{key}"),
+				})
+			})
+		})
+
+		Convey("When creating a config with all parameters", func() {
+			params["into"] = data.String("a.b")
+			params["ttl"] = data.Float(1.5)
+			params["max_entries"] = data.Int(10)
+			c, rest, err := newEnrichConfig(params)
+			So(err, ShouldBeNil)
+
+			Convey("Then it should have the values", func() {
+				So(c.ttl, ShouldEqual, 1500*time.Millisecond)
+				So(c.maxEntries, ShouldEqual, 10)
+				So(len(rest), ShouldEqual, 1)
+			})
+		})
+
+		Convey("When creating a config with invalid parameters", func() {
+			Convey("Then missing key should result in an error", func() {
+				delete(params, "key")
+				_, _, err := newEnrichConfig(params)
+				So(err, ShouldNotBeNil)
+			})
+
+			Convey("Then a non-positive ttl should result in an error", func() {
+				params["ttl"] = data.Int(0)
+				_, _, err := newEnrichConfig(params)
+				So(err, ShouldNotBeNil)
+			})
+
+			Convey("Then a non-positive max_entries should result in an error", func() {
+				params["max_entries"] = data.Int(0)
+				_, _, err := newEnrichConfig(params)
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+func TestEnrichBox(t *testing.T) {
+	Convey("Given an enrich box with a loader", t, func() {
+		clock := core.NewManualClock(time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC))
+		ctx := core.NewContext(&core.ContextConfig{
+			Clock: clock,
+		})
+		c, _, err := newEnrichConfig(data.Map{
+			"key":         data.String("id"),
+			"into":        data.String("user.name"),
+			"ttl":         data.Int(10),
+			"max_entries": data.Int(2),
+		})
+		So(err, ShouldBeNil)
+		l := &countingLoader{}
+		b := newEnrichBox(c, l)
+		w := &testTupleCollector{}
+		w.c = sync.NewCond(&w.m)
+
+		process := func(id data.Value) *core.Tuple {
+			t := core.NewTuple(data.Map{"id": id})
+			So(b.Process(ctx, t, w), ShouldBeNil)
+			return w.tuples[len(w.tuples)-1]
+		}
+
+		Convey("When processing a tuple", func() {
+			in := core.NewTuple(data.Map{"id": data.Int(1)})
+			So(b.Process(ctx, in, w), ShouldBeNil)
+
+			Convey("Then the loaded value should be set to the into path", func() {
+				So(w.tuples[0].Data, ShouldResemble, data.Map{
+					"id":   data.Int(1),
+					"user": data.Map{"name": data.String("v1")},
+				})
+			})
+
+			Convey("Then the input tuple shouldn't be modified", func() {
+				So(in.Data, ShouldResemble, data.Map{"id": data.Int(1)})
+			})
+		})
+
+		Convey("When processing tuples having the same key", func() {
+			process(data.Int(1))
+			out := process(data.Int(1))
+
+			Convey("Then the loader should only be called once", func() {
+				So(l.numCalls(), ShouldEqual, 1)
+				So(out.Data["user"], ShouldResemble, data.Map{"name": data.String("v1")})
+			})
+
+			Convey("Then the status should have the numbers of hits and misses", func() {
+				st := b.Status()
+				So(st["num_entries"], ShouldEqual, 1)
+				So(st["num_hits"], ShouldEqual, 1)
+				So(st["num_misses"], ShouldEqual, 1)
+				So(st["num_loads"], ShouldEqual, 1)
+			})
+		})
+
+		Convey("When processing keys having different types", func() {
+			process(data.Int(1))
+			process(data.String("1"))
+
+			Convey("Then they should be cached separately", func() {
+				So(l.numCalls(), ShouldEqual, 2)
+			})
+		})
+
+		Convey("When processing a key after ttl", func() {
+			process(data.Int(1))
+			clock.Advance(10 * time.Second)
+			process(data.Int(1))
+
+			Convey("Then the value should be loaded again", func() {
+				So(l.numCalls(), ShouldEqual, 2)
+			})
+		})
+
+		Convey("When processing more keys than max_entries", func() {
+			process(data.Int(1))
+			process(data.Int(2))
+			process(data.Int(1))
+			process(data.Int(3))
+
+			Convey("Then the least recently used key should be evicted", func() {
+				So(l.numCalls(), ShouldEqual, 3)
+				process(data.Int(1))
+				So(l.numCalls(), ShouldEqual, 3)
+				process(data.Int(2))
+				So(l.numCalls(), ShouldEqual, 4)
+				So(b.Status()["num_evicted"], ShouldEqual, 2)
+			})
+		})
+
+		Convey("When processing a tuple not having the key", func() {
+			out := process(data.Null{})
+
+			Convey("Then NULL should be set without calling the loader", func() {
+				So(l.numCalls(), ShouldEqual, 0)
+				So(out.Data["user"], ShouldResemble, data.Map{"name": data.Null{}})
+			})
+		})
+
+		Convey("When the loader fails", func() {
+			l.fail = true
+			err := b.Process(ctx, core.NewTuple(data.Map{"id": data.Int(1)}), w)
+
+			Convey("Then the tuple should be dropped with an error", func() {
+				So(err, ShouldNotBeNil)
+				So(w.tuples, ShouldBeEmpty)
+			})
+
+			Convey("Then the error shouldn't be cached", func() {
+				l.fail = false
+				process(data.Int(1))
+				So(l.numCalls(), ShouldEqual, 2)
+				So(b.Status()["num_load_errors"], ShouldEqual, 1)
+			})
+		})
+
+		Convey("When looking up the same key concurrently", func() {
+			l.started = make(chan struct{}, 1)
+			l.release = make(chan struct{})
+			var wg sync.WaitGroup
+			res := make([]data.Value, 2)
+			lookup := func(i int) {
+				defer wg.Done()
+				res[i], _ = b.lookup(ctx, data.Int(1))
+			}
+			wg.Add(2)
+			go lookup(0)
+			<-l.started
+			go lookup(1)
+			for {
+				if b.Status()["num_misses"] == data.Int(2) {
+					break
+				}
+				time.Sleep(time.Millisecond)
+			}
+			close(l.release)
+			wg.Wait()
+
+			Convey("Then lookups should be coalesced", func() {
+				So(l.numCalls(), ShouldEqual, 1)
+				So(res[0], ShouldEqual, data.String("v1"))
+				So(res[1], ShouldEqual, data.String("v1"))
+			})
+		})
+	})
+}
+
+func TestHTTPLoader(t *testing.T) {
+	Convey("Given an HTTP server", t, func() {
+		var paths []string
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			paths = append(paths, r.URL.EscapedPath())
+			switch r.URL.Path {
+			case "/users/1":
+				fmt.Fprint(w, `{"name": "alice", "age": 20}`)
+			case "/users/a b":
+				fmt.Fprint(w, `"spaced"`)
+			case "/users/error":
+				w.WriteHeader(http.StatusInternalServerError)
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		Reset(func() {
+			ts.Close()
+		})
+		ctx := core.NewContext(nil)
+
+		Convey("When creating an http_loader", func() {
+			l, err := createHTTPLoader(ctx, &IOParams{}, data.Map{
+				"url_template": data.String(ts.URL + "/users/{key}"),
+			})
+			So(err, ShouldBeNil)
+			Reset(func() {
+				l.(io.Closer).Close()
+			})
+
+			Convey("Then it should load a JSON value", func() {
+				v, err := l.Load(ctx, data.Int(1))
+				So(err, ShouldBeNil)
+				So(v, ShouldResemble, data.Map{
+					"name": data.String("alice"),
+					"age":  data.Int(20),
+				})
+			})
+
+			Convey("Then it should escape the key", func() {
+				v, err := l.Load(ctx, data.String("a b"))
+				So(err, ShouldBeNil)
+				So(v, ShouldEqual, data.String("spaced"))
+				So(paths, ShouldResemble, []string{"/users/a%20b"})
+			})
+
+			Convey("Then it should return NULL for 404", func() {
+				v, err := l.Load(ctx, data.Int(2))
+				So(err, ShouldBeNil)
+				So(v.Type(), ShouldEqual, data.TypeNull)
+			})
+
+			Convey("Then it should fail for other errors", func() {
+				_, err := l.Load(ctx, data.String("error"))
+				So(err, ShouldNotBeNil)
+			})
+
+			Convey("Then it should release the shared connection pool when it's closed", func() {
+				key := "http:" + ts.URL
+				m, err := ListGlobalSharedClients()
+				So(err, ShouldBeNil)
+				So(m[key], ShouldEqual, 1)
+
+				So(l.(io.Closer).Close(), ShouldBeNil)
+				m, err = ListGlobalSharedClients()
+				So(err, ShouldBeNil)
+				So(m, ShouldNotContainKey, key)
+			})
+		})
+
+		Convey("When creating an http_loader with invalid parameters", func() {
+			Convey("Then url_template without {key} should result in an error", func() {
+				_, err := createHTTPLoader(ctx, &IOParams{}, data.Map{
+					"url_template": data.String(ts.URL + "/users"),
+				})
+				So(err, ShouldNotBeNil)
+			})
+
+			Convey("Then url_template having an unsupported scheme should result in an error", func() {
+				_, err := createHTTPLoader(ctx, &IOParams{}, data.Map{
+					"url_template": data.String("ftp://localhost/{key}"),
+				})
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+func TestEnrichStmt(t *testing.T) {
+	Convey("Given a topology having a stream and a kv state", t, func() {
+		dt := newTestTopology()
+		Reset(func() {
+			dt.Stop()
+		})
+		tb, err := NewTopologyBuilder(dt)
+		So(err, ShouldBeNil)
+		So(addBQLToTopology(tb, `
+			CREATE STATE users TYPE kv WITH key="id";
+			CREATE PAUSED SOURCE s TYPE static WITH
+			    tuples=[{"uid": 1}, {"uid": 2}, {"uid": 1}];
+			CREATE SINK snk TYPE collector;`), ShouldBeNil)
+		st, err := dt.Context().SharedStates.Get("users")
+		So(err, ShouldBeNil)
+		So(st.(*core.KeyValueState).Put("1", data.Map{"name": data.String("alice")}), ShouldBeNil)
+
+		Convey("When creating an ENRICH stream with uds_loader", func() {
+			So(addBQLToTopology(tb, `
+				CREATE STREAM enriched AS ENRICH s USING uds_loader WITH
+				    key="uid", into="user", state="users";
+				INSERT INTO snk FROM enriched;
+				RESUME SOURCE s;`), ShouldBeNil)
+			sin, err := dt.Sink("snk")
+			So(err, ShouldBeNil)
+			si := sin.Sink().(*tupleCollectorSink)
+			si.Wait(3)
+
+			Convey("Then tuples should have values in the state", func() {
+				So(si.get(0).Data["user"], ShouldResemble, data.Map{"name": data.String("alice")})
+				So(si.get(1).Data["user"].Type(), ShouldEqual, data.TypeNull)
+				So(si.get(2).Data["user"], ShouldResemble, data.Map{"name": data.String("alice")})
+			})
+		})
+
+		Convey("When creating an ENRICH stream with an unknown loader", func() {
+			err := addBQLToTopology(tb, `CREATE STREAM enriched AS ENRICH s USING no_such_loader WITH key="uid";`)
+
+			Convey("Then it should fail", func() {
+				So(core.IsNotExist(err), ShouldBeTrue)
+			})
+		})
+
+		Convey("When creating an ENRICH stream reading from itself", func() {
+			err := addBQLToTopology(tb, `CREATE STREAM enriched AS ENRICH enriched USING uds_loader WITH key="uid", state="users";`)
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When planning an ENRICH stream", func() {
+			stmts, err := parser.New().ParseStmts(`
+				CREATE STREAM enriched AS ENRICH s USING uds_loader WITH key="uid", state="users";`)
+			So(err, ShouldBeNil)
+			p := tb.Plan(stmts)
+
+			Convey("Then the plan should have the stream", func() {
+				So(p.Errors, ShouldBeEmpty)
+				n := p.Nodes[len(p.Nodes)-1]
+				So(n.Name, ShouldEqual, "enriched")
+				So(n.TypeName, ShouldEqual, "uds_loader")
+				So(n.Inputs, ShouldResemble, []string{"s"})
+			})
+		})
+	})
+}
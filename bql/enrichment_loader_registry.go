@@ -0,0 +1,159 @@
+package bql
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// EnrichmentLoader loads a value associated with a key from an external
+// system, such as a web API or a database, for a stream created by
+// CREATE STREAM AS ENRICH statement. Load can be called concurrently.
+type EnrichmentLoader interface {
+	// Load returns the value associated with the key. It should return
+	// data.Null when the external system doesn't have a value for the key
+	// so that the absence is also cached. Results aren't cached when Load
+	// returns an error.
+	Load(ctx *core.Context, key data.Value) (data.Value, error)
+}
+
+// EnrichmentLoaderCreator is an interface which creates instances of an
+// EnrichmentLoader. When the loader created by the creator implements
+// io.Closer, Close is called when the stream using it is removed.
+type EnrichmentLoaderCreator interface {
+	// CreateEnrichmentLoader creates a new EnrichmentLoader instance using
+	// given parameters. IOParams has the name of the stream.
+	CreateEnrichmentLoader(ctx *core.Context, ioParams *IOParams, params data.Map) (EnrichmentLoader, error)
+}
+
+type enrichmentLoaderCreatorFunc func(*core.Context, *IOParams, data.Map) (EnrichmentLoader, error)
+
+func (f enrichmentLoaderCreatorFunc) CreateEnrichmentLoader(ctx *core.Context, ioParams *IOParams, params data.Map) (EnrichmentLoader, error) {
+	return f(ctx, ioParams, params)
+}
+
+// EnrichmentLoaderCreatorFunc creates an EnrichmentLoaderCreator from a
+// function.
+func EnrichmentLoaderCreatorFunc(f func(*core.Context, *IOParams, data.Map) (EnrichmentLoader, error)) EnrichmentLoaderCreator {
+	return enrichmentLoaderCreatorFunc(f)
+}
+
+// EnrichmentLoaderCreatorRegistry manages creators of EnrichmentLoaders.
+type EnrichmentLoaderCreatorRegistry interface {
+	// Register adds an EnrichmentLoader creator to the registry. It returns
+	// an error if the type name is already registered.
+	Register(typeName string, c EnrichmentLoaderCreator) error
+
+	// Lookup returns an EnrichmentLoader creator having the type name. It
+	// returns core.NotExistError if it doesn't have the creator.
+	Lookup(typeName string) (EnrichmentLoaderCreator, error)
+
+	// List returns all creators the registry has. The caller can safely modify
+	// the map returned from this method.
+	List() (map[string]EnrichmentLoaderCreator, error)
+
+	// Unregister removes a creator from the registry. It returns
+	// core.NotExistError when the registry doesn't have a creator having the
+	// type name.
+	Unregister(typeName string) error
+}
+
+type defaultEnrichmentLoaderCreatorRegistry struct {
+	m        sync.RWMutex
+	creators map[string]EnrichmentLoaderCreator
+}
+
+// NewDefaultEnrichmentLoaderCreatorRegistry returns an
+// EnrichmentLoaderCreatorRegistry having a default implementation.
+func NewDefaultEnrichmentLoaderCreatorRegistry() EnrichmentLoaderCreatorRegistry {
+	return &defaultEnrichmentLoaderCreatorRegistry{
+		creators: map[string]EnrichmentLoaderCreator{},
+	}
+}
+
+func (r *defaultEnrichmentLoaderCreatorRegistry) Register(typeName string, c EnrichmentLoaderCreator) error {
+	if err := core.ValidateSymbol(typeName); err != nil {
+		return fmt.Errorf("invalid name for loader type: %s", err.Error())
+	}
+
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	lowerName := strings.ToLower(typeName)
+	if _, ok := r.creators[lowerName]; ok {
+		return fmt.Errorf("loader type '%v' is already registered", typeName)
+	}
+	r.creators[lowerName] = c
+	return nil
+}
+
+func (r *defaultEnrichmentLoaderCreatorRegistry) Lookup(typeName string) (EnrichmentLoaderCreator, error) {
+	r.m.RLock()
+	defer r.m.RUnlock()
+	if c, ok := r.creators[strings.ToLower(typeName)]; ok {
+		return c, nil
+	}
+	return nil, core.NotExistError(fmt.Errorf("loader type '%v' is not registered", typeName))
+}
+
+func (r *defaultEnrichmentLoaderCreatorRegistry) List() (map[string]EnrichmentLoaderCreator, error) {
+	r.m.RLock()
+	defer r.m.RUnlock()
+
+	m := make(map[string]EnrichmentLoaderCreator, len(r.creators))
+	for t, c := range r.creators {
+		m[t] = c
+	}
+	return m, nil
+}
+
+func (r *defaultEnrichmentLoaderCreatorRegistry) Unregister(typeName string) error {
+	r.m.Lock()
+	defer r.m.Unlock()
+	tn := strings.ToLower(typeName)
+	if _, ok := r.creators[tn]; !ok {
+		return core.NotExistError(fmt.Errorf("loader type '%v' is not registered", typeName))
+	}
+	delete(r.creators, tn)
+	return nil
+}
+
+var (
+	globalEnrichmentLoaderCreatorRegistry = NewDefaultEnrichmentLoaderCreatorRegistry()
+)
+
+// RegisterGlobalEnrichmentLoaderCreator adds an EnrichmentLoaderCreator which
+// can be referred from all topologies. EnrichmentLoaderCreators registered
+// after running topologies might not be seen by those topologies. Call it
+// from init functions to avoid such conditions.
+func RegisterGlobalEnrichmentLoaderCreator(typeName string, c EnrichmentLoaderCreator) error {
+	return globalEnrichmentLoaderCreatorRegistry.Register(typeName, c)
+}
+
+// MustRegisterGlobalEnrichmentLoaderCreator is like
+// RegisterGlobalEnrichmentLoaderCreator but panics if an error occurred.
+func MustRegisterGlobalEnrichmentLoaderCreator(typeName string, c EnrichmentLoaderCreator) {
+	if err := globalEnrichmentLoaderCreatorRegistry.Register(typeName, c); err != nil {
+		panic(fmt.Errorf("bql.MustRegisterGlobalEnrichmentLoaderCreator: cannot register '%v': %v", typeName, err))
+	}
+}
+
+// CopyGlobalEnrichmentLoaderCreatorRegistry creates a new independent copy of
+// the global EnrichmentLoaderCreatorRegistry.
+func CopyGlobalEnrichmentLoaderCreatorRegistry() (EnrichmentLoaderCreatorRegistry, error) {
+	r := NewDefaultEnrichmentLoaderCreatorRegistry()
+	m, err := globalEnrichmentLoaderCreatorRegistry.List()
+	if err != nil {
+		return nil, err
+	}
+
+	for t, c := range m {
+		if err := r.Register(t, c); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
@@ -0,0 +1,72 @@
+package bql
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"testing"
+)
+
+func createDummyLoader(ctx *core.Context, ioParams *IOParams, params data.Map) (EnrichmentLoader, error) {
+	return &countingLoader{}, nil
+}
+
+func TestDefaultEnrichmentLoaderCreatorRegistry(t *testing.T) {
+	Convey("Given an default loader registry having two types", t, func() {
+		r := NewDefaultEnrichmentLoaderCreatorRegistry()
+		So(r.Register("TEST_loader", EnrichmentLoaderCreatorFunc(createDummyLoader)), ShouldBeNil)
+		So(r.Register("TEST_loader2", EnrichmentLoaderCreatorFunc(createDummyLoader)), ShouldBeNil)
+
+		Convey("When adding a new type having the registered type name", func() {
+			err := r.Register("TEST_loader", EnrichmentLoaderCreatorFunc(createDummyLoader))
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When looking up a creator", func() {
+			_, err := r.Lookup("test_LOADER2")
+
+			Convey("Then it should succeed", func() {
+				So(err, ShouldBeNil)
+			})
+		})
+
+		Convey("When retrieving a list of creators", func() {
+			m, err := r.List()
+
+			Convey("Then the list should have all creators", func() {
+				So(err, ShouldBeNil)
+				So(len(m), ShouldEqual, 2)
+				So(m["test_loader"], ShouldNotBeNil)
+				So(m["test_loader2"], ShouldNotBeNil)
+			})
+		})
+
+		Convey("When unregistering a creator", func() {
+			So(r.Unregister("test_LOADER"), ShouldBeNil)
+
+			Convey("Then the unregistered creator shouldn't be found", func() {
+				_, err := r.Lookup("test_loader")
+				So(core.IsNotExist(err), ShouldBeTrue)
+			})
+
+			Convey("Then unregistering it again should fail", func() {
+				So(core.IsNotExist(r.Unregister("test_loader")), ShouldBeTrue)
+			})
+		})
+	})
+
+	Convey("Given a copy of the global loader registry", t, func() {
+		r, err := CopyGlobalEnrichmentLoaderCreatorRegistry()
+		So(err, ShouldBeNil)
+
+		Convey("Then it should have builtin loaders", func() {
+			_, err := r.Lookup("http_loader")
+			So(err, ShouldBeNil)
+			_, err = r.Lookup("uds_loader")
+			So(err, ShouldBeNil)
+		})
+	})
+}
@@ -0,0 +1,117 @@
+package bql_test
+
+import (
+	"fmt"
+	"sync"
+
+	"gopkg.in/sensorbee/sensorbee.v0/bql"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// printSink prints the data of received tuples and signals wg for each tuple.
+type printSink struct {
+	wg *sync.WaitGroup
+}
+
+func (s *printSink) Write(ctx *core.Context, t *core.Tuple) error {
+	fmt.Println(t.Data)
+	s.wg.Done()
+	return nil
+}
+
+func (s *printSink) Close(ctx *core.Context) error {
+	return nil
+}
+
+func ExampleTopologyBuilder() {
+	tp, err := core.NewDefaultTopology(core.NewContext(nil), "example")
+	if err != nil {
+		panic(err)
+	}
+	defer tp.Stop()
+
+	tb, err := bql.NewTopologyBuilder(tp)
+	if err != nil {
+		panic(err)
+	}
+
+	// Sources are paused on startup so that no tuple is emitted until the
+	// whole topology is built.
+	src, err := tb.AddSource("numbers", "static", data.Map{
+		"tuples": data.Array{
+			data.Map{"n": data.Int(1)},
+			data.Map{"n": data.Int(2)},
+			data.Map{"n": data.Int(3)},
+			data.Map{"n": data.Int(4)},
+		},
+	}, &core.SourceConfig{PausedOnStartup: true})
+	if err != nil {
+		panic(err)
+	}
+
+	box, err := tb.AddBQLStream("even_numbers",
+		"SELECT RSTREAM n, n * 10 AS m FROM numbers [RANGE 1 TUPLES] WHERE n % 2 = 0")
+	if err != nil {
+		panic(err)
+	}
+
+	// A custom sink type can be registered to the builder.
+	wg := &sync.WaitGroup{}
+	wg.Add(2)
+	if err := tb.SinkCreators.Register("printer", bql.SinkCreatorFunc(
+		func(ctx *core.Context, ioParams *bql.IOParams, params data.Map) (core.Sink, error) {
+			return &printSink{wg: wg}, nil
+		})); err != nil {
+		panic(err)
+	}
+	sink, err := tb.AddSink("results", "printer", nil, nil)
+	if err != nil {
+		panic(err)
+	}
+	if err := sink.Input(box.Name(), nil); err != nil {
+		panic(err)
+	}
+
+	if err := src.Resume(); err != nil {
+		panic(err)
+	}
+	wg.Wait()
+
+	// Output:
+	// {"m":20,"n":2}
+	// {"m":40,"n":4}
+}
+
+func ExampleTopologyBuilder_AddBQLStream() {
+	tp, err := core.NewDefaultTopology(core.NewContext(nil), "example")
+	if err != nil {
+		panic(err)
+	}
+	defer tp.Stop()
+
+	tb, err := bql.NewTopologyBuilder(tp)
+	if err != nil {
+		panic(err)
+	}
+
+	if _, err := tb.AddSource("s", "static", data.Map{
+		"tuples": data.Array{data.Map{"a": data.Int(1)}},
+	}, &core.SourceConfig{PausedOnStartup: true}); err != nil {
+		panic(err)
+	}
+
+	// A statement other than SELECT results in an error.
+	_, err = tb.AddBQLStream("t", "CREATE SINK x TYPE stdout")
+	fmt.Println(err != nil)
+
+	box, err := tb.AddBQLStream("t", "SELECT ISTREAM a FROM s [RANGE 1 TUPLES];")
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(box.Name())
+
+	// Output:
+	// true
+	// t
+}
@@ -0,0 +1,641 @@
+package execution
+
+import (
+	"container/list"
+	"fmt"
+
+	"gopkg.in/sensorbee/sensorbee.v0/bql/parser"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/udf"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// ColumnarExecutor is implemented by a PhysicalPlan which can process its
+// window as a columnar batch.
+type ColumnarExecutor interface {
+	// EnableColumnarExecution makes the plan evaluate aggregates over its
+	// window as a columnar batch, i.e. an array per field, while all rows in
+	// the window have the same schema. The plan falls back to per-tuple
+	// evaluation while the window has a row which doesn't fit in the batch.
+	// It returns false when the statement cannot be processed as a batch,
+	// e.g. when it uses aggregate functions other than count, sum, avg, min,
+	// and max.
+	//
+	// This is experimental.
+	EnableColumnarExecution() bool
+}
+
+// columnarAggregates are aggregate functions which can be computed on a
+// columnar batch. They must be the builtin functions registered globally.
+var columnarAggregates = map[string]bool{
+	"count": true,
+	"sum":   true,
+	"avg":   true,
+	"min":   true,
+	"max":   true,
+}
+
+type colKind int
+
+const (
+	colInt colKind = iota
+	colFloat
+)
+
+// colVector has values of a column. A constant vector has only one value
+// which is used for all rows.
+type colVector struct {
+	kind    colKind
+	ints    []int64
+	floats  []float64
+	isConst bool
+}
+
+func (v *colVector) intAt(i int) int64 {
+	if v.isConst {
+		return v.ints[0]
+	}
+	return v.ints[i]
+}
+
+func (v *colVector) floatAt(i int) float64 {
+	if v.kind == colInt {
+		return float64(v.intAt(i))
+	}
+	if v.isConst {
+		return v.floats[0]
+	}
+	return v.floats[i]
+}
+
+func (v *colVector) valueAt(i int) data.Value {
+	if v.kind == colInt {
+		return data.Int(v.intAt(i))
+	}
+	return data.Float(v.floatAt(i))
+}
+
+// colExpr is an expression evaluated on all rows of a columnarBatch at once.
+type colExpr interface {
+	// eval returns false when the expression cannot be evaluated on the
+	// batch, e.g. integer division by zero. The per-tuple evaluation is used
+	// in that case so that it reports the error.
+	eval(b *columnarBatch) (*colVector, bool)
+}
+
+type colRef struct {
+	idx int
+}
+
+func (c *colRef) eval(b *columnarBatch) (*colVector, bool) {
+	return &b.cols[c.idx], true
+}
+
+type colConst struct {
+	v colVector
+}
+
+func (c *colConst) eval(b *columnarBatch) (*colVector, bool) {
+	return &c.v, true
+}
+
+// colArith is an arithmetic operator having the same semantics as
+// numBinOp.
+type colArith struct {
+	op    parser.Operator
+	left  colExpr
+	right colExpr
+}
+
+func (c *colArith) eval(b *columnarBatch) (*colVector, bool) {
+	l, ok := c.left.eval(b)
+	if !ok {
+		return nil, false
+	}
+	r, ok := c.right.eval(b)
+	if !ok {
+		return nil, false
+	}
+
+	n := len(b.rows)
+	res := &colVector{}
+	if l.isConst && r.isConst {
+		n = 1
+		res.isConst = true
+	}
+	if l.kind == colInt && r.kind == colInt {
+		res.kind = colInt
+		res.ints = make([]int64, n)
+		for i := range res.ints {
+			x, y := l.intAt(i), r.intAt(i)
+			switch c.op {
+			case parser.Plus:
+				res.ints[i] = x + y
+			case parser.Minus:
+				res.ints[i] = x - y
+			case parser.Multiply:
+				res.ints[i] = x * y
+			case parser.Divide:
+				if y == 0 {
+					return nil, false
+				}
+				res.ints[i] = x / y
+			}
+		}
+		return res, true
+	}
+
+	res.kind = colFloat
+	res.floats = make([]float64, n)
+	for i := range res.floats {
+		x, y := l.floatAt(i), r.floatAt(i)
+		switch c.op {
+		case parser.Plus:
+			res.floats[i] = x + y
+		case parser.Minus:
+			res.floats[i] = x - y
+		case parser.Multiply:
+			res.floats[i] = x * y
+		case parser.Divide:
+			res.floats[i] = x / y
+		}
+	}
+	return res, true
+}
+
+// colAgg is an aggregate function computed on a columnar batch. Its result
+// is stored in the data of each group with key.
+type colAgg struct {
+	function string
+	input    colExpr
+	key      string
+}
+
+// columnarBatch has rows of a window as columns. cols has a column of each
+// field referred by inputs of aggregate functions. The kind of a column is
+// decided by the first row, and a row having a value of another type, NULL,
+// or no value doesn't fit in the batch.
+type columnarBatch struct {
+	rows []*inputRowWithCachedResult
+	cols []colVector
+}
+
+// columnarQuery computes aggregates of a groupbyExecutionPlan on a columnar
+// batch.
+type columnarQuery struct {
+	// paths has the path of each column in the batch. pathKeys has their
+	// string representations.
+	paths    []data.Path
+	pathKeys []string
+	aggs     []*colAgg
+	// projections are projections of the plan whose aggregate functions are
+	// replaced with references to results of aggs.
+	projections []aliasedEvaluator
+	batch       columnarBatch
+	// blocker is a row which didn't fit in the batch. The per-tuple
+	// evaluation is used until it leaves the window.
+	blocker *inputRowWithCachedResult
+
+	// numBatches and numFallbacks are the numbers of queries performed on
+	// the batch and by the per-tuple evaluation, respectively.
+	numBatches   int64
+	numFallbacks int64
+}
+
+// newColumnarQuery compiles the projections for columnar execution. It
+// returns nil when they cannot be computed on a columnar batch.
+func newColumnarQuery(lp *LogicalPlan, projs []aliasedEvaluator, reg udf.FunctionRegistry) (*columnarQuery, error) {
+	// rows padded by an outer join don't have columns of a relation
+	if lp.GroupingSets != nil || lp.isOuterJoin() {
+		return nil, nil
+	}
+	globals := udf.CopyGlobalUDFRegistry(nil)
+	q := &columnarQuery{
+		projections: make([]aliasedEvaluator, len(projs)),
+	}
+	aggs := map[string]*colAgg{}
+	var rewrite func(e FlatExpression, inputs map[string]FlatExpression) (FlatExpression, bool)
+	rewrite = func(e FlatExpression, inputs map[string]FlatExpression) (FlatExpression, bool) {
+		switch obj := e.(type) {
+		case rowValue, numericLiteral, floatLiteral, boolLiteral, stringLiteral, nullLiteral, stmtMeta, rowMeta:
+			return e, true
+		case binaryOpAST:
+			l, ok := rewrite(obj.Left, inputs)
+			if !ok {
+				return nil, false
+			}
+			r, ok := rewrite(obj.Right, inputs)
+			if !ok {
+				return nil, false
+			}
+			return binaryOpAST{obj.Op, l, r}, true
+		case unaryOpAST:
+			expr, ok := rewrite(obj.Expr, inputs)
+			if !ok {
+				return nil, false
+			}
+			return unaryOpAST{obj.Op, expr}, true
+		case typeCastAST:
+			expr, ok := rewrite(obj.Expr, inputs)
+			if !ok {
+				return nil, false
+			}
+			return typeCastAST{expr, obj.Target}, true
+		case funcAppAST:
+			name := string(obj.Function)
+			if ref, ok := columnarAggregateInput(obj); ok {
+				if !columnarAggregates[name] || !isGlobalUDF(reg, globals, name) {
+					return nil, false
+				}
+				key := fmt.Sprintf("c_%s_%s", name, ref.Ref)
+				if _, ok := aggs[key]; !ok {
+					input, ok := q.compileColExpr(inputs[ref.Ref])
+					if !ok {
+						return nil, false
+					}
+					aggs[key] = &colAgg{function: name, input: input, key: key}
+					q.aggs = append(q.aggs, aggs[key])
+				}
+				return aggInputRef{key}, true
+			}
+			exprs := make([]FlatExpression, len(obj.Expressions))
+			for i, arg := range obj.Expressions {
+				expr, ok := rewrite(arg, inputs)
+				if !ok {
+					return nil, false
+				}
+				exprs[i] = expr
+			}
+			return funcAppAST{obj.Function, exprs}, true
+		}
+		// other expressions, including references to inputs of aggregate
+		// functions which cannot be computed on a batch, aren't supported.
+		return nil, false
+	}
+
+	for i, proj := range lp.Projections {
+		q.projections[i] = projs[i]
+		if len(proj.aggrInputs) == 0 {
+			continue
+		}
+		expr, ok := rewrite(proj.expr, proj.aggrInputs)
+		if !ok {
+			return nil, nil
+		}
+		eval, err := ExpressionToEvaluator(expr, reg)
+		if err != nil {
+			return nil, err
+		}
+		q.projections[i].evaluator = eval
+		q.projections[i].aggrEvals = nil
+	}
+	if len(q.aggs) == 0 {
+		return nil, nil
+	}
+	return q, nil
+}
+
+// columnarAggregateInput returns the reference to the input of the aggregate
+// function when f is an aggregate function having one aggregated parameter.
+func columnarAggregateInput(f funcAppAST) (aggInputRef, bool) {
+	if len(f.Expressions) != 1 {
+		return aggInputRef{}, false
+	}
+	ref, ok := f.Expressions[0].(aggInputRef)
+	return ref, ok
+}
+
+// isGlobalUDF returns true when reg has the same function as the global
+// registry so that a user-defined function having the same name as a
+// builtin function isn't computed on a batch.
+func isGlobalUDF(reg, globals udf.FunctionRegistry, name string) bool {
+	f, err := reg.Lookup(name, 1)
+	if err != nil {
+		return false
+	}
+	g, err := globals.Lookup(name, 1)
+	if err != nil {
+		return false
+	}
+	return f == g
+}
+
+// compileColExpr compiles an input of an aggregate function. Only columns,
+// numeric literals, and arithmetic operators are supported.
+func (q *columnarQuery) compileColExpr(e FlatExpression) (colExpr, bool) {
+	switch obj := e.(type) {
+	case rowValue:
+		key := rowValuePath(obj)
+		for i, k := range q.pathKeys {
+			if k == key {
+				return &colRef{i}, true
+			}
+		}
+		path, err := data.CompilePath(key)
+		if err != nil {
+			return nil, false
+		}
+		q.paths = append(q.paths, path)
+		q.pathKeys = append(q.pathKeys, key)
+		return &colRef{len(q.paths) - 1}, true
+	case numericLiteral:
+		return &colConst{colVector{kind: colInt, ints: []int64{obj.Value}, isConst: true}}, true
+	case floatLiteral:
+		return &colConst{colVector{kind: colFloat, floats: []float64{obj.Value}, isConst: true}}, true
+	case unaryOpAST:
+		if obj.Op != parser.UnaryMinus {
+			return nil, false
+		}
+		expr, ok := q.compileColExpr(obj.Expr)
+		if !ok {
+			return nil, false
+		}
+		minusOne := &colConst{colVector{kind: colInt, ints: []int64{-1}, isConst: true}}
+		return &colArith{parser.Multiply, expr, minusOne}, true
+	case binaryOpAST:
+		switch obj.Op {
+		case parser.Plus, parser.Minus, parser.Multiply, parser.Divide:
+		default:
+			return nil, false
+		}
+		l, ok := q.compileColExpr(obj.Left)
+		if !ok {
+			return nil, false
+		}
+		r, ok := q.compileColExpr(obj.Right)
+		if !ok {
+			return nil, false
+		}
+		return &colArith{obj.Op, l, r}, true
+	}
+	return nil, false
+}
+
+// reset removes all rows from the batch.
+func (q *columnarQuery) reset() {
+	q.batch.rows = q.batch.rows[:0]
+	for i := range q.batch.cols {
+		q.batch.cols[i].ints = q.batch.cols[i].ints[:0]
+		q.batch.cols[i].floats = q.batch.cols[i].floats[:0]
+	}
+}
+
+// sync updates the batch so that it has the same rows as rows. Rows are
+// only appended to the back of rows or removed, so rows which are still in
+// the window are kept in the batch and only new rows are converted. It
+// returns false when a new row doesn't fit in the batch.
+func (q *columnarQuery) sync(ep *groupbyExecutionPlan, rows *list.List) (bool, error) {
+	b := &q.batch
+	if b.cols == nil {
+		b.cols = make([]colVector, len(q.paths))
+	}
+
+	// remove rows which have left the window
+	e := rows.Front()
+	w := 0
+	for i, r := range b.rows {
+		if e == nil || e.Value.(*inputRowWithCachedResult) != r {
+			continue
+		}
+		b.rows[w] = r
+		for c := range b.cols {
+			col := &b.cols[c]
+			if col.kind == colInt {
+				col.ints[w] = col.ints[i]
+			} else {
+				col.floats[w] = col.floats[i]
+			}
+		}
+		w++
+		e = e.Next()
+	}
+	for i := w; i < len(b.rows); i++ {
+		b.rows[i] = nil
+	}
+	b.rows = b.rows[:w]
+	for c := range b.cols {
+		col := &b.cols[c]
+		if col.kind == colInt {
+			col.ints = col.ints[:w]
+		} else {
+			col.floats = col.floats[:w]
+		}
+	}
+
+	// append new rows
+	for ; e != nil; e = e.Next() {
+		io := e.Value.(*inputRowWithCachedResult)
+		input, err := ep.inputData(io)
+		if err != nil {
+			return false, err
+		}
+		if len(b.rows) == 0 {
+			// the first row decides kinds of columns
+			for c, path := range q.paths {
+				v, err := input.Get(path)
+				if err != nil {
+					q.blocker = io
+					return false, nil
+				}
+				b.cols[c].ints = b.cols[c].ints[:0]
+				b.cols[c].floats = b.cols[c].floats[:0]
+				switch v.Type() {
+				case data.TypeInt:
+					b.cols[c].kind = colInt
+				case data.TypeFloat:
+					b.cols[c].kind = colFloat
+				default:
+					q.blocker = io
+					return false, nil
+				}
+			}
+		}
+		for c, path := range q.paths {
+			col := &b.cols[c]
+			v, err := input.Get(path)
+			if err != nil {
+				q.blocker = io
+				return false, nil
+			}
+			switch {
+			case col.kind == colInt && v.Type() == data.TypeInt:
+				i, _ := data.AsInt(v)
+				col.ints = append(col.ints, i)
+			case col.kind == colFloat && v.Type() == data.TypeFloat:
+				f, _ := data.AsFloat(v)
+				col.floats = append(col.floats, f)
+			default:
+				q.blocker = io
+				return false, nil
+			}
+		}
+		if _, err := ep.groupValues(io, input); err != nil {
+			return false, err
+		}
+		b.rows = append(b.rows, io)
+	}
+	return true, nil
+}
+
+// colGroup is a group of rows in a columnar batch.
+type colGroup struct {
+	values data.Array
+	// first is the first row of the group.
+	first *inputRowWithCachedResult
+	// count is the number of rows in the group.
+	count int64
+	// results has results of aggregate functions of the group.
+	results []data.Value
+}
+
+// perform computes results of the query on the batch. It returns false when
+// the query cannot be performed on the batch and the per-tuple evaluation
+// has to be used instead.
+func (q *columnarQuery) perform(ep *groupbyExecutionPlan) ([]resultRow, bool, error) {
+	if q.blocker != nil {
+		for e := ep.filteredInputRows.Front(); e != nil; e = e.Next() {
+			if e.Value.(*inputRowWithCachedResult) == q.blocker {
+				return nil, false, nil
+			}
+		}
+		q.blocker = nil
+	}
+	if ok, err := q.sync(ep, ep.filteredInputRows); err != nil || !ok {
+		q.reset()
+		return nil, false, err
+	}
+	b := &q.batch
+	n := len(b.rows)
+	if n == 0 {
+		// the per-tuple evaluation handles the empty window
+		return nil, false, nil
+	}
+
+	// evaluate inputs of aggregate functions
+	inputs := make([]*colVector, len(q.aggs))
+	for i, agg := range q.aggs {
+		v, ok := agg.input.eval(b)
+		if !ok {
+			return nil, false, nil
+		}
+		inputs[i] = v
+	}
+
+	// assign rows to groups in the order of their first rows
+	groups := []*colGroup{}
+	groupIdx := map[data.HashValue][]int{}
+	rowGroups := make([]int, n)
+	for i, r := range b.rows {
+		values := r.cache.(data.Array)
+		g := -1
+		for _, c := range groupIdx[r.hash] {
+			if data.Equal(values, groups[c].values) {
+				g = c
+				break
+			}
+		}
+		if g < 0 {
+			g = len(groups)
+			groups = append(groups, &colGroup{values: values, first: r})
+			groupIdx[r.hash] = append(groupIdx[r.hash], g)
+		}
+		groups[g].count++
+		rowGroups[i] = g
+	}
+
+	// compute aggregate functions
+	for _, g := range groups {
+		g.results = make([]data.Value, len(q.aggs))
+	}
+	for a, agg := range q.aggs {
+		in := inputs[a]
+		switch agg.function {
+		case "count":
+			for _, g := range groups {
+				g.results[a] = data.Int(g.count)
+			}
+
+		case "sum", "avg":
+			if agg.function == "sum" && in.kind == colInt {
+				sums := make([]int64, len(groups))
+				for i := 0; i < n; i++ {
+					sums[rowGroups[i]] += in.intAt(i)
+				}
+				for i, g := range groups {
+					g.results[a] = data.Int(sums[i])
+				}
+				break
+			}
+			sums := make([]float64, len(groups))
+			for i := 0; i < n; i++ {
+				sums[rowGroups[i]] += in.floatAt(i)
+			}
+			for i, g := range groups {
+				if agg.function == "avg" {
+					g.results[a] = data.Float(sums[i] / float64(g.count))
+				} else {
+					g.results[a] = data.Float(sums[i])
+				}
+			}
+
+		case "min", "max":
+			best := make([]int, len(groups))
+			for i := range best {
+				best[i] = -1
+			}
+			isMax := agg.function == "max"
+			for i := 0; i < n; i++ {
+				g := rowGroups[i]
+				if best[g] < 0 {
+					best[g] = i
+					continue
+				}
+				var better bool
+				if in.kind == colInt {
+					better = (in.intAt(i) > in.intAt(best[g])) == isMax && in.intAt(i) != in.intAt(best[g])
+				} else {
+					better = (in.floatAt(i) > in.floatAt(best[g])) == isMax && in.floatAt(i) != in.floatAt(best[g])
+				}
+				if better {
+					best[g] = i
+				}
+			}
+			for i, g := range groups {
+				g.results[a] = in.valueAt(best[i])
+			}
+		}
+	}
+
+	// evaluate projections on each group
+	results := make([]resultRow, 0, len(groups))
+	for _, g := range groups {
+		input, err := ep.inputData(g.first)
+		if err != nil {
+			return nil, false, err
+		}
+		groupData := input.Copy()
+		for a, agg := range q.aggs {
+			groupData[agg.key] = g.results[a]
+		}
+		ep.projCache.reset()
+		if ok, err := evalHaving(q.projections, groupData); err != nil {
+			return nil, false, err
+		} else if !ok {
+			continue
+		}
+		result := data.Map(make(map[string]data.Value, len(q.projections)))
+		for _, proj := range q.projections {
+			if proj.alias == ":having:" {
+				continue
+			}
+			value, err := proj.evaluator.Eval(groupData)
+			if err != nil {
+				return nil, false, err
+			}
+			if err := assignOutputValue(result, proj.alias, proj.aliasPath, value); err != nil {
+				return nil, false, err
+			}
+		}
+		results = append(results, resultRow{row: result, hash: data.Hash(result)})
+	}
+	return results, true, nil
+}
@@ -0,0 +1,144 @@
+package execution
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+func getColumnarTuples(num int) []*core.Tuple {
+	tuples := getTuples(num)
+	for i, t := range tuples {
+		t.Data["k"] = data.Int(i % 3)
+		t.Data["x"] = data.Int(i*7%11 - 3)
+		t.Data["y"] = data.Float(float64(i) * 1.5)
+	}
+	return tuples
+}
+
+func TestColumnarExecution(t *testing.T) {
+	stmts := []string{
+		`CREATE STREAM box AS SELECT RSTREAM k, count(*) AS c, sum(x) AS s, avg(y) AS a,
+			min(x*2+1) AS mn, max(y/2.0) AS mx FROM src [RANGE 4 TUPLES] GROUP BY k`,
+		`CREATE STREAM box AS SELECT RSTREAM sum(x) + count(x) AS v, max(-x) AS m, sum(x + y) AS xy
+			FROM src [RANGE 5 TUPLES] HAVING sum(x) > 5`,
+		`CREATE STREAM box AS SELECT ISTREAM k, sum(y) AS s FROM src [RANGE 3 TUPLES] WHERE x > 0 GROUP BY k`,
+		`CREATE STREAM box AS SELECT DSTREAM k, avg(x) / 2 AS a FROM src [RANGE 2 SECONDS] GROUP BY k`,
+	}
+
+	for i, s := range stmts {
+		Convey(fmt.Sprintf("Given a statement which can be processed as a columnar batch (%v)", i), t, func() {
+			rowPlan, err := createGroupbyPlan(s, t)
+			So(err, ShouldBeNil)
+			colPlan, err := createGroupbyPlan(s, t)
+			So(err, ShouldBeNil)
+			So(colPlan.(ColumnarExecutor).EnableColumnarExecution(), ShouldBeTrue)
+			q := colPlan.(*groupbyExecutionPlan).columnar
+
+			Convey("When feeding it with tuples having the same schema", func() {
+				for _, tuple := range getColumnarTuples(12) {
+					expected, err := rowPlan.Process(tuple.Copy())
+					So(err, ShouldBeNil)
+					actual, err := colPlan.Process(tuple.Copy())
+					So(err, ShouldBeNil)
+					So(actual, ShouldResemble, expected)
+				}
+
+				Convey("Then the query should be performed on the batch", func() {
+					// an empty window is still processed per tuple
+					So(q.numBatches, ShouldBeGreaterThan, q.numFallbacks)
+					So(q.blocker, ShouldBeNil)
+				})
+			})
+
+			Convey("When feeding it with tuples having different schemas", func() {
+				tuples := getColumnarTuples(16)
+				tuples[5].Data["x"] = data.Float(2.5)
+				tuples[6].Data["y"] = data.Int(3)
+				tuples[7].Data["x"] = data.Null{}
+				for _, tuple := range tuples {
+					expected, err := rowPlan.Process(tuple.Copy())
+					So(err, ShouldBeNil)
+					actual, err := colPlan.Process(tuple.Copy())
+					So(err, ShouldBeNil)
+					So(actual, ShouldResemble, expected)
+				}
+
+				Convey("Then it should fall back to the per-tuple evaluation", func() {
+					So(q.numFallbacks, ShouldBeGreaterThan, 0)
+				})
+
+				Convey("Then it should return to the batch after those tuples leave the window", func() {
+					So(q.blocker, ShouldBeNil)
+					So(q.numBatches, ShouldBeGreaterThan, 0)
+				})
+			})
+		})
+	}
+
+	Convey("Given a statement dividing an integer by zero", t, func() {
+		s := `CREATE STREAM box AS SELECT RSTREAM sum(x / (k - k)) AS s FROM src [RANGE 3 TUPLES]`
+		plan, err := createGroupbyPlan(s, t)
+		So(err, ShouldBeNil)
+		So(plan.(ColumnarExecutor).EnableColumnarExecution(), ShouldBeTrue)
+
+		Convey("When feeding it with a tuple", func() {
+			_, err := plan.Process(getColumnarTuples(1)[0])
+
+			Convey("Then it should report the error of the per-tuple evaluation", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+
+	Convey("Given a statement which cannot be processed as a columnar batch", t, func() {
+		for i, s := range []string{
+			`CREATE STREAM box AS SELECT RSTREAM udaf(x) AS u FROM src [RANGE 3 TUPLES]`,
+			`CREATE STREAM box AS SELECT RSTREAM median(x) AS m FROM src [RANGE 3 TUPLES]`,
+			`CREATE STREAM box AS SELECT RSTREAM sum(abs(x)) AS s FROM src [RANGE 3 TUPLES]`,
+			`CREATE STREAM box AS SELECT RSTREAM k, count(*) AS c FROM src [RANGE 3 TUPLES] GROUP BY GROUPING SETS ((k), ())`,
+		} {
+			plan, err := createGroupbyPlan(s, t)
+			So(err, ShouldBeNil)
+
+			Convey(fmt.Sprintf("Then columnar execution shouldn't be enabled (%v)", i), func() {
+				So(plan.(ColumnarExecutor).EnableColumnarExecution(), ShouldBeFalse)
+			})
+		}
+	})
+}
+
+func benchmarkAggregation(b *testing.B, columnar bool) {
+	s := `CREATE STREAM box AS SELECT RSTREAM k, count(*) AS c, sum(x * 2 + y) AS s,
+			avg(y) AS a, max(x) AS m FROM src [RANGE 1000 TUPLES] GROUP BY k`
+	plan, err := createGroupbyPlan2(s)
+	if err != nil {
+		panic(err.Error())
+	}
+	if columnar {
+		plan.(ColumnarExecutor).EnableColumnarExecution()
+	}
+	tuples := getColumnarTuples(1000)
+	for _, t := range tuples {
+		if _, err := plan.Process(t); err != nil {
+			panic(err.Error())
+		}
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := plan.Process(tuples[i%len(tuples)].Copy()); err != nil {
+			panic(err.Error())
+		}
+	}
+}
+
+func BenchmarkRowAggregation(b *testing.B) {
+	benchmarkAggregation(b, false)
+}
+
+func BenchmarkColumnarAggregation(b *testing.B) {
+	benchmarkAggregation(b, true)
+}
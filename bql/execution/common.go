@@ -2,6 +2,8 @@ package execution
 
 import (
 	"fmt"
+	"sync"
+
 	"gopkg.in/sensorbee/sensorbee.v0/bql/parser"
 	"gopkg.in/sensorbee/sensorbee.v0/bql/udf"
 	"gopkg.in/sensorbee/sensorbee.v0/core"
@@ -18,19 +20,40 @@ type aliasedEvaluator struct {
 
 type commonExecutionPlan struct {
 	projections []aliasedEvaluator
-	groupList   []Evaluator
+	// projCache holds results of sub-expressions shared by projections.
+	// It must be reset before evaluating projections on a new input.
+	projCache *evalCache
+	groupList []Evaluator
 	// filter stores the evaluator of the filter condition,
 	// or nil if there is no WHERE clause.
 	filter Evaluator
+	// dedup drops duplicate input tuples, or is nil if there is
+	// no DEDUPLICATE BY clause.
+	dedup *deduplicator
+	// clock provides the time returned from now() and used by the
+	// deduplicator.
+	clock core.Clock
+	// profile measures operators of the plan, or is nil if profiling
+	// isn't enabled.
+	profile *profiler
 }
 
-func prepareProjections(projections []aliasedExpression, reg udf.FunctionRegistry) ([]aliasedEvaluator, error) {
+// prepareProjections creates evaluators of projections. Sub-expressions and
+// prefixes of paths appearing more than once in projections are evaluated
+// only once per input by sharing their results in the returned cache.
+func prepareProjections(projections []aliasedExpression, reg udf.FunctionRegistry) ([]aliasedEvaluator, *evalCache, error) {
+	exprs := make([]FlatExpression, len(projections))
+	for i, proj := range projections {
+		exprs[i] = proj.expr
+	}
+	b := newSharingEvaluatorBuilder(exprs, reg)
+
 	output := make([]aliasedEvaluator, len(projections))
 	for i, proj := range projections {
 		// compute evaluators for each column
-		plan, err := ExpressionToEvaluator(proj.expr, reg)
+		plan, err := b.build(proj.expr)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		containsAggregate := len(proj.aggrInputs) > 0
 		// compute evaluators for the aggregate inputs
@@ -40,7 +63,7 @@ func prepareProjections(projections []aliasedExpression, reg udf.FunctionRegistr
 			for key, aggrInput := range proj.aggrInputs {
 				aggrEval, err := ExpressionToEvaluator(aggrInput, reg)
 				if err != nil {
-					return nil, err
+					return nil, nil, err
 				}
 				aggrEvals[key] = aggrEval
 			}
@@ -49,12 +72,12 @@ func prepareProjections(projections []aliasedExpression, reg udf.FunctionRegistr
 		if proj.alias != "*" && proj.alias != ":having:" {
 			path, err = data.CompilePath(proj.alias)
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 		}
 		output[i] = aliasedEvaluator{proj.alias, path, plan, containsAggregate, aggrEvals}
 	}
-	return output, nil
+	return output, b.cache, nil
 }
 
 func prepareFilter(filter FlatExpression, reg udf.FunctionRegistry) (Evaluator, error) {
@@ -82,12 +105,62 @@ func prepareGroupList(groupList []FlatExpression, reg udf.FunctionRegistry) ([]E
 //   {"alias": {"col_0": ..., "col_1": ...}}
 // is transformed into
 //   {"alias": {"col_0": ..., "col_1": ...},
-//    "alias:meta:TS": (timestamp of the given tuple)}
+//    "alias:meta:TS": (timestamp of the given tuple),
+//    "alias:meta:ID": (ID of the given tuple, or NULL if it has no ID),
+//    "alias:meta:BACKFILL": (true if the tuple has core.TFBackfill flag)}
 // so that the Evaluator created from a parser.RowMeta AST struct works correctly.
 func setMetadata(where data.Map, alias string, t *core.Tuple) {
+	keys := lookupMetaKeys(alias)
+	where[keys.ts] = data.Timestamp(t.Timestamp)
+	if t.ID == "" {
+		where[keys.id] = data.Null{}
+	} else {
+		where[keys.id] = data.String(t.ID)
+	}
+	where[keys.backfill] = data.Bool(t.Flags.IsSet(core.TFBackfill))
+}
+
+// metaKeys are keys with which setMetadata writes the metadata of a relation.
+type metaKeys struct {
+	ts       string
+	id       string
+	backfill string
+}
+
+// maxCachedMetaKeys is the maximum number of relations whose metaKeys are
+// cached.
+const maxCachedMetaKeys = 1024
+
+var metaKeysCache = struct {
+	m    sync.RWMutex
+	keys map[string]*metaKeys
+}{
+	keys: map[string]*metaKeys{},
+}
+
+// lookupMetaKeys returns metaKeys of the relation. Because setMetadata is
+// called on every tuple, keys are computed once per relation and cached
+// instead of being formatted each time.
+func lookupMetaKeys(alias string) *metaKeys {
+	metaKeysCache.m.RLock()
+	keys, ok := metaKeysCache.keys[alias]
+	metaKeysCache.m.RUnlock()
+	if ok {
+		return keys
+	}
+
 	// this key format is also used in ExpressionToEvaluator()
-	tsKey := fmt.Sprintf("%s:meta:%s", alias, parser.TimestampMeta)
-	where[tsKey] = data.Timestamp(t.Timestamp)
+	keys = &metaKeys{
+		ts:       fmt.Sprintf("%s:meta:%s", alias, parser.TimestampMeta),
+		id:       fmt.Sprintf("%s:meta:%s", alias, parser.IDMeta),
+		backfill: fmt.Sprintf("%s:meta:%s", alias, parser.BackfillMeta),
+	}
+	metaKeysCache.m.Lock()
+	defer metaKeysCache.m.Unlock()
+	if len(metaKeysCache.keys) < maxCachedMetaKeys {
+		metaKeysCache.keys[alias] = keys
+	}
+	return keys
 }
 
 // assignOutputValue writes the given Value `value` to the given
@@ -0,0 +1,166 @@
+package execution
+
+import (
+	"container/list"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/parser"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/udf"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"time"
+)
+
+// deduplicator detects duplicate tuples for the DEDUPLICATE BY clause.
+// It remembers the keys of tuples seen recently in a bounded structure:
+// for a time-based interval, keys older than the interval are forgotten,
+// and for a tuple-based interval, only the most recently seen keys are
+// kept (LRU). In both cases at most MaxRangeTuples keys are held.
+type deduplicator struct {
+	key Evaluator
+
+	// alias is the alias of the relation the key refers to.
+	alias string
+	// inputName is the InputName of tuples to be deduplicated. When it's
+	// empty, all tuples are deduplicated.
+	inputName string
+
+	within   parser.IntervalAST
+	capacity int
+
+	// keys has *dedupEntry values in the order of their insertion (or their
+	// last access for a tuple-based interval).
+	keys    *list.List
+	entries map[data.HashValue][]*list.Element
+}
+
+type dedupEntry struct {
+	key       data.Value
+	hash      data.HashValue
+	timestamp time.Time
+}
+
+// newDeduplicator creates a deduplicator for the given logical plan. It
+// returns nil when the plan doesn't have a DEDUPLICATE BY clause.
+func newDeduplicator(lp *LogicalPlan, reg udf.FunctionRegistry) (*deduplicator, error) {
+	if lp.DedupKey == nil {
+		return nil, nil
+	}
+	key, err := ExpressionToEvaluator(lp.DedupKey, reg)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &deduplicator{
+		key:      key,
+		within:   lp.DedupWithin,
+		capacity: int(MaxRangeTuples),
+		keys:     list.New(),
+		entries:  map[data.HashValue][]*list.Element{},
+	}
+	if lp.DedupWithin.Unit == parser.Tuples {
+		d.capacity = int(lp.DedupWithin.Value)
+	}
+
+	// the key refers to at most one relation, which has been checked
+	// in validateReferences
+	if len(lp.Relations) == 1 {
+		d.alias = lp.Relations[0].Alias
+		return d, nil
+	}
+	for _, rel := range lp.Relations {
+		if rel.Alias != lp.DedupRelation {
+			continue
+		}
+		d.alias = rel.Alias
+		if rel.Type == parser.ActualStream {
+			d.inputName = rel.Name
+		} else {
+			// same as streamRelationStreamExecutionPlan.relationKey
+			d.inputName = rel.Name + "/" + rel.Alias
+		}
+		break
+	}
+	return d, nil
+}
+
+// isDuplicate returns true when a tuple having the same key as the given
+// tuple has been seen within the interval. Otherwise, it remembers the key
+// of the tuple and returns false. Tuples whose key is NULL are never
+// considered duplicates.
+func (d *deduplicator) isDuplicate(t *core.Tuple, now time.Time) (bool, error) {
+	if d.inputName != "" && t.InputName != d.inputName {
+		return false, nil
+	}
+
+	m := data.Map{d.alias: t.Data}
+	setMetadata(m, d.alias, t)
+	m[":meta:NOW"] = data.Timestamp(now)
+	key, err := d.key.Eval(m)
+	if err != nil {
+		return false, err
+	}
+	if key.Type() == data.TypeNull {
+		return false, nil
+	}
+
+	d.removeExpiredKeys(t.Timestamp)
+
+	h := data.Hash(key)
+	for _, e := range d.entries[h] {
+		if data.Equal(e.Value.(*dedupEntry).key, key) {
+			if d.within.Unit == parser.Tuples {
+				d.keys.MoveToBack(e)
+			}
+			return true, nil
+		}
+	}
+
+	// because the key may be a part of Data, it's marked as shared.
+	t.Flags.Set(core.TFSharedData)
+	e := d.keys.PushBack(&dedupEntry{
+		key:       key,
+		hash:      h,
+		timestamp: t.Timestamp,
+	})
+	d.entries[h] = append(d.entries[h], e)
+	if d.keys.Len() > d.capacity {
+		d.remove(d.keys.Front())
+	}
+	return false, nil
+}
+
+// removeExpiredKeys removes keys older than the interval when it's
+// time-based. Keys are checked in the order of their arrival and the check
+// stops at the first key which hasn't expired yet, so that it doesn't
+// have to scan all keys for each tuple.
+func (d *deduplicator) removeExpiredKeys(curTupTime time.Time) {
+	if d.within.Unit == parser.Tuples {
+		return
+	}
+	withinSeconds := d.within.Value
+	if d.within.Unit == parser.Milliseconds {
+		withinSeconds = withinSeconds / 1000
+	}
+	for e := d.keys.Front(); e != nil; e = d.keys.Front() {
+		dur := curTupTime.Sub(e.Value.(*dedupEntry).timestamp)
+		if dur.Seconds() <= withinSeconds {
+			break
+		}
+		d.remove(e)
+	}
+}
+
+func (d *deduplicator) remove(e *list.Element) {
+	entry := d.keys.Remove(e).(*dedupEntry)
+	es := d.entries[entry.hash]
+	for i, x := range es {
+		if x == e {
+			es = append(es[:i], es[i+1:]...)
+			break
+		}
+	}
+	if len(es) == 0 {
+		delete(d.entries, entry.hash)
+	} else {
+		d.entries[entry.hash] = es
+	}
+}
@@ -0,0 +1,224 @@
+package execution
+
+import (
+	"fmt"
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/parser"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/udf"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"testing"
+)
+
+func TestDeduplicate(t *testing.T) {
+	Convey("Given a SELECT statement with a time-based DEDUPLICATE BY", t, func() {
+		// keys: 1, 0, 1, 0, 1, 0 with timestamps 0s, 1s, ..., 5s
+		tuples := getTuples(6)
+		s := `CREATE STREAM box AS SELECT RSTREAM int FROM src [RANGE 1 TUPLES]
+			DEDUPLICATE BY int % 2 WITHIN 2 SECONDS`
+		plan, err := createFilterPlan2(s)
+		So(err, ShouldBeNil)
+
+		Convey("When feeding it with tuples", func() {
+			for idx, inTup := range tuples {
+				out, err := plan.Process(inTup)
+				So(err, ShouldBeNil)
+
+				Convey(fmt.Sprintf("Then duplicates should be dropped in %v", idx), func() {
+					if idx == 2 || idx == 3 {
+						So(out, ShouldBeEmpty)
+					} else {
+						So(len(out), ShouldEqual, 1)
+						So(out[0], ShouldResemble, data.Map{"int": data.Int(idx + 1)})
+					}
+				})
+			}
+		})
+	})
+
+	Convey("Given a SELECT statement with a tuple-based DEDUPLICATE BY", t, func() {
+		tuples := getTuples(6)
+		for i, k := range []string{"a", "b", "a", "c", "a", "b"} {
+			tuples[i].Data["k"] = data.String(k)
+		}
+		s := `CREATE STREAM box AS SELECT RSTREAM int FROM src [RANGE 1 TUPLES]
+			DEDUPLICATE BY k WITHIN 2 TUPLES`
+		plan, err := createFilterPlan2(s)
+		So(err, ShouldBeNil)
+
+		Convey("When feeding it with tuples", func() {
+			for idx, inTup := range tuples {
+				out, err := plan.Process(inTup)
+				So(err, ShouldBeNil)
+
+				Convey(fmt.Sprintf("Then only recently seen keys should be dropped in %v", idx), func() {
+					// "a" is kept because it's accessed again while "b"
+					// is evicted when "c" arrives
+					if idx == 2 || idx == 4 {
+						So(out, ShouldBeEmpty)
+					} else {
+						So(len(out), ShouldEqual, 1)
+						So(out[0], ShouldResemble, data.Map{"int": data.Int(idx + 1)})
+					}
+				})
+			}
+		})
+	})
+
+	Convey("Given a SELECT statement deduplicating by tuple IDs", t, func() {
+		tuples := getTuples(4)
+		tuples[0].ID = "x"
+		tuples[1].ID = "x"
+		// tuples[2] and tuples[3] don't have IDs
+		s := `CREATE STREAM box AS SELECT RSTREAM int, tuple_id() FROM src [RANGE 1 TUPLES]
+			DEDUPLICATE BY tuple_id() WITHIN 10 SECONDS`
+		plan, err := createFilterPlan2(s)
+		So(err, ShouldBeNil)
+
+		Convey("When feeding it with tuples", func() {
+			for idx, inTup := range tuples {
+				out, err := plan.Process(inTup)
+				So(err, ShouldBeNil)
+
+				Convey(fmt.Sprintf("Then tuples having the same ID should be dropped in %v", idx), func() {
+					switch idx {
+					case 0:
+						So(out, ShouldResemble, []data.Map{{"int": data.Int(1), "tuple_id": data.String("x")}})
+					case 1:
+						So(out, ShouldBeEmpty)
+					default:
+						So(out, ShouldResemble, []data.Map{{"int": data.Int(idx + 1), "tuple_id": data.Null{}}})
+					}
+				})
+			}
+		})
+	})
+
+	Convey("Given a SELECT statement with a window and DEDUPLICATE BY", t, func() {
+		tuples := getTuples(4)
+		for i, k := range []int{1, 1, 2, 1} {
+			tuples[i].Data["k"] = data.Int(k)
+		}
+		s := `CREATE STREAM box AS SELECT ISTREAM int FROM src [RANGE 2 TUPLES]
+			DEDUPLICATE BY k WITHIN 10 SECONDS`
+		plan, err := createDefaultSelectPlan(s, t)
+		So(err, ShouldBeNil)
+
+		Convey("When feeding it with tuples", func() {
+			for idx, inTup := range tuples {
+				out, err := plan.Process(inTup)
+				So(err, ShouldBeNil)
+
+				Convey(fmt.Sprintf("Then duplicates should not enter the window in %v", idx), func() {
+					switch idx {
+					case 0:
+						So(out, ShouldResemble, []data.Map{{"int": data.Int(1)}})
+					case 2:
+						So(out, ShouldResemble, []data.Map{{"int": data.Int(3)}})
+					default:
+						So(out, ShouldBeEmpty)
+					}
+				})
+			}
+		})
+	})
+
+	Convey("Given a JOIN with DEDUPLICATE BY on one relation", t, func() {
+		tuples := getTuples(6)
+		for i, t := range tuples {
+			if i%2 == 0 {
+				t.InputName = "src1"
+				t.Data["l"] = data.String("l")
+			} else {
+				t.InputName = "src2"
+				t.Data["r"] = data.String("r")
+			}
+		}
+		s := `CREATE STREAM box AS SELECT RSTREAM src1:int AS l, src2:int AS r
+			FROM src1 [RANGE 1 TUPLES], src2 [RANGE 1 TUPLES]
+			DEDUPLICATE BY src1:l WITHIN 10 SECONDS`
+		plan, err := createDefaultSelectPlan(s, t)
+		So(err, ShouldBeNil)
+
+		Convey("When feeding it with tuples", func() {
+			for idx, inTup := range tuples {
+				out, err := plan.Process(inTup)
+				So(err, ShouldBeNil)
+
+				Convey(fmt.Sprintf("Then only tuples from src1 should be deduplicated in %v", idx), func() {
+					switch idx {
+					case 0:
+						So(out, ShouldBeEmpty)
+					case 2, 4:
+						// the duplicate doesn't change the relation
+						So(out, ShouldBeEmpty)
+					default:
+						So(out, ShouldResemble, []data.Map{{"l": data.Int(1), "r": data.Int(idx + 1)}})
+					}
+				})
+			}
+		})
+	})
+}
+
+func TestDeduplicateAnalysis(t *testing.T) {
+	analyze := func(s string) error {
+		p := parser.New()
+		reg := udf.CopyGlobalUDFRegistry(core.NewContext(nil))
+		stmt, _, err := p.ParseStmt(s)
+		if err != nil {
+			return err
+		}
+		_, err = Analyze(stmt.(parser.SelectStmt), reg)
+		return err
+	}
+
+	Convey("Given a SELECT statement with DEDUPLICATE BY", t, func() {
+		Convey("When the key refers to the input relation", func() {
+			err := analyze(`SELECT RSTREAM s:a FROM s [RANGE 1 TUPLES] DEDUPLICATE BY s:a WITHIN 1 SECONDS`)
+
+			Convey("Then it should be valid", func() {
+				So(err, ShouldBeNil)
+			})
+		})
+
+		Convey("When the key refers to an unknown relation", func() {
+			err := analyze(`SELECT RSTREAM a FROM s [RANGE 1 TUPLES] DEDUPLICATE BY t:a WITHIN 1 SECONDS`)
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When the key refers to multiple relations in a JOIN", func() {
+			err := analyze(`SELECT RSTREAM s:a FROM s [RANGE 1 TUPLES], t [RANGE 1 TUPLES]
+				DEDUPLICATE BY s:a + t:a WITHIN 1 SECONDS`)
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "exactly one relation")
+			})
+		})
+
+		Convey("When the key has an aggregate", func() {
+			err := analyze(`SELECT RSTREAM a FROM s [RANGE 1 TUPLES] DEDUPLICATE BY count(a) WITHIN 1 SECONDS`)
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "DEDUPLICATE BY")
+			})
+		})
+
+		for _, i := range []string{"0 SECONDS", "0 TUPLES", "100000000 SECONDS",
+			"1000000000 MILLISECONDS", "2000000 TUPLES"} {
+			i := i
+			Convey(fmt.Sprintf("When the interval is %v", i), func() {
+				err := analyze(fmt.Sprintf(`SELECT RSTREAM a FROM s [RANGE 1 TUPLES] DEDUPLICATE BY a WITHIN %v`, i))
+
+				Convey("Then it should fail", func() {
+					So(err, ShouldNotBeNil)
+				})
+			})
+		}
+	})
+}
@@ -9,6 +9,11 @@ import (
 
 type defaultSelectExecutionPlan struct {
 	streamRelationStreamExecutionPlan
+
+	// batchFuncs has the funcApp of each projection which calls a UDF
+	// implementing udf.BatchUDF, or nil for other projections. It's nil
+	// when there's no such projection.
+	batchFuncs []*funcApp
 }
 
 // CanBuildDefaultSelectExecutionPlan checks whether the given statement
@@ -31,8 +36,18 @@ func NewDefaultSelectExecutionPlan(lp *LogicalPlan, reg udf.FunctionRegistry) (P
 	if err != nil {
 		return nil, err
 	}
+	var batchFuncs []*funcApp
+	for i, proj := range underlying.projections {
+		if f := batchFuncApp(proj.evaluator); f != nil {
+			if batchFuncs == nil {
+				batchFuncs = make([]*funcApp, len(underlying.projections))
+			}
+			batchFuncs[i] = f
+		}
+	}
 	return &defaultSelectExecutionPlan{
-		*underlying,
+		streamRelationStreamExecutionPlan: *underlying,
+		batchFuncs:                        batchFuncs,
 	}, nil
 }
 
@@ -41,7 +56,9 @@ func NewDefaultSelectExecutionPlan(lp *LogicalPlan, reg udf.FunctionRegistry) (P
 // plan. Note that the order of items in the returned slice is undefined
 // and cannot be relied on.
 func (ep *defaultSelectExecutionPlan) Process(input *core.Tuple) ([]data.Map, error) {
-	return ep.process(input, ep.performQueryOnBuffer)
+	ep.profile.beginTuple()
+	defer ep.profile.end()
+	return ep.process(input, ep.profile.wrap(profileProjection, ep.performQueryOnBuffer))
 }
 
 // performQueryOnBuffer computes the projections of a SELECT query on the data
@@ -75,6 +92,17 @@ func (ep *defaultSelectExecutionPlan) performQueryOnBuffer() error {
 		ep.prevResults = output
 	}
 
+	// compute the projections calling batch UDFs on all rows which
+	// don't have a cached result in advance
+	var batched map[*inputRowWithCachedResult]*batchedRow
+	if ep.batchFuncs != nil {
+		var err error
+		if batched, err = ep.evalBatchProjections(); err != nil {
+			rollback()
+			return err
+		}
+	}
+
 	// function to compute the projection values and store
 	// the result in the `output` slice
 	evalItem := func(io *inputRowWithCachedResult) error {
@@ -84,17 +112,32 @@ func (ep *defaultSelectExecutionPlan) performQueryOnBuffer() error {
 			if err != nil {
 				return fmt.Errorf("cached data was not a map: %v", io.cache)
 			}
-			output = append(output, resultRow{row: cachedResults, hash: io.hash})
+			output = append(output, resultRow{row: cachedResults, hash: io.hash, lineage: io.lineage})
 			return nil
 		}
 		// otherwise, compute all the expressions
-		d := *io.input
-		result := data.Map(make(map[string]data.Value, len(ep.projections)))
-		for _, proj := range ep.projections {
-			value, err := proj.evaluator.Eval(d)
-			if err != nil {
+		var d data.Map
+		var values []data.Value
+		if b := batched[io]; b != nil {
+			d, values = b.input, b.values
+		} else {
+			var err error
+			if d, err = ep.inputData(io); err != nil {
 				return err
 			}
+		}
+		ep.projCache.reset()
+		result := data.Map(make(map[string]data.Value, len(ep.projections)))
+		for i, proj := range ep.projections {
+			var value data.Value
+			if values != nil && values[i] != nil {
+				value = values[i]
+			} else {
+				var err error
+				if value, err = proj.evaluator.Eval(d); err != nil {
+					return err
+				}
+			}
 			if err := assignOutputValue(result, proj.alias, proj.aliasPath, value); err != nil {
 				return err
 			}
@@ -104,7 +147,7 @@ func (ep *defaultSelectExecutionPlan) performQueryOnBuffer() error {
 		io.hash = data.Hash(io.cache)
 		// since we have no grouping etc., "output data" = "cached data"
 		// and "hash of output data" = "hash of cached data"
-		output = append(output, resultRow{row: result, hash: io.hash})
+		output = append(output, resultRow{row: result, hash: io.hash, lineage: io.lineage})
 		return nil
 	}
 
@@ -120,3 +163,55 @@ func (ep *defaultSelectExecutionPlan) performQueryOnBuffer() error {
 	ep.curResults = output
 	return nil
 }
+
+// batchedRow has the input data of a row and the results of projections
+// calling batch UDFs on it. values[i] is nil when the i-th projection
+// doesn't call a batch UDF.
+type batchedRow struct {
+	input  data.Map
+	values []data.Value
+}
+
+// evalBatchProjections evaluates projections calling UDFs implementing
+// udf.BatchUDF on all rows in ep.filteredInputRows not having a cached
+// result so that each of the UDFs is called only once.
+func (ep *defaultSelectExecutionPlan) evalBatchProjections() (map[*inputRowWithCachedResult]*batchedRow, error) {
+	var rows []*inputRowWithCachedResult
+	var inputs []data.Value
+	for e := ep.filteredInputRows.Front(); e != nil; e = e.Next() {
+		r := e.Value.(*inputRowWithCachedResult)
+		if r.cache != nil {
+			continue
+		}
+		d, err := ep.inputData(r)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, r)
+		inputs = append(inputs, d)
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	batched := make(map[*inputRowWithCachedResult]*batchedRow, len(rows))
+	for i, r := range rows {
+		batched[r] = &batchedRow{
+			input:  inputs[i].(data.Map),
+			values: make([]data.Value, len(ep.projections)),
+		}
+	}
+	for i, f := range ep.batchFuncs {
+		if f == nil {
+			continue
+		}
+		vs, err := f.evalBatch(inputs, ep.projCache)
+		if err != nil {
+			return nil, err
+		}
+		for j, r := range rows {
+			batched[r].values[i] = vs[j]
+		}
+	}
+	return batched, nil
+}
@@ -2243,3 +2243,120 @@ func BenchmarkNormalJoin(b *testing.B) {
 		}
 	}
 }
+
+// testBatchUDF increments an integer. It records the number of calls of Call
+// and sizes of batches given to CallBatch.
+type testBatchUDF struct {
+	calls     int
+	batches   []int
+	shortened bool
+}
+
+func (f *testBatchUDF) Call(ctx *core.Context, args ...data.Value) (data.Value, error) {
+	f.calls++
+	i, err := data.AsInt(args[0])
+	if err != nil {
+		return nil, err
+	}
+	return data.Int(i + 1), nil
+}
+
+func (f *testBatchUDF) CallBatch(ctx *core.Context, args [][]data.Value) ([]data.Value, error) {
+	f.batches = append(f.batches, len(args))
+	res := make([]data.Value, len(args))
+	for i, a := range args {
+		v, err := data.AsInt(a[0])
+		if err != nil {
+			return nil, err
+		}
+		res[i] = data.Int(v + 1)
+	}
+	if f.shortened {
+		res = res[1:]
+	}
+	return res, nil
+}
+
+func (f *testBatchUDF) Accept(arity int) bool {
+	return arity == 1
+}
+
+func (f *testBatchUDF) IsAggregationParameter(k int) bool {
+	return false
+}
+
+func TestDefaultSelectExecutionPlanBatchUDF(t *testing.T) {
+	createPlan := func(s string, f udf.UDF) PhysicalPlan {
+		reg := udf.CopyGlobalUDFRegistry(core.NewContext(nil))
+		So(reg.Register("batch_inc", f), ShouldBeNil)
+		stmt, _, err := parser.New().ParseStmt(s)
+		So(err, ShouldBeNil)
+		lp, err := Analyze(stmt.(parser.CreateStreamAsSelectStmt).Select, reg)
+		So(err, ShouldBeNil)
+		plan, err := NewDefaultSelectExecutionPlan(lp, reg)
+		So(err, ShouldBeNil)
+		return plan
+	}
+
+	Convey("Given a join projecting a UDF implementing BatchUDF", t, func() {
+		f := &testBatchUDF{}
+		plan := createPlan(`CREATE STREAM box AS SELECT RSTREAM batch_inc(a:int) AS x, b:int AS y
+			FROM src [RANGE 3 TUPLES] AS a, src [RANGE 3 TUPLES] AS b`, f)
+
+		Convey("When feeding it with tuples", func() {
+			var out []data.Map
+			for _, inTup := range getTuples(3) {
+				var err error
+				out, err = plan.Process(inTup)
+				So(err, ShouldBeNil)
+			}
+
+			Convey("Then the UDF should be called once for all new rows", func() {
+				So(f.batches, ShouldResemble, []int{1, 3, 5})
+				So(f.calls, ShouldEqual, 0)
+			})
+
+			Convey("Then results should be the same as calling it one by one", func() {
+				So(len(out), ShouldEqual, 9)
+				for _, m := range out {
+					So(len(m), ShouldEqual, 2)
+				}
+				sort.Sort(tupleList(out))
+				So(out[0], ShouldResemble, data.Map{"x": data.Int(2), "y": data.Int(1)})
+				So(out[8], ShouldResemble, data.Map{"x": data.Int(4), "y": data.Int(3)})
+			})
+		})
+	})
+
+	Convey("Given a UDF implementing BatchUDF used in an expression", t, func() {
+		f := &testBatchUDF{}
+		plan := createPlan(`CREATE STREAM box AS SELECT RSTREAM batch_inc(int) + 1 AS x
+			FROM src [RANGE 3 TUPLES]`, f)
+
+		Convey("When feeding it with tuples", func() {
+			for _, inTup := range getTuples(3) {
+				_, err := plan.Process(inTup)
+				So(err, ShouldBeNil)
+			}
+
+			Convey("Then the UDF should be called for each row", func() {
+				So(f.batches, ShouldBeEmpty)
+				So(f.calls, ShouldEqual, 3)
+			})
+		})
+	})
+
+	Convey("Given a UDF returning a wrong number of results", t, func() {
+		f := &testBatchUDF{shortened: true}
+		plan := createPlan(`CREATE STREAM box AS SELECT RSTREAM batch_inc(int) AS x
+			FROM src [RANGE 3 TUPLES]`, f)
+
+		Convey("When feeding it with a tuple", func() {
+			_, err := plan.Process(getTuples(1)[0])
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
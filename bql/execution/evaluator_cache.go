@@ -0,0 +1,316 @@
+package execution
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"gopkg.in/sensorbee/sensorbee.v0/bql/udf"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// evalCache holds results of expressions evaluated on the current input row.
+// Each expression shared by Evaluators has a slot in the cache. The cache
+// must be reset before evaluating Evaluators on a new input row.
+type evalCache struct {
+	values []data.Value
+	errs   []error
+	valid  []bool
+}
+
+func (c *evalCache) newSlot() int {
+	c.values = append(c.values, nil)
+	c.errs = append(c.errs, nil)
+	c.valid = append(c.valid, false)
+	return len(c.valid) - 1
+}
+
+// reset invalidates all values in the cache. It can be called on a nil
+// cache.
+func (c *evalCache) reset() {
+	if c == nil {
+		return
+	}
+	for i := range c.valid {
+		c.values[i] = nil
+		c.errs[i] = nil
+		c.valid[i] = false
+	}
+}
+
+// cachedEvaluator evaluates the underlying Evaluator only once per input row
+// and returns the cached result afterwards.
+type cachedEvaluator struct {
+	cache *evalCache
+	slot  int
+	eval  Evaluator
+}
+
+func (c *cachedEvaluator) Eval(input data.Value) (data.Value, error) {
+	if c.cache.valid[c.slot] {
+		return c.cache.values[c.slot], c.cache.errs[c.slot]
+	}
+	v, err := c.eval.Eval(input)
+	c.cache.values[c.slot] = v
+	c.cache.errs[c.slot] = err
+	c.cache.valid[c.slot] = true
+	return v, err
+}
+
+// subPathAccess returns the value at the path in the Map returned from
+// the parent Evaluator. It's used to share extraction of a common prefix
+// of paths, e.g. `x:a.b` in `x:a.b.c` and `x:a.b.d`.
+type subPathAccess struct {
+	parent Evaluator
+	path   data.Path
+	// relation is the alias of the relation which the path refers to.
+	relation string
+}
+
+func (s *subPathAccess) Eval(input data.Value) (data.Value, error) {
+	v, err := s.parent.Eval(input)
+	if err != nil {
+		return nil, err
+	}
+	m, err := data.AsMap(v)
+	if err == nil {
+		v, err = m.Get(s.path)
+	}
+	if err != nil {
+		if in, ok := input.(data.Map); ok && isPaddedRelation(in, s.relation) {
+			return data.Null{}, nil
+		}
+		return nil, err
+	}
+	return v, nil
+}
+
+// evaluatorBuilder creates Evaluators from FlatExpressions. When it has
+// a cache, expressions appearing more than once in the expressions given to
+// newSharingEvaluatorBuilder are evaluated only once per input row. Common
+// prefixes of paths are also extracted only once.
+type evaluatorBuilder struct {
+	reg udf.FunctionRegistry
+
+	// cache is nil when the builder doesn't share Evaluators.
+	cache *evalCache
+
+	// counts has the number of occurrences of each cacheable expression
+	// and path prefix.
+	counts map[string]int
+
+	// shared has Evaluators of expressions and path prefixes which have
+	// already been created.
+	shared map[string]Evaluator
+}
+
+// newSharingEvaluatorBuilder creates an evaluatorBuilder sharing common
+// sub-expressions of exprs. Evaluators created by the builder must only be
+// evaluated after resetting the cache for each input row.
+func newSharingEvaluatorBuilder(exprs []FlatExpression, reg udf.FunctionRegistry) *evaluatorBuilder {
+	b := &evaluatorBuilder{
+		reg:    reg,
+		cache:  &evalCache{},
+		counts: map[string]int{},
+		shared: map[string]Evaluator{},
+	}
+	for _, e := range exprs {
+		b.count(e)
+	}
+	return b
+}
+
+// build creates an Evaluator of the expression. It returns a shared Evaluator
+// when the expression appears more than once.
+func (b *evaluatorBuilder) build(ast FlatExpression) (Evaluator, error) {
+	if b.cache == nil {
+		return b.newEvaluator(ast)
+	}
+	key, ok := exprCacheKey(ast)
+	if !ok || b.counts[key] < 2 {
+		return b.newEvaluator(ast)
+	}
+	if e, ok := b.shared[key]; ok {
+		return e, nil
+	}
+	e, err := b.newEvaluator(ast)
+	if err != nil {
+		return nil, err
+	}
+	return b.share(key, e), nil
+}
+
+func (b *evaluatorBuilder) share(key string, e Evaluator) Evaluator {
+	c := &cachedEvaluator{
+		cache: b.cache,
+		slot:  b.cache.newSlot(),
+		eval:  e,
+	}
+	b.shared[key] = c
+	return c
+}
+
+// count counts occurrences of the expression and its sub-expressions.
+func (b *evaluatorBuilder) count(ast FlatExpression) {
+	if ast == nil {
+		return
+	}
+	if key, ok := exprCacheKey(ast); ok {
+		b.counts[key]++
+		if b.counts[key] > 1 {
+			// sub-expressions of a shared expression are only evaluated
+			// through the shared one.
+			return
+		}
+	}
+
+	switch obj := ast.(type) {
+	case rowValue:
+		segs, err := splitRowValuePath(obj)
+		if err != nil || segs == nil {
+			return
+		}
+		for n := 2; n <= len(segs); n++ {
+			b.counts[pathCacheKey(segs[:n])]++
+		}
+	case binaryOpAST:
+		b.count(obj.Left)
+		b.count(obj.Right)
+	case unaryOpAST:
+		b.count(obj.Expr)
+	case typeCastAST:
+		b.count(obj.Expr)
+	case funcAppAST:
+		for _, e := range obj.Expressions {
+			b.count(e)
+		}
+	case funcAppSelectorAST:
+		b.count(obj.Expr)
+	case arrayAST:
+		for _, e := range obj.Expressions {
+			b.count(e)
+		}
+	case mapAST:
+		for _, p := range obj.Entries {
+			b.count(p.Value)
+		}
+	case caseAST:
+		b.count(obj.Reference)
+		for _, p := range obj.Checks {
+			b.count(p.When)
+			b.count(p.Then)
+		}
+		b.count(obj.Default)
+	}
+	// missing and aggregateInputSorter create Evaluators of their
+	// sub-expressions without sharing them.
+}
+
+// exprCacheKey returns the key of the expression used for sharing its
+// Evaluator. It returns false when the expression cannot or doesn't have to
+// be shared. Volatile expressions cannot be shared because they can return
+// a different result on every evaluation. Array and map builders aren't
+// shared so that output values don't share the same container. rowValues
+// are shared by their path prefixes instead.
+func exprCacheKey(ast FlatExpression) (string, bool) {
+	switch ast.(type) {
+	case nullLiteral, numericLiteral, floatLiteral, boolLiteral, stringLiteral,
+		valueLiteral, wildcardAST, arrayAST, mapAST, rowValue:
+		return "", false
+	}
+	if ast.Volatility() == Volatile {
+		return "", false
+	}
+	return "expr:" + ast.Repr(), true
+}
+
+func rowValuePath(rv rowValue) string {
+	path := rv.Column
+	if rv.Relation != "" {
+		if strings.HasPrefix(path, "[") {
+			path = rv.Relation + path
+		} else {
+			path = rv.Relation + "." + path
+		}
+	}
+	return path
+}
+
+// splitRowValuePath splits the path of a rowValue with data.SplitPath. It
+// returns nil when the path cannot be split.
+func splitRowValuePath(rv rowValue) ([]data.Path, error) {
+	p, err := data.CompilePath(rowValuePath(rv))
+	if err != nil {
+		return nil, err
+	}
+	segs, ok := data.SplitPath(p)
+	if !ok {
+		return nil, nil
+	}
+	return segs, nil
+}
+
+func joinPathSegments(segs []data.Path) string {
+	var buf bytes.Buffer
+	for _, s := range segs {
+		fmt.Fprint(&buf, s)
+	}
+	return buf.String()
+}
+
+func pathCacheKey(segs []data.Path) string {
+	return "path:" + joinPathSegments(segs)
+}
+
+// newSharedPathAccess creates an Evaluator of a rowValue which shares
+// extraction of the longest common prefix with other rowValues.
+func (b *evaluatorBuilder) newSharedPathAccess(rv rowValue) (Evaluator, error) {
+	segs, err := splitRowValuePath(rv)
+	if err != nil {
+		return nil, err
+	}
+	if segs == nil {
+		return newRowValueAccess(rv)
+	}
+	return b.pathPrefixEvaluator(rv.Relation, segs, len(segs))
+}
+
+// pathPrefixEvaluator returns an Evaluator of the path consisting of the
+// first n segments. relation is the alias of the relation which the path
+// refers to.
+func (b *evaluatorBuilder) pathPrefixEvaluator(relation string, segs []data.Path, n int) (Evaluator, error) {
+	key := pathCacheKey(segs[:n])
+	if e, ok := b.shared[key]; ok {
+		return e, nil
+	}
+
+	var e Evaluator
+	// Prefixes having only one segment, which is usually the name of
+	// a relation, aren't shared because it's a single map lookup.
+	for k := n - 1; k >= 2; k-- {
+		if b.counts[pathCacheKey(segs[:k])] < 2 {
+			continue
+		}
+		parent, err := b.pathPrefixEvaluator(relation, segs, k)
+		if err != nil {
+			return nil, err
+		}
+		rest, err := data.CompilePath(joinPathSegments(segs[k:n]))
+		if err != nil {
+			return nil, err
+		}
+		e = &subPathAccess{parent, rest, relation}
+		break
+	}
+	if e == nil {
+		path, err := data.CompilePath(joinPathSegments(segs[:n]))
+		if err != nil {
+			return nil, err
+		}
+		e = &pathAccess{path, relation}
+	}
+	if b.counts[key] >= 2 {
+		e = b.share(key, e)
+	}
+	return e, nil
+}
@@ -0,0 +1,88 @@
+package execution
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/parser"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/udf"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+func TestSharingEvaluatorBuilder(t *testing.T) {
+	reg := udf.CopyGlobalUDFRegistry(core.NewContext(nil))
+	abc := rowValue{"x", "a.b.c"}
+	abd := rowValue{"x", `a["b"].d`}
+	plus := binaryOpAST{parser.Plus, abc, numericLiteral{1}}
+
+	Convey("Given expressions having common sub-expressions", t, func() {
+		exprs := []FlatExpression{
+			plus,
+			binaryOpAST{parser.Multiply, plus, numericLiteral{2}},
+			abd,
+			funcAppAST{parser.FuncName("abs"), []FlatExpression{numericLiteral{1}}},
+		}
+		b := newSharingEvaluatorBuilder(exprs, reg)
+		evals := make([]Evaluator, len(exprs))
+		for i, e := range exprs {
+			ev, err := b.build(e)
+			So(err, ShouldBeNil)
+			evals[i] = ev
+		}
+
+		Convey("Then the common sub-expression should be shared", func() {
+			So(evals[0], ShouldHaveSameTypeAs, &cachedEvaluator{})
+			mul := evals[1].(*numBinOp)
+			So(mul.left, ShouldEqual, evals[0])
+		})
+
+		Convey("Then the common prefix of paths should be extracted once", func() {
+			d, ok := evals[2].(*subPathAccess)
+			So(ok, ShouldBeTrue)
+			c := evals[0].(*cachedEvaluator).eval.(*numBinOp).left.(*subPathAccess)
+			So(c.parent, ShouldEqual, d.parent)
+			So(c.parent, ShouldHaveSameTypeAs, &cachedEvaluator{})
+		})
+
+		Convey("Then volatile expressions shouldn't be shared", func() {
+			So(evals[3], ShouldHaveSameTypeAs, &funcApp{})
+		})
+
+		Convey("When evaluating them on rows", func() {
+			eval := func(row data.Map) []data.Value {
+				b.cache.reset()
+				res := make([]data.Value, len(evals))
+				for i, e := range evals {
+					v, err := e.Eval(row)
+					So(err, ShouldBeNil)
+					res[i] = v
+				}
+				return res
+			}
+			row := func(c, d int64) data.Map {
+				return data.Map{"x": data.Map{"a": data.Map{"b": data.Map{
+					"c": data.Int(c),
+					"d": data.Int(d),
+				}}}}
+			}
+
+			Convey("Then the results should be the same as without sharing", func() {
+				So(eval(row(1, 2)), ShouldResemble, []data.Value{data.Int(2), data.Int(4), data.Int(2), data.Int(1)})
+				So(eval(row(3, 4)), ShouldResemble, []data.Value{data.Int(4), data.Int(8), data.Int(4), data.Int(1)})
+			})
+		})
+	})
+
+	Convey("Given expressions without common sub-expressions", t, func() {
+		exprs := []FlatExpression{abc, rowValue{"x", "e"}, plus}
+		b := newSharingEvaluatorBuilder(exprs, reg)
+
+		Convey("Then evaluators shouldn't be shared", func() {
+			ev, err := b.build(exprs[1])
+			So(err, ShouldBeNil)
+			So(ev, ShouldHaveSameTypeAs, &pathAccess{})
+			So(b.cache.values, ShouldBeEmpty)
+		})
+	})
+}
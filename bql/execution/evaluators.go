@@ -74,6 +74,13 @@ func EvaluateOnInput(expr parser.Expression, input data.Value, reg udf.FunctionR
 // an Evaluator that can be used to evaluate an expression given a particular
 // input Value.
 func ExpressionToEvaluator(ast FlatExpression, reg udf.FunctionRegistry) (Evaluator, error) {
+	b := &evaluatorBuilder{reg: reg}
+	return b.build(ast)
+}
+
+// newEvaluator creates an Evaluator of the given expression. Evaluators of
+// sub-expressions are created by b.build.
+func (b *evaluatorBuilder) newEvaluator(ast FlatExpression) (Evaluator, error) {
 	switch obj := ast.(type) {
 	case rowMeta:
 		// construct a key for reading as used in setMetadata() for writing
@@ -84,6 +91,8 @@ func ExpressionToEvaluator(ast FlatExpression, reg udf.FunctionRegistry) (Evalua
 				return nil, err
 			}
 			return &timestampCast{pa}, nil
+		} else if obj.MetaType == parser.IDMeta || obj.MetaType == parser.BackfillMeta {
+			return newPathAccess(metaKey)
 		}
 	case stmtMeta:
 		// construct a key for reading as used in setMetadata() for writing
@@ -96,15 +105,10 @@ func ExpressionToEvaluator(ast FlatExpression, reg udf.FunctionRegistry) (Evalua
 			return &timestampCast{pa}, nil
 		}
 	case rowValue:
-		path := obj.Column
-		if obj.Relation != "" {
-			if strings.HasPrefix(path, "[") {
-				path = obj.Relation + path
-			} else {
-				path = obj.Relation + "." + path
-			}
+		if b.cache != nil {
+			return b.newSharedPathAccess(obj)
 		}
-		return newPathAccess(path)
+		return newRowValueAccess(obj)
 	case aggInputRef:
 		return newPathAccess(obj.Ref)
 	case nullLiteral:
@@ -117,13 +121,15 @@ func ExpressionToEvaluator(ast FlatExpression, reg udf.FunctionRegistry) (Evalua
 		return &boolConstant{obj.Value}, nil
 	case stringLiteral:
 		return &stringConstant{obj.Value}, nil
+	case valueLiteral:
+		return &valueConstant{obj.Value}, nil
 	case binaryOpAST:
 		// recurse
-		left, err := ExpressionToEvaluator(obj.Left, reg)
+		left, err := b.build(obj.Left)
 		if err != nil {
 			return nil, err
 		}
-		right, err := ExpressionToEvaluator(obj.Right, reg)
+		right, err := b.build(obj.Right)
 		if err != nil {
 			return nil, err
 		}
@@ -163,6 +169,10 @@ func ExpressionToEvaluator(ast FlatExpression, reg udf.FunctionRegistry) (Evalua
 			if obj.Right == (nullLiteral{}) {
 				return newNot(newIsNull(left)), nil
 			}
+		case parser.IsDistinctFrom:
+			return newIsDistinctFrom(bo), nil
+		case parser.IsNotDistinctFrom:
+			return newNot(newIsDistinctFrom(bo)), nil
 		case parser.Plus:
 			return newPlus(bo), nil
 		case parser.Minus:
@@ -176,7 +186,7 @@ func ExpressionToEvaluator(ast FlatExpression, reg udf.FunctionRegistry) (Evalua
 		}
 	case unaryOpAST:
 		// recurse
-		expr, err := ExpressionToEvaluator(obj.Expr, reg)
+		expr, err := b.build(obj.Expr)
 		if err != nil {
 			return nil, err
 		}
@@ -193,22 +203,23 @@ func ExpressionToEvaluator(ast FlatExpression, reg udf.FunctionRegistry) (Evalua
 			return newMultiply(bo), nil
 		}
 	case missing:
-		// recurse
-		expr, err := ExpressionToEvaluator(obj.Expr, reg)
+		// recurse without the cache because newMissingPathCheck requires
+		// a plain pathAccess
+		expr, err := (&evaluatorBuilder{reg: b.reg}).build(obj.Expr)
 		if err != nil {
 			return nil, err
 		}
 		return newMissingPathCheck(expr, obj.Not)
 	case typeCastAST:
 		// recurse
-		expr, err := ExpressionToEvaluator(obj.Expr, reg)
+		expr, err := b.build(obj.Expr)
 		if err != nil {
 			return nil, err
 		}
 		return newTypeCast(expr, obj.Target)
 	case funcAppSelectorAST:
 		// recurse
-		expr, err := ExpressionToEvaluator(obj.Expr, reg)
+		expr, err := b.build(obj.Expr)
 		if err != nil {
 			return nil, err
 		}
@@ -219,27 +230,27 @@ func ExpressionToEvaluator(ast FlatExpression, reg udf.FunctionRegistry) (Evalua
 		// (the registry will decide if the requested function
 		// is callable with the given number of arguments).
 		fName := string(obj.Function)
-		f, err := reg.Lookup(fName, len(obj.Expressions))
+		f, err := b.reg.Lookup(fName, len(obj.Expressions))
 		if err != nil {
 			return nil, err
 		}
 		// compute child Evaluators
 		evals := make([]Evaluator, len(obj.Expressions))
 		for i, ast := range obj.Expressions {
-			eval, err := ExpressionToEvaluator(ast, reg)
+			eval, err := b.build(ast)
 			if err != nil {
 				return nil, err
 			}
 			evals[i] = eval
 		}
-		return FuncApp(fName, f, reg.Context(), evals), nil
+		return FuncApp(fName, f, b.reg.Context(), evals), nil
 	case aggregateInputSorter:
-		return newSortedInputAggFuncApp(obj.funcAppAST, obj.ID, obj.Ordering, reg)
+		return newSortedInputAggFuncApp(obj.funcAppAST, obj.ID, obj.Ordering, b.reg)
 	case arrayAST:
 		// compute child Evaluators
 		evals := make([]Evaluator, len(obj.Expressions))
 		for i, ast := range obj.Expressions {
-			eval, err := ExpressionToEvaluator(ast, reg)
+			eval, err := b.build(ast)
 			if err != nil {
 				return nil, err
 			}
@@ -251,7 +262,7 @@ func ExpressionToEvaluator(ast FlatExpression, reg udf.FunctionRegistry) (Evalua
 		names := make([]string, len(obj.Entries))
 		evals := make([]Evaluator, len(obj.Entries))
 		for i, pair := range obj.Entries {
-			eval, err := ExpressionToEvaluator(pair.Value, reg)
+			eval, err := b.build(pair.Value)
 			if err != nil {
 				return nil, err
 			}
@@ -261,7 +272,7 @@ func ExpressionToEvaluator(ast FlatExpression, reg udf.FunctionRegistry) (Evalua
 		return newMapBuilder(names, evals)
 	case caseAST:
 		// compute the Evaluator for the thing we match against
-		ref, err := ExpressionToEvaluator(obj.Reference, reg)
+		ref, err := b.build(obj.Reference)
 		if err != nil {
 			return nil, err
 		}
@@ -269,19 +280,19 @@ func ExpressionToEvaluator(ast FlatExpression, reg udf.FunctionRegistry) (Evalua
 		whens := make([]Evaluator, len(obj.Checks))
 		thens := make([]Evaluator, len(obj.Checks))
 		for i, pair := range obj.Checks {
-			eval, err := ExpressionToEvaluator(pair.When, reg)
+			eval, err := b.build(pair.When)
 			if err != nil {
 				return nil, err
 			}
 			whens[i] = eval
-			eval, err = ExpressionToEvaluator(pair.Then, reg)
+			eval, err = b.build(pair.Then)
 			if err != nil {
 				return nil, err
 			}
 			thens[i] = eval
 		}
 		// compute the Evaluator for the default value (if nothing matches)
-		def, err := ExpressionToEvaluator(obj.Default, reg)
+		def, err := b.build(obj.Default)
 		if err != nil {
 			return nil, err
 		}
@@ -312,6 +323,23 @@ func (i *intConstant) Eval(input data.Value) (data.Value, error) {
 	return data.Int(i.value), nil
 }
 
+// valueConstant always returns the same value, independent of the input.
+// An array or a map is copied on each evaluation so that callers can't
+// modify the original value.
+type valueConstant struct {
+	value data.Value
+}
+
+func (c *valueConstant) Eval(input data.Value) (data.Value, error) {
+	switch v := c.value.(type) {
+	case data.Array:
+		return v.Copy(), nil
+	case data.Map:
+		return v.Copy(), nil
+	}
+	return c.value, nil
+}
+
 // floatConstant always returns the same float value, independent
 // of the input.
 type floatConstant struct {
@@ -346,6 +374,9 @@ func (s *stringConstant) Eval(input data.Value) (data.Value, error) {
 // JSON path.
 type pathAccess struct {
 	path data.Path
+	// relation is the alias of the relation which the path refers to. It's
+	// empty when the path doesn't refer to a column of a relation.
+	relation string
 }
 
 func (fa *pathAccess) Eval(input data.Value) (data.Value, error) {
@@ -353,7 +384,11 @@ func (fa *pathAccess) Eval(input data.Value) (data.Value, error) {
 	if err != nil {
 		return nil, err
 	}
-	return aMap.Get(fa.path)
+	v, err := aMap.Get(fa.path)
+	if err != nil && isPaddedRelation(aMap, fa.relation) {
+		return data.Null{}, nil
+	}
+	return v, err
 }
 
 func newPathAccess(s string) (Evaluator, error) {
@@ -361,7 +396,28 @@ func newPathAccess(s string) (Evaluator, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &pathAccess{path}, nil
+	return &pathAccess{path: path}, nil
+}
+
+// newRowValueAccess creates an Evaluator of a column of a relation.
+func newRowValueAccess(rv rowValue) (Evaluator, error) {
+	path, err := data.CompilePath(rowValuePath(rv))
+	if err != nil {
+		return nil, err
+	}
+	return &pathAccess{path, rv.Relation}, nil
+}
+
+// isPaddedRelation returns true when the relation is NULL in the input row.
+// It only happens in a row of an outer join padded for a tuple which doesn't
+// match any tuple of the relation. All columns of the relation are NULL in
+// such a row.
+func isPaddedRelation(input data.Map, relation string) bool {
+	if relation == "" {
+		return false
+	}
+	v, ok := input[relation]
+	return ok && v.Type() == data.TypeNull
 }
 
 type missingPathCheck struct {
@@ -475,6 +531,10 @@ func (t *timestampCast) Eval(input data.Value) (data.Value, error) {
 	if err != nil {
 		return nil, err
 	}
+	if val.Type() == data.TypeNull {
+		// the metadata of a relation padded by an outer join
+		return val, nil
+	}
 	if val.Type() != data.TypeTimestamp {
 		return nil, fmt.Errorf("value %v was %T, not Time", val, val)
 	}
@@ -766,6 +826,35 @@ func newIsNull(e Evaluator) Evaluator {
 	return &isNull{e}
 }
 
+/// A Null-Safe Comparison Operation
+
+// isDistinctFrom compares two values treating NULL as an ordinary value, so
+// it never returns NULL:
+//
+//	NULL IS DISTINCT FROM NULL => false
+//	NULL IS DISTINCT FROM 1    => true
+//	1    IS DISTINCT FROM 1    => false
+type isDistinctFrom struct {
+	binOp
+}
+
+func (d *isDistinctFrom) Eval(input data.Value) (data.Value, error) {
+	leftVal, rightVal, err := d.evalLeftAndRight(input)
+	if err != nil {
+		return nil, err
+	}
+	leftNull := leftVal.Type() == data.TypeNull
+	rightNull := rightVal.Type() == data.TypeNull
+	if leftNull || rightNull {
+		return data.Bool(leftNull != rightNull), nil
+	}
+	return data.Bool(!data.Equal(leftVal, rightVal)), nil
+}
+
+func newIsDistinctFrom(bo binOp) Evaluator {
+	return &isDistinctFrom{bo}
+}
+
 /// Binary Numerical Operations
 
 // numBinOp provides functionality for evaluating binary operations
@@ -924,6 +1013,10 @@ type funcApp struct {
 	params      []Evaluator
 	paramValues []reflect.Value
 	selector    data.Path
+
+	// batch is the UDF when it implements udf.BatchUDF, otherwise nil.
+	batch udf.BatchUDF
+	ctx   *core.Context
 }
 
 func (f *funcApp) Eval(input data.Value) (v data.Value, err error) {
@@ -954,28 +1047,77 @@ func (f *funcApp) Eval(input data.Value) (v data.Value, err error) {
 		err := errVal.Interface().(error)
 		return nil, err
 	}
-	result := resultVal.Interface().(data.Value)
-	if f.selector != nil {
-		switch result.Type() {
-		case data.TypeMap:
-			retmap, _ := data.AsMap(result)
-			selected, err := retmap.Get(f.selector)
-			if err != nil {
-				return nil, err
-			}
-			result = selected
-		case data.TypeArray:
-			retarr, _ := data.AsArray(result)
-			selected, err := retarr.Get(f.selector)
+	return f.selectResult(resultVal.Interface().(data.Value))
+}
+
+// evalBatch evaluates the function on each of inputs with one call of
+// udf.BatchUDF.CallBatch. It must only be called when f.batch isn't nil.
+// cache is reset before evaluating parameters on each input since they
+// may share results of sub-expressions with other Evaluators.
+func (f *funcApp) evalBatch(inputs []data.Value, cache *evalCache) (vs []data.Value, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			vs = nil
+			err = fmt.Errorf("evaluating '%s' paniced: %s", f.name, r)
+		}
+	}()
+	args := make([][]data.Value, len(inputs))
+	for i, input := range inputs {
+		cache.reset()
+		args[i] = make([]data.Value, len(f.params))
+		for j, param := range f.params {
+			value, err := param.Eval(input)
 			if err != nil {
 				return nil, err
 			}
-			result = selected
-		default:
-			return nil, fmt.Errorf("type '%v' is not supported with selector", result.Type())
+			args[i][j] = value
+		}
+	}
+	results, err := f.batch.CallBatch(f.ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	if len(results) != len(inputs) {
+		return nil, fmt.Errorf("function %s returned %d results for %d calls",
+			f.name, len(results), len(inputs))
+	}
+	vs = make([]data.Value, len(results))
+	for i, r := range results {
+		if r == nil {
+			return nil, fmt.Errorf("function %s returned nil", f.name)
+		}
+		if vs[i], err = f.selectResult(r); err != nil {
+			return nil, err
 		}
 	}
-	return result, nil
+	return vs, nil
+}
+
+// selectResult returns the value at the selector in the result of the
+// function.
+func (f *funcApp) selectResult(result data.Value) (data.Value, error) {
+	if f.selector == nil {
+		return result, nil
+	}
+	switch result.Type() {
+	case data.TypeMap:
+		retmap, _ := data.AsMap(result)
+		return retmap.Get(f.selector)
+	case data.TypeArray:
+		retarr, _ := data.AsArray(result)
+		return retarr.Get(f.selector)
+	default:
+		return nil, fmt.Errorf("type '%v' is not supported with selector", result.Type())
+	}
+}
+
+// batchFuncApp returns the funcApp when the Evaluator is a call of a UDF
+// implementing udf.BatchUDF. Otherwise, it returns nil.
+func batchFuncApp(e Evaluator) *funcApp {
+	if f, ok := e.(*funcApp); ok && f.batch != nil {
+		return f
+	}
+	return nil
 }
 
 // FuncApp represents evaluation of a function on a number
@@ -984,11 +1126,14 @@ func FuncApp(name string, f udf.UDF, ctx *core.Context, params []Evaluator) Eval
 	fVal := reflect.ValueOf(f.Call)
 	paramValues := make([]reflect.Value, len(params)+1)
 	paramValues[0] = reflect.ValueOf(ctx)
+	batch, _ := f.(udf.BatchUDF)
 	return &funcApp{
 		name:        name,
 		fVal:        fVal,
 		params:      params,
 		paramValues: paramValues,
+		batch:       batch,
+		ctx:         ctx,
 	}
 }
 
@@ -1054,9 +1199,10 @@ func (s *sortedInputAggFuncApp) Eval(input data.Value) (v data.Value, err error)
 	for i := range indexes {
 		indexes[i] = i
 	}
-	// sort the index array
+	// sort the index array (stably so that rows having the same sort
+	// key keep the order of the window)
 	is := &indexSlice{indexes, sortData}
-	sort.Sort(is)
+	sort.Stable(is)
 
 	// now use the sorted index array to write a sorted copy of the data
 	for unsortedKey, sortedKey := range s.inOutKeys {
@@ -1224,6 +1370,11 @@ func (c *caseBuilder) Eval(input data.Value) (v data.Value, err error) {
 		if err != nil {
 			return nil, err
 		}
+		// Like a = b, comparing NULL with any value results in NULL, which
+		// isn't a match. So, CASE NULL WHEN NULL doesn't match.
+		if predicate.Type() == data.TypeNull || whenValue.Type() == data.TypeNull {
+			continue
+		}
 		if data.Equal(predicate, whenValue) {
 			resultEval = c.thens[i]
 			break
@@ -1257,24 +1408,35 @@ func (w *wildcard) Eval(input data.Value) (data.Value, error) {
 	if err != nil {
 		return nil, err
 	}
-	output := data.Map{}
+	var output data.Map
 	if w.Relation != "" {
 		// if we have t:*, pick only the items in this submap
 		subElement, exists := aMap[w.Relation]
 		if !exists {
 			return nil, fmt.Errorf("there is no entry with key '%s'", w.Relation)
 		}
+		if isPaddedRelation(aMap, w.Relation) {
+			return data.Map{}, nil
+		}
 		subMap, err := data.AsMap(subElement)
 		if err != nil {
 			return nil, err
 		}
+		output = make(data.Map, len(subMap))
 		for key, value := range subMap {
 			output[key] = value
 		}
 	} else {
 		// if we have *, take items from all submaps
+		n := 0
+		for alias, subElement := range aMap {
+			if m, ok := subElement.(data.Map); ok && !strings.Contains(alias, ":meta:") {
+				n += len(m)
+			}
+		}
+		output = make(data.Map, n)
 		for alias, subElement := range aMap {
-			if strings.Contains(alias, ":meta:") {
+			if strings.Contains(alias, ":meta:") || isPaddedRelation(aMap, alias) {
 				continue
 			}
 			subMap, err := data.AsMap(subElement)
@@ -187,6 +187,9 @@ func TestFoldableExecution(t *testing.T) {
 		{parser.ExpressionCaseAST{parser.BinaryOpAST{parser.Plus, parser.NumericLiteral{1}, parser.NumericLiteral{3}}, parser.ConditionCaseAST{[]parser.WhenThenPairAST{
 			{parser.NumericLiteral{3}, parser.NumericLiteral{4}}, {parser.NumericLiteral{4}, parser.NumericLiteral{5}}}, parser.NullLiteral{}}},
 			true, data.Int(5)},
+		{parser.ExpressionCaseAST{parser.NullLiteral{}, parser.ConditionCaseAST{[]parser.WhenThenPairAST{
+			{parser.NullLiteral{}, parser.NumericLiteral{4}}}, parser.NumericLiteral{5}}},
+			true, data.Int(5)},
 		{parser.ConditionCaseAST{[]parser.WhenThenPairAST{
 			{parser.RowValue{"", "a"}, parser.NumericLiteral{3}}}, parser.NullLiteral{}},
 			false, nil},
@@ -1546,6 +1549,42 @@ func getTestCases() []struct {
 				{data.Map{"a": data.Null{}}, data.Bool(true)},
 			},
 		},
+		// IsDistinctFrom
+		{parser.BinaryOpAST{parser.IsDistinctFrom, parser.RowValue{"", "a"}, parser.RowValue{"", "b"}},
+			[]evalTest{
+				// not a map:
+				{data.Int(17), nil},
+				// keys not present:
+				{data.Map{"x": data.Int(17)}, nil},
+				// both present and not null => same as <>
+				{data.Map{"a": data.Int(1), "b": data.Int(1)}, data.Bool(false)},
+				{data.Map{"a": data.Int(1), "b": data.Float(1)}, data.Bool(false)},
+				{data.Map{"a": data.Int(1), "b": data.Int(2)}, data.Bool(true)},
+				{data.Map{"a": data.Int(1), "b": data.String("1")}, data.Bool(true)},
+				// one of them is null => true
+				{data.Map{"a": data.Int(1), "b": data.Null{}}, data.Bool(true)},
+				{data.Map{"a": data.Null{}, "b": data.Int(1)}, data.Bool(true)},
+				// both null => false
+				{data.Map{"a": data.Null{}, "b": data.Null{}}, data.Bool(false)},
+			},
+		},
+		// IsNotDistinctFrom
+		{parser.BinaryOpAST{parser.IsNotDistinctFrom, parser.RowValue{"", "a"}, parser.RowValue{"", "b"}},
+			[]evalTest{
+				// not a map:
+				{data.Int(17), nil},
+				// keys not present:
+				{data.Map{"x": data.Int(17)}, nil},
+				// both present and not null => same as =
+				{data.Map{"a": data.Int(1), "b": data.Int(1)}, data.Bool(true)},
+				{data.Map{"a": data.Int(1), "b": data.Int(2)}, data.Bool(false)},
+				// one of them is null => false
+				{data.Map{"a": data.Int(1), "b": data.Null{}}, data.Bool(false)},
+				{data.Map{"a": data.Null{}, "b": data.Int(1)}, data.Bool(false)},
+				// both null => true
+				{data.Map{"a": data.Null{}, "b": data.Null{}}, data.Bool(true)},
+			},
+		},
 		/// Computational Operations
 		// Plus
 		{parser.BinaryOpAST{parser.Plus, parser.RowValue{"", "a"}, parser.RowValue{"", "b"}},
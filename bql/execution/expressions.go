@@ -8,6 +8,8 @@ import (
 
 	"gopkg.in/sensorbee/sensorbee.v0/bql/parser"
 	"gopkg.in/sensorbee/sensorbee.v0/bql/udf"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
 )
 
 // aliasedExpression represents an expression in a SELECT clause
@@ -102,6 +104,8 @@ func ParserExprToFlatExpr(e parser.Expression, reg udf.FunctionRegistry) (FlatEx
 		return boolLiteral{obj.Value}, nil
 	case parser.StringLiteral:
 		return stringLiteral{obj.Value}, nil
+	case parser.ConstantRef:
+		return constantToFlatExpr(obj.Name, reg)
 	case parser.BinaryOpAST:
 		// recurse left
 		left, err := ParserExprToFlatExpr(obj.Left, reg)
@@ -252,6 +256,36 @@ func ParserExprToFlatExpr(e parser.Expression, reg udf.FunctionRegistry) (FlatEx
 	return nil, err
 }
 
+// constantToFlatExpr converts a reference to a constant of the topology to
+// a literal having the current value of the constant.
+func constantToFlatExpr(name string, reg udf.FunctionRegistry) (FlatExpression, error) {
+	var ctx *core.Context
+	if reg != nil {
+		ctx = reg.Context()
+	}
+	v, err := ctx.Constant(name)
+	if err != nil {
+		return nil, err
+	}
+	switch v.Type() {
+	case data.TypeNull:
+		return nullLiteral{}, nil
+	case data.TypeInt:
+		i, _ := data.AsInt(v)
+		return numericLiteral{i}, nil
+	case data.TypeFloat:
+		f, _ := data.AsFloat(v)
+		return floatLiteral{f}, nil
+	case data.TypeBool:
+		b, _ := data.AsBool(v)
+		return boolLiteral{b}, nil
+	case data.TypeString:
+		s, _ := data.AsString(v)
+		return stringLiteral{s}, nil
+	}
+	return valueLiteral{v}, nil
+}
+
 // ParserExprToMaybeAggregate converts an expression obtained by the BQL
 // parser into a data structure where the aggregate and the non-aggregate
 // parts are separated.
@@ -1154,6 +1188,28 @@ func (l boolLiteral) ContainsWildcard() bool {
 	return false
 }
 
+// valueLiteral is a literal of a value which doesn't have its own literal
+// in BQL such as a timestamp. It's created from a constant of the topology.
+type valueLiteral struct {
+	Value data.Value
+}
+
+func (l valueLiteral) Repr() string {
+	return fmt.Sprintf("%v::%v", l.Value, l.Value.Type())
+}
+
+func (l valueLiteral) Columns() []rowValue {
+	return nil
+}
+
+func (l valueLiteral) Volatility() VolatilityType {
+	return Immutable
+}
+
+func (l valueLiteral) ContainsWildcard() bool {
+	return false
+}
+
 type stringLiteral struct {
 	Value string
 }
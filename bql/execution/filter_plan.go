@@ -31,7 +31,7 @@ func CanBuildFilterPlan(lp *LogicalPlan, reg udf.FunctionRegistry) bool {
 // perform the check with less memory and faster than the default plan.
 func NewFilterPlan(lp *LogicalPlan, reg udf.FunctionRegistry) (PhysicalPlan, error) {
 	// prepare projection components
-	projs, err := prepareProjections(lp.Projections, reg)
+	projs, projCache, err := prepareProjections(lp.Projections, reg)
 	if err != nil {
 		return nil, err
 	}
@@ -40,13 +40,34 @@ func NewFilterPlan(lp *LogicalPlan, reg udf.FunctionRegistry) (PhysicalPlan, err
 	if err != nil {
 		return nil, err
 	}
+	dedup, err := newDeduplicator(lp, reg)
+	if err != nil {
+		return nil, err
+	}
 	return &filterPlan{commonExecutionPlan{
 		projections: projs,
+		projCache:   projCache,
 		filter:      filter,
+		dedup:       dedup,
+		clock:       reg.Context().Clock(),
 	}, lp.Relations[0].Alias}, nil
 }
 
 func (ep *filterPlan) Process(input *core.Tuple) ([]data.Map, error) {
+	ep.profile.beginTuple()
+	defer ep.profile.end()
+
+	// drop duplicates before doing anything else
+	if ep.dedup != nil {
+		dup, err := ep.dedup.isDuplicate(input, ep.clock.Now().In(time.UTC))
+		if err != nil {
+			return nil, err
+		}
+		if dup {
+			return nil, nil
+		}
+	}
+
 	// nest the data in a one-element map using the alias as the key
 	d := data.Map{ep.relAlias: input.Data}
 	setMetadata(d, ep.relAlias, input)
@@ -55,11 +76,13 @@ func (ep *filterPlan) Process(input *core.Tuple) ([]data.Map, error) {
 
 	// add the information accessed by the now() function
 	// to each item
-	d[":meta:NOW"] = data.Timestamp(time.Now().In(time.UTC))
+	d[":meta:NOW"] = data.Timestamp(ep.clock.Now().In(time.UTC))
 
 	// evaluate filter condition and convert to bool
 	if ep.filter != nil {
+		ep.profile.begin(profileFilter)
 		filterResult, err := ep.filter.Eval(d)
+		ep.profile.end()
 		if err != nil {
 			return nil, err
 		}
@@ -79,6 +102,9 @@ func (ep *filterPlan) Process(input *core.Tuple) ([]data.Map, error) {
 		}
 	}
 	// otherwise, compute all the expressions
+	ep.profile.begin(profileProjection)
+	defer ep.profile.end()
+	ep.projCache.reset()
 	result := data.Map(make(map[string]data.Value, len(ep.projections)))
 	for _, proj := range ep.projections {
 		value, err := proj.evaluator.Eval(d)
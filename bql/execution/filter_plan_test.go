@@ -457,3 +457,38 @@ func BenchmarkFilterWithWhere(b *testing.B) {
 		}
 	}
 }
+
+func benchmarkFilterSensorTuples(b *testing.B, s string) {
+	plan, err := createFilterPlan2(s)
+	if err != nil {
+		panic(err.Error())
+	}
+	tmplTup := core.Tuple{
+		Data: data.Map{
+			"device_id": data.String("dev-1"),
+			"temp":      data.Float(21.5),
+			"humidity":  data.Float(0.45),
+			"battery":   data.Int(87),
+		},
+		InputName: "src",
+		Timestamp: time.Date(2015, time.April, 10, 10, 23, 0, 0, time.UTC),
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		_, err := plan.Process(&tmplTup)
+		if err != nil {
+			panic(err.Error())
+		}
+	}
+}
+
+func BenchmarkFilterProjection(b *testing.B) {
+	benchmarkFilterSensorTuples(b, `CREATE STREAM box AS SELECT RSTREAM device_id, temp,
+		humidity * 100 AS humidity FROM src [RANGE 1 TUPLES] WHERE temp > 0`)
+}
+
+func BenchmarkFilterWildcard(b *testing.B) {
+	benchmarkFilterSensorTuples(b, `CREATE STREAM box AS SELECT RSTREAM * FROM src [RANGE 1 TUPLES]
+		WHERE battery > 10`)
+}
@@ -9,6 +9,19 @@ import (
 
 type groupbyExecutionPlan struct {
 	streamRelationStreamExecutionPlan
+	// groupingSets has indexes of groupList in each set of GROUPING SETS.
+	// It's nil when the statement doesn't have GROUPING SETS.
+	groupingSets [][]int
+	// groupPaths has the path of each column in groupList. It's used to
+	// set columns which aren't in a grouping set to NULL.
+	groupPaths []data.Path
+	// groupRelations has the relation of each column in groupList.
+	groupRelations []string
+	// columnarCandidate is the compiled query for columnar execution. It's
+	// nil when the statement cannot be processed as a columnar batch.
+	columnarCandidate *columnarQuery
+	// columnar is set when columnar execution is enabled.
+	columnar *columnarQuery
 }
 
 // tmpGroupData is an intermediate data structure to represent
@@ -23,6 +36,9 @@ type tmpGroupData struct {
 	// as per our assumptions about grouping, the non-aggregation
 	// data should be identical within every group
 	nonAggData data.Map
+	// lineage has IDs of input tuples in the group when lineage is
+	// tracked.
+	lineage *core.Lineage
 }
 
 // CanBuildGroupbyExecutionPlan checks whether the given statement
@@ -41,14 +57,130 @@ func CanBuildGroupbyExecutionPlan(lp *LogicalPlan, reg udf.FunctionRegistry) boo
 // - perform a SELECT query on that data,
 // - compute the data that need to be emitted by comparison with
 //   the previous run's results.
+//
+// When the statement has GROUPING SETS, each row is aggregated once per
+// grouping set and columns of the GROUP BY clause which aren't in the set
+// are NULL in results of the set.
 func NewGroupbyExecutionPlan(lp *LogicalPlan, reg udf.FunctionRegistry) (PhysicalPlan, error) {
 	underlying, err := newStreamRelationStreamExecutionPlan(lp, reg)
 	if err != nil {
 		return nil, err
 	}
-	return &groupbyExecutionPlan{
-		*underlying,
-	}, nil
+	ep := &groupbyExecutionPlan{
+		streamRelationStreamExecutionPlan: *underlying,
+		groupingSets:                      lp.GroupingSets,
+	}
+	if lp.GroupingSets != nil {
+		ep.groupPaths = make([]data.Path, len(lp.GroupList))
+		ep.groupRelations = make([]string, len(lp.GroupList))
+		for i, expr := range lp.GroupList {
+			// flattenExpressions only allows columns in GROUP BY
+			col, ok := expr.(rowValue)
+			if !ok {
+				return nil, fmt.Errorf("grouping by expressions is not supported yet")
+			}
+			path, err := data.CompilePath(rowValuePath(col))
+			if err != nil {
+				return nil, err
+			}
+			ep.groupPaths[i] = path
+			ep.groupRelations[i] = col.Relation
+		}
+	}
+	q, err := newColumnarQuery(lp, ep.projections, reg)
+	if err != nil {
+		return nil, err
+	}
+	ep.columnarCandidate = q
+	return ep, nil
+}
+
+// EnableColumnarExecution enables columnar execution when the statement
+// can be processed as a columnar batch.
+func (ep *groupbyExecutionPlan) EnableColumnarExecution() bool {
+	ep.columnar = ep.columnarCandidate
+	return ep.columnar != nil
+}
+
+// hasEmptyGroupingSet returns true when the statement has an empty grouping
+// set, i.e. "()", which aggregates all rows.
+func (ep *groupbyExecutionPlan) hasEmptyGroupingSet() bool {
+	for _, set := range ep.groupingSets {
+		if len(set) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// maskGroupColumns sets columns of the GROUP BY clause which aren't in the
+// given grouping set to NULL in m.
+func (ep *groupbyExecutionPlan) maskGroupColumns(m data.Map, set []int) error {
+	for i, path := range ep.groupPaths {
+		masked := true
+		for _, j := range set {
+			if i == j {
+				masked = false
+				break
+			}
+		}
+		// columns of a relation padded by an outer join are already NULL
+		if masked && !isPaddedRelation(m, ep.groupRelations[i]) {
+			if err := m.Set(path, data.Null{}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// evalHaving evaluates the HAVING condition in the projections on the given
+// input. It returns true when the statement doesn't have a HAVING clause.
+func evalHaving(projections []aliasedEvaluator, input data.Map) (bool, error) {
+	for _, proj := range projections {
+		if proj.alias != ":having:" {
+			continue
+		}
+		havingResult, err := proj.evaluator.Eval(input)
+		if err != nil {
+			return false, err
+		}
+		// a NULL value is definitely not "true", so since we
+		// have only a binary decision, we should drop tuples
+		// where the condition evaluates to NULL
+		if havingResult.Type() == data.TypeNull {
+			return false, nil
+		}
+		return data.AsBool(havingResult)
+	}
+	return true, nil
+}
+
+// groupValues returns values of the GROUP BY clause of the row. The values
+// are cached in the row together with their hash.
+func (ep *groupbyExecutionPlan) groupValues(io *inputRowWithCachedResult, input data.Map) (data.Array, error) {
+	// if we have a cached result, use this
+	if io.cache != nil {
+		cachedGroupValues, err := data.AsArray(io.cache)
+		if err != nil {
+			return nil, fmt.Errorf("cached data was not an array: %v", io.cache)
+		}
+		return cachedGroupValues, nil
+	}
+	// otherwise, compute the expressions in the GROUP BY to find
+	// the correct group to append to
+	itemGroupValues := make(data.Array, len(ep.groupList))
+	for i, eval := range ep.groupList {
+		// ordinary "flat" expression
+		value, err := eval.Eval(input)
+		if err != nil {
+			return nil, err
+		}
+		itemGroupValues[i] = value
+	}
+	io.cache = itemGroupValues
+	io.hash = data.Hash(io.cache)
+	return itemGroupValues, nil
 }
 
 // Process takes an input tuple and returns a slice of Map values that
@@ -56,7 +188,9 @@ func NewGroupbyExecutionPlan(lp *LogicalPlan, reg udf.FunctionRegistry) (Physica
 // plan. Note that the order of items in the returned slice is undefined
 // and cannot be relied on.
 func (ep *groupbyExecutionPlan) Process(input *core.Tuple) ([]data.Map, error) {
-	return ep.process(input, ep.performQueryOnBuffer)
+	ep.profile.beginTuple()
+	defer ep.profile.end()
+	return ep.process(input, ep.profile.wrap(profileAggregation, ep.performQueryOnBuffer))
 }
 
 // performQueryOnBuffer computes the projections of a SELECT query on the data
@@ -71,6 +205,20 @@ func (ep *groupbyExecutionPlan) Process(input *core.Tuple) ([]data.Map, error) {
 // if no error had happened), but the contents of ep.curResults are
 // undefined.
 func (ep *groupbyExecutionPlan) performQueryOnBuffer() error {
+	if ep.columnar != nil && !ep.lineageEnabled() {
+		results, ok, err := ep.columnar.perform(ep)
+		if err != nil {
+			return err
+		}
+		if ok {
+			ep.columnar.numBatches++
+			ep.prevResults = ep.curResults
+			ep.curResults = results
+			return nil
+		}
+		ep.columnar.numFallbacks++
+	}
+
 	// reuse the allocated memory
 	output := ep.prevResults[0:0]
 	// remember the previous results
@@ -111,8 +259,10 @@ func (ep *groupbyExecutionPlan) performQueryOnBuffer() error {
 	// groupValues in the `groups`map. if there is no such
 	// group, a new one is created and a copy of the given map
 	// is used as a representative of this group's values.
-	findOrCreateGroup := func(groupValues []data.Value, groupHash data.HashValue, nonGroupValues data.Map) (*tmpGroupData, error) {
-		mkGroup := func() *tmpGroupData {
+	// when the statement has GROUPING SETS, columns which aren't
+	// in the given set are set to NULL in the copy.
+	findOrCreateGroup := func(groupValues []data.Value, groupHash data.HashValue, nonGroupValues data.Map, set []int) (*tmpGroupData, error) {
+		mkGroup := func() (*tmpGroupData, error) {
 			newGroup := &tmpGroupData{
 				// the values that make up this group
 				groupValues,
@@ -122,6 +272,7 @@ func (ep *groupbyExecutionPlan) performQueryOnBuffer() error {
 				// TODO actually we don't need the whole map,
 				//      just the parts common to the whole group
 				nonGroupValues.Copy(),
+				nil,
 			}
 			// initialize the map with the aggregate function inputs
 			for _, proj := range ep.projections {
@@ -129,7 +280,12 @@ func (ep *groupbyExecutionPlan) performQueryOnBuffer() error {
 					newGroup.aggData[key] = make([]data.Value, 0, 1)
 				}
 			}
-			return newGroup
+			if ep.groupingSets != nil {
+				if err := ep.maskGroupColumns(newGroup.nonAggData, set); err != nil {
+					return nil, err
+				}
+			}
+			return newGroup, nil
 		}
 
 		// find the correct group
@@ -137,7 +293,11 @@ func (ep *groupbyExecutionPlan) performQueryOnBuffer() error {
 		var group *tmpGroupData
 		// if there is no such group, create one
 		if !exists {
-			group = mkGroup()
+			g, err := mkGroup()
+			if err != nil {
+				return nil, err
+			}
+			group = g
 			groups[groupHash] = []*tmpGroupData{group}
 			groupKeys = append(groupKeys, groupHash)
 		} else {
@@ -152,7 +312,11 @@ func (ep *groupbyExecutionPlan) performQueryOnBuffer() error {
 			// no group with the same groupValues was found, so create
 			// one and append it to the list of groups with the same hash
 			if group == nil {
-				group = mkGroup()
+				g, err := mkGroup()
+				if err != nil {
+					return nil, err
+				}
+				group = g
 				groups[groupHash] = append(groupCandidates, group)
 			}
 		}
@@ -160,42 +324,20 @@ func (ep *groupbyExecutionPlan) performQueryOnBuffer() error {
 		return group, nil
 	}
 
-	// function to compute the grouping expressions and store the
-	// input for aggregate functions in the correct group.
-	evalItem := func(io *inputRowWithCachedResult) error {
-		var itemGroupValues data.Array
-		// if we have a cached result, use this
-		if io.cache != nil {
-			cachedGroupValues, err := data.AsArray(io.cache)
-			if err != nil {
-				return fmt.Errorf("cached data was not an array: %v", io.cache)
+	// addItem stores the input for aggregate functions in the given
+	// group.
+	addItem := func(itemGroup *tmpGroupData, io *inputRowWithCachedResult, input data.Map) error {
+		if ep.lineageEnabled() && io.lineage != nil {
+			if itemGroup.lineage == nil {
+				itemGroup.lineage = &core.Lineage{}
 			}
-			itemGroupValues = cachedGroupValues
-		} else {
-			// otherwise, compute the expressions in the GROUP BY to find
-			// the correct group to append to
-			itemGroupValues = make([]data.Value, len(ep.groupList))
-			for i, eval := range ep.groupList {
-				// ordinary "flat" expression
-				value, err := eval.Eval(*io.input)
-				if err != nil {
-					return err
-				}
-				itemGroupValues[i] = value
-			}
-			io.cache = itemGroupValues
-			io.hash = data.Hash(io.cache)
-		}
-
-		itemGroup, err := findOrCreateGroup(itemGroupValues, io.hash, *io.input)
-		if err != nil {
-			return err
+			itemGroup.lineage.Merge(io.lineage, ep.lineageMaxInputs)
 		}
 
 		// now compute all the input data for the aggregate functions,
 		// e.g. for `SELECT count(a) + max(b/2)`, compute `a` and `b/2`
 		for key, agg := range allAggEvaluators {
-			value, err := agg.Eval(*io.input)
+			value, err := agg.Eval(input)
 			if err != nil {
 				return err
 			}
@@ -205,7 +347,51 @@ func (ep *groupbyExecutionPlan) performQueryOnBuffer() error {
 		return nil
 	}
 
+	// function to compute the grouping expressions and store the
+	// input for aggregate functions in the correct group.
+	evalItem := func(io *inputRowWithCachedResult) error {
+		input, err := ep.inputData(io)
+		if err != nil {
+			return err
+		}
+		itemGroupValues, err := ep.groupValues(io, input)
+		if err != nil {
+			return err
+		}
+
+		if ep.groupingSets == nil {
+			itemGroup, err := findOrCreateGroup(itemGroupValues, io.hash, input, nil)
+			if err != nil {
+				return err
+			}
+			return addItem(itemGroup, io, input)
+		}
+
+		// with GROUPING SETS, the item belongs to one group of each set.
+		// the index of the set is a part of the group values so that
+		// groups of different sets are never merged.
+		for i, set := range ep.groupingSets {
+			setGroupValues := make(data.Array, len(itemGroupValues)+1)
+			setGroupValues[0] = data.Int(i)
+			for j := range itemGroupValues {
+				setGroupValues[j+1] = data.Null{}
+			}
+			for _, j := range set {
+				setGroupValues[j+1] = itemGroupValues[j]
+			}
+			itemGroup, err := findOrCreateGroup(setGroupValues, data.Hash(setGroupValues), input, set)
+			if err != nil {
+				return err
+			}
+			if err := addItem(itemGroup, io, input); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
 	evalGroup := func(group *tmpGroupData) error {
+		ep.projCache.reset()
 		result := data.Map(make(map[string]data.Value, len(ep.projections)))
 		// collect input for aggregate functions into an array
 		// within each group
@@ -213,29 +399,12 @@ func (ep *groupbyExecutionPlan) performQueryOnBuffer() error {
 			group.nonAggData[key] = data.Array(group.aggData[key])
 			delete(group.aggData, key)
 		}
-		// evaluate HAVING condition, if there is one
-		for _, proj := range ep.projections {
-			if proj.alias == ":having:" {
-				havingResult, err := proj.evaluator.Eval(group.nonAggData)
-				if err != nil {
-					return err
-				}
-				// a NULL value is definitely not "true", so since we
-				// have only a binary decision, we should drop tuples
-				// where the condition evaluates to NULL
-				havingResultBool := false
-				if havingResult.Type() != data.TypeNull {
-					havingResultBool, err = data.AsBool(havingResult)
-					if err != nil {
-						return err
-					}
-				}
-				// if it evaluated to false, do not further process this group
-				if !havingResultBool {
-					return nil
-				}
-				break
-			}
+		// evaluate HAVING condition, if there is one, and
+		// if it evaluated to false, do not further process this group
+		if ok, err := evalHaving(ep.projections, group.nonAggData); err != nil {
+			return err
+		} else if !ok {
+			return nil
 		}
 		// now evaluate all other projections
 		for _, proj := range ep.projections {
@@ -251,7 +420,7 @@ func (ep *groupbyExecutionPlan) performQueryOnBuffer() error {
 				return err
 			}
 		}
-		output = append(output, resultRow{row: result, hash: data.Hash(result)})
+		output = append(output, resultRow{row: result, hash: data.Hash(result), lineage: group.lineage})
 		return nil
 	}
 
@@ -260,10 +429,12 @@ func (ep *groupbyExecutionPlan) performQueryOnBuffer() error {
 		// we have to return an empty result (because there are no
 		// rows with "the same values"). but if the list is empty and
 		// we *don't* have a GROUP BY clause, then we need to compute
-		// all foldables and aggregates with an empty input
-		if len(ep.groupList) > 0 {
+		// all foldables and aggregates with an empty input. an
+		// empty grouping set of GROUPING SETS behaves like the latter.
+		if len(ep.groupList) > 0 && !ep.hasEmptyGroupingSet() {
 			return nil
 		}
+		ep.projCache.reset()
 		input := data.Map{}
 		result := data.Map(make(map[string]data.Value, len(ep.projections)))
 		for _, proj := range ep.projections {
@@ -273,11 +444,25 @@ func (ep *groupbyExecutionPlan) performQueryOnBuffer() error {
 					input[key] = data.Array{}
 				}
 			}
+		}
+		// columns of GROUP BY are NULL in the empty grouping set
+		if err := ep.maskGroupColumns(input, nil); err != nil {
+			return err
+		}
+		if ok, err := evalHaving(ep.projections, input); err != nil {
+			return err
+		} else if !ok {
+			return nil
+		}
+		for _, proj := range ep.projections {
+			if proj.alias == ":having:" {
+				continue
+			}
 			// now evaluate this projection on the flattened data.
 			// note that input has *only* the keys of the empty
-			// arrays, no other columns, but we cannot have other
-			// columns involved in the projection (since we know
-			// that GROUP BY is empty).
+			// arrays and NULL columns of an empty grouping set, but
+			// we cannot have other columns involved in the projection
+			// (since we know that GROUP BY is empty).
 			value, err := proj.evaluator.Eval(input)
 			if err != nil {
 				return err
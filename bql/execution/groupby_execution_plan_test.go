@@ -267,6 +267,46 @@ func TestGroupbyExecutionPlan(t *testing.T) {
 		})
 	})
 
+	Convey("Given a SELECT clause with a simple aggregation and GROUP BY on NaN and nested values", t, func() {
+		tuples := getOtherTuples()
+		tuples[0].Data["foo"] = data.Map{"a": data.Float(math.NaN()), "b": data.Array{data.Int(1)}}
+		tuples[1].Data["foo"] = data.Map{"a": data.Float(math.NaN()), "b": data.Array{data.Int(1)}}
+		tuples[2].Data["foo"] = data.Map{"a": data.Float(2)}
+		tuples[3].Data["foo"] = data.Map{"a": data.Int(2)}
+		s := `CREATE STREAM box AS SELECT RSTREAM foo, count(*) FROM src [RANGE 3 TUPLES] GROUP BY foo`
+		plan, err := createGroupbyPlan(s, t)
+		So(err, ShouldBeNil)
+
+		Convey("When feeding it with tuples", func() {
+			for idx, inTup := range tuples {
+				out, err := plan.Process(inTup)
+				So(err, ShouldBeNil)
+
+				Convey(fmt.Sprintf("Then those values should appear in %v", idx), func() {
+					// NaNs and numbers having the same value are grouped
+					// together respectively.
+					if idx == 0 {
+						So(len(out), ShouldEqual, 1)
+						So(out[0]["count"], ShouldEqual, data.Int(1))
+					} else if idx == 1 {
+						So(len(out), ShouldEqual, 1)
+						So(out[0]["count"], ShouldEqual, data.Int(2))
+					} else if idx == 2 {
+						So(len(out), ShouldEqual, 2)
+						So(out[0]["count"], ShouldEqual, data.Int(2))
+						So(out[1], ShouldResemble,
+							data.Map{"foo": data.Map{"a": data.Float(2)}, "count": data.Int(1)})
+					} else {
+						So(len(out), ShouldEqual, 2)
+						So(out[0]["count"], ShouldEqual, data.Int(1))
+						So(out[1], ShouldResemble,
+							data.Map{"foo": data.Map{"a": data.Float(2)}, "count": data.Int(2)})
+					}
+				})
+			}
+		})
+	})
+
 	SkipConvey("Given a SELECT clause with a simple aggregation and GROUP BY (hash collision)", t, func() {
 		tuples := getOtherTuples()
 		// TODO this test is working because the two numbers below are not
@@ -418,6 +458,99 @@ func TestGroupbyExecutionPlan(t *testing.T) {
 		})
 	})
 
+	Convey("Given a SELECT clause with aggregation and HAVING but no GROUP BY on empty input", t, func() {
+		tuples := getOtherTuples()
+		s := `CREATE STREAM box AS SELECT RSTREAM count(foo) FROM src [RANGE 3 TUPLES] WHERE foo=7 HAVING count(foo) > 0`
+		plan, err := createGroupbyPlan(s, t)
+		So(err, ShouldBeNil)
+
+		Convey("When feeding it with tuples", func() {
+			for idx, inTup := range tuples {
+				out, err := plan.Process(inTup)
+				So(err, ShouldBeNil)
+
+				Convey(fmt.Sprintf("Then nothing should be emitted in %v", idx), func() {
+					So(out, ShouldBeEmpty)
+				})
+			}
+		})
+	})
+
+	Convey("Given a SELECT clause with GROUPING SETS", t, func() {
+		tuples := getOtherTuples()
+		for i, t := range tuples {
+			t.Data["bar"] = data.String([]string{"a", "b"}[i%2])
+		}
+		s := `CREATE STREAM box AS SELECT RSTREAM foo, bar, count(*) AS c FROM src [RANGE 4 TUPLES]
+			GROUP BY GROUPING SETS ((foo), (foo, bar), ())`
+		plan, err := createGroupbyPlan(s, t)
+		So(err, ShouldBeNil)
+
+		Convey("When feeding it with tuples", func() {
+			var out []data.Map
+			for _, inTup := range tuples {
+				out, err = plan.Process(inTup)
+				So(err, ShouldBeNil)
+			}
+
+			Convey("Then each grouping set should be aggregated with NULL for other columns", func() {
+				So(out, ShouldResemble, []data.Map{
+					{"foo": data.Int(1), "bar": data.Null{}, "c": data.Int(2)},
+					{"foo": data.Int(1), "bar": data.String("a"), "c": data.Int(1)},
+					{"foo": data.Null{}, "bar": data.Null{}, "c": data.Int(4)},
+					{"foo": data.Int(1), "bar": data.String("b"), "c": data.Int(1)},
+					{"foo": data.Int(2), "bar": data.Null{}, "c": data.Int(2)},
+					{"foo": data.Int(2), "bar": data.String("a"), "c": data.Int(1)},
+					{"foo": data.Int(2), "bar": data.String("b"), "c": data.Int(1)},
+				})
+			})
+		})
+	})
+
+	Convey("Given a SELECT clause with GROUPING SETS and HAVING", t, func() {
+		tuples := getOtherTuples()
+		s := `CREATE STREAM box AS SELECT RSTREAM foo, sum(int) AS s FROM src [RANGE 4 TUPLES]
+			GROUP BY GROUPING SETS ((foo), ()) HAVING count(*) > 2`
+		plan, err := createGroupbyPlan(s, t)
+		So(err, ShouldBeNil)
+
+		Convey("When feeding it with tuples", func() {
+			for idx, inTup := range tuples {
+				out, err := plan.Process(inTup)
+				So(err, ShouldBeNil)
+
+				Convey(fmt.Sprintf("Then those values should appear in %v", idx), func() {
+					if idx < 2 {
+						So(out, ShouldBeEmpty)
+					} else {
+						So(out, ShouldResemble, []data.Map{
+							{"foo": data.Null{}, "s": data.Int((idx + 1) * (idx + 2) / 2)},
+						})
+					}
+				})
+			}
+		})
+	})
+
+	Convey("Given a SELECT clause with an empty grouping set on empty input", t, func() {
+		tuples := getOtherTuples()
+		s := `CREATE STREAM box AS SELECT RSTREAM foo, count(*) AS c FROM src [RANGE 3 TUPLES] WHERE foo=7
+			GROUP BY GROUPING SETS ((foo), ())`
+		plan, err := createGroupbyPlan(s, t)
+		So(err, ShouldBeNil)
+
+		Convey("When feeding it with tuples", func() {
+			for idx, inTup := range tuples {
+				out, err := plan.Process(inTup)
+				So(err, ShouldBeNil)
+
+				Convey(fmt.Sprintf("Then only the empty grouping set should be emitted in %v", idx), func() {
+					So(out, ShouldResemble, []data.Map{{"foo": data.Null{}, "c": data.Int(0)}})
+				})
+			}
+		})
+	})
+
 	Convey("Given a SELECT clause with two identical aggregations and GROUP BY", t, func() {
 		tuples := getOtherTuples()
 		tuples[3].Data["int"] = data.Null{} // NULL should not be counted
@@ -801,6 +934,13 @@ func TestGroupbyExecutionPlan(t *testing.T) {
 		So(err.Error(), ShouldEqual, `column "src:int" must appear in the GROUP BY clause or be used in an aggregate function`)
 	})
 
+	Convey("Given an SELECT statement with a column not in GROUPING SETS", t, func() {
+		s := `CREATE STREAM box AS SELECT RSTREAM foo, bar FROM src [RANGE 3 TUPLES] GROUP BY GROUPING SETS ((foo), ())`
+		_, err := createGroupbyPlan(s, t)
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldEqual, `column "src:bar" must appear in the GROUP BY clause or be used in an aggregate function`)
+	})
+
 	// BQL limitations (less features than SQL)
 
 	Convey("Given an SELECT statement with an invalid GROUP BY (3)", t, func() {
@@ -1148,6 +1288,37 @@ func TestAggregateFunctions(t *testing.T) {
 		})
 	})
 
+	Convey("Given a SELECT clause with analytic functions", t, func() {
+		tuples := getExtTuples()
+
+		s := `CREATE STREAM box AS SELECT RSTREAM last_value(int ORDER BY bar DESC) AS l,
+			lag(int ORDER BY bar DESC) AS p, lead(bar, 2, "none") AS n,
+			first_value(bar ORDER BY foo DESC) AS f, row_number(int) AS r
+			FROM src [RANGE 4 TUPLES]`
+		plan, err := createGroupbyPlan(s, t)
+		So(err, ShouldBeNil)
+
+		Convey("When feeding it with tuples", func() {
+			for idx, inTup := range tuples {
+				out, err := plan.Process(inTup)
+				So(err, ShouldBeNil)
+
+				Convey(fmt.Sprintf("Then those values should appear in %v", idx), func() {
+					So(len(out), ShouldEqual, 1)
+
+					if idx == 0 {
+						So(out[0], ShouldResemble, data.Map{"l": data.Int(1), "p": data.Null{},
+							"n": data.String("none"), "f": data.String("a"), "r": data.Int(1)})
+					} else if idx == 3 {
+						// rows having the same foo keep the order of the window
+						So(out[0], ShouldResemble, data.Map{"l": data.Int(1), "p": data.Int(2),
+							"n": data.String("c"), "f": data.String("c"), "r": data.Int(4)})
+					}
+				})
+			}
+		})
+	})
+
 	Convey("Given a SELECT clause with sum", t, func() {
 		tuples := getExtTuples()
 
@@ -0,0 +1,231 @@
+package execution
+
+import (
+	"math"
+
+	"gopkg.in/sensorbee/sensorbee.v0/bql/parser"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/udf"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// equiJoinKey represents an equality condition `left = right` in the WHERE
+// clause of a join where each side refers to exactly one relation and both
+// relations are different, for example, `a:id = b:id + 1`.
+type equiJoinKey struct {
+	relations [2]string
+	exprs     [2]FlatExpression
+}
+
+// extractEquiJoinKeys returns equality conditions which can be used to index
+// window buffers. Only conditions combined by AND at the top level of the
+// filter are considered because each of them must hold for a row to pass
+// the filter. Expressions which aren't immutable (e.g. function calls) are
+// ignored since their values may change after being indexed.
+func extractEquiJoinKeys(filter parser.Expression, reg udf.FunctionRegistry) ([]equiJoinKey, error) {
+	var keys []equiJoinKey
+	var walk func(e parser.Expression) error
+	walk = func(e parser.Expression) error {
+		b, ok := e.(parser.BinaryOpAST)
+		if !ok {
+			return nil
+		}
+		switch b.Op {
+		case parser.And:
+			if err := walk(b.Left); err != nil {
+				return err
+			}
+			return walk(b.Right)
+		case parser.Equal:
+		default:
+			return nil
+		}
+
+		k := equiJoinKey{}
+		for i, side := range []parser.Expression{b.Left, b.Right} {
+			rels := side.ReferencedRelations()
+			if len(rels) != 1 {
+				return nil
+			}
+			for rel := range rels {
+				k.relations[i] = rel
+			}
+			if k.relations[i] == "" {
+				return nil
+			}
+			expr, err := ParserExprToFlatExpr(side, reg)
+			if err != nil {
+				return err
+			}
+			if expr.Volatility() != Immutable || expr.ContainsWildcard() {
+				return nil
+			}
+			k.exprs[i] = expr
+		}
+		if k.relations[0] == k.relations[1] {
+			return nil
+		}
+		keys = append(keys, k)
+		return nil
+	}
+	if err := walk(filter); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// joinIndex is a hash index of tuples in a window buffer keyed on one side
+// of an equiJoinKey. It's maintained incrementally as tuples enter and leave
+// the window so that rows of the other relation can find matching tuples
+// without scanning the whole buffer.
+type joinIndex struct {
+	// relation is the alias of the relation whose buffer is indexed.
+	relation string
+
+	// key computes the indexed value from a row of the relation.
+	key Evaluator
+
+	// probeRelation is the alias of the relation which probe refers to.
+	probeRelation string
+
+	// probe computes the value to be looked up from a row having the data
+	// of probeRelation.
+	probe Evaluator
+
+	// slot is the position of the index in the indexes of the buffer,
+	// which is also the position of the key of the index in
+	// tupleWithDerivedInputRows.keys.
+	slot int
+
+	// entries has indexed tuples in the order of their arrival.
+	entries map[data.HashValue][]*tupleWithDerivedInputRows
+
+	// unindexed has tuples whose key couldn't be computed. Since it isn't
+	// known whether they match or not, they're always candidates.
+	unindexed []*tupleWithDerivedInputRows
+}
+
+// joinIndexKey is the key of a tuple in a joinIndex.
+type joinIndexKey struct {
+	// indexed is false when the key couldn't be computed.
+	indexed bool
+	null    bool
+	hash    data.HashValue
+}
+
+func newJoinIndex(k *equiJoinKey, side, slot int, reg udf.FunctionRegistry) (*joinIndex, error) {
+	key, err := ExpressionToEvaluator(k.exprs[side], reg)
+	if err != nil {
+		return nil, err
+	}
+	probe, err := ExpressionToEvaluator(k.exprs[1-side], reg)
+	if err != nil {
+		return nil, err
+	}
+	return &joinIndex{
+		relation:      k.relations[side],
+		key:           key,
+		probeRelation: k.relations[1-side],
+		probe:         probe,
+		slot:          slot,
+		entries:       map[data.HashValue][]*tupleWithDerivedInputRows{},
+	}, nil
+}
+
+// add adds a tuple which has just been appended to the buffer.
+func (idx *joinIndex) add(t *tupleWithDerivedInputRows) {
+	row := data.Map{idx.relation: t.tuple.Data[idx.relation]}
+	setMetadata(row, idx.relation, t.tuple)
+	k := &t.keys[idx.slot]
+	v, err := idx.key.Eval(row)
+	if err != nil {
+		k.indexed = false
+		idx.unindexed = append(idx.unindexed, t)
+		return
+	}
+	k.indexed = true
+	if v.Type() == data.TypeNull {
+		// NULL never equals anything, so the tuple never matches.
+		k.null = true
+		return
+	}
+	k.null = false
+	k.hash = data.Hash(v)
+	idx.entries[k.hash] = append(idx.entries[k.hash], t)
+}
+
+// remove removes a tuple leaving the window. Because tuples usually leave
+// in the order of their arrival, the tuple is searched from the front.
+func (idx *joinIndex) remove(t *tupleWithDerivedInputRows) {
+	k := &t.keys[idx.slot]
+	if !k.indexed {
+		idx.unindexed = removeTuple(idx.unindexed, t)
+		return
+	}
+	if k.null {
+		return
+	}
+	ts := removeTuple(idx.entries[k.hash], t)
+	if len(ts) == 0 {
+		delete(idx.entries, k.hash)
+	} else {
+		idx.entries[k.hash] = ts
+	}
+}
+
+func removeTuple(ts []*tupleWithDerivedInputRows, t *tupleWithDerivedInputRows) []*tupleWithDerivedInputRows {
+	for i, e := range ts {
+		if e == t {
+			copy(ts[i:], ts[i+1:])
+			ts[len(ts)-1] = nil
+			return ts[:len(ts)-1]
+		}
+	}
+	return ts
+}
+
+// candidates returns tuples having seq in [from, to) which may match the
+// row. The row must have the data of probeRelation. It returns false when
+// the value to be looked up cannot be computed, and then all tuples need to
+// be scanned. Returned tuples are sorted by seq.
+func (idx *joinIndex) candidates(row data.Map, from, to int64) ([]*tupleWithDerivedInputRows, bool) {
+	v, err := idx.probe.Eval(row)
+	if err != nil {
+		return nil, false
+	}
+	var matched []*tupleWithDerivedInputRows
+	if v.Type() != data.TypeNull {
+		matched = idx.entries[data.Hash(v)]
+	}
+
+	// merge both lists because the order of tuples in the window affects
+	// results of some aggregate functions
+	res := make([]*tupleWithDerivedInputRows, 0, len(matched)+len(idx.unindexed))
+	i, j := 0, 0
+	for i < len(matched) || j < len(idx.unindexed) {
+		var t *tupleWithDerivedInputRows
+		if j == len(idx.unindexed) || (i < len(matched) && matched[i].seq < idx.unindexed[j].seq) {
+			t = matched[i]
+			i++
+		} else {
+			t = idx.unindexed[j]
+			j++
+		}
+		if from <= t.seq && t.seq < to {
+			res = append(res, t)
+		}
+	}
+	return res, true
+}
+
+// seqRange returns the range of seq of tuples in the partial list.
+func (p partialList) seqRange() (int64, int64) {
+	if p.start == nil {
+		return 0, 0
+	}
+	from := p.start.Value.(*tupleWithDerivedInputRows).seq
+	to := int64(math.MaxInt64)
+	if p.end != nil {
+		to = p.end.Value.(*tupleWithDerivedInputRows).seq
+	}
+	return from, to
+}
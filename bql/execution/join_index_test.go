@@ -0,0 +1,169 @@
+package execution
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/parser"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/udf"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+func TestExtractEquiJoinKeys(t *testing.T) {
+	reg := udf.CopyGlobalUDFRegistry(core.NewContext(nil))
+	p := parser.New()
+
+	cases := []struct {
+		filter string
+		keys   []string
+	}{
+		{"a:x = b:y", []string{"a:x=b:y"}},
+		{"a:x + 1 = b:y AND b:z = c:z", []string{"(a:x)+(1)=b:y", "b:z=c:z"}},
+		{"a:x = b:y AND a:z > 3", []string{"a:x=b:y"}},
+		{"a:x = b:y OR a:z = b:z", nil},
+		{"a:x = a:y", nil},
+		{"a:x = 1", nil},
+		{"a:x + b:x = b:y", nil},
+		{"abs(a:x) = b:y", nil},
+	}
+
+	for _, c := range cases {
+		c := c
+		Convey(fmt.Sprintf("Given a filter %v", c.filter), t, func() {
+			stmt, _, err := p.ParseStmt("SELECT ISTREAM * FROM a [RANGE 1 TUPLES], b [RANGE 1 TUPLES] WHERE " + c.filter)
+			So(err, ShouldBeNil)
+			filter := stmt.(parser.SelectStmt).Filter
+
+			Convey("When extracting equality join keys", func() {
+				keys, err := extractEquiJoinKeys(filter, reg)
+				So(err, ShouldBeNil)
+
+				Convey("Then they should have the expected expressions", func() {
+					var reprs []string
+					for _, k := range keys {
+						reprs = append(reprs, fmt.Sprintf("%v=%v", k.exprs[0].Repr(), k.exprs[1].Repr()))
+					}
+					So(reprs, ShouldResemble, c.keys)
+				})
+			})
+		})
+	}
+}
+
+func TestJoinIndex(t *testing.T) {
+	Convey("Given an equality JOIN over time-based windows", t, func() {
+		s := `CREATE STREAM box AS SELECT ISTREAM l:id AS l, r:id AS r FROM ` +
+			`src1 [RANGE 4 SECONDS] AS l, src2 [RANGE 4 SECONDS] AS r WHERE l:k = r:k AND l:id < r:id`
+		plan, err := createDefaultSelectPlan(s, t)
+		So(err, ShouldBeNil)
+		ep := plan.(*defaultSelectExecutionPlan)
+
+		Convey("Then both buffers should be indexed", func() {
+			So(ep.buffers["l"].index("l"), ShouldNotBeNil)
+			So(ep.buffers["r"].index("r"), ShouldNotBeNil)
+		})
+
+		Convey("When feeding it with tuples", func() {
+			input := []struct {
+				src string
+				k   data.Value
+			}{
+				{"src1", data.Int(1)},
+				{"src1", data.Int(2)},
+				{"src2", data.Float(1)}, // matches 1 because 1 = 1.0
+				{"src1", data.Null{}},
+				{"src2", data.Null{}}, // NULL doesn't match anything
+				{"src2", data.Int(2)},
+				{"src1", data.Int(2)}, // doesn't satisfy l:id < r:id
+				{"src2", data.Int(1)}, // the first tuple has expired
+				{"src2", data.Int(2)},
+			}
+			var outs [][]data.Map
+			for i, in := range input {
+				out, err := plan.Process(&core.Tuple{
+					InputName: in.src,
+					Data:      data.Map{"id": data.Int(i), "k": in.k},
+					Timestamp: time.Date(2015, time.April, 10, 10, 23, i, 0, time.UTC),
+				})
+				So(err, ShouldBeNil)
+				sort.Sort(tupleList(out))
+				outs = append(outs, out)
+			}
+
+			Convey("Then joined rows should be emitted", func() {
+				row := func(l, r int) data.Map {
+					return data.Map{"l": data.Int(l), "r": data.Int(r)}
+				}
+				So(outs[2], ShouldResemble, []data.Map{row(0, 2)})
+				So(outs[3], ShouldBeEmpty)
+				So(outs[4], ShouldBeEmpty)
+				So(outs[5], ShouldResemble, []data.Map{row(1, 5)})
+				So(outs[6], ShouldBeEmpty)
+				So(outs[7], ShouldBeEmpty)
+				So(outs[8], ShouldResemble, []data.Map{row(6, 8)})
+			})
+
+			Convey("Then expired tuples should be removed from indexes", func() {
+				n := 0
+				for _, ts := range ep.buffers["l"].index("l").entries {
+					n += len(ts)
+				}
+				So(n, ShouldEqual, 1)
+				So(ep.buffers["l"].tuples.Len(), ShouldEqual, 1)
+			})
+		})
+	})
+
+	Convey("Given an equality self-join", t, func() {
+		s := `CREATE STREAM box AS SELECT ISTREAM a:id AS a, b:id AS b FROM ` +
+			`src [RANGE 4 TUPLES] AS a, src [RANGE 4 TUPLES] AS b WHERE a:k = b:k AND a:id < b:id`
+		plan, err := createDefaultSelectPlan(s, t)
+		So(err, ShouldBeNil)
+		ep := plan.(*defaultSelectExecutionPlan)
+
+		Convey("Then both relations should share one buffer indexed for each of them", func() {
+			So(ep.buffers["a"], ShouldEqual, ep.buffers["b"])
+			So(ep.buffers["a"].index("a"), ShouldNotBeNil)
+			So(ep.buffers["a"].index("b"), ShouldNotBeNil)
+		})
+
+		Convey("When feeding it with tuples", func() {
+			var outs [][]data.Map
+			for i, k := range []int{1, 2, 1, 1, 2, 3} {
+				out, err := plan.Process(&core.Tuple{
+					InputName: "src",
+					Data:      data.Map{"id": data.Int(i), "k": data.Int(k)},
+					Timestamp: time.Date(2015, time.April, 10, 10, 23, i, 0, time.UTC),
+				})
+				So(err, ShouldBeNil)
+				sort.Sort(tupleList(out))
+				outs = append(outs, out)
+			}
+
+			Convey("Then rows having the same key in the window should be joined", func() {
+				row := func(a, b int) data.Map {
+					return data.Map{"a": data.Int(a), "b": data.Int(b)}
+				}
+				So(outs[0], ShouldBeEmpty)
+				So(outs[1], ShouldBeEmpty)
+				So(outs[2], ShouldResemble, []data.Map{row(0, 2)})
+				So(outs[3], ShouldResemble, []data.Map{row(0, 3), row(2, 3)})
+				So(outs[4], ShouldResemble, []data.Map{row(1, 4)})
+				So(outs[5], ShouldBeEmpty)
+			})
+
+			Convey("Then the shared buffer should only have tuples in the window", func() {
+				So(ep.buffers["a"].tuples.Len(), ShouldEqual, 4)
+				n := 0
+				for _, ts := range ep.buffers["a"].index("b").entries {
+					n += len(ts)
+				}
+				So(n, ShouldEqual, 4)
+			})
+		})
+	})
+}
@@ -0,0 +1,55 @@
+package execution
+
+import (
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"sort"
+)
+
+// LineageTracer is implemented by a PhysicalPlan which can report input
+// tuples contributing to each result of Process.
+type LineageTracer interface {
+	// EnableLineage makes the plan track IDs of input tuples contributing
+	// to results. At most maxInputs IDs are kept for each result.
+	EnableLineage(maxInputs int)
+
+	// Lineage returns the lineage of each result returned from the last
+	// call of Process in the same order. An element can be nil when no
+	// tuple having an ID contributed to the result.
+	Lineage() []*core.Lineage
+}
+
+// EnableLineage makes the plan track IDs of input tuples.
+func (ep *streamRelationStreamExecutionPlan) EnableLineage(maxInputs int) {
+	ep.lineageMaxInputs = maxInputs
+}
+
+// Lineage returns the lineage of each result of the last call of Process.
+func (ep *streamRelationStreamExecutionPlan) Lineage() []*core.Lineage {
+	return ep.lineage
+}
+
+// lineageEnabled returns true when the plan tracks lineage.
+func (ep *streamRelationStreamExecutionPlan) lineageEnabled() bool {
+	return ep.lineageMaxInputs > 0
+}
+
+// newInputRowLineage creates the lineage of an item of the cartesian
+// product from tuples it originates from. IDs are sorted because the order
+// of visiting buffers isn't stable.
+func (ep *streamRelationStreamExecutionPlan) newInputRowLineage(origin map[string]*tupleWithDerivedInputRows) *core.Lineage {
+	ids := make([]string, 0, len(origin))
+	for _, t := range origin {
+		if t.tuple.ID != "" {
+			ids = append(ids, t.tuple.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	sort.Strings(ids)
+	l := &core.Lineage{}
+	for _, id := range ids {
+		l.Add(id, ep.lineageMaxInputs)
+	}
+	return l
+}
@@ -0,0 +1,214 @@
+package execution
+
+import (
+	"container/list"
+	"fmt"
+
+	"gopkg.in/sensorbee/sensorbee.v0/bql/parser"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/udf"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// outerJoin maintains rows of an outer join padded with NULL for tuples not
+// matching any tuple of the other relation in the current window. Like
+// other rows, a padded row is computed only once: it's added when a tuple
+// enters the window without a match or when the last tuple matching it
+// leaves the window, and it's removed when a matching tuple arrives or the
+// tuple itself leaves the window.
+type outerJoin struct {
+	// relations has aliases of both relations of the join.
+	relations [2]string
+
+	// outer has aliases of relations whose unmatched tuples are padded.
+	// It has both aliases for FULL OUTER JOIN.
+	outer map[string]bool
+
+	// on evaluates the ON condition of the join.
+	on Evaluator
+
+	// matched has pairs of tuples satisfying the ON condition found while
+	// computing new rows. They're applied to match counts of tuples only
+	// after all new rows have been computed successfully.
+	matched [][2]*tupleWithDerivedInputRows
+}
+
+func newOuterJoin(lp *LogicalPlan, reg udf.FunctionRegistry) (*outerJoin, error) {
+	if len(lp.Relations) != 2 {
+		return nil, fmt.Errorf("an outer join requires exactly two relations")
+	}
+	on, err := ExpressionToEvaluator(lp.JoinFilter, reg)
+	if err != nil {
+		return nil, err
+	}
+	j := &outerJoin{
+		relations: [2]string{lp.Relations[0].Alias, lp.Relations[1].Alias},
+		outer:     map[string]bool{},
+		on:        on,
+	}
+	switch lp.Join.Type {
+	case parser.LeftOuterJoin:
+		j.outer[j.relations[0]] = true
+	case parser.RightOuterJoin:
+		j.outer[j.relations[1]] = true
+	case parser.FullOuterJoin:
+		j.outer[j.relations[0]] = true
+		j.outer[j.relations[1]] = true
+	default:
+		return nil, fmt.Errorf("unsupported join type: %v", lp.Join.Type)
+	}
+	return j, nil
+}
+
+// other returns the alias of the relation joined with the given one.
+func (j *outerJoin) other(alias string) string {
+	if alias == j.relations[0] {
+		return j.relations[1]
+	}
+	return j.relations[0]
+}
+
+// match evaluates the ON condition on a row of the cartesian product and
+// records the pair of tuples when it holds.
+func (j *outerJoin) match(row data.Map, origin map[string]*tupleWithDerivedInputRows) (bool, error) {
+	ok, err := evalCondition(j.on, row)
+	if err != nil || !ok {
+		return false, err
+	}
+	j.matched = append(j.matched, [2]*tupleWithDerivedInputRows{
+		origin[j.relations[0]], origin[j.relations[1]]})
+	return true, nil
+}
+
+// evalCondition evaluates a condition like the WHERE clause. NULL is
+// regarded as false.
+func evalCondition(cond Evaluator, row data.Map) (bool, error) {
+	res, err := cond.Eval(row)
+	if err != nil {
+		return false, err
+	}
+	if res.Type() == data.TypeNull {
+		return false, nil
+	}
+	return data.AsBool(res)
+}
+
+// applyMatches updates match counts of tuples with pairs recorded by match
+// and removes padded rows of tuples which now have a match. It must be
+// called after new rows are added to ep.filteredInputRows.
+func (ep *streamRelationStreamExecutionPlan) applyMatches() {
+	j := ep.outerJoin
+	obsolete := map[*inputRowWithCachedResult]bool{}
+	for _, pair := range j.matched {
+		for i, t := range pair {
+			if !j.outer[j.relations[i]] {
+				continue
+			}
+			t.matches++
+			// the other tuple decrements the count when it leaves the window
+			pair[1-i].partners = append(pair[1-i].partners, t)
+			if t.padRow != nil {
+				obsolete[t.padRow] = true
+				t.rows = removeInputRow(t.rows, t.padRow)
+				t.padRow = nil
+			}
+		}
+	}
+	j.matched = nil
+	if len(obsolete) == 0 {
+		return
+	}
+	var next *list.Element
+	for e := ep.filteredInputRows.Front(); e != nil; e = next {
+		next = e.Next()
+		if obsolete[e.Value.(*inputRowWithCachedResult)] {
+			ep.filteredInputRows.Remove(e)
+		}
+	}
+}
+
+// padNewTuples adds padded rows of tuples which have just been added to
+// the buffers of outer relations and don't match any tuple.
+func (ep *streamRelationStreamExecutionPlan) padNewTuples() error {
+	for alias := range ep.lastTupleBuffers {
+		if !ep.outerJoin.outer[alias] {
+			continue
+		}
+		t := ep.buffers[alias].tuples.Back().Value.(*tupleWithDerivedInputRows)
+		if t.matches == 0 {
+			if err := ep.padTuple(alias, t); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// unmatchPartners decrements match counts of tuples which matched the
+// tuples leaving the window and pads the tuples which don't have a match
+// anymore.
+func (ep *streamRelationStreamExecutionPlan) unmatchPartners(expired map[string][]*tupleWithDerivedInputRows) error {
+	for alias, ts := range expired {
+		other := ep.outerJoin.other(alias)
+		for _, t := range ts {
+			for _, p := range t.partners {
+				if p.expired {
+					continue
+				}
+				p.matches--
+				if p.matches == 0 {
+					if err := ep.padTuple(other, p); err != nil {
+						return err
+					}
+				}
+			}
+			t.partners = nil
+		}
+	}
+	return nil
+}
+
+// padTuple adds a row having the data of the tuple and NULL as the data of
+// the other relation when the row satisfies the WHERE clause.
+func (ep *streamRelationStreamExecutionPlan) padTuple(alias string, t *tupleWithDerivedInputRows) error {
+	other := ep.outerJoin.other(alias)
+	row := data.Map{
+		alias: t.tuple.Data[alias],
+		other: data.Null{},
+	}
+	setMetadata(row, alias, t.tuple)
+	for _, meta := range []parser.MetaInformation{parser.TimestampMeta, parser.IDMeta, parser.BackfillMeta} {
+		row[fmt.Sprintf("%s:meta:%s", other, meta)] = data.Null{}
+	}
+	row[":meta:NOW"] = data.Timestamp(ep.now)
+
+	if ep.filter != nil {
+		ok, err := evalCondition(ep.filter, row)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return nil
+		}
+	}
+	r := &inputRowWithCachedResult{
+		input: &row,
+	}
+	if ep.lineageEnabled() {
+		r.lineage = ep.newInputRowLineage(map[string]*tupleWithDerivedInputRows{alias: t})
+	}
+	t.rows = append(t.rows, r)
+	t.padRow = r
+	ep.filteredInputRows.PushBack(r)
+	return nil
+}
+
+func removeInputRow(rows []*inputRowWithCachedResult, r *inputRowWithCachedResult) []*inputRowWithCachedResult {
+	for i, e := range rows {
+		if e == r {
+			copy(rows[i:], rows[i+1:])
+			rows[len(rows)-1] = nil
+			return rows[:len(rows)-1]
+		}
+	}
+	return rows
+}
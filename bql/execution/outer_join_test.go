@@ -0,0 +1,223 @@
+package execution
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// getOuterJoinTuples returns tuples of src1 and src2 having a value "v" and
+// a join key "k". a and b are in src1 and the others are in src2.
+func getOuterJoinTuples() []*core.Tuple {
+	specs := []struct {
+		input string
+		v     string
+		k     int
+	}{
+		{"src1", "a", 1},
+		{"src2", "x", 2},
+		{"src2", "y", 1},
+		{"src2", "z", 3},
+		{"src2", "w", 4},
+		{"src1", "b", 4},
+	}
+	tuples := make([]*core.Tuple, len(specs))
+	for i, s := range specs {
+		tuples[i] = &core.Tuple{
+			Data: data.Map{
+				"v": data.String(s.v),
+				"k": data.Int(s.k),
+			},
+			InputName:     s.input,
+			Timestamp:     time.Date(2015, time.April, 10, 10, 23, i, 0, time.UTC),
+			ProcTimestamp: time.Date(2015, time.April, 10, 10, 24, i, 0, time.UTC),
+		}
+	}
+	return tuples
+}
+
+// joinedRows returns rows of pairs of values of src1 and src2. An empty
+// value means NULL.
+func joinedRows(pairs ...[2]string) []data.Map {
+	rows := make([]data.Map, len(pairs))
+	for i, p := range pairs {
+		rows[i] = data.Map{"l": data.Null{}, "r": data.Null{}}
+		if p[0] != "" {
+			rows[i]["l"] = data.String(p[0])
+		}
+		if p[1] != "" {
+			rows[i]["r"] = data.String(p[1])
+		}
+	}
+	sort.Sort(tupleList(rows))
+	return rows
+}
+
+func TestOuterJoin(t *testing.T) {
+	for _, c := range []struct {
+		join     string
+		expected [][]data.Map
+	}{
+		{
+			"LEFT OUTER JOIN",
+			[][]data.Map{
+				joinedRows([2]string{"a", ""}),
+				joinedRows([2]string{"a", ""}),
+				joinedRows([2]string{"a", "y"}),
+				joinedRows([2]string{"a", "y"}),
+				// y left the window
+				joinedRows([2]string{"a", ""}),
+				joinedRows([2]string{"a", ""}, [2]string{"b", "w"}),
+			},
+		},
+		{
+			"RIGHT OUTER JOIN",
+			[][]data.Map{
+				joinedRows(),
+				joinedRows([2]string{"", "x"}),
+				joinedRows([2]string{"", "x"}, [2]string{"a", "y"}),
+				joinedRows([2]string{"a", "y"}, [2]string{"", "z"}),
+				joinedRows([2]string{"", "z"}, [2]string{"", "w"}),
+				joinedRows([2]string{"", "z"}, [2]string{"b", "w"}),
+			},
+		},
+		{
+			"FULL OUTER JOIN",
+			[][]data.Map{
+				joinedRows([2]string{"a", ""}),
+				joinedRows([2]string{"a", ""}, [2]string{"", "x"}),
+				joinedRows([2]string{"", "x"}, [2]string{"a", "y"}),
+				joinedRows([2]string{"a", "y"}, [2]string{"", "z"}),
+				joinedRows([2]string{"a", ""}, [2]string{"", "z"}, [2]string{"", "w"}),
+				joinedRows([2]string{"a", ""}, [2]string{"", "z"}, [2]string{"b", "w"}),
+			},
+		},
+	} {
+		c := c
+		Convey(fmt.Sprintf("Given a %v", c.join), t, func() {
+			s := `CREATE STREAM box AS SELECT RSTREAM src1:v AS l, src2:v AS r FROM src1 [RANGE 2 TUPLES] ` +
+				c.join + ` src2 [RANGE 2 TUPLES] ON src1:k = src2:k`
+			plan, err := createDefaultSelectPlan(s, t)
+			So(err, ShouldBeNil)
+
+			Convey("When feeding it with tuples", func() {
+				for idx, inTup := range getOuterJoinTuples() {
+					out, err := plan.Process(inTup)
+					So(err, ShouldBeNil)
+					sort.Sort(tupleList(out))
+
+					Convey(fmt.Sprintf("Then unmatched tuples should be padded with NULL in %v", idx), func() {
+						if len(c.expected[idx]) == 0 {
+							So(out, ShouldBeEmpty)
+						} else {
+							So(out, ShouldResemble, c.expected[idx])
+						}
+					})
+				}
+			})
+		})
+	}
+
+	Convey("Given a LEFT OUTER JOIN with a WHERE clause", t, func() {
+		s := `CREATE STREAM box AS SELECT ISTREAM src1:v AS l, src2:v AS r FROM src1 [RANGE 2 TUPLES] ` +
+			`LEFT JOIN src2 [RANGE 2 TUPLES] ON src1:k = src2:k WHERE src2:v IS NULL`
+		plan, err := createDefaultSelectPlan(s, t)
+		So(err, ShouldBeNil)
+
+		Convey("When feeding it with tuples", func() {
+			var res []data.Map
+			for _, inTup := range getOuterJoinTuples() {
+				out, err := plan.Process(inTup)
+				So(err, ShouldBeNil)
+				res = append(res, out...)
+			}
+
+			Convey("Then the WHERE clause should be applied to padded rows", func() {
+				So(res, ShouldResemble, joinedRows([2]string{"a", ""}, [2]string{"a", ""}))
+			})
+		})
+	})
+
+	Convey("Given a LEFT OUTER JOIN selecting all columns", t, func() {
+		s := `CREATE STREAM box AS SELECT RSTREAM *, src2:ts() AS ts FROM src1 [RANGE 2 TUPLES] ` +
+			`LEFT JOIN src2 [RANGE 2 TUPLES] ON src1:k = src2:k`
+		plan, err := createDefaultSelectPlan(s, t)
+		So(err, ShouldBeNil)
+
+		Convey("When feeding it with an unmatched tuple", func() {
+			out, err := plan.Process(getOuterJoinTuples()[0])
+			So(err, ShouldBeNil)
+
+			Convey("Then the padded relation should be NULL", func() {
+				So(out, ShouldResemble, []data.Map{{
+					"v":  data.String("a"),
+					"k":  data.Int(1),
+					"ts": data.Null{},
+				}})
+			})
+		})
+	})
+
+	Convey("Given a LEFT OUTER JOIN with GROUP BY", t, func() {
+		s := `CREATE STREAM box AS SELECT RSTREAM src1:v AS l, count(src2:v) AS c FROM src1 [RANGE 2 TUPLES] ` +
+			`LEFT JOIN src2 [RANGE 3 TUPLES] ON src1:k <= src2:k GROUP BY src1:v`
+		plan, err := createGroupbyPlan(s, t)
+		So(err, ShouldBeNil)
+
+		Convey("When feeding it with tuples", func() {
+			var out []data.Map
+			for _, inTup := range getOuterJoinTuples() {
+				out, err = plan.Process(inTup)
+				So(err, ShouldBeNil)
+			}
+			sort.Sort(tupleList(out))
+
+			Convey("Then unmatched tuples should be counted as a group", func() {
+				So(out, ShouldResemble, []data.Map{
+					{"l": data.String("b"), "c": data.Int(1)},
+					{"l": data.String("a"), "c": data.Int(3)},
+				})
+			})
+		})
+	})
+
+	Convey("Given a statement with an inner JOIN", t, func() {
+		s := `CREATE STREAM box AS SELECT RSTREAM src1:v AS l, src2:v AS r FROM src1 [RANGE 2 TUPLES] ` +
+			`JOIN src2 [RANGE 2 TUPLES] ON src1:k = src2:k WHERE src2:v != "w"`
+		plan, err := createDefaultSelectPlan(s, t)
+		So(err, ShouldBeNil)
+
+		Convey("When feeding it with tuples", func() {
+			var res []data.Map
+			for _, inTup := range getOuterJoinTuples() {
+				out, err := plan.Process(inTup)
+				So(err, ShouldBeNil)
+				res = append(res, out...)
+			}
+
+			Convey("Then only matched tuples satisfying the WHERE clause should be joined", func() {
+				So(res, ShouldResemble, joinedRows([2]string{"a", "y"}, [2]string{"a", "y"}))
+			})
+		})
+	})
+
+	Convey("Given an outer join with a wrong ON clause", t, func() {
+		for i, s := range []string{
+			`CREATE STREAM box AS SELECT RSTREAM src1:v FROM src1 [RANGE 2 TUPLES] ` +
+				`LEFT JOIN src2 [RANGE 2 TUPLES] ON src1:k = src3:k`,
+			`CREATE STREAM box AS SELECT RSTREAM src1:v FROM src1 [RANGE 2 TUPLES] ` +
+				`LEFT JOIN src2 [RANGE 2 TUPLES] ON count(src1:k) = 1`,
+		} {
+			_, err := createDefaultSelectPlan(s, t)
+
+			Convey(fmt.Sprintf("Then the statement should be rejected (%v)", i), func() {
+				So(err, ShouldNotBeNil)
+			})
+		}
+	})
+}
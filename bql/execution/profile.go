@@ -0,0 +1,246 @@
+package execution
+
+import (
+	"runtime/metrics"
+	"time"
+)
+
+// Profiler is implemented by a PhysicalPlan which can measure the time spent
+// and the memory allocated by each operator of the plan.
+type Profiler interface {
+	// EnableProfiling makes the plan measure its operators on each call of
+	// Process. Measuring adds some overhead, so it should only be enabled
+	// for analyzing a statement.
+	EnableProfiling()
+
+	// Profile returns the measurements accumulated since profiling was
+	// enabled.
+	Profile() *Profile
+}
+
+// Profile has measurements of operators of a plan.
+type Profile struct {
+	// Tuples is the number of input tuples processed by the plan.
+	Tuples int64
+
+	// Operators has measurements of operators which have been run at least
+	// once. Each operator appears at most once in the order of
+	// ProfiledOperators.
+	Operators []OperatorProfile
+}
+
+// Time returns the total time spent in the plan.
+func (p *Profile) Time() time.Duration {
+	var t time.Duration
+	for _, o := range p.Operators {
+		t += o.Time
+	}
+	return t
+}
+
+// OperatorProfile has measurements of an operator. Time and allocations of
+// an operator don't include ones of operators nested in it, e.g. a join
+// doesn't include the time spent in evaluating the filter of each joined row.
+//
+// Allocations are read from process-wide counters of the runtime, so they can
+// include allocations made by other goroutines concurrently, and small
+// objects are counted when the runtime refills its per-thread cache rather
+// than one by one. They're an estimate suitable for finding the operator
+// allocating most.
+type OperatorProfile struct {
+	// Operator is the name of the operator, which is one of
+	// ProfiledOperators.
+	Operator string
+
+	// Calls is the number of times the operator has been run.
+	Calls int64
+
+	// Time is the time spent in the operator.
+	Time time.Duration
+
+	// AllocBytes is the number of bytes allocated by the operator.
+	AllocBytes uint64
+
+	// AllocObjects is the number of objects allocated by the operator.
+	AllocObjects uint64
+}
+
+type profiledOperator int
+
+const (
+	// profileOther is the time spent in a plan outside other operators,
+	// such as deduplication and building input rows. It's run once per
+	// input tuple.
+	profileOther profiledOperator = iota
+	profileWindow
+	profileJoin
+	profileFilter
+	profileProjection
+	profileAggregation
+	profileEmit
+	numProfiledOperators
+)
+
+// ProfiledOperators has names of operators measured by a Profiler:
+//
+//   - other: processing not belonging to other operators, such as
+//     deduplication and building input rows
+//   - window: adding tuples to windows and removing expired ones
+//   - join: computing joined rows of relations in the FROM clause
+//   - filter: evaluating the WHERE clause on each row
+//   - projection: evaluating the SELECT clause on each row
+//   - aggregation: grouping rows and evaluating aggregate functions
+//   - emit: computing results to be emitted by the emitter such as ISTREAM
+var ProfiledOperators = []string{
+	"other",
+	"window",
+	"join",
+	"filter",
+	"projection",
+	"aggregation",
+	"emit",
+}
+
+// profileFrame is an operator being run.
+type profileFrame struct {
+	op      profiledOperator
+	start   time.Time
+	bytes   uint64
+	objects uint64
+
+	// child* have the measurements of operators nested in the frame, which
+	// are excluded from the frame's own measurements.
+	childTime    time.Duration
+	childBytes   uint64
+	childObjects uint64
+}
+
+// profiler accumulates measurements of operators. All methods can be called
+// on nil, in which case they do nothing, so that a plan can call them
+// unconditionally without measuring anything while profiling is disabled.
+type profiler struct {
+	ops     [numProfiledOperators]OperatorProfile
+	stack   []profileFrame
+	samples []metrics.Sample
+}
+
+func newProfiler() *profiler {
+	return &profiler{
+		samples: []metrics.Sample{
+			{Name: "/gc/heap/allocs:bytes"},
+			{Name: "/gc/heap/allocs:objects"},
+		},
+	}
+}
+
+func (p *profiler) allocs() (bytes, objects uint64) {
+	metrics.Read(p.samples)
+	if p.samples[0].Value.Kind() == metrics.KindUint64 {
+		bytes = p.samples[0].Value.Uint64()
+	}
+	if p.samples[1].Value.Kind() == metrics.KindUint64 {
+		objects = p.samples[1].Value.Uint64()
+	}
+	return
+}
+
+// beginTuple starts processing an input tuple. Frames left by a call of
+// Process which has been interrupted by a panic are discarded.
+func (p *profiler) beginTuple() {
+	if p == nil {
+		return
+	}
+	p.stack = p.stack[:0]
+	p.begin(profileOther)
+}
+
+// begin starts measuring the operator. It must be followed by end.
+func (p *profiler) begin(op profiledOperator) {
+	if p == nil {
+		return
+	}
+	b, o := p.allocs()
+	p.stack = append(p.stack, profileFrame{
+		op:      op,
+		start:   time.Now(),
+		bytes:   b,
+		objects: o,
+	})
+}
+
+// end finishes measuring the operator started by the last call of begin.
+func (p *profiler) end() {
+	if p == nil || len(p.stack) == 0 {
+		return
+	}
+	now := time.Now()
+	b, o := p.allocs()
+	f := p.stack[len(p.stack)-1]
+	p.stack = p.stack[:len(p.stack)-1]
+
+	t := now.Sub(f.start)
+	db := b - f.bytes
+	do := o - f.objects
+	op := &p.ops[f.op]
+	op.Calls++
+	op.Time += t - f.childTime
+	op.AllocBytes += nonNegativeSub(db, f.childBytes)
+	op.AllocObjects += nonNegativeSub(do, f.childObjects)
+
+	if len(p.stack) > 0 {
+		parent := &p.stack[len(p.stack)-1]
+		parent.childTime += t
+		parent.childBytes += db
+		parent.childObjects += do
+	}
+}
+
+func nonNegativeSub(a, b uint64) uint64 {
+	if a < b {
+		return 0
+	}
+	return a - b
+}
+
+// wrap returns a function measuring f as the operator. It returns f as it is
+// when profiling is disabled.
+func (p *profiler) wrap(op profiledOperator, f func() error) func() error {
+	if p == nil {
+		return f
+	}
+	return func() error {
+		p.begin(op)
+		defer p.end()
+		return f()
+	}
+}
+
+func (p *profiler) profile() *Profile {
+	res := &Profile{
+		Tuples: p.ops[profileOther].Calls,
+	}
+	for i, o := range p.ops {
+		if o.Calls == 0 {
+			continue
+		}
+		o.Operator = ProfiledOperators[i]
+		res.Operators = append(res.Operators, o)
+	}
+	return res
+}
+
+// EnableProfiling makes the plan measure its operators.
+func (ep *commonExecutionPlan) EnableProfiling() {
+	if ep.profile == nil {
+		ep.profile = newProfiler()
+	}
+}
+
+// Profile returns measurements of operators of the plan. It returns nil when
+// profiling isn't enabled.
+func (ep *commonExecutionPlan) Profile() *Profile {
+	if ep.profile == nil {
+		return nil
+	}
+	return ep.profile.profile()
+}
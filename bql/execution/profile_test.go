@@ -0,0 +1,120 @@
+package execution
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestProfile(t *testing.T) {
+	operators := func(p *Profile) map[string]OperatorProfile {
+		m := map[string]OperatorProfile{}
+		for _, o := range p.Operators {
+			m[o.Operator] = o
+		}
+		return m
+	}
+
+	Convey("Given a default plan joining two streams", t, func() {
+		tuples := getTuples(4)
+		for i, tup := range tuples {
+			if i%2 == 0 {
+				tup.InputName = "src1"
+			} else {
+				tup.InputName = "src2"
+			}
+		}
+		s := `CREATE STREAM box AS SELECT ISTREAM src1:int AS l, src2:int AS r
+			FROM src1 [RANGE 2 TUPLES], src2 [RANGE 2 TUPLES] WHERE src1:int < src2:int`
+		plan, err := createDefaultSelectPlan(s, t)
+		So(err, ShouldBeNil)
+
+		Convey("When profiling isn't enabled", func() {
+			for _, tup := range tuples {
+				_, err := plan.Process(tup)
+				So(err, ShouldBeNil)
+			}
+
+			Convey("Then it shouldn't have a profile", func() {
+				So(plan.(Profiler).Profile(), ShouldBeNil)
+			})
+		})
+
+		Convey("When processing tuples with profiling", func() {
+			plan.(Profiler).EnableProfiling()
+			for _, tup := range tuples {
+				_, err := plan.Process(tup)
+				So(err, ShouldBeNil)
+			}
+			p := plan.(Profiler).Profile()
+
+			Convey("Then it should have measured each operator", func() {
+				So(p.Tuples, ShouldEqual, 4)
+				ops := operators(p)
+				So(ops["other"].Calls, ShouldEqual, 4)
+				So(ops["window"].Calls, ShouldEqual, 4)
+				So(ops["join"].Calls, ShouldEqual, 4)
+				So(ops["projection"].Calls, ShouldEqual, 4)
+				So(ops["emit"].Calls, ShouldEqual, 4)
+				// the filter is evaluated on each joined row including
+				// the new tuple: 0 + 1 + 1 + 2
+				So(ops["filter"].Calls, ShouldEqual, 4)
+				So(ops, ShouldNotContainKey, "aggregation")
+				for _, o := range p.Operators {
+					So(o.Time, ShouldBeGreaterThanOrEqualTo, 0)
+				}
+				So(p.Time(), ShouldBeGreaterThan, 0)
+			})
+
+			Convey("Then operators should be in the order of ProfiledOperators", func() {
+				names := []string{}
+				for _, o := range p.Operators {
+					names = append(names, o.Operator)
+				}
+				So(names, ShouldResemble, []string{"other", "window", "join", "filter", "projection", "emit"})
+			})
+		})
+	})
+
+	Convey("Given a groupby plan", t, func() {
+		s := `CREATE STREAM box AS SELECT RSTREAM foo, count(*) FROM src [RANGE 3 TUPLES] GROUP BY foo`
+		plan, err := createGroupbyPlan(s, t)
+		So(err, ShouldBeNil)
+
+		Convey("When processing tuples with profiling", func() {
+			plan.(Profiler).EnableProfiling()
+			for _, tup := range getOtherTuples() {
+				_, err := plan.Process(tup)
+				So(err, ShouldBeNil)
+			}
+
+			Convey("Then aggregation should be measured instead of projection", func() {
+				ops := operators(plan.(Profiler).Profile())
+				So(ops["aggregation"].Calls, ShouldEqual, 4)
+				So(ops, ShouldNotContainKey, "projection")
+				So(ops, ShouldNotContainKey, "join")
+				So(ops, ShouldNotContainKey, "filter")
+			})
+		})
+	})
+
+	Convey("Given a filter plan", t, func() {
+		s := `CREATE STREAM box AS SELECT RSTREAM int FROM src [RANGE 1 TUPLES] WHERE int % 2 = 0`
+		plan, _, err := createFilterPlan(s, t)
+		So(err, ShouldBeNil)
+
+		Convey("When processing tuples with profiling", func() {
+			plan.(Profiler).EnableProfiling()
+			for _, tup := range getTuples(4) {
+				_, err := plan.Process(tup)
+				So(err, ShouldBeNil)
+			}
+
+			Convey("Then projection should only be measured for rows passing the filter", func() {
+				ops := operators(plan.(Profiler).Profile())
+				So(ops["filter"].Calls, ShouldEqual, 4)
+				So(ops["projection"].Calls, ShouldEqual, 2)
+				So(ops, ShouldNotContainKey, "window")
+			})
+		})
+	})
+}
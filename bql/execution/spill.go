@@ -0,0 +1,254 @@
+package execution
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// WindowSpiller is implemented by a PhysicalPlan which can spill contents of
+// its window to disk.
+type WindowSpiller interface {
+	// EnableWindowSpill makes the plan write rows of its window exceeding
+	// c.MaxRowsInMemory to segment files. It returns false when the plan
+	// cannot spill its window, e.g. when it joins multiple streams.
+	EnableWindowSpill(c *core.WindowSpillConfig) bool
+
+	// CloseWindowSpill removes all segment files of the plan. The plan
+	// cannot process tuples after this method is called.
+	CloseWindowSpill() error
+}
+
+// spillSegment is a file having encoded rows. Rows are appended to the
+// active segment of a spillStore. Once a segment is sealed, it's mapped to
+// memory when the platform supports it.
+type spillSegment struct {
+	f    *os.File
+	size int64
+	// live is the number of rows in the segment which aren't released.
+	live int
+	// mapped is the content of the sealed segment. It's nil when the
+	// segment isn't mapped and rows are read by ReadAt.
+	mapped []byte
+}
+
+// spillRef refers to a row written to a segment.
+type spillRef struct {
+	seg *spillSegment
+	off int64
+	n   int
+}
+
+// spillStore writes rows to segment files and reads them again. A segment is
+// removed when all rows in it are released. spillStore isn't thread-safe.
+type spillStore struct {
+	dir         string
+	segmentSize int64
+	active      *spillSegment
+	segments    map[*spillSegment]bool
+}
+
+func newSpillStore(dir string, segmentSize int64) *spillStore {
+	return &spillStore{
+		dir:         dir,
+		segmentSize: segmentSize,
+		segments:    map[*spillSegment]bool{},
+	}
+}
+
+// write appends the row to the active segment.
+func (s *spillStore) write(m data.Map) (*spillRef, error) {
+	b, err := data.EncodeMsgpack(m)
+	if err != nil {
+		return nil, err
+	}
+	if s.active == nil || s.active.size >= s.segmentSize {
+		if err := s.rotate(); err != nil {
+			return nil, err
+		}
+	}
+	seg := s.active
+	if _, err := seg.f.Write(b); err != nil {
+		return nil, fmt.Errorf("cannot spill a row of a window: %v", err)
+	}
+	r := &spillRef{
+		seg: seg,
+		off: seg.size,
+		n:   len(b),
+	}
+	seg.size += int64(len(b))
+	seg.live++
+	return r, nil
+}
+
+// rotate seals the active segment and creates a new one.
+func (s *spillStore) rotate() error {
+	if seg := s.active; seg != nil {
+		s.active = nil
+		if seg.live == 0 {
+			s.remove(seg)
+		} else if m, err := mmapFile(seg.f, seg.size); err == nil {
+			// The segment can still be read by ReadAt when it cannot be
+			// mapped.
+			seg.mapped = m
+		}
+	}
+
+	f, err := ioutil.TempFile(s.dir, "sensorbee-window-")
+	if err != nil {
+		return fmt.Errorf("cannot create a segment file of a window: %v", err)
+	}
+	s.active = &spillSegment{
+		f: f,
+	}
+	s.segments[s.active] = true
+	return nil
+}
+
+// read decodes the row.
+func (s *spillStore) read(r *spillRef) (data.Map, error) {
+	// The row is copied even when the segment is mapped because decoded
+	// values such as blobs can refer to the buffer, which is unmapped when
+	// the segment is removed.
+	b := make([]byte, r.n)
+	if r.seg.mapped != nil {
+		copy(b, r.seg.mapped[r.off:r.off+int64(r.n)])
+	} else if _, err := r.seg.f.ReadAt(b, r.off); err != nil {
+		return nil, fmt.Errorf("cannot read a spilled row of a window: %v", err)
+	}
+	v, err := data.DecodeMsgpack(b)
+	if err != nil {
+		return nil, err
+	}
+	return data.AsMap(v)
+}
+
+// release marks the row as unused. The segment is removed when it's sealed
+// and all rows in it are released.
+func (s *spillStore) release(r *spillRef) {
+	r.seg.live--
+	if r.seg.live == 0 && r.seg != s.active {
+		s.remove(r.seg)
+	}
+}
+
+func (s *spillStore) remove(seg *spillSegment) error {
+	delete(s.segments, seg)
+	var firstErr error
+	if seg.mapped != nil {
+		firstErr = munmap(seg.mapped)
+		seg.mapped = nil
+	}
+	if err := seg.f.Close(); err != nil && firstErr == nil {
+		firstErr = err
+	}
+	if err := os.Remove(seg.f.Name()); err != nil && firstErr == nil {
+		firstErr = err
+	}
+	return firstErr
+}
+
+// numSegments returns the number of segment files.
+func (s *spillStore) numSegments() int {
+	return len(s.segments)
+}
+
+// close removes all segments.
+func (s *spillStore) close() error {
+	var firstErr error
+	for seg := range s.segments {
+		if err := s.remove(seg); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	s.active = nil
+	return firstErr
+}
+
+// windowSpill spills the oldest rows of a window when the window has more
+// rows in memory than the limit.
+type windowSpill struct {
+	store           *spillStore
+	maxRowsInMemory int
+
+	// inMemory has rows which haven't been spilled in the order they were
+	// added. It can have expired rows, which are skipped.
+	inMemory    []*inputRowWithCachedResult
+	numInMemory int
+}
+
+func newWindowSpill(c *core.WindowSpillConfig) *windowSpill {
+	return &windowSpill{
+		store:           newSpillStore(c.Dir, c.SegmentSize),
+		maxRowsInMemory: c.MaxRowsInMemory,
+	}
+}
+
+// add registers a new row kept in memory.
+func (w *windowSpill) add(r *inputRowWithCachedResult) {
+	w.inMemory = append(w.inMemory, r)
+	w.numInMemory++
+}
+
+// expire releases the row removed from the window.
+func (w *windowSpill) expire(r *inputRowWithCachedResult) {
+	r.expired = true
+	if r.spilled != nil {
+		w.store.release(r.spilled)
+		r.spilled = nil
+		return
+	}
+	w.numInMemory--
+}
+
+// spill writes the oldest rows to disk until the number of rows in memory
+// gets within the limit.
+func (w *windowSpill) spill() error {
+	for w.numInMemory > w.maxRowsInMemory {
+		r := w.inMemory[0]
+		if !r.expired {
+			ref, err := w.store.write(*r.input)
+			if err != nil {
+				return err
+			}
+			r.spilled = ref
+			r.input = nil
+			w.numInMemory--
+		}
+		w.inMemory[0] = nil
+		w.inMemory = w.inMemory[1:]
+	}
+	return nil
+}
+
+// EnableWindowSpill makes the plan spill its window to disk. Only a window
+// of a single stream can be spilled because tuples in windows of a join are
+// needed to join them with new tuples. Windows of a window function aren't
+// spilled either.
+func (ep *streamRelationStreamExecutionPlan) EnableWindowSpill(c *core.WindowSpillConfig) bool {
+	if len(ep.buffers) != 1 || ep.window != nil {
+		return false
+	}
+	ep.spill = newWindowSpill(c)
+	return true
+}
+
+// CloseWindowSpill removes segment files of the plan.
+func (ep *streamRelationStreamExecutionPlan) CloseWindowSpill() error {
+	if ep.spill == nil {
+		return nil
+	}
+	return ep.spill.store.close()
+}
+
+// inputData returns the input data of the row, which is read from disk when
+// the row was spilled.
+func (ep *streamRelationStreamExecutionPlan) inputData(r *inputRowWithCachedResult) (data.Map, error) {
+	if r.spilled != nil {
+		return ep.spill.store.read(r.spilled)
+	}
+	return *r.input, nil
+}
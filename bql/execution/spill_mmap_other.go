@@ -0,0 +1,19 @@
+//go:build !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd
+// +build !darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd
+
+package execution
+
+import (
+	"errors"
+	"os"
+)
+
+// mmapFile always fails because memory-mapped files aren't supported on this
+// platform. Segments are read by ReadAt instead.
+func mmapFile(f *os.File, size int64) ([]byte, error) {
+	return nil, errors.New("memory-mapped files aren't supported on this platform")
+}
+
+func munmap(b []byte) error {
+	return nil
+}
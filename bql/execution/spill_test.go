@@ -0,0 +1,152 @@
+package execution
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+func TestSpillStore(t *testing.T) {
+	Convey("Given a spill store having small segments", t, func() {
+		dir, err := ioutil.TempDir("", "spill_test")
+		So(err, ShouldBeNil)
+		Reset(func() {
+			os.RemoveAll(dir)
+		})
+		s := newSpillStore(dir, 64)
+		numFiles := func() int {
+			fs, err := filepath.Glob(filepath.Join(dir, "*"))
+			So(err, ShouldBeNil)
+			return len(fs)
+		}
+
+		Convey("When writing rows", func() {
+			ts := time.Date(2015, time.April, 10, 10, 23, 0, 123456789, time.UTC)
+			var refs []*spillRef
+			for i := 0; i < 10; i++ {
+				r, err := s.write(data.Map{
+					"int":  data.Int(i),
+					"str":  data.String(fmt.Sprint("row", i)),
+					"blob": data.Blob("blob"),
+					"ts":   data.Timestamp(ts),
+					"arr":  data.Array{data.Float(1.5), data.Null{}},
+				})
+				So(err, ShouldBeNil)
+				refs = append(refs, r)
+			}
+
+			Convey("Then they should be written to multiple segments", func() {
+				So(s.numSegments(), ShouldBeGreaterThan, 1)
+				So(numFiles(), ShouldEqual, s.numSegments())
+			})
+
+			Convey("Then they should be read as they were written", func() {
+				for i, r := range refs {
+					m, err := s.read(r)
+					So(err, ShouldBeNil)
+					So(m, ShouldResemble, data.Map{
+						"int":  data.Int(i),
+						"str":  data.String(fmt.Sprint("row", i)),
+						"blob": data.Blob("blob"),
+						"ts":   data.Timestamp(ts),
+						"arr":  data.Array{data.Float(1.5), data.Null{}},
+					})
+				}
+			})
+
+			Convey("Then segments should be removed when all rows are released", func() {
+				for _, r := range refs[:len(refs)-1] {
+					s.release(r)
+				}
+				So(s.numSegments(), ShouldEqual, 1)
+				So(numFiles(), ShouldEqual, 1)
+
+				m, err := s.read(refs[len(refs)-1])
+				So(err, ShouldBeNil)
+				So(m["int"], ShouldEqual, data.Int(9))
+			})
+
+			Convey("Then closing the store should remove all segments", func() {
+				So(s.close(), ShouldBeNil)
+				So(s.numSegments(), ShouldEqual, 0)
+				So(numFiles(), ShouldEqual, 0)
+			})
+		})
+	})
+}
+
+func TestWindowSpill(t *testing.T) {
+	Convey("Given GROUP BY plans with and without spilling windows", t, func() {
+		dir, err := ioutil.TempDir("", "spill_test")
+		So(err, ShouldBeNil)
+		Reset(func() {
+			os.RemoveAll(dir)
+		})
+		s := `CREATE STREAM box AS SELECT RSTREAM foo, count(*) AS c, sum(int) AS s, array_agg(str) AS m
+			FROM src [RANGE 10 TUPLES] GROUP BY foo`
+		plan, err := createGroupbyPlan(s, t)
+		So(err, ShouldBeNil)
+		spilled, err := createGroupbyPlan(s, t)
+		So(err, ShouldBeNil)
+		So(spilled.(WindowSpiller).EnableWindowSpill(&core.WindowSpillConfig{
+			Dir:             dir,
+			MaxRowsInMemory: 3,
+			SegmentSize:     128,
+		}), ShouldBeTrue)
+		ep := &spilled.(*groupbyExecutionPlan).streamRelationStreamExecutionPlan
+
+		Convey("When feeding them with tuples", func() {
+			tuples := getTuples(30)
+			for i, t := range tuples {
+				t.Data["foo"] = data.Int(i % 3)
+				t.Data["str"] = data.String(fmt.Sprint("str", i))
+			}
+
+			Convey("Then they should emit the same results", func() {
+				for _, t := range tuples {
+					expected, err := plan.Process(t.Copy())
+					So(err, ShouldBeNil)
+					actual, err := spilled.Process(t.Copy())
+					So(err, ShouldBeNil)
+					So(actual, ShouldResemble, expected)
+					So(ep.spill.numInMemory, ShouldBeLessThanOrEqualTo, 3)
+				}
+				So(ep.filteredInputRows.Len(), ShouldEqual, 10)
+
+				Convey("And segments of expired rows should be removed", func() {
+					// Only segments having some of the 7 spilled rows in the window
+					// and the active segment remain.
+					So(ep.spill.store.numSegments(), ShouldBeLessThanOrEqualTo, 5)
+				})
+
+				Convey("And closing the plan should remove all segments", func() {
+					So(spilled.(WindowSpiller).CloseWindowSpill(), ShouldBeNil)
+					fs, err := filepath.Glob(filepath.Join(dir, "*"))
+					So(err, ShouldBeNil)
+					So(fs, ShouldBeEmpty)
+				})
+			})
+		})
+	})
+
+	Convey("Given a plan joining two streams", t, func() {
+		s := `CREATE STREAM box AS SELECT RSTREAM a:int, b:int FROM src [RANGE 2 TUPLES] AS a, src2 [RANGE 2 TUPLES] AS b`
+		plan, err := createDefaultSelectPlan(s, t)
+		So(err, ShouldBeNil)
+
+		Convey("When enabling spilling windows", func() {
+			ok := plan.(WindowSpiller).EnableWindowSpill(&core.WindowSpillConfig{MaxRowsInMemory: 1})
+
+			Convey("Then it should be rejected", func() {
+				So(ok, ShouldBeFalse)
+			})
+		})
+	})
+}
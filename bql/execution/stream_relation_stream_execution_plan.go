@@ -14,11 +14,49 @@ type inputBuffer struct {
 	tuples     *list.List
 	windowSize float64
 	windowType parser.IntervalUnit
+	// nextSeq is the sequence number assigned to the next tuple.
+	nextSeq int64
+	// aliases are the aliases of relations whose windows are in the
+	// buffer. Relations of a self-join having the same window share one
+	// buffer, and then the first alias owns the buffer.
+	aliases []string
+	// indexes are hash indexes of tuples in the buffer used to find rows
+	// satisfying equality join conditions. Each relation sharing the
+	// buffer can have its own index.
+	indexes []*joinIndex
+}
+
+// index returns the index used to find tuples of the relation in the
+// buffer. It returns nil when the relation isn't indexed.
+func (i *inputBuffer) index(alias string) *joinIndex {
+	for _, idx := range i.indexes {
+		if idx.relation == alias {
+			return idx
+		}
+	}
+	return nil
 }
 
 type tupleWithDerivedInputRows struct {
 	tuple *core.Tuple
 	rows  []*inputRowWithCachedResult
+	// seq is a sequence number of the tuple in the buffer. It's used to
+	// check if an indexed tuple is in a partialList.
+	seq int64
+	// keys are keys of the tuple in the indexes of the buffer.
+	keys []joinIndexKey
+	// eventTime is the time used to assign the tuple to windows of a
+	// window function.
+	eventTime time.Time
+	// matches, partners, padRow, and expired are used by outerJoin.
+	// matches is the number of tuples of the other relation satisfying the
+	// ON condition with the tuple and partners has tuples of the other
+	// relation whose matches the tuple contributes to. padRow is the row
+	// padded with NULL while the tuple doesn't have a match.
+	matches  int
+	partners []*tupleWithDerivedInputRows
+	padRow   *inputRowWithCachedResult
+	expired  bool
 }
 
 func (i *inputBuffer) isTimeBased() bool {
@@ -32,6 +70,14 @@ type inputRowWithCachedResult struct {
 	input *data.Map
 	cache data.Value
 	hash  data.HashValue
+	// lineage has IDs of tuples the row originates from. It's nil when
+	// lineage isn't tracked.
+	lineage *core.Lineage
+	// spilled refers to the input data written to disk. input is nil
+	// while the row is spilled.
+	spilled *spillRef
+	// expired is set when the row is removed from the window.
+	expired bool
 }
 
 // resultRow holds data for a tuple to be emitted (sooner or later)
@@ -41,6 +87,9 @@ type inputRowWithCachedResult struct {
 type resultRow struct {
 	row  data.Map
 	hash data.HashValue
+	// lineage has IDs of input tuples contributing to the row. It's nil
+	// when lineage isn't tracked.
+	lineage *core.Lineage
 }
 
 // resultRowCount stores a count for a particular data item. This is
@@ -104,11 +153,26 @@ type streamRelationStreamExecutionPlan struct {
 	// the last tuple was appended to. this is valid after
 	// `addTupleToBuffer` has returned.
 	lastTupleBuffers map[string]bool
+	// lineageMaxInputs is the maximum number of IDs of input tuples kept
+	// for each result. Lineage isn't tracked when it's 0.
+	lineageMaxInputs int
+	// lineage holds the lineage of each result returned from the last
+	// call of Process when lineage is tracked.
+	lineage []*core.Lineage
+	// spill writes older rows of the window to disk. It's nil when the
+	// window is always kept in memory.
+	spill *windowSpill
+	// window assigns tuples to windows of a window function such as
+	// TUMBLE. It's nil when the window is given by a RANGE clause.
+	window *windowFunction
+	// outerJoin pads tuples of an outer join not matching any tuple. It's
+	// nil when the statement doesn't have an outer join.
+	outerJoin *outerJoin
 }
 
 func newStreamRelationStreamExecutionPlan(lp *LogicalPlan, reg udf.FunctionRegistry) (*streamRelationStreamExecutionPlan, error) {
 	// prepare projection components
-	projs, err := prepareProjections(lp.Projections, reg)
+	projs, projCache, err := prepareProjections(lp.Projections, reg)
 	if err != nil {
 		return nil, err
 	}
@@ -122,26 +186,80 @@ func newStreamRelationStreamExecutionPlan(lp *LogicalPlan, reg udf.FunctionRegis
 	if err != nil {
 		return nil, err
 	}
+	dedup, err := newDeduplicator(lp, reg)
+	if err != nil {
+		return nil, err
+	}
 	// for compatibility with the old syntax, take the last RANGE
 	// specification as valid for all buffers
 
-	// initialize buffers (one per declared input relation)
+	// initialize buffers (one per declared input relation except ones
+	// sharing a buffer with another relation of a self-join)
 	buffers := make(map[string]*inputBuffer, len(lp.Relations))
 	for _, rel := range lp.Relations {
+		if owner, ok := lp.SharedWindows[rel.Alias]; ok {
+			buffer := buffers[owner]
+			buffer.aliases = append(buffer.aliases, rel.Alias)
+			buffers[rel.Alias] = buffer
+			continue
+		}
 		tuples := list.New()
 		rangeValue := float64(rel.Value)
 		rangeUnit := rel.Unit
 		// the alias of the relation is the key of the buffer
 		buffers[rel.Alias] = &inputBuffer{
-			tuples, rangeValue, rangeUnit,
+			tuples:     tuples,
+			windowSize: rangeValue,
+			windowType: rangeUnit,
+			aliases:    []string{rel.Alias},
+		}
+	}
+	// index buffers on equality join conditions so that a join doesn't
+	// have to scan all tuples in other buffers
+	for i := range lp.EquiJoinKeys {
+		k := &lp.EquiJoinKeys[i]
+		for side, rel := range k.relations {
+			buffer, ok := buffers[rel]
+			if !ok || buffer.index(rel) != nil {
+				continue
+			}
+			if _, ok := buffers[k.relations[1-side]]; !ok {
+				continue
+			}
+			idx, err := newJoinIndex(k, side, len(buffer.indexes), reg)
+			if err != nil {
+				return nil, err
+			}
+			buffer.indexes = append(buffer.indexes, idx)
 		}
 	}
 
+	var window *windowFunction
+	if len(lp.Relations) == 1 && lp.Relations[0].Function != nil {
+		w, err := newWindowFunction(&lp.Relations[0])
+		if err != nil {
+			return nil, err
+		}
+		window = w
+	}
+
+	var oj *outerJoin
+	if lp.isOuterJoin() {
+		j, err := newOuterJoin(lp, reg)
+		if err != nil {
+			return nil, err
+		}
+		oj = j
+	}
+
 	return &streamRelationStreamExecutionPlan{
 		commonExecutionPlan: commonExecutionPlan{
 			projections: projs,
+			projCache:   projCache,
 			groupList:   groupList,
 			filter:      filter,
+			dedup:       dedup,
+			clock:       reg.Context().Clock(),
 		},
 		relations:            lp.Relations,
 		buffers:              buffers,
@@ -150,6 +268,8 @@ func newStreamRelationStreamExecutionPlan(lp *LogicalPlan, reg udf.FunctionRegis
 		prevResults:          []resultRow{},
 		prevHashesForIstream: map[data.HashValue][]resultRowCount{},
 		filteredInputRows:    list.New(),
+		window:               window,
+		outerJoin:            oj,
 	}, nil
 }
 
@@ -196,17 +316,35 @@ func (ep *streamRelationStreamExecutionPlan) addTupleToBuffer(t *core.Tuple) err
 	ep.lastTupleBuffers = make(map[string]bool, numAppends)
 	for _, rel := range ep.relations {
 		if t.InputName == ep.relationKey(&rel) {
+			ep.lastTupleBuffers[rel.Alias] = true
+			buffer := ep.buffers[rel.Alias]
+			if buffer.aliases[0] != rel.Alias {
+				// the tuple has already been appended to the buffer
+				// shared with the owner
+				continue
+			}
 			// because the tuple is always cached, ShallowCopy is required here.
 			editTuple := t.ShallowCopy()
-			// nest the data in a one-element map using the alias as the key
-			editTuple.Data = data.Map{rel.Alias: editTuple.Data}
+			// nest the data in a map using the aliases of the buffer as
+			// keys, which is usually a one-element map
+			nested := make(data.Map, len(buffer.aliases))
+			for _, alias := range buffer.aliases {
+				nested[alias] = editTuple.Data
+			}
+			editTuple.Data = nested
 			// wrap this in a container struct
+			buffer.nextSeq++
 			editTupleCont := tupleWithDerivedInputRows{
 				tuple: editTuple,
+				seq:   buffer.nextSeq,
+			}
+			if len(buffer.indexes) > 0 {
+				editTupleCont.keys = make([]joinIndexKey, len(buffer.indexes))
+				for _, idx := range buffer.indexes {
+					idx.add(&editTupleCont)
+				}
 			}
-			buffer := ep.buffers[rel.Alias]
 			buffer.tuples.PushBack(&editTupleCont)
-			ep.lastTupleBuffers[rel.Alias] = true
 		}
 	}
 
@@ -218,7 +356,23 @@ func (ep *streamRelationStreamExecutionPlan) addTupleToBuffer(t *core.Tuple) err
 // specification.
 func (ep *streamRelationStreamExecutionPlan) removeOutdatedTuplesFromBuffer(curTupTime time.Time) error {
 	expiredInputRows := map[*inputRowWithCachedResult]bool{}
-	for _, buffer := range ep.buffers {
+	expiredTuples := map[string][]*tupleWithDerivedInputRows{}
+	expire := func(key string, tupCont *tupleWithDerivedInputRows) {
+		// mark input rows that are derived from outdated
+		// tuples for deletion
+		for _, inputRow := range tupCont.rows {
+			expiredInputRows[inputRow] = true
+		}
+		if ep.outerJoin != nil {
+			tupCont.expired = true
+			expiredTuples[key] = append(expiredTuples[key], tupCont)
+		}
+	}
+	for key, buffer := range ep.buffers {
+		if buffer.aliases[0] != key {
+			// a shared buffer is only updated by its owner
+			continue
+		}
 		curBufSize := int64(buffer.tuples.Len())
 		if buffer.windowType == parser.Tuples { // tuple-based window
 			windowSizeInt := int64(buffer.windowSize)
@@ -232,10 +386,9 @@ func (ep *streamRelationStreamExecutionPlan) removeOutdatedTuplesFromBuffer(curT
 					next = e.Next()
 					i++
 					tupCont := e.Value.(*tupleWithDerivedInputRows)
-					// mark input rows that are derived from outdated
-					// tuples for deletion
-					for _, inputRow := range tupCont.rows {
-						expiredInputRows[inputRow] = true
+					expire(key, tupCont)
+					for _, idx := range buffer.indexes {
+						idx.remove(tupCont)
 					}
 					buffer.tuples.Remove(e)
 				}
@@ -254,10 +407,9 @@ func (ep *streamRelationStreamExecutionPlan) removeOutdatedTuplesFromBuffer(curT
 				tupCont := e.Value.(*tupleWithDerivedInputRows)
 				dur := curTupTime.Sub(tupCont.tuple.Timestamp)
 				if dur.Seconds() > windowSizeSeconds {
-					// mark input rows that are derived from outdated
-					// tuples for deletion
-					for _, inputRow := range tupCont.rows {
-						expiredInputRows[inputRow] = true
+					expire(key, tupCont)
+					for _, idx := range buffer.indexes {
+						idx.remove(tupCont)
 					}
 					buffer.tuples.Remove(e)
 				}
@@ -273,8 +425,15 @@ func (ep *streamRelationStreamExecutionPlan) removeOutdatedTuplesFromBuffer(curT
 		itemPtr := e.Value.(*inputRowWithCachedResult)
 		if toDelete := expiredInputRows[itemPtr]; toDelete {
 			ep.filteredInputRows.Remove(e)
+			if ep.spill != nil {
+				ep.spill.expire(itemPtr)
+			}
 		}
 	}
+	// tuples which matched the outdated tuples may have to be padded now
+	if ep.outerJoin != nil {
+		return ep.unmatchPartners(expiredTuples)
+	}
 
 	return nil
 }
@@ -337,10 +496,16 @@ func (ep *streamRelationStreamExecutionPlan) incrAndGetMultiplicity(r *resultRow
 func (ep *streamRelationStreamExecutionPlan) computeResultTuples() ([]data.Map, error) {
 	// TODO turn this into an iterator/generator pattern
 	var output []data.Map
+	emit := func(res *resultRow) {
+		output = append(output, res.row)
+		if ep.lineageEnabled() {
+			ep.lineage = append(ep.lineage, res.lineage)
+		}
+	}
 	if ep.emitterType == parser.Rstream {
 		// emit all tuples
-		for _, res := range ep.curResults {
-			output = append(output, res.row)
+		for i := range ep.curResults {
+			emit(&ep.curResults[i])
 		}
 		return output, nil
 	}
@@ -349,7 +514,7 @@ func (ep *streamRelationStreamExecutionPlan) computeResultTuples() ([]data.Map,
 	if ep.emitterType == parser.Istream {
 		// emit only new tuples
 		for _, res := range ep.curResults {
-			if res.hash == 0 {
+			if res.hash == (data.HashValue{}) {
 				return nil, fmt.Errorf("output row %v did not "+
 					"have a precomputed hash", res.row)
 			}
@@ -360,7 +525,7 @@ func (ep *streamRelationStreamExecutionPlan) computeResultTuples() ([]data.Map,
 			}
 			// if we arrive here, `res` is not contained in prevResults
 			// as often as in curResults
-			output = append(output, res.row)
+			emit(&res)
 		}
 		// the hashes computed for the current items will be reused
 		// in the next run
@@ -370,7 +535,7 @@ func (ep *streamRelationStreamExecutionPlan) computeResultTuples() ([]data.Map,
 	} else if ep.emitterType == parser.Dstream {
 		// build a map containing the counts of the current items
 		for _, res := range ep.curResults {
-			if res.hash == 0 {
+			if res.hash == (data.HashValue{}) {
 				return nil, fmt.Errorf("output row %v did not "+
 					"have a precomputed hash", res.row)
 			}
@@ -379,7 +544,7 @@ func (ep *streamRelationStreamExecutionPlan) computeResultTuples() ([]data.Map,
 		// emit only old tuples
 		counts := map[data.HashValue][]resultRowCount{}
 		for _, prevItem := range ep.prevResults {
-			if prevItem.hash == 0 {
+			if prevItem.hash == (data.HashValue{}) {
 				return nil, fmt.Errorf("output row %v did not "+
 					"have a precomputed hash", prevItem.row)
 			}
@@ -395,7 +560,7 @@ func (ep *streamRelationStreamExecutionPlan) computeResultTuples() ([]data.Map,
 			}
 			// if we arrive here, `prevItem` is not contained in curResults
 			// as often as in prevResults
-			output = append(output, prevItem.row)
+			emit(&prevItem)
 		}
 		return output, nil
 	}
@@ -408,29 +573,67 @@ func (ep *streamRelationStreamExecutionPlan) computeResultTuples() ([]data.Map,
 // to the results of the query represented by this execution plan. Note that the
 // order of items in the returned slice is undefined and cannot be relied on.
 func (ep *streamRelationStreamExecutionPlan) process(input *core.Tuple, performQueryOnBuffer func() error) ([]data.Map, error) {
-	ep.now = time.Now().In(time.UTC)
+	ep.now = ep.clock.Now().In(time.UTC)
+	ep.lineage = nil
+
+	// duplicate tuples don't enter the window, so nothing changes
+	if ep.dedup != nil {
+		dup, err := ep.dedup.isDuplicate(input, ep.now)
+		if err != nil {
+			return nil, err
+		}
+		if dup {
+			return nil, nil
+		}
+	}
+
+	// a window function performs the query when a window is closed
+	// instead of on every tuple
+	if ep.window != nil {
+		return ep.processWindowFunction(input, performQueryOnBuffer)
+	}
 
 	// stream-to-relation:
 	// updates the internal buffer with correct window data
-	if err := ep.addTupleToBuffer(input); err != nil {
-		return nil, err
+	ep.profile.begin(profileWindow)
+	err := ep.addTupleToBuffer(input)
+	if err == nil {
+		err = ep.removeOutdatedTuplesFromBuffer(input.Timestamp)
 	}
-	if err := ep.removeOutdatedTuplesFromBuffer(input.Timestamp); err != nil {
+	ep.profile.end()
+	if err != nil {
 		return nil, err
 	}
 
 	// relation-to-relation:
 	// performs a SELECT query on buffer and writes result
 	// to temporary table
-	if err := ep.filterInputTuples(); err != nil {
+	if len(ep.relations) > 1 {
+		ep.profile.begin(profileJoin)
+	}
+	err = ep.filterInputTuples()
+	if len(ep.relations) > 1 {
+		ep.profile.end()
+	}
+	if err != nil {
 		return nil, err
 	}
 	if err := performQueryOnBuffer(); err != nil {
 		return nil, err
 	}
+	if ep.spill != nil {
+		ep.profile.begin(profileWindow)
+		err := ep.spill.spill()
+		ep.profile.end()
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	// relation-to-stream:
 	// compute new/old/all result data and return it
+	ep.profile.begin(profileEmit)
+	defer ep.profile.end()
 	return ep.computeResultTuples()
 }
 
@@ -445,6 +648,9 @@ func (ep *streamRelationStreamExecutionPlan) filterInputTuples() error {
 	// we append the filtered results to a separate buffer so that
 	// we avoid having to rollback our actual buffer if something fails
 	ep.filteredInputRowsBuffer = list.New()
+	if ep.outerJoin != nil {
+		ep.outerJoin.matched = nil
+	}
 
 	// Note: `ep.buffers` is a map, so iterating over its keys may yield
 	// different results in every run of the program. We cannot expect
@@ -521,6 +727,23 @@ func (ep *streamRelationStreamExecutionPlan) filterInputTuples() error {
 	// (NB. the items appended here will be cleaned up in future
 	// runs by `removeOutdatedTuplesFromBuffer`)
 	ep.filteredInputRows.PushBackList(ep.filteredInputRowsBuffer)
+	if ep.outerJoin != nil {
+		ep.applyMatches()
+		if err := ep.padNewTuples(); err != nil {
+			return err
+		}
+	}
+	if ep.spill != nil {
+		for e := ep.filteredInputRowsBuffer.Front(); e != nil; e = e.Next() {
+			ep.spill.add(e.Value.(*inputRowWithCachedResult))
+		}
+		// The window only has one stream, so the data of the new tuple
+		// isn't used anymore once its rows are computed. Releasing it
+		// leaves rows as the only reference to the data.
+		for _, buffer := range ep.buffers {
+			buffer.tuples.Back().Value.(*tupleWithDerivedInputRows).tuple.Data = nil
+		}
+	}
 	return nil
 }
 
@@ -532,14 +755,36 @@ func (ep *streamRelationStreamExecutionPlan) preprocessCartesianProduct(dataHold
 		map[string]*tupleWithDerivedInputRows{})
 }
 
+// nextBufferToJoin chooses a buffer to be visited next from the remaining
+// buffers. A buffer whose index can be probed with the data of already
+// visited buffers is preferred, and then a buffer having only one tuple,
+// which is usually the newly added tuple. The index is returned when it can
+// be used.
+func (ep *streamRelationStreamExecutionPlan) nextBufferToJoin(remainingBuffers map[string]partialList) (string, *joinIndex) {
+	var single, first string
+	for key, pl := range remainingBuffers {
+		if idx := ep.buffers[key].index(key); idx != nil {
+			if _, ok := remainingBuffers[idx.probeRelation]; !ok {
+				return key, idx
+			}
+		}
+		if pl.start != nil && pl.start.Next() == pl.end && single == "" {
+			single = key
+		}
+		if first == "" {
+			first = key
+		}
+	}
+	if single != "" {
+		return single, nil
+	}
+	return first, nil
+}
+
 func (ep *streamRelationStreamExecutionPlan) preprocCartProdInt(dataHolder data.Map, remainingBuffers map[string]partialList, origin map[string]*tupleWithDerivedInputRows) error {
 	if len(remainingBuffers) > 0 {
 		// not all buffers have been visited yet
-		var myKey string
-		for key := range remainingBuffers {
-			myKey = key
-			break
-		}
+		myKey, idx := ep.nextBufferToJoin(remainingBuffers)
 		myBuffer := remainingBuffers[myKey]
 		// compile a dictionary with the rest of the unvisited streams
 		// (do NOT modify remainingBuffers directly!)
@@ -549,13 +794,27 @@ func (ep *streamRelationStreamExecutionPlan) preprocCartProdInt(dataHolder data.
 				rest[key] = buffer
 			}
 		}
-		for e := myBuffer.start; e != myBuffer.end; e = e.Next() {
-			t := e.Value.(*tupleWithDerivedInputRows)
+		visit := func(t *tupleWithDerivedInputRows) error {
 			// add the data of this tuple to dataHolder and recurse
 			dataHolder[myKey] = t.tuple.Data[myKey]
 			origin[myKey] = t
 			setMetadata(dataHolder, myKey, t.tuple)
-			if err := ep.preprocCartProdInt(dataHolder, rest, origin); err != nil {
+			return ep.preprocCartProdInt(dataHolder, rest, origin)
+		}
+		if idx != nil {
+			// only visit tuples which can satisfy the join condition
+			from, to := myBuffer.seqRange()
+			if ts, ok := idx.candidates(dataHolder, from, to); ok {
+				for _, t := range ts {
+					if err := visit(t); err != nil {
+						return err
+					}
+				}
+				return nil
+			}
+		}
+		for e := myBuffer.start; e != myBuffer.end; e = e.Next() {
+			if err := visit(e.Value.(*tupleWithDerivedInputRows)); err != nil {
 				return err
 			}
 		}
@@ -568,9 +827,24 @@ func (ep *streamRelationStreamExecutionPlan) preprocCartProdInt(dataHolder data.
 		// to each item
 		dataHolder[":meta:NOW"] = data.Timestamp(ep.now)
 
+		// the ON condition of an outer join is evaluated before the
+		// filter because a tuple is padded only when it doesn't have
+		// a match
+		if ep.outerJoin != nil {
+			matched, err := ep.outerJoin.match(dataHolder, origin)
+			if err != nil {
+				return err
+			}
+			if !matched {
+				return nil
+			}
+		}
+
 		// evaluate filter condition
 		if ep.filter != nil {
+			ep.profile.begin(profileFilter)
 			filterResult, err := ep.filter.Eval(dataHolder)
+			ep.profile.end()
 			if err != nil {
 				return err
 			}
@@ -600,6 +874,9 @@ func (ep *streamRelationStreamExecutionPlan) preprocCartProdInt(dataHolder data.
 		itemWithCachedResult := &inputRowWithCachedResult{
 			input: &item,
 		}
+		if ep.lineageEnabled() {
+			itemWithCachedResult.lineage = ep.newInputRowLineage(origin)
+		}
 		// also write the address of this item to all tuples
 		// it originates from
 		for _, tupHolder := range origin {
@@ -13,16 +13,16 @@ func TestMultiplicityHandling(t *testing.T) {
 
 		Convey("and an item/hash pairs", func() {
 			a := resultRow{
-				data.Map{"a": data.Int(5)},
-				data.HashValue(17),
+				row:  data.Map{"a": data.Int(5)},
+				hash: data.HashValue{Low: 17},
 			}
 			b := resultRow{
-				data.Map{"a": data.Int(6)},
-				data.HashValue(17),
+				row:  data.Map{"a": data.Int(6)},
+				hash: data.HashValue{Low: 17},
 			}
 			c := resultRow{
-				data.Map{"a": data.Int(7)},
-				data.HashValue(18),
+				row:  data.Map{"a": data.Int(7)},
+				hash: data.HashValue{Low: 18},
 			}
 
 			Convey("Then adding and counting should work correctly", func() {
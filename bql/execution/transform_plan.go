@@ -45,8 +45,29 @@ type LogicalPlan struct {
 	EmitterSamplingType parser.EmitterSamplingType
 	Projections         []aliasedExpression
 	parser.WindowedFromAST
-	Filter    FlatExpression
-	GroupList []FlatExpression
+	DedupKey FlatExpression
+	// DedupRelation is the alias of the relation referred by DedupKey.
+	DedupRelation string
+	DedupWithin   parser.IntervalAST
+	Filter        FlatExpression
+	// JoinFilter is the ON condition of an outer join, which decides
+	// whether tuples of both relations match, while Filter is applied to
+	// joined rows including ones padded with NULL. It's nil when the
+	// statement doesn't have an outer join. The ON condition of an inner
+	// join is a part of Filter.
+	JoinFilter FlatExpression
+	// EquiJoinKeys are equality conditions in Filter, or in JoinFilter for
+	// an outer join, which are used to index window buffers of a join.
+	EquiJoinKeys []equiJoinKey
+	// SharedWindows maps the alias of a relation of a self-join to the
+	// alias of a preceding relation of the same stream having the same
+	// window. Such relations share one window buffer instead of buffering
+	// the stream twice. It's nil when no buffer is shared.
+	SharedWindows map[string]string
+	GroupList     []FlatExpression
+	// GroupingSets has indexes of GroupList in each set of GROUPING SETS.
+	// It's nil when the statement doesn't have GROUPING SETS.
+	GroupingSets [][]int
 	parser.HavingAST
 }
 
@@ -98,9 +119,24 @@ func Analyze(s parser.SelectStmt, reg udf.FunctionRegistry) (*LogicalPlan, error
 		return nil, err
 	}
 
+	if s.Join != nil && s.Join.Type == parser.InnerJoin {
+		// an inner join is the same as a join with the condition in the
+		// WHERE clause
+		if s.Filter == nil {
+			s.Filter = s.Join.On
+		} else {
+			s.Filter = parser.BinaryOpAST{parser.And, s.Join.On, s.Filter}
+		}
+	}
+
 	return flattenExpressions(&s, reg)
 }
 
+// isOuterJoin returns true when the statement has an outer join.
+func (lp *LogicalPlan) isOuterJoin() bool {
+	return lp.Join != nil && lp.Join.Type != parser.InnerJoin
+}
+
 // isAggregateFunc is a helper function to check if one of
 // the parameters of the given function is an aggregate
 // parameter.
@@ -135,45 +171,7 @@ func flattenExpressions(s *parser.SelectStmt, reg udf.FunctionRegistry) (*Logica
 		if len(aggrs) > 0 {
 			groupingMode = true
 		}
-		// compute column name
-		colHeader := fmt.Sprintf("col_%v", i)
-		switch projType := expr.(type) {
-		case parser.RowMeta:
-			if projType.MetaType == parser.TimestampMeta {
-				colHeader = "ts"
-			}
-		case parser.RowValue:
-			// We can only use the column name as an alias if it is not
-			// a complex JSON Path. For example, `SELECT a` will be treated
-			// like `SELECT a AS a`, but for `SELECT a..b` we will have to
-			// use the col_N form.
-			if simpleColumnNameRe.MatchString(projType.Column) {
-				colHeader = projType.Column
-			}
-		case parser.AliasAST:
-			colHeader = projType.Alias
-		case parser.FuncAppSelectorAST:
-			colHeader = fmt.Sprintf("%s_%d",
-				string(projType.FuncAppAST.Function), i)
-		case parser.FuncAppAST:
-			colHeader = string(projType.Function)
-		case parser.Wildcard:
-			// The wildcard projection (without AS) is very special in that
-			// it is the only case where the BQL user does not determine
-			// the output key names (implicitly or explicitly). The
-			// Evaluator interface is designed such that Evaluator
-			// has 100% control over the returned value, but 0% control
-			// over how it is named, therefore the wildcard evaluation
-			// requires handling in multiple locations.
-			// As a workaround, we will return the complete Map from
-			// the wildcard Evaluator, nest it under a hard-coded key
-			// called "*" and flatten them later (this is done correctly
-			// by the assignOutputValue function).
-			// Note that if it is desired at some point that there are
-			// more evaluators with that behavior, we should change the
-			// Evaluator.Eval interface.
-			colHeader = "*"
-		}
+		colHeader := projectionName(expr, i)
 		flatProjExprs[i] = aliasedExpression{colHeader, flatExpr, aggrs}
 	}
 
@@ -191,6 +189,23 @@ func flattenExpressions(s *parser.SelectStmt, reg udf.FunctionRegistry) (*Logica
 		groupingMode = true
 	}
 
+	var dedupExpr FlatExpression
+	dedupRel := ""
+	if s.DedupKey != nil {
+		for rel := range s.DedupKey.ReferencedRelations() {
+			dedupRel = rel
+		}
+		dedupFlatExpr, err := ParserExprToFlatExpr(s.DedupKey, reg)
+		if err != nil {
+			// return a prettier error message
+			if strings.HasPrefix(err.Error(), "you cannot use aggregate") {
+				err = fmt.Errorf("aggregates not allowed in DEDUPLICATE BY clause")
+			}
+			return nil, err
+		}
+		dedupExpr = dedupFlatExpr
+	}
+
 	var filterExpr FlatExpression
 	if s.Filter != nil {
 		filterFlatExpr, err := ParserExprToFlatExpr(s.Filter, reg)
@@ -203,6 +218,30 @@ func flattenExpressions(s *parser.SelectStmt, reg udf.FunctionRegistry) (*Logica
 		}
 		filterExpr = filterFlatExpr
 	}
+	var joinFilterExpr FlatExpression
+	joinCond := s.Filter
+	if s.Join != nil && s.Join.Type != parser.InnerJoin {
+		flatExpr, err := ParserExprToFlatExpr(s.Join.On, reg)
+		if err != nil {
+			// return a prettier error message
+			if strings.HasPrefix(err.Error(), "you cannot use aggregate") {
+				err = fmt.Errorf("aggregates not allowed in ON clause")
+			}
+			return nil, err
+		}
+		joinFilterExpr = flatExpr
+		// conditions in the WHERE clause cannot be used to find matching
+		// tuples because unmatched tuples are padded with NULL
+		joinCond = s.Join.On
+	}
+	var joinKeys []equiJoinKey
+	if joinCond != nil && len(s.Relations) > 1 {
+		keys, err := extractEquiJoinKeys(joinCond, reg)
+		if err != nil {
+			return nil, err
+		}
+		joinKeys = keys
+	}
 
 	groupCols := make([]rowValue, len(s.GroupList))
 	flatGroupExprs := make([]FlatExpression, len(s.GroupList))
@@ -228,6 +267,22 @@ func flattenExpressions(s *parser.SelectStmt, reg udf.FunctionRegistry) (*Logica
 	}
 	groupingMode = groupingMode || len(flatGroupExprs) > 0
 
+	var groupingSets [][]int
+	if s.GroupingSets != nil {
+		idx := make(map[string]int, len(s.GroupList))
+		for i, expr := range s.GroupList {
+			idx[expr.String()] = i
+		}
+		groupingSets = make([][]int, len(s.GroupingSets))
+		for i, set := range s.GroupingSets {
+			groupingSets[i] = make([]int, len(set))
+			for j, expr := range set {
+				groupingSets[i][j] = idx[expr.String()]
+			}
+		}
+		groupingMode = true
+	}
+
 	// check if grouping is done correctly
 	if groupingMode {
 		for _, expr := range flatProjExprs {
@@ -319,12 +374,116 @@ func flattenExpressions(s *parser.SelectStmt, reg udf.FunctionRegistry) (*Logica
 		emitSamplingType,
 		flatProjExprs,
 		s.WindowedFromAST,
+		dedupExpr,
+		dedupRel,
+		s.Within,
 		filterExpr,
+		joinFilterExpr,
+		joinKeys,
+		findSharedWindows(s),
 		flatGroupExprs,
+		groupingSets,
 		s.HavingAST,
 	}, nil
 }
 
+// projectionName returns the name of the field to which the i-th projection
+// of a SELECT statement is assigned.
+func projectionName(expr parser.Expression, i int) string {
+	colHeader := fmt.Sprintf("col_%v", i)
+	switch projType := expr.(type) {
+	case parser.RowMeta:
+		if projType.MetaType == parser.TimestampMeta {
+			colHeader = "ts"
+		} else if projType.MetaType == parser.IDMeta {
+			colHeader = "tuple_id"
+		} else if projType.MetaType == parser.BackfillMeta {
+			colHeader = "is_backfill"
+		}
+	case parser.RowValue:
+		// We can only use the column name as an alias if it is not
+		// a complex JSON Path. For example, `SELECT a` will be treated
+		// like `SELECT a AS a`, but for `SELECT a..b` we will have to
+		// use the col_N form.
+		if simpleColumnNameRe.MatchString(projType.Column) {
+			colHeader = projType.Column
+		}
+	case parser.AliasAST:
+		colHeader = projType.Alias
+	case parser.FuncAppSelectorAST:
+		colHeader = fmt.Sprintf("%s_%d",
+			string(projType.FuncAppAST.Function), i)
+	case parser.FuncAppAST:
+		colHeader = string(projType.Function)
+	case parser.Wildcard:
+		// The wildcard projection (without AS) is very special in that
+		// it is the only case where the BQL user does not determine
+		// the output key names (implicitly or explicitly). The
+		// Evaluator interface is designed such that Evaluator
+		// has 100% control over the returned value, but 0% control
+		// over how it is named, therefore the wildcard evaluation
+		// requires handling in multiple locations.
+		// As a workaround, we will return the complete Map from
+		// the wildcard Evaluator, nest it under a hard-coded key
+		// called "*" and flatten them later (this is done correctly
+		// by the assignOutputValue function).
+		// Note that if it is desired at some point that there are
+		// more evaluators with that behavior, we should change the
+		// Evaluator.Eval interface.
+		colHeader = "*"
+	}
+	return colHeader
+}
+
+// OutputFields returns names of fields which tuples emitted by the SELECT
+// statement have. Nested fields are represented by JSON Paths given as
+// aliases like "a.b". It returns false when the names cannot be known
+// without evaluating the statement, i.e. it has a wildcard without an alias.
+func OutputFields(s *parser.SelectStmt) ([]string, bool) {
+	names := make([]string, len(s.Projections))
+	for i, expr := range s.Projections {
+		names[i] = projectionName(expr, i)
+		if names[i] == "*" {
+			return nil, false
+		}
+	}
+	return names, true
+}
+
+// findSharedWindows finds relations of a self-join which can share a window
+// buffer, that is, relations of the same stream having identical RANGE
+// clauses. Relations of an outer join don't share buffers because each side
+// keeps its own state of matches in the tuples of its buffer.
+func findSharedWindows(s *parser.SelectStmt) map[string]string {
+	if len(s.Relations) < 2 || (s.Join != nil && s.Join.Type != parser.InnerJoin) {
+		return nil
+	}
+	shareable := func(rel *parser.AliasedStreamWindowAST) bool {
+		return rel.Type == parser.ActualStream && rel.Function == nil
+	}
+
+	var shared map[string]string
+	for i := range s.Relations {
+		rel := &s.Relations[i]
+		if !shareable(rel) {
+			continue
+		}
+		// the first matching relation always owns the buffer because
+		// it cannot match any relation before it
+		for j := 0; j < i; j++ {
+			owner := &s.Relations[j]
+			if shareable(owner) && owner.Name == rel.Name && owner.IntervalAST == rel.IntervalAST {
+				if shared == nil {
+					shared = map[string]string{}
+				}
+				shared[rel.Alias] = owner.Alias
+				break
+			}
+		}
+	}
+	return shared
+}
+
 // makeRelationAliases will assign an internal alias to every relation
 // does not yet have one (given by the user). It will also detect if
 // there is a conflict between aliases.
@@ -372,11 +531,21 @@ func validateReferences(s *parser.SelectStmt) error {
 			refRels[rel] = true
 		}
 	}
+	if s.DedupKey != nil {
+		for rel := range s.DedupKey.ReferencedRelations() {
+			refRels[rel] = true
+		}
+	}
 	if s.Filter != nil {
 		for rel := range s.Filter.ReferencedRelations() {
 			refRels[rel] = true
 		}
 	}
+	if s.Join != nil {
+		for rel := range s.Join.On.ReferencedRelations() {
+			refRels[rel] = true
+		}
+	}
 	for _, group := range s.GroupList {
 		for rel := range group.ReferencedRelations() {
 			refRels[rel] = true
@@ -415,6 +584,9 @@ func validateReferences(s *parser.SelectStmt) error {
 				newProjs[i] = proj.RenameReferencedRelation("", inputRel)
 			}
 			s.Projections = newProjs
+			if s.DedupKey != nil {
+				s.DedupKey = s.DedupKey.RenameReferencedRelation("", inputRel)
+			}
 			if s.Filter != nil {
 				s.Filter = s.Filter.RenameReferencedRelation("", inputRel)
 			}
@@ -423,6 +595,16 @@ func validateReferences(s *parser.SelectStmt) error {
 				newGroup[i] = group.RenameReferencedRelation("", inputRel)
 			}
 			s.GroupList = newGroup
+			if s.GroupingSets != nil {
+				newSets := make([][]parser.Expression, len(s.GroupingSets))
+				for i, set := range s.GroupingSets {
+					newSets[i] = make([]parser.Expression, len(set))
+					for j, group := range set {
+						newSets[i][j] = group.RenameReferencedRelation("", inputRel)
+					}
+				}
+				s.GroupingSets = newSets
+			}
 			if s.Having != nil {
 				s.Having = s.Having.RenameReferencedRelation("", inputRel)
 			}
@@ -466,9 +648,26 @@ func validateReferences(s *parser.SelectStmt) error {
 		}
 		// if we arrive here, all referenced relations exist in the
 		// FROM clause -> OK
+
+		// the key of DEDUPLICATE BY is computed from a single input
+		// tuple, so it has to refer to exactly one relation
+		if s.DedupKey != nil && len(s.DedupKey.ReferencedRelations()) != 1 {
+			err := fmt.Errorf("DEDUPLICATE BY clause must refer to " +
+				"exactly one relation when using multiple input relations")
+			return err
+		}
 	}
 
 	for _, rel := range s.Relations {
+		if rel.Function != nil && len(s.Relations) > 1 {
+			return fmt.Errorf("%v cannot be used with multiple input relations",
+				rel.Function.Type)
+		}
+		if rel.Function != nil && rel.Function.Type == parser.HopWindow {
+			if err := validateWindowInterval(rel.Function.Slide); err != nil {
+				return err
+			}
+		}
 		if rel.Value <= 0 {
 			err := fmt.Errorf("number in RANGE clause must be positive, not %v", rel.Value)
 			return err
@@ -502,6 +701,56 @@ func validateReferences(s *parser.SelectStmt) error {
 		}
 	}
 
+	if s.DedupKey != nil {
+		if err := validateDeduplicateInterval(s.Within); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateDeduplicateInterval checks the interval in a DEDUPLICATE BY
+// clause. The same limits as for RANGE are applied.
+func validateDeduplicateInterval(i parser.IntervalAST) error {
+	if i.Value <= 0 {
+		return fmt.Errorf("number in WITHIN clause must be positive, not %v", i.Value)
+	}
+	switch i.Unit {
+	case parser.Tuples:
+		if math.Trunc(i.Value) != i.Value {
+			return fmt.Errorf("number in WITHIN clause must be integral "+
+				"for TUPLES, not %v", i.Value)
+		}
+		if i.Value > MaxRangeTuples {
+			return fmt.Errorf("WITHIN value %d is too large for TUPLES (must be at most %d)",
+				int64(i.Value), int64(MaxRangeTuples))
+		}
+	case parser.Seconds:
+		if i.Value > MaxRangeSec {
+			return fmt.Errorf("WITHIN value %v is too large for SECONDS (must be at most %d)",
+				i.Value, int64(MaxRangeSec))
+		}
+	case parser.Milliseconds:
+		if i.Value > MaxRangeMillisec {
+			return fmt.Errorf("WITHIN value %v is too large for MILLISECONDS (must be at most %d)",
+				i.Value, int64(MaxRangeMillisec))
+		}
+	}
+	return nil
+}
+
+// validateWindowInterval checks the slide of a HOP window function. The same
+// limits as for RANGE are applied.
+func validateWindowInterval(i parser.IntervalAST) error {
+	if i.Value <= 0 {
+		return fmt.Errorf("slide of HOP must be positive, not %v", i.Value)
+	}
+	if (i.Unit == parser.Seconds && i.Value > MaxRangeSec) ||
+		(i.Unit == parser.Milliseconds && i.Value > MaxRangeMillisec) {
+		return fmt.Errorf("slide of HOP %v %v is too large (must be at most %d SECONDS)",
+			i.Value, i.Unit, int64(MaxRangeSec))
+	}
 	return nil
 }
 
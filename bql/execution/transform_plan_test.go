@@ -48,13 +48,15 @@ func TestRelationChecker(t *testing.T) {
 	r := parser.IntervalAST{parser.FloatLiteral{2}, parser.Tuples}
 	singleFrom := parser.WindowedFromAST{
 		[]parser.AliasedStreamWindowAST{
-			{parser.StreamWindowAST{parser.Stream{parser.ActualStream, "t", nil}, r, 0, parser.Wait}, ""},
+			{parser.StreamWindowAST{parser.Stream{parser.ActualStream, "t", nil}, r, 0, parser.Wait, nil}, ""},
 		},
+		nil,
 	}
 	singleFromAlias := parser.WindowedFromAST{
 		[]parser.AliasedStreamWindowAST{
-			{parser.StreamWindowAST{parser.Stream{parser.ActualStream, "s", nil}, r, 0, parser.Wait}, "t"},
+			{parser.StreamWindowAST{parser.Stream{parser.ActualStream, "s", nil}, r, 0, parser.Wait, nil}, "t"},
 		},
+		nil,
 	}
 	two := parser.NumericLiteral{2}
 	a := parser.RowValue{"", "a"}
@@ -299,79 +301,79 @@ func TestRelationChecker(t *testing.T) {
 		{&parser.SelectStmt{
 			ProjectionsAST:  parser.ProjectionsAST{[]parser.Expression{a}},
 			WindowedFromAST: singleFrom,
-			GroupingAST:     parser.GroupingAST{[]parser.Expression{two}},
+			GroupingAST:     parser.GroupingAST{GroupList: []parser.Expression{two}},
 		}, ""},
 		// SELECT 2   FROM t GROUP BY 2        -> OK
 		{&parser.SelectStmt{
 			ProjectionsAST:  parser.ProjectionsAST{[]parser.Expression{two}},
 			WindowedFromAST: singleFrom,
-			GroupingAST:     parser.GroupingAST{[]parser.Expression{two}},
+			GroupingAST:     parser.GroupingAST{GroupList: []parser.Expression{two}},
 		}, ""},
 		// SELECT t:a FROM t GROUP BY 2        -> OK
 		{&parser.SelectStmt{
 			ProjectionsAST:  parser.ProjectionsAST{[]parser.Expression{tA}},
 			WindowedFromAST: singleFrom,
-			GroupingAST:     parser.GroupingAST{[]parser.Expression{two}},
+			GroupingAST:     parser.GroupingAST{GroupList: []parser.Expression{two}},
 		}, ""},
 		// SELECT a   FROM t GROUP BY b        -> OK
 		{&parser.SelectStmt{
 			ProjectionsAST:  parser.ProjectionsAST{[]parser.Expression{a}},
 			WindowedFromAST: singleFrom,
-			GroupingAST:     parser.GroupingAST{[]parser.Expression{b}},
+			GroupingAST:     parser.GroupingAST{GroupList: []parser.Expression{b}},
 		}, ""},
 		// SELECT a   FROM t GROUP BY b, c     -> OK
 		{&parser.SelectStmt{
 			ProjectionsAST:  parser.ProjectionsAST{[]parser.Expression{a}},
 			WindowedFromAST: singleFrom,
-			GroupingAST:     parser.GroupingAST{[]parser.Expression{b, c}},
+			GroupingAST:     parser.GroupingAST{GroupList: []parser.Expression{b, c}},
 		}, ""},
 		// SELECT 2   FROM t GROUP BY b        -> OK
 		{&parser.SelectStmt{
 			ProjectionsAST:  parser.ProjectionsAST{[]parser.Expression{two}},
 			WindowedFromAST: singleFrom,
-			GroupingAST:     parser.GroupingAST{[]parser.Expression{b}},
+			GroupingAST:     parser.GroupingAST{GroupList: []parser.Expression{b}},
 		}, ""},
 		// SELECT t:a FROM t GROUP BY b        -> NG
 		{&parser.SelectStmt{
 			ProjectionsAST:  parser.ProjectionsAST{[]parser.Expression{tA}},
 			WindowedFromAST: singleFrom,
-			GroupingAST:     parser.GroupingAST{[]parser.Expression{b}},
+			GroupingAST:     parser.GroupingAST{GroupList: []parser.Expression{b}},
 		}, "cannot refer to relations"},
 		// SELECT a   FROM t GROUP BY t:b      -> NG
 		{&parser.SelectStmt{
 			ProjectionsAST:  parser.ProjectionsAST{[]parser.Expression{a}},
 			WindowedFromAST: singleFrom,
-			GroupingAST:     parser.GroupingAST{[]parser.Expression{tB}},
+			GroupingAST:     parser.GroupingAST{GroupList: []parser.Expression{tB}},
 		}, "cannot refer to relations"},
 		// SELECT 2   FROM t GROUP BY t:b      -> OK
 		{&parser.SelectStmt{
 			ProjectionsAST:  parser.ProjectionsAST{[]parser.Expression{two}},
 			WindowedFromAST: singleFrom,
-			GroupingAST:     parser.GroupingAST{[]parser.Expression{tB}},
+			GroupingAST:     parser.GroupingAST{GroupList: []parser.Expression{tB}},
 		}, ""},
 		// SELECT t:a FROM t GROUP BY t:b      -> OK
 		{&parser.SelectStmt{
 			ProjectionsAST:  parser.ProjectionsAST{[]parser.Expression{tA}},
 			WindowedFromAST: singleFrom,
-			GroupingAST:     parser.GroupingAST{[]parser.Expression{tB}},
+			GroupingAST:     parser.GroupingAST{GroupList: []parser.Expression{tB}},
 		}, ""},
 		// SELECT t:a FROM t GROUP BY t:b, t:c -> OK
 		{&parser.SelectStmt{
 			ProjectionsAST:  parser.ProjectionsAST{[]parser.Expression{tA}},
 			WindowedFromAST: singleFrom,
-			GroupingAST:     parser.GroupingAST{[]parser.Expression{tB, tC}},
+			GroupingAST:     parser.GroupingAST{GroupList: []parser.Expression{tB, tC}},
 		}, ""},
 		// SELECT t:a FROM t GROUP BY b, t:b   -> NG (same table with multiple aliases)
 		{&parser.SelectStmt{
 			ProjectionsAST:  parser.ProjectionsAST{[]parser.Expression{tA}},
 			WindowedFromAST: singleFrom,
-			GroupingAST:     parser.GroupingAST{[]parser.Expression{b, tB}},
+			GroupingAST:     parser.GroupingAST{GroupList: []parser.Expression{b, tB}},
 		}, "cannot refer to relations"},
 		// SELECT 2   FROM t GROUP BY x:b      -> NG
 		{&parser.SelectStmt{
 			ProjectionsAST:  parser.ProjectionsAST{[]parser.Expression{two}},
 			WindowedFromAST: singleFrom,
-			GroupingAST:     parser.GroupingAST{[]parser.Expression{xB}},
+			GroupingAST:     parser.GroupingAST{GroupList: []parser.Expression{xB}},
 		}, "cannot refer to relation 'x' when using only 't'"},
 
 		////////// HAVING //////////////
@@ -534,52 +536,52 @@ func TestRelationAliasing(t *testing.T) {
 			ProjectionsAST: proj,
 			WindowedFromAST: parser.WindowedFromAST{
 				[]parser.AliasedStreamWindowAST{
-					{parser.StreamWindowAST{parser.Stream{parser.ActualStream, "a", nil}, r, 0, parser.Wait}, ""},
-				}},
+					{parser.StreamWindowAST{parser.Stream{parser.ActualStream, "a", nil}, r, 0, parser.Wait, nil}, ""},
+				}, nil},
 		}, ""},
 		// SELECT 2 FROM a AS b         -> OK
 		{&parser.SelectStmt{
 			ProjectionsAST: proj,
 			WindowedFromAST: parser.WindowedFromAST{
 				[]parser.AliasedStreamWindowAST{
-					{parser.StreamWindowAST{parser.Stream{parser.ActualStream, "a", nil}, r, 0, parser.Wait}, "b"},
-				}},
+					{parser.StreamWindowAST{parser.Stream{parser.ActualStream, "a", nil}, r, 0, parser.Wait, nil}, "b"},
+				}, nil},
 		}, ""},
 		// SELECT 2 FROM a AS b, a      -> OK
 		{&parser.SelectStmt{
 			ProjectionsAST: proj,
 			WindowedFromAST: parser.WindowedFromAST{
 				[]parser.AliasedStreamWindowAST{
-					{parser.StreamWindowAST{parser.Stream{parser.ActualStream, "a", nil}, r, 0, parser.Wait}, "b"},
-					{parser.StreamWindowAST{parser.Stream{parser.ActualStream, "a", nil}, r, 0, parser.Wait}, ""},
-				}},
+					{parser.StreamWindowAST{parser.Stream{parser.ActualStream, "a", nil}, r, 0, parser.Wait, nil}, "b"},
+					{parser.StreamWindowAST{parser.Stream{parser.ActualStream, "a", nil}, r, 0, parser.Wait, nil}, ""},
+				}, nil},
 		}, ""},
 		// SELECT 2 FROM a AS b, c AS a -> OK
 		{&parser.SelectStmt{
 			ProjectionsAST: proj,
 			WindowedFromAST: parser.WindowedFromAST{
 				[]parser.AliasedStreamWindowAST{
-					{parser.StreamWindowAST{parser.Stream{parser.ActualStream, "a", nil}, r, 0, parser.Wait}, "b"},
-					{parser.StreamWindowAST{parser.Stream{parser.ActualStream, "c", nil}, r, 0, parser.Wait}, "a"},
-				}},
+					{parser.StreamWindowAST{parser.Stream{parser.ActualStream, "a", nil}, r, 0, parser.Wait, nil}, "b"},
+					{parser.StreamWindowAST{parser.Stream{parser.ActualStream, "c", nil}, r, 0, parser.Wait, nil}, "a"},
+				}, nil},
 		}, ""},
 		// SELECT 2 FROM a, a           -> NG
 		{&parser.SelectStmt{
 			ProjectionsAST: proj,
 			WindowedFromAST: parser.WindowedFromAST{
 				[]parser.AliasedStreamWindowAST{
-					{parser.StreamWindowAST{parser.Stream{parser.ActualStream, "a", nil}, r, 0, parser.Wait}, ""},
-					{parser.StreamWindowAST{parser.Stream{parser.ActualStream, "a", nil}, r, 0, parser.Wait}, ""},
-				}},
+					{parser.StreamWindowAST{parser.Stream{parser.ActualStream, "a", nil}, r, 0, parser.Wait, nil}, ""},
+					{parser.StreamWindowAST{parser.Stream{parser.ActualStream, "a", nil}, r, 0, parser.Wait, nil}, ""},
+				}, nil},
 		}, "cannot use relations"},
 		// SELECT 2 FROM a, b AS a      -> NG
 		{&parser.SelectStmt{
 			ProjectionsAST: proj,
 			WindowedFromAST: parser.WindowedFromAST{
 				[]parser.AliasedStreamWindowAST{
-					{parser.StreamWindowAST{parser.Stream{parser.ActualStream, "a", nil}, r, 0, parser.Wait}, ""},
-					{parser.StreamWindowAST{parser.Stream{parser.ActualStream, "b", nil}, r, 0, parser.Wait}, "a"},
-				}},
+					{parser.StreamWindowAST{parser.Stream{parser.ActualStream, "a", nil}, r, 0, parser.Wait, nil}, ""},
+					{parser.StreamWindowAST{parser.Stream{parser.ActualStream, "b", nil}, r, 0, parser.Wait, nil}, "a"},
+				}, nil},
 		}, "cannot use relations"},
 	}
 
@@ -1002,6 +1004,82 @@ func TestRangeChecker(t *testing.T) {
 	}
 }
 
+func TestSharedWindows(t *testing.T) {
+	reg := udf.CopyGlobalUDFRegistry(core.NewContext(nil))
+
+	testCases := []struct {
+		bql    string
+		shared map[string]string
+	}{
+		{"a:x FROM x [RANGE 2 TUPLES] AS a, x [RANGE 2 TUPLES] AS b", map[string]string{"b": "a"}},
+		{"a:x FROM x [RANGE 2 TUPLES] AS a, x [RANGE 2 TUPLES] AS b, x [RANGE 2 TUPLES] AS c",
+			map[string]string{"b": "a", "c": "a"}},
+		{"a:x FROM x [RANGE 2 TUPLES] AS a, x [RANGE 3 TUPLES] AS b, x [RANGE 2 TUPLES] AS c",
+			map[string]string{"c": "a"}},
+		{"a:x FROM x [RANGE 2 TUPLES] AS a, x [RANGE 2 SECONDS] AS b", nil},
+		{"a:x FROM x [RANGE 2 TUPLES] AS a, y [RANGE 2 TUPLES] AS b", nil},
+		{"a:x FROM x [RANGE 2 TUPLES] AS a JOIN x [RANGE 2 TUPLES] AS b ON a:x = b:x",
+			map[string]string{"b": "a"}},
+		{"a:x FROM x [RANGE 2 TUPLES] AS a LEFT JOIN x [RANGE 2 TUPLES] AS b ON a:x = b:x", nil},
+		{"a:x FROM x [RANGE 2 TUPLES] AS a", nil},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		Convey(fmt.Sprintf("Given the statement %v", testCase.bql), t, func() {
+			p := parser.New()
+			stmt := "CREATE STREAM x AS SELECT ISTREAM " + testCase.bql
+			astUnchecked, _, err := p.ParseStmt(stmt)
+			So(err, ShouldBeNil)
+			ast := astUnchecked.(parser.CreateStreamAsSelectStmt).Select
+
+			Convey("When we analyze it", func() {
+				lp, err := Analyze(ast, reg)
+				So(err, ShouldBeNil)
+
+				Convey("Then relations sharing windows should be found", func() {
+					So(lp.SharedWindows, ShouldResemble, testCase.shared)
+				})
+			})
+		})
+	}
+}
+
+func TestOutputFields(t *testing.T) {
+	testCases := []struct {
+		bql    string
+		fields []string
+	}{
+		{"a, b FROM x [RANGE 1 TUPLES]", []string{"a", "b"}},
+		{"a + 1, b AS c, x:d FROM x [RANGE 1 TUPLES]", []string{"col_0", "c", "d"}},
+		{"count(a), now(), ts(), a.b FROM x [RANGE 1 TUPLES]", []string{"count", "now", "ts", "col_3"}},
+		{"* AS all FROM x [RANGE 1 TUPLES]", []string{"all"}},
+		{"*, a FROM x [RANGE 1 TUPLES]", nil},
+		{"x:* FROM x [RANGE 1 TUPLES]", nil},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		Convey(fmt.Sprintf("Given the statement %v", testCase.bql), t, func() {
+			p := parser.New()
+			stmt, _, err := p.ParseStmt("SELECT ISTREAM " + testCase.bql)
+			So(err, ShouldBeNil)
+			s := stmt.(parser.SelectStmt)
+
+			Convey("When getting output fields", func() {
+				fields, ok := OutputFields(&s)
+
+				Convey("Then they should be the names of projections", func() {
+					So(ok, ShouldEqual, testCase.fields != nil)
+					So(fields, ShouldResemble, testCase.fields)
+				})
+			})
+		})
+	}
+}
+
 func TestVolatileAggregateChecker(t *testing.T) {
 	reg := udf.CopyGlobalUDFRegistry(core.NewContext(nil))
 
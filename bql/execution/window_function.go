@@ -0,0 +1,317 @@
+package execution
+
+import (
+	"container/list"
+	"fmt"
+	"time"
+
+	"gopkg.in/sensorbee/sensorbee.v0/bql/parser"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// windowFunction assigns tuples to windows of a TUMBLE, HOP, or SESSION
+// window function by their event time. Unlike a window given by a RANGE
+// clause, the query is performed once for each window when the window is
+// closed, i.e. when a tuple having an event time after the end of the window
+// arrives. Each row of a window has "window_start" and "window_end" columns
+// so that they can be used in the query, e.g. in the GROUP BY clause.
+//
+// Tuples are expected to arrive roughly in the order of their event time.
+// A tuple arriving after all windows it belongs to are closed is dropped.
+// Sessions aren't partitioned by a key: all tuples of the stream belong to
+// the same session as long as the gap between them is shorter than the
+// session gap.
+type windowFunction struct {
+	typ parser.WindowFunctionType
+	// timeColumn is the path to the event time in a tuple. The timestamp
+	// of a tuple is used when it's nil.
+	timeColumn data.Path
+	// size is the size of a window for TUMBLE and HOP, and the gap of
+	// sessions for SESSION.
+	size time.Duration
+	// slide is the interval between starts of windows. It's the same as
+	// size for TUMBLE.
+	slide time.Duration
+
+	// watermark is the largest event time observed so far.
+	watermark time.Time
+	// nextEnd is the end of the next window to be closed for TUMBLE and
+	// HOP. It's zero until the first tuple arrives.
+	nextEnd time.Time
+	// sessionStart and sessionEnd are the range of the current session.
+	// sessionEnd is the event time of the latest tuple plus the gap.
+	sessionStart time.Time
+	sessionEnd   time.Time
+	// closedEnd is the end of the last closed session.
+	closedEnd time.Time
+}
+
+// timeWindow is the range [start, end) of a window.
+type timeWindow struct {
+	start time.Time
+	end   time.Time
+}
+
+func newWindowFunction(rel *parser.AliasedStreamWindowAST) (*windowFunction, error) {
+	w := &windowFunction{
+		typ:  rel.Function.Type,
+		size: intervalToDuration(rel.IntervalAST),
+	}
+	w.slide = w.size
+	if w.typ == parser.HopWindow {
+		w.slide = intervalToDuration(rel.Function.Slide)
+	}
+	if w.size <= 0 || w.slide <= 0 {
+		return nil, fmt.Errorf("intervals of %v must be positive", w.typ)
+	}
+	if rel.Function.TimeColumn != "" {
+		p, err := data.CompilePath(rel.Function.TimeColumn)
+		if err != nil {
+			return nil, err
+		}
+		w.timeColumn = p
+	}
+	return w, nil
+}
+
+func intervalToDuration(i parser.IntervalAST) time.Duration {
+	d := time.Duration(i.Value * float64(time.Second))
+	if i.Unit == parser.Milliseconds {
+		d = time.Duration(i.Value * float64(time.Millisecond))
+	}
+	return d
+}
+
+// eventTime returns the event time of the tuple.
+func (w *windowFunction) eventTime(t *core.Tuple) (time.Time, error) {
+	if w.timeColumn == nil {
+		return t.Timestamp, nil
+	}
+	v, err := t.Data.Get(w.timeColumn)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("cannot get the time column of %v: %v", w.typ, err)
+	}
+	if v.Type() == data.TypeNull {
+		return time.Time{}, fmt.Errorf("the time column of %v is null", w.typ)
+	}
+	return data.ToTimestamp(v)
+}
+
+// firstWindowEnd returns the end of the first window having the time. Windows
+// are aligned to multiples of the slide.
+func (w *windowFunction) firstWindowEnd(t time.Time) time.Time {
+	return t.Add(-w.size).Truncate(w.slide).Add(w.slide + w.size)
+}
+
+// isLate returns true when all windows the time belongs to are closed.
+func (w *windowFunction) isLate(t time.Time) bool {
+	if w.typ == parser.SessionWindow {
+		return !w.closedEnd.IsZero() && t.Before(w.closedEnd)
+	}
+	return !w.nextEnd.IsZero() && t.Before(w.nextEnd.Add(-w.size))
+}
+
+// processWindowFunction adds the tuple to the window and performs the query
+// on each window closed by the tuple.
+func (ep *streamRelationStreamExecutionPlan) processWindowFunction(input *core.Tuple, performQueryOnBuffer func() error) ([]data.Map, error) {
+	w := ep.window
+	ts, err := w.eventTime(input)
+	if err != nil {
+		return nil, err
+	}
+	if w.isLate(ts) {
+		return nil, nil
+	}
+
+	var output []data.Map
+	if w.typ == parser.SessionWindow && !w.sessionEnd.IsZero() && !ts.Before(w.sessionEnd) {
+		// the tuple starts a new session, so the current session has to
+		// be closed before the tuple is added
+		tw := timeWindow{w.sessionStart, w.sessionEnd}
+		res, err := ep.performQueryOnWindow(tw, performQueryOnBuffer)
+		if err != nil {
+			return nil, err
+		}
+		output = res
+		ep.profile.begin(profileWindow)
+		ep.removeTuplesBefore(tw.end)
+		ep.profile.end()
+		w.closedEnd = tw.end
+		w.sessionEnd = time.Time{}
+	}
+
+	ep.profile.begin(profileWindow)
+	err = ep.addTupleToBuffer(input)
+	ep.profile.end()
+	if err != nil {
+		return nil, err
+	}
+	for _, buffer := range ep.buffers {
+		buffer.tuples.Back().Value.(*tupleWithDerivedInputRows).eventTime = ts
+	}
+	if ts.After(w.watermark) {
+		w.watermark = ts
+	}
+
+	if w.typ == parser.SessionWindow {
+		if w.sessionEnd.IsZero() || ts.Before(w.sessionStart) {
+			w.sessionStart = ts
+		}
+		if end := ts.Add(w.size); end.After(w.sessionEnd) {
+			w.sessionEnd = end
+		}
+		return output, nil
+	}
+
+	for {
+		earliest, ok := ep.earliestEventTime()
+		if !ok {
+			break
+		}
+		// skip windows not having any tuple
+		if end := w.firstWindowEnd(earliest); end.After(w.nextEnd) {
+			w.nextEnd = end
+		}
+		if w.nextEnd.After(w.watermark) {
+			break
+		}
+		res, err := ep.performQueryOnWindow(timeWindow{w.nextEnd.Add(-w.size), w.nextEnd}, performQueryOnBuffer)
+		if err != nil {
+			return nil, err
+		}
+		output = append(output, res...)
+		w.nextEnd = w.nextEnd.Add(w.slide)
+		ep.profile.begin(profileWindow)
+		ep.removeTuplesBefore(w.nextEnd.Add(-w.size))
+		ep.profile.end()
+	}
+	return output, nil
+}
+
+// earliestEventTime returns the earliest event time of tuples in the buffer.
+// It returns false when the buffer is empty.
+func (ep *streamRelationStreamExecutionPlan) earliestEventTime() (time.Time, bool) {
+	var (
+		earliest time.Time
+		found    bool
+	)
+	for _, buffer := range ep.buffers {
+		for e := buffer.tuples.Front(); e != nil; e = e.Next() {
+			t := e.Value.(*tupleWithDerivedInputRows).eventTime
+			if !found || t.Before(earliest) {
+				earliest = t
+				found = true
+			}
+		}
+	}
+	return earliest, found
+}
+
+// removeTuplesBefore removes tuples having an event time before t from the
+// buffer.
+func (ep *streamRelationStreamExecutionPlan) removeTuplesBefore(t time.Time) {
+	for _, buffer := range ep.buffers {
+		var next *list.Element
+		for e := buffer.tuples.Front(); e != nil; e = next {
+			next = e.Next()
+			if e.Value.(*tupleWithDerivedInputRows).eventTime.Before(t) {
+				buffer.tuples.Remove(e)
+			}
+		}
+	}
+}
+
+// performQueryOnWindow performs the query on tuples in the window and returns
+// results to be emitted. It returns nothing when the window doesn't have any
+// tuple.
+func (ep *streamRelationStreamExecutionPlan) performQueryOnWindow(tw timeWindow, performQueryOnBuffer func() error) ([]data.Map, error) {
+	ep.profile.begin(profileWindow)
+	rows, numTuples, err := ep.windowRows(tw)
+	ep.profile.end()
+	if err != nil {
+		return nil, err
+	}
+	if numTuples == 0 {
+		return nil, nil
+	}
+
+	ep.filteredInputRows = rows
+	if err := performQueryOnBuffer(); err != nil {
+		return nil, err
+	}
+	ep.filteredInputRows = list.New()
+	ep.profile.begin(profileEmit)
+	defer ep.profile.end()
+	return ep.computeResultTuples()
+}
+
+// windowRows returns input rows of tuples in the window satisfying the filter
+// and the number of tuples in the window.
+func (ep *streamRelationStreamExecutionPlan) windowRows(tw timeWindow) (*list.List, int, error) {
+	rows := list.New()
+	numTuples := 0
+	for alias, buffer := range ep.buffers {
+		for e := buffer.tuples.Front(); e != nil; e = e.Next() {
+			t := e.Value.(*tupleWithDerivedInputRows)
+			if t.eventTime.Before(tw.start) || !t.eventTime.Before(tw.end) {
+				continue
+			}
+			numTuples++
+			row, err := ep.newWindowRow(alias, t, tw)
+			if err != nil {
+				return nil, 0, err
+			}
+			if row != nil {
+				rows.PushBack(row)
+			}
+		}
+	}
+	return rows, numTuples, nil
+}
+
+// newWindowRow creates an input row of the tuple having columns of the window.
+// It returns nil when the row doesn't satisfy the filter.
+func (ep *streamRelationStreamExecutionPlan) newWindowRow(alias string, t *tupleWithDerivedInputRows, tw timeWindow) (*inputRowWithCachedResult, error) {
+	orig, err := data.AsMap(t.tuple.Data[alias])
+	if err != nil {
+		return nil, err
+	}
+	m := make(data.Map, len(orig)+2)
+	for k, v := range orig {
+		m[k] = v
+	}
+	m["window_start"] = data.Timestamp(tw.start.In(time.UTC))
+	m["window_end"] = data.Timestamp(tw.end.In(time.UTC))
+
+	item := data.Map{alias: m}
+	setMetadata(item, alias, t.tuple)
+	item[":meta:NOW"] = data.Timestamp(ep.now)
+	if ep.filter != nil {
+		ep.profile.begin(profileFilter)
+		res, err := ep.filter.Eval(item)
+		ep.profile.end()
+		if err != nil {
+			return nil, err
+		}
+		// NULL is treated as false as the filter of a RANGE window
+		if res.Type() == data.TypeNull {
+			return nil, nil
+		}
+		ok, err := data.AsBool(res)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, nil
+		}
+	}
+
+	row := &inputRowWithCachedResult{
+		input: &item,
+	}
+	if ep.lineageEnabled() {
+		row.lineage = ep.newInputRowLineage(map[string]*tupleWithDerivedInputRows{alias: t})
+	}
+	return row, nil
+}
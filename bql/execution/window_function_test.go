@@ -0,0 +1,121 @@
+package execution
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+func TestWindowFunction(t *testing.T) {
+	at := func(sec int) data.Timestamp {
+		return data.Timestamp(time.Date(2015, time.April, 10, 10, 23, sec, 0, time.UTC))
+	}
+
+	Convey("Given a TUMBLE window function with GROUP BY", t, func() {
+		s := `CREATE STREAM box AS SELECT RSTREAM window_start, window_end, count(*) AS c, sum(int) AS s
+			FROM TUMBLE(src, ts(), INTERVAL '2' SECONDS) GROUP BY window_start, window_end`
+		plan, err := createGroupbyPlan(s, t)
+		So(err, ShouldBeNil)
+
+		Convey("When feeding it with tuples", func() {
+			var out [][]data.Map
+			for _, t := range getTuples(6) {
+				res, err := plan.Process(t)
+				So(err, ShouldBeNil)
+				out = append(out, res)
+			}
+
+			Convey("Then it should emit results only when windows are closed", func() {
+				So(out[0], ShouldBeEmpty)
+				So(out[1], ShouldBeEmpty)
+				So(out[2], ShouldResemble, []data.Map{{"window_start": at(0), "window_end": at(2),
+					"c": data.Int(2), "s": data.Int(3)}})
+				So(out[3], ShouldBeEmpty)
+				So(out[4], ShouldResemble, []data.Map{{"window_start": at(2), "window_end": at(4),
+					"c": data.Int(2), "s": data.Int(7)}})
+				So(out[5], ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given a HOP window function", t, func() {
+		s := `CREATE STREAM box AS SELECT RSTREAM int, window_start
+			FROM HOP(src, ts(), INTERVAL 1 SECOND, INTERVAL 2 SECONDS) WHERE int != 2`
+		plan, err := createDefaultSelectPlan(s, t)
+		So(err, ShouldBeNil)
+
+		Convey("When feeding it with tuples", func() {
+			var out [][]data.Map
+			for _, t := range getTuples(4) {
+				res, err := plan.Process(t)
+				So(err, ShouldBeNil)
+				out = append(out, res)
+			}
+
+			Convey("Then each tuple should be emitted in all windows having it", func() {
+				So(out[0], ShouldBeEmpty)
+				So(out[1], ShouldResemble, []data.Map{{"int": data.Int(1), "window_start": at(-1)}})
+				So(out[2], ShouldResemble, []data.Map{{"int": data.Int(1), "window_start": at(0)}})
+				So(out[3], ShouldResemble, []data.Map{{"int": data.Int(3), "window_start": at(1)}})
+			})
+		})
+	})
+
+	Convey("Given a SESSION window function using a time column", t, func() {
+		s := `CREATE STREAM box AS SELECT RSTREAM window_start, window_end, count(*) AS c
+			FROM SESSION(src, t, INTERVAL 2 SECONDS) GROUP BY window_start, window_end`
+		plan, err := createGroupbyPlan(s, t)
+		So(err, ShouldBeNil)
+
+		Convey("When feeding it with tuples", func() {
+			var out [][]data.Map
+			tuples := getTuples(6)
+			for i, sec := range []int{0, 1, 5, 6, 20, 7} {
+				tuples[i].Data["t"] = at(sec)
+				res, err := plan.Process(tuples[i])
+				So(err, ShouldBeNil)
+				out = append(out, res)
+			}
+
+			Convey("Then it should emit results when sessions are closed", func() {
+				So(out[0], ShouldBeEmpty)
+				So(out[1], ShouldBeEmpty)
+				So(out[2], ShouldResemble, []data.Map{{"window_start": at(0), "window_end": at(3),
+					"c": data.Int(2)}})
+				So(out[3], ShouldBeEmpty)
+				So(out[4], ShouldResemble, []data.Map{{"window_start": at(5), "window_end": at(8),
+					"c": data.Int(2)}})
+			})
+
+			Convey("Then a tuple of a closed session should be dropped", func() {
+				So(out[5], ShouldBeEmpty)
+			})
+		})
+
+		Convey("When feeding it with a tuple not having the time column", func() {
+			_, err := plan.Process(getTuples(1)[0])
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+
+	Convey("Given invalid window functions", t, func() {
+		for _, s := range []string{
+			`CREATE STREAM box AS SELECT RSTREAM * FROM TUMBLE(src, ts(), INTERVAL 1 SECOND) AS a, src [RANGE 1 TUPLES] AS b`,
+			`CREATE STREAM box AS SELECT RSTREAM * FROM HOP(src, ts(), INTERVAL 0 SECONDS, INTERVAL 1 SECOND)`,
+			`CREATE STREAM box AS SELECT RSTREAM * FROM TUMBLE(src, ts(), INTERVAL 2 DAYS)`,
+		} {
+			Convey("When creating a plan of "+s, func() {
+				_, err := createDefaultSelectPlan(s, t)
+
+				Convey("Then it should fail", func() {
+					So(err, ShouldNotBeNil)
+				})
+			})
+		}
+	})
+}
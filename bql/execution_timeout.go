@@ -0,0 +1,185 @@
+package bql
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"gopkg.in/sensorbee/sensorbee.v0/bql/udf"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// executionTimeoutParams are parameters of CREATE STREAM overriding default
+// execution timeouts given to the Context.
+var executionTimeoutParams = []string{"udf_timeout", "box_timeout", "on_timeout"}
+
+// extractExecutionTimeout extracts execution timeout parameters from params.
+// Parameters not given are taken from base, which can be nil. It returns a
+// nil config when params don't have any of them. The returned map has the
+// rest of parameters and params isn't modified.
+//
+// It accepts following parameters:
+//
+//	- udf_timeout: the maximum duration of a UDF call such as "500ms"
+//	- box_timeout: the maximum duration of processing a tuple such as "5s"
+//	- on_timeout: "fail" (default) or "error_output"
+//
+// A timeout of 0 disables it even if base has one.
+func extractExecutionTimeout(params data.Map, base *core.ExecutionTimeoutConfig) (*core.ExecutionTimeoutConfig, data.Map, error) {
+	rest := make(data.Map, len(params))
+	timeoutParams := data.Map{}
+	for k, v := range params {
+		rest[k] = v
+	}
+	for _, k := range executionTimeoutParams {
+		if v, ok := rest[k]; ok {
+			timeoutParams[k] = v
+			delete(rest, k)
+		}
+	}
+	if len(timeoutParams) == 0 {
+		return nil, params, nil
+	}
+
+	c := &core.ExecutionTimeoutConfig{}
+	if base != nil {
+		*c = *base
+	}
+	for _, p := range []struct {
+		name string
+		d    *time.Duration
+	}{
+		{"udf_timeout", &c.UDF},
+		{"box_timeout", &c.Box},
+	} {
+		v, ok := timeoutParams[p.name]
+		if !ok {
+			continue
+		}
+		d, err := data.ToDuration(v)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%v must be a duration: %v", p.name, err)
+		}
+		if d < 0 {
+			return nil, nil, fmt.Errorf("%v must not be negative", p.name)
+		}
+		*p.d = d
+	}
+	if v, ok := timeoutParams["on_timeout"]; ok {
+		a, err := data.AsString(v)
+		if err != nil {
+			return nil, nil, fmt.Errorf("on_timeout must be a string: %v", err)
+		}
+		switch strings.ToLower(a) {
+		case "fail":
+			c.ErrorOutput = false
+		case "error_output":
+			c.ErrorOutput = true
+		default:
+			return nil, nil, fmt.Errorf("unsupported action for on_timeout: %v", a)
+		}
+	}
+	return c, rest, nil
+}
+
+// timeoutFunctionRegistry wraps UDFs looked up from a FunctionRegistry so
+// that their calls time out.
+type timeoutFunctionRegistry struct {
+	udf.FunctionRegistry
+	timeout time.Duration
+
+	// timedOut is called every time a call times out.
+	timedOut func()
+}
+
+func (r *timeoutFunctionRegistry) Lookup(name string, arity int) (udf.UDF, error) {
+	f, err := r.FunctionRegistry.Lookup(name, arity)
+	if err != nil {
+		return nil, err
+	}
+	t := &timeoutUDF{
+		UDF:      f,
+		name:     name,
+		timeout:  r.timeout,
+		timedOut: r.timedOut,
+	}
+	if b, ok := f.(udf.BatchUDF); ok {
+		return &timeoutBatchUDF{t, b}, nil
+	}
+	return t, nil
+}
+
+// timeoutUDF is a UDF whose call fails with core.TimeoutError when the
+// wrapped UDF doesn't return within the timeout. The call of the wrapped UDF
+// is abandoned and keeps running in background because it cannot be
+// interrupted.
+type timeoutUDF struct {
+	udf.UDF
+	name     string
+	timeout  time.Duration
+	timedOut func()
+}
+
+func (f *timeoutUDF) Call(ctx *core.Context, args ...data.Value) (data.Value, error) {
+	v, err := f.withTimeout(func() (interface{}, error) {
+		return f.UDF.Call(ctx, args...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	res, _ := v.(data.Value)
+	return res, nil
+}
+
+// withTimeout runs call in a separate goroutine and waits for it to return
+// until the timeout expires.
+func (f *timeoutUDF) withTimeout(call func() (interface{}, error)) (interface{}, error) {
+	type result struct {
+		v   interface{}
+		err error
+	}
+	ch := make(chan result, 1) // buffered so that an abandoned call can exit
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				ch <- result{err: fmt.Errorf("evaluating '%s' paniced: %s", f.name, r)}
+			}
+		}()
+		v, err := call()
+		ch <- result{v, err}
+	}()
+
+	t := time.NewTimer(f.timeout)
+	defer t.Stop()
+	select {
+	case r := <-ch:
+		return r.v, r.err
+	case <-t.C:
+		if f.timedOut != nil {
+			f.timedOut()
+		}
+		return nil, &core.TimeoutError{
+			Target:  fmt.Sprintf("function '%v'", f.name),
+			Timeout: f.timeout,
+		}
+	}
+}
+
+// timeoutBatchUDF is a timeoutUDF wrapping a udf.BatchUDF. The timeout is
+// applied to a whole batch rather than each call in it.
+type timeoutBatchUDF struct {
+	*timeoutUDF
+	batch udf.BatchUDF
+}
+
+func (f *timeoutBatchUDF) CallBatch(ctx *core.Context, args [][]data.Value) ([]data.Value, error) {
+	v, err := f.withTimeout(func() (interface{}, error) {
+		return f.batch.CallBatch(ctx, args)
+	})
+	if err != nil {
+		return nil, err
+	}
+	res, _ := v.([]data.Value)
+	return res, nil
+}
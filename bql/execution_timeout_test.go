@@ -0,0 +1,232 @@
+package bql
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/udf"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+func TestExtractExecutionTimeout(t *testing.T) {
+	Convey("Given parameters having execution timeouts", t, func() {
+		params := data.Map{
+			"udf_timeout": data.String("100ms"),
+			"on_timeout":  data.String("error_output"),
+			"num":         data.Int(1),
+		}
+
+		Convey("When extracting timeouts with a base config", func() {
+			c, rest, err := extractExecutionTimeout(params, &core.ExecutionTimeoutConfig{
+				UDF: time.Second,
+				Box: 5 * time.Second,
+			})
+			So(err, ShouldBeNil)
+
+			Convey("Then given parameters should override the base config", func() {
+				So(c.UDF, ShouldEqual, 100*time.Millisecond)
+				So(c.Box, ShouldEqual, 5*time.Second)
+				So(c.ErrorOutput, ShouldBeTrue)
+			})
+
+			Convey("Then the rest of parameters should only have num", func() {
+				So(rest, ShouldResemble, data.Map{"num": data.Int(1)})
+				So(params, ShouldContainKey, "udf_timeout")
+			})
+		})
+
+		Convey("When extracting timeouts without a base config", func() {
+			c, _, err := extractExecutionTimeout(params, nil)
+			So(err, ShouldBeNil)
+
+			Convey("Then the box shouldn't time out", func() {
+				So(c.Box, ShouldEqual, 0)
+			})
+		})
+	})
+
+	Convey("Given parameters not having execution timeouts", t, func() {
+		params := data.Map{"num": data.Int(1)}
+
+		Convey("When extracting timeouts", func() {
+			c, rest, err := extractExecutionTimeout(params, &core.ExecutionTimeoutConfig{UDF: time.Second})
+			So(err, ShouldBeNil)
+
+			Convey("Then it should return a nil config", func() {
+				So(c, ShouldBeNil)
+				So(rest, ShouldResemble, params)
+			})
+		})
+	})
+
+	Convey("Given invalid parameters", t, func() {
+		for _, params := range []data.Map{
+			{"udf_timeout": data.String("hoge")},
+			{"box_timeout": data.String("-1s")},
+			{"on_timeout": data.String("drop")},
+			{"on_timeout": data.Int(1)},
+		} {
+			Convey("When extracting timeouts from "+params.String(), func() {
+				_, _, err := extractExecutionTimeout(params, nil)
+
+				Convey("Then it should fail", func() {
+					So(err, ShouldNotBeNil)
+				})
+			})
+		}
+	})
+}
+
+func TestExecutionTimeout(t *testing.T) {
+	Convey("Given a BQL TopologyBuilder having a function blocking on even numbers", t, func() {
+		ctx := core.NewContext(&core.ContextConfig{
+			ExecutionTimeout: &core.ExecutionTimeoutConfig{
+				UDF:         20 * time.Millisecond,
+				ErrorOutput: true,
+			},
+		})
+		dt, err := core.NewDefaultTopology(ctx, "testTopology")
+		So(err, ShouldBeNil)
+		tb, err := NewTopologyBuilder(dt)
+		So(err, ShouldBeNil)
+
+		block := make(chan struct{})
+		Reset(func() {
+			close(block)
+			dt.Stop()
+		})
+		So(tb.Reg.Register("block_if_even", udf.UnaryFunc(func(ctx *core.Context, v data.Value) (data.Value, error) {
+			i, err := data.AsInt(v)
+			if err != nil {
+				return nil, err
+			}
+			if i%2 == 0 {
+				<-block
+			}
+			return v, nil
+		})), ShouldBeNil)
+		So(addBQLToTopology(tb, `CREATE PAUSED SOURCE source TYPE dummy WITH num=4;`), ShouldBeNil)
+
+		setUpSink := func() *tupleCollectorSink {
+			So(addBQLToTopology(tb, `
+				CREATE SINK snk TYPE collector;
+				INSERT INTO snk FROM box;
+				RESUME SOURCE source;`), ShouldBeNil)
+			sn, err := dt.Sink("snk")
+			So(err, ShouldBeNil)
+			return sn.Sink().(*tupleCollectorSink)
+		}
+
+		Convey("When creating a stream using the default timeouts", func() {
+			So(addBQLToTopology(tb, `CREATE STREAM box AS SELECT RSTREAM block_if_even(int) AS x
+				FROM source [RANGE 1 TUPLES];`), ShouldBeNil)
+			si := setUpSink()
+
+			Convey("Then tuples timed out should be dropped", func() {
+				si.Wait(2)
+				So(si.get(0).Data, ShouldResemble, data.Map{"x": data.Int(1)})
+				So(si.get(1).Data, ShouldResemble, data.Map{"x": data.Int(3)})
+
+				bn, err := dt.Box("box")
+				So(err, ShouldBeNil)
+				So(bn.State().Get(), ShouldEqual, core.TSRunning)
+			})
+		})
+
+		Convey("When creating a stream failing on a UDF timeout", func() {
+			So(addBQLToTopology(tb, `CREATE STREAM box AS SELECT RSTREAM block_if_even(int) AS x
+				FROM source [RANGE 1 TUPLES] WITH on_timeout="fail";`), ShouldBeNil)
+			si := setUpSink()
+
+			Convey("Then the box should stop", func() {
+				bn, err := dt.Box("box")
+				So(err, ShouldBeNil)
+				So(bn.State().Wait(core.TSStopped), ShouldEqual, core.TSStopped)
+				si.Wait(1)
+				So(si.get(0).Data, ShouldResemble, data.Map{"x": data.Int(1)})
+			})
+		})
+
+		Convey("When creating a stream having a box timeout", func() {
+			So(addBQLToTopology(tb, `CREATE STREAM box AS SELECT RSTREAM block_if_even(int) AS x
+				FROM source [RANGE 1 TUPLES] WITH udf_timeout=0, box_timeout="20ms";`), ShouldBeNil)
+			si := setUpSink()
+
+			Convey("Then tuples waiting for the blocked plan should time out as well", func() {
+				bn, err := dt.Box("box")
+				So(err, ShouldBeNil)
+				waitForExpectedCondition(func() bool {
+					is := bn.Status()["input_stats"].(data.Map)
+					return is["num_errors"] == data.Int(3)
+				})
+				So(bn.State().Get(), ShouldEqual, core.TSRunning)
+				So(si.len(), ShouldEqual, 1)
+				So(si.get(0).Data, ShouldResemble, data.Map{"x": data.Int(1)})
+			})
+		})
+
+		Convey("When creating a stream having an invalid timeout", func() {
+			err := addBQLToTopology(tb, `CREATE STREAM box AS SELECT RSTREAM block_if_even(int) AS x
+				FROM source [RANGE 1 TUPLES] WITH box_timeout="hoge";`)
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+type blockingBatchUDF struct {
+	udf.UDF
+	block chan struct{}
+}
+
+func (f *blockingBatchUDF) CallBatch(ctx *core.Context, args [][]data.Value) ([]data.Value, error) {
+	<-f.block
+	return make([]data.Value, len(args)), nil
+}
+
+func TestTimeoutBatchUDF(t *testing.T) {
+	Convey("Given a timeout registry having a UDF implementing BatchUDF", t, func() {
+		ctx := core.NewContext(nil)
+		reg := udf.CopyGlobalUDFRegistry(ctx)
+		f := &blockingBatchUDF{
+			UDF:   udf.UnaryFunc(func(ctx *core.Context, v data.Value) (data.Value, error) { return v, nil }),
+			block: make(chan struct{}),
+		}
+		So(reg.Register("blocking_batch", f), ShouldBeNil)
+		timedOut := 0
+		treg := &timeoutFunctionRegistry{
+			FunctionRegistry: reg,
+			timeout:          10 * time.Millisecond,
+			timedOut:         func() { timedOut++ },
+		}
+		Reset(func() {
+			close(f.block)
+		})
+
+		Convey("When looking up the UDF", func() {
+			g, err := treg.Lookup("blocking_batch", 1)
+			So(err, ShouldBeNil)
+
+			Convey("Then it should still implement BatchUDF", func() {
+				b, ok := g.(udf.BatchUDF)
+				So(ok, ShouldBeTrue)
+
+				Convey("And a batch should time out", func() {
+					_, err := b.CallBatch(ctx, [][]data.Value{{data.Int(1)}, {data.Int(2)}})
+					So(err, ShouldHaveSameTypeAs, &core.TimeoutError{})
+					So(timedOut, ShouldEqual, 1)
+				})
+
+				Convey("And a single call should succeed", func() {
+					v, err := g.Call(ctx, data.Int(1))
+					So(err, ShouldBeNil)
+					So(v, ShouldEqual, data.Int(1))
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,173 @@
+package bql
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"gopkg.in/sensorbee/sensorbee.v0/bql/execution"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/parser"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// defaultExplainAnalyzeDuration is the duration for which EXPLAIN ANALYZE
+// runs a statement when the FOR clause is omitted.
+const defaultExplainAnalyzeDuration = 10 * time.Second
+
+// explainAnalysis collects measurements of a bqlBox running the SELECT
+// statement of EXPLAIN ANALYZE.
+type explainAnalysis struct {
+	// maxTuples is the number of input tuples after which the analysis
+	// finishes. It's 0 when the analysis is bounded by a duration.
+	maxTuples int64
+
+	// profiler is the execution plan of the box. It's set when the box is
+	// initialized.
+	profiler execution.Profiler
+
+	m       sync.Mutex
+	tuples  int64
+	results int64
+
+	done     chan struct{}
+	doneOnce sync.Once
+}
+
+func newExplainAnalysis(maxTuples int64) *explainAnalysis {
+	return &explainAnalysis{
+		maxTuples: maxTuples,
+		done:      make(chan struct{}),
+	}
+}
+
+// processed records that the box processed an input tuple and computed
+// numResults results from it.
+func (e *explainAnalysis) processed(numResults int) {
+	e.m.Lock()
+	e.tuples++
+	e.results += int64(numResults)
+	reached := e.maxTuples > 0 && e.tuples >= e.maxTuples
+	e.m.Unlock()
+	if reached {
+		e.finish()
+	}
+}
+
+// finish stops waiting for the analysis. It's called when the box processed
+// enough tuples or stopped.
+func (e *explainAnalysis) finish() {
+	e.doneOnce.Do(func() {
+		close(e.done)
+	})
+}
+
+// report returns the result of EXPLAIN ANALYZE. It must be called after the
+// box stopped.
+func (e *explainAnalysis) report(elapsed time.Duration) data.Map {
+	e.m.Lock()
+	defer e.m.Unlock()
+	m := data.Map{
+		"tuples":  data.Int(e.tuples),
+		"results": data.Int(e.results),
+		"elapsed": data.Float(elapsed.Seconds()),
+	}
+
+	p := e.profiler.Profile()
+	total := p.Time()
+	ops := make(data.Array, len(p.Operators))
+	for i, o := range p.Operators {
+		ratio := 0.0
+		if total > 0 {
+			ratio = float64(o.Time) / float64(total)
+		}
+		ops[i] = data.Map{
+			"operator":      data.String(o.Operator),
+			"calls":         data.Int(o.Calls),
+			"time":          data.Float(o.Time.Seconds()),
+			"time_ratio":    data.Float(ratio),
+			"alloc_bytes":   data.Int(o.AllocBytes),
+			"alloc_objects": data.Int(o.AllocObjects),
+		}
+	}
+	m["time"] = data.Float(total.Seconds())
+	m["operators"] = ops
+	return m
+}
+
+// RunExplainAnalyzeStmt runs the SELECT statement of EXPLAIN ANALYZE and
+// reports the time spent and the memory allocated by each operator of its
+// execution plan. The statement runs until it processes the given number of
+// input tuples, or for the given duration. It runs for 10 seconds when the
+// FOR clause is omitted. With a number of tuples, this method blocks until
+// the tuples arrive or the input streams stop. Results of the statement are
+// discarded.
+//
+// The result has the following fields:
+//
+//   - tuples: the number of input tuples processed
+//   - results: the number of results computed
+//   - elapsed: the duration of the analysis in seconds
+//   - time: the time spent in the execution plan in seconds
+//   - operators: an array of operators, see below
+//
+// Each element of operators has the following fields:
+//
+//   - operator: the name of the operator, e.g. "filter" or "join"
+//   - calls: the number of times the operator has been run
+//   - time: the time spent in the operator in seconds
+//   - time_ratio: the ratio of time to the time spent in the plan
+//   - alloc_bytes: the estimated number of bytes allocated
+//   - alloc_objects: the estimated number of objects allocated
+//
+// The time and allocations of an operator don't include ones of other
+// operators nested in it. See execution.ProfiledOperators for operators.
+func (tb *TopologyBuilder) RunExplainAnalyzeStmt(stmt *parser.ExplainAnalyzeStmt) (data.Map, error) {
+	d := defaultExplainAnalyzeDuration
+	var maxTuples int64
+	if stmt.Limit.Unit != parser.UnspecifiedIntervalUnit {
+		if stmt.Limit.Value <= 0 {
+			return nil, fmt.Errorf("the limit of EXPLAIN ANALYZE must be positive: %v",
+				stmt.Limit.FloatLiteral)
+		}
+		switch stmt.Limit.Unit {
+		case parser.Tuples:
+			maxTuples = int64(stmt.Limit.Value)
+			d = 0
+		case parser.Seconds:
+			d = time.Duration(stmt.Limit.Value * float64(time.Second))
+		case parser.Milliseconds:
+			d = time.Duration(stmt.Limit.Value * float64(time.Millisecond))
+		}
+	}
+
+	explain := newExplainAnalysis(maxTuples)
+	tmpName := fmt.Sprintf("sensorbee_tmp_%v", topologyBuilderNextTemporaryID())
+	tmpStmt := parser.CreateStreamAsSelectStmt{
+		Name:   parser.StreamIdentifier(tmpName),
+		Select: stmt.SelectStmt,
+	}
+	clock := tb.topology.Context().Clock()
+	start := clock.Now()
+	if _, err := tb.createStreamAsSelectStmt(&tmpStmt, time.Time{}, explain); err != nil {
+		return nil, err
+	}
+
+	if d > 0 {
+		timer := clock.NewTimer(d)
+		select {
+		case <-explain.done:
+		case <-timer.C():
+		}
+		timer.Stop()
+	} else {
+		<-explain.done
+	}
+	elapsed := clock.Now().Sub(start)
+
+	// the box may have already been removed by itself
+	if err := tb.topology.Remove(tmpName); err != nil && !core.IsNotExist(err) {
+		return nil, err
+	}
+	return explain.report(elapsed), nil
+}
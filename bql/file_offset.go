@@ -0,0 +1,168 @@
+package bql
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+)
+
+// fileOffsetTracker persists the offset in a file up to which all lines have
+// been acknowledged. A file source reading the file again starts from the
+// persisted offset so that lines are delivered at least once.
+type fileOffsetTracker struct {
+	m    sync.Mutex
+	path string
+
+	// committed is the offset of the first line which hasn't been
+	// acknowledged yet.
+	committed int64
+
+	// pending has the end offsets of lines which have been read but whose
+	// preceding lines haven't all been acknowledged yet. The first element
+	// corresponds to the line starting at committed.
+	pending []pendingOffset
+
+	// numCommitted is the number of lines committed in the current
+	// generation. The index of a line in the generation minus numCommitted
+	// is its index in pending.
+	numCommitted int64
+
+	// generation is incremented when the tracker is reset so that
+	// acknowledgments of lines read before resetting are ignored.
+	generation int64
+
+	// stalled is true when a line failed to be processed. The offset will
+	// not be committed any further until the tracker is reset.
+	stalled bool
+	loaded  bool
+}
+
+type pendingOffset struct {
+	end   int64
+	acked bool
+}
+
+// newFileOffsetTracker creates a tracker persisting offsets in the file.
+// The persisted offset is loaded if the file exists.
+func newFileOffsetTracker(path string) (*fileOffsetTracker, error) {
+	t := &fileOffsetTracker{
+		path: path,
+	}
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return t, nil
+		}
+		return nil, err
+	}
+	if s := strings.TrimSpace(string(b)); s != "" {
+		o, err := strconv.ParseInt(s, 10, 64)
+		if err != nil || o < 0 {
+			return nil, fmt.Errorf("the offset file '%v' has an invalid offset: %v", path, s)
+		}
+		t.committed = o
+	}
+	return t, nil
+}
+
+// start returns the offset from which the source starts reading. It returns
+// the persisted offset when it's called for the first time. Otherwise, e.g.
+// when the source is rewound, the tracker is reset and starts from 0.
+func (t *fileOffsetTracker) start() (int64, error) {
+	t.m.Lock()
+	defer t.m.Unlock()
+	if !t.loaded {
+		t.loaded = true
+		return t.committed, nil
+	}
+	t.generation++
+	t.committed = 0
+	t.pending = nil
+	t.numCommitted = 0
+	t.stalled = false
+	return 0, t.persist()
+}
+
+// add registers a line ending at the offset and returns the AckHandler of
+// the tuple created from the line.
+func (t *fileOffsetTracker) add(end int64) core.AckHandler {
+	t.m.Lock()
+	defer t.m.Unlock()
+	if t.stalled {
+		return core.AckFunc(func(*core.Context, error) {})
+	}
+	gen := t.generation
+	idx := t.numCommitted + int64(len(t.pending))
+	t.pending = append(t.pending, pendingOffset{end: end})
+	return core.AckFunc(func(ctx *core.Context, err error) {
+		t.ack(ctx, gen, idx, err)
+	})
+}
+
+// skip registers a line which doesn't produce a tuple, e.g. an empty line.
+func (t *fileOffsetTracker) skip(ctx *core.Context, end int64) {
+	t.add(end).Ack(ctx, nil)
+}
+
+func (t *fileOffsetTracker) ack(ctx *core.Context, gen, idx int64, err error) {
+	t.m.Lock()
+	defer t.m.Unlock()
+	if gen != t.generation || t.stalled {
+		return
+	}
+	if err != nil {
+		t.stalled = true
+		t.pending = nil
+		ctx.ErrLog(err).WithField("offset_file", t.path).
+			WithField("offset", t.committed).
+			Error("A line failed to be processed and the offset won't be committed any further")
+		return
+	}
+
+	t.pending[idx-t.numCommitted].acked = true
+	n := 0
+	for n < len(t.pending) && t.pending[n].acked {
+		n++
+	}
+	if n == 0 {
+		return
+	}
+	t.committed = t.pending[n-1].end
+	t.pending = t.pending[n:]
+	t.numCommitted += int64(n)
+	if err := t.persist(); err != nil {
+		ctx.ErrLog(err).WithField("offset_file", t.path).
+			Error("Cannot persist the offset")
+	}
+}
+
+// persist writes the committed offset to the file. The caller must hold the
+// lock.
+func (t *fileOffsetTracker) persist() error {
+	return writeFileAtomically(t.path, []byte(fmt.Sprintln(t.committed)))
+}
+
+// writeFileAtomically replaces the content of the file with b by renaming a
+// temporary file so that the file never has partially written content.
+func writeFileAtomically(path string, b []byte) error {
+	f, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
@@ -0,0 +1,122 @@
+package bql
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// jsonLimitParams has parameters of sources limiting each line of JSON Lines
+// they read. See data.JSONLimits for details of each limit. The default
+// values are data.DefaultJSONLimits and 0 disables the limit.
+type jsonLimitParams struct {
+	MaxDocumentSize int64
+	MaxDepth        int
+	MaxStringLength int
+}
+
+func defaultJSONLimitParams() jsonLimitParams {
+	return jsonLimitParams{
+		MaxDocumentSize: data.DefaultJSONLimits.MaxDocumentSize,
+		MaxDepth:        data.DefaultJSONLimits.MaxDepth,
+		MaxStringLength: data.DefaultJSONLimits.MaxStringLength,
+	}
+}
+
+func (p *jsonLimitParams) limits() (*data.JSONLimits, error) {
+	if p.MaxDocumentSize < 0 {
+		return nil, fmt.Errorf("'max_document_size' parameter must not be negative: %v", p.MaxDocumentSize)
+	}
+	if p.MaxDepth < 0 {
+		return nil, fmt.Errorf("'max_depth' parameter must not be negative: %v", p.MaxDepth)
+	}
+	if p.MaxStringLength < 0 {
+		return nil, fmt.Errorf("'max_string_length' parameter must not be negative: %v", p.MaxStringLength)
+	}
+	return &data.JSONLimits{
+		MaxDocumentSize: p.MaxDocumentSize,
+		MaxDepth:        p.MaxDepth,
+		MaxStringLength: p.MaxStringLength,
+	}, nil
+}
+
+// isJSONLines returns true when the format is the one decoded by
+// readJSONLine rather than by a textformat.Decoder.
+func isJSONLines(format string) bool {
+	switch strings.ToLower(format) {
+	case "", "jsonl":
+		return true
+	default:
+		return false
+	}
+}
+
+// newJSONLinesDecoder returns a decoder reading JSON Lines from r.
+func newJSONLinesDecoder(r io.Reader, limits *data.JSONLimits) *data.JSONDecoder {
+	dec := data.NewJSONDecoder(r, *limits)
+	dec.DisallowNewlines()
+	return dec
+}
+
+// jsonLine is a line read by readJSONLine.
+type jsonLine struct {
+	// m is the object in the line. It's nil when err isn't nil.
+	m data.Map
+
+	// err is the reason why the line couldn't be decoded, such as a syntax
+	// error or a violation of limits.
+	err error
+
+	// number is the 0-based line number.
+	number int64
+
+	// eol is true when the line ends with a newline. The last line of a
+	// file may not have it, but it can also mean that the line is still
+	// being written.
+	eol bool
+}
+
+// readJSONLine reads the next non-blank line of JSON Lines. The line is
+// decoded while being read so that a huge line is rejected without holding
+// it in memory. It returns io.EOF when there're no more lines. Other errors
+// are ones returned from the underlying reader, and errors of the line
+// itself are set to jsonLine.err.
+func readJSONLine(dec *data.JSONDecoder) (*jsonLine, error) {
+	v, err := dec.Decode()
+	if err == io.EOF {
+		return nil, io.EOF
+	}
+	l := &jsonLine{number: dec.Line()}
+	if err == nil {
+		if err = dec.EndLine(); err == nil {
+			l.eol = true
+		} else if err == io.EOF {
+			err = nil
+		}
+	}
+	if err == nil {
+		if l.m, err = data.AsMap(v); err != nil {
+			l.err = fmt.Errorf("the line isn't a JSON object: %v", err)
+		}
+		return l, nil
+	}
+
+	switch err.(type) {
+	case *data.JSONSyntaxError, *data.JSONLimitError:
+	default:
+		if err != io.ErrUnexpectedEOF {
+			return nil, err
+		}
+		l.err = err
+		return l, nil
+	}
+	l.err = err
+	if err := dec.SkipLine(); err == nil {
+		l.eol = true
+	} else if err != io.EOF {
+		return nil, err
+	}
+	return l, nil
+}
@@ -0,0 +1,40 @@
+package bql
+
+import (
+	"fmt"
+
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// extractLabels extracts "labels" parameter from params. The parameter has
+// to be an array of strings, each of which is a valid label. The returned map
+// has the rest of parameters and params isn't modified.
+func extractLabels(params data.Map) ([]string, data.Map, error) {
+	v, ok := params["labels"]
+	if !ok {
+		return nil, params, nil
+	}
+	rest := make(data.Map, len(params))
+	for k, v := range params {
+		rest[k] = v
+	}
+	delete(rest, "labels")
+
+	a, err := data.AsArray(v)
+	if err != nil {
+		return nil, nil, fmt.Errorf("labels must be an array: %v", err)
+	}
+	labels := make([]string, 0, len(a))
+	for _, l := range a {
+		s, err := data.AsString(l)
+		if err != nil {
+			return nil, nil, fmt.Errorf("labels must only have strings: %v", err)
+		}
+		if err := core.ValidateLabel(s); err != nil {
+			return nil, nil, err
+		}
+		labels = append(labels, s)
+	}
+	return labels, rest, nil
+}
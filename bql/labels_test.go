@@ -0,0 +1,51 @@
+package bql
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+)
+
+func TestNodeLabelsParameter(t *testing.T) {
+	Convey("Given a BQL TopologyBuilder", t, func() {
+		dt := newTestTopology()
+		Reset(func() {
+			dt.Stop()
+		})
+		tb, err := NewTopologyBuilder(dt)
+		So(err, ShouldBeNil)
+
+		Convey("When creating nodes with labels", func() {
+			So(addBQLToTopology(tb, `CREATE SOURCE src TYPE dummy WITH labels=["ingest", "V2"]`), ShouldBeNil)
+			So(addBQLToTopology(tb, `CREATE SINK snk TYPE collector WITH labels=["ingest"]`), ShouldBeNil)
+
+			Convey("Then the nodes should have the labels", func() {
+				src, err := dt.Source("src")
+				So(err, ShouldBeNil)
+				So(src.Labels(), ShouldResemble, []string{"ingest", "v2"})
+				sn, err := dt.Sink("snk")
+				So(err, ShouldBeNil)
+				So(sn.Labels(), ShouldResemble, []string{"ingest"})
+			})
+
+			Convey("Then they should be selected by a label selector", func() {
+				sel, err := core.ParseLabelSelector("ingest,!v2")
+				So(err, ShouldBeNil)
+				ns := core.SelectNodes(dt, sel)
+				So(ns, ShouldHaveLength, 1)
+				So(ns, ShouldContainKey, "snk")
+			})
+		})
+
+		Convey("When creating a node with invalid labels", func() {
+			for _, l := range []string{`"ingest"`, `[1]`, `["in gest"]`} {
+				err := addBQLToTopology(tb, `CREATE SOURCE src TYPE dummy WITH labels=`+l)
+
+				Convey("Then it should fail: "+l, func() {
+					So(err, ShouldNotBeNil)
+				})
+			}
+		})
+	})
+}
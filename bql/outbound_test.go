@@ -0,0 +1,435 @@
+package bql
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/pem"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// startEchoServer starts a TCP server echoing lines.
+func startEchoServer() net.Listener {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	So(err, ShouldBeNil)
+	go func() {
+		for {
+			c, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer c.Close()
+				io.Copy(c, c)
+			}()
+		}
+	}()
+	return l
+}
+
+// newTestHTTPProxy returns an HTTP proxy which connects all CONNECT requests
+// to target and responds to other requests by itself.
+func newTestHTTPProxy(target string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "CONNECT" {
+			w.Write([]byte("proxied " + r.URL.String()))
+			return
+		}
+		if r.Header.Get("Proxy-Authorization") != "Basic dXNlcjpwYXNz" { // user:pass
+			w.WriteHeader(http.StatusProxyAuthRequired)
+			return
+		}
+		dst, err := net.Dial("tcp", target)
+		if err != nil {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		c, buf, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			dst.Close()
+			return
+		}
+		c.Write([]byte("HTTP/1.1 200 Connection established\r\n\r\n"))
+		go func() {
+			io.Copy(dst, buf)
+			dst.Close()
+		}()
+		go func() {
+			io.Copy(c, dst)
+			c.Close()
+		}()
+	}))
+}
+
+// startTestSOCKS5Proxy starts a SOCKS5 proxy which only supports connecting
+// to domain names with username/password authentication. All connections are
+// made to target.
+func startTestSOCKS5Proxy(target string) (net.Listener, chan string) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	So(err, ShouldBeNil)
+	hosts := make(chan string, 1)
+	go func() {
+		for {
+			c, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer c.Close()
+				b := make([]byte, 256)
+				io.ReadFull(c, b[:2])
+				io.ReadFull(c, b[:b[1]])
+				c.Write([]byte{0x05, 0x02})
+				io.ReadFull(c, b[:2])
+				io.ReadFull(c, b[:b[1]]) // username
+				io.ReadFull(c, b[:1])
+				io.ReadFull(c, b[:b[0]]) // password
+				c.Write([]byte{0x01, 0x00})
+				io.ReadFull(c, b[:5])
+				if b[3] != 0x03 {
+					c.Write([]byte{0x05, 0x08, 0x00, 0x01, 0, 0, 0, 0, 0, 0})
+					return
+				}
+				host := make([]byte, b[4])
+				io.ReadFull(c, host)
+				io.ReadFull(c, b[:2]) // port
+				hosts <- string(host)
+				dst, err := net.Dial("tcp", target)
+				if err != nil {
+					return
+				}
+				defer dst.Close()
+				c.Write([]byte{0x05, 0x00, 0x00, 0x01, 127, 0, 0, 1, 0, 0})
+				go io.Copy(dst, c)
+				io.Copy(c, dst)
+			}()
+		}
+	}()
+	return l, hosts
+}
+
+func echo(conn net.Conn) string {
+	_, err := conn.Write([]byte("hello\n"))
+	So(err, ShouldBeNil)
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	So(err, ShouldBeNil)
+	return line
+}
+
+func TestOutboundConfigProxy(t *testing.T) {
+	Convey("Given an outbound config having a proxy", t, func() {
+		c := &OutboundConfig{
+			Proxy:   "http://proxy:8080",
+			NoProxy: "internal.example.com, .corp, 10.0.0.0/8, 192.168.1.1",
+		}
+		So(c.Validate(), ShouldBeNil)
+
+		Convey("When getting proxies of addresses", func() {
+			cases := map[string]bool{
+				"example.com:443":          true,
+				"internal.example.com:443": false,
+				"a.internal.example.com:1": false,
+				"xinternal.example.com:1":  true,
+				"host.corp:80":             false,
+				"corp:80":                  false,
+				"10.1.2.3:80":              false,
+				"11.1.2.3:80":              true,
+				"192.168.1.1:80":           false,
+				"localhost:80":             false,
+				"127.0.0.1:80":             false,
+				"[::1]:80":                 false,
+			}
+			for addr, proxied := range cases {
+				addr, proxied := addr, proxied
+				u, err := c.ProxyURL("https", addr)
+				So(err, ShouldBeNil)
+
+				Convey("Then the proxy should be decided by no_proxy: "+addr, func() {
+					if proxied {
+						So(u, ShouldNotBeNil)
+						So(u.String(), ShouldEqual, "http://proxy:8080")
+					} else {
+						So(u, ShouldBeNil)
+					}
+				})
+			}
+		})
+	})
+
+	Convey("Given proxies in environment variables", t, func() {
+		envs := map[string]string{
+			"HTTPS_PROXY": "secure-proxy:8443",
+			"HTTP_PROXY":  "",
+			"http_proxy":  "",
+			"ALL_PROXY":   "socks5://all-proxy:1080",
+			"NO_PROXY":    "example.org",
+		}
+		for k, v := range envs {
+			old, ok := os.LookupEnv(k)
+			os.Setenv(k, v)
+			k := k
+			Reset(func() {
+				if ok {
+					os.Setenv(k, old)
+				} else {
+					os.Unsetenv(k)
+				}
+			})
+		}
+
+		Convey("When the config doesn't have a proxy", func() {
+			c := &OutboundConfig{}
+
+			Convey("Then HTTPS_PROXY should be used for https", func() {
+				u, err := c.ProxyURL("https", "example.com:443")
+				So(err, ShouldBeNil)
+				So(u.String(), ShouldEqual, "http://secure-proxy:8443")
+			})
+
+			Convey("Then ALL_PROXY should be used for http and other protocols", func() {
+				u, err := c.ProxyURL("http", "example.com:80")
+				So(err, ShouldBeNil)
+				So(u.String(), ShouldEqual, "socks5://all-proxy:1080")
+				u, err = c.ProxyURL("", "example.com:25")
+				So(err, ShouldBeNil)
+				So(u.String(), ShouldEqual, "socks5://all-proxy:1080")
+			})
+
+			Convey("Then NO_PROXY should be used", func() {
+				u, err := c.ProxyURL("https", "example.org:443")
+				So(err, ShouldBeNil)
+				So(u, ShouldBeNil)
+			})
+		})
+
+		Convey("When the config has a proxy and no_proxy", func() {
+			c := &OutboundConfig{
+				Proxy:   "http://proxy:8080",
+				NoProxy: "example.com",
+			}
+
+			Convey("Then the config should be used", func() {
+				u, err := c.ProxyURL("https", "example.org:443")
+				So(err, ShouldBeNil)
+				So(u.String(), ShouldEqual, "http://proxy:8080")
+				u, err = c.ProxyURL("https", "example.com:443")
+				So(err, ShouldBeNil)
+				So(u, ShouldBeNil)
+			})
+		})
+	})
+
+	Convey("Given an echo server", t, func() {
+		l := startEchoServer()
+		Reset(func() {
+			l.Close()
+		})
+
+		Convey("When dialing it through an HTTP proxy", func() {
+			p := newTestHTTPProxy(l.Addr().String())
+			Reset(p.Close)
+			c := &OutboundConfig{Proxy: "http://user:pass@" + p.Listener.Addr().String()}
+			conn, err := c.DialContext(context.Background(), "tcp", "echo.example.com:7")
+			So(err, ShouldBeNil)
+			Reset(func() {
+				conn.Close()
+			})
+
+			Convey("Then it should be connected through the tunnel", func() {
+				So(echo(conn), ShouldEqual, "hello\n")
+			})
+		})
+
+		Convey("When dialing it through an HTTP proxy without credentials", func() {
+			p := newTestHTTPProxy(l.Addr().String())
+			Reset(p.Close)
+			c := &OutboundConfig{Proxy: p.URL}
+			_, err := c.DialContext(context.Background(), "tcp", "echo.example.com:7")
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When dialing it through a SOCKS5 proxy", func() {
+			p, hosts := startTestSOCKS5Proxy(l.Addr().String())
+			Reset(func() {
+				p.Close()
+			})
+			c := &OutboundConfig{Proxy: "socks5h://user:pass@" + p.Addr().String()}
+			conn, err := c.DialContext(context.Background(), "tcp", "echo.example.com:7")
+			So(err, ShouldBeNil)
+			Reset(func() {
+				conn.Close()
+			})
+
+			Convey("Then it should be connected through the proxy", func() {
+				So(<-hosts, ShouldEqual, "echo.example.com")
+				So(echo(conn), ShouldEqual, "hello\n")
+			})
+		})
+
+		Convey("When dialing it without a proxy", func() {
+			c := &OutboundConfig{Proxy: "http://proxy.invalid:8080"}
+			conn, err := c.DialContext(context.Background(), "tcp", l.Addr().String())
+			So(err, ShouldBeNil)
+			Reset(func() {
+				conn.Close()
+			})
+
+			Convey("Then the loopback address should be connected directly", func() {
+				So(echo(conn), ShouldEqual, "hello\n")
+			})
+		})
+	})
+
+	Convey("Given a shared HTTP client using an HTTP proxy", t, func() {
+		p := newTestHTTPProxy("")
+		Reset(p.Close)
+		c, err := NewSharedHTTPClient("http://api.example.com/", 0, data.Map{
+			"proxy": data.String(p.URL),
+		})
+		So(err, ShouldBeNil)
+		Reset(func() {
+			c.Close()
+		})
+
+		Convey("When sending a request", func() {
+			res, err := c.Get("http://api.example.com/a")
+			So(err, ShouldBeNil)
+			defer res.Body.Close()
+			b, err := ioutil.ReadAll(res.Body)
+			So(err, ShouldBeNil)
+
+			Convey("Then it should be sent through the proxy", func() {
+				So(string(b), ShouldEqual, "proxied http://api.example.com/a")
+			})
+		})
+
+		Convey("Then it shouldn't share the transport with a client without the proxy", func() {
+			c2, err := NewSharedHTTPClient("http://api.example.com/", 0, data.Map{})
+			So(err, ShouldBeNil)
+			defer c2.Close()
+			So(c2.Transport, ShouldNotEqual, c.Transport)
+		})
+	})
+}
+
+func TestOutboundConfigTLS(t *testing.T) {
+	Convey("Given a TLS server", t, func() {
+		s := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("ok"))
+		}))
+		Reset(s.Close)
+
+		dir, err := ioutil.TempDir("", "sensorbee_outbound_test")
+		So(err, ShouldBeNil)
+		Reset(func() {
+			os.RemoveAll(dir)
+		})
+		caFile := filepath.Join(dir, "ca.pem")
+		So(ioutil.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{
+			Type:  "CERTIFICATE",
+			Bytes: s.Certificate().Raw,
+		}), 0644), ShouldBeNil)
+
+		get := func(params data.Map) error {
+			params["shared_client"] = data.False
+			c, err := NewSharedHTTPClient(s.URL, 0, params)
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			res, err := c.Get(s.URL)
+			if err != nil {
+				return err
+			}
+			return res.Body.Close()
+		}
+
+		Convey("When sending a request with the CA certificate", func() {
+			err := get(data.Map{"ca_cert_file": data.String(caFile)})
+
+			Convey("Then it should succeed", func() {
+				So(err, ShouldBeNil)
+			})
+		})
+
+		Convey("When sending a request without the CA certificate", func() {
+			err := get(data.Map{})
+
+			Convey("Then it should fail to verify the certificate", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When sending a request with a server name not in the certificate", func() {
+			err := get(data.Map{
+				"ca_cert_file":    data.String(caFile),
+				"tls_server_name": data.String("other.example.org"),
+			})
+
+			Convey("Then it should fail to verify the certificate", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When the global config has the CA certificate", func() {
+			So(SetGlobalOutboundConfig(&OutboundConfig{CACertFile: caFile}), ShouldBeNil)
+			Reset(func() {
+				SetGlobalOutboundConfig(&OutboundConfig{})
+			})
+
+			Convey("Then a request should succeed", func() {
+				So(get(data.Map{}), ShouldBeNil)
+			})
+		})
+	})
+
+	Convey("Given outbound configs", t, func() {
+		Convey("When creating a TLS config with the minimum version", func() {
+			c, err := NewOutboundConfig(data.Map{"tls_min_version": data.String("1.3")})
+			So(err, ShouldBeNil)
+			tc, err := c.TLSConfig()
+			So(err, ShouldBeNil)
+
+			Convey("Then it should have the version", func() {
+				So(tc.MinVersion, ShouldEqual, tls.VersionTLS13)
+			})
+		})
+
+		for _, params := range []data.Map{
+			{"tls_min_version": data.String("1.4")},
+			{"ca_cert_file": data.String("/path/to/no/such/file")},
+			{"proxy": data.String("ftp://proxy")},
+			{"no_proxy": data.String("10.0.0.0/33")},
+		} {
+			Convey("When creating a config with invalid parameters "+params.String(), func() {
+				_, err := NewOutboundConfig(params)
+
+				Convey("Then it should fail", func() {
+					So(err, ShouldNotBeNil)
+				})
+			})
+		}
+
+		Convey("When setting an invalid global config", func() {
+			err := SetGlobalOutboundConfig(&OutboundConfig{TLSMinVersion: "1"})
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+				So(GlobalOutboundConfig(), ShouldResemble, &OutboundConfig{})
+			})
+		})
+	})
+}
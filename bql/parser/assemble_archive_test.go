@@ -0,0 +1,193 @@
+package parser
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+func TestAssembleArchiveStream(t *testing.T) {
+	Convey("Given a parseStack", t, func() {
+		ps := parseStack{}
+		Convey("When the stack contains the correct ARCHIVE STREAM items", func() {
+			ps.PushComponent(15, 16, StreamIdentifier("s"))
+			ps.PushComponent(20, 27, StringLiteral{"/tmp/a"})
+			ps.PushComponent(27, 40, SourceSinkSpecsAST{[]SourceSinkParamAST{
+				{"rotate", data.String("1h"), ""},
+			}})
+			ps.AssembleArchiveStream()
+
+			Convey("Then AssembleArchiveStream transforms them into one item", func() {
+				So(ps.Len(), ShouldEqual, 1)
+
+				Convey("And that item is an ArchiveStreamStmt", func() {
+					top := ps.Peek()
+					So(top, ShouldNotBeNil)
+					So(top.begin, ShouldEqual, 15)
+					So(top.end, ShouldEqual, 40)
+					So(top.comp, ShouldHaveSameTypeAs, ArchiveStreamStmt{})
+
+					Convey("And it contains the previously pushed data", func() {
+						comp := top.comp.(ArchiveStreamStmt)
+						So(comp.Stream, ShouldEqual, "s")
+						So(comp.Dir, ShouldEqual, "/tmp/a")
+						So(len(comp.Params), ShouldEqual, 1)
+					})
+				})
+			})
+		})
+
+		Convey("When the stack contains a wrong item", func() {
+			ps.PushComponent(15, 16, StreamIdentifier("s"))
+			ps.PushComponent(20, 21, StreamIdentifier("a")) // must be StringLiteral
+			ps.PushComponent(21, 21, SourceSinkSpecsAST{})
+			Convey("Then AssembleArchiveStream panics", func() {
+				So(ps.AssembleArchiveStream, ShouldPanic)
+			})
+		})
+	})
+
+	Convey("Given a parser", t, func() {
+		p := &bqlPeg{}
+
+		Convey("When doing a full ARCHIVE STREAM", func() {
+			p.Buffer = `ARCHIVE STREAM s TO "dir/" WITH format="jsonl", rotate="1h"`
+			p.Init()
+
+			Convey("Then the statement should be parsed correctly", func() {
+				err := p.Parse()
+				So(err, ShouldBeNil)
+				p.Execute()
+
+				ps := p.parseStack
+				So(ps.Len(), ShouldEqual, 1)
+				top := ps.Peek().comp
+				So(top, ShouldHaveSameTypeAs, ArchiveStreamStmt{})
+				comp := top.(ArchiveStreamStmt)
+
+				So(comp.Stream, ShouldEqual, "s")
+				So(comp.Dir, ShouldEqual, "dir/")
+				So(comp.Params, ShouldResemble, []SourceSinkParamAST{
+					{"format", data.String("jsonl"), ""},
+					{"rotate", data.String("1h"), ""},
+				})
+
+				Convey("And String() should return the original statement", func() {
+					So(comp.String(), ShouldEqual, p.Buffer)
+				})
+			})
+		})
+
+		Convey("When doing an ARCHIVE STREAM without a directory", func() {
+			p.Buffer = "ARCHIVE STREAM s"
+			p.Init()
+
+			Convey("Then parsing should fail", func() {
+				So(p.Parse(), ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+func TestAssembleReplayArchive(t *testing.T) {
+	Convey("Given a parseStack", t, func() {
+		ps := parseStack{}
+		Convey("When the stack contains the correct REPLAY ARCHIVE items", func() {
+			ps.PushComponent(15, 22, StringLiteral{"/tmp/a"})
+			ps.PushComponent(28, 30, StreamIdentifier("s2"))
+			ps.PushComponent(35, 50, StringLiteral{"2024-05-01T00:00"})
+			ps.PushComponent(50, 50, StringLiteral{})
+			ps.PushComponent(50, 50, SourceSinkSpecsAST{})
+			ps.AssembleReplayArchive()
+
+			Convey("Then AssembleReplayArchive transforms them into one item", func() {
+				So(ps.Len(), ShouldEqual, 1)
+
+				Convey("And that item is a ReplayArchiveStmt", func() {
+					top := ps.Peek()
+					So(top, ShouldNotBeNil)
+					So(top.begin, ShouldEqual, 15)
+					So(top.end, ShouldEqual, 50)
+					So(top.comp, ShouldHaveSameTypeAs, ReplayArchiveStmt{})
+
+					Convey("And it contains the previously pushed data", func() {
+						comp := top.comp.(ReplayArchiveStmt)
+						So(comp.Dir, ShouldEqual, "/tmp/a")
+						So(comp.Stream, ShouldEqual, "s2")
+						So(comp.From, ShouldEqual, "2024-05-01T00:00")
+						So(comp.To, ShouldEqual, "")
+						So(comp.Params, ShouldBeEmpty)
+					})
+				})
+			})
+		})
+
+		Convey("When the stack does not contain enough items", func() {
+			ps.PushComponent(15, 22, StringLiteral{"/tmp/a"})
+			ps.PushComponent(28, 30, StreamIdentifier("s2"))
+			Convey("Then AssembleReplayArchive panics", func() {
+				So(ps.AssembleReplayArchive, ShouldPanic)
+			})
+		})
+	})
+
+	Convey("Given a parser", t, func() {
+		p := &bqlPeg{}
+
+		for _, c := range []struct {
+			stmt     string
+			from, to string
+		}{
+			{`REPLAY ARCHIVE "dir/" INTO s2 FROM "2024-05-01T00:00" TO "2024-05-02T00:00"`,
+				"2024-05-01T00:00", "2024-05-02T00:00"},
+			{`REPLAY ARCHIVE "dir/" INTO s2 FROM "2024-05-01T00:00"`, "2024-05-01T00:00", ""},
+			{`REPLAY ARCHIVE "dir/" INTO s2 TO "2024-05-02T00:00"`, "", "2024-05-02T00:00"},
+			{`REPLAY ARCHIVE "dir/" INTO s2`, "", ""},
+		} {
+			c := c
+			Convey("When parsing "+c.stmt, func() {
+				p.Buffer = c.stmt
+				p.Init()
+
+				Convey("Then the statement should be parsed correctly", func() {
+					err := p.Parse()
+					So(err, ShouldBeNil)
+					p.Execute()
+
+					ps := p.parseStack
+					So(ps.Len(), ShouldEqual, 1)
+					top := ps.Peek().comp
+					So(top, ShouldHaveSameTypeAs, ReplayArchiveStmt{})
+					comp := top.(ReplayArchiveStmt)
+
+					So(comp.Dir, ShouldEqual, "dir/")
+					So(comp.Stream, ShouldEqual, "s2")
+					So(comp.From, ShouldEqual, c.from)
+					So(comp.To, ShouldEqual, c.to)
+
+					Convey("And String() should return the original statement", func() {
+						So(comp.String(), ShouldEqual, p.Buffer)
+					})
+				})
+			})
+		}
+
+		Convey("When doing a REPLAY ARCHIVE with parameters", func() {
+			p.Buffer = `REPLAY ARCHIVE "dir/" INTO s2 TO "2024-05-02" WITH rewindable=true`
+			p.Init()
+
+			Convey("Then the statement should be parsed correctly", func() {
+				err := p.Parse()
+				So(err, ShouldBeNil)
+				p.Execute()
+
+				comp := p.parseStack.Peek().comp.(ReplayArchiveStmt)
+				So(comp.To, ShouldEqual, "2024-05-02")
+				So(comp.Params, ShouldResemble, []SourceSinkParamAST{
+					{"rewindable", data.True, ""},
+				})
+			})
+		})
+	})
+}
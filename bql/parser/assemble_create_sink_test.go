@@ -13,8 +13,8 @@ func TestAssembleCreateSink(t *testing.T) {
 		Convey("When the stack contains the correct CREATE SINK items", func() {
 			ps.PushComponent(2, 4, StreamIdentifier("a"))
 			ps.PushComponent(4, 6, SourceSinkType("b"))
-			ps.PushComponent(6, 8, SourceSinkParamAST{"c", data.String("d")})
-			ps.PushComponent(8, 10, SourceSinkParamAST{"e", data.String("f")})
+			ps.PushComponent(6, 8, SourceSinkParamAST{"c", data.String("d"), ""})
+			ps.PushComponent(8, 10, SourceSinkParamAST{"e", data.String("f"), ""})
 			ps.AssembleSourceSinkSpecs(6, 10)
 			ps.AssembleCreateSink()
 
@@ -53,8 +53,8 @@ func TestAssembleCreateSink(t *testing.T) {
 		Convey("When the stack contains a wrong item", func() {
 			ps.PushComponent(2, 4, Raw{"a"}) // must be StreamIdentifier
 			ps.PushComponent(4, 6, SourceSinkType("b"))
-			ps.PushComponent(6, 8, SourceSinkParamAST{"c", data.String("d")})
-			ps.PushComponent(8, 10, SourceSinkParamAST{"e", data.String("f")})
+			ps.PushComponent(6, 8, SourceSinkParamAST{"c", data.String("d"), ""})
+			ps.PushComponent(8, 10, SourceSinkParamAST{"e", data.String("f"), ""})
 			ps.AssembleSourceSinkSpecs(6, 10)
 
 			Convey("Then AssembleCreateSink panics", func() {
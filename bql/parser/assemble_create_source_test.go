@@ -14,8 +14,8 @@ func TestAssembleCreateSource(t *testing.T) {
 			ps.PushComponent(0, 2, Yes)
 			ps.PushComponent(2, 4, StreamIdentifier("a"))
 			ps.PushComponent(4, 6, SourceSinkType("b"))
-			ps.PushComponent(6, 8, SourceSinkParamAST{"c", data.String("d")})
-			ps.PushComponent(8, 10, SourceSinkParamAST{"e", data.String("f")})
+			ps.PushComponent(6, 8, SourceSinkParamAST{"c", data.String("d"), ""})
+			ps.PushComponent(8, 10, SourceSinkParamAST{"e", data.String("f"), ""})
 			ps.AssembleSourceSinkSpecs(6, 10)
 			ps.AssembleCreateSource()
 
@@ -56,8 +56,8 @@ func TestAssembleCreateSource(t *testing.T) {
 			ps.PushComponent(0, 2, Yes)
 			ps.PushComponent(2, 4, Raw{"a"}) // must be StreamIdentifier
 			ps.PushComponent(4, 6, SourceSinkType("b"))
-			ps.PushComponent(6, 8, SourceSinkParamAST{"c", data.String("d")})
-			ps.PushComponent(8, 10, SourceSinkParamAST{"e", data.String("f")})
+			ps.PushComponent(6, 8, SourceSinkParamAST{"c", data.String("d"), ""})
+			ps.PushComponent(8, 10, SourceSinkParamAST{"e", data.String("f"), ""})
 			ps.AssembleSourceSinkSpecs(6, 10)
 
 			Convey("Then AssembleCreateSource panics", func() {
@@ -0,0 +1,110 @@
+package parser
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+func TestAssembleCreateStreamAsEnrich(t *testing.T) {
+	Convey("Given a parseStack", t, func() {
+		ps := parseStack{}
+		Convey("When the stack contains the correct CREATE STREAM AS ENRICH items", func() {
+			ps.PushComponent(2, 4, StreamIdentifier("a"))
+			ps.PushComponent(4, 6, StreamIdentifier("b"))
+			ps.PushComponent(6, 8, SourceSinkType("c"))
+			ps.PushComponent(8, 10, SourceSinkParamAST{"key", data.String("k"), ""})
+			ps.AssembleSourceSinkSpecs(8, 10)
+			ps.AssembleCreateStreamAsEnrich()
+
+			Convey("Then AssembleCreateStreamAsEnrich transforms them into one item", func() {
+				So(ps.Len(), ShouldEqual, 1)
+
+				Convey("And that item is a CreateStreamAsEnrichStmt", func() {
+					top := ps.Peek()
+					So(top, ShouldNotBeNil)
+					So(top.begin, ShouldEqual, 2)
+					So(top.end, ShouldEqual, 10)
+					So(top.comp, ShouldHaveSameTypeAs, CreateStreamAsEnrichStmt{})
+
+					Convey("And it contains the previously pushed data", func() {
+						comp := top.comp.(CreateStreamAsEnrichStmt)
+						So(comp.Name, ShouldEqual, "a")
+						So(comp.Input, ShouldEqual, "b")
+						So(comp.Loader, ShouldEqual, "c")
+						So(len(comp.Params), ShouldEqual, 1)
+						So(comp.Params[0].Key, ShouldEqual, "key")
+						So(comp.Params[0].Value, ShouldEqual, data.String("k"))
+					})
+				})
+			})
+		})
+
+		Convey("When the stack does not contain enough items", func() {
+			ps.PushComponent(6, 8, SourceSinkType("c"))
+			ps.PushComponent(8, 10, SourceSinkSpecsAST{})
+			Convey("Then AssembleCreateStreamAsEnrich panics", func() {
+				So(ps.AssembleCreateStreamAsEnrich, ShouldPanic)
+			})
+		})
+
+		Convey("When the stack contains a wrong item", func() {
+			ps.PushComponent(2, 4, StreamIdentifier("a"))
+			ps.PushComponent(4, 6, StreamIdentifier("b"))
+			ps.PushComponent(6, 8, StreamIdentifier("c")) // must be SourceSinkType
+			ps.PushComponent(8, 10, SourceSinkSpecsAST{})
+			Convey("Then AssembleCreateStreamAsEnrich panics", func() {
+				So(ps.AssembleCreateStreamAsEnrich, ShouldPanic)
+			})
+		})
+	})
+
+	Convey("Given a parser", t, func() {
+		p := &bqlPeg{}
+
+		Convey("When doing a full CREATE STREAM AS ENRICH", func() {
+			p.Buffer = `CREATE STREAM users_ex AS ENRICH users USING http_loader WITH key="id", ttl=300`
+			p.Init()
+
+			Convey("Then the statement should be parsed correctly", func() {
+				err := p.Parse()
+				So(err, ShouldBeNil)
+				p.Execute()
+
+				ps := p.parseStack
+				So(ps.Len(), ShouldEqual, 1)
+				top := ps.Peek().comp
+				So(top, ShouldHaveSameTypeAs, CreateStreamAsEnrichStmt{})
+				comp := top.(CreateStreamAsEnrichStmt)
+
+				So(comp.Name, ShouldEqual, "users_ex")
+				So(comp.Input, ShouldEqual, "users")
+				So(comp.Loader, ShouldEqual, "http_loader")
+				So(len(comp.Params), ShouldEqual, 2)
+				So(comp.Params[0].Key, ShouldEqual, "key")
+				So(comp.Params[1].Key, ShouldEqual, "ttl")
+
+				Convey("And String() should return the original statement", func() {
+					So(comp.String(), ShouldEqual, p.Buffer)
+				})
+			})
+		})
+
+		Convey("When doing a CREATE STREAM AS ENRICH without WITH", func() {
+			p.Buffer = "CREATE STREAM users_ex AS ENRICH users USING uds_loader"
+			p.Init()
+
+			Convey("Then the statement should be parsed correctly", func() {
+				err := p.Parse()
+				So(err, ShouldBeNil)
+				p.Execute()
+
+				comp := p.parseStack.Peek().comp.(CreateStreamAsEnrichStmt)
+				So(comp.Loader, ShouldEqual, "uds_loader")
+				So(comp.Params, ShouldBeEmpty)
+				So(comp.String(), ShouldEqual, p.Buffer)
+			})
+		})
+	})
+}
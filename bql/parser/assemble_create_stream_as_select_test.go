@@ -2,6 +2,7 @@ package parser
 
 import (
 	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
 	"testing"
 )
 
@@ -37,6 +38,7 @@ func TestAssembleCreateStreamAsSelect(t *testing.T) {
 			ps.AssembleAliasedStreamWindow()
 			ps.EnsureAliasedStreamWindow()
 			ps.AssembleWindowedFrom(10, 20)
+			ps.AssembleDeduplicate(20, 20)
 			ps.PushComponent(20, 21, RowValue{"", "e"})
 			ps.AssembleFilter(20, 21)
 			ps.PushComponent(21, 22, RowValue{"", "f"})
@@ -45,6 +47,7 @@ func TestAssembleCreateStreamAsSelect(t *testing.T) {
 			ps.PushComponent(23, 24, RowValue{"", "h"})
 			ps.AssembleHaving(23, 24)
 			ps.AssembleSelect()
+			ps.AssembleSourceSinkSpecs(24, 24)
 			ps.AssembleCreateStreamAsSelect()
 
 			Convey("Then AssembleCreateStreamAsSelect transforms them into one item", func() {
@@ -155,5 +158,32 @@ func TestAssembleCreateStreamAsSelect(t *testing.T) {
 				})
 			})
 		})
+
+		Convey("When doing a SELECT with parameters", func() {
+			p.Buffer = `CREATE STREAM x AS SELECT ISTREAM a FROM c [RANGE 3 TUPLES] WITH retention="10m"`
+			p.Init()
+
+			Convey("Then the statement should be parsed correctly", func() {
+				err := p.Parse()
+				So(err, ShouldBeNil)
+				p.Execute()
+
+				ps := p.parseStack
+				So(ps.Len(), ShouldEqual, 1)
+				top := ps.Peek().comp
+				So(top, ShouldHaveSameTypeAs, CreateStreamAsSelectStmt{})
+				cssComp := top.(CreateStreamAsSelectStmt)
+
+				So(cssComp.Name, ShouldEqual, "x")
+				So(len(cssComp.Select.Relations), ShouldEqual, 1)
+				So(cssComp.Params, ShouldResemble, []SourceSinkParamAST{
+					{"retention", data.String("10m"), ""},
+				})
+
+				Convey("And String() should return the original statement", func() {
+					So(cssComp.String(), ShouldEqual, p.Buffer)
+				})
+			})
+		})
 	})
 }
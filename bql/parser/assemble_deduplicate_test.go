@@ -0,0 +1,148 @@
+package parser
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestAssembleDeduplicate(t *testing.T) {
+	Convey("Given a parseStack", t, func() {
+		ps := parseStack{}
+
+		Convey("When the stack contains two items in the given range", func() {
+			ps.PushComponent(0, 6, Raw{"PRE"})
+			ps.PushComponent(6, 7, RowValue{"", "a"})
+			ps.PushComponent(7, 8, IntervalAST{FloatLiteral{2}, Seconds})
+			ps.AssembleDeduplicate(6, 8)
+
+			Convey("Then AssembleDeduplicate replaces them with a new item", func() {
+				So(ps.Len(), ShouldEqual, 2)
+
+				Convey("And that item is a DeduplicateAST", func() {
+					top := ps.Peek()
+					So(top, ShouldNotBeNil)
+					So(top.begin, ShouldEqual, 6)
+					So(top.end, ShouldEqual, 8)
+					So(top.comp, ShouldHaveSameTypeAs, DeduplicateAST{})
+
+					Convey("And it contains the previous data", func() {
+						comp := top.comp.(DeduplicateAST)
+						So(comp.DedupKey, ShouldResemble, RowValue{"", "a"})
+						So(comp.Within, ShouldResemble, IntervalAST{FloatLiteral{2}, Seconds})
+					})
+				})
+			})
+		})
+
+		Convey("When the given range is empty", func() {
+			ps.PushComponent(0, 6, Raw{"PRE"})
+			ps.AssembleDeduplicate(6, 6)
+
+			Convey("Then AssembleDeduplicate pushes one item onto the stack", func() {
+				So(ps.Len(), ShouldEqual, 2)
+
+				Convey("And that item is an empty DeduplicateAST", func() {
+					top := ps.Peek()
+					So(top, ShouldNotBeNil)
+					So(top.begin, ShouldEqual, 6)
+					So(top.end, ShouldEqual, 6)
+					So(top.comp, ShouldResemble, DeduplicateAST{})
+				})
+			})
+		})
+
+		Convey("When the stack contains items not in the given range", func() {
+			ps.PushComponent(0, 6, Raw{"PRE"})
+			ps.PushComponent(6, 7, RowValue{"", "a"})
+			ps.PushComponent(7, 8, IntervalAST{FloatLiteral{2}, Seconds})
+			f := func() {
+				ps.AssembleDeduplicate(7, 8)
+			}
+			Convey("Then AssembleDeduplicate panics", func() {
+				So(f, ShouldPanic)
+			})
+		})
+	})
+
+	Convey("Given a parser", t, func() {
+		p := &bqlPeg{}
+
+		Convey("When selecting without a DEDUPLICATE BY", func() {
+			p.Buffer = "SELECT ISTREAM a, b FROM c [RANGE 1 TUPLES] WHERE a"
+			p.Init()
+
+			Convey("Then the statement should be parsed correctly", func() {
+				err := p.Parse()
+				So(err, ShouldBeNil)
+				p.Execute()
+
+				ps := p.parseStack
+				So(ps.Len(), ShouldEqual, 1)
+				top := ps.Peek().comp
+				So(top, ShouldHaveSameTypeAs, SelectStmt{})
+				s := top.(SelectStmt)
+				So(s.DedupKey, ShouldBeNil)
+
+				Convey("And String() should return the original statement", func() {
+					So(s.String(), ShouldEqual, p.Buffer)
+				})
+			})
+		})
+
+		Convey("When selecting with a DEDUPLICATE BY", func() {
+			p.Buffer = "SELECT ISTREAM a, b FROM c [RANGE 1 TUPLES] DEDUPLICATE BY c:id WITHIN 10 SECONDS WHERE a"
+			p.Init()
+
+			Convey("Then the statement should be parsed correctly", func() {
+				err := p.Parse()
+				So(err, ShouldBeNil)
+				p.Execute()
+
+				ps := p.parseStack
+				So(ps.Len(), ShouldEqual, 1)
+				top := ps.Peek().comp
+				So(top, ShouldHaveSameTypeAs, SelectStmt{})
+				s := top.(SelectStmt)
+				So(s.DedupKey, ShouldResemble, RowValue{"c", "id"})
+				So(s.Within, ShouldResemble, IntervalAST{FloatLiteral{10}, Seconds})
+				So(s.Filter, ShouldResemble, RowValue{"", "a"})
+
+				Convey("And String() should return the original statement", func() {
+					So(s.String(), ShouldEqual, p.Buffer)
+				})
+			})
+		})
+
+		Convey("When selecting with a DEDUPLICATE BY a tuple count", func() {
+			p.Buffer = "SELECT ISTREAM a FROM c [RANGE 1 TUPLES] DEDUPLICATE BY a WITHIN 100 TUPLES"
+			p.Init()
+
+			Convey("Then the statement should be parsed correctly", func() {
+				err := p.Parse()
+				So(err, ShouldBeNil)
+				p.Execute()
+
+				ps := p.parseStack
+				So(ps.Len(), ShouldEqual, 1)
+				top := ps.Peek().comp
+				So(top, ShouldHaveSameTypeAs, SelectStmt{})
+				s := top.(SelectStmt)
+				So(s.DedupKey, ShouldResemble, RowValue{"", "a"})
+				So(s.Within, ShouldResemble, IntervalAST{FloatLiteral{100}, Tuples})
+
+				Convey("And String() should return the original statement", func() {
+					So(s.String(), ShouldEqual, p.Buffer)
+				})
+			})
+		})
+
+		Convey("When selecting with an incomplete DEDUPLICATE BY", func() {
+			p.Buffer = "SELECT ISTREAM a FROM c [RANGE 1 TUPLES] DEDUPLICATE BY a"
+			p.Init()
+
+			Convey("Then parsing should fail", func() {
+				So(p.Parse(), ShouldNotBeNil)
+			})
+		})
+	})
+}
@@ -0,0 +1,85 @@
+package parser
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestAssembleExplainAnalyze(t *testing.T) {
+	Convey("Given a parser", t, func() {
+		p := &bqlPeg{}
+
+		Convey("When doing an EXPLAIN ANALYZE without FOR", func() {
+			p.Buffer = `EXPLAIN ANALYZE SELECT RSTREAM a FROM c [RANGE 1 TUPLES] WHERE b`
+			p.Init()
+
+			Convey("Then the statement should be parsed correctly", func() {
+				err := p.Parse()
+				So(err, ShouldBeNil)
+				p.Execute()
+
+				ps := p.parseStack
+				So(ps.Len(), ShouldEqual, 1)
+				top := ps.Peek().comp
+				So(top, ShouldHaveSameTypeAs, ExplainAnalyzeStmt{})
+				comp := top.(ExplainAnalyzeStmt)
+
+				So(comp.EmitterType, ShouldEqual, Rstream)
+				So(len(comp.Relations), ShouldEqual, 1)
+				So(comp.Relations[0].Name, ShouldEqual, "c")
+				So(comp.Filter, ShouldResemble, RowValue{"", "b"})
+				So(comp.Limit, ShouldResemble, IntervalAST{})
+
+				Convey("And String() should return the original statement", func() {
+					So(comp.String(), ShouldEqual, p.Buffer)
+				})
+			})
+		})
+
+		Convey("When doing an EXPLAIN ANALYZE FOR a number of tuples", func() {
+			p.Buffer = `EXPLAIN ANALYZE FOR 100 TUPLES SELECT ISTREAM count(*) FROM c [RANGE 10 TUPLES]`
+			p.Init()
+
+			Convey("Then the statement should be parsed correctly", func() {
+				err := p.Parse()
+				So(err, ShouldBeNil)
+				p.Execute()
+
+				ps := p.parseStack
+				So(ps.Len(), ShouldEqual, 1)
+				comp := ps.Peek().comp.(ExplainAnalyzeStmt)
+				So(comp.EmitterType, ShouldEqual, Istream)
+				So(comp.Limit, ShouldResemble, IntervalAST{FloatLiteral{100}, Tuples})
+
+				Convey("And String() should return the original statement", func() {
+					So(comp.String(), ShouldEqual, p.Buffer)
+				})
+			})
+		})
+
+		Convey("When doing an EXPLAIN ANALYZE FOR a duration", func() {
+			p.Buffer = `EXPLAIN ANALYZE FOR 1.5 SECONDS SELECT RSTREAM a FROM c [RANGE 1 TUPLES]`
+			p.Init()
+
+			Convey("Then the statement should be parsed correctly", func() {
+				err := p.Parse()
+				So(err, ShouldBeNil)
+				p.Execute()
+
+				ps := p.parseStack
+				So(ps.Len(), ShouldEqual, 1)
+				comp := ps.Peek().comp.(ExplainAnalyzeStmt)
+				So(comp.Limit, ShouldResemble, IntervalAST{FloatLiteral{1.5}, Seconds})
+			})
+		})
+
+		Convey("When doing an EXPLAIN ANALYZE of a statement other than SELECT", func() {
+			p.Buffer = `EXPLAIN ANALYZE EVAL 1`
+			p.Init()
+
+			Convey("Then parsing should fail", func() {
+				So(p.Parse(), ShouldNotBeNil)
+			})
+		})
+	})
+}
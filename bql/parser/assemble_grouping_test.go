@@ -128,5 +128,32 @@ func TestAssembleGrouping(t *testing.T) {
 				})
 			})
 		})
+
+		Convey("When selecting with GROUPING SETS", func() {
+			p.Buffer = "SELECT ISTREAM a, b GROUP BY GROUPING SETS ((a), (a, b), ())"
+			p.Init()
+
+			Convey("Then the statement should be parsed correctly", func() {
+				err := p.Parse()
+				So(err, ShouldBeNil)
+				p.Execute()
+
+				ps := p.parseStack
+				So(ps.Len(), ShouldEqual, 1)
+				top := ps.Peek().comp
+				So(top, ShouldHaveSameTypeAs, SelectStmt{})
+				s := top.(SelectStmt)
+				So(s.GroupList, ShouldResemble, []Expression{RowValue{"", "a"}, RowValue{"", "b"}})
+				So(s.GroupingSets, ShouldResemble, [][]Expression{
+					{RowValue{"", "a"}},
+					{RowValue{"", "a"}, RowValue{"", "b"}},
+					{},
+				})
+
+				Convey("And String() should return the original statement", func() {
+					So(s.String(), ShouldEqual, p.Buffer)
+				})
+			})
+		})
 	})
 }
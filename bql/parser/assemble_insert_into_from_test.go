@@ -2,6 +2,7 @@ package parser
 
 import (
 	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
 	"testing"
 )
 
@@ -10,7 +11,10 @@ func TestAssembleInsertIntoFrom(t *testing.T) {
 		ps := parseStack{}
 		Convey("When the stack contains the correct SELECT items with a Interval specification", func() {
 			ps.PushComponent(4, 5, StreamIdentifier("x"))
-			ps.PushComponent(5, 6, StreamIdentifier("y"))
+			ps.PushComponent(5, 6, []StreamIdentifier{"y", "z"})
+			ps.PushComponent(6, 7, SourceSinkSpecsAST{[]SourceSinkParamAST{
+				{"routing_field", data.String("origin"), ""},
+			}})
 			ps.AssembleInsertIntoFrom()
 
 			Convey("Then AssembleInsertIntoFrom transforms them into one item", func() {
@@ -20,13 +24,16 @@ func TestAssembleInsertIntoFrom(t *testing.T) {
 					top := ps.Peek()
 					So(top, ShouldNotBeNil)
 					So(top.begin, ShouldEqual, 4)
-					So(top.end, ShouldEqual, 6)
+					So(top.end, ShouldEqual, 7)
 					So(top.comp, ShouldHaveSameTypeAs, InsertIntoFromStmt{})
 
 					Convey("And it contains the previously pushed data", func() {
 						comp := top.comp.(InsertIntoFromStmt)
 						So(comp.Sink, ShouldEqual, "x")
-						So(comp.Input, ShouldEqual, "y")
+						So(comp.Inputs, ShouldResemble, []StreamIdentifier{"y", "z"})
+						So(comp.Params, ShouldResemble, []SourceSinkParamAST{
+							{"routing_field", data.String("origin"), ""},
+						})
 					})
 				})
 			})
@@ -34,6 +41,7 @@ func TestAssembleInsertIntoFrom(t *testing.T) {
 
 		Convey("When the stack does not contain enough items", func() {
 			ps.PushComponent(4, 5, StreamIdentifier("x"))
+			ps.PushComponent(5, 6, []StreamIdentifier{"y"})
 			Convey("Then AssembleInsertIntoFrom panics", func() {
 				So(ps.AssembleInsertIntoFrom, ShouldPanic)
 			})
@@ -41,7 +49,8 @@ func TestAssembleInsertIntoFrom(t *testing.T) {
 
 		Convey("When the stack contains a wrong item", func() {
 			ps.PushComponent(4, 5, StreamIdentifier("x"))
-			ps.PushComponent(5, 6, Istream) // must be StreamIdentifier
+			ps.PushComponent(5, 6, StreamIdentifier("y")) // must be []StreamIdentifier
+			ps.PushComponent(6, 7, SourceSinkSpecsAST{})
 			Convey("Then AssembleInsertIntoFrom panics", func() {
 				So(ps.AssembleInsertIntoFrom, ShouldPanic)
 			})
@@ -69,7 +78,33 @@ func TestAssembleInsertIntoFrom(t *testing.T) {
 				comp := top.(InsertIntoFromStmt)
 
 				So(comp.Sink, ShouldEqual, "x")
-				So(comp.Input, ShouldEqual, "y")
+				So(comp.Inputs, ShouldResemble, []StreamIdentifier{"y"})
+				So(comp.Params, ShouldBeEmpty)
+				So(comp.String(), ShouldEqual, p.Buffer)
+			})
+		})
+
+		Convey("When doing an INSERT INTO FROM with multiple inputs", func() {
+			p.Buffer = "INSERT INTO x FROM y, z WITH routing_field=\"origin\""
+			p.Init()
+
+			Convey("Then the statement should be parsed correctly", func() {
+				err := p.Parse()
+				So(err, ShouldBeNil)
+				p.Execute()
+
+				ps := p.parseStack
+				So(ps.Len(), ShouldEqual, 1)
+				top := ps.Peek().comp
+				So(top, ShouldHaveSameTypeAs, InsertIntoFromStmt{})
+				comp := top.(InsertIntoFromStmt)
+
+				So(comp.Sink, ShouldEqual, "x")
+				So(comp.Inputs, ShouldResemble, []StreamIdentifier{"y", "z"})
+				So(comp.Params, ShouldResemble, []SourceSinkParamAST{
+					{"routing_field", data.String("origin"), ""},
+				})
+				So(comp.String(), ShouldEqual, "INSERT INTO x FROM y, z WITH routing_field=\"origin\"")
 			})
 		})
 	})
@@ -0,0 +1,102 @@
+package parser
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestAssembleInsertIntoSelect(t *testing.T) {
+	Convey("Given a parseStack", t, func() {
+		ps := parseStack{}
+		Convey("When the stack contains the correct INSERT INTO SELECT items", func() {
+			ps.PushComponent(2, 4, StreamIdentifier("x"))
+			ps.PushComponent(4, 6, Istream)
+			ps.AssembleEmitterOptions(6, 6)
+			ps.AssembleEmitter()
+			ps.PushComponent(6, 7, RowValue{"", "a"})
+			ps.AssembleProjections(6, 7)
+			ps.PushComponent(7, 8, Stream{ActualStream, "c", nil})
+			ps.PushComponent(8, 9, IntervalAST{FloatLiteral{3}, Tuples})
+			ps.EnsureCapacitySpec(9, 9)
+			ps.EnsureSheddingSpec(9, 9)
+			ps.AssembleStreamWindow()
+			ps.EnsureAliasedStreamWindow()
+			ps.AssembleWindowedFrom(7, 9)
+			ps.AssembleDeduplicate(9, 9)
+			ps.AssembleFilter(9, 9)
+			ps.AssembleGrouping(9, 9)
+			ps.AssembleHaving(9, 9)
+			ps.AssembleSelect()
+			ps.AssembleInsertIntoSelect()
+
+			Convey("Then AssembleInsertIntoSelect transforms them into one item", func() {
+				So(ps.Len(), ShouldEqual, 1)
+
+				Convey("And that item is a InsertIntoSelectStmt", func() {
+					top := ps.Peek()
+					So(top, ShouldNotBeNil)
+					So(top.begin, ShouldEqual, 2)
+					So(top.end, ShouldEqual, 9)
+					So(top.comp, ShouldHaveSameTypeAs, InsertIntoSelectStmt{})
+
+					Convey("And it contains the previously pushed data", func() {
+						comp := top.comp.(InsertIntoSelectStmt)
+						So(comp.Sink, ShouldEqual, "x")
+						So(comp.Select.EmitterType, ShouldEqual, Istream)
+						So(comp.Select.Projections, ShouldResemble, []Expression{RowValue{"", "a"}})
+						So(len(comp.Select.Relations), ShouldEqual, 1)
+						So(comp.Select.Relations[0].Name, ShouldEqual, "c")
+					})
+				})
+			})
+		})
+
+		Convey("When the stack contains a wrong item", func() {
+			ps.PushComponent(2, 4, StreamIdentifier("x"))
+			ps.PushComponent(4, 6, Istream) // must be SELECT in correct stmt
+
+			Convey("Then AssembleInsertIntoSelect panics", func() {
+				So(ps.AssembleInsertIntoSelect, ShouldPanic)
+			})
+		})
+	})
+
+	Convey("Given a parser", t, func() {
+		p := &bqlPeg{}
+
+		Convey("When doing a full INSERT INTO SELECT", func() {
+			p.Buffer = "INSERT INTO x SELECT ISTREAM a, b AS c FROM y [RANGE 2 SECONDS] WHERE a > 1"
+			p.Init()
+
+			Convey("Then the statement should be parsed correctly", func() {
+				err := p.Parse()
+				So(err, ShouldBeNil)
+				p.Execute()
+
+				ps := p.parseStack
+				So(ps.Len(), ShouldEqual, 1)
+				top := ps.Peek().comp
+				So(top, ShouldHaveSameTypeAs, InsertIntoSelectStmt{})
+				comp := top.(InsertIntoSelectStmt)
+
+				So(comp.Sink, ShouldEqual, "x")
+				So(comp.Select.EmitterType, ShouldEqual, Istream)
+				So(len(comp.Select.Projections), ShouldEqual, 2)
+				So(comp.Select.Relations[0].Name, ShouldEqual, "y")
+				So(comp.String(), ShouldEqual, p.Buffer)
+			})
+		})
+
+		Convey("When doing an INSERT INTO FROM", func() {
+			p.Buffer = "INSERT INTO x FROM y"
+			p.Init()
+
+			Convey("Then it shouldn't be parsed as INSERT INTO SELECT", func() {
+				err := p.Parse()
+				So(err, ShouldBeNil)
+				p.Execute()
+				So(p.parseStack.Peek().comp, ShouldHaveSameTypeAs, InsertIntoFromStmt{})
+			})
+		})
+	})
+}
@@ -0,0 +1,140 @@
+package parser
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestAssembleJoin(t *testing.T) {
+	Convey("Given a parseStack", t, func() {
+		ps := parseStack{}
+		left := AliasedStreamWindowAST{StreamWindowAST{Stream{ActualStream, "a", nil},
+			IntervalAST{FloatLiteral{2}, Tuples}, UnspecifiedCapacity, UnspecifiedSheddingOption, nil}, ""}
+		right := AliasedStreamWindowAST{StreamWindowAST{Stream{ActualStream, "b", nil},
+			IntervalAST{FloatLiteral{3}, Tuples}, UnspecifiedCapacity, UnspecifiedSheddingOption, nil}, "c"}
+		on := BinaryOpAST{Equal, RowValue{"a", "x"}, RowValue{"c", "x"}}
+
+		Convey("When the stack contains the components of a LEFT OUTER JOIN", func() {
+			ps.PushComponent(0, 6, Raw{"PRE"})
+			ps.PushComponent(6, 8, left)
+			ps.PushComponent(8, 10, LeftOuterJoin)
+			ps.PushComponent(10, 12, right)
+			ps.PushComponent(12, 14, on)
+			ps.AssembleJoin()
+
+			Convey("Then AssembleJoin transforms them into one item", func() {
+				So(ps.Len(), ShouldEqual, 2)
+
+				Convey("And that item is a WindowedFromAST", func() {
+					top := ps.Peek()
+					So(top, ShouldNotBeNil)
+					So(top.begin, ShouldEqual, 6)
+					So(top.end, ShouldEqual, 14)
+					So(top.comp, ShouldResemble, WindowedFromAST{
+						[]AliasedStreamWindowAST{left, right}, &JoinAST{LeftOuterJoin, on}})
+				})
+			})
+		})
+
+		Convey("When the stack contains the components of a JOIN without a type", func() {
+			ps.PushComponent(6, 8, left)
+			ps.PushComponent(10, 12, right)
+			ps.PushComponent(12, 14, on)
+			ps.AssembleJoin()
+
+			Convey("Then the join should be an inner join", func() {
+				So(ps.Len(), ShouldEqual, 1)
+				comp := ps.Peek().comp.(WindowedFromAST)
+				So(comp.Join, ShouldResemble, &JoinAST{InnerJoin, on})
+			})
+		})
+
+		Convey("When the stack contains a wrong item", func() {
+			ps.PushComponent(6, 8, left)
+			ps.PushComponent(8, 10, LeftOuterJoin)
+			ps.PushComponent(12, 14, on)
+
+			Convey("Then AssembleJoin panics", func() {
+				So(func() { ps.AssembleJoin() }, ShouldPanic)
+			})
+		})
+	})
+
+	Convey("Given a parser", t, func() {
+		p := &bqlPeg{}
+
+		for _, c := range []struct {
+			stmt     string
+			typ      JoinType
+			original string
+		}{
+			{
+				`SELECT RSTREAM a:x, b:y FROM a [RANGE 2 TUPLES] JOIN b [RANGE 3 TUPLES] ON a:x = b:x`,
+				InnerJoin,
+				`SELECT RSTREAM a:x, b:y FROM a [RANGE 2 TUPLES] INNER JOIN b [RANGE 3 TUPLES] ON a:x = b:x`,
+			},
+			{
+				`SELECT RSTREAM a:x, b:y FROM a [RANGE 2 TUPLES] inner join b [RANGE 3 TUPLES] on a:x = b:x`,
+				InnerJoin,
+				`SELECT RSTREAM a:x, b:y FROM a [RANGE 2 TUPLES] INNER JOIN b [RANGE 3 TUPLES] ON a:x = b:x`,
+			},
+			{
+				`SELECT RSTREAM a:x, b:y FROM a [RANGE 2 TUPLES] LEFT JOIN b [RANGE 3 TUPLES] ON a:x = b:x`,
+				LeftOuterJoin,
+				`SELECT RSTREAM a:x, b:y FROM a [RANGE 2 TUPLES] LEFT OUTER JOIN b [RANGE 3 TUPLES] ON a:x = b:x`,
+			},
+			{
+				`SELECT RSTREAM a:x, b:y FROM a [RANGE 2 TUPLES] RIGHT OUTER JOIN b [RANGE 3 TUPLES] ON a:x = b:x`,
+				RightOuterJoin,
+				`SELECT RSTREAM a:x, b:y FROM a [RANGE 2 TUPLES] RIGHT OUTER JOIN b [RANGE 3 TUPLES] ON a:x = b:x`,
+			},
+			{
+				`SELECT RSTREAM l:x, r:y FROM a [RANGE 2 TUPLES] AS l FULL OUTER JOIN a [RANGE 3 TUPLES] AS r ON l:x = r:x WHERE l:y > 1`,
+				FullOuterJoin,
+				`SELECT RSTREAM l:x, r:y FROM a [RANGE 2 TUPLES] AS l FULL OUTER JOIN a [RANGE 3 TUPLES] AS r ON l:x = r:x WHERE l:y > 1`,
+			},
+		} {
+			c := c
+			Convey("When parsing "+c.stmt, func() {
+				p.Buffer = c.stmt
+				p.Init()
+
+				Convey("Then the statement should be parsed correctly", func() {
+					err := p.Parse()
+					So(err, ShouldBeNil)
+					p.Execute()
+
+					ps := p.parseStack
+					So(ps.Len(), ShouldEqual, 1)
+					top := ps.Peek().comp
+					So(top, ShouldHaveSameTypeAs, SelectStmt{})
+					comp := top.(SelectStmt)
+					So(len(comp.Relations), ShouldEqual, 2)
+					So(comp.Join, ShouldNotBeNil)
+					So(comp.Join.Type, ShouldEqual, c.typ)
+
+					Convey("And String() should return the normalized statement", func() {
+						So(comp.String(), ShouldEqual, c.original)
+					})
+				})
+			})
+		}
+
+		for _, stmt := range []string{
+			`SELECT RSTREAM * FROM a [RANGE 2 TUPLES] LEFT JOIN b [RANGE 3 TUPLES]`,
+			`SELECT RSTREAM * FROM a [RANGE 2 TUPLES] OUTER JOIN b [RANGE 3 TUPLES] ON a:x = b:x`,
+			`SELECT RSTREAM * FROM a [RANGE 2 TUPLES] LEFT JOIN b [RANGE 3 TUPLES] ON a:x = b:x, c [RANGE 1 TUPLES]`,
+		} {
+			stmt := stmt
+			Convey("When parsing a wrong JOIN clause: "+stmt, func() {
+				p.Buffer = stmt
+				p.Init()
+
+				Convey("Then it should fail", func() {
+					So(p.Parse(), ShouldNotBeNil)
+				})
+			})
+		}
+	})
+}
@@ -13,12 +13,12 @@ func TestAssembleLoadStateOrCreate(t *testing.T) {
 			ps.PushComponent(2, 4, StreamIdentifier("a"))
 			ps.PushComponent(4, 6, SourceSinkType("b"))
 			ps.EnsureIdentifier(6, 6)
-			ps.PushComponent(6, 8, SourceSinkParamAST{"c", data.String("d")})
-			ps.PushComponent(8, 10, SourceSinkParamAST{"e", data.String("f")})
+			ps.PushComponent(6, 8, SourceSinkParamAST{"c", data.String("d"), ""})
+			ps.PushComponent(8, 10, SourceSinkParamAST{"e", data.String("f"), ""})
 			ps.AssembleSourceSinkSpecs(6, 10)
 			ps.AssembleLoadState()
-			ps.PushComponent(11, 13, SourceSinkParamAST{"g", data.String("h")})
-			ps.PushComponent(14, 15, SourceSinkParamAST{"i", data.String("j")})
+			ps.PushComponent(11, 13, SourceSinkParamAST{"g", data.String("h"), ""})
+			ps.PushComponent(14, 15, SourceSinkParamAST{"i", data.String("j"), ""})
 			ps.AssembleSourceSinkSpecs(11, 15)
 			ps.AssembleLoadStateOrCreate()
 
@@ -57,12 +57,12 @@ func TestAssembleLoadStateOrCreate(t *testing.T) {
 			ps.PushComponent(4, 5, SourceSinkType("b"))
 			ps.PushComponent(5, 6, Identifier("t"))
 			ps.EnsureIdentifier(5, 6)
-			ps.PushComponent(6, 8, SourceSinkParamAST{"c", data.String("d")})
-			ps.PushComponent(8, 10, SourceSinkParamAST{"e", data.String("f")})
+			ps.PushComponent(6, 8, SourceSinkParamAST{"c", data.String("d"), ""})
+			ps.PushComponent(8, 10, SourceSinkParamAST{"e", data.String("f"), ""})
 			ps.AssembleSourceSinkSpecs(6, 10)
 			ps.AssembleLoadState()
-			ps.PushComponent(11, 13, SourceSinkParamAST{"g", data.String("h")})
-			ps.PushComponent(14, 15, SourceSinkParamAST{"i", data.String("j")})
+			ps.PushComponent(11, 13, SourceSinkParamAST{"g", data.String("h"), ""})
+			ps.PushComponent(14, 15, SourceSinkParamAST{"i", data.String("j"), ""})
 			ps.AssembleSourceSinkSpecs(11, 15)
 			ps.AssembleLoadStateOrCreate()
 
@@ -107,8 +107,8 @@ func TestAssembleLoadStateOrCreate(t *testing.T) {
 		Convey("When the stack contains a wrong item", func() {
 			ps.PushComponent(2, 4, Raw{"a"}) // must be StreamIdentifier
 			ps.PushComponent(4, 6, SourceSinkType("b"))
-			ps.PushComponent(6, 8, SourceSinkParamAST{"c", data.String("d")})
-			ps.PushComponent(8, 10, SourceSinkParamAST{"e", data.String("f")})
+			ps.PushComponent(6, 8, SourceSinkParamAST{"c", data.String("d"), ""})
+			ps.PushComponent(8, 10, SourceSinkParamAST{"e", data.String("f"), ""})
 			ps.AssembleSourceSinkSpecs(6, 10)
 
 			Convey("Then AssembleLoadStateOrCreate panics", func() {
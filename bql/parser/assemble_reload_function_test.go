@@ -0,0 +1,59 @@
+package parser
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestAssembleReloadFunction(t *testing.T) {
+	Convey("Given a parser", t, func() {
+		p := &bqlPeg{}
+
+		Convey("When doing a RELOAD FUNCTION", func() {
+			p.Buffer = "RELOAD FUNCTION normalize"
+			p.Init()
+
+			Convey("Then the statement should be parsed correctly", func() {
+				err := p.Parse()
+				So(err, ShouldBeNil)
+				p.Execute()
+
+				ps := p.parseStack
+				So(ps.Len(), ShouldEqual, 1)
+				top := ps.Peek().comp
+				So(top, ShouldHaveSameTypeAs, ReloadFunctionStmt{})
+				comp := top.(ReloadFunctionStmt)
+
+				So(comp.Function, ShouldEqual, "normalize")
+
+				Convey("And String() should return the original statement", func() {
+					So(comp.String(), ShouldEqual, p.Buffer)
+				})
+			})
+		})
+
+		Convey("When doing a RELOAD FUNCTION in lower case", func() {
+			p.Buffer = "reload  function f"
+			p.Init()
+
+			Convey("Then the statement should be parsed correctly", func() {
+				err := p.Parse()
+				So(err, ShouldBeNil)
+				p.Execute()
+
+				ps := p.parseStack
+				So(ps.Len(), ShouldEqual, 1)
+				So(ps.Peek().comp, ShouldResemble, ReloadFunctionStmt{"f"})
+			})
+		})
+
+		Convey("When doing a RELOAD FUNCTION without a name", func() {
+			p.Buffer = "RELOAD FUNCTION"
+			p.Init()
+
+			Convey("Then the statement should not be parsed", func() {
+				So(p.Parse(), ShouldNotBeNil)
+			})
+		})
+	})
+}
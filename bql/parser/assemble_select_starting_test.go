@@ -0,0 +1,65 @@
+package parser
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestAssembleSelectStarting(t *testing.T) {
+	Convey("Given a parser", t, func() {
+		p := &bqlPeg{}
+
+		Convey("When doing a SELECT with STARTING", func() {
+			p.Buffer = `SELECT RSTREAM a FROM c [RANGE 1 TUPLES] WHERE b STARTING 5 MINUTES AGO`
+			p.Init()
+
+			Convey("Then the statement should be parsed correctly", func() {
+				err := p.Parse()
+				So(err, ShouldBeNil)
+				p.Execute()
+
+				ps := p.parseStack
+				So(ps.Len(), ShouldEqual, 1)
+				top := ps.Peek().comp
+				So(top, ShouldHaveSameTypeAs, SelectStartingStmt{})
+				comp := top.(SelectStartingStmt)
+
+				So(comp.EmitterType, ShouldEqual, Rstream)
+				So(len(comp.Relations), ShouldEqual, 1)
+				So(comp.Relations[0].Name, ShouldEqual, "c")
+				So(comp.Filter, ShouldResemble, RowValue{"", "b"})
+				So(comp.Starting, ShouldResemble, IntervalAST{FloatLiteral{300}, Seconds})
+
+				Convey("And String() should return the statement in seconds", func() {
+					So(comp.String(), ShouldEqual,
+						`SELECT RSTREAM a FROM c [RANGE 1 TUPLES] WHERE b STARTING 300 SECONDS AGO`)
+				})
+			})
+		})
+
+		Convey("When doing a SELECT with STARTING in milliseconds", func() {
+			p.Buffer = `SELECT RSTREAM a FROM c [RANGE 1 TUPLES] STARTING 1.5 MILLISECONDS AGO`
+			p.Init()
+
+			Convey("Then the statement should be parsed correctly", func() {
+				err := p.Parse()
+				So(err, ShouldBeNil)
+				p.Execute()
+
+				ps := p.parseStack
+				So(ps.Len(), ShouldEqual, 1)
+				comp := ps.Peek().comp.(SelectStartingStmt)
+				So(comp.Starting, ShouldResemble, IntervalAST{FloatLiteral{1.5}, Milliseconds})
+			})
+		})
+
+		Convey("When doing a SELECT with STARTING without AGO", func() {
+			p.Buffer = `SELECT RSTREAM a FROM c [RANGE 1 TUPLES] STARTING 5 MINUTES`
+			p.Init()
+
+			Convey("Then parsing should fail", func() {
+				So(p.Parse(), ShouldNotBeNil)
+			})
+		})
+	})
+}
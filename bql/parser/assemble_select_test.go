@@ -35,6 +35,7 @@ func TestAssembleSelect(t *testing.T) {
 			ps.AssembleAliasedStreamWindow()
 			ps.EnsureAliasedStreamWindow()
 			ps.AssembleWindowedFrom(10, 20)
+			ps.AssembleDeduplicate(20, 20)
 			ps.PushComponent(22, 24, RowValue{"", "e"})
 			ps.AssembleFilter(22, 24)
 			ps.PushComponent(24, 26, RowValue{"", "f"})
@@ -118,6 +119,7 @@ func TestAssembleSelect(t *testing.T) {
 			ps.AssembleAliasedStreamWindow()
 			ps.EnsureAliasedStreamWindow()
 			ps.AssembleWindowedFrom(10, 20)
+			ps.AssembleDeduplicate(20, 20)
 			ps.PushComponent(22, 24, RowValue{"", "e"})
 			ps.AssembleFilter(22, 24)
 			ps.PushComponent(24, 26, RowValue{"", "f"})
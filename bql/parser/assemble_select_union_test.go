@@ -111,6 +111,38 @@ func TestAssembleSelectUnion(t *testing.T) {
 			})
 		})
 
+		Convey("When working with SELECT statements combined by UNION", func() {
+			p.Buffer = "SELECT ISTREAM a FROM x [RANGE 1 TUPLES] UNION SELECT ISTREAM a FROM y [RANGE 1 TUPLES]"
+			p.Init()
+
+			Convey("Then the statement should be parsed as an aligned union", func() {
+				err := p.Parse()
+				So(err, ShouldBeNil)
+				p.Execute()
+
+				ps := p.parseStack
+				So(ps.Len(), ShouldEqual, 1)
+				top := ps.Peek().comp
+				So(top, ShouldHaveSameTypeAs, SelectUnionStmt{})
+				s := top.(SelectUnionStmt)
+				So(len(s.Selects), ShouldEqual, 2)
+				So(s.Aligned, ShouldBeTrue)
+
+				Convey("And String() should return the original statement", func() {
+					So(s.String(), ShouldEqual, p.Buffer)
+				})
+			})
+		})
+
+		Convey("When mixing UNION and UNION ALL", func() {
+			p.Buffer = "SELECT ISTREAM a UNION ALL SELECT ISTREAM b UNION SELECT ISTREAM c"
+			p.Init()
+
+			Convey("Then the statement should be rejected", func() {
+				So(p.Parse(), ShouldNotBeNil)
+			})
+		})
+
 		Convey("When working with more than two SELECT statements", func() {
 			p.Buffer = "SELECT ISTREAM a UNION ALL SELECT DSTREAM b UNION ALL SELECT RSTREAM c"
 			p.Init()
@@ -0,0 +1,118 @@
+package parser
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"testing"
+)
+
+func TestAssembleSetConstant(t *testing.T) {
+	Convey("Given a parser", t, func() {
+		p := &bqlPeg{}
+
+		Convey("When doing a SET CONSTANT", func() {
+			p.Buffer = "SET CONSTANT threshold = 1.5 * 2"
+			p.Init()
+
+			Convey("Then the statement should be parsed correctly", func() {
+				err := p.Parse()
+				So(err, ShouldBeNil)
+				p.Execute()
+
+				ps := p.parseStack
+				So(ps.Len(), ShouldEqual, 1)
+				top := ps.Peek().comp
+				So(top, ShouldHaveSameTypeAs, SetConstantStmt{})
+				comp := top.(SetConstantStmt)
+
+				So(comp.Name, ShouldEqual, "threshold")
+				So(comp.Value, ShouldResemble, BinaryOpAST{Multiply,
+					FloatLiteral{1.5}, NumericLiteral{2}})
+
+				Convey("And String() should return the original statement", func() {
+					So(comp.String(), ShouldEqual, p.Buffer)
+				})
+			})
+		})
+
+		Convey("When doing a SET CONSTANT referring to another constant", func() {
+			p.Buffer = "set constant b = $a"
+			p.Init()
+
+			Convey("Then the statement should be parsed correctly", func() {
+				err := p.Parse()
+				So(err, ShouldBeNil)
+				p.Execute()
+
+				ps := p.parseStack
+				So(ps.Len(), ShouldEqual, 1)
+				top := ps.Peek().comp
+				So(top, ShouldHaveSameTypeAs, SetConstantStmt{})
+				So(top.(SetConstantStmt).Value, ShouldResemble, ConstantRef{"a"})
+			})
+		})
+
+		Convey("When doing a SET CONSTANT without a value", func() {
+			p.Buffer = "SET CONSTANT a ="
+			p.Init()
+
+			Convey("Then the statement should not be parsed", func() {
+				So(p.Parse(), ShouldNotBeNil)
+			})
+		})
+
+		Convey("When using a constant in a SELECT statement", func() {
+			p.Buffer = "SELECT ISTREAM a + $offset FROM s [RANGE 1 TUPLES] WHERE a > $min"
+			p.Init()
+
+			Convey("Then the statement should be parsed correctly", func() {
+				err := p.Parse()
+				So(err, ShouldBeNil)
+				p.Execute()
+
+				ps := p.parseStack
+				So(ps.Len(), ShouldEqual, 1)
+				top := ps.Peek().comp
+				So(top, ShouldHaveSameTypeAs, SelectStmt{})
+				comp := top.(SelectStmt)
+
+				So(comp.Projections[0], ShouldResemble, BinaryOpAST{Plus,
+					RowValue{"", "a"}, ConstantRef{"offset"}})
+				So(comp.Filter, ShouldResemble, BinaryOpAST{Greater,
+					RowValue{"", "a"}, ConstantRef{"min"}})
+				So(comp.Filter.Foldable(), ShouldBeFalse)
+				So(ConstantRef{"min"}.Foldable(), ShouldBeTrue)
+
+				Convey("And String() should return the original statement", func() {
+					So(comp.String(), ShouldEqual, p.Buffer)
+				})
+			})
+		})
+
+		Convey("When using a constant in a WITH clause", func() {
+			p.Buffer = "CREATE SOURCE a TYPE b WITH path=$dir, interval=1"
+			p.Init()
+
+			Convey("Then the statement should be parsed correctly", func() {
+				err := p.Parse()
+				So(err, ShouldBeNil)
+				p.Execute()
+
+				ps := p.parseStack
+				So(ps.Len(), ShouldEqual, 1)
+				top := ps.Peek().comp
+				So(top, ShouldHaveSameTypeAs, CreateSourceStmt{})
+				comp := top.(CreateSourceStmt)
+
+				So(comp.Params, ShouldResemble, []SourceSinkParamAST{
+					{"path", data.Null{}, "dir"},
+					{"interval", data.Int(1), ""},
+				})
+
+				Convey("And String() should return the original statement", func() {
+					So(comp.String(), ShouldEqual, p.Buffer)
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,57 @@
+package parser
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestAssembleShowFunctions(t *testing.T) {
+	Convey("Given a parser", t, func() {
+		p := &bqlPeg{}
+
+		Convey("When doing a SHOW FUNCTIONS", func() {
+			p.Buffer = "SHOW FUNCTIONS"
+			p.Init()
+
+			Convey("Then the statement should be parsed correctly", func() {
+				err := p.Parse()
+				So(err, ShouldBeNil)
+				p.Execute()
+
+				ps := p.parseStack
+				So(ps.Len(), ShouldEqual, 1)
+				top := ps.Peek().comp
+				So(top, ShouldHaveSameTypeAs, ShowFunctionsStmt{})
+				comp := top.(ShowFunctionsStmt)
+
+				Convey("And String() should return the original statement", func() {
+					So(comp.String(), ShouldEqual, p.Buffer)
+				})
+			})
+		})
+
+		Convey("When doing a SHOW FUNCTIONS in lower case", func() {
+			p.Buffer = "show  functions"
+			p.Init()
+
+			Convey("Then the statement should be parsed correctly", func() {
+				err := p.Parse()
+				So(err, ShouldBeNil)
+				p.Execute()
+
+				ps := p.parseStack
+				So(ps.Len(), ShouldEqual, 1)
+				So(ps.Peek().comp, ShouldHaveSameTypeAs, ShowFunctionsStmt{})
+			})
+		})
+
+		Convey("When doing a SHOW without FUNCTIONS", func() {
+			p.Buffer = "SHOW"
+			p.Init()
+
+			Convey("Then the statement should not be parsed", func() {
+				So(p.Parse(), ShouldNotBeNil)
+			})
+		})
+	})
+}
@@ -12,8 +12,8 @@ func TestAssembleSourceSinkSpecs(t *testing.T) {
 
 		Convey("When the stack contains only SourceSinkParams in the given range", func() {
 			ps.PushComponent(0, 6, Raw{"PRE"})
-			ps.PushComponent(6, 7, SourceSinkParamAST{"key", data.String("val")})
-			ps.PushComponent(7, 8, SourceSinkParamAST{"a", data.String("b")})
+			ps.PushComponent(6, 7, SourceSinkParamAST{"key", data.String("val"), ""})
+			ps.PushComponent(7, 8, SourceSinkParamAST{"a", data.String("b"), ""})
 			ps.AssembleSourceSinkSpecs(6, 8)
 
 			Convey("Then AssembleSourceSinkSpecs transforms them into one item", func() {
@@ -136,9 +136,9 @@ func TestAssembleSourceSinkSpecs(t *testing.T) {
 				So(s.Params, ShouldNotBeNil)
 				So(len(s.Params), ShouldEqual, 2)
 				So(s.Params[0], ShouldResemble,
-					SourceSinkParamAST{"port", data.Int(8080)})
+					SourceSinkParamAST{"port", data.Int(8080), ""})
 				So(s.Params[1], ShouldResemble,
-					SourceSinkParamAST{"proto", data.String("http")})
+					SourceSinkParamAST{"proto", data.String("http"), ""})
 
 				Convey("And String() should return the original statement", func() {
 					So(s.String(), ShouldEqual, p.Buffer)
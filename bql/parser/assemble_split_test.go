@@ -0,0 +1,125 @@
+package parser
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestAssembleSplit(t *testing.T) {
+	Convey("Given a parseStack", t, func() {
+		ps := parseStack{}
+		Convey("When the stack contains the correct SPLIT items", func() {
+			ps.PushComponent(6, 7, StreamIdentifier("x"))
+			ps.PushComponent(8, 9, StreamIdentifier("a"))
+			ps.PushComponent(9, 10, RowValue{"", "b"})
+			ps.AssembleSplitBranch()
+			ps.PushComponent(11, 12, StreamIdentifier("c"))
+			ps.AssembleSplitOtherwise()
+			ps.AssembleSplitBranches(8, 12)
+			ps.AssembleSplit()
+
+			Convey("Then AssembleSplit transforms them into one item", func() {
+				So(ps.Len(), ShouldEqual, 1)
+
+				Convey("And that item is a SplitStmt", func() {
+					top := ps.Peek()
+					So(top, ShouldNotBeNil)
+					So(top.begin, ShouldEqual, 6)
+					So(top.end, ShouldEqual, 12)
+					So(top.comp, ShouldHaveSameTypeAs, SplitStmt{})
+
+					Convey("And it contains the previously pushed data", func() {
+						comp := top.comp.(SplitStmt)
+						So(comp.Input, ShouldEqual, "x")
+						So(comp.Branches, ShouldResemble, []SplitBranchAST{
+							{"a", RowValue{"", "b"}},
+							{"c", nil},
+						})
+					})
+				})
+			})
+		})
+
+		Convey("When the stack does not contain enough items", func() {
+			ps.PushComponent(4, 5, StreamIdentifier("x"))
+			Convey("Then AssembleSplit panics", func() {
+				So(ps.AssembleSplit, ShouldPanic)
+			})
+		})
+
+		Convey("When the stack contains a wrong item", func() {
+			ps.PushComponent(4, 5, StreamIdentifier("x"))
+			ps.PushComponent(5, 6, StreamIdentifier("y")) // must be []SplitBranchAST
+			Convey("Then AssembleSplit panics", func() {
+				So(ps.AssembleSplit, ShouldPanic)
+			})
+		})
+	})
+
+	Convey("Given a parser", t, func() {
+		p := &bqlPeg{}
+
+		Convey("When doing a full SPLIT", func() {
+			p.Buffer = "SPLIT s INTO hot WHEN temp > 80, warm WHEN temp > 50, cold OTHERWISE"
+			p.Init()
+
+			Convey("Then the statement should be parsed correctly", func() {
+				err := p.Parse()
+				So(err, ShouldBeNil)
+				p.Execute()
+
+				ps := p.parseStack
+				So(ps.Len(), ShouldEqual, 1)
+				top := ps.Peek().comp
+				So(top, ShouldHaveSameTypeAs, SplitStmt{})
+				comp := top.(SplitStmt)
+
+				So(comp.Input, ShouldEqual, "s")
+				So(len(comp.Branches), ShouldEqual, 3)
+				So(comp.Branches[0].Name, ShouldEqual, "hot")
+				So(comp.Branches[0].Cond, ShouldResemble,
+					BinaryOpAST{Greater, RowValue{"", "temp"}, NumericLiteral{80}})
+				So(comp.Branches[1].Name, ShouldEqual, "warm")
+				So(comp.Branches[2].Name, ShouldEqual, "cold")
+				So(comp.Branches[2].Cond, ShouldBeNil)
+
+				Convey("And String() should return the original statement", func() {
+					So(comp.String(), ShouldEqual, p.Buffer)
+				})
+			})
+		})
+
+		Convey("When doing a SPLIT without OTHERWISE", func() {
+			p.Buffer = "SPLIT s INTO hot WHEN temp > 80"
+			p.Init()
+
+			Convey("Then the statement should be parsed correctly", func() {
+				err := p.Parse()
+				So(err, ShouldBeNil)
+				p.Execute()
+
+				comp := p.parseStack.Peek().comp.(SplitStmt)
+				So(len(comp.Branches), ShouldEqual, 1)
+				So(comp.Branches[0].Name, ShouldEqual, "hot")
+			})
+		})
+
+		Convey("When OTHERWISE isn't the last branch", func() {
+			p.Buffer = "SPLIT s INTO cold OTHERWISE, hot WHEN temp > 80"
+			p.Init()
+
+			Convey("Then parsing should fail", func() {
+				So(p.Parse(), ShouldNotBeNil)
+			})
+		})
+
+		Convey("When SPLIT only has OTHERWISE", func() {
+			p.Buffer = "SPLIT s INTO cold OTHERWISE"
+			p.Init()
+
+			Convey("Then parsing should fail", func() {
+				So(p.Parse(), ShouldNotBeNil)
+			})
+		})
+	})
+}
@@ -0,0 +1,123 @@
+package parser
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestAssembleWindowFunction(t *testing.T) {
+	Convey("Given a parseStack", t, func() {
+		ps := parseStack{}
+
+		Convey("When the stack contains arguments of HOP", func() {
+			ps.PushComponent(0, 6, Raw{"PRE"})
+			ps.PushComponent(6, 8, Stream{ActualStream, "a", nil})
+			ps.PushComponent(8, 10, Identifier("t"))
+			ps.PushComponent(10, 12, IntervalAST{FloatLiteral{1}, Seconds})
+			ps.PushComponent(12, 14, IntervalAST{FloatLiteral{5}, Seconds})
+			ps.AssembleWindowFunction(HopWindow)
+
+			Convey("Then AssembleWindowFunction transforms them into one item", func() {
+				So(ps.Len(), ShouldEqual, 2)
+
+				Convey("And that item is a StreamWindowAST", func() {
+					top := ps.Peek()
+					So(top, ShouldNotBeNil)
+					So(top.begin, ShouldEqual, 6)
+					So(top.end, ShouldEqual, 14)
+					So(top.comp, ShouldResemble, StreamWindowAST{Stream{ActualStream, "a", nil},
+						IntervalAST{FloatLiteral{5}, Seconds}, UnspecifiedCapacity, UnspecifiedSheddingOption,
+						&WindowFunctionAST{HopWindow, "t", IntervalAST{FloatLiteral{1}, Seconds}}})
+				})
+			})
+		})
+
+		Convey("When the stack contains arguments of TUMBLE using the timestamp", func() {
+			ps.PushComponent(6, 8, Stream{ActualStream, "a", nil})
+			ps.PushComponent(8, 10, RowMeta{"", TimestampMeta})
+			ps.PushComponent(10, 12, IntervalAST{FloatLiteral{5}, Seconds})
+			ps.AssembleWindowFunction(TumbleWindow)
+
+			Convey("Then the window function shouldn't have a time column", func() {
+				So(ps.Len(), ShouldEqual, 1)
+				comp := ps.Peek().comp.(StreamWindowAST)
+				So(comp.Function, ShouldResemble, &WindowFunctionAST{Type: TumbleWindow})
+			})
+		})
+
+		Convey("When the stack contains a wrong item", func() {
+			ps.PushComponent(6, 8, Stream{ActualStream, "a", nil})
+			ps.PushComponent(8, 10, IntervalAST{FloatLiteral{5}, Seconds})
+
+			Convey("Then AssembleWindowFunction panics", func() {
+				So(func() { ps.AssembleWindowFunction(TumbleWindow) }, ShouldPanic)
+			})
+		})
+	})
+
+	Convey("Given a parser", t, func() {
+		p := &bqlPeg{}
+
+		for _, c := range []struct {
+			stmt     string
+			window   StreamWindowAST
+			original string
+		}{
+			{
+				`SELECT RSTREAM * FROM TUMBLE(s, ts(), INTERVAL '1' MINUTE)`,
+				StreamWindowAST{Stream{ActualStream, "s", nil}, IntervalAST{FloatLiteral{60}, Seconds},
+					UnspecifiedCapacity, UnspecifiedSheddingOption,
+					&WindowFunctionAST{Type: TumbleWindow}},
+				`SELECT RSTREAM * FROM TUMBLE(s, ts(), INTERVAL 60 SECONDS)`,
+			},
+			{
+				`SELECT RSTREAM * FROM hop(s, t, interval 500 milliseconds, interval 1.5 seconds)`,
+				StreamWindowAST{Stream{ActualStream, "s", nil}, IntervalAST{FloatLiteral{1.5}, Seconds},
+					UnspecifiedCapacity, UnspecifiedSheddingOption,
+					&WindowFunctionAST{HopWindow, "t", IntervalAST{FloatLiteral{500}, Milliseconds}}},
+				`SELECT RSTREAM * FROM HOP(s, t, INTERVAL 500 MILLISECONDS, INTERVAL 1.5 SECONDS)`,
+			},
+			{
+				`SELECT RSTREAM * FROM SESSION(gen(1), ts(), INTERVAL '2' HOURS)`,
+				StreamWindowAST{Stream{UDSFStream, "gen", []Expression{NumericLiteral{1}}},
+					IntervalAST{FloatLiteral{7200}, Seconds}, UnspecifiedCapacity, UnspecifiedSheddingOption,
+					&WindowFunctionAST{Type: SessionWindow}},
+				`SELECT RSTREAM * FROM SESSION(gen(1), ts(), INTERVAL 7200 SECONDS)`,
+			},
+		} {
+			c := c
+			Convey("When parsing "+c.stmt, func() {
+				p.Buffer = c.stmt
+				p.Init()
+
+				Convey("Then the statement should be parsed correctly", func() {
+					err := p.Parse()
+					So(err, ShouldBeNil)
+					p.Execute()
+
+					ps := p.parseStack
+					So(ps.Len(), ShouldEqual, 1)
+					top := ps.Peek().comp
+					So(top, ShouldHaveSameTypeAs, SelectStmt{})
+					comp := top.(SelectStmt)
+					So(len(comp.Relations), ShouldEqual, 1)
+					So(comp.Relations[0].StreamWindowAST, ShouldResemble, c.window)
+
+					Convey("And String() should return the normalized statement", func() {
+						So(comp.String(), ShouldEqual, c.original)
+					})
+				})
+			})
+		}
+
+		Convey("When parsing a window function having a RANGE clause", func() {
+			p.Buffer = `SELECT RSTREAM * FROM TUMBLE(s, ts(), INTERVAL 1 SECOND) [RANGE 1 TUPLES]`
+			p.Init()
+
+			Convey("Then it should fail", func() {
+				So(p.Parse(), ShouldNotBeNil)
+			})
+		})
+	})
+}
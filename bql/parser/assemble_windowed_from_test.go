@@ -14,11 +14,11 @@ func TestAssembleWindowedFrom(t *testing.T) {
 			ps.PushComponent(0, 6, Raw{"PRE"})
 			ps.PushComponent(6, 8, AliasedStreamWindowAST{
 				StreamWindowAST{Stream{ActualStream, "a", nil}, IntervalAST{FloatLiteral{3}, Tuples},
-					2, UnspecifiedSheddingOption}, "",
+					2, UnspecifiedSheddingOption, nil}, "",
 			})
 			ps.PushComponent(8, 10, AliasedStreamWindowAST{
 				StreamWindowAST{Stream{ActualStream, "b", nil}, IntervalAST{FloatLiteral{2}, Seconds},
-					UnspecifiedCapacity, Wait}, "",
+					UnspecifiedCapacity, Wait, nil}, "",
 			})
 			ps.AssembleWindowedFrom(6, 10)
 
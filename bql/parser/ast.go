@@ -26,6 +26,7 @@ type SelectStmt struct {
 	EmitterAST
 	ProjectionsAST
 	WindowedFromAST
+	DeduplicateAST
 	FilterAST
 	GroupingAST
 	HavingAST
@@ -35,6 +36,7 @@ func (s SelectStmt) String() string {
 	str := []string{"SELECT", s.EmitterAST.string()}
 	str = append(str, s.ProjectionsAST.string())
 	str = append(str, s.WindowedFromAST.string())
+	str = append(str, s.DeduplicateAST.string())
 	str = append(str, s.FilterAST.string())
 	str = append(str, s.GroupingAST.string())
 	str = append(str, s.HavingAST.string())
@@ -48,8 +50,25 @@ func (s SelectStmt) String() string {
 	return strings.Join(st, " ")
 }
 
+// SelectStartingStmt is a SELECT statement which first receives tuples
+// written to its input streams during the given period before the statement
+// is issued and then live tuples. Input streams must have a retention buffer.
+type SelectStartingStmt struct {
+	SelectStmt
+	Starting IntervalAST
+}
+
+func (s SelectStartingStmt) String() string {
+	return s.SelectStmt.String() + " " + s.Starting.startingString()
+}
+
 type SelectUnionStmt struct {
 	Selects []SelectStmt
+
+	// Aligned is true when SELECT statements are combined by UNION rather
+	// than UNION ALL. All SELECT statements of an aligned union must have
+	// the same output fields.
+	Aligned bool
 }
 
 func (s SelectUnionStmt) String() string {
@@ -57,16 +76,24 @@ func (s SelectUnionStmt) String() string {
 	for i, s := range s.Selects {
 		str[i] = s.String()
 	}
+	if s.Aligned {
+		return strings.Join(str, " UNION ")
+	}
 	return strings.Join(str, " UNION ALL ")
 }
 
 type CreateStreamAsSelectStmt struct {
 	Name   StreamIdentifier
 	Select SelectStmt
+	SourceSinkSpecsAST
 }
 
 func (s CreateStreamAsSelectStmt) String() string {
 	str := []string{"CREATE", "STREAM", string(s.Name), "AS", s.Select.String()}
+	specs := s.SourceSinkSpecsAST.string("WITH")
+	if specs != "" {
+		str = append(str, specs)
+	}
 	return strings.Join(str, " ")
 }
 
@@ -80,6 +107,23 @@ func (s CreateStreamAsSelectUnionStmt) String() string {
 	return strings.Join(str, " ")
 }
 
+type CreateStreamAsEnrichStmt struct {
+	Name   StreamIdentifier
+	Input  StreamIdentifier
+	Loader SourceSinkType
+	SourceSinkSpecsAST
+}
+
+func (s CreateStreamAsEnrichStmt) String() string {
+	str := []string{"CREATE", "STREAM", string(s.Name), "AS", "ENRICH",
+		string(s.Input), "USING", string(s.Loader)}
+	specs := s.SourceSinkSpecsAST.string("WITH")
+	if specs != "" {
+		str = append(str, specs)
+	}
+	return strings.Join(str, " ")
+}
+
 type CreateSourceStmt struct {
 	Paused BinaryKeyword
 	Name   StreamIdentifier
@@ -173,15 +217,106 @@ func (s UpdateSinkStmt) String() string {
 }
 
 type InsertIntoFromStmt struct {
-	Sink  StreamIdentifier
-	Input StreamIdentifier
+	Sink   StreamIdentifier
+	Inputs []StreamIdentifier
+	SourceSinkSpecsAST
 }
 
 func (s InsertIntoFromStmt) String() string {
-	str := []string{"INSERT", "INTO", string(s.Sink), "FROM", string(s.Input)}
+	inputs := make([]string, len(s.Inputs))
+	for i, in := range s.Inputs {
+		inputs[i] = string(in)
+	}
+	str := []string{"INSERT", "INTO", string(s.Sink), "FROM", strings.Join(inputs, ", ")}
+	specs := s.SourceSinkSpecsAST.string("WITH")
+	if specs != "" {
+		str = append(str, specs)
+	}
 	return strings.Join(str, " ")
 }
 
+// InsertIntoSelectStmt is INSERT INTO ... SELECT ..., which inserts results
+// of the SELECT statement into the sink through an anonymous stream.
+type InsertIntoSelectStmt struct {
+	Sink   StreamIdentifier
+	Select SelectStmt
+}
+
+func (s InsertIntoSelectStmt) String() string {
+	str := []string{"INSERT", "INTO", string(s.Sink), s.Select.String()}
+	return strings.Join(str, " ")
+}
+
+type SplitStmt struct {
+	Input    StreamIdentifier
+	Branches []SplitBranchAST
+}
+
+func (s SplitStmt) String() string {
+	branches := make([]string, len(s.Branches))
+	for i, b := range s.Branches {
+		branches[i] = b.string()
+	}
+	str := []string{"SPLIT", string(s.Input), "INTO", strings.Join(branches, ", ")}
+	return strings.Join(str, " ")
+}
+
+// ArchiveStreamStmt writes tuples of a stream to files in a directory so
+// that they can be replayed by ReplayArchiveStmt later.
+type ArchiveStreamStmt struct {
+	Stream StreamIdentifier
+	Dir    string
+	SourceSinkSpecsAST
+}
+
+func (s ArchiveStreamStmt) String() string {
+	str := []string{"ARCHIVE", "STREAM", string(s.Stream), "TO", StringLiteral{s.Dir}.String()}
+	specs := s.SourceSinkSpecsAST.string("WITH")
+	if specs != "" {
+		str = append(str, specs)
+	}
+	return strings.Join(str, " ")
+}
+
+// ReplayArchiveStmt creates a source emitting tuples archived by
+// ArchiveStreamStmt. From and To are empty when they're not given.
+type ReplayArchiveStmt struct {
+	Dir    string
+	Stream StreamIdentifier
+	From   string
+	To     string
+	SourceSinkSpecsAST
+}
+
+func (s ReplayArchiveStmt) String() string {
+	str := []string{"REPLAY", "ARCHIVE", StringLiteral{s.Dir}.String(), "INTO", string(s.Stream)}
+	if s.From != "" {
+		str = append(str, "FROM", StringLiteral{s.From}.String())
+	}
+	if s.To != "" {
+		str = append(str, "TO", StringLiteral{s.To}.String())
+	}
+	specs := s.SourceSinkSpecsAST.string("WITH")
+	if specs != "" {
+		str = append(str, specs)
+	}
+	return strings.Join(str, " ")
+}
+
+// SplitBranchAST is an output stream of a SPLIT statement. Cond is nil when
+// the branch is the OTHERWISE branch.
+type SplitBranchAST struct {
+	Name StreamIdentifier
+	Cond Expression
+}
+
+func (b SplitBranchAST) string() string {
+	if b.Cond == nil {
+		return string(b.Name) + " OTHERWISE"
+	}
+	return string(b.Name) + " WHEN " + b.Cond.String()
+}
+
 type PauseSourceStmt struct {
 	Source StreamIdentifier
 }
@@ -317,6 +452,46 @@ func (s EvalStmt) String() string {
 	return strings.Join(str, " ")
 }
 
+// ExplainAnalyzeStmt runs a SELECT statement for a bounded number of input
+// tuples or a bounded duration and reports the time and memory spent in each
+// operator of its execution plan. Limit.Unit is UnspecifiedIntervalUnit when
+// the FOR clause is omitted.
+type ExplainAnalyzeStmt struct {
+	SelectStmt
+	Limit IntervalAST
+}
+
+func (s ExplainAnalyzeStmt) String() string {
+	str := "EXPLAIN ANALYZE "
+	if s.Limit.Unit != UnspecifiedIntervalUnit {
+		str += "FOR " + s.Limit.FloatLiteral.String() + " " + s.Limit.Unit.String() + " "
+	}
+	return str + s.SelectStmt.String()
+}
+
+type ShowFunctionsStmt struct{}
+
+func (s ShowFunctionsStmt) String() string {
+	return "SHOW FUNCTIONS"
+}
+
+type ReloadFunctionStmt struct {
+	Function FuncName
+}
+
+func (s ReloadFunctionStmt) String() string {
+	return "RELOAD FUNCTION " + string(s.Function)
+}
+
+type SetConstantStmt struct {
+	Name  StreamIdentifier
+	Value Expression
+}
+
+func (s SetConstantStmt) String() string {
+	return "SET CONSTANT " + string(s.Name) + " = " + s.Value.String()
+}
+
 type EmitterAST struct {
 	EmitterType    Emitter
 	EmitterOptions []interface{}
@@ -406,12 +581,18 @@ func (a AliasAST) String() string {
 
 type WindowedFromAST struct {
 	Relations []AliasedStreamWindowAST
+	// Join has the type and the condition of a JOIN clause. Relations has
+	// exactly two relations when it isn't nil.
+	Join *JoinAST
 }
 
 func (a WindowedFromAST) string() string {
 	if len(a.Relations) == 0 {
 		return ""
 	}
+	if a.Join != nil && len(a.Relations) == 2 {
+		return "FROM " + a.Relations[0].string() + " " + a.Join.string(a.Relations[1])
+	}
 
 	str := []string{}
 	for _, r := range a.Relations {
@@ -420,6 +601,17 @@ func (a WindowedFromAST) string() string {
 	return "FROM " + strings.Join(str, ", ")
 }
 
+// JoinAST is a JOIN clause joining two windowed relations, e.g.
+// `a [RANGE 1 SECONDS] LEFT OUTER JOIN b [RANGE 1 SECONDS] ON a:id = b:id`.
+type JoinAST struct {
+	Type JoinType
+	On   Expression
+}
+
+func (a JoinAST) string(right AliasedStreamWindowAST) string {
+	return a.Type.String() + " JOIN " + right.string() + " ON " + a.On.String()
+}
+
 type AliasedStreamWindowAST struct {
 	StreamWindowAST
 	Alias string
@@ -440,9 +632,18 @@ type StreamWindowAST struct {
 	IntervalAST
 	Capacity int64
 	Shedding SheddingOption
+	// Function is the window function, i.e. TUMBLE, HOP, or SESSION, used
+	// instead of a RANGE clause. IntervalAST has the size of a window for
+	// TUMBLE and HOP and the gap of sessions for SESSION. It's nil when
+	// the window is given by a RANGE clause.
+	Function *WindowFunctionAST
 }
 
 func (a StreamWindowAST) string() string {
+	if a.Function != nil {
+		return a.Function.string(a.streamString(), a.IntervalAST)
+	}
+
 	interval := a.IntervalAST.string()
 	capacity := ""
 	if a.Capacity != UnspecifiedCapacity {
@@ -453,22 +654,52 @@ func (a StreamWindowAST) string() string {
 		shedding = fmt.Sprintf(", %s IF FULL", a.Shedding.String())
 	}
 	suffix := "[" + interval + capacity + shedding + "]"
+	return a.streamString() + " " + suffix
+}
 
+func (a StreamWindowAST) streamString() string {
 	switch a.Stream.Type {
 	case ActualStream:
-		return a.Stream.Name + " " + suffix
+		return a.Stream.Name
 
 	case UDSFStream:
 		ps := []string{}
 		for _, p := range a.Stream.Params {
 			ps = append(ps, p.String())
 		}
-		return a.Stream.Name + "(" + strings.Join(ps, ", ") + ") " + suffix
+		return a.Stream.Name + "(" + strings.Join(ps, ", ") + ")"
 	}
 
 	return "UnknownStreamType"
 }
 
+// WindowFunctionAST is a table-valued window function in the FROM clause
+// such as TUMBLE(s, ts(), INTERVAL '1' MINUTE). They're provided for
+// compatibility with other stream processing systems and assign tuples to
+// windows by their event time.
+type WindowFunctionAST struct {
+	Type WindowFunctionType
+	// TimeColumn is the name of the column having the event time of a
+	// tuple. The timestamp of a tuple is used when it's empty.
+	TimeColumn string
+	// Slide is the interval between the starts of two consecutive
+	// windows of HOP.
+	Slide IntervalAST
+}
+
+func (a WindowFunctionAST) string(stream string, size IntervalAST) string {
+	timeCol := a.TimeColumn
+	if timeCol == "" {
+		timeCol = TimestampMeta.string()
+	}
+	args := []string{stream, timeCol}
+	if a.Type == HopWindow {
+		args = append(args, a.Slide.literalString())
+	}
+	args = append(args, size.literalString())
+	return a.Type.String() + "(" + strings.Join(args, ", ") + ")"
+}
+
 type IntervalAST struct {
 	FloatLiteral
 	Unit IntervalUnit
@@ -478,6 +709,29 @@ func (a IntervalAST) string() string {
 	return "RANGE " + a.FloatLiteral.String() + " " + a.Unit.String()
 }
 
+func (a IntervalAST) literalString() string {
+	return "INTERVAL " + a.FloatLiteral.String() + " " + a.Unit.String()
+}
+
+func (a IntervalAST) startingString() string {
+	return "STARTING " + a.FloatLiteral.String() + " " + a.Unit.String() + " AGO"
+}
+
+// DeduplicateAST has an expression computing the key used to detect
+// duplicate tuples and the interval within which a duplicate is dropped.
+type DeduplicateAST struct {
+	DedupKey Expression
+	Within   IntervalAST
+}
+
+func (a DeduplicateAST) string() string {
+	if a.DedupKey == nil {
+		return ""
+	}
+	return "DEDUPLICATE BY " + a.DedupKey.String() + " WITHIN " +
+		a.Within.FloatLiteral.String() + " " + a.Within.Unit.String()
+}
+
 type FilterAST struct {
 	Filter Expression
 }
@@ -491,9 +745,43 @@ func (a FilterAST) string() string {
 
 type GroupingAST struct {
 	GroupList []Expression
+
+	// GroupingSets has expressions of each set of GROUPING SETS. It's nil
+	// when the clause doesn't have GROUPING SETS. GroupList has all distinct
+	// expressions appearing in the sets in that case.
+	GroupingSets [][]Expression
+}
+
+// NewGroupingSetsAST creates a GroupingAST having the given grouping sets.
+func NewGroupingSetsAST(sets [][]Expression) GroupingAST {
+	a := GroupingAST{
+		GroupList:    []Expression{},
+		GroupingSets: sets,
+	}
+	seen := map[string]bool{}
+	for _, set := range sets {
+		for _, e := range set {
+			if s := e.String(); !seen[s] {
+				seen[s] = true
+				a.GroupList = append(a.GroupList, e)
+			}
+		}
+	}
+	return a
 }
 
 func (a GroupingAST) string() string {
+	if a.GroupingSets != nil {
+		sets := make([]string, len(a.GroupingSets))
+		for i, set := range a.GroupingSets {
+			str := make([]string, len(set))
+			for j, e := range set {
+				str[j] = e.String()
+			}
+			sets[i] = "(" + strings.Join(str, ", ") + ")"
+		}
+		return "GROUP BY GROUPING SETS (" + strings.Join(sets, ", ") + ")"
+	}
 	if len(a.GroupList) == 0 {
 		return ""
 	}
@@ -534,6 +822,11 @@ func (a SourceSinkSpecsAST) string(keyword string) string {
 type SourceSinkParamAST struct {
 	Key   SourceSinkParamKey
 	Value data.Value
+
+	// Constant is the name of the constant referred by the parameter like
+	// `key=$name`. Value is NULL when it's set and the parameter has the
+	// value of the constant when the statement is executed.
+	Constant string
 }
 
 func (a SourceSinkParamAST) string() string {
@@ -547,7 +840,9 @@ func (a SourceSinkParamAST) string() string {
 		return s
 	}
 	var valRepr string
-	if a.Value.Type() == data.TypeArray {
+	if a.Constant != "" {
+		valRepr = ConstantRef{a.Constant}.String()
+	} else if a.Value.Type() == data.TypeArray {
 		// convert arrays to string elementwise and
 		// add brackets
 		arr, _ := data.AsArray(a.Value)
@@ -1137,6 +1432,27 @@ func (c ExpressionCaseAST) String() string {
 		c.Expr.String(), strings.Join(entries, " "))
 }
 
+// ConstantRef refers to a constant of the topology like $name.
+type ConstantRef struct {
+	Name string
+}
+
+func (c ConstantRef) ReferencedRelations() map[string]bool {
+	return nil
+}
+
+func (c ConstantRef) RenameReferencedRelation(from, to string) Expression {
+	return c
+}
+
+func (c ConstantRef) Foldable() bool {
+	return true
+}
+
+func (c ConstantRef) String() string {
+	return "$" + c.Name
+}
+
 type RowMeta struct {
 	Relation string
 	MetaType MetaInformation
@@ -1437,6 +1753,8 @@ const (
 	UnknownMeta MetaInformation = iota
 	TimestampMeta
 	NowMeta
+	IDMeta
+	BackfillMeta
 )
 
 func (m MetaInformation) String() string {
@@ -1446,6 +1764,10 @@ func (m MetaInformation) String() string {
 		s = "TS"
 	case NowMeta:
 		s = "NOW"
+	case IDMeta:
+		s = "ID"
+	case BackfillMeta:
+		s = "BACKFILL"
 	}
 	return s
 }
@@ -1457,6 +1779,10 @@ func (m MetaInformation) string() string {
 		s = "ts()"
 	case NowMeta:
 		s = "now()"
+	case IDMeta:
+		s = "tuple_id()"
+	case BackfillMeta:
+		s = "is_backfill()"
 	}
 	return s
 }
@@ -1490,6 +1816,53 @@ func (k BinaryKeyword) string(yes, no string) string {
 	return ""
 }
 
+type WindowFunctionType int
+
+const (
+	UnknownWindowFunction WindowFunctionType = iota
+	TumbleWindow
+	HopWindow
+	SessionWindow
+)
+
+func (t WindowFunctionType) String() string {
+	s := "UnknownWindowFunction"
+	switch t {
+	case TumbleWindow:
+		s = "TUMBLE"
+	case HopWindow:
+		s = "HOP"
+	case SessionWindow:
+		s = "SESSION"
+	}
+	return s
+}
+
+type JoinType int
+
+const (
+	UnknownJoinType JoinType = iota
+	InnerJoin
+	LeftOuterJoin
+	RightOuterJoin
+	FullOuterJoin
+)
+
+func (t JoinType) String() string {
+	s := "UnknownJoinType"
+	switch t {
+	case InnerJoin:
+		s = "INNER"
+	case LeftOuterJoin:
+		s = "LEFT OUTER"
+	case RightOuterJoin:
+		s = "RIGHT OUTER"
+	case FullOuterJoin:
+		s = "FULL OUTER"
+	}
+	return s
+}
+
 type SheddingOption int
 
 const (
@@ -1567,6 +1940,8 @@ const (
 	Concat
 	Is
 	IsNot
+	IsDistinctFrom
+	IsNotDistinctFrom
 	Plus
 	Minus
 	Multiply
@@ -1583,7 +1958,7 @@ func (op Operator) hasSamePrecedenceAs(rhs Operator) bool {
 	if Less <= op && op <= GreaterOrEqual && Less <= rhs && rhs <= GreaterOrEqual {
 		return true
 	}
-	if Is <= op && op <= IsNot && Is <= rhs && rhs <= IsNot {
+	if Is <= op && op <= IsNotDistinctFrom && Is <= rhs && rhs <= IsNotDistinctFrom {
 		return true
 	}
 	if Plus <= op && op <= Minus && Plus <= rhs && rhs <= Minus {
@@ -1631,6 +2006,10 @@ func (o Operator) String() string {
 		s = "IS"
 	case IsNot:
 		s = "IS NOT"
+	case IsDistinctFrom:
+		s = "IS DISTINCT FROM"
+	case IsNotDistinctFrom:
+		s = "IS NOT DISTINCT FROM"
 	case Plus:
 		s = "+"
 	case Minus:
@@ -24,15 +24,27 @@ const (
 	ruleStreamStmt
 	ruleSelectStmt
 	ruleSelectUnionStmt
+	ruleSelectStartingStmt
 	ruleCreateStreamAsSelectStmt
 	ruleCreateStreamAsSelectUnionStmt
+	ruleCreateStreamAsEnrichStmt
 	ruleCreateSourceStmt
 	ruleCreateSinkStmt
 	ruleCreateStateStmt
 	ruleUpdateStateStmt
 	ruleUpdateSourceStmt
 	ruleUpdateSinkStmt
+	ruleInsertIntoSelectStmt
 	ruleInsertIntoFromStmt
+	ruleInsertIntoInputs
+	ruleSplitStmt
+	ruleSplitBranches
+	ruleSplitBranch
+	ruleSplitOtherwise
+	ruleArchiveStreamStmt
+	ruleReplayArchiveStmt
+	ruleReplayFromOpt
+	ruleReplayToOpt
 	rulePauseSourceStmt
 	ruleResumeSourceStmt
 	ruleRewindSourceStmt
@@ -44,6 +56,11 @@ const (
 	ruleLoadStateOrCreateStmt
 	ruleSaveStateStmt
 	ruleEvalStmt
+	ruleExplainAnalyzeStmt
+	ruleExplainAnalyzeLimit
+	ruleShowFunctionsStmt
+	ruleReloadFunctionStmt
+	ruleSetConstantStmt
 	ruleEmitter
 	ruleEmitterOptions
 	ruleEmitterOptionCombinations
@@ -62,14 +79,28 @@ const (
 	ruleTimeInterval
 	ruleTuplesInterval
 	ruleRelations
+	ruleJoinedRelations
+	ruleJoinType
+	ruleDeduplicate
 	ruleFilter
 	ruleGrouping
 	ruleGroupList
+	ruleGroupingSets
+	ruleGroupingSet
 	ruleHaving
 	ruleRelationLike
 	ruleAliasedStreamWindow
 	ruleStreamWindow
 	ruleStreamLike
+	ruleWindowFunction
+	ruleTumbleWindow
+	ruleHopWindow
+	ruleSessionWindow
+	ruleTimeAttribute
+	ruleTupleTimestamp
+	ruleIntervalLiteral
+	ruleIntervalLiteralValue
+	ruleIntervalLiteralUnit
 	ruleUDSFFuncApp
 	ruleCapacitySpecOpt
 	ruleSheddingSpecOpt
@@ -119,12 +150,16 @@ const (
 	ruleComparisonOp
 	ruleOtherOp
 	ruleIsOp
+	ruleIsDistinctOp
 	rulePlusMinusOp
 	ruleMultDivOp
 	ruleStream
 	ruleRowMeta
 	ruleRowTimestamp
+	ruleRowTupleID
+	ruleRowBackfill
 	ruleRowValue
+	ruleConstantRef
 	ruleNumericLiteral
 	ruleNonNegativeNumericLiteral
 	ruleFloatLiteral
@@ -142,6 +177,10 @@ const (
 	ruleTUPLES
 	ruleSECONDS
 	ruleMILLISECONDS
+	ruleInnerJoin
+	ruleLeftOuterJoin
+	ruleRightOuterJoin
+	ruleFullOuterJoin
 	ruleWait
 	ruleDropOldest
 	ruleDropNewest
@@ -173,6 +212,8 @@ const (
 	ruleConcat
 	ruleIs
 	ruleIsNot
+	ruleIsDistinctFrom
+	ruleIsNotDistinctFrom
 	rulePlus
 	ruleMinus
 	ruleMultiply
@@ -339,6 +380,42 @@ const (
 	ruleAction133
 	ruleAction134
 	ruleAction135
+	ruleAction136
+	ruleAction137
+	ruleAction138
+	ruleAction139
+	ruleAction140
+	ruleAction141
+	ruleAction142
+	ruleAction143
+	ruleAction144
+	ruleAction145
+	ruleAction146
+	ruleAction147
+	ruleAction148
+	ruleAction149
+	ruleAction150
+	ruleAction151
+	ruleAction152
+	ruleAction153
+	ruleAction154
+	ruleAction155
+	ruleAction156
+	ruleAction157
+	ruleAction158
+	ruleAction159
+	ruleAction160
+	ruleAction161
+	ruleAction162
+	ruleAction163
+	ruleAction164
+	ruleAction165
+	ruleAction166
+	ruleAction167
+	ruleAction168
+	ruleAction169
+	ruleAction170
+	ruleAction171
 )
 
 var rul3s = [...]string{
@@ -353,15 +430,27 @@ var rul3s = [...]string{
 	"StreamStmt",
 	"SelectStmt",
 	"SelectUnionStmt",
+	"SelectStartingStmt",
 	"CreateStreamAsSelectStmt",
 	"CreateStreamAsSelectUnionStmt",
+	"CreateStreamAsEnrichStmt",
 	"CreateSourceStmt",
 	"CreateSinkStmt",
 	"CreateStateStmt",
 	"UpdateStateStmt",
 	"UpdateSourceStmt",
 	"UpdateSinkStmt",
+	"InsertIntoSelectStmt",
 	"InsertIntoFromStmt",
+	"InsertIntoInputs",
+	"SplitStmt",
+	"SplitBranches",
+	"SplitBranch",
+	"SplitOtherwise",
+	"ArchiveStreamStmt",
+	"ReplayArchiveStmt",
+	"ReplayFromOpt",
+	"ReplayToOpt",
 	"PauseSourceStmt",
 	"ResumeSourceStmt",
 	"RewindSourceStmt",
@@ -373,6 +462,11 @@ var rul3s = [...]string{
 	"LoadStateOrCreateStmt",
 	"SaveStateStmt",
 	"EvalStmt",
+	"ExplainAnalyzeStmt",
+	"ExplainAnalyzeLimit",
+	"ShowFunctionsStmt",
+	"ReloadFunctionStmt",
+	"SetConstantStmt",
 	"Emitter",
 	"EmitterOptions",
 	"EmitterOptionCombinations",
@@ -391,14 +485,28 @@ var rul3s = [...]string{
 	"TimeInterval",
 	"TuplesInterval",
 	"Relations",
+	"JoinedRelations",
+	"JoinType",
+	"Deduplicate",
 	"Filter",
 	"Grouping",
 	"GroupList",
+	"GroupingSets",
+	"GroupingSet",
 	"Having",
 	"RelationLike",
 	"AliasedStreamWindow",
 	"StreamWindow",
 	"StreamLike",
+	"WindowFunction",
+	"TumbleWindow",
+	"HopWindow",
+	"SessionWindow",
+	"TimeAttribute",
+	"TupleTimestamp",
+	"IntervalLiteral",
+	"IntervalLiteralValue",
+	"IntervalLiteralUnit",
 	"UDSFFuncApp",
 	"CapacitySpecOpt",
 	"SheddingSpecOpt",
@@ -448,12 +556,16 @@ var rul3s = [...]string{
 	"ComparisonOp",
 	"OtherOp",
 	"IsOp",
+	"IsDistinctOp",
 	"PlusMinusOp",
 	"MultDivOp",
 	"Stream",
 	"RowMeta",
 	"RowTimestamp",
+	"RowTupleID",
+	"RowBackfill",
 	"RowValue",
+	"ConstantRef",
 	"NumericLiteral",
 	"NonNegativeNumericLiteral",
 	"FloatLiteral",
@@ -471,6 +583,10 @@ var rul3s = [...]string{
 	"TUPLES",
 	"SECONDS",
 	"MILLISECONDS",
+	"InnerJoin",
+	"LeftOuterJoin",
+	"RightOuterJoin",
+	"FullOuterJoin",
 	"Wait",
 	"DropOldest",
 	"DropNewest",
@@ -502,6 +618,8 @@ var rul3s = [...]string{
 	"Concat",
 	"Is",
 	"IsNot",
+	"IsDistinctFrom",
+	"IsNotDistinctFrom",
 	"Plus",
 	"Minus",
 	"Multiply",
@@ -668,6 +786,42 @@ var rul3s = [...]string{
 	"Action133",
 	"Action134",
 	"Action135",
+	"Action136",
+	"Action137",
+	"Action138",
+	"Action139",
+	"Action140",
+	"Action141",
+	"Action142",
+	"Action143",
+	"Action144",
+	"Action145",
+	"Action146",
+	"Action147",
+	"Action148",
+	"Action149",
+	"Action150",
+	"Action151",
+	"Action152",
+	"Action153",
+	"Action154",
+	"Action155",
+	"Action156",
+	"Action157",
+	"Action158",
+	"Action159",
+	"Action160",
+	"Action161",
+	"Action162",
+	"Action163",
+	"Action164",
+	"Action165",
+	"Action166",
+	"Action167",
+	"Action168",
+	"Action169",
+	"Action170",
+	"Action171",
 }
 
 type token32 struct {
@@ -784,7 +938,7 @@ type bqlPegBackend struct {
 
 	Buffer string
 	buffer []rune
-	rules  [326]func() bool
+	rules  [403]func() bool
 	parse  func(rule ...int) error
 	reset  func()
 	Pretty bool
@@ -894,554 +1048,704 @@ func (p *bqlPegBackend) Execute() {
 
 		case ruleAction4:
 
-			p.AssembleCreateStreamAsSelect()
+			p.AssembleSelectAlignedUnion(begin, end)
 
 		case ruleAction5:
 
-			p.AssembleCreateStreamAsSelectUnion()
+			p.AssembleSelectStarting()
 
 		case ruleAction6:
 
-			p.AssembleCreateSource()
+			p.AssembleCreateStreamAsSelect()
 
 		case ruleAction7:
 
-			p.AssembleCreateSink()
+			p.AssembleCreateStreamAsSelectUnion()
 
 		case ruleAction8:
 
-			p.AssembleCreateState()
+			p.AssembleCreateStreamAsEnrich()
 
 		case ruleAction9:
 
-			p.AssembleUpdateState()
+			p.AssembleCreateSource()
 
 		case ruleAction10:
 
-			p.AssembleUpdateSource()
+			p.AssembleCreateSink()
 
 		case ruleAction11:
 
-			p.AssembleUpdateSink()
+			p.AssembleCreateState()
 
 		case ruleAction12:
 
-			p.AssembleInsertIntoFrom()
+			p.AssembleUpdateState()
 
 		case ruleAction13:
 
-			p.AssemblePauseSource()
+			p.AssembleUpdateSource()
 
 		case ruleAction14:
 
-			p.AssembleResumeSource()
+			p.AssembleUpdateSink()
 
 		case ruleAction15:
 
-			p.AssembleRewindSource()
+			p.AssembleInsertIntoSelect()
 
 		case ruleAction16:
 
-			p.AssembleDropSource()
+			p.AssembleInsertIntoFrom()
 
 		case ruleAction17:
 
-			p.AssembleDropStream()
+			p.AssembleStreamIdentifiers(begin, end)
 
 		case ruleAction18:
 
-			p.AssembleDropSink()
+			p.AssembleSplit()
 
 		case ruleAction19:
 
-			p.AssembleDropState()
+			p.AssembleSplitBranches(begin, end)
 
 		case ruleAction20:
 
-			p.AssembleLoadState()
+			p.AssembleSplitBranch()
 
 		case ruleAction21:
 
-			p.AssembleLoadStateOrCreate()
+			p.AssembleSplitOtherwise()
 
 		case ruleAction22:
 
-			p.AssembleSaveState()
+			p.AssembleArchiveStream()
 
 		case ruleAction23:
 
-			p.AssembleEval(begin, end)
+			p.AssembleReplayArchive()
 
 		case ruleAction24:
 
-			p.AssembleEmitter()
+			p.EnsureStringLiteral(begin, end)
 
 		case ruleAction25:
 
-			p.AssembleEmitterOptions(begin, end)
+			p.EnsureStringLiteral(begin, end)
 
 		case ruleAction26:
 
-			p.AssembleEmitterLimit()
+			p.AssemblePauseSource()
 
 		case ruleAction27:
 
-			p.AssembleEmitterSampling(CountBasedSampling, 1)
+			p.AssembleResumeSource()
 
 		case ruleAction28:
 
-			p.AssembleEmitterSampling(RandomizedSampling, 1)
+			p.AssembleRewindSource()
 
 		case ruleAction29:
 
-			p.AssembleEmitterSampling(TimeBasedSampling, 1)
+			p.AssembleDropSource()
 
 		case ruleAction30:
 
-			p.AssembleEmitterSampling(TimeBasedSampling, 0.001)
+			p.AssembleDropStream()
 
 		case ruleAction31:
 
-			p.AssembleProjections(begin, end)
+			p.AssembleDropSink()
 
 		case ruleAction32:
 
-			p.AssembleAlias()
+			p.AssembleDropState()
 
 		case ruleAction33:
 
-			// This is *always* executed, even if there is no
-			// FROM clause present in the statement.
-			p.AssembleWindowedFrom(begin, end)
+			p.AssembleLoadState()
 
 		case ruleAction34:
 
-			p.AssembleInterval()
+			p.AssembleLoadStateOrCreate()
 
 		case ruleAction35:
 
-			p.AssembleInterval()
+			p.AssembleSaveState()
 
 		case ruleAction36:
 
-			// This is *always* executed, even if there is no
-			// WHERE clause present in the statement.
-			p.AssembleFilter(begin, end)
+			p.AssembleEval(begin, end)
 
 		case ruleAction37:
 
-			// This is *always* executed, even if there is no
-			// GROUP BY clause present in the statement.
-			p.AssembleGrouping(begin, end)
+			p.AssembleExplainAnalyze()
 
 		case ruleAction38:
 
-			// This is *always* executed, even if there is no
-			// HAVING clause present in the statement.
-			p.AssembleHaving(begin, end)
+			p.EnsureExplainAnalyzeLimit(begin, end)
 
 		case ruleAction39:
 
-			p.EnsureAliasedStreamWindow()
+			p.AssembleShowFunctions(begin, end)
 
 		case ruleAction40:
 
-			p.AssembleAliasedStreamWindow()
+			p.AssembleReloadFunction(begin, end)
 
 		case ruleAction41:
 
-			p.AssembleStreamWindow()
+			p.AssembleSetConstant()
 
 		case ruleAction42:
 
-			p.AssembleUDSFFuncApp()
+			p.AssembleEmitter()
 
 		case ruleAction43:
 
-			p.EnsureCapacitySpec(begin, end)
+			p.AssembleEmitterOptions(begin, end)
 
 		case ruleAction44:
 
-			p.EnsureSheddingSpec(begin, end)
+			p.AssembleEmitterLimit()
 
 		case ruleAction45:
 
-			p.AssembleSourceSinkSpecs(begin, end)
+			p.AssembleEmitterSampling(CountBasedSampling, 1)
 
 		case ruleAction46:
 
-			p.AssembleSourceSinkSpecs(begin, end)
+			p.AssembleEmitterSampling(RandomizedSampling, 1)
 
 		case ruleAction47:
 
-			p.AssembleSourceSinkSpecs(begin, end)
+			p.AssembleEmitterSampling(TimeBasedSampling, 1)
 
 		case ruleAction48:
 
-			p.EnsureIdentifier(begin, end)
+			p.AssembleEmitterSampling(TimeBasedSampling, 0.001)
 
 		case ruleAction49:
 
-			p.AssembleSourceSinkParam()
+			p.AssembleProjections(begin, end)
 
 		case ruleAction50:
 
-			p.AssembleExpressions(begin, end)
-			p.AssembleArray()
+			p.AssembleAlias()
 
 		case ruleAction51:
 
-			p.AssembleMap(begin, end)
+			// This is *always* executed, even if there is no
+			// FROM clause present in the statement.
+			p.AssembleWindowedFrom(begin, end)
 
 		case ruleAction52:
 
-			p.AssembleKeyValuePair()
+			p.AssembleInterval()
 
 		case ruleAction53:
 
-			p.EnsureKeywordPresent(begin, end)
+			p.AssembleInterval()
 
 		case ruleAction54:
 
-			p.AssembleBinaryOperation(begin, end)
+			p.AssembleJoin()
 
 		case ruleAction55:
 
-			p.AssembleBinaryOperation(begin, end)
+			// This is *always* executed, even if there is no
+			// DEDUPLICATE BY clause present in the statement.
+			p.AssembleDeduplicate(begin, end)
 
 		case ruleAction56:
 
-			p.AssembleUnaryPrefixOperation(begin, end)
+			// This is *always* executed, even if there is no
+			// WHERE clause present in the statement.
+			p.AssembleFilter(begin, end)
 
 		case ruleAction57:
 
-			p.AssembleBinaryOperation(begin, end)
+			// This is *always* executed, even if there is no
+			// GROUP BY clause present in the statement.
+			p.AssembleGrouping(begin, end)
 
 		case ruleAction58:
 
-			p.AssembleBinaryOperation(begin, end)
+			p.AssembleExpressions(begin, end)
 
 		case ruleAction59:
 
-			p.AssembleBinaryOperation(begin, end)
+			// This is *always* executed, even if there is no
+			// HAVING clause present in the statement.
+			p.AssembleHaving(begin, end)
 
 		case ruleAction60:
 
-			p.AssembleBinaryOperation(begin, end)
+			p.EnsureAliasedStreamWindow()
 
 		case ruleAction61:
 
-			p.AssembleBinaryOperation(begin, end)
+			p.AssembleAliasedStreamWindow()
 
 		case ruleAction62:
 
-			p.AssembleUnaryPrefixOperation(begin, end)
+			p.AssembleStreamWindow()
 
 		case ruleAction63:
 
-			p.AssembleTypeCast(begin, end)
+			p.AssembleWindowFunction(TumbleWindow)
 
 		case ruleAction64:
 
-			p.AssembleTypeCast(begin, end)
+			p.AssembleWindowFunction(HopWindow)
 
 		case ruleAction65:
 
-			p.AssembleFuncAppSelector()
+			p.AssembleWindowFunction(SessionWindow)
 
 		case ruleAction66:
 
-			substr := string([]rune(buffer)[begin:end])
-			p.PushComponent(begin, end, NewRaw(substr))
+			p.PushComponent(begin, end, NewRowMeta("", TimestampMeta))
 
 		case ruleAction67:
 
-			p.AssembleFuncApp()
+			p.AssembleIntervalLiteral()
 
 		case ruleAction68:
 
-			p.AssembleExpressions(begin, end)
-			p.AssembleFuncApp()
+			substr := string([]rune(buffer)[begin:end])
+			p.PushComponent(begin, end, Raw{substr})
 
 		case ruleAction69:
 
-			p.AssembleExpressions(begin, end)
+			p.AssembleUDSFFuncApp()
 
 		case ruleAction70:
 
-			p.AssembleExpressions(begin, end)
+			p.EnsureCapacitySpec(begin, end)
 
 		case ruleAction71:
 
-			p.AssembleSortedExpression()
+			p.EnsureSheddingSpec(begin, end)
 
 		case ruleAction72:
 
-			p.EnsureKeywordPresent(begin, end)
+			p.AssembleSourceSinkSpecs(begin, end)
 
 		case ruleAction73:
 
-			p.AssembleExpressions(begin, end)
-			p.AssembleArray()
+			p.AssembleSourceSinkSpecs(begin, end)
 
 		case ruleAction74:
 
-			p.AssembleMap(begin, end)
+			p.AssembleSourceSinkSpecs(begin, end)
 
 		case ruleAction75:
 
-			p.AssembleKeyValuePair()
+			p.EnsureIdentifier(begin, end)
 
 		case ruleAction76:
 
-			p.AssembleConditionCase(begin, end)
+			p.AssembleSourceSinkParam()
 
 		case ruleAction77:
 
-			p.AssembleExpressionCase(begin, end)
+			p.AssembleExpressions(begin, end)
+			p.AssembleArray()
 
 		case ruleAction78:
 
-			p.AssembleWhenThenPair()
+			p.AssembleMap(begin, end)
 
 		case ruleAction79:
 
-			substr := string([]rune(buffer)[begin:end])
-			p.PushComponent(begin, end, NewStream(substr))
+			p.AssembleKeyValuePair()
 
 		case ruleAction80:
 
-			substr := string([]rune(buffer)[begin:end])
-			p.PushComponent(begin, end, NewRowMeta(substr, TimestampMeta))
+			p.EnsureKeywordPresent(begin, end)
 
 		case ruleAction81:
 
-			substr := string([]rune(buffer)[begin:end])
-			p.PushComponent(begin, end, NewRowValue(substr))
+			p.AssembleBinaryOperation(begin, end)
 
 		case ruleAction82:
 
-			substr := string([]rune(buffer)[begin:end])
-			p.PushComponent(begin, end, NewNumericLiteral(substr))
+			p.AssembleBinaryOperation(begin, end)
 
 		case ruleAction83:
 
-			substr := string([]rune(buffer)[begin:end])
-			p.PushComponent(begin, end, NewNumericLiteral(substr))
+			p.AssembleUnaryPrefixOperation(begin, end)
 
 		case ruleAction84:
 
-			substr := string([]rune(buffer)[begin:end])
-			p.PushComponent(begin, end, NewFloatLiteral(substr))
+			p.AssembleBinaryOperation(begin, end)
 
 		case ruleAction85:
 
-			substr := string([]rune(buffer)[begin:end])
-			p.PushComponent(begin, end, FuncName(substr))
+			p.AssembleBinaryOperation(begin, end)
 
 		case ruleAction86:
 
-			p.PushComponent(begin, end, NewNullLiteral())
+			p.AssembleBinaryOperation(begin, end)
 
 		case ruleAction87:
 
-			p.PushComponent(begin, end, NewMissing())
+			p.AssembleBinaryOperation(begin, end)
 
 		case ruleAction88:
 
-			p.PushComponent(begin, end, NewBoolLiteral(true))
+			p.AssembleBinaryOperation(begin, end)
 
 		case ruleAction89:
 
-			p.PushComponent(begin, end, NewBoolLiteral(false))
+			p.AssembleUnaryPrefixOperation(begin, end)
 
 		case ruleAction90:
 
-			substr := string([]rune(buffer)[begin:end])
-			p.PushComponent(begin, end, NewWildcard(substr))
+			p.AssembleTypeCast(begin, end)
 
 		case ruleAction91:
 
-			substr := string([]rune(buffer)[begin:end])
-			p.PushComponent(begin, end, NewStringLiteral(substr))
+			p.AssembleTypeCast(begin, end)
 
 		case ruleAction92:
 
-			p.PushComponent(begin, end, Istream)
+			p.AssembleFuncAppSelector()
 
 		case ruleAction93:
 
-			p.PushComponent(begin, end, Dstream)
+			substr := string([]rune(buffer)[begin:end])
+			p.PushComponent(begin, end, NewRaw(substr))
 
 		case ruleAction94:
 
-			p.PushComponent(begin, end, Rstream)
+			p.AssembleFuncApp()
 
 		case ruleAction95:
 
-			p.PushComponent(begin, end, Tuples)
+			p.AssembleExpressions(begin, end)
+			p.AssembleFuncApp()
 
 		case ruleAction96:
 
-			p.PushComponent(begin, end, Seconds)
+			p.AssembleExpressions(begin, end)
 
 		case ruleAction97:
 
-			p.PushComponent(begin, end, Milliseconds)
+			p.AssembleExpressions(begin, end)
 
 		case ruleAction98:
 
-			p.PushComponent(begin, end, Wait)
+			p.AssembleSortedExpression()
 
 		case ruleAction99:
 
-			p.PushComponent(begin, end, DropOldest)
+			p.EnsureKeywordPresent(begin, end)
 
 		case ruleAction100:
 
-			p.PushComponent(begin, end, DropNewest)
+			p.AssembleExpressions(begin, end)
+			p.AssembleArray()
 
 		case ruleAction101:
 
-			substr := string([]rune(buffer)[begin:end])
-			p.PushComponent(begin, end, StreamIdentifier(substr))
+			p.AssembleMap(begin, end)
 
 		case ruleAction102:
 
-			substr := string([]rune(buffer)[begin:end])
-			p.PushComponent(begin, end, SourceSinkType(substr))
+			p.AssembleKeyValuePair()
 
 		case ruleAction103:
 
-			substr := string([]rune(buffer)[begin:end])
-			p.PushComponent(begin, end, SourceSinkParamKey(substr))
+			p.AssembleConditionCase(begin, end)
 
 		case ruleAction104:
 
-			p.PushComponent(begin, end, Yes)
+			p.AssembleExpressionCase(begin, end)
 
 		case ruleAction105:
 
-			p.PushComponent(begin, end, No)
+			p.AssembleWhenThenPair()
 
 		case ruleAction106:
 
-			p.PushComponent(begin, end, Yes)
+			substr := string([]rune(buffer)[begin:end])
+			p.PushComponent(begin, end, NewStream(substr))
 
 		case ruleAction107:
 
-			p.PushComponent(begin, end, No)
+			substr := string([]rune(buffer)[begin:end])
+			p.PushComponent(begin, end, NewRowMeta(substr, TimestampMeta))
 
 		case ruleAction108:
 
-			p.PushComponent(begin, end, Bool)
+			substr := string([]rune(buffer)[begin:end])
+			p.PushComponent(begin, end, NewRowMeta(substr, IDMeta))
 
 		case ruleAction109:
 
-			p.PushComponent(begin, end, Int)
+			substr := string([]rune(buffer)[begin:end])
+			p.PushComponent(begin, end, NewRowMeta(substr, BackfillMeta))
 
 		case ruleAction110:
 
-			p.PushComponent(begin, end, Float)
+			substr := string([]rune(buffer)[begin:end])
+			p.PushComponent(begin, end, NewRowValue(substr))
 
 		case ruleAction111:
 
-			p.PushComponent(begin, end, String)
+			substr := string([]rune(buffer)[begin:end])
+			p.PushComponent(begin, end, ConstantRef{substr[1:]})
 
 		case ruleAction112:
 
-			p.PushComponent(begin, end, Blob)
+			substr := string([]rune(buffer)[begin:end])
+			p.PushComponent(begin, end, NewNumericLiteral(substr))
 
 		case ruleAction113:
 
-			p.PushComponent(begin, end, Timestamp)
+			substr := string([]rune(buffer)[begin:end])
+			p.PushComponent(begin, end, NewNumericLiteral(substr))
 
 		case ruleAction114:
 
-			p.PushComponent(begin, end, Array)
+			substr := string([]rune(buffer)[begin:end])
+			p.PushComponent(begin, end, NewFloatLiteral(substr))
 
 		case ruleAction115:
 
-			p.PushComponent(begin, end, Map)
+			substr := string([]rune(buffer)[begin:end])
+			p.PushComponent(begin, end, FuncName(substr))
 
 		case ruleAction116:
 
-			p.PushComponent(begin, end, Or)
+			p.PushComponent(begin, end, NewNullLiteral())
 
 		case ruleAction117:
 
-			p.PushComponent(begin, end, And)
+			p.PushComponent(begin, end, NewMissing())
 
 		case ruleAction118:
 
-			p.PushComponent(begin, end, Not)
+			p.PushComponent(begin, end, NewBoolLiteral(true))
 
 		case ruleAction119:
 
-			p.PushComponent(begin, end, Equal)
+			p.PushComponent(begin, end, NewBoolLiteral(false))
 
 		case ruleAction120:
 
-			p.PushComponent(begin, end, Less)
+			substr := string([]rune(buffer)[begin:end])
+			p.PushComponent(begin, end, NewWildcard(substr))
 
 		case ruleAction121:
 
-			p.PushComponent(begin, end, LessOrEqual)
-
+			substr := string([]rune(buffer)[begin:end])
+			p.PushComponent(begin, end, NewStringLiteral(substr))
+
 		case ruleAction122:
 
-			p.PushComponent(begin, end, Greater)
+			p.PushComponent(begin, end, Istream)
 
 		case ruleAction123:
 
-			p.PushComponent(begin, end, GreaterOrEqual)
+			p.PushComponent(begin, end, Dstream)
 
 		case ruleAction124:
 
-			p.PushComponent(begin, end, NotEqual)
+			p.PushComponent(begin, end, Rstream)
 
 		case ruleAction125:
 
-			p.PushComponent(begin, end, Concat)
+			p.PushComponent(begin, end, Tuples)
 
 		case ruleAction126:
 
-			p.PushComponent(begin, end, Is)
+			p.PushComponent(begin, end, Seconds)
 
 		case ruleAction127:
 
-			p.PushComponent(begin, end, IsNot)
+			p.PushComponent(begin, end, Milliseconds)
 
 		case ruleAction128:
 
-			p.PushComponent(begin, end, Plus)
+			p.PushComponent(begin, end, InnerJoin)
 
 		case ruleAction129:
 
-			p.PushComponent(begin, end, Minus)
+			p.PushComponent(begin, end, LeftOuterJoin)
 
 		case ruleAction130:
 
-			p.PushComponent(begin, end, Multiply)
+			p.PushComponent(begin, end, RightOuterJoin)
 
 		case ruleAction131:
 
-			p.PushComponent(begin, end, Divide)
+			p.PushComponent(begin, end, FullOuterJoin)
 
 		case ruleAction132:
 
-			p.PushComponent(begin, end, Modulo)
+			p.PushComponent(begin, end, Wait)
 
 		case ruleAction133:
 
-			p.PushComponent(begin, end, UnaryMinus)
+			p.PushComponent(begin, end, DropOldest)
 
 		case ruleAction134:
 
+			p.PushComponent(begin, end, DropNewest)
+
+		case ruleAction135:
+
+			substr := string([]rune(buffer)[begin:end])
+			p.PushComponent(begin, end, StreamIdentifier(substr))
+
+		case ruleAction136:
+
+			substr := string([]rune(buffer)[begin:end])
+			p.PushComponent(begin, end, SourceSinkType(substr))
+
+		case ruleAction137:
+
+			substr := string([]rune(buffer)[begin:end])
+			p.PushComponent(begin, end, SourceSinkParamKey(substr))
+
+		case ruleAction138:
+
+			p.PushComponent(begin, end, Yes)
+
+		case ruleAction139:
+
+			p.PushComponent(begin, end, No)
+
+		case ruleAction140:
+
+			p.PushComponent(begin, end, Yes)
+
+		case ruleAction141:
+
+			p.PushComponent(begin, end, No)
+
+		case ruleAction142:
+
+			p.PushComponent(begin, end, Bool)
+
+		case ruleAction143:
+
+			p.PushComponent(begin, end, Int)
+
+		case ruleAction144:
+
+			p.PushComponent(begin, end, Float)
+
+		case ruleAction145:
+
+			p.PushComponent(begin, end, String)
+
+		case ruleAction146:
+
+			p.PushComponent(begin, end, Blob)
+
+		case ruleAction147:
+
+			p.PushComponent(begin, end, Timestamp)
+
+		case ruleAction148:
+
+			p.PushComponent(begin, end, Array)
+
+		case ruleAction149:
+
+			p.PushComponent(begin, end, Map)
+
+		case ruleAction150:
+
+			p.PushComponent(begin, end, Or)
+
+		case ruleAction151:
+
+			p.PushComponent(begin, end, And)
+
+		case ruleAction152:
+
+			p.PushComponent(begin, end, Not)
+
+		case ruleAction153:
+
+			p.PushComponent(begin, end, Equal)
+
+		case ruleAction154:
+
+			p.PushComponent(begin, end, Less)
+
+		case ruleAction155:
+
+			p.PushComponent(begin, end, LessOrEqual)
+
+		case ruleAction156:
+
+			p.PushComponent(begin, end, Greater)
+
+		case ruleAction157:
+
+			p.PushComponent(begin, end, GreaterOrEqual)
+
+		case ruleAction158:
+
+			p.PushComponent(begin, end, NotEqual)
+
+		case ruleAction159:
+
+			p.PushComponent(begin, end, Concat)
+
+		case ruleAction160:
+
+			p.PushComponent(begin, end, Is)
+
+		case ruleAction161:
+
+			p.PushComponent(begin, end, IsNot)
+
+		case ruleAction162:
+
+			p.PushComponent(begin, end, IsDistinctFrom)
+
+		case ruleAction163:
+
+			p.PushComponent(begin, end, IsNotDistinctFrom)
+
+		case ruleAction164:
+
+			p.PushComponent(begin, end, Plus)
+
+		case ruleAction165:
+
+			p.PushComponent(begin, end, Minus)
+
+		case ruleAction166:
+
+			p.PushComponent(begin, end, Multiply)
+
+		case ruleAction167:
+
+			p.PushComponent(begin, end, Divide)
+
+		case ruleAction168:
+
+			p.PushComponent(begin, end, Modulo)
+
+		case ruleAction169:
+
+			p.PushComponent(begin, end, UnaryMinus)
+
+		case ruleAction170:
+
 			substr := string([]rune(buffer)[begin:end])
 			p.PushComponent(begin, end, Identifier(substr))
 
-		case ruleAction135:
+		case ruleAction171:
 
 			substr := string([]rune(buffer)[begin:end])
 			p.PushComponent(begin, end, Identifier(substr))
@@ -1623,7 +1927,7 @@ func (p *bqlPegBackend) Init() {
 			position, tokenIndex = position10, tokenIndex10
 			return false
 		},
-		/* 3 Statement <- <(SelectUnionStmt / SelectStmt / SourceStmt / SinkStmt / StateStmt / StreamStmt / EvalStmt)> */
+		/* 3 Statement <- <(SelectUnionStmt / SelectStartingStmt / SelectStmt / SourceStmt / SinkStmt / StateStmt / StreamStmt / EvalStmt / ExplainAnalyzeStmt / ShowFunctionsStmt / ReloadFunctionStmt / SetConstantStmt)> */
 		func() bool {
 			position13, tokenIndex13 := position, tokenIndex
 			{
@@ -1636,37 +1940,67 @@ func (p *bqlPegBackend) Init() {
 					goto l15
 				l16:
 					position, tokenIndex = position15, tokenIndex15
-					if !_rules[ruleSelectStmt]() {
+					if !_rules[ruleSelectStartingStmt]() {
 						goto l17
 					}
 					goto l15
 				l17:
 					position, tokenIndex = position15, tokenIndex15
-					if !_rules[ruleSourceStmt]() {
+					if !_rules[ruleSelectStmt]() {
 						goto l18
 					}
 					goto l15
 				l18:
 					position, tokenIndex = position15, tokenIndex15
-					if !_rules[ruleSinkStmt]() {
+					if !_rules[ruleSourceStmt]() {
 						goto l19
 					}
 					goto l15
 				l19:
 					position, tokenIndex = position15, tokenIndex15
-					if !_rules[ruleStateStmt]() {
+					if !_rules[ruleSinkStmt]() {
 						goto l20
 					}
 					goto l15
 				l20:
 					position, tokenIndex = position15, tokenIndex15
-					if !_rules[ruleStreamStmt]() {
+					if !_rules[ruleStateStmt]() {
 						goto l21
 					}
 					goto l15
 				l21:
+					position, tokenIndex = position15, tokenIndex15
+					if !_rules[ruleStreamStmt]() {
+						goto l22
+					}
+					goto l15
+				l22:
 					position, tokenIndex = position15, tokenIndex15
 					if !_rules[ruleEvalStmt]() {
+						goto l23
+					}
+					goto l15
+				l23:
+					position, tokenIndex = position15, tokenIndex15
+					if !_rules[ruleExplainAnalyzeStmt]() {
+						goto l24
+					}
+					goto l15
+				l24:
+					position, tokenIndex = position15, tokenIndex15
+					if !_rules[ruleShowFunctionsStmt]() {
+						goto l25
+					}
+					goto l15
+				l25:
+					position, tokenIndex = position15, tokenIndex15
+					if !_rules[ruleReloadFunctionStmt]() {
+						goto l26
+					}
+					goto l15
+				l26:
+					position, tokenIndex = position15, tokenIndex15
+					if !_rules[ruleSetConstantStmt]() {
 						goto l13
 					}
 				}
@@ -1680,6011 +2014,6062 @@ func (p *bqlPegBackend) Init() {
 		},
 		/* 4 SourceStmt <- <(CreateSourceStmt / UpdateSourceStmt / DropSourceStmt / PauseSourceStmt / ResumeSourceStmt / RewindSourceStmt)> */
 		func() bool {
-			position22, tokenIndex22 := position, tokenIndex
+			position27, tokenIndex27 := position, tokenIndex
 			{
-				position23 := position
+				position28 := position
 				{
-					position24, tokenIndex24 := position, tokenIndex
+					position29, tokenIndex29 := position, tokenIndex
 					if !_rules[ruleCreateSourceStmt]() {
-						goto l25
+						goto l30
 					}
-					goto l24
-				l25:
-					position, tokenIndex = position24, tokenIndex24
+					goto l29
+				l30:
+					position, tokenIndex = position29, tokenIndex29
 					if !_rules[ruleUpdateSourceStmt]() {
-						goto l26
+						goto l31
 					}
-					goto l24
-				l26:
-					position, tokenIndex = position24, tokenIndex24
+					goto l29
+				l31:
+					position, tokenIndex = position29, tokenIndex29
 					if !_rules[ruleDropSourceStmt]() {
-						goto l27
+						goto l32
 					}
-					goto l24
-				l27:
-					position, tokenIndex = position24, tokenIndex24
+					goto l29
+				l32:
+					position, tokenIndex = position29, tokenIndex29
 					if !_rules[rulePauseSourceStmt]() {
-						goto l28
+						goto l33
 					}
-					goto l24
-				l28:
-					position, tokenIndex = position24, tokenIndex24
+					goto l29
+				l33:
+					position, tokenIndex = position29, tokenIndex29
 					if !_rules[ruleResumeSourceStmt]() {
-						goto l29
+						goto l34
 					}
-					goto l24
-				l29:
-					position, tokenIndex = position24, tokenIndex24
+					goto l29
+				l34:
+					position, tokenIndex = position29, tokenIndex29
 					if !_rules[ruleRewindSourceStmt]() {
-						goto l22
+						goto l27
 					}
 				}
-			l24:
-				add(ruleSourceStmt, position23)
+			l29:
+				add(ruleSourceStmt, position28)
 			}
 			return true
-		l22:
-			position, tokenIndex = position22, tokenIndex22
+		l27:
+			position, tokenIndex = position27, tokenIndex27
 			return false
 		},
 		/* 5 SinkStmt <- <(CreateSinkStmt / UpdateSinkStmt / DropSinkStmt)> */
 		func() bool {
-			position30, tokenIndex30 := position, tokenIndex
+			position35, tokenIndex35 := position, tokenIndex
 			{
-				position31 := position
+				position36 := position
 				{
-					position32, tokenIndex32 := position, tokenIndex
+					position37, tokenIndex37 := position, tokenIndex
 					if !_rules[ruleCreateSinkStmt]() {
-						goto l33
+						goto l38
 					}
-					goto l32
-				l33:
-					position, tokenIndex = position32, tokenIndex32
+					goto l37
+				l38:
+					position, tokenIndex = position37, tokenIndex37
 					if !_rules[ruleUpdateSinkStmt]() {
-						goto l34
+						goto l39
 					}
-					goto l32
-				l34:
-					position, tokenIndex = position32, tokenIndex32
+					goto l37
+				l39:
+					position, tokenIndex = position37, tokenIndex37
 					if !_rules[ruleDropSinkStmt]() {
-						goto l30
+						goto l35
 					}
 				}
-			l32:
-				add(ruleSinkStmt, position31)
+			l37:
+				add(ruleSinkStmt, position36)
 			}
 			return true
-		l30:
-			position, tokenIndex = position30, tokenIndex30
+		l35:
+			position, tokenIndex = position35, tokenIndex35
 			return false
 		},
 		/* 6 StateStmt <- <(CreateStateStmt / UpdateStateStmt / DropStateStmt / LoadStateOrCreateStmt / LoadStateStmt / SaveStateStmt)> */
 		func() bool {
-			position35, tokenIndex35 := position, tokenIndex
+			position40, tokenIndex40 := position, tokenIndex
 			{
-				position36 := position
+				position41 := position
 				{
-					position37, tokenIndex37 := position, tokenIndex
+					position42, tokenIndex42 := position, tokenIndex
 					if !_rules[ruleCreateStateStmt]() {
-						goto l38
+						goto l43
 					}
-					goto l37
-				l38:
-					position, tokenIndex = position37, tokenIndex37
+					goto l42
+				l43:
+					position, tokenIndex = position42, tokenIndex42
 					if !_rules[ruleUpdateStateStmt]() {
-						goto l39
+						goto l44
 					}
-					goto l37
-				l39:
-					position, tokenIndex = position37, tokenIndex37
+					goto l42
+				l44:
+					position, tokenIndex = position42, tokenIndex42
 					if !_rules[ruleDropStateStmt]() {
-						goto l40
+						goto l45
 					}
-					goto l37
-				l40:
-					position, tokenIndex = position37, tokenIndex37
+					goto l42
+				l45:
+					position, tokenIndex = position42, tokenIndex42
 					if !_rules[ruleLoadStateOrCreateStmt]() {
-						goto l41
+						goto l46
 					}
-					goto l37
-				l41:
-					position, tokenIndex = position37, tokenIndex37
+					goto l42
+				l46:
+					position, tokenIndex = position42, tokenIndex42
 					if !_rules[ruleLoadStateStmt]() {
-						goto l42
+						goto l47
 					}
-					goto l37
-				l42:
-					position, tokenIndex = position37, tokenIndex37
+					goto l42
+				l47:
+					position, tokenIndex = position42, tokenIndex42
 					if !_rules[ruleSaveStateStmt]() {
-						goto l35
+						goto l40
 					}
 				}
-			l37:
-				add(ruleStateStmt, position36)
+			l42:
+				add(ruleStateStmt, position41)
 			}
 			return true
-		l35:
-			position, tokenIndex = position35, tokenIndex35
+		l40:
+			position, tokenIndex = position40, tokenIndex40
 			return false
 		},
-		/* 7 StreamStmt <- <(CreateStreamAsSelectUnionStmt / CreateStreamAsSelectStmt / DropStreamStmt / InsertIntoFromStmt)> */
+		/* 7 StreamStmt <- <(CreateStreamAsSelectUnionStmt / CreateStreamAsSelectStmt / CreateStreamAsEnrichStmt / DropStreamStmt / InsertIntoSelectStmt / InsertIntoFromStmt / SplitStmt / ArchiveStreamStmt / ReplayArchiveStmt)> */
 		func() bool {
-			position43, tokenIndex43 := position, tokenIndex
+			position48, tokenIndex48 := position, tokenIndex
 			{
-				position44 := position
+				position49 := position
 				{
-					position45, tokenIndex45 := position, tokenIndex
+					position50, tokenIndex50 := position, tokenIndex
 					if !_rules[ruleCreateStreamAsSelectUnionStmt]() {
-						goto l46
+						goto l51
 					}
-					goto l45
-				l46:
-					position, tokenIndex = position45, tokenIndex45
+					goto l50
+				l51:
+					position, tokenIndex = position50, tokenIndex50
 					if !_rules[ruleCreateStreamAsSelectStmt]() {
-						goto l47
+						goto l52
 					}
-					goto l45
-				l47:
-					position, tokenIndex = position45, tokenIndex45
+					goto l50
+				l52:
+					position, tokenIndex = position50, tokenIndex50
+					if !_rules[ruleCreateStreamAsEnrichStmt]() {
+						goto l53
+					}
+					goto l50
+				l53:
+					position, tokenIndex = position50, tokenIndex50
 					if !_rules[ruleDropStreamStmt]() {
-						goto l48
+						goto l54
+					}
+					goto l50
+				l54:
+					position, tokenIndex = position50, tokenIndex50
+					if !_rules[ruleInsertIntoSelectStmt]() {
+						goto l55
 					}
-					goto l45
-				l48:
-					position, tokenIndex = position45, tokenIndex45
+					goto l50
+				l55:
+					position, tokenIndex = position50, tokenIndex50
 					if !_rules[ruleInsertIntoFromStmt]() {
-						goto l43
+						goto l56
+					}
+					goto l50
+				l56:
+					position, tokenIndex = position50, tokenIndex50
+					if !_rules[ruleSplitStmt]() {
+						goto l57
+					}
+					goto l50
+				l57:
+					position, tokenIndex = position50, tokenIndex50
+					if !_rules[ruleArchiveStreamStmt]() {
+						goto l58
+					}
+					goto l50
+				l58:
+					position, tokenIndex = position50, tokenIndex50
+					if !_rules[ruleReplayArchiveStmt]() {
+						goto l48
 					}
 				}
-			l45:
-				add(ruleStreamStmt, position44)
+			l50:
+				add(ruleStreamStmt, position49)
 			}
 			return true
-		l43:
-			position, tokenIndex = position43, tokenIndex43
+		l48:
+			position, tokenIndex = position48, tokenIndex48
 			return false
 		},
-		/* 8 SelectStmt <- <(('s' / 'S') ('e' / 'E') ('l' / 'L') ('e' / 'E') ('c' / 'C') ('t' / 'T') Emitter Projections WindowedFrom Filter Grouping Having Action2)> */
+		/* 8 SelectStmt <- <(('s' / 'S') ('e' / 'E') ('l' / 'L') ('e' / 'E') ('c' / 'C') ('t' / 'T') Emitter Projections WindowedFrom Deduplicate Filter Grouping Having Action2)> */
 		func() bool {
-			position49, tokenIndex49 := position, tokenIndex
+			position59, tokenIndex59 := position, tokenIndex
 			{
-				position50 := position
+				position60 := position
 				{
-					position51, tokenIndex51 := position, tokenIndex
+					position61, tokenIndex61 := position, tokenIndex
 					if buffer[position] != rune('s') {
-						goto l52
+						goto l62
 					}
 					position++
-					goto l51
-				l52:
-					position, tokenIndex = position51, tokenIndex51
+					goto l61
+				l62:
+					position, tokenIndex = position61, tokenIndex61
 					if buffer[position] != rune('S') {
-						goto l49
+						goto l59
 					}
 					position++
 				}
-			l51:
+			l61:
 				{
-					position53, tokenIndex53 := position, tokenIndex
+					position63, tokenIndex63 := position, tokenIndex
 					if buffer[position] != rune('e') {
-						goto l54
+						goto l64
 					}
 					position++
-					goto l53
-				l54:
-					position, tokenIndex = position53, tokenIndex53
+					goto l63
+				l64:
+					position, tokenIndex = position63, tokenIndex63
 					if buffer[position] != rune('E') {
-						goto l49
+						goto l59
 					}
 					position++
 				}
-			l53:
+			l63:
 				{
-					position55, tokenIndex55 := position, tokenIndex
+					position65, tokenIndex65 := position, tokenIndex
 					if buffer[position] != rune('l') {
-						goto l56
+						goto l66
 					}
 					position++
-					goto l55
-				l56:
-					position, tokenIndex = position55, tokenIndex55
+					goto l65
+				l66:
+					position, tokenIndex = position65, tokenIndex65
 					if buffer[position] != rune('L') {
-						goto l49
+						goto l59
 					}
 					position++
 				}
-			l55:
+			l65:
 				{
-					position57, tokenIndex57 := position, tokenIndex
+					position67, tokenIndex67 := position, tokenIndex
 					if buffer[position] != rune('e') {
-						goto l58
+						goto l68
 					}
 					position++
-					goto l57
-				l58:
-					position, tokenIndex = position57, tokenIndex57
+					goto l67
+				l68:
+					position, tokenIndex = position67, tokenIndex67
 					if buffer[position] != rune('E') {
-						goto l49
+						goto l59
 					}
 					position++
 				}
-			l57:
+			l67:
 				{
-					position59, tokenIndex59 := position, tokenIndex
+					position69, tokenIndex69 := position, tokenIndex
 					if buffer[position] != rune('c') {
-						goto l60
+						goto l70
 					}
 					position++
-					goto l59
-				l60:
-					position, tokenIndex = position59, tokenIndex59
+					goto l69
+				l70:
+					position, tokenIndex = position69, tokenIndex69
 					if buffer[position] != rune('C') {
-						goto l49
+						goto l59
 					}
 					position++
 				}
-			l59:
+			l69:
 				{
-					position61, tokenIndex61 := position, tokenIndex
+					position71, tokenIndex71 := position, tokenIndex
 					if buffer[position] != rune('t') {
-						goto l62
+						goto l72
 					}
 					position++
-					goto l61
-				l62:
-					position, tokenIndex = position61, tokenIndex61
+					goto l71
+				l72:
+					position, tokenIndex = position71, tokenIndex71
 					if buffer[position] != rune('T') {
-						goto l49
+						goto l59
 					}
 					position++
 				}
-			l61:
+			l71:
 				if !_rules[ruleEmitter]() {
-					goto l49
+					goto l59
 				}
 				if !_rules[ruleProjections]() {
-					goto l49
+					goto l59
 				}
 				if !_rules[ruleWindowedFrom]() {
-					goto l49
+					goto l59
+				}
+				if !_rules[ruleDeduplicate]() {
+					goto l59
 				}
 				if !_rules[ruleFilter]() {
-					goto l49
+					goto l59
 				}
 				if !_rules[ruleGrouping]() {
-					goto l49
+					goto l59
 				}
 				if !_rules[ruleHaving]() {
-					goto l49
+					goto l59
 				}
 				if !_rules[ruleAction2]() {
-					goto l49
+					goto l59
 				}
-				add(ruleSelectStmt, position50)
+				add(ruleSelectStmt, position60)
 			}
 			return true
-		l49:
-			position, tokenIndex = position49, tokenIndex49
+		l59:
+			position, tokenIndex = position59, tokenIndex59
 			return false
 		},
-		/* 9 SelectUnionStmt <- <(<(SelectStmt (sp (('u' / 'U') ('n' / 'N') ('i' / 'I') ('o' / 'O') ('n' / 'N')) sp (('a' / 'A') ('l' / 'L') ('l' / 'L')) sp SelectStmt)+)> Action3)> */
+		/* 9 SelectUnionStmt <- <((<(SelectStmt (sp (('u' / 'U') ('n' / 'N') ('i' / 'I') ('o' / 'O') ('n' / 'N')) sp (('a' / 'A') ('l' / 'L') ('l' / 'L')) sp SelectStmt)+)> Action3) / (<(SelectStmt (sp (('u' / 'U') ('n' / 'N') ('i' / 'I') ('o' / 'O') ('n' / 'N')) sp SelectStmt)+)> Action4))> */
 		func() bool {
-			position63, tokenIndex63 := position, tokenIndex
+			position73, tokenIndex73 := position, tokenIndex
 			{
-				position64 := position
+				position74 := position
 				{
-					position65 := position
-					if !_rules[ruleSelectStmt]() {
-						goto l63
-					}
-					if !_rules[rulesp]() {
-						goto l63
-					}
-					{
-						position68, tokenIndex68 := position, tokenIndex
-						if buffer[position] != rune('u') {
-							goto l69
-						}
-						position++
-						goto l68
-					l69:
-						position, tokenIndex = position68, tokenIndex68
-						if buffer[position] != rune('U') {
-							goto l63
-						}
-						position++
-					}
-				l68:
-					{
-						position70, tokenIndex70 := position, tokenIndex
-						if buffer[position] != rune('n') {
-							goto l71
-						}
-						position++
-						goto l70
-					l71:
-						position, tokenIndex = position70, tokenIndex70
-						if buffer[position] != rune('N') {
-							goto l63
-						}
-						position++
-					}
-				l70:
-					{
-						position72, tokenIndex72 := position, tokenIndex
-						if buffer[position] != rune('i') {
-							goto l73
-						}
-						position++
-						goto l72
-					l73:
-						position, tokenIndex = position72, tokenIndex72
-						if buffer[position] != rune('I') {
-							goto l63
-						}
-						position++
-					}
-				l72:
-					{
-						position74, tokenIndex74 := position, tokenIndex
-						if buffer[position] != rune('o') {
-							goto l75
-						}
-						position++
-						goto l74
-					l75:
-						position, tokenIndex = position74, tokenIndex74
-						if buffer[position] != rune('O') {
-							goto l63
-						}
-						position++
-					}
-				l74:
-					{
-						position76, tokenIndex76 := position, tokenIndex
-						if buffer[position] != rune('n') {
-							goto l77
-						}
-						position++
-						goto l76
-					l77:
-						position, tokenIndex = position76, tokenIndex76
-						if buffer[position] != rune('N') {
-							goto l63
-						}
-						position++
-					}
-				l76:
-					if !_rules[rulesp]() {
-						goto l63
-					}
-					{
-						position78, tokenIndex78 := position, tokenIndex
-						if buffer[position] != rune('a') {
-							goto l79
-						}
-						position++
-						goto l78
-					l79:
-						position, tokenIndex = position78, tokenIndex78
-						if buffer[position] != rune('A') {
-							goto l63
-						}
-						position++
-					}
-				l78:
+					position75, tokenIndex75 := position, tokenIndex
 					{
-						position80, tokenIndex80 := position, tokenIndex
-						if buffer[position] != rune('l') {
-							goto l81
-						}
-						position++
-						goto l80
-					l81:
-						position, tokenIndex = position80, tokenIndex80
-						if buffer[position] != rune('L') {
-							goto l63
+						position77 := position
+						if !_rules[ruleSelectStmt]() {
+							goto l76
 						}
-						position++
-					}
-				l80:
-					{
-						position82, tokenIndex82 := position, tokenIndex
-						if buffer[position] != rune('l') {
-							goto l83
+						if !_rules[rulesp]() {
+							goto l76
 						}
-						position++
-						goto l82
-					l83:
-						position, tokenIndex = position82, tokenIndex82
-						if buffer[position] != rune('L') {
-							goto l63
+						{
+							position80, tokenIndex80 := position, tokenIndex
+							if buffer[position] != rune('u') {
+								goto l81
+							}
+							position++
+							goto l80
+						l81:
+							position, tokenIndex = position80, tokenIndex80
+							if buffer[position] != rune('U') {
+								goto l76
+							}
+							position++
 						}
-						position++
-					}
-				l82:
-					if !_rules[rulesp]() {
-						goto l63
-					}
-					if !_rules[ruleSelectStmt]() {
-						goto l63
-					}
-				l66:
-					{
-						position67, tokenIndex67 := position, tokenIndex
-						if !_rules[rulesp]() {
-							goto l67
+					l80:
+						{
+							position82, tokenIndex82 := position, tokenIndex
+							if buffer[position] != rune('n') {
+								goto l83
+							}
+							position++
+							goto l82
+						l83:
+							position, tokenIndex = position82, tokenIndex82
+							if buffer[position] != rune('N') {
+								goto l76
+							}
+							position++
 						}
+					l82:
 						{
 							position84, tokenIndex84 := position, tokenIndex
-							if buffer[position] != rune('u') {
+							if buffer[position] != rune('i') {
 								goto l85
 							}
 							position++
 							goto l84
 						l85:
 							position, tokenIndex = position84, tokenIndex84
-							if buffer[position] != rune('U') {
-								goto l67
+							if buffer[position] != rune('I') {
+								goto l76
 							}
 							position++
 						}
 					l84:
 						{
 							position86, tokenIndex86 := position, tokenIndex
-							if buffer[position] != rune('n') {
+							if buffer[position] != rune('o') {
 								goto l87
 							}
 							position++
 							goto l86
 						l87:
 							position, tokenIndex = position86, tokenIndex86
-							if buffer[position] != rune('N') {
-								goto l67
+							if buffer[position] != rune('O') {
+								goto l76
 							}
 							position++
 						}
 					l86:
 						{
 							position88, tokenIndex88 := position, tokenIndex
-							if buffer[position] != rune('i') {
+							if buffer[position] != rune('n') {
 								goto l89
 							}
 							position++
 							goto l88
 						l89:
 							position, tokenIndex = position88, tokenIndex88
-							if buffer[position] != rune('I') {
-								goto l67
+							if buffer[position] != rune('N') {
+								goto l76
 							}
 							position++
 						}
 					l88:
+						if !_rules[rulesp]() {
+							goto l76
+						}
 						{
 							position90, tokenIndex90 := position, tokenIndex
-							if buffer[position] != rune('o') {
+							if buffer[position] != rune('a') {
 								goto l91
 							}
 							position++
 							goto l90
 						l91:
 							position, tokenIndex = position90, tokenIndex90
-							if buffer[position] != rune('O') {
-								goto l67
+							if buffer[position] != rune('A') {
+								goto l76
 							}
 							position++
 						}
 					l90:
 						{
 							position92, tokenIndex92 := position, tokenIndex
-							if buffer[position] != rune('n') {
+							if buffer[position] != rune('l') {
 								goto l93
 							}
 							position++
 							goto l92
 						l93:
 							position, tokenIndex = position92, tokenIndex92
-							if buffer[position] != rune('N') {
-								goto l67
+							if buffer[position] != rune('L') {
+								goto l76
 							}
 							position++
 						}
 					l92:
-						if !_rules[rulesp]() {
-							goto l67
-						}
 						{
 							position94, tokenIndex94 := position, tokenIndex
-							if buffer[position] != rune('a') {
+							if buffer[position] != rune('l') {
 								goto l95
 							}
 							position++
 							goto l94
 						l95:
 							position, tokenIndex = position94, tokenIndex94
-							if buffer[position] != rune('A') {
-								goto l67
+							if buffer[position] != rune('L') {
+								goto l76
 							}
 							position++
 						}
 					l94:
+						if !_rules[rulesp]() {
+							goto l76
+						}
+						if !_rules[ruleSelectStmt]() {
+							goto l76
+						}
+					l78:
 						{
-							position96, tokenIndex96 := position, tokenIndex
-							if buffer[position] != rune('l') {
-								goto l97
+							position79, tokenIndex79 := position, tokenIndex
+							if !_rules[rulesp]() {
+								goto l79
+							}
+							{
+								position96, tokenIndex96 := position, tokenIndex
+								if buffer[position] != rune('u') {
+									goto l97
+								}
+								position++
+								goto l96
+							l97:
+								position, tokenIndex = position96, tokenIndex96
+								if buffer[position] != rune('U') {
+									goto l79
+								}
+								position++
+							}
+						l96:
+							{
+								position98, tokenIndex98 := position, tokenIndex
+								if buffer[position] != rune('n') {
+									goto l99
+								}
+								position++
+								goto l98
+							l99:
+								position, tokenIndex = position98, tokenIndex98
+								if buffer[position] != rune('N') {
+									goto l79
+								}
+								position++
+							}
+						l98:
+							{
+								position100, tokenIndex100 := position, tokenIndex
+								if buffer[position] != rune('i') {
+									goto l101
+								}
+								position++
+								goto l100
+							l101:
+								position, tokenIndex = position100, tokenIndex100
+								if buffer[position] != rune('I') {
+									goto l79
+								}
+								position++
+							}
+						l100:
+							{
+								position102, tokenIndex102 := position, tokenIndex
+								if buffer[position] != rune('o') {
+									goto l103
+								}
+								position++
+								goto l102
+							l103:
+								position, tokenIndex = position102, tokenIndex102
+								if buffer[position] != rune('O') {
+									goto l79
+								}
+								position++
+							}
+						l102:
+							{
+								position104, tokenIndex104 := position, tokenIndex
+								if buffer[position] != rune('n') {
+									goto l105
+								}
+								position++
+								goto l104
+							l105:
+								position, tokenIndex = position104, tokenIndex104
+								if buffer[position] != rune('N') {
+									goto l79
+								}
+								position++
+							}
+						l104:
+							if !_rules[rulesp]() {
+								goto l79
+							}
+							{
+								position106, tokenIndex106 := position, tokenIndex
+								if buffer[position] != rune('a') {
+									goto l107
+								}
+								position++
+								goto l106
+							l107:
+								position, tokenIndex = position106, tokenIndex106
+								if buffer[position] != rune('A') {
+									goto l79
+								}
+								position++
+							}
+						l106:
+							{
+								position108, tokenIndex108 := position, tokenIndex
+								if buffer[position] != rune('l') {
+									goto l109
+								}
+								position++
+								goto l108
+							l109:
+								position, tokenIndex = position108, tokenIndex108
+								if buffer[position] != rune('L') {
+									goto l79
+								}
+								position++
+							}
+						l108:
+							{
+								position110, tokenIndex110 := position, tokenIndex
+								if buffer[position] != rune('l') {
+									goto l111
+								}
+								position++
+								goto l110
+							l111:
+								position, tokenIndex = position110, tokenIndex110
+								if buffer[position] != rune('L') {
+									goto l79
+								}
+								position++
+							}
+						l110:
+							if !_rules[rulesp]() {
+								goto l79
+							}
+							if !_rules[ruleSelectStmt]() {
+								goto l79
+							}
+							goto l78
+						l79:
+							position, tokenIndex = position79, tokenIndex79
+						}
+						add(rulePegText, position77)
+					}
+					if !_rules[ruleAction3]() {
+						goto l76
+					}
+					goto l75
+				l76:
+					position, tokenIndex = position75, tokenIndex75
+					{
+						position112 := position
+						if !_rules[ruleSelectStmt]() {
+							goto l73
+						}
+						if !_rules[rulesp]() {
+							goto l73
+						}
+						{
+							position115, tokenIndex115 := position, tokenIndex
+							if buffer[position] != rune('u') {
+								goto l116
 							}
 							position++
-							goto l96
-						l97:
-							position, tokenIndex = position96, tokenIndex96
-							if buffer[position] != rune('L') {
-								goto l67
+							goto l115
+						l116:
+							position, tokenIndex = position115, tokenIndex115
+							if buffer[position] != rune('U') {
+								goto l73
 							}
 							position++
 						}
-					l96:
+					l115:
 						{
-							position98, tokenIndex98 := position, tokenIndex
-							if buffer[position] != rune('l') {
-								goto l99
+							position117, tokenIndex117 := position, tokenIndex
+							if buffer[position] != rune('n') {
+								goto l118
 							}
 							position++
-							goto l98
-						l99:
-							position, tokenIndex = position98, tokenIndex98
-							if buffer[position] != rune('L') {
-								goto l67
+							goto l117
+						l118:
+							position, tokenIndex = position117, tokenIndex117
+							if buffer[position] != rune('N') {
+								goto l73
 							}
 							position++
 						}
-					l98:
-						if !_rules[rulesp]() {
-							goto l67
+					l117:
+						{
+							position119, tokenIndex119 := position, tokenIndex
+							if buffer[position] != rune('i') {
+								goto l120
+							}
+							position++
+							goto l119
+						l120:
+							position, tokenIndex = position119, tokenIndex119
+							if buffer[position] != rune('I') {
+								goto l73
+							}
+							position++
 						}
-						if !_rules[ruleSelectStmt]() {
-							goto l67
+					l119:
+						{
+							position121, tokenIndex121 := position, tokenIndex
+							if buffer[position] != rune('o') {
+								goto l122
+							}
+							position++
+							goto l121
+						l122:
+							position, tokenIndex = position121, tokenIndex121
+							if buffer[position] != rune('O') {
+								goto l73
+							}
+							position++
 						}
-						goto l66
-					l67:
-						position, tokenIndex = position67, tokenIndex67
-					}
-					add(rulePegText, position65)
-				}
-				if !_rules[ruleAction3]() {
-					goto l63
-				}
-				add(ruleSelectUnionStmt, position64)
-			}
-			return true
-		l63:
-			position, tokenIndex = position63, tokenIndex63
-			return false
-		},
-		/* 10 CreateStreamAsSelectStmt <- <(('c' / 'C') ('r' / 'R') ('e' / 'E') ('a' / 'A') ('t' / 'T') ('e' / 'E') sp (('s' / 'S') ('t' / 'T') ('r' / 'R') ('e' / 'E') ('a' / 'A') ('m' / 'M')) sp StreamIdentifier sp (('a' / 'A') ('s' / 'S')) sp SelectStmt Action4)> */
-		func() bool {
-			position100, tokenIndex100 := position, tokenIndex
-			{
-				position101 := position
-				{
-					position102, tokenIndex102 := position, tokenIndex
-					if buffer[position] != rune('c') {
-						goto l103
+					l121:
+						{
+							position123, tokenIndex123 := position, tokenIndex
+							if buffer[position] != rune('n') {
+								goto l124
+							}
+							position++
+							goto l123
+						l124:
+							position, tokenIndex = position123, tokenIndex123
+							if buffer[position] != rune('N') {
+								goto l73
+							}
+							position++
+						}
+					l123:
+						if !_rules[rulesp]() {
+							goto l73
+						}
+						if !_rules[ruleSelectStmt]() {
+							goto l73
+						}
+					l113:
+						{
+							position114, tokenIndex114 := position, tokenIndex
+							if !_rules[rulesp]() {
+								goto l114
+							}
+							{
+								position125, tokenIndex125 := position, tokenIndex
+								if buffer[position] != rune('u') {
+									goto l126
+								}
+								position++
+								goto l125
+							l126:
+								position, tokenIndex = position125, tokenIndex125
+								if buffer[position] != rune('U') {
+									goto l114
+								}
+								position++
+							}
+						l125:
+							{
+								position127, tokenIndex127 := position, tokenIndex
+								if buffer[position] != rune('n') {
+									goto l128
+								}
+								position++
+								goto l127
+							l128:
+								position, tokenIndex = position127, tokenIndex127
+								if buffer[position] != rune('N') {
+									goto l114
+								}
+								position++
+							}
+						l127:
+							{
+								position129, tokenIndex129 := position, tokenIndex
+								if buffer[position] != rune('i') {
+									goto l130
+								}
+								position++
+								goto l129
+							l130:
+								position, tokenIndex = position129, tokenIndex129
+								if buffer[position] != rune('I') {
+									goto l114
+								}
+								position++
+							}
+						l129:
+							{
+								position131, tokenIndex131 := position, tokenIndex
+								if buffer[position] != rune('o') {
+									goto l132
+								}
+								position++
+								goto l131
+							l132:
+								position, tokenIndex = position131, tokenIndex131
+								if buffer[position] != rune('O') {
+									goto l114
+								}
+								position++
+							}
+						l131:
+							{
+								position133, tokenIndex133 := position, tokenIndex
+								if buffer[position] != rune('n') {
+									goto l134
+								}
+								position++
+								goto l133
+							l134:
+								position, tokenIndex = position133, tokenIndex133
+								if buffer[position] != rune('N') {
+									goto l114
+								}
+								position++
+							}
+						l133:
+							if !_rules[rulesp]() {
+								goto l114
+							}
+							if !_rules[ruleSelectStmt]() {
+								goto l114
+							}
+							goto l113
+						l114:
+							position, tokenIndex = position114, tokenIndex114
+						}
+						add(rulePegText, position112)
 					}
-					position++
-					goto l102
-				l103:
-					position, tokenIndex = position102, tokenIndex102
-					if buffer[position] != rune('C') {
-						goto l100
+					if !_rules[ruleAction4]() {
+						goto l73
 					}
-					position++
 				}
-			l102:
-				{
-					position104, tokenIndex104 := position, tokenIndex
-					if buffer[position] != rune('r') {
-						goto l105
-					}
-					position++
-					goto l104
-				l105:
-					position, tokenIndex = position104, tokenIndex104
-					if buffer[position] != rune('R') {
-						goto l100
-					}
-					position++
+			l75:
+				add(ruleSelectUnionStmt, position74)
+			}
+			return true
+		l73:
+			position, tokenIndex = position73, tokenIndex73
+			return false
+		},
+		/* 10 SelectStartingStmt <- <(SelectStmt sp (('s' / 'S') ('t' / 'T') ('a' / 'A') ('r' / 'R') ('t' / 'T') ('i' / 'I') ('n' / 'N') ('g' / 'G')) sp IntervalLiteralValue sp IntervalLiteralUnit sp (('a' / 'A') ('g' / 'G') ('o' / 'O')) Action5)> */
+		func() bool {
+			position135, tokenIndex135 := position, tokenIndex
+			{
+				position136 := position
+				if !_rules[ruleSelectStmt]() {
+					goto l135
 				}
-			l104:
-				{
-					position106, tokenIndex106 := position, tokenIndex
-					if buffer[position] != rune('e') {
-						goto l107
-					}
-					position++
-					goto l106
-				l107:
-					position, tokenIndex = position106, tokenIndex106
-					if buffer[position] != rune('E') {
-						goto l100
-					}
-					position++
+				if !_rules[rulesp]() {
+					goto l135
 				}
-			l106:
 				{
-					position108, tokenIndex108 := position, tokenIndex
-					if buffer[position] != rune('a') {
-						goto l109
+					position137, tokenIndex137 := position, tokenIndex
+					if buffer[position] != rune('s') {
+						goto l138
 					}
 					position++
-					goto l108
-				l109:
-					position, tokenIndex = position108, tokenIndex108
-					if buffer[position] != rune('A') {
-						goto l100
+					goto l137
+				l138:
+					position, tokenIndex = position137, tokenIndex137
+					if buffer[position] != rune('S') {
+						goto l135
 					}
 					position++
 				}
-			l108:
+			l137:
 				{
-					position110, tokenIndex110 := position, tokenIndex
+					position139, tokenIndex139 := position, tokenIndex
 					if buffer[position] != rune('t') {
-						goto l111
+						goto l140
 					}
 					position++
-					goto l110
-				l111:
-					position, tokenIndex = position110, tokenIndex110
+					goto l139
+				l140:
+					position, tokenIndex = position139, tokenIndex139
 					if buffer[position] != rune('T') {
-						goto l100
+						goto l135
 					}
 					position++
 				}
-			l110:
+			l139:
 				{
-					position112, tokenIndex112 := position, tokenIndex
-					if buffer[position] != rune('e') {
-						goto l113
+					position141, tokenIndex141 := position, tokenIndex
+					if buffer[position] != rune('a') {
+						goto l142
 					}
 					position++
-					goto l112
-				l113:
-					position, tokenIndex = position112, tokenIndex112
-					if buffer[position] != rune('E') {
-						goto l100
+					goto l141
+				l142:
+					position, tokenIndex = position141, tokenIndex141
+					if buffer[position] != rune('A') {
+						goto l135
 					}
 					position++
 				}
-			l112:
-				if !_rules[rulesp]() {
-					goto l100
-				}
+			l141:
 				{
-					position114, tokenIndex114 := position, tokenIndex
-					if buffer[position] != rune('s') {
-						goto l115
+					position143, tokenIndex143 := position, tokenIndex
+					if buffer[position] != rune('r') {
+						goto l144
 					}
 					position++
-					goto l114
-				l115:
-					position, tokenIndex = position114, tokenIndex114
-					if buffer[position] != rune('S') {
-						goto l100
+					goto l143
+				l144:
+					position, tokenIndex = position143, tokenIndex143
+					if buffer[position] != rune('R') {
+						goto l135
 					}
 					position++
 				}
-			l114:
+			l143:
 				{
-					position116, tokenIndex116 := position, tokenIndex
+					position145, tokenIndex145 := position, tokenIndex
 					if buffer[position] != rune('t') {
-						goto l117
+						goto l146
 					}
 					position++
-					goto l116
-				l117:
-					position, tokenIndex = position116, tokenIndex116
+					goto l145
+				l146:
+					position, tokenIndex = position145, tokenIndex145
 					if buffer[position] != rune('T') {
-						goto l100
+						goto l135
 					}
 					position++
 				}
-			l116:
+			l145:
 				{
-					position118, tokenIndex118 := position, tokenIndex
-					if buffer[position] != rune('r') {
-						goto l119
+					position147, tokenIndex147 := position, tokenIndex
+					if buffer[position] != rune('i') {
+						goto l148
 					}
 					position++
-					goto l118
-				l119:
-					position, tokenIndex = position118, tokenIndex118
-					if buffer[position] != rune('R') {
-						goto l100
+					goto l147
+				l148:
+					position, tokenIndex = position147, tokenIndex147
+					if buffer[position] != rune('I') {
+						goto l135
 					}
 					position++
 				}
-			l118:
+			l147:
 				{
-					position120, tokenIndex120 := position, tokenIndex
-					if buffer[position] != rune('e') {
-						goto l121
+					position149, tokenIndex149 := position, tokenIndex
+					if buffer[position] != rune('n') {
+						goto l150
 					}
 					position++
-					goto l120
-				l121:
-					position, tokenIndex = position120, tokenIndex120
-					if buffer[position] != rune('E') {
-						goto l100
+					goto l149
+				l150:
+					position, tokenIndex = position149, tokenIndex149
+					if buffer[position] != rune('N') {
+						goto l135
 					}
 					position++
 				}
-			l120:
+			l149:
 				{
-					position122, tokenIndex122 := position, tokenIndex
-					if buffer[position] != rune('a') {
-						goto l123
+					position151, tokenIndex151 := position, tokenIndex
+					if buffer[position] != rune('g') {
+						goto l152
 					}
 					position++
-					goto l122
-				l123:
-					position, tokenIndex = position122, tokenIndex122
-					if buffer[position] != rune('A') {
-						goto l100
+					goto l151
+				l152:
+					position, tokenIndex = position151, tokenIndex151
+					if buffer[position] != rune('G') {
+						goto l135
 					}
 					position++
 				}
-			l122:
-				{
-					position124, tokenIndex124 := position, tokenIndex
-					if buffer[position] != rune('m') {
-						goto l125
-					}
-					position++
-					goto l124
-				l125:
-					position, tokenIndex = position124, tokenIndex124
-					if buffer[position] != rune('M') {
-						goto l100
-					}
-					position++
+			l151:
+				if !_rules[rulesp]() {
+					goto l135
+				}
+				if !_rules[ruleIntervalLiteralValue]() {
+					goto l135
 				}
-			l124:
 				if !_rules[rulesp]() {
-					goto l100
+					goto l135
 				}
-				if !_rules[ruleStreamIdentifier]() {
-					goto l100
+				if !_rules[ruleIntervalLiteralUnit]() {
+					goto l135
 				}
 				if !_rules[rulesp]() {
-					goto l100
+					goto l135
 				}
 				{
-					position126, tokenIndex126 := position, tokenIndex
+					position153, tokenIndex153 := position, tokenIndex
 					if buffer[position] != rune('a') {
-						goto l127
+						goto l154
 					}
 					position++
-					goto l126
-				l127:
-					position, tokenIndex = position126, tokenIndex126
+					goto l153
+				l154:
+					position, tokenIndex = position153, tokenIndex153
 					if buffer[position] != rune('A') {
-						goto l100
+						goto l135
 					}
 					position++
 				}
-			l126:
+			l153:
 				{
-					position128, tokenIndex128 := position, tokenIndex
-					if buffer[position] != rune('s') {
-						goto l129
+					position155, tokenIndex155 := position, tokenIndex
+					if buffer[position] != rune('g') {
+						goto l156
 					}
 					position++
-					goto l128
-				l129:
-					position, tokenIndex = position128, tokenIndex128
-					if buffer[position] != rune('S') {
-						goto l100
+					goto l155
+				l156:
+					position, tokenIndex = position155, tokenIndex155
+					if buffer[position] != rune('G') {
+						goto l135
 					}
 					position++
 				}
-			l128:
-				if !_rules[rulesp]() {
-					goto l100
-				}
-				if !_rules[ruleSelectStmt]() {
-					goto l100
+			l155:
+				{
+					position157, tokenIndex157 := position, tokenIndex
+					if buffer[position] != rune('o') {
+						goto l158
+					}
+					position++
+					goto l157
+				l158:
+					position, tokenIndex = position157, tokenIndex157
+					if buffer[position] != rune('O') {
+						goto l135
+					}
+					position++
 				}
-				if !_rules[ruleAction4]() {
-					goto l100
+			l157:
+				if !_rules[ruleAction5]() {
+					goto l135
 				}
-				add(ruleCreateStreamAsSelectStmt, position101)
+				add(ruleSelectStartingStmt, position136)
 			}
 			return true
-		l100:
-			position, tokenIndex = position100, tokenIndex100
+		l135:
+			position, tokenIndex = position135, tokenIndex135
 			return false
 		},
-		/* 11 CreateStreamAsSelectUnionStmt <- <(('c' / 'C') ('r' / 'R') ('e' / 'E') ('a' / 'A') ('t' / 'T') ('e' / 'E') sp (('s' / 'S') ('t' / 'T') ('r' / 'R') ('e' / 'E') ('a' / 'A') ('m' / 'M')) sp StreamIdentifier sp (('a' / 'A') ('s' / 'S')) sp SelectUnionStmt Action5)> */
+		/* 11 CreateStreamAsSelectStmt <- <(('c' / 'C') ('r' / 'R') ('e' / 'E') ('a' / 'A') ('t' / 'T') ('e' / 'E') sp (('s' / 'S') ('t' / 'T') ('r' / 'R') ('e' / 'E') ('a' / 'A') ('m' / 'M')) sp StreamIdentifier sp (('a' / 'A') ('s' / 'S')) sp SelectStmt SourceSinkSpecs Action6)> */
 		func() bool {
-			position130, tokenIndex130 := position, tokenIndex
+			position159, tokenIndex159 := position, tokenIndex
 			{
-				position131 := position
+				position160 := position
 				{
-					position132, tokenIndex132 := position, tokenIndex
+					position161, tokenIndex161 := position, tokenIndex
 					if buffer[position] != rune('c') {
-						goto l133
+						goto l162
 					}
 					position++
-					goto l132
-				l133:
-					position, tokenIndex = position132, tokenIndex132
+					goto l161
+				l162:
+					position, tokenIndex = position161, tokenIndex161
 					if buffer[position] != rune('C') {
-						goto l130
+						goto l159
 					}
 					position++
 				}
-			l132:
+			l161:
 				{
-					position134, tokenIndex134 := position, tokenIndex
+					position163, tokenIndex163 := position, tokenIndex
 					if buffer[position] != rune('r') {
-						goto l135
+						goto l164
 					}
 					position++
-					goto l134
-				l135:
-					position, tokenIndex = position134, tokenIndex134
+					goto l163
+				l164:
+					position, tokenIndex = position163, tokenIndex163
 					if buffer[position] != rune('R') {
-						goto l130
+						goto l159
 					}
 					position++
 				}
-			l134:
+			l163:
 				{
-					position136, tokenIndex136 := position, tokenIndex
+					position165, tokenIndex165 := position, tokenIndex
 					if buffer[position] != rune('e') {
-						goto l137
+						goto l166
 					}
 					position++
-					goto l136
-				l137:
-					position, tokenIndex = position136, tokenIndex136
+					goto l165
+				l166:
+					position, tokenIndex = position165, tokenIndex165
 					if buffer[position] != rune('E') {
-						goto l130
+						goto l159
 					}
 					position++
 				}
-			l136:
+			l165:
 				{
-					position138, tokenIndex138 := position, tokenIndex
+					position167, tokenIndex167 := position, tokenIndex
 					if buffer[position] != rune('a') {
-						goto l139
+						goto l168
 					}
 					position++
-					goto l138
-				l139:
-					position, tokenIndex = position138, tokenIndex138
+					goto l167
+				l168:
+					position, tokenIndex = position167, tokenIndex167
 					if buffer[position] != rune('A') {
-						goto l130
+						goto l159
 					}
 					position++
 				}
-			l138:
+			l167:
 				{
-					position140, tokenIndex140 := position, tokenIndex
+					position169, tokenIndex169 := position, tokenIndex
 					if buffer[position] != rune('t') {
-						goto l141
+						goto l170
 					}
 					position++
-					goto l140
-				l141:
-					position, tokenIndex = position140, tokenIndex140
+					goto l169
+				l170:
+					position, tokenIndex = position169, tokenIndex169
 					if buffer[position] != rune('T') {
-						goto l130
+						goto l159
 					}
 					position++
 				}
-			l140:
+			l169:
 				{
-					position142, tokenIndex142 := position, tokenIndex
+					position171, tokenIndex171 := position, tokenIndex
 					if buffer[position] != rune('e') {
-						goto l143
+						goto l172
 					}
 					position++
-					goto l142
-				l143:
-					position, tokenIndex = position142, tokenIndex142
+					goto l171
+				l172:
+					position, tokenIndex = position171, tokenIndex171
 					if buffer[position] != rune('E') {
-						goto l130
+						goto l159
 					}
 					position++
 				}
-			l142:
+			l171:
 				if !_rules[rulesp]() {
-					goto l130
+					goto l159
 				}
 				{
-					position144, tokenIndex144 := position, tokenIndex
+					position173, tokenIndex173 := position, tokenIndex
 					if buffer[position] != rune('s') {
-						goto l145
+						goto l174
 					}
 					position++
-					goto l144
-				l145:
-					position, tokenIndex = position144, tokenIndex144
+					goto l173
+				l174:
+					position, tokenIndex = position173, tokenIndex173
 					if buffer[position] != rune('S') {
-						goto l130
+						goto l159
 					}
 					position++
 				}
-			l144:
+			l173:
 				{
-					position146, tokenIndex146 := position, tokenIndex
+					position175, tokenIndex175 := position, tokenIndex
 					if buffer[position] != rune('t') {
-						goto l147
+						goto l176
 					}
 					position++
-					goto l146
-				l147:
-					position, tokenIndex = position146, tokenIndex146
+					goto l175
+				l176:
+					position, tokenIndex = position175, tokenIndex175
 					if buffer[position] != rune('T') {
-						goto l130
+						goto l159
 					}
 					position++
 				}
-			l146:
+			l175:
 				{
-					position148, tokenIndex148 := position, tokenIndex
+					position177, tokenIndex177 := position, tokenIndex
 					if buffer[position] != rune('r') {
-						goto l149
+						goto l178
 					}
 					position++
-					goto l148
-				l149:
-					position, tokenIndex = position148, tokenIndex148
+					goto l177
+				l178:
+					position, tokenIndex = position177, tokenIndex177
 					if buffer[position] != rune('R') {
-						goto l130
+						goto l159
 					}
 					position++
 				}
-			l148:
+			l177:
 				{
-					position150, tokenIndex150 := position, tokenIndex
+					position179, tokenIndex179 := position, tokenIndex
 					if buffer[position] != rune('e') {
-						goto l151
+						goto l180
 					}
 					position++
-					goto l150
-				l151:
-					position, tokenIndex = position150, tokenIndex150
+					goto l179
+				l180:
+					position, tokenIndex = position179, tokenIndex179
 					if buffer[position] != rune('E') {
-						goto l130
+						goto l159
 					}
 					position++
 				}
-			l150:
+			l179:
 				{
-					position152, tokenIndex152 := position, tokenIndex
+					position181, tokenIndex181 := position, tokenIndex
 					if buffer[position] != rune('a') {
-						goto l153
+						goto l182
 					}
 					position++
-					goto l152
-				l153:
-					position, tokenIndex = position152, tokenIndex152
+					goto l181
+				l182:
+					position, tokenIndex = position181, tokenIndex181
 					if buffer[position] != rune('A') {
-						goto l130
+						goto l159
 					}
 					position++
 				}
-			l152:
+			l181:
 				{
-					position154, tokenIndex154 := position, tokenIndex
+					position183, tokenIndex183 := position, tokenIndex
 					if buffer[position] != rune('m') {
-						goto l155
+						goto l184
 					}
 					position++
-					goto l154
-				l155:
-					position, tokenIndex = position154, tokenIndex154
+					goto l183
+				l184:
+					position, tokenIndex = position183, tokenIndex183
 					if buffer[position] != rune('M') {
-						goto l130
+						goto l159
 					}
 					position++
 				}
-			l154:
+			l183:
 				if !_rules[rulesp]() {
-					goto l130
+					goto l159
 				}
 				if !_rules[ruleStreamIdentifier]() {
-					goto l130
+					goto l159
 				}
 				if !_rules[rulesp]() {
-					goto l130
+					goto l159
 				}
 				{
-					position156, tokenIndex156 := position, tokenIndex
+					position185, tokenIndex185 := position, tokenIndex
 					if buffer[position] != rune('a') {
-						goto l157
+						goto l186
 					}
 					position++
-					goto l156
-				l157:
-					position, tokenIndex = position156, tokenIndex156
+					goto l185
+				l186:
+					position, tokenIndex = position185, tokenIndex185
 					if buffer[position] != rune('A') {
-						goto l130
+						goto l159
 					}
 					position++
 				}
-			l156:
+			l185:
 				{
-					position158, tokenIndex158 := position, tokenIndex
+					position187, tokenIndex187 := position, tokenIndex
 					if buffer[position] != rune('s') {
-						goto l159
+						goto l188
 					}
 					position++
-					goto l158
-				l159:
-					position, tokenIndex = position158, tokenIndex158
+					goto l187
+				l188:
+					position, tokenIndex = position187, tokenIndex187
 					if buffer[position] != rune('S') {
-						goto l130
+						goto l159
 					}
 					position++
 				}
-			l158:
+			l187:
 				if !_rules[rulesp]() {
-					goto l130
+					goto l159
 				}
-				if !_rules[ruleSelectUnionStmt]() {
-					goto l130
+				if !_rules[ruleSelectStmt]() {
+					goto l159
 				}
-				if !_rules[ruleAction5]() {
-					goto l130
+				if !_rules[ruleSourceSinkSpecs]() {
+					goto l159
+				}
+				if !_rules[ruleAction6]() {
+					goto l159
 				}
-				add(ruleCreateStreamAsSelectUnionStmt, position131)
+				add(ruleCreateStreamAsSelectStmt, position160)
 			}
 			return true
-		l130:
-			position, tokenIndex = position130, tokenIndex130
+		l159:
+			position, tokenIndex = position159, tokenIndex159
 			return false
 		},
-		/* 12 CreateSourceStmt <- <(('c' / 'C') ('r' / 'R') ('e' / 'E') ('a' / 'A') ('t' / 'T') ('e' / 'E') PausedOpt sp (('s' / 'S') ('o' / 'O') ('u' / 'U') ('r' / 'R') ('c' / 'C') ('e' / 'E')) sp StreamIdentifier sp (('t' / 'T') ('y' / 'Y') ('p' / 'P') ('e' / 'E')) sp SourceSinkType SourceSinkSpecs Action6)> */
+		/* 12 CreateStreamAsSelectUnionStmt <- <(('c' / 'C') ('r' / 'R') ('e' / 'E') ('a' / 'A') ('t' / 'T') ('e' / 'E') sp (('s' / 'S') ('t' / 'T') ('r' / 'R') ('e' / 'E') ('a' / 'A') ('m' / 'M')) sp StreamIdentifier sp (('a' / 'A') ('s' / 'S')) sp SelectUnionStmt Action7)> */
 		func() bool {
-			position160, tokenIndex160 := position, tokenIndex
+			position189, tokenIndex189 := position, tokenIndex
 			{
-				position161 := position
+				position190 := position
 				{
-					position162, tokenIndex162 := position, tokenIndex
+					position191, tokenIndex191 := position, tokenIndex
 					if buffer[position] != rune('c') {
-						goto l163
+						goto l192
 					}
 					position++
-					goto l162
-				l163:
-					position, tokenIndex = position162, tokenIndex162
+					goto l191
+				l192:
+					position, tokenIndex = position191, tokenIndex191
 					if buffer[position] != rune('C') {
-						goto l160
+						goto l189
 					}
 					position++
 				}
-			l162:
+			l191:
 				{
-					position164, tokenIndex164 := position, tokenIndex
+					position193, tokenIndex193 := position, tokenIndex
 					if buffer[position] != rune('r') {
-						goto l165
+						goto l194
 					}
 					position++
-					goto l164
-				l165:
-					position, tokenIndex = position164, tokenIndex164
+					goto l193
+				l194:
+					position, tokenIndex = position193, tokenIndex193
 					if buffer[position] != rune('R') {
-						goto l160
+						goto l189
 					}
 					position++
 				}
-			l164:
+			l193:
 				{
-					position166, tokenIndex166 := position, tokenIndex
+					position195, tokenIndex195 := position, tokenIndex
 					if buffer[position] != rune('e') {
-						goto l167
+						goto l196
 					}
 					position++
-					goto l166
-				l167:
-					position, tokenIndex = position166, tokenIndex166
+					goto l195
+				l196:
+					position, tokenIndex = position195, tokenIndex195
 					if buffer[position] != rune('E') {
-						goto l160
+						goto l189
 					}
 					position++
 				}
-			l166:
+			l195:
 				{
-					position168, tokenIndex168 := position, tokenIndex
+					position197, tokenIndex197 := position, tokenIndex
 					if buffer[position] != rune('a') {
-						goto l169
+						goto l198
 					}
 					position++
-					goto l168
-				l169:
-					position, tokenIndex = position168, tokenIndex168
+					goto l197
+				l198:
+					position, tokenIndex = position197, tokenIndex197
 					if buffer[position] != rune('A') {
-						goto l160
+						goto l189
 					}
 					position++
 				}
-			l168:
+			l197:
 				{
-					position170, tokenIndex170 := position, tokenIndex
+					position199, tokenIndex199 := position, tokenIndex
 					if buffer[position] != rune('t') {
-						goto l171
+						goto l200
 					}
 					position++
-					goto l170
-				l171:
-					position, tokenIndex = position170, tokenIndex170
+					goto l199
+				l200:
+					position, tokenIndex = position199, tokenIndex199
 					if buffer[position] != rune('T') {
-						goto l160
+						goto l189
 					}
 					position++
 				}
-			l170:
+			l199:
 				{
-					position172, tokenIndex172 := position, tokenIndex
+					position201, tokenIndex201 := position, tokenIndex
 					if buffer[position] != rune('e') {
-						goto l173
+						goto l202
 					}
 					position++
-					goto l172
-				l173:
-					position, tokenIndex = position172, tokenIndex172
+					goto l201
+				l202:
+					position, tokenIndex = position201, tokenIndex201
 					if buffer[position] != rune('E') {
-						goto l160
+						goto l189
 					}
 					position++
 				}
-			l172:
-				if !_rules[rulePausedOpt]() {
-					goto l160
-				}
+			l201:
 				if !_rules[rulesp]() {
-					goto l160
+					goto l189
 				}
 				{
-					position174, tokenIndex174 := position, tokenIndex
+					position203, tokenIndex203 := position, tokenIndex
 					if buffer[position] != rune('s') {
-						goto l175
+						goto l204
 					}
 					position++
-					goto l174
-				l175:
-					position, tokenIndex = position174, tokenIndex174
+					goto l203
+				l204:
+					position, tokenIndex = position203, tokenIndex203
 					if buffer[position] != rune('S') {
-						goto l160
+						goto l189
 					}
 					position++
 				}
-			l174:
+			l203:
 				{
-					position176, tokenIndex176 := position, tokenIndex
-					if buffer[position] != rune('o') {
-						goto l177
+					position205, tokenIndex205 := position, tokenIndex
+					if buffer[position] != rune('t') {
+						goto l206
 					}
 					position++
-					goto l176
-				l177:
-					position, tokenIndex = position176, tokenIndex176
-					if buffer[position] != rune('O') {
-						goto l160
+					goto l205
+				l206:
+					position, tokenIndex = position205, tokenIndex205
+					if buffer[position] != rune('T') {
+						goto l189
 					}
 					position++
 				}
-			l176:
+			l205:
 				{
-					position178, tokenIndex178 := position, tokenIndex
-					if buffer[position] != rune('u') {
-						goto l179
+					position207, tokenIndex207 := position, tokenIndex
+					if buffer[position] != rune('r') {
+						goto l208
 					}
 					position++
-					goto l178
-				l179:
-					position, tokenIndex = position178, tokenIndex178
-					if buffer[position] != rune('U') {
-						goto l160
+					goto l207
+				l208:
+					position, tokenIndex = position207, tokenIndex207
+					if buffer[position] != rune('R') {
+						goto l189
 					}
 					position++
 				}
-			l178:
+			l207:
 				{
-					position180, tokenIndex180 := position, tokenIndex
-					if buffer[position] != rune('r') {
-						goto l181
+					position209, tokenIndex209 := position, tokenIndex
+					if buffer[position] != rune('e') {
+						goto l210
 					}
 					position++
-					goto l180
-				l181:
-					position, tokenIndex = position180, tokenIndex180
-					if buffer[position] != rune('R') {
-						goto l160
+					goto l209
+				l210:
+					position, tokenIndex = position209, tokenIndex209
+					if buffer[position] != rune('E') {
+						goto l189
 					}
 					position++
 				}
-			l180:
+			l209:
 				{
-					position182, tokenIndex182 := position, tokenIndex
-					if buffer[position] != rune('c') {
-						goto l183
+					position211, tokenIndex211 := position, tokenIndex
+					if buffer[position] != rune('a') {
+						goto l212
 					}
 					position++
-					goto l182
-				l183:
-					position, tokenIndex = position182, tokenIndex182
-					if buffer[position] != rune('C') {
-						goto l160
+					goto l211
+				l212:
+					position, tokenIndex = position211, tokenIndex211
+					if buffer[position] != rune('A') {
+						goto l189
 					}
 					position++
 				}
-			l182:
+			l211:
 				{
-					position184, tokenIndex184 := position, tokenIndex
-					if buffer[position] != rune('e') {
-						goto l185
+					position213, tokenIndex213 := position, tokenIndex
+					if buffer[position] != rune('m') {
+						goto l214
 					}
 					position++
-					goto l184
-				l185:
-					position, tokenIndex = position184, tokenIndex184
-					if buffer[position] != rune('E') {
-						goto l160
+					goto l213
+				l214:
+					position, tokenIndex = position213, tokenIndex213
+					if buffer[position] != rune('M') {
+						goto l189
 					}
 					position++
 				}
-			l184:
+			l213:
 				if !_rules[rulesp]() {
-					goto l160
+					goto l189
 				}
 				if !_rules[ruleStreamIdentifier]() {
-					goto l160
+					goto l189
 				}
 				if !_rules[rulesp]() {
-					goto l160
+					goto l189
 				}
 				{
-					position186, tokenIndex186 := position, tokenIndex
-					if buffer[position] != rune('t') {
-						goto l187
-					}
-					position++
-					goto l186
-				l187:
-					position, tokenIndex = position186, tokenIndex186
-					if buffer[position] != rune('T') {
-						goto l160
+					position215, tokenIndex215 := position, tokenIndex
+					if buffer[position] != rune('a') {
+						goto l216
 					}
 					position++
-				}
-			l186:
-				{
-					position188, tokenIndex188 := position, tokenIndex
-					if buffer[position] != rune('y') {
+					goto l215
+				l216:
+					position, tokenIndex = position215, tokenIndex215
+					if buffer[position] != rune('A') {
 						goto l189
 					}
 					position++
-					goto l188
-				l189:
-					position, tokenIndex = position188, tokenIndex188
-					if buffer[position] != rune('Y') {
-						goto l160
-					}
-					position++
-				}
-			l188:
-				{
-					position190, tokenIndex190 := position, tokenIndex
-					if buffer[position] != rune('p') {
-						goto l191
-					}
-					position++
-					goto l190
-				l191:
-					position, tokenIndex = position190, tokenIndex190
-					if buffer[position] != rune('P') {
-						goto l160
-					}
-					position++
 				}
-			l190:
+			l215:
 				{
-					position192, tokenIndex192 := position, tokenIndex
-					if buffer[position] != rune('e') {
-						goto l193
+					position217, tokenIndex217 := position, tokenIndex
+					if buffer[position] != rune('s') {
+						goto l218
 					}
 					position++
-					goto l192
-				l193:
-					position, tokenIndex = position192, tokenIndex192
-					if buffer[position] != rune('E') {
-						goto l160
+					goto l217
+				l218:
+					position, tokenIndex = position217, tokenIndex217
+					if buffer[position] != rune('S') {
+						goto l189
 					}
 					position++
 				}
-			l192:
+			l217:
 				if !_rules[rulesp]() {
-					goto l160
-				}
-				if !_rules[ruleSourceSinkType]() {
-					goto l160
+					goto l189
 				}
-				if !_rules[ruleSourceSinkSpecs]() {
-					goto l160
+				if !_rules[ruleSelectUnionStmt]() {
+					goto l189
 				}
-				if !_rules[ruleAction6]() {
-					goto l160
+				if !_rules[ruleAction7]() {
+					goto l189
 				}
-				add(ruleCreateSourceStmt, position161)
+				add(ruleCreateStreamAsSelectUnionStmt, position190)
 			}
 			return true
-		l160:
-			position, tokenIndex = position160, tokenIndex160
+		l189:
+			position, tokenIndex = position189, tokenIndex189
 			return false
 		},
-		/* 13 CreateSinkStmt <- <(('c' / 'C') ('r' / 'R') ('e' / 'E') ('a' / 'A') ('t' / 'T') ('e' / 'E') sp (('s' / 'S') ('i' / 'I') ('n' / 'N') ('k' / 'K')) sp StreamIdentifier sp (('t' / 'T') ('y' / 'Y') ('p' / 'P') ('e' / 'E')) sp SourceSinkType SourceSinkSpecs Action7)> */
+		/* 13 CreateStreamAsEnrichStmt <- <(('c' / 'C') ('r' / 'R') ('e' / 'E') ('a' / 'A') ('t' / 'T') ('e' / 'E') sp (('s' / 'S') ('t' / 'T') ('r' / 'R') ('e' / 'E') ('a' / 'A') ('m' / 'M')) sp StreamIdentifier sp (('a' / 'A') ('s' / 'S')) sp (('e' / 'E') ('n' / 'N') ('r' / 'R') ('i' / 'I') ('c' / 'C') ('h' / 'H')) sp StreamIdentifier sp (('u' / 'U') ('s' / 'S') ('i' / 'I') ('n' / 'N') ('g' / 'G')) sp SourceSinkType SourceSinkSpecs Action8)> */
 		func() bool {
-			position194, tokenIndex194 := position, tokenIndex
+			position219, tokenIndex219 := position, tokenIndex
 			{
-				position195 := position
+				position220 := position
 				{
-					position196, tokenIndex196 := position, tokenIndex
+					position221, tokenIndex221 := position, tokenIndex
 					if buffer[position] != rune('c') {
-						goto l197
+						goto l222
 					}
 					position++
-					goto l196
-				l197:
-					position, tokenIndex = position196, tokenIndex196
+					goto l221
+				l222:
+					position, tokenIndex = position221, tokenIndex221
 					if buffer[position] != rune('C') {
-						goto l194
+						goto l219
 					}
 					position++
 				}
-			l196:
+			l221:
 				{
-					position198, tokenIndex198 := position, tokenIndex
+					position223, tokenIndex223 := position, tokenIndex
 					if buffer[position] != rune('r') {
-						goto l199
+						goto l224
 					}
 					position++
-					goto l198
-				l199:
-					position, tokenIndex = position198, tokenIndex198
+					goto l223
+				l224:
+					position, tokenIndex = position223, tokenIndex223
 					if buffer[position] != rune('R') {
-						goto l194
+						goto l219
 					}
 					position++
 				}
-			l198:
+			l223:
 				{
-					position200, tokenIndex200 := position, tokenIndex
+					position225, tokenIndex225 := position, tokenIndex
 					if buffer[position] != rune('e') {
-						goto l201
+						goto l226
 					}
 					position++
-					goto l200
-				l201:
-					position, tokenIndex = position200, tokenIndex200
+					goto l225
+				l226:
+					position, tokenIndex = position225, tokenIndex225
 					if buffer[position] != rune('E') {
-						goto l194
+						goto l219
 					}
 					position++
 				}
-			l200:
+			l225:
 				{
-					position202, tokenIndex202 := position, tokenIndex
+					position227, tokenIndex227 := position, tokenIndex
 					if buffer[position] != rune('a') {
-						goto l203
+						goto l228
 					}
 					position++
-					goto l202
-				l203:
-					position, tokenIndex = position202, tokenIndex202
+					goto l227
+				l228:
+					position, tokenIndex = position227, tokenIndex227
 					if buffer[position] != rune('A') {
-						goto l194
+						goto l219
 					}
 					position++
 				}
-			l202:
+			l227:
 				{
-					position204, tokenIndex204 := position, tokenIndex
+					position229, tokenIndex229 := position, tokenIndex
 					if buffer[position] != rune('t') {
-						goto l205
+						goto l230
 					}
 					position++
-					goto l204
-				l205:
-					position, tokenIndex = position204, tokenIndex204
+					goto l229
+				l230:
+					position, tokenIndex = position229, tokenIndex229
 					if buffer[position] != rune('T') {
-						goto l194
+						goto l219
 					}
 					position++
 				}
-			l204:
+			l229:
 				{
-					position206, tokenIndex206 := position, tokenIndex
+					position231, tokenIndex231 := position, tokenIndex
 					if buffer[position] != rune('e') {
-						goto l207
+						goto l232
 					}
 					position++
-					goto l206
-				l207:
-					position, tokenIndex = position206, tokenIndex206
+					goto l231
+				l232:
+					position, tokenIndex = position231, tokenIndex231
 					if buffer[position] != rune('E') {
-						goto l194
+						goto l219
 					}
 					position++
 				}
-			l206:
+			l231:
 				if !_rules[rulesp]() {
-					goto l194
+					goto l219
 				}
 				{
-					position208, tokenIndex208 := position, tokenIndex
+					position233, tokenIndex233 := position, tokenIndex
 					if buffer[position] != rune('s') {
-						goto l209
+						goto l234
 					}
 					position++
-					goto l208
-				l209:
-					position, tokenIndex = position208, tokenIndex208
+					goto l233
+				l234:
+					position, tokenIndex = position233, tokenIndex233
 					if buffer[position] != rune('S') {
-						goto l194
+						goto l219
 					}
 					position++
 				}
-			l208:
+			l233:
 				{
-					position210, tokenIndex210 := position, tokenIndex
-					if buffer[position] != rune('i') {
-						goto l211
+					position235, tokenIndex235 := position, tokenIndex
+					if buffer[position] != rune('t') {
+						goto l236
 					}
 					position++
-					goto l210
-				l211:
-					position, tokenIndex = position210, tokenIndex210
-					if buffer[position] != rune('I') {
-						goto l194
+					goto l235
+				l236:
+					position, tokenIndex = position235, tokenIndex235
+					if buffer[position] != rune('T') {
+						goto l219
 					}
 					position++
 				}
-			l210:
+			l235:
 				{
-					position212, tokenIndex212 := position, tokenIndex
-					if buffer[position] != rune('n') {
-						goto l213
+					position237, tokenIndex237 := position, tokenIndex
+					if buffer[position] != rune('r') {
+						goto l238
 					}
 					position++
-					goto l212
-				l213:
-					position, tokenIndex = position212, tokenIndex212
-					if buffer[position] != rune('N') {
-						goto l194
+					goto l237
+				l238:
+					position, tokenIndex = position237, tokenIndex237
+					if buffer[position] != rune('R') {
+						goto l219
 					}
 					position++
 				}
-			l212:
+			l237:
 				{
-					position214, tokenIndex214 := position, tokenIndex
-					if buffer[position] != rune('k') {
-						goto l215
+					position239, tokenIndex239 := position, tokenIndex
+					if buffer[position] != rune('e') {
+						goto l240
 					}
 					position++
-					goto l214
-				l215:
-					position, tokenIndex = position214, tokenIndex214
-					if buffer[position] != rune('K') {
-						goto l194
+					goto l239
+				l240:
+					position, tokenIndex = position239, tokenIndex239
+					if buffer[position] != rune('E') {
+						goto l219
+					}
+					position++
+				}
+			l239:
+				{
+					position241, tokenIndex241 := position, tokenIndex
+					if buffer[position] != rune('a') {
+						goto l242
+					}
+					position++
+					goto l241
+				l242:
+					position, tokenIndex = position241, tokenIndex241
+					if buffer[position] != rune('A') {
+						goto l219
+					}
+					position++
+				}
+			l241:
+				{
+					position243, tokenIndex243 := position, tokenIndex
+					if buffer[position] != rune('m') {
+						goto l244
+					}
+					position++
+					goto l243
+				l244:
+					position, tokenIndex = position243, tokenIndex243
+					if buffer[position] != rune('M') {
+						goto l219
 					}
 					position++
 				}
-			l214:
+			l243:
 				if !_rules[rulesp]() {
-					goto l194
+					goto l219
 				}
 				if !_rules[ruleStreamIdentifier]() {
-					goto l194
+					goto l219
 				}
 				if !_rules[rulesp]() {
-					goto l194
+					goto l219
 				}
 				{
-					position216, tokenIndex216 := position, tokenIndex
-					if buffer[position] != rune('t') {
-						goto l217
+					position245, tokenIndex245 := position, tokenIndex
+					if buffer[position] != rune('a') {
+						goto l246
 					}
 					position++
-					goto l216
-				l217:
-					position, tokenIndex = position216, tokenIndex216
-					if buffer[position] != rune('T') {
-						goto l194
+					goto l245
+				l246:
+					position, tokenIndex = position245, tokenIndex245
+					if buffer[position] != rune('A') {
+						goto l219
 					}
 					position++
 				}
-			l216:
+			l245:
 				{
-					position218, tokenIndex218 := position, tokenIndex
-					if buffer[position] != rune('y') {
+					position247, tokenIndex247 := position, tokenIndex
+					if buffer[position] != rune('s') {
+						goto l248
+					}
+					position++
+					goto l247
+				l248:
+					position, tokenIndex = position247, tokenIndex247
+					if buffer[position] != rune('S') {
 						goto l219
 					}
 					position++
-					goto l218
-				l219:
-					position, tokenIndex = position218, tokenIndex218
-					if buffer[position] != rune('Y') {
-						goto l194
+				}
+			l247:
+				if !_rules[rulesp]() {
+					goto l219
+				}
+				{
+					position249, tokenIndex249 := position, tokenIndex
+					if buffer[position] != rune('e') {
+						goto l250
+					}
+					position++
+					goto l249
+				l250:
+					position, tokenIndex = position249, tokenIndex249
+					if buffer[position] != rune('E') {
+						goto l219
 					}
 					position++
 				}
-			l218:
+			l249:
 				{
-					position220, tokenIndex220 := position, tokenIndex
-					if buffer[position] != rune('p') {
-						goto l221
+					position251, tokenIndex251 := position, tokenIndex
+					if buffer[position] != rune('n') {
+						goto l252
 					}
 					position++
-					goto l220
-				l221:
-					position, tokenIndex = position220, tokenIndex220
-					if buffer[position] != rune('P') {
-						goto l194
+					goto l251
+				l252:
+					position, tokenIndex = position251, tokenIndex251
+					if buffer[position] != rune('N') {
+						goto l219
 					}
 					position++
 				}
-			l220:
+			l251:
 				{
-					position222, tokenIndex222 := position, tokenIndex
-					if buffer[position] != rune('e') {
-						goto l223
+					position253, tokenIndex253 := position, tokenIndex
+					if buffer[position] != rune('r') {
+						goto l254
 					}
 					position++
-					goto l222
-				l223:
-					position, tokenIndex = position222, tokenIndex222
-					if buffer[position] != rune('E') {
-						goto l194
+					goto l253
+				l254:
+					position, tokenIndex = position253, tokenIndex253
+					if buffer[position] != rune('R') {
+						goto l219
+					}
+					position++
+				}
+			l253:
+				{
+					position255, tokenIndex255 := position, tokenIndex
+					if buffer[position] != rune('i') {
+						goto l256
+					}
+					position++
+					goto l255
+				l256:
+					position, tokenIndex = position255, tokenIndex255
+					if buffer[position] != rune('I') {
+						goto l219
+					}
+					position++
+				}
+			l255:
+				{
+					position257, tokenIndex257 := position, tokenIndex
+					if buffer[position] != rune('c') {
+						goto l258
+					}
+					position++
+					goto l257
+				l258:
+					position, tokenIndex = position257, tokenIndex257
+					if buffer[position] != rune('C') {
+						goto l219
+					}
+					position++
+				}
+			l257:
+				{
+					position259, tokenIndex259 := position, tokenIndex
+					if buffer[position] != rune('h') {
+						goto l260
+					}
+					position++
+					goto l259
+				l260:
+					position, tokenIndex = position259, tokenIndex259
+					if buffer[position] != rune('H') {
+						goto l219
+					}
+					position++
+				}
+			l259:
+				if !_rules[rulesp]() {
+					goto l219
+				}
+				if !_rules[ruleStreamIdentifier]() {
+					goto l219
+				}
+				if !_rules[rulesp]() {
+					goto l219
+				}
+				{
+					position261, tokenIndex261 := position, tokenIndex
+					if buffer[position] != rune('u') {
+						goto l262
+					}
+					position++
+					goto l261
+				l262:
+					position, tokenIndex = position261, tokenIndex261
+					if buffer[position] != rune('U') {
+						goto l219
+					}
+					position++
+				}
+			l261:
+				{
+					position263, tokenIndex263 := position, tokenIndex
+					if buffer[position] != rune('s') {
+						goto l264
+					}
+					position++
+					goto l263
+				l264:
+					position, tokenIndex = position263, tokenIndex263
+					if buffer[position] != rune('S') {
+						goto l219
+					}
+					position++
+				}
+			l263:
+				{
+					position265, tokenIndex265 := position, tokenIndex
+					if buffer[position] != rune('i') {
+						goto l266
+					}
+					position++
+					goto l265
+				l266:
+					position, tokenIndex = position265, tokenIndex265
+					if buffer[position] != rune('I') {
+						goto l219
+					}
+					position++
+				}
+			l265:
+				{
+					position267, tokenIndex267 := position, tokenIndex
+					if buffer[position] != rune('n') {
+						goto l268
+					}
+					position++
+					goto l267
+				l268:
+					position, tokenIndex = position267, tokenIndex267
+					if buffer[position] != rune('N') {
+						goto l219
+					}
+					position++
+				}
+			l267:
+				{
+					position269, tokenIndex269 := position, tokenIndex
+					if buffer[position] != rune('g') {
+						goto l270
+					}
+					position++
+					goto l269
+				l270:
+					position, tokenIndex = position269, tokenIndex269
+					if buffer[position] != rune('G') {
+						goto l219
 					}
 					position++
 				}
-			l222:
+			l269:
 				if !_rules[rulesp]() {
-					goto l194
+					goto l219
 				}
 				if !_rules[ruleSourceSinkType]() {
-					goto l194
+					goto l219
 				}
 				if !_rules[ruleSourceSinkSpecs]() {
-					goto l194
+					goto l219
 				}
-				if !_rules[ruleAction7]() {
-					goto l194
+				if !_rules[ruleAction8]() {
+					goto l219
 				}
-				add(ruleCreateSinkStmt, position195)
+				add(ruleCreateStreamAsEnrichStmt, position220)
 			}
 			return true
-		l194:
-			position, tokenIndex = position194, tokenIndex194
+		l219:
+			position, tokenIndex = position219, tokenIndex219
 			return false
 		},
-		/* 14 CreateStateStmt <- <(('c' / 'C') ('r' / 'R') ('e' / 'E') ('a' / 'A') ('t' / 'T') ('e' / 'E') sp (('s' / 'S') ('t' / 'T') ('a' / 'A') ('t' / 'T') ('e' / 'E')) sp StreamIdentifier sp (('t' / 'T') ('y' / 'Y') ('p' / 'P') ('e' / 'E')) sp SourceSinkType SourceSinkSpecs Action8)> */
+		/* 14 CreateSourceStmt <- <(('c' / 'C') ('r' / 'R') ('e' / 'E') ('a' / 'A') ('t' / 'T') ('e' / 'E') PausedOpt sp (('s' / 'S') ('o' / 'O') ('u' / 'U') ('r' / 'R') ('c' / 'C') ('e' / 'E')) sp StreamIdentifier sp (('t' / 'T') ('y' / 'Y') ('p' / 'P') ('e' / 'E')) sp SourceSinkType SourceSinkSpecs Action9)> */
 		func() bool {
-			position224, tokenIndex224 := position, tokenIndex
+			position271, tokenIndex271 := position, tokenIndex
 			{
-				position225 := position
+				position272 := position
 				{
-					position226, tokenIndex226 := position, tokenIndex
+					position273, tokenIndex273 := position, tokenIndex
 					if buffer[position] != rune('c') {
-						goto l227
+						goto l274
 					}
 					position++
-					goto l226
-				l227:
-					position, tokenIndex = position226, tokenIndex226
+					goto l273
+				l274:
+					position, tokenIndex = position273, tokenIndex273
 					if buffer[position] != rune('C') {
-						goto l224
+						goto l271
 					}
 					position++
 				}
-			l226:
+			l273:
 				{
-					position228, tokenIndex228 := position, tokenIndex
+					position275, tokenIndex275 := position, tokenIndex
 					if buffer[position] != rune('r') {
-						goto l229
+						goto l276
 					}
 					position++
-					goto l228
-				l229:
-					position, tokenIndex = position228, tokenIndex228
+					goto l275
+				l276:
+					position, tokenIndex = position275, tokenIndex275
 					if buffer[position] != rune('R') {
-						goto l224
+						goto l271
 					}
 					position++
 				}
-			l228:
+			l275:
 				{
-					position230, tokenIndex230 := position, tokenIndex
+					position277, tokenIndex277 := position, tokenIndex
 					if buffer[position] != rune('e') {
-						goto l231
+						goto l278
 					}
 					position++
-					goto l230
-				l231:
-					position, tokenIndex = position230, tokenIndex230
+					goto l277
+				l278:
+					position, tokenIndex = position277, tokenIndex277
 					if buffer[position] != rune('E') {
-						goto l224
+						goto l271
 					}
 					position++
 				}
-			l230:
+			l277:
 				{
-					position232, tokenIndex232 := position, tokenIndex
+					position279, tokenIndex279 := position, tokenIndex
 					if buffer[position] != rune('a') {
-						goto l233
+						goto l280
 					}
 					position++
-					goto l232
-				l233:
-					position, tokenIndex = position232, tokenIndex232
+					goto l279
+				l280:
+					position, tokenIndex = position279, tokenIndex279
 					if buffer[position] != rune('A') {
-						goto l224
+						goto l271
 					}
 					position++
 				}
-			l232:
+			l279:
 				{
-					position234, tokenIndex234 := position, tokenIndex
+					position281, tokenIndex281 := position, tokenIndex
 					if buffer[position] != rune('t') {
-						goto l235
+						goto l282
 					}
 					position++
-					goto l234
-				l235:
-					position, tokenIndex = position234, tokenIndex234
+					goto l281
+				l282:
+					position, tokenIndex = position281, tokenIndex281
 					if buffer[position] != rune('T') {
-						goto l224
+						goto l271
 					}
 					position++
 				}
-			l234:
+			l281:
 				{
-					position236, tokenIndex236 := position, tokenIndex
+					position283, tokenIndex283 := position, tokenIndex
 					if buffer[position] != rune('e') {
-						goto l237
+						goto l284
 					}
 					position++
-					goto l236
-				l237:
-					position, tokenIndex = position236, tokenIndex236
+					goto l283
+				l284:
+					position, tokenIndex = position283, tokenIndex283
 					if buffer[position] != rune('E') {
-						goto l224
+						goto l271
 					}
 					position++
 				}
-			l236:
+			l283:
+				if !_rules[rulePausedOpt]() {
+					goto l271
+				}
 				if !_rules[rulesp]() {
-					goto l224
+					goto l271
 				}
 				{
-					position238, tokenIndex238 := position, tokenIndex
+					position285, tokenIndex285 := position, tokenIndex
 					if buffer[position] != rune('s') {
-						goto l239
+						goto l286
 					}
 					position++
-					goto l238
-				l239:
-					position, tokenIndex = position238, tokenIndex238
+					goto l285
+				l286:
+					position, tokenIndex = position285, tokenIndex285
 					if buffer[position] != rune('S') {
-						goto l224
+						goto l271
 					}
 					position++
 				}
-			l238:
+			l285:
 				{
-					position240, tokenIndex240 := position, tokenIndex
-					if buffer[position] != rune('t') {
-						goto l241
+					position287, tokenIndex287 := position, tokenIndex
+					if buffer[position] != rune('o') {
+						goto l288
 					}
 					position++
-					goto l240
-				l241:
-					position, tokenIndex = position240, tokenIndex240
-					if buffer[position] != rune('T') {
-						goto l224
+					goto l287
+				l288:
+					position, tokenIndex = position287, tokenIndex287
+					if buffer[position] != rune('O') {
+						goto l271
 					}
 					position++
 				}
-			l240:
+			l287:
 				{
-					position242, tokenIndex242 := position, tokenIndex
-					if buffer[position] != rune('a') {
-						goto l243
+					position289, tokenIndex289 := position, tokenIndex
+					if buffer[position] != rune('u') {
+						goto l290
 					}
 					position++
-					goto l242
-				l243:
-					position, tokenIndex = position242, tokenIndex242
-					if buffer[position] != rune('A') {
-						goto l224
+					goto l289
+				l290:
+					position, tokenIndex = position289, tokenIndex289
+					if buffer[position] != rune('U') {
+						goto l271
 					}
 					position++
 				}
-			l242:
+			l289:
 				{
-					position244, tokenIndex244 := position, tokenIndex
-					if buffer[position] != rune('t') {
-						goto l245
+					position291, tokenIndex291 := position, tokenIndex
+					if buffer[position] != rune('r') {
+						goto l292
 					}
 					position++
-					goto l244
-				l245:
-					position, tokenIndex = position244, tokenIndex244
-					if buffer[position] != rune('T') {
-						goto l224
+					goto l291
+				l292:
+					position, tokenIndex = position291, tokenIndex291
+					if buffer[position] != rune('R') {
+						goto l271
 					}
 					position++
 				}
-			l244:
+			l291:
 				{
-					position246, tokenIndex246 := position, tokenIndex
+					position293, tokenIndex293 := position, tokenIndex
+					if buffer[position] != rune('c') {
+						goto l294
+					}
+					position++
+					goto l293
+				l294:
+					position, tokenIndex = position293, tokenIndex293
+					if buffer[position] != rune('C') {
+						goto l271
+					}
+					position++
+				}
+			l293:
+				{
+					position295, tokenIndex295 := position, tokenIndex
 					if buffer[position] != rune('e') {
-						goto l247
+						goto l296
 					}
 					position++
-					goto l246
-				l247:
-					position, tokenIndex = position246, tokenIndex246
+					goto l295
+				l296:
+					position, tokenIndex = position295, tokenIndex295
 					if buffer[position] != rune('E') {
-						goto l224
+						goto l271
 					}
 					position++
 				}
-			l246:
+			l295:
 				if !_rules[rulesp]() {
-					goto l224
+					goto l271
 				}
 				if !_rules[ruleStreamIdentifier]() {
-					goto l224
+					goto l271
 				}
 				if !_rules[rulesp]() {
-					goto l224
+					goto l271
 				}
 				{
-					position248, tokenIndex248 := position, tokenIndex
+					position297, tokenIndex297 := position, tokenIndex
 					if buffer[position] != rune('t') {
-						goto l249
+						goto l298
 					}
 					position++
-					goto l248
-				l249:
-					position, tokenIndex = position248, tokenIndex248
+					goto l297
+				l298:
+					position, tokenIndex = position297, tokenIndex297
 					if buffer[position] != rune('T') {
-						goto l224
+						goto l271
 					}
 					position++
 				}
-			l248:
+			l297:
 				{
-					position250, tokenIndex250 := position, tokenIndex
+					position299, tokenIndex299 := position, tokenIndex
 					if buffer[position] != rune('y') {
-						goto l251
+						goto l300
 					}
 					position++
-					goto l250
-				l251:
-					position, tokenIndex = position250, tokenIndex250
+					goto l299
+				l300:
+					position, tokenIndex = position299, tokenIndex299
 					if buffer[position] != rune('Y') {
-						goto l224
+						goto l271
 					}
 					position++
 				}
-			l250:
+			l299:
 				{
-					position252, tokenIndex252 := position, tokenIndex
+					position301, tokenIndex301 := position, tokenIndex
 					if buffer[position] != rune('p') {
-						goto l253
+						goto l302
 					}
 					position++
-					goto l252
-				l253:
-					position, tokenIndex = position252, tokenIndex252
+					goto l301
+				l302:
+					position, tokenIndex = position301, tokenIndex301
 					if buffer[position] != rune('P') {
-						goto l224
+						goto l271
 					}
 					position++
 				}
-			l252:
+			l301:
 				{
-					position254, tokenIndex254 := position, tokenIndex
+					position303, tokenIndex303 := position, tokenIndex
 					if buffer[position] != rune('e') {
-						goto l255
+						goto l304
 					}
 					position++
-					goto l254
-				l255:
-					position, tokenIndex = position254, tokenIndex254
+					goto l303
+				l304:
+					position, tokenIndex = position303, tokenIndex303
 					if buffer[position] != rune('E') {
-						goto l224
+						goto l271
 					}
 					position++
 				}
-			l254:
+			l303:
 				if !_rules[rulesp]() {
-					goto l224
+					goto l271
 				}
 				if !_rules[ruleSourceSinkType]() {
-					goto l224
+					goto l271
 				}
 				if !_rules[ruleSourceSinkSpecs]() {
-					goto l224
+					goto l271
 				}
-				if !_rules[ruleAction8]() {
-					goto l224
+				if !_rules[ruleAction9]() {
+					goto l271
 				}
-				add(ruleCreateStateStmt, position225)
+				add(ruleCreateSourceStmt, position272)
 			}
 			return true
-		l224:
-			position, tokenIndex = position224, tokenIndex224
+		l271:
+			position, tokenIndex = position271, tokenIndex271
 			return false
 		},
-		/* 15 UpdateStateStmt <- <(('u' / 'U') ('p' / 'P') ('d' / 'D') ('a' / 'A') ('t' / 'T') ('e' / 'E') sp (('s' / 'S') ('t' / 'T') ('a' / 'A') ('t' / 'T') ('e' / 'E')) sp StreamIdentifier UpdateSourceSinkSpecs Action9)> */
+		/* 15 CreateSinkStmt <- <(('c' / 'C') ('r' / 'R') ('e' / 'E') ('a' / 'A') ('t' / 'T') ('e' / 'E') sp (('s' / 'S') ('i' / 'I') ('n' / 'N') ('k' / 'K')) sp StreamIdentifier sp (('t' / 'T') ('y' / 'Y') ('p' / 'P') ('e' / 'E')) sp SourceSinkType SourceSinkSpecs Action10)> */
 		func() bool {
-			position256, tokenIndex256 := position, tokenIndex
+			position305, tokenIndex305 := position, tokenIndex
 			{
-				position257 := position
+				position306 := position
 				{
-					position258, tokenIndex258 := position, tokenIndex
-					if buffer[position] != rune('u') {
-						goto l259
+					position307, tokenIndex307 := position, tokenIndex
+					if buffer[position] != rune('c') {
+						goto l308
 					}
 					position++
-					goto l258
-				l259:
-					position, tokenIndex = position258, tokenIndex258
-					if buffer[position] != rune('U') {
-						goto l256
+					goto l307
+				l308:
+					position, tokenIndex = position307, tokenIndex307
+					if buffer[position] != rune('C') {
+						goto l305
 					}
 					position++
 				}
-			l258:
+			l307:
 				{
-					position260, tokenIndex260 := position, tokenIndex
-					if buffer[position] != rune('p') {
-						goto l261
+					position309, tokenIndex309 := position, tokenIndex
+					if buffer[position] != rune('r') {
+						goto l310
 					}
 					position++
-					goto l260
-				l261:
-					position, tokenIndex = position260, tokenIndex260
-					if buffer[position] != rune('P') {
-						goto l256
+					goto l309
+				l310:
+					position, tokenIndex = position309, tokenIndex309
+					if buffer[position] != rune('R') {
+						goto l305
 					}
 					position++
 				}
-			l260:
+			l309:
 				{
-					position262, tokenIndex262 := position, tokenIndex
-					if buffer[position] != rune('d') {
-						goto l263
+					position311, tokenIndex311 := position, tokenIndex
+					if buffer[position] != rune('e') {
+						goto l312
 					}
 					position++
-					goto l262
-				l263:
-					position, tokenIndex = position262, tokenIndex262
-					if buffer[position] != rune('D') {
-						goto l256
+					goto l311
+				l312:
+					position, tokenIndex = position311, tokenIndex311
+					if buffer[position] != rune('E') {
+						goto l305
 					}
 					position++
 				}
-			l262:
+			l311:
 				{
-					position264, tokenIndex264 := position, tokenIndex
+					position313, tokenIndex313 := position, tokenIndex
 					if buffer[position] != rune('a') {
-						goto l265
+						goto l314
 					}
 					position++
-					goto l264
-				l265:
-					position, tokenIndex = position264, tokenIndex264
+					goto l313
+				l314:
+					position, tokenIndex = position313, tokenIndex313
 					if buffer[position] != rune('A') {
-						goto l256
+						goto l305
 					}
 					position++
 				}
-			l264:
+			l313:
 				{
-					position266, tokenIndex266 := position, tokenIndex
+					position315, tokenIndex315 := position, tokenIndex
 					if buffer[position] != rune('t') {
-						goto l267
+						goto l316
 					}
 					position++
-					goto l266
-				l267:
-					position, tokenIndex = position266, tokenIndex266
+					goto l315
+				l316:
+					position, tokenIndex = position315, tokenIndex315
 					if buffer[position] != rune('T') {
-						goto l256
+						goto l305
 					}
 					position++
 				}
-			l266:
+			l315:
 				{
-					position268, tokenIndex268 := position, tokenIndex
+					position317, tokenIndex317 := position, tokenIndex
 					if buffer[position] != rune('e') {
-						goto l269
+						goto l318
 					}
 					position++
-					goto l268
-				l269:
-					position, tokenIndex = position268, tokenIndex268
+					goto l317
+				l318:
+					position, tokenIndex = position317, tokenIndex317
 					if buffer[position] != rune('E') {
-						goto l256
+						goto l305
 					}
 					position++
 				}
-			l268:
+			l317:
 				if !_rules[rulesp]() {
-					goto l256
+					goto l305
 				}
 				{
-					position270, tokenIndex270 := position, tokenIndex
+					position319, tokenIndex319 := position, tokenIndex
 					if buffer[position] != rune('s') {
-						goto l271
+						goto l320
 					}
 					position++
-					goto l270
-				l271:
-					position, tokenIndex = position270, tokenIndex270
+					goto l319
+				l320:
+					position, tokenIndex = position319, tokenIndex319
 					if buffer[position] != rune('S') {
-						goto l256
+						goto l305
 					}
 					position++
 				}
-			l270:
+			l319:
 				{
-					position272, tokenIndex272 := position, tokenIndex
-					if buffer[position] != rune('t') {
-						goto l273
+					position321, tokenIndex321 := position, tokenIndex
+					if buffer[position] != rune('i') {
+						goto l322
 					}
 					position++
-					goto l272
-				l273:
-					position, tokenIndex = position272, tokenIndex272
-					if buffer[position] != rune('T') {
-						goto l256
+					goto l321
+				l322:
+					position, tokenIndex = position321, tokenIndex321
+					if buffer[position] != rune('I') {
+						goto l305
 					}
 					position++
 				}
-			l272:
+			l321:
 				{
-					position274, tokenIndex274 := position, tokenIndex
-					if buffer[position] != rune('a') {
-						goto l275
+					position323, tokenIndex323 := position, tokenIndex
+					if buffer[position] != rune('n') {
+						goto l324
 					}
 					position++
-					goto l274
-				l275:
-					position, tokenIndex = position274, tokenIndex274
-					if buffer[position] != rune('A') {
-						goto l256
+					goto l323
+				l324:
+					position, tokenIndex = position323, tokenIndex323
+					if buffer[position] != rune('N') {
+						goto l305
 					}
 					position++
 				}
-			l274:
+			l323:
 				{
-					position276, tokenIndex276 := position, tokenIndex
-					if buffer[position] != rune('t') {
-						goto l277
+					position325, tokenIndex325 := position, tokenIndex
+					if buffer[position] != rune('k') {
+						goto l326
 					}
 					position++
-					goto l276
-				l277:
-					position, tokenIndex = position276, tokenIndex276
-					if buffer[position] != rune('T') {
-						goto l256
+					goto l325
+				l326:
+					position, tokenIndex = position325, tokenIndex325
+					if buffer[position] != rune('K') {
+						goto l305
 					}
 					position++
 				}
-			l276:
+			l325:
+				if !_rules[rulesp]() {
+					goto l305
+				}
+				if !_rules[ruleStreamIdentifier]() {
+					goto l305
+				}
+				if !_rules[rulesp]() {
+					goto l305
+				}
 				{
-					position278, tokenIndex278 := position, tokenIndex
-					if buffer[position] != rune('e') {
-						goto l279
+					position327, tokenIndex327 := position, tokenIndex
+					if buffer[position] != rune('t') {
+						goto l328
 					}
 					position++
-					goto l278
-				l279:
-					position, tokenIndex = position278, tokenIndex278
-					if buffer[position] != rune('E') {
-						goto l256
+					goto l327
+				l328:
+					position, tokenIndex = position327, tokenIndex327
+					if buffer[position] != rune('T') {
+						goto l305
 					}
 					position++
 				}
-			l278:
-				if !_rules[rulesp]() {
-					goto l256
+			l327:
+				{
+					position329, tokenIndex329 := position, tokenIndex
+					if buffer[position] != rune('y') {
+						goto l330
+					}
+					position++
+					goto l329
+				l330:
+					position, tokenIndex = position329, tokenIndex329
+					if buffer[position] != rune('Y') {
+						goto l305
+					}
+					position++
 				}
-				if !_rules[ruleStreamIdentifier]() {
-					goto l256
+			l329:
+				{
+					position331, tokenIndex331 := position, tokenIndex
+					if buffer[position] != rune('p') {
+						goto l332
+					}
+					position++
+					goto l331
+				l332:
+					position, tokenIndex = position331, tokenIndex331
+					if buffer[position] != rune('P') {
+						goto l305
+					}
+					position++
 				}
-				if !_rules[ruleUpdateSourceSinkSpecs]() {
-					goto l256
+			l331:
+				{
+					position333, tokenIndex333 := position, tokenIndex
+					if buffer[position] != rune('e') {
+						goto l334
+					}
+					position++
+					goto l333
+				l334:
+					position, tokenIndex = position333, tokenIndex333
+					if buffer[position] != rune('E') {
+						goto l305
+					}
+					position++
 				}
-				if !_rules[ruleAction9]() {
-					goto l256
+			l333:
+				if !_rules[rulesp]() {
+					goto l305
+				}
+				if !_rules[ruleSourceSinkType]() {
+					goto l305
+				}
+				if !_rules[ruleSourceSinkSpecs]() {
+					goto l305
 				}
-				add(ruleUpdateStateStmt, position257)
+				if !_rules[ruleAction10]() {
+					goto l305
+				}
+				add(ruleCreateSinkStmt, position306)
 			}
 			return true
-		l256:
-			position, tokenIndex = position256, tokenIndex256
+		l305:
+			position, tokenIndex = position305, tokenIndex305
 			return false
 		},
-		/* 16 UpdateSourceStmt <- <(('u' / 'U') ('p' / 'P') ('d' / 'D') ('a' / 'A') ('t' / 'T') ('e' / 'E') sp (('s' / 'S') ('o' / 'O') ('u' / 'U') ('r' / 'R') ('c' / 'C') ('e' / 'E')) sp StreamIdentifier UpdateSourceSinkSpecs Action10)> */
+		/* 16 CreateStateStmt <- <(('c' / 'C') ('r' / 'R') ('e' / 'E') ('a' / 'A') ('t' / 'T') ('e' / 'E') sp (('s' / 'S') ('t' / 'T') ('a' / 'A') ('t' / 'T') ('e' / 'E')) sp StreamIdentifier sp (('t' / 'T') ('y' / 'Y') ('p' / 'P') ('e' / 'E')) sp SourceSinkType SourceSinkSpecs Action11)> */
 		func() bool {
-			position280, tokenIndex280 := position, tokenIndex
+			position335, tokenIndex335 := position, tokenIndex
 			{
-				position281 := position
+				position336 := position
 				{
-					position282, tokenIndex282 := position, tokenIndex
-					if buffer[position] != rune('u') {
-						goto l283
+					position337, tokenIndex337 := position, tokenIndex
+					if buffer[position] != rune('c') {
+						goto l338
 					}
 					position++
-					goto l282
-				l283:
-					position, tokenIndex = position282, tokenIndex282
-					if buffer[position] != rune('U') {
-						goto l280
+					goto l337
+				l338:
+					position, tokenIndex = position337, tokenIndex337
+					if buffer[position] != rune('C') {
+						goto l335
 					}
 					position++
 				}
-			l282:
+			l337:
 				{
-					position284, tokenIndex284 := position, tokenIndex
-					if buffer[position] != rune('p') {
-						goto l285
+					position339, tokenIndex339 := position, tokenIndex
+					if buffer[position] != rune('r') {
+						goto l340
 					}
 					position++
-					goto l284
-				l285:
-					position, tokenIndex = position284, tokenIndex284
-					if buffer[position] != rune('P') {
-						goto l280
+					goto l339
+				l340:
+					position, tokenIndex = position339, tokenIndex339
+					if buffer[position] != rune('R') {
+						goto l335
 					}
 					position++
 				}
-			l284:
+			l339:
 				{
-					position286, tokenIndex286 := position, tokenIndex
-					if buffer[position] != rune('d') {
-						goto l287
+					position341, tokenIndex341 := position, tokenIndex
+					if buffer[position] != rune('e') {
+						goto l342
 					}
 					position++
-					goto l286
-				l287:
-					position, tokenIndex = position286, tokenIndex286
-					if buffer[position] != rune('D') {
-						goto l280
+					goto l341
+				l342:
+					position, tokenIndex = position341, tokenIndex341
+					if buffer[position] != rune('E') {
+						goto l335
 					}
 					position++
 				}
-			l286:
+			l341:
 				{
-					position288, tokenIndex288 := position, tokenIndex
+					position343, tokenIndex343 := position, tokenIndex
 					if buffer[position] != rune('a') {
-						goto l289
+						goto l344
 					}
 					position++
-					goto l288
-				l289:
-					position, tokenIndex = position288, tokenIndex288
+					goto l343
+				l344:
+					position, tokenIndex = position343, tokenIndex343
 					if buffer[position] != rune('A') {
-						goto l280
+						goto l335
 					}
 					position++
 				}
-			l288:
+			l343:
 				{
-					position290, tokenIndex290 := position, tokenIndex
+					position345, tokenIndex345 := position, tokenIndex
 					if buffer[position] != rune('t') {
-						goto l291
+						goto l346
 					}
 					position++
-					goto l290
-				l291:
-					position, tokenIndex = position290, tokenIndex290
+					goto l345
+				l346:
+					position, tokenIndex = position345, tokenIndex345
 					if buffer[position] != rune('T') {
-						goto l280
+						goto l335
 					}
 					position++
 				}
-			l290:
+			l345:
 				{
-					position292, tokenIndex292 := position, tokenIndex
+					position347, tokenIndex347 := position, tokenIndex
 					if buffer[position] != rune('e') {
-						goto l293
+						goto l348
 					}
 					position++
-					goto l292
-				l293:
-					position, tokenIndex = position292, tokenIndex292
+					goto l347
+				l348:
+					position, tokenIndex = position347, tokenIndex347
 					if buffer[position] != rune('E') {
-						goto l280
+						goto l335
 					}
 					position++
 				}
-			l292:
+			l347:
 				if !_rules[rulesp]() {
-					goto l280
+					goto l335
 				}
 				{
-					position294, tokenIndex294 := position, tokenIndex
+					position349, tokenIndex349 := position, tokenIndex
 					if buffer[position] != rune('s') {
-						goto l295
+						goto l350
 					}
 					position++
-					goto l294
-				l295:
-					position, tokenIndex = position294, tokenIndex294
+					goto l349
+				l350:
+					position, tokenIndex = position349, tokenIndex349
 					if buffer[position] != rune('S') {
-						goto l280
+						goto l335
 					}
 					position++
 				}
-			l294:
+			l349:
 				{
-					position296, tokenIndex296 := position, tokenIndex
-					if buffer[position] != rune('o') {
-						goto l297
+					position351, tokenIndex351 := position, tokenIndex
+					if buffer[position] != rune('t') {
+						goto l352
 					}
 					position++
-					goto l296
-				l297:
-					position, tokenIndex = position296, tokenIndex296
-					if buffer[position] != rune('O') {
-						goto l280
+					goto l351
+				l352:
+					position, tokenIndex = position351, tokenIndex351
+					if buffer[position] != rune('T') {
+						goto l335
 					}
 					position++
 				}
-			l296:
+			l351:
 				{
-					position298, tokenIndex298 := position, tokenIndex
-					if buffer[position] != rune('u') {
-						goto l299
+					position353, tokenIndex353 := position, tokenIndex
+					if buffer[position] != rune('a') {
+						goto l354
 					}
 					position++
-					goto l298
-				l299:
-					position, tokenIndex = position298, tokenIndex298
-					if buffer[position] != rune('U') {
-						goto l280
+					goto l353
+				l354:
+					position, tokenIndex = position353, tokenIndex353
+					if buffer[position] != rune('A') {
+						goto l335
 					}
 					position++
 				}
-			l298:
+			l353:
 				{
-					position300, tokenIndex300 := position, tokenIndex
-					if buffer[position] != rune('r') {
-						goto l301
+					position355, tokenIndex355 := position, tokenIndex
+					if buffer[position] != rune('t') {
+						goto l356
 					}
 					position++
-					goto l300
-				l301:
-					position, tokenIndex = position300, tokenIndex300
-					if buffer[position] != rune('R') {
-						goto l280
+					goto l355
+				l356:
+					position, tokenIndex = position355, tokenIndex355
+					if buffer[position] != rune('T') {
+						goto l335
 					}
 					position++
 				}
-			l300:
+			l355:
 				{
-					position302, tokenIndex302 := position, tokenIndex
-					if buffer[position] != rune('c') {
-						goto l303
+					position357, tokenIndex357 := position, tokenIndex
+					if buffer[position] != rune('e') {
+						goto l358
 					}
 					position++
-					goto l302
-				l303:
-					position, tokenIndex = position302, tokenIndex302
-					if buffer[position] != rune('C') {
-						goto l280
+					goto l357
+				l358:
+					position, tokenIndex = position357, tokenIndex357
+					if buffer[position] != rune('E') {
+						goto l335
+					}
+					position++
+				}
+			l357:
+				if !_rules[rulesp]() {
+					goto l335
+				}
+				if !_rules[ruleStreamIdentifier]() {
+					goto l335
+				}
+				if !_rules[rulesp]() {
+					goto l335
+				}
+				{
+					position359, tokenIndex359 := position, tokenIndex
+					if buffer[position] != rune('t') {
+						goto l360
+					}
+					position++
+					goto l359
+				l360:
+					position, tokenIndex = position359, tokenIndex359
+					if buffer[position] != rune('T') {
+						goto l335
+					}
+					position++
+				}
+			l359:
+				{
+					position361, tokenIndex361 := position, tokenIndex
+					if buffer[position] != rune('y') {
+						goto l362
+					}
+					position++
+					goto l361
+				l362:
+					position, tokenIndex = position361, tokenIndex361
+					if buffer[position] != rune('Y') {
+						goto l335
+					}
+					position++
+				}
+			l361:
+				{
+					position363, tokenIndex363 := position, tokenIndex
+					if buffer[position] != rune('p') {
+						goto l364
+					}
+					position++
+					goto l363
+				l364:
+					position, tokenIndex = position363, tokenIndex363
+					if buffer[position] != rune('P') {
+						goto l335
 					}
 					position++
 				}
-			l302:
+			l363:
 				{
-					position304, tokenIndex304 := position, tokenIndex
+					position365, tokenIndex365 := position, tokenIndex
 					if buffer[position] != rune('e') {
-						goto l305
+						goto l366
 					}
 					position++
-					goto l304
-				l305:
-					position, tokenIndex = position304, tokenIndex304
+					goto l365
+				l366:
+					position, tokenIndex = position365, tokenIndex365
 					if buffer[position] != rune('E') {
-						goto l280
+						goto l335
 					}
 					position++
 				}
-			l304:
+			l365:
 				if !_rules[rulesp]() {
-					goto l280
+					goto l335
 				}
-				if !_rules[ruleStreamIdentifier]() {
-					goto l280
+				if !_rules[ruleSourceSinkType]() {
+					goto l335
 				}
-				if !_rules[ruleUpdateSourceSinkSpecs]() {
-					goto l280
+				if !_rules[ruleSourceSinkSpecs]() {
+					goto l335
 				}
-				if !_rules[ruleAction10]() {
-					goto l280
+				if !_rules[ruleAction11]() {
+					goto l335
 				}
-				add(ruleUpdateSourceStmt, position281)
+				add(ruleCreateStateStmt, position336)
 			}
 			return true
-		l280:
-			position, tokenIndex = position280, tokenIndex280
+		l335:
+			position, tokenIndex = position335, tokenIndex335
 			return false
 		},
-		/* 17 UpdateSinkStmt <- <(('u' / 'U') ('p' / 'P') ('d' / 'D') ('a' / 'A') ('t' / 'T') ('e' / 'E') sp (('s' / 'S') ('i' / 'I') ('n' / 'N') ('k' / 'K')) sp StreamIdentifier UpdateSourceSinkSpecs Action11)> */
+		/* 17 UpdateStateStmt <- <(('u' / 'U') ('p' / 'P') ('d' / 'D') ('a' / 'A') ('t' / 'T') ('e' / 'E') sp (('s' / 'S') ('t' / 'T') ('a' / 'A') ('t' / 'T') ('e' / 'E')) sp StreamIdentifier UpdateSourceSinkSpecs Action12)> */
 		func() bool {
-			position306, tokenIndex306 := position, tokenIndex
+			position367, tokenIndex367 := position, tokenIndex
 			{
-				position307 := position
+				position368 := position
 				{
-					position308, tokenIndex308 := position, tokenIndex
+					position369, tokenIndex369 := position, tokenIndex
 					if buffer[position] != rune('u') {
-						goto l309
+						goto l370
 					}
 					position++
-					goto l308
-				l309:
-					position, tokenIndex = position308, tokenIndex308
+					goto l369
+				l370:
+					position, tokenIndex = position369, tokenIndex369
 					if buffer[position] != rune('U') {
-						goto l306
+						goto l367
 					}
 					position++
 				}
-			l308:
+			l369:
 				{
-					position310, tokenIndex310 := position, tokenIndex
+					position371, tokenIndex371 := position, tokenIndex
 					if buffer[position] != rune('p') {
-						goto l311
+						goto l372
 					}
 					position++
-					goto l310
-				l311:
-					position, tokenIndex = position310, tokenIndex310
+					goto l371
+				l372:
+					position, tokenIndex = position371, tokenIndex371
 					if buffer[position] != rune('P') {
-						goto l306
+						goto l367
 					}
 					position++
 				}
-			l310:
+			l371:
 				{
-					position312, tokenIndex312 := position, tokenIndex
+					position373, tokenIndex373 := position, tokenIndex
 					if buffer[position] != rune('d') {
-						goto l313
+						goto l374
 					}
 					position++
-					goto l312
-				l313:
-					position, tokenIndex = position312, tokenIndex312
+					goto l373
+				l374:
+					position, tokenIndex = position373, tokenIndex373
 					if buffer[position] != rune('D') {
-						goto l306
+						goto l367
 					}
 					position++
 				}
-			l312:
+			l373:
 				{
-					position314, tokenIndex314 := position, tokenIndex
+					position375, tokenIndex375 := position, tokenIndex
 					if buffer[position] != rune('a') {
-						goto l315
+						goto l376
 					}
 					position++
-					goto l314
-				l315:
-					position, tokenIndex = position314, tokenIndex314
+					goto l375
+				l376:
+					position, tokenIndex = position375, tokenIndex375
 					if buffer[position] != rune('A') {
-						goto l306
+						goto l367
 					}
 					position++
 				}
-			l314:
+			l375:
 				{
-					position316, tokenIndex316 := position, tokenIndex
+					position377, tokenIndex377 := position, tokenIndex
 					if buffer[position] != rune('t') {
-						goto l317
+						goto l378
 					}
 					position++
-					goto l316
-				l317:
-					position, tokenIndex = position316, tokenIndex316
+					goto l377
+				l378:
+					position, tokenIndex = position377, tokenIndex377
 					if buffer[position] != rune('T') {
-						goto l306
+						goto l367
 					}
 					position++
 				}
-			l316:
+			l377:
 				{
-					position318, tokenIndex318 := position, tokenIndex
+					position379, tokenIndex379 := position, tokenIndex
 					if buffer[position] != rune('e') {
-						goto l319
+						goto l380
 					}
 					position++
-					goto l318
-				l319:
-					position, tokenIndex = position318, tokenIndex318
+					goto l379
+				l380:
+					position, tokenIndex = position379, tokenIndex379
 					if buffer[position] != rune('E') {
-						goto l306
+						goto l367
 					}
 					position++
 				}
-			l318:
+			l379:
 				if !_rules[rulesp]() {
-					goto l306
+					goto l367
 				}
 				{
-					position320, tokenIndex320 := position, tokenIndex
+					position381, tokenIndex381 := position, tokenIndex
 					if buffer[position] != rune('s') {
-						goto l321
+						goto l382
 					}
 					position++
-					goto l320
-				l321:
-					position, tokenIndex = position320, tokenIndex320
+					goto l381
+				l382:
+					position, tokenIndex = position381, tokenIndex381
 					if buffer[position] != rune('S') {
-						goto l306
+						goto l367
 					}
 					position++
 				}
-			l320:
+			l381:
 				{
-					position322, tokenIndex322 := position, tokenIndex
-					if buffer[position] != rune('i') {
-						goto l323
+					position383, tokenIndex383 := position, tokenIndex
+					if buffer[position] != rune('t') {
+						goto l384
 					}
 					position++
-					goto l322
-				l323:
-					position, tokenIndex = position322, tokenIndex322
-					if buffer[position] != rune('I') {
-						goto l306
+					goto l383
+				l384:
+					position, tokenIndex = position383, tokenIndex383
+					if buffer[position] != rune('T') {
+						goto l367
 					}
 					position++
 				}
-			l322:
+			l383:
 				{
-					position324, tokenIndex324 := position, tokenIndex
-					if buffer[position] != rune('n') {
-						goto l325
+					position385, tokenIndex385 := position, tokenIndex
+					if buffer[position] != rune('a') {
+						goto l386
 					}
 					position++
-					goto l324
-				l325:
-					position, tokenIndex = position324, tokenIndex324
-					if buffer[position] != rune('N') {
-						goto l306
+					goto l385
+				l386:
+					position, tokenIndex = position385, tokenIndex385
+					if buffer[position] != rune('A') {
+						goto l367
 					}
 					position++
 				}
-			l324:
+			l385:
 				{
-					position326, tokenIndex326 := position, tokenIndex
-					if buffer[position] != rune('k') {
-						goto l327
+					position387, tokenIndex387 := position, tokenIndex
+					if buffer[position] != rune('t') {
+						goto l388
 					}
 					position++
-					goto l326
-				l327:
-					position, tokenIndex = position326, tokenIndex326
-					if buffer[position] != rune('K') {
-						goto l306
+					goto l387
+				l388:
+					position, tokenIndex = position387, tokenIndex387
+					if buffer[position] != rune('T') {
+						goto l367
+					}
+					position++
+				}
+			l387:
+				{
+					position389, tokenIndex389 := position, tokenIndex
+					if buffer[position] != rune('e') {
+						goto l390
+					}
+					position++
+					goto l389
+				l390:
+					position, tokenIndex = position389, tokenIndex389
+					if buffer[position] != rune('E') {
+						goto l367
 					}
 					position++
 				}
-			l326:
+			l389:
 				if !_rules[rulesp]() {
-					goto l306
+					goto l367
 				}
 				if !_rules[ruleStreamIdentifier]() {
-					goto l306
+					goto l367
 				}
 				if !_rules[ruleUpdateSourceSinkSpecs]() {
-					goto l306
+					goto l367
 				}
-				if !_rules[ruleAction11]() {
-					goto l306
+				if !_rules[ruleAction12]() {
+					goto l367
 				}
-				add(ruleUpdateSinkStmt, position307)
+				add(ruleUpdateStateStmt, position368)
 			}
 			return true
-		l306:
-			position, tokenIndex = position306, tokenIndex306
+		l367:
+			position, tokenIndex = position367, tokenIndex367
 			return false
 		},
-		/* 18 InsertIntoFromStmt <- <(('i' / 'I') ('n' / 'N') ('s' / 'S') ('e' / 'E') ('r' / 'R') ('t' / 'T') sp (('i' / 'I') ('n' / 'N') ('t' / 'T') ('o' / 'O')) sp StreamIdentifier sp (('f' / 'F') ('r' / 'R') ('o' / 'O') ('m' / 'M')) sp StreamIdentifier Action12)> */
+		/* 18 UpdateSourceStmt <- <(('u' / 'U') ('p' / 'P') ('d' / 'D') ('a' / 'A') ('t' / 'T') ('e' / 'E') sp (('s' / 'S') ('o' / 'O') ('u' / 'U') ('r' / 'R') ('c' / 'C') ('e' / 'E')) sp StreamIdentifier UpdateSourceSinkSpecs Action13)> */
 		func() bool {
-			position328, tokenIndex328 := position, tokenIndex
+			position391, tokenIndex391 := position, tokenIndex
 			{
-				position329 := position
-				{
-					position330, tokenIndex330 := position, tokenIndex
-					if buffer[position] != rune('i') {
-						goto l331
-					}
-					position++
-					goto l330
-				l331:
-					position, tokenIndex = position330, tokenIndex330
-					if buffer[position] != rune('I') {
-						goto l328
-					}
-					position++
-				}
-			l330:
+				position392 := position
 				{
-					position332, tokenIndex332 := position, tokenIndex
-					if buffer[position] != rune('n') {
-						goto l333
+					position393, tokenIndex393 := position, tokenIndex
+					if buffer[position] != rune('u') {
+						goto l394
 					}
 					position++
-					goto l332
-				l333:
-					position, tokenIndex = position332, tokenIndex332
-					if buffer[position] != rune('N') {
-						goto l328
+					goto l393
+				l394:
+					position, tokenIndex = position393, tokenIndex393
+					if buffer[position] != rune('U') {
+						goto l391
 					}
 					position++
 				}
-			l332:
+			l393:
 				{
-					position334, tokenIndex334 := position, tokenIndex
-					if buffer[position] != rune('s') {
-						goto l335
+					position395, tokenIndex395 := position, tokenIndex
+					if buffer[position] != rune('p') {
+						goto l396
 					}
 					position++
-					goto l334
-				l335:
-					position, tokenIndex = position334, tokenIndex334
-					if buffer[position] != rune('S') {
-						goto l328
+					goto l395
+				l396:
+					position, tokenIndex = position395, tokenIndex395
+					if buffer[position] != rune('P') {
+						goto l391
 					}
 					position++
 				}
-			l334:
+			l395:
 				{
-					position336, tokenIndex336 := position, tokenIndex
-					if buffer[position] != rune('e') {
-						goto l337
+					position397, tokenIndex397 := position, tokenIndex
+					if buffer[position] != rune('d') {
+						goto l398
 					}
 					position++
-					goto l336
-				l337:
-					position, tokenIndex = position336, tokenIndex336
-					if buffer[position] != rune('E') {
-						goto l328
+					goto l397
+				l398:
+					position, tokenIndex = position397, tokenIndex397
+					if buffer[position] != rune('D') {
+						goto l391
 					}
 					position++
 				}
-			l336:
+			l397:
 				{
-					position338, tokenIndex338 := position, tokenIndex
-					if buffer[position] != rune('r') {
-						goto l339
+					position399, tokenIndex399 := position, tokenIndex
+					if buffer[position] != rune('a') {
+						goto l400
 					}
 					position++
-					goto l338
-				l339:
-					position, tokenIndex = position338, tokenIndex338
-					if buffer[position] != rune('R') {
-						goto l328
+					goto l399
+				l400:
+					position, tokenIndex = position399, tokenIndex399
+					if buffer[position] != rune('A') {
+						goto l391
 					}
 					position++
 				}
-			l338:
+			l399:
 				{
-					position340, tokenIndex340 := position, tokenIndex
+					position401, tokenIndex401 := position, tokenIndex
 					if buffer[position] != rune('t') {
-						goto l341
+						goto l402
 					}
 					position++
-					goto l340
-				l341:
-					position, tokenIndex = position340, tokenIndex340
+					goto l401
+				l402:
+					position, tokenIndex = position401, tokenIndex401
 					if buffer[position] != rune('T') {
-						goto l328
+						goto l391
 					}
 					position++
 				}
-			l340:
-				if !_rules[rulesp]() {
-					goto l328
-				}
+			l401:
 				{
-					position342, tokenIndex342 := position, tokenIndex
-					if buffer[position] != rune('i') {
-						goto l343
+					position403, tokenIndex403 := position, tokenIndex
+					if buffer[position] != rune('e') {
+						goto l404
 					}
 					position++
-					goto l342
-				l343:
-					position, tokenIndex = position342, tokenIndex342
-					if buffer[position] != rune('I') {
-						goto l328
+					goto l403
+				l404:
+					position, tokenIndex = position403, tokenIndex403
+					if buffer[position] != rune('E') {
+						goto l391
 					}
 					position++
 				}
-			l342:
-				{
-					position344, tokenIndex344 := position, tokenIndex
-					if buffer[position] != rune('n') {
-						goto l345
-					}
-					position++
-					goto l344
-				l345:
-					position, tokenIndex = position344, tokenIndex344
-					if buffer[position] != rune('N') {
-						goto l328
-					}
-					position++
+			l403:
+				if !_rules[rulesp]() {
+					goto l391
 				}
-			l344:
 				{
-					position346, tokenIndex346 := position, tokenIndex
-					if buffer[position] != rune('t') {
-						goto l347
+					position405, tokenIndex405 := position, tokenIndex
+					if buffer[position] != rune('s') {
+						goto l406
 					}
 					position++
-					goto l346
-				l347:
-					position, tokenIndex = position346, tokenIndex346
-					if buffer[position] != rune('T') {
-						goto l328
+					goto l405
+				l406:
+					position, tokenIndex = position405, tokenIndex405
+					if buffer[position] != rune('S') {
+						goto l391
 					}
 					position++
 				}
-			l346:
+			l405:
 				{
-					position348, tokenIndex348 := position, tokenIndex
+					position407, tokenIndex407 := position, tokenIndex
 					if buffer[position] != rune('o') {
-						goto l349
+						goto l408
 					}
 					position++
-					goto l348
-				l349:
-					position, tokenIndex = position348, tokenIndex348
+					goto l407
+				l408:
+					position, tokenIndex = position407, tokenIndex407
 					if buffer[position] != rune('O') {
-						goto l328
+						goto l391
 					}
 					position++
 				}
-			l348:
-				if !_rules[rulesp]() {
-					goto l328
-				}
-				if !_rules[ruleStreamIdentifier]() {
-					goto l328
-				}
-				if !_rules[rulesp]() {
-					goto l328
-				}
+			l407:
 				{
-					position350, tokenIndex350 := position, tokenIndex
-					if buffer[position] != rune('f') {
-						goto l351
+					position409, tokenIndex409 := position, tokenIndex
+					if buffer[position] != rune('u') {
+						goto l410
 					}
 					position++
-					goto l350
-				l351:
-					position, tokenIndex = position350, tokenIndex350
-					if buffer[position] != rune('F') {
-						goto l328
+					goto l409
+				l410:
+					position, tokenIndex = position409, tokenIndex409
+					if buffer[position] != rune('U') {
+						goto l391
 					}
 					position++
 				}
-			l350:
+			l409:
 				{
-					position352, tokenIndex352 := position, tokenIndex
+					position411, tokenIndex411 := position, tokenIndex
 					if buffer[position] != rune('r') {
-						goto l353
+						goto l412
 					}
 					position++
-					goto l352
-				l353:
-					position, tokenIndex = position352, tokenIndex352
+					goto l411
+				l412:
+					position, tokenIndex = position411, tokenIndex411
 					if buffer[position] != rune('R') {
-						goto l328
+						goto l391
 					}
 					position++
 				}
-			l352:
+			l411:
 				{
-					position354, tokenIndex354 := position, tokenIndex
-					if buffer[position] != rune('o') {
-						goto l355
+					position413, tokenIndex413 := position, tokenIndex
+					if buffer[position] != rune('c') {
+						goto l414
 					}
 					position++
-					goto l354
-				l355:
-					position, tokenIndex = position354, tokenIndex354
-					if buffer[position] != rune('O') {
-						goto l328
+					goto l413
+				l414:
+					position, tokenIndex = position413, tokenIndex413
+					if buffer[position] != rune('C') {
+						goto l391
 					}
 					position++
 				}
-			l354:
+			l413:
 				{
-					position356, tokenIndex356 := position, tokenIndex
-					if buffer[position] != rune('m') {
-						goto l357
+					position415, tokenIndex415 := position, tokenIndex
+					if buffer[position] != rune('e') {
+						goto l416
 					}
 					position++
-					goto l356
-				l357:
-					position, tokenIndex = position356, tokenIndex356
-					if buffer[position] != rune('M') {
-						goto l328
+					goto l415
+				l416:
+					position, tokenIndex = position415, tokenIndex415
+					if buffer[position] != rune('E') {
+						goto l391
 					}
 					position++
 				}
-			l356:
+			l415:
 				if !_rules[rulesp]() {
-					goto l328
+					goto l391
 				}
 				if !_rules[ruleStreamIdentifier]() {
-					goto l328
+					goto l391
 				}
-				if !_rules[ruleAction12]() {
-					goto l328
+				if !_rules[ruleUpdateSourceSinkSpecs]() {
+					goto l391
+				}
+				if !_rules[ruleAction13]() {
+					goto l391
 				}
-				add(ruleInsertIntoFromStmt, position329)
+				add(ruleUpdateSourceStmt, position392)
 			}
 			return true
-		l328:
-			position, tokenIndex = position328, tokenIndex328
+		l391:
+			position, tokenIndex = position391, tokenIndex391
 			return false
 		},
-		/* 19 PauseSourceStmt <- <(('p' / 'P') ('a' / 'A') ('u' / 'U') ('s' / 'S') ('e' / 'E') sp (('s' / 'S') ('o' / 'O') ('u' / 'U') ('r' / 'R') ('c' / 'C') ('e' / 'E')) sp StreamIdentifier Action13)> */
+		/* 19 UpdateSinkStmt <- <(('u' / 'U') ('p' / 'P') ('d' / 'D') ('a' / 'A') ('t' / 'T') ('e' / 'E') sp (('s' / 'S') ('i' / 'I') ('n' / 'N') ('k' / 'K')) sp StreamIdentifier UpdateSourceSinkSpecs Action14)> */
 		func() bool {
-			position358, tokenIndex358 := position, tokenIndex
+			position417, tokenIndex417 := position, tokenIndex
 			{
-				position359 := position
+				position418 := position
 				{
-					position360, tokenIndex360 := position, tokenIndex
-					if buffer[position] != rune('p') {
-						goto l361
+					position419, tokenIndex419 := position, tokenIndex
+					if buffer[position] != rune('u') {
+						goto l420
 					}
 					position++
-					goto l360
-				l361:
-					position, tokenIndex = position360, tokenIndex360
-					if buffer[position] != rune('P') {
-						goto l358
+					goto l419
+				l420:
+					position, tokenIndex = position419, tokenIndex419
+					if buffer[position] != rune('U') {
+						goto l417
 					}
 					position++
 				}
-			l360:
+			l419:
 				{
-					position362, tokenIndex362 := position, tokenIndex
-					if buffer[position] != rune('a') {
-						goto l363
+					position421, tokenIndex421 := position, tokenIndex
+					if buffer[position] != rune('p') {
+						goto l422
 					}
 					position++
-					goto l362
-				l363:
-					position, tokenIndex = position362, tokenIndex362
-					if buffer[position] != rune('A') {
-						goto l358
+					goto l421
+				l422:
+					position, tokenIndex = position421, tokenIndex421
+					if buffer[position] != rune('P') {
+						goto l417
 					}
 					position++
 				}
-			l362:
+			l421:
 				{
-					position364, tokenIndex364 := position, tokenIndex
-					if buffer[position] != rune('u') {
-						goto l365
+					position423, tokenIndex423 := position, tokenIndex
+					if buffer[position] != rune('d') {
+						goto l424
 					}
 					position++
-					goto l364
-				l365:
-					position, tokenIndex = position364, tokenIndex364
-					if buffer[position] != rune('U') {
-						goto l358
+					goto l423
+				l424:
+					position, tokenIndex = position423, tokenIndex423
+					if buffer[position] != rune('D') {
+						goto l417
 					}
 					position++
 				}
-			l364:
+			l423:
 				{
-					position366, tokenIndex366 := position, tokenIndex
-					if buffer[position] != rune('s') {
-						goto l367
+					position425, tokenIndex425 := position, tokenIndex
+					if buffer[position] != rune('a') {
+						goto l426
 					}
 					position++
-					goto l366
-				l367:
-					position, tokenIndex = position366, tokenIndex366
-					if buffer[position] != rune('S') {
-						goto l358
+					goto l425
+				l426:
+					position, tokenIndex = position425, tokenIndex425
+					if buffer[position] != rune('A') {
+						goto l417
 					}
 					position++
 				}
-			l366:
+			l425:
 				{
-					position368, tokenIndex368 := position, tokenIndex
-					if buffer[position] != rune('e') {
-						goto l369
+					position427, tokenIndex427 := position, tokenIndex
+					if buffer[position] != rune('t') {
+						goto l428
 					}
 					position++
-					goto l368
-				l369:
-					position, tokenIndex = position368, tokenIndex368
-					if buffer[position] != rune('E') {
-						goto l358
+					goto l427
+				l428:
+					position, tokenIndex = position427, tokenIndex427
+					if buffer[position] != rune('T') {
+						goto l417
 					}
 					position++
 				}
-			l368:
-				if !_rules[rulesp]() {
-					goto l358
-				}
+			l427:
 				{
-					position370, tokenIndex370 := position, tokenIndex
-					if buffer[position] != rune('s') {
-						goto l371
+					position429, tokenIndex429 := position, tokenIndex
+					if buffer[position] != rune('e') {
+						goto l430
 					}
 					position++
-					goto l370
-				l371:
-					position, tokenIndex = position370, tokenIndex370
-					if buffer[position] != rune('S') {
-						goto l358
+					goto l429
+				l430:
+					position, tokenIndex = position429, tokenIndex429
+					if buffer[position] != rune('E') {
+						goto l417
 					}
 					position++
 				}
-			l370:
-				{
-					position372, tokenIndex372 := position, tokenIndex
-					if buffer[position] != rune('o') {
-						goto l373
-					}
-					position++
-					goto l372
-				l373:
-					position, tokenIndex = position372, tokenIndex372
-					if buffer[position] != rune('O') {
-						goto l358
-					}
-					position++
+			l429:
+				if !_rules[rulesp]() {
+					goto l417
 				}
-			l372:
 				{
-					position374, tokenIndex374 := position, tokenIndex
-					if buffer[position] != rune('u') {
-						goto l375
+					position431, tokenIndex431 := position, tokenIndex
+					if buffer[position] != rune('s') {
+						goto l432
 					}
 					position++
-					goto l374
-				l375:
-					position, tokenIndex = position374, tokenIndex374
-					if buffer[position] != rune('U') {
-						goto l358
+					goto l431
+				l432:
+					position, tokenIndex = position431, tokenIndex431
+					if buffer[position] != rune('S') {
+						goto l417
 					}
 					position++
 				}
-			l374:
+			l431:
 				{
-					position376, tokenIndex376 := position, tokenIndex
-					if buffer[position] != rune('r') {
-						goto l377
+					position433, tokenIndex433 := position, tokenIndex
+					if buffer[position] != rune('i') {
+						goto l434
 					}
 					position++
-					goto l376
-				l377:
-					position, tokenIndex = position376, tokenIndex376
-					if buffer[position] != rune('R') {
-						goto l358
+					goto l433
+				l434:
+					position, tokenIndex = position433, tokenIndex433
+					if buffer[position] != rune('I') {
+						goto l417
 					}
 					position++
 				}
-			l376:
+			l433:
 				{
-					position378, tokenIndex378 := position, tokenIndex
-					if buffer[position] != rune('c') {
-						goto l379
+					position435, tokenIndex435 := position, tokenIndex
+					if buffer[position] != rune('n') {
+						goto l436
 					}
 					position++
-					goto l378
-				l379:
-					position, tokenIndex = position378, tokenIndex378
-					if buffer[position] != rune('C') {
-						goto l358
+					goto l435
+				l436:
+					position, tokenIndex = position435, tokenIndex435
+					if buffer[position] != rune('N') {
+						goto l417
 					}
 					position++
 				}
-			l378:
+			l435:
 				{
-					position380, tokenIndex380 := position, tokenIndex
-					if buffer[position] != rune('e') {
-						goto l381
+					position437, tokenIndex437 := position, tokenIndex
+					if buffer[position] != rune('k') {
+						goto l438
 					}
 					position++
-					goto l380
-				l381:
-					position, tokenIndex = position380, tokenIndex380
-					if buffer[position] != rune('E') {
-						goto l358
+					goto l437
+				l438:
+					position, tokenIndex = position437, tokenIndex437
+					if buffer[position] != rune('K') {
+						goto l417
 					}
 					position++
 				}
-			l380:
+			l437:
 				if !_rules[rulesp]() {
-					goto l358
+					goto l417
 				}
 				if !_rules[ruleStreamIdentifier]() {
-					goto l358
+					goto l417
 				}
-				if !_rules[ruleAction13]() {
-					goto l358
+				if !_rules[ruleUpdateSourceSinkSpecs]() {
+					goto l417
 				}
-				add(rulePauseSourceStmt, position359)
+				if !_rules[ruleAction14]() {
+					goto l417
+				}
+				add(ruleUpdateSinkStmt, position418)
 			}
 			return true
-		l358:
-			position, tokenIndex = position358, tokenIndex358
+		l417:
+			position, tokenIndex = position417, tokenIndex417
 			return false
 		},
-		/* 20 ResumeSourceStmt <- <(('r' / 'R') ('e' / 'E') ('s' / 'S') ('u' / 'U') ('m' / 'M') ('e' / 'E') sp (('s' / 'S') ('o' / 'O') ('u' / 'U') ('r' / 'R') ('c' / 'C') ('e' / 'E')) sp StreamIdentifier Action14)> */
+		/* 20 InsertIntoSelectStmt <- <(('i' / 'I') ('n' / 'N') ('s' / 'S') ('e' / 'E') ('r' / 'R') ('t' / 'T') sp (('i' / 'I') ('n' / 'N') ('t' / 'T') ('o' / 'O')) sp StreamIdentifier sp SelectStmt Action15)> */
 		func() bool {
-			position382, tokenIndex382 := position, tokenIndex
+			position439, tokenIndex439 := position, tokenIndex
 			{
-				position383 := position
+				position440 := position
 				{
-					position384, tokenIndex384 := position, tokenIndex
-					if buffer[position] != rune('r') {
-						goto l385
+					position441, tokenIndex441 := position, tokenIndex
+					if buffer[position] != rune('i') {
+						goto l442
 					}
 					position++
-					goto l384
-				l385:
-					position, tokenIndex = position384, tokenIndex384
-					if buffer[position] != rune('R') {
-						goto l382
+					goto l441
+				l442:
+					position, tokenIndex = position441, tokenIndex441
+					if buffer[position] != rune('I') {
+						goto l439
 					}
 					position++
 				}
-			l384:
+			l441:
 				{
-					position386, tokenIndex386 := position, tokenIndex
-					if buffer[position] != rune('e') {
-						goto l387
+					position443, tokenIndex443 := position, tokenIndex
+					if buffer[position] != rune('n') {
+						goto l444
 					}
 					position++
-					goto l386
-				l387:
-					position, tokenIndex = position386, tokenIndex386
-					if buffer[position] != rune('E') {
-						goto l382
+					goto l443
+				l444:
+					position, tokenIndex = position443, tokenIndex443
+					if buffer[position] != rune('N') {
+						goto l439
 					}
 					position++
 				}
-			l386:
+			l443:
 				{
-					position388, tokenIndex388 := position, tokenIndex
+					position445, tokenIndex445 := position, tokenIndex
 					if buffer[position] != rune('s') {
-						goto l389
+						goto l446
 					}
 					position++
-					goto l388
-				l389:
-					position, tokenIndex = position388, tokenIndex388
+					goto l445
+				l446:
+					position, tokenIndex = position445, tokenIndex445
 					if buffer[position] != rune('S') {
-						goto l382
+						goto l439
 					}
 					position++
 				}
-			l388:
+			l445:
 				{
-					position390, tokenIndex390 := position, tokenIndex
-					if buffer[position] != rune('u') {
-						goto l391
+					position447, tokenIndex447 := position, tokenIndex
+					if buffer[position] != rune('e') {
+						goto l448
 					}
 					position++
-					goto l390
-				l391:
-					position, tokenIndex = position390, tokenIndex390
-					if buffer[position] != rune('U') {
-						goto l382
+					goto l447
+				l448:
+					position, tokenIndex = position447, tokenIndex447
+					if buffer[position] != rune('E') {
+						goto l439
 					}
 					position++
 				}
-			l390:
+			l447:
 				{
-					position392, tokenIndex392 := position, tokenIndex
-					if buffer[position] != rune('m') {
-						goto l393
+					position449, tokenIndex449 := position, tokenIndex
+					if buffer[position] != rune('r') {
+						goto l450
 					}
 					position++
-					goto l392
-				l393:
-					position, tokenIndex = position392, tokenIndex392
-					if buffer[position] != rune('M') {
-						goto l382
+					goto l449
+				l450:
+					position, tokenIndex = position449, tokenIndex449
+					if buffer[position] != rune('R') {
+						goto l439
 					}
 					position++
 				}
-			l392:
+			l449:
 				{
-					position394, tokenIndex394 := position, tokenIndex
-					if buffer[position] != rune('e') {
-						goto l395
+					position451, tokenIndex451 := position, tokenIndex
+					if buffer[position] != rune('t') {
+						goto l452
 					}
 					position++
-					goto l394
-				l395:
-					position, tokenIndex = position394, tokenIndex394
-					if buffer[position] != rune('E') {
-						goto l382
+					goto l451
+				l452:
+					position, tokenIndex = position451, tokenIndex451
+					if buffer[position] != rune('T') {
+						goto l439
 					}
 					position++
 				}
-			l394:
+			l451:
 				if !_rules[rulesp]() {
-					goto l382
+					goto l439
 				}
 				{
-					position396, tokenIndex396 := position, tokenIndex
-					if buffer[position] != rune('s') {
-						goto l397
+					position453, tokenIndex453 := position, tokenIndex
+					if buffer[position] != rune('i') {
+						goto l454
 					}
 					position++
-					goto l396
-				l397:
-					position, tokenIndex = position396, tokenIndex396
-					if buffer[position] != rune('S') {
-						goto l382
+					goto l453
+				l454:
+					position, tokenIndex = position453, tokenIndex453
+					if buffer[position] != rune('I') {
+						goto l439
 					}
 					position++
 				}
-			l396:
+			l453:
 				{
-					position398, tokenIndex398 := position, tokenIndex
-					if buffer[position] != rune('o') {
-						goto l399
+					position455, tokenIndex455 := position, tokenIndex
+					if buffer[position] != rune('n') {
+						goto l456
 					}
 					position++
-					goto l398
-				l399:
-					position, tokenIndex = position398, tokenIndex398
-					if buffer[position] != rune('O') {
-						goto l382
+					goto l455
+				l456:
+					position, tokenIndex = position455, tokenIndex455
+					if buffer[position] != rune('N') {
+						goto l439
 					}
 					position++
 				}
-			l398:
+			l455:
 				{
-					position400, tokenIndex400 := position, tokenIndex
-					if buffer[position] != rune('u') {
-						goto l401
+					position457, tokenIndex457 := position, tokenIndex
+					if buffer[position] != rune('t') {
+						goto l458
 					}
 					position++
-					goto l400
-				l401:
-					position, tokenIndex = position400, tokenIndex400
-					if buffer[position] != rune('U') {
-						goto l382
+					goto l457
+				l458:
+					position, tokenIndex = position457, tokenIndex457
+					if buffer[position] != rune('T') {
+						goto l439
 					}
 					position++
 				}
-			l400:
+			l457:
 				{
-					position402, tokenIndex402 := position, tokenIndex
-					if buffer[position] != rune('r') {
-						goto l403
+					position459, tokenIndex459 := position, tokenIndex
+					if buffer[position] != rune('o') {
+						goto l460
 					}
 					position++
-					goto l402
-				l403:
-					position, tokenIndex = position402, tokenIndex402
-					if buffer[position] != rune('R') {
-						goto l382
+					goto l459
+				l460:
+					position, tokenIndex = position459, tokenIndex459
+					if buffer[position] != rune('O') {
+						goto l439
 					}
 					position++
 				}
-			l402:
-				{
-					position404, tokenIndex404 := position, tokenIndex
-					if buffer[position] != rune('c') {
-						goto l405
-					}
-					position++
-					goto l404
-				l405:
-					position, tokenIndex = position404, tokenIndex404
-					if buffer[position] != rune('C') {
-						goto l382
-					}
-					position++
+			l459:
+				if !_rules[rulesp]() {
+					goto l439
 				}
-			l404:
-				{
-					position406, tokenIndex406 := position, tokenIndex
-					if buffer[position] != rune('e') {
-						goto l407
-					}
-					position++
-					goto l406
-				l407:
-					position, tokenIndex = position406, tokenIndex406
-					if buffer[position] != rune('E') {
-						goto l382
-					}
-					position++
+				if !_rules[ruleStreamIdentifier]() {
+					goto l439
 				}
-			l406:
 				if !_rules[rulesp]() {
-					goto l382
+					goto l439
 				}
-				if !_rules[ruleStreamIdentifier]() {
-					goto l382
+				if !_rules[ruleSelectStmt]() {
+					goto l439
 				}
-				if !_rules[ruleAction14]() {
-					goto l382
+				if !_rules[ruleAction15]() {
+					goto l439
 				}
-				add(ruleResumeSourceStmt, position383)
+				add(ruleInsertIntoSelectStmt, position440)
 			}
 			return true
-		l382:
-			position, tokenIndex = position382, tokenIndex382
+		l439:
+			position, tokenIndex = position439, tokenIndex439
 			return false
 		},
-		/* 21 RewindSourceStmt <- <(('r' / 'R') ('e' / 'E') ('w' / 'W') ('i' / 'I') ('n' / 'N') ('d' / 'D') sp (('s' / 'S') ('o' / 'O') ('u' / 'U') ('r' / 'R') ('c' / 'C') ('e' / 'E')) sp StreamIdentifier Action15)> */
+		/* 21 InsertIntoFromStmt <- <(('i' / 'I') ('n' / 'N') ('s' / 'S') ('e' / 'E') ('r' / 'R') ('t' / 'T') sp (('i' / 'I') ('n' / 'N') ('t' / 'T') ('o' / 'O')) sp StreamIdentifier sp (('f' / 'F') ('r' / 'R') ('o' / 'O') ('m' / 'M')) sp InsertIntoInputs SourceSinkSpecs Action16)> */
 		func() bool {
-			position408, tokenIndex408 := position, tokenIndex
+			position461, tokenIndex461 := position, tokenIndex
 			{
-				position409 := position
+				position462 := position
 				{
-					position410, tokenIndex410 := position, tokenIndex
-					if buffer[position] != rune('r') {
-						goto l411
+					position463, tokenIndex463 := position, tokenIndex
+					if buffer[position] != rune('i') {
+						goto l464
 					}
 					position++
-					goto l410
-				l411:
-					position, tokenIndex = position410, tokenIndex410
-					if buffer[position] != rune('R') {
-						goto l408
+					goto l463
+				l464:
+					position, tokenIndex = position463, tokenIndex463
+					if buffer[position] != rune('I') {
+						goto l461
 					}
 					position++
 				}
-			l410:
+			l463:
 				{
-					position412, tokenIndex412 := position, tokenIndex
-					if buffer[position] != rune('e') {
-						goto l413
+					position465, tokenIndex465 := position, tokenIndex
+					if buffer[position] != rune('n') {
+						goto l466
 					}
 					position++
-					goto l412
-				l413:
-					position, tokenIndex = position412, tokenIndex412
-					if buffer[position] != rune('E') {
-						goto l408
+					goto l465
+				l466:
+					position, tokenIndex = position465, tokenIndex465
+					if buffer[position] != rune('N') {
+						goto l461
 					}
 					position++
 				}
-			l412:
+			l465:
 				{
-					position414, tokenIndex414 := position, tokenIndex
-					if buffer[position] != rune('w') {
-						goto l415
+					position467, tokenIndex467 := position, tokenIndex
+					if buffer[position] != rune('s') {
+						goto l468
 					}
 					position++
-					goto l414
-				l415:
-					position, tokenIndex = position414, tokenIndex414
-					if buffer[position] != rune('W') {
-						goto l408
+					goto l467
+				l468:
+					position, tokenIndex = position467, tokenIndex467
+					if buffer[position] != rune('S') {
+						goto l461
 					}
 					position++
 				}
-			l414:
+			l467:
 				{
-					position416, tokenIndex416 := position, tokenIndex
-					if buffer[position] != rune('i') {
-						goto l417
+					position469, tokenIndex469 := position, tokenIndex
+					if buffer[position] != rune('e') {
+						goto l470
 					}
 					position++
-					goto l416
-				l417:
-					position, tokenIndex = position416, tokenIndex416
-					if buffer[position] != rune('I') {
-						goto l408
+					goto l469
+				l470:
+					position, tokenIndex = position469, tokenIndex469
+					if buffer[position] != rune('E') {
+						goto l461
 					}
 					position++
 				}
-			l416:
+			l469:
 				{
-					position418, tokenIndex418 := position, tokenIndex
-					if buffer[position] != rune('n') {
-						goto l419
+					position471, tokenIndex471 := position, tokenIndex
+					if buffer[position] != rune('r') {
+						goto l472
 					}
 					position++
-					goto l418
-				l419:
-					position, tokenIndex = position418, tokenIndex418
-					if buffer[position] != rune('N') {
-						goto l408
+					goto l471
+				l472:
+					position, tokenIndex = position471, tokenIndex471
+					if buffer[position] != rune('R') {
+						goto l461
 					}
 					position++
 				}
-			l418:
+			l471:
 				{
-					position420, tokenIndex420 := position, tokenIndex
-					if buffer[position] != rune('d') {
-						goto l421
+					position473, tokenIndex473 := position, tokenIndex
+					if buffer[position] != rune('t') {
+						goto l474
 					}
 					position++
-					goto l420
-				l421:
-					position, tokenIndex = position420, tokenIndex420
-					if buffer[position] != rune('D') {
-						goto l408
+					goto l473
+				l474:
+					position, tokenIndex = position473, tokenIndex473
+					if buffer[position] != rune('T') {
+						goto l461
 					}
 					position++
 				}
-			l420:
+			l473:
 				if !_rules[rulesp]() {
-					goto l408
-				}
-				{
-					position422, tokenIndex422 := position, tokenIndex
-					if buffer[position] != rune('s') {
-						goto l423
-					}
-					position++
-					goto l422
-				l423:
-					position, tokenIndex = position422, tokenIndex422
-					if buffer[position] != rune('S') {
-						goto l408
-					}
-					position++
-				}
-			l422:
-				{
-					position424, tokenIndex424 := position, tokenIndex
-					if buffer[position] != rune('o') {
-						goto l425
-					}
-					position++
-					goto l424
-				l425:
-					position, tokenIndex = position424, tokenIndex424
-					if buffer[position] != rune('O') {
-						goto l408
-					}
-					position++
+					goto l461
 				}
-			l424:
 				{
-					position426, tokenIndex426 := position, tokenIndex
-					if buffer[position] != rune('u') {
-						goto l427
+					position475, tokenIndex475 := position, tokenIndex
+					if buffer[position] != rune('i') {
+						goto l476
 					}
 					position++
-					goto l426
-				l427:
-					position, tokenIndex = position426, tokenIndex426
-					if buffer[position] != rune('U') {
-						goto l408
+					goto l475
+				l476:
+					position, tokenIndex = position475, tokenIndex475
+					if buffer[position] != rune('I') {
+						goto l461
 					}
 					position++
 				}
-			l426:
+			l475:
 				{
-					position428, tokenIndex428 := position, tokenIndex
-					if buffer[position] != rune('r') {
-						goto l429
+					position477, tokenIndex477 := position, tokenIndex
+					if buffer[position] != rune('n') {
+						goto l478
 					}
 					position++
-					goto l428
-				l429:
-					position, tokenIndex = position428, tokenIndex428
-					if buffer[position] != rune('R') {
-						goto l408
+					goto l477
+				l478:
+					position, tokenIndex = position477, tokenIndex477
+					if buffer[position] != rune('N') {
+						goto l461
 					}
 					position++
 				}
-			l428:
+			l477:
 				{
-					position430, tokenIndex430 := position, tokenIndex
-					if buffer[position] != rune('c') {
-						goto l431
+					position479, tokenIndex479 := position, tokenIndex
+					if buffer[position] != rune('t') {
+						goto l480
 					}
 					position++
-					goto l430
-				l431:
-					position, tokenIndex = position430, tokenIndex430
-					if buffer[position] != rune('C') {
-						goto l408
+					goto l479
+				l480:
+					position, tokenIndex = position479, tokenIndex479
+					if buffer[position] != rune('T') {
+						goto l461
 					}
 					position++
 				}
-			l430:
+			l479:
 				{
-					position432, tokenIndex432 := position, tokenIndex
-					if buffer[position] != rune('e') {
-						goto l433
+					position481, tokenIndex481 := position, tokenIndex
+					if buffer[position] != rune('o') {
+						goto l482
 					}
 					position++
-					goto l432
-				l433:
-					position, tokenIndex = position432, tokenIndex432
-					if buffer[position] != rune('E') {
-						goto l408
+					goto l481
+				l482:
+					position, tokenIndex = position481, tokenIndex481
+					if buffer[position] != rune('O') {
+						goto l461
 					}
 					position++
 				}
-			l432:
+			l481:
 				if !_rules[rulesp]() {
-					goto l408
+					goto l461
 				}
 				if !_rules[ruleStreamIdentifier]() {
-					goto l408
+					goto l461
 				}
-				if !_rules[ruleAction15]() {
-					goto l408
+				if !_rules[rulesp]() {
+					goto l461
 				}
-				add(ruleRewindSourceStmt, position409)
-			}
-			return true
-		l408:
-			position, tokenIndex = position408, tokenIndex408
-			return false
-		},
-		/* 22 DropSourceStmt <- <(('d' / 'D') ('r' / 'R') ('o' / 'O') ('p' / 'P') sp (('s' / 'S') ('o' / 'O') ('u' / 'U') ('r' / 'R') ('c' / 'C') ('e' / 'E')) sp StreamIdentifier Action16)> */
-		func() bool {
-			position434, tokenIndex434 := position, tokenIndex
-			{
-				position435 := position
 				{
-					position436, tokenIndex436 := position, tokenIndex
-					if buffer[position] != rune('d') {
-						goto l437
+					position483, tokenIndex483 := position, tokenIndex
+					if buffer[position] != rune('f') {
+						goto l484
 					}
 					position++
-					goto l436
-				l437:
-					position, tokenIndex = position436, tokenIndex436
-					if buffer[position] != rune('D') {
-						goto l434
+					goto l483
+				l484:
+					position, tokenIndex = position483, tokenIndex483
+					if buffer[position] != rune('F') {
+						goto l461
 					}
 					position++
 				}
-			l436:
+			l483:
 				{
-					position438, tokenIndex438 := position, tokenIndex
+					position485, tokenIndex485 := position, tokenIndex
 					if buffer[position] != rune('r') {
-						goto l439
+						goto l486
 					}
 					position++
-					goto l438
-				l439:
-					position, tokenIndex = position438, tokenIndex438
+					goto l485
+				l486:
+					position, tokenIndex = position485, tokenIndex485
 					if buffer[position] != rune('R') {
-						goto l434
+						goto l461
 					}
 					position++
 				}
-			l438:
+			l485:
 				{
-					position440, tokenIndex440 := position, tokenIndex
+					position487, tokenIndex487 := position, tokenIndex
 					if buffer[position] != rune('o') {
-						goto l441
+						goto l488
 					}
 					position++
-					goto l440
-				l441:
-					position, tokenIndex = position440, tokenIndex440
+					goto l487
+				l488:
+					position, tokenIndex = position487, tokenIndex487
 					if buffer[position] != rune('O') {
-						goto l434
+						goto l461
 					}
 					position++
 				}
-			l440:
+			l487:
 				{
-					position442, tokenIndex442 := position, tokenIndex
-					if buffer[position] != rune('p') {
-						goto l443
+					position489, tokenIndex489 := position, tokenIndex
+					if buffer[position] != rune('m') {
+						goto l490
 					}
 					position++
-					goto l442
-				l443:
-					position, tokenIndex = position442, tokenIndex442
-					if buffer[position] != rune('P') {
-						goto l434
+					goto l489
+				l490:
+					position, tokenIndex = position489, tokenIndex489
+					if buffer[position] != rune('M') {
+						goto l461
 					}
 					position++
 				}
-			l442:
+			l489:
 				if !_rules[rulesp]() {
-					goto l434
+					goto l461
+				}
+				if !_rules[ruleInsertIntoInputs]() {
+					goto l461
+				}
+				if !_rules[ruleSourceSinkSpecs]() {
+					goto l461
+				}
+				if !_rules[ruleAction16]() {
+					goto l461
 				}
+				add(ruleInsertIntoFromStmt, position462)
+			}
+			return true
+		l461:
+			position, tokenIndex = position461, tokenIndex461
+			return false
+		},
+		/* 22 InsertIntoInputs <- <(<(StreamIdentifier (spOpt ',' spOpt StreamIdentifier)*)> Action17)> */
+		func() bool {
+			position491, tokenIndex491 := position, tokenIndex
+			{
+				position492 := position
 				{
-					position444, tokenIndex444 := position, tokenIndex
-					if buffer[position] != rune('s') {
-						goto l445
+					position493 := position
+					if !_rules[ruleStreamIdentifier]() {
+						goto l491
 					}
-					position++
-					goto l444
-				l445:
-					position, tokenIndex = position444, tokenIndex444
-					if buffer[position] != rune('S') {
-						goto l434
+				l494:
+					{
+						position495, tokenIndex495 := position, tokenIndex
+						if !_rules[rulespOpt]() {
+							goto l495
+						}
+						if buffer[position] != rune(',') {
+							goto l495
+						}
+						position++
+						if !_rules[rulespOpt]() {
+							goto l495
+						}
+						if !_rules[ruleStreamIdentifier]() {
+							goto l495
+						}
+						goto l494
+					l495:
+						position, tokenIndex = position495, tokenIndex495
 					}
-					position++
+					add(rulePegText, position493)
+				}
+				if !_rules[ruleAction17]() {
+					goto l491
 				}
-			l444:
+				add(ruleInsertIntoInputs, position492)
+			}
+			return true
+		l491:
+			position, tokenIndex = position491, tokenIndex491
+			return false
+		},
+		/* 23 SplitStmt <- <(('s' / 'S') ('p' / 'P') ('l' / 'L') ('i' / 'I') ('t' / 'T') sp StreamIdentifier sp (('i' / 'I') ('n' / 'N') ('t' / 'T') ('o' / 'O')) sp SplitBranches Action18)> */
+		func() bool {
+			position496, tokenIndex496 := position, tokenIndex
+			{
+				position497 := position
 				{
-					position446, tokenIndex446 := position, tokenIndex
-					if buffer[position] != rune('o') {
-						goto l447
+					position498, tokenIndex498 := position, tokenIndex
+					if buffer[position] != rune('s') {
+						goto l499
 					}
 					position++
-					goto l446
-				l447:
-					position, tokenIndex = position446, tokenIndex446
-					if buffer[position] != rune('O') {
-						goto l434
+					goto l498
+				l499:
+					position, tokenIndex = position498, tokenIndex498
+					if buffer[position] != rune('S') {
+						goto l496
 					}
 					position++
 				}
-			l446:
+			l498:
 				{
-					position448, tokenIndex448 := position, tokenIndex
-					if buffer[position] != rune('u') {
-						goto l449
+					position500, tokenIndex500 := position, tokenIndex
+					if buffer[position] != rune('p') {
+						goto l501
 					}
 					position++
-					goto l448
-				l449:
-					position, tokenIndex = position448, tokenIndex448
-					if buffer[position] != rune('U') {
-						goto l434
+					goto l500
+				l501:
+					position, tokenIndex = position500, tokenIndex500
+					if buffer[position] != rune('P') {
+						goto l496
 					}
 					position++
 				}
-			l448:
+			l500:
 				{
-					position450, tokenIndex450 := position, tokenIndex
-					if buffer[position] != rune('r') {
-						goto l451
+					position502, tokenIndex502 := position, tokenIndex
+					if buffer[position] != rune('l') {
+						goto l503
 					}
 					position++
-					goto l450
-				l451:
-					position, tokenIndex = position450, tokenIndex450
-					if buffer[position] != rune('R') {
-						goto l434
+					goto l502
+				l503:
+					position, tokenIndex = position502, tokenIndex502
+					if buffer[position] != rune('L') {
+						goto l496
 					}
 					position++
 				}
-			l450:
+			l502:
 				{
-					position452, tokenIndex452 := position, tokenIndex
-					if buffer[position] != rune('c') {
-						goto l453
+					position504, tokenIndex504 := position, tokenIndex
+					if buffer[position] != rune('i') {
+						goto l505
 					}
 					position++
-					goto l452
-				l453:
-					position, tokenIndex = position452, tokenIndex452
-					if buffer[position] != rune('C') {
-						goto l434
+					goto l504
+				l505:
+					position, tokenIndex = position504, tokenIndex504
+					if buffer[position] != rune('I') {
+						goto l496
 					}
 					position++
 				}
-			l452:
+			l504:
 				{
-					position454, tokenIndex454 := position, tokenIndex
-					if buffer[position] != rune('e') {
-						goto l455
+					position506, tokenIndex506 := position, tokenIndex
+					if buffer[position] != rune('t') {
+						goto l507
 					}
 					position++
-					goto l454
-				l455:
-					position, tokenIndex = position454, tokenIndex454
-					if buffer[position] != rune('E') {
-						goto l434
+					goto l506
+				l507:
+					position, tokenIndex = position506, tokenIndex506
+					if buffer[position] != rune('T') {
+						goto l496
 					}
 					position++
 				}
-			l454:
+			l506:
 				if !_rules[rulesp]() {
-					goto l434
+					goto l496
 				}
 				if !_rules[ruleStreamIdentifier]() {
-					goto l434
+					goto l496
 				}
-				if !_rules[ruleAction16]() {
-					goto l434
+				if !_rules[rulesp]() {
+					goto l496
 				}
-				add(ruleDropSourceStmt, position435)
-			}
-			return true
-		l434:
-			position, tokenIndex = position434, tokenIndex434
-			return false
-		},
-		/* 23 DropStreamStmt <- <(('d' / 'D') ('r' / 'R') ('o' / 'O') ('p' / 'P') sp (('s' / 'S') ('t' / 'T') ('r' / 'R') ('e' / 'E') ('a' / 'A') ('m' / 'M')) sp StreamIdentifier Action17)> */
-		func() bool {
-			position456, tokenIndex456 := position, tokenIndex
-			{
-				position457 := position
 				{
-					position458, tokenIndex458 := position, tokenIndex
-					if buffer[position] != rune('d') {
-						goto l459
+					position508, tokenIndex508 := position, tokenIndex
+					if buffer[position] != rune('i') {
+						goto l509
 					}
 					position++
-					goto l458
-				l459:
-					position, tokenIndex = position458, tokenIndex458
-					if buffer[position] != rune('D') {
-						goto l456
+					goto l508
+				l509:
+					position, tokenIndex = position508, tokenIndex508
+					if buffer[position] != rune('I') {
+						goto l496
 					}
 					position++
 				}
-			l458:
+			l508:
 				{
-					position460, tokenIndex460 := position, tokenIndex
-					if buffer[position] != rune('r') {
-						goto l461
+					position510, tokenIndex510 := position, tokenIndex
+					if buffer[position] != rune('n') {
+						goto l511
 					}
 					position++
-					goto l460
-				l461:
-					position, tokenIndex = position460, tokenIndex460
-					if buffer[position] != rune('R') {
-						goto l456
+					goto l510
+				l511:
+					position, tokenIndex = position510, tokenIndex510
+					if buffer[position] != rune('N') {
+						goto l496
 					}
 					position++
 				}
-			l460:
+			l510:
 				{
-					position462, tokenIndex462 := position, tokenIndex
-					if buffer[position] != rune('o') {
-						goto l463
+					position512, tokenIndex512 := position, tokenIndex
+					if buffer[position] != rune('t') {
+						goto l513
 					}
 					position++
-					goto l462
-				l463:
-					position, tokenIndex = position462, tokenIndex462
-					if buffer[position] != rune('O') {
-						goto l456
+					goto l512
+				l513:
+					position, tokenIndex = position512, tokenIndex512
+					if buffer[position] != rune('T') {
+						goto l496
 					}
 					position++
 				}
-			l462:
+			l512:
 				{
-					position464, tokenIndex464 := position, tokenIndex
-					if buffer[position] != rune('p') {
-						goto l465
+					position514, tokenIndex514 := position, tokenIndex
+					if buffer[position] != rune('o') {
+						goto l515
 					}
 					position++
-					goto l464
-				l465:
-					position, tokenIndex = position464, tokenIndex464
-					if buffer[position] != rune('P') {
-						goto l456
+					goto l514
+				l515:
+					position, tokenIndex = position514, tokenIndex514
+					if buffer[position] != rune('O') {
+						goto l496
 					}
 					position++
 				}
-			l464:
+			l514:
 				if !_rules[rulesp]() {
-					goto l456
+					goto l496
 				}
+				if !_rules[ruleSplitBranches]() {
+					goto l496
+				}
+				if !_rules[ruleAction18]() {
+					goto l496
+				}
+				add(ruleSplitStmt, position497)
+			}
+			return true
+		l496:
+			position, tokenIndex = position496, tokenIndex496
+			return false
+		},
+		/* 24 SplitBranches <- <(<(SplitBranch (spOpt ',' spOpt SplitBranch)* (spOpt ',' spOpt SplitOtherwise)?)> Action19)> */
+		func() bool {
+			position516, tokenIndex516 := position, tokenIndex
+			{
+				position517 := position
 				{
-					position466, tokenIndex466 := position, tokenIndex
-					if buffer[position] != rune('s') {
-						goto l467
+					position518 := position
+					if !_rules[ruleSplitBranch]() {
+						goto l516
 					}
-					position++
-					goto l466
-				l467:
-					position, tokenIndex = position466, tokenIndex466
-					if buffer[position] != rune('S') {
-						goto l456
+				l519:
+					{
+						position520, tokenIndex520 := position, tokenIndex
+						if !_rules[rulespOpt]() {
+							goto l520
+						}
+						if buffer[position] != rune(',') {
+							goto l520
+						}
+						position++
+						if !_rules[rulespOpt]() {
+							goto l520
+						}
+						if !_rules[ruleSplitBranch]() {
+							goto l520
+						}
+						goto l519
+					l520:
+						position, tokenIndex = position520, tokenIndex520
 					}
-					position++
+					{
+						position521, tokenIndex521 := position, tokenIndex
+						if !_rules[rulespOpt]() {
+							goto l521
+						}
+						if buffer[position] != rune(',') {
+							goto l521
+						}
+						position++
+						if !_rules[rulespOpt]() {
+							goto l521
+						}
+						if !_rules[ruleSplitOtherwise]() {
+							goto l521
+						}
+						goto l522
+					l521:
+						position, tokenIndex = position521, tokenIndex521
+					}
+				l522:
+					add(rulePegText, position518)
+				}
+				if !_rules[ruleAction19]() {
+					goto l516
+				}
+				add(ruleSplitBranches, position517)
+			}
+			return true
+		l516:
+			position, tokenIndex = position516, tokenIndex516
+			return false
+		},
+		/* 25 SplitBranch <- <(StreamIdentifier sp (('w' / 'W') ('h' / 'H') ('e' / 'E') ('n' / 'N')) sp Expression Action20)> */
+		func() bool {
+			position523, tokenIndex523 := position, tokenIndex
+			{
+				position524 := position
+				if !_rules[ruleStreamIdentifier]() {
+					goto l523
+				}
+				if !_rules[rulesp]() {
+					goto l523
 				}
-			l466:
 				{
-					position468, tokenIndex468 := position, tokenIndex
-					if buffer[position] != rune('t') {
-						goto l469
+					position525, tokenIndex525 := position, tokenIndex
+					if buffer[position] != rune('w') {
+						goto l526
 					}
 					position++
-					goto l468
-				l469:
-					position, tokenIndex = position468, tokenIndex468
-					if buffer[position] != rune('T') {
-						goto l456
+					goto l525
+				l526:
+					position, tokenIndex = position525, tokenIndex525
+					if buffer[position] != rune('W') {
+						goto l523
 					}
 					position++
 				}
-			l468:
+			l525:
 				{
-					position470, tokenIndex470 := position, tokenIndex
-					if buffer[position] != rune('r') {
-						goto l471
+					position527, tokenIndex527 := position, tokenIndex
+					if buffer[position] != rune('h') {
+						goto l528
 					}
 					position++
-					goto l470
-				l471:
-					position, tokenIndex = position470, tokenIndex470
-					if buffer[position] != rune('R') {
-						goto l456
+					goto l527
+				l528:
+					position, tokenIndex = position527, tokenIndex527
+					if buffer[position] != rune('H') {
+						goto l523
 					}
 					position++
 				}
-			l470:
+			l527:
 				{
-					position472, tokenIndex472 := position, tokenIndex
+					position529, tokenIndex529 := position, tokenIndex
 					if buffer[position] != rune('e') {
-						goto l473
+						goto l530
 					}
 					position++
-					goto l472
-				l473:
-					position, tokenIndex = position472, tokenIndex472
+					goto l529
+				l530:
+					position, tokenIndex = position529, tokenIndex529
 					if buffer[position] != rune('E') {
-						goto l456
-					}
-					position++
-				}
-			l472:
-				{
-					position474, tokenIndex474 := position, tokenIndex
-					if buffer[position] != rune('a') {
-						goto l475
-					}
-					position++
-					goto l474
-				l475:
-					position, tokenIndex = position474, tokenIndex474
-					if buffer[position] != rune('A') {
-						goto l456
+						goto l523
 					}
 					position++
 				}
-			l474:
+			l529:
 				{
-					position476, tokenIndex476 := position, tokenIndex
-					if buffer[position] != rune('m') {
-						goto l477
+					position531, tokenIndex531 := position, tokenIndex
+					if buffer[position] != rune('n') {
+						goto l532
 					}
 					position++
-					goto l476
-				l477:
-					position, tokenIndex = position476, tokenIndex476
-					if buffer[position] != rune('M') {
-						goto l456
+					goto l531
+				l532:
+					position, tokenIndex = position531, tokenIndex531
+					if buffer[position] != rune('N') {
+						goto l523
 					}
 					position++
 				}
-			l476:
+			l531:
 				if !_rules[rulesp]() {
-					goto l456
+					goto l523
 				}
-				if !_rules[ruleStreamIdentifier]() {
-					goto l456
+				if !_rules[ruleExpression]() {
+					goto l523
 				}
-				if !_rules[ruleAction17]() {
-					goto l456
+				if !_rules[ruleAction20]() {
+					goto l523
 				}
-				add(ruleDropStreamStmt, position457)
+				add(ruleSplitBranch, position524)
 			}
 			return true
-		l456:
-			position, tokenIndex = position456, tokenIndex456
+		l523:
+			position, tokenIndex = position523, tokenIndex523
 			return false
 		},
-		/* 24 DropSinkStmt <- <(('d' / 'D') ('r' / 'R') ('o' / 'O') ('p' / 'P') sp (('s' / 'S') ('i' / 'I') ('n' / 'N') ('k' / 'K')) sp StreamIdentifier Action18)> */
+		/* 26 SplitOtherwise <- <(StreamIdentifier sp (('o' / 'O') ('t' / 'T') ('h' / 'H') ('e' / 'E') ('r' / 'R') ('w' / 'W') ('i' / 'I') ('s' / 'S') ('e' / 'E')) Action21)> */
 		func() bool {
-			position478, tokenIndex478 := position, tokenIndex
+			position533, tokenIndex533 := position, tokenIndex
 			{
-				position479 := position
+				position534 := position
+				if !_rules[ruleStreamIdentifier]() {
+					goto l533
+				}
+				if !_rules[rulesp]() {
+					goto l533
+				}
 				{
-					position480, tokenIndex480 := position, tokenIndex
-					if buffer[position] != rune('d') {
-						goto l481
+					position535, tokenIndex535 := position, tokenIndex
+					if buffer[position] != rune('o') {
+						goto l536
 					}
 					position++
-					goto l480
-				l481:
-					position, tokenIndex = position480, tokenIndex480
-					if buffer[position] != rune('D') {
-						goto l478
+					goto l535
+				l536:
+					position, tokenIndex = position535, tokenIndex535
+					if buffer[position] != rune('O') {
+						goto l533
 					}
 					position++
 				}
-			l480:
+			l535:
 				{
-					position482, tokenIndex482 := position, tokenIndex
-					if buffer[position] != rune('r') {
-						goto l483
+					position537, tokenIndex537 := position, tokenIndex
+					if buffer[position] != rune('t') {
+						goto l538
 					}
 					position++
-					goto l482
-				l483:
-					position, tokenIndex = position482, tokenIndex482
-					if buffer[position] != rune('R') {
-						goto l478
+					goto l537
+				l538:
+					position, tokenIndex = position537, tokenIndex537
+					if buffer[position] != rune('T') {
+						goto l533
 					}
 					position++
 				}
-			l482:
+			l537:
 				{
-					position484, tokenIndex484 := position, tokenIndex
-					if buffer[position] != rune('o') {
-						goto l485
+					position539, tokenIndex539 := position, tokenIndex
+					if buffer[position] != rune('h') {
+						goto l540
 					}
 					position++
-					goto l484
-				l485:
-					position, tokenIndex = position484, tokenIndex484
-					if buffer[position] != rune('O') {
-						goto l478
+					goto l539
+				l540:
+					position, tokenIndex = position539, tokenIndex539
+					if buffer[position] != rune('H') {
+						goto l533
 					}
 					position++
 				}
-			l484:
+			l539:
 				{
-					position486, tokenIndex486 := position, tokenIndex
-					if buffer[position] != rune('p') {
-						goto l487
+					position541, tokenIndex541 := position, tokenIndex
+					if buffer[position] != rune('e') {
+						goto l542
 					}
 					position++
-					goto l486
-				l487:
-					position, tokenIndex = position486, tokenIndex486
-					if buffer[position] != rune('P') {
-						goto l478
+					goto l541
+				l542:
+					position, tokenIndex = position541, tokenIndex541
+					if buffer[position] != rune('E') {
+						goto l533
 					}
 					position++
 				}
-			l486:
-				if !_rules[rulesp]() {
-					goto l478
+			l541:
+				{
+					position543, tokenIndex543 := position, tokenIndex
+					if buffer[position] != rune('r') {
+						goto l544
+					}
+					position++
+					goto l543
+				l544:
+					position, tokenIndex = position543, tokenIndex543
+					if buffer[position] != rune('R') {
+						goto l533
+					}
+					position++
 				}
+			l543:
 				{
-					position488, tokenIndex488 := position, tokenIndex
-					if buffer[position] != rune('s') {
-						goto l489
+					position545, tokenIndex545 := position, tokenIndex
+					if buffer[position] != rune('w') {
+						goto l546
 					}
 					position++
-					goto l488
-				l489:
-					position, tokenIndex = position488, tokenIndex488
-					if buffer[position] != rune('S') {
-						goto l478
+					goto l545
+				l546:
+					position, tokenIndex = position545, tokenIndex545
+					if buffer[position] != rune('W') {
+						goto l533
 					}
 					position++
 				}
-			l488:
+			l545:
 				{
-					position490, tokenIndex490 := position, tokenIndex
+					position547, tokenIndex547 := position, tokenIndex
 					if buffer[position] != rune('i') {
-						goto l491
+						goto l548
 					}
 					position++
-					goto l490
-				l491:
-					position, tokenIndex = position490, tokenIndex490
+					goto l547
+				l548:
+					position, tokenIndex = position547, tokenIndex547
 					if buffer[position] != rune('I') {
-						goto l478
+						goto l533
 					}
 					position++
 				}
-			l490:
+			l547:
 				{
-					position492, tokenIndex492 := position, tokenIndex
-					if buffer[position] != rune('n') {
-						goto l493
+					position549, tokenIndex549 := position, tokenIndex
+					if buffer[position] != rune('s') {
+						goto l550
 					}
 					position++
-					goto l492
-				l493:
-					position, tokenIndex = position492, tokenIndex492
-					if buffer[position] != rune('N') {
-						goto l478
+					goto l549
+				l550:
+					position, tokenIndex = position549, tokenIndex549
+					if buffer[position] != rune('S') {
+						goto l533
 					}
 					position++
 				}
-			l492:
+			l549:
 				{
-					position494, tokenIndex494 := position, tokenIndex
-					if buffer[position] != rune('k') {
-						goto l495
+					position551, tokenIndex551 := position, tokenIndex
+					if buffer[position] != rune('e') {
+						goto l552
 					}
 					position++
-					goto l494
-				l495:
-					position, tokenIndex = position494, tokenIndex494
-					if buffer[position] != rune('K') {
-						goto l478
+					goto l551
+				l552:
+					position, tokenIndex = position551, tokenIndex551
+					if buffer[position] != rune('E') {
+						goto l533
 					}
 					position++
 				}
-			l494:
-				if !_rules[rulesp]() {
-					goto l478
-				}
-				if !_rules[ruleStreamIdentifier]() {
-					goto l478
-				}
-				if !_rules[ruleAction18]() {
-					goto l478
+			l551:
+				if !_rules[ruleAction21]() {
+					goto l533
 				}
-				add(ruleDropSinkStmt, position479)
+				add(ruleSplitOtherwise, position534)
 			}
 			return true
-		l478:
-			position, tokenIndex = position478, tokenIndex478
+		l533:
+			position, tokenIndex = position533, tokenIndex533
 			return false
 		},
-		/* 25 DropStateStmt <- <(('d' / 'D') ('r' / 'R') ('o' / 'O') ('p' / 'P') sp (('s' / 'S') ('t' / 'T') ('a' / 'A') ('t' / 'T') ('e' / 'E')) sp StreamIdentifier Action19)> */
+		/* 27 ArchiveStreamStmt <- <(('a' / 'A') ('r' / 'R') ('c' / 'C') ('h' / 'H') ('i' / 'I') ('v' / 'V') ('e' / 'E') sp (('s' / 'S') ('t' / 'T') ('r' / 'R') ('e' / 'E') ('a' / 'A') ('m' / 'M')) sp StreamIdentifier sp (('t' / 'T') ('o' / 'O')) sp StringLiteral SourceSinkSpecs Action22)> */
 		func() bool {
-			position496, tokenIndex496 := position, tokenIndex
+			position553, tokenIndex553 := position, tokenIndex
 			{
-				position497 := position
+				position554 := position
 				{
-					position498, tokenIndex498 := position, tokenIndex
-					if buffer[position] != rune('d') {
-						goto l499
+					position555, tokenIndex555 := position, tokenIndex
+					if buffer[position] != rune('a') {
+						goto l556
 					}
 					position++
-					goto l498
-				l499:
-					position, tokenIndex = position498, tokenIndex498
-					if buffer[position] != rune('D') {
-						goto l496
+					goto l555
+				l556:
+					position, tokenIndex = position555, tokenIndex555
+					if buffer[position] != rune('A') {
+						goto l553
 					}
 					position++
 				}
-			l498:
+			l555:
 				{
-					position500, tokenIndex500 := position, tokenIndex
+					position557, tokenIndex557 := position, tokenIndex
 					if buffer[position] != rune('r') {
-						goto l501
+						goto l558
 					}
 					position++
-					goto l500
-				l501:
-					position, tokenIndex = position500, tokenIndex500
+					goto l557
+				l558:
+					position, tokenIndex = position557, tokenIndex557
 					if buffer[position] != rune('R') {
-						goto l496
+						goto l553
 					}
 					position++
 				}
-			l500:
+			l557:
 				{
-					position502, tokenIndex502 := position, tokenIndex
-					if buffer[position] != rune('o') {
-						goto l503
+					position559, tokenIndex559 := position, tokenIndex
+					if buffer[position] != rune('c') {
+						goto l560
 					}
 					position++
-					goto l502
-				l503:
-					position, tokenIndex = position502, tokenIndex502
-					if buffer[position] != rune('O') {
-						goto l496
+					goto l559
+				l560:
+					position, tokenIndex = position559, tokenIndex559
+					if buffer[position] != rune('C') {
+						goto l553
 					}
 					position++
 				}
-			l502:
+			l559:
 				{
-					position504, tokenIndex504 := position, tokenIndex
-					if buffer[position] != rune('p') {
-						goto l505
+					position561, tokenIndex561 := position, tokenIndex
+					if buffer[position] != rune('h') {
+						goto l562
 					}
 					position++
-					goto l504
-				l505:
-					position, tokenIndex = position504, tokenIndex504
-					if buffer[position] != rune('P') {
-						goto l496
+					goto l561
+				l562:
+					position, tokenIndex = position561, tokenIndex561
+					if buffer[position] != rune('H') {
+						goto l553
 					}
 					position++
 				}
-			l504:
-				if !_rules[rulesp]() {
-					goto l496
-				}
+			l561:
 				{
-					position506, tokenIndex506 := position, tokenIndex
-					if buffer[position] != rune('s') {
-						goto l507
+					position563, tokenIndex563 := position, tokenIndex
+					if buffer[position] != rune('i') {
+						goto l564
 					}
 					position++
-					goto l506
-				l507:
-					position, tokenIndex = position506, tokenIndex506
-					if buffer[position] != rune('S') {
-						goto l496
+					goto l563
+				l564:
+					position, tokenIndex = position563, tokenIndex563
+					if buffer[position] != rune('I') {
+						goto l553
 					}
 					position++
 				}
-			l506:
+			l563:
 				{
-					position508, tokenIndex508 := position, tokenIndex
-					if buffer[position] != rune('t') {
-						goto l509
+					position565, tokenIndex565 := position, tokenIndex
+					if buffer[position] != rune('v') {
+						goto l566
 					}
 					position++
-					goto l508
-				l509:
-					position, tokenIndex = position508, tokenIndex508
-					if buffer[position] != rune('T') {
-						goto l496
+					goto l565
+				l566:
+					position, tokenIndex = position565, tokenIndex565
+					if buffer[position] != rune('V') {
+						goto l553
 					}
 					position++
 				}
-			l508:
+			l565:
 				{
-					position510, tokenIndex510 := position, tokenIndex
-					if buffer[position] != rune('a') {
-						goto l511
+					position567, tokenIndex567 := position, tokenIndex
+					if buffer[position] != rune('e') {
+						goto l568
 					}
 					position++
-					goto l510
-				l511:
-					position, tokenIndex = position510, tokenIndex510
-					if buffer[position] != rune('A') {
-						goto l496
+					goto l567
+				l568:
+					position, tokenIndex = position567, tokenIndex567
+					if buffer[position] != rune('E') {
+						goto l553
 					}
 					position++
 				}
-			l510:
+			l567:
+				if !_rules[rulesp]() {
+					goto l553
+				}
 				{
-					position512, tokenIndex512 := position, tokenIndex
-					if buffer[position] != rune('t') {
-						goto l513
+					position569, tokenIndex569 := position, tokenIndex
+					if buffer[position] != rune('s') {
+						goto l570
 					}
 					position++
-					goto l512
-				l513:
-					position, tokenIndex = position512, tokenIndex512
-					if buffer[position] != rune('T') {
-						goto l496
+					goto l569
+				l570:
+					position, tokenIndex = position569, tokenIndex569
+					if buffer[position] != rune('S') {
+						goto l553
 					}
 					position++
 				}
-			l512:
+			l569:
 				{
-					position514, tokenIndex514 := position, tokenIndex
-					if buffer[position] != rune('e') {
-						goto l515
+					position571, tokenIndex571 := position, tokenIndex
+					if buffer[position] != rune('t') {
+						goto l572
 					}
 					position++
-					goto l514
-				l515:
-					position, tokenIndex = position514, tokenIndex514
-					if buffer[position] != rune('E') {
-						goto l496
+					goto l571
+				l572:
+					position, tokenIndex = position571, tokenIndex571
+					if buffer[position] != rune('T') {
+						goto l553
 					}
 					position++
 				}
-			l514:
-				if !_rules[rulesp]() {
-					goto l496
-				}
-				if !_rules[ruleStreamIdentifier]() {
-					goto l496
-				}
-				if !_rules[ruleAction19]() {
-					goto l496
-				}
-				add(ruleDropStateStmt, position497)
-			}
-			return true
-		l496:
-			position, tokenIndex = position496, tokenIndex496
-			return false
-		},
-		/* 26 LoadStateStmt <- <(('l' / 'L') ('o' / 'O') ('a' / 'A') ('d' / 'D') sp (('s' / 'S') ('t' / 'T') ('a' / 'A') ('t' / 'T') ('e' / 'E')) sp StreamIdentifier sp (('t' / 'T') ('y' / 'Y') ('p' / 'P') ('e' / 'E')) sp SourceSinkType StateTagOpt SetOptSpecs Action20)> */
-		func() bool {
-			position516, tokenIndex516 := position, tokenIndex
-			{
-				position517 := position
+			l571:
 				{
-					position518, tokenIndex518 := position, tokenIndex
-					if buffer[position] != rune('l') {
-						goto l519
+					position573, tokenIndex573 := position, tokenIndex
+					if buffer[position] != rune('r') {
+						goto l574
 					}
 					position++
-					goto l518
-				l519:
-					position, tokenIndex = position518, tokenIndex518
-					if buffer[position] != rune('L') {
-						goto l516
+					goto l573
+				l574:
+					position, tokenIndex = position573, tokenIndex573
+					if buffer[position] != rune('R') {
+						goto l553
 					}
 					position++
 				}
-			l518:
+			l573:
 				{
-					position520, tokenIndex520 := position, tokenIndex
-					if buffer[position] != rune('o') {
-						goto l521
+					position575, tokenIndex575 := position, tokenIndex
+					if buffer[position] != rune('e') {
+						goto l576
 					}
 					position++
-					goto l520
-				l521:
-					position, tokenIndex = position520, tokenIndex520
-					if buffer[position] != rune('O') {
-						goto l516
+					goto l575
+				l576:
+					position, tokenIndex = position575, tokenIndex575
+					if buffer[position] != rune('E') {
+						goto l553
 					}
 					position++
 				}
-			l520:
+			l575:
 				{
-					position522, tokenIndex522 := position, tokenIndex
+					position577, tokenIndex577 := position, tokenIndex
 					if buffer[position] != rune('a') {
-						goto l523
+						goto l578
 					}
 					position++
-					goto l522
-				l523:
-					position, tokenIndex = position522, tokenIndex522
+					goto l577
+				l578:
+					position, tokenIndex = position577, tokenIndex577
 					if buffer[position] != rune('A') {
-						goto l516
+						goto l553
 					}
 					position++
 				}
-			l522:
+			l577:
 				{
-					position524, tokenIndex524 := position, tokenIndex
-					if buffer[position] != rune('d') {
-						goto l525
+					position579, tokenIndex579 := position, tokenIndex
+					if buffer[position] != rune('m') {
+						goto l580
 					}
 					position++
-					goto l524
-				l525:
-					position, tokenIndex = position524, tokenIndex524
-					if buffer[position] != rune('D') {
-						goto l516
+					goto l579
+				l580:
+					position, tokenIndex = position579, tokenIndex579
+					if buffer[position] != rune('M') {
+						goto l553
 					}
 					position++
 				}
-			l524:
+			l579:
 				if !_rules[rulesp]() {
-					goto l516
+					goto l553
 				}
-				{
-					position526, tokenIndex526 := position, tokenIndex
-					if buffer[position] != rune('s') {
-						goto l527
-					}
-					position++
-					goto l526
-				l527:
-					position, tokenIndex = position526, tokenIndex526
-					if buffer[position] != rune('S') {
-						goto l516
-					}
-					position++
+				if !_rules[ruleStreamIdentifier]() {
+					goto l553
+				}
+				if !_rules[rulesp]() {
+					goto l553
 				}
-			l526:
 				{
-					position528, tokenIndex528 := position, tokenIndex
+					position581, tokenIndex581 := position, tokenIndex
 					if buffer[position] != rune('t') {
-						goto l529
+						goto l582
 					}
 					position++
-					goto l528
-				l529:
-					position, tokenIndex = position528, tokenIndex528
+					goto l581
+				l582:
+					position, tokenIndex = position581, tokenIndex581
 					if buffer[position] != rune('T') {
-						goto l516
+						goto l553
 					}
 					position++
 				}
-			l528:
+			l581:
 				{
-					position530, tokenIndex530 := position, tokenIndex
-					if buffer[position] != rune('a') {
-						goto l531
+					position583, tokenIndex583 := position, tokenIndex
+					if buffer[position] != rune('o') {
+						goto l584
 					}
 					position++
-					goto l530
-				l531:
-					position, tokenIndex = position530, tokenIndex530
-					if buffer[position] != rune('A') {
-						goto l516
+					goto l583
+				l584:
+					position, tokenIndex = position583, tokenIndex583
+					if buffer[position] != rune('O') {
+						goto l553
 					}
 					position++
 				}
-			l530:
-				{
-					position532, tokenIndex532 := position, tokenIndex
-					if buffer[position] != rune('t') {
-						goto l533
-					}
-					position++
-					goto l532
-				l533:
-					position, tokenIndex = position532, tokenIndex532
-					if buffer[position] != rune('T') {
-						goto l516
-					}
-					position++
+			l583:
+				if !_rules[rulesp]() {
+					goto l553
 				}
-			l532:
-				{
-					position534, tokenIndex534 := position, tokenIndex
-					if buffer[position] != rune('e') {
-						goto l535
-					}
-					position++
-					goto l534
-				l535:
-					position, tokenIndex = position534, tokenIndex534
-					if buffer[position] != rune('E') {
-						goto l516
-					}
-					position++
-				}
-			l534:
-				if !_rules[rulesp]() {
-					goto l516
+				if !_rules[ruleStringLiteral]() {
+					goto l553
 				}
-				if !_rules[ruleStreamIdentifier]() {
-					goto l516
+				if !_rules[ruleSourceSinkSpecs]() {
+					goto l553
 				}
-				if !_rules[rulesp]() {
-					goto l516
+				if !_rules[ruleAction22]() {
+					goto l553
 				}
+				add(ruleArchiveStreamStmt, position554)
+			}
+			return true
+		l553:
+			position, tokenIndex = position553, tokenIndex553
+			return false
+		},
+		/* 28 ReplayArchiveStmt <- <(('r' / 'R') ('e' / 'E') ('p' / 'P') ('l' / 'L') ('a' / 'A') ('y' / 'Y') sp (('a' / 'A') ('r' / 'R') ('c' / 'C') ('h' / 'H') ('i' / 'I') ('v' / 'V') ('e' / 'E')) sp StringLiteral sp (('i' / 'I') ('n' / 'N') ('t' / 'T') ('o' / 'O')) sp StreamIdentifier ReplayFromOpt ReplayToOpt SourceSinkSpecs Action23)> */
+		func() bool {
+			position585, tokenIndex585 := position, tokenIndex
+			{
+				position586 := position
 				{
-					position536, tokenIndex536 := position, tokenIndex
-					if buffer[position] != rune('t') {
-						goto l537
+					position587, tokenIndex587 := position, tokenIndex
+					if buffer[position] != rune('r') {
+						goto l588
 					}
 					position++
-					goto l536
-				l537:
-					position, tokenIndex = position536, tokenIndex536
-					if buffer[position] != rune('T') {
-						goto l516
+					goto l587
+				l588:
+					position, tokenIndex = position587, tokenIndex587
+					if buffer[position] != rune('R') {
+						goto l585
 					}
 					position++
 				}
-			l536:
+			l587:
 				{
-					position538, tokenIndex538 := position, tokenIndex
-					if buffer[position] != rune('y') {
-						goto l539
+					position589, tokenIndex589 := position, tokenIndex
+					if buffer[position] != rune('e') {
+						goto l590
 					}
 					position++
-					goto l538
-				l539:
-					position, tokenIndex = position538, tokenIndex538
-					if buffer[position] != rune('Y') {
-						goto l516
+					goto l589
+				l590:
+					position, tokenIndex = position589, tokenIndex589
+					if buffer[position] != rune('E') {
+						goto l585
 					}
 					position++
 				}
-			l538:
+			l589:
 				{
-					position540, tokenIndex540 := position, tokenIndex
+					position591, tokenIndex591 := position, tokenIndex
 					if buffer[position] != rune('p') {
-						goto l541
+						goto l592
 					}
 					position++
-					goto l540
-				l541:
-					position, tokenIndex = position540, tokenIndex540
+					goto l591
+				l592:
+					position, tokenIndex = position591, tokenIndex591
 					if buffer[position] != rune('P') {
-						goto l516
+						goto l585
 					}
 					position++
 				}
-			l540:
+			l591:
 				{
-					position542, tokenIndex542 := position, tokenIndex
-					if buffer[position] != rune('e') {
-						goto l543
+					position593, tokenIndex593 := position, tokenIndex
+					if buffer[position] != rune('l') {
+						goto l594
 					}
 					position++
-					goto l542
-				l543:
-					position, tokenIndex = position542, tokenIndex542
-					if buffer[position] != rune('E') {
-						goto l516
+					goto l593
+				l594:
+					position, tokenIndex = position593, tokenIndex593
+					if buffer[position] != rune('L') {
+						goto l585
 					}
 					position++
 				}
-			l542:
-				if !_rules[rulesp]() {
-					goto l516
-				}
-				if !_rules[ruleSourceSinkType]() {
-					goto l516
-				}
-				if !_rules[ruleStateTagOpt]() {
-					goto l516
-				}
-				if !_rules[ruleSetOptSpecs]() {
-					goto l516
-				}
-				if !_rules[ruleAction20]() {
-					goto l516
-				}
-				add(ruleLoadStateStmt, position517)
-			}
-			return true
-		l516:
-			position, tokenIndex = position516, tokenIndex516
-			return false
-		},
-		/* 27 LoadStateOrCreateStmt <- <(LoadStateStmt sp (('o' / 'O') ('r' / 'R')) sp (('c' / 'C') ('r' / 'R') ('e' / 'E') ('a' / 'A') ('t' / 'T') ('e' / 'E')) sp (('i' / 'I') ('f' / 'F')) sp (('n' / 'N') ('o' / 'O') ('t' / 'T')) sp ((('s' / 'S') ('a' / 'A') ('v' / 'V') ('e' / 'E') ('d' / 'D')) / (('e' / 'E') ('x' / 'X') ('i' / 'I') ('s' / 'S') ('t' / 'T') ('s' / 'S'))) SourceSinkSpecs Action21)> */
-		func() bool {
-			position544, tokenIndex544 := position, tokenIndex
-			{
-				position545 := position
-				if !_rules[ruleLoadStateStmt]() {
-					goto l544
-				}
-				if !_rules[rulesp]() {
-					goto l544
-				}
+			l593:
 				{
-					position546, tokenIndex546 := position, tokenIndex
-					if buffer[position] != rune('o') {
-						goto l547
+					position595, tokenIndex595 := position, tokenIndex
+					if buffer[position] != rune('a') {
+						goto l596
 					}
 					position++
-					goto l546
-				l547:
-					position, tokenIndex = position546, tokenIndex546
-					if buffer[position] != rune('O') {
-						goto l544
+					goto l595
+				l596:
+					position, tokenIndex = position595, tokenIndex595
+					if buffer[position] != rune('A') {
+						goto l585
 					}
 					position++
 				}
-			l546:
+			l595:
 				{
-					position548, tokenIndex548 := position, tokenIndex
-					if buffer[position] != rune('r') {
-						goto l549
+					position597, tokenIndex597 := position, tokenIndex
+					if buffer[position] != rune('y') {
+						goto l598
 					}
 					position++
-					goto l548
-				l549:
-					position, tokenIndex = position548, tokenIndex548
-					if buffer[position] != rune('R') {
-						goto l544
+					goto l597
+				l598:
+					position, tokenIndex = position597, tokenIndex597
+					if buffer[position] != rune('Y') {
+						goto l585
 					}
 					position++
 				}
-			l548:
+			l597:
 				if !_rules[rulesp]() {
-					goto l544
+					goto l585
 				}
 				{
-					position550, tokenIndex550 := position, tokenIndex
-					if buffer[position] != rune('c') {
-						goto l551
+					position599, tokenIndex599 := position, tokenIndex
+					if buffer[position] != rune('a') {
+						goto l600
 					}
 					position++
-					goto l550
-				l551:
-					position, tokenIndex = position550, tokenIndex550
-					if buffer[position] != rune('C') {
-						goto l544
+					goto l599
+				l600:
+					position, tokenIndex = position599, tokenIndex599
+					if buffer[position] != rune('A') {
+						goto l585
 					}
 					position++
 				}
-			l550:
+			l599:
 				{
-					position552, tokenIndex552 := position, tokenIndex
+					position601, tokenIndex601 := position, tokenIndex
 					if buffer[position] != rune('r') {
-						goto l553
+						goto l602
 					}
 					position++
-					goto l552
-				l553:
-					position, tokenIndex = position552, tokenIndex552
+					goto l601
+				l602:
+					position, tokenIndex = position601, tokenIndex601
 					if buffer[position] != rune('R') {
-						goto l544
+						goto l585
 					}
 					position++
 				}
-			l552:
+			l601:
 				{
-					position554, tokenIndex554 := position, tokenIndex
-					if buffer[position] != rune('e') {
-						goto l555
+					position603, tokenIndex603 := position, tokenIndex
+					if buffer[position] != rune('c') {
+						goto l604
 					}
 					position++
-					goto l554
-				l555:
-					position, tokenIndex = position554, tokenIndex554
-					if buffer[position] != rune('E') {
-						goto l544
+					goto l603
+				l604:
+					position, tokenIndex = position603, tokenIndex603
+					if buffer[position] != rune('C') {
+						goto l585
 					}
 					position++
 				}
-			l554:
+			l603:
 				{
-					position556, tokenIndex556 := position, tokenIndex
-					if buffer[position] != rune('a') {
-						goto l557
+					position605, tokenIndex605 := position, tokenIndex
+					if buffer[position] != rune('h') {
+						goto l606
 					}
 					position++
-					goto l556
-				l557:
-					position, tokenIndex = position556, tokenIndex556
-					if buffer[position] != rune('A') {
-						goto l544
+					goto l605
+				l606:
+					position, tokenIndex = position605, tokenIndex605
+					if buffer[position] != rune('H') {
+						goto l585
 					}
 					position++
 				}
-			l556:
+			l605:
 				{
-					position558, tokenIndex558 := position, tokenIndex
-					if buffer[position] != rune('t') {
-						goto l559
+					position607, tokenIndex607 := position, tokenIndex
+					if buffer[position] != rune('i') {
+						goto l608
 					}
 					position++
-					goto l558
-				l559:
-					position, tokenIndex = position558, tokenIndex558
-					if buffer[position] != rune('T') {
-						goto l544
+					goto l607
+				l608:
+					position, tokenIndex = position607, tokenIndex607
+					if buffer[position] != rune('I') {
+						goto l585
 					}
 					position++
 				}
-			l558:
+			l607:
 				{
-					position560, tokenIndex560 := position, tokenIndex
-					if buffer[position] != rune('e') {
-						goto l561
+					position609, tokenIndex609 := position, tokenIndex
+					if buffer[position] != rune('v') {
+						goto l610
 					}
 					position++
-					goto l560
-				l561:
-					position, tokenIndex = position560, tokenIndex560
-					if buffer[position] != rune('E') {
-						goto l544
+					goto l609
+				l610:
+					position, tokenIndex = position609, tokenIndex609
+					if buffer[position] != rune('V') {
+						goto l585
 					}
 					position++
 				}
-			l560:
-				if !_rules[rulesp]() {
-					goto l544
-				}
+			l609:
 				{
-					position562, tokenIndex562 := position, tokenIndex
-					if buffer[position] != rune('i') {
-						goto l563
+					position611, tokenIndex611 := position, tokenIndex
+					if buffer[position] != rune('e') {
+						goto l612
 					}
 					position++
-					goto l562
-				l563:
-					position, tokenIndex = position562, tokenIndex562
-					if buffer[position] != rune('I') {
-						goto l544
+					goto l611
+				l612:
+					position, tokenIndex = position611, tokenIndex611
+					if buffer[position] != rune('E') {
+						goto l585
 					}
 					position++
 				}
-			l562:
+			l611:
+				if !_rules[rulesp]() {
+					goto l585
+				}
+				if !_rules[ruleStringLiteral]() {
+					goto l585
+				}
+				if !_rules[rulesp]() {
+					goto l585
+				}
 				{
-					position564, tokenIndex564 := position, tokenIndex
-					if buffer[position] != rune('f') {
-						goto l565
+					position613, tokenIndex613 := position, tokenIndex
+					if buffer[position] != rune('i') {
+						goto l614
 					}
 					position++
-					goto l564
-				l565:
-					position, tokenIndex = position564, tokenIndex564
-					if buffer[position] != rune('F') {
-						goto l544
+					goto l613
+				l614:
+					position, tokenIndex = position613, tokenIndex613
+					if buffer[position] != rune('I') {
+						goto l585
 					}
 					position++
 				}
-			l564:
-				if !_rules[rulesp]() {
-					goto l544
-				}
+			l613:
 				{
-					position566, tokenIndex566 := position, tokenIndex
+					position615, tokenIndex615 := position, tokenIndex
 					if buffer[position] != rune('n') {
-						goto l567
+						goto l616
 					}
 					position++
-					goto l566
-				l567:
-					position, tokenIndex = position566, tokenIndex566
+					goto l615
+				l616:
+					position, tokenIndex = position615, tokenIndex615
 					if buffer[position] != rune('N') {
-						goto l544
+						goto l585
 					}
 					position++
 				}
-			l566:
+			l615:
 				{
-					position568, tokenIndex568 := position, tokenIndex
-					if buffer[position] != rune('o') {
-						goto l569
+					position617, tokenIndex617 := position, tokenIndex
+					if buffer[position] != rune('t') {
+						goto l618
 					}
 					position++
-					goto l568
-				l569:
-					position, tokenIndex = position568, tokenIndex568
-					if buffer[position] != rune('O') {
-						goto l544
+					goto l617
+				l618:
+					position, tokenIndex = position617, tokenIndex617
+					if buffer[position] != rune('T') {
+						goto l585
 					}
 					position++
 				}
-			l568:
+			l617:
 				{
-					position570, tokenIndex570 := position, tokenIndex
-					if buffer[position] != rune('t') {
-						goto l571
+					position619, tokenIndex619 := position, tokenIndex
+					if buffer[position] != rune('o') {
+						goto l620
 					}
 					position++
-					goto l570
-				l571:
-					position, tokenIndex = position570, tokenIndex570
-					if buffer[position] != rune('T') {
-						goto l544
+					goto l619
+				l620:
+					position, tokenIndex = position619, tokenIndex619
+					if buffer[position] != rune('O') {
+						goto l585
 					}
 					position++
 				}
-			l570:
+			l619:
 				if !_rules[rulesp]() {
-					goto l544
+					goto l585
+				}
+				if !_rules[ruleStreamIdentifier]() {
+					goto l585
+				}
+				if !_rules[ruleReplayFromOpt]() {
+					goto l585
 				}
+				if !_rules[ruleReplayToOpt]() {
+					goto l585
+				}
+				if !_rules[ruleSourceSinkSpecs]() {
+					goto l585
+				}
+				if !_rules[ruleAction23]() {
+					goto l585
+				}
+				add(ruleReplayArchiveStmt, position586)
+			}
+			return true
+		l585:
+			position, tokenIndex = position585, tokenIndex585
+			return false
+		},
+		/* 29 ReplayFromOpt <- <(<(sp (('f' / 'F') ('r' / 'R') ('o' / 'O') ('m' / 'M')) sp StringLiteral)?> Action24)> */
+		func() bool {
+			position621, tokenIndex621 := position, tokenIndex
+			{
+				position622 := position
 				{
-					position572, tokenIndex572 := position, tokenIndex
+					position623 := position
 					{
-						position574, tokenIndex574 := position, tokenIndex
-						if buffer[position] != rune('s') {
-							goto l575
+						position624, tokenIndex624 := position, tokenIndex
+						if !_rules[rulesp]() {
+							goto l624
 						}
-						position++
-						goto l574
-					l575:
-						position, tokenIndex = position574, tokenIndex574
-						if buffer[position] != rune('S') {
-							goto l573
+						{
+							position626, tokenIndex626 := position, tokenIndex
+							if buffer[position] != rune('f') {
+								goto l627
+							}
+							position++
+							goto l626
+						l627:
+							position, tokenIndex = position626, tokenIndex626
+							if buffer[position] != rune('F') {
+								goto l624
+							}
+							position++
 						}
-						position++
-					}
-				l574:
-					{
-						position576, tokenIndex576 := position, tokenIndex
-						if buffer[position] != rune('a') {
-							goto l577
+					l626:
+						{
+							position628, tokenIndex628 := position, tokenIndex
+							if buffer[position] != rune('r') {
+								goto l629
+							}
+							position++
+							goto l628
+						l629:
+							position, tokenIndex = position628, tokenIndex628
+							if buffer[position] != rune('R') {
+								goto l624
+							}
+							position++
 						}
-						position++
-						goto l576
-					l577:
-						position, tokenIndex = position576, tokenIndex576
-						if buffer[position] != rune('A') {
-							goto l573
+					l628:
+						{
+							position630, tokenIndex630 := position, tokenIndex
+							if buffer[position] != rune('o') {
+								goto l631
+							}
+							position++
+							goto l630
+						l631:
+							position, tokenIndex = position630, tokenIndex630
+							if buffer[position] != rune('O') {
+								goto l624
+							}
+							position++
 						}
-						position++
-					}
-				l576:
-					{
-						position578, tokenIndex578 := position, tokenIndex
-						if buffer[position] != rune('v') {
-							goto l579
+					l630:
+						{
+							position632, tokenIndex632 := position, tokenIndex
+							if buffer[position] != rune('m') {
+								goto l633
+							}
+							position++
+							goto l632
+						l633:
+							position, tokenIndex = position632, tokenIndex632
+							if buffer[position] != rune('M') {
+								goto l624
+							}
+							position++
 						}
-						position++
-						goto l578
-					l579:
-						position, tokenIndex = position578, tokenIndex578
-						if buffer[position] != rune('V') {
-							goto l573
+					l632:
+						if !_rules[rulesp]() {
+							goto l624
 						}
-						position++
+						if !_rules[ruleStringLiteral]() {
+							goto l624
+						}
+						goto l625
+					l624:
+						position, tokenIndex = position624, tokenIndex624
 					}
-				l578:
+				l625:
+					add(rulePegText, position623)
+				}
+				if !_rules[ruleAction24]() {
+					goto l621
+				}
+				add(ruleReplayFromOpt, position622)
+			}
+			return true
+		l621:
+			position, tokenIndex = position621, tokenIndex621
+			return false
+		},
+		/* 30 ReplayToOpt <- <(<(sp (('t' / 'T') ('o' / 'O')) sp StringLiteral)?> Action25)> */
+		func() bool {
+			position634, tokenIndex634 := position, tokenIndex
+			{
+				position635 := position
+				{
+					position636 := position
 					{
-						position580, tokenIndex580 := position, tokenIndex
-						if buffer[position] != rune('e') {
-							goto l581
+						position637, tokenIndex637 := position, tokenIndex
+						if !_rules[rulesp]() {
+							goto l637
 						}
-						position++
-						goto l580
-					l581:
-						position, tokenIndex = position580, tokenIndex580
-						if buffer[position] != rune('E') {
-							goto l573
+						{
+							position639, tokenIndex639 := position, tokenIndex
+							if buffer[position] != rune('t') {
+								goto l640
+							}
+							position++
+							goto l639
+						l640:
+							position, tokenIndex = position639, tokenIndex639
+							if buffer[position] != rune('T') {
+								goto l637
+							}
+							position++
 						}
-						position++
-					}
-				l580:
-					{
-						position582, tokenIndex582 := position, tokenIndex
-						if buffer[position] != rune('d') {
-							goto l583
+					l639:
+						{
+							position641, tokenIndex641 := position, tokenIndex
+							if buffer[position] != rune('o') {
+								goto l642
+							}
+							position++
+							goto l641
+						l642:
+							position, tokenIndex = position641, tokenIndex641
+							if buffer[position] != rune('O') {
+								goto l637
+							}
+							position++
 						}
-						position++
-						goto l582
-					l583:
-						position, tokenIndex = position582, tokenIndex582
-						if buffer[position] != rune('D') {
-							goto l573
+					l641:
+						if !_rules[rulesp]() {
+							goto l637
 						}
-						position++
-					}
-				l582:
-					goto l572
-				l573:
-					position, tokenIndex = position572, tokenIndex572
-					{
-						position584, tokenIndex584 := position, tokenIndex
-						if buffer[position] != rune('e') {
-							goto l585
+						if !_rules[ruleStringLiteral]() {
+							goto l637
 						}
-						position++
-						goto l584
-					l585:
-						position, tokenIndex = position584, tokenIndex584
-						if buffer[position] != rune('E') {
-							goto l544
-						}
-						position++
-					}
-				l584:
-					{
-						position586, tokenIndex586 := position, tokenIndex
-						if buffer[position] != rune('x') {
-							goto l587
-						}
-						position++
-						goto l586
-					l587:
-						position, tokenIndex = position586, tokenIndex586
-						if buffer[position] != rune('X') {
-							goto l544
-						}
-						position++
-					}
-				l586:
-					{
-						position588, tokenIndex588 := position, tokenIndex
-						if buffer[position] != rune('i') {
-							goto l589
-						}
-						position++
-						goto l588
-					l589:
-						position, tokenIndex = position588, tokenIndex588
-						if buffer[position] != rune('I') {
-							goto l544
-						}
-						position++
-					}
-				l588:
-					{
-						position590, tokenIndex590 := position, tokenIndex
-						if buffer[position] != rune('s') {
-							goto l591
-						}
-						position++
-						goto l590
-					l591:
-						position, tokenIndex = position590, tokenIndex590
-						if buffer[position] != rune('S') {
-							goto l544
-						}
-						position++
-					}
-				l590:
-					{
-						position592, tokenIndex592 := position, tokenIndex
-						if buffer[position] != rune('t') {
-							goto l593
-						}
-						position++
-						goto l592
-					l593:
-						position, tokenIndex = position592, tokenIndex592
-						if buffer[position] != rune('T') {
-							goto l544
-						}
-						position++
-					}
-				l592:
-					{
-						position594, tokenIndex594 := position, tokenIndex
-						if buffer[position] != rune('s') {
-							goto l595
-						}
-						position++
-						goto l594
-					l595:
-						position, tokenIndex = position594, tokenIndex594
-						if buffer[position] != rune('S') {
-							goto l544
-						}
-						position++
+						goto l638
+					l637:
+						position, tokenIndex = position637, tokenIndex637
 					}
-				l594:
-				}
-			l572:
-				if !_rules[ruleSourceSinkSpecs]() {
-					goto l544
+				l638:
+					add(rulePegText, position636)
 				}
-				if !_rules[ruleAction21]() {
-					goto l544
+				if !_rules[ruleAction25]() {
+					goto l634
 				}
-				add(ruleLoadStateOrCreateStmt, position545)
+				add(ruleReplayToOpt, position635)
 			}
 			return true
-		l544:
-			position, tokenIndex = position544, tokenIndex544
+		l634:
+			position, tokenIndex = position634, tokenIndex634
 			return false
 		},
-		/* 28 SaveStateStmt <- <(('s' / 'S') ('a' / 'A') ('v' / 'V') ('e' / 'E') sp (('s' / 'S') ('t' / 'T') ('a' / 'A') ('t' / 'T') ('e' / 'E')) sp StreamIdentifier StateTagOpt Action22)> */
+		/* 31 PauseSourceStmt <- <(('p' / 'P') ('a' / 'A') ('u' / 'U') ('s' / 'S') ('e' / 'E') sp (('s' / 'S') ('o' / 'O') ('u' / 'U') ('r' / 'R') ('c' / 'C') ('e' / 'E')) sp StreamIdentifier Action26)> */
 		func() bool {
-			position596, tokenIndex596 := position, tokenIndex
+			position643, tokenIndex643 := position, tokenIndex
 			{
-				position597 := position
+				position644 := position
 				{
-					position598, tokenIndex598 := position, tokenIndex
-					if buffer[position] != rune('s') {
-						goto l599
+					position645, tokenIndex645 := position, tokenIndex
+					if buffer[position] != rune('p') {
+						goto l646
 					}
 					position++
-					goto l598
-				l599:
-					position, tokenIndex = position598, tokenIndex598
-					if buffer[position] != rune('S') {
-						goto l596
+					goto l645
+				l646:
+					position, tokenIndex = position645, tokenIndex645
+					if buffer[position] != rune('P') {
+						goto l643
 					}
 					position++
 				}
-			l598:
+			l645:
 				{
-					position600, tokenIndex600 := position, tokenIndex
+					position647, tokenIndex647 := position, tokenIndex
 					if buffer[position] != rune('a') {
-						goto l601
+						goto l648
 					}
 					position++
-					goto l600
-				l601:
-					position, tokenIndex = position600, tokenIndex600
+					goto l647
+				l648:
+					position, tokenIndex = position647, tokenIndex647
 					if buffer[position] != rune('A') {
-						goto l596
+						goto l643
 					}
 					position++
 				}
-			l600:
+			l647:
 				{
-					position602, tokenIndex602 := position, tokenIndex
-					if buffer[position] != rune('v') {
-						goto l603
+					position649, tokenIndex649 := position, tokenIndex
+					if buffer[position] != rune('u') {
+						goto l650
 					}
 					position++
-					goto l602
-				l603:
-					position, tokenIndex = position602, tokenIndex602
-					if buffer[position] != rune('V') {
-						goto l596
+					goto l649
+				l650:
+					position, tokenIndex = position649, tokenIndex649
+					if buffer[position] != rune('U') {
+						goto l643
+					}
+					position++
+				}
+			l649:
+				{
+					position651, tokenIndex651 := position, tokenIndex
+					if buffer[position] != rune('s') {
+						goto l652
+					}
+					position++
+					goto l651
+				l652:
+					position, tokenIndex = position651, tokenIndex651
+					if buffer[position] != rune('S') {
+						goto l643
 					}
 					position++
 				}
-			l602:
+			l651:
 				{
-					position604, tokenIndex604 := position, tokenIndex
+					position653, tokenIndex653 := position, tokenIndex
 					if buffer[position] != rune('e') {
-						goto l605
+						goto l654
 					}
 					position++
-					goto l604
-				l605:
-					position, tokenIndex = position604, tokenIndex604
+					goto l653
+				l654:
+					position, tokenIndex = position653, tokenIndex653
 					if buffer[position] != rune('E') {
-						goto l596
+						goto l643
 					}
 					position++
 				}
-			l604:
+			l653:
 				if !_rules[rulesp]() {
-					goto l596
+					goto l643
 				}
 				{
-					position606, tokenIndex606 := position, tokenIndex
+					position655, tokenIndex655 := position, tokenIndex
 					if buffer[position] != rune('s') {
-						goto l607
+						goto l656
 					}
 					position++
-					goto l606
-				l607:
-					position, tokenIndex = position606, tokenIndex606
+					goto l655
+				l656:
+					position, tokenIndex = position655, tokenIndex655
 					if buffer[position] != rune('S') {
-						goto l596
+						goto l643
 					}
 					position++
 				}
-			l606:
+			l655:
 				{
-					position608, tokenIndex608 := position, tokenIndex
-					if buffer[position] != rune('t') {
-						goto l609
+					position657, tokenIndex657 := position, tokenIndex
+					if buffer[position] != rune('o') {
+						goto l658
 					}
 					position++
-					goto l608
-				l609:
-					position, tokenIndex = position608, tokenIndex608
-					if buffer[position] != rune('T') {
-						goto l596
+					goto l657
+				l658:
+					position, tokenIndex = position657, tokenIndex657
+					if buffer[position] != rune('O') {
+						goto l643
 					}
 					position++
 				}
-			l608:
+			l657:
 				{
-					position610, tokenIndex610 := position, tokenIndex
-					if buffer[position] != rune('a') {
-						goto l611
+					position659, tokenIndex659 := position, tokenIndex
+					if buffer[position] != rune('u') {
+						goto l660
 					}
 					position++
-					goto l610
-				l611:
-					position, tokenIndex = position610, tokenIndex610
-					if buffer[position] != rune('A') {
-						goto l596
+					goto l659
+				l660:
+					position, tokenIndex = position659, tokenIndex659
+					if buffer[position] != rune('U') {
+						goto l643
 					}
 					position++
 				}
-			l610:
+			l659:
 				{
-					position612, tokenIndex612 := position, tokenIndex
-					if buffer[position] != rune('t') {
-						goto l613
+					position661, tokenIndex661 := position, tokenIndex
+					if buffer[position] != rune('r') {
+						goto l662
 					}
 					position++
-					goto l612
-				l613:
-					position, tokenIndex = position612, tokenIndex612
-					if buffer[position] != rune('T') {
-						goto l596
+					goto l661
+				l662:
+					position, tokenIndex = position661, tokenIndex661
+					if buffer[position] != rune('R') {
+						goto l643
+					}
+					position++
+				}
+			l661:
+				{
+					position663, tokenIndex663 := position, tokenIndex
+					if buffer[position] != rune('c') {
+						goto l664
+					}
+					position++
+					goto l663
+				l664:
+					position, tokenIndex = position663, tokenIndex663
+					if buffer[position] != rune('C') {
+						goto l643
 					}
 					position++
 				}
-			l612:
+			l663:
 				{
-					position614, tokenIndex614 := position, tokenIndex
+					position665, tokenIndex665 := position, tokenIndex
 					if buffer[position] != rune('e') {
-						goto l615
+						goto l666
 					}
 					position++
-					goto l614
-				l615:
-					position, tokenIndex = position614, tokenIndex614
+					goto l665
+				l666:
+					position, tokenIndex = position665, tokenIndex665
 					if buffer[position] != rune('E') {
-						goto l596
+						goto l643
 					}
 					position++
 				}
-			l614:
+			l665:
 				if !_rules[rulesp]() {
-					goto l596
+					goto l643
 				}
 				if !_rules[ruleStreamIdentifier]() {
-					goto l596
-				}
-				if !_rules[ruleStateTagOpt]() {
-					goto l596
+					goto l643
 				}
-				if !_rules[ruleAction22]() {
-					goto l596
+				if !_rules[ruleAction26]() {
+					goto l643
 				}
-				add(ruleSaveStateStmt, position597)
+				add(rulePauseSourceStmt, position644)
 			}
 			return true
-		l596:
-			position, tokenIndex = position596, tokenIndex596
+		l643:
+			position, tokenIndex = position643, tokenIndex643
 			return false
 		},
-		/* 29 EvalStmt <- <(('e' / 'E') ('v' / 'V') ('a' / 'A') ('l' / 'L') sp Expression <(sp (('o' / 'O') ('n' / 'N')) sp MapExpr)?> Action23)> */
+		/* 32 ResumeSourceStmt <- <(('r' / 'R') ('e' / 'E') ('s' / 'S') ('u' / 'U') ('m' / 'M') ('e' / 'E') sp (('s' / 'S') ('o' / 'O') ('u' / 'U') ('r' / 'R') ('c' / 'C') ('e' / 'E')) sp StreamIdentifier Action27)> */
 		func() bool {
-			position616, tokenIndex616 := position, tokenIndex
+			position667, tokenIndex667 := position, tokenIndex
 			{
-				position617 := position
+				position668 := position
+				{
+					position669, tokenIndex669 := position, tokenIndex
+					if buffer[position] != rune('r') {
+						goto l670
+					}
+					position++
+					goto l669
+				l670:
+					position, tokenIndex = position669, tokenIndex669
+					if buffer[position] != rune('R') {
+						goto l667
+					}
+					position++
+				}
+			l669:
 				{
-					position618, tokenIndex618 := position, tokenIndex
+					position671, tokenIndex671 := position, tokenIndex
 					if buffer[position] != rune('e') {
-						goto l619
+						goto l672
 					}
 					position++
-					goto l618
-				l619:
-					position, tokenIndex = position618, tokenIndex618
+					goto l671
+				l672:
+					position, tokenIndex = position671, tokenIndex671
 					if buffer[position] != rune('E') {
-						goto l616
+						goto l667
 					}
 					position++
 				}
-			l618:
+			l671:
 				{
-					position620, tokenIndex620 := position, tokenIndex
-					if buffer[position] != rune('v') {
-						goto l621
+					position673, tokenIndex673 := position, tokenIndex
+					if buffer[position] != rune('s') {
+						goto l674
 					}
 					position++
-					goto l620
-				l621:
-					position, tokenIndex = position620, tokenIndex620
-					if buffer[position] != rune('V') {
-						goto l616
+					goto l673
+				l674:
+					position, tokenIndex = position673, tokenIndex673
+					if buffer[position] != rune('S') {
+						goto l667
 					}
 					position++
 				}
-			l620:
+			l673:
 				{
-					position622, tokenIndex622 := position, tokenIndex
-					if buffer[position] != rune('a') {
-						goto l623
+					position675, tokenIndex675 := position, tokenIndex
+					if buffer[position] != rune('u') {
+						goto l676
 					}
 					position++
-					goto l622
-				l623:
-					position, tokenIndex = position622, tokenIndex622
-					if buffer[position] != rune('A') {
-						goto l616
+					goto l675
+				l676:
+					position, tokenIndex = position675, tokenIndex675
+					if buffer[position] != rune('U') {
+						goto l667
 					}
 					position++
 				}
-			l622:
+			l675:
 				{
-					position624, tokenIndex624 := position, tokenIndex
-					if buffer[position] != rune('l') {
-						goto l625
+					position677, tokenIndex677 := position, tokenIndex
+					if buffer[position] != rune('m') {
+						goto l678
 					}
 					position++
-					goto l624
-				l625:
-					position, tokenIndex = position624, tokenIndex624
-					if buffer[position] != rune('L') {
-						goto l616
+					goto l677
+				l678:
+					position, tokenIndex = position677, tokenIndex677
+					if buffer[position] != rune('M') {
+						goto l667
 					}
 					position++
 				}
-			l624:
-				if !_rules[rulesp]() {
-					goto l616
-				}
-				if !_rules[ruleExpression]() {
-					goto l616
-				}
+			l677:
 				{
-					position626 := position
-					{
-						position627, tokenIndex627 := position, tokenIndex
-						if !_rules[rulesp]() {
-							goto l627
-						}
-						{
-							position629, tokenIndex629 := position, tokenIndex
-							if buffer[position] != rune('o') {
-								goto l630
-							}
-							position++
-							goto l629
-						l630:
-							position, tokenIndex = position629, tokenIndex629
-							if buffer[position] != rune('O') {
-								goto l627
-							}
-							position++
-						}
-					l629:
-						{
-							position631, tokenIndex631 := position, tokenIndex
-							if buffer[position] != rune('n') {
-								goto l632
-							}
-							position++
-							goto l631
-						l632:
-							position, tokenIndex = position631, tokenIndex631
-							if buffer[position] != rune('N') {
-								goto l627
-							}
-							position++
-						}
-					l631:
-						if !_rules[rulesp]() {
-							goto l627
-						}
-						if !_rules[ruleMapExpr]() {
-							goto l627
-						}
-						goto l628
-					l627:
-						position, tokenIndex = position627, tokenIndex627
+					position679, tokenIndex679 := position, tokenIndex
+					if buffer[position] != rune('e') {
+						goto l680
 					}
-				l628:
-					add(rulePegText, position626)
-				}
-				if !_rules[ruleAction23]() {
-					goto l616
+					position++
+					goto l679
+				l680:
+					position, tokenIndex = position679, tokenIndex679
+					if buffer[position] != rune('E') {
+						goto l667
+					}
+					position++
 				}
-				add(ruleEvalStmt, position617)
-			}
-			return true
-		l616:
-			position, tokenIndex = position616, tokenIndex616
-			return false
-		},
-		/* 30 Emitter <- <(sp (ISTREAM / DSTREAM / RSTREAM) EmitterOptions Action24)> */
-		func() bool {
-			position633, tokenIndex633 := position, tokenIndex
-			{
-				position634 := position
+			l679:
 				if !_rules[rulesp]() {
-					goto l633
+					goto l667
 				}
 				{
-					position635, tokenIndex635 := position, tokenIndex
-					if !_rules[ruleISTREAM]() {
-						goto l636
-					}
-					goto l635
-				l636:
-					position, tokenIndex = position635, tokenIndex635
-					if !_rules[ruleDSTREAM]() {
-						goto l637
+					position681, tokenIndex681 := position, tokenIndex
+					if buffer[position] != rune('s') {
+						goto l682
 					}
-					goto l635
-				l637:
-					position, tokenIndex = position635, tokenIndex635
-					if !_rules[ruleRSTREAM]() {
-						goto l633
+					position++
+					goto l681
+				l682:
+					position, tokenIndex = position681, tokenIndex681
+					if buffer[position] != rune('S') {
+						goto l667
 					}
+					position++
 				}
-			l635:
-				if !_rules[ruleEmitterOptions]() {
-					goto l633
-				}
-				if !_rules[ruleAction24]() {
-					goto l633
-				}
-				add(ruleEmitter, position634)
-			}
-			return true
-		l633:
-			position, tokenIndex = position633, tokenIndex633
-			return false
-		},
-		/* 31 EmitterOptions <- <(<(spOpt '[' spOpt EmitterOptionCombinations spOpt ']')?> Action25)> */
-		func() bool {
-			position638, tokenIndex638 := position, tokenIndex
-			{
-				position639 := position
+			l681:
 				{
-					position640 := position
-					{
-						position641, tokenIndex641 := position, tokenIndex
-						if !_rules[rulespOpt]() {
-							goto l641
-						}
-						if buffer[position] != rune('[') {
-							goto l641
-						}
-						position++
-						if !_rules[rulespOpt]() {
-							goto l641
-						}
-						if !_rules[ruleEmitterOptionCombinations]() {
-							goto l641
-						}
-						if !_rules[rulespOpt]() {
-							goto l641
-						}
-						if buffer[position] != rune(']') {
-							goto l641
-						}
-						position++
-						goto l642
-					l641:
-						position, tokenIndex = position641, tokenIndex641
+					position683, tokenIndex683 := position, tokenIndex
+					if buffer[position] != rune('o') {
+						goto l684
+					}
+					position++
+					goto l683
+				l684:
+					position, tokenIndex = position683, tokenIndex683
+					if buffer[position] != rune('O') {
+						goto l667
 					}
-				l642:
-					add(rulePegText, position640)
+					position++
 				}
-				if !_rules[ruleAction25]() {
-					goto l638
+			l683:
+				{
+					position685, tokenIndex685 := position, tokenIndex
+					if buffer[position] != rune('u') {
+						goto l686
+					}
+					position++
+					goto l685
+				l686:
+					position, tokenIndex = position685, tokenIndex685
+					if buffer[position] != rune('U') {
+						goto l667
+					}
+					position++
 				}
-				add(ruleEmitterOptions, position639)
-			}
-			return true
-		l638:
-			position, tokenIndex = position638, tokenIndex638
-			return false
-		},
-		/* 32 EmitterOptionCombinations <- <(EmitterLimit / (EmitterSample sp EmitterLimit) / EmitterSample)> */
-		func() bool {
-			position643, tokenIndex643 := position, tokenIndex
-			{
-				position644 := position
+			l685:
 				{
-					position645, tokenIndex645 := position, tokenIndex
-					if !_rules[ruleEmitterLimit]() {
-						goto l646
+					position687, tokenIndex687 := position, tokenIndex
+					if buffer[position] != rune('r') {
+						goto l688
 					}
-					goto l645
-				l646:
-					position, tokenIndex = position645, tokenIndex645
-					if !_rules[ruleEmitterSample]() {
-						goto l647
+					position++
+					goto l687
+				l688:
+					position, tokenIndex = position687, tokenIndex687
+					if buffer[position] != rune('R') {
+						goto l667
 					}
-					if !_rules[rulesp]() {
-						goto l647
+					position++
+				}
+			l687:
+				{
+					position689, tokenIndex689 := position, tokenIndex
+					if buffer[position] != rune('c') {
+						goto l690
 					}
-					if !_rules[ruleEmitterLimit]() {
-						goto l647
+					position++
+					goto l689
+				l690:
+					position, tokenIndex = position689, tokenIndex689
+					if buffer[position] != rune('C') {
+						goto l667
 					}
-					goto l645
-				l647:
-					position, tokenIndex = position645, tokenIndex645
-					if !_rules[ruleEmitterSample]() {
-						goto l643
+					position++
+				}
+			l689:
+				{
+					position691, tokenIndex691 := position, tokenIndex
+					if buffer[position] != rune('e') {
+						goto l692
+					}
+					position++
+					goto l691
+				l692:
+					position, tokenIndex = position691, tokenIndex691
+					if buffer[position] != rune('E') {
+						goto l667
 					}
+					position++
 				}
-			l645:
-				add(ruleEmitterOptionCombinations, position644)
+			l691:
+				if !_rules[rulesp]() {
+					goto l667
+				}
+				if !_rules[ruleStreamIdentifier]() {
+					goto l667
+				}
+				if !_rules[ruleAction27]() {
+					goto l667
+				}
+				add(ruleResumeSourceStmt, position668)
 			}
 			return true
-		l643:
-			position, tokenIndex = position643, tokenIndex643
+		l667:
+			position, tokenIndex = position667, tokenIndex667
 			return false
 		},
-		/* 33 EmitterLimit <- <(('l' / 'L') ('i' / 'I') ('m' / 'M') ('i' / 'I') ('t' / 'T') sp NumericLiteral Action26)> */
+		/* 33 RewindSourceStmt <- <(('r' / 'R') ('e' / 'E') ('w' / 'W') ('i' / 'I') ('n' / 'N') ('d' / 'D') sp (('s' / 'S') ('o' / 'O') ('u' / 'U') ('r' / 'R') ('c' / 'C') ('e' / 'E')) sp StreamIdentifier Action28)> */
 		func() bool {
-			position648, tokenIndex648 := position, tokenIndex
+			position693, tokenIndex693 := position, tokenIndex
 			{
-				position649 := position
+				position694 := position
 				{
-					position650, tokenIndex650 := position, tokenIndex
-					if buffer[position] != rune('l') {
-						goto l651
+					position695, tokenIndex695 := position, tokenIndex
+					if buffer[position] != rune('r') {
+						goto l696
 					}
 					position++
-					goto l650
-				l651:
-					position, tokenIndex = position650, tokenIndex650
-					if buffer[position] != rune('L') {
-						goto l648
+					goto l695
+				l696:
+					position, tokenIndex = position695, tokenIndex695
+					if buffer[position] != rune('R') {
+						goto l693
 					}
 					position++
 				}
-			l650:
+			l695:
 				{
-					position652, tokenIndex652 := position, tokenIndex
-					if buffer[position] != rune('i') {
-						goto l653
+					position697, tokenIndex697 := position, tokenIndex
+					if buffer[position] != rune('e') {
+						goto l698
 					}
 					position++
-					goto l652
-				l653:
-					position, tokenIndex = position652, tokenIndex652
-					if buffer[position] != rune('I') {
-						goto l648
+					goto l697
+				l698:
+					position, tokenIndex = position697, tokenIndex697
+					if buffer[position] != rune('E') {
+						goto l693
 					}
 					position++
 				}
-			l652:
+			l697:
 				{
-					position654, tokenIndex654 := position, tokenIndex
-					if buffer[position] != rune('m') {
-						goto l655
+					position699, tokenIndex699 := position, tokenIndex
+					if buffer[position] != rune('w') {
+						goto l700
 					}
 					position++
-					goto l654
-				l655:
-					position, tokenIndex = position654, tokenIndex654
-					if buffer[position] != rune('M') {
-						goto l648
+					goto l699
+				l700:
+					position, tokenIndex = position699, tokenIndex699
+					if buffer[position] != rune('W') {
+						goto l693
 					}
 					position++
 				}
-			l654:
+			l699:
 				{
-					position656, tokenIndex656 := position, tokenIndex
+					position701, tokenIndex701 := position, tokenIndex
 					if buffer[position] != rune('i') {
-						goto l657
+						goto l702
 					}
 					position++
-					goto l656
-				l657:
-					position, tokenIndex = position656, tokenIndex656
+					goto l701
+				l702:
+					position, tokenIndex = position701, tokenIndex701
 					if buffer[position] != rune('I') {
-						goto l648
+						goto l693
 					}
 					position++
 				}
-			l656:
+			l701:
 				{
-					position658, tokenIndex658 := position, tokenIndex
-					if buffer[position] != rune('t') {
-						goto l659
+					position703, tokenIndex703 := position, tokenIndex
+					if buffer[position] != rune('n') {
+						goto l704
 					}
 					position++
-					goto l658
-				l659:
-					position, tokenIndex = position658, tokenIndex658
-					if buffer[position] != rune('T') {
-						goto l648
+					goto l703
+				l704:
+					position, tokenIndex = position703, tokenIndex703
+					if buffer[position] != rune('N') {
+						goto l693
 					}
 					position++
 				}
-			l658:
-				if !_rules[rulesp]() {
-					goto l648
-				}
-				if !_rules[ruleNumericLiteral]() {
-					goto l648
-				}
-				if !_rules[ruleAction26]() {
-					goto l648
-				}
-				add(ruleEmitterLimit, position649)
-			}
-			return true
-		l648:
-			position, tokenIndex = position648, tokenIndex648
-			return false
-		},
-		/* 34 EmitterSample <- <(CountBasedSampling / RandomizedSampling / TimeBasedSampling)> */
-		func() bool {
-			position660, tokenIndex660 := position, tokenIndex
-			{
-				position661 := position
+			l703:
 				{
-					position662, tokenIndex662 := position, tokenIndex
-					if !_rules[ruleCountBasedSampling]() {
-						goto l663
-					}
-					goto l662
-				l663:
-					position, tokenIndex = position662, tokenIndex662
-					if !_rules[ruleRandomizedSampling]() {
-						goto l664
+					position705, tokenIndex705 := position, tokenIndex
+					if buffer[position] != rune('d') {
+						goto l706
 					}
-					goto l662
-				l664:
-					position, tokenIndex = position662, tokenIndex662
-					if !_rules[ruleTimeBasedSampling]() {
-						goto l660
+					position++
+					goto l705
+				l706:
+					position, tokenIndex = position705, tokenIndex705
+					if buffer[position] != rune('D') {
+						goto l693
 					}
+					position++
+				}
+			l705:
+				if !_rules[rulesp]() {
+					goto l693
 				}
-			l662:
-				add(ruleEmitterSample, position661)
-			}
-			return true
-		l660:
-			position, tokenIndex = position660, tokenIndex660
-			return false
-		},
-		/* 35 CountBasedSampling <- <(('e' / 'E') ('v' / 'V') ('e' / 'E') ('r' / 'R') ('y' / 'Y') sp NumericLiteral spOpt '-'? spOpt ((('s' / 'S') ('t' / 'T')) / (('n' / 'N') ('d' / 'D')) / (('r' / 'R') ('d' / 'D')) / (('t' / 'T') ('h' / 'H'))) sp (('t' / 'T') ('u' / 'U') ('p' / 'P') ('l' / 'L') ('e' / 'E')) Action27)> */
-		func() bool {
-			position665, tokenIndex665 := position, tokenIndex
-			{
-				position666 := position
 				{
-					position667, tokenIndex667 := position, tokenIndex
-					if buffer[position] != rune('e') {
-						goto l668
+					position707, tokenIndex707 := position, tokenIndex
+					if buffer[position] != rune('s') {
+						goto l708
 					}
 					position++
-					goto l667
-				l668:
-					position, tokenIndex = position667, tokenIndex667
-					if buffer[position] != rune('E') {
-						goto l665
+					goto l707
+				l708:
+					position, tokenIndex = position707, tokenIndex707
+					if buffer[position] != rune('S') {
+						goto l693
 					}
 					position++
 				}
-			l667:
+			l707:
 				{
-					position669, tokenIndex669 := position, tokenIndex
-					if buffer[position] != rune('v') {
-						goto l670
+					position709, tokenIndex709 := position, tokenIndex
+					if buffer[position] != rune('o') {
+						goto l710
 					}
 					position++
-					goto l669
-				l670:
-					position, tokenIndex = position669, tokenIndex669
-					if buffer[position] != rune('V') {
-						goto l665
+					goto l709
+				l710:
+					position, tokenIndex = position709, tokenIndex709
+					if buffer[position] != rune('O') {
+						goto l693
 					}
 					position++
 				}
-			l669:
+			l709:
 				{
-					position671, tokenIndex671 := position, tokenIndex
-					if buffer[position] != rune('e') {
-						goto l672
+					position711, tokenIndex711 := position, tokenIndex
+					if buffer[position] != rune('u') {
+						goto l712
 					}
 					position++
-					goto l671
-				l672:
-					position, tokenIndex = position671, tokenIndex671
-					if buffer[position] != rune('E') {
-						goto l665
+					goto l711
+				l712:
+					position, tokenIndex = position711, tokenIndex711
+					if buffer[position] != rune('U') {
+						goto l693
 					}
 					position++
 				}
-			l671:
+			l711:
 				{
-					position673, tokenIndex673 := position, tokenIndex
+					position713, tokenIndex713 := position, tokenIndex
 					if buffer[position] != rune('r') {
-						goto l674
+						goto l714
 					}
 					position++
-					goto l673
-				l674:
-					position, tokenIndex = position673, tokenIndex673
+					goto l713
+				l714:
+					position, tokenIndex = position713, tokenIndex713
 					if buffer[position] != rune('R') {
-						goto l665
+						goto l693
 					}
 					position++
 				}
-			l673:
+			l713:
 				{
-					position675, tokenIndex675 := position, tokenIndex
-					if buffer[position] != rune('y') {
-						goto l676
+					position715, tokenIndex715 := position, tokenIndex
+					if buffer[position] != rune('c') {
+						goto l716
 					}
 					position++
-					goto l675
-				l676:
-					position, tokenIndex = position675, tokenIndex675
-					if buffer[position] != rune('Y') {
-						goto l665
+					goto l715
+				l716:
+					position, tokenIndex = position715, tokenIndex715
+					if buffer[position] != rune('C') {
+						goto l693
 					}
 					position++
 				}
-			l675:
-				if !_rules[rulesp]() {
-					goto l665
-				}
-				if !_rules[ruleNumericLiteral]() {
-					goto l665
-				}
-				if !_rules[rulespOpt]() {
-					goto l665
-				}
+			l715:
 				{
-					position677, tokenIndex677 := position, tokenIndex
-					if buffer[position] != rune('-') {
-						goto l677
+					position717, tokenIndex717 := position, tokenIndex
+					if buffer[position] != rune('e') {
+						goto l718
 					}
 					position++
-					goto l678
-				l677:
-					position, tokenIndex = position677, tokenIndex677
-				}
-			l678:
-				if !_rules[rulespOpt]() {
-					goto l665
-				}
-				{
-					position679, tokenIndex679 := position, tokenIndex
-					{
-						position681, tokenIndex681 := position, tokenIndex
-						if buffer[position] != rune('s') {
-							goto l682
-						}
-						position++
-						goto l681
-					l682:
-						position, tokenIndex = position681, tokenIndex681
-						if buffer[position] != rune('S') {
-							goto l680
-						}
-						position++
-					}
-				l681:
-					{
-						position683, tokenIndex683 := position, tokenIndex
-						if buffer[position] != rune('t') {
-							goto l684
-						}
-						position++
-						goto l683
-					l684:
-						position, tokenIndex = position683, tokenIndex683
-						if buffer[position] != rune('T') {
-							goto l680
-						}
-						position++
-					}
-				l683:
-					goto l679
-				l680:
-					position, tokenIndex = position679, tokenIndex679
-					{
-						position686, tokenIndex686 := position, tokenIndex
-						if buffer[position] != rune('n') {
-							goto l687
-						}
-						position++
-						goto l686
-					l687:
-						position, tokenIndex = position686, tokenIndex686
-						if buffer[position] != rune('N') {
-							goto l685
-						}
-						position++
-					}
-				l686:
-					{
-						position688, tokenIndex688 := position, tokenIndex
-						if buffer[position] != rune('d') {
-							goto l689
-						}
-						position++
-						goto l688
-					l689:
-						position, tokenIndex = position688, tokenIndex688
-						if buffer[position] != rune('D') {
-							goto l685
-						}
-						position++
-					}
-				l688:
-					goto l679
-				l685:
-					position, tokenIndex = position679, tokenIndex679
-					{
-						position691, tokenIndex691 := position, tokenIndex
-						if buffer[position] != rune('r') {
-							goto l692
-						}
-						position++
-						goto l691
-					l692:
-						position, tokenIndex = position691, tokenIndex691
-						if buffer[position] != rune('R') {
-							goto l690
-						}
-						position++
-					}
-				l691:
-					{
-						position693, tokenIndex693 := position, tokenIndex
-						if buffer[position] != rune('d') {
-							goto l694
-						}
-						position++
+					goto l717
+				l718:
+					position, tokenIndex = position717, tokenIndex717
+					if buffer[position] != rune('E') {
 						goto l693
-					l694:
-						position, tokenIndex = position693, tokenIndex693
-						if buffer[position] != rune('D') {
-							goto l690
-						}
-						position++
-					}
-				l693:
-					goto l679
-				l690:
-					position, tokenIndex = position679, tokenIndex679
-					{
-						position695, tokenIndex695 := position, tokenIndex
-						if buffer[position] != rune('t') {
-							goto l696
-						}
-						position++
-						goto l695
-					l696:
-						position, tokenIndex = position695, tokenIndex695
-						if buffer[position] != rune('T') {
-							goto l665
-						}
-						position++
-					}
-				l695:
-					{
-						position697, tokenIndex697 := position, tokenIndex
-						if buffer[position] != rune('h') {
-							goto l698
-						}
-						position++
-						goto l697
-					l698:
-						position, tokenIndex = position697, tokenIndex697
-						if buffer[position] != rune('H') {
-							goto l665
-						}
-						position++
 					}
-				l697:
+					position++
 				}
-			l679:
+			l717:
 				if !_rules[rulesp]() {
-					goto l665
+					goto l693
 				}
-				{
-					position699, tokenIndex699 := position, tokenIndex
-					if buffer[position] != rune('t') {
-						goto l700
-					}
-					position++
-					goto l699
-				l700:
-					position, tokenIndex = position699, tokenIndex699
-					if buffer[position] != rune('T') {
-						goto l665
-					}
-					position++
+				if !_rules[ruleStreamIdentifier]() {
+					goto l693
 				}
-			l699:
+				if !_rules[ruleAction28]() {
+					goto l693
+				}
+				add(ruleRewindSourceStmt, position694)
+			}
+			return true
+		l693:
+			position, tokenIndex = position693, tokenIndex693
+			return false
+		},
+		/* 34 DropSourceStmt <- <(('d' / 'D') ('r' / 'R') ('o' / 'O') ('p' / 'P') sp (('s' / 'S') ('o' / 'O') ('u' / 'U') ('r' / 'R') ('c' / 'C') ('e' / 'E')) sp StreamIdentifier Action29)> */
+		func() bool {
+			position719, tokenIndex719 := position, tokenIndex
+			{
+				position720 := position
 				{
-					position701, tokenIndex701 := position, tokenIndex
-					if buffer[position] != rune('u') {
-						goto l702
+					position721, tokenIndex721 := position, tokenIndex
+					if buffer[position] != rune('d') {
+						goto l722
 					}
 					position++
-					goto l701
-				l702:
-					position, tokenIndex = position701, tokenIndex701
-					if buffer[position] != rune('U') {
-						goto l665
+					goto l721
+				l722:
+					position, tokenIndex = position721, tokenIndex721
+					if buffer[position] != rune('D') {
+						goto l719
 					}
 					position++
 				}
-			l701:
+			l721:
 				{
-					position703, tokenIndex703 := position, tokenIndex
-					if buffer[position] != rune('p') {
-						goto l704
+					position723, tokenIndex723 := position, tokenIndex
+					if buffer[position] != rune('r') {
+						goto l724
 					}
 					position++
-					goto l703
-				l704:
-					position, tokenIndex = position703, tokenIndex703
-					if buffer[position] != rune('P') {
-						goto l665
+					goto l723
+				l724:
+					position, tokenIndex = position723, tokenIndex723
+					if buffer[position] != rune('R') {
+						goto l719
 					}
 					position++
 				}
-			l703:
+			l723:
 				{
-					position705, tokenIndex705 := position, tokenIndex
-					if buffer[position] != rune('l') {
-						goto l706
+					position725, tokenIndex725 := position, tokenIndex
+					if buffer[position] != rune('o') {
+						goto l726
 					}
 					position++
-					goto l705
-				l706:
-					position, tokenIndex = position705, tokenIndex705
-					if buffer[position] != rune('L') {
-						goto l665
+					goto l725
+				l726:
+					position, tokenIndex = position725, tokenIndex725
+					if buffer[position] != rune('O') {
+						goto l719
 					}
 					position++
 				}
-			l705:
+			l725:
 				{
-					position707, tokenIndex707 := position, tokenIndex
-					if buffer[position] != rune('e') {
-						goto l708
+					position727, tokenIndex727 := position, tokenIndex
+					if buffer[position] != rune('p') {
+						goto l728
 					}
 					position++
-					goto l707
-				l708:
-					position, tokenIndex = position707, tokenIndex707
-					if buffer[position] != rune('E') {
-						goto l665
+					goto l727
+				l728:
+					position, tokenIndex = position727, tokenIndex727
+					if buffer[position] != rune('P') {
+						goto l719
 					}
 					position++
 				}
-			l707:
-				if !_rules[ruleAction27]() {
-					goto l665
+			l727:
+				if !_rules[rulesp]() {
+					goto l719
 				}
-				add(ruleCountBasedSampling, position666)
-			}
-			return true
-		l665:
-			position, tokenIndex = position665, tokenIndex665
-			return false
-		},
-		/* 36 RandomizedSampling <- <(('s' / 'S') ('a' / 'A') ('m' / 'M') ('p' / 'P') ('l' / 'L') ('e' / 'E') sp (FloatLiteral / NumericLiteral) spOpt '%' Action28)> */
-		func() bool {
-			position709, tokenIndex709 := position, tokenIndex
-			{
-				position710 := position
 				{
-					position711, tokenIndex711 := position, tokenIndex
+					position729, tokenIndex729 := position, tokenIndex
 					if buffer[position] != rune('s') {
-						goto l712
+						goto l730
 					}
 					position++
-					goto l711
-				l712:
-					position, tokenIndex = position711, tokenIndex711
+					goto l729
+				l730:
+					position, tokenIndex = position729, tokenIndex729
 					if buffer[position] != rune('S') {
-						goto l709
+						goto l719
 					}
 					position++
 				}
-			l711:
+			l729:
 				{
-					position713, tokenIndex713 := position, tokenIndex
-					if buffer[position] != rune('a') {
-						goto l714
+					position731, tokenIndex731 := position, tokenIndex
+					if buffer[position] != rune('o') {
+						goto l732
 					}
 					position++
-					goto l713
-				l714:
-					position, tokenIndex = position713, tokenIndex713
-					if buffer[position] != rune('A') {
-						goto l709
+					goto l731
+				l732:
+					position, tokenIndex = position731, tokenIndex731
+					if buffer[position] != rune('O') {
+						goto l719
 					}
 					position++
 				}
-			l713:
+			l731:
 				{
-					position715, tokenIndex715 := position, tokenIndex
-					if buffer[position] != rune('m') {
-						goto l716
+					position733, tokenIndex733 := position, tokenIndex
+					if buffer[position] != rune('u') {
+						goto l734
 					}
 					position++
-					goto l715
-				l716:
-					position, tokenIndex = position715, tokenIndex715
-					if buffer[position] != rune('M') {
-						goto l709
+					goto l733
+				l734:
+					position, tokenIndex = position733, tokenIndex733
+					if buffer[position] != rune('U') {
+						goto l719
 					}
 					position++
 				}
-			l715:
+			l733:
 				{
-					position717, tokenIndex717 := position, tokenIndex
-					if buffer[position] != rune('p') {
-						goto l718
+					position735, tokenIndex735 := position, tokenIndex
+					if buffer[position] != rune('r') {
+						goto l736
 					}
 					position++
-					goto l717
-				l718:
-					position, tokenIndex = position717, tokenIndex717
-					if buffer[position] != rune('P') {
-						goto l709
+					goto l735
+				l736:
+					position, tokenIndex = position735, tokenIndex735
+					if buffer[position] != rune('R') {
+						goto l719
 					}
 					position++
 				}
-			l717:
+			l735:
 				{
-					position719, tokenIndex719 := position, tokenIndex
-					if buffer[position] != rune('l') {
-						goto l720
+					position737, tokenIndex737 := position, tokenIndex
+					if buffer[position] != rune('c') {
+						goto l738
 					}
 					position++
-					goto l719
-				l720:
-					position, tokenIndex = position719, tokenIndex719
-					if buffer[position] != rune('L') {
-						goto l709
+					goto l737
+				l738:
+					position, tokenIndex = position737, tokenIndex737
+					if buffer[position] != rune('C') {
+						goto l719
 					}
 					position++
 				}
-			l719:
+			l737:
 				{
-					position721, tokenIndex721 := position, tokenIndex
+					position739, tokenIndex739 := position, tokenIndex
 					if buffer[position] != rune('e') {
-						goto l722
+						goto l740
 					}
 					position++
-					goto l721
-				l722:
-					position, tokenIndex = position721, tokenIndex721
+					goto l739
+				l740:
+					position, tokenIndex = position739, tokenIndex739
 					if buffer[position] != rune('E') {
-						goto l709
+						goto l719
 					}
 					position++
 				}
-			l721:
+			l739:
 				if !_rules[rulesp]() {
-					goto l709
+					goto l719
 				}
-				{
-					position723, tokenIndex723 := position, tokenIndex
-					if !_rules[ruleFloatLiteral]() {
-						goto l724
-					}
-					goto l723
-				l724:
-					position, tokenIndex = position723, tokenIndex723
-					if !_rules[ruleNumericLiteral]() {
-						goto l709
-					}
-				}
-			l723:
-				if !_rules[rulespOpt]() {
-					goto l709
-				}
-				if buffer[position] != rune('%') {
-					goto l709
-				}
-				position++
-				if !_rules[ruleAction28]() {
-					goto l709
+				if !_rules[ruleStreamIdentifier]() {
+					goto l719
 				}
-				add(ruleRandomizedSampling, position710)
-			}
-			return true
-		l709:
-			position, tokenIndex = position709, tokenIndex709
-			return false
-		},
-		/* 37 TimeBasedSampling <- <(TimeBasedSamplingSeconds / TimeBasedSamplingMilliseconds)> */
-		func() bool {
-			position725, tokenIndex725 := position, tokenIndex
-			{
-				position726 := position
-				{
-					position727, tokenIndex727 := position, tokenIndex
-					if !_rules[ruleTimeBasedSamplingSeconds]() {
-						goto l728
-					}
-					goto l727
-				l728:
-					position, tokenIndex = position727, tokenIndex727
-					if !_rules[ruleTimeBasedSamplingMilliseconds]() {
-						goto l725
-					}
+				if !_rules[ruleAction29]() {
+					goto l719
 				}
-			l727:
-				add(ruleTimeBasedSampling, position726)
+				add(ruleDropSourceStmt, position720)
 			}
 			return true
-		l725:
-			position, tokenIndex = position725, tokenIndex725
+		l719:
+			position, tokenIndex = position719, tokenIndex719
 			return false
 		},
-		/* 38 TimeBasedSamplingSeconds <- <(('e' / 'E') ('v' / 'V') ('e' / 'E') ('r' / 'R') ('y' / 'Y') sp (FloatLiteral / NumericLiteral) sp (('s' / 'S') ('e' / 'E') ('c' / 'C') ('o' / 'O') ('n' / 'N') ('d' / 'D') ('s' / 'S')) Action29)> */
+		/* 35 DropStreamStmt <- <(('d' / 'D') ('r' / 'R') ('o' / 'O') ('p' / 'P') sp (('s' / 'S') ('t' / 'T') ('r' / 'R') ('e' / 'E') ('a' / 'A') ('m' / 'M')) sp StreamIdentifier Action30)> */
 		func() bool {
-			position729, tokenIndex729 := position, tokenIndex
+			position741, tokenIndex741 := position, tokenIndex
 			{
-				position730 := position
-				{
-					position731, tokenIndex731 := position, tokenIndex
-					if buffer[position] != rune('e') {
-						goto l732
-					}
-					position++
-					goto l731
-				l732:
-					position, tokenIndex = position731, tokenIndex731
-					if buffer[position] != rune('E') {
-						goto l729
-					}
-					position++
-				}
-			l731:
-				{
-					position733, tokenIndex733 := position, tokenIndex
-					if buffer[position] != rune('v') {
-						goto l734
-					}
-					position++
-					goto l733
-				l734:
-					position, tokenIndex = position733, tokenIndex733
-					if buffer[position] != rune('V') {
-						goto l729
-					}
-					position++
-				}
-			l733:
-				{
-					position735, tokenIndex735 := position, tokenIndex
-					if buffer[position] != rune('e') {
-						goto l736
-					}
-					position++
-					goto l735
-				l736:
-					position, tokenIndex = position735, tokenIndex735
-					if buffer[position] != rune('E') {
-						goto l729
-					}
-					position++
-				}
-			l735:
-				{
-					position737, tokenIndex737 := position, tokenIndex
-					if buffer[position] != rune('r') {
-						goto l738
-					}
-					position++
-					goto l737
-				l738:
-					position, tokenIndex = position737, tokenIndex737
-					if buffer[position] != rune('R') {
-						goto l729
-					}
-					position++
-				}
-			l737:
-				{
-					position739, tokenIndex739 := position, tokenIndex
-					if buffer[position] != rune('y') {
-						goto l740
-					}
-					position++
-					goto l739
-				l740:
-					position, tokenIndex = position739, tokenIndex739
-					if buffer[position] != rune('Y') {
-						goto l729
-					}
-					position++
-				}
-			l739:
-				if !_rules[rulesp]() {
-					goto l729
-				}
-				{
-					position741, tokenIndex741 := position, tokenIndex
-					if !_rules[ruleFloatLiteral]() {
-						goto l742
-					}
-					goto l741
-				l742:
-					position, tokenIndex = position741, tokenIndex741
-					if !_rules[ruleNumericLiteral]() {
-						goto l729
-					}
-				}
-			l741:
-				if !_rules[rulesp]() {
-					goto l729
-				}
+				position742 := position
 				{
 					position743, tokenIndex743 := position, tokenIndex
-					if buffer[position] != rune('s') {
+					if buffer[position] != rune('d') {
 						goto l744
 					}
 					position++
 					goto l743
 				l744:
 					position, tokenIndex = position743, tokenIndex743
-					if buffer[position] != rune('S') {
-						goto l729
+					if buffer[position] != rune('D') {
+						goto l741
 					}
 					position++
 				}
 			l743:
 				{
 					position745, tokenIndex745 := position, tokenIndex
-					if buffer[position] != rune('e') {
+					if buffer[position] != rune('r') {
 						goto l746
 					}
 					position++
 					goto l745
 				l746:
 					position, tokenIndex = position745, tokenIndex745
-					if buffer[position] != rune('E') {
-						goto l729
+					if buffer[position] != rune('R') {
+						goto l741
 					}
 					position++
 				}
 			l745:
 				{
 					position747, tokenIndex747 := position, tokenIndex
-					if buffer[position] != rune('c') {
+					if buffer[position] != rune('o') {
 						goto l748
 					}
 					position++
 					goto l747
 				l748:
 					position, tokenIndex = position747, tokenIndex747
-					if buffer[position] != rune('C') {
-						goto l729
+					if buffer[position] != rune('O') {
+						goto l741
 					}
 					position++
 				}
 			l747:
 				{
 					position749, tokenIndex749 := position, tokenIndex
-					if buffer[position] != rune('o') {
+					if buffer[position] != rune('p') {
 						goto l750
 					}
 					position++
 					goto l749
 				l750:
 					position, tokenIndex = position749, tokenIndex749
-					if buffer[position] != rune('O') {
-						goto l729
+					if buffer[position] != rune('P') {
+						goto l741
 					}
 					position++
 				}
 			l749:
+				if !_rules[rulesp]() {
+					goto l741
+				}
 				{
 					position751, tokenIndex751 := position, tokenIndex
-					if buffer[position] != rune('n') {
+					if buffer[position] != rune('s') {
 						goto l752
 					}
 					position++
 					goto l751
 				l752:
 					position, tokenIndex = position751, tokenIndex751
-					if buffer[position] != rune('N') {
-						goto l729
+					if buffer[position] != rune('S') {
+						goto l741
 					}
 					position++
 				}
 			l751:
 				{
 					position753, tokenIndex753 := position, tokenIndex
-					if buffer[position] != rune('d') {
+					if buffer[position] != rune('t') {
 						goto l754
 					}
 					position++
 					goto l753
 				l754:
 					position, tokenIndex = position753, tokenIndex753
-					if buffer[position] != rune('D') {
-						goto l729
+					if buffer[position] != rune('T') {
+						goto l741
 					}
 					position++
 				}
 			l753:
 				{
 					position755, tokenIndex755 := position, tokenIndex
-					if buffer[position] != rune('s') {
+					if buffer[position] != rune('r') {
 						goto l756
 					}
 					position++
 					goto l755
 				l756:
 					position, tokenIndex = position755, tokenIndex755
-					if buffer[position] != rune('S') {
-						goto l729
+					if buffer[position] != rune('R') {
+						goto l741
 					}
 					position++
 				}
 			l755:
-				if !_rules[ruleAction29]() {
-					goto l729
+				{
+					position757, tokenIndex757 := position, tokenIndex
+					if buffer[position] != rune('e') {
+						goto l758
+					}
+					position++
+					goto l757
+				l758:
+					position, tokenIndex = position757, tokenIndex757
+					if buffer[position] != rune('E') {
+						goto l741
+					}
+					position++
 				}
-				add(ruleTimeBasedSamplingSeconds, position730)
-			}
-			return true
-		l729:
-			position, tokenIndex = position729, tokenIndex729
-			return false
-		},
-		/* 39 TimeBasedSamplingMilliseconds <- <(('e' / 'E') ('v' / 'V') ('e' / 'E') ('r' / 'R') ('y' / 'Y') sp (FloatLiteral / NumericLiteral) sp (('m' / 'M') ('i' / 'I') ('l' / 'L') ('l' / 'L') ('i' / 'I') ('s' / 'S') ('e' / 'E') ('c' / 'C') ('o' / 'O') ('n' / 'N') ('d' / 'D') ('s' / 'S')) Action30)> */
-		func() bool {
-			position757, tokenIndex757 := position, tokenIndex
-			{
-				position758 := position
+			l757:
 				{
 					position759, tokenIndex759 := position, tokenIndex
-					if buffer[position] != rune('e') {
+					if buffer[position] != rune('a') {
 						goto l760
 					}
 					position++
 					goto l759
 				l760:
 					position, tokenIndex = position759, tokenIndex759
-					if buffer[position] != rune('E') {
-						goto l757
+					if buffer[position] != rune('A') {
+						goto l741
 					}
 					position++
 				}
 			l759:
 				{
 					position761, tokenIndex761 := position, tokenIndex
-					if buffer[position] != rune('v') {
+					if buffer[position] != rune('m') {
 						goto l762
 					}
 					position++
 					goto l761
 				l762:
 					position, tokenIndex = position761, tokenIndex761
-					if buffer[position] != rune('V') {
-						goto l757
+					if buffer[position] != rune('M') {
+						goto l741
 					}
 					position++
 				}
 			l761:
-				{
-					position763, tokenIndex763 := position, tokenIndex
-					if buffer[position] != rune('e') {
-						goto l764
-					}
-					position++
-					goto l763
-				l764:
-					position, tokenIndex = position763, tokenIndex763
-					if buffer[position] != rune('E') {
-						goto l757
-					}
-					position++
+				if !_rules[rulesp]() {
+					goto l741
+				}
+				if !_rules[ruleStreamIdentifier]() {
+					goto l741
+				}
+				if !_rules[ruleAction30]() {
+					goto l741
 				}
-			l763:
+				add(ruleDropStreamStmt, position742)
+			}
+			return true
+		l741:
+			position, tokenIndex = position741, tokenIndex741
+			return false
+		},
+		/* 36 DropSinkStmt <- <(('d' / 'D') ('r' / 'R') ('o' / 'O') ('p' / 'P') sp (('s' / 'S') ('i' / 'I') ('n' / 'N') ('k' / 'K')) sp StreamIdentifier Action31)> */
+		func() bool {
+			position763, tokenIndex763 := position, tokenIndex
+			{
+				position764 := position
 				{
 					position765, tokenIndex765 := position, tokenIndex
-					if buffer[position] != rune('r') {
+					if buffer[position] != rune('d') {
 						goto l766
 					}
 					position++
 					goto l765
 				l766:
 					position, tokenIndex = position765, tokenIndex765
-					if buffer[position] != rune('R') {
-						goto l757
+					if buffer[position] != rune('D') {
+						goto l763
 					}
 					position++
 				}
 			l765:
 				{
 					position767, tokenIndex767 := position, tokenIndex
-					if buffer[position] != rune('y') {
+					if buffer[position] != rune('r') {
 						goto l768
 					}
 					position++
 					goto l767
 				l768:
 					position, tokenIndex = position767, tokenIndex767
-					if buffer[position] != rune('Y') {
-						goto l757
+					if buffer[position] != rune('R') {
+						goto l763
 					}
 					position++
 				}
 			l767:
-				if !_rules[rulesp]() {
-					goto l757
-				}
 				{
 					position769, tokenIndex769 := position, tokenIndex
-					if !_rules[ruleFloatLiteral]() {
+					if buffer[position] != rune('o') {
 						goto l770
 					}
+					position++
 					goto l769
 				l770:
 					position, tokenIndex = position769, tokenIndex769
-					if !_rules[ruleNumericLiteral]() {
-						goto l757
+					if buffer[position] != rune('O') {
+						goto l763
 					}
+					position++
 				}
 			l769:
-				if !_rules[rulesp]() {
-					goto l757
-				}
 				{
 					position771, tokenIndex771 := position, tokenIndex
-					if buffer[position] != rune('m') {
+					if buffer[position] != rune('p') {
 						goto l772
 					}
 					position++
 					goto l771
 				l772:
 					position, tokenIndex = position771, tokenIndex771
-					if buffer[position] != rune('M') {
-						goto l757
+					if buffer[position] != rune('P') {
+						goto l763
 					}
 					position++
 				}
 			l771:
+				if !_rules[rulesp]() {
+					goto l763
+				}
 				{
 					position773, tokenIndex773 := position, tokenIndex
-					if buffer[position] != rune('i') {
+					if buffer[position] != rune('s') {
 						goto l774
 					}
 					position++
 					goto l773
 				l774:
 					position, tokenIndex = position773, tokenIndex773
-					if buffer[position] != rune('I') {
-						goto l757
+					if buffer[position] != rune('S') {
+						goto l763
 					}
 					position++
 				}
 			l773:
 				{
 					position775, tokenIndex775 := position, tokenIndex
-					if buffer[position] != rune('l') {
+					if buffer[position] != rune('i') {
 						goto l776
 					}
 					position++
 					goto l775
 				l776:
 					position, tokenIndex = position775, tokenIndex775
-					if buffer[position] != rune('L') {
-						goto l757
+					if buffer[position] != rune('I') {
+						goto l763
 					}
 					position++
 				}
 			l775:
 				{
 					position777, tokenIndex777 := position, tokenIndex
-					if buffer[position] != rune('l') {
+					if buffer[position] != rune('n') {
 						goto l778
 					}
 					position++
 					goto l777
 				l778:
 					position, tokenIndex = position777, tokenIndex777
-					if buffer[position] != rune('L') {
-						goto l757
+					if buffer[position] != rune('N') {
+						goto l763
 					}
 					position++
 				}
 			l777:
 				{
 					position779, tokenIndex779 := position, tokenIndex
-					if buffer[position] != rune('i') {
+					if buffer[position] != rune('k') {
 						goto l780
 					}
 					position++
 					goto l779
 				l780:
 					position, tokenIndex = position779, tokenIndex779
-					if buffer[position] != rune('I') {
-						goto l757
+					if buffer[position] != rune('K') {
+						goto l763
 					}
 					position++
 				}
 			l779:
-				{
-					position781, tokenIndex781 := position, tokenIndex
-					if buffer[position] != rune('s') {
-						goto l782
-					}
-					position++
-					goto l781
-				l782:
-					position, tokenIndex = position781, tokenIndex781
-					if buffer[position] != rune('S') {
-						goto l757
-					}
-					position++
+				if !_rules[rulesp]() {
+					goto l763
 				}
-			l781:
-				{
+				if !_rules[ruleStreamIdentifier]() {
+					goto l763
+				}
+				if !_rules[ruleAction31]() {
+					goto l763
+				}
+				add(ruleDropSinkStmt, position764)
+			}
+			return true
+		l763:
+			position, tokenIndex = position763, tokenIndex763
+			return false
+		},
+		/* 37 DropStateStmt <- <(('d' / 'D') ('r' / 'R') ('o' / 'O') ('p' / 'P') sp (('s' / 'S') ('t' / 'T') ('a' / 'A') ('t' / 'T') ('e' / 'E')) sp StreamIdentifier Action32)> */
+		func() bool {
+			position781, tokenIndex781 := position, tokenIndex
+			{
+				position782 := position
+				{
 					position783, tokenIndex783 := position, tokenIndex
-					if buffer[position] != rune('e') {
+					if buffer[position] != rune('d') {
 						goto l784
 					}
 					position++
 					goto l783
 				l784:
 					position, tokenIndex = position783, tokenIndex783
-					if buffer[position] != rune('E') {
-						goto l757
+					if buffer[position] != rune('D') {
+						goto l781
 					}
 					position++
 				}
 			l783:
 				{
 					position785, tokenIndex785 := position, tokenIndex
-					if buffer[position] != rune('c') {
+					if buffer[position] != rune('r') {
 						goto l786
 					}
 					position++
 					goto l785
 				l786:
 					position, tokenIndex = position785, tokenIndex785
-					if buffer[position] != rune('C') {
-						goto l757
+					if buffer[position] != rune('R') {
+						goto l781
 					}
 					position++
 				}
@@ -7699,10449 +8084,17930 @@ func (p *bqlPegBackend) Init() {
 				l788:
 					position, tokenIndex = position787, tokenIndex787
 					if buffer[position] != rune('O') {
-						goto l757
+						goto l781
 					}
 					position++
 				}
 			l787:
 				{
 					position789, tokenIndex789 := position, tokenIndex
-					if buffer[position] != rune('n') {
+					if buffer[position] != rune('p') {
 						goto l790
 					}
 					position++
 					goto l789
 				l790:
 					position, tokenIndex = position789, tokenIndex789
-					if buffer[position] != rune('N') {
-						goto l757
+					if buffer[position] != rune('P') {
+						goto l781
 					}
 					position++
 				}
 			l789:
+				if !_rules[rulesp]() {
+					goto l781
+				}
 				{
 					position791, tokenIndex791 := position, tokenIndex
-					if buffer[position] != rune('d') {
+					if buffer[position] != rune('s') {
 						goto l792
 					}
 					position++
 					goto l791
 				l792:
 					position, tokenIndex = position791, tokenIndex791
-					if buffer[position] != rune('D') {
-						goto l757
+					if buffer[position] != rune('S') {
+						goto l781
 					}
 					position++
 				}
 			l791:
 				{
 					position793, tokenIndex793 := position, tokenIndex
-					if buffer[position] != rune('s') {
+					if buffer[position] != rune('t') {
 						goto l794
 					}
 					position++
 					goto l793
 				l794:
 					position, tokenIndex = position793, tokenIndex793
-					if buffer[position] != rune('S') {
-						goto l757
+					if buffer[position] != rune('T') {
+						goto l781
 					}
 					position++
 				}
 			l793:
-				if !_rules[ruleAction30]() {
-					goto l757
-				}
-				add(ruleTimeBasedSamplingMilliseconds, position758)
-			}
-			return true
-		l757:
-			position, tokenIndex = position757, tokenIndex757
-			return false
-		},
-		/* 40 Projections <- <(<(sp Projection (spOpt ',' spOpt Projection)*)> Action31)> */
-		func() bool {
-			position795, tokenIndex795 := position, tokenIndex
-			{
-				position796 := position
 				{
-					position797 := position
-					if !_rules[rulesp]() {
-						goto l795
+					position795, tokenIndex795 := position, tokenIndex
+					if buffer[position] != rune('a') {
+						goto l796
 					}
-					if !_rules[ruleProjection]() {
-						goto l795
+					position++
+					goto l795
+				l796:
+					position, tokenIndex = position795, tokenIndex795
+					if buffer[position] != rune('A') {
+						goto l781
 					}
-				l798:
-					{
-						position799, tokenIndex799 := position, tokenIndex
-						if !_rules[rulespOpt]() {
-							goto l799
-						}
-						if buffer[position] != rune(',') {
-							goto l799
-						}
-						position++
-						if !_rules[rulespOpt]() {
-							goto l799
-						}
-						if !_rules[ruleProjection]() {
-							goto l799
-						}
+					position++
+				}
+			l795:
+				{
+					position797, tokenIndex797 := position, tokenIndex
+					if buffer[position] != rune('t') {
 						goto l798
-					l799:
-						position, tokenIndex = position799, tokenIndex799
 					}
-					add(rulePegText, position797)
-				}
-				if !_rules[ruleAction31]() {
-					goto l795
+					position++
+					goto l797
+				l798:
+					position, tokenIndex = position797, tokenIndex797
+					if buffer[position] != rune('T') {
+						goto l781
+					}
+					position++
 				}
-				add(ruleProjections, position796)
-			}
-			return true
-		l795:
-			position, tokenIndex = position795, tokenIndex795
-			return false
-		},
-		/* 41 Projection <- <(AliasExpression / ExpressionOrWildcard)> */
-		func() bool {
-			position800, tokenIndex800 := position, tokenIndex
-			{
-				position801 := position
+			l797:
 				{
-					position802, tokenIndex802 := position, tokenIndex
-					if !_rules[ruleAliasExpression]() {
-						goto l803
-					}
-					goto l802
-				l803:
-					position, tokenIndex = position802, tokenIndex802
-					if !_rules[ruleExpressionOrWildcard]() {
+					position799, tokenIndex799 := position, tokenIndex
+					if buffer[position] != rune('e') {
 						goto l800
 					}
+					position++
+					goto l799
+				l800:
+					position, tokenIndex = position799, tokenIndex799
+					if buffer[position] != rune('E') {
+						goto l781
+					}
+					position++
+				}
+			l799:
+				if !_rules[rulesp]() {
+					goto l781
+				}
+				if !_rules[ruleStreamIdentifier]() {
+					goto l781
+				}
+				if !_rules[ruleAction32]() {
+					goto l781
 				}
-			l802:
-				add(ruleProjection, position801)
+				add(ruleDropStateStmt, position782)
 			}
 			return true
-		l800:
-			position, tokenIndex = position800, tokenIndex800
+		l781:
+			position, tokenIndex = position781, tokenIndex781
 			return false
 		},
-		/* 42 AliasExpression <- <(ExpressionOrWildcard sp (('a' / 'A') ('s' / 'S')) sp TargetIdentifier Action32)> */
+		/* 38 LoadStateStmt <- <(('l' / 'L') ('o' / 'O') ('a' / 'A') ('d' / 'D') sp (('s' / 'S') ('t' / 'T') ('a' / 'A') ('t' / 'T') ('e' / 'E')) sp StreamIdentifier sp (('t' / 'T') ('y' / 'Y') ('p' / 'P') ('e' / 'E')) sp SourceSinkType StateTagOpt SetOptSpecs Action33)> */
 		func() bool {
-			position804, tokenIndex804 := position, tokenIndex
+			position801, tokenIndex801 := position, tokenIndex
 			{
-				position805 := position
-				if !_rules[ruleExpressionOrWildcard]() {
-					goto l804
+				position802 := position
+				{
+					position803, tokenIndex803 := position, tokenIndex
+					if buffer[position] != rune('l') {
+						goto l804
+					}
+					position++
+					goto l803
+				l804:
+					position, tokenIndex = position803, tokenIndex803
+					if buffer[position] != rune('L') {
+						goto l801
+					}
+					position++
 				}
-				if !_rules[rulesp]() {
-					goto l804
+			l803:
+				{
+					position805, tokenIndex805 := position, tokenIndex
+					if buffer[position] != rune('o') {
+						goto l806
+					}
+					position++
+					goto l805
+				l806:
+					position, tokenIndex = position805, tokenIndex805
+					if buffer[position] != rune('O') {
+						goto l801
+					}
+					position++
 				}
+			l805:
 				{
-					position806, tokenIndex806 := position, tokenIndex
+					position807, tokenIndex807 := position, tokenIndex
 					if buffer[position] != rune('a') {
-						goto l807
+						goto l808
 					}
 					position++
-					goto l806
-				l807:
-					position, tokenIndex = position806, tokenIndex806
+					goto l807
+				l808:
+					position, tokenIndex = position807, tokenIndex807
 					if buffer[position] != rune('A') {
-						goto l804
+						goto l801
+					}
+					position++
+				}
+			l807:
+				{
+					position809, tokenIndex809 := position, tokenIndex
+					if buffer[position] != rune('d') {
+						goto l810
 					}
 					position++
+					goto l809
+				l810:
+					position, tokenIndex = position809, tokenIndex809
+					if buffer[position] != rune('D') {
+						goto l801
+					}
+					position++
+				}
+			l809:
+				if !_rules[rulesp]() {
+					goto l801
 				}
-			l806:
 				{
-					position808, tokenIndex808 := position, tokenIndex
+					position811, tokenIndex811 := position, tokenIndex
 					if buffer[position] != rune('s') {
-						goto l809
+						goto l812
 					}
 					position++
-					goto l808
-				l809:
-					position, tokenIndex = position808, tokenIndex808
+					goto l811
+				l812:
+					position, tokenIndex = position811, tokenIndex811
 					if buffer[position] != rune('S') {
-						goto l804
+						goto l801
+					}
+					position++
+				}
+			l811:
+				{
+					position813, tokenIndex813 := position, tokenIndex
+					if buffer[position] != rune('t') {
+						goto l814
+					}
+					position++
+					goto l813
+				l814:
+					position, tokenIndex = position813, tokenIndex813
+					if buffer[position] != rune('T') {
+						goto l801
+					}
+					position++
+				}
+			l813:
+				{
+					position815, tokenIndex815 := position, tokenIndex
+					if buffer[position] != rune('a') {
+						goto l816
+					}
+					position++
+					goto l815
+				l816:
+					position, tokenIndex = position815, tokenIndex815
+					if buffer[position] != rune('A') {
+						goto l801
+					}
+					position++
+				}
+			l815:
+				{
+					position817, tokenIndex817 := position, tokenIndex
+					if buffer[position] != rune('t') {
+						goto l818
+					}
+					position++
+					goto l817
+				l818:
+					position, tokenIndex = position817, tokenIndex817
+					if buffer[position] != rune('T') {
+						goto l801
+					}
+					position++
+				}
+			l817:
+				{
+					position819, tokenIndex819 := position, tokenIndex
+					if buffer[position] != rune('e') {
+						goto l820
+					}
+					position++
+					goto l819
+				l820:
+					position, tokenIndex = position819, tokenIndex819
+					if buffer[position] != rune('E') {
+						goto l801
 					}
 					position++
 				}
-			l808:
+			l819:
 				if !_rules[rulesp]() {
-					goto l804
+					goto l801
 				}
-				if !_rules[ruleTargetIdentifier]() {
-					goto l804
+				if !_rules[ruleStreamIdentifier]() {
+					goto l801
 				}
-				if !_rules[ruleAction32]() {
-					goto l804
+				if !_rules[rulesp]() {
+					goto l801
 				}
-				add(ruleAliasExpression, position805)
-			}
-			return true
-		l804:
-			position, tokenIndex = position804, tokenIndex804
-			return false
-		},
-		/* 43 WindowedFrom <- <(<(sp (('f' / 'F') ('r' / 'R') ('o' / 'O') ('m' / 'M')) sp Relations)?> Action33)> */
-		func() bool {
-			position810, tokenIndex810 := position, tokenIndex
-			{
-				position811 := position
 				{
-					position812 := position
-					{
-						position813, tokenIndex813 := position, tokenIndex
-						if !_rules[rulesp]() {
-							goto l813
-						}
-						{
-							position815, tokenIndex815 := position, tokenIndex
-							if buffer[position] != rune('f') {
-								goto l816
-							}
-							position++
-							goto l815
-						l816:
-							position, tokenIndex = position815, tokenIndex815
-							if buffer[position] != rune('F') {
-								goto l813
-							}
-							position++
-						}
-					l815:
-						{
-							position817, tokenIndex817 := position, tokenIndex
-							if buffer[position] != rune('r') {
-								goto l818
-							}
-							position++
-							goto l817
-						l818:
-							position, tokenIndex = position817, tokenIndex817
-							if buffer[position] != rune('R') {
-								goto l813
-							}
-							position++
-						}
-					l817:
-						{
-							position819, tokenIndex819 := position, tokenIndex
-							if buffer[position] != rune('o') {
-								goto l820
-							}
-							position++
-							goto l819
-						l820:
-							position, tokenIndex = position819, tokenIndex819
-							if buffer[position] != rune('O') {
-								goto l813
-							}
-							position++
-						}
-					l819:
-						{
-							position821, tokenIndex821 := position, tokenIndex
-							if buffer[position] != rune('m') {
-								goto l822
-							}
-							position++
-							goto l821
-						l822:
-							position, tokenIndex = position821, tokenIndex821
-							if buffer[position] != rune('M') {
-								goto l813
-							}
-							position++
-						}
-					l821:
-						if !_rules[rulesp]() {
-							goto l813
-						}
-						if !_rules[ruleRelations]() {
-							goto l813
-						}
-						goto l814
-					l813:
-						position, tokenIndex = position813, tokenIndex813
+					position821, tokenIndex821 := position, tokenIndex
+					if buffer[position] != rune('t') {
+						goto l822
 					}
-				l814:
-					add(rulePegText, position812)
+					position++
+					goto l821
+				l822:
+					position, tokenIndex = position821, tokenIndex821
+					if buffer[position] != rune('T') {
+						goto l801
+					}
+					position++
 				}
-				if !_rules[ruleAction33]() {
-					goto l810
+			l821:
+				{
+					position823, tokenIndex823 := position, tokenIndex
+					if buffer[position] != rune('y') {
+						goto l824
+					}
+					position++
+					goto l823
+				l824:
+					position, tokenIndex = position823, tokenIndex823
+					if buffer[position] != rune('Y') {
+						goto l801
+					}
+					position++
 				}
-				add(ruleWindowedFrom, position811)
-			}
-			return true
-		l810:
-			position, tokenIndex = position810, tokenIndex810
-			return false
-		},
-		/* 44 Interval <- <(TimeInterval / TuplesInterval)> */
-		func() bool {
-			position823, tokenIndex823 := position, tokenIndex
-			{
-				position824 := position
+			l823:
 				{
 					position825, tokenIndex825 := position, tokenIndex
-					if !_rules[ruleTimeInterval]() {
+					if buffer[position] != rune('p') {
 						goto l826
 					}
+					position++
 					goto l825
 				l826:
 					position, tokenIndex = position825, tokenIndex825
-					if !_rules[ruleTuplesInterval]() {
-						goto l823
+					if buffer[position] != rune('P') {
+						goto l801
 					}
+					position++
 				}
 			l825:
-				add(ruleInterval, position824)
-			}
-			return true
-		l823:
-			position, tokenIndex = position823, tokenIndex823
-			return false
-		},
-		/* 45 TimeInterval <- <((FloatLiteral / NumericLiteral) sp (SECONDS / MILLISECONDS) Action34)> */
-		func() bool {
-			position827, tokenIndex827 := position, tokenIndex
-			{
-				position828 := position
 				{
-					position829, tokenIndex829 := position, tokenIndex
-					if !_rules[ruleFloatLiteral]() {
-						goto l830
+					position827, tokenIndex827 := position, tokenIndex
+					if buffer[position] != rune('e') {
+						goto l828
 					}
-					goto l829
-				l830:
-					position, tokenIndex = position829, tokenIndex829
-					if !_rules[ruleNumericLiteral]() {
-						goto l827
+					position++
+					goto l827
+				l828:
+					position, tokenIndex = position827, tokenIndex827
+					if buffer[position] != rune('E') {
+						goto l801
 					}
+					position++
 				}
-			l829:
+			l827:
 				if !_rules[rulesp]() {
-					goto l827
+					goto l801
 				}
-				{
-					position831, tokenIndex831 := position, tokenIndex
-					if !_rules[ruleSECONDS]() {
-						goto l832
-					}
-					goto l831
-				l832:
-					position, tokenIndex = position831, tokenIndex831
-					if !_rules[ruleMILLISECONDS]() {
-						goto l827
-					}
+				if !_rules[ruleSourceSinkType]() {
+					goto l801
 				}
-			l831:
-				if !_rules[ruleAction34]() {
-					goto l827
+				if !_rules[ruleStateTagOpt]() {
+					goto l801
+				}
+				if !_rules[ruleSetOptSpecs]() {
+					goto l801
 				}
-				add(ruleTimeInterval, position828)
+				if !_rules[ruleAction33]() {
+					goto l801
+				}
+				add(ruleLoadStateStmt, position802)
 			}
 			return true
-		l827:
-			position, tokenIndex = position827, tokenIndex827
+		l801:
+			position, tokenIndex = position801, tokenIndex801
 			return false
 		},
-		/* 46 TuplesInterval <- <(NumericLiteral sp TUPLES Action35)> */
+		/* 39 LoadStateOrCreateStmt <- <(LoadStateStmt sp (('o' / 'O') ('r' / 'R')) sp (('c' / 'C') ('r' / 'R') ('e' / 'E') ('a' / 'A') ('t' / 'T') ('e' / 'E')) sp (('i' / 'I') ('f' / 'F')) sp (('n' / 'N') ('o' / 'O') ('t' / 'T')) sp ((('s' / 'S') ('a' / 'A') ('v' / 'V') ('e' / 'E') ('d' / 'D')) / (('e' / 'E') ('x' / 'X') ('i' / 'I') ('s' / 'S') ('t' / 'T') ('s' / 'S'))) SourceSinkSpecs Action34)> */
 		func() bool {
-			position833, tokenIndex833 := position, tokenIndex
+			position829, tokenIndex829 := position, tokenIndex
 			{
-				position834 := position
-				if !_rules[ruleNumericLiteral]() {
-					goto l833
+				position830 := position
+				if !_rules[ruleLoadStateStmt]() {
+					goto l829
 				}
 				if !_rules[rulesp]() {
-					goto l833
+					goto l829
 				}
-				if !_rules[ruleTUPLES]() {
-					goto l833
+				{
+					position831, tokenIndex831 := position, tokenIndex
+					if buffer[position] != rune('o') {
+						goto l832
+					}
+					position++
+					goto l831
+				l832:
+					position, tokenIndex = position831, tokenIndex831
+					if buffer[position] != rune('O') {
+						goto l829
+					}
+					position++
 				}
-				if !_rules[ruleAction35]() {
+			l831:
+				{
+					position833, tokenIndex833 := position, tokenIndex
+					if buffer[position] != rune('r') {
+						goto l834
+					}
+					position++
 					goto l833
+				l834:
+					position, tokenIndex = position833, tokenIndex833
+					if buffer[position] != rune('R') {
+						goto l829
+					}
+					position++
 				}
-				add(ruleTuplesInterval, position834)
-			}
-			return true
-		l833:
-			position, tokenIndex = position833, tokenIndex833
-			return false
-		},
-		/* 47 Relations <- <(RelationLike (spOpt ',' spOpt RelationLike)*)> */
-		func() bool {
-			position835, tokenIndex835 := position, tokenIndex
-			{
-				position836 := position
-				if !_rules[ruleRelationLike]() {
-					goto l835
+			l833:
+				if !_rules[rulesp]() {
+					goto l829
 				}
-			l837:
 				{
-					position838, tokenIndex838 := position, tokenIndex
-					if !_rules[rulespOpt]() {
-						goto l838
-					}
-					if buffer[position] != rune(',') {
-						goto l838
+					position835, tokenIndex835 := position, tokenIndex
+					if buffer[position] != rune('c') {
+						goto l836
 					}
 					position++
-					if !_rules[rulespOpt]() {
-						goto l838
+					goto l835
+				l836:
+					position, tokenIndex = position835, tokenIndex835
+					if buffer[position] != rune('C') {
+						goto l829
 					}
-					if !_rules[ruleRelationLike]() {
+					position++
+				}
+			l835:
+				{
+					position837, tokenIndex837 := position, tokenIndex
+					if buffer[position] != rune('r') {
 						goto l838
 					}
+					position++
 					goto l837
 				l838:
-					position, tokenIndex = position838, tokenIndex838
+					position, tokenIndex = position837, tokenIndex837
+					if buffer[position] != rune('R') {
+						goto l829
+					}
+					position++
+				}
+			l837:
+				{
+					position839, tokenIndex839 := position, tokenIndex
+					if buffer[position] != rune('e') {
+						goto l840
+					}
+					position++
+					goto l839
+				l840:
+					position, tokenIndex = position839, tokenIndex839
+					if buffer[position] != rune('E') {
+						goto l829
+					}
+					position++
+				}
+			l839:
+				{
+					position841, tokenIndex841 := position, tokenIndex
+					if buffer[position] != rune('a') {
+						goto l842
+					}
+					position++
+					goto l841
+				l842:
+					position, tokenIndex = position841, tokenIndex841
+					if buffer[position] != rune('A') {
+						goto l829
+					}
+					position++
+				}
+			l841:
+				{
+					position843, tokenIndex843 := position, tokenIndex
+					if buffer[position] != rune('t') {
+						goto l844
+					}
+					position++
+					goto l843
+				l844:
+					position, tokenIndex = position843, tokenIndex843
+					if buffer[position] != rune('T') {
+						goto l829
+					}
+					position++
+				}
+			l843:
+				{
+					position845, tokenIndex845 := position, tokenIndex
+					if buffer[position] != rune('e') {
+						goto l846
+					}
+					position++
+					goto l845
+				l846:
+					position, tokenIndex = position845, tokenIndex845
+					if buffer[position] != rune('E') {
+						goto l829
+					}
+					position++
+				}
+			l845:
+				if !_rules[rulesp]() {
+					goto l829
+				}
+				{
+					position847, tokenIndex847 := position, tokenIndex
+					if buffer[position] != rune('i') {
+						goto l848
+					}
+					position++
+					goto l847
+				l848:
+					position, tokenIndex = position847, tokenIndex847
+					if buffer[position] != rune('I') {
+						goto l829
+					}
+					position++
+				}
+			l847:
+				{
+					position849, tokenIndex849 := position, tokenIndex
+					if buffer[position] != rune('f') {
+						goto l850
+					}
+					position++
+					goto l849
+				l850:
+					position, tokenIndex = position849, tokenIndex849
+					if buffer[position] != rune('F') {
+						goto l829
+					}
+					position++
+				}
+			l849:
+				if !_rules[rulesp]() {
+					goto l829
+				}
+				{
+					position851, tokenIndex851 := position, tokenIndex
+					if buffer[position] != rune('n') {
+						goto l852
+					}
+					position++
+					goto l851
+				l852:
+					position, tokenIndex = position851, tokenIndex851
+					if buffer[position] != rune('N') {
+						goto l829
+					}
+					position++
+				}
+			l851:
+				{
+					position853, tokenIndex853 := position, tokenIndex
+					if buffer[position] != rune('o') {
+						goto l854
+					}
+					position++
+					goto l853
+				l854:
+					position, tokenIndex = position853, tokenIndex853
+					if buffer[position] != rune('O') {
+						goto l829
+					}
+					position++
+				}
+			l853:
+				{
+					position855, tokenIndex855 := position, tokenIndex
+					if buffer[position] != rune('t') {
+						goto l856
+					}
+					position++
+					goto l855
+				l856:
+					position, tokenIndex = position855, tokenIndex855
+					if buffer[position] != rune('T') {
+						goto l829
+					}
+					position++
+				}
+			l855:
+				if !_rules[rulesp]() {
+					goto l829
+				}
+				{
+					position857, tokenIndex857 := position, tokenIndex
+					{
+						position859, tokenIndex859 := position, tokenIndex
+						if buffer[position] != rune('s') {
+							goto l860
+						}
+						position++
+						goto l859
+					l860:
+						position, tokenIndex = position859, tokenIndex859
+						if buffer[position] != rune('S') {
+							goto l858
+						}
+						position++
+					}
+				l859:
+					{
+						position861, tokenIndex861 := position, tokenIndex
+						if buffer[position] != rune('a') {
+							goto l862
+						}
+						position++
+						goto l861
+					l862:
+						position, tokenIndex = position861, tokenIndex861
+						if buffer[position] != rune('A') {
+							goto l858
+						}
+						position++
+					}
+				l861:
+					{
+						position863, tokenIndex863 := position, tokenIndex
+						if buffer[position] != rune('v') {
+							goto l864
+						}
+						position++
+						goto l863
+					l864:
+						position, tokenIndex = position863, tokenIndex863
+						if buffer[position] != rune('V') {
+							goto l858
+						}
+						position++
+					}
+				l863:
+					{
+						position865, tokenIndex865 := position, tokenIndex
+						if buffer[position] != rune('e') {
+							goto l866
+						}
+						position++
+						goto l865
+					l866:
+						position, tokenIndex = position865, tokenIndex865
+						if buffer[position] != rune('E') {
+							goto l858
+						}
+						position++
+					}
+				l865:
+					{
+						position867, tokenIndex867 := position, tokenIndex
+						if buffer[position] != rune('d') {
+							goto l868
+						}
+						position++
+						goto l867
+					l868:
+						position, tokenIndex = position867, tokenIndex867
+						if buffer[position] != rune('D') {
+							goto l858
+						}
+						position++
+					}
+				l867:
+					goto l857
+				l858:
+					position, tokenIndex = position857, tokenIndex857
+					{
+						position869, tokenIndex869 := position, tokenIndex
+						if buffer[position] != rune('e') {
+							goto l870
+						}
+						position++
+						goto l869
+					l870:
+						position, tokenIndex = position869, tokenIndex869
+						if buffer[position] != rune('E') {
+							goto l829
+						}
+						position++
+					}
+				l869:
+					{
+						position871, tokenIndex871 := position, tokenIndex
+						if buffer[position] != rune('x') {
+							goto l872
+						}
+						position++
+						goto l871
+					l872:
+						position, tokenIndex = position871, tokenIndex871
+						if buffer[position] != rune('X') {
+							goto l829
+						}
+						position++
+					}
+				l871:
+					{
+						position873, tokenIndex873 := position, tokenIndex
+						if buffer[position] != rune('i') {
+							goto l874
+						}
+						position++
+						goto l873
+					l874:
+						position, tokenIndex = position873, tokenIndex873
+						if buffer[position] != rune('I') {
+							goto l829
+						}
+						position++
+					}
+				l873:
+					{
+						position875, tokenIndex875 := position, tokenIndex
+						if buffer[position] != rune('s') {
+							goto l876
+						}
+						position++
+						goto l875
+					l876:
+						position, tokenIndex = position875, tokenIndex875
+						if buffer[position] != rune('S') {
+							goto l829
+						}
+						position++
+					}
+				l875:
+					{
+						position877, tokenIndex877 := position, tokenIndex
+						if buffer[position] != rune('t') {
+							goto l878
+						}
+						position++
+						goto l877
+					l878:
+						position, tokenIndex = position877, tokenIndex877
+						if buffer[position] != rune('T') {
+							goto l829
+						}
+						position++
+					}
+				l877:
+					{
+						position879, tokenIndex879 := position, tokenIndex
+						if buffer[position] != rune('s') {
+							goto l880
+						}
+						position++
+						goto l879
+					l880:
+						position, tokenIndex = position879, tokenIndex879
+						if buffer[position] != rune('S') {
+							goto l829
+						}
+						position++
+					}
+				l879:
+				}
+			l857:
+				if !_rules[ruleSourceSinkSpecs]() {
+					goto l829
 				}
-				add(ruleRelations, position836)
+				if !_rules[ruleAction34]() {
+					goto l829
+				}
+				add(ruleLoadStateOrCreateStmt, position830)
 			}
 			return true
-		l835:
-			position, tokenIndex = position835, tokenIndex835
+		l829:
+			position, tokenIndex = position829, tokenIndex829
 			return false
 		},
-		/* 48 Filter <- <(<(sp (('w' / 'W') ('h' / 'H') ('e' / 'E') ('r' / 'R') ('e' / 'E')) sp Expression)?> Action36)> */
+		/* 40 SaveStateStmt <- <(('s' / 'S') ('a' / 'A') ('v' / 'V') ('e' / 'E') sp (('s' / 'S') ('t' / 'T') ('a' / 'A') ('t' / 'T') ('e' / 'E')) sp StreamIdentifier StateTagOpt Action35)> */
 		func() bool {
-			position839, tokenIndex839 := position, tokenIndex
+			position881, tokenIndex881 := position, tokenIndex
 			{
-				position840 := position
+				position882 := position
 				{
-					position841 := position
-					{
-						position842, tokenIndex842 := position, tokenIndex
+					position883, tokenIndex883 := position, tokenIndex
+					if buffer[position] != rune('s') {
+						goto l884
+					}
+					position++
+					goto l883
+				l884:
+					position, tokenIndex = position883, tokenIndex883
+					if buffer[position] != rune('S') {
+						goto l881
+					}
+					position++
+				}
+			l883:
+				{
+					position885, tokenIndex885 := position, tokenIndex
+					if buffer[position] != rune('a') {
+						goto l886
+					}
+					position++
+					goto l885
+				l886:
+					position, tokenIndex = position885, tokenIndex885
+					if buffer[position] != rune('A') {
+						goto l881
+					}
+					position++
+				}
+			l885:
+				{
+					position887, tokenIndex887 := position, tokenIndex
+					if buffer[position] != rune('v') {
+						goto l888
+					}
+					position++
+					goto l887
+				l888:
+					position, tokenIndex = position887, tokenIndex887
+					if buffer[position] != rune('V') {
+						goto l881
+					}
+					position++
+				}
+			l887:
+				{
+					position889, tokenIndex889 := position, tokenIndex
+					if buffer[position] != rune('e') {
+						goto l890
+					}
+					position++
+					goto l889
+				l890:
+					position, tokenIndex = position889, tokenIndex889
+					if buffer[position] != rune('E') {
+						goto l881
+					}
+					position++
+				}
+			l889:
+				if !_rules[rulesp]() {
+					goto l881
+				}
+				{
+					position891, tokenIndex891 := position, tokenIndex
+					if buffer[position] != rune('s') {
+						goto l892
+					}
+					position++
+					goto l891
+				l892:
+					position, tokenIndex = position891, tokenIndex891
+					if buffer[position] != rune('S') {
+						goto l881
+					}
+					position++
+				}
+			l891:
+				{
+					position893, tokenIndex893 := position, tokenIndex
+					if buffer[position] != rune('t') {
+						goto l894
+					}
+					position++
+					goto l893
+				l894:
+					position, tokenIndex = position893, tokenIndex893
+					if buffer[position] != rune('T') {
+						goto l881
+					}
+					position++
+				}
+			l893:
+				{
+					position895, tokenIndex895 := position, tokenIndex
+					if buffer[position] != rune('a') {
+						goto l896
+					}
+					position++
+					goto l895
+				l896:
+					position, tokenIndex = position895, tokenIndex895
+					if buffer[position] != rune('A') {
+						goto l881
+					}
+					position++
+				}
+			l895:
+				{
+					position897, tokenIndex897 := position, tokenIndex
+					if buffer[position] != rune('t') {
+						goto l898
+					}
+					position++
+					goto l897
+				l898:
+					position, tokenIndex = position897, tokenIndex897
+					if buffer[position] != rune('T') {
+						goto l881
+					}
+					position++
+				}
+			l897:
+				{
+					position899, tokenIndex899 := position, tokenIndex
+					if buffer[position] != rune('e') {
+						goto l900
+					}
+					position++
+					goto l899
+				l900:
+					position, tokenIndex = position899, tokenIndex899
+					if buffer[position] != rune('E') {
+						goto l881
+					}
+					position++
+				}
+			l899:
+				if !_rules[rulesp]() {
+					goto l881
+				}
+				if !_rules[ruleStreamIdentifier]() {
+					goto l881
+				}
+				if !_rules[ruleStateTagOpt]() {
+					goto l881
+				}
+				if !_rules[ruleAction35]() {
+					goto l881
+				}
+				add(ruleSaveStateStmt, position882)
+			}
+			return true
+		l881:
+			position, tokenIndex = position881, tokenIndex881
+			return false
+		},
+		/* 41 EvalStmt <- <(('e' / 'E') ('v' / 'V') ('a' / 'A') ('l' / 'L') sp Expression <(sp (('o' / 'O') ('n' / 'N')) sp MapExpr)?> Action36)> */
+		func() bool {
+			position901, tokenIndex901 := position, tokenIndex
+			{
+				position902 := position
+				{
+					position903, tokenIndex903 := position, tokenIndex
+					if buffer[position] != rune('e') {
+						goto l904
+					}
+					position++
+					goto l903
+				l904:
+					position, tokenIndex = position903, tokenIndex903
+					if buffer[position] != rune('E') {
+						goto l901
+					}
+					position++
+				}
+			l903:
+				{
+					position905, tokenIndex905 := position, tokenIndex
+					if buffer[position] != rune('v') {
+						goto l906
+					}
+					position++
+					goto l905
+				l906:
+					position, tokenIndex = position905, tokenIndex905
+					if buffer[position] != rune('V') {
+						goto l901
+					}
+					position++
+				}
+			l905:
+				{
+					position907, tokenIndex907 := position, tokenIndex
+					if buffer[position] != rune('a') {
+						goto l908
+					}
+					position++
+					goto l907
+				l908:
+					position, tokenIndex = position907, tokenIndex907
+					if buffer[position] != rune('A') {
+						goto l901
+					}
+					position++
+				}
+			l907:
+				{
+					position909, tokenIndex909 := position, tokenIndex
+					if buffer[position] != rune('l') {
+						goto l910
+					}
+					position++
+					goto l909
+				l910:
+					position, tokenIndex = position909, tokenIndex909
+					if buffer[position] != rune('L') {
+						goto l901
+					}
+					position++
+				}
+			l909:
+				if !_rules[rulesp]() {
+					goto l901
+				}
+				if !_rules[ruleExpression]() {
+					goto l901
+				}
+				{
+					position911 := position
+					{
+						position912, tokenIndex912 := position, tokenIndex
 						if !_rules[rulesp]() {
-							goto l842
+							goto l912
 						}
 						{
-							position844, tokenIndex844 := position, tokenIndex
-							if buffer[position] != rune('w') {
-								goto l845
+							position914, tokenIndex914 := position, tokenIndex
+							if buffer[position] != rune('o') {
+								goto l915
 							}
 							position++
-							goto l844
-						l845:
-							position, tokenIndex = position844, tokenIndex844
-							if buffer[position] != rune('W') {
-								goto l842
+							goto l914
+						l915:
+							position, tokenIndex = position914, tokenIndex914
+							if buffer[position] != rune('O') {
+								goto l912
+							}
+							position++
+						}
+					l914:
+						{
+							position916, tokenIndex916 := position, tokenIndex
+							if buffer[position] != rune('n') {
+								goto l917
+							}
+							position++
+							goto l916
+						l917:
+							position, tokenIndex = position916, tokenIndex916
+							if buffer[position] != rune('N') {
+								goto l912
+							}
+							position++
+						}
+					l916:
+						if !_rules[rulesp]() {
+							goto l912
+						}
+						if !_rules[ruleMapExpr]() {
+							goto l912
+						}
+						goto l913
+					l912:
+						position, tokenIndex = position912, tokenIndex912
+					}
+				l913:
+					add(rulePegText, position911)
+				}
+				if !_rules[ruleAction36]() {
+					goto l901
+				}
+				add(ruleEvalStmt, position902)
+			}
+			return true
+		l901:
+			position, tokenIndex = position901, tokenIndex901
+			return false
+		},
+		/* 42 ExplainAnalyzeStmt <- <(('e' / 'E') ('x' / 'X') ('p' / 'P') ('l' / 'L') ('a' / 'A') ('i' / 'I') ('n' / 'N') sp (('a' / 'A') ('n' / 'N') ('a' / 'A') ('l' / 'L') ('y' / 'Y') ('z' / 'Z') ('e' / 'E')) sp ExplainAnalyzeLimit SelectStmt Action37)> */
+		func() bool {
+			position918, tokenIndex918 := position, tokenIndex
+			{
+				position919 := position
+				{
+					position920, tokenIndex920 := position, tokenIndex
+					if buffer[position] != rune('e') {
+						goto l921
+					}
+					position++
+					goto l920
+				l921:
+					position, tokenIndex = position920, tokenIndex920
+					if buffer[position] != rune('E') {
+						goto l918
+					}
+					position++
+				}
+			l920:
+				{
+					position922, tokenIndex922 := position, tokenIndex
+					if buffer[position] != rune('x') {
+						goto l923
+					}
+					position++
+					goto l922
+				l923:
+					position, tokenIndex = position922, tokenIndex922
+					if buffer[position] != rune('X') {
+						goto l918
+					}
+					position++
+				}
+			l922:
+				{
+					position924, tokenIndex924 := position, tokenIndex
+					if buffer[position] != rune('p') {
+						goto l925
+					}
+					position++
+					goto l924
+				l925:
+					position, tokenIndex = position924, tokenIndex924
+					if buffer[position] != rune('P') {
+						goto l918
+					}
+					position++
+				}
+			l924:
+				{
+					position926, tokenIndex926 := position, tokenIndex
+					if buffer[position] != rune('l') {
+						goto l927
+					}
+					position++
+					goto l926
+				l927:
+					position, tokenIndex = position926, tokenIndex926
+					if buffer[position] != rune('L') {
+						goto l918
+					}
+					position++
+				}
+			l926:
+				{
+					position928, tokenIndex928 := position, tokenIndex
+					if buffer[position] != rune('a') {
+						goto l929
+					}
+					position++
+					goto l928
+				l929:
+					position, tokenIndex = position928, tokenIndex928
+					if buffer[position] != rune('A') {
+						goto l918
+					}
+					position++
+				}
+			l928:
+				{
+					position930, tokenIndex930 := position, tokenIndex
+					if buffer[position] != rune('i') {
+						goto l931
+					}
+					position++
+					goto l930
+				l931:
+					position, tokenIndex = position930, tokenIndex930
+					if buffer[position] != rune('I') {
+						goto l918
+					}
+					position++
+				}
+			l930:
+				{
+					position932, tokenIndex932 := position, tokenIndex
+					if buffer[position] != rune('n') {
+						goto l933
+					}
+					position++
+					goto l932
+				l933:
+					position, tokenIndex = position932, tokenIndex932
+					if buffer[position] != rune('N') {
+						goto l918
+					}
+					position++
+				}
+			l932:
+				if !_rules[rulesp]() {
+					goto l918
+				}
+				{
+					position934, tokenIndex934 := position, tokenIndex
+					if buffer[position] != rune('a') {
+						goto l935
+					}
+					position++
+					goto l934
+				l935:
+					position, tokenIndex = position934, tokenIndex934
+					if buffer[position] != rune('A') {
+						goto l918
+					}
+					position++
+				}
+			l934:
+				{
+					position936, tokenIndex936 := position, tokenIndex
+					if buffer[position] != rune('n') {
+						goto l937
+					}
+					position++
+					goto l936
+				l937:
+					position, tokenIndex = position936, tokenIndex936
+					if buffer[position] != rune('N') {
+						goto l918
+					}
+					position++
+				}
+			l936:
+				{
+					position938, tokenIndex938 := position, tokenIndex
+					if buffer[position] != rune('a') {
+						goto l939
+					}
+					position++
+					goto l938
+				l939:
+					position, tokenIndex = position938, tokenIndex938
+					if buffer[position] != rune('A') {
+						goto l918
+					}
+					position++
+				}
+			l938:
+				{
+					position940, tokenIndex940 := position, tokenIndex
+					if buffer[position] != rune('l') {
+						goto l941
+					}
+					position++
+					goto l940
+				l941:
+					position, tokenIndex = position940, tokenIndex940
+					if buffer[position] != rune('L') {
+						goto l918
+					}
+					position++
+				}
+			l940:
+				{
+					position942, tokenIndex942 := position, tokenIndex
+					if buffer[position] != rune('y') {
+						goto l943
+					}
+					position++
+					goto l942
+				l943:
+					position, tokenIndex = position942, tokenIndex942
+					if buffer[position] != rune('Y') {
+						goto l918
+					}
+					position++
+				}
+			l942:
+				{
+					position944, tokenIndex944 := position, tokenIndex
+					if buffer[position] != rune('z') {
+						goto l945
+					}
+					position++
+					goto l944
+				l945:
+					position, tokenIndex = position944, tokenIndex944
+					if buffer[position] != rune('Z') {
+						goto l918
+					}
+					position++
+				}
+			l944:
+				{
+					position946, tokenIndex946 := position, tokenIndex
+					if buffer[position] != rune('e') {
+						goto l947
+					}
+					position++
+					goto l946
+				l947:
+					position, tokenIndex = position946, tokenIndex946
+					if buffer[position] != rune('E') {
+						goto l918
+					}
+					position++
+				}
+			l946:
+				if !_rules[rulesp]() {
+					goto l918
+				}
+				if !_rules[ruleExplainAnalyzeLimit]() {
+					goto l918
+				}
+				if !_rules[ruleSelectStmt]() {
+					goto l918
+				}
+				if !_rules[ruleAction37]() {
+					goto l918
+				}
+				add(ruleExplainAnalyzeStmt, position919)
+			}
+			return true
+		l918:
+			position, tokenIndex = position918, tokenIndex918
+			return false
+		},
+		/* 43 ExplainAnalyzeLimit <- <(<(('f' / 'F') ('o' / 'O') ('r' / 'R') sp Interval sp)?> Action38)> */
+		func() bool {
+			position948, tokenIndex948 := position, tokenIndex
+			{
+				position949 := position
+				{
+					position950 := position
+					{
+						position951, tokenIndex951 := position, tokenIndex
+						{
+							position953, tokenIndex953 := position, tokenIndex
+							if buffer[position] != rune('f') {
+								goto l954
+							}
+							position++
+							goto l953
+						l954:
+							position, tokenIndex = position953, tokenIndex953
+							if buffer[position] != rune('F') {
+								goto l951
+							}
+							position++
+						}
+					l953:
+						{
+							position955, tokenIndex955 := position, tokenIndex
+							if buffer[position] != rune('o') {
+								goto l956
+							}
+							position++
+							goto l955
+						l956:
+							position, tokenIndex = position955, tokenIndex955
+							if buffer[position] != rune('O') {
+								goto l951
+							}
+							position++
+						}
+					l955:
+						{
+							position957, tokenIndex957 := position, tokenIndex
+							if buffer[position] != rune('r') {
+								goto l958
+							}
+							position++
+							goto l957
+						l958:
+							position, tokenIndex = position957, tokenIndex957
+							if buffer[position] != rune('R') {
+								goto l951
+							}
+							position++
+						}
+					l957:
+						if !_rules[rulesp]() {
+							goto l951
+						}
+						if !_rules[ruleInterval]() {
+							goto l951
+						}
+						if !_rules[rulesp]() {
+							goto l951
+						}
+						goto l952
+					l951:
+						position, tokenIndex = position951, tokenIndex951
+					}
+				l952:
+					add(rulePegText, position950)
+				}
+				if !_rules[ruleAction38]() {
+					goto l948
+				}
+				add(ruleExplainAnalyzeLimit, position949)
+			}
+			return true
+		l948:
+			position, tokenIndex = position948, tokenIndex948
+			return false
+		},
+		/* 44 ShowFunctionsStmt <- <(<(('s' / 'S') ('h' / 'H') ('o' / 'O') ('w' / 'W') sp (('f' / 'F') ('u' / 'U') ('n' / 'N') ('c' / 'C') ('t' / 'T') ('i' / 'I') ('o' / 'O') ('n' / 'N') ('s' / 'S')))> Action39)> */
+		func() bool {
+			position959, tokenIndex959 := position, tokenIndex
+			{
+				position960 := position
+				{
+					position961 := position
+					{
+						position962, tokenIndex962 := position, tokenIndex
+						if buffer[position] != rune('s') {
+							goto l963
+						}
+						position++
+						goto l962
+					l963:
+						position, tokenIndex = position962, tokenIndex962
+						if buffer[position] != rune('S') {
+							goto l959
+						}
+						position++
+					}
+				l962:
+					{
+						position964, tokenIndex964 := position, tokenIndex
+						if buffer[position] != rune('h') {
+							goto l965
+						}
+						position++
+						goto l964
+					l965:
+						position, tokenIndex = position964, tokenIndex964
+						if buffer[position] != rune('H') {
+							goto l959
+						}
+						position++
+					}
+				l964:
+					{
+						position966, tokenIndex966 := position, tokenIndex
+						if buffer[position] != rune('o') {
+							goto l967
+						}
+						position++
+						goto l966
+					l967:
+						position, tokenIndex = position966, tokenIndex966
+						if buffer[position] != rune('O') {
+							goto l959
+						}
+						position++
+					}
+				l966:
+					{
+						position968, tokenIndex968 := position, tokenIndex
+						if buffer[position] != rune('w') {
+							goto l969
+						}
+						position++
+						goto l968
+					l969:
+						position, tokenIndex = position968, tokenIndex968
+						if buffer[position] != rune('W') {
+							goto l959
+						}
+						position++
+					}
+				l968:
+					if !_rules[rulesp]() {
+						goto l959
+					}
+					{
+						position970, tokenIndex970 := position, tokenIndex
+						if buffer[position] != rune('f') {
+							goto l971
+						}
+						position++
+						goto l970
+					l971:
+						position, tokenIndex = position970, tokenIndex970
+						if buffer[position] != rune('F') {
+							goto l959
+						}
+						position++
+					}
+				l970:
+					{
+						position972, tokenIndex972 := position, tokenIndex
+						if buffer[position] != rune('u') {
+							goto l973
+						}
+						position++
+						goto l972
+					l973:
+						position, tokenIndex = position972, tokenIndex972
+						if buffer[position] != rune('U') {
+							goto l959
+						}
+						position++
+					}
+				l972:
+					{
+						position974, tokenIndex974 := position, tokenIndex
+						if buffer[position] != rune('n') {
+							goto l975
+						}
+						position++
+						goto l974
+					l975:
+						position, tokenIndex = position974, tokenIndex974
+						if buffer[position] != rune('N') {
+							goto l959
+						}
+						position++
+					}
+				l974:
+					{
+						position976, tokenIndex976 := position, tokenIndex
+						if buffer[position] != rune('c') {
+							goto l977
+						}
+						position++
+						goto l976
+					l977:
+						position, tokenIndex = position976, tokenIndex976
+						if buffer[position] != rune('C') {
+							goto l959
+						}
+						position++
+					}
+				l976:
+					{
+						position978, tokenIndex978 := position, tokenIndex
+						if buffer[position] != rune('t') {
+							goto l979
+						}
+						position++
+						goto l978
+					l979:
+						position, tokenIndex = position978, tokenIndex978
+						if buffer[position] != rune('T') {
+							goto l959
+						}
+						position++
+					}
+				l978:
+					{
+						position980, tokenIndex980 := position, tokenIndex
+						if buffer[position] != rune('i') {
+							goto l981
+						}
+						position++
+						goto l980
+					l981:
+						position, tokenIndex = position980, tokenIndex980
+						if buffer[position] != rune('I') {
+							goto l959
+						}
+						position++
+					}
+				l980:
+					{
+						position982, tokenIndex982 := position, tokenIndex
+						if buffer[position] != rune('o') {
+							goto l983
+						}
+						position++
+						goto l982
+					l983:
+						position, tokenIndex = position982, tokenIndex982
+						if buffer[position] != rune('O') {
+							goto l959
+						}
+						position++
+					}
+				l982:
+					{
+						position984, tokenIndex984 := position, tokenIndex
+						if buffer[position] != rune('n') {
+							goto l985
+						}
+						position++
+						goto l984
+					l985:
+						position, tokenIndex = position984, tokenIndex984
+						if buffer[position] != rune('N') {
+							goto l959
+						}
+						position++
+					}
+				l984:
+					{
+						position986, tokenIndex986 := position, tokenIndex
+						if buffer[position] != rune('s') {
+							goto l987
+						}
+						position++
+						goto l986
+					l987:
+						position, tokenIndex = position986, tokenIndex986
+						if buffer[position] != rune('S') {
+							goto l959
+						}
+						position++
+					}
+				l986:
+					add(rulePegText, position961)
+				}
+				if !_rules[ruleAction39]() {
+					goto l959
+				}
+				add(ruleShowFunctionsStmt, position960)
+			}
+			return true
+		l959:
+			position, tokenIndex = position959, tokenIndex959
+			return false
+		},
+		/* 45 ReloadFunctionStmt <- <(<(('r' / 'R') ('e' / 'E') ('l' / 'L') ('o' / 'O') ('a' / 'A') ('d' / 'D') sp (('f' / 'F') ('u' / 'U') ('n' / 'N') ('c' / 'C') ('t' / 'T') ('i' / 'I') ('o' / 'O') ('n' / 'N')) sp Function)> Action40)> */
+		func() bool {
+			position988, tokenIndex988 := position, tokenIndex
+			{
+				position989 := position
+				{
+					position990 := position
+					{
+						position991, tokenIndex991 := position, tokenIndex
+						if buffer[position] != rune('r') {
+							goto l992
+						}
+						position++
+						goto l991
+					l992:
+						position, tokenIndex = position991, tokenIndex991
+						if buffer[position] != rune('R') {
+							goto l988
+						}
+						position++
+					}
+				l991:
+					{
+						position993, tokenIndex993 := position, tokenIndex
+						if buffer[position] != rune('e') {
+							goto l994
+						}
+						position++
+						goto l993
+					l994:
+						position, tokenIndex = position993, tokenIndex993
+						if buffer[position] != rune('E') {
+							goto l988
+						}
+						position++
+					}
+				l993:
+					{
+						position995, tokenIndex995 := position, tokenIndex
+						if buffer[position] != rune('l') {
+							goto l996
+						}
+						position++
+						goto l995
+					l996:
+						position, tokenIndex = position995, tokenIndex995
+						if buffer[position] != rune('L') {
+							goto l988
+						}
+						position++
+					}
+				l995:
+					{
+						position997, tokenIndex997 := position, tokenIndex
+						if buffer[position] != rune('o') {
+							goto l998
+						}
+						position++
+						goto l997
+					l998:
+						position, tokenIndex = position997, tokenIndex997
+						if buffer[position] != rune('O') {
+							goto l988
+						}
+						position++
+					}
+				l997:
+					{
+						position999, tokenIndex999 := position, tokenIndex
+						if buffer[position] != rune('a') {
+							goto l1000
+						}
+						position++
+						goto l999
+					l1000:
+						position, tokenIndex = position999, tokenIndex999
+						if buffer[position] != rune('A') {
+							goto l988
+						}
+						position++
+					}
+				l999:
+					{
+						position1001, tokenIndex1001 := position, tokenIndex
+						if buffer[position] != rune('d') {
+							goto l1002
+						}
+						position++
+						goto l1001
+					l1002:
+						position, tokenIndex = position1001, tokenIndex1001
+						if buffer[position] != rune('D') {
+							goto l988
+						}
+						position++
+					}
+				l1001:
+					if !_rules[rulesp]() {
+						goto l988
+					}
+					{
+						position1003, tokenIndex1003 := position, tokenIndex
+						if buffer[position] != rune('f') {
+							goto l1004
+						}
+						position++
+						goto l1003
+					l1004:
+						position, tokenIndex = position1003, tokenIndex1003
+						if buffer[position] != rune('F') {
+							goto l988
+						}
+						position++
+					}
+				l1003:
+					{
+						position1005, tokenIndex1005 := position, tokenIndex
+						if buffer[position] != rune('u') {
+							goto l1006
+						}
+						position++
+						goto l1005
+					l1006:
+						position, tokenIndex = position1005, tokenIndex1005
+						if buffer[position] != rune('U') {
+							goto l988
+						}
+						position++
+					}
+				l1005:
+					{
+						position1007, tokenIndex1007 := position, tokenIndex
+						if buffer[position] != rune('n') {
+							goto l1008
+						}
+						position++
+						goto l1007
+					l1008:
+						position, tokenIndex = position1007, tokenIndex1007
+						if buffer[position] != rune('N') {
+							goto l988
+						}
+						position++
+					}
+				l1007:
+					{
+						position1009, tokenIndex1009 := position, tokenIndex
+						if buffer[position] != rune('c') {
+							goto l1010
+						}
+						position++
+						goto l1009
+					l1010:
+						position, tokenIndex = position1009, tokenIndex1009
+						if buffer[position] != rune('C') {
+							goto l988
+						}
+						position++
+					}
+				l1009:
+					{
+						position1011, tokenIndex1011 := position, tokenIndex
+						if buffer[position] != rune('t') {
+							goto l1012
+						}
+						position++
+						goto l1011
+					l1012:
+						position, tokenIndex = position1011, tokenIndex1011
+						if buffer[position] != rune('T') {
+							goto l988
+						}
+						position++
+					}
+				l1011:
+					{
+						position1013, tokenIndex1013 := position, tokenIndex
+						if buffer[position] != rune('i') {
+							goto l1014
+						}
+						position++
+						goto l1013
+					l1014:
+						position, tokenIndex = position1013, tokenIndex1013
+						if buffer[position] != rune('I') {
+							goto l988
+						}
+						position++
+					}
+				l1013:
+					{
+						position1015, tokenIndex1015 := position, tokenIndex
+						if buffer[position] != rune('o') {
+							goto l1016
+						}
+						position++
+						goto l1015
+					l1016:
+						position, tokenIndex = position1015, tokenIndex1015
+						if buffer[position] != rune('O') {
+							goto l988
+						}
+						position++
+					}
+				l1015:
+					{
+						position1017, tokenIndex1017 := position, tokenIndex
+						if buffer[position] != rune('n') {
+							goto l1018
+						}
+						position++
+						goto l1017
+					l1018:
+						position, tokenIndex = position1017, tokenIndex1017
+						if buffer[position] != rune('N') {
+							goto l988
+						}
+						position++
+					}
+				l1017:
+					if !_rules[rulesp]() {
+						goto l988
+					}
+					if !_rules[ruleFunction]() {
+						goto l988
+					}
+					add(rulePegText, position990)
+				}
+				if !_rules[ruleAction40]() {
+					goto l988
+				}
+				add(ruleReloadFunctionStmt, position989)
+			}
+			return true
+		l988:
+			position, tokenIndex = position988, tokenIndex988
+			return false
+		},
+		/* 46 SetConstantStmt <- <(('s' / 'S') ('e' / 'E') ('t' / 'T') sp (('c' / 'C') ('o' / 'O') ('n' / 'N') ('s' / 'S') ('t' / 'T') ('a' / 'A') ('n' / 'N') ('t' / 'T')) sp StreamIdentifier spOpt '=' spOpt Expression Action41)> */
+		func() bool {
+			position1019, tokenIndex1019 := position, tokenIndex
+			{
+				position1020 := position
+				{
+					position1021, tokenIndex1021 := position, tokenIndex
+					if buffer[position] != rune('s') {
+						goto l1022
+					}
+					position++
+					goto l1021
+				l1022:
+					position, tokenIndex = position1021, tokenIndex1021
+					if buffer[position] != rune('S') {
+						goto l1019
+					}
+					position++
+				}
+			l1021:
+				{
+					position1023, tokenIndex1023 := position, tokenIndex
+					if buffer[position] != rune('e') {
+						goto l1024
+					}
+					position++
+					goto l1023
+				l1024:
+					position, tokenIndex = position1023, tokenIndex1023
+					if buffer[position] != rune('E') {
+						goto l1019
+					}
+					position++
+				}
+			l1023:
+				{
+					position1025, tokenIndex1025 := position, tokenIndex
+					if buffer[position] != rune('t') {
+						goto l1026
+					}
+					position++
+					goto l1025
+				l1026:
+					position, tokenIndex = position1025, tokenIndex1025
+					if buffer[position] != rune('T') {
+						goto l1019
+					}
+					position++
+				}
+			l1025:
+				if !_rules[rulesp]() {
+					goto l1019
+				}
+				{
+					position1027, tokenIndex1027 := position, tokenIndex
+					if buffer[position] != rune('c') {
+						goto l1028
+					}
+					position++
+					goto l1027
+				l1028:
+					position, tokenIndex = position1027, tokenIndex1027
+					if buffer[position] != rune('C') {
+						goto l1019
+					}
+					position++
+				}
+			l1027:
+				{
+					position1029, tokenIndex1029 := position, tokenIndex
+					if buffer[position] != rune('o') {
+						goto l1030
+					}
+					position++
+					goto l1029
+				l1030:
+					position, tokenIndex = position1029, tokenIndex1029
+					if buffer[position] != rune('O') {
+						goto l1019
+					}
+					position++
+				}
+			l1029:
+				{
+					position1031, tokenIndex1031 := position, tokenIndex
+					if buffer[position] != rune('n') {
+						goto l1032
+					}
+					position++
+					goto l1031
+				l1032:
+					position, tokenIndex = position1031, tokenIndex1031
+					if buffer[position] != rune('N') {
+						goto l1019
+					}
+					position++
+				}
+			l1031:
+				{
+					position1033, tokenIndex1033 := position, tokenIndex
+					if buffer[position] != rune('s') {
+						goto l1034
+					}
+					position++
+					goto l1033
+				l1034:
+					position, tokenIndex = position1033, tokenIndex1033
+					if buffer[position] != rune('S') {
+						goto l1019
+					}
+					position++
+				}
+			l1033:
+				{
+					position1035, tokenIndex1035 := position, tokenIndex
+					if buffer[position] != rune('t') {
+						goto l1036
+					}
+					position++
+					goto l1035
+				l1036:
+					position, tokenIndex = position1035, tokenIndex1035
+					if buffer[position] != rune('T') {
+						goto l1019
+					}
+					position++
+				}
+			l1035:
+				{
+					position1037, tokenIndex1037 := position, tokenIndex
+					if buffer[position] != rune('a') {
+						goto l1038
+					}
+					position++
+					goto l1037
+				l1038:
+					position, tokenIndex = position1037, tokenIndex1037
+					if buffer[position] != rune('A') {
+						goto l1019
+					}
+					position++
+				}
+			l1037:
+				{
+					position1039, tokenIndex1039 := position, tokenIndex
+					if buffer[position] != rune('n') {
+						goto l1040
+					}
+					position++
+					goto l1039
+				l1040:
+					position, tokenIndex = position1039, tokenIndex1039
+					if buffer[position] != rune('N') {
+						goto l1019
+					}
+					position++
+				}
+			l1039:
+				{
+					position1041, tokenIndex1041 := position, tokenIndex
+					if buffer[position] != rune('t') {
+						goto l1042
+					}
+					position++
+					goto l1041
+				l1042:
+					position, tokenIndex = position1041, tokenIndex1041
+					if buffer[position] != rune('T') {
+						goto l1019
+					}
+					position++
+				}
+			l1041:
+				if !_rules[rulesp]() {
+					goto l1019
+				}
+				if !_rules[ruleStreamIdentifier]() {
+					goto l1019
+				}
+				if !_rules[rulespOpt]() {
+					goto l1019
+				}
+				if buffer[position] != rune('=') {
+					goto l1019
+				}
+				position++
+				if !_rules[rulespOpt]() {
+					goto l1019
+				}
+				if !_rules[ruleExpression]() {
+					goto l1019
+				}
+				if !_rules[ruleAction41]() {
+					goto l1019
+				}
+				add(ruleSetConstantStmt, position1020)
+			}
+			return true
+		l1019:
+			position, tokenIndex = position1019, tokenIndex1019
+			return false
+		},
+		/* 47 Emitter <- <(sp (ISTREAM / DSTREAM / RSTREAM) EmitterOptions Action42)> */
+		func() bool {
+			position1043, tokenIndex1043 := position, tokenIndex
+			{
+				position1044 := position
+				if !_rules[rulesp]() {
+					goto l1043
+				}
+				{
+					position1045, tokenIndex1045 := position, tokenIndex
+					if !_rules[ruleISTREAM]() {
+						goto l1046
+					}
+					goto l1045
+				l1046:
+					position, tokenIndex = position1045, tokenIndex1045
+					if !_rules[ruleDSTREAM]() {
+						goto l1047
+					}
+					goto l1045
+				l1047:
+					position, tokenIndex = position1045, tokenIndex1045
+					if !_rules[ruleRSTREAM]() {
+						goto l1043
+					}
+				}
+			l1045:
+				if !_rules[ruleEmitterOptions]() {
+					goto l1043
+				}
+				if !_rules[ruleAction42]() {
+					goto l1043
+				}
+				add(ruleEmitter, position1044)
+			}
+			return true
+		l1043:
+			position, tokenIndex = position1043, tokenIndex1043
+			return false
+		},
+		/* 48 EmitterOptions <- <(<(spOpt '[' spOpt EmitterOptionCombinations spOpt ']')?> Action43)> */
+		func() bool {
+			position1048, tokenIndex1048 := position, tokenIndex
+			{
+				position1049 := position
+				{
+					position1050 := position
+					{
+						position1051, tokenIndex1051 := position, tokenIndex
+						if !_rules[rulespOpt]() {
+							goto l1051
+						}
+						if buffer[position] != rune('[') {
+							goto l1051
+						}
+						position++
+						if !_rules[rulespOpt]() {
+							goto l1051
+						}
+						if !_rules[ruleEmitterOptionCombinations]() {
+							goto l1051
+						}
+						if !_rules[rulespOpt]() {
+							goto l1051
+						}
+						if buffer[position] != rune(']') {
+							goto l1051
+						}
+						position++
+						goto l1052
+					l1051:
+						position, tokenIndex = position1051, tokenIndex1051
+					}
+				l1052:
+					add(rulePegText, position1050)
+				}
+				if !_rules[ruleAction43]() {
+					goto l1048
+				}
+				add(ruleEmitterOptions, position1049)
+			}
+			return true
+		l1048:
+			position, tokenIndex = position1048, tokenIndex1048
+			return false
+		},
+		/* 49 EmitterOptionCombinations <- <(EmitterLimit / (EmitterSample sp EmitterLimit) / EmitterSample)> */
+		func() bool {
+			position1053, tokenIndex1053 := position, tokenIndex
+			{
+				position1054 := position
+				{
+					position1055, tokenIndex1055 := position, tokenIndex
+					if !_rules[ruleEmitterLimit]() {
+						goto l1056
+					}
+					goto l1055
+				l1056:
+					position, tokenIndex = position1055, tokenIndex1055
+					if !_rules[ruleEmitterSample]() {
+						goto l1057
+					}
+					if !_rules[rulesp]() {
+						goto l1057
+					}
+					if !_rules[ruleEmitterLimit]() {
+						goto l1057
+					}
+					goto l1055
+				l1057:
+					position, tokenIndex = position1055, tokenIndex1055
+					if !_rules[ruleEmitterSample]() {
+						goto l1053
+					}
+				}
+			l1055:
+				add(ruleEmitterOptionCombinations, position1054)
+			}
+			return true
+		l1053:
+			position, tokenIndex = position1053, tokenIndex1053
+			return false
+		},
+		/* 50 EmitterLimit <- <(('l' / 'L') ('i' / 'I') ('m' / 'M') ('i' / 'I') ('t' / 'T') sp NumericLiteral Action44)> */
+		func() bool {
+			position1058, tokenIndex1058 := position, tokenIndex
+			{
+				position1059 := position
+				{
+					position1060, tokenIndex1060 := position, tokenIndex
+					if buffer[position] != rune('l') {
+						goto l1061
+					}
+					position++
+					goto l1060
+				l1061:
+					position, tokenIndex = position1060, tokenIndex1060
+					if buffer[position] != rune('L') {
+						goto l1058
+					}
+					position++
+				}
+			l1060:
+				{
+					position1062, tokenIndex1062 := position, tokenIndex
+					if buffer[position] != rune('i') {
+						goto l1063
+					}
+					position++
+					goto l1062
+				l1063:
+					position, tokenIndex = position1062, tokenIndex1062
+					if buffer[position] != rune('I') {
+						goto l1058
+					}
+					position++
+				}
+			l1062:
+				{
+					position1064, tokenIndex1064 := position, tokenIndex
+					if buffer[position] != rune('m') {
+						goto l1065
+					}
+					position++
+					goto l1064
+				l1065:
+					position, tokenIndex = position1064, tokenIndex1064
+					if buffer[position] != rune('M') {
+						goto l1058
+					}
+					position++
+				}
+			l1064:
+				{
+					position1066, tokenIndex1066 := position, tokenIndex
+					if buffer[position] != rune('i') {
+						goto l1067
+					}
+					position++
+					goto l1066
+				l1067:
+					position, tokenIndex = position1066, tokenIndex1066
+					if buffer[position] != rune('I') {
+						goto l1058
+					}
+					position++
+				}
+			l1066:
+				{
+					position1068, tokenIndex1068 := position, tokenIndex
+					if buffer[position] != rune('t') {
+						goto l1069
+					}
+					position++
+					goto l1068
+				l1069:
+					position, tokenIndex = position1068, tokenIndex1068
+					if buffer[position] != rune('T') {
+						goto l1058
+					}
+					position++
+				}
+			l1068:
+				if !_rules[rulesp]() {
+					goto l1058
+				}
+				if !_rules[ruleNumericLiteral]() {
+					goto l1058
+				}
+				if !_rules[ruleAction44]() {
+					goto l1058
+				}
+				add(ruleEmitterLimit, position1059)
+			}
+			return true
+		l1058:
+			position, tokenIndex = position1058, tokenIndex1058
+			return false
+		},
+		/* 51 EmitterSample <- <(CountBasedSampling / RandomizedSampling / TimeBasedSampling)> */
+		func() bool {
+			position1070, tokenIndex1070 := position, tokenIndex
+			{
+				position1071 := position
+				{
+					position1072, tokenIndex1072 := position, tokenIndex
+					if !_rules[ruleCountBasedSampling]() {
+						goto l1073
+					}
+					goto l1072
+				l1073:
+					position, tokenIndex = position1072, tokenIndex1072
+					if !_rules[ruleRandomizedSampling]() {
+						goto l1074
+					}
+					goto l1072
+				l1074:
+					position, tokenIndex = position1072, tokenIndex1072
+					if !_rules[ruleTimeBasedSampling]() {
+						goto l1070
+					}
+				}
+			l1072:
+				add(ruleEmitterSample, position1071)
+			}
+			return true
+		l1070:
+			position, tokenIndex = position1070, tokenIndex1070
+			return false
+		},
+		/* 52 CountBasedSampling <- <(('e' / 'E') ('v' / 'V') ('e' / 'E') ('r' / 'R') ('y' / 'Y') sp NumericLiteral spOpt '-'? spOpt ((('s' / 'S') ('t' / 'T')) / (('n' / 'N') ('d' / 'D')) / (('r' / 'R') ('d' / 'D')) / (('t' / 'T') ('h' / 'H'))) sp (('t' / 'T') ('u' / 'U') ('p' / 'P') ('l' / 'L') ('e' / 'E')) Action45)> */
+		func() bool {
+			position1075, tokenIndex1075 := position, tokenIndex
+			{
+				position1076 := position
+				{
+					position1077, tokenIndex1077 := position, tokenIndex
+					if buffer[position] != rune('e') {
+						goto l1078
+					}
+					position++
+					goto l1077
+				l1078:
+					position, tokenIndex = position1077, tokenIndex1077
+					if buffer[position] != rune('E') {
+						goto l1075
+					}
+					position++
+				}
+			l1077:
+				{
+					position1079, tokenIndex1079 := position, tokenIndex
+					if buffer[position] != rune('v') {
+						goto l1080
+					}
+					position++
+					goto l1079
+				l1080:
+					position, tokenIndex = position1079, tokenIndex1079
+					if buffer[position] != rune('V') {
+						goto l1075
+					}
+					position++
+				}
+			l1079:
+				{
+					position1081, tokenIndex1081 := position, tokenIndex
+					if buffer[position] != rune('e') {
+						goto l1082
+					}
+					position++
+					goto l1081
+				l1082:
+					position, tokenIndex = position1081, tokenIndex1081
+					if buffer[position] != rune('E') {
+						goto l1075
+					}
+					position++
+				}
+			l1081:
+				{
+					position1083, tokenIndex1083 := position, tokenIndex
+					if buffer[position] != rune('r') {
+						goto l1084
+					}
+					position++
+					goto l1083
+				l1084:
+					position, tokenIndex = position1083, tokenIndex1083
+					if buffer[position] != rune('R') {
+						goto l1075
+					}
+					position++
+				}
+			l1083:
+				{
+					position1085, tokenIndex1085 := position, tokenIndex
+					if buffer[position] != rune('y') {
+						goto l1086
+					}
+					position++
+					goto l1085
+				l1086:
+					position, tokenIndex = position1085, tokenIndex1085
+					if buffer[position] != rune('Y') {
+						goto l1075
+					}
+					position++
+				}
+			l1085:
+				if !_rules[rulesp]() {
+					goto l1075
+				}
+				if !_rules[ruleNumericLiteral]() {
+					goto l1075
+				}
+				if !_rules[rulespOpt]() {
+					goto l1075
+				}
+				{
+					position1087, tokenIndex1087 := position, tokenIndex
+					if buffer[position] != rune('-') {
+						goto l1087
+					}
+					position++
+					goto l1088
+				l1087:
+					position, tokenIndex = position1087, tokenIndex1087
+				}
+			l1088:
+				if !_rules[rulespOpt]() {
+					goto l1075
+				}
+				{
+					position1089, tokenIndex1089 := position, tokenIndex
+					{
+						position1091, tokenIndex1091 := position, tokenIndex
+						if buffer[position] != rune('s') {
+							goto l1092
+						}
+						position++
+						goto l1091
+					l1092:
+						position, tokenIndex = position1091, tokenIndex1091
+						if buffer[position] != rune('S') {
+							goto l1090
+						}
+						position++
+					}
+				l1091:
+					{
+						position1093, tokenIndex1093 := position, tokenIndex
+						if buffer[position] != rune('t') {
+							goto l1094
+						}
+						position++
+						goto l1093
+					l1094:
+						position, tokenIndex = position1093, tokenIndex1093
+						if buffer[position] != rune('T') {
+							goto l1090
+						}
+						position++
+					}
+				l1093:
+					goto l1089
+				l1090:
+					position, tokenIndex = position1089, tokenIndex1089
+					{
+						position1096, tokenIndex1096 := position, tokenIndex
+						if buffer[position] != rune('n') {
+							goto l1097
+						}
+						position++
+						goto l1096
+					l1097:
+						position, tokenIndex = position1096, tokenIndex1096
+						if buffer[position] != rune('N') {
+							goto l1095
+						}
+						position++
+					}
+				l1096:
+					{
+						position1098, tokenIndex1098 := position, tokenIndex
+						if buffer[position] != rune('d') {
+							goto l1099
+						}
+						position++
+						goto l1098
+					l1099:
+						position, tokenIndex = position1098, tokenIndex1098
+						if buffer[position] != rune('D') {
+							goto l1095
+						}
+						position++
+					}
+				l1098:
+					goto l1089
+				l1095:
+					position, tokenIndex = position1089, tokenIndex1089
+					{
+						position1101, tokenIndex1101 := position, tokenIndex
+						if buffer[position] != rune('r') {
+							goto l1102
+						}
+						position++
+						goto l1101
+					l1102:
+						position, tokenIndex = position1101, tokenIndex1101
+						if buffer[position] != rune('R') {
+							goto l1100
+						}
+						position++
+					}
+				l1101:
+					{
+						position1103, tokenIndex1103 := position, tokenIndex
+						if buffer[position] != rune('d') {
+							goto l1104
+						}
+						position++
+						goto l1103
+					l1104:
+						position, tokenIndex = position1103, tokenIndex1103
+						if buffer[position] != rune('D') {
+							goto l1100
+						}
+						position++
+					}
+				l1103:
+					goto l1089
+				l1100:
+					position, tokenIndex = position1089, tokenIndex1089
+					{
+						position1105, tokenIndex1105 := position, tokenIndex
+						if buffer[position] != rune('t') {
+							goto l1106
+						}
+						position++
+						goto l1105
+					l1106:
+						position, tokenIndex = position1105, tokenIndex1105
+						if buffer[position] != rune('T') {
+							goto l1075
+						}
+						position++
+					}
+				l1105:
+					{
+						position1107, tokenIndex1107 := position, tokenIndex
+						if buffer[position] != rune('h') {
+							goto l1108
+						}
+						position++
+						goto l1107
+					l1108:
+						position, tokenIndex = position1107, tokenIndex1107
+						if buffer[position] != rune('H') {
+							goto l1075
+						}
+						position++
+					}
+				l1107:
+				}
+			l1089:
+				if !_rules[rulesp]() {
+					goto l1075
+				}
+				{
+					position1109, tokenIndex1109 := position, tokenIndex
+					if buffer[position] != rune('t') {
+						goto l1110
+					}
+					position++
+					goto l1109
+				l1110:
+					position, tokenIndex = position1109, tokenIndex1109
+					if buffer[position] != rune('T') {
+						goto l1075
+					}
+					position++
+				}
+			l1109:
+				{
+					position1111, tokenIndex1111 := position, tokenIndex
+					if buffer[position] != rune('u') {
+						goto l1112
+					}
+					position++
+					goto l1111
+				l1112:
+					position, tokenIndex = position1111, tokenIndex1111
+					if buffer[position] != rune('U') {
+						goto l1075
+					}
+					position++
+				}
+			l1111:
+				{
+					position1113, tokenIndex1113 := position, tokenIndex
+					if buffer[position] != rune('p') {
+						goto l1114
+					}
+					position++
+					goto l1113
+				l1114:
+					position, tokenIndex = position1113, tokenIndex1113
+					if buffer[position] != rune('P') {
+						goto l1075
+					}
+					position++
+				}
+			l1113:
+				{
+					position1115, tokenIndex1115 := position, tokenIndex
+					if buffer[position] != rune('l') {
+						goto l1116
+					}
+					position++
+					goto l1115
+				l1116:
+					position, tokenIndex = position1115, tokenIndex1115
+					if buffer[position] != rune('L') {
+						goto l1075
+					}
+					position++
+				}
+			l1115:
+				{
+					position1117, tokenIndex1117 := position, tokenIndex
+					if buffer[position] != rune('e') {
+						goto l1118
+					}
+					position++
+					goto l1117
+				l1118:
+					position, tokenIndex = position1117, tokenIndex1117
+					if buffer[position] != rune('E') {
+						goto l1075
+					}
+					position++
+				}
+			l1117:
+				if !_rules[ruleAction45]() {
+					goto l1075
+				}
+				add(ruleCountBasedSampling, position1076)
+			}
+			return true
+		l1075:
+			position, tokenIndex = position1075, tokenIndex1075
+			return false
+		},
+		/* 53 RandomizedSampling <- <(('s' / 'S') ('a' / 'A') ('m' / 'M') ('p' / 'P') ('l' / 'L') ('e' / 'E') sp (FloatLiteral / NumericLiteral) spOpt '%' Action46)> */
+		func() bool {
+			position1119, tokenIndex1119 := position, tokenIndex
+			{
+				position1120 := position
+				{
+					position1121, tokenIndex1121 := position, tokenIndex
+					if buffer[position] != rune('s') {
+						goto l1122
+					}
+					position++
+					goto l1121
+				l1122:
+					position, tokenIndex = position1121, tokenIndex1121
+					if buffer[position] != rune('S') {
+						goto l1119
+					}
+					position++
+				}
+			l1121:
+				{
+					position1123, tokenIndex1123 := position, tokenIndex
+					if buffer[position] != rune('a') {
+						goto l1124
+					}
+					position++
+					goto l1123
+				l1124:
+					position, tokenIndex = position1123, tokenIndex1123
+					if buffer[position] != rune('A') {
+						goto l1119
+					}
+					position++
+				}
+			l1123:
+				{
+					position1125, tokenIndex1125 := position, tokenIndex
+					if buffer[position] != rune('m') {
+						goto l1126
+					}
+					position++
+					goto l1125
+				l1126:
+					position, tokenIndex = position1125, tokenIndex1125
+					if buffer[position] != rune('M') {
+						goto l1119
+					}
+					position++
+				}
+			l1125:
+				{
+					position1127, tokenIndex1127 := position, tokenIndex
+					if buffer[position] != rune('p') {
+						goto l1128
+					}
+					position++
+					goto l1127
+				l1128:
+					position, tokenIndex = position1127, tokenIndex1127
+					if buffer[position] != rune('P') {
+						goto l1119
+					}
+					position++
+				}
+			l1127:
+				{
+					position1129, tokenIndex1129 := position, tokenIndex
+					if buffer[position] != rune('l') {
+						goto l1130
+					}
+					position++
+					goto l1129
+				l1130:
+					position, tokenIndex = position1129, tokenIndex1129
+					if buffer[position] != rune('L') {
+						goto l1119
+					}
+					position++
+				}
+			l1129:
+				{
+					position1131, tokenIndex1131 := position, tokenIndex
+					if buffer[position] != rune('e') {
+						goto l1132
+					}
+					position++
+					goto l1131
+				l1132:
+					position, tokenIndex = position1131, tokenIndex1131
+					if buffer[position] != rune('E') {
+						goto l1119
+					}
+					position++
+				}
+			l1131:
+				if !_rules[rulesp]() {
+					goto l1119
+				}
+				{
+					position1133, tokenIndex1133 := position, tokenIndex
+					if !_rules[ruleFloatLiteral]() {
+						goto l1134
+					}
+					goto l1133
+				l1134:
+					position, tokenIndex = position1133, tokenIndex1133
+					if !_rules[ruleNumericLiteral]() {
+						goto l1119
+					}
+				}
+			l1133:
+				if !_rules[rulespOpt]() {
+					goto l1119
+				}
+				if buffer[position] != rune('%') {
+					goto l1119
+				}
+				position++
+				if !_rules[ruleAction46]() {
+					goto l1119
+				}
+				add(ruleRandomizedSampling, position1120)
+			}
+			return true
+		l1119:
+			position, tokenIndex = position1119, tokenIndex1119
+			return false
+		},
+		/* 54 TimeBasedSampling <- <(TimeBasedSamplingSeconds / TimeBasedSamplingMilliseconds)> */
+		func() bool {
+			position1135, tokenIndex1135 := position, tokenIndex
+			{
+				position1136 := position
+				{
+					position1137, tokenIndex1137 := position, tokenIndex
+					if !_rules[ruleTimeBasedSamplingSeconds]() {
+						goto l1138
+					}
+					goto l1137
+				l1138:
+					position, tokenIndex = position1137, tokenIndex1137
+					if !_rules[ruleTimeBasedSamplingMilliseconds]() {
+						goto l1135
+					}
+				}
+			l1137:
+				add(ruleTimeBasedSampling, position1136)
+			}
+			return true
+		l1135:
+			position, tokenIndex = position1135, tokenIndex1135
+			return false
+		},
+		/* 55 TimeBasedSamplingSeconds <- <(('e' / 'E') ('v' / 'V') ('e' / 'E') ('r' / 'R') ('y' / 'Y') sp (FloatLiteral / NumericLiteral) sp (('s' / 'S') ('e' / 'E') ('c' / 'C') ('o' / 'O') ('n' / 'N') ('d' / 'D') ('s' / 'S')) Action47)> */
+		func() bool {
+			position1139, tokenIndex1139 := position, tokenIndex
+			{
+				position1140 := position
+				{
+					position1141, tokenIndex1141 := position, tokenIndex
+					if buffer[position] != rune('e') {
+						goto l1142
+					}
+					position++
+					goto l1141
+				l1142:
+					position, tokenIndex = position1141, tokenIndex1141
+					if buffer[position] != rune('E') {
+						goto l1139
+					}
+					position++
+				}
+			l1141:
+				{
+					position1143, tokenIndex1143 := position, tokenIndex
+					if buffer[position] != rune('v') {
+						goto l1144
+					}
+					position++
+					goto l1143
+				l1144:
+					position, tokenIndex = position1143, tokenIndex1143
+					if buffer[position] != rune('V') {
+						goto l1139
+					}
+					position++
+				}
+			l1143:
+				{
+					position1145, tokenIndex1145 := position, tokenIndex
+					if buffer[position] != rune('e') {
+						goto l1146
+					}
+					position++
+					goto l1145
+				l1146:
+					position, tokenIndex = position1145, tokenIndex1145
+					if buffer[position] != rune('E') {
+						goto l1139
+					}
+					position++
+				}
+			l1145:
+				{
+					position1147, tokenIndex1147 := position, tokenIndex
+					if buffer[position] != rune('r') {
+						goto l1148
+					}
+					position++
+					goto l1147
+				l1148:
+					position, tokenIndex = position1147, tokenIndex1147
+					if buffer[position] != rune('R') {
+						goto l1139
+					}
+					position++
+				}
+			l1147:
+				{
+					position1149, tokenIndex1149 := position, tokenIndex
+					if buffer[position] != rune('y') {
+						goto l1150
+					}
+					position++
+					goto l1149
+				l1150:
+					position, tokenIndex = position1149, tokenIndex1149
+					if buffer[position] != rune('Y') {
+						goto l1139
+					}
+					position++
+				}
+			l1149:
+				if !_rules[rulesp]() {
+					goto l1139
+				}
+				{
+					position1151, tokenIndex1151 := position, tokenIndex
+					if !_rules[ruleFloatLiteral]() {
+						goto l1152
+					}
+					goto l1151
+				l1152:
+					position, tokenIndex = position1151, tokenIndex1151
+					if !_rules[ruleNumericLiteral]() {
+						goto l1139
+					}
+				}
+			l1151:
+				if !_rules[rulesp]() {
+					goto l1139
+				}
+				{
+					position1153, tokenIndex1153 := position, tokenIndex
+					if buffer[position] != rune('s') {
+						goto l1154
+					}
+					position++
+					goto l1153
+				l1154:
+					position, tokenIndex = position1153, tokenIndex1153
+					if buffer[position] != rune('S') {
+						goto l1139
+					}
+					position++
+				}
+			l1153:
+				{
+					position1155, tokenIndex1155 := position, tokenIndex
+					if buffer[position] != rune('e') {
+						goto l1156
+					}
+					position++
+					goto l1155
+				l1156:
+					position, tokenIndex = position1155, tokenIndex1155
+					if buffer[position] != rune('E') {
+						goto l1139
+					}
+					position++
+				}
+			l1155:
+				{
+					position1157, tokenIndex1157 := position, tokenIndex
+					if buffer[position] != rune('c') {
+						goto l1158
+					}
+					position++
+					goto l1157
+				l1158:
+					position, tokenIndex = position1157, tokenIndex1157
+					if buffer[position] != rune('C') {
+						goto l1139
+					}
+					position++
+				}
+			l1157:
+				{
+					position1159, tokenIndex1159 := position, tokenIndex
+					if buffer[position] != rune('o') {
+						goto l1160
+					}
+					position++
+					goto l1159
+				l1160:
+					position, tokenIndex = position1159, tokenIndex1159
+					if buffer[position] != rune('O') {
+						goto l1139
+					}
+					position++
+				}
+			l1159:
+				{
+					position1161, tokenIndex1161 := position, tokenIndex
+					if buffer[position] != rune('n') {
+						goto l1162
+					}
+					position++
+					goto l1161
+				l1162:
+					position, tokenIndex = position1161, tokenIndex1161
+					if buffer[position] != rune('N') {
+						goto l1139
+					}
+					position++
+				}
+			l1161:
+				{
+					position1163, tokenIndex1163 := position, tokenIndex
+					if buffer[position] != rune('d') {
+						goto l1164
+					}
+					position++
+					goto l1163
+				l1164:
+					position, tokenIndex = position1163, tokenIndex1163
+					if buffer[position] != rune('D') {
+						goto l1139
+					}
+					position++
+				}
+			l1163:
+				{
+					position1165, tokenIndex1165 := position, tokenIndex
+					if buffer[position] != rune('s') {
+						goto l1166
+					}
+					position++
+					goto l1165
+				l1166:
+					position, tokenIndex = position1165, tokenIndex1165
+					if buffer[position] != rune('S') {
+						goto l1139
+					}
+					position++
+				}
+			l1165:
+				if !_rules[ruleAction47]() {
+					goto l1139
+				}
+				add(ruleTimeBasedSamplingSeconds, position1140)
+			}
+			return true
+		l1139:
+			position, tokenIndex = position1139, tokenIndex1139
+			return false
+		},
+		/* 56 TimeBasedSamplingMilliseconds <- <(('e' / 'E') ('v' / 'V') ('e' / 'E') ('r' / 'R') ('y' / 'Y') sp (FloatLiteral / NumericLiteral) sp (('m' / 'M') ('i' / 'I') ('l' / 'L') ('l' / 'L') ('i' / 'I') ('s' / 'S') ('e' / 'E') ('c' / 'C') ('o' / 'O') ('n' / 'N') ('d' / 'D') ('s' / 'S')) Action48)> */
+		func() bool {
+			position1167, tokenIndex1167 := position, tokenIndex
+			{
+				position1168 := position
+				{
+					position1169, tokenIndex1169 := position, tokenIndex
+					if buffer[position] != rune('e') {
+						goto l1170
+					}
+					position++
+					goto l1169
+				l1170:
+					position, tokenIndex = position1169, tokenIndex1169
+					if buffer[position] != rune('E') {
+						goto l1167
+					}
+					position++
+				}
+			l1169:
+				{
+					position1171, tokenIndex1171 := position, tokenIndex
+					if buffer[position] != rune('v') {
+						goto l1172
+					}
+					position++
+					goto l1171
+				l1172:
+					position, tokenIndex = position1171, tokenIndex1171
+					if buffer[position] != rune('V') {
+						goto l1167
+					}
+					position++
+				}
+			l1171:
+				{
+					position1173, tokenIndex1173 := position, tokenIndex
+					if buffer[position] != rune('e') {
+						goto l1174
+					}
+					position++
+					goto l1173
+				l1174:
+					position, tokenIndex = position1173, tokenIndex1173
+					if buffer[position] != rune('E') {
+						goto l1167
+					}
+					position++
+				}
+			l1173:
+				{
+					position1175, tokenIndex1175 := position, tokenIndex
+					if buffer[position] != rune('r') {
+						goto l1176
+					}
+					position++
+					goto l1175
+				l1176:
+					position, tokenIndex = position1175, tokenIndex1175
+					if buffer[position] != rune('R') {
+						goto l1167
+					}
+					position++
+				}
+			l1175:
+				{
+					position1177, tokenIndex1177 := position, tokenIndex
+					if buffer[position] != rune('y') {
+						goto l1178
+					}
+					position++
+					goto l1177
+				l1178:
+					position, tokenIndex = position1177, tokenIndex1177
+					if buffer[position] != rune('Y') {
+						goto l1167
+					}
+					position++
+				}
+			l1177:
+				if !_rules[rulesp]() {
+					goto l1167
+				}
+				{
+					position1179, tokenIndex1179 := position, tokenIndex
+					if !_rules[ruleFloatLiteral]() {
+						goto l1180
+					}
+					goto l1179
+				l1180:
+					position, tokenIndex = position1179, tokenIndex1179
+					if !_rules[ruleNumericLiteral]() {
+						goto l1167
+					}
+				}
+			l1179:
+				if !_rules[rulesp]() {
+					goto l1167
+				}
+				{
+					position1181, tokenIndex1181 := position, tokenIndex
+					if buffer[position] != rune('m') {
+						goto l1182
+					}
+					position++
+					goto l1181
+				l1182:
+					position, tokenIndex = position1181, tokenIndex1181
+					if buffer[position] != rune('M') {
+						goto l1167
+					}
+					position++
+				}
+			l1181:
+				{
+					position1183, tokenIndex1183 := position, tokenIndex
+					if buffer[position] != rune('i') {
+						goto l1184
+					}
+					position++
+					goto l1183
+				l1184:
+					position, tokenIndex = position1183, tokenIndex1183
+					if buffer[position] != rune('I') {
+						goto l1167
+					}
+					position++
+				}
+			l1183:
+				{
+					position1185, tokenIndex1185 := position, tokenIndex
+					if buffer[position] != rune('l') {
+						goto l1186
+					}
+					position++
+					goto l1185
+				l1186:
+					position, tokenIndex = position1185, tokenIndex1185
+					if buffer[position] != rune('L') {
+						goto l1167
+					}
+					position++
+				}
+			l1185:
+				{
+					position1187, tokenIndex1187 := position, tokenIndex
+					if buffer[position] != rune('l') {
+						goto l1188
+					}
+					position++
+					goto l1187
+				l1188:
+					position, tokenIndex = position1187, tokenIndex1187
+					if buffer[position] != rune('L') {
+						goto l1167
+					}
+					position++
+				}
+			l1187:
+				{
+					position1189, tokenIndex1189 := position, tokenIndex
+					if buffer[position] != rune('i') {
+						goto l1190
+					}
+					position++
+					goto l1189
+				l1190:
+					position, tokenIndex = position1189, tokenIndex1189
+					if buffer[position] != rune('I') {
+						goto l1167
+					}
+					position++
+				}
+			l1189:
+				{
+					position1191, tokenIndex1191 := position, tokenIndex
+					if buffer[position] != rune('s') {
+						goto l1192
+					}
+					position++
+					goto l1191
+				l1192:
+					position, tokenIndex = position1191, tokenIndex1191
+					if buffer[position] != rune('S') {
+						goto l1167
+					}
+					position++
+				}
+			l1191:
+				{
+					position1193, tokenIndex1193 := position, tokenIndex
+					if buffer[position] != rune('e') {
+						goto l1194
+					}
+					position++
+					goto l1193
+				l1194:
+					position, tokenIndex = position1193, tokenIndex1193
+					if buffer[position] != rune('E') {
+						goto l1167
+					}
+					position++
+				}
+			l1193:
+				{
+					position1195, tokenIndex1195 := position, tokenIndex
+					if buffer[position] != rune('c') {
+						goto l1196
+					}
+					position++
+					goto l1195
+				l1196:
+					position, tokenIndex = position1195, tokenIndex1195
+					if buffer[position] != rune('C') {
+						goto l1167
+					}
+					position++
+				}
+			l1195:
+				{
+					position1197, tokenIndex1197 := position, tokenIndex
+					if buffer[position] != rune('o') {
+						goto l1198
+					}
+					position++
+					goto l1197
+				l1198:
+					position, tokenIndex = position1197, tokenIndex1197
+					if buffer[position] != rune('O') {
+						goto l1167
+					}
+					position++
+				}
+			l1197:
+				{
+					position1199, tokenIndex1199 := position, tokenIndex
+					if buffer[position] != rune('n') {
+						goto l1200
+					}
+					position++
+					goto l1199
+				l1200:
+					position, tokenIndex = position1199, tokenIndex1199
+					if buffer[position] != rune('N') {
+						goto l1167
+					}
+					position++
+				}
+			l1199:
+				{
+					position1201, tokenIndex1201 := position, tokenIndex
+					if buffer[position] != rune('d') {
+						goto l1202
+					}
+					position++
+					goto l1201
+				l1202:
+					position, tokenIndex = position1201, tokenIndex1201
+					if buffer[position] != rune('D') {
+						goto l1167
+					}
+					position++
+				}
+			l1201:
+				{
+					position1203, tokenIndex1203 := position, tokenIndex
+					if buffer[position] != rune('s') {
+						goto l1204
+					}
+					position++
+					goto l1203
+				l1204:
+					position, tokenIndex = position1203, tokenIndex1203
+					if buffer[position] != rune('S') {
+						goto l1167
+					}
+					position++
+				}
+			l1203:
+				if !_rules[ruleAction48]() {
+					goto l1167
+				}
+				add(ruleTimeBasedSamplingMilliseconds, position1168)
+			}
+			return true
+		l1167:
+			position, tokenIndex = position1167, tokenIndex1167
+			return false
+		},
+		/* 57 Projections <- <(<(sp Projection (spOpt ',' spOpt Projection)*)> Action49)> */
+		func() bool {
+			position1205, tokenIndex1205 := position, tokenIndex
+			{
+				position1206 := position
+				{
+					position1207 := position
+					if !_rules[rulesp]() {
+						goto l1205
+					}
+					if !_rules[ruleProjection]() {
+						goto l1205
+					}
+				l1208:
+					{
+						position1209, tokenIndex1209 := position, tokenIndex
+						if !_rules[rulespOpt]() {
+							goto l1209
+						}
+						if buffer[position] != rune(',') {
+							goto l1209
+						}
+						position++
+						if !_rules[rulespOpt]() {
+							goto l1209
+						}
+						if !_rules[ruleProjection]() {
+							goto l1209
+						}
+						goto l1208
+					l1209:
+						position, tokenIndex = position1209, tokenIndex1209
+					}
+					add(rulePegText, position1207)
+				}
+				if !_rules[ruleAction49]() {
+					goto l1205
+				}
+				add(ruleProjections, position1206)
+			}
+			return true
+		l1205:
+			position, tokenIndex = position1205, tokenIndex1205
+			return false
+		},
+		/* 58 Projection <- <(AliasExpression / ExpressionOrWildcard)> */
+		func() bool {
+			position1210, tokenIndex1210 := position, tokenIndex
+			{
+				position1211 := position
+				{
+					position1212, tokenIndex1212 := position, tokenIndex
+					if !_rules[ruleAliasExpression]() {
+						goto l1213
+					}
+					goto l1212
+				l1213:
+					position, tokenIndex = position1212, tokenIndex1212
+					if !_rules[ruleExpressionOrWildcard]() {
+						goto l1210
+					}
+				}
+			l1212:
+				add(ruleProjection, position1211)
+			}
+			return true
+		l1210:
+			position, tokenIndex = position1210, tokenIndex1210
+			return false
+		},
+		/* 59 AliasExpression <- <(ExpressionOrWildcard sp (('a' / 'A') ('s' / 'S')) sp TargetIdentifier Action50)> */
+		func() bool {
+			position1214, tokenIndex1214 := position, tokenIndex
+			{
+				position1215 := position
+				if !_rules[ruleExpressionOrWildcard]() {
+					goto l1214
+				}
+				if !_rules[rulesp]() {
+					goto l1214
+				}
+				{
+					position1216, tokenIndex1216 := position, tokenIndex
+					if buffer[position] != rune('a') {
+						goto l1217
+					}
+					position++
+					goto l1216
+				l1217:
+					position, tokenIndex = position1216, tokenIndex1216
+					if buffer[position] != rune('A') {
+						goto l1214
+					}
+					position++
+				}
+			l1216:
+				{
+					position1218, tokenIndex1218 := position, tokenIndex
+					if buffer[position] != rune('s') {
+						goto l1219
+					}
+					position++
+					goto l1218
+				l1219:
+					position, tokenIndex = position1218, tokenIndex1218
+					if buffer[position] != rune('S') {
+						goto l1214
+					}
+					position++
+				}
+			l1218:
+				if !_rules[rulesp]() {
+					goto l1214
+				}
+				if !_rules[ruleTargetIdentifier]() {
+					goto l1214
+				}
+				if !_rules[ruleAction50]() {
+					goto l1214
+				}
+				add(ruleAliasExpression, position1215)
+			}
+			return true
+		l1214:
+			position, tokenIndex = position1214, tokenIndex1214
+			return false
+		},
+		/* 60 WindowedFrom <- <(<(sp (('f' / 'F') ('r' / 'R') ('o' / 'O') ('m' / 'M')) sp Relations)?> Action51)> */
+		func() bool {
+			position1220, tokenIndex1220 := position, tokenIndex
+			{
+				position1221 := position
+				{
+					position1222 := position
+					{
+						position1223, tokenIndex1223 := position, tokenIndex
+						if !_rules[rulesp]() {
+							goto l1223
+						}
+						{
+							position1225, tokenIndex1225 := position, tokenIndex
+							if buffer[position] != rune('f') {
+								goto l1226
+							}
+							position++
+							goto l1225
+						l1226:
+							position, tokenIndex = position1225, tokenIndex1225
+							if buffer[position] != rune('F') {
+								goto l1223
+							}
+							position++
+						}
+					l1225:
+						{
+							position1227, tokenIndex1227 := position, tokenIndex
+							if buffer[position] != rune('r') {
+								goto l1228
+							}
+							position++
+							goto l1227
+						l1228:
+							position, tokenIndex = position1227, tokenIndex1227
+							if buffer[position] != rune('R') {
+								goto l1223
+							}
+							position++
+						}
+					l1227:
+						{
+							position1229, tokenIndex1229 := position, tokenIndex
+							if buffer[position] != rune('o') {
+								goto l1230
+							}
+							position++
+							goto l1229
+						l1230:
+							position, tokenIndex = position1229, tokenIndex1229
+							if buffer[position] != rune('O') {
+								goto l1223
+							}
+							position++
+						}
+					l1229:
+						{
+							position1231, tokenIndex1231 := position, tokenIndex
+							if buffer[position] != rune('m') {
+								goto l1232
+							}
+							position++
+							goto l1231
+						l1232:
+							position, tokenIndex = position1231, tokenIndex1231
+							if buffer[position] != rune('M') {
+								goto l1223
+							}
+							position++
+						}
+					l1231:
+						if !_rules[rulesp]() {
+							goto l1223
+						}
+						if !_rules[ruleRelations]() {
+							goto l1223
+						}
+						goto l1224
+					l1223:
+						position, tokenIndex = position1223, tokenIndex1223
+					}
+				l1224:
+					add(rulePegText, position1222)
+				}
+				if !_rules[ruleAction51]() {
+					goto l1220
+				}
+				add(ruleWindowedFrom, position1221)
+			}
+			return true
+		l1220:
+			position, tokenIndex = position1220, tokenIndex1220
+			return false
+		},
+		/* 61 Interval <- <(TimeInterval / TuplesInterval)> */
+		func() bool {
+			position1233, tokenIndex1233 := position, tokenIndex
+			{
+				position1234 := position
+				{
+					position1235, tokenIndex1235 := position, tokenIndex
+					if !_rules[ruleTimeInterval]() {
+						goto l1236
+					}
+					goto l1235
+				l1236:
+					position, tokenIndex = position1235, tokenIndex1235
+					if !_rules[ruleTuplesInterval]() {
+						goto l1233
+					}
+				}
+			l1235:
+				add(ruleInterval, position1234)
+			}
+			return true
+		l1233:
+			position, tokenIndex = position1233, tokenIndex1233
+			return false
+		},
+		/* 62 TimeInterval <- <((FloatLiteral / NumericLiteral) sp (SECONDS / MILLISECONDS) Action52)> */
+		func() bool {
+			position1237, tokenIndex1237 := position, tokenIndex
+			{
+				position1238 := position
+				{
+					position1239, tokenIndex1239 := position, tokenIndex
+					if !_rules[ruleFloatLiteral]() {
+						goto l1240
+					}
+					goto l1239
+				l1240:
+					position, tokenIndex = position1239, tokenIndex1239
+					if !_rules[ruleNumericLiteral]() {
+						goto l1237
+					}
+				}
+			l1239:
+				if !_rules[rulesp]() {
+					goto l1237
+				}
+				{
+					position1241, tokenIndex1241 := position, tokenIndex
+					if !_rules[ruleSECONDS]() {
+						goto l1242
+					}
+					goto l1241
+				l1242:
+					position, tokenIndex = position1241, tokenIndex1241
+					if !_rules[ruleMILLISECONDS]() {
+						goto l1237
+					}
+				}
+			l1241:
+				if !_rules[ruleAction52]() {
+					goto l1237
+				}
+				add(ruleTimeInterval, position1238)
+			}
+			return true
+		l1237:
+			position, tokenIndex = position1237, tokenIndex1237
+			return false
+		},
+		/* 63 TuplesInterval <- <(NumericLiteral sp TUPLES Action53)> */
+		func() bool {
+			position1243, tokenIndex1243 := position, tokenIndex
+			{
+				position1244 := position
+				if !_rules[ruleNumericLiteral]() {
+					goto l1243
+				}
+				if !_rules[rulesp]() {
+					goto l1243
+				}
+				if !_rules[ruleTUPLES]() {
+					goto l1243
+				}
+				if !_rules[ruleAction53]() {
+					goto l1243
+				}
+				add(ruleTuplesInterval, position1244)
+			}
+			return true
+		l1243:
+			position, tokenIndex = position1243, tokenIndex1243
+			return false
+		},
+		/* 64 Relations <- <(JoinedRelations / (RelationLike (spOpt ',' spOpt RelationLike)*))> */
+		func() bool {
+			position1245, tokenIndex1245 := position, tokenIndex
+			{
+				position1246 := position
+				{
+					position1247, tokenIndex1247 := position, tokenIndex
+					if !_rules[ruleJoinedRelations]() {
+						goto l1248
+					}
+					goto l1247
+				l1248:
+					position, tokenIndex = position1247, tokenIndex1247
+					if !_rules[ruleRelationLike]() {
+						goto l1245
+					}
+				l1249:
+					{
+						position1250, tokenIndex1250 := position, tokenIndex
+						if !_rules[rulespOpt]() {
+							goto l1250
+						}
+						if buffer[position] != rune(',') {
+							goto l1250
+						}
+						position++
+						if !_rules[rulespOpt]() {
+							goto l1250
+						}
+						if !_rules[ruleRelationLike]() {
+							goto l1250
+						}
+						goto l1249
+					l1250:
+						position, tokenIndex = position1250, tokenIndex1250
+					}
+				}
+			l1247:
+				add(ruleRelations, position1246)
+			}
+			return true
+		l1245:
+			position, tokenIndex = position1245, tokenIndex1245
+			return false
+		},
+		/* 65 JoinedRelations <- <(RelationLike sp (JoinType sp)? (('j' / 'J') ('o' / 'O') ('i' / 'I') ('n' / 'N')) sp RelationLike sp (('o' / 'O') ('n' / 'N')) sp Expression Action54)> */
+		func() bool {
+			position1251, tokenIndex1251 := position, tokenIndex
+			{
+				position1252 := position
+				if !_rules[ruleRelationLike]() {
+					goto l1251
+				}
+				if !_rules[rulesp]() {
+					goto l1251
+				}
+				{
+					position1253, tokenIndex1253 := position, tokenIndex
+					if !_rules[ruleJoinType]() {
+						goto l1253
+					}
+					if !_rules[rulesp]() {
+						goto l1253
+					}
+					goto l1254
+				l1253:
+					position, tokenIndex = position1253, tokenIndex1253
+				}
+			l1254:
+				{
+					position1255, tokenIndex1255 := position, tokenIndex
+					if buffer[position] != rune('j') {
+						goto l1256
+					}
+					position++
+					goto l1255
+				l1256:
+					position, tokenIndex = position1255, tokenIndex1255
+					if buffer[position] != rune('J') {
+						goto l1251
+					}
+					position++
+				}
+			l1255:
+				{
+					position1257, tokenIndex1257 := position, tokenIndex
+					if buffer[position] != rune('o') {
+						goto l1258
+					}
+					position++
+					goto l1257
+				l1258:
+					position, tokenIndex = position1257, tokenIndex1257
+					if buffer[position] != rune('O') {
+						goto l1251
+					}
+					position++
+				}
+			l1257:
+				{
+					position1259, tokenIndex1259 := position, tokenIndex
+					if buffer[position] != rune('i') {
+						goto l1260
+					}
+					position++
+					goto l1259
+				l1260:
+					position, tokenIndex = position1259, tokenIndex1259
+					if buffer[position] != rune('I') {
+						goto l1251
+					}
+					position++
+				}
+			l1259:
+				{
+					position1261, tokenIndex1261 := position, tokenIndex
+					if buffer[position] != rune('n') {
+						goto l1262
+					}
+					position++
+					goto l1261
+				l1262:
+					position, tokenIndex = position1261, tokenIndex1261
+					if buffer[position] != rune('N') {
+						goto l1251
+					}
+					position++
+				}
+			l1261:
+				if !_rules[rulesp]() {
+					goto l1251
+				}
+				if !_rules[ruleRelationLike]() {
+					goto l1251
+				}
+				if !_rules[rulesp]() {
+					goto l1251
+				}
+				{
+					position1263, tokenIndex1263 := position, tokenIndex
+					if buffer[position] != rune('o') {
+						goto l1264
+					}
+					position++
+					goto l1263
+				l1264:
+					position, tokenIndex = position1263, tokenIndex1263
+					if buffer[position] != rune('O') {
+						goto l1251
+					}
+					position++
+				}
+			l1263:
+				{
+					position1265, tokenIndex1265 := position, tokenIndex
+					if buffer[position] != rune('n') {
+						goto l1266
+					}
+					position++
+					goto l1265
+				l1266:
+					position, tokenIndex = position1265, tokenIndex1265
+					if buffer[position] != rune('N') {
+						goto l1251
+					}
+					position++
+				}
+			l1265:
+				if !_rules[rulesp]() {
+					goto l1251
+				}
+				if !_rules[ruleExpression]() {
+					goto l1251
+				}
+				if !_rules[ruleAction54]() {
+					goto l1251
+				}
+				add(ruleJoinedRelations, position1252)
+			}
+			return true
+		l1251:
+			position, tokenIndex = position1251, tokenIndex1251
+			return false
+		},
+		/* 66 JoinType <- <(InnerJoin / LeftOuterJoin / RightOuterJoin / FullOuterJoin)> */
+		func() bool {
+			position1267, tokenIndex1267 := position, tokenIndex
+			{
+				position1268 := position
+				{
+					position1269, tokenIndex1269 := position, tokenIndex
+					if !_rules[ruleInnerJoin]() {
+						goto l1270
+					}
+					goto l1269
+				l1270:
+					position, tokenIndex = position1269, tokenIndex1269
+					if !_rules[ruleLeftOuterJoin]() {
+						goto l1271
+					}
+					goto l1269
+				l1271:
+					position, tokenIndex = position1269, tokenIndex1269
+					if !_rules[ruleRightOuterJoin]() {
+						goto l1272
+					}
+					goto l1269
+				l1272:
+					position, tokenIndex = position1269, tokenIndex1269
+					if !_rules[ruleFullOuterJoin]() {
+						goto l1267
+					}
+				}
+			l1269:
+				add(ruleJoinType, position1268)
+			}
+			return true
+		l1267:
+			position, tokenIndex = position1267, tokenIndex1267
+			return false
+		},
+		/* 67 Deduplicate <- <(<(sp (('d' / 'D') ('e' / 'E') ('d' / 'D') ('u' / 'U') ('p' / 'P') ('l' / 'L') ('i' / 'I') ('c' / 'C') ('a' / 'A') ('t' / 'T') ('e' / 'E')) sp (('b' / 'B') ('y' / 'Y')) sp Expression sp (('w' / 'W') ('i' / 'I') ('t' / 'T') ('h' / 'H') ('i' / 'I') ('n' / 'N')) sp Interval)?> Action55)> */
+		func() bool {
+			position1273, tokenIndex1273 := position, tokenIndex
+			{
+				position1274 := position
+				{
+					position1275 := position
+					{
+						position1276, tokenIndex1276 := position, tokenIndex
+						if !_rules[rulesp]() {
+							goto l1276
+						}
+						{
+							position1278, tokenIndex1278 := position, tokenIndex
+							if buffer[position] != rune('d') {
+								goto l1279
+							}
+							position++
+							goto l1278
+						l1279:
+							position, tokenIndex = position1278, tokenIndex1278
+							if buffer[position] != rune('D') {
+								goto l1276
+							}
+							position++
+						}
+					l1278:
+						{
+							position1280, tokenIndex1280 := position, tokenIndex
+							if buffer[position] != rune('e') {
+								goto l1281
+							}
+							position++
+							goto l1280
+						l1281:
+							position, tokenIndex = position1280, tokenIndex1280
+							if buffer[position] != rune('E') {
+								goto l1276
+							}
+							position++
+						}
+					l1280:
+						{
+							position1282, tokenIndex1282 := position, tokenIndex
+							if buffer[position] != rune('d') {
+								goto l1283
+							}
+							position++
+							goto l1282
+						l1283:
+							position, tokenIndex = position1282, tokenIndex1282
+							if buffer[position] != rune('D') {
+								goto l1276
+							}
+							position++
+						}
+					l1282:
+						{
+							position1284, tokenIndex1284 := position, tokenIndex
+							if buffer[position] != rune('u') {
+								goto l1285
+							}
+							position++
+							goto l1284
+						l1285:
+							position, tokenIndex = position1284, tokenIndex1284
+							if buffer[position] != rune('U') {
+								goto l1276
+							}
+							position++
+						}
+					l1284:
+						{
+							position1286, tokenIndex1286 := position, tokenIndex
+							if buffer[position] != rune('p') {
+								goto l1287
+							}
+							position++
+							goto l1286
+						l1287:
+							position, tokenIndex = position1286, tokenIndex1286
+							if buffer[position] != rune('P') {
+								goto l1276
+							}
+							position++
+						}
+					l1286:
+						{
+							position1288, tokenIndex1288 := position, tokenIndex
+							if buffer[position] != rune('l') {
+								goto l1289
+							}
+							position++
+							goto l1288
+						l1289:
+							position, tokenIndex = position1288, tokenIndex1288
+							if buffer[position] != rune('L') {
+								goto l1276
+							}
+							position++
+						}
+					l1288:
+						{
+							position1290, tokenIndex1290 := position, tokenIndex
+							if buffer[position] != rune('i') {
+								goto l1291
+							}
+							position++
+							goto l1290
+						l1291:
+							position, tokenIndex = position1290, tokenIndex1290
+							if buffer[position] != rune('I') {
+								goto l1276
+							}
+							position++
+						}
+					l1290:
+						{
+							position1292, tokenIndex1292 := position, tokenIndex
+							if buffer[position] != rune('c') {
+								goto l1293
+							}
+							position++
+							goto l1292
+						l1293:
+							position, tokenIndex = position1292, tokenIndex1292
+							if buffer[position] != rune('C') {
+								goto l1276
+							}
+							position++
+						}
+					l1292:
+						{
+							position1294, tokenIndex1294 := position, tokenIndex
+							if buffer[position] != rune('a') {
+								goto l1295
+							}
+							position++
+							goto l1294
+						l1295:
+							position, tokenIndex = position1294, tokenIndex1294
+							if buffer[position] != rune('A') {
+								goto l1276
+							}
+							position++
+						}
+					l1294:
+						{
+							position1296, tokenIndex1296 := position, tokenIndex
+							if buffer[position] != rune('t') {
+								goto l1297
+							}
+							position++
+							goto l1296
+						l1297:
+							position, tokenIndex = position1296, tokenIndex1296
+							if buffer[position] != rune('T') {
+								goto l1276
+							}
+							position++
+						}
+					l1296:
+						{
+							position1298, tokenIndex1298 := position, tokenIndex
+							if buffer[position] != rune('e') {
+								goto l1299
+							}
+							position++
+							goto l1298
+						l1299:
+							position, tokenIndex = position1298, tokenIndex1298
+							if buffer[position] != rune('E') {
+								goto l1276
+							}
+							position++
+						}
+					l1298:
+						if !_rules[rulesp]() {
+							goto l1276
+						}
+						{
+							position1300, tokenIndex1300 := position, tokenIndex
+							if buffer[position] != rune('b') {
+								goto l1301
+							}
+							position++
+							goto l1300
+						l1301:
+							position, tokenIndex = position1300, tokenIndex1300
+							if buffer[position] != rune('B') {
+								goto l1276
+							}
+							position++
+						}
+					l1300:
+						{
+							position1302, tokenIndex1302 := position, tokenIndex
+							if buffer[position] != rune('y') {
+								goto l1303
+							}
+							position++
+							goto l1302
+						l1303:
+							position, tokenIndex = position1302, tokenIndex1302
+							if buffer[position] != rune('Y') {
+								goto l1276
+							}
+							position++
+						}
+					l1302:
+						if !_rules[rulesp]() {
+							goto l1276
+						}
+						if !_rules[ruleExpression]() {
+							goto l1276
+						}
+						if !_rules[rulesp]() {
+							goto l1276
+						}
+						{
+							position1304, tokenIndex1304 := position, tokenIndex
+							if buffer[position] != rune('w') {
+								goto l1305
+							}
+							position++
+							goto l1304
+						l1305:
+							position, tokenIndex = position1304, tokenIndex1304
+							if buffer[position] != rune('W') {
+								goto l1276
+							}
+							position++
+						}
+					l1304:
+						{
+							position1306, tokenIndex1306 := position, tokenIndex
+							if buffer[position] != rune('i') {
+								goto l1307
+							}
+							position++
+							goto l1306
+						l1307:
+							position, tokenIndex = position1306, tokenIndex1306
+							if buffer[position] != rune('I') {
+								goto l1276
+							}
+							position++
+						}
+					l1306:
+						{
+							position1308, tokenIndex1308 := position, tokenIndex
+							if buffer[position] != rune('t') {
+								goto l1309
+							}
+							position++
+							goto l1308
+						l1309:
+							position, tokenIndex = position1308, tokenIndex1308
+							if buffer[position] != rune('T') {
+								goto l1276
+							}
+							position++
+						}
+					l1308:
+						{
+							position1310, tokenIndex1310 := position, tokenIndex
+							if buffer[position] != rune('h') {
+								goto l1311
+							}
+							position++
+							goto l1310
+						l1311:
+							position, tokenIndex = position1310, tokenIndex1310
+							if buffer[position] != rune('H') {
+								goto l1276
+							}
+							position++
+						}
+					l1310:
+						{
+							position1312, tokenIndex1312 := position, tokenIndex
+							if buffer[position] != rune('i') {
+								goto l1313
+							}
+							position++
+							goto l1312
+						l1313:
+							position, tokenIndex = position1312, tokenIndex1312
+							if buffer[position] != rune('I') {
+								goto l1276
+							}
+							position++
+						}
+					l1312:
+						{
+							position1314, tokenIndex1314 := position, tokenIndex
+							if buffer[position] != rune('n') {
+								goto l1315
+							}
+							position++
+							goto l1314
+						l1315:
+							position, tokenIndex = position1314, tokenIndex1314
+							if buffer[position] != rune('N') {
+								goto l1276
+							}
+							position++
+						}
+					l1314:
+						if !_rules[rulesp]() {
+							goto l1276
+						}
+						if !_rules[ruleInterval]() {
+							goto l1276
+						}
+						goto l1277
+					l1276:
+						position, tokenIndex = position1276, tokenIndex1276
+					}
+				l1277:
+					add(rulePegText, position1275)
+				}
+				if !_rules[ruleAction55]() {
+					goto l1273
+				}
+				add(ruleDeduplicate, position1274)
+			}
+			return true
+		l1273:
+			position, tokenIndex = position1273, tokenIndex1273
+			return false
+		},
+		/* 68 Filter <- <(<(sp (('w' / 'W') ('h' / 'H') ('e' / 'E') ('r' / 'R') ('e' / 'E')) sp Expression)?> Action56)> */
+		func() bool {
+			position1316, tokenIndex1316 := position, tokenIndex
+			{
+				position1317 := position
+				{
+					position1318 := position
+					{
+						position1319, tokenIndex1319 := position, tokenIndex
+						if !_rules[rulesp]() {
+							goto l1319
+						}
+						{
+							position1321, tokenIndex1321 := position, tokenIndex
+							if buffer[position] != rune('w') {
+								goto l1322
+							}
+							position++
+							goto l1321
+						l1322:
+							position, tokenIndex = position1321, tokenIndex1321
+							if buffer[position] != rune('W') {
+								goto l1319
+							}
+							position++
+						}
+					l1321:
+						{
+							position1323, tokenIndex1323 := position, tokenIndex
+							if buffer[position] != rune('h') {
+								goto l1324
+							}
+							position++
+							goto l1323
+						l1324:
+							position, tokenIndex = position1323, tokenIndex1323
+							if buffer[position] != rune('H') {
+								goto l1319
+							}
+							position++
+						}
+					l1323:
+						{
+							position1325, tokenIndex1325 := position, tokenIndex
+							if buffer[position] != rune('e') {
+								goto l1326
+							}
+							position++
+							goto l1325
+						l1326:
+							position, tokenIndex = position1325, tokenIndex1325
+							if buffer[position] != rune('E') {
+								goto l1319
+							}
+							position++
+						}
+					l1325:
+						{
+							position1327, tokenIndex1327 := position, tokenIndex
+							if buffer[position] != rune('r') {
+								goto l1328
+							}
+							position++
+							goto l1327
+						l1328:
+							position, tokenIndex = position1327, tokenIndex1327
+							if buffer[position] != rune('R') {
+								goto l1319
+							}
+							position++
+						}
+					l1327:
+						{
+							position1329, tokenIndex1329 := position, tokenIndex
+							if buffer[position] != rune('e') {
+								goto l1330
+							}
+							position++
+							goto l1329
+						l1330:
+							position, tokenIndex = position1329, tokenIndex1329
+							if buffer[position] != rune('E') {
+								goto l1319
+							}
+							position++
+						}
+					l1329:
+						if !_rules[rulesp]() {
+							goto l1319
+						}
+						if !_rules[ruleExpression]() {
+							goto l1319
+						}
+						goto l1320
+					l1319:
+						position, tokenIndex = position1319, tokenIndex1319
+					}
+				l1320:
+					add(rulePegText, position1318)
+				}
+				if !_rules[ruleAction56]() {
+					goto l1316
+				}
+				add(ruleFilter, position1317)
+			}
+			return true
+		l1316:
+			position, tokenIndex = position1316, tokenIndex1316
+			return false
+		},
+		/* 69 Grouping <- <(<(sp (('g' / 'G') ('r' / 'R') ('o' / 'O') ('u' / 'U') ('p' / 'P')) sp (('b' / 'B') ('y' / 'Y')) sp (GroupingSets / GroupList))?> Action57)> */
+		func() bool {
+			position1331, tokenIndex1331 := position, tokenIndex
+			{
+				position1332 := position
+				{
+					position1333 := position
+					{
+						position1334, tokenIndex1334 := position, tokenIndex
+						if !_rules[rulesp]() {
+							goto l1334
+						}
+						{
+							position1336, tokenIndex1336 := position, tokenIndex
+							if buffer[position] != rune('g') {
+								goto l1337
+							}
+							position++
+							goto l1336
+						l1337:
+							position, tokenIndex = position1336, tokenIndex1336
+							if buffer[position] != rune('G') {
+								goto l1334
+							}
+							position++
+						}
+					l1336:
+						{
+							position1338, tokenIndex1338 := position, tokenIndex
+							if buffer[position] != rune('r') {
+								goto l1339
+							}
+							position++
+							goto l1338
+						l1339:
+							position, tokenIndex = position1338, tokenIndex1338
+							if buffer[position] != rune('R') {
+								goto l1334
+							}
+							position++
+						}
+					l1338:
+						{
+							position1340, tokenIndex1340 := position, tokenIndex
+							if buffer[position] != rune('o') {
+								goto l1341
+							}
+							position++
+							goto l1340
+						l1341:
+							position, tokenIndex = position1340, tokenIndex1340
+							if buffer[position] != rune('O') {
+								goto l1334
+							}
+							position++
+						}
+					l1340:
+						{
+							position1342, tokenIndex1342 := position, tokenIndex
+							if buffer[position] != rune('u') {
+								goto l1343
+							}
+							position++
+							goto l1342
+						l1343:
+							position, tokenIndex = position1342, tokenIndex1342
+							if buffer[position] != rune('U') {
+								goto l1334
+							}
+							position++
+						}
+					l1342:
+						{
+							position1344, tokenIndex1344 := position, tokenIndex
+							if buffer[position] != rune('p') {
+								goto l1345
+							}
+							position++
+							goto l1344
+						l1345:
+							position, tokenIndex = position1344, tokenIndex1344
+							if buffer[position] != rune('P') {
+								goto l1334
+							}
+							position++
+						}
+					l1344:
+						if !_rules[rulesp]() {
+							goto l1334
+						}
+						{
+							position1346, tokenIndex1346 := position, tokenIndex
+							if buffer[position] != rune('b') {
+								goto l1347
+							}
+							position++
+							goto l1346
+						l1347:
+							position, tokenIndex = position1346, tokenIndex1346
+							if buffer[position] != rune('B') {
+								goto l1334
+							}
+							position++
+						}
+					l1346:
+						{
+							position1348, tokenIndex1348 := position, tokenIndex
+							if buffer[position] != rune('y') {
+								goto l1349
+							}
+							position++
+							goto l1348
+						l1349:
+							position, tokenIndex = position1348, tokenIndex1348
+							if buffer[position] != rune('Y') {
+								goto l1334
+							}
+							position++
+						}
+					l1348:
+						if !_rules[rulesp]() {
+							goto l1334
+						}
+						{
+							position1350, tokenIndex1350 := position, tokenIndex
+							if !_rules[ruleGroupingSets]() {
+								goto l1351
+							}
+							goto l1350
+						l1351:
+							position, tokenIndex = position1350, tokenIndex1350
+							if !_rules[ruleGroupList]() {
+								goto l1334
+							}
+						}
+					l1350:
+						goto l1335
+					l1334:
+						position, tokenIndex = position1334, tokenIndex1334
+					}
+				l1335:
+					add(rulePegText, position1333)
+				}
+				if !_rules[ruleAction57]() {
+					goto l1331
+				}
+				add(ruleGrouping, position1332)
+			}
+			return true
+		l1331:
+			position, tokenIndex = position1331, tokenIndex1331
+			return false
+		},
+		/* 70 GroupList <- <(Expression (spOpt ',' spOpt Expression)*)> */
+		func() bool {
+			position1352, tokenIndex1352 := position, tokenIndex
+			{
+				position1353 := position
+				if !_rules[ruleExpression]() {
+					goto l1352
+				}
+			l1354:
+				{
+					position1355, tokenIndex1355 := position, tokenIndex
+					if !_rules[rulespOpt]() {
+						goto l1355
+					}
+					if buffer[position] != rune(',') {
+						goto l1355
+					}
+					position++
+					if !_rules[rulespOpt]() {
+						goto l1355
+					}
+					if !_rules[ruleExpression]() {
+						goto l1355
+					}
+					goto l1354
+				l1355:
+					position, tokenIndex = position1355, tokenIndex1355
+				}
+				add(ruleGroupList, position1353)
+			}
+			return true
+		l1352:
+			position, tokenIndex = position1352, tokenIndex1352
+			return false
+		},
+		/* 71 GroupingSets <- <(('g' / 'G') ('r' / 'R') ('o' / 'O') ('u' / 'U') ('p' / 'P') ('i' / 'I') ('n' / 'N') ('g' / 'G') sp (('s' / 'S') ('e' / 'E') ('t' / 'T') ('s' / 'S')) spOpt '(' spOpt GroupingSet (spOpt ',' spOpt GroupingSet)* spOpt ')')> */
+		func() bool {
+			position1356, tokenIndex1356 := position, tokenIndex
+			{
+				position1357 := position
+				{
+					position1358, tokenIndex1358 := position, tokenIndex
+					if buffer[position] != rune('g') {
+						goto l1359
+					}
+					position++
+					goto l1358
+				l1359:
+					position, tokenIndex = position1358, tokenIndex1358
+					if buffer[position] != rune('G') {
+						goto l1356
+					}
+					position++
+				}
+			l1358:
+				{
+					position1360, tokenIndex1360 := position, tokenIndex
+					if buffer[position] != rune('r') {
+						goto l1361
+					}
+					position++
+					goto l1360
+				l1361:
+					position, tokenIndex = position1360, tokenIndex1360
+					if buffer[position] != rune('R') {
+						goto l1356
+					}
+					position++
+				}
+			l1360:
+				{
+					position1362, tokenIndex1362 := position, tokenIndex
+					if buffer[position] != rune('o') {
+						goto l1363
+					}
+					position++
+					goto l1362
+				l1363:
+					position, tokenIndex = position1362, tokenIndex1362
+					if buffer[position] != rune('O') {
+						goto l1356
+					}
+					position++
+				}
+			l1362:
+				{
+					position1364, tokenIndex1364 := position, tokenIndex
+					if buffer[position] != rune('u') {
+						goto l1365
+					}
+					position++
+					goto l1364
+				l1365:
+					position, tokenIndex = position1364, tokenIndex1364
+					if buffer[position] != rune('U') {
+						goto l1356
+					}
+					position++
+				}
+			l1364:
+				{
+					position1366, tokenIndex1366 := position, tokenIndex
+					if buffer[position] != rune('p') {
+						goto l1367
+					}
+					position++
+					goto l1366
+				l1367:
+					position, tokenIndex = position1366, tokenIndex1366
+					if buffer[position] != rune('P') {
+						goto l1356
+					}
+					position++
+				}
+			l1366:
+				{
+					position1368, tokenIndex1368 := position, tokenIndex
+					if buffer[position] != rune('i') {
+						goto l1369
+					}
+					position++
+					goto l1368
+				l1369:
+					position, tokenIndex = position1368, tokenIndex1368
+					if buffer[position] != rune('I') {
+						goto l1356
+					}
+					position++
+				}
+			l1368:
+				{
+					position1370, tokenIndex1370 := position, tokenIndex
+					if buffer[position] != rune('n') {
+						goto l1371
+					}
+					position++
+					goto l1370
+				l1371:
+					position, tokenIndex = position1370, tokenIndex1370
+					if buffer[position] != rune('N') {
+						goto l1356
+					}
+					position++
+				}
+			l1370:
+				{
+					position1372, tokenIndex1372 := position, tokenIndex
+					if buffer[position] != rune('g') {
+						goto l1373
+					}
+					position++
+					goto l1372
+				l1373:
+					position, tokenIndex = position1372, tokenIndex1372
+					if buffer[position] != rune('G') {
+						goto l1356
+					}
+					position++
+				}
+			l1372:
+				if !_rules[rulesp]() {
+					goto l1356
+				}
+				{
+					position1374, tokenIndex1374 := position, tokenIndex
+					if buffer[position] != rune('s') {
+						goto l1375
+					}
+					position++
+					goto l1374
+				l1375:
+					position, tokenIndex = position1374, tokenIndex1374
+					if buffer[position] != rune('S') {
+						goto l1356
+					}
+					position++
+				}
+			l1374:
+				{
+					position1376, tokenIndex1376 := position, tokenIndex
+					if buffer[position] != rune('e') {
+						goto l1377
+					}
+					position++
+					goto l1376
+				l1377:
+					position, tokenIndex = position1376, tokenIndex1376
+					if buffer[position] != rune('E') {
+						goto l1356
+					}
+					position++
+				}
+			l1376:
+				{
+					position1378, tokenIndex1378 := position, tokenIndex
+					if buffer[position] != rune('t') {
+						goto l1379
+					}
+					position++
+					goto l1378
+				l1379:
+					position, tokenIndex = position1378, tokenIndex1378
+					if buffer[position] != rune('T') {
+						goto l1356
+					}
+					position++
+				}
+			l1378:
+				{
+					position1380, tokenIndex1380 := position, tokenIndex
+					if buffer[position] != rune('s') {
+						goto l1381
+					}
+					position++
+					goto l1380
+				l1381:
+					position, tokenIndex = position1380, tokenIndex1380
+					if buffer[position] != rune('S') {
+						goto l1356
+					}
+					position++
+				}
+			l1380:
+				if !_rules[rulespOpt]() {
+					goto l1356
+				}
+				if buffer[position] != rune('(') {
+					goto l1356
+				}
+				position++
+				if !_rules[rulespOpt]() {
+					goto l1356
+				}
+				if !_rules[ruleGroupingSet]() {
+					goto l1356
+				}
+			l1382:
+				{
+					position1383, tokenIndex1383 := position, tokenIndex
+					if !_rules[rulespOpt]() {
+						goto l1383
+					}
+					if buffer[position] != rune(',') {
+						goto l1383
+					}
+					position++
+					if !_rules[rulespOpt]() {
+						goto l1383
+					}
+					if !_rules[ruleGroupingSet]() {
+						goto l1383
+					}
+					goto l1382
+				l1383:
+					position, tokenIndex = position1383, tokenIndex1383
+				}
+				if !_rules[rulespOpt]() {
+					goto l1356
+				}
+				if buffer[position] != rune(')') {
+					goto l1356
+				}
+				position++
+				add(ruleGroupingSets, position1357)
+			}
+			return true
+		l1356:
+			position, tokenIndex = position1356, tokenIndex1356
+			return false
+		},
+		/* 72 GroupingSet <- <(<('(' spOpt (Expression (spOpt ',' spOpt Expression)*)? spOpt ')')> Action58)> */
+		func() bool {
+			position1384, tokenIndex1384 := position, tokenIndex
+			{
+				position1385 := position
+				{
+					position1386 := position
+					if buffer[position] != rune('(') {
+						goto l1384
+					}
+					position++
+					if !_rules[rulespOpt]() {
+						goto l1384
+					}
+					{
+						position1387, tokenIndex1387 := position, tokenIndex
+						if !_rules[ruleExpression]() {
+							goto l1387
+						}
+					l1389:
+						{
+							position1390, tokenIndex1390 := position, tokenIndex
+							if !_rules[rulespOpt]() {
+								goto l1390
+							}
+							if buffer[position] != rune(',') {
+								goto l1390
+							}
+							position++
+							if !_rules[rulespOpt]() {
+								goto l1390
+							}
+							if !_rules[ruleExpression]() {
+								goto l1390
+							}
+							goto l1389
+						l1390:
+							position, tokenIndex = position1390, tokenIndex1390
+						}
+						goto l1388
+					l1387:
+						position, tokenIndex = position1387, tokenIndex1387
+					}
+				l1388:
+					if !_rules[rulespOpt]() {
+						goto l1384
+					}
+					if buffer[position] != rune(')') {
+						goto l1384
+					}
+					position++
+					add(rulePegText, position1386)
+				}
+				if !_rules[ruleAction58]() {
+					goto l1384
+				}
+				add(ruleGroupingSet, position1385)
+			}
+			return true
+		l1384:
+			position, tokenIndex = position1384, tokenIndex1384
+			return false
+		},
+		/* 73 Having <- <(<(sp (('h' / 'H') ('a' / 'A') ('v' / 'V') ('i' / 'I') ('n' / 'N') ('g' / 'G')) sp Expression)?> Action59)> */
+		func() bool {
+			position1391, tokenIndex1391 := position, tokenIndex
+			{
+				position1392 := position
+				{
+					position1393 := position
+					{
+						position1394, tokenIndex1394 := position, tokenIndex
+						if !_rules[rulesp]() {
+							goto l1394
+						}
+						{
+							position1396, tokenIndex1396 := position, tokenIndex
+							if buffer[position] != rune('h') {
+								goto l1397
+							}
+							position++
+							goto l1396
+						l1397:
+							position, tokenIndex = position1396, tokenIndex1396
+							if buffer[position] != rune('H') {
+								goto l1394
+							}
+							position++
+						}
+					l1396:
+						{
+							position1398, tokenIndex1398 := position, tokenIndex
+							if buffer[position] != rune('a') {
+								goto l1399
+							}
+							position++
+							goto l1398
+						l1399:
+							position, tokenIndex = position1398, tokenIndex1398
+							if buffer[position] != rune('A') {
+								goto l1394
+							}
+							position++
+						}
+					l1398:
+						{
+							position1400, tokenIndex1400 := position, tokenIndex
+							if buffer[position] != rune('v') {
+								goto l1401
+							}
+							position++
+							goto l1400
+						l1401:
+							position, tokenIndex = position1400, tokenIndex1400
+							if buffer[position] != rune('V') {
+								goto l1394
+							}
+							position++
+						}
+					l1400:
+						{
+							position1402, tokenIndex1402 := position, tokenIndex
+							if buffer[position] != rune('i') {
+								goto l1403
+							}
+							position++
+							goto l1402
+						l1403:
+							position, tokenIndex = position1402, tokenIndex1402
+							if buffer[position] != rune('I') {
+								goto l1394
+							}
+							position++
+						}
+					l1402:
+						{
+							position1404, tokenIndex1404 := position, tokenIndex
+							if buffer[position] != rune('n') {
+								goto l1405
+							}
+							position++
+							goto l1404
+						l1405:
+							position, tokenIndex = position1404, tokenIndex1404
+							if buffer[position] != rune('N') {
+								goto l1394
+							}
+							position++
+						}
+					l1404:
+						{
+							position1406, tokenIndex1406 := position, tokenIndex
+							if buffer[position] != rune('g') {
+								goto l1407
+							}
+							position++
+							goto l1406
+						l1407:
+							position, tokenIndex = position1406, tokenIndex1406
+							if buffer[position] != rune('G') {
+								goto l1394
+							}
+							position++
+						}
+					l1406:
+						if !_rules[rulesp]() {
+							goto l1394
+						}
+						if !_rules[ruleExpression]() {
+							goto l1394
+						}
+						goto l1395
+					l1394:
+						position, tokenIndex = position1394, tokenIndex1394
+					}
+				l1395:
+					add(rulePegText, position1393)
+				}
+				if !_rules[ruleAction59]() {
+					goto l1391
+				}
+				add(ruleHaving, position1392)
+			}
+			return true
+		l1391:
+			position, tokenIndex = position1391, tokenIndex1391
+			return false
+		},
+		/* 74 RelationLike <- <(AliasedStreamWindow / (StreamWindow Action60))> */
+		func() bool {
+			position1408, tokenIndex1408 := position, tokenIndex
+			{
+				position1409 := position
+				{
+					position1410, tokenIndex1410 := position, tokenIndex
+					if !_rules[ruleAliasedStreamWindow]() {
+						goto l1411
+					}
+					goto l1410
+				l1411:
+					position, tokenIndex = position1410, tokenIndex1410
+					if !_rules[ruleStreamWindow]() {
+						goto l1408
+					}
+					if !_rules[ruleAction60]() {
+						goto l1408
+					}
+				}
+			l1410:
+				add(ruleRelationLike, position1409)
+			}
+			return true
+		l1408:
+			position, tokenIndex = position1408, tokenIndex1408
+			return false
+		},
+		/* 75 AliasedStreamWindow <- <(StreamWindow sp (('a' / 'A') ('s' / 'S')) sp Identifier Action61)> */
+		func() bool {
+			position1412, tokenIndex1412 := position, tokenIndex
+			{
+				position1413 := position
+				if !_rules[ruleStreamWindow]() {
+					goto l1412
+				}
+				if !_rules[rulesp]() {
+					goto l1412
+				}
+				{
+					position1414, tokenIndex1414 := position, tokenIndex
+					if buffer[position] != rune('a') {
+						goto l1415
+					}
+					position++
+					goto l1414
+				l1415:
+					position, tokenIndex = position1414, tokenIndex1414
+					if buffer[position] != rune('A') {
+						goto l1412
+					}
+					position++
+				}
+			l1414:
+				{
+					position1416, tokenIndex1416 := position, tokenIndex
+					if buffer[position] != rune('s') {
+						goto l1417
+					}
+					position++
+					goto l1416
+				l1417:
+					position, tokenIndex = position1416, tokenIndex1416
+					if buffer[position] != rune('S') {
+						goto l1412
+					}
+					position++
+				}
+			l1416:
+				if !_rules[rulesp]() {
+					goto l1412
+				}
+				if !_rules[ruleIdentifier]() {
+					goto l1412
+				}
+				if !_rules[ruleAction61]() {
+					goto l1412
+				}
+				add(ruleAliasedStreamWindow, position1413)
+			}
+			return true
+		l1412:
+			position, tokenIndex = position1412, tokenIndex1412
+			return false
+		},
+		/* 76 StreamWindow <- <(WindowFunction / (StreamLike spOpt '[' spOpt (('r' / 'R') ('a' / 'A') ('n' / 'N') ('g' / 'G') ('e' / 'E')) sp Interval CapacitySpecOpt SheddingSpecOpt spOpt ']' Action62))> */
+		func() bool {
+			position1418, tokenIndex1418 := position, tokenIndex
+			{
+				position1419 := position
+				{
+					position1420, tokenIndex1420 := position, tokenIndex
+					if !_rules[ruleWindowFunction]() {
+						goto l1421
+					}
+					goto l1420
+				l1421:
+					position, tokenIndex = position1420, tokenIndex1420
+					if !_rules[ruleStreamLike]() {
+						goto l1418
+					}
+					if !_rules[rulespOpt]() {
+						goto l1418
+					}
+					if buffer[position] != rune('[') {
+						goto l1418
+					}
+					position++
+					if !_rules[rulespOpt]() {
+						goto l1418
+					}
+					{
+						position1422, tokenIndex1422 := position, tokenIndex
+						if buffer[position] != rune('r') {
+							goto l1423
+						}
+						position++
+						goto l1422
+					l1423:
+						position, tokenIndex = position1422, tokenIndex1422
+						if buffer[position] != rune('R') {
+							goto l1418
+						}
+						position++
+					}
+				l1422:
+					{
+						position1424, tokenIndex1424 := position, tokenIndex
+						if buffer[position] != rune('a') {
+							goto l1425
+						}
+						position++
+						goto l1424
+					l1425:
+						position, tokenIndex = position1424, tokenIndex1424
+						if buffer[position] != rune('A') {
+							goto l1418
+						}
+						position++
+					}
+				l1424:
+					{
+						position1426, tokenIndex1426 := position, tokenIndex
+						if buffer[position] != rune('n') {
+							goto l1427
+						}
+						position++
+						goto l1426
+					l1427:
+						position, tokenIndex = position1426, tokenIndex1426
+						if buffer[position] != rune('N') {
+							goto l1418
+						}
+						position++
+					}
+				l1426:
+					{
+						position1428, tokenIndex1428 := position, tokenIndex
+						if buffer[position] != rune('g') {
+							goto l1429
+						}
+						position++
+						goto l1428
+					l1429:
+						position, tokenIndex = position1428, tokenIndex1428
+						if buffer[position] != rune('G') {
+							goto l1418
+						}
+						position++
+					}
+				l1428:
+					{
+						position1430, tokenIndex1430 := position, tokenIndex
+						if buffer[position] != rune('e') {
+							goto l1431
+						}
+						position++
+						goto l1430
+					l1431:
+						position, tokenIndex = position1430, tokenIndex1430
+						if buffer[position] != rune('E') {
+							goto l1418
+						}
+						position++
+					}
+				l1430:
+					if !_rules[rulesp]() {
+						goto l1418
+					}
+					if !_rules[ruleInterval]() {
+						goto l1418
+					}
+					if !_rules[ruleCapacitySpecOpt]() {
+						goto l1418
+					}
+					if !_rules[ruleSheddingSpecOpt]() {
+						goto l1418
+					}
+					if !_rules[rulespOpt]() {
+						goto l1418
+					}
+					if buffer[position] != rune(']') {
+						goto l1418
+					}
+					position++
+					if !_rules[ruleAction62]() {
+						goto l1418
+					}
+				}
+			l1420:
+				add(ruleStreamWindow, position1419)
+			}
+			return true
+		l1418:
+			position, tokenIndex = position1418, tokenIndex1418
+			return false
+		},
+		/* 77 StreamLike <- <(UDSFFuncApp / Stream)> */
+		func() bool {
+			position1432, tokenIndex1432 := position, tokenIndex
+			{
+				position1433 := position
+				{
+					position1434, tokenIndex1434 := position, tokenIndex
+					if !_rules[ruleUDSFFuncApp]() {
+						goto l1435
+					}
+					goto l1434
+				l1435:
+					position, tokenIndex = position1434, tokenIndex1434
+					if !_rules[ruleStream]() {
+						goto l1432
+					}
+				}
+			l1434:
+				add(ruleStreamLike, position1433)
+			}
+			return true
+		l1432:
+			position, tokenIndex = position1432, tokenIndex1432
+			return false
+		},
+		/* 78 WindowFunction <- <(TumbleWindow / HopWindow / SessionWindow)> */
+		func() bool {
+			position1436, tokenIndex1436 := position, tokenIndex
+			{
+				position1437 := position
+				{
+					position1438, tokenIndex1438 := position, tokenIndex
+					if !_rules[ruleTumbleWindow]() {
+						goto l1439
+					}
+					goto l1438
+				l1439:
+					position, tokenIndex = position1438, tokenIndex1438
+					if !_rules[ruleHopWindow]() {
+						goto l1440
+					}
+					goto l1438
+				l1440:
+					position, tokenIndex = position1438, tokenIndex1438
+					if !_rules[ruleSessionWindow]() {
+						goto l1436
+					}
+				}
+			l1438:
+				add(ruleWindowFunction, position1437)
+			}
+			return true
+		l1436:
+			position, tokenIndex = position1436, tokenIndex1436
+			return false
+		},
+		/* 79 TumbleWindow <- <(('t' / 'T') ('u' / 'U') ('m' / 'M') ('b' / 'B') ('l' / 'L') ('e' / 'E') spOpt '(' spOpt StreamLike spOpt ',' spOpt TimeAttribute spOpt ',' spOpt IntervalLiteral spOpt ')' Action63)> */
+		func() bool {
+			position1441, tokenIndex1441 := position, tokenIndex
+			{
+				position1442 := position
+				{
+					position1443, tokenIndex1443 := position, tokenIndex
+					if buffer[position] != rune('t') {
+						goto l1444
+					}
+					position++
+					goto l1443
+				l1444:
+					position, tokenIndex = position1443, tokenIndex1443
+					if buffer[position] != rune('T') {
+						goto l1441
+					}
+					position++
+				}
+			l1443:
+				{
+					position1445, tokenIndex1445 := position, tokenIndex
+					if buffer[position] != rune('u') {
+						goto l1446
+					}
+					position++
+					goto l1445
+				l1446:
+					position, tokenIndex = position1445, tokenIndex1445
+					if buffer[position] != rune('U') {
+						goto l1441
+					}
+					position++
+				}
+			l1445:
+				{
+					position1447, tokenIndex1447 := position, tokenIndex
+					if buffer[position] != rune('m') {
+						goto l1448
+					}
+					position++
+					goto l1447
+				l1448:
+					position, tokenIndex = position1447, tokenIndex1447
+					if buffer[position] != rune('M') {
+						goto l1441
+					}
+					position++
+				}
+			l1447:
+				{
+					position1449, tokenIndex1449 := position, tokenIndex
+					if buffer[position] != rune('b') {
+						goto l1450
+					}
+					position++
+					goto l1449
+				l1450:
+					position, tokenIndex = position1449, tokenIndex1449
+					if buffer[position] != rune('B') {
+						goto l1441
+					}
+					position++
+				}
+			l1449:
+				{
+					position1451, tokenIndex1451 := position, tokenIndex
+					if buffer[position] != rune('l') {
+						goto l1452
+					}
+					position++
+					goto l1451
+				l1452:
+					position, tokenIndex = position1451, tokenIndex1451
+					if buffer[position] != rune('L') {
+						goto l1441
+					}
+					position++
+				}
+			l1451:
+				{
+					position1453, tokenIndex1453 := position, tokenIndex
+					if buffer[position] != rune('e') {
+						goto l1454
+					}
+					position++
+					goto l1453
+				l1454:
+					position, tokenIndex = position1453, tokenIndex1453
+					if buffer[position] != rune('E') {
+						goto l1441
+					}
+					position++
+				}
+			l1453:
+				if !_rules[rulespOpt]() {
+					goto l1441
+				}
+				if buffer[position] != rune('(') {
+					goto l1441
+				}
+				position++
+				if !_rules[rulespOpt]() {
+					goto l1441
+				}
+				if !_rules[ruleStreamLike]() {
+					goto l1441
+				}
+				if !_rules[rulespOpt]() {
+					goto l1441
+				}
+				if buffer[position] != rune(',') {
+					goto l1441
+				}
+				position++
+				if !_rules[rulespOpt]() {
+					goto l1441
+				}
+				if !_rules[ruleTimeAttribute]() {
+					goto l1441
+				}
+				if !_rules[rulespOpt]() {
+					goto l1441
+				}
+				if buffer[position] != rune(',') {
+					goto l1441
+				}
+				position++
+				if !_rules[rulespOpt]() {
+					goto l1441
+				}
+				if !_rules[ruleIntervalLiteral]() {
+					goto l1441
+				}
+				if !_rules[rulespOpt]() {
+					goto l1441
+				}
+				if buffer[position] != rune(')') {
+					goto l1441
+				}
+				position++
+				if !_rules[ruleAction63]() {
+					goto l1441
+				}
+				add(ruleTumbleWindow, position1442)
+			}
+			return true
+		l1441:
+			position, tokenIndex = position1441, tokenIndex1441
+			return false
+		},
+		/* 80 HopWindow <- <(('h' / 'H') ('o' / 'O') ('p' / 'P') spOpt '(' spOpt StreamLike spOpt ',' spOpt TimeAttribute spOpt ',' spOpt IntervalLiteral spOpt ',' spOpt IntervalLiteral spOpt ')' Action64)> */
+		func() bool {
+			position1455, tokenIndex1455 := position, tokenIndex
+			{
+				position1456 := position
+				{
+					position1457, tokenIndex1457 := position, tokenIndex
+					if buffer[position] != rune('h') {
+						goto l1458
+					}
+					position++
+					goto l1457
+				l1458:
+					position, tokenIndex = position1457, tokenIndex1457
+					if buffer[position] != rune('H') {
+						goto l1455
+					}
+					position++
+				}
+			l1457:
+				{
+					position1459, tokenIndex1459 := position, tokenIndex
+					if buffer[position] != rune('o') {
+						goto l1460
+					}
+					position++
+					goto l1459
+				l1460:
+					position, tokenIndex = position1459, tokenIndex1459
+					if buffer[position] != rune('O') {
+						goto l1455
+					}
+					position++
+				}
+			l1459:
+				{
+					position1461, tokenIndex1461 := position, tokenIndex
+					if buffer[position] != rune('p') {
+						goto l1462
+					}
+					position++
+					goto l1461
+				l1462:
+					position, tokenIndex = position1461, tokenIndex1461
+					if buffer[position] != rune('P') {
+						goto l1455
+					}
+					position++
+				}
+			l1461:
+				if !_rules[rulespOpt]() {
+					goto l1455
+				}
+				if buffer[position] != rune('(') {
+					goto l1455
+				}
+				position++
+				if !_rules[rulespOpt]() {
+					goto l1455
+				}
+				if !_rules[ruleStreamLike]() {
+					goto l1455
+				}
+				if !_rules[rulespOpt]() {
+					goto l1455
+				}
+				if buffer[position] != rune(',') {
+					goto l1455
+				}
+				position++
+				if !_rules[rulespOpt]() {
+					goto l1455
+				}
+				if !_rules[ruleTimeAttribute]() {
+					goto l1455
+				}
+				if !_rules[rulespOpt]() {
+					goto l1455
+				}
+				if buffer[position] != rune(',') {
+					goto l1455
+				}
+				position++
+				if !_rules[rulespOpt]() {
+					goto l1455
+				}
+				if !_rules[ruleIntervalLiteral]() {
+					goto l1455
+				}
+				if !_rules[rulespOpt]() {
+					goto l1455
+				}
+				if buffer[position] != rune(',') {
+					goto l1455
+				}
+				position++
+				if !_rules[rulespOpt]() {
+					goto l1455
+				}
+				if !_rules[ruleIntervalLiteral]() {
+					goto l1455
+				}
+				if !_rules[rulespOpt]() {
+					goto l1455
+				}
+				if buffer[position] != rune(')') {
+					goto l1455
+				}
+				position++
+				if !_rules[ruleAction64]() {
+					goto l1455
+				}
+				add(ruleHopWindow, position1456)
+			}
+			return true
+		l1455:
+			position, tokenIndex = position1455, tokenIndex1455
+			return false
+		},
+		/* 81 SessionWindow <- <(('s' / 'S') ('e' / 'E') ('s' / 'S') ('s' / 'S') ('i' / 'I') ('o' / 'O') ('n' / 'N') spOpt '(' spOpt StreamLike spOpt ',' spOpt TimeAttribute spOpt ',' spOpt IntervalLiteral spOpt ')' Action65)> */
+		func() bool {
+			position1463, tokenIndex1463 := position, tokenIndex
+			{
+				position1464 := position
+				{
+					position1465, tokenIndex1465 := position, tokenIndex
+					if buffer[position] != rune('s') {
+						goto l1466
+					}
+					position++
+					goto l1465
+				l1466:
+					position, tokenIndex = position1465, tokenIndex1465
+					if buffer[position] != rune('S') {
+						goto l1463
+					}
+					position++
+				}
+			l1465:
+				{
+					position1467, tokenIndex1467 := position, tokenIndex
+					if buffer[position] != rune('e') {
+						goto l1468
+					}
+					position++
+					goto l1467
+				l1468:
+					position, tokenIndex = position1467, tokenIndex1467
+					if buffer[position] != rune('E') {
+						goto l1463
+					}
+					position++
+				}
+			l1467:
+				{
+					position1469, tokenIndex1469 := position, tokenIndex
+					if buffer[position] != rune('s') {
+						goto l1470
+					}
+					position++
+					goto l1469
+				l1470:
+					position, tokenIndex = position1469, tokenIndex1469
+					if buffer[position] != rune('S') {
+						goto l1463
+					}
+					position++
+				}
+			l1469:
+				{
+					position1471, tokenIndex1471 := position, tokenIndex
+					if buffer[position] != rune('s') {
+						goto l1472
+					}
+					position++
+					goto l1471
+				l1472:
+					position, tokenIndex = position1471, tokenIndex1471
+					if buffer[position] != rune('S') {
+						goto l1463
+					}
+					position++
+				}
+			l1471:
+				{
+					position1473, tokenIndex1473 := position, tokenIndex
+					if buffer[position] != rune('i') {
+						goto l1474
+					}
+					position++
+					goto l1473
+				l1474:
+					position, tokenIndex = position1473, tokenIndex1473
+					if buffer[position] != rune('I') {
+						goto l1463
+					}
+					position++
+				}
+			l1473:
+				{
+					position1475, tokenIndex1475 := position, tokenIndex
+					if buffer[position] != rune('o') {
+						goto l1476
+					}
+					position++
+					goto l1475
+				l1476:
+					position, tokenIndex = position1475, tokenIndex1475
+					if buffer[position] != rune('O') {
+						goto l1463
+					}
+					position++
+				}
+			l1475:
+				{
+					position1477, tokenIndex1477 := position, tokenIndex
+					if buffer[position] != rune('n') {
+						goto l1478
+					}
+					position++
+					goto l1477
+				l1478:
+					position, tokenIndex = position1477, tokenIndex1477
+					if buffer[position] != rune('N') {
+						goto l1463
+					}
+					position++
+				}
+			l1477:
+				if !_rules[rulespOpt]() {
+					goto l1463
+				}
+				if buffer[position] != rune('(') {
+					goto l1463
+				}
+				position++
+				if !_rules[rulespOpt]() {
+					goto l1463
+				}
+				if !_rules[ruleStreamLike]() {
+					goto l1463
+				}
+				if !_rules[rulespOpt]() {
+					goto l1463
+				}
+				if buffer[position] != rune(',') {
+					goto l1463
+				}
+				position++
+				if !_rules[rulespOpt]() {
+					goto l1463
+				}
+				if !_rules[ruleTimeAttribute]() {
+					goto l1463
+				}
+				if !_rules[rulespOpt]() {
+					goto l1463
+				}
+				if buffer[position] != rune(',') {
+					goto l1463
+				}
+				position++
+				if !_rules[rulespOpt]() {
+					goto l1463
+				}
+				if !_rules[ruleIntervalLiteral]() {
+					goto l1463
+				}
+				if !_rules[rulespOpt]() {
+					goto l1463
+				}
+				if buffer[position] != rune(')') {
+					goto l1463
+				}
+				position++
+				if !_rules[ruleAction65]() {
+					goto l1463
+				}
+				add(ruleSessionWindow, position1464)
+			}
+			return true
+		l1463:
+			position, tokenIndex = position1463, tokenIndex1463
+			return false
+		},
+		/* 82 TimeAttribute <- <(TupleTimestamp / Identifier)> */
+		func() bool {
+			position1479, tokenIndex1479 := position, tokenIndex
+			{
+				position1480 := position
+				{
+					position1481, tokenIndex1481 := position, tokenIndex
+					if !_rules[ruleTupleTimestamp]() {
+						goto l1482
+					}
+					goto l1481
+				l1482:
+					position, tokenIndex = position1481, tokenIndex1481
+					if !_rules[ruleIdentifier]() {
+						goto l1479
+					}
+				}
+			l1481:
+				add(ruleTimeAttribute, position1480)
+			}
+			return true
+		l1479:
+			position, tokenIndex = position1479, tokenIndex1479
+			return false
+		},
+		/* 83 TupleTimestamp <- <(<('t' 's' '(' ')')> Action66)> */
+		func() bool {
+			position1483, tokenIndex1483 := position, tokenIndex
+			{
+				position1484 := position
+				{
+					position1485 := position
+					if buffer[position] != rune('t') {
+						goto l1483
+					}
+					position++
+					if buffer[position] != rune('s') {
+						goto l1483
+					}
+					position++
+					if buffer[position] != rune('(') {
+						goto l1483
+					}
+					position++
+					if buffer[position] != rune(')') {
+						goto l1483
+					}
+					position++
+					add(rulePegText, position1485)
+				}
+				if !_rules[ruleAction66]() {
+					goto l1483
+				}
+				add(ruleTupleTimestamp, position1484)
+			}
+			return true
+		l1483:
+			position, tokenIndex = position1483, tokenIndex1483
+			return false
+		},
+		/* 84 IntervalLiteral <- <(('i' / 'I') ('n' / 'N') ('t' / 'T') ('e' / 'E') ('r' / 'R') ('v' / 'V') ('a' / 'A') ('l' / 'L') sp (IntervalLiteralValue / ('\'' spOpt IntervalLiteralValue spOpt '\'')) sp IntervalLiteralUnit Action67)> */
+		func() bool {
+			position1486, tokenIndex1486 := position, tokenIndex
+			{
+				position1487 := position
+				{
+					position1488, tokenIndex1488 := position, tokenIndex
+					if buffer[position] != rune('i') {
+						goto l1489
+					}
+					position++
+					goto l1488
+				l1489:
+					position, tokenIndex = position1488, tokenIndex1488
+					if buffer[position] != rune('I') {
+						goto l1486
+					}
+					position++
+				}
+			l1488:
+				{
+					position1490, tokenIndex1490 := position, tokenIndex
+					if buffer[position] != rune('n') {
+						goto l1491
+					}
+					position++
+					goto l1490
+				l1491:
+					position, tokenIndex = position1490, tokenIndex1490
+					if buffer[position] != rune('N') {
+						goto l1486
+					}
+					position++
+				}
+			l1490:
+				{
+					position1492, tokenIndex1492 := position, tokenIndex
+					if buffer[position] != rune('t') {
+						goto l1493
+					}
+					position++
+					goto l1492
+				l1493:
+					position, tokenIndex = position1492, tokenIndex1492
+					if buffer[position] != rune('T') {
+						goto l1486
+					}
+					position++
+				}
+			l1492:
+				{
+					position1494, tokenIndex1494 := position, tokenIndex
+					if buffer[position] != rune('e') {
+						goto l1495
+					}
+					position++
+					goto l1494
+				l1495:
+					position, tokenIndex = position1494, tokenIndex1494
+					if buffer[position] != rune('E') {
+						goto l1486
+					}
+					position++
+				}
+			l1494:
+				{
+					position1496, tokenIndex1496 := position, tokenIndex
+					if buffer[position] != rune('r') {
+						goto l1497
+					}
+					position++
+					goto l1496
+				l1497:
+					position, tokenIndex = position1496, tokenIndex1496
+					if buffer[position] != rune('R') {
+						goto l1486
+					}
+					position++
+				}
+			l1496:
+				{
+					position1498, tokenIndex1498 := position, tokenIndex
+					if buffer[position] != rune('v') {
+						goto l1499
+					}
+					position++
+					goto l1498
+				l1499:
+					position, tokenIndex = position1498, tokenIndex1498
+					if buffer[position] != rune('V') {
+						goto l1486
+					}
+					position++
+				}
+			l1498:
+				{
+					position1500, tokenIndex1500 := position, tokenIndex
+					if buffer[position] != rune('a') {
+						goto l1501
+					}
+					position++
+					goto l1500
+				l1501:
+					position, tokenIndex = position1500, tokenIndex1500
+					if buffer[position] != rune('A') {
+						goto l1486
+					}
+					position++
+				}
+			l1500:
+				{
+					position1502, tokenIndex1502 := position, tokenIndex
+					if buffer[position] != rune('l') {
+						goto l1503
+					}
+					position++
+					goto l1502
+				l1503:
+					position, tokenIndex = position1502, tokenIndex1502
+					if buffer[position] != rune('L') {
+						goto l1486
+					}
+					position++
+				}
+			l1502:
+				if !_rules[rulesp]() {
+					goto l1486
+				}
+				{
+					position1504, tokenIndex1504 := position, tokenIndex
+					if !_rules[ruleIntervalLiteralValue]() {
+						goto l1505
+					}
+					goto l1504
+				l1505:
+					position, tokenIndex = position1504, tokenIndex1504
+					if buffer[position] != rune('\'') {
+						goto l1486
+					}
+					position++
+					if !_rules[rulespOpt]() {
+						goto l1486
+					}
+					if !_rules[ruleIntervalLiteralValue]() {
+						goto l1486
+					}
+					if !_rules[rulespOpt]() {
+						goto l1486
+					}
+					if buffer[position] != rune('\'') {
+						goto l1486
+					}
+					position++
+				}
+			l1504:
+				if !_rules[rulesp]() {
+					goto l1486
+				}
+				if !_rules[ruleIntervalLiteralUnit]() {
+					goto l1486
+				}
+				if !_rules[ruleAction67]() {
+					goto l1486
+				}
+				add(ruleIntervalLiteral, position1487)
+			}
+			return true
+		l1486:
+			position, tokenIndex = position1486, tokenIndex1486
+			return false
+		},
+		/* 85 IntervalLiteralValue <- <(FloatLiteral / NonNegativeNumericLiteral)> */
+		func() bool {
+			position1506, tokenIndex1506 := position, tokenIndex
+			{
+				position1507 := position
+				{
+					position1508, tokenIndex1508 := position, tokenIndex
+					if !_rules[ruleFloatLiteral]() {
+						goto l1509
+					}
+					goto l1508
+				l1509:
+					position, tokenIndex = position1508, tokenIndex1508
+					if !_rules[ruleNonNegativeNumericLiteral]() {
+						goto l1506
+					}
+				}
+			l1508:
+				add(ruleIntervalLiteralValue, position1507)
+			}
+			return true
+		l1506:
+			position, tokenIndex = position1506, tokenIndex1506
+			return false
+		},
+		/* 86 IntervalLiteralUnit <- <(<((('m' / 'M') ('i' / 'I') ('l' / 'L') ('l' / 'L') ('i' / 'I') ('s' / 'S') ('e' / 'E') ('c' / 'C') ('o' / 'O') ('n' / 'N') ('d' / 'D') ('s' / 'S')) / (('m' / 'M') ('i' / 'I') ('l' / 'L') ('l' / 'L') ('i' / 'I') ('s' / 'S') ('e' / 'E') ('c' / 'C') ('o' / 'O') ('n' / 'N') ('d' / 'D')) / (('s' / 'S') ('e' / 'E') ('c' / 'C') ('o' / 'O') ('n' / 'N') ('d' / 'D') ('s' / 'S')) / (('s' / 'S') ('e' / 'E') ('c' / 'C') ('o' / 'O') ('n' / 'N') ('d' / 'D')) / (('m' / 'M') ('i' / 'I') ('n' / 'N') ('u' / 'U') ('t' / 'T') ('e' / 'E') ('s' / 'S')) / (('m' / 'M') ('i' / 'I') ('n' / 'N') ('u' / 'U') ('t' / 'T') ('e' / 'E')) / (('h' / 'H') ('o' / 'O') ('u' / 'U') ('r' / 'R') ('s' / 'S')) / (('h' / 'H') ('o' / 'O') ('u' / 'U') ('r' / 'R')) / (('d' / 'D') ('a' / 'A') ('y' / 'Y') ('s' / 'S')) / (('d' / 'D') ('a' / 'A') ('y' / 'Y')))> Action68)> */
+		func() bool {
+			position1510, tokenIndex1510 := position, tokenIndex
+			{
+				position1511 := position
+				{
+					position1512 := position
+					{
+						position1513, tokenIndex1513 := position, tokenIndex
+						{
+							position1515, tokenIndex1515 := position, tokenIndex
+							if buffer[position] != rune('m') {
+								goto l1516
+							}
+							position++
+							goto l1515
+						l1516:
+							position, tokenIndex = position1515, tokenIndex1515
+							if buffer[position] != rune('M') {
+								goto l1514
+							}
+							position++
+						}
+					l1515:
+						{
+							position1517, tokenIndex1517 := position, tokenIndex
+							if buffer[position] != rune('i') {
+								goto l1518
+							}
+							position++
+							goto l1517
+						l1518:
+							position, tokenIndex = position1517, tokenIndex1517
+							if buffer[position] != rune('I') {
+								goto l1514
+							}
+							position++
+						}
+					l1517:
+						{
+							position1519, tokenIndex1519 := position, tokenIndex
+							if buffer[position] != rune('l') {
+								goto l1520
+							}
+							position++
+							goto l1519
+						l1520:
+							position, tokenIndex = position1519, tokenIndex1519
+							if buffer[position] != rune('L') {
+								goto l1514
+							}
+							position++
+						}
+					l1519:
+						{
+							position1521, tokenIndex1521 := position, tokenIndex
+							if buffer[position] != rune('l') {
+								goto l1522
+							}
+							position++
+							goto l1521
+						l1522:
+							position, tokenIndex = position1521, tokenIndex1521
+							if buffer[position] != rune('L') {
+								goto l1514
+							}
+							position++
+						}
+					l1521:
+						{
+							position1523, tokenIndex1523 := position, tokenIndex
+							if buffer[position] != rune('i') {
+								goto l1524
+							}
+							position++
+							goto l1523
+						l1524:
+							position, tokenIndex = position1523, tokenIndex1523
+							if buffer[position] != rune('I') {
+								goto l1514
+							}
+							position++
+						}
+					l1523:
+						{
+							position1525, tokenIndex1525 := position, tokenIndex
+							if buffer[position] != rune('s') {
+								goto l1526
+							}
+							position++
+							goto l1525
+						l1526:
+							position, tokenIndex = position1525, tokenIndex1525
+							if buffer[position] != rune('S') {
+								goto l1514
+							}
+							position++
+						}
+					l1525:
+						{
+							position1527, tokenIndex1527 := position, tokenIndex
+							if buffer[position] != rune('e') {
+								goto l1528
+							}
+							position++
+							goto l1527
+						l1528:
+							position, tokenIndex = position1527, tokenIndex1527
+							if buffer[position] != rune('E') {
+								goto l1514
+							}
+							position++
+						}
+					l1527:
+						{
+							position1529, tokenIndex1529 := position, tokenIndex
+							if buffer[position] != rune('c') {
+								goto l1530
+							}
+							position++
+							goto l1529
+						l1530:
+							position, tokenIndex = position1529, tokenIndex1529
+							if buffer[position] != rune('C') {
+								goto l1514
+							}
+							position++
+						}
+					l1529:
+						{
+							position1531, tokenIndex1531 := position, tokenIndex
+							if buffer[position] != rune('o') {
+								goto l1532
+							}
+							position++
+							goto l1531
+						l1532:
+							position, tokenIndex = position1531, tokenIndex1531
+							if buffer[position] != rune('O') {
+								goto l1514
+							}
+							position++
+						}
+					l1531:
+						{
+							position1533, tokenIndex1533 := position, tokenIndex
+							if buffer[position] != rune('n') {
+								goto l1534
+							}
+							position++
+							goto l1533
+						l1534:
+							position, tokenIndex = position1533, tokenIndex1533
+							if buffer[position] != rune('N') {
+								goto l1514
+							}
+							position++
+						}
+					l1533:
+						{
+							position1535, tokenIndex1535 := position, tokenIndex
+							if buffer[position] != rune('d') {
+								goto l1536
+							}
+							position++
+							goto l1535
+						l1536:
+							position, tokenIndex = position1535, tokenIndex1535
+							if buffer[position] != rune('D') {
+								goto l1514
+							}
+							position++
+						}
+					l1535:
+						{
+							position1537, tokenIndex1537 := position, tokenIndex
+							if buffer[position] != rune('s') {
+								goto l1538
+							}
+							position++
+							goto l1537
+						l1538:
+							position, tokenIndex = position1537, tokenIndex1537
+							if buffer[position] != rune('S') {
+								goto l1514
+							}
+							position++
+						}
+					l1537:
+						goto l1513
+					l1514:
+						position, tokenIndex = position1513, tokenIndex1513
+						{
+							position1540, tokenIndex1540 := position, tokenIndex
+							if buffer[position] != rune('m') {
+								goto l1541
+							}
+							position++
+							goto l1540
+						l1541:
+							position, tokenIndex = position1540, tokenIndex1540
+							if buffer[position] != rune('M') {
+								goto l1539
+							}
+							position++
+						}
+					l1540:
+						{
+							position1542, tokenIndex1542 := position, tokenIndex
+							if buffer[position] != rune('i') {
+								goto l1543
+							}
+							position++
+							goto l1542
+						l1543:
+							position, tokenIndex = position1542, tokenIndex1542
+							if buffer[position] != rune('I') {
+								goto l1539
+							}
+							position++
+						}
+					l1542:
+						{
+							position1544, tokenIndex1544 := position, tokenIndex
+							if buffer[position] != rune('l') {
+								goto l1545
+							}
+							position++
+							goto l1544
+						l1545:
+							position, tokenIndex = position1544, tokenIndex1544
+							if buffer[position] != rune('L') {
+								goto l1539
+							}
+							position++
+						}
+					l1544:
+						{
+							position1546, tokenIndex1546 := position, tokenIndex
+							if buffer[position] != rune('l') {
+								goto l1547
+							}
+							position++
+							goto l1546
+						l1547:
+							position, tokenIndex = position1546, tokenIndex1546
+							if buffer[position] != rune('L') {
+								goto l1539
+							}
+							position++
+						}
+					l1546:
+						{
+							position1548, tokenIndex1548 := position, tokenIndex
+							if buffer[position] != rune('i') {
+								goto l1549
+							}
+							position++
+							goto l1548
+						l1549:
+							position, tokenIndex = position1548, tokenIndex1548
+							if buffer[position] != rune('I') {
+								goto l1539
+							}
+							position++
+						}
+					l1548:
+						{
+							position1550, tokenIndex1550 := position, tokenIndex
+							if buffer[position] != rune('s') {
+								goto l1551
+							}
+							position++
+							goto l1550
+						l1551:
+							position, tokenIndex = position1550, tokenIndex1550
+							if buffer[position] != rune('S') {
+								goto l1539
+							}
+							position++
+						}
+					l1550:
+						{
+							position1552, tokenIndex1552 := position, tokenIndex
+							if buffer[position] != rune('e') {
+								goto l1553
+							}
+							position++
+							goto l1552
+						l1553:
+							position, tokenIndex = position1552, tokenIndex1552
+							if buffer[position] != rune('E') {
+								goto l1539
+							}
+							position++
+						}
+					l1552:
+						{
+							position1554, tokenIndex1554 := position, tokenIndex
+							if buffer[position] != rune('c') {
+								goto l1555
+							}
+							position++
+							goto l1554
+						l1555:
+							position, tokenIndex = position1554, tokenIndex1554
+							if buffer[position] != rune('C') {
+								goto l1539
+							}
+							position++
+						}
+					l1554:
+						{
+							position1556, tokenIndex1556 := position, tokenIndex
+							if buffer[position] != rune('o') {
+								goto l1557
+							}
+							position++
+							goto l1556
+						l1557:
+							position, tokenIndex = position1556, tokenIndex1556
+							if buffer[position] != rune('O') {
+								goto l1539
+							}
+							position++
+						}
+					l1556:
+						{
+							position1558, tokenIndex1558 := position, tokenIndex
+							if buffer[position] != rune('n') {
+								goto l1559
+							}
+							position++
+							goto l1558
+						l1559:
+							position, tokenIndex = position1558, tokenIndex1558
+							if buffer[position] != rune('N') {
+								goto l1539
+							}
+							position++
+						}
+					l1558:
+						{
+							position1560, tokenIndex1560 := position, tokenIndex
+							if buffer[position] != rune('d') {
+								goto l1561
+							}
+							position++
+							goto l1560
+						l1561:
+							position, tokenIndex = position1560, tokenIndex1560
+							if buffer[position] != rune('D') {
+								goto l1539
+							}
+							position++
+						}
+					l1560:
+						goto l1513
+					l1539:
+						position, tokenIndex = position1513, tokenIndex1513
+						{
+							position1563, tokenIndex1563 := position, tokenIndex
+							if buffer[position] != rune('s') {
+								goto l1564
+							}
+							position++
+							goto l1563
+						l1564:
+							position, tokenIndex = position1563, tokenIndex1563
+							if buffer[position] != rune('S') {
+								goto l1562
+							}
+							position++
+						}
+					l1563:
+						{
+							position1565, tokenIndex1565 := position, tokenIndex
+							if buffer[position] != rune('e') {
+								goto l1566
+							}
+							position++
+							goto l1565
+						l1566:
+							position, tokenIndex = position1565, tokenIndex1565
+							if buffer[position] != rune('E') {
+								goto l1562
+							}
+							position++
+						}
+					l1565:
+						{
+							position1567, tokenIndex1567 := position, tokenIndex
+							if buffer[position] != rune('c') {
+								goto l1568
+							}
+							position++
+							goto l1567
+						l1568:
+							position, tokenIndex = position1567, tokenIndex1567
+							if buffer[position] != rune('C') {
+								goto l1562
+							}
+							position++
+						}
+					l1567:
+						{
+							position1569, tokenIndex1569 := position, tokenIndex
+							if buffer[position] != rune('o') {
+								goto l1570
+							}
+							position++
+							goto l1569
+						l1570:
+							position, tokenIndex = position1569, tokenIndex1569
+							if buffer[position] != rune('O') {
+								goto l1562
+							}
+							position++
+						}
+					l1569:
+						{
+							position1571, tokenIndex1571 := position, tokenIndex
+							if buffer[position] != rune('n') {
+								goto l1572
+							}
+							position++
+							goto l1571
+						l1572:
+							position, tokenIndex = position1571, tokenIndex1571
+							if buffer[position] != rune('N') {
+								goto l1562
+							}
+							position++
+						}
+					l1571:
+						{
+							position1573, tokenIndex1573 := position, tokenIndex
+							if buffer[position] != rune('d') {
+								goto l1574
+							}
+							position++
+							goto l1573
+						l1574:
+							position, tokenIndex = position1573, tokenIndex1573
+							if buffer[position] != rune('D') {
+								goto l1562
+							}
+							position++
+						}
+					l1573:
+						{
+							position1575, tokenIndex1575 := position, tokenIndex
+							if buffer[position] != rune('s') {
+								goto l1576
+							}
+							position++
+							goto l1575
+						l1576:
+							position, tokenIndex = position1575, tokenIndex1575
+							if buffer[position] != rune('S') {
+								goto l1562
+							}
+							position++
+						}
+					l1575:
+						goto l1513
+					l1562:
+						position, tokenIndex = position1513, tokenIndex1513
+						{
+							position1578, tokenIndex1578 := position, tokenIndex
+							if buffer[position] != rune('s') {
+								goto l1579
+							}
+							position++
+							goto l1578
+						l1579:
+							position, tokenIndex = position1578, tokenIndex1578
+							if buffer[position] != rune('S') {
+								goto l1577
+							}
+							position++
+						}
+					l1578:
+						{
+							position1580, tokenIndex1580 := position, tokenIndex
+							if buffer[position] != rune('e') {
+								goto l1581
+							}
+							position++
+							goto l1580
+						l1581:
+							position, tokenIndex = position1580, tokenIndex1580
+							if buffer[position] != rune('E') {
+								goto l1577
+							}
+							position++
+						}
+					l1580:
+						{
+							position1582, tokenIndex1582 := position, tokenIndex
+							if buffer[position] != rune('c') {
+								goto l1583
+							}
+							position++
+							goto l1582
+						l1583:
+							position, tokenIndex = position1582, tokenIndex1582
+							if buffer[position] != rune('C') {
+								goto l1577
+							}
+							position++
+						}
+					l1582:
+						{
+							position1584, tokenIndex1584 := position, tokenIndex
+							if buffer[position] != rune('o') {
+								goto l1585
+							}
+							position++
+							goto l1584
+						l1585:
+							position, tokenIndex = position1584, tokenIndex1584
+							if buffer[position] != rune('O') {
+								goto l1577
+							}
+							position++
+						}
+					l1584:
+						{
+							position1586, tokenIndex1586 := position, tokenIndex
+							if buffer[position] != rune('n') {
+								goto l1587
+							}
+							position++
+							goto l1586
+						l1587:
+							position, tokenIndex = position1586, tokenIndex1586
+							if buffer[position] != rune('N') {
+								goto l1577
+							}
+							position++
+						}
+					l1586:
+						{
+							position1588, tokenIndex1588 := position, tokenIndex
+							if buffer[position] != rune('d') {
+								goto l1589
+							}
+							position++
+							goto l1588
+						l1589:
+							position, tokenIndex = position1588, tokenIndex1588
+							if buffer[position] != rune('D') {
+								goto l1577
+							}
+							position++
+						}
+					l1588:
+						goto l1513
+					l1577:
+						position, tokenIndex = position1513, tokenIndex1513
+						{
+							position1591, tokenIndex1591 := position, tokenIndex
+							if buffer[position] != rune('m') {
+								goto l1592
+							}
+							position++
+							goto l1591
+						l1592:
+							position, tokenIndex = position1591, tokenIndex1591
+							if buffer[position] != rune('M') {
+								goto l1590
+							}
+							position++
+						}
+					l1591:
+						{
+							position1593, tokenIndex1593 := position, tokenIndex
+							if buffer[position] != rune('i') {
+								goto l1594
+							}
+							position++
+							goto l1593
+						l1594:
+							position, tokenIndex = position1593, tokenIndex1593
+							if buffer[position] != rune('I') {
+								goto l1590
+							}
+							position++
+						}
+					l1593:
+						{
+							position1595, tokenIndex1595 := position, tokenIndex
+							if buffer[position] != rune('n') {
+								goto l1596
+							}
+							position++
+							goto l1595
+						l1596:
+							position, tokenIndex = position1595, tokenIndex1595
+							if buffer[position] != rune('N') {
+								goto l1590
+							}
+							position++
+						}
+					l1595:
+						{
+							position1597, tokenIndex1597 := position, tokenIndex
+							if buffer[position] != rune('u') {
+								goto l1598
+							}
+							position++
+							goto l1597
+						l1598:
+							position, tokenIndex = position1597, tokenIndex1597
+							if buffer[position] != rune('U') {
+								goto l1590
+							}
+							position++
+						}
+					l1597:
+						{
+							position1599, tokenIndex1599 := position, tokenIndex
+							if buffer[position] != rune('t') {
+								goto l1600
+							}
+							position++
+							goto l1599
+						l1600:
+							position, tokenIndex = position1599, tokenIndex1599
+							if buffer[position] != rune('T') {
+								goto l1590
+							}
+							position++
+						}
+					l1599:
+						{
+							position1601, tokenIndex1601 := position, tokenIndex
+							if buffer[position] != rune('e') {
+								goto l1602
+							}
+							position++
+							goto l1601
+						l1602:
+							position, tokenIndex = position1601, tokenIndex1601
+							if buffer[position] != rune('E') {
+								goto l1590
+							}
+							position++
+						}
+					l1601:
+						{
+							position1603, tokenIndex1603 := position, tokenIndex
+							if buffer[position] != rune('s') {
+								goto l1604
+							}
+							position++
+							goto l1603
+						l1604:
+							position, tokenIndex = position1603, tokenIndex1603
+							if buffer[position] != rune('S') {
+								goto l1590
+							}
+							position++
+						}
+					l1603:
+						goto l1513
+					l1590:
+						position, tokenIndex = position1513, tokenIndex1513
+						{
+							position1606, tokenIndex1606 := position, tokenIndex
+							if buffer[position] != rune('m') {
+								goto l1607
+							}
+							position++
+							goto l1606
+						l1607:
+							position, tokenIndex = position1606, tokenIndex1606
+							if buffer[position] != rune('M') {
+								goto l1605
 							}
 							position++
 						}
-					l844:
+					l1606:
 						{
-							position846, tokenIndex846 := position, tokenIndex
-							if buffer[position] != rune('h') {
-								goto l847
+							position1608, tokenIndex1608 := position, tokenIndex
+							if buffer[position] != rune('i') {
+								goto l1609
 							}
 							position++
-							goto l846
-						l847:
-							position, tokenIndex = position846, tokenIndex846
-							if buffer[position] != rune('H') {
-								goto l842
+							goto l1608
+						l1609:
+							position, tokenIndex = position1608, tokenIndex1608
+							if buffer[position] != rune('I') {
+								goto l1605
 							}
 							position++
 						}
-					l846:
+					l1608:
 						{
-							position848, tokenIndex848 := position, tokenIndex
-							if buffer[position] != rune('e') {
-								goto l849
+							position1610, tokenIndex1610 := position, tokenIndex
+							if buffer[position] != rune('n') {
+								goto l1611
 							}
 							position++
-							goto l848
-						l849:
-							position, tokenIndex = position848, tokenIndex848
-							if buffer[position] != rune('E') {
-								goto l842
+							goto l1610
+						l1611:
+							position, tokenIndex = position1610, tokenIndex1610
+							if buffer[position] != rune('N') {
+								goto l1605
 							}
 							position++
 						}
-					l848:
+					l1610:
 						{
-							position850, tokenIndex850 := position, tokenIndex
-							if buffer[position] != rune('r') {
-								goto l851
+							position1612, tokenIndex1612 := position, tokenIndex
+							if buffer[position] != rune('u') {
+								goto l1613
 							}
 							position++
-							goto l850
-						l851:
-							position, tokenIndex = position850, tokenIndex850
-							if buffer[position] != rune('R') {
-								goto l842
+							goto l1612
+						l1613:
+							position, tokenIndex = position1612, tokenIndex1612
+							if buffer[position] != rune('U') {
+								goto l1605
 							}
 							position++
 						}
-					l850:
+					l1612:
 						{
-							position852, tokenIndex852 := position, tokenIndex
-							if buffer[position] != rune('e') {
-								goto l853
+							position1614, tokenIndex1614 := position, tokenIndex
+							if buffer[position] != rune('t') {
+								goto l1615
 							}
 							position++
-							goto l852
-						l853:
-							position, tokenIndex = position852, tokenIndex852
-							if buffer[position] != rune('E') {
-								goto l842
+							goto l1614
+						l1615:
+							position, tokenIndex = position1614, tokenIndex1614
+							if buffer[position] != rune('T') {
+								goto l1605
 							}
 							position++
 						}
-					l852:
-						if !_rules[rulesp]() {
-							goto l842
-						}
-						if !_rules[ruleExpression]() {
-							goto l842
-						}
-						goto l843
-					l842:
-						position, tokenIndex = position842, tokenIndex842
-					}
-				l843:
-					add(rulePegText, position841)
-				}
-				if !_rules[ruleAction36]() {
-					goto l839
-				}
-				add(ruleFilter, position840)
-			}
-			return true
-		l839:
-			position, tokenIndex = position839, tokenIndex839
-			return false
-		},
-		/* 49 Grouping <- <(<(sp (('g' / 'G') ('r' / 'R') ('o' / 'O') ('u' / 'U') ('p' / 'P')) sp (('b' / 'B') ('y' / 'Y')) sp GroupList)?> Action37)> */
-		func() bool {
-			position854, tokenIndex854 := position, tokenIndex
-			{
-				position855 := position
-				{
-					position856 := position
-					{
-						position857, tokenIndex857 := position, tokenIndex
-						if !_rules[rulesp]() {
-							goto l857
-						}
+					l1614:
 						{
-							position859, tokenIndex859 := position, tokenIndex
-							if buffer[position] != rune('g') {
-								goto l860
+							position1616, tokenIndex1616 := position, tokenIndex
+							if buffer[position] != rune('e') {
+								goto l1617
 							}
 							position++
-							goto l859
-						l860:
-							position, tokenIndex = position859, tokenIndex859
-							if buffer[position] != rune('G') {
-								goto l857
+							goto l1616
+						l1617:
+							position, tokenIndex = position1616, tokenIndex1616
+							if buffer[position] != rune('E') {
+								goto l1605
 							}
 							position++
 						}
-					l859:
+					l1616:
+						goto l1513
+					l1605:
+						position, tokenIndex = position1513, tokenIndex1513
 						{
-							position861, tokenIndex861 := position, tokenIndex
-							if buffer[position] != rune('r') {
-								goto l862
+							position1619, tokenIndex1619 := position, tokenIndex
+							if buffer[position] != rune('h') {
+								goto l1620
 							}
 							position++
-							goto l861
-						l862:
-							position, tokenIndex = position861, tokenIndex861
-							if buffer[position] != rune('R') {
-								goto l857
+							goto l1619
+						l1620:
+							position, tokenIndex = position1619, tokenIndex1619
+							if buffer[position] != rune('H') {
+								goto l1618
 							}
 							position++
 						}
-					l861:
+					l1619:
 						{
-							position863, tokenIndex863 := position, tokenIndex
+							position1621, tokenIndex1621 := position, tokenIndex
 							if buffer[position] != rune('o') {
-								goto l864
+								goto l1622
 							}
 							position++
-							goto l863
-						l864:
-							position, tokenIndex = position863, tokenIndex863
+							goto l1621
+						l1622:
+							position, tokenIndex = position1621, tokenIndex1621
 							if buffer[position] != rune('O') {
-								goto l857
+								goto l1618
 							}
 							position++
 						}
-					l863:
+					l1621:
 						{
-							position865, tokenIndex865 := position, tokenIndex
+							position1623, tokenIndex1623 := position, tokenIndex
 							if buffer[position] != rune('u') {
-								goto l866
+								goto l1624
 							}
 							position++
-							goto l865
-						l866:
-							position, tokenIndex = position865, tokenIndex865
+							goto l1623
+						l1624:
+							position, tokenIndex = position1623, tokenIndex1623
 							if buffer[position] != rune('U') {
-								goto l857
+								goto l1618
 							}
 							position++
 						}
-					l865:
+					l1623:
 						{
-							position867, tokenIndex867 := position, tokenIndex
-							if buffer[position] != rune('p') {
-								goto l868
+							position1625, tokenIndex1625 := position, tokenIndex
+							if buffer[position] != rune('r') {
+								goto l1626
 							}
 							position++
-							goto l867
-						l868:
-							position, tokenIndex = position867, tokenIndex867
-							if buffer[position] != rune('P') {
-								goto l857
+							goto l1625
+						l1626:
+							position, tokenIndex = position1625, tokenIndex1625
+							if buffer[position] != rune('R') {
+								goto l1618
 							}
 							position++
 						}
-					l867:
-						if !_rules[rulesp]() {
-							goto l857
-						}
+					l1625:
 						{
-							position869, tokenIndex869 := position, tokenIndex
-							if buffer[position] != rune('b') {
-								goto l870
+							position1627, tokenIndex1627 := position, tokenIndex
+							if buffer[position] != rune('s') {
+								goto l1628
 							}
 							position++
-							goto l869
-						l870:
-							position, tokenIndex = position869, tokenIndex869
-							if buffer[position] != rune('B') {
-								goto l857
+							goto l1627
+						l1628:
+							position, tokenIndex = position1627, tokenIndex1627
+							if buffer[position] != rune('S') {
+								goto l1618
 							}
 							position++
 						}
-					l869:
+					l1627:
+						goto l1513
+					l1618:
+						position, tokenIndex = position1513, tokenIndex1513
 						{
-							position871, tokenIndex871 := position, tokenIndex
-							if buffer[position] != rune('y') {
-								goto l872
+							position1630, tokenIndex1630 := position, tokenIndex
+							if buffer[position] != rune('h') {
+								goto l1631
 							}
 							position++
-							goto l871
-						l872:
-							position, tokenIndex = position871, tokenIndex871
-							if buffer[position] != rune('Y') {
-								goto l857
+							goto l1630
+						l1631:
+							position, tokenIndex = position1630, tokenIndex1630
+							if buffer[position] != rune('H') {
+								goto l1629
 							}
 							position++
 						}
-					l871:
-						if !_rules[rulesp]() {
-							goto l857
-						}
-						if !_rules[ruleGroupList]() {
-							goto l857
-						}
-						goto l858
-					l857:
-						position, tokenIndex = position857, tokenIndex857
-					}
-				l858:
-					add(rulePegText, position856)
-				}
-				if !_rules[ruleAction37]() {
-					goto l854
-				}
-				add(ruleGrouping, position855)
-			}
-			return true
-		l854:
-			position, tokenIndex = position854, tokenIndex854
-			return false
-		},
-		/* 50 GroupList <- <(Expression (spOpt ',' spOpt Expression)*)> */
-		func() bool {
-			position873, tokenIndex873 := position, tokenIndex
-			{
-				position874 := position
-				if !_rules[ruleExpression]() {
-					goto l873
-				}
-			l875:
-				{
-					position876, tokenIndex876 := position, tokenIndex
-					if !_rules[rulespOpt]() {
-						goto l876
-					}
-					if buffer[position] != rune(',') {
-						goto l876
-					}
-					position++
-					if !_rules[rulespOpt]() {
-						goto l876
-					}
-					if !_rules[ruleExpression]() {
-						goto l876
-					}
-					goto l875
-				l876:
-					position, tokenIndex = position876, tokenIndex876
-				}
-				add(ruleGroupList, position874)
-			}
-			return true
-		l873:
-			position, tokenIndex = position873, tokenIndex873
-			return false
-		},
-		/* 51 Having <- <(<(sp (('h' / 'H') ('a' / 'A') ('v' / 'V') ('i' / 'I') ('n' / 'N') ('g' / 'G')) sp Expression)?> Action38)> */
-		func() bool {
-			position877, tokenIndex877 := position, tokenIndex
-			{
-				position878 := position
-				{
-					position879 := position
-					{
-						position880, tokenIndex880 := position, tokenIndex
-						if !_rules[rulesp]() {
-							goto l880
-						}
+					l1630:
 						{
-							position882, tokenIndex882 := position, tokenIndex
-							if buffer[position] != rune('h') {
-								goto l883
+							position1632, tokenIndex1632 := position, tokenIndex
+							if buffer[position] != rune('o') {
+								goto l1633
 							}
 							position++
-							goto l882
-						l883:
-							position, tokenIndex = position882, tokenIndex882
-							if buffer[position] != rune('H') {
-								goto l880
+							goto l1632
+						l1633:
+							position, tokenIndex = position1632, tokenIndex1632
+							if buffer[position] != rune('O') {
+								goto l1629
 							}
 							position++
 						}
-					l882:
+					l1632:
 						{
-							position884, tokenIndex884 := position, tokenIndex
-							if buffer[position] != rune('a') {
-								goto l885
+							position1634, tokenIndex1634 := position, tokenIndex
+							if buffer[position] != rune('u') {
+								goto l1635
 							}
 							position++
-							goto l884
-						l885:
-							position, tokenIndex = position884, tokenIndex884
-							if buffer[position] != rune('A') {
-								goto l880
+							goto l1634
+						l1635:
+							position, tokenIndex = position1634, tokenIndex1634
+							if buffer[position] != rune('U') {
+								goto l1629
 							}
 							position++
 						}
-					l884:
+					l1634:
 						{
-							position886, tokenIndex886 := position, tokenIndex
-							if buffer[position] != rune('v') {
-								goto l887
+							position1636, tokenIndex1636 := position, tokenIndex
+							if buffer[position] != rune('r') {
+								goto l1637
 							}
 							position++
-							goto l886
-						l887:
-							position, tokenIndex = position886, tokenIndex886
-							if buffer[position] != rune('V') {
-								goto l880
+							goto l1636
+						l1637:
+							position, tokenIndex = position1636, tokenIndex1636
+							if buffer[position] != rune('R') {
+								goto l1629
 							}
 							position++
 						}
-					l886:
+					l1636:
+						goto l1513
+					l1629:
+						position, tokenIndex = position1513, tokenIndex1513
 						{
-							position888, tokenIndex888 := position, tokenIndex
-							if buffer[position] != rune('i') {
-								goto l889
+							position1639, tokenIndex1639 := position, tokenIndex
+							if buffer[position] != rune('d') {
+								goto l1640
 							}
 							position++
-							goto l888
-						l889:
-							position, tokenIndex = position888, tokenIndex888
-							if buffer[position] != rune('I') {
-								goto l880
+							goto l1639
+						l1640:
+							position, tokenIndex = position1639, tokenIndex1639
+							if buffer[position] != rune('D') {
+								goto l1638
 							}
 							position++
 						}
-					l888:
+					l1639:
 						{
-							position890, tokenIndex890 := position, tokenIndex
-							if buffer[position] != rune('n') {
-								goto l891
+							position1641, tokenIndex1641 := position, tokenIndex
+							if buffer[position] != rune('a') {
+								goto l1642
 							}
 							position++
-							goto l890
-						l891:
-							position, tokenIndex = position890, tokenIndex890
-							if buffer[position] != rune('N') {
-								goto l880
+							goto l1641
+						l1642:
+							position, tokenIndex = position1641, tokenIndex1641
+							if buffer[position] != rune('A') {
+								goto l1638
 							}
 							position++
 						}
-					l890:
+					l1641:
 						{
-							position892, tokenIndex892 := position, tokenIndex
-							if buffer[position] != rune('g') {
-								goto l893
+							position1643, tokenIndex1643 := position, tokenIndex
+							if buffer[position] != rune('y') {
+								goto l1644
 							}
 							position++
-							goto l892
-						l893:
-							position, tokenIndex = position892, tokenIndex892
-							if buffer[position] != rune('G') {
-								goto l880
+							goto l1643
+						l1644:
+							position, tokenIndex = position1643, tokenIndex1643
+							if buffer[position] != rune('Y') {
+								goto l1638
 							}
 							position++
 						}
-					l892:
-						if !_rules[rulesp]() {
-							goto l880
-						}
-						if !_rules[ruleExpression]() {
-							goto l880
-						}
-						goto l881
-					l880:
-						position, tokenIndex = position880, tokenIndex880
-					}
-				l881:
-					add(rulePegText, position879)
-				}
-				if !_rules[ruleAction38]() {
-					goto l877
-				}
-				add(ruleHaving, position878)
-			}
-			return true
-		l877:
-			position, tokenIndex = position877, tokenIndex877
-			return false
-		},
-		/* 52 RelationLike <- <(AliasedStreamWindow / (StreamWindow Action39))> */
-		func() bool {
-			position894, tokenIndex894 := position, tokenIndex
-			{
-				position895 := position
-				{
-					position896, tokenIndex896 := position, tokenIndex
-					if !_rules[ruleAliasedStreamWindow]() {
-						goto l897
-					}
-					goto l896
-				l897:
-					position, tokenIndex = position896, tokenIndex896
-					if !_rules[ruleStreamWindow]() {
-						goto l894
-					}
-					if !_rules[ruleAction39]() {
-						goto l894
-					}
-				}
-			l896:
-				add(ruleRelationLike, position895)
-			}
-			return true
-		l894:
-			position, tokenIndex = position894, tokenIndex894
-			return false
-		},
-		/* 53 AliasedStreamWindow <- <(StreamWindow sp (('a' / 'A') ('s' / 'S')) sp Identifier Action40)> */
-		func() bool {
-			position898, tokenIndex898 := position, tokenIndex
-			{
-				position899 := position
-				if !_rules[ruleStreamWindow]() {
-					goto l898
-				}
-				if !_rules[rulesp]() {
-					goto l898
-				}
-				{
-					position900, tokenIndex900 := position, tokenIndex
-					if buffer[position] != rune('a') {
-						goto l901
-					}
-					position++
-					goto l900
-				l901:
-					position, tokenIndex = position900, tokenIndex900
-					if buffer[position] != rune('A') {
-						goto l898
-					}
-					position++
-				}
-			l900:
-				{
-					position902, tokenIndex902 := position, tokenIndex
-					if buffer[position] != rune('s') {
-						goto l903
-					}
-					position++
-					goto l902
-				l903:
-					position, tokenIndex = position902, tokenIndex902
-					if buffer[position] != rune('S') {
-						goto l898
-					}
-					position++
-				}
-			l902:
-				if !_rules[rulesp]() {
-					goto l898
-				}
-				if !_rules[ruleIdentifier]() {
-					goto l898
-				}
-				if !_rules[ruleAction40]() {
-					goto l898
-				}
-				add(ruleAliasedStreamWindow, position899)
-			}
-			return true
-		l898:
-			position, tokenIndex = position898, tokenIndex898
-			return false
-		},
-		/* 54 StreamWindow <- <(StreamLike spOpt '[' spOpt (('r' / 'R') ('a' / 'A') ('n' / 'N') ('g' / 'G') ('e' / 'E')) sp Interval CapacitySpecOpt SheddingSpecOpt spOpt ']' Action41)> */
-		func() bool {
-			position904, tokenIndex904 := position, tokenIndex
-			{
-				position905 := position
-				if !_rules[ruleStreamLike]() {
-					goto l904
-				}
-				if !_rules[rulespOpt]() {
-					goto l904
-				}
-				if buffer[position] != rune('[') {
-					goto l904
-				}
-				position++
-				if !_rules[rulespOpt]() {
-					goto l904
-				}
-				{
-					position906, tokenIndex906 := position, tokenIndex
-					if buffer[position] != rune('r') {
-						goto l907
-					}
-					position++
-					goto l906
-				l907:
-					position, tokenIndex = position906, tokenIndex906
-					if buffer[position] != rune('R') {
-						goto l904
-					}
-					position++
-				}
-			l906:
-				{
-					position908, tokenIndex908 := position, tokenIndex
-					if buffer[position] != rune('a') {
-						goto l909
-					}
-					position++
-					goto l908
-				l909:
-					position, tokenIndex = position908, tokenIndex908
-					if buffer[position] != rune('A') {
-						goto l904
-					}
-					position++
-				}
-			l908:
-				{
-					position910, tokenIndex910 := position, tokenIndex
-					if buffer[position] != rune('n') {
-						goto l911
-					}
-					position++
-					goto l910
-				l911:
-					position, tokenIndex = position910, tokenIndex910
-					if buffer[position] != rune('N') {
-						goto l904
-					}
-					position++
-				}
-			l910:
-				{
-					position912, tokenIndex912 := position, tokenIndex
-					if buffer[position] != rune('g') {
-						goto l913
-					}
-					position++
-					goto l912
-				l913:
-					position, tokenIndex = position912, tokenIndex912
-					if buffer[position] != rune('G') {
-						goto l904
-					}
-					position++
-				}
-			l912:
-				{
-					position914, tokenIndex914 := position, tokenIndex
-					if buffer[position] != rune('e') {
-						goto l915
-					}
-					position++
-					goto l914
-				l915:
-					position, tokenIndex = position914, tokenIndex914
-					if buffer[position] != rune('E') {
-						goto l904
+					l1643:
+						{
+							position1645, tokenIndex1645 := position, tokenIndex
+							if buffer[position] != rune('s') {
+								goto l1646
+							}
+							position++
+							goto l1645
+						l1646:
+							position, tokenIndex = position1645, tokenIndex1645
+							if buffer[position] != rune('S') {
+								goto l1638
+							}
+							position++
+						}
+					l1645:
+						goto l1513
+					l1638:
+						position, tokenIndex = position1513, tokenIndex1513
+						{
+							position1647, tokenIndex1647 := position, tokenIndex
+							if buffer[position] != rune('d') {
+								goto l1648
+							}
+							position++
+							goto l1647
+						l1648:
+							position, tokenIndex = position1647, tokenIndex1647
+							if buffer[position] != rune('D') {
+								goto l1510
+							}
+							position++
+						}
+					l1647:
+						{
+							position1649, tokenIndex1649 := position, tokenIndex
+							if buffer[position] != rune('a') {
+								goto l1650
+							}
+							position++
+							goto l1649
+						l1650:
+							position, tokenIndex = position1649, tokenIndex1649
+							if buffer[position] != rune('A') {
+								goto l1510
+							}
+							position++
+						}
+					l1649:
+						{
+							position1651, tokenIndex1651 := position, tokenIndex
+							if buffer[position] != rune('y') {
+								goto l1652
+							}
+							position++
+							goto l1651
+						l1652:
+							position, tokenIndex = position1651, tokenIndex1651
+							if buffer[position] != rune('Y') {
+								goto l1510
+							}
+							position++
+						}
+					l1651:
 					}
-					position++
-				}
-			l914:
-				if !_rules[rulesp]() {
-					goto l904
-				}
-				if !_rules[ruleInterval]() {
-					goto l904
-				}
-				if !_rules[ruleCapacitySpecOpt]() {
-					goto l904
-				}
-				if !_rules[ruleSheddingSpecOpt]() {
-					goto l904
-				}
-				if !_rules[rulespOpt]() {
-					goto l904
-				}
-				if buffer[position] != rune(']') {
-					goto l904
-				}
-				position++
-				if !_rules[ruleAction41]() {
-					goto l904
+				l1513:
+					add(rulePegText, position1512)
 				}
-				add(ruleStreamWindow, position905)
-			}
-			return true
-		l904:
-			position, tokenIndex = position904, tokenIndex904
-			return false
-		},
-		/* 55 StreamLike <- <(UDSFFuncApp / Stream)> */
-		func() bool {
-			position916, tokenIndex916 := position, tokenIndex
-			{
-				position917 := position
-				{
-					position918, tokenIndex918 := position, tokenIndex
-					if !_rules[ruleUDSFFuncApp]() {
-						goto l919
-					}
-					goto l918
-				l919:
-					position, tokenIndex = position918, tokenIndex918
-					if !_rules[ruleStream]() {
-						goto l916
-					}
+				if !_rules[ruleAction68]() {
+					goto l1510
 				}
-			l918:
-				add(ruleStreamLike, position917)
+				add(ruleIntervalLiteralUnit, position1511)
 			}
 			return true
-		l916:
-			position, tokenIndex = position916, tokenIndex916
+		l1510:
+			position, tokenIndex = position1510, tokenIndex1510
 			return false
 		},
-		/* 56 UDSFFuncApp <- <(FuncAppWithoutOrderBy Action42)> */
+		/* 87 UDSFFuncApp <- <(FuncAppWithoutOrderBy Action69)> */
 		func() bool {
-			position920, tokenIndex920 := position, tokenIndex
+			position1653, tokenIndex1653 := position, tokenIndex
 			{
-				position921 := position
+				position1654 := position
 				if !_rules[ruleFuncAppWithoutOrderBy]() {
-					goto l920
+					goto l1653
 				}
-				if !_rules[ruleAction42]() {
-					goto l920
+				if !_rules[ruleAction69]() {
+					goto l1653
 				}
-				add(ruleUDSFFuncApp, position921)
+				add(ruleUDSFFuncApp, position1654)
 			}
 			return true
-		l920:
-			position, tokenIndex = position920, tokenIndex920
+		l1653:
+			position, tokenIndex = position1653, tokenIndex1653
 			return false
 		},
-		/* 57 CapacitySpecOpt <- <(<(spOpt ',' spOpt (('b' / 'B') ('u' / 'U') ('f' / 'F') ('f' / 'F') ('e' / 'E') ('r' / 'R')) sp (('s' / 'S') ('i' / 'I') ('z' / 'Z') ('e' / 'E')) sp NonNegativeNumericLiteral)?> Action43)> */
+		/* 88 CapacitySpecOpt <- <(<(spOpt ',' spOpt (('b' / 'B') ('u' / 'U') ('f' / 'F') ('f' / 'F') ('e' / 'E') ('r' / 'R')) sp (('s' / 'S') ('i' / 'I') ('z' / 'Z') ('e' / 'E')) sp NonNegativeNumericLiteral)?> Action70)> */
 		func() bool {
-			position922, tokenIndex922 := position, tokenIndex
+			position1655, tokenIndex1655 := position, tokenIndex
 			{
-				position923 := position
+				position1656 := position
 				{
-					position924 := position
+					position1657 := position
 					{
-						position925, tokenIndex925 := position, tokenIndex
+						position1658, tokenIndex1658 := position, tokenIndex
 						if !_rules[rulespOpt]() {
-							goto l925
+							goto l1658
 						}
 						if buffer[position] != rune(',') {
-							goto l925
+							goto l1658
 						}
 						position++
 						if !_rules[rulespOpt]() {
-							goto l925
+							goto l1658
 						}
 						{
-							position927, tokenIndex927 := position, tokenIndex
+							position1660, tokenIndex1660 := position, tokenIndex
 							if buffer[position] != rune('b') {
-								goto l928
+								goto l1661
 							}
 							position++
-							goto l927
-						l928:
-							position, tokenIndex = position927, tokenIndex927
+							goto l1660
+						l1661:
+							position, tokenIndex = position1660, tokenIndex1660
 							if buffer[position] != rune('B') {
-								goto l925
+								goto l1658
 							}
 							position++
 						}
-					l927:
+					l1660:
 						{
-							position929, tokenIndex929 := position, tokenIndex
+							position1662, tokenIndex1662 := position, tokenIndex
 							if buffer[position] != rune('u') {
-								goto l930
+								goto l1663
 							}
 							position++
-							goto l929
-						l930:
-							position, tokenIndex = position929, tokenIndex929
+							goto l1662
+						l1663:
+							position, tokenIndex = position1662, tokenIndex1662
 							if buffer[position] != rune('U') {
-								goto l925
+								goto l1658
 							}
 							position++
 						}
-					l929:
+					l1662:
 						{
-							position931, tokenIndex931 := position, tokenIndex
+							position1664, tokenIndex1664 := position, tokenIndex
 							if buffer[position] != rune('f') {
-								goto l932
+								goto l1665
 							}
 							position++
-							goto l931
-						l932:
-							position, tokenIndex = position931, tokenIndex931
+							goto l1664
+						l1665:
+							position, tokenIndex = position1664, tokenIndex1664
 							if buffer[position] != rune('F') {
-								goto l925
+								goto l1658
 							}
 							position++
 						}
-					l931:
+					l1664:
 						{
-							position933, tokenIndex933 := position, tokenIndex
+							position1666, tokenIndex1666 := position, tokenIndex
 							if buffer[position] != rune('f') {
-								goto l934
+								goto l1667
 							}
 							position++
-							goto l933
-						l934:
-							position, tokenIndex = position933, tokenIndex933
+							goto l1666
+						l1667:
+							position, tokenIndex = position1666, tokenIndex1666
 							if buffer[position] != rune('F') {
-								goto l925
+								goto l1658
 							}
 							position++
 						}
-					l933:
+					l1666:
 						{
-							position935, tokenIndex935 := position, tokenIndex
+							position1668, tokenIndex1668 := position, tokenIndex
 							if buffer[position] != rune('e') {
-								goto l936
+								goto l1669
 							}
 							position++
-							goto l935
-						l936:
-							position, tokenIndex = position935, tokenIndex935
+							goto l1668
+						l1669:
+							position, tokenIndex = position1668, tokenIndex1668
 							if buffer[position] != rune('E') {
-								goto l925
+								goto l1658
 							}
 							position++
 						}
-					l935:
+					l1668:
 						{
-							position937, tokenIndex937 := position, tokenIndex
+							position1670, tokenIndex1670 := position, tokenIndex
 							if buffer[position] != rune('r') {
-								goto l938
+								goto l1671
 							}
 							position++
-							goto l937
-						l938:
-							position, tokenIndex = position937, tokenIndex937
+							goto l1670
+						l1671:
+							position, tokenIndex = position1670, tokenIndex1670
 							if buffer[position] != rune('R') {
-								goto l925
+								goto l1658
 							}
 							position++
 						}
-					l937:
+					l1670:
 						if !_rules[rulesp]() {
-							goto l925
+							goto l1658
 						}
 						{
-							position939, tokenIndex939 := position, tokenIndex
+							position1672, tokenIndex1672 := position, tokenIndex
 							if buffer[position] != rune('s') {
-								goto l940
+								goto l1673
 							}
 							position++
-							goto l939
-						l940:
-							position, tokenIndex = position939, tokenIndex939
+							goto l1672
+						l1673:
+							position, tokenIndex = position1672, tokenIndex1672
 							if buffer[position] != rune('S') {
-								goto l925
+								goto l1658
 							}
 							position++
 						}
-					l939:
+					l1672:
 						{
-							position941, tokenIndex941 := position, tokenIndex
+							position1674, tokenIndex1674 := position, tokenIndex
 							if buffer[position] != rune('i') {
-								goto l942
+								goto l1675
 							}
 							position++
-							goto l941
-						l942:
-							position, tokenIndex = position941, tokenIndex941
+							goto l1674
+						l1675:
+							position, tokenIndex = position1674, tokenIndex1674
 							if buffer[position] != rune('I') {
-								goto l925
+								goto l1658
 							}
 							position++
 						}
-					l941:
+					l1674:
 						{
-							position943, tokenIndex943 := position, tokenIndex
+							position1676, tokenIndex1676 := position, tokenIndex
 							if buffer[position] != rune('z') {
-								goto l944
+								goto l1677
 							}
 							position++
-							goto l943
-						l944:
-							position, tokenIndex = position943, tokenIndex943
+							goto l1676
+						l1677:
+							position, tokenIndex = position1676, tokenIndex1676
 							if buffer[position] != rune('Z') {
-								goto l925
+								goto l1658
 							}
 							position++
 						}
-					l943:
+					l1676:
 						{
-							position945, tokenIndex945 := position, tokenIndex
+							position1678, tokenIndex1678 := position, tokenIndex
 							if buffer[position] != rune('e') {
-								goto l946
+								goto l1679
 							}
 							position++
-							goto l945
-						l946:
-							position, tokenIndex = position945, tokenIndex945
+							goto l1678
+						l1679:
+							position, tokenIndex = position1678, tokenIndex1678
 							if buffer[position] != rune('E') {
-								goto l925
+								goto l1658
 							}
 							position++
 						}
-					l945:
+					l1678:
 						if !_rules[rulesp]() {
-							goto l925
+							goto l1658
 						}
 						if !_rules[ruleNonNegativeNumericLiteral]() {
-							goto l925
+							goto l1658
 						}
-						goto l926
-					l925:
-						position, tokenIndex = position925, tokenIndex925
+						goto l1659
+					l1658:
+						position, tokenIndex = position1658, tokenIndex1658
 					}
-				l926:
-					add(rulePegText, position924)
+				l1659:
+					add(rulePegText, position1657)
 				}
-				if !_rules[ruleAction43]() {
-					goto l922
+				if !_rules[ruleAction70]() {
+					goto l1655
 				}
-				add(ruleCapacitySpecOpt, position923)
+				add(ruleCapacitySpecOpt, position1656)
 			}
 			return true
-		l922:
-			position, tokenIndex = position922, tokenIndex922
+		l1655:
+			position, tokenIndex = position1655, tokenIndex1655
 			return false
 		},
-		/* 58 SheddingSpecOpt <- <(<(spOpt ',' spOpt SheddingOption sp (('i' / 'I') ('f' / 'F')) sp (('f' / 'F') ('u' / 'U') ('l' / 'L') ('l' / 'L')))?> Action44)> */
+		/* 89 SheddingSpecOpt <- <(<(spOpt ',' spOpt SheddingOption sp (('i' / 'I') ('f' / 'F')) sp (('f' / 'F') ('u' / 'U') ('l' / 'L') ('l' / 'L')))?> Action71)> */
 		func() bool {
-			position947, tokenIndex947 := position, tokenIndex
+			position1680, tokenIndex1680 := position, tokenIndex
 			{
-				position948 := position
+				position1681 := position
 				{
-					position949 := position
+					position1682 := position
 					{
-						position950, tokenIndex950 := position, tokenIndex
+						position1683, tokenIndex1683 := position, tokenIndex
 						if !_rules[rulespOpt]() {
-							goto l950
+							goto l1683
 						}
 						if buffer[position] != rune(',') {
-							goto l950
+							goto l1683
 						}
 						position++
 						if !_rules[rulespOpt]() {
-							goto l950
+							goto l1683
 						}
 						if !_rules[ruleSheddingOption]() {
-							goto l950
+							goto l1683
 						}
 						if !_rules[rulesp]() {
-							goto l950
+							goto l1683
 						}
 						{
-							position952, tokenIndex952 := position, tokenIndex
+							position1685, tokenIndex1685 := position, tokenIndex
 							if buffer[position] != rune('i') {
-								goto l953
+								goto l1686
 							}
 							position++
-							goto l952
-						l953:
-							position, tokenIndex = position952, tokenIndex952
+							goto l1685
+						l1686:
+							position, tokenIndex = position1685, tokenIndex1685
 							if buffer[position] != rune('I') {
-								goto l950
+								goto l1683
 							}
 							position++
 						}
-					l952:
+					l1685:
 						{
-							position954, tokenIndex954 := position, tokenIndex
+							position1687, tokenIndex1687 := position, tokenIndex
 							if buffer[position] != rune('f') {
-								goto l955
+								goto l1688
 							}
 							position++
-							goto l954
-						l955:
-							position, tokenIndex = position954, tokenIndex954
+							goto l1687
+						l1688:
+							position, tokenIndex = position1687, tokenIndex1687
 							if buffer[position] != rune('F') {
-								goto l950
+								goto l1683
 							}
 							position++
 						}
-					l954:
+					l1687:
 						if !_rules[rulesp]() {
-							goto l950
+							goto l1683
 						}
 						{
-							position956, tokenIndex956 := position, tokenIndex
+							position1689, tokenIndex1689 := position, tokenIndex
 							if buffer[position] != rune('f') {
-								goto l957
+								goto l1690
 							}
 							position++
-							goto l956
-						l957:
-							position, tokenIndex = position956, tokenIndex956
+							goto l1689
+						l1690:
+							position, tokenIndex = position1689, tokenIndex1689
 							if buffer[position] != rune('F') {
-								goto l950
+								goto l1683
 							}
 							position++
 						}
-					l956:
+					l1689:
 						{
-							position958, tokenIndex958 := position, tokenIndex
+							position1691, tokenIndex1691 := position, tokenIndex
 							if buffer[position] != rune('u') {
-								goto l959
+								goto l1692
 							}
 							position++
-							goto l958
-						l959:
-							position, tokenIndex = position958, tokenIndex958
+							goto l1691
+						l1692:
+							position, tokenIndex = position1691, tokenIndex1691
 							if buffer[position] != rune('U') {
-								goto l950
+								goto l1683
 							}
 							position++
 						}
-					l958:
+					l1691:
 						{
-							position960, tokenIndex960 := position, tokenIndex
+							position1693, tokenIndex1693 := position, tokenIndex
 							if buffer[position] != rune('l') {
-								goto l961
+								goto l1694
 							}
 							position++
-							goto l960
-						l961:
-							position, tokenIndex = position960, tokenIndex960
+							goto l1693
+						l1694:
+							position, tokenIndex = position1693, tokenIndex1693
 							if buffer[position] != rune('L') {
-								goto l950
+								goto l1683
 							}
 							position++
 						}
-					l960:
+					l1693:
 						{
-							position962, tokenIndex962 := position, tokenIndex
+							position1695, tokenIndex1695 := position, tokenIndex
 							if buffer[position] != rune('l') {
-								goto l963
+								goto l1696
 							}
 							position++
-							goto l962
-						l963:
-							position, tokenIndex = position962, tokenIndex962
+							goto l1695
+						l1696:
+							position, tokenIndex = position1695, tokenIndex1695
 							if buffer[position] != rune('L') {
-								goto l950
+								goto l1683
 							}
 							position++
 						}
-					l962:
-						goto l951
-					l950:
-						position, tokenIndex = position950, tokenIndex950
+					l1695:
+						goto l1684
+					l1683:
+						position, tokenIndex = position1683, tokenIndex1683
 					}
-				l951:
-					add(rulePegText, position949)
+				l1684:
+					add(rulePegText, position1682)
 				}
-				if !_rules[ruleAction44]() {
-					goto l947
+				if !_rules[ruleAction71]() {
+					goto l1680
 				}
-				add(ruleSheddingSpecOpt, position948)
+				add(ruleSheddingSpecOpt, position1681)
 			}
 			return true
-		l947:
-			position, tokenIndex = position947, tokenIndex947
+		l1680:
+			position, tokenIndex = position1680, tokenIndex1680
 			return false
 		},
-		/* 59 SheddingOption <- <(Wait / DropOldest / DropNewest)> */
+		/* 90 SheddingOption <- <(Wait / DropOldest / DropNewest)> */
 		func() bool {
-			position964, tokenIndex964 := position, tokenIndex
+			position1697, tokenIndex1697 := position, tokenIndex
 			{
-				position965 := position
+				position1698 := position
 				{
-					position966, tokenIndex966 := position, tokenIndex
+					position1699, tokenIndex1699 := position, tokenIndex
 					if !_rules[ruleWait]() {
-						goto l967
+						goto l1700
 					}
-					goto l966
-				l967:
-					position, tokenIndex = position966, tokenIndex966
+					goto l1699
+				l1700:
+					position, tokenIndex = position1699, tokenIndex1699
 					if !_rules[ruleDropOldest]() {
-						goto l968
+						goto l1701
 					}
-					goto l966
-				l968:
-					position, tokenIndex = position966, tokenIndex966
+					goto l1699
+				l1701:
+					position, tokenIndex = position1699, tokenIndex1699
 					if !_rules[ruleDropNewest]() {
-						goto l964
+						goto l1697
 					}
 				}
-			l966:
-				add(ruleSheddingOption, position965)
+			l1699:
+				add(ruleSheddingOption, position1698)
 			}
 			return true
-		l964:
-			position, tokenIndex = position964, tokenIndex964
+		l1697:
+			position, tokenIndex = position1697, tokenIndex1697
 			return false
 		},
-		/* 60 SourceSinkSpecs <- <(<(sp (('w' / 'W') ('i' / 'I') ('t' / 'T') ('h' / 'H')) sp SourceSinkParam (spOpt ',' spOpt SourceSinkParam)*)?> Action45)> */
+		/* 91 SourceSinkSpecs <- <(<(sp (('w' / 'W') ('i' / 'I') ('t' / 'T') ('h' / 'H')) sp SourceSinkParam (spOpt ',' spOpt SourceSinkParam)*)?> Action72)> */
 		func() bool {
-			position969, tokenIndex969 := position, tokenIndex
+			position1702, tokenIndex1702 := position, tokenIndex
 			{
-				position970 := position
+				position1703 := position
 				{
-					position971 := position
+					position1704 := position
 					{
-						position972, tokenIndex972 := position, tokenIndex
+						position1705, tokenIndex1705 := position, tokenIndex
 						if !_rules[rulesp]() {
-							goto l972
+							goto l1705
 						}
 						{
-							position974, tokenIndex974 := position, tokenIndex
+							position1707, tokenIndex1707 := position, tokenIndex
 							if buffer[position] != rune('w') {
-								goto l975
+								goto l1708
 							}
 							position++
-							goto l974
-						l975:
-							position, tokenIndex = position974, tokenIndex974
+							goto l1707
+						l1708:
+							position, tokenIndex = position1707, tokenIndex1707
 							if buffer[position] != rune('W') {
-								goto l972
+								goto l1705
 							}
 							position++
 						}
-					l974:
+					l1707:
 						{
-							position976, tokenIndex976 := position, tokenIndex
+							position1709, tokenIndex1709 := position, tokenIndex
 							if buffer[position] != rune('i') {
-								goto l977
+								goto l1710
 							}
 							position++
-							goto l976
-						l977:
-							position, tokenIndex = position976, tokenIndex976
+							goto l1709
+						l1710:
+							position, tokenIndex = position1709, tokenIndex1709
 							if buffer[position] != rune('I') {
-								goto l972
+								goto l1705
 							}
 							position++
 						}
-					l976:
+					l1709:
 						{
-							position978, tokenIndex978 := position, tokenIndex
+							position1711, tokenIndex1711 := position, tokenIndex
 							if buffer[position] != rune('t') {
-								goto l979
+								goto l1712
 							}
 							position++
-							goto l978
-						l979:
-							position, tokenIndex = position978, tokenIndex978
+							goto l1711
+						l1712:
+							position, tokenIndex = position1711, tokenIndex1711
 							if buffer[position] != rune('T') {
-								goto l972
+								goto l1705
 							}
 							position++
 						}
-					l978:
+					l1711:
 						{
-							position980, tokenIndex980 := position, tokenIndex
+							position1713, tokenIndex1713 := position, tokenIndex
 							if buffer[position] != rune('h') {
-								goto l981
+								goto l1714
 							}
 							position++
-							goto l980
-						l981:
-							position, tokenIndex = position980, tokenIndex980
+							goto l1713
+						l1714:
+							position, tokenIndex = position1713, tokenIndex1713
 							if buffer[position] != rune('H') {
-								goto l972
+								goto l1705
 							}
 							position++
 						}
-					l980:
+					l1713:
 						if !_rules[rulesp]() {
-							goto l972
+							goto l1705
 						}
 						if !_rules[ruleSourceSinkParam]() {
-							goto l972
+							goto l1705
 						}
-					l982:
+					l1715:
 						{
-							position983, tokenIndex983 := position, tokenIndex
+							position1716, tokenIndex1716 := position, tokenIndex
 							if !_rules[rulespOpt]() {
-								goto l983
+								goto l1716
 							}
 							if buffer[position] != rune(',') {
-								goto l983
+								goto l1716
 							}
 							position++
 							if !_rules[rulespOpt]() {
-								goto l983
+								goto l1716
 							}
 							if !_rules[ruleSourceSinkParam]() {
-								goto l983
+								goto l1716
 							}
-							goto l982
-						l983:
-							position, tokenIndex = position983, tokenIndex983
+							goto l1715
+						l1716:
+							position, tokenIndex = position1716, tokenIndex1716
 						}
-						goto l973
-					l972:
-						position, tokenIndex = position972, tokenIndex972
+						goto l1706
+					l1705:
+						position, tokenIndex = position1705, tokenIndex1705
 					}
-				l973:
-					add(rulePegText, position971)
+				l1706:
+					add(rulePegText, position1704)
 				}
-				if !_rules[ruleAction45]() {
-					goto l969
+				if !_rules[ruleAction72]() {
+					goto l1702
 				}
-				add(ruleSourceSinkSpecs, position970)
+				add(ruleSourceSinkSpecs, position1703)
 			}
 			return true
-		l969:
-			position, tokenIndex = position969, tokenIndex969
+		l1702:
+			position, tokenIndex = position1702, tokenIndex1702
 			return false
 		},
-		/* 61 UpdateSourceSinkSpecs <- <(<(sp (('s' / 'S') ('e' / 'E') ('t' / 'T')) sp SourceSinkParam (spOpt ',' spOpt SourceSinkParam)*)> Action46)> */
+		/* 92 UpdateSourceSinkSpecs <- <(<(sp (('s' / 'S') ('e' / 'E') ('t' / 'T')) sp SourceSinkParam (spOpt ',' spOpt SourceSinkParam)*)> Action73)> */
 		func() bool {
-			position984, tokenIndex984 := position, tokenIndex
+			position1717, tokenIndex1717 := position, tokenIndex
 			{
-				position985 := position
+				position1718 := position
 				{
-					position986 := position
+					position1719 := position
 					if !_rules[rulesp]() {
-						goto l984
+						goto l1717
 					}
 					{
-						position987, tokenIndex987 := position, tokenIndex
+						position1720, tokenIndex1720 := position, tokenIndex
 						if buffer[position] != rune('s') {
-							goto l988
+							goto l1721
 						}
 						position++
-						goto l987
-					l988:
-						position, tokenIndex = position987, tokenIndex987
+						goto l1720
+					l1721:
+						position, tokenIndex = position1720, tokenIndex1720
 						if buffer[position] != rune('S') {
-							goto l984
+							goto l1717
 						}
 						position++
 					}
-				l987:
+				l1720:
 					{
-						position989, tokenIndex989 := position, tokenIndex
+						position1722, tokenIndex1722 := position, tokenIndex
 						if buffer[position] != rune('e') {
-							goto l990
+							goto l1723
 						}
 						position++
-						goto l989
-					l990:
-						position, tokenIndex = position989, tokenIndex989
+						goto l1722
+					l1723:
+						position, tokenIndex = position1722, tokenIndex1722
 						if buffer[position] != rune('E') {
-							goto l984
+							goto l1717
 						}
 						position++
 					}
-				l989:
+				l1722:
 					{
-						position991, tokenIndex991 := position, tokenIndex
+						position1724, tokenIndex1724 := position, tokenIndex
 						if buffer[position] != rune('t') {
-							goto l992
+							goto l1725
 						}
 						position++
-						goto l991
-					l992:
-						position, tokenIndex = position991, tokenIndex991
+						goto l1724
+					l1725:
+						position, tokenIndex = position1724, tokenIndex1724
 						if buffer[position] != rune('T') {
-							goto l984
+							goto l1717
 						}
 						position++
 					}
-				l991:
+				l1724:
 					if !_rules[rulesp]() {
-						goto l984
+						goto l1717
 					}
 					if !_rules[ruleSourceSinkParam]() {
-						goto l984
+						goto l1717
 					}
-				l993:
+				l1726:
 					{
-						position994, tokenIndex994 := position, tokenIndex
+						position1727, tokenIndex1727 := position, tokenIndex
 						if !_rules[rulespOpt]() {
-							goto l994
+							goto l1727
 						}
 						if buffer[position] != rune(',') {
-							goto l994
+							goto l1727
 						}
 						position++
 						if !_rules[rulespOpt]() {
-							goto l994
+							goto l1727
 						}
 						if !_rules[ruleSourceSinkParam]() {
-							goto l994
+							goto l1727
 						}
-						goto l993
-					l994:
-						position, tokenIndex = position994, tokenIndex994
+						goto l1726
+					l1727:
+						position, tokenIndex = position1727, tokenIndex1727
 					}
-					add(rulePegText, position986)
+					add(rulePegText, position1719)
 				}
-				if !_rules[ruleAction46]() {
-					goto l984
+				if !_rules[ruleAction73]() {
+					goto l1717
 				}
-				add(ruleUpdateSourceSinkSpecs, position985)
+				add(ruleUpdateSourceSinkSpecs, position1718)
 			}
 			return true
-		l984:
-			position, tokenIndex = position984, tokenIndex984
+		l1717:
+			position, tokenIndex = position1717, tokenIndex1717
 			return false
 		},
-		/* 62 SetOptSpecs <- <(<(sp (('s' / 'S') ('e' / 'E') ('t' / 'T')) sp SourceSinkParam (spOpt ',' spOpt SourceSinkParam)*)?> Action47)> */
+		/* 93 SetOptSpecs <- <(<(sp (('s' / 'S') ('e' / 'E') ('t' / 'T')) sp SourceSinkParam (spOpt ',' spOpt SourceSinkParam)*)?> Action74)> */
 		func() bool {
-			position995, tokenIndex995 := position, tokenIndex
+			position1728, tokenIndex1728 := position, tokenIndex
 			{
-				position996 := position
+				position1729 := position
 				{
-					position997 := position
+					position1730 := position
 					{
-						position998, tokenIndex998 := position, tokenIndex
+						position1731, tokenIndex1731 := position, tokenIndex
 						if !_rules[rulesp]() {
-							goto l998
+							goto l1731
 						}
 						{
-							position1000, tokenIndex1000 := position, tokenIndex
+							position1733, tokenIndex1733 := position, tokenIndex
 							if buffer[position] != rune('s') {
-								goto l1001
+								goto l1734
 							}
 							position++
-							goto l1000
-						l1001:
-							position, tokenIndex = position1000, tokenIndex1000
+							goto l1733
+						l1734:
+							position, tokenIndex = position1733, tokenIndex1733
 							if buffer[position] != rune('S') {
-								goto l998
+								goto l1731
 							}
 							position++
 						}
-					l1000:
+					l1733:
 						{
-							position1002, tokenIndex1002 := position, tokenIndex
+							position1735, tokenIndex1735 := position, tokenIndex
 							if buffer[position] != rune('e') {
-								goto l1003
+								goto l1736
 							}
 							position++
-							goto l1002
-						l1003:
-							position, tokenIndex = position1002, tokenIndex1002
+							goto l1735
+						l1736:
+							position, tokenIndex = position1735, tokenIndex1735
 							if buffer[position] != rune('E') {
-								goto l998
+								goto l1731
 							}
 							position++
 						}
-					l1002:
+					l1735:
 						{
-							position1004, tokenIndex1004 := position, tokenIndex
+							position1737, tokenIndex1737 := position, tokenIndex
 							if buffer[position] != rune('t') {
-								goto l1005
+								goto l1738
 							}
 							position++
-							goto l1004
-						l1005:
-							position, tokenIndex = position1004, tokenIndex1004
+							goto l1737
+						l1738:
+							position, tokenIndex = position1737, tokenIndex1737
 							if buffer[position] != rune('T') {
-								goto l998
+								goto l1731
 							}
 							position++
 						}
-					l1004:
+					l1737:
 						if !_rules[rulesp]() {
-							goto l998
+							goto l1731
 						}
 						if !_rules[ruleSourceSinkParam]() {
-							goto l998
+							goto l1731
 						}
-					l1006:
+					l1739:
 						{
-							position1007, tokenIndex1007 := position, tokenIndex
+							position1740, tokenIndex1740 := position, tokenIndex
 							if !_rules[rulespOpt]() {
-								goto l1007
+								goto l1740
 							}
 							if buffer[position] != rune(',') {
-								goto l1007
+								goto l1740
 							}
 							position++
 							if !_rules[rulespOpt]() {
-								goto l1007
+								goto l1740
 							}
 							if !_rules[ruleSourceSinkParam]() {
-								goto l1007
+								goto l1740
 							}
-							goto l1006
-						l1007:
-							position, tokenIndex = position1007, tokenIndex1007
+							goto l1739
+						l1740:
+							position, tokenIndex = position1740, tokenIndex1740
 						}
-						goto l999
-					l998:
-						position, tokenIndex = position998, tokenIndex998
+						goto l1732
+					l1731:
+						position, tokenIndex = position1731, tokenIndex1731
 					}
-				l999:
-					add(rulePegText, position997)
+				l1732:
+					add(rulePegText, position1730)
 				}
-				if !_rules[ruleAction47]() {
-					goto l995
+				if !_rules[ruleAction74]() {
+					goto l1728
 				}
-				add(ruleSetOptSpecs, position996)
+				add(ruleSetOptSpecs, position1729)
 			}
 			return true
-		l995:
-			position, tokenIndex = position995, tokenIndex995
+		l1728:
+			position, tokenIndex = position1728, tokenIndex1728
 			return false
 		},
-		/* 63 StateTagOpt <- <(<(sp (('t' / 'T') ('a' / 'A') ('g' / 'G')) sp Identifier)?> Action48)> */
+		/* 94 StateTagOpt <- <(<(sp (('t' / 'T') ('a' / 'A') ('g' / 'G')) sp Identifier)?> Action75)> */
 		func() bool {
-			position1008, tokenIndex1008 := position, tokenIndex
+			position1741, tokenIndex1741 := position, tokenIndex
 			{
-				position1009 := position
+				position1742 := position
 				{
-					position1010 := position
+					position1743 := position
 					{
-						position1011, tokenIndex1011 := position, tokenIndex
+						position1744, tokenIndex1744 := position, tokenIndex
 						if !_rules[rulesp]() {
-							goto l1011
+							goto l1744
 						}
 						{
-							position1013, tokenIndex1013 := position, tokenIndex
+							position1746, tokenIndex1746 := position, tokenIndex
 							if buffer[position] != rune('t') {
-								goto l1014
+								goto l1747
 							}
 							position++
-							goto l1013
-						l1014:
-							position, tokenIndex = position1013, tokenIndex1013
+							goto l1746
+						l1747:
+							position, tokenIndex = position1746, tokenIndex1746
 							if buffer[position] != rune('T') {
-								goto l1011
+								goto l1744
 							}
 							position++
 						}
-					l1013:
+					l1746:
 						{
-							position1015, tokenIndex1015 := position, tokenIndex
+							position1748, tokenIndex1748 := position, tokenIndex
 							if buffer[position] != rune('a') {
-								goto l1016
+								goto l1749
 							}
 							position++
-							goto l1015
-						l1016:
-							position, tokenIndex = position1015, tokenIndex1015
+							goto l1748
+						l1749:
+							position, tokenIndex = position1748, tokenIndex1748
 							if buffer[position] != rune('A') {
-								goto l1011
+								goto l1744
 							}
 							position++
 						}
-					l1015:
+					l1748:
 						{
-							position1017, tokenIndex1017 := position, tokenIndex
+							position1750, tokenIndex1750 := position, tokenIndex
 							if buffer[position] != rune('g') {
-								goto l1018
+								goto l1751
 							}
 							position++
-							goto l1017
-						l1018:
-							position, tokenIndex = position1017, tokenIndex1017
+							goto l1750
+						l1751:
+							position, tokenIndex = position1750, tokenIndex1750
 							if buffer[position] != rune('G') {
-								goto l1011
+								goto l1744
 							}
 							position++
 						}
-					l1017:
+					l1750:
 						if !_rules[rulesp]() {
-							goto l1011
+							goto l1744
 						}
 						if !_rules[ruleIdentifier]() {
-							goto l1011
+							goto l1744
 						}
-						goto l1012
-					l1011:
-						position, tokenIndex = position1011, tokenIndex1011
+						goto l1745
+					l1744:
+						position, tokenIndex = position1744, tokenIndex1744
 					}
-				l1012:
-					add(rulePegText, position1010)
+				l1745:
+					add(rulePegText, position1743)
 				}
-				if !_rules[ruleAction48]() {
-					goto l1008
+				if !_rules[ruleAction75]() {
+					goto l1741
 				}
-				add(ruleStateTagOpt, position1009)
+				add(ruleStateTagOpt, position1742)
 			}
 			return true
-		l1008:
-			position, tokenIndex = position1008, tokenIndex1008
+		l1741:
+			position, tokenIndex = position1741, tokenIndex1741
 			return false
 		},
-		/* 64 SourceSinkParam <- <(SourceSinkParamKey spOpt '=' spOpt SourceSinkParamVal Action49)> */
+		/* 95 SourceSinkParam <- <(SourceSinkParamKey spOpt '=' spOpt SourceSinkParamVal Action76)> */
 		func() bool {
-			position1019, tokenIndex1019 := position, tokenIndex
+			position1752, tokenIndex1752 := position, tokenIndex
 			{
-				position1020 := position
+				position1753 := position
 				if !_rules[ruleSourceSinkParamKey]() {
-					goto l1019
+					goto l1752
 				}
 				if !_rules[rulespOpt]() {
-					goto l1019
+					goto l1752
 				}
 				if buffer[position] != rune('=') {
-					goto l1019
+					goto l1752
 				}
 				position++
 				if !_rules[rulespOpt]() {
-					goto l1019
+					goto l1752
 				}
 				if !_rules[ruleSourceSinkParamVal]() {
-					goto l1019
+					goto l1752
 				}
-				if !_rules[ruleAction49]() {
-					goto l1019
+				if !_rules[ruleAction76]() {
+					goto l1752
 				}
-				add(ruleSourceSinkParam, position1020)
+				add(ruleSourceSinkParam, position1753)
 			}
 			return true
-		l1019:
-			position, tokenIndex = position1019, tokenIndex1019
+		l1752:
+			position, tokenIndex = position1752, tokenIndex1752
 			return false
 		},
-		/* 65 SourceSinkParamVal <- <ParamLiteral> */
+		/* 96 SourceSinkParamVal <- <(ParamLiteral / ConstantRef)> */
 		func() bool {
-			position1021, tokenIndex1021 := position, tokenIndex
+			position1754, tokenIndex1754 := position, tokenIndex
 			{
-				position1022 := position
-				if !_rules[ruleParamLiteral]() {
-					goto l1021
+				position1755 := position
+				{
+					position1756, tokenIndex1756 := position, tokenIndex
+					if !_rules[ruleParamLiteral]() {
+						goto l1757
+					}
+					goto l1756
+				l1757:
+					position, tokenIndex = position1756, tokenIndex1756
+					if !_rules[ruleConstantRef]() {
+						goto l1754
+					}
 				}
-				add(ruleSourceSinkParamVal, position1022)
+			l1756:
+				add(ruleSourceSinkParamVal, position1755)
 			}
 			return true
-		l1021:
-			position, tokenIndex = position1021, tokenIndex1021
+		l1754:
+			position, tokenIndex = position1754, tokenIndex1754
 			return false
 		},
-		/* 66 ParamLiteral <- <(BooleanLiteral / Literal / ParamArrayExpr / ParamMapExpr)> */
+		/* 97 ParamLiteral <- <(BooleanLiteral / Literal / ParamArrayExpr / ParamMapExpr)> */
 		func() bool {
-			position1023, tokenIndex1023 := position, tokenIndex
+			position1758, tokenIndex1758 := position, tokenIndex
 			{
-				position1024 := position
+				position1759 := position
 				{
-					position1025, tokenIndex1025 := position, tokenIndex
+					position1760, tokenIndex1760 := position, tokenIndex
 					if !_rules[ruleBooleanLiteral]() {
-						goto l1026
+						goto l1761
 					}
-					goto l1025
-				l1026:
-					position, tokenIndex = position1025, tokenIndex1025
+					goto l1760
+				l1761:
+					position, tokenIndex = position1760, tokenIndex1760
 					if !_rules[ruleLiteral]() {
-						goto l1027
+						goto l1762
 					}
-					goto l1025
-				l1027:
-					position, tokenIndex = position1025, tokenIndex1025
+					goto l1760
+				l1762:
+					position, tokenIndex = position1760, tokenIndex1760
 					if !_rules[ruleParamArrayExpr]() {
-						goto l1028
+						goto l1763
 					}
-					goto l1025
-				l1028:
-					position, tokenIndex = position1025, tokenIndex1025
+					goto l1760
+				l1763:
+					position, tokenIndex = position1760, tokenIndex1760
 					if !_rules[ruleParamMapExpr]() {
-						goto l1023
+						goto l1758
 					}
 				}
-			l1025:
-				add(ruleParamLiteral, position1024)
+			l1760:
+				add(ruleParamLiteral, position1759)
 			}
 			return true
-		l1023:
-			position, tokenIndex = position1023, tokenIndex1023
+		l1758:
+			position, tokenIndex = position1758, tokenIndex1758
 			return false
 		},
-		/* 67 ParamArrayExpr <- <(<('[' spOpt (ParamLiteral (',' spOpt ParamLiteral)*)? spOpt ','? spOpt ']')> Action50)> */
+		/* 98 ParamArrayExpr <- <(<('[' spOpt (ParamLiteral (',' spOpt ParamLiteral)*)? spOpt ','? spOpt ']')> Action77)> */
 		func() bool {
-			position1029, tokenIndex1029 := position, tokenIndex
+			position1764, tokenIndex1764 := position, tokenIndex
 			{
-				position1030 := position
+				position1765 := position
 				{
-					position1031 := position
+					position1766 := position
 					if buffer[position] != rune('[') {
-						goto l1029
+						goto l1764
 					}
 					position++
 					if !_rules[rulespOpt]() {
-						goto l1029
+						goto l1764
 					}
 					{
-						position1032, tokenIndex1032 := position, tokenIndex
+						position1767, tokenIndex1767 := position, tokenIndex
 						if !_rules[ruleParamLiteral]() {
-							goto l1032
+							goto l1767
 						}
-					l1034:
+					l1769:
 						{
-							position1035, tokenIndex1035 := position, tokenIndex
+							position1770, tokenIndex1770 := position, tokenIndex
 							if buffer[position] != rune(',') {
-								goto l1035
+								goto l1770
 							}
 							position++
 							if !_rules[rulespOpt]() {
-								goto l1035
+								goto l1770
 							}
 							if !_rules[ruleParamLiteral]() {
-								goto l1035
+								goto l1770
 							}
-							goto l1034
-						l1035:
-							position, tokenIndex = position1035, tokenIndex1035
+							goto l1769
+						l1770:
+							position, tokenIndex = position1770, tokenIndex1770
 						}
-						goto l1033
-					l1032:
-						position, tokenIndex = position1032, tokenIndex1032
+						goto l1768
+					l1767:
+						position, tokenIndex = position1767, tokenIndex1767
 					}
-				l1033:
+				l1768:
 					if !_rules[rulespOpt]() {
-						goto l1029
+						goto l1764
 					}
 					{
-						position1036, tokenIndex1036 := position, tokenIndex
+						position1771, tokenIndex1771 := position, tokenIndex
 						if buffer[position] != rune(',') {
-							goto l1036
+							goto l1771
 						}
 						position++
-						goto l1037
-					l1036:
-						position, tokenIndex = position1036, tokenIndex1036
+						goto l1772
+					l1771:
+						position, tokenIndex = position1771, tokenIndex1771
 					}
-				l1037:
+				l1772:
 					if !_rules[rulespOpt]() {
-						goto l1029
+						goto l1764
 					}
 					if buffer[position] != rune(']') {
-						goto l1029
+						goto l1764
 					}
 					position++
-					add(rulePegText, position1031)
+					add(rulePegText, position1766)
 				}
-				if !_rules[ruleAction50]() {
-					goto l1029
+				if !_rules[ruleAction77]() {
+					goto l1764
 				}
-				add(ruleParamArrayExpr, position1030)
+				add(ruleParamArrayExpr, position1765)
 			}
 			return true
-		l1029:
-			position, tokenIndex = position1029, tokenIndex1029
+		l1764:
+			position, tokenIndex = position1764, tokenIndex1764
 			return false
 		},
-		/* 68 ParamMapExpr <- <(<('{' spOpt (ParamKeyValuePair (spOpt ',' spOpt ParamKeyValuePair)*)? spOpt '}')> Action51)> */
+		/* 99 ParamMapExpr <- <(<('{' spOpt (ParamKeyValuePair (spOpt ',' spOpt ParamKeyValuePair)*)? spOpt '}')> Action78)> */
 		func() bool {
-			position1038, tokenIndex1038 := position, tokenIndex
+			position1773, tokenIndex1773 := position, tokenIndex
 			{
-				position1039 := position
+				position1774 := position
 				{
-					position1040 := position
+					position1775 := position
 					if buffer[position] != rune('{') {
-						goto l1038
+						goto l1773
 					}
 					position++
 					if !_rules[rulespOpt]() {
-						goto l1038
+						goto l1773
 					}
 					{
-						position1041, tokenIndex1041 := position, tokenIndex
+						position1776, tokenIndex1776 := position, tokenIndex
 						if !_rules[ruleParamKeyValuePair]() {
-							goto l1041
+							goto l1776
 						}
-					l1043:
+					l1778:
 						{
-							position1044, tokenIndex1044 := position, tokenIndex
+							position1779, tokenIndex1779 := position, tokenIndex
 							if !_rules[rulespOpt]() {
-								goto l1044
+								goto l1779
 							}
 							if buffer[position] != rune(',') {
-								goto l1044
+								goto l1779
 							}
 							position++
 							if !_rules[rulespOpt]() {
-								goto l1044
+								goto l1779
 							}
 							if !_rules[ruleParamKeyValuePair]() {
-								goto l1044
+								goto l1779
 							}
-							goto l1043
-						l1044:
-							position, tokenIndex = position1044, tokenIndex1044
+							goto l1778
+						l1779:
+							position, tokenIndex = position1779, tokenIndex1779
 						}
-						goto l1042
-					l1041:
-						position, tokenIndex = position1041, tokenIndex1041
+						goto l1777
+					l1776:
+						position, tokenIndex = position1776, tokenIndex1776
 					}
-				l1042:
+				l1777:
 					if !_rules[rulespOpt]() {
-						goto l1038
+						goto l1773
 					}
 					if buffer[position] != rune('}') {
-						goto l1038
+						goto l1773
 					}
 					position++
-					add(rulePegText, position1040)
+					add(rulePegText, position1775)
 				}
-				if !_rules[ruleAction51]() {
-					goto l1038
+				if !_rules[ruleAction78]() {
+					goto l1773
 				}
-				add(ruleParamMapExpr, position1039)
+				add(ruleParamMapExpr, position1774)
 			}
 			return true
-		l1038:
-			position, tokenIndex = position1038, tokenIndex1038
+		l1773:
+			position, tokenIndex = position1773, tokenIndex1773
 			return false
 		},
-		/* 69 ParamKeyValuePair <- <(<(StringLiteral spOpt ':' spOpt ParamLiteral)> Action52)> */
+		/* 100 ParamKeyValuePair <- <(<(StringLiteral spOpt ':' spOpt ParamLiteral)> Action79)> */
 		func() bool {
-			position1045, tokenIndex1045 := position, tokenIndex
+			position1780, tokenIndex1780 := position, tokenIndex
 			{
-				position1046 := position
+				position1781 := position
 				{
-					position1047 := position
+					position1782 := position
 					if !_rules[ruleStringLiteral]() {
-						goto l1045
+						goto l1780
 					}
 					if !_rules[rulespOpt]() {
-						goto l1045
+						goto l1780
 					}
 					if buffer[position] != rune(':') {
-						goto l1045
+						goto l1780
 					}
 					position++
 					if !_rules[rulespOpt]() {
-						goto l1045
+						goto l1780
 					}
 					if !_rules[ruleParamLiteral]() {
-						goto l1045
+						goto l1780
 					}
-					add(rulePegText, position1047)
+					add(rulePegText, position1782)
 				}
-				if !_rules[ruleAction52]() {
-					goto l1045
+				if !_rules[ruleAction79]() {
+					goto l1780
 				}
-				add(ruleParamKeyValuePair, position1046)
+				add(ruleParamKeyValuePair, position1781)
 			}
 			return true
-		l1045:
-			position, tokenIndex = position1045, tokenIndex1045
+		l1780:
+			position, tokenIndex = position1780, tokenIndex1780
 			return false
 		},
-		/* 70 PausedOpt <- <(<(sp (Paused / Unpaused))?> Action53)> */
+		/* 101 PausedOpt <- <(<(sp (Paused / Unpaused))?> Action80)> */
 		func() bool {
-			position1048, tokenIndex1048 := position, tokenIndex
+			position1783, tokenIndex1783 := position, tokenIndex
 			{
-				position1049 := position
+				position1784 := position
 				{
-					position1050 := position
+					position1785 := position
 					{
-						position1051, tokenIndex1051 := position, tokenIndex
+						position1786, tokenIndex1786 := position, tokenIndex
 						if !_rules[rulesp]() {
-							goto l1051
+							goto l1786
 						}
 						{
-							position1053, tokenIndex1053 := position, tokenIndex
+							position1788, tokenIndex1788 := position, tokenIndex
 							if !_rules[rulePaused]() {
-								goto l1054
+								goto l1789
 							}
-							goto l1053
-						l1054:
-							position, tokenIndex = position1053, tokenIndex1053
+							goto l1788
+						l1789:
+							position, tokenIndex = position1788, tokenIndex1788
 							if !_rules[ruleUnpaused]() {
-								goto l1051
+								goto l1786
 							}
 						}
-					l1053:
-						goto l1052
-					l1051:
-						position, tokenIndex = position1051, tokenIndex1051
+					l1788:
+						goto l1787
+					l1786:
+						position, tokenIndex = position1786, tokenIndex1786
 					}
-				l1052:
-					add(rulePegText, position1050)
+				l1787:
+					add(rulePegText, position1785)
 				}
-				if !_rules[ruleAction53]() {
-					goto l1048
+				if !_rules[ruleAction80]() {
+					goto l1783
 				}
-				add(rulePausedOpt, position1049)
+				add(rulePausedOpt, position1784)
 			}
 			return true
-		l1048:
-			position, tokenIndex = position1048, tokenIndex1048
+		l1783:
+			position, tokenIndex = position1783, tokenIndex1783
 			return false
 		},
-		/* 71 ExpressionOrWildcard <- <(Wildcard / Expression)> */
+		/* 102 ExpressionOrWildcard <- <(Wildcard / Expression)> */
 		func() bool {
-			position1055, tokenIndex1055 := position, tokenIndex
+			position1790, tokenIndex1790 := position, tokenIndex
 			{
-				position1056 := position
+				position1791 := position
 				{
-					position1057, tokenIndex1057 := position, tokenIndex
+					position1792, tokenIndex1792 := position, tokenIndex
 					if !_rules[ruleWildcard]() {
-						goto l1058
+						goto l1793
 					}
-					goto l1057
-				l1058:
-					position, tokenIndex = position1057, tokenIndex1057
+					goto l1792
+				l1793:
+					position, tokenIndex = position1792, tokenIndex1792
 					if !_rules[ruleExpression]() {
-						goto l1055
+						goto l1790
 					}
 				}
-			l1057:
-				add(ruleExpressionOrWildcard, position1056)
+			l1792:
+				add(ruleExpressionOrWildcard, position1791)
 			}
 			return true
-		l1055:
-			position, tokenIndex = position1055, tokenIndex1055
+		l1790:
+			position, tokenIndex = position1790, tokenIndex1790
 			return false
 		},
-		/* 72 Expression <- <orExpr> */
+		/* 103 Expression <- <orExpr> */
 		func() bool {
-			position1059, tokenIndex1059 := position, tokenIndex
+			position1794, tokenIndex1794 := position, tokenIndex
 			{
-				position1060 := position
+				position1795 := position
 				if !_rules[ruleorExpr]() {
-					goto l1059
+					goto l1794
 				}
-				add(ruleExpression, position1060)
+				add(ruleExpression, position1795)
 			}
 			return true
-		l1059:
-			position, tokenIndex = position1059, tokenIndex1059
+		l1794:
+			position, tokenIndex = position1794, tokenIndex1794
 			return false
 		},
-		/* 73 orExpr <- <(<(andExpr (sp Or sp andExpr)*)> Action54)> */
+		/* 104 orExpr <- <(<(andExpr (sp Or sp andExpr)*)> Action81)> */
 		func() bool {
-			position1061, tokenIndex1061 := position, tokenIndex
+			position1796, tokenIndex1796 := position, tokenIndex
 			{
-				position1062 := position
+				position1797 := position
 				{
-					position1063 := position
+					position1798 := position
 					if !_rules[ruleandExpr]() {
-						goto l1061
+						goto l1796
 					}
-				l1064:
+				l1799:
 					{
-						position1065, tokenIndex1065 := position, tokenIndex
+						position1800, tokenIndex1800 := position, tokenIndex
 						if !_rules[rulesp]() {
-							goto l1065
+							goto l1800
 						}
 						if !_rules[ruleOr]() {
-							goto l1065
+							goto l1800
 						}
 						if !_rules[rulesp]() {
-							goto l1065
+							goto l1800
 						}
 						if !_rules[ruleandExpr]() {
-							goto l1065
+							goto l1800
 						}
-						goto l1064
-					l1065:
-						position, tokenIndex = position1065, tokenIndex1065
+						goto l1799
+					l1800:
+						position, tokenIndex = position1800, tokenIndex1800
 					}
-					add(rulePegText, position1063)
+					add(rulePegText, position1798)
 				}
-				if !_rules[ruleAction54]() {
-					goto l1061
+				if !_rules[ruleAction81]() {
+					goto l1796
 				}
-				add(ruleorExpr, position1062)
+				add(ruleorExpr, position1797)
 			}
 			return true
-		l1061:
-			position, tokenIndex = position1061, tokenIndex1061
+		l1796:
+			position, tokenIndex = position1796, tokenIndex1796
 			return false
 		},
-		/* 74 andExpr <- <(<(notExpr (sp And sp notExpr)*)> Action55)> */
+		/* 105 andExpr <- <(<(notExpr (sp And sp notExpr)*)> Action82)> */
 		func() bool {
-			position1066, tokenIndex1066 := position, tokenIndex
+			position1801, tokenIndex1801 := position, tokenIndex
 			{
-				position1067 := position
+				position1802 := position
 				{
-					position1068 := position
+					position1803 := position
 					if !_rules[rulenotExpr]() {
-						goto l1066
+						goto l1801
 					}
-				l1069:
+				l1804:
 					{
-						position1070, tokenIndex1070 := position, tokenIndex
+						position1805, tokenIndex1805 := position, tokenIndex
 						if !_rules[rulesp]() {
-							goto l1070
+							goto l1805
 						}
 						if !_rules[ruleAnd]() {
-							goto l1070
+							goto l1805
 						}
 						if !_rules[rulesp]() {
-							goto l1070
+							goto l1805
 						}
 						if !_rules[rulenotExpr]() {
-							goto l1070
+							goto l1805
 						}
-						goto l1069
-					l1070:
-						position, tokenIndex = position1070, tokenIndex1070
+						goto l1804
+					l1805:
+						position, tokenIndex = position1805, tokenIndex1805
 					}
-					add(rulePegText, position1068)
+					add(rulePegText, position1803)
 				}
-				if !_rules[ruleAction55]() {
-					goto l1066
+				if !_rules[ruleAction82]() {
+					goto l1801
 				}
-				add(ruleandExpr, position1067)
+				add(ruleandExpr, position1802)
 			}
 			return true
-		l1066:
-			position, tokenIndex = position1066, tokenIndex1066
+		l1801:
+			position, tokenIndex = position1801, tokenIndex1801
 			return false
 		},
-		/* 75 notExpr <- <(<((Not sp)? comparisonExpr)> Action56)> */
+		/* 106 notExpr <- <(<((Not sp)? comparisonExpr)> Action83)> */
 		func() bool {
-			position1071, tokenIndex1071 := position, tokenIndex
+			position1806, tokenIndex1806 := position, tokenIndex
 			{
-				position1072 := position
+				position1807 := position
 				{
-					position1073 := position
+					position1808 := position
 					{
-						position1074, tokenIndex1074 := position, tokenIndex
+						position1809, tokenIndex1809 := position, tokenIndex
 						if !_rules[ruleNot]() {
-							goto l1074
+							goto l1809
 						}
 						if !_rules[rulesp]() {
-							goto l1074
+							goto l1809
 						}
-						goto l1075
-					l1074:
-						position, tokenIndex = position1074, tokenIndex1074
+						goto l1810
+					l1809:
+						position, tokenIndex = position1809, tokenIndex1809
 					}
-				l1075:
+				l1810:
 					if !_rules[rulecomparisonExpr]() {
-						goto l1071
+						goto l1806
 					}
-					add(rulePegText, position1073)
+					add(rulePegText, position1808)
 				}
-				if !_rules[ruleAction56]() {
-					goto l1071
+				if !_rules[ruleAction83]() {
+					goto l1806
 				}
-				add(rulenotExpr, position1072)
+				add(rulenotExpr, position1807)
 			}
 			return true
-		l1071:
-			position, tokenIndex = position1071, tokenIndex1071
+		l1806:
+			position, tokenIndex = position1806, tokenIndex1806
 			return false
 		},
-		/* 76 comparisonExpr <- <(<(otherOpExpr (spOpt ComparisonOp spOpt otherOpExpr)?)> Action57)> */
+		/* 107 comparisonExpr <- <(<(otherOpExpr (spOpt ComparisonOp spOpt otherOpExpr)?)> Action84)> */
 		func() bool {
-			position1076, tokenIndex1076 := position, tokenIndex
+			position1811, tokenIndex1811 := position, tokenIndex
 			{
-				position1077 := position
+				position1812 := position
 				{
-					position1078 := position
+					position1813 := position
 					if !_rules[ruleotherOpExpr]() {
-						goto l1076
+						goto l1811
 					}
 					{
-						position1079, tokenIndex1079 := position, tokenIndex
+						position1814, tokenIndex1814 := position, tokenIndex
 						if !_rules[rulespOpt]() {
-							goto l1079
+							goto l1814
 						}
 						if !_rules[ruleComparisonOp]() {
-							goto l1079
+							goto l1814
 						}
 						if !_rules[rulespOpt]() {
-							goto l1079
+							goto l1814
 						}
 						if !_rules[ruleotherOpExpr]() {
-							goto l1079
+							goto l1814
 						}
-						goto l1080
-					l1079:
-						position, tokenIndex = position1079, tokenIndex1079
+						goto l1815
+					l1814:
+						position, tokenIndex = position1814, tokenIndex1814
 					}
-				l1080:
-					add(rulePegText, position1078)
+				l1815:
+					add(rulePegText, position1813)
 				}
-				if !_rules[ruleAction57]() {
-					goto l1076
+				if !_rules[ruleAction84]() {
+					goto l1811
 				}
-				add(rulecomparisonExpr, position1077)
+				add(rulecomparisonExpr, position1812)
 			}
 			return true
-		l1076:
-			position, tokenIndex = position1076, tokenIndex1076
+		l1811:
+			position, tokenIndex = position1811, tokenIndex1811
 			return false
 		},
-		/* 77 otherOpExpr <- <(<(isExpr (spOpt OtherOp spOpt isExpr)*)> Action58)> */
+		/* 108 otherOpExpr <- <(<(isExpr (spOpt OtherOp spOpt isExpr)*)> Action85)> */
 		func() bool {
-			position1081, tokenIndex1081 := position, tokenIndex
+			position1816, tokenIndex1816 := position, tokenIndex
 			{
-				position1082 := position
+				position1817 := position
 				{
-					position1083 := position
+					position1818 := position
 					if !_rules[ruleisExpr]() {
-						goto l1081
+						goto l1816
 					}
-				l1084:
+				l1819:
 					{
-						position1085, tokenIndex1085 := position, tokenIndex
+						position1820, tokenIndex1820 := position, tokenIndex
 						if !_rules[rulespOpt]() {
-							goto l1085
+							goto l1820
 						}
 						if !_rules[ruleOtherOp]() {
-							goto l1085
+							goto l1820
 						}
 						if !_rules[rulespOpt]() {
-							goto l1085
+							goto l1820
 						}
 						if !_rules[ruleisExpr]() {
-							goto l1085
+							goto l1820
 						}
-						goto l1084
-					l1085:
-						position, tokenIndex = position1085, tokenIndex1085
+						goto l1819
+					l1820:
+						position, tokenIndex = position1820, tokenIndex1820
 					}
-					add(rulePegText, position1083)
+					add(rulePegText, position1818)
 				}
-				if !_rules[ruleAction58]() {
-					goto l1081
+				if !_rules[ruleAction85]() {
+					goto l1816
 				}
-				add(ruleotherOpExpr, position1082)
+				add(ruleotherOpExpr, position1817)
 			}
 			return true
-		l1081:
-			position, tokenIndex = position1081, tokenIndex1081
+		l1816:
+			position, tokenIndex = position1816, tokenIndex1816
 			return false
 		},
-		/* 78 isExpr <- <(<((RowValue sp IsOp sp Missing) / (termExpr (sp IsOp sp NullLiteral)?))> Action59)> */
+		/* 109 isExpr <- <(<((RowValue sp IsOp sp Missing) / (termExpr ((sp IsDistinctOp sp termExpr) / (sp IsOp sp NullLiteral))?))> Action86)> */
 		func() bool {
-			position1086, tokenIndex1086 := position, tokenIndex
+			position1821, tokenIndex1821 := position, tokenIndex
 			{
-				position1087 := position
+				position1822 := position
 				{
-					position1088 := position
+					position1823 := position
 					{
-						position1089, tokenIndex1089 := position, tokenIndex
+						position1824, tokenIndex1824 := position, tokenIndex
 						if !_rules[ruleRowValue]() {
-							goto l1090
+							goto l1825
 						}
 						if !_rules[rulesp]() {
-							goto l1090
+							goto l1825
 						}
 						if !_rules[ruleIsOp]() {
-							goto l1090
+							goto l1825
 						}
 						if !_rules[rulesp]() {
-							goto l1090
+							goto l1825
 						}
 						if !_rules[ruleMissing]() {
-							goto l1090
+							goto l1825
 						}
-						goto l1089
-					l1090:
-						position, tokenIndex = position1089, tokenIndex1089
+						goto l1824
+					l1825:
+						position, tokenIndex = position1824, tokenIndex1824
 						if !_rules[ruletermExpr]() {
-							goto l1086
+							goto l1821
 						}
 						{
-							position1091, tokenIndex1091 := position, tokenIndex
-							if !_rules[rulesp]() {
-								goto l1091
-							}
-							if !_rules[ruleIsOp]() {
-								goto l1091
-							}
-							if !_rules[rulesp]() {
-								goto l1091
-							}
-							if !_rules[ruleNullLiteral]() {
-								goto l1091
+							position1826, tokenIndex1826 := position, tokenIndex
+							{
+								position1828, tokenIndex1828 := position, tokenIndex
+								if !_rules[rulesp]() {
+									goto l1829
+								}
+								if !_rules[ruleIsDistinctOp]() {
+									goto l1829
+								}
+								if !_rules[rulesp]() {
+									goto l1829
+								}
+								if !_rules[ruletermExpr]() {
+									goto l1829
+								}
+								goto l1828
+							l1829:
+								position, tokenIndex = position1828, tokenIndex1828
+								if !_rules[rulesp]() {
+									goto l1826
+								}
+								if !_rules[ruleIsOp]() {
+									goto l1826
+								}
+								if !_rules[rulesp]() {
+									goto l1826
+								}
+								if !_rules[ruleNullLiteral]() {
+									goto l1826
+								}
 							}
-							goto l1092
-						l1091:
-							position, tokenIndex = position1091, tokenIndex1091
+						l1828:
+							goto l1827
+						l1826:
+							position, tokenIndex = position1826, tokenIndex1826
 						}
-					l1092:
+					l1827:
 					}
-				l1089:
-					add(rulePegText, position1088)
+				l1824:
+					add(rulePegText, position1823)
 				}
-				if !_rules[ruleAction59]() {
-					goto l1086
+				if !_rules[ruleAction86]() {
+					goto l1821
 				}
-				add(ruleisExpr, position1087)
+				add(ruleisExpr, position1822)
 			}
 			return true
-		l1086:
-			position, tokenIndex = position1086, tokenIndex1086
+		l1821:
+			position, tokenIndex = position1821, tokenIndex1821
 			return false
 		},
-		/* 79 termExpr <- <(<(productExpr (spOpt PlusMinusOp spOpt productExpr)*)> Action60)> */
+		/* 110 termExpr <- <(<(productExpr (spOpt PlusMinusOp spOpt productExpr)*)> Action87)> */
 		func() bool {
-			position1093, tokenIndex1093 := position, tokenIndex
+			position1830, tokenIndex1830 := position, tokenIndex
 			{
-				position1094 := position
+				position1831 := position
 				{
-					position1095 := position
+					position1832 := position
 					if !_rules[ruleproductExpr]() {
-						goto l1093
+						goto l1830
 					}
-				l1096:
+				l1833:
 					{
-						position1097, tokenIndex1097 := position, tokenIndex
+						position1834, tokenIndex1834 := position, tokenIndex
 						if !_rules[rulespOpt]() {
-							goto l1097
+							goto l1834
 						}
 						if !_rules[rulePlusMinusOp]() {
-							goto l1097
+							goto l1834
 						}
 						if !_rules[rulespOpt]() {
-							goto l1097
+							goto l1834
 						}
 						if !_rules[ruleproductExpr]() {
-							goto l1097
+							goto l1834
 						}
-						goto l1096
-					l1097:
-						position, tokenIndex = position1097, tokenIndex1097
+						goto l1833
+					l1834:
+						position, tokenIndex = position1834, tokenIndex1834
 					}
-					add(rulePegText, position1095)
+					add(rulePegText, position1832)
 				}
-				if !_rules[ruleAction60]() {
-					goto l1093
+				if !_rules[ruleAction87]() {
+					goto l1830
 				}
-				add(ruletermExpr, position1094)
+				add(ruletermExpr, position1831)
 			}
 			return true
-		l1093:
-			position, tokenIndex = position1093, tokenIndex1093
+		l1830:
+			position, tokenIndex = position1830, tokenIndex1830
 			return false
 		},
-		/* 80 productExpr <- <(<(minusExpr (spOpt MultDivOp spOpt minusExpr)*)> Action61)> */
+		/* 111 productExpr <- <(<(minusExpr (spOpt MultDivOp spOpt minusExpr)*)> Action88)> */
 		func() bool {
-			position1098, tokenIndex1098 := position, tokenIndex
+			position1835, tokenIndex1835 := position, tokenIndex
 			{
-				position1099 := position
+				position1836 := position
 				{
-					position1100 := position
+					position1837 := position
 					if !_rules[ruleminusExpr]() {
-						goto l1098
+						goto l1835
 					}
-				l1101:
+				l1838:
 					{
-						position1102, tokenIndex1102 := position, tokenIndex
+						position1839, tokenIndex1839 := position, tokenIndex
 						if !_rules[rulespOpt]() {
-							goto l1102
+							goto l1839
 						}
 						if !_rules[ruleMultDivOp]() {
-							goto l1102
+							goto l1839
 						}
 						if !_rules[rulespOpt]() {
-							goto l1102
+							goto l1839
 						}
 						if !_rules[ruleminusExpr]() {
-							goto l1102
+							goto l1839
 						}
-						goto l1101
-					l1102:
-						position, tokenIndex = position1102, tokenIndex1102
+						goto l1838
+					l1839:
+						position, tokenIndex = position1839, tokenIndex1839
 					}
-					add(rulePegText, position1100)
+					add(rulePegText, position1837)
 				}
-				if !_rules[ruleAction61]() {
-					goto l1098
+				if !_rules[ruleAction88]() {
+					goto l1835
 				}
-				add(ruleproductExpr, position1099)
+				add(ruleproductExpr, position1836)
 			}
 			return true
-		l1098:
-			position, tokenIndex = position1098, tokenIndex1098
+		l1835:
+			position, tokenIndex = position1835, tokenIndex1835
 			return false
 		},
-		/* 81 minusExpr <- <(<((UnaryMinus spOpt)? castExpr)> Action62)> */
+		/* 112 minusExpr <- <(<((UnaryMinus spOpt)? castExpr)> Action89)> */
 		func() bool {
-			position1103, tokenIndex1103 := position, tokenIndex
+			position1840, tokenIndex1840 := position, tokenIndex
 			{
-				position1104 := position
+				position1841 := position
 				{
-					position1105 := position
+					position1842 := position
 					{
-						position1106, tokenIndex1106 := position, tokenIndex
+						position1843, tokenIndex1843 := position, tokenIndex
 						if !_rules[ruleUnaryMinus]() {
-							goto l1106
+							goto l1843
 						}
 						if !_rules[rulespOpt]() {
-							goto l1106
+							goto l1843
 						}
-						goto l1107
-					l1106:
-						position, tokenIndex = position1106, tokenIndex1106
+						goto l1844
+					l1843:
+						position, tokenIndex = position1843, tokenIndex1843
 					}
-				l1107:
+				l1844:
 					if !_rules[rulecastExpr]() {
-						goto l1103
+						goto l1840
 					}
-					add(rulePegText, position1105)
+					add(rulePegText, position1842)
 				}
-				if !_rules[ruleAction62]() {
-					goto l1103
+				if !_rules[ruleAction89]() {
+					goto l1840
 				}
-				add(ruleminusExpr, position1104)
+				add(ruleminusExpr, position1841)
 			}
 			return true
-		l1103:
-			position, tokenIndex = position1103, tokenIndex1103
+		l1840:
+			position, tokenIndex = position1840, tokenIndex1840
 			return false
 		},
-		/* 82 castExpr <- <(<(baseExpr (spOpt (':' ':') spOpt Type)?)> Action63)> */
+		/* 113 castExpr <- <(<(baseExpr (spOpt (':' ':') spOpt Type)?)> Action90)> */
 		func() bool {
-			position1108, tokenIndex1108 := position, tokenIndex
+			position1845, tokenIndex1845 := position, tokenIndex
 			{
-				position1109 := position
+				position1846 := position
 				{
-					position1110 := position
+					position1847 := position
 					if !_rules[rulebaseExpr]() {
-						goto l1108
+						goto l1845
 					}
 					{
-						position1111, tokenIndex1111 := position, tokenIndex
+						position1848, tokenIndex1848 := position, tokenIndex
 						if !_rules[rulespOpt]() {
-							goto l1111
+							goto l1848
 						}
 						if buffer[position] != rune(':') {
-							goto l1111
+							goto l1848
 						}
 						position++
 						if buffer[position] != rune(':') {
-							goto l1111
+							goto l1848
 						}
 						position++
 						if !_rules[rulespOpt]() {
-							goto l1111
+							goto l1848
 						}
 						if !_rules[ruleType]() {
-							goto l1111
+							goto l1848
 						}
-						goto l1112
-					l1111:
-						position, tokenIndex = position1111, tokenIndex1111
+						goto l1849
+					l1848:
+						position, tokenIndex = position1848, tokenIndex1848
 					}
-				l1112:
-					add(rulePegText, position1110)
+				l1849:
+					add(rulePegText, position1847)
 				}
-				if !_rules[ruleAction63]() {
-					goto l1108
+				if !_rules[ruleAction90]() {
+					goto l1845
 				}
-				add(rulecastExpr, position1109)
+				add(rulecastExpr, position1846)
 			}
 			return true
-		l1108:
-			position, tokenIndex = position1108, tokenIndex1108
+		l1845:
+			position, tokenIndex = position1845, tokenIndex1845
 			return false
 		},
-		/* 83 baseExpr <- <(('(' spOpt Expression spOpt ')') / MapExpr / BooleanLiteral / NullLiteral / Case / RowMeta / FuncTypeCast / FuncAppSelector / FuncApp / RowValue / ArrayExpr / Literal)> */
+		/* 114 baseExpr <- <(('(' spOpt Expression spOpt ')') / MapExpr / BooleanLiteral / NullLiteral / Case / RowMeta / ConstantRef / FuncTypeCast / FuncAppSelector / FuncApp / RowValue / ArrayExpr / Literal)> */
 		func() bool {
-			position1113, tokenIndex1113 := position, tokenIndex
+			position1850, tokenIndex1850 := position, tokenIndex
 			{
-				position1114 := position
+				position1851 := position
 				{
-					position1115, tokenIndex1115 := position, tokenIndex
+					position1852, tokenIndex1852 := position, tokenIndex
 					if buffer[position] != rune('(') {
-						goto l1116
+						goto l1853
 					}
 					position++
 					if !_rules[rulespOpt]() {
-						goto l1116
+						goto l1853
 					}
 					if !_rules[ruleExpression]() {
-						goto l1116
+						goto l1853
 					}
 					if !_rules[rulespOpt]() {
-						goto l1116
+						goto l1853
 					}
 					if buffer[position] != rune(')') {
-						goto l1116
+						goto l1853
 					}
 					position++
-					goto l1115
-				l1116:
-					position, tokenIndex = position1115, tokenIndex1115
+					goto l1852
+				l1853:
+					position, tokenIndex = position1852, tokenIndex1852
 					if !_rules[ruleMapExpr]() {
-						goto l1117
+						goto l1854
 					}
-					goto l1115
-				l1117:
-					position, tokenIndex = position1115, tokenIndex1115
+					goto l1852
+				l1854:
+					position, tokenIndex = position1852, tokenIndex1852
 					if !_rules[ruleBooleanLiteral]() {
-						goto l1118
+						goto l1855
 					}
-					goto l1115
-				l1118:
-					position, tokenIndex = position1115, tokenIndex1115
+					goto l1852
+				l1855:
+					position, tokenIndex = position1852, tokenIndex1852
 					if !_rules[ruleNullLiteral]() {
-						goto l1119
+						goto l1856
 					}
-					goto l1115
-				l1119:
-					position, tokenIndex = position1115, tokenIndex1115
+					goto l1852
+				l1856:
+					position, tokenIndex = position1852, tokenIndex1852
 					if !_rules[ruleCase]() {
-						goto l1120
+						goto l1857
 					}
-					goto l1115
-				l1120:
-					position, tokenIndex = position1115, tokenIndex1115
+					goto l1852
+				l1857:
+					position, tokenIndex = position1852, tokenIndex1852
 					if !_rules[ruleRowMeta]() {
-						goto l1121
+						goto l1858
 					}
-					goto l1115
-				l1121:
-					position, tokenIndex = position1115, tokenIndex1115
+					goto l1852
+				l1858:
+					position, tokenIndex = position1852, tokenIndex1852
+					if !_rules[ruleConstantRef]() {
+						goto l1859
+					}
+					goto l1852
+				l1859:
+					position, tokenIndex = position1852, tokenIndex1852
 					if !_rules[ruleFuncTypeCast]() {
-						goto l1122
+						goto l1860
 					}
-					goto l1115
-				l1122:
-					position, tokenIndex = position1115, tokenIndex1115
+					goto l1852
+				l1860:
+					position, tokenIndex = position1852, tokenIndex1852
 					if !_rules[ruleFuncAppSelector]() {
-						goto l1123
+						goto l1861
 					}
-					goto l1115
-				l1123:
-					position, tokenIndex = position1115, tokenIndex1115
+					goto l1852
+				l1861:
+					position, tokenIndex = position1852, tokenIndex1852
 					if !_rules[ruleFuncApp]() {
-						goto l1124
+						goto l1862
 					}
-					goto l1115
-				l1124:
-					position, tokenIndex = position1115, tokenIndex1115
+					goto l1852
+				l1862:
+					position, tokenIndex = position1852, tokenIndex1852
 					if !_rules[ruleRowValue]() {
-						goto l1125
+						goto l1863
 					}
-					goto l1115
-				l1125:
-					position, tokenIndex = position1115, tokenIndex1115
+					goto l1852
+				l1863:
+					position, tokenIndex = position1852, tokenIndex1852
 					if !_rules[ruleArrayExpr]() {
-						goto l1126
+						goto l1864
 					}
-					goto l1115
-				l1126:
-					position, tokenIndex = position1115, tokenIndex1115
+					goto l1852
+				l1864:
+					position, tokenIndex = position1852, tokenIndex1852
 					if !_rules[ruleLiteral]() {
-						goto l1113
+						goto l1850
 					}
 				}
-			l1115:
-				add(rulebaseExpr, position1114)
+			l1852:
+				add(rulebaseExpr, position1851)
 			}
 			return true
-		l1113:
-			position, tokenIndex = position1113, tokenIndex1113
+		l1850:
+			position, tokenIndex = position1850, tokenIndex1850
 			return false
 		},
-		/* 84 FuncTypeCast <- <(<(('c' / 'C') ('a' / 'A') ('s' / 'S') ('t' / 'T') spOpt '(' spOpt Expression sp (('a' / 'A') ('s' / 'S')) sp Type spOpt ')')> Action64)> */
+		/* 115 FuncTypeCast <- <(<(('c' / 'C') ('a' / 'A') ('s' / 'S') ('t' / 'T') spOpt '(' spOpt Expression sp (('a' / 'A') ('s' / 'S')) sp Type spOpt ')')> Action91)> */
 		func() bool {
-			position1127, tokenIndex1127 := position, tokenIndex
+			position1865, tokenIndex1865 := position, tokenIndex
 			{
-				position1128 := position
+				position1866 := position
 				{
-					position1129 := position
+					position1867 := position
 					{
-						position1130, tokenIndex1130 := position, tokenIndex
+						position1868, tokenIndex1868 := position, tokenIndex
 						if buffer[position] != rune('c') {
-							goto l1131
+							goto l1869
 						}
 						position++
-						goto l1130
-					l1131:
-						position, tokenIndex = position1130, tokenIndex1130
+						goto l1868
+					l1869:
+						position, tokenIndex = position1868, tokenIndex1868
 						if buffer[position] != rune('C') {
-							goto l1127
+							goto l1865
 						}
 						position++
 					}
-				l1130:
+				l1868:
 					{
-						position1132, tokenIndex1132 := position, tokenIndex
+						position1870, tokenIndex1870 := position, tokenIndex
 						if buffer[position] != rune('a') {
-							goto l1133
+							goto l1871
 						}
 						position++
-						goto l1132
-					l1133:
-						position, tokenIndex = position1132, tokenIndex1132
+						goto l1870
+					l1871:
+						position, tokenIndex = position1870, tokenIndex1870
 						if buffer[position] != rune('A') {
-							goto l1127
+							goto l1865
 						}
 						position++
 					}
-				l1132:
+				l1870:
 					{
-						position1134, tokenIndex1134 := position, tokenIndex
+						position1872, tokenIndex1872 := position, tokenIndex
 						if buffer[position] != rune('s') {
-							goto l1135
+							goto l1873
 						}
 						position++
-						goto l1134
-					l1135:
-						position, tokenIndex = position1134, tokenIndex1134
+						goto l1872
+					l1873:
+						position, tokenIndex = position1872, tokenIndex1872
 						if buffer[position] != rune('S') {
-							goto l1127
+							goto l1865
 						}
 						position++
 					}
-				l1134:
+				l1872:
 					{
-						position1136, tokenIndex1136 := position, tokenIndex
+						position1874, tokenIndex1874 := position, tokenIndex
 						if buffer[position] != rune('t') {
-							goto l1137
+							goto l1875
 						}
 						position++
-						goto l1136
-					l1137:
-						position, tokenIndex = position1136, tokenIndex1136
+						goto l1874
+					l1875:
+						position, tokenIndex = position1874, tokenIndex1874
 						if buffer[position] != rune('T') {
-							goto l1127
+							goto l1865
 						}
 						position++
 					}
-				l1136:
+				l1874:
 					if !_rules[rulespOpt]() {
-						goto l1127
+						goto l1865
 					}
 					if buffer[position] != rune('(') {
-						goto l1127
+						goto l1865
 					}
 					position++
 					if !_rules[rulespOpt]() {
-						goto l1127
+						goto l1865
 					}
 					if !_rules[ruleExpression]() {
-						goto l1127
+						goto l1865
 					}
 					if !_rules[rulesp]() {
-						goto l1127
+						goto l1865
 					}
 					{
-						position1138, tokenIndex1138 := position, tokenIndex
+						position1876, tokenIndex1876 := position, tokenIndex
 						if buffer[position] != rune('a') {
-							goto l1139
+							goto l1877
 						}
 						position++
-						goto l1138
-					l1139:
-						position, tokenIndex = position1138, tokenIndex1138
+						goto l1876
+					l1877:
+						position, tokenIndex = position1876, tokenIndex1876
 						if buffer[position] != rune('A') {
-							goto l1127
+							goto l1865
 						}
 						position++
 					}
-				l1138:
+				l1876:
 					{
-						position1140, tokenIndex1140 := position, tokenIndex
+						position1878, tokenIndex1878 := position, tokenIndex
 						if buffer[position] != rune('s') {
-							goto l1141
+							goto l1879
 						}
 						position++
-						goto l1140
-					l1141:
-						position, tokenIndex = position1140, tokenIndex1140
+						goto l1878
+					l1879:
+						position, tokenIndex = position1878, tokenIndex1878
 						if buffer[position] != rune('S') {
-							goto l1127
+							goto l1865
 						}
 						position++
 					}
-				l1140:
+				l1878:
 					if !_rules[rulesp]() {
-						goto l1127
+						goto l1865
 					}
 					if !_rules[ruleType]() {
-						goto l1127
+						goto l1865
 					}
 					if !_rules[rulespOpt]() {
-						goto l1127
+						goto l1865
 					}
 					if buffer[position] != rune(')') {
-						goto l1127
+						goto l1865
 					}
 					position++
-					add(rulePegText, position1129)
+					add(rulePegText, position1867)
 				}
-				if !_rules[ruleAction64]() {
-					goto l1127
+				if !_rules[ruleAction91]() {
+					goto l1865
 				}
-				add(ruleFuncTypeCast, position1128)
+				add(ruleFuncTypeCast, position1866)
 			}
 			return true
-		l1127:
-			position, tokenIndex = position1127, tokenIndex1127
+		l1865:
+			position, tokenIndex = position1865, tokenIndex1865
 			return false
 		},
-		/* 85 FuncApp <- <(FuncAppWithOrderBy / FuncAppWithoutOrderBy)> */
+		/* 116 FuncApp <- <(FuncAppWithOrderBy / FuncAppWithoutOrderBy)> */
 		func() bool {
-			position1142, tokenIndex1142 := position, tokenIndex
+			position1880, tokenIndex1880 := position, tokenIndex
 			{
-				position1143 := position
+				position1881 := position
 				{
-					position1144, tokenIndex1144 := position, tokenIndex
+					position1882, tokenIndex1882 := position, tokenIndex
 					if !_rules[ruleFuncAppWithOrderBy]() {
-						goto l1145
+						goto l1883
 					}
-					goto l1144
-				l1145:
-					position, tokenIndex = position1144, tokenIndex1144
+					goto l1882
+				l1883:
+					position, tokenIndex = position1882, tokenIndex1882
 					if !_rules[ruleFuncAppWithoutOrderBy]() {
-						goto l1142
+						goto l1880
 					}
 				}
-			l1144:
-				add(ruleFuncApp, position1143)
+			l1882:
+				add(ruleFuncApp, position1881)
 			}
 			return true
-		l1142:
-			position, tokenIndex = position1142, tokenIndex1142
+		l1880:
+			position, tokenIndex = position1880, tokenIndex1880
 			return false
 		},
-		/* 86 FuncAppSelector <- <(FuncApp FuncElemAccessor Action65)> */
+		/* 117 FuncAppSelector <- <(FuncApp FuncElemAccessor Action92)> */
 		func() bool {
-			position1146, tokenIndex1146 := position, tokenIndex
+			position1884, tokenIndex1884 := position, tokenIndex
 			{
-				position1147 := position
+				position1885 := position
 				if !_rules[ruleFuncApp]() {
-					goto l1146
+					goto l1884
 				}
 				if !_rules[ruleFuncElemAccessor]() {
-					goto l1146
+					goto l1884
 				}
-				if !_rules[ruleAction65]() {
-					goto l1146
+				if !_rules[ruleAction92]() {
+					goto l1884
 				}
-				add(ruleFuncAppSelector, position1147)
+				add(ruleFuncAppSelector, position1885)
 			}
 			return true
-		l1146:
-			position, tokenIndex = position1146, tokenIndex1146
+		l1884:
+			position, tokenIndex = position1884, tokenIndex1884
 			return false
 		},
-		/* 87 FuncElemAccessor <- <(<jsonGetPathNonHead+> Action66)> */
+		/* 118 FuncElemAccessor <- <(<jsonGetPathNonHead+> Action93)> */
 		func() bool {
-			position1148, tokenIndex1148 := position, tokenIndex
+			position1886, tokenIndex1886 := position, tokenIndex
 			{
-				position1149 := position
+				position1887 := position
 				{
-					position1150 := position
+					position1888 := position
 					if !_rules[rulejsonGetPathNonHead]() {
-						goto l1148
+						goto l1886
 					}
-				l1151:
+				l1889:
 					{
-						position1152, tokenIndex1152 := position, tokenIndex
+						position1890, tokenIndex1890 := position, tokenIndex
 						if !_rules[rulejsonGetPathNonHead]() {
-							goto l1152
+							goto l1890
 						}
-						goto l1151
-					l1152:
-						position, tokenIndex = position1152, tokenIndex1152
+						goto l1889
+					l1890:
+						position, tokenIndex = position1890, tokenIndex1890
 					}
-					add(rulePegText, position1150)
+					add(rulePegText, position1888)
 				}
-				if !_rules[ruleAction66]() {
-					goto l1148
+				if !_rules[ruleAction93]() {
+					goto l1886
 				}
-				add(ruleFuncElemAccessor, position1149)
+				add(ruleFuncElemAccessor, position1887)
 			}
 			return true
-		l1148:
-			position, tokenIndex = position1148, tokenIndex1148
+		l1886:
+			position, tokenIndex = position1886, tokenIndex1886
 			return false
 		},
-		/* 88 FuncAppWithOrderBy <- <(Function spOpt '(' spOpt FuncParams sp ParamsOrder spOpt ')' Action67)> */
+		/* 119 FuncAppWithOrderBy <- <(Function spOpt '(' spOpt FuncParams sp ParamsOrder spOpt ')' Action94)> */
 		func() bool {
-			position1153, tokenIndex1153 := position, tokenIndex
+			position1891, tokenIndex1891 := position, tokenIndex
 			{
-				position1154 := position
+				position1892 := position
 				if !_rules[ruleFunction]() {
-					goto l1153
+					goto l1891
 				}
 				if !_rules[rulespOpt]() {
-					goto l1153
+					goto l1891
 				}
 				if buffer[position] != rune('(') {
-					goto l1153
+					goto l1891
 				}
 				position++
 				if !_rules[rulespOpt]() {
-					goto l1153
+					goto l1891
 				}
 				if !_rules[ruleFuncParams]() {
-					goto l1153
+					goto l1891
 				}
 				if !_rules[rulesp]() {
-					goto l1153
+					goto l1891
 				}
 				if !_rules[ruleParamsOrder]() {
-					goto l1153
+					goto l1891
 				}
 				if !_rules[rulespOpt]() {
-					goto l1153
+					goto l1891
 				}
 				if buffer[position] != rune(')') {
-					goto l1153
+					goto l1891
 				}
 				position++
-				if !_rules[ruleAction67]() {
-					goto l1153
+				if !_rules[ruleAction94]() {
+					goto l1891
 				}
-				add(ruleFuncAppWithOrderBy, position1154)
+				add(ruleFuncAppWithOrderBy, position1892)
 			}
 			return true
-		l1153:
-			position, tokenIndex = position1153, tokenIndex1153
+		l1891:
+			position, tokenIndex = position1891, tokenIndex1891
 			return false
 		},
-		/* 89 FuncAppWithoutOrderBy <- <(Function spOpt '(' spOpt FuncParams <spOpt> ')' Action68)> */
+		/* 120 FuncAppWithoutOrderBy <- <(Function spOpt '(' spOpt FuncParams <spOpt> ')' Action95)> */
 		func() bool {
-			position1155, tokenIndex1155 := position, tokenIndex
+			position1893, tokenIndex1893 := position, tokenIndex
 			{
-				position1156 := position
+				position1894 := position
 				if !_rules[ruleFunction]() {
-					goto l1155
+					goto l1893
 				}
 				if !_rules[rulespOpt]() {
-					goto l1155
+					goto l1893
 				}
 				if buffer[position] != rune('(') {
-					goto l1155
+					goto l1893
 				}
 				position++
 				if !_rules[rulespOpt]() {
-					goto l1155
+					goto l1893
 				}
 				if !_rules[ruleFuncParams]() {
-					goto l1155
+					goto l1893
 				}
 				{
-					position1157 := position
+					position1895 := position
 					if !_rules[rulespOpt]() {
-						goto l1155
+						goto l1893
 					}
-					add(rulePegText, position1157)
+					add(rulePegText, position1895)
 				}
 				if buffer[position] != rune(')') {
-					goto l1155
+					goto l1893
 				}
 				position++
-				if !_rules[ruleAction68]() {
-					goto l1155
+				if !_rules[ruleAction95]() {
+					goto l1893
 				}
-				add(ruleFuncAppWithoutOrderBy, position1156)
+				add(ruleFuncAppWithoutOrderBy, position1894)
 			}
 			return true
-		l1155:
-			position, tokenIndex = position1155, tokenIndex1155
+		l1893:
+			position, tokenIndex = position1893, tokenIndex1893
 			return false
 		},
-		/* 90 FuncParams <- <(<(ExpressionOrWildcard (spOpt ',' spOpt ExpressionOrWildcard)*)?> Action69)> */
+		/* 121 FuncParams <- <(<(ExpressionOrWildcard (spOpt ',' spOpt ExpressionOrWildcard)*)?> Action96)> */
 		func() bool {
-			position1158, tokenIndex1158 := position, tokenIndex
+			position1896, tokenIndex1896 := position, tokenIndex
 			{
-				position1159 := position
+				position1897 := position
 				{
-					position1160 := position
+					position1898 := position
 					{
-						position1161, tokenIndex1161 := position, tokenIndex
+						position1899, tokenIndex1899 := position, tokenIndex
 						if !_rules[ruleExpressionOrWildcard]() {
-							goto l1161
+							goto l1899
 						}
-					l1163:
+					l1901:
 						{
-							position1164, tokenIndex1164 := position, tokenIndex
+							position1902, tokenIndex1902 := position, tokenIndex
 							if !_rules[rulespOpt]() {
-								goto l1164
+								goto l1902
 							}
 							if buffer[position] != rune(',') {
-								goto l1164
+								goto l1902
 							}
 							position++
 							if !_rules[rulespOpt]() {
-								goto l1164
+								goto l1902
 							}
 							if !_rules[ruleExpressionOrWildcard]() {
-								goto l1164
+								goto l1902
 							}
-							goto l1163
-						l1164:
-							position, tokenIndex = position1164, tokenIndex1164
+							goto l1901
+						l1902:
+							position, tokenIndex = position1902, tokenIndex1902
 						}
-						goto l1162
-					l1161:
-						position, tokenIndex = position1161, tokenIndex1161
+						goto l1900
+					l1899:
+						position, tokenIndex = position1899, tokenIndex1899
 					}
-				l1162:
-					add(rulePegText, position1160)
+				l1900:
+					add(rulePegText, position1898)
 				}
-				if !_rules[ruleAction69]() {
-					goto l1158
+				if !_rules[ruleAction96]() {
+					goto l1896
 				}
-				add(ruleFuncParams, position1159)
+				add(ruleFuncParams, position1897)
 			}
 			return true
-		l1158:
-			position, tokenIndex = position1158, tokenIndex1158
+		l1896:
+			position, tokenIndex = position1896, tokenIndex1896
 			return false
 		},
-		/* 91 ParamsOrder <- <(<(('o' / 'O') ('r' / 'R') ('d' / 'D') ('e' / 'E') ('r' / 'R') sp (('b' / 'B') ('y' / 'Y')) sp SortedExpression (spOpt ',' spOpt SortedExpression)*)> Action70)> */
+		/* 122 ParamsOrder <- <(<(('o' / 'O') ('r' / 'R') ('d' / 'D') ('e' / 'E') ('r' / 'R') sp (('b' / 'B') ('y' / 'Y')) sp SortedExpression (spOpt ',' spOpt SortedExpression)*)> Action97)> */
 		func() bool {
-			position1165, tokenIndex1165 := position, tokenIndex
+			position1903, tokenIndex1903 := position, tokenIndex
 			{
-				position1166 := position
+				position1904 := position
 				{
-					position1167 := position
+					position1905 := position
 					{
-						position1168, tokenIndex1168 := position, tokenIndex
+						position1906, tokenIndex1906 := position, tokenIndex
 						if buffer[position] != rune('o') {
-							goto l1169
+							goto l1907
 						}
 						position++
-						goto l1168
-					l1169:
-						position, tokenIndex = position1168, tokenIndex1168
+						goto l1906
+					l1907:
+						position, tokenIndex = position1906, tokenIndex1906
 						if buffer[position] != rune('O') {
-							goto l1165
+							goto l1903
 						}
 						position++
 					}
-				l1168:
+				l1906:
 					{
-						position1170, tokenIndex1170 := position, tokenIndex
+						position1908, tokenIndex1908 := position, tokenIndex
 						if buffer[position] != rune('r') {
-							goto l1171
+							goto l1909
 						}
 						position++
-						goto l1170
-					l1171:
-						position, tokenIndex = position1170, tokenIndex1170
+						goto l1908
+					l1909:
+						position, tokenIndex = position1908, tokenIndex1908
 						if buffer[position] != rune('R') {
-							goto l1165
+							goto l1903
 						}
 						position++
 					}
-				l1170:
+				l1908:
 					{
-						position1172, tokenIndex1172 := position, tokenIndex
+						position1910, tokenIndex1910 := position, tokenIndex
 						if buffer[position] != rune('d') {
-							goto l1173
+							goto l1911
 						}
 						position++
-						goto l1172
-					l1173:
-						position, tokenIndex = position1172, tokenIndex1172
+						goto l1910
+					l1911:
+						position, tokenIndex = position1910, tokenIndex1910
 						if buffer[position] != rune('D') {
-							goto l1165
+							goto l1903
 						}
 						position++
 					}
-				l1172:
+				l1910:
 					{
-						position1174, tokenIndex1174 := position, tokenIndex
+						position1912, tokenIndex1912 := position, tokenIndex
 						if buffer[position] != rune('e') {
-							goto l1175
+							goto l1913
 						}
 						position++
-						goto l1174
-					l1175:
-						position, tokenIndex = position1174, tokenIndex1174
+						goto l1912
+					l1913:
+						position, tokenIndex = position1912, tokenIndex1912
 						if buffer[position] != rune('E') {
-							goto l1165
+							goto l1903
 						}
 						position++
 					}
-				l1174:
+				l1912:
 					{
-						position1176, tokenIndex1176 := position, tokenIndex
+						position1914, tokenIndex1914 := position, tokenIndex
 						if buffer[position] != rune('r') {
-							goto l1177
+							goto l1915
 						}
 						position++
-						goto l1176
-					l1177:
-						position, tokenIndex = position1176, tokenIndex1176
+						goto l1914
+					l1915:
+						position, tokenIndex = position1914, tokenIndex1914
 						if buffer[position] != rune('R') {
-							goto l1165
+							goto l1903
 						}
 						position++
 					}
-				l1176:
+				l1914:
 					if !_rules[rulesp]() {
-						goto l1165
+						goto l1903
 					}
 					{
-						position1178, tokenIndex1178 := position, tokenIndex
+						position1916, tokenIndex1916 := position, tokenIndex
 						if buffer[position] != rune('b') {
-							goto l1179
+							goto l1917
 						}
 						position++
-						goto l1178
-					l1179:
-						position, tokenIndex = position1178, tokenIndex1178
+						goto l1916
+					l1917:
+						position, tokenIndex = position1916, tokenIndex1916
 						if buffer[position] != rune('B') {
-							goto l1165
+							goto l1903
 						}
 						position++
 					}
-				l1178:
+				l1916:
 					{
-						position1180, tokenIndex1180 := position, tokenIndex
+						position1918, tokenIndex1918 := position, tokenIndex
 						if buffer[position] != rune('y') {
-							goto l1181
+							goto l1919
 						}
 						position++
-						goto l1180
-					l1181:
-						position, tokenIndex = position1180, tokenIndex1180
+						goto l1918
+					l1919:
+						position, tokenIndex = position1918, tokenIndex1918
 						if buffer[position] != rune('Y') {
-							goto l1165
+							goto l1903
 						}
 						position++
 					}
-				l1180:
+				l1918:
 					if !_rules[rulesp]() {
-						goto l1165
+						goto l1903
 					}
 					if !_rules[ruleSortedExpression]() {
-						goto l1165
+						goto l1903
 					}
-				l1182:
+				l1920:
 					{
-						position1183, tokenIndex1183 := position, tokenIndex
+						position1921, tokenIndex1921 := position, tokenIndex
 						if !_rules[rulespOpt]() {
-							goto l1183
+							goto l1921
 						}
 						if buffer[position] != rune(',') {
-							goto l1183
+							goto l1921
 						}
 						position++
 						if !_rules[rulespOpt]() {
-							goto l1183
+							goto l1921
 						}
 						if !_rules[ruleSortedExpression]() {
-							goto l1183
+							goto l1921
 						}
-						goto l1182
-					l1183:
-						position, tokenIndex = position1183, tokenIndex1183
+						goto l1920
+					l1921:
+						position, tokenIndex = position1921, tokenIndex1921
 					}
-					add(rulePegText, position1167)
+					add(rulePegText, position1905)
 				}
-				if !_rules[ruleAction70]() {
-					goto l1165
+				if !_rules[ruleAction97]() {
+					goto l1903
 				}
-				add(ruleParamsOrder, position1166)
+				add(ruleParamsOrder, position1904)
 			}
 			return true
-		l1165:
-			position, tokenIndex = position1165, tokenIndex1165
+		l1903:
+			position, tokenIndex = position1903, tokenIndex1903
 			return false
 		},
-		/* 92 SortedExpression <- <(Expression OrderDirectionOpt Action71)> */
+		/* 123 SortedExpression <- <(Expression OrderDirectionOpt Action98)> */
 		func() bool {
-			position1184, tokenIndex1184 := position, tokenIndex
+			position1922, tokenIndex1922 := position, tokenIndex
 			{
-				position1185 := position
+				position1923 := position
 				if !_rules[ruleExpression]() {
-					goto l1184
+					goto l1922
 				}
 				if !_rules[ruleOrderDirectionOpt]() {
-					goto l1184
+					goto l1922
 				}
-				if !_rules[ruleAction71]() {
-					goto l1184
+				if !_rules[ruleAction98]() {
+					goto l1922
 				}
-				add(ruleSortedExpression, position1185)
+				add(ruleSortedExpression, position1923)
 			}
 			return true
-		l1184:
-			position, tokenIndex = position1184, tokenIndex1184
+		l1922:
+			position, tokenIndex = position1922, tokenIndex1922
 			return false
 		},
-		/* 93 OrderDirectionOpt <- <(<(sp (Ascending / Descending))?> Action72)> */
+		/* 124 OrderDirectionOpt <- <(<(sp (Ascending / Descending))?> Action99)> */
 		func() bool {
-			position1186, tokenIndex1186 := position, tokenIndex
+			position1924, tokenIndex1924 := position, tokenIndex
 			{
-				position1187 := position
+				position1925 := position
 				{
-					position1188 := position
+					position1926 := position
 					{
-						position1189, tokenIndex1189 := position, tokenIndex
+						position1927, tokenIndex1927 := position, tokenIndex
 						if !_rules[rulesp]() {
-							goto l1189
+							goto l1927
 						}
 						{
-							position1191, tokenIndex1191 := position, tokenIndex
+							position1929, tokenIndex1929 := position, tokenIndex
 							if !_rules[ruleAscending]() {
-								goto l1192
+								goto l1930
 							}
-							goto l1191
-						l1192:
-							position, tokenIndex = position1191, tokenIndex1191
+							goto l1929
+						l1930:
+							position, tokenIndex = position1929, tokenIndex1929
 							if !_rules[ruleDescending]() {
-								goto l1189
+								goto l1927
 							}
 						}
-					l1191:
-						goto l1190
-					l1189:
-						position, tokenIndex = position1189, tokenIndex1189
+					l1929:
+						goto l1928
+					l1927:
+						position, tokenIndex = position1927, tokenIndex1927
 					}
-				l1190:
-					add(rulePegText, position1188)
+				l1928:
+					add(rulePegText, position1926)
 				}
-				if !_rules[ruleAction72]() {
-					goto l1186
+				if !_rules[ruleAction99]() {
+					goto l1924
 				}
-				add(ruleOrderDirectionOpt, position1187)
+				add(ruleOrderDirectionOpt, position1925)
 			}
 			return true
-		l1186:
-			position, tokenIndex = position1186, tokenIndex1186
+		l1924:
+			position, tokenIndex = position1924, tokenIndex1924
 			return false
 		},
-		/* 94 ArrayExpr <- <(<('[' spOpt (ExpressionOrWildcard (spOpt ',' spOpt ExpressionOrWildcard)*)? spOpt ','? spOpt ']')> Action73)> */
+		/* 125 ArrayExpr <- <(<('[' spOpt (ExpressionOrWildcard (spOpt ',' spOpt ExpressionOrWildcard)*)? spOpt ','? spOpt ']')> Action100)> */
 		func() bool {
-			position1193, tokenIndex1193 := position, tokenIndex
+			position1931, tokenIndex1931 := position, tokenIndex
 			{
-				position1194 := position
+				position1932 := position
 				{
-					position1195 := position
+					position1933 := position
 					if buffer[position] != rune('[') {
-						goto l1193
+						goto l1931
 					}
 					position++
 					if !_rules[rulespOpt]() {
-						goto l1193
+						goto l1931
 					}
 					{
-						position1196, tokenIndex1196 := position, tokenIndex
+						position1934, tokenIndex1934 := position, tokenIndex
 						if !_rules[ruleExpressionOrWildcard]() {
-							goto l1196
+							goto l1934
 						}
-					l1198:
+					l1936:
 						{
-							position1199, tokenIndex1199 := position, tokenIndex
+							position1937, tokenIndex1937 := position, tokenIndex
 							if !_rules[rulespOpt]() {
-								goto l1199
+								goto l1937
 							}
 							if buffer[position] != rune(',') {
-								goto l1199
+								goto l1937
 							}
 							position++
 							if !_rules[rulespOpt]() {
-								goto l1199
+								goto l1937
 							}
 							if !_rules[ruleExpressionOrWildcard]() {
-								goto l1199
+								goto l1937
 							}
-							goto l1198
-						l1199:
-							position, tokenIndex = position1199, tokenIndex1199
+							goto l1936
+						l1937:
+							position, tokenIndex = position1937, tokenIndex1937
 						}
-						goto l1197
-					l1196:
-						position, tokenIndex = position1196, tokenIndex1196
+						goto l1935
+					l1934:
+						position, tokenIndex = position1934, tokenIndex1934
 					}
-				l1197:
+				l1935:
 					if !_rules[rulespOpt]() {
-						goto l1193
+						goto l1931
 					}
 					{
-						position1200, tokenIndex1200 := position, tokenIndex
+						position1938, tokenIndex1938 := position, tokenIndex
 						if buffer[position] != rune(',') {
-							goto l1200
+							goto l1938
 						}
 						position++
-						goto l1201
-					l1200:
-						position, tokenIndex = position1200, tokenIndex1200
+						goto l1939
+					l1938:
+						position, tokenIndex = position1938, tokenIndex1938
 					}
-				l1201:
+				l1939:
 					if !_rules[rulespOpt]() {
-						goto l1193
+						goto l1931
 					}
 					if buffer[position] != rune(']') {
-						goto l1193
+						goto l1931
 					}
 					position++
-					add(rulePegText, position1195)
+					add(rulePegText, position1933)
 				}
-				if !_rules[ruleAction73]() {
-					goto l1193
+				if !_rules[ruleAction100]() {
+					goto l1931
 				}
-				add(ruleArrayExpr, position1194)
+				add(ruleArrayExpr, position1932)
 			}
 			return true
-		l1193:
-			position, tokenIndex = position1193, tokenIndex1193
+		l1931:
+			position, tokenIndex = position1931, tokenIndex1931
 			return false
 		},
-		/* 95 MapExpr <- <(<('{' spOpt (KeyValuePair (spOpt ',' spOpt KeyValuePair)*)? spOpt '}')> Action74)> */
+		/* 126 MapExpr <- <(<('{' spOpt (KeyValuePair (spOpt ',' spOpt KeyValuePair)*)? spOpt '}')> Action101)> */
 		func() bool {
-			position1202, tokenIndex1202 := position, tokenIndex
+			position1940, tokenIndex1940 := position, tokenIndex
 			{
-				position1203 := position
+				position1941 := position
 				{
-					position1204 := position
+					position1942 := position
 					if buffer[position] != rune('{') {
-						goto l1202
+						goto l1940
 					}
 					position++
 					if !_rules[rulespOpt]() {
-						goto l1202
+						goto l1940
 					}
 					{
-						position1205, tokenIndex1205 := position, tokenIndex
+						position1943, tokenIndex1943 := position, tokenIndex
 						if !_rules[ruleKeyValuePair]() {
-							goto l1205
+							goto l1943
 						}
-					l1207:
+					l1945:
 						{
-							position1208, tokenIndex1208 := position, tokenIndex
+							position1946, tokenIndex1946 := position, tokenIndex
 							if !_rules[rulespOpt]() {
-								goto l1208
+								goto l1946
 							}
 							if buffer[position] != rune(',') {
-								goto l1208
+								goto l1946
 							}
 							position++
 							if !_rules[rulespOpt]() {
-								goto l1208
+								goto l1946
 							}
 							if !_rules[ruleKeyValuePair]() {
-								goto l1208
+								goto l1946
 							}
-							goto l1207
-						l1208:
-							position, tokenIndex = position1208, tokenIndex1208
+							goto l1945
+						l1946:
+							position, tokenIndex = position1946, tokenIndex1946
 						}
-						goto l1206
-					l1205:
-						position, tokenIndex = position1205, tokenIndex1205
+						goto l1944
+					l1943:
+						position, tokenIndex = position1943, tokenIndex1943
 					}
-				l1206:
+				l1944:
 					if !_rules[rulespOpt]() {
-						goto l1202
+						goto l1940
 					}
 					if buffer[position] != rune('}') {
-						goto l1202
+						goto l1940
 					}
 					position++
-					add(rulePegText, position1204)
+					add(rulePegText, position1942)
 				}
-				if !_rules[ruleAction74]() {
-					goto l1202
+				if !_rules[ruleAction101]() {
+					goto l1940
 				}
-				add(ruleMapExpr, position1203)
+				add(ruleMapExpr, position1941)
 			}
 			return true
-		l1202:
-			position, tokenIndex = position1202, tokenIndex1202
+		l1940:
+			position, tokenIndex = position1940, tokenIndex1940
 			return false
 		},
-		/* 96 KeyValuePair <- <(<(StringLiteral spOpt ':' spOpt ExpressionOrWildcard)> Action75)> */
+		/* 127 KeyValuePair <- <(<(StringLiteral spOpt ':' spOpt ExpressionOrWildcard)> Action102)> */
 		func() bool {
-			position1209, tokenIndex1209 := position, tokenIndex
+			position1947, tokenIndex1947 := position, tokenIndex
 			{
-				position1210 := position
+				position1948 := position
 				{
-					position1211 := position
+					position1949 := position
 					if !_rules[ruleStringLiteral]() {
-						goto l1209
+						goto l1947
 					}
 					if !_rules[rulespOpt]() {
-						goto l1209
+						goto l1947
 					}
 					if buffer[position] != rune(':') {
-						goto l1209
+						goto l1947
 					}
 					position++
 					if !_rules[rulespOpt]() {
-						goto l1209
+						goto l1947
 					}
 					if !_rules[ruleExpressionOrWildcard]() {
-						goto l1209
+						goto l1947
 					}
-					add(rulePegText, position1211)
+					add(rulePegText, position1949)
 				}
-				if !_rules[ruleAction75]() {
-					goto l1209
+				if !_rules[ruleAction102]() {
+					goto l1947
 				}
-				add(ruleKeyValuePair, position1210)
+				add(ruleKeyValuePair, position1948)
 			}
 			return true
-		l1209:
-			position, tokenIndex = position1209, tokenIndex1209
+		l1947:
+			position, tokenIndex = position1947, tokenIndex1947
 			return false
 		},
-		/* 97 Case <- <(ConditionCase / ExpressionCase)> */
+		/* 128 Case <- <(ConditionCase / ExpressionCase)> */
 		func() bool {
-			position1212, tokenIndex1212 := position, tokenIndex
+			position1950, tokenIndex1950 := position, tokenIndex
 			{
-				position1213 := position
+				position1951 := position
 				{
-					position1214, tokenIndex1214 := position, tokenIndex
+					position1952, tokenIndex1952 := position, tokenIndex
 					if !_rules[ruleConditionCase]() {
-						goto l1215
+						goto l1953
 					}
-					goto l1214
-				l1215:
-					position, tokenIndex = position1214, tokenIndex1214
+					goto l1952
+				l1953:
+					position, tokenIndex = position1952, tokenIndex1952
 					if !_rules[ruleExpressionCase]() {
-						goto l1212
+						goto l1950
 					}
 				}
-			l1214:
-				add(ruleCase, position1213)
+			l1952:
+				add(ruleCase, position1951)
 			}
 			return true
-		l1212:
-			position, tokenIndex = position1212, tokenIndex1212
+		l1950:
+			position, tokenIndex = position1950, tokenIndex1950
 			return false
 		},
-		/* 98 ConditionCase <- <(('c' / 'C') ('a' / 'A') ('s' / 'S') ('e' / 'E') <((sp WhenThenPair)+ (sp (('e' / 'E') ('l' / 'L') ('s' / 'S') ('e' / 'E')) sp Expression)? sp (('e' / 'E') ('n' / 'N') ('d' / 'D')))> Action76)> */
+		/* 129 ConditionCase <- <(('c' / 'C') ('a' / 'A') ('s' / 'S') ('e' / 'E') <((sp WhenThenPair)+ (sp (('e' / 'E') ('l' / 'L') ('s' / 'S') ('e' / 'E')) sp Expression)? sp (('e' / 'E') ('n' / 'N') ('d' / 'D')))> Action103)> */
 		func() bool {
-			position1216, tokenIndex1216 := position, tokenIndex
+			position1954, tokenIndex1954 := position, tokenIndex
 			{
-				position1217 := position
+				position1955 := position
 				{
-					position1218, tokenIndex1218 := position, tokenIndex
+					position1956, tokenIndex1956 := position, tokenIndex
 					if buffer[position] != rune('c') {
-						goto l1219
+						goto l1957
 					}
 					position++
-					goto l1218
-				l1219:
-					position, tokenIndex = position1218, tokenIndex1218
+					goto l1956
+				l1957:
+					position, tokenIndex = position1956, tokenIndex1956
 					if buffer[position] != rune('C') {
-						goto l1216
+						goto l1954
 					}
 					position++
 				}
-			l1218:
+			l1956:
 				{
-					position1220, tokenIndex1220 := position, tokenIndex
+					position1958, tokenIndex1958 := position, tokenIndex
 					if buffer[position] != rune('a') {
-						goto l1221
+						goto l1959
 					}
 					position++
-					goto l1220
-				l1221:
-					position, tokenIndex = position1220, tokenIndex1220
+					goto l1958
+				l1959:
+					position, tokenIndex = position1958, tokenIndex1958
 					if buffer[position] != rune('A') {
-						goto l1216
+						goto l1954
 					}
 					position++
 				}
-			l1220:
+			l1958:
 				{
-					position1222, tokenIndex1222 := position, tokenIndex
+					position1960, tokenIndex1960 := position, tokenIndex
 					if buffer[position] != rune('s') {
-						goto l1223
+						goto l1961
 					}
 					position++
-					goto l1222
-				l1223:
-					position, tokenIndex = position1222, tokenIndex1222
+					goto l1960
+				l1961:
+					position, tokenIndex = position1960, tokenIndex1960
 					if buffer[position] != rune('S') {
-						goto l1216
+						goto l1954
 					}
 					position++
 				}
-			l1222:
+			l1960:
 				{
-					position1224, tokenIndex1224 := position, tokenIndex
+					position1962, tokenIndex1962 := position, tokenIndex
 					if buffer[position] != rune('e') {
-						goto l1225
+						goto l1963
 					}
 					position++
-					goto l1224
-				l1225:
-					position, tokenIndex = position1224, tokenIndex1224
+					goto l1962
+				l1963:
+					position, tokenIndex = position1962, tokenIndex1962
 					if buffer[position] != rune('E') {
-						goto l1216
+						goto l1954
 					}
 					position++
 				}
-			l1224:
+			l1962:
 				{
-					position1226 := position
+					position1964 := position
 					if !_rules[rulesp]() {
-						goto l1216
+						goto l1954
 					}
 					if !_rules[ruleWhenThenPair]() {
-						goto l1216
+						goto l1954
 					}
-				l1227:
+				l1965:
 					{
-						position1228, tokenIndex1228 := position, tokenIndex
+						position1966, tokenIndex1966 := position, tokenIndex
 						if !_rules[rulesp]() {
-							goto l1228
+							goto l1966
 						}
 						if !_rules[ruleWhenThenPair]() {
-							goto l1228
+							goto l1966
 						}
-						goto l1227
-					l1228:
-						position, tokenIndex = position1228, tokenIndex1228
+						goto l1965
+					l1966:
+						position, tokenIndex = position1966, tokenIndex1966
 					}
 					{
-						position1229, tokenIndex1229 := position, tokenIndex
+						position1967, tokenIndex1967 := position, tokenIndex
 						if !_rules[rulesp]() {
-							goto l1229
+							goto l1967
 						}
 						{
-							position1231, tokenIndex1231 := position, tokenIndex
+							position1969, tokenIndex1969 := position, tokenIndex
 							if buffer[position] != rune('e') {
-								goto l1232
+								goto l1970
 							}
 							position++
-							goto l1231
-						l1232:
-							position, tokenIndex = position1231, tokenIndex1231
+							goto l1969
+						l1970:
+							position, tokenIndex = position1969, tokenIndex1969
 							if buffer[position] != rune('E') {
-								goto l1229
+								goto l1967
 							}
 							position++
 						}
-					l1231:
+					l1969:
 						{
-							position1233, tokenIndex1233 := position, tokenIndex
+							position1971, tokenIndex1971 := position, tokenIndex
 							if buffer[position] != rune('l') {
-								goto l1234
+								goto l1972
 							}
 							position++
-							goto l1233
-						l1234:
-							position, tokenIndex = position1233, tokenIndex1233
+							goto l1971
+						l1972:
+							position, tokenIndex = position1971, tokenIndex1971
 							if buffer[position] != rune('L') {
-								goto l1229
+								goto l1967
 							}
 							position++
 						}
-					l1233:
+					l1971:
 						{
-							position1235, tokenIndex1235 := position, tokenIndex
+							position1973, tokenIndex1973 := position, tokenIndex
 							if buffer[position] != rune('s') {
-								goto l1236
+								goto l1974
 							}
 							position++
-							goto l1235
-						l1236:
-							position, tokenIndex = position1235, tokenIndex1235
+							goto l1973
+						l1974:
+							position, tokenIndex = position1973, tokenIndex1973
 							if buffer[position] != rune('S') {
-								goto l1229
+								goto l1967
 							}
 							position++
 						}
-					l1235:
+					l1973:
 						{
-							position1237, tokenIndex1237 := position, tokenIndex
+							position1975, tokenIndex1975 := position, tokenIndex
 							if buffer[position] != rune('e') {
-								goto l1238
+								goto l1976
 							}
 							position++
-							goto l1237
-						l1238:
-							position, tokenIndex = position1237, tokenIndex1237
+							goto l1975
+						l1976:
+							position, tokenIndex = position1975, tokenIndex1975
 							if buffer[position] != rune('E') {
-								goto l1229
+								goto l1967
 							}
 							position++
 						}
-					l1237:
+					l1975:
 						if !_rules[rulesp]() {
-							goto l1229
+							goto l1967
 						}
 						if !_rules[ruleExpression]() {
-							goto l1229
+							goto l1967
 						}
-						goto l1230
-					l1229:
-						position, tokenIndex = position1229, tokenIndex1229
+						goto l1968
+					l1967:
+						position, tokenIndex = position1967, tokenIndex1967
 					}
-				l1230:
+				l1968:
 					if !_rules[rulesp]() {
-						goto l1216
+						goto l1954
 					}
 					{
-						position1239, tokenIndex1239 := position, tokenIndex
+						position1977, tokenIndex1977 := position, tokenIndex
 						if buffer[position] != rune('e') {
-							goto l1240
+							goto l1978
 						}
 						position++
-						goto l1239
-					l1240:
-						position, tokenIndex = position1239, tokenIndex1239
+						goto l1977
+					l1978:
+						position, tokenIndex = position1977, tokenIndex1977
 						if buffer[position] != rune('E') {
-							goto l1216
+							goto l1954
 						}
 						position++
 					}
-				l1239:
+				l1977:
 					{
-						position1241, tokenIndex1241 := position, tokenIndex
+						position1979, tokenIndex1979 := position, tokenIndex
 						if buffer[position] != rune('n') {
-							goto l1242
+							goto l1980
 						}
 						position++
-						goto l1241
-					l1242:
-						position, tokenIndex = position1241, tokenIndex1241
+						goto l1979
+					l1980:
+						position, tokenIndex = position1979, tokenIndex1979
 						if buffer[position] != rune('N') {
-							goto l1216
+							goto l1954
 						}
 						position++
 					}
-				l1241:
+				l1979:
 					{
-						position1243, tokenIndex1243 := position, tokenIndex
+						position1981, tokenIndex1981 := position, tokenIndex
 						if buffer[position] != rune('d') {
-							goto l1244
+							goto l1982
 						}
 						position++
-						goto l1243
-					l1244:
-						position, tokenIndex = position1243, tokenIndex1243
+						goto l1981
+					l1982:
+						position, tokenIndex = position1981, tokenIndex1981
 						if buffer[position] != rune('D') {
-							goto l1216
+							goto l1954
 						}
 						position++
 					}
-				l1243:
-					add(rulePegText, position1226)
+				l1981:
+					add(rulePegText, position1964)
 				}
-				if !_rules[ruleAction76]() {
-					goto l1216
+				if !_rules[ruleAction103]() {
+					goto l1954
 				}
-				add(ruleConditionCase, position1217)
+				add(ruleConditionCase, position1955)
 			}
 			return true
-		l1216:
-			position, tokenIndex = position1216, tokenIndex1216
+		l1954:
+			position, tokenIndex = position1954, tokenIndex1954
 			return false
 		},
-		/* 99 ExpressionCase <- <(('c' / 'C') ('a' / 'A') ('s' / 'S') ('e' / 'E') sp Expression <((sp WhenThenPair)+ (sp (('e' / 'E') ('l' / 'L') ('s' / 'S') ('e' / 'E')) sp Expression)? sp (('e' / 'E') ('n' / 'N') ('d' / 'D')))> Action77)> */
+		/* 130 ExpressionCase <- <(('c' / 'C') ('a' / 'A') ('s' / 'S') ('e' / 'E') sp Expression <((sp WhenThenPair)+ (sp (('e' / 'E') ('l' / 'L') ('s' / 'S') ('e' / 'E')) sp Expression)? sp (('e' / 'E') ('n' / 'N') ('d' / 'D')))> Action104)> */
 		func() bool {
-			position1245, tokenIndex1245 := position, tokenIndex
+			position1983, tokenIndex1983 := position, tokenIndex
 			{
-				position1246 := position
+				position1984 := position
 				{
-					position1247, tokenIndex1247 := position, tokenIndex
+					position1985, tokenIndex1985 := position, tokenIndex
 					if buffer[position] != rune('c') {
-						goto l1248
+						goto l1986
 					}
 					position++
-					goto l1247
-				l1248:
-					position, tokenIndex = position1247, tokenIndex1247
+					goto l1985
+				l1986:
+					position, tokenIndex = position1985, tokenIndex1985
 					if buffer[position] != rune('C') {
-						goto l1245
+						goto l1983
 					}
 					position++
 				}
-			l1247:
+			l1985:
 				{
-					position1249, tokenIndex1249 := position, tokenIndex
+					position1987, tokenIndex1987 := position, tokenIndex
 					if buffer[position] != rune('a') {
-						goto l1250
+						goto l1988
 					}
 					position++
-					goto l1249
-				l1250:
-					position, tokenIndex = position1249, tokenIndex1249
+					goto l1987
+				l1988:
+					position, tokenIndex = position1987, tokenIndex1987
 					if buffer[position] != rune('A') {
-						goto l1245
+						goto l1983
 					}
 					position++
 				}
-			l1249:
+			l1987:
 				{
-					position1251, tokenIndex1251 := position, tokenIndex
+					position1989, tokenIndex1989 := position, tokenIndex
 					if buffer[position] != rune('s') {
-						goto l1252
+						goto l1990
 					}
 					position++
-					goto l1251
-				l1252:
-					position, tokenIndex = position1251, tokenIndex1251
+					goto l1989
+				l1990:
+					position, tokenIndex = position1989, tokenIndex1989
 					if buffer[position] != rune('S') {
-						goto l1245
+						goto l1983
 					}
 					position++
 				}
-			l1251:
+			l1989:
 				{
-					position1253, tokenIndex1253 := position, tokenIndex
+					position1991, tokenIndex1991 := position, tokenIndex
 					if buffer[position] != rune('e') {
-						goto l1254
+						goto l1992
 					}
 					position++
-					goto l1253
-				l1254:
-					position, tokenIndex = position1253, tokenIndex1253
+					goto l1991
+				l1992:
+					position, tokenIndex = position1991, tokenIndex1991
 					if buffer[position] != rune('E') {
-						goto l1245
+						goto l1983
 					}
 					position++
 				}
-			l1253:
+			l1991:
 				if !_rules[rulesp]() {
-					goto l1245
+					goto l1983
 				}
 				if !_rules[ruleExpression]() {
-					goto l1245
+					goto l1983
 				}
 				{
-					position1255 := position
+					position1993 := position
 					if !_rules[rulesp]() {
-						goto l1245
+						goto l1983
 					}
 					if !_rules[ruleWhenThenPair]() {
-						goto l1245
+						goto l1983
 					}
-				l1256:
+				l1994:
 					{
-						position1257, tokenIndex1257 := position, tokenIndex
+						position1995, tokenIndex1995 := position, tokenIndex
 						if !_rules[rulesp]() {
-							goto l1257
+							goto l1995
 						}
 						if !_rules[ruleWhenThenPair]() {
-							goto l1257
+							goto l1995
 						}
-						goto l1256
-					l1257:
-						position, tokenIndex = position1257, tokenIndex1257
+						goto l1994
+					l1995:
+						position, tokenIndex = position1995, tokenIndex1995
 					}
 					{
-						position1258, tokenIndex1258 := position, tokenIndex
+						position1996, tokenIndex1996 := position, tokenIndex
 						if !_rules[rulesp]() {
-							goto l1258
+							goto l1996
 						}
 						{
-							position1260, tokenIndex1260 := position, tokenIndex
+							position1998, tokenIndex1998 := position, tokenIndex
 							if buffer[position] != rune('e') {
-								goto l1261
+								goto l1999
 							}
 							position++
-							goto l1260
-						l1261:
-							position, tokenIndex = position1260, tokenIndex1260
+							goto l1998
+						l1999:
+							position, tokenIndex = position1998, tokenIndex1998
 							if buffer[position] != rune('E') {
-								goto l1258
+								goto l1996
 							}
 							position++
 						}
-					l1260:
+					l1998:
 						{
-							position1262, tokenIndex1262 := position, tokenIndex
+							position2000, tokenIndex2000 := position, tokenIndex
 							if buffer[position] != rune('l') {
-								goto l1263
+								goto l2001
 							}
 							position++
-							goto l1262
-						l1263:
-							position, tokenIndex = position1262, tokenIndex1262
+							goto l2000
+						l2001:
+							position, tokenIndex = position2000, tokenIndex2000
 							if buffer[position] != rune('L') {
-								goto l1258
+								goto l1996
 							}
 							position++
 						}
-					l1262:
+					l2000:
 						{
-							position1264, tokenIndex1264 := position, tokenIndex
+							position2002, tokenIndex2002 := position, tokenIndex
 							if buffer[position] != rune('s') {
-								goto l1265
+								goto l2003
 							}
 							position++
-							goto l1264
-						l1265:
-							position, tokenIndex = position1264, tokenIndex1264
+							goto l2002
+						l2003:
+							position, tokenIndex = position2002, tokenIndex2002
 							if buffer[position] != rune('S') {
-								goto l1258
+								goto l1996
 							}
 							position++
 						}
-					l1264:
+					l2002:
 						{
-							position1266, tokenIndex1266 := position, tokenIndex
+							position2004, tokenIndex2004 := position, tokenIndex
 							if buffer[position] != rune('e') {
-								goto l1267
+								goto l2005
 							}
 							position++
-							goto l1266
-						l1267:
-							position, tokenIndex = position1266, tokenIndex1266
+							goto l2004
+						l2005:
+							position, tokenIndex = position2004, tokenIndex2004
 							if buffer[position] != rune('E') {
-								goto l1258
+								goto l1996
 							}
 							position++
 						}
-					l1266:
+					l2004:
 						if !_rules[rulesp]() {
-							goto l1258
+							goto l1996
 						}
 						if !_rules[ruleExpression]() {
-							goto l1258
+							goto l1996
 						}
-						goto l1259
-					l1258:
-						position, tokenIndex = position1258, tokenIndex1258
+						goto l1997
+					l1996:
+						position, tokenIndex = position1996, tokenIndex1996
 					}
-				l1259:
+				l1997:
 					if !_rules[rulesp]() {
-						goto l1245
+						goto l1983
 					}
 					{
-						position1268, tokenIndex1268 := position, tokenIndex
+						position2006, tokenIndex2006 := position, tokenIndex
 						if buffer[position] != rune('e') {
-							goto l1269
+							goto l2007
 						}
 						position++
-						goto l1268
-					l1269:
-						position, tokenIndex = position1268, tokenIndex1268
+						goto l2006
+					l2007:
+						position, tokenIndex = position2006, tokenIndex2006
 						if buffer[position] != rune('E') {
-							goto l1245
+							goto l1983
 						}
 						position++
 					}
-				l1268:
+				l2006:
 					{
-						position1270, tokenIndex1270 := position, tokenIndex
+						position2008, tokenIndex2008 := position, tokenIndex
 						if buffer[position] != rune('n') {
-							goto l1271
+							goto l2009
 						}
 						position++
-						goto l1270
-					l1271:
-						position, tokenIndex = position1270, tokenIndex1270
+						goto l2008
+					l2009:
+						position, tokenIndex = position2008, tokenIndex2008
 						if buffer[position] != rune('N') {
-							goto l1245
+							goto l1983
 						}
 						position++
 					}
-				l1270:
+				l2008:
 					{
-						position1272, tokenIndex1272 := position, tokenIndex
+						position2010, tokenIndex2010 := position, tokenIndex
 						if buffer[position] != rune('d') {
-							goto l1273
+							goto l2011
 						}
 						position++
-						goto l1272
-					l1273:
-						position, tokenIndex = position1272, tokenIndex1272
+						goto l2010
+					l2011:
+						position, tokenIndex = position2010, tokenIndex2010
 						if buffer[position] != rune('D') {
-							goto l1245
+							goto l1983
 						}
 						position++
 					}
-				l1272:
-					add(rulePegText, position1255)
+				l2010:
+					add(rulePegText, position1993)
 				}
-				if !_rules[ruleAction77]() {
-					goto l1245
+				if !_rules[ruleAction104]() {
+					goto l1983
 				}
-				add(ruleExpressionCase, position1246)
+				add(ruleExpressionCase, position1984)
 			}
 			return true
-		l1245:
-			position, tokenIndex = position1245, tokenIndex1245
+		l1983:
+			position, tokenIndex = position1983, tokenIndex1983
 			return false
 		},
-		/* 100 WhenThenPair <- <(('w' / 'W') ('h' / 'H') ('e' / 'E') ('n' / 'N') sp Expression sp (('t' / 'T') ('h' / 'H') ('e' / 'E') ('n' / 'N')) sp ExpressionOrWildcard Action78)> */
+		/* 131 WhenThenPair <- <(('w' / 'W') ('h' / 'H') ('e' / 'E') ('n' / 'N') sp Expression sp (('t' / 'T') ('h' / 'H') ('e' / 'E') ('n' / 'N')) sp ExpressionOrWildcard Action105)> */
 		func() bool {
-			position1274, tokenIndex1274 := position, tokenIndex
+			position2012, tokenIndex2012 := position, tokenIndex
 			{
-				position1275 := position
+				position2013 := position
 				{
-					position1276, tokenIndex1276 := position, tokenIndex
+					position2014, tokenIndex2014 := position, tokenIndex
 					if buffer[position] != rune('w') {
-						goto l1277
+						goto l2015
 					}
 					position++
-					goto l1276
-				l1277:
-					position, tokenIndex = position1276, tokenIndex1276
+					goto l2014
+				l2015:
+					position, tokenIndex = position2014, tokenIndex2014
 					if buffer[position] != rune('W') {
-						goto l1274
+						goto l2012
 					}
 					position++
 				}
-			l1276:
+			l2014:
 				{
-					position1278, tokenIndex1278 := position, tokenIndex
+					position2016, tokenIndex2016 := position, tokenIndex
 					if buffer[position] != rune('h') {
-						goto l1279
+						goto l2017
 					}
 					position++
-					goto l1278
-				l1279:
-					position, tokenIndex = position1278, tokenIndex1278
+					goto l2016
+				l2017:
+					position, tokenIndex = position2016, tokenIndex2016
 					if buffer[position] != rune('H') {
-						goto l1274
+						goto l2012
 					}
 					position++
 				}
-			l1278:
+			l2016:
 				{
-					position1280, tokenIndex1280 := position, tokenIndex
+					position2018, tokenIndex2018 := position, tokenIndex
 					if buffer[position] != rune('e') {
-						goto l1281
+						goto l2019
 					}
 					position++
-					goto l1280
-				l1281:
-					position, tokenIndex = position1280, tokenIndex1280
+					goto l2018
+				l2019:
+					position, tokenIndex = position2018, tokenIndex2018
 					if buffer[position] != rune('E') {
-						goto l1274
+						goto l2012
 					}
 					position++
 				}
-			l1280:
+			l2018:
 				{
-					position1282, tokenIndex1282 := position, tokenIndex
+					position2020, tokenIndex2020 := position, tokenIndex
 					if buffer[position] != rune('n') {
-						goto l1283
+						goto l2021
 					}
 					position++
-					goto l1282
-				l1283:
-					position, tokenIndex = position1282, tokenIndex1282
+					goto l2020
+				l2021:
+					position, tokenIndex = position2020, tokenIndex2020
 					if buffer[position] != rune('N') {
-						goto l1274
+						goto l2012
 					}
 					position++
 				}
-			l1282:
+			l2020:
 				if !_rules[rulesp]() {
-					goto l1274
+					goto l2012
 				}
 				if !_rules[ruleExpression]() {
-					goto l1274
+					goto l2012
 				}
 				if !_rules[rulesp]() {
-					goto l1274
+					goto l2012
 				}
 				{
-					position1284, tokenIndex1284 := position, tokenIndex
+					position2022, tokenIndex2022 := position, tokenIndex
 					if buffer[position] != rune('t') {
-						goto l1285
+						goto l2023
 					}
 					position++
-					goto l1284
-				l1285:
-					position, tokenIndex = position1284, tokenIndex1284
+					goto l2022
+				l2023:
+					position, tokenIndex = position2022, tokenIndex2022
 					if buffer[position] != rune('T') {
-						goto l1274
+						goto l2012
 					}
 					position++
 				}
-			l1284:
+			l2022:
 				{
-					position1286, tokenIndex1286 := position, tokenIndex
+					position2024, tokenIndex2024 := position, tokenIndex
 					if buffer[position] != rune('h') {
-						goto l1287
+						goto l2025
 					}
 					position++
-					goto l1286
-				l1287:
-					position, tokenIndex = position1286, tokenIndex1286
+					goto l2024
+				l2025:
+					position, tokenIndex = position2024, tokenIndex2024
 					if buffer[position] != rune('H') {
-						goto l1274
+						goto l2012
 					}
 					position++
 				}
-			l1286:
+			l2024:
 				{
-					position1288, tokenIndex1288 := position, tokenIndex
+					position2026, tokenIndex2026 := position, tokenIndex
 					if buffer[position] != rune('e') {
-						goto l1289
+						goto l2027
 					}
 					position++
-					goto l1288
-				l1289:
-					position, tokenIndex = position1288, tokenIndex1288
+					goto l2026
+				l2027:
+					position, tokenIndex = position2026, tokenIndex2026
 					if buffer[position] != rune('E') {
-						goto l1274
+						goto l2012
 					}
 					position++
 				}
-			l1288:
+			l2026:
 				{
-					position1290, tokenIndex1290 := position, tokenIndex
+					position2028, tokenIndex2028 := position, tokenIndex
 					if buffer[position] != rune('n') {
-						goto l1291
+						goto l2029
 					}
 					position++
-					goto l1290
-				l1291:
-					position, tokenIndex = position1290, tokenIndex1290
+					goto l2028
+				l2029:
+					position, tokenIndex = position2028, tokenIndex2028
 					if buffer[position] != rune('N') {
-						goto l1274
+						goto l2012
 					}
 					position++
 				}
-			l1290:
+			l2028:
 				if !_rules[rulesp]() {
-					goto l1274
+					goto l2012
 				}
 				if !_rules[ruleExpressionOrWildcard]() {
-					goto l1274
+					goto l2012
 				}
-				if !_rules[ruleAction78]() {
-					goto l1274
+				if !_rules[ruleAction105]() {
+					goto l2012
 				}
-				add(ruleWhenThenPair, position1275)
+				add(ruleWhenThenPair, position2013)
 			}
 			return true
-		l1274:
-			position, tokenIndex = position1274, tokenIndex1274
+		l2012:
+			position, tokenIndex = position2012, tokenIndex2012
 			return false
 		},
-		/* 101 Literal <- <(FloatLiteral / NumericLiteral / StringLiteral)> */
+		/* 132 Literal <- <(FloatLiteral / NumericLiteral / StringLiteral)> */
 		func() bool {
-			position1292, tokenIndex1292 := position, tokenIndex
+			position2030, tokenIndex2030 := position, tokenIndex
 			{
-				position1293 := position
+				position2031 := position
 				{
-					position1294, tokenIndex1294 := position, tokenIndex
+					position2032, tokenIndex2032 := position, tokenIndex
 					if !_rules[ruleFloatLiteral]() {
-						goto l1295
+						goto l2033
 					}
-					goto l1294
-				l1295:
-					position, tokenIndex = position1294, tokenIndex1294
+					goto l2032
+				l2033:
+					position, tokenIndex = position2032, tokenIndex2032
 					if !_rules[ruleNumericLiteral]() {
-						goto l1296
+						goto l2034
 					}
-					goto l1294
-				l1296:
-					position, tokenIndex = position1294, tokenIndex1294
+					goto l2032
+				l2034:
+					position, tokenIndex = position2032, tokenIndex2032
 					if !_rules[ruleStringLiteral]() {
-						goto l1292
+						goto l2030
 					}
 				}
-			l1294:
-				add(ruleLiteral, position1293)
+			l2032:
+				add(ruleLiteral, position2031)
 			}
 			return true
-		l1292:
-			position, tokenIndex = position1292, tokenIndex1292
+		l2030:
+			position, tokenIndex = position2030, tokenIndex2030
 			return false
 		},
-		/* 102 ComparisonOp <- <(Equal / NotEqual / LessOrEqual / Less / GreaterOrEqual / Greater / NotEqual)> */
+		/* 133 ComparisonOp <- <(Equal / NotEqual / LessOrEqual / Less / GreaterOrEqual / Greater / NotEqual)> */
 		func() bool {
-			position1297, tokenIndex1297 := position, tokenIndex
+			position2035, tokenIndex2035 := position, tokenIndex
 			{
-				position1298 := position
+				position2036 := position
 				{
-					position1299, tokenIndex1299 := position, tokenIndex
+					position2037, tokenIndex2037 := position, tokenIndex
 					if !_rules[ruleEqual]() {
-						goto l1300
+						goto l2038
 					}
-					goto l1299
-				l1300:
-					position, tokenIndex = position1299, tokenIndex1299
+					goto l2037
+				l2038:
+					position, tokenIndex = position2037, tokenIndex2037
 					if !_rules[ruleNotEqual]() {
-						goto l1301
+						goto l2039
 					}
-					goto l1299
-				l1301:
-					position, tokenIndex = position1299, tokenIndex1299
+					goto l2037
+				l2039:
+					position, tokenIndex = position2037, tokenIndex2037
 					if !_rules[ruleLessOrEqual]() {
-						goto l1302
+						goto l2040
 					}
-					goto l1299
-				l1302:
-					position, tokenIndex = position1299, tokenIndex1299
+					goto l2037
+				l2040:
+					position, tokenIndex = position2037, tokenIndex2037
 					if !_rules[ruleLess]() {
-						goto l1303
+						goto l2041
 					}
-					goto l1299
-				l1303:
-					position, tokenIndex = position1299, tokenIndex1299
+					goto l2037
+				l2041:
+					position, tokenIndex = position2037, tokenIndex2037
 					if !_rules[ruleGreaterOrEqual]() {
-						goto l1304
+						goto l2042
 					}
-					goto l1299
-				l1304:
-					position, tokenIndex = position1299, tokenIndex1299
+					goto l2037
+				l2042:
+					position, tokenIndex = position2037, tokenIndex2037
 					if !_rules[ruleGreater]() {
-						goto l1305
+						goto l2043
 					}
-					goto l1299
-				l1305:
-					position, tokenIndex = position1299, tokenIndex1299
+					goto l2037
+				l2043:
+					position, tokenIndex = position2037, tokenIndex2037
 					if !_rules[ruleNotEqual]() {
-						goto l1297
+						goto l2035
 					}
 				}
-			l1299:
-				add(ruleComparisonOp, position1298)
+			l2037:
+				add(ruleComparisonOp, position2036)
 			}
 			return true
-		l1297:
-			position, tokenIndex = position1297, tokenIndex1297
+		l2035:
+			position, tokenIndex = position2035, tokenIndex2035
 			return false
 		},
-		/* 103 OtherOp <- <Concat> */
+		/* 134 OtherOp <- <Concat> */
 		func() bool {
-			position1306, tokenIndex1306 := position, tokenIndex
+			position2044, tokenIndex2044 := position, tokenIndex
 			{
-				position1307 := position
+				position2045 := position
 				if !_rules[ruleConcat]() {
-					goto l1306
+					goto l2044
 				}
-				add(ruleOtherOp, position1307)
+				add(ruleOtherOp, position2045)
 			}
 			return true
-		l1306:
-			position, tokenIndex = position1306, tokenIndex1306
+		l2044:
+			position, tokenIndex = position2044, tokenIndex2044
 			return false
 		},
-		/* 104 IsOp <- <(IsNot / Is)> */
+		/* 135 IsOp <- <(IsNot / Is)> */
 		func() bool {
-			position1308, tokenIndex1308 := position, tokenIndex
+			position2046, tokenIndex2046 := position, tokenIndex
 			{
-				position1309 := position
+				position2047 := position
 				{
-					position1310, tokenIndex1310 := position, tokenIndex
+					position2048, tokenIndex2048 := position, tokenIndex
 					if !_rules[ruleIsNot]() {
-						goto l1311
+						goto l2049
 					}
-					goto l1310
-				l1311:
-					position, tokenIndex = position1310, tokenIndex1310
+					goto l2048
+				l2049:
+					position, tokenIndex = position2048, tokenIndex2048
 					if !_rules[ruleIs]() {
-						goto l1308
+						goto l2046
+					}
+				}
+			l2048:
+				add(ruleIsOp, position2047)
+			}
+			return true
+		l2046:
+			position, tokenIndex = position2046, tokenIndex2046
+			return false
+		},
+		/* 136 IsDistinctOp <- <(IsNotDistinctFrom / IsDistinctFrom)> */
+		func() bool {
+			position2050, tokenIndex2050 := position, tokenIndex
+			{
+				position2051 := position
+				{
+					position2052, tokenIndex2052 := position, tokenIndex
+					if !_rules[ruleIsNotDistinctFrom]() {
+						goto l2053
+					}
+					goto l2052
+				l2053:
+					position, tokenIndex = position2052, tokenIndex2052
+					if !_rules[ruleIsDistinctFrom]() {
+						goto l2050
 					}
 				}
-			l1310:
-				add(ruleIsOp, position1309)
+			l2052:
+				add(ruleIsDistinctOp, position2051)
 			}
 			return true
-		l1308:
-			position, tokenIndex = position1308, tokenIndex1308
+		l2050:
+			position, tokenIndex = position2050, tokenIndex2050
 			return false
 		},
-		/* 105 PlusMinusOp <- <(Plus / Minus)> */
+		/* 137 PlusMinusOp <- <(Plus / Minus)> */
 		func() bool {
-			position1312, tokenIndex1312 := position, tokenIndex
+			position2054, tokenIndex2054 := position, tokenIndex
 			{
-				position1313 := position
+				position2055 := position
 				{
-					position1314, tokenIndex1314 := position, tokenIndex
+					position2056, tokenIndex2056 := position, tokenIndex
 					if !_rules[rulePlus]() {
-						goto l1315
+						goto l2057
 					}
-					goto l1314
-				l1315:
-					position, tokenIndex = position1314, tokenIndex1314
+					goto l2056
+				l2057:
+					position, tokenIndex = position2056, tokenIndex2056
 					if !_rules[ruleMinus]() {
-						goto l1312
+						goto l2054
 					}
 				}
-			l1314:
-				add(rulePlusMinusOp, position1313)
+			l2056:
+				add(rulePlusMinusOp, position2055)
 			}
 			return true
-		l1312:
-			position, tokenIndex = position1312, tokenIndex1312
+		l2054:
+			position, tokenIndex = position2054, tokenIndex2054
 			return false
 		},
-		/* 106 MultDivOp <- <(Multiply / Divide / Modulo)> */
+		/* 138 MultDivOp <- <(Multiply / Divide / Modulo)> */
 		func() bool {
-			position1316, tokenIndex1316 := position, tokenIndex
+			position2058, tokenIndex2058 := position, tokenIndex
 			{
-				position1317 := position
+				position2059 := position
 				{
-					position1318, tokenIndex1318 := position, tokenIndex
+					position2060, tokenIndex2060 := position, tokenIndex
 					if !_rules[ruleMultiply]() {
-						goto l1319
+						goto l2061
 					}
-					goto l1318
-				l1319:
-					position, tokenIndex = position1318, tokenIndex1318
+					goto l2060
+				l2061:
+					position, tokenIndex = position2060, tokenIndex2060
 					if !_rules[ruleDivide]() {
-						goto l1320
+						goto l2062
 					}
-					goto l1318
-				l1320:
-					position, tokenIndex = position1318, tokenIndex1318
+					goto l2060
+				l2062:
+					position, tokenIndex = position2060, tokenIndex2060
 					if !_rules[ruleModulo]() {
-						goto l1316
+						goto l2058
 					}
 				}
-			l1318:
-				add(ruleMultDivOp, position1317)
+			l2060:
+				add(ruleMultDivOp, position2059)
 			}
 			return true
-		l1316:
-			position, tokenIndex = position1316, tokenIndex1316
+		l2058:
+			position, tokenIndex = position2058, tokenIndex2058
 			return false
 		},
-		/* 107 Stream <- <(<ident> Action79)> */
+		/* 139 Stream <- <(<ident> Action106)> */
 		func() bool {
-			position1321, tokenIndex1321 := position, tokenIndex
+			position2063, tokenIndex2063 := position, tokenIndex
 			{
-				position1322 := position
+				position2064 := position
 				{
-					position1323 := position
+					position2065 := position
 					if !_rules[ruleident]() {
-						goto l1321
+						goto l2063
 					}
-					add(rulePegText, position1323)
+					add(rulePegText, position2065)
 				}
-				if !_rules[ruleAction79]() {
-					goto l1321
+				if !_rules[ruleAction106]() {
+					goto l2063
 				}
-				add(ruleStream, position1322)
+				add(ruleStream, position2064)
 			}
 			return true
-		l1321:
-			position, tokenIndex = position1321, tokenIndex1321
+		l2063:
+			position, tokenIndex = position2063, tokenIndex2063
 			return false
 		},
-		/* 108 RowMeta <- <RowTimestamp> */
+		/* 140 RowMeta <- <(RowTimestamp / RowTupleID / RowBackfill)> */
 		func() bool {
-			position1324, tokenIndex1324 := position, tokenIndex
+			position2066, tokenIndex2066 := position, tokenIndex
 			{
-				position1325 := position
-				if !_rules[ruleRowTimestamp]() {
-					goto l1324
+				position2067 := position
+				{
+					position2068, tokenIndex2068 := position, tokenIndex
+					if !_rules[ruleRowTimestamp]() {
+						goto l2069
+					}
+					goto l2068
+				l2069:
+					position, tokenIndex = position2068, tokenIndex2068
+					if !_rules[ruleRowTupleID]() {
+						goto l2070
+					}
+					goto l2068
+				l2070:
+					position, tokenIndex = position2068, tokenIndex2068
+					if !_rules[ruleRowBackfill]() {
+						goto l2066
+					}
 				}
-				add(ruleRowMeta, position1325)
+			l2068:
+				add(ruleRowMeta, position2067)
 			}
 			return true
-		l1324:
-			position, tokenIndex = position1324, tokenIndex1324
+		l2066:
+			position, tokenIndex = position2066, tokenIndex2066
 			return false
 		},
-		/* 109 RowTimestamp <- <(<((ident ':')? ('t' 's' '(' ')'))> Action80)> */
+		/* 141 RowTimestamp <- <(<((ident ':')? ('t' 's' '(' ')'))> Action107)> */
 		func() bool {
-			position1326, tokenIndex1326 := position, tokenIndex
+			position2071, tokenIndex2071 := position, tokenIndex
 			{
-				position1327 := position
+				position2072 := position
 				{
-					position1328 := position
+					position2073 := position
 					{
-						position1329, tokenIndex1329 := position, tokenIndex
+						position2074, tokenIndex2074 := position, tokenIndex
 						if !_rules[ruleident]() {
-							goto l1329
+							goto l2074
 						}
 						if buffer[position] != rune(':') {
-							goto l1329
+							goto l2074
+						}
+						position++
+						goto l2075
+					l2074:
+						position, tokenIndex = position2074, tokenIndex2074
+					}
+				l2075:
+					if buffer[position] != rune('t') {
+						goto l2071
+					}
+					position++
+					if buffer[position] != rune('s') {
+						goto l2071
+					}
+					position++
+					if buffer[position] != rune('(') {
+						goto l2071
+					}
+					position++
+					if buffer[position] != rune(')') {
+						goto l2071
+					}
+					position++
+					add(rulePegText, position2073)
+				}
+				if !_rules[ruleAction107]() {
+					goto l2071
+				}
+				add(ruleRowTimestamp, position2072)
+			}
+			return true
+		l2071:
+			position, tokenIndex = position2071, tokenIndex2071
+			return false
+		},
+		/* 142 RowTupleID <- <(<((ident ':')? ('t' 'u' 'p' 'l' 'e' '_' 'i' 'd' '(' ')'))> Action108)> */
+		func() bool {
+			position2076, tokenIndex2076 := position, tokenIndex
+			{
+				position2077 := position
+				{
+					position2078 := position
+					{
+						position2079, tokenIndex2079 := position, tokenIndex
+						if !_rules[ruleident]() {
+							goto l2079
+						}
+						if buffer[position] != rune(':') {
+							goto l2079
+						}
+						position++
+						goto l2080
+					l2079:
+						position, tokenIndex = position2079, tokenIndex2079
+					}
+				l2080:
+					if buffer[position] != rune('t') {
+						goto l2076
+					}
+					position++
+					if buffer[position] != rune('u') {
+						goto l2076
+					}
+					position++
+					if buffer[position] != rune('p') {
+						goto l2076
+					}
+					position++
+					if buffer[position] != rune('l') {
+						goto l2076
+					}
+					position++
+					if buffer[position] != rune('e') {
+						goto l2076
+					}
+					position++
+					if buffer[position] != rune('_') {
+						goto l2076
+					}
+					position++
+					if buffer[position] != rune('i') {
+						goto l2076
+					}
+					position++
+					if buffer[position] != rune('d') {
+						goto l2076
+					}
+					position++
+					if buffer[position] != rune('(') {
+						goto l2076
+					}
+					position++
+					if buffer[position] != rune(')') {
+						goto l2076
+					}
+					position++
+					add(rulePegText, position2078)
+				}
+				if !_rules[ruleAction108]() {
+					goto l2076
+				}
+				add(ruleRowTupleID, position2077)
+			}
+			return true
+		l2076:
+			position, tokenIndex = position2076, tokenIndex2076
+			return false
+		},
+		/* 143 RowBackfill <- <(<((ident ':')? ('i' 's' '_' 'b' 'a' 'c' 'k' 'f' 'i' 'l' 'l' '(' ')'))> Action109)> */
+		func() bool {
+			position2081, tokenIndex2081 := position, tokenIndex
+			{
+				position2082 := position
+				{
+					position2083 := position
+					{
+						position2084, tokenIndex2084 := position, tokenIndex
+						if !_rules[ruleident]() {
+							goto l2084
+						}
+						if buffer[position] != rune(':') {
+							goto l2084
 						}
 						position++
-						goto l1330
-					l1329:
-						position, tokenIndex = position1329, tokenIndex1329
+						goto l2085
+					l2084:
+						position, tokenIndex = position2084, tokenIndex2084
 					}
-				l1330:
-					if buffer[position] != rune('t') {
-						goto l1326
+				l2085:
+					if buffer[position] != rune('i') {
+						goto l2081
 					}
 					position++
 					if buffer[position] != rune('s') {
-						goto l1326
+						goto l2081
+					}
+					position++
+					if buffer[position] != rune('_') {
+						goto l2081
+					}
+					position++
+					if buffer[position] != rune('b') {
+						goto l2081
+					}
+					position++
+					if buffer[position] != rune('a') {
+						goto l2081
+					}
+					position++
+					if buffer[position] != rune('c') {
+						goto l2081
+					}
+					position++
+					if buffer[position] != rune('k') {
+						goto l2081
+					}
+					position++
+					if buffer[position] != rune('f') {
+						goto l2081
+					}
+					position++
+					if buffer[position] != rune('i') {
+						goto l2081
+					}
+					position++
+					if buffer[position] != rune('l') {
+						goto l2081
+					}
+					position++
+					if buffer[position] != rune('l') {
+						goto l2081
 					}
 					position++
 					if buffer[position] != rune('(') {
-						goto l1326
+						goto l2081
 					}
 					position++
 					if buffer[position] != rune(')') {
-						goto l1326
+						goto l2081
 					}
 					position++
-					add(rulePegText, position1328)
+					add(rulePegText, position2083)
 				}
-				if !_rules[ruleAction80]() {
-					goto l1326
+				if !_rules[ruleAction109]() {
+					goto l2081
 				}
-				add(ruleRowTimestamp, position1327)
+				add(ruleRowBackfill, position2082)
 			}
 			return true
-		l1326:
-			position, tokenIndex = position1326, tokenIndex1326
+		l2081:
+			position, tokenIndex = position2081, tokenIndex2081
 			return false
 		},
-		/* 110 RowValue <- <(<((ident ':' !':')? jsonGetPath)> Action81)> */
+		/* 144 RowValue <- <(<((ident ':' !':')? jsonGetPath)> Action110)> */
 		func() bool {
-			position1331, tokenIndex1331 := position, tokenIndex
+			position2086, tokenIndex2086 := position, tokenIndex
 			{
-				position1332 := position
+				position2087 := position
 				{
-					position1333 := position
+					position2088 := position
 					{
-						position1334, tokenIndex1334 := position, tokenIndex
+						position2089, tokenIndex2089 := position, tokenIndex
 						if !_rules[ruleident]() {
-							goto l1334
+							goto l2089
 						}
 						if buffer[position] != rune(':') {
-							goto l1334
+							goto l2089
 						}
 						position++
 						{
-							position1336, tokenIndex1336 := position, tokenIndex
+							position2091, tokenIndex2091 := position, tokenIndex
 							if buffer[position] != rune(':') {
-								goto l1336
+								goto l2091
 							}
 							position++
-							goto l1334
-						l1336:
-							position, tokenIndex = position1336, tokenIndex1336
+							goto l2089
+						l2091:
+							position, tokenIndex = position2091, tokenIndex2091
 						}
-						goto l1335
-					l1334:
-						position, tokenIndex = position1334, tokenIndex1334
+						goto l2090
+					l2089:
+						position, tokenIndex = position2089, tokenIndex2089
 					}
-				l1335:
+				l2090:
 					if !_rules[rulejsonGetPath]() {
-						goto l1331
+						goto l2086
 					}
-					add(rulePegText, position1333)
+					add(rulePegText, position2088)
 				}
-				if !_rules[ruleAction81]() {
-					goto l1331
+				if !_rules[ruleAction110]() {
+					goto l2086
 				}
-				add(ruleRowValue, position1332)
+				add(ruleRowValue, position2087)
 			}
 			return true
-		l1331:
-			position, tokenIndex = position1331, tokenIndex1331
+		l2086:
+			position, tokenIndex = position2086, tokenIndex2086
+			return false
+		},
+		/* 145 ConstantRef <- <(<('$' ident)> Action111)> */
+		func() bool {
+			position2092, tokenIndex2092 := position, tokenIndex
+			{
+				position2093 := position
+				{
+					position2094 := position
+					if buffer[position] != rune('$') {
+						goto l2092
+					}
+					position++
+					if !_rules[ruleident]() {
+						goto l2092
+					}
+					add(rulePegText, position2094)
+				}
+				if !_rules[ruleAction111]() {
+					goto l2092
+				}
+				add(ruleConstantRef, position2093)
+			}
+			return true
+		l2092:
+			position, tokenIndex = position2092, tokenIndex2092
 			return false
 		},
-		/* 111 NumericLiteral <- <(<('-'? [0-9]+)> Action82)> */
+		/* 146 NumericLiteral <- <(<('-'? [0-9]+)> Action112)> */
 		func() bool {
-			position1337, tokenIndex1337 := position, tokenIndex
+			position2095, tokenIndex2095 := position, tokenIndex
 			{
-				position1338 := position
+				position2096 := position
 				{
-					position1339 := position
+					position2097 := position
 					{
-						position1340, tokenIndex1340 := position, tokenIndex
+						position2098, tokenIndex2098 := position, tokenIndex
 						if buffer[position] != rune('-') {
-							goto l1340
+							goto l2098
 						}
 						position++
-						goto l1341
-					l1340:
-						position, tokenIndex = position1340, tokenIndex1340
+						goto l2099
+					l2098:
+						position, tokenIndex = position2098, tokenIndex2098
 					}
-				l1341:
+				l2099:
 					if c := buffer[position]; c < rune('0') || c > rune('9') {
-						goto l1337
+						goto l2095
 					}
 					position++
-				l1342:
+				l2100:
 					{
-						position1343, tokenIndex1343 := position, tokenIndex
+						position2101, tokenIndex2101 := position, tokenIndex
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l1343
+							goto l2101
 						}
 						position++
-						goto l1342
-					l1343:
-						position, tokenIndex = position1343, tokenIndex1343
+						goto l2100
+					l2101:
+						position, tokenIndex = position2101, tokenIndex2101
 					}
-					add(rulePegText, position1339)
+					add(rulePegText, position2097)
 				}
-				if !_rules[ruleAction82]() {
-					goto l1337
+				if !_rules[ruleAction112]() {
+					goto l2095
 				}
-				add(ruleNumericLiteral, position1338)
+				add(ruleNumericLiteral, position2096)
 			}
 			return true
-		l1337:
-			position, tokenIndex = position1337, tokenIndex1337
+		l2095:
+			position, tokenIndex = position2095, tokenIndex2095
 			return false
 		},
-		/* 112 NonNegativeNumericLiteral <- <(<[0-9]+> Action83)> */
+		/* 147 NonNegativeNumericLiteral <- <(<[0-9]+> Action113)> */
 		func() bool {
-			position1344, tokenIndex1344 := position, tokenIndex
+			position2102, tokenIndex2102 := position, tokenIndex
 			{
-				position1345 := position
+				position2103 := position
 				{
-					position1346 := position
+					position2104 := position
 					if c := buffer[position]; c < rune('0') || c > rune('9') {
-						goto l1344
+						goto l2102
 					}
 					position++
-				l1347:
+				l2105:
 					{
-						position1348, tokenIndex1348 := position, tokenIndex
+						position2106, tokenIndex2106 := position, tokenIndex
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l1348
+							goto l2106
 						}
 						position++
-						goto l1347
-					l1348:
-						position, tokenIndex = position1348, tokenIndex1348
+						goto l2105
+					l2106:
+						position, tokenIndex = position2106, tokenIndex2106
 					}
-					add(rulePegText, position1346)
+					add(rulePegText, position2104)
 				}
-				if !_rules[ruleAction83]() {
-					goto l1344
+				if !_rules[ruleAction113]() {
+					goto l2102
 				}
-				add(ruleNonNegativeNumericLiteral, position1345)
+				add(ruleNonNegativeNumericLiteral, position2103)
 			}
 			return true
-		l1344:
-			position, tokenIndex = position1344, tokenIndex1344
+		l2102:
+			position, tokenIndex = position2102, tokenIndex2102
 			return false
 		},
-		/* 113 FloatLiteral <- <(<('-'? [0-9]+ '.' [0-9]+)> Action84)> */
+		/* 148 FloatLiteral <- <(<('-'? [0-9]+ '.' [0-9]+)> Action114)> */
 		func() bool {
-			position1349, tokenIndex1349 := position, tokenIndex
+			position2107, tokenIndex2107 := position, tokenIndex
 			{
-				position1350 := position
+				position2108 := position
 				{
-					position1351 := position
+					position2109 := position
 					{
-						position1352, tokenIndex1352 := position, tokenIndex
+						position2110, tokenIndex2110 := position, tokenIndex
 						if buffer[position] != rune('-') {
-							goto l1352
+							goto l2110
 						}
 						position++
-						goto l1353
-					l1352:
-						position, tokenIndex = position1352, tokenIndex1352
+						goto l2111
+					l2110:
+						position, tokenIndex = position2110, tokenIndex2110
 					}
-				l1353:
+				l2111:
 					if c := buffer[position]; c < rune('0') || c > rune('9') {
-						goto l1349
+						goto l2107
 					}
 					position++
-				l1354:
+				l2112:
 					{
-						position1355, tokenIndex1355 := position, tokenIndex
+						position2113, tokenIndex2113 := position, tokenIndex
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l1355
+							goto l2113
 						}
 						position++
-						goto l1354
-					l1355:
-						position, tokenIndex = position1355, tokenIndex1355
+						goto l2112
+					l2113:
+						position, tokenIndex = position2113, tokenIndex2113
 					}
 					if buffer[position] != rune('.') {
-						goto l1349
+						goto l2107
 					}
 					position++
 					if c := buffer[position]; c < rune('0') || c > rune('9') {
-						goto l1349
+						goto l2107
 					}
 					position++
-				l1356:
+				l2114:
 					{
-						position1357, tokenIndex1357 := position, tokenIndex
+						position2115, tokenIndex2115 := position, tokenIndex
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l1357
+							goto l2115
 						}
 						position++
-						goto l1356
-					l1357:
-						position, tokenIndex = position1357, tokenIndex1357
+						goto l2114
+					l2115:
+						position, tokenIndex = position2115, tokenIndex2115
 					}
-					add(rulePegText, position1351)
+					add(rulePegText, position2109)
 				}
-				if !_rules[ruleAction84]() {
-					goto l1349
+				if !_rules[ruleAction114]() {
+					goto l2107
 				}
-				add(ruleFloatLiteral, position1350)
+				add(ruleFloatLiteral, position2108)
 			}
 			return true
-		l1349:
-			position, tokenIndex = position1349, tokenIndex1349
+		l2107:
+			position, tokenIndex = position2107, tokenIndex2107
 			return false
 		},
-		/* 114 Function <- <(<ident> Action85)> */
+		/* 149 Function <- <(<ident> Action115)> */
 		func() bool {
-			position1358, tokenIndex1358 := position, tokenIndex
+			position2116, tokenIndex2116 := position, tokenIndex
 			{
-				position1359 := position
+				position2117 := position
 				{
-					position1360 := position
+					position2118 := position
 					if !_rules[ruleident]() {
-						goto l1358
+						goto l2116
 					}
-					add(rulePegText, position1360)
+					add(rulePegText, position2118)
 				}
-				if !_rules[ruleAction85]() {
-					goto l1358
+				if !_rules[ruleAction115]() {
+					goto l2116
 				}
-				add(ruleFunction, position1359)
+				add(ruleFunction, position2117)
 			}
 			return true
-		l1358:
-			position, tokenIndex = position1358, tokenIndex1358
+		l2116:
+			position, tokenIndex = position2116, tokenIndex2116
 			return false
 		},
-		/* 115 NullLiteral <- <(<(('n' / 'N') ('u' / 'U') ('l' / 'L') ('l' / 'L'))> Action86)> */
+		/* 150 NullLiteral <- <(<(('n' / 'N') ('u' / 'U') ('l' / 'L') ('l' / 'L'))> Action116)> */
 		func() bool {
-			position1361, tokenIndex1361 := position, tokenIndex
+			position2119, tokenIndex2119 := position, tokenIndex
 			{
-				position1362 := position
+				position2120 := position
 				{
-					position1363 := position
+					position2121 := position
 					{
-						position1364, tokenIndex1364 := position, tokenIndex
+						position2122, tokenIndex2122 := position, tokenIndex
 						if buffer[position] != rune('n') {
-							goto l1365
+							goto l2123
 						}
 						position++
-						goto l1364
-					l1365:
-						position, tokenIndex = position1364, tokenIndex1364
+						goto l2122
+					l2123:
+						position, tokenIndex = position2122, tokenIndex2122
 						if buffer[position] != rune('N') {
-							goto l1361
+							goto l2119
 						}
 						position++
 					}
-				l1364:
+				l2122:
 					{
-						position1366, tokenIndex1366 := position, tokenIndex
+						position2124, tokenIndex2124 := position, tokenIndex
 						if buffer[position] != rune('u') {
-							goto l1367
+							goto l2125
 						}
 						position++
-						goto l1366
-					l1367:
-						position, tokenIndex = position1366, tokenIndex1366
+						goto l2124
+					l2125:
+						position, tokenIndex = position2124, tokenIndex2124
 						if buffer[position] != rune('U') {
-							goto l1361
+							goto l2119
 						}
 						position++
 					}
-				l1366:
+				l2124:
 					{
-						position1368, tokenIndex1368 := position, tokenIndex
+						position2126, tokenIndex2126 := position, tokenIndex
 						if buffer[position] != rune('l') {
-							goto l1369
+							goto l2127
 						}
 						position++
-						goto l1368
-					l1369:
-						position, tokenIndex = position1368, tokenIndex1368
+						goto l2126
+					l2127:
+						position, tokenIndex = position2126, tokenIndex2126
 						if buffer[position] != rune('L') {
-							goto l1361
+							goto l2119
 						}
 						position++
 					}
-				l1368:
+				l2126:
 					{
-						position1370, tokenIndex1370 := position, tokenIndex
+						position2128, tokenIndex2128 := position, tokenIndex
 						if buffer[position] != rune('l') {
-							goto l1371
+							goto l2129
 						}
 						position++
-						goto l1370
-					l1371:
-						position, tokenIndex = position1370, tokenIndex1370
+						goto l2128
+					l2129:
+						position, tokenIndex = position2128, tokenIndex2128
 						if buffer[position] != rune('L') {
-							goto l1361
+							goto l2119
 						}
 						position++
 					}
-				l1370:
-					add(rulePegText, position1363)
+				l2128:
+					add(rulePegText, position2121)
 				}
-				if !_rules[ruleAction86]() {
-					goto l1361
+				if !_rules[ruleAction116]() {
+					goto l2119
 				}
-				add(ruleNullLiteral, position1362)
+				add(ruleNullLiteral, position2120)
 			}
 			return true
-		l1361:
-			position, tokenIndex = position1361, tokenIndex1361
+		l2119:
+			position, tokenIndex = position2119, tokenIndex2119
 			return false
 		},
-		/* 116 Missing <- <(<(('m' / 'M') ('i' / 'I') ('s' / 'S') ('s' / 'S') ('i' / 'I') ('n' / 'N') ('g' / 'G'))> Action87)> */
+		/* 151 Missing <- <(<(('m' / 'M') ('i' / 'I') ('s' / 'S') ('s' / 'S') ('i' / 'I') ('n' / 'N') ('g' / 'G'))> Action117)> */
 		func() bool {
-			position1372, tokenIndex1372 := position, tokenIndex
+			position2130, tokenIndex2130 := position, tokenIndex
 			{
-				position1373 := position
+				position2131 := position
 				{
-					position1374 := position
+					position2132 := position
 					{
-						position1375, tokenIndex1375 := position, tokenIndex
+						position2133, tokenIndex2133 := position, tokenIndex
 						if buffer[position] != rune('m') {
-							goto l1376
+							goto l2134
 						}
 						position++
-						goto l1375
-					l1376:
-						position, tokenIndex = position1375, tokenIndex1375
+						goto l2133
+					l2134:
+						position, tokenIndex = position2133, tokenIndex2133
 						if buffer[position] != rune('M') {
-							goto l1372
+							goto l2130
 						}
 						position++
 					}
-				l1375:
+				l2133:
 					{
-						position1377, tokenIndex1377 := position, tokenIndex
+						position2135, tokenIndex2135 := position, tokenIndex
 						if buffer[position] != rune('i') {
-							goto l1378
+							goto l2136
 						}
 						position++
-						goto l1377
-					l1378:
-						position, tokenIndex = position1377, tokenIndex1377
+						goto l2135
+					l2136:
+						position, tokenIndex = position2135, tokenIndex2135
 						if buffer[position] != rune('I') {
-							goto l1372
+							goto l2130
 						}
 						position++
 					}
-				l1377:
+				l2135:
 					{
-						position1379, tokenIndex1379 := position, tokenIndex
+						position2137, tokenIndex2137 := position, tokenIndex
 						if buffer[position] != rune('s') {
-							goto l1380
+							goto l2138
 						}
 						position++
-						goto l1379
-					l1380:
-						position, tokenIndex = position1379, tokenIndex1379
+						goto l2137
+					l2138:
+						position, tokenIndex = position2137, tokenIndex2137
 						if buffer[position] != rune('S') {
-							goto l1372
+							goto l2130
 						}
 						position++
 					}
-				l1379:
+				l2137:
 					{
-						position1381, tokenIndex1381 := position, tokenIndex
+						position2139, tokenIndex2139 := position, tokenIndex
 						if buffer[position] != rune('s') {
-							goto l1382
+							goto l2140
 						}
 						position++
-						goto l1381
-					l1382:
-						position, tokenIndex = position1381, tokenIndex1381
+						goto l2139
+					l2140:
+						position, tokenIndex = position2139, tokenIndex2139
 						if buffer[position] != rune('S') {
-							goto l1372
+							goto l2130
 						}
 						position++
 					}
-				l1381:
+				l2139:
 					{
-						position1383, tokenIndex1383 := position, tokenIndex
+						position2141, tokenIndex2141 := position, tokenIndex
 						if buffer[position] != rune('i') {
-							goto l1384
+							goto l2142
 						}
 						position++
-						goto l1383
-					l1384:
-						position, tokenIndex = position1383, tokenIndex1383
+						goto l2141
+					l2142:
+						position, tokenIndex = position2141, tokenIndex2141
 						if buffer[position] != rune('I') {
-							goto l1372
+							goto l2130
 						}
 						position++
 					}
-				l1383:
+				l2141:
 					{
-						position1385, tokenIndex1385 := position, tokenIndex
+						position2143, tokenIndex2143 := position, tokenIndex
 						if buffer[position] != rune('n') {
-							goto l1386
+							goto l2144
 						}
 						position++
-						goto l1385
-					l1386:
-						position, tokenIndex = position1385, tokenIndex1385
+						goto l2143
+					l2144:
+						position, tokenIndex = position2143, tokenIndex2143
 						if buffer[position] != rune('N') {
-							goto l1372
+							goto l2130
 						}
 						position++
 					}
-				l1385:
+				l2143:
 					{
-						position1387, tokenIndex1387 := position, tokenIndex
+						position2145, tokenIndex2145 := position, tokenIndex
 						if buffer[position] != rune('g') {
-							goto l1388
+							goto l2146
 						}
 						position++
-						goto l1387
-					l1388:
-						position, tokenIndex = position1387, tokenIndex1387
+						goto l2145
+					l2146:
+						position, tokenIndex = position2145, tokenIndex2145
 						if buffer[position] != rune('G') {
-							goto l1372
+							goto l2130
 						}
 						position++
 					}
-				l1387:
-					add(rulePegText, position1374)
+				l2145:
+					add(rulePegText, position2132)
 				}
-				if !_rules[ruleAction87]() {
-					goto l1372
+				if !_rules[ruleAction117]() {
+					goto l2130
 				}
-				add(ruleMissing, position1373)
+				add(ruleMissing, position2131)
 			}
 			return true
-		l1372:
-			position, tokenIndex = position1372, tokenIndex1372
+		l2130:
+			position, tokenIndex = position2130, tokenIndex2130
 			return false
 		},
-		/* 117 BooleanLiteral <- <(TRUE / FALSE)> */
+		/* 152 BooleanLiteral <- <(TRUE / FALSE)> */
 		func() bool {
-			position1389, tokenIndex1389 := position, tokenIndex
+			position2147, tokenIndex2147 := position, tokenIndex
 			{
-				position1390 := position
+				position2148 := position
 				{
-					position1391, tokenIndex1391 := position, tokenIndex
+					position2149, tokenIndex2149 := position, tokenIndex
 					if !_rules[ruleTRUE]() {
-						goto l1392
+						goto l2150
 					}
-					goto l1391
-				l1392:
-					position, tokenIndex = position1391, tokenIndex1391
+					goto l2149
+				l2150:
+					position, tokenIndex = position2149, tokenIndex2149
 					if !_rules[ruleFALSE]() {
-						goto l1389
+						goto l2147
 					}
 				}
-			l1391:
-				add(ruleBooleanLiteral, position1390)
+			l2149:
+				add(ruleBooleanLiteral, position2148)
 			}
 			return true
-		l1389:
-			position, tokenIndex = position1389, tokenIndex1389
+		l2147:
+			position, tokenIndex = position2147, tokenIndex2147
 			return false
 		},
-		/* 118 TRUE <- <(<(('t' / 'T') ('r' / 'R') ('u' / 'U') ('e' / 'E'))> Action88)> */
+		/* 153 TRUE <- <(<(('t' / 'T') ('r' / 'R') ('u' / 'U') ('e' / 'E'))> Action118)> */
 		func() bool {
-			position1393, tokenIndex1393 := position, tokenIndex
+			position2151, tokenIndex2151 := position, tokenIndex
 			{
-				position1394 := position
+				position2152 := position
 				{
-					position1395 := position
+					position2153 := position
 					{
-						position1396, tokenIndex1396 := position, tokenIndex
+						position2154, tokenIndex2154 := position, tokenIndex
 						if buffer[position] != rune('t') {
-							goto l1397
+							goto l2155
 						}
 						position++
-						goto l1396
-					l1397:
-						position, tokenIndex = position1396, tokenIndex1396
+						goto l2154
+					l2155:
+						position, tokenIndex = position2154, tokenIndex2154
 						if buffer[position] != rune('T') {
-							goto l1393
+							goto l2151
 						}
 						position++
 					}
-				l1396:
+				l2154:
 					{
-						position1398, tokenIndex1398 := position, tokenIndex
+						position2156, tokenIndex2156 := position, tokenIndex
 						if buffer[position] != rune('r') {
-							goto l1399
+							goto l2157
 						}
 						position++
-						goto l1398
-					l1399:
-						position, tokenIndex = position1398, tokenIndex1398
+						goto l2156
+					l2157:
+						position, tokenIndex = position2156, tokenIndex2156
 						if buffer[position] != rune('R') {
-							goto l1393
+							goto l2151
 						}
 						position++
 					}
-				l1398:
+				l2156:
 					{
-						position1400, tokenIndex1400 := position, tokenIndex
+						position2158, tokenIndex2158 := position, tokenIndex
 						if buffer[position] != rune('u') {
-							goto l1401
+							goto l2159
 						}
 						position++
-						goto l1400
-					l1401:
-						position, tokenIndex = position1400, tokenIndex1400
+						goto l2158
+					l2159:
+						position, tokenIndex = position2158, tokenIndex2158
 						if buffer[position] != rune('U') {
-							goto l1393
+							goto l2151
 						}
 						position++
 					}
-				l1400:
+				l2158:
 					{
-						position1402, tokenIndex1402 := position, tokenIndex
+						position2160, tokenIndex2160 := position, tokenIndex
 						if buffer[position] != rune('e') {
-							goto l1403
+							goto l2161
 						}
 						position++
-						goto l1402
-					l1403:
-						position, tokenIndex = position1402, tokenIndex1402
+						goto l2160
+					l2161:
+						position, tokenIndex = position2160, tokenIndex2160
 						if buffer[position] != rune('E') {
-							goto l1393
+							goto l2151
 						}
 						position++
 					}
-				l1402:
-					add(rulePegText, position1395)
+				l2160:
+					add(rulePegText, position2153)
 				}
-				if !_rules[ruleAction88]() {
-					goto l1393
+				if !_rules[ruleAction118]() {
+					goto l2151
 				}
-				add(ruleTRUE, position1394)
+				add(ruleTRUE, position2152)
 			}
 			return true
-		l1393:
-			position, tokenIndex = position1393, tokenIndex1393
+		l2151:
+			position, tokenIndex = position2151, tokenIndex2151
 			return false
 		},
-		/* 119 FALSE <- <(<(('f' / 'F') ('a' / 'A') ('l' / 'L') ('s' / 'S') ('e' / 'E'))> Action89)> */
+		/* 154 FALSE <- <(<(('f' / 'F') ('a' / 'A') ('l' / 'L') ('s' / 'S') ('e' / 'E'))> Action119)> */
 		func() bool {
-			position1404, tokenIndex1404 := position, tokenIndex
+			position2162, tokenIndex2162 := position, tokenIndex
 			{
-				position1405 := position
+				position2163 := position
 				{
-					position1406 := position
+					position2164 := position
 					{
-						position1407, tokenIndex1407 := position, tokenIndex
+						position2165, tokenIndex2165 := position, tokenIndex
 						if buffer[position] != rune('f') {
-							goto l1408
+							goto l2166
 						}
 						position++
-						goto l1407
-					l1408:
-						position, tokenIndex = position1407, tokenIndex1407
+						goto l2165
+					l2166:
+						position, tokenIndex = position2165, tokenIndex2165
 						if buffer[position] != rune('F') {
-							goto l1404
+							goto l2162
 						}
 						position++
 					}
-				l1407:
+				l2165:
 					{
-						position1409, tokenIndex1409 := position, tokenIndex
+						position2167, tokenIndex2167 := position, tokenIndex
 						if buffer[position] != rune('a') {
-							goto l1410
+							goto l2168
 						}
 						position++
-						goto l1409
-					l1410:
-						position, tokenIndex = position1409, tokenIndex1409
+						goto l2167
+					l2168:
+						position, tokenIndex = position2167, tokenIndex2167
 						if buffer[position] != rune('A') {
-							goto l1404
+							goto l2162
 						}
 						position++
 					}
-				l1409:
+				l2167:
 					{
-						position1411, tokenIndex1411 := position, tokenIndex
+						position2169, tokenIndex2169 := position, tokenIndex
 						if buffer[position] != rune('l') {
-							goto l1412
+							goto l2170
 						}
 						position++
-						goto l1411
-					l1412:
-						position, tokenIndex = position1411, tokenIndex1411
+						goto l2169
+					l2170:
+						position, tokenIndex = position2169, tokenIndex2169
 						if buffer[position] != rune('L') {
-							goto l1404
+							goto l2162
 						}
 						position++
 					}
-				l1411:
+				l2169:
 					{
-						position1413, tokenIndex1413 := position, tokenIndex
+						position2171, tokenIndex2171 := position, tokenIndex
 						if buffer[position] != rune('s') {
-							goto l1414
+							goto l2172
 						}
 						position++
-						goto l1413
-					l1414:
-						position, tokenIndex = position1413, tokenIndex1413
+						goto l2171
+					l2172:
+						position, tokenIndex = position2171, tokenIndex2171
 						if buffer[position] != rune('S') {
-							goto l1404
+							goto l2162
 						}
 						position++
 					}
-				l1413:
+				l2171:
 					{
-						position1415, tokenIndex1415 := position, tokenIndex
+						position2173, tokenIndex2173 := position, tokenIndex
 						if buffer[position] != rune('e') {
-							goto l1416
+							goto l2174
 						}
 						position++
-						goto l1415
-					l1416:
-						position, tokenIndex = position1415, tokenIndex1415
+						goto l2173
+					l2174:
+						position, tokenIndex = position2173, tokenIndex2173
 						if buffer[position] != rune('E') {
-							goto l1404
+							goto l2162
 						}
 						position++
 					}
-				l1415:
-					add(rulePegText, position1406)
+				l2173:
+					add(rulePegText, position2164)
 				}
-				if !_rules[ruleAction89]() {
-					goto l1404
+				if !_rules[ruleAction119]() {
+					goto l2162
 				}
-				add(ruleFALSE, position1405)
+				add(ruleFALSE, position2163)
 			}
 			return true
-		l1404:
-			position, tokenIndex = position1404, tokenIndex1404
+		l2162:
+			position, tokenIndex = position2162, tokenIndex2162
 			return false
 		},
-		/* 120 Wildcard <- <(<((ident ':' !':')? '*')> Action90)> */
+		/* 155 Wildcard <- <(<((ident ':' !':')? '*')> Action120)> */
 		func() bool {
-			position1417, tokenIndex1417 := position, tokenIndex
+			position2175, tokenIndex2175 := position, tokenIndex
 			{
-				position1418 := position
+				position2176 := position
 				{
-					position1419 := position
+					position2177 := position
 					{
-						position1420, tokenIndex1420 := position, tokenIndex
+						position2178, tokenIndex2178 := position, tokenIndex
 						if !_rules[ruleident]() {
-							goto l1420
+							goto l2178
 						}
 						if buffer[position] != rune(':') {
-							goto l1420
+							goto l2178
 						}
 						position++
 						{
-							position1422, tokenIndex1422 := position, tokenIndex
+							position2180, tokenIndex2180 := position, tokenIndex
 							if buffer[position] != rune(':') {
-								goto l1422
+								goto l2180
 							}
 							position++
-							goto l1420
-						l1422:
-							position, tokenIndex = position1422, tokenIndex1422
+							goto l2178
+						l2180:
+							position, tokenIndex = position2180, tokenIndex2180
 						}
-						goto l1421
-					l1420:
-						position, tokenIndex = position1420, tokenIndex1420
+						goto l2179
+					l2178:
+						position, tokenIndex = position2178, tokenIndex2178
 					}
-				l1421:
+				l2179:
 					if buffer[position] != rune('*') {
-						goto l1417
+						goto l2175
 					}
 					position++
-					add(rulePegText, position1419)
+					add(rulePegText, position2177)
 				}
-				if !_rules[ruleAction90]() {
-					goto l1417
+				if !_rules[ruleAction120]() {
+					goto l2175
 				}
-				add(ruleWildcard, position1418)
+				add(ruleWildcard, position2176)
 			}
 			return true
-		l1417:
-			position, tokenIndex = position1417, tokenIndex1417
+		l2175:
+			position, tokenIndex = position2175, tokenIndex2175
 			return false
 		},
-		/* 121 StringLiteral <- <(<('"' (('"' '"') / (!'"' .))* '"')> Action91)> */
+		/* 156 StringLiteral <- <(<('"' (('"' '"') / (!'"' .))* '"')> Action121)> */
 		func() bool {
-			position1423, tokenIndex1423 := position, tokenIndex
+			position2181, tokenIndex2181 := position, tokenIndex
 			{
-				position1424 := position
+				position2182 := position
 				{
-					position1425 := position
+					position2183 := position
 					if buffer[position] != rune('"') {
-						goto l1423
+						goto l2181
 					}
 					position++
-				l1426:
+				l2184:
 					{
-						position1427, tokenIndex1427 := position, tokenIndex
+						position2185, tokenIndex2185 := position, tokenIndex
 						{
-							position1428, tokenIndex1428 := position, tokenIndex
+							position2186, tokenIndex2186 := position, tokenIndex
 							if buffer[position] != rune('"') {
-								goto l1429
+								goto l2187
 							}
 							position++
 							if buffer[position] != rune('"') {
-								goto l1429
+								goto l2187
 							}
 							position++
-							goto l1428
-						l1429:
-							position, tokenIndex = position1428, tokenIndex1428
+							goto l2186
+						l2187:
+							position, tokenIndex = position2186, tokenIndex2186
 							{
-								position1430, tokenIndex1430 := position, tokenIndex
+								position2188, tokenIndex2188 := position, tokenIndex
 								if buffer[position] != rune('"') {
-									goto l1430
+									goto l2188
 								}
 								position++
-								goto l1427
-							l1430:
-								position, tokenIndex = position1430, tokenIndex1430
+								goto l2185
+							l2188:
+								position, tokenIndex = position2188, tokenIndex2188
 							}
 							if !matchDot() {
-								goto l1427
+								goto l2185
 							}
 						}
-					l1428:
-						goto l1426
-					l1427:
-						position, tokenIndex = position1427, tokenIndex1427
+					l2186:
+						goto l2184
+					l2185:
+						position, tokenIndex = position2185, tokenIndex2185
 					}
 					if buffer[position] != rune('"') {
-						goto l1423
+						goto l2181
 					}
 					position++
-					add(rulePegText, position1425)
+					add(rulePegText, position2183)
 				}
-				if !_rules[ruleAction91]() {
-					goto l1423
+				if !_rules[ruleAction121]() {
+					goto l2181
 				}
-				add(ruleStringLiteral, position1424)
+				add(ruleStringLiteral, position2182)
 			}
 			return true
-		l1423:
-			position, tokenIndex = position1423, tokenIndex1423
+		l2181:
+			position, tokenIndex = position2181, tokenIndex2181
 			return false
 		},
-		/* 122 ISTREAM <- <(<(('i' / 'I') ('s' / 'S') ('t' / 'T') ('r' / 'R') ('e' / 'E') ('a' / 'A') ('m' / 'M'))> Action92)> */
+		/* 157 ISTREAM <- <(<(('i' / 'I') ('s' / 'S') ('t' / 'T') ('r' / 'R') ('e' / 'E') ('a' / 'A') ('m' / 'M'))> Action122)> */
 		func() bool {
-			position1431, tokenIndex1431 := position, tokenIndex
+			position2189, tokenIndex2189 := position, tokenIndex
 			{
-				position1432 := position
+				position2190 := position
 				{
-					position1433 := position
+					position2191 := position
 					{
-						position1434, tokenIndex1434 := position, tokenIndex
+						position2192, tokenIndex2192 := position, tokenIndex
 						if buffer[position] != rune('i') {
-							goto l1435
+							goto l2193
 						}
 						position++
-						goto l1434
-					l1435:
-						position, tokenIndex = position1434, tokenIndex1434
+						goto l2192
+					l2193:
+						position, tokenIndex = position2192, tokenIndex2192
 						if buffer[position] != rune('I') {
-							goto l1431
+							goto l2189
 						}
 						position++
 					}
-				l1434:
+				l2192:
 					{
-						position1436, tokenIndex1436 := position, tokenIndex
+						position2194, tokenIndex2194 := position, tokenIndex
 						if buffer[position] != rune('s') {
-							goto l1437
+							goto l2195
 						}
 						position++
-						goto l1436
-					l1437:
-						position, tokenIndex = position1436, tokenIndex1436
+						goto l2194
+					l2195:
+						position, tokenIndex = position2194, tokenIndex2194
 						if buffer[position] != rune('S') {
-							goto l1431
+							goto l2189
 						}
 						position++
 					}
-				l1436:
+				l2194:
 					{
-						position1438, tokenIndex1438 := position, tokenIndex
+						position2196, tokenIndex2196 := position, tokenIndex
 						if buffer[position] != rune('t') {
-							goto l1439
+							goto l2197
 						}
 						position++
-						goto l1438
-					l1439:
-						position, tokenIndex = position1438, tokenIndex1438
+						goto l2196
+					l2197:
+						position, tokenIndex = position2196, tokenIndex2196
 						if buffer[position] != rune('T') {
-							goto l1431
+							goto l2189
 						}
 						position++
 					}
-				l1438:
+				l2196:
 					{
-						position1440, tokenIndex1440 := position, tokenIndex
+						position2198, tokenIndex2198 := position, tokenIndex
 						if buffer[position] != rune('r') {
-							goto l1441
+							goto l2199
 						}
 						position++
-						goto l1440
-					l1441:
-						position, tokenIndex = position1440, tokenIndex1440
+						goto l2198
+					l2199:
+						position, tokenIndex = position2198, tokenIndex2198
 						if buffer[position] != rune('R') {
-							goto l1431
+							goto l2189
+						}
+						position++
+					}
+				l2198:
+					{
+						position2200, tokenIndex2200 := position, tokenIndex
+						if buffer[position] != rune('e') {
+							goto l2201
+						}
+						position++
+						goto l2200
+					l2201:
+						position, tokenIndex = position2200, tokenIndex2200
+						if buffer[position] != rune('E') {
+							goto l2189
+						}
+						position++
+					}
+				l2200:
+					{
+						position2202, tokenIndex2202 := position, tokenIndex
+						if buffer[position] != rune('a') {
+							goto l2203
+						}
+						position++
+						goto l2202
+					l2203:
+						position, tokenIndex = position2202, tokenIndex2202
+						if buffer[position] != rune('A') {
+							goto l2189
+						}
+						position++
+					}
+				l2202:
+					{
+						position2204, tokenIndex2204 := position, tokenIndex
+						if buffer[position] != rune('m') {
+							goto l2205
+						}
+						position++
+						goto l2204
+					l2205:
+						position, tokenIndex = position2204, tokenIndex2204
+						if buffer[position] != rune('M') {
+							goto l2189
+						}
+						position++
+					}
+				l2204:
+					add(rulePegText, position2191)
+				}
+				if !_rules[ruleAction122]() {
+					goto l2189
+				}
+				add(ruleISTREAM, position2190)
+			}
+			return true
+		l2189:
+			position, tokenIndex = position2189, tokenIndex2189
+			return false
+		},
+		/* 158 DSTREAM <- <(<(('d' / 'D') ('s' / 'S') ('t' / 'T') ('r' / 'R') ('e' / 'E') ('a' / 'A') ('m' / 'M'))> Action123)> */
+		func() bool {
+			position2206, tokenIndex2206 := position, tokenIndex
+			{
+				position2207 := position
+				{
+					position2208 := position
+					{
+						position2209, tokenIndex2209 := position, tokenIndex
+						if buffer[position] != rune('d') {
+							goto l2210
+						}
+						position++
+						goto l2209
+					l2210:
+						position, tokenIndex = position2209, tokenIndex2209
+						if buffer[position] != rune('D') {
+							goto l2206
+						}
+						position++
+					}
+				l2209:
+					{
+						position2211, tokenIndex2211 := position, tokenIndex
+						if buffer[position] != rune('s') {
+							goto l2212
+						}
+						position++
+						goto l2211
+					l2212:
+						position, tokenIndex = position2211, tokenIndex2211
+						if buffer[position] != rune('S') {
+							goto l2206
+						}
+						position++
+					}
+				l2211:
+					{
+						position2213, tokenIndex2213 := position, tokenIndex
+						if buffer[position] != rune('t') {
+							goto l2214
+						}
+						position++
+						goto l2213
+					l2214:
+						position, tokenIndex = position2213, tokenIndex2213
+						if buffer[position] != rune('T') {
+							goto l2206
+						}
+						position++
+					}
+				l2213:
+					{
+						position2215, tokenIndex2215 := position, tokenIndex
+						if buffer[position] != rune('r') {
+							goto l2216
+						}
+						position++
+						goto l2215
+					l2216:
+						position, tokenIndex = position2215, tokenIndex2215
+						if buffer[position] != rune('R') {
+							goto l2206
+						}
+						position++
+					}
+				l2215:
+					{
+						position2217, tokenIndex2217 := position, tokenIndex
+						if buffer[position] != rune('e') {
+							goto l2218
+						}
+						position++
+						goto l2217
+					l2218:
+						position, tokenIndex = position2217, tokenIndex2217
+						if buffer[position] != rune('E') {
+							goto l2206
+						}
+						position++
+					}
+				l2217:
+					{
+						position2219, tokenIndex2219 := position, tokenIndex
+						if buffer[position] != rune('a') {
+							goto l2220
+						}
+						position++
+						goto l2219
+					l2220:
+						position, tokenIndex = position2219, tokenIndex2219
+						if buffer[position] != rune('A') {
+							goto l2206
+						}
+						position++
+					}
+				l2219:
+					{
+						position2221, tokenIndex2221 := position, tokenIndex
+						if buffer[position] != rune('m') {
+							goto l2222
+						}
+						position++
+						goto l2221
+					l2222:
+						position, tokenIndex = position2221, tokenIndex2221
+						if buffer[position] != rune('M') {
+							goto l2206
+						}
+						position++
+					}
+				l2221:
+					add(rulePegText, position2208)
+				}
+				if !_rules[ruleAction123]() {
+					goto l2206
+				}
+				add(ruleDSTREAM, position2207)
+			}
+			return true
+		l2206:
+			position, tokenIndex = position2206, tokenIndex2206
+			return false
+		},
+		/* 159 RSTREAM <- <(<(('r' / 'R') ('s' / 'S') ('t' / 'T') ('r' / 'R') ('e' / 'E') ('a' / 'A') ('m' / 'M'))> Action124)> */
+		func() bool {
+			position2223, tokenIndex2223 := position, tokenIndex
+			{
+				position2224 := position
+				{
+					position2225 := position
+					{
+						position2226, tokenIndex2226 := position, tokenIndex
+						if buffer[position] != rune('r') {
+							goto l2227
+						}
+						position++
+						goto l2226
+					l2227:
+						position, tokenIndex = position2226, tokenIndex2226
+						if buffer[position] != rune('R') {
+							goto l2223
+						}
+						position++
+					}
+				l2226:
+					{
+						position2228, tokenIndex2228 := position, tokenIndex
+						if buffer[position] != rune('s') {
+							goto l2229
+						}
+						position++
+						goto l2228
+					l2229:
+						position, tokenIndex = position2228, tokenIndex2228
+						if buffer[position] != rune('S') {
+							goto l2223
+						}
+						position++
+					}
+				l2228:
+					{
+						position2230, tokenIndex2230 := position, tokenIndex
+						if buffer[position] != rune('t') {
+							goto l2231
+						}
+						position++
+						goto l2230
+					l2231:
+						position, tokenIndex = position2230, tokenIndex2230
+						if buffer[position] != rune('T') {
+							goto l2223
+						}
+						position++
+					}
+				l2230:
+					{
+						position2232, tokenIndex2232 := position, tokenIndex
+						if buffer[position] != rune('r') {
+							goto l2233
+						}
+						position++
+						goto l2232
+					l2233:
+						position, tokenIndex = position2232, tokenIndex2232
+						if buffer[position] != rune('R') {
+							goto l2223
 						}
 						position++
 					}
-				l1440:
+				l2232:
 					{
-						position1442, tokenIndex1442 := position, tokenIndex
+						position2234, tokenIndex2234 := position, tokenIndex
 						if buffer[position] != rune('e') {
-							goto l1443
+							goto l2235
 						}
 						position++
-						goto l1442
-					l1443:
-						position, tokenIndex = position1442, tokenIndex1442
+						goto l2234
+					l2235:
+						position, tokenIndex = position2234, tokenIndex2234
 						if buffer[position] != rune('E') {
-							goto l1431
+							goto l2223
 						}
 						position++
 					}
-				l1442:
+				l2234:
 					{
-						position1444, tokenIndex1444 := position, tokenIndex
+						position2236, tokenIndex2236 := position, tokenIndex
 						if buffer[position] != rune('a') {
-							goto l1445
+							goto l2237
 						}
 						position++
-						goto l1444
-					l1445:
-						position, tokenIndex = position1444, tokenIndex1444
+						goto l2236
+					l2237:
+						position, tokenIndex = position2236, tokenIndex2236
 						if buffer[position] != rune('A') {
-							goto l1431
+							goto l2223
 						}
 						position++
 					}
-				l1444:
+				l2236:
 					{
-						position1446, tokenIndex1446 := position, tokenIndex
+						position2238, tokenIndex2238 := position, tokenIndex
 						if buffer[position] != rune('m') {
-							goto l1447
+							goto l2239
 						}
 						position++
-						goto l1446
-					l1447:
-						position, tokenIndex = position1446, tokenIndex1446
+						goto l2238
+					l2239:
+						position, tokenIndex = position2238, tokenIndex2238
 						if buffer[position] != rune('M') {
-							goto l1431
+							goto l2223
 						}
 						position++
 					}
-				l1446:
-					add(rulePegText, position1433)
+				l2238:
+					add(rulePegText, position2225)
 				}
-				if !_rules[ruleAction92]() {
-					goto l1431
+				if !_rules[ruleAction124]() {
+					goto l2223
 				}
-				add(ruleISTREAM, position1432)
+				add(ruleRSTREAM, position2224)
 			}
 			return true
-		l1431:
-			position, tokenIndex = position1431, tokenIndex1431
+		l2223:
+			position, tokenIndex = position2223, tokenIndex2223
 			return false
 		},
-		/* 123 DSTREAM <- <(<(('d' / 'D') ('s' / 'S') ('t' / 'T') ('r' / 'R') ('e' / 'E') ('a' / 'A') ('m' / 'M'))> Action93)> */
+		/* 160 TUPLES <- <(<(('t' / 'T') ('u' / 'U') ('p' / 'P') ('l' / 'L') ('e' / 'E') ('s' / 'S'))> Action125)> */
 		func() bool {
-			position1448, tokenIndex1448 := position, tokenIndex
+			position2240, tokenIndex2240 := position, tokenIndex
 			{
-				position1449 := position
+				position2241 := position
 				{
-					position1450 := position
+					position2242 := position
 					{
-						position1451, tokenIndex1451 := position, tokenIndex
-						if buffer[position] != rune('d') {
-							goto l1452
+						position2243, tokenIndex2243 := position, tokenIndex
+						if buffer[position] != rune('t') {
+							goto l2244
 						}
 						position++
-						goto l1451
-					l1452:
-						position, tokenIndex = position1451, tokenIndex1451
-						if buffer[position] != rune('D') {
-							goto l1448
+						goto l2243
+					l2244:
+						position, tokenIndex = position2243, tokenIndex2243
+						if buffer[position] != rune('T') {
+							goto l2240
 						}
 						position++
 					}
-				l1451:
+				l2243:
 					{
-						position1453, tokenIndex1453 := position, tokenIndex
-						if buffer[position] != rune('s') {
-							goto l1454
+						position2245, tokenIndex2245 := position, tokenIndex
+						if buffer[position] != rune('u') {
+							goto l2246
 						}
 						position++
-						goto l1453
-					l1454:
-						position, tokenIndex = position1453, tokenIndex1453
-						if buffer[position] != rune('S') {
-							goto l1448
+						goto l2245
+					l2246:
+						position, tokenIndex = position2245, tokenIndex2245
+						if buffer[position] != rune('U') {
+							goto l2240
 						}
 						position++
 					}
-				l1453:
+				l2245:
 					{
-						position1455, tokenIndex1455 := position, tokenIndex
-						if buffer[position] != rune('t') {
-							goto l1456
+						position2247, tokenIndex2247 := position, tokenIndex
+						if buffer[position] != rune('p') {
+							goto l2248
 						}
 						position++
-						goto l1455
-					l1456:
-						position, tokenIndex = position1455, tokenIndex1455
-						if buffer[position] != rune('T') {
-							goto l1448
+						goto l2247
+					l2248:
+						position, tokenIndex = position2247, tokenIndex2247
+						if buffer[position] != rune('P') {
+							goto l2240
 						}
 						position++
 					}
-				l1455:
+				l2247:
 					{
-						position1457, tokenIndex1457 := position, tokenIndex
-						if buffer[position] != rune('r') {
-							goto l1458
+						position2249, tokenIndex2249 := position, tokenIndex
+						if buffer[position] != rune('l') {
+							goto l2250
 						}
 						position++
-						goto l1457
-					l1458:
-						position, tokenIndex = position1457, tokenIndex1457
-						if buffer[position] != rune('R') {
-							goto l1448
+						goto l2249
+					l2250:
+						position, tokenIndex = position2249, tokenIndex2249
+						if buffer[position] != rune('L') {
+							goto l2240
 						}
 						position++
 					}
-				l1457:
+				l2249:
 					{
-						position1459, tokenIndex1459 := position, tokenIndex
+						position2251, tokenIndex2251 := position, tokenIndex
 						if buffer[position] != rune('e') {
-							goto l1460
+							goto l2252
 						}
 						position++
-						goto l1459
-					l1460:
-						position, tokenIndex = position1459, tokenIndex1459
+						goto l2251
+					l2252:
+						position, tokenIndex = position2251, tokenIndex2251
 						if buffer[position] != rune('E') {
-							goto l1448
-						}
-						position++
-					}
-				l1459:
-					{
-						position1461, tokenIndex1461 := position, tokenIndex
-						if buffer[position] != rune('a') {
-							goto l1462
-						}
-						position++
-						goto l1461
-					l1462:
-						position, tokenIndex = position1461, tokenIndex1461
-						if buffer[position] != rune('A') {
-							goto l1448
+							goto l2240
 						}
 						position++
 					}
-				l1461:
+				l2251:
 					{
-						position1463, tokenIndex1463 := position, tokenIndex
-						if buffer[position] != rune('m') {
-							goto l1464
+						position2253, tokenIndex2253 := position, tokenIndex
+						if buffer[position] != rune('s') {
+							goto l2254
 						}
 						position++
-						goto l1463
-					l1464:
-						position, tokenIndex = position1463, tokenIndex1463
-						if buffer[position] != rune('M') {
-							goto l1448
+						goto l2253
+					l2254:
+						position, tokenIndex = position2253, tokenIndex2253
+						if buffer[position] != rune('S') {
+							goto l2240
 						}
 						position++
 					}
-				l1463:
-					add(rulePegText, position1450)
+				l2253:
+					add(rulePegText, position2242)
 				}
-				if !_rules[ruleAction93]() {
-					goto l1448
+				if !_rules[ruleAction125]() {
+					goto l2240
 				}
-				add(ruleDSTREAM, position1449)
+				add(ruleTUPLES, position2241)
 			}
 			return true
-		l1448:
-			position, tokenIndex = position1448, tokenIndex1448
+		l2240:
+			position, tokenIndex = position2240, tokenIndex2240
 			return false
 		},
-		/* 124 RSTREAM <- <(<(('r' / 'R') ('s' / 'S') ('t' / 'T') ('r' / 'R') ('e' / 'E') ('a' / 'A') ('m' / 'M'))> Action94)> */
+		/* 161 SECONDS <- <(<(('s' / 'S') ('e' / 'E') ('c' / 'C') ('o' / 'O') ('n' / 'N') ('d' / 'D') ('s' / 'S'))> Action126)> */
 		func() bool {
-			position1465, tokenIndex1465 := position, tokenIndex
+			position2255, tokenIndex2255 := position, tokenIndex
 			{
-				position1466 := position
+				position2256 := position
 				{
-					position1467 := position
+					position2257 := position
 					{
-						position1468, tokenIndex1468 := position, tokenIndex
-						if buffer[position] != rune('r') {
-							goto l1469
+						position2258, tokenIndex2258 := position, tokenIndex
+						if buffer[position] != rune('s') {
+							goto l2259
 						}
 						position++
-						goto l1468
-					l1469:
-						position, tokenIndex = position1468, tokenIndex1468
-						if buffer[position] != rune('R') {
-							goto l1465
+						goto l2258
+					l2259:
+						position, tokenIndex = position2258, tokenIndex2258
+						if buffer[position] != rune('S') {
+							goto l2255
 						}
 						position++
 					}
-				l1468:
+				l2258:
 					{
-						position1470, tokenIndex1470 := position, tokenIndex
-						if buffer[position] != rune('s') {
-							goto l1471
+						position2260, tokenIndex2260 := position, tokenIndex
+						if buffer[position] != rune('e') {
+							goto l2261
 						}
 						position++
-						goto l1470
-					l1471:
-						position, tokenIndex = position1470, tokenIndex1470
-						if buffer[position] != rune('S') {
-							goto l1465
+						goto l2260
+					l2261:
+						position, tokenIndex = position2260, tokenIndex2260
+						if buffer[position] != rune('E') {
+							goto l2255
 						}
 						position++
 					}
-				l1470:
+				l2260:
 					{
-						position1472, tokenIndex1472 := position, tokenIndex
-						if buffer[position] != rune('t') {
-							goto l1473
+						position2262, tokenIndex2262 := position, tokenIndex
+						if buffer[position] != rune('c') {
+							goto l2263
 						}
 						position++
-						goto l1472
-					l1473:
-						position, tokenIndex = position1472, tokenIndex1472
-						if buffer[position] != rune('T') {
-							goto l1465
+						goto l2262
+					l2263:
+						position, tokenIndex = position2262, tokenIndex2262
+						if buffer[position] != rune('C') {
+							goto l2255
 						}
 						position++
 					}
-				l1472:
+				l2262:
 					{
-						position1474, tokenIndex1474 := position, tokenIndex
-						if buffer[position] != rune('r') {
-							goto l1475
+						position2264, tokenIndex2264 := position, tokenIndex
+						if buffer[position] != rune('o') {
+							goto l2265
 						}
 						position++
-						goto l1474
-					l1475:
-						position, tokenIndex = position1474, tokenIndex1474
-						if buffer[position] != rune('R') {
-							goto l1465
+						goto l2264
+					l2265:
+						position, tokenIndex = position2264, tokenIndex2264
+						if buffer[position] != rune('O') {
+							goto l2255
 						}
 						position++
 					}
-				l1474:
+				l2264:
 					{
-						position1476, tokenIndex1476 := position, tokenIndex
-						if buffer[position] != rune('e') {
-							goto l1477
+						position2266, tokenIndex2266 := position, tokenIndex
+						if buffer[position] != rune('n') {
+							goto l2267
 						}
 						position++
-						goto l1476
-					l1477:
-						position, tokenIndex = position1476, tokenIndex1476
-						if buffer[position] != rune('E') {
-							goto l1465
+						goto l2266
+					l2267:
+						position, tokenIndex = position2266, tokenIndex2266
+						if buffer[position] != rune('N') {
+							goto l2255
 						}
 						position++
 					}
-				l1476:
+				l2266:
 					{
-						position1478, tokenIndex1478 := position, tokenIndex
-						if buffer[position] != rune('a') {
-							goto l1479
+						position2268, tokenIndex2268 := position, tokenIndex
+						if buffer[position] != rune('d') {
+							goto l2269
 						}
 						position++
-						goto l1478
-					l1479:
-						position, tokenIndex = position1478, tokenIndex1478
-						if buffer[position] != rune('A') {
-							goto l1465
+						goto l2268
+					l2269:
+						position, tokenIndex = position2268, tokenIndex2268
+						if buffer[position] != rune('D') {
+							goto l2255
 						}
 						position++
 					}
-				l1478:
+				l2268:
 					{
-						position1480, tokenIndex1480 := position, tokenIndex
-						if buffer[position] != rune('m') {
-							goto l1481
+						position2270, tokenIndex2270 := position, tokenIndex
+						if buffer[position] != rune('s') {
+							goto l2271
 						}
 						position++
-						goto l1480
-					l1481:
-						position, tokenIndex = position1480, tokenIndex1480
-						if buffer[position] != rune('M') {
-							goto l1465
+						goto l2270
+					l2271:
+						position, tokenIndex = position2270, tokenIndex2270
+						if buffer[position] != rune('S') {
+							goto l2255
 						}
 						position++
 					}
-				l1480:
-					add(rulePegText, position1467)
+				l2270:
+					add(rulePegText, position2257)
 				}
-				if !_rules[ruleAction94]() {
-					goto l1465
+				if !_rules[ruleAction126]() {
+					goto l2255
 				}
-				add(ruleRSTREAM, position1466)
+				add(ruleSECONDS, position2256)
 			}
 			return true
-		l1465:
-			position, tokenIndex = position1465, tokenIndex1465
+		l2255:
+			position, tokenIndex = position2255, tokenIndex2255
 			return false
 		},
-		/* 125 TUPLES <- <(<(('t' / 'T') ('u' / 'U') ('p' / 'P') ('l' / 'L') ('e' / 'E') ('s' / 'S'))> Action95)> */
+		/* 162 MILLISECONDS <- <(<(('m' / 'M') ('i' / 'I') ('l' / 'L') ('l' / 'L') ('i' / 'I') ('s' / 'S') ('e' / 'E') ('c' / 'C') ('o' / 'O') ('n' / 'N') ('d' / 'D') ('s' / 'S'))> Action127)> */
 		func() bool {
-			position1482, tokenIndex1482 := position, tokenIndex
+			position2272, tokenIndex2272 := position, tokenIndex
 			{
-				position1483 := position
+				position2273 := position
 				{
-					position1484 := position
+					position2274 := position
 					{
-						position1485, tokenIndex1485 := position, tokenIndex
-						if buffer[position] != rune('t') {
-							goto l1486
+						position2275, tokenIndex2275 := position, tokenIndex
+						if buffer[position] != rune('m') {
+							goto l2276
 						}
 						position++
-						goto l1485
-					l1486:
-						position, tokenIndex = position1485, tokenIndex1485
-						if buffer[position] != rune('T') {
-							goto l1482
+						goto l2275
+					l2276:
+						position, tokenIndex = position2275, tokenIndex2275
+						if buffer[position] != rune('M') {
+							goto l2272
 						}
 						position++
 					}
-				l1485:
+				l2275:
 					{
-						position1487, tokenIndex1487 := position, tokenIndex
-						if buffer[position] != rune('u') {
-							goto l1488
+						position2277, tokenIndex2277 := position, tokenIndex
+						if buffer[position] != rune('i') {
+							goto l2278
 						}
 						position++
-						goto l1487
-					l1488:
-						position, tokenIndex = position1487, tokenIndex1487
-						if buffer[position] != rune('U') {
-							goto l1482
+						goto l2277
+					l2278:
+						position, tokenIndex = position2277, tokenIndex2277
+						if buffer[position] != rune('I') {
+							goto l2272
 						}
 						position++
 					}
-				l1487:
+				l2277:
 					{
-						position1489, tokenIndex1489 := position, tokenIndex
-						if buffer[position] != rune('p') {
-							goto l1490
+						position2279, tokenIndex2279 := position, tokenIndex
+						if buffer[position] != rune('l') {
+							goto l2280
 						}
 						position++
-						goto l1489
-					l1490:
-						position, tokenIndex = position1489, tokenIndex1489
-						if buffer[position] != rune('P') {
-							goto l1482
+						goto l2279
+					l2280:
+						position, tokenIndex = position2279, tokenIndex2279
+						if buffer[position] != rune('L') {
+							goto l2272
 						}
 						position++
 					}
-				l1489:
+				l2279:
 					{
-						position1491, tokenIndex1491 := position, tokenIndex
+						position2281, tokenIndex2281 := position, tokenIndex
 						if buffer[position] != rune('l') {
-							goto l1492
+							goto l2282
 						}
 						position++
-						goto l1491
-					l1492:
-						position, tokenIndex = position1491, tokenIndex1491
+						goto l2281
+					l2282:
+						position, tokenIndex = position2281, tokenIndex2281
 						if buffer[position] != rune('L') {
-							goto l1482
+							goto l2272
 						}
 						position++
 					}
-				l1491:
+				l2281:
+					{
+						position2283, tokenIndex2283 := position, tokenIndex
+						if buffer[position] != rune('i') {
+							goto l2284
+						}
+						position++
+						goto l2283
+					l2284:
+						position, tokenIndex = position2283, tokenIndex2283
+						if buffer[position] != rune('I') {
+							goto l2272
+						}
+						position++
+					}
+				l2283:
+					{
+						position2285, tokenIndex2285 := position, tokenIndex
+						if buffer[position] != rune('s') {
+							goto l2286
+						}
+						position++
+						goto l2285
+					l2286:
+						position, tokenIndex = position2285, tokenIndex2285
+						if buffer[position] != rune('S') {
+							goto l2272
+						}
+						position++
+					}
+				l2285:
 					{
-						position1493, tokenIndex1493 := position, tokenIndex
+						position2287, tokenIndex2287 := position, tokenIndex
 						if buffer[position] != rune('e') {
-							goto l1494
+							goto l2288
 						}
 						position++
-						goto l1493
-					l1494:
-						position, tokenIndex = position1493, tokenIndex1493
+						goto l2287
+					l2288:
+						position, tokenIndex = position2287, tokenIndex2287
 						if buffer[position] != rune('E') {
-							goto l1482
+							goto l2272
 						}
 						position++
 					}
-				l1493:
+				l2287:
+					{
+						position2289, tokenIndex2289 := position, tokenIndex
+						if buffer[position] != rune('c') {
+							goto l2290
+						}
+						position++
+						goto l2289
+					l2290:
+						position, tokenIndex = position2289, tokenIndex2289
+						if buffer[position] != rune('C') {
+							goto l2272
+						}
+						position++
+					}
+				l2289:
+					{
+						position2291, tokenIndex2291 := position, tokenIndex
+						if buffer[position] != rune('o') {
+							goto l2292
+						}
+						position++
+						goto l2291
+					l2292:
+						position, tokenIndex = position2291, tokenIndex2291
+						if buffer[position] != rune('O') {
+							goto l2272
+						}
+						position++
+					}
+				l2291:
+					{
+						position2293, tokenIndex2293 := position, tokenIndex
+						if buffer[position] != rune('n') {
+							goto l2294
+						}
+						position++
+						goto l2293
+					l2294:
+						position, tokenIndex = position2293, tokenIndex2293
+						if buffer[position] != rune('N') {
+							goto l2272
+						}
+						position++
+					}
+				l2293:
+					{
+						position2295, tokenIndex2295 := position, tokenIndex
+						if buffer[position] != rune('d') {
+							goto l2296
+						}
+						position++
+						goto l2295
+					l2296:
+						position, tokenIndex = position2295, tokenIndex2295
+						if buffer[position] != rune('D') {
+							goto l2272
+						}
+						position++
+					}
+				l2295:
 					{
-						position1495, tokenIndex1495 := position, tokenIndex
+						position2297, tokenIndex2297 := position, tokenIndex
 						if buffer[position] != rune('s') {
-							goto l1496
+							goto l2298
 						}
 						position++
-						goto l1495
-					l1496:
-						position, tokenIndex = position1495, tokenIndex1495
+						goto l2297
+					l2298:
+						position, tokenIndex = position2297, tokenIndex2297
 						if buffer[position] != rune('S') {
-							goto l1482
+							goto l2272
 						}
 						position++
 					}
-				l1495:
-					add(rulePegText, position1484)
+				l2297:
+					add(rulePegText, position2274)
 				}
-				if !_rules[ruleAction95]() {
-					goto l1482
+				if !_rules[ruleAction127]() {
+					goto l2272
 				}
-				add(ruleTUPLES, position1483)
+				add(ruleMILLISECONDS, position2273)
 			}
 			return true
-		l1482:
-			position, tokenIndex = position1482, tokenIndex1482
+		l2272:
+			position, tokenIndex = position2272, tokenIndex2272
 			return false
 		},
-		/* 126 SECONDS <- <(<(('s' / 'S') ('e' / 'E') ('c' / 'C') ('o' / 'O') ('n' / 'N') ('d' / 'D') ('s' / 'S'))> Action96)> */
+		/* 163 InnerJoin <- <(<(('i' / 'I') ('n' / 'N') ('n' / 'N') ('e' / 'E') ('r' / 'R'))> Action128)> */
 		func() bool {
-			position1497, tokenIndex1497 := position, tokenIndex
+			position2299, tokenIndex2299 := position, tokenIndex
 			{
-				position1498 := position
+				position2300 := position
 				{
-					position1499 := position
+					position2301 := position
 					{
-						position1500, tokenIndex1500 := position, tokenIndex
-						if buffer[position] != rune('s') {
-							goto l1501
+						position2302, tokenIndex2302 := position, tokenIndex
+						if buffer[position] != rune('i') {
+							goto l2303
 						}
 						position++
-						goto l1500
-					l1501:
-						position, tokenIndex = position1500, tokenIndex1500
-						if buffer[position] != rune('S') {
-							goto l1497
+						goto l2302
+					l2303:
+						position, tokenIndex = position2302, tokenIndex2302
+						if buffer[position] != rune('I') {
+							goto l2299
+						}
+						position++
+					}
+				l2302:
+					{
+						position2304, tokenIndex2304 := position, tokenIndex
+						if buffer[position] != rune('n') {
+							goto l2305
+						}
+						position++
+						goto l2304
+					l2305:
+						position, tokenIndex = position2304, tokenIndex2304
+						if buffer[position] != rune('N') {
+							goto l2299
+						}
+						position++
+					}
+				l2304:
+					{
+						position2306, tokenIndex2306 := position, tokenIndex
+						if buffer[position] != rune('n') {
+							goto l2307
+						}
+						position++
+						goto l2306
+					l2307:
+						position, tokenIndex = position2306, tokenIndex2306
+						if buffer[position] != rune('N') {
+							goto l2299
 						}
 						position++
 					}
-				l1500:
+				l2306:
 					{
-						position1502, tokenIndex1502 := position, tokenIndex
+						position2308, tokenIndex2308 := position, tokenIndex
 						if buffer[position] != rune('e') {
-							goto l1503
+							goto l2309
 						}
 						position++
-						goto l1502
-					l1503:
-						position, tokenIndex = position1502, tokenIndex1502
+						goto l2308
+					l2309:
+						position, tokenIndex = position2308, tokenIndex2308
 						if buffer[position] != rune('E') {
-							goto l1497
+							goto l2299
 						}
 						position++
 					}
-				l1502:
+				l2308:
 					{
-						position1504, tokenIndex1504 := position, tokenIndex
-						if buffer[position] != rune('c') {
-							goto l1505
+						position2310, tokenIndex2310 := position, tokenIndex
+						if buffer[position] != rune('r') {
+							goto l2311
 						}
 						position++
-						goto l1504
-					l1505:
-						position, tokenIndex = position1504, tokenIndex1504
-						if buffer[position] != rune('C') {
-							goto l1497
+						goto l2310
+					l2311:
+						position, tokenIndex = position2310, tokenIndex2310
+						if buffer[position] != rune('R') {
+							goto l2299
 						}
 						position++
 					}
-				l1504:
+				l2310:
+					add(rulePegText, position2301)
+				}
+				if !_rules[ruleAction128]() {
+					goto l2299
+				}
+				add(ruleInnerJoin, position2300)
+			}
+			return true
+		l2299:
+			position, tokenIndex = position2299, tokenIndex2299
+			return false
+		},
+		/* 164 LeftOuterJoin <- <(<(('l' / 'L') ('e' / 'E') ('f' / 'F') ('t' / 'T') (sp (('o' / 'O') ('u' / 'U') ('t' / 'T') ('e' / 'E') ('r' / 'R')))?)> Action129)> */
+		func() bool {
+			position2312, tokenIndex2312 := position, tokenIndex
+			{
+				position2313 := position
+				{
+					position2314 := position
 					{
-						position1506, tokenIndex1506 := position, tokenIndex
-						if buffer[position] != rune('o') {
-							goto l1507
+						position2315, tokenIndex2315 := position, tokenIndex
+						if buffer[position] != rune('l') {
+							goto l2316
 						}
 						position++
-						goto l1506
-					l1507:
-						position, tokenIndex = position1506, tokenIndex1506
-						if buffer[position] != rune('O') {
-							goto l1497
+						goto l2315
+					l2316:
+						position, tokenIndex = position2315, tokenIndex2315
+						if buffer[position] != rune('L') {
+							goto l2312
 						}
 						position++
 					}
-				l1506:
+				l2315:
 					{
-						position1508, tokenIndex1508 := position, tokenIndex
-						if buffer[position] != rune('n') {
-							goto l1509
+						position2317, tokenIndex2317 := position, tokenIndex
+						if buffer[position] != rune('e') {
+							goto l2318
 						}
 						position++
-						goto l1508
-					l1509:
-						position, tokenIndex = position1508, tokenIndex1508
-						if buffer[position] != rune('N') {
-							goto l1497
+						goto l2317
+					l2318:
+						position, tokenIndex = position2317, tokenIndex2317
+						if buffer[position] != rune('E') {
+							goto l2312
 						}
 						position++
 					}
-				l1508:
+				l2317:
 					{
-						position1510, tokenIndex1510 := position, tokenIndex
-						if buffer[position] != rune('d') {
-							goto l1511
+						position2319, tokenIndex2319 := position, tokenIndex
+						if buffer[position] != rune('f') {
+							goto l2320
 						}
 						position++
-						goto l1510
-					l1511:
-						position, tokenIndex = position1510, tokenIndex1510
-						if buffer[position] != rune('D') {
-							goto l1497
+						goto l2319
+					l2320:
+						position, tokenIndex = position2319, tokenIndex2319
+						if buffer[position] != rune('F') {
+							goto l2312
 						}
 						position++
 					}
-				l1510:
+				l2319:
 					{
-						position1512, tokenIndex1512 := position, tokenIndex
-						if buffer[position] != rune('s') {
-							goto l1513
+						position2321, tokenIndex2321 := position, tokenIndex
+						if buffer[position] != rune('t') {
+							goto l2322
 						}
 						position++
-						goto l1512
-					l1513:
-						position, tokenIndex = position1512, tokenIndex1512
-						if buffer[position] != rune('S') {
-							goto l1497
+						goto l2321
+					l2322:
+						position, tokenIndex = position2321, tokenIndex2321
+						if buffer[position] != rune('T') {
+							goto l2312
 						}
 						position++
 					}
-				l1512:
-					add(rulePegText, position1499)
+				l2321:
+					{
+						position2323, tokenIndex2323 := position, tokenIndex
+						if !_rules[rulesp]() {
+							goto l2323
+						}
+						{
+							position2325, tokenIndex2325 := position, tokenIndex
+							if buffer[position] != rune('o') {
+								goto l2326
+							}
+							position++
+							goto l2325
+						l2326:
+							position, tokenIndex = position2325, tokenIndex2325
+							if buffer[position] != rune('O') {
+								goto l2323
+							}
+							position++
+						}
+					l2325:
+						{
+							position2327, tokenIndex2327 := position, tokenIndex
+							if buffer[position] != rune('u') {
+								goto l2328
+							}
+							position++
+							goto l2327
+						l2328:
+							position, tokenIndex = position2327, tokenIndex2327
+							if buffer[position] != rune('U') {
+								goto l2323
+							}
+							position++
+						}
+					l2327:
+						{
+							position2329, tokenIndex2329 := position, tokenIndex
+							if buffer[position] != rune('t') {
+								goto l2330
+							}
+							position++
+							goto l2329
+						l2330:
+							position, tokenIndex = position2329, tokenIndex2329
+							if buffer[position] != rune('T') {
+								goto l2323
+							}
+							position++
+						}
+					l2329:
+						{
+							position2331, tokenIndex2331 := position, tokenIndex
+							if buffer[position] != rune('e') {
+								goto l2332
+							}
+							position++
+							goto l2331
+						l2332:
+							position, tokenIndex = position2331, tokenIndex2331
+							if buffer[position] != rune('E') {
+								goto l2323
+							}
+							position++
+						}
+					l2331:
+						{
+							position2333, tokenIndex2333 := position, tokenIndex
+							if buffer[position] != rune('r') {
+								goto l2334
+							}
+							position++
+							goto l2333
+						l2334:
+							position, tokenIndex = position2333, tokenIndex2333
+							if buffer[position] != rune('R') {
+								goto l2323
+							}
+							position++
+						}
+					l2333:
+						goto l2324
+					l2323:
+						position, tokenIndex = position2323, tokenIndex2323
+					}
+				l2324:
+					add(rulePegText, position2314)
 				}
-				if !_rules[ruleAction96]() {
-					goto l1497
+				if !_rules[ruleAction129]() {
+					goto l2312
 				}
-				add(ruleSECONDS, position1498)
+				add(ruleLeftOuterJoin, position2313)
 			}
 			return true
-		l1497:
-			position, tokenIndex = position1497, tokenIndex1497
+		l2312:
+			position, tokenIndex = position2312, tokenIndex2312
 			return false
 		},
-		/* 127 MILLISECONDS <- <(<(('m' / 'M') ('i' / 'I') ('l' / 'L') ('l' / 'L') ('i' / 'I') ('s' / 'S') ('e' / 'E') ('c' / 'C') ('o' / 'O') ('n' / 'N') ('d' / 'D') ('s' / 'S'))> Action97)> */
+		/* 165 RightOuterJoin <- <(<(('r' / 'R') ('i' / 'I') ('g' / 'G') ('h' / 'H') ('t' / 'T') (sp (('o' / 'O') ('u' / 'U') ('t' / 'T') ('e' / 'E') ('r' / 'R')))?)> Action130)> */
 		func() bool {
-			position1514, tokenIndex1514 := position, tokenIndex
+			position2335, tokenIndex2335 := position, tokenIndex
 			{
-				position1515 := position
+				position2336 := position
 				{
-					position1516 := position
+					position2337 := position
 					{
-						position1517, tokenIndex1517 := position, tokenIndex
-						if buffer[position] != rune('m') {
-							goto l1518
+						position2338, tokenIndex2338 := position, tokenIndex
+						if buffer[position] != rune('r') {
+							goto l2339
 						}
 						position++
-						goto l1517
-					l1518:
-						position, tokenIndex = position1517, tokenIndex1517
-						if buffer[position] != rune('M') {
-							goto l1514
+						goto l2338
+					l2339:
+						position, tokenIndex = position2338, tokenIndex2338
+						if buffer[position] != rune('R') {
+							goto l2335
 						}
 						position++
 					}
-				l1517:
+				l2338:
 					{
-						position1519, tokenIndex1519 := position, tokenIndex
+						position2340, tokenIndex2340 := position, tokenIndex
 						if buffer[position] != rune('i') {
-							goto l1520
+							goto l2341
 						}
 						position++
-						goto l1519
-					l1520:
-						position, tokenIndex = position1519, tokenIndex1519
+						goto l2340
+					l2341:
+						position, tokenIndex = position2340, tokenIndex2340
 						if buffer[position] != rune('I') {
-							goto l1514
+							goto l2335
 						}
 						position++
 					}
-				l1519:
+				l2340:
 					{
-						position1521, tokenIndex1521 := position, tokenIndex
-						if buffer[position] != rune('l') {
-							goto l1522
+						position2342, tokenIndex2342 := position, tokenIndex
+						if buffer[position] != rune('g') {
+							goto l2343
 						}
 						position++
-						goto l1521
-					l1522:
-						position, tokenIndex = position1521, tokenIndex1521
-						if buffer[position] != rune('L') {
-							goto l1514
+						goto l2342
+					l2343:
+						position, tokenIndex = position2342, tokenIndex2342
+						if buffer[position] != rune('G') {
+							goto l2335
 						}
 						position++
 					}
-				l1521:
+				l2342:
 					{
-						position1523, tokenIndex1523 := position, tokenIndex
-						if buffer[position] != rune('l') {
-							goto l1524
+						position2344, tokenIndex2344 := position, tokenIndex
+						if buffer[position] != rune('h') {
+							goto l2345
 						}
 						position++
-						goto l1523
-					l1524:
-						position, tokenIndex = position1523, tokenIndex1523
-						if buffer[position] != rune('L') {
-							goto l1514
+						goto l2344
+					l2345:
+						position, tokenIndex = position2344, tokenIndex2344
+						if buffer[position] != rune('H') {
+							goto l2335
 						}
 						position++
 					}
-				l1523:
+				l2344:
 					{
-						position1525, tokenIndex1525 := position, tokenIndex
-						if buffer[position] != rune('i') {
-							goto l1526
+						position2346, tokenIndex2346 := position, tokenIndex
+						if buffer[position] != rune('t') {
+							goto l2347
 						}
 						position++
-						goto l1525
-					l1526:
-						position, tokenIndex = position1525, tokenIndex1525
-						if buffer[position] != rune('I') {
-							goto l1514
+						goto l2346
+					l2347:
+						position, tokenIndex = position2346, tokenIndex2346
+						if buffer[position] != rune('T') {
+							goto l2335
 						}
 						position++
 					}
-				l1525:
+				l2346:
 					{
-						position1527, tokenIndex1527 := position, tokenIndex
-						if buffer[position] != rune('s') {
-							goto l1528
+						position2348, tokenIndex2348 := position, tokenIndex
+						if !_rules[rulesp]() {
+							goto l2348
 						}
-						position++
-						goto l1527
-					l1528:
-						position, tokenIndex = position1527, tokenIndex1527
-						if buffer[position] != rune('S') {
-							goto l1514
+						{
+							position2350, tokenIndex2350 := position, tokenIndex
+							if buffer[position] != rune('o') {
+								goto l2351
+							}
+							position++
+							goto l2350
+						l2351:
+							position, tokenIndex = position2350, tokenIndex2350
+							if buffer[position] != rune('O') {
+								goto l2348
+							}
+							position++
 						}
-						position++
-					}
-				l1527:
-					{
-						position1529, tokenIndex1529 := position, tokenIndex
-						if buffer[position] != rune('e') {
-							goto l1530
+					l2350:
+						{
+							position2352, tokenIndex2352 := position, tokenIndex
+							if buffer[position] != rune('u') {
+								goto l2353
+							}
+							position++
+							goto l2352
+						l2353:
+							position, tokenIndex = position2352, tokenIndex2352
+							if buffer[position] != rune('U') {
+								goto l2348
+							}
+							position++
 						}
-						position++
-						goto l1529
-					l1530:
-						position, tokenIndex = position1529, tokenIndex1529
-						if buffer[position] != rune('E') {
-							goto l1514
+					l2352:
+						{
+							position2354, tokenIndex2354 := position, tokenIndex
+							if buffer[position] != rune('t') {
+								goto l2355
+							}
+							position++
+							goto l2354
+						l2355:
+							position, tokenIndex = position2354, tokenIndex2354
+							if buffer[position] != rune('T') {
+								goto l2348
+							}
+							position++
 						}
-						position++
+					l2354:
+						{
+							position2356, tokenIndex2356 := position, tokenIndex
+							if buffer[position] != rune('e') {
+								goto l2357
+							}
+							position++
+							goto l2356
+						l2357:
+							position, tokenIndex = position2356, tokenIndex2356
+							if buffer[position] != rune('E') {
+								goto l2348
+							}
+							position++
+						}
+					l2356:
+						{
+							position2358, tokenIndex2358 := position, tokenIndex
+							if buffer[position] != rune('r') {
+								goto l2359
+							}
+							position++
+							goto l2358
+						l2359:
+							position, tokenIndex = position2358, tokenIndex2358
+							if buffer[position] != rune('R') {
+								goto l2348
+							}
+							position++
+						}
+					l2358:
+						goto l2349
+					l2348:
+						position, tokenIndex = position2348, tokenIndex2348
 					}
-				l1529:
+				l2349:
+					add(rulePegText, position2337)
+				}
+				if !_rules[ruleAction130]() {
+					goto l2335
+				}
+				add(ruleRightOuterJoin, position2336)
+			}
+			return true
+		l2335:
+			position, tokenIndex = position2335, tokenIndex2335
+			return false
+		},
+		/* 166 FullOuterJoin <- <(<(('f' / 'F') ('u' / 'U') ('l' / 'L') ('l' / 'L') (sp (('o' / 'O') ('u' / 'U') ('t' / 'T') ('e' / 'E') ('r' / 'R')))?)> Action131)> */
+		func() bool {
+			position2360, tokenIndex2360 := position, tokenIndex
+			{
+				position2361 := position
+				{
+					position2362 := position
 					{
-						position1531, tokenIndex1531 := position, tokenIndex
-						if buffer[position] != rune('c') {
-							goto l1532
+						position2363, tokenIndex2363 := position, tokenIndex
+						if buffer[position] != rune('f') {
+							goto l2364
 						}
 						position++
-						goto l1531
-					l1532:
-						position, tokenIndex = position1531, tokenIndex1531
-						if buffer[position] != rune('C') {
-							goto l1514
+						goto l2363
+					l2364:
+						position, tokenIndex = position2363, tokenIndex2363
+						if buffer[position] != rune('F') {
+							goto l2360
 						}
 						position++
 					}
-				l1531:
+				l2363:
 					{
-						position1533, tokenIndex1533 := position, tokenIndex
-						if buffer[position] != rune('o') {
-							goto l1534
+						position2365, tokenIndex2365 := position, tokenIndex
+						if buffer[position] != rune('u') {
+							goto l2366
 						}
 						position++
-						goto l1533
-					l1534:
-						position, tokenIndex = position1533, tokenIndex1533
-						if buffer[position] != rune('O') {
-							goto l1514
+						goto l2365
+					l2366:
+						position, tokenIndex = position2365, tokenIndex2365
+						if buffer[position] != rune('U') {
+							goto l2360
 						}
 						position++
 					}
-				l1533:
+				l2365:
 					{
-						position1535, tokenIndex1535 := position, tokenIndex
-						if buffer[position] != rune('n') {
-							goto l1536
+						position2367, tokenIndex2367 := position, tokenIndex
+						if buffer[position] != rune('l') {
+							goto l2368
 						}
 						position++
-						goto l1535
-					l1536:
-						position, tokenIndex = position1535, tokenIndex1535
-						if buffer[position] != rune('N') {
-							goto l1514
+						goto l2367
+					l2368:
+						position, tokenIndex = position2367, tokenIndex2367
+						if buffer[position] != rune('L') {
+							goto l2360
 						}
 						position++
 					}
-				l1535:
+				l2367:
 					{
-						position1537, tokenIndex1537 := position, tokenIndex
-						if buffer[position] != rune('d') {
-							goto l1538
+						position2369, tokenIndex2369 := position, tokenIndex
+						if buffer[position] != rune('l') {
+							goto l2370
 						}
 						position++
-						goto l1537
-					l1538:
-						position, tokenIndex = position1537, tokenIndex1537
-						if buffer[position] != rune('D') {
-							goto l1514
+						goto l2369
+					l2370:
+						position, tokenIndex = position2369, tokenIndex2369
+						if buffer[position] != rune('L') {
+							goto l2360
 						}
 						position++
 					}
-				l1537:
+				l2369:
 					{
-						position1539, tokenIndex1539 := position, tokenIndex
-						if buffer[position] != rune('s') {
-							goto l1540
+						position2371, tokenIndex2371 := position, tokenIndex
+						if !_rules[rulesp]() {
+							goto l2371
 						}
-						position++
-						goto l1539
-					l1540:
-						position, tokenIndex = position1539, tokenIndex1539
-						if buffer[position] != rune('S') {
-							goto l1514
+						{
+							position2373, tokenIndex2373 := position, tokenIndex
+							if buffer[position] != rune('o') {
+								goto l2374
+							}
+							position++
+							goto l2373
+						l2374:
+							position, tokenIndex = position2373, tokenIndex2373
+							if buffer[position] != rune('O') {
+								goto l2371
+							}
+							position++
 						}
-						position++
+					l2373:
+						{
+							position2375, tokenIndex2375 := position, tokenIndex
+							if buffer[position] != rune('u') {
+								goto l2376
+							}
+							position++
+							goto l2375
+						l2376:
+							position, tokenIndex = position2375, tokenIndex2375
+							if buffer[position] != rune('U') {
+								goto l2371
+							}
+							position++
+						}
+					l2375:
+						{
+							position2377, tokenIndex2377 := position, tokenIndex
+							if buffer[position] != rune('t') {
+								goto l2378
+							}
+							position++
+							goto l2377
+						l2378:
+							position, tokenIndex = position2377, tokenIndex2377
+							if buffer[position] != rune('T') {
+								goto l2371
+							}
+							position++
+						}
+					l2377:
+						{
+							position2379, tokenIndex2379 := position, tokenIndex
+							if buffer[position] != rune('e') {
+								goto l2380
+							}
+							position++
+							goto l2379
+						l2380:
+							position, tokenIndex = position2379, tokenIndex2379
+							if buffer[position] != rune('E') {
+								goto l2371
+							}
+							position++
+						}
+					l2379:
+						{
+							position2381, tokenIndex2381 := position, tokenIndex
+							if buffer[position] != rune('r') {
+								goto l2382
+							}
+							position++
+							goto l2381
+						l2382:
+							position, tokenIndex = position2381, tokenIndex2381
+							if buffer[position] != rune('R') {
+								goto l2371
+							}
+							position++
+						}
+					l2381:
+						goto l2372
+					l2371:
+						position, tokenIndex = position2371, tokenIndex2371
 					}
-				l1539:
-					add(rulePegText, position1516)
+				l2372:
+					add(rulePegText, position2362)
 				}
-				if !_rules[ruleAction97]() {
-					goto l1514
+				if !_rules[ruleAction131]() {
+					goto l2360
 				}
-				add(ruleMILLISECONDS, position1515)
+				add(ruleFullOuterJoin, position2361)
 			}
 			return true
-		l1514:
-			position, tokenIndex = position1514, tokenIndex1514
+		l2360:
+			position, tokenIndex = position2360, tokenIndex2360
 			return false
 		},
-		/* 128 Wait <- <(<(('w' / 'W') ('a' / 'A') ('i' / 'I') ('t' / 'T'))> Action98)> */
+		/* 167 Wait <- <(<(('w' / 'W') ('a' / 'A') ('i' / 'I') ('t' / 'T'))> Action132)> */
 		func() bool {
-			position1541, tokenIndex1541 := position, tokenIndex
+			position2383, tokenIndex2383 := position, tokenIndex
 			{
-				position1542 := position
+				position2384 := position
 				{
-					position1543 := position
+					position2385 := position
 					{
-						position1544, tokenIndex1544 := position, tokenIndex
+						position2386, tokenIndex2386 := position, tokenIndex
 						if buffer[position] != rune('w') {
-							goto l1545
+							goto l2387
 						}
 						position++
-						goto l1544
-					l1545:
-						position, tokenIndex = position1544, tokenIndex1544
+						goto l2386
+					l2387:
+						position, tokenIndex = position2386, tokenIndex2386
 						if buffer[position] != rune('W') {
-							goto l1541
+							goto l2383
 						}
 						position++
 					}
-				l1544:
+				l2386:
 					{
-						position1546, tokenIndex1546 := position, tokenIndex
+						position2388, tokenIndex2388 := position, tokenIndex
 						if buffer[position] != rune('a') {
-							goto l1547
+							goto l2389
 						}
 						position++
-						goto l1546
-					l1547:
-						position, tokenIndex = position1546, tokenIndex1546
+						goto l2388
+					l2389:
+						position, tokenIndex = position2388, tokenIndex2388
 						if buffer[position] != rune('A') {
-							goto l1541
+							goto l2383
 						}
 						position++
 					}
-				l1546:
+				l2388:
 					{
-						position1548, tokenIndex1548 := position, tokenIndex
+						position2390, tokenIndex2390 := position, tokenIndex
 						if buffer[position] != rune('i') {
-							goto l1549
+							goto l2391
 						}
 						position++
-						goto l1548
-					l1549:
-						position, tokenIndex = position1548, tokenIndex1548
+						goto l2390
+					l2391:
+						position, tokenIndex = position2390, tokenIndex2390
 						if buffer[position] != rune('I') {
-							goto l1541
+							goto l2383
 						}
 						position++
 					}
-				l1548:
+				l2390:
 					{
-						position1550, tokenIndex1550 := position, tokenIndex
+						position2392, tokenIndex2392 := position, tokenIndex
 						if buffer[position] != rune('t') {
-							goto l1551
+							goto l2393
 						}
 						position++
-						goto l1550
-					l1551:
-						position, tokenIndex = position1550, tokenIndex1550
+						goto l2392
+					l2393:
+						position, tokenIndex = position2392, tokenIndex2392
 						if buffer[position] != rune('T') {
-							goto l1541
+							goto l2383
 						}
 						position++
 					}
-				l1550:
-					add(rulePegText, position1543)
+				l2392:
+					add(rulePegText, position2385)
 				}
-				if !_rules[ruleAction98]() {
-					goto l1541
+				if !_rules[ruleAction132]() {
+					goto l2383
 				}
-				add(ruleWait, position1542)
+				add(ruleWait, position2384)
 			}
 			return true
-		l1541:
-			position, tokenIndex = position1541, tokenIndex1541
+		l2383:
+			position, tokenIndex = position2383, tokenIndex2383
 			return false
 		},
-		/* 129 DropOldest <- <(<(('d' / 'D') ('r' / 'R') ('o' / 'O') ('p' / 'P') sp (('o' / 'O') ('l' / 'L') ('d' / 'D') ('e' / 'E') ('s' / 'S') ('t' / 'T')))> Action99)> */
+		/* 168 DropOldest <- <(<(('d' / 'D') ('r' / 'R') ('o' / 'O') ('p' / 'P') sp (('o' / 'O') ('l' / 'L') ('d' / 'D') ('e' / 'E') ('s' / 'S') ('t' / 'T')))> Action133)> */
 		func() bool {
-			position1552, tokenIndex1552 := position, tokenIndex
+			position2394, tokenIndex2394 := position, tokenIndex
 			{
-				position1553 := position
+				position2395 := position
 				{
-					position1554 := position
+					position2396 := position
 					{
-						position1555, tokenIndex1555 := position, tokenIndex
+						position2397, tokenIndex2397 := position, tokenIndex
 						if buffer[position] != rune('d') {
-							goto l1556
+							goto l2398
 						}
 						position++
-						goto l1555
-					l1556:
-						position, tokenIndex = position1555, tokenIndex1555
+						goto l2397
+					l2398:
+						position, tokenIndex = position2397, tokenIndex2397
 						if buffer[position] != rune('D') {
-							goto l1552
+							goto l2394
 						}
 						position++
 					}
-				l1555:
+				l2397:
 					{
-						position1557, tokenIndex1557 := position, tokenIndex
+						position2399, tokenIndex2399 := position, tokenIndex
 						if buffer[position] != rune('r') {
-							goto l1558
+							goto l2400
 						}
 						position++
-						goto l1557
-					l1558:
-						position, tokenIndex = position1557, tokenIndex1557
+						goto l2399
+					l2400:
+						position, tokenIndex = position2399, tokenIndex2399
 						if buffer[position] != rune('R') {
-							goto l1552
+							goto l2394
 						}
 						position++
 					}
-				l1557:
+				l2399:
 					{
-						position1559, tokenIndex1559 := position, tokenIndex
+						position2401, tokenIndex2401 := position, tokenIndex
 						if buffer[position] != rune('o') {
-							goto l1560
+							goto l2402
 						}
 						position++
-						goto l1559
-					l1560:
-						position, tokenIndex = position1559, tokenIndex1559
+						goto l2401
+					l2402:
+						position, tokenIndex = position2401, tokenIndex2401
 						if buffer[position] != rune('O') {
-							goto l1552
+							goto l2394
 						}
 						position++
 					}
-				l1559:
+				l2401:
 					{
-						position1561, tokenIndex1561 := position, tokenIndex
+						position2403, tokenIndex2403 := position, tokenIndex
 						if buffer[position] != rune('p') {
-							goto l1562
+							goto l2404
 						}
 						position++
-						goto l1561
-					l1562:
-						position, tokenIndex = position1561, tokenIndex1561
+						goto l2403
+					l2404:
+						position, tokenIndex = position2403, tokenIndex2403
 						if buffer[position] != rune('P') {
-							goto l1552
+							goto l2394
 						}
 						position++
 					}
-				l1561:
+				l2403:
 					if !_rules[rulesp]() {
-						goto l1552
+						goto l2394
 					}
 					{
-						position1563, tokenIndex1563 := position, tokenIndex
+						position2405, tokenIndex2405 := position, tokenIndex
 						if buffer[position] != rune('o') {
-							goto l1564
+							goto l2406
 						}
 						position++
-						goto l1563
-					l1564:
-						position, tokenIndex = position1563, tokenIndex1563
+						goto l2405
+					l2406:
+						position, tokenIndex = position2405, tokenIndex2405
 						if buffer[position] != rune('O') {
-							goto l1552
+							goto l2394
 						}
 						position++
 					}
-				l1563:
+				l2405:
 					{
-						position1565, tokenIndex1565 := position, tokenIndex
+						position2407, tokenIndex2407 := position, tokenIndex
 						if buffer[position] != rune('l') {
-							goto l1566
+							goto l2408
 						}
 						position++
-						goto l1565
-					l1566:
-						position, tokenIndex = position1565, tokenIndex1565
+						goto l2407
+					l2408:
+						position, tokenIndex = position2407, tokenIndex2407
 						if buffer[position] != rune('L') {
-							goto l1552
+							goto l2394
 						}
 						position++
 					}
-				l1565:
+				l2407:
 					{
-						position1567, tokenIndex1567 := position, tokenIndex
+						position2409, tokenIndex2409 := position, tokenIndex
 						if buffer[position] != rune('d') {
-							goto l1568
+							goto l2410
 						}
 						position++
-						goto l1567
-					l1568:
-						position, tokenIndex = position1567, tokenIndex1567
+						goto l2409
+					l2410:
+						position, tokenIndex = position2409, tokenIndex2409
 						if buffer[position] != rune('D') {
-							goto l1552
+							goto l2394
 						}
 						position++
 					}
-				l1567:
+				l2409:
 					{
-						position1569, tokenIndex1569 := position, tokenIndex
+						position2411, tokenIndex2411 := position, tokenIndex
 						if buffer[position] != rune('e') {
-							goto l1570
+							goto l2412
 						}
 						position++
-						goto l1569
-					l1570:
-						position, tokenIndex = position1569, tokenIndex1569
+						goto l2411
+					l2412:
+						position, tokenIndex = position2411, tokenIndex2411
 						if buffer[position] != rune('E') {
-							goto l1552
+							goto l2394
 						}
 						position++
 					}
-				l1569:
+				l2411:
 					{
-						position1571, tokenIndex1571 := position, tokenIndex
+						position2413, tokenIndex2413 := position, tokenIndex
 						if buffer[position] != rune('s') {
-							goto l1572
+							goto l2414
 						}
 						position++
-						goto l1571
-					l1572:
-						position, tokenIndex = position1571, tokenIndex1571
+						goto l2413
+					l2414:
+						position, tokenIndex = position2413, tokenIndex2413
 						if buffer[position] != rune('S') {
-							goto l1552
+							goto l2394
 						}
 						position++
 					}
-				l1571:
+				l2413:
 					{
-						position1573, tokenIndex1573 := position, tokenIndex
+						position2415, tokenIndex2415 := position, tokenIndex
 						if buffer[position] != rune('t') {
-							goto l1574
+							goto l2416
 						}
 						position++
-						goto l1573
-					l1574:
-						position, tokenIndex = position1573, tokenIndex1573
+						goto l2415
+					l2416:
+						position, tokenIndex = position2415, tokenIndex2415
 						if buffer[position] != rune('T') {
-							goto l1552
+							goto l2394
 						}
 						position++
 					}
-				l1573:
-					add(rulePegText, position1554)
+				l2415:
+					add(rulePegText, position2396)
 				}
-				if !_rules[ruleAction99]() {
-					goto l1552
+				if !_rules[ruleAction133]() {
+					goto l2394
 				}
-				add(ruleDropOldest, position1553)
+				add(ruleDropOldest, position2395)
 			}
 			return true
-		l1552:
-			position, tokenIndex = position1552, tokenIndex1552
+		l2394:
+			position, tokenIndex = position2394, tokenIndex2394
 			return false
 		},
-		/* 130 DropNewest <- <(<(('d' / 'D') ('r' / 'R') ('o' / 'O') ('p' / 'P') sp (('n' / 'N') ('e' / 'E') ('w' / 'W') ('e' / 'E') ('s' / 'S') ('t' / 'T')))> Action100)> */
+		/* 169 DropNewest <- <(<(('d' / 'D') ('r' / 'R') ('o' / 'O') ('p' / 'P') sp (('n' / 'N') ('e' / 'E') ('w' / 'W') ('e' / 'E') ('s' / 'S') ('t' / 'T')))> Action134)> */
 		func() bool {
-			position1575, tokenIndex1575 := position, tokenIndex
+			position2417, tokenIndex2417 := position, tokenIndex
 			{
-				position1576 := position
+				position2418 := position
 				{
-					position1577 := position
+					position2419 := position
 					{
-						position1578, tokenIndex1578 := position, tokenIndex
+						position2420, tokenIndex2420 := position, tokenIndex
 						if buffer[position] != rune('d') {
-							goto l1579
+							goto l2421
 						}
 						position++
-						goto l1578
-					l1579:
-						position, tokenIndex = position1578, tokenIndex1578
+						goto l2420
+					l2421:
+						position, tokenIndex = position2420, tokenIndex2420
 						if buffer[position] != rune('D') {
-							goto l1575
+							goto l2417
 						}
 						position++
 					}
-				l1578:
+				l2420:
 					{
-						position1580, tokenIndex1580 := position, tokenIndex
+						position2422, tokenIndex2422 := position, tokenIndex
 						if buffer[position] != rune('r') {
-							goto l1581
+							goto l2423
 						}
 						position++
-						goto l1580
-					l1581:
-						position, tokenIndex = position1580, tokenIndex1580
+						goto l2422
+					l2423:
+						position, tokenIndex = position2422, tokenIndex2422
 						if buffer[position] != rune('R') {
-							goto l1575
+							goto l2417
 						}
 						position++
 					}
-				l1580:
+				l2422:
 					{
-						position1582, tokenIndex1582 := position, tokenIndex
+						position2424, tokenIndex2424 := position, tokenIndex
 						if buffer[position] != rune('o') {
-							goto l1583
+							goto l2425
 						}
 						position++
-						goto l1582
-					l1583:
-						position, tokenIndex = position1582, tokenIndex1582
+						goto l2424
+					l2425:
+						position, tokenIndex = position2424, tokenIndex2424
 						if buffer[position] != rune('O') {
-							goto l1575
+							goto l2417
 						}
 						position++
 					}
-				l1582:
+				l2424:
 					{
-						position1584, tokenIndex1584 := position, tokenIndex
+						position2426, tokenIndex2426 := position, tokenIndex
 						if buffer[position] != rune('p') {
-							goto l1585
+							goto l2427
 						}
 						position++
-						goto l1584
-					l1585:
-						position, tokenIndex = position1584, tokenIndex1584
+						goto l2426
+					l2427:
+						position, tokenIndex = position2426, tokenIndex2426
 						if buffer[position] != rune('P') {
-							goto l1575
+							goto l2417
 						}
 						position++
 					}
-				l1584:
+				l2426:
 					if !_rules[rulesp]() {
-						goto l1575
+						goto l2417
 					}
 					{
-						position1586, tokenIndex1586 := position, tokenIndex
+						position2428, tokenIndex2428 := position, tokenIndex
 						if buffer[position] != rune('n') {
-							goto l1587
+							goto l2429
 						}
 						position++
-						goto l1586
-					l1587:
-						position, tokenIndex = position1586, tokenIndex1586
+						goto l2428
+					l2429:
+						position, tokenIndex = position2428, tokenIndex2428
 						if buffer[position] != rune('N') {
-							goto l1575
+							goto l2417
 						}
 						position++
 					}
-				l1586:
+				l2428:
 					{
-						position1588, tokenIndex1588 := position, tokenIndex
+						position2430, tokenIndex2430 := position, tokenIndex
 						if buffer[position] != rune('e') {
-							goto l1589
+							goto l2431
 						}
 						position++
-						goto l1588
-					l1589:
-						position, tokenIndex = position1588, tokenIndex1588
+						goto l2430
+					l2431:
+						position, tokenIndex = position2430, tokenIndex2430
 						if buffer[position] != rune('E') {
-							goto l1575
+							goto l2417
 						}
 						position++
 					}
-				l1588:
+				l2430:
 					{
-						position1590, tokenIndex1590 := position, tokenIndex
+						position2432, tokenIndex2432 := position, tokenIndex
 						if buffer[position] != rune('w') {
-							goto l1591
+							goto l2433
 						}
 						position++
-						goto l1590
-					l1591:
-						position, tokenIndex = position1590, tokenIndex1590
+						goto l2432
+					l2433:
+						position, tokenIndex = position2432, tokenIndex2432
 						if buffer[position] != rune('W') {
-							goto l1575
+							goto l2417
 						}
 						position++
 					}
-				l1590:
+				l2432:
 					{
-						position1592, tokenIndex1592 := position, tokenIndex
+						position2434, tokenIndex2434 := position, tokenIndex
 						if buffer[position] != rune('e') {
-							goto l1593
+							goto l2435
 						}
 						position++
-						goto l1592
-					l1593:
-						position, tokenIndex = position1592, tokenIndex1592
+						goto l2434
+					l2435:
+						position, tokenIndex = position2434, tokenIndex2434
 						if buffer[position] != rune('E') {
-							goto l1575
+							goto l2417
 						}
 						position++
 					}
-				l1592:
+				l2434:
 					{
-						position1594, tokenIndex1594 := position, tokenIndex
+						position2436, tokenIndex2436 := position, tokenIndex
 						if buffer[position] != rune('s') {
-							goto l1595
+							goto l2437
 						}
 						position++
-						goto l1594
-					l1595:
-						position, tokenIndex = position1594, tokenIndex1594
+						goto l2436
+					l2437:
+						position, tokenIndex = position2436, tokenIndex2436
 						if buffer[position] != rune('S') {
-							goto l1575
+							goto l2417
 						}
 						position++
 					}
-				l1594:
+				l2436:
 					{
-						position1596, tokenIndex1596 := position, tokenIndex
+						position2438, tokenIndex2438 := position, tokenIndex
 						if buffer[position] != rune('t') {
-							goto l1597
+							goto l2439
 						}
 						position++
-						goto l1596
-					l1597:
-						position, tokenIndex = position1596, tokenIndex1596
+						goto l2438
+					l2439:
+						position, tokenIndex = position2438, tokenIndex2438
 						if buffer[position] != rune('T') {
-							goto l1575
+							goto l2417
 						}
 						position++
 					}
-				l1596:
-					add(rulePegText, position1577)
+				l2438:
+					add(rulePegText, position2419)
 				}
-				if !_rules[ruleAction100]() {
-					goto l1575
+				if !_rules[ruleAction134]() {
+					goto l2417
 				}
-				add(ruleDropNewest, position1576)
+				add(ruleDropNewest, position2418)
 			}
 			return true
-		l1575:
-			position, tokenIndex = position1575, tokenIndex1575
+		l2417:
+			position, tokenIndex = position2417, tokenIndex2417
 			return false
 		},
-		/* 131 StreamIdentifier <- <(<ident> Action101)> */
+		/* 170 StreamIdentifier <- <(<ident> Action135)> */
 		func() bool {
-			position1598, tokenIndex1598 := position, tokenIndex
+			position2440, tokenIndex2440 := position, tokenIndex
 			{
-				position1599 := position
+				position2441 := position
 				{
-					position1600 := position
+					position2442 := position
 					if !_rules[ruleident]() {
-						goto l1598
+						goto l2440
 					}
-					add(rulePegText, position1600)
+					add(rulePegText, position2442)
 				}
-				if !_rules[ruleAction101]() {
-					goto l1598
+				if !_rules[ruleAction135]() {
+					goto l2440
 				}
-				add(ruleStreamIdentifier, position1599)
+				add(ruleStreamIdentifier, position2441)
 			}
 			return true
-		l1598:
-			position, tokenIndex = position1598, tokenIndex1598
+		l2440:
+			position, tokenIndex = position2440, tokenIndex2440
 			return false
 		},
-		/* 132 SourceSinkType <- <(<ident> Action102)> */
+		/* 171 SourceSinkType <- <(<ident> Action136)> */
 		func() bool {
-			position1601, tokenIndex1601 := position, tokenIndex
+			position2443, tokenIndex2443 := position, tokenIndex
 			{
-				position1602 := position
+				position2444 := position
 				{
-					position1603 := position
+					position2445 := position
 					if !_rules[ruleident]() {
-						goto l1601
+						goto l2443
 					}
-					add(rulePegText, position1603)
+					add(rulePegText, position2445)
 				}
-				if !_rules[ruleAction102]() {
-					goto l1601
+				if !_rules[ruleAction136]() {
+					goto l2443
 				}
-				add(ruleSourceSinkType, position1602)
+				add(ruleSourceSinkType, position2444)
 			}
 			return true
-		l1601:
-			position, tokenIndex = position1601, tokenIndex1601
+		l2443:
+			position, tokenIndex = position2443, tokenIndex2443
 			return false
 		},
-		/* 133 SourceSinkParamKey <- <(<ident> Action103)> */
+		/* 172 SourceSinkParamKey <- <(<ident> Action137)> */
 		func() bool {
-			position1604, tokenIndex1604 := position, tokenIndex
+			position2446, tokenIndex2446 := position, tokenIndex
 			{
-				position1605 := position
+				position2447 := position
 				{
-					position1606 := position
+					position2448 := position
 					if !_rules[ruleident]() {
-						goto l1604
+						goto l2446
 					}
-					add(rulePegText, position1606)
+					add(rulePegText, position2448)
 				}
-				if !_rules[ruleAction103]() {
-					goto l1604
+				if !_rules[ruleAction137]() {
+					goto l2446
 				}
-				add(ruleSourceSinkParamKey, position1605)
+				add(ruleSourceSinkParamKey, position2447)
 			}
 			return true
-		l1604:
-			position, tokenIndex = position1604, tokenIndex1604
+		l2446:
+			position, tokenIndex = position2446, tokenIndex2446
 			return false
 		},
-		/* 134 Paused <- <(<(('p' / 'P') ('a' / 'A') ('u' / 'U') ('s' / 'S') ('e' / 'E') ('d' / 'D'))> Action104)> */
+		/* 173 Paused <- <(<(('p' / 'P') ('a' / 'A') ('u' / 'U') ('s' / 'S') ('e' / 'E') ('d' / 'D'))> Action138)> */
 		func() bool {
-			position1607, tokenIndex1607 := position, tokenIndex
+			position2449, tokenIndex2449 := position, tokenIndex
 			{
-				position1608 := position
+				position2450 := position
 				{
-					position1609 := position
+					position2451 := position
 					{
-						position1610, tokenIndex1610 := position, tokenIndex
+						position2452, tokenIndex2452 := position, tokenIndex
 						if buffer[position] != rune('p') {
-							goto l1611
+							goto l2453
 						}
 						position++
-						goto l1610
-					l1611:
-						position, tokenIndex = position1610, tokenIndex1610
+						goto l2452
+					l2453:
+						position, tokenIndex = position2452, tokenIndex2452
 						if buffer[position] != rune('P') {
-							goto l1607
+							goto l2449
 						}
 						position++
 					}
-				l1610:
+				l2452:
 					{
-						position1612, tokenIndex1612 := position, tokenIndex
+						position2454, tokenIndex2454 := position, tokenIndex
 						if buffer[position] != rune('a') {
-							goto l1613
+							goto l2455
 						}
 						position++
-						goto l1612
-					l1613:
-						position, tokenIndex = position1612, tokenIndex1612
+						goto l2454
+					l2455:
+						position, tokenIndex = position2454, tokenIndex2454
 						if buffer[position] != rune('A') {
-							goto l1607
+							goto l2449
 						}
 						position++
 					}
-				l1612:
+				l2454:
 					{
-						position1614, tokenIndex1614 := position, tokenIndex
+						position2456, tokenIndex2456 := position, tokenIndex
 						if buffer[position] != rune('u') {
-							goto l1615
+							goto l2457
 						}
 						position++
-						goto l1614
-					l1615:
-						position, tokenIndex = position1614, tokenIndex1614
+						goto l2456
+					l2457:
+						position, tokenIndex = position2456, tokenIndex2456
 						if buffer[position] != rune('U') {
-							goto l1607
+							goto l2449
 						}
 						position++
 					}
-				l1614:
+				l2456:
 					{
-						position1616, tokenIndex1616 := position, tokenIndex
+						position2458, tokenIndex2458 := position, tokenIndex
 						if buffer[position] != rune('s') {
-							goto l1617
+							goto l2459
 						}
 						position++
-						goto l1616
-					l1617:
-						position, tokenIndex = position1616, tokenIndex1616
+						goto l2458
+					l2459:
+						position, tokenIndex = position2458, tokenIndex2458
 						if buffer[position] != rune('S') {
-							goto l1607
+							goto l2449
 						}
 						position++
 					}
-				l1616:
+				l2458:
 					{
-						position1618, tokenIndex1618 := position, tokenIndex
+						position2460, tokenIndex2460 := position, tokenIndex
 						if buffer[position] != rune('e') {
-							goto l1619
+							goto l2461
 						}
 						position++
-						goto l1618
-					l1619:
-						position, tokenIndex = position1618, tokenIndex1618
+						goto l2460
+					l2461:
+						position, tokenIndex = position2460, tokenIndex2460
 						if buffer[position] != rune('E') {
-							goto l1607
+							goto l2449
 						}
 						position++
 					}
-				l1618:
+				l2460:
 					{
-						position1620, tokenIndex1620 := position, tokenIndex
+						position2462, tokenIndex2462 := position, tokenIndex
 						if buffer[position] != rune('d') {
-							goto l1621
+							goto l2463
 						}
 						position++
-						goto l1620
-					l1621:
-						position, tokenIndex = position1620, tokenIndex1620
+						goto l2462
+					l2463:
+						position, tokenIndex = position2462, tokenIndex2462
 						if buffer[position] != rune('D') {
-							goto l1607
+							goto l2449
 						}
 						position++
 					}
-				l1620:
-					add(rulePegText, position1609)
+				l2462:
+					add(rulePegText, position2451)
 				}
-				if !_rules[ruleAction104]() {
-					goto l1607
+				if !_rules[ruleAction138]() {
+					goto l2449
 				}
-				add(rulePaused, position1608)
+				add(rulePaused, position2450)
 			}
 			return true
-		l1607:
-			position, tokenIndex = position1607, tokenIndex1607
+		l2449:
+			position, tokenIndex = position2449, tokenIndex2449
 			return false
 		},
-		/* 135 Unpaused <- <(<(('u' / 'U') ('n' / 'N') ('p' / 'P') ('a' / 'A') ('u' / 'U') ('s' / 'S') ('e' / 'E') ('d' / 'D'))> Action105)> */
+		/* 174 Unpaused <- <(<(('u' / 'U') ('n' / 'N') ('p' / 'P') ('a' / 'A') ('u' / 'U') ('s' / 'S') ('e' / 'E') ('d' / 'D'))> Action139)> */
 		func() bool {
-			position1622, tokenIndex1622 := position, tokenIndex
+			position2464, tokenIndex2464 := position, tokenIndex
 			{
-				position1623 := position
+				position2465 := position
 				{
-					position1624 := position
+					position2466 := position
 					{
-						position1625, tokenIndex1625 := position, tokenIndex
+						position2467, tokenIndex2467 := position, tokenIndex
 						if buffer[position] != rune('u') {
-							goto l1626
+							goto l2468
 						}
 						position++
-						goto l1625
-					l1626:
-						position, tokenIndex = position1625, tokenIndex1625
+						goto l2467
+					l2468:
+						position, tokenIndex = position2467, tokenIndex2467
 						if buffer[position] != rune('U') {
-							goto l1622
+							goto l2464
 						}
 						position++
 					}
-				l1625:
+				l2467:
 					{
-						position1627, tokenIndex1627 := position, tokenIndex
+						position2469, tokenIndex2469 := position, tokenIndex
 						if buffer[position] != rune('n') {
-							goto l1628
+							goto l2470
 						}
 						position++
-						goto l1627
-					l1628:
-						position, tokenIndex = position1627, tokenIndex1627
+						goto l2469
+					l2470:
+						position, tokenIndex = position2469, tokenIndex2469
 						if buffer[position] != rune('N') {
-							goto l1622
+							goto l2464
 						}
 						position++
 					}
-				l1627:
+				l2469:
 					{
-						position1629, tokenIndex1629 := position, tokenIndex
+						position2471, tokenIndex2471 := position, tokenIndex
 						if buffer[position] != rune('p') {
-							goto l1630
+							goto l2472
 						}
 						position++
-						goto l1629
-					l1630:
-						position, tokenIndex = position1629, tokenIndex1629
+						goto l2471
+					l2472:
+						position, tokenIndex = position2471, tokenIndex2471
 						if buffer[position] != rune('P') {
-							goto l1622
+							goto l2464
 						}
 						position++
 					}
-				l1629:
+				l2471:
 					{
-						position1631, tokenIndex1631 := position, tokenIndex
+						position2473, tokenIndex2473 := position, tokenIndex
 						if buffer[position] != rune('a') {
-							goto l1632
+							goto l2474
 						}
 						position++
-						goto l1631
-					l1632:
-						position, tokenIndex = position1631, tokenIndex1631
+						goto l2473
+					l2474:
+						position, tokenIndex = position2473, tokenIndex2473
 						if buffer[position] != rune('A') {
-							goto l1622
+							goto l2464
 						}
 						position++
 					}
-				l1631:
+				l2473:
 					{
-						position1633, tokenIndex1633 := position, tokenIndex
+						position2475, tokenIndex2475 := position, tokenIndex
 						if buffer[position] != rune('u') {
-							goto l1634
+							goto l2476
 						}
 						position++
-						goto l1633
-					l1634:
-						position, tokenIndex = position1633, tokenIndex1633
+						goto l2475
+					l2476:
+						position, tokenIndex = position2475, tokenIndex2475
 						if buffer[position] != rune('U') {
-							goto l1622
+							goto l2464
 						}
 						position++
 					}
-				l1633:
+				l2475:
 					{
-						position1635, tokenIndex1635 := position, tokenIndex
+						position2477, tokenIndex2477 := position, tokenIndex
 						if buffer[position] != rune('s') {
-							goto l1636
+							goto l2478
 						}
 						position++
-						goto l1635
-					l1636:
-						position, tokenIndex = position1635, tokenIndex1635
+						goto l2477
+					l2478:
+						position, tokenIndex = position2477, tokenIndex2477
 						if buffer[position] != rune('S') {
-							goto l1622
+							goto l2464
 						}
 						position++
 					}
-				l1635:
+				l2477:
 					{
-						position1637, tokenIndex1637 := position, tokenIndex
+						position2479, tokenIndex2479 := position, tokenIndex
 						if buffer[position] != rune('e') {
-							goto l1638
+							goto l2480
 						}
 						position++
-						goto l1637
-					l1638:
-						position, tokenIndex = position1637, tokenIndex1637
+						goto l2479
+					l2480:
+						position, tokenIndex = position2479, tokenIndex2479
 						if buffer[position] != rune('E') {
-							goto l1622
+							goto l2464
 						}
 						position++
 					}
-				l1637:
+				l2479:
 					{
-						position1639, tokenIndex1639 := position, tokenIndex
+						position2481, tokenIndex2481 := position, tokenIndex
 						if buffer[position] != rune('d') {
-							goto l1640
+							goto l2482
 						}
 						position++
-						goto l1639
-					l1640:
-						position, tokenIndex = position1639, tokenIndex1639
+						goto l2481
+					l2482:
+						position, tokenIndex = position2481, tokenIndex2481
 						if buffer[position] != rune('D') {
-							goto l1622
+							goto l2464
 						}
 						position++
 					}
-				l1639:
-					add(rulePegText, position1624)
+				l2481:
+					add(rulePegText, position2466)
 				}
-				if !_rules[ruleAction105]() {
-					goto l1622
+				if !_rules[ruleAction139]() {
+					goto l2464
 				}
-				add(ruleUnpaused, position1623)
+				add(ruleUnpaused, position2465)
 			}
 			return true
-		l1622:
-			position, tokenIndex = position1622, tokenIndex1622
+		l2464:
+			position, tokenIndex = position2464, tokenIndex2464
 			return false
 		},
-		/* 136 Ascending <- <(<(('a' / 'A') ('s' / 'S') ('c' / 'C'))> Action106)> */
+		/* 175 Ascending <- <(<(('a' / 'A') ('s' / 'S') ('c' / 'C'))> Action140)> */
 		func() bool {
-			position1641, tokenIndex1641 := position, tokenIndex
+			position2483, tokenIndex2483 := position, tokenIndex
 			{
-				position1642 := position
+				position2484 := position
 				{
-					position1643 := position
+					position2485 := position
 					{
-						position1644, tokenIndex1644 := position, tokenIndex
+						position2486, tokenIndex2486 := position, tokenIndex
 						if buffer[position] != rune('a') {
-							goto l1645
+							goto l2487
 						}
 						position++
-						goto l1644
-					l1645:
-						position, tokenIndex = position1644, tokenIndex1644
+						goto l2486
+					l2487:
+						position, tokenIndex = position2486, tokenIndex2486
 						if buffer[position] != rune('A') {
-							goto l1641
+							goto l2483
 						}
 						position++
 					}
-				l1644:
+				l2486:
 					{
-						position1646, tokenIndex1646 := position, tokenIndex
+						position2488, tokenIndex2488 := position, tokenIndex
 						if buffer[position] != rune('s') {
-							goto l1647
+							goto l2489
 						}
 						position++
-						goto l1646
-					l1647:
-						position, tokenIndex = position1646, tokenIndex1646
+						goto l2488
+					l2489:
+						position, tokenIndex = position2488, tokenIndex2488
 						if buffer[position] != rune('S') {
-							goto l1641
+							goto l2483
 						}
 						position++
 					}
-				l1646:
+				l2488:
 					{
-						position1648, tokenIndex1648 := position, tokenIndex
+						position2490, tokenIndex2490 := position, tokenIndex
 						if buffer[position] != rune('c') {
-							goto l1649
+							goto l2491
 						}
 						position++
-						goto l1648
-					l1649:
-						position, tokenIndex = position1648, tokenIndex1648
+						goto l2490
+					l2491:
+						position, tokenIndex = position2490, tokenIndex2490
 						if buffer[position] != rune('C') {
-							goto l1641
+							goto l2483
 						}
 						position++
 					}
-				l1648:
-					add(rulePegText, position1643)
+				l2490:
+					add(rulePegText, position2485)
 				}
-				if !_rules[ruleAction106]() {
-					goto l1641
+				if !_rules[ruleAction140]() {
+					goto l2483
 				}
-				add(ruleAscending, position1642)
+				add(ruleAscending, position2484)
 			}
 			return true
-		l1641:
-			position, tokenIndex = position1641, tokenIndex1641
+		l2483:
+			position, tokenIndex = position2483, tokenIndex2483
 			return false
 		},
-		/* 137 Descending <- <(<(('d' / 'D') ('e' / 'E') ('s' / 'S') ('c' / 'C'))> Action107)> */
+		/* 176 Descending <- <(<(('d' / 'D') ('e' / 'E') ('s' / 'S') ('c' / 'C'))> Action141)> */
 		func() bool {
-			position1650, tokenIndex1650 := position, tokenIndex
+			position2492, tokenIndex2492 := position, tokenIndex
 			{
-				position1651 := position
+				position2493 := position
 				{
-					position1652 := position
+					position2494 := position
 					{
-						position1653, tokenIndex1653 := position, tokenIndex
+						position2495, tokenIndex2495 := position, tokenIndex
 						if buffer[position] != rune('d') {
-							goto l1654
+							goto l2496
 						}
 						position++
-						goto l1653
-					l1654:
-						position, tokenIndex = position1653, tokenIndex1653
+						goto l2495
+					l2496:
+						position, tokenIndex = position2495, tokenIndex2495
 						if buffer[position] != rune('D') {
-							goto l1650
+							goto l2492
 						}
 						position++
 					}
-				l1653:
+				l2495:
 					{
-						position1655, tokenIndex1655 := position, tokenIndex
+						position2497, tokenIndex2497 := position, tokenIndex
 						if buffer[position] != rune('e') {
-							goto l1656
+							goto l2498
 						}
 						position++
-						goto l1655
-					l1656:
-						position, tokenIndex = position1655, tokenIndex1655
+						goto l2497
+					l2498:
+						position, tokenIndex = position2497, tokenIndex2497
 						if buffer[position] != rune('E') {
-							goto l1650
+							goto l2492
 						}
 						position++
 					}
-				l1655:
+				l2497:
 					{
-						position1657, tokenIndex1657 := position, tokenIndex
+						position2499, tokenIndex2499 := position, tokenIndex
 						if buffer[position] != rune('s') {
-							goto l1658
+							goto l2500
 						}
 						position++
-						goto l1657
-					l1658:
-						position, tokenIndex = position1657, tokenIndex1657
+						goto l2499
+					l2500:
+						position, tokenIndex = position2499, tokenIndex2499
 						if buffer[position] != rune('S') {
-							goto l1650
+							goto l2492
 						}
 						position++
 					}
-				l1657:
+				l2499:
 					{
-						position1659, tokenIndex1659 := position, tokenIndex
+						position2501, tokenIndex2501 := position, tokenIndex
 						if buffer[position] != rune('c') {
-							goto l1660
+							goto l2502
 						}
 						position++
-						goto l1659
-					l1660:
-						position, tokenIndex = position1659, tokenIndex1659
+						goto l2501
+					l2502:
+						position, tokenIndex = position2501, tokenIndex2501
 						if buffer[position] != rune('C') {
-							goto l1650
+							goto l2492
 						}
 						position++
 					}
-				l1659:
-					add(rulePegText, position1652)
+				l2501:
+					add(rulePegText, position2494)
 				}
-				if !_rules[ruleAction107]() {
-					goto l1650
+				if !_rules[ruleAction141]() {
+					goto l2492
 				}
-				add(ruleDescending, position1651)
+				add(ruleDescending, position2493)
 			}
 			return true
-		l1650:
-			position, tokenIndex = position1650, tokenIndex1650
+		l2492:
+			position, tokenIndex = position2492, tokenIndex2492
 			return false
 		},
-		/* 138 Type <- <(Bool / Int / Float / String / Blob / Timestamp / Array / Map)> */
+		/* 177 Type <- <(Bool / Int / Float / String / Blob / Timestamp / Array / Map)> */
 		func() bool {
-			position1661, tokenIndex1661 := position, tokenIndex
+			position2503, tokenIndex2503 := position, tokenIndex
 			{
-				position1662 := position
+				position2504 := position
 				{
-					position1663, tokenIndex1663 := position, tokenIndex
+					position2505, tokenIndex2505 := position, tokenIndex
 					if !_rules[ruleBool]() {
-						goto l1664
+						goto l2506
 					}
-					goto l1663
-				l1664:
-					position, tokenIndex = position1663, tokenIndex1663
+					goto l2505
+				l2506:
+					position, tokenIndex = position2505, tokenIndex2505
 					if !_rules[ruleInt]() {
-						goto l1665
+						goto l2507
 					}
-					goto l1663
-				l1665:
-					position, tokenIndex = position1663, tokenIndex1663
+					goto l2505
+				l2507:
+					position, tokenIndex = position2505, tokenIndex2505
 					if !_rules[ruleFloat]() {
-						goto l1666
+						goto l2508
 					}
-					goto l1663
-				l1666:
-					position, tokenIndex = position1663, tokenIndex1663
+					goto l2505
+				l2508:
+					position, tokenIndex = position2505, tokenIndex2505
 					if !_rules[ruleString]() {
-						goto l1667
+						goto l2509
 					}
-					goto l1663
-				l1667:
-					position, tokenIndex = position1663, tokenIndex1663
+					goto l2505
+				l2509:
+					position, tokenIndex = position2505, tokenIndex2505
 					if !_rules[ruleBlob]() {
-						goto l1668
+						goto l2510
 					}
-					goto l1663
-				l1668:
-					position, tokenIndex = position1663, tokenIndex1663
+					goto l2505
+				l2510:
+					position, tokenIndex = position2505, tokenIndex2505
 					if !_rules[ruleTimestamp]() {
-						goto l1669
+						goto l2511
 					}
-					goto l1663
-				l1669:
-					position, tokenIndex = position1663, tokenIndex1663
+					goto l2505
+				l2511:
+					position, tokenIndex = position2505, tokenIndex2505
 					if !_rules[ruleArray]() {
-						goto l1670
+						goto l2512
 					}
-					goto l1663
-				l1670:
-					position, tokenIndex = position1663, tokenIndex1663
+					goto l2505
+				l2512:
+					position, tokenIndex = position2505, tokenIndex2505
 					if !_rules[ruleMap]() {
-						goto l1661
+						goto l2503
 					}
 				}
-			l1663:
-				add(ruleType, position1662)
+			l2505:
+				add(ruleType, position2504)
 			}
 			return true
-		l1661:
-			position, tokenIndex = position1661, tokenIndex1661
+		l2503:
+			position, tokenIndex = position2503, tokenIndex2503
 			return false
 		},
-		/* 139 Bool <- <(<(('b' / 'B') ('o' / 'O') ('o' / 'O') ('l' / 'L'))> Action108)> */
+		/* 178 Bool <- <(<(('b' / 'B') ('o' / 'O') ('o' / 'O') ('l' / 'L'))> Action142)> */
 		func() bool {
-			position1671, tokenIndex1671 := position, tokenIndex
+			position2513, tokenIndex2513 := position, tokenIndex
 			{
-				position1672 := position
+				position2514 := position
 				{
-					position1673 := position
+					position2515 := position
 					{
-						position1674, tokenIndex1674 := position, tokenIndex
+						position2516, tokenIndex2516 := position, tokenIndex
 						if buffer[position] != rune('b') {
-							goto l1675
+							goto l2517
 						}
 						position++
-						goto l1674
-					l1675:
-						position, tokenIndex = position1674, tokenIndex1674
+						goto l2516
+					l2517:
+						position, tokenIndex = position2516, tokenIndex2516
 						if buffer[position] != rune('B') {
-							goto l1671
+							goto l2513
 						}
 						position++
 					}
-				l1674:
+				l2516:
 					{
-						position1676, tokenIndex1676 := position, tokenIndex
+						position2518, tokenIndex2518 := position, tokenIndex
 						if buffer[position] != rune('o') {
-							goto l1677
+							goto l2519
 						}
 						position++
-						goto l1676
-					l1677:
-						position, tokenIndex = position1676, tokenIndex1676
+						goto l2518
+					l2519:
+						position, tokenIndex = position2518, tokenIndex2518
 						if buffer[position] != rune('O') {
-							goto l1671
+							goto l2513
 						}
 						position++
 					}
-				l1676:
+				l2518:
 					{
-						position1678, tokenIndex1678 := position, tokenIndex
+						position2520, tokenIndex2520 := position, tokenIndex
 						if buffer[position] != rune('o') {
-							goto l1679
+							goto l2521
 						}
 						position++
-						goto l1678
-					l1679:
-						position, tokenIndex = position1678, tokenIndex1678
+						goto l2520
+					l2521:
+						position, tokenIndex = position2520, tokenIndex2520
 						if buffer[position] != rune('O') {
-							goto l1671
+							goto l2513
 						}
 						position++
 					}
-				l1678:
+				l2520:
 					{
-						position1680, tokenIndex1680 := position, tokenIndex
+						position2522, tokenIndex2522 := position, tokenIndex
 						if buffer[position] != rune('l') {
-							goto l1681
+							goto l2523
 						}
 						position++
-						goto l1680
-					l1681:
-						position, tokenIndex = position1680, tokenIndex1680
+						goto l2522
+					l2523:
+						position, tokenIndex = position2522, tokenIndex2522
 						if buffer[position] != rune('L') {
-							goto l1671
+							goto l2513
 						}
 						position++
 					}
-				l1680:
-					add(rulePegText, position1673)
+				l2522:
+					add(rulePegText, position2515)
 				}
-				if !_rules[ruleAction108]() {
-					goto l1671
+				if !_rules[ruleAction142]() {
+					goto l2513
 				}
-				add(ruleBool, position1672)
+				add(ruleBool, position2514)
 			}
 			return true
-		l1671:
-			position, tokenIndex = position1671, tokenIndex1671
+		l2513:
+			position, tokenIndex = position2513, tokenIndex2513
 			return false
 		},
-		/* 140 Int <- <(<(('i' / 'I') ('n' / 'N') ('t' / 'T'))> Action109)> */
+		/* 179 Int <- <(<(('i' / 'I') ('n' / 'N') ('t' / 'T'))> Action143)> */
 		func() bool {
-			position1682, tokenIndex1682 := position, tokenIndex
+			position2524, tokenIndex2524 := position, tokenIndex
 			{
-				position1683 := position
+				position2525 := position
 				{
-					position1684 := position
+					position2526 := position
 					{
-						position1685, tokenIndex1685 := position, tokenIndex
+						position2527, tokenIndex2527 := position, tokenIndex
 						if buffer[position] != rune('i') {
-							goto l1686
+							goto l2528
 						}
 						position++
-						goto l1685
-					l1686:
-						position, tokenIndex = position1685, tokenIndex1685
+						goto l2527
+					l2528:
+						position, tokenIndex = position2527, tokenIndex2527
 						if buffer[position] != rune('I') {
-							goto l1682
+							goto l2524
 						}
 						position++
 					}
-				l1685:
+				l2527:
 					{
-						position1687, tokenIndex1687 := position, tokenIndex
+						position2529, tokenIndex2529 := position, tokenIndex
 						if buffer[position] != rune('n') {
-							goto l1688
+							goto l2530
 						}
 						position++
-						goto l1687
-					l1688:
-						position, tokenIndex = position1687, tokenIndex1687
+						goto l2529
+					l2530:
+						position, tokenIndex = position2529, tokenIndex2529
 						if buffer[position] != rune('N') {
-							goto l1682
+							goto l2524
 						}
 						position++
 					}
-				l1687:
+				l2529:
 					{
-						position1689, tokenIndex1689 := position, tokenIndex
+						position2531, tokenIndex2531 := position, tokenIndex
 						if buffer[position] != rune('t') {
-							goto l1690
+							goto l2532
 						}
 						position++
-						goto l1689
-					l1690:
-						position, tokenIndex = position1689, tokenIndex1689
+						goto l2531
+					l2532:
+						position, tokenIndex = position2531, tokenIndex2531
 						if buffer[position] != rune('T') {
-							goto l1682
+							goto l2524
 						}
 						position++
 					}
-				l1689:
-					add(rulePegText, position1684)
+				l2531:
+					add(rulePegText, position2526)
 				}
-				if !_rules[ruleAction109]() {
-					goto l1682
+				if !_rules[ruleAction143]() {
+					goto l2524
 				}
-				add(ruleInt, position1683)
+				add(ruleInt, position2525)
 			}
 			return true
-		l1682:
-			position, tokenIndex = position1682, tokenIndex1682
+		l2524:
+			position, tokenIndex = position2524, tokenIndex2524
 			return false
 		},
-		/* 141 Float <- <(<(('f' / 'F') ('l' / 'L') ('o' / 'O') ('a' / 'A') ('t' / 'T'))> Action110)> */
+		/* 180 Float <- <(<(('f' / 'F') ('l' / 'L') ('o' / 'O') ('a' / 'A') ('t' / 'T'))> Action144)> */
 		func() bool {
-			position1691, tokenIndex1691 := position, tokenIndex
+			position2533, tokenIndex2533 := position, tokenIndex
 			{
-				position1692 := position
+				position2534 := position
 				{
-					position1693 := position
+					position2535 := position
 					{
-						position1694, tokenIndex1694 := position, tokenIndex
+						position2536, tokenIndex2536 := position, tokenIndex
 						if buffer[position] != rune('f') {
-							goto l1695
+							goto l2537
 						}
 						position++
-						goto l1694
-					l1695:
-						position, tokenIndex = position1694, tokenIndex1694
+						goto l2536
+					l2537:
+						position, tokenIndex = position2536, tokenIndex2536
 						if buffer[position] != rune('F') {
-							goto l1691
+							goto l2533
 						}
 						position++
 					}
-				l1694:
+				l2536:
 					{
-						position1696, tokenIndex1696 := position, tokenIndex
+						position2538, tokenIndex2538 := position, tokenIndex
 						if buffer[position] != rune('l') {
-							goto l1697
+							goto l2539
 						}
 						position++
-						goto l1696
-					l1697:
-						position, tokenIndex = position1696, tokenIndex1696
+						goto l2538
+					l2539:
+						position, tokenIndex = position2538, tokenIndex2538
 						if buffer[position] != rune('L') {
-							goto l1691
+							goto l2533
 						}
 						position++
 					}
-				l1696:
+				l2538:
 					{
-						position1698, tokenIndex1698 := position, tokenIndex
+						position2540, tokenIndex2540 := position, tokenIndex
 						if buffer[position] != rune('o') {
-							goto l1699
+							goto l2541
 						}
 						position++
-						goto l1698
-					l1699:
-						position, tokenIndex = position1698, tokenIndex1698
+						goto l2540
+					l2541:
+						position, tokenIndex = position2540, tokenIndex2540
 						if buffer[position] != rune('O') {
-							goto l1691
+							goto l2533
 						}
 						position++
 					}
-				l1698:
+				l2540:
 					{
-						position1700, tokenIndex1700 := position, tokenIndex
+						position2542, tokenIndex2542 := position, tokenIndex
 						if buffer[position] != rune('a') {
-							goto l1701
+							goto l2543
 						}
 						position++
-						goto l1700
-					l1701:
-						position, tokenIndex = position1700, tokenIndex1700
+						goto l2542
+					l2543:
+						position, tokenIndex = position2542, tokenIndex2542
 						if buffer[position] != rune('A') {
-							goto l1691
+							goto l2533
 						}
 						position++
 					}
-				l1700:
+				l2542:
 					{
-						position1702, tokenIndex1702 := position, tokenIndex
+						position2544, tokenIndex2544 := position, tokenIndex
 						if buffer[position] != rune('t') {
-							goto l1703
+							goto l2545
 						}
 						position++
-						goto l1702
-					l1703:
-						position, tokenIndex = position1702, tokenIndex1702
+						goto l2544
+					l2545:
+						position, tokenIndex = position2544, tokenIndex2544
 						if buffer[position] != rune('T') {
-							goto l1691
+							goto l2533
 						}
 						position++
 					}
-				l1702:
-					add(rulePegText, position1693)
+				l2544:
+					add(rulePegText, position2535)
 				}
-				if !_rules[ruleAction110]() {
-					goto l1691
+				if !_rules[ruleAction144]() {
+					goto l2533
 				}
-				add(ruleFloat, position1692)
+				add(ruleFloat, position2534)
 			}
 			return true
-		l1691:
-			position, tokenIndex = position1691, tokenIndex1691
+		l2533:
+			position, tokenIndex = position2533, tokenIndex2533
 			return false
 		},
-		/* 142 String <- <(<(('s' / 'S') ('t' / 'T') ('r' / 'R') ('i' / 'I') ('n' / 'N') ('g' / 'G'))> Action111)> */
+		/* 181 String <- <(<(('s' / 'S') ('t' / 'T') ('r' / 'R') ('i' / 'I') ('n' / 'N') ('g' / 'G'))> Action145)> */
 		func() bool {
-			position1704, tokenIndex1704 := position, tokenIndex
+			position2546, tokenIndex2546 := position, tokenIndex
 			{
-				position1705 := position
+				position2547 := position
 				{
-					position1706 := position
+					position2548 := position
 					{
-						position1707, tokenIndex1707 := position, tokenIndex
+						position2549, tokenIndex2549 := position, tokenIndex
 						if buffer[position] != rune('s') {
-							goto l1708
+							goto l2550
 						}
 						position++
-						goto l1707
-					l1708:
-						position, tokenIndex = position1707, tokenIndex1707
+						goto l2549
+					l2550:
+						position, tokenIndex = position2549, tokenIndex2549
 						if buffer[position] != rune('S') {
-							goto l1704
+							goto l2546
 						}
 						position++
 					}
-				l1707:
+				l2549:
 					{
-						position1709, tokenIndex1709 := position, tokenIndex
+						position2551, tokenIndex2551 := position, tokenIndex
 						if buffer[position] != rune('t') {
-							goto l1710
+							goto l2552
 						}
 						position++
-						goto l1709
-					l1710:
-						position, tokenIndex = position1709, tokenIndex1709
+						goto l2551
+					l2552:
+						position, tokenIndex = position2551, tokenIndex2551
 						if buffer[position] != rune('T') {
-							goto l1704
+							goto l2546
 						}
 						position++
 					}
-				l1709:
+				l2551:
 					{
-						position1711, tokenIndex1711 := position, tokenIndex
+						position2553, tokenIndex2553 := position, tokenIndex
 						if buffer[position] != rune('r') {
-							goto l1712
+							goto l2554
 						}
 						position++
-						goto l1711
-					l1712:
-						position, tokenIndex = position1711, tokenIndex1711
+						goto l2553
+					l2554:
+						position, tokenIndex = position2553, tokenIndex2553
 						if buffer[position] != rune('R') {
-							goto l1704
+							goto l2546
 						}
 						position++
 					}
-				l1711:
+				l2553:
 					{
-						position1713, tokenIndex1713 := position, tokenIndex
+						position2555, tokenIndex2555 := position, tokenIndex
 						if buffer[position] != rune('i') {
-							goto l1714
+							goto l2556
 						}
 						position++
-						goto l1713
-					l1714:
-						position, tokenIndex = position1713, tokenIndex1713
+						goto l2555
+					l2556:
+						position, tokenIndex = position2555, tokenIndex2555
 						if buffer[position] != rune('I') {
-							goto l1704
+							goto l2546
 						}
 						position++
 					}
-				l1713:
+				l2555:
 					{
-						position1715, tokenIndex1715 := position, tokenIndex
+						position2557, tokenIndex2557 := position, tokenIndex
 						if buffer[position] != rune('n') {
-							goto l1716
+							goto l2558
 						}
 						position++
-						goto l1715
-					l1716:
-						position, tokenIndex = position1715, tokenIndex1715
+						goto l2557
+					l2558:
+						position, tokenIndex = position2557, tokenIndex2557
 						if buffer[position] != rune('N') {
-							goto l1704
+							goto l2546
 						}
 						position++
 					}
-				l1715:
+				l2557:
 					{
-						position1717, tokenIndex1717 := position, tokenIndex
+						position2559, tokenIndex2559 := position, tokenIndex
 						if buffer[position] != rune('g') {
-							goto l1718
+							goto l2560
 						}
 						position++
-						goto l1717
-					l1718:
-						position, tokenIndex = position1717, tokenIndex1717
+						goto l2559
+					l2560:
+						position, tokenIndex = position2559, tokenIndex2559
 						if buffer[position] != rune('G') {
-							goto l1704
+							goto l2546
 						}
 						position++
 					}
-				l1717:
-					add(rulePegText, position1706)
+				l2559:
+					add(rulePegText, position2548)
 				}
-				if !_rules[ruleAction111]() {
-					goto l1704
+				if !_rules[ruleAction145]() {
+					goto l2546
 				}
-				add(ruleString, position1705)
+				add(ruleString, position2547)
 			}
 			return true
-		l1704:
-			position, tokenIndex = position1704, tokenIndex1704
+		l2546:
+			position, tokenIndex = position2546, tokenIndex2546
 			return false
 		},
-		/* 143 Blob <- <(<(('b' / 'B') ('l' / 'L') ('o' / 'O') ('b' / 'B'))> Action112)> */
+		/* 182 Blob <- <(<(('b' / 'B') ('l' / 'L') ('o' / 'O') ('b' / 'B'))> Action146)> */
 		func() bool {
-			position1719, tokenIndex1719 := position, tokenIndex
+			position2561, tokenIndex2561 := position, tokenIndex
 			{
-				position1720 := position
+				position2562 := position
 				{
-					position1721 := position
+					position2563 := position
 					{
-						position1722, tokenIndex1722 := position, tokenIndex
+						position2564, tokenIndex2564 := position, tokenIndex
 						if buffer[position] != rune('b') {
-							goto l1723
+							goto l2565
 						}
 						position++
-						goto l1722
-					l1723:
-						position, tokenIndex = position1722, tokenIndex1722
+						goto l2564
+					l2565:
+						position, tokenIndex = position2564, tokenIndex2564
 						if buffer[position] != rune('B') {
-							goto l1719
+							goto l2561
 						}
 						position++
 					}
-				l1722:
+				l2564:
 					{
-						position1724, tokenIndex1724 := position, tokenIndex
+						position2566, tokenIndex2566 := position, tokenIndex
 						if buffer[position] != rune('l') {
-							goto l1725
+							goto l2567
 						}
 						position++
-						goto l1724
-					l1725:
-						position, tokenIndex = position1724, tokenIndex1724
+						goto l2566
+					l2567:
+						position, tokenIndex = position2566, tokenIndex2566
 						if buffer[position] != rune('L') {
-							goto l1719
+							goto l2561
 						}
 						position++
 					}
-				l1724:
+				l2566:
 					{
-						position1726, tokenIndex1726 := position, tokenIndex
+						position2568, tokenIndex2568 := position, tokenIndex
 						if buffer[position] != rune('o') {
-							goto l1727
+							goto l2569
 						}
 						position++
-						goto l1726
-					l1727:
-						position, tokenIndex = position1726, tokenIndex1726
+						goto l2568
+					l2569:
+						position, tokenIndex = position2568, tokenIndex2568
 						if buffer[position] != rune('O') {
-							goto l1719
+							goto l2561
 						}
 						position++
 					}
-				l1726:
+				l2568:
 					{
-						position1728, tokenIndex1728 := position, tokenIndex
+						position2570, tokenIndex2570 := position, tokenIndex
 						if buffer[position] != rune('b') {
-							goto l1729
+							goto l2571
 						}
 						position++
-						goto l1728
-					l1729:
-						position, tokenIndex = position1728, tokenIndex1728
+						goto l2570
+					l2571:
+						position, tokenIndex = position2570, tokenIndex2570
 						if buffer[position] != rune('B') {
-							goto l1719
+							goto l2561
 						}
 						position++
 					}
-				l1728:
-					add(rulePegText, position1721)
+				l2570:
+					add(rulePegText, position2563)
 				}
-				if !_rules[ruleAction112]() {
-					goto l1719
+				if !_rules[ruleAction146]() {
+					goto l2561
 				}
-				add(ruleBlob, position1720)
+				add(ruleBlob, position2562)
 			}
 			return true
-		l1719:
-			position, tokenIndex = position1719, tokenIndex1719
+		l2561:
+			position, tokenIndex = position2561, tokenIndex2561
 			return false
 		},
-		/* 144 Timestamp <- <(<(('t' / 'T') ('i' / 'I') ('m' / 'M') ('e' / 'E') ('s' / 'S') ('t' / 'T') ('a' / 'A') ('m' / 'M') ('p' / 'P'))> Action113)> */
+		/* 183 Timestamp <- <(<(('t' / 'T') ('i' / 'I') ('m' / 'M') ('e' / 'E') ('s' / 'S') ('t' / 'T') ('a' / 'A') ('m' / 'M') ('p' / 'P'))> Action147)> */
 		func() bool {
-			position1730, tokenIndex1730 := position, tokenIndex
+			position2572, tokenIndex2572 := position, tokenIndex
 			{
-				position1731 := position
+				position2573 := position
 				{
-					position1732 := position
+					position2574 := position
 					{
-						position1733, tokenIndex1733 := position, tokenIndex
+						position2575, tokenIndex2575 := position, tokenIndex
 						if buffer[position] != rune('t') {
-							goto l1734
+							goto l2576
 						}
 						position++
-						goto l1733
-					l1734:
-						position, tokenIndex = position1733, tokenIndex1733
+						goto l2575
+					l2576:
+						position, tokenIndex = position2575, tokenIndex2575
 						if buffer[position] != rune('T') {
-							goto l1730
+							goto l2572
 						}
 						position++
 					}
-				l1733:
+				l2575:
 					{
-						position1735, tokenIndex1735 := position, tokenIndex
+						position2577, tokenIndex2577 := position, tokenIndex
 						if buffer[position] != rune('i') {
-							goto l1736
+							goto l2578
 						}
 						position++
-						goto l1735
-					l1736:
-						position, tokenIndex = position1735, tokenIndex1735
+						goto l2577
+					l2578:
+						position, tokenIndex = position2577, tokenIndex2577
 						if buffer[position] != rune('I') {
-							goto l1730
+							goto l2572
 						}
 						position++
 					}
-				l1735:
+				l2577:
 					{
-						position1737, tokenIndex1737 := position, tokenIndex
+						position2579, tokenIndex2579 := position, tokenIndex
 						if buffer[position] != rune('m') {
-							goto l1738
+							goto l2580
 						}
 						position++
-						goto l1737
-					l1738:
-						position, tokenIndex = position1737, tokenIndex1737
+						goto l2579
+					l2580:
+						position, tokenIndex = position2579, tokenIndex2579
 						if buffer[position] != rune('M') {
-							goto l1730
+							goto l2572
 						}
 						position++
 					}
-				l1737:
+				l2579:
 					{
-						position1739, tokenIndex1739 := position, tokenIndex
+						position2581, tokenIndex2581 := position, tokenIndex
 						if buffer[position] != rune('e') {
-							goto l1740
+							goto l2582
 						}
 						position++
-						goto l1739
-					l1740:
-						position, tokenIndex = position1739, tokenIndex1739
+						goto l2581
+					l2582:
+						position, tokenIndex = position2581, tokenIndex2581
 						if buffer[position] != rune('E') {
-							goto l1730
+							goto l2572
 						}
 						position++
 					}
-				l1739:
+				l2581:
 					{
-						position1741, tokenIndex1741 := position, tokenIndex
+						position2583, tokenIndex2583 := position, tokenIndex
 						if buffer[position] != rune('s') {
-							goto l1742
+							goto l2584
 						}
 						position++
-						goto l1741
-					l1742:
-						position, tokenIndex = position1741, tokenIndex1741
+						goto l2583
+					l2584:
+						position, tokenIndex = position2583, tokenIndex2583
 						if buffer[position] != rune('S') {
-							goto l1730
+							goto l2572
 						}
 						position++
 					}
-				l1741:
+				l2583:
 					{
-						position1743, tokenIndex1743 := position, tokenIndex
+						position2585, tokenIndex2585 := position, tokenIndex
 						if buffer[position] != rune('t') {
-							goto l1744
+							goto l2586
 						}
 						position++
-						goto l1743
-					l1744:
-						position, tokenIndex = position1743, tokenIndex1743
+						goto l2585
+					l2586:
+						position, tokenIndex = position2585, tokenIndex2585
 						if buffer[position] != rune('T') {
-							goto l1730
+							goto l2572
 						}
 						position++
 					}
-				l1743:
+				l2585:
 					{
-						position1745, tokenIndex1745 := position, tokenIndex
+						position2587, tokenIndex2587 := position, tokenIndex
 						if buffer[position] != rune('a') {
-							goto l1746
+							goto l2588
 						}
 						position++
-						goto l1745
-					l1746:
-						position, tokenIndex = position1745, tokenIndex1745
+						goto l2587
+					l2588:
+						position, tokenIndex = position2587, tokenIndex2587
 						if buffer[position] != rune('A') {
-							goto l1730
+							goto l2572
 						}
 						position++
 					}
-				l1745:
+				l2587:
 					{
-						position1747, tokenIndex1747 := position, tokenIndex
+						position2589, tokenIndex2589 := position, tokenIndex
 						if buffer[position] != rune('m') {
-							goto l1748
+							goto l2590
 						}
 						position++
-						goto l1747
-					l1748:
-						position, tokenIndex = position1747, tokenIndex1747
+						goto l2589
+					l2590:
+						position, tokenIndex = position2589, tokenIndex2589
 						if buffer[position] != rune('M') {
-							goto l1730
+							goto l2572
 						}
 						position++
 					}
-				l1747:
+				l2589:
 					{
-						position1749, tokenIndex1749 := position, tokenIndex
+						position2591, tokenIndex2591 := position, tokenIndex
 						if buffer[position] != rune('p') {
-							goto l1750
+							goto l2592
 						}
 						position++
-						goto l1749
-					l1750:
-						position, tokenIndex = position1749, tokenIndex1749
+						goto l2591
+					l2592:
+						position, tokenIndex = position2591, tokenIndex2591
 						if buffer[position] != rune('P') {
-							goto l1730
+							goto l2572
 						}
 						position++
 					}
-				l1749:
-					add(rulePegText, position1732)
+				l2591:
+					add(rulePegText, position2574)
 				}
-				if !_rules[ruleAction113]() {
-					goto l1730
+				if !_rules[ruleAction147]() {
+					goto l2572
 				}
-				add(ruleTimestamp, position1731)
+				add(ruleTimestamp, position2573)
 			}
 			return true
-		l1730:
-			position, tokenIndex = position1730, tokenIndex1730
+		l2572:
+			position, tokenIndex = position2572, tokenIndex2572
 			return false
 		},
-		/* 145 Array <- <(<(('a' / 'A') ('r' / 'R') ('r' / 'R') ('a' / 'A') ('y' / 'Y'))> Action114)> */
+		/* 184 Array <- <(<(('a' / 'A') ('r' / 'R') ('r' / 'R') ('a' / 'A') ('y' / 'Y'))> Action148)> */
 		func() bool {
-			position1751, tokenIndex1751 := position, tokenIndex
+			position2593, tokenIndex2593 := position, tokenIndex
 			{
-				position1752 := position
+				position2594 := position
 				{
-					position1753 := position
+					position2595 := position
 					{
-						position1754, tokenIndex1754 := position, tokenIndex
+						position2596, tokenIndex2596 := position, tokenIndex
 						if buffer[position] != rune('a') {
-							goto l1755
+							goto l2597
 						}
 						position++
-						goto l1754
-					l1755:
-						position, tokenIndex = position1754, tokenIndex1754
+						goto l2596
+					l2597:
+						position, tokenIndex = position2596, tokenIndex2596
 						if buffer[position] != rune('A') {
-							goto l1751
+							goto l2593
 						}
 						position++
 					}
-				l1754:
+				l2596:
 					{
-						position1756, tokenIndex1756 := position, tokenIndex
+						position2598, tokenIndex2598 := position, tokenIndex
 						if buffer[position] != rune('r') {
-							goto l1757
+							goto l2599
 						}
 						position++
-						goto l1756
-					l1757:
-						position, tokenIndex = position1756, tokenIndex1756
+						goto l2598
+					l2599:
+						position, tokenIndex = position2598, tokenIndex2598
 						if buffer[position] != rune('R') {
-							goto l1751
+							goto l2593
 						}
 						position++
 					}
-				l1756:
+				l2598:
 					{
-						position1758, tokenIndex1758 := position, tokenIndex
+						position2600, tokenIndex2600 := position, tokenIndex
 						if buffer[position] != rune('r') {
-							goto l1759
+							goto l2601
 						}
 						position++
-						goto l1758
-					l1759:
-						position, tokenIndex = position1758, tokenIndex1758
+						goto l2600
+					l2601:
+						position, tokenIndex = position2600, tokenIndex2600
 						if buffer[position] != rune('R') {
-							goto l1751
+							goto l2593
 						}
 						position++
 					}
-				l1758:
+				l2600:
 					{
-						position1760, tokenIndex1760 := position, tokenIndex
+						position2602, tokenIndex2602 := position, tokenIndex
 						if buffer[position] != rune('a') {
-							goto l1761
+							goto l2603
 						}
 						position++
-						goto l1760
-					l1761:
-						position, tokenIndex = position1760, tokenIndex1760
+						goto l2602
+					l2603:
+						position, tokenIndex = position2602, tokenIndex2602
 						if buffer[position] != rune('A') {
-							goto l1751
+							goto l2593
 						}
 						position++
 					}
-				l1760:
+				l2602:
 					{
-						position1762, tokenIndex1762 := position, tokenIndex
+						position2604, tokenIndex2604 := position, tokenIndex
 						if buffer[position] != rune('y') {
-							goto l1763
+							goto l2605
 						}
 						position++
-						goto l1762
-					l1763:
-						position, tokenIndex = position1762, tokenIndex1762
+						goto l2604
+					l2605:
+						position, tokenIndex = position2604, tokenIndex2604
 						if buffer[position] != rune('Y') {
-							goto l1751
+							goto l2593
 						}
 						position++
 					}
-				l1762:
-					add(rulePegText, position1753)
+				l2604:
+					add(rulePegText, position2595)
 				}
-				if !_rules[ruleAction114]() {
-					goto l1751
+				if !_rules[ruleAction148]() {
+					goto l2593
 				}
-				add(ruleArray, position1752)
+				add(ruleArray, position2594)
 			}
 			return true
-		l1751:
-			position, tokenIndex = position1751, tokenIndex1751
+		l2593:
+			position, tokenIndex = position2593, tokenIndex2593
 			return false
 		},
-		/* 146 Map <- <(<(('m' / 'M') ('a' / 'A') ('p' / 'P'))> Action115)> */
+		/* 185 Map <- <(<(('m' / 'M') ('a' / 'A') ('p' / 'P'))> Action149)> */
 		func() bool {
-			position1764, tokenIndex1764 := position, tokenIndex
+			position2606, tokenIndex2606 := position, tokenIndex
 			{
-				position1765 := position
+				position2607 := position
 				{
-					position1766 := position
+					position2608 := position
 					{
-						position1767, tokenIndex1767 := position, tokenIndex
+						position2609, tokenIndex2609 := position, tokenIndex
 						if buffer[position] != rune('m') {
-							goto l1768
+							goto l2610
 						}
 						position++
-						goto l1767
-					l1768:
-						position, tokenIndex = position1767, tokenIndex1767
+						goto l2609
+					l2610:
+						position, tokenIndex = position2609, tokenIndex2609
 						if buffer[position] != rune('M') {
-							goto l1764
+							goto l2606
 						}
 						position++
 					}
-				l1767:
+				l2609:
 					{
-						position1769, tokenIndex1769 := position, tokenIndex
+						position2611, tokenIndex2611 := position, tokenIndex
 						if buffer[position] != rune('a') {
-							goto l1770
+							goto l2612
 						}
 						position++
-						goto l1769
-					l1770:
-						position, tokenIndex = position1769, tokenIndex1769
+						goto l2611
+					l2612:
+						position, tokenIndex = position2611, tokenIndex2611
 						if buffer[position] != rune('A') {
-							goto l1764
+							goto l2606
 						}
 						position++
 					}
-				l1769:
+				l2611:
 					{
-						position1771, tokenIndex1771 := position, tokenIndex
+						position2613, tokenIndex2613 := position, tokenIndex
 						if buffer[position] != rune('p') {
-							goto l1772
+							goto l2614
 						}
 						position++
-						goto l1771
-					l1772:
-						position, tokenIndex = position1771, tokenIndex1771
+						goto l2613
+					l2614:
+						position, tokenIndex = position2613, tokenIndex2613
 						if buffer[position] != rune('P') {
-							goto l1764
+							goto l2606
 						}
 						position++
 					}
-				l1771:
-					add(rulePegText, position1766)
+				l2613:
+					add(rulePegText, position2608)
 				}
-				if !_rules[ruleAction115]() {
-					goto l1764
+				if !_rules[ruleAction149]() {
+					goto l2606
 				}
-				add(ruleMap, position1765)
+				add(ruleMap, position2607)
 			}
 			return true
-		l1764:
-			position, tokenIndex = position1764, tokenIndex1764
+		l2606:
+			position, tokenIndex = position2606, tokenIndex2606
 			return false
 		},
-		/* 147 Or <- <(<(('o' / 'O') ('r' / 'R'))> Action116)> */
+		/* 186 Or <- <(<(('o' / 'O') ('r' / 'R'))> Action150)> */
 		func() bool {
-			position1773, tokenIndex1773 := position, tokenIndex
+			position2615, tokenIndex2615 := position, tokenIndex
 			{
-				position1774 := position
+				position2616 := position
 				{
-					position1775 := position
+					position2617 := position
 					{
-						position1776, tokenIndex1776 := position, tokenIndex
+						position2618, tokenIndex2618 := position, tokenIndex
 						if buffer[position] != rune('o') {
-							goto l1777
+							goto l2619
 						}
 						position++
-						goto l1776
-					l1777:
-						position, tokenIndex = position1776, tokenIndex1776
+						goto l2618
+					l2619:
+						position, tokenIndex = position2618, tokenIndex2618
 						if buffer[position] != rune('O') {
-							goto l1773
+							goto l2615
 						}
 						position++
 					}
-				l1776:
+				l2618:
 					{
-						position1778, tokenIndex1778 := position, tokenIndex
+						position2620, tokenIndex2620 := position, tokenIndex
 						if buffer[position] != rune('r') {
-							goto l1779
+							goto l2621
 						}
 						position++
-						goto l1778
-					l1779:
-						position, tokenIndex = position1778, tokenIndex1778
+						goto l2620
+					l2621:
+						position, tokenIndex = position2620, tokenIndex2620
 						if buffer[position] != rune('R') {
-							goto l1773
+							goto l2615
 						}
 						position++
 					}
-				l1778:
-					add(rulePegText, position1775)
+				l2620:
+					add(rulePegText, position2617)
 				}
-				if !_rules[ruleAction116]() {
-					goto l1773
+				if !_rules[ruleAction150]() {
+					goto l2615
 				}
-				add(ruleOr, position1774)
+				add(ruleOr, position2616)
 			}
 			return true
-		l1773:
-			position, tokenIndex = position1773, tokenIndex1773
+		l2615:
+			position, tokenIndex = position2615, tokenIndex2615
 			return false
 		},
-		/* 148 And <- <(<(('a' / 'A') ('n' / 'N') ('d' / 'D'))> Action117)> */
+		/* 187 And <- <(<(('a' / 'A') ('n' / 'N') ('d' / 'D'))> Action151)> */
 		func() bool {
-			position1780, tokenIndex1780 := position, tokenIndex
+			position2622, tokenIndex2622 := position, tokenIndex
 			{
-				position1781 := position
+				position2623 := position
 				{
-					position1782 := position
+					position2624 := position
 					{
-						position1783, tokenIndex1783 := position, tokenIndex
+						position2625, tokenIndex2625 := position, tokenIndex
 						if buffer[position] != rune('a') {
-							goto l1784
+							goto l2626
 						}
 						position++
-						goto l1783
-					l1784:
-						position, tokenIndex = position1783, tokenIndex1783
+						goto l2625
+					l2626:
+						position, tokenIndex = position2625, tokenIndex2625
 						if buffer[position] != rune('A') {
-							goto l1780
+							goto l2622
 						}
 						position++
 					}
-				l1783:
+				l2625:
 					{
-						position1785, tokenIndex1785 := position, tokenIndex
+						position2627, tokenIndex2627 := position, tokenIndex
 						if buffer[position] != rune('n') {
-							goto l1786
+							goto l2628
 						}
 						position++
-						goto l1785
-					l1786:
-						position, tokenIndex = position1785, tokenIndex1785
+						goto l2627
+					l2628:
+						position, tokenIndex = position2627, tokenIndex2627
 						if buffer[position] != rune('N') {
-							goto l1780
+							goto l2622
 						}
 						position++
 					}
-				l1785:
+				l2627:
 					{
-						position1787, tokenIndex1787 := position, tokenIndex
+						position2629, tokenIndex2629 := position, tokenIndex
 						if buffer[position] != rune('d') {
-							goto l1788
+							goto l2630
 						}
 						position++
-						goto l1787
-					l1788:
-						position, tokenIndex = position1787, tokenIndex1787
+						goto l2629
+					l2630:
+						position, tokenIndex = position2629, tokenIndex2629
 						if buffer[position] != rune('D') {
-							goto l1780
+							goto l2622
 						}
 						position++
 					}
-				l1787:
-					add(rulePegText, position1782)
+				l2629:
+					add(rulePegText, position2624)
 				}
-				if !_rules[ruleAction117]() {
-					goto l1780
+				if !_rules[ruleAction151]() {
+					goto l2622
 				}
-				add(ruleAnd, position1781)
+				add(ruleAnd, position2623)
 			}
 			return true
-		l1780:
-			position, tokenIndex = position1780, tokenIndex1780
+		l2622:
+			position, tokenIndex = position2622, tokenIndex2622
 			return false
 		},
-		/* 149 Not <- <(<(('n' / 'N') ('o' / 'O') ('t' / 'T'))> Action118)> */
+		/* 188 Not <- <(<(('n' / 'N') ('o' / 'O') ('t' / 'T'))> Action152)> */
 		func() bool {
-			position1789, tokenIndex1789 := position, tokenIndex
+			position2631, tokenIndex2631 := position, tokenIndex
 			{
-				position1790 := position
+				position2632 := position
 				{
-					position1791 := position
+					position2633 := position
 					{
-						position1792, tokenIndex1792 := position, tokenIndex
+						position2634, tokenIndex2634 := position, tokenIndex
 						if buffer[position] != rune('n') {
-							goto l1793
+							goto l2635
 						}
 						position++
-						goto l1792
-					l1793:
-						position, tokenIndex = position1792, tokenIndex1792
+						goto l2634
+					l2635:
+						position, tokenIndex = position2634, tokenIndex2634
 						if buffer[position] != rune('N') {
-							goto l1789
+							goto l2631
 						}
 						position++
 					}
-				l1792:
+				l2634:
 					{
-						position1794, tokenIndex1794 := position, tokenIndex
+						position2636, tokenIndex2636 := position, tokenIndex
 						if buffer[position] != rune('o') {
-							goto l1795
+							goto l2637
 						}
 						position++
-						goto l1794
-					l1795:
-						position, tokenIndex = position1794, tokenIndex1794
+						goto l2636
+					l2637:
+						position, tokenIndex = position2636, tokenIndex2636
 						if buffer[position] != rune('O') {
-							goto l1789
+							goto l2631
 						}
 						position++
 					}
-				l1794:
+				l2636:
 					{
-						position1796, tokenIndex1796 := position, tokenIndex
+						position2638, tokenIndex2638 := position, tokenIndex
 						if buffer[position] != rune('t') {
-							goto l1797
+							goto l2639
 						}
 						position++
-						goto l1796
-					l1797:
-						position, tokenIndex = position1796, tokenIndex1796
+						goto l2638
+					l2639:
+						position, tokenIndex = position2638, tokenIndex2638
 						if buffer[position] != rune('T') {
-							goto l1789
+							goto l2631
 						}
 						position++
 					}
-				l1796:
-					add(rulePegText, position1791)
+				l2638:
+					add(rulePegText, position2633)
 				}
-				if !_rules[ruleAction118]() {
-					goto l1789
+				if !_rules[ruleAction152]() {
+					goto l2631
 				}
-				add(ruleNot, position1790)
+				add(ruleNot, position2632)
 			}
 			return true
-		l1789:
-			position, tokenIndex = position1789, tokenIndex1789
+		l2631:
+			position, tokenIndex = position2631, tokenIndex2631
 			return false
 		},
-		/* 150 Equal <- <(<'='> Action119)> */
+		/* 189 Equal <- <(<'='> Action153)> */
 		func() bool {
-			position1798, tokenIndex1798 := position, tokenIndex
+			position2640, tokenIndex2640 := position, tokenIndex
 			{
-				position1799 := position
+				position2641 := position
 				{
-					position1800 := position
+					position2642 := position
 					if buffer[position] != rune('=') {
-						goto l1798
+						goto l2640
 					}
 					position++
-					add(rulePegText, position1800)
+					add(rulePegText, position2642)
 				}
-				if !_rules[ruleAction119]() {
-					goto l1798
+				if !_rules[ruleAction153]() {
+					goto l2640
 				}
-				add(ruleEqual, position1799)
+				add(ruleEqual, position2641)
 			}
 			return true
-		l1798:
-			position, tokenIndex = position1798, tokenIndex1798
+		l2640:
+			position, tokenIndex = position2640, tokenIndex2640
 			return false
 		},
-		/* 151 Less <- <(<'<'> Action120)> */
+		/* 190 Less <- <(<'<'> Action154)> */
 		func() bool {
-			position1801, tokenIndex1801 := position, tokenIndex
+			position2643, tokenIndex2643 := position, tokenIndex
 			{
-				position1802 := position
+				position2644 := position
 				{
-					position1803 := position
+					position2645 := position
 					if buffer[position] != rune('<') {
-						goto l1801
+						goto l2643
 					}
 					position++
-					add(rulePegText, position1803)
+					add(rulePegText, position2645)
 				}
-				if !_rules[ruleAction120]() {
-					goto l1801
+				if !_rules[ruleAction154]() {
+					goto l2643
 				}
-				add(ruleLess, position1802)
+				add(ruleLess, position2644)
 			}
 			return true
-		l1801:
-			position, tokenIndex = position1801, tokenIndex1801
+		l2643:
+			position, tokenIndex = position2643, tokenIndex2643
 			return false
 		},
-		/* 152 LessOrEqual <- <(<('<' '=')> Action121)> */
+		/* 191 LessOrEqual <- <(<('<' '=')> Action155)> */
 		func() bool {
-			position1804, tokenIndex1804 := position, tokenIndex
+			position2646, tokenIndex2646 := position, tokenIndex
 			{
-				position1805 := position
+				position2647 := position
 				{
-					position1806 := position
+					position2648 := position
 					if buffer[position] != rune('<') {
-						goto l1804
+						goto l2646
 					}
 					position++
 					if buffer[position] != rune('=') {
-						goto l1804
+						goto l2646
 					}
 					position++
-					add(rulePegText, position1806)
+					add(rulePegText, position2648)
 				}
-				if !_rules[ruleAction121]() {
-					goto l1804
+				if !_rules[ruleAction155]() {
+					goto l2646
 				}
-				add(ruleLessOrEqual, position1805)
+				add(ruleLessOrEqual, position2647)
 			}
 			return true
-		l1804:
-			position, tokenIndex = position1804, tokenIndex1804
+		l2646:
+			position, tokenIndex = position2646, tokenIndex2646
 			return false
 		},
-		/* 153 Greater <- <(<'>'> Action122)> */
+		/* 192 Greater <- <(<'>'> Action156)> */
 		func() bool {
-			position1807, tokenIndex1807 := position, tokenIndex
+			position2649, tokenIndex2649 := position, tokenIndex
 			{
-				position1808 := position
+				position2650 := position
 				{
-					position1809 := position
+					position2651 := position
 					if buffer[position] != rune('>') {
-						goto l1807
+						goto l2649
 					}
 					position++
-					add(rulePegText, position1809)
+					add(rulePegText, position2651)
 				}
-				if !_rules[ruleAction122]() {
-					goto l1807
+				if !_rules[ruleAction156]() {
+					goto l2649
 				}
-				add(ruleGreater, position1808)
+				add(ruleGreater, position2650)
 			}
 			return true
-		l1807:
-			position, tokenIndex = position1807, tokenIndex1807
+		l2649:
+			position, tokenIndex = position2649, tokenIndex2649
 			return false
 		},
-		/* 154 GreaterOrEqual <- <(<('>' '=')> Action123)> */
+		/* 193 GreaterOrEqual <- <(<('>' '=')> Action157)> */
 		func() bool {
-			position1810, tokenIndex1810 := position, tokenIndex
+			position2652, tokenIndex2652 := position, tokenIndex
 			{
-				position1811 := position
+				position2653 := position
 				{
-					position1812 := position
+					position2654 := position
 					if buffer[position] != rune('>') {
-						goto l1810
+						goto l2652
 					}
 					position++
 					if buffer[position] != rune('=') {
-						goto l1810
+						goto l2652
 					}
 					position++
-					add(rulePegText, position1812)
+					add(rulePegText, position2654)
 				}
-				if !_rules[ruleAction123]() {
-					goto l1810
+				if !_rules[ruleAction157]() {
+					goto l2652
 				}
-				add(ruleGreaterOrEqual, position1811)
+				add(ruleGreaterOrEqual, position2653)
 			}
 			return true
-		l1810:
-			position, tokenIndex = position1810, tokenIndex1810
+		l2652:
+			position, tokenIndex = position2652, tokenIndex2652
 			return false
 		},
-		/* 155 NotEqual <- <(<(('!' '=') / ('<' '>'))> Action124)> */
+		/* 194 NotEqual <- <(<(('!' '=') / ('<' '>'))> Action158)> */
 		func() bool {
-			position1813, tokenIndex1813 := position, tokenIndex
+			position2655, tokenIndex2655 := position, tokenIndex
 			{
-				position1814 := position
+				position2656 := position
 				{
-					position1815 := position
+					position2657 := position
 					{
-						position1816, tokenIndex1816 := position, tokenIndex
+						position2658, tokenIndex2658 := position, tokenIndex
 						if buffer[position] != rune('!') {
-							goto l1817
+							goto l2659
 						}
 						position++
 						if buffer[position] != rune('=') {
-							goto l1817
+							goto l2659
 						}
 						position++
-						goto l1816
-					l1817:
-						position, tokenIndex = position1816, tokenIndex1816
+						goto l2658
+					l2659:
+						position, tokenIndex = position2658, tokenIndex2658
 						if buffer[position] != rune('<') {
-							goto l1813
+							goto l2655
 						}
 						position++
 						if buffer[position] != rune('>') {
-							goto l1813
+							goto l2655
 						}
 						position++
 					}
-				l1816:
-					add(rulePegText, position1815)
+				l2658:
+					add(rulePegText, position2657)
 				}
-				if !_rules[ruleAction124]() {
-					goto l1813
+				if !_rules[ruleAction158]() {
+					goto l2655
 				}
-				add(ruleNotEqual, position1814)
+				add(ruleNotEqual, position2656)
 			}
 			return true
-		l1813:
-			position, tokenIndex = position1813, tokenIndex1813
+		l2655:
+			position, tokenIndex = position2655, tokenIndex2655
 			return false
 		},
-		/* 156 Concat <- <(<('|' '|')> Action125)> */
+		/* 195 Concat <- <(<('|' '|')> Action159)> */
 		func() bool {
-			position1818, tokenIndex1818 := position, tokenIndex
+			position2660, tokenIndex2660 := position, tokenIndex
 			{
-				position1819 := position
+				position2661 := position
 				{
-					position1820 := position
+					position2662 := position
+					if buffer[position] != rune('|') {
+						goto l2660
+					}
+					position++
 					if buffer[position] != rune('|') {
-						goto l1818
+						goto l2660
+					}
+					position++
+					add(rulePegText, position2662)
+				}
+				if !_rules[ruleAction159]() {
+					goto l2660
+				}
+				add(ruleConcat, position2661)
+			}
+			return true
+		l2660:
+			position, tokenIndex = position2660, tokenIndex2660
+			return false
+		},
+		/* 196 Is <- <(<(('i' / 'I') ('s' / 'S'))> Action160)> */
+		func() bool {
+			position2663, tokenIndex2663 := position, tokenIndex
+			{
+				position2664 := position
+				{
+					position2665 := position
+					{
+						position2666, tokenIndex2666 := position, tokenIndex
+						if buffer[position] != rune('i') {
+							goto l2667
+						}
+						position++
+						goto l2666
+					l2667:
+						position, tokenIndex = position2666, tokenIndex2666
+						if buffer[position] != rune('I') {
+							goto l2663
+						}
+						position++
+					}
+				l2666:
+					{
+						position2668, tokenIndex2668 := position, tokenIndex
+						if buffer[position] != rune('s') {
+							goto l2669
+						}
+						position++
+						goto l2668
+					l2669:
+						position, tokenIndex = position2668, tokenIndex2668
+						if buffer[position] != rune('S') {
+							goto l2663
+						}
+						position++
+					}
+				l2668:
+					add(rulePegText, position2665)
+				}
+				if !_rules[ruleAction160]() {
+					goto l2663
+				}
+				add(ruleIs, position2664)
+			}
+			return true
+		l2663:
+			position, tokenIndex = position2663, tokenIndex2663
+			return false
+		},
+		/* 197 IsNot <- <(<(('i' / 'I') ('s' / 'S') sp (('n' / 'N') ('o' / 'O') ('t' / 'T')))> Action161)> */
+		func() bool {
+			position2670, tokenIndex2670 := position, tokenIndex
+			{
+				position2671 := position
+				{
+					position2672 := position
+					{
+						position2673, tokenIndex2673 := position, tokenIndex
+						if buffer[position] != rune('i') {
+							goto l2674
+						}
+						position++
+						goto l2673
+					l2674:
+						position, tokenIndex = position2673, tokenIndex2673
+						if buffer[position] != rune('I') {
+							goto l2670
+						}
+						position++
+					}
+				l2673:
+					{
+						position2675, tokenIndex2675 := position, tokenIndex
+						if buffer[position] != rune('s') {
+							goto l2676
+						}
+						position++
+						goto l2675
+					l2676:
+						position, tokenIndex = position2675, tokenIndex2675
+						if buffer[position] != rune('S') {
+							goto l2670
+						}
+						position++
+					}
+				l2675:
+					if !_rules[rulesp]() {
+						goto l2670
+					}
+					{
+						position2677, tokenIndex2677 := position, tokenIndex
+						if buffer[position] != rune('n') {
+							goto l2678
+						}
+						position++
+						goto l2677
+					l2678:
+						position, tokenIndex = position2677, tokenIndex2677
+						if buffer[position] != rune('N') {
+							goto l2670
+						}
+						position++
+					}
+				l2677:
+					{
+						position2679, tokenIndex2679 := position, tokenIndex
+						if buffer[position] != rune('o') {
+							goto l2680
+						}
+						position++
+						goto l2679
+					l2680:
+						position, tokenIndex = position2679, tokenIndex2679
+						if buffer[position] != rune('O') {
+							goto l2670
+						}
+						position++
+					}
+				l2679:
+					{
+						position2681, tokenIndex2681 := position, tokenIndex
+						if buffer[position] != rune('t') {
+							goto l2682
+						}
+						position++
+						goto l2681
+					l2682:
+						position, tokenIndex = position2681, tokenIndex2681
+						if buffer[position] != rune('T') {
+							goto l2670
+						}
+						position++
+					}
+				l2681:
+					add(rulePegText, position2672)
+				}
+				if !_rules[ruleAction161]() {
+					goto l2670
+				}
+				add(ruleIsNot, position2671)
+			}
+			return true
+		l2670:
+			position, tokenIndex = position2670, tokenIndex2670
+			return false
+		},
+		/* 198 IsDistinctFrom <- <(<(('i' / 'I') ('s' / 'S') sp (('d' / 'D') ('i' / 'I') ('s' / 'S') ('t' / 'T') ('i' / 'I') ('n' / 'N') ('c' / 'C') ('t' / 'T')) sp (('f' / 'F') ('r' / 'R') ('o' / 'O') ('m' / 'M')))> Action162)> */
+		func() bool {
+			position2683, tokenIndex2683 := position, tokenIndex
+			{
+				position2684 := position
+				{
+					position2685 := position
+					{
+						position2686, tokenIndex2686 := position, tokenIndex
+						if buffer[position] != rune('i') {
+							goto l2687
+						}
+						position++
+						goto l2686
+					l2687:
+						position, tokenIndex = position2686, tokenIndex2686
+						if buffer[position] != rune('I') {
+							goto l2683
+						}
+						position++
+					}
+				l2686:
+					{
+						position2688, tokenIndex2688 := position, tokenIndex
+						if buffer[position] != rune('s') {
+							goto l2689
+						}
+						position++
+						goto l2688
+					l2689:
+						position, tokenIndex = position2688, tokenIndex2688
+						if buffer[position] != rune('S') {
+							goto l2683
+						}
+						position++
+					}
+				l2688:
+					if !_rules[rulesp]() {
+						goto l2683
+					}
+					{
+						position2690, tokenIndex2690 := position, tokenIndex
+						if buffer[position] != rune('d') {
+							goto l2691
+						}
+						position++
+						goto l2690
+					l2691:
+						position, tokenIndex = position2690, tokenIndex2690
+						if buffer[position] != rune('D') {
+							goto l2683
+						}
+						position++
+					}
+				l2690:
+					{
+						position2692, tokenIndex2692 := position, tokenIndex
+						if buffer[position] != rune('i') {
+							goto l2693
+						}
+						position++
+						goto l2692
+					l2693:
+						position, tokenIndex = position2692, tokenIndex2692
+						if buffer[position] != rune('I') {
+							goto l2683
+						}
+						position++
+					}
+				l2692:
+					{
+						position2694, tokenIndex2694 := position, tokenIndex
+						if buffer[position] != rune('s') {
+							goto l2695
+						}
+						position++
+						goto l2694
+					l2695:
+						position, tokenIndex = position2694, tokenIndex2694
+						if buffer[position] != rune('S') {
+							goto l2683
+						}
+						position++
+					}
+				l2694:
+					{
+						position2696, tokenIndex2696 := position, tokenIndex
+						if buffer[position] != rune('t') {
+							goto l2697
+						}
+						position++
+						goto l2696
+					l2697:
+						position, tokenIndex = position2696, tokenIndex2696
+						if buffer[position] != rune('T') {
+							goto l2683
+						}
+						position++
+					}
+				l2696:
+					{
+						position2698, tokenIndex2698 := position, tokenIndex
+						if buffer[position] != rune('i') {
+							goto l2699
+						}
+						position++
+						goto l2698
+					l2699:
+						position, tokenIndex = position2698, tokenIndex2698
+						if buffer[position] != rune('I') {
+							goto l2683
+						}
+						position++
+					}
+				l2698:
+					{
+						position2700, tokenIndex2700 := position, tokenIndex
+						if buffer[position] != rune('n') {
+							goto l2701
+						}
+						position++
+						goto l2700
+					l2701:
+						position, tokenIndex = position2700, tokenIndex2700
+						if buffer[position] != rune('N') {
+							goto l2683
+						}
+						position++
+					}
+				l2700:
+					{
+						position2702, tokenIndex2702 := position, tokenIndex
+						if buffer[position] != rune('c') {
+							goto l2703
+						}
+						position++
+						goto l2702
+					l2703:
+						position, tokenIndex = position2702, tokenIndex2702
+						if buffer[position] != rune('C') {
+							goto l2683
+						}
+						position++
+					}
+				l2702:
+					{
+						position2704, tokenIndex2704 := position, tokenIndex
+						if buffer[position] != rune('t') {
+							goto l2705
+						}
+						position++
+						goto l2704
+					l2705:
+						position, tokenIndex = position2704, tokenIndex2704
+						if buffer[position] != rune('T') {
+							goto l2683
+						}
+						position++
+					}
+				l2704:
+					if !_rules[rulesp]() {
+						goto l2683
+					}
+					{
+						position2706, tokenIndex2706 := position, tokenIndex
+						if buffer[position] != rune('f') {
+							goto l2707
+						}
+						position++
+						goto l2706
+					l2707:
+						position, tokenIndex = position2706, tokenIndex2706
+						if buffer[position] != rune('F') {
+							goto l2683
+						}
+						position++
+					}
+				l2706:
+					{
+						position2708, tokenIndex2708 := position, tokenIndex
+						if buffer[position] != rune('r') {
+							goto l2709
+						}
+						position++
+						goto l2708
+					l2709:
+						position, tokenIndex = position2708, tokenIndex2708
+						if buffer[position] != rune('R') {
+							goto l2683
+						}
+						position++
+					}
+				l2708:
+					{
+						position2710, tokenIndex2710 := position, tokenIndex
+						if buffer[position] != rune('o') {
+							goto l2711
+						}
+						position++
+						goto l2710
+					l2711:
+						position, tokenIndex = position2710, tokenIndex2710
+						if buffer[position] != rune('O') {
+							goto l2683
+						}
+						position++
 					}
-					position++
-					if buffer[position] != rune('|') {
-						goto l1818
+				l2710:
+					{
+						position2712, tokenIndex2712 := position, tokenIndex
+						if buffer[position] != rune('m') {
+							goto l2713
+						}
+						position++
+						goto l2712
+					l2713:
+						position, tokenIndex = position2712, tokenIndex2712
+						if buffer[position] != rune('M') {
+							goto l2683
+						}
+						position++
 					}
-					position++
-					add(rulePegText, position1820)
+				l2712:
+					add(rulePegText, position2685)
 				}
-				if !_rules[ruleAction125]() {
-					goto l1818
+				if !_rules[ruleAction162]() {
+					goto l2683
 				}
-				add(ruleConcat, position1819)
+				add(ruleIsDistinctFrom, position2684)
 			}
 			return true
-		l1818:
-			position, tokenIndex = position1818, tokenIndex1818
+		l2683:
+			position, tokenIndex = position2683, tokenIndex2683
 			return false
 		},
-		/* 157 Is <- <(<(('i' / 'I') ('s' / 'S'))> Action126)> */
+		/* 199 IsNotDistinctFrom <- <(<(('i' / 'I') ('s' / 'S') sp (('n' / 'N') ('o' / 'O') ('t' / 'T')) sp (('d' / 'D') ('i' / 'I') ('s' / 'S') ('t' / 'T') ('i' / 'I') ('n' / 'N') ('c' / 'C') ('t' / 'T')) sp (('f' / 'F') ('r' / 'R') ('o' / 'O') ('m' / 'M')))> Action163)> */
 		func() bool {
-			position1821, tokenIndex1821 := position, tokenIndex
+			position2714, tokenIndex2714 := position, tokenIndex
 			{
-				position1822 := position
+				position2715 := position
 				{
-					position1823 := position
+					position2716 := position
 					{
-						position1824, tokenIndex1824 := position, tokenIndex
+						position2717, tokenIndex2717 := position, tokenIndex
 						if buffer[position] != rune('i') {
-							goto l1825
+							goto l2718
 						}
 						position++
-						goto l1824
-					l1825:
-						position, tokenIndex = position1824, tokenIndex1824
+						goto l2717
+					l2718:
+						position, tokenIndex = position2717, tokenIndex2717
 						if buffer[position] != rune('I') {
-							goto l1821
+							goto l2714
 						}
 						position++
 					}
-				l1824:
+				l2717:
 					{
-						position1826, tokenIndex1826 := position, tokenIndex
+						position2719, tokenIndex2719 := position, tokenIndex
 						if buffer[position] != rune('s') {
-							goto l1827
+							goto l2720
 						}
 						position++
-						goto l1826
-					l1827:
-						position, tokenIndex = position1826, tokenIndex1826
+						goto l2719
+					l2720:
+						position, tokenIndex = position2719, tokenIndex2719
 						if buffer[position] != rune('S') {
-							goto l1821
+							goto l2714
 						}
 						position++
 					}
-				l1826:
-					add(rulePegText, position1823)
-				}
-				if !_rules[ruleAction126]() {
-					goto l1821
-				}
-				add(ruleIs, position1822)
-			}
-			return true
-		l1821:
-			position, tokenIndex = position1821, tokenIndex1821
-			return false
-		},
-		/* 158 IsNot <- <(<(('i' / 'I') ('s' / 'S') sp (('n' / 'N') ('o' / 'O') ('t' / 'T')))> Action127)> */
-		func() bool {
-			position1828, tokenIndex1828 := position, tokenIndex
-			{
-				position1829 := position
-				{
-					position1830 := position
+				l2719:
+					if !_rules[rulesp]() {
+						goto l2714
+					}
+					{
+						position2721, tokenIndex2721 := position, tokenIndex
+						if buffer[position] != rune('n') {
+							goto l2722
+						}
+						position++
+						goto l2721
+					l2722:
+						position, tokenIndex = position2721, tokenIndex2721
+						if buffer[position] != rune('N') {
+							goto l2714
+						}
+						position++
+					}
+				l2721:
+					{
+						position2723, tokenIndex2723 := position, tokenIndex
+						if buffer[position] != rune('o') {
+							goto l2724
+						}
+						position++
+						goto l2723
+					l2724:
+						position, tokenIndex = position2723, tokenIndex2723
+						if buffer[position] != rune('O') {
+							goto l2714
+						}
+						position++
+					}
+				l2723:
+					{
+						position2725, tokenIndex2725 := position, tokenIndex
+						if buffer[position] != rune('t') {
+							goto l2726
+						}
+						position++
+						goto l2725
+					l2726:
+						position, tokenIndex = position2725, tokenIndex2725
+						if buffer[position] != rune('T') {
+							goto l2714
+						}
+						position++
+					}
+				l2725:
+					if !_rules[rulesp]() {
+						goto l2714
+					}
+					{
+						position2727, tokenIndex2727 := position, tokenIndex
+						if buffer[position] != rune('d') {
+							goto l2728
+						}
+						position++
+						goto l2727
+					l2728:
+						position, tokenIndex = position2727, tokenIndex2727
+						if buffer[position] != rune('D') {
+							goto l2714
+						}
+						position++
+					}
+				l2727:
 					{
-						position1831, tokenIndex1831 := position, tokenIndex
+						position2729, tokenIndex2729 := position, tokenIndex
 						if buffer[position] != rune('i') {
-							goto l1832
+							goto l2730
 						}
 						position++
-						goto l1831
-					l1832:
-						position, tokenIndex = position1831, tokenIndex1831
+						goto l2729
+					l2730:
+						position, tokenIndex = position2729, tokenIndex2729
 						if buffer[position] != rune('I') {
-							goto l1828
+							goto l2714
 						}
 						position++
 					}
-				l1831:
+				l2729:
 					{
-						position1833, tokenIndex1833 := position, tokenIndex
+						position2731, tokenIndex2731 := position, tokenIndex
 						if buffer[position] != rune('s') {
-							goto l1834
+							goto l2732
 						}
 						position++
-						goto l1833
-					l1834:
-						position, tokenIndex = position1833, tokenIndex1833
+						goto l2731
+					l2732:
+						position, tokenIndex = position2731, tokenIndex2731
 						if buffer[position] != rune('S') {
-							goto l1828
+							goto l2714
 						}
 						position++
 					}
-				l1833:
-					if !_rules[rulesp]() {
-						goto l1828
+				l2731:
+					{
+						position2733, tokenIndex2733 := position, tokenIndex
+						if buffer[position] != rune('t') {
+							goto l2734
+						}
+						position++
+						goto l2733
+					l2734:
+						position, tokenIndex = position2733, tokenIndex2733
+						if buffer[position] != rune('T') {
+							goto l2714
+						}
+						position++
+					}
+				l2733:
+					{
+						position2735, tokenIndex2735 := position, tokenIndex
+						if buffer[position] != rune('i') {
+							goto l2736
+						}
+						position++
+						goto l2735
+					l2736:
+						position, tokenIndex = position2735, tokenIndex2735
+						if buffer[position] != rune('I') {
+							goto l2714
+						}
+						position++
 					}
+				l2735:
 					{
-						position1835, tokenIndex1835 := position, tokenIndex
+						position2737, tokenIndex2737 := position, tokenIndex
 						if buffer[position] != rune('n') {
-							goto l1836
+							goto l2738
 						}
 						position++
-						goto l1835
-					l1836:
-						position, tokenIndex = position1835, tokenIndex1835
+						goto l2737
+					l2738:
+						position, tokenIndex = position2737, tokenIndex2737
 						if buffer[position] != rune('N') {
-							goto l1828
+							goto l2714
 						}
 						position++
 					}
-				l1835:
+				l2737:
 					{
-						position1837, tokenIndex1837 := position, tokenIndex
-						if buffer[position] != rune('o') {
-							goto l1838
+						position2739, tokenIndex2739 := position, tokenIndex
+						if buffer[position] != rune('c') {
+							goto l2740
 						}
 						position++
-						goto l1837
-					l1838:
-						position, tokenIndex = position1837, tokenIndex1837
-						if buffer[position] != rune('O') {
-							goto l1828
+						goto l2739
+					l2740:
+						position, tokenIndex = position2739, tokenIndex2739
+						if buffer[position] != rune('C') {
+							goto l2714
 						}
 						position++
 					}
-				l1837:
+				l2739:
 					{
-						position1839, tokenIndex1839 := position, tokenIndex
+						position2741, tokenIndex2741 := position, tokenIndex
 						if buffer[position] != rune('t') {
-							goto l1840
+							goto l2742
 						}
 						position++
-						goto l1839
-					l1840:
-						position, tokenIndex = position1839, tokenIndex1839
+						goto l2741
+					l2742:
+						position, tokenIndex = position2741, tokenIndex2741
 						if buffer[position] != rune('T') {
-							goto l1828
+							goto l2714
+						}
+						position++
+					}
+				l2741:
+					if !_rules[rulesp]() {
+						goto l2714
+					}
+					{
+						position2743, tokenIndex2743 := position, tokenIndex
+						if buffer[position] != rune('f') {
+							goto l2744
+						}
+						position++
+						goto l2743
+					l2744:
+						position, tokenIndex = position2743, tokenIndex2743
+						if buffer[position] != rune('F') {
+							goto l2714
+						}
+						position++
+					}
+				l2743:
+					{
+						position2745, tokenIndex2745 := position, tokenIndex
+						if buffer[position] != rune('r') {
+							goto l2746
+						}
+						position++
+						goto l2745
+					l2746:
+						position, tokenIndex = position2745, tokenIndex2745
+						if buffer[position] != rune('R') {
+							goto l2714
+						}
+						position++
+					}
+				l2745:
+					{
+						position2747, tokenIndex2747 := position, tokenIndex
+						if buffer[position] != rune('o') {
+							goto l2748
+						}
+						position++
+						goto l2747
+					l2748:
+						position, tokenIndex = position2747, tokenIndex2747
+						if buffer[position] != rune('O') {
+							goto l2714
+						}
+						position++
+					}
+				l2747:
+					{
+						position2749, tokenIndex2749 := position, tokenIndex
+						if buffer[position] != rune('m') {
+							goto l2750
+						}
+						position++
+						goto l2749
+					l2750:
+						position, tokenIndex = position2749, tokenIndex2749
+						if buffer[position] != rune('M') {
+							goto l2714
 						}
 						position++
 					}
-				l1839:
-					add(rulePegText, position1830)
+				l2749:
+					add(rulePegText, position2716)
 				}
-				if !_rules[ruleAction127]() {
-					goto l1828
+				if !_rules[ruleAction163]() {
+					goto l2714
 				}
-				add(ruleIsNot, position1829)
+				add(ruleIsNotDistinctFrom, position2715)
 			}
 			return true
-		l1828:
-			position, tokenIndex = position1828, tokenIndex1828
+		l2714:
+			position, tokenIndex = position2714, tokenIndex2714
 			return false
 		},
-		/* 159 Plus <- <(<'+'> Action128)> */
+		/* 200 Plus <- <(<'+'> Action164)> */
 		func() bool {
-			position1841, tokenIndex1841 := position, tokenIndex
+			position2751, tokenIndex2751 := position, tokenIndex
 			{
-				position1842 := position
+				position2752 := position
 				{
-					position1843 := position
+					position2753 := position
 					if buffer[position] != rune('+') {
-						goto l1841
+						goto l2751
 					}
 					position++
-					add(rulePegText, position1843)
+					add(rulePegText, position2753)
 				}
-				if !_rules[ruleAction128]() {
-					goto l1841
+				if !_rules[ruleAction164]() {
+					goto l2751
 				}
-				add(rulePlus, position1842)
+				add(rulePlus, position2752)
 			}
 			return true
-		l1841:
-			position, tokenIndex = position1841, tokenIndex1841
+		l2751:
+			position, tokenIndex = position2751, tokenIndex2751
 			return false
 		},
-		/* 160 Minus <- <(<'-'> Action129)> */
+		/* 201 Minus <- <(<'-'> Action165)> */
 		func() bool {
-			position1844, tokenIndex1844 := position, tokenIndex
+			position2754, tokenIndex2754 := position, tokenIndex
 			{
-				position1845 := position
+				position2755 := position
 				{
-					position1846 := position
+					position2756 := position
 					if buffer[position] != rune('-') {
-						goto l1844
+						goto l2754
 					}
 					position++
-					add(rulePegText, position1846)
+					add(rulePegText, position2756)
 				}
-				if !_rules[ruleAction129]() {
-					goto l1844
+				if !_rules[ruleAction165]() {
+					goto l2754
 				}
-				add(ruleMinus, position1845)
+				add(ruleMinus, position2755)
 			}
 			return true
-		l1844:
-			position, tokenIndex = position1844, tokenIndex1844
+		l2754:
+			position, tokenIndex = position2754, tokenIndex2754
 			return false
 		},
-		/* 161 Multiply <- <(<'*'> Action130)> */
+		/* 202 Multiply <- <(<'*'> Action166)> */
 		func() bool {
-			position1847, tokenIndex1847 := position, tokenIndex
+			position2757, tokenIndex2757 := position, tokenIndex
 			{
-				position1848 := position
+				position2758 := position
 				{
-					position1849 := position
+					position2759 := position
 					if buffer[position] != rune('*') {
-						goto l1847
+						goto l2757
 					}
 					position++
-					add(rulePegText, position1849)
+					add(rulePegText, position2759)
 				}
-				if !_rules[ruleAction130]() {
-					goto l1847
+				if !_rules[ruleAction166]() {
+					goto l2757
 				}
-				add(ruleMultiply, position1848)
+				add(ruleMultiply, position2758)
 			}
 			return true
-		l1847:
-			position, tokenIndex = position1847, tokenIndex1847
+		l2757:
+			position, tokenIndex = position2757, tokenIndex2757
 			return false
 		},
-		/* 162 Divide <- <(<'/'> Action131)> */
+		/* 203 Divide <- <(<'/'> Action167)> */
 		func() bool {
-			position1850, tokenIndex1850 := position, tokenIndex
+			position2760, tokenIndex2760 := position, tokenIndex
 			{
-				position1851 := position
+				position2761 := position
 				{
-					position1852 := position
+					position2762 := position
 					if buffer[position] != rune('/') {
-						goto l1850
+						goto l2760
 					}
 					position++
-					add(rulePegText, position1852)
+					add(rulePegText, position2762)
 				}
-				if !_rules[ruleAction131]() {
-					goto l1850
+				if !_rules[ruleAction167]() {
+					goto l2760
 				}
-				add(ruleDivide, position1851)
+				add(ruleDivide, position2761)
 			}
 			return true
-		l1850:
-			position, tokenIndex = position1850, tokenIndex1850
+		l2760:
+			position, tokenIndex = position2760, tokenIndex2760
 			return false
 		},
-		/* 163 Modulo <- <(<'%'> Action132)> */
+		/* 204 Modulo <- <(<'%'> Action168)> */
 		func() bool {
-			position1853, tokenIndex1853 := position, tokenIndex
+			position2763, tokenIndex2763 := position, tokenIndex
 			{
-				position1854 := position
+				position2764 := position
 				{
-					position1855 := position
+					position2765 := position
 					if buffer[position] != rune('%') {
-						goto l1853
+						goto l2763
 					}
 					position++
-					add(rulePegText, position1855)
+					add(rulePegText, position2765)
 				}
-				if !_rules[ruleAction132]() {
-					goto l1853
+				if !_rules[ruleAction168]() {
+					goto l2763
 				}
-				add(ruleModulo, position1854)
+				add(ruleModulo, position2764)
 			}
 			return true
-		l1853:
-			position, tokenIndex = position1853, tokenIndex1853
+		l2763:
+			position, tokenIndex = position2763, tokenIndex2763
 			return false
 		},
-		/* 164 UnaryMinus <- <(<'-'> Action133)> */
+		/* 205 UnaryMinus <- <(<'-'> Action169)> */
 		func() bool {
-			position1856, tokenIndex1856 := position, tokenIndex
+			position2766, tokenIndex2766 := position, tokenIndex
 			{
-				position1857 := position
+				position2767 := position
 				{
-					position1858 := position
+					position2768 := position
 					if buffer[position] != rune('-') {
-						goto l1856
+						goto l2766
 					}
 					position++
-					add(rulePegText, position1858)
+					add(rulePegText, position2768)
 				}
-				if !_rules[ruleAction133]() {
-					goto l1856
+				if !_rules[ruleAction169]() {
+					goto l2766
 				}
-				add(ruleUnaryMinus, position1857)
+				add(ruleUnaryMinus, position2767)
 			}
 			return true
-		l1856:
-			position, tokenIndex = position1856, tokenIndex1856
+		l2766:
+			position, tokenIndex = position2766, tokenIndex2766
 			return false
 		},
-		/* 165 Identifier <- <(<ident> Action134)> */
+		/* 206 Identifier <- <(<ident> Action170)> */
 		func() bool {
-			position1859, tokenIndex1859 := position, tokenIndex
+			position2769, tokenIndex2769 := position, tokenIndex
 			{
-				position1860 := position
+				position2770 := position
 				{
-					position1861 := position
+					position2771 := position
 					if !_rules[ruleident]() {
-						goto l1859
+						goto l2769
 					}
-					add(rulePegText, position1861)
+					add(rulePegText, position2771)
 				}
-				if !_rules[ruleAction134]() {
-					goto l1859
+				if !_rules[ruleAction170]() {
+					goto l2769
 				}
-				add(ruleIdentifier, position1860)
+				add(ruleIdentifier, position2770)
 			}
 			return true
-		l1859:
-			position, tokenIndex = position1859, tokenIndex1859
+		l2769:
+			position, tokenIndex = position2769, tokenIndex2769
 			return false
 		},
-		/* 166 TargetIdentifier <- <(<('*' / jsonSetPath)> Action135)> */
+		/* 207 TargetIdentifier <- <(<('*' / jsonSetPath)> Action171)> */
 		func() bool {
-			position1862, tokenIndex1862 := position, tokenIndex
+			position2772, tokenIndex2772 := position, tokenIndex
 			{
-				position1863 := position
+				position2773 := position
 				{
-					position1864 := position
+					position2774 := position
 					{
-						position1865, tokenIndex1865 := position, tokenIndex
+						position2775, tokenIndex2775 := position, tokenIndex
 						if buffer[position] != rune('*') {
-							goto l1866
+							goto l2776
 						}
 						position++
-						goto l1865
-					l1866:
-						position, tokenIndex = position1865, tokenIndex1865
+						goto l2775
+					l2776:
+						position, tokenIndex = position2775, tokenIndex2775
 						if !_rules[rulejsonSetPath]() {
-							goto l1862
+							goto l2772
 						}
 					}
-				l1865:
-					add(rulePegText, position1864)
+				l2775:
+					add(rulePegText, position2774)
 				}
-				if !_rules[ruleAction135]() {
-					goto l1862
+				if !_rules[ruleAction171]() {
+					goto l2772
 				}
-				add(ruleTargetIdentifier, position1863)
+				add(ruleTargetIdentifier, position2773)
 			}
 			return true
-		l1862:
-			position, tokenIndex = position1862, tokenIndex1862
+		l2772:
+			position, tokenIndex = position2772, tokenIndex2772
 			return false
 		},
-		/* 167 ident <- <(([a-z] / [A-Z]) ([a-z] / [A-Z] / [0-9] / '_')*)> */
+		/* 208 ident <- <(([a-z] / [A-Z]) ([a-z] / [A-Z] / [0-9] / '_')*)> */
 		func() bool {
-			position1867, tokenIndex1867 := position, tokenIndex
+			position2777, tokenIndex2777 := position, tokenIndex
 			{
-				position1868 := position
+				position2778 := position
 				{
-					position1869, tokenIndex1869 := position, tokenIndex
+					position2779, tokenIndex2779 := position, tokenIndex
 					if c := buffer[position]; c < rune('a') || c > rune('z') {
-						goto l1870
+						goto l2780
 					}
 					position++
-					goto l1869
-				l1870:
-					position, tokenIndex = position1869, tokenIndex1869
+					goto l2779
+				l2780:
+					position, tokenIndex = position2779, tokenIndex2779
 					if c := buffer[position]; c < rune('A') || c > rune('Z') {
-						goto l1867
+						goto l2777
 					}
 					position++
 				}
-			l1869:
-			l1871:
+			l2779:
+			l2781:
 				{
-					position1872, tokenIndex1872 := position, tokenIndex
+					position2782, tokenIndex2782 := position, tokenIndex
 					{
-						position1873, tokenIndex1873 := position, tokenIndex
+						position2783, tokenIndex2783 := position, tokenIndex
 						if c := buffer[position]; c < rune('a') || c > rune('z') {
-							goto l1874
+							goto l2784
 						}
 						position++
-						goto l1873
-					l1874:
-						position, tokenIndex = position1873, tokenIndex1873
+						goto l2783
+					l2784:
+						position, tokenIndex = position2783, tokenIndex2783
 						if c := buffer[position]; c < rune('A') || c > rune('Z') {
-							goto l1875
+							goto l2785
 						}
 						position++
-						goto l1873
-					l1875:
-						position, tokenIndex = position1873, tokenIndex1873
+						goto l2783
+					l2785:
+						position, tokenIndex = position2783, tokenIndex2783
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l1876
+							goto l2786
 						}
 						position++
-						goto l1873
-					l1876:
-						position, tokenIndex = position1873, tokenIndex1873
+						goto l2783
+					l2786:
+						position, tokenIndex = position2783, tokenIndex2783
 						if buffer[position] != rune('_') {
-							goto l1872
+							goto l2782
 						}
 						position++
 					}
-				l1873:
-					goto l1871
-				l1872:
-					position, tokenIndex = position1872, tokenIndex1872
+				l2783:
+					goto l2781
+				l2782:
+					position, tokenIndex = position2782, tokenIndex2782
 				}
-				add(ruleident, position1868)
+				add(ruleident, position2778)
 			}
 			return true
-		l1867:
-			position, tokenIndex = position1867, tokenIndex1867
+		l2777:
+			position, tokenIndex = position2777, tokenIndex2777
 			return false
 		},
-		/* 168 jsonGetPath <- <(jsonPathHead jsonGetPathNonHead*)> */
+		/* 209 jsonGetPath <- <(jsonPathHead jsonGetPathNonHead*)> */
 		func() bool {
-			position1877, tokenIndex1877 := position, tokenIndex
+			position2787, tokenIndex2787 := position, tokenIndex
 			{
-				position1878 := position
+				position2788 := position
 				if !_rules[rulejsonPathHead]() {
-					goto l1877
+					goto l2787
 				}
-			l1879:
+			l2789:
 				{
-					position1880, tokenIndex1880 := position, tokenIndex
+					position2790, tokenIndex2790 := position, tokenIndex
 					if !_rules[rulejsonGetPathNonHead]() {
-						goto l1880
+						goto l2790
 					}
-					goto l1879
-				l1880:
-					position, tokenIndex = position1880, tokenIndex1880
+					goto l2789
+				l2790:
+					position, tokenIndex = position2790, tokenIndex2790
 				}
-				add(rulejsonGetPath, position1878)
+				add(rulejsonGetPath, position2788)
 			}
 			return true
-		l1877:
-			position, tokenIndex = position1877, tokenIndex1877
+		l2787:
+			position, tokenIndex = position2787, tokenIndex2787
 			return false
 		},
-		/* 169 jsonSetPath <- <(jsonPathHead jsonSetPathNonHead*)> */
+		/* 210 jsonSetPath <- <(jsonPathHead jsonSetPathNonHead*)> */
 		func() bool {
-			position1881, tokenIndex1881 := position, tokenIndex
+			position2791, tokenIndex2791 := position, tokenIndex
 			{
-				position1882 := position
+				position2792 := position
 				if !_rules[rulejsonPathHead]() {
-					goto l1881
+					goto l2791
 				}
-			l1883:
+			l2793:
 				{
-					position1884, tokenIndex1884 := position, tokenIndex
+					position2794, tokenIndex2794 := position, tokenIndex
 					if !_rules[rulejsonSetPathNonHead]() {
-						goto l1884
+						goto l2794
 					}
-					goto l1883
-				l1884:
-					position, tokenIndex = position1884, tokenIndex1884
+					goto l2793
+				l2794:
+					position, tokenIndex = position2794, tokenIndex2794
 				}
-				add(rulejsonSetPath, position1882)
+				add(rulejsonSetPath, position2792)
 			}
 			return true
-		l1881:
-			position, tokenIndex = position1881, tokenIndex1881
+		l2791:
+			position, tokenIndex = position2791, tokenIndex2791
 			return false
 		},
-		/* 170 jsonPathHead <- <(jsonMapAccessString / jsonMapAccessBracket)> */
+		/* 211 jsonPathHead <- <(jsonMapAccessString / jsonMapAccessBracket)> */
 		func() bool {
-			position1885, tokenIndex1885 := position, tokenIndex
+			position2795, tokenIndex2795 := position, tokenIndex
 			{
-				position1886 := position
+				position2796 := position
 				{
-					position1887, tokenIndex1887 := position, tokenIndex
+					position2797, tokenIndex2797 := position, tokenIndex
 					if !_rules[rulejsonMapAccessString]() {
-						goto l1888
+						goto l2798
 					}
-					goto l1887
-				l1888:
-					position, tokenIndex = position1887, tokenIndex1887
+					goto l2797
+				l2798:
+					position, tokenIndex = position2797, tokenIndex2797
 					if !_rules[rulejsonMapAccessBracket]() {
-						goto l1885
+						goto l2795
 					}
 				}
-			l1887:
-				add(rulejsonPathHead, position1886)
+			l2797:
+				add(rulejsonPathHead, position2796)
 			}
 			return true
-		l1885:
-			position, tokenIndex = position1885, tokenIndex1885
+		l2795:
+			position, tokenIndex = position2795, tokenIndex2795
 			return false
 		},
-		/* 171 jsonGetPathNonHead <- <(jsonMapMultipleLevel / jsonMapSingleLevel / jsonArrayFullSlice / jsonArrayPartialSlice / jsonArraySlice / jsonArrayAccess)> */
+		/* 212 jsonGetPathNonHead <- <(jsonMapMultipleLevel / jsonMapSingleLevel / jsonArrayFullSlice / jsonArrayPartialSlice / jsonArraySlice / jsonArrayAccess)> */
 		func() bool {
-			position1889, tokenIndex1889 := position, tokenIndex
+			position2799, tokenIndex2799 := position, tokenIndex
 			{
-				position1890 := position
+				position2800 := position
 				{
-					position1891, tokenIndex1891 := position, tokenIndex
+					position2801, tokenIndex2801 := position, tokenIndex
 					if !_rules[rulejsonMapMultipleLevel]() {
-						goto l1892
+						goto l2802
 					}
-					goto l1891
-				l1892:
-					position, tokenIndex = position1891, tokenIndex1891
+					goto l2801
+				l2802:
+					position, tokenIndex = position2801, tokenIndex2801
 					if !_rules[rulejsonMapSingleLevel]() {
-						goto l1893
+						goto l2803
 					}
-					goto l1891
-				l1893:
-					position, tokenIndex = position1891, tokenIndex1891
+					goto l2801
+				l2803:
+					position, tokenIndex = position2801, tokenIndex2801
 					if !_rules[rulejsonArrayFullSlice]() {
-						goto l1894
+						goto l2804
 					}
-					goto l1891
-				l1894:
-					position, tokenIndex = position1891, tokenIndex1891
+					goto l2801
+				l2804:
+					position, tokenIndex = position2801, tokenIndex2801
 					if !_rules[rulejsonArrayPartialSlice]() {
-						goto l1895
+						goto l2805
 					}
-					goto l1891
-				l1895:
-					position, tokenIndex = position1891, tokenIndex1891
+					goto l2801
+				l2805:
+					position, tokenIndex = position2801, tokenIndex2801
 					if !_rules[rulejsonArraySlice]() {
-						goto l1896
+						goto l2806
 					}
-					goto l1891
-				l1896:
-					position, tokenIndex = position1891, tokenIndex1891
+					goto l2801
+				l2806:
+					position, tokenIndex = position2801, tokenIndex2801
 					if !_rules[rulejsonArrayAccess]() {
-						goto l1889
+						goto l2799
 					}
 				}
-			l1891:
-				add(rulejsonGetPathNonHead, position1890)
+			l2801:
+				add(rulejsonGetPathNonHead, position2800)
 			}
 			return true
-		l1889:
-			position, tokenIndex = position1889, tokenIndex1889
+		l2799:
+			position, tokenIndex = position2799, tokenIndex2799
 			return false
 		},
-		/* 172 jsonSetPathNonHead <- <(jsonMapSingleLevel / jsonNonNegativeArrayAccess)> */
+		/* 213 jsonSetPathNonHead <- <(jsonMapSingleLevel / jsonNonNegativeArrayAccess)> */
 		func() bool {
-			position1897, tokenIndex1897 := position, tokenIndex
+			position2807, tokenIndex2807 := position, tokenIndex
 			{
-				position1898 := position
+				position2808 := position
 				{
-					position1899, tokenIndex1899 := position, tokenIndex
+					position2809, tokenIndex2809 := position, tokenIndex
 					if !_rules[rulejsonMapSingleLevel]() {
-						goto l1900
+						goto l2810
 					}
-					goto l1899
-				l1900:
-					position, tokenIndex = position1899, tokenIndex1899
+					goto l2809
+				l2810:
+					position, tokenIndex = position2809, tokenIndex2809
 					if !_rules[rulejsonNonNegativeArrayAccess]() {
-						goto l1897
+						goto l2807
 					}
 				}
-			l1899:
-				add(rulejsonSetPathNonHead, position1898)
+			l2809:
+				add(rulejsonSetPathNonHead, position2808)
 			}
 			return true
-		l1897:
-			position, tokenIndex = position1897, tokenIndex1897
+		l2807:
+			position, tokenIndex = position2807, tokenIndex2807
 			return false
 		},
-		/* 173 jsonMapSingleLevel <- <(('.' jsonMapAccessString) / jsonMapAccessBracket)> */
+		/* 214 jsonMapSingleLevel <- <(('.' jsonMapAccessString) / jsonMapAccessBracket)> */
 		func() bool {
-			position1901, tokenIndex1901 := position, tokenIndex
+			position2811, tokenIndex2811 := position, tokenIndex
 			{
-				position1902 := position
+				position2812 := position
 				{
-					position1903, tokenIndex1903 := position, tokenIndex
+					position2813, tokenIndex2813 := position, tokenIndex
 					if buffer[position] != rune('.') {
-						goto l1904
+						goto l2814
 					}
 					position++
 					if !_rules[rulejsonMapAccessString]() {
-						goto l1904
+						goto l2814
 					}
-					goto l1903
-				l1904:
-					position, tokenIndex = position1903, tokenIndex1903
+					goto l2813
+				l2814:
+					position, tokenIndex = position2813, tokenIndex2813
 					if !_rules[rulejsonMapAccessBracket]() {
-						goto l1901
+						goto l2811
 					}
 				}
-			l1903:
-				add(rulejsonMapSingleLevel, position1902)
+			l2813:
+				add(rulejsonMapSingleLevel, position2812)
 			}
 			return true
-		l1901:
-			position, tokenIndex = position1901, tokenIndex1901
+		l2811:
+			position, tokenIndex = position2811, tokenIndex2811
 			return false
 		},
-		/* 174 jsonMapMultipleLevel <- <('.' '.' (jsonMapAccessString / jsonMapAccessBracket))> */
+		/* 215 jsonMapMultipleLevel <- <('.' '.' (jsonMapAccessString / jsonMapAccessBracket))> */
 		func() bool {
-			position1905, tokenIndex1905 := position, tokenIndex
+			position2815, tokenIndex2815 := position, tokenIndex
 			{
-				position1906 := position
+				position2816 := position
 				if buffer[position] != rune('.') {
-					goto l1905
+					goto l2815
 				}
 				position++
 				if buffer[position] != rune('.') {
-					goto l1905
+					goto l2815
 				}
 				position++
 				{
-					position1907, tokenIndex1907 := position, tokenIndex
+					position2817, tokenIndex2817 := position, tokenIndex
 					if !_rules[rulejsonMapAccessString]() {
-						goto l1908
+						goto l2818
 					}
-					goto l1907
-				l1908:
-					position, tokenIndex = position1907, tokenIndex1907
+					goto l2817
+				l2818:
+					position, tokenIndex = position2817, tokenIndex2817
 					if !_rules[rulejsonMapAccessBracket]() {
-						goto l1905
+						goto l2815
 					}
 				}
-			l1907:
-				add(rulejsonMapMultipleLevel, position1906)
+			l2817:
+				add(rulejsonMapMultipleLevel, position2816)
 			}
 			return true
-		l1905:
-			position, tokenIndex = position1905, tokenIndex1905
+		l2815:
+			position, tokenIndex = position2815, tokenIndex2815
 			return false
 		},
-		/* 175 jsonMapAccessString <- <<(([a-z] / [A-Z]) ([a-z] / [A-Z] / [0-9] / '_')*)>> */
+		/* 216 jsonMapAccessString <- <<(([a-z] / [A-Z]) ([a-z] / [A-Z] / [0-9] / '_')*)>> */
 		func() bool {
-			position1909, tokenIndex1909 := position, tokenIndex
+			position2819, tokenIndex2819 := position, tokenIndex
 			{
-				position1910 := position
+				position2820 := position
 				{
-					position1911 := position
+					position2821 := position
 					{
-						position1912, tokenIndex1912 := position, tokenIndex
+						position2822, tokenIndex2822 := position, tokenIndex
 						if c := buffer[position]; c < rune('a') || c > rune('z') {
-							goto l1913
+							goto l2823
 						}
 						position++
-						goto l1912
-					l1913:
-						position, tokenIndex = position1912, tokenIndex1912
+						goto l2822
+					l2823:
+						position, tokenIndex = position2822, tokenIndex2822
 						if c := buffer[position]; c < rune('A') || c > rune('Z') {
-							goto l1909
+							goto l2819
 						}
 						position++
 					}
-				l1912:
-				l1914:
+				l2822:
+				l2824:
 					{
-						position1915, tokenIndex1915 := position, tokenIndex
+						position2825, tokenIndex2825 := position, tokenIndex
 						{
-							position1916, tokenIndex1916 := position, tokenIndex
+							position2826, tokenIndex2826 := position, tokenIndex
 							if c := buffer[position]; c < rune('a') || c > rune('z') {
-								goto l1917
+								goto l2827
 							}
 							position++
-							goto l1916
-						l1917:
-							position, tokenIndex = position1916, tokenIndex1916
+							goto l2826
+						l2827:
+							position, tokenIndex = position2826, tokenIndex2826
 							if c := buffer[position]; c < rune('A') || c > rune('Z') {
-								goto l1918
+								goto l2828
 							}
 							position++
-							goto l1916
-						l1918:
-							position, tokenIndex = position1916, tokenIndex1916
+							goto l2826
+						l2828:
+							position, tokenIndex = position2826, tokenIndex2826
 							if c := buffer[position]; c < rune('0') || c > rune('9') {
-								goto l1919
+								goto l2829
 							}
 							position++
-							goto l1916
-						l1919:
-							position, tokenIndex = position1916, tokenIndex1916
+							goto l2826
+						l2829:
+							position, tokenIndex = position2826, tokenIndex2826
 							if buffer[position] != rune('_') {
-								goto l1915
+								goto l2825
 							}
 							position++
 						}
-					l1916:
-						goto l1914
-					l1915:
-						position, tokenIndex = position1915, tokenIndex1915
+					l2826:
+						goto l2824
+					l2825:
+						position, tokenIndex = position2825, tokenIndex2825
 					}
-					add(rulePegText, position1911)
+					add(rulePegText, position2821)
 				}
-				add(rulejsonMapAccessString, position1910)
+				add(rulejsonMapAccessString, position2820)
 			}
 			return true
-		l1909:
-			position, tokenIndex = position1909, tokenIndex1909
+		l2819:
+			position, tokenIndex = position2819, tokenIndex2819
 			return false
 		},
-		/* 176 jsonMapAccessBracket <- <('[' doubleQuotedString ']')> */
+		/* 217 jsonMapAccessBracket <- <('[' doubleQuotedString ']')> */
 		func() bool {
-			position1920, tokenIndex1920 := position, tokenIndex
+			position2830, tokenIndex2830 := position, tokenIndex
 			{
-				position1921 := position
+				position2831 := position
 				if buffer[position] != rune('[') {
-					goto l1920
+					goto l2830
 				}
 				position++
 				if !_rules[ruledoubleQuotedString]() {
-					goto l1920
+					goto l2830
 				}
 				if buffer[position] != rune(']') {
-					goto l1920
+					goto l2830
 				}
 				position++
-				add(rulejsonMapAccessBracket, position1921)
+				add(rulejsonMapAccessBracket, position2831)
 			}
 			return true
-		l1920:
-			position, tokenIndex = position1920, tokenIndex1920
+		l2830:
+			position, tokenIndex = position2830, tokenIndex2830
 			return false
 		},
-		/* 177 doubleQuotedString <- <('"' <(('"' '"') / (!'"' .))*> '"')> */
+		/* 218 doubleQuotedString <- <('"' <(('"' '"') / (!'"' .))*> '"')> */
 		func() bool {
-			position1922, tokenIndex1922 := position, tokenIndex
+			position2832, tokenIndex2832 := position, tokenIndex
 			{
-				position1923 := position
+				position2833 := position
 				if buffer[position] != rune('"') {
-					goto l1922
+					goto l2832
 				}
 				position++
 				{
-					position1924 := position
-				l1925:
+					position2834 := position
+				l2835:
 					{
-						position1926, tokenIndex1926 := position, tokenIndex
+						position2836, tokenIndex2836 := position, tokenIndex
 						{
-							position1927, tokenIndex1927 := position, tokenIndex
+							position2837, tokenIndex2837 := position, tokenIndex
 							if buffer[position] != rune('"') {
-								goto l1928
+								goto l2838
 							}
 							position++
 							if buffer[position] != rune('"') {
-								goto l1928
+								goto l2838
 							}
 							position++
-							goto l1927
-						l1928:
-							position, tokenIndex = position1927, tokenIndex1927
+							goto l2837
+						l2838:
+							position, tokenIndex = position2837, tokenIndex2837
 							{
-								position1929, tokenIndex1929 := position, tokenIndex
+								position2839, tokenIndex2839 := position, tokenIndex
 								if buffer[position] != rune('"') {
-									goto l1929
+									goto l2839
 								}
 								position++
-								goto l1926
-							l1929:
-								position, tokenIndex = position1929, tokenIndex1929
+								goto l2836
+							l2839:
+								position, tokenIndex = position2839, tokenIndex2839
 							}
 							if !matchDot() {
-								goto l1926
+								goto l2836
 							}
 						}
-					l1927:
-						goto l1925
-					l1926:
-						position, tokenIndex = position1926, tokenIndex1926
+					l2837:
+						goto l2835
+					l2836:
+						position, tokenIndex = position2836, tokenIndex2836
 					}
-					add(rulePegText, position1924)
+					add(rulePegText, position2834)
 				}
 				if buffer[position] != rune('"') {
-					goto l1922
+					goto l2832
 				}
 				position++
-				add(ruledoubleQuotedString, position1923)
+				add(ruledoubleQuotedString, position2833)
 			}
 			return true
-		l1922:
-			position, tokenIndex = position1922, tokenIndex1922
+		l2832:
+			position, tokenIndex = position2832, tokenIndex2832
 			return false
 		},
-		/* 178 jsonArrayAccess <- <('[' <('-'? [0-9]+)> ']')> */
+		/* 219 jsonArrayAccess <- <('[' <('-'? [0-9]+)> ']')> */
 		func() bool {
-			position1930, tokenIndex1930 := position, tokenIndex
+			position2840, tokenIndex2840 := position, tokenIndex
 			{
-				position1931 := position
+				position2841 := position
 				if buffer[position] != rune('[') {
-					goto l1930
+					goto l2840
 				}
 				position++
 				{
-					position1932 := position
+					position2842 := position
 					{
-						position1933, tokenIndex1933 := position, tokenIndex
+						position2843, tokenIndex2843 := position, tokenIndex
 						if buffer[position] != rune('-') {
-							goto l1933
+							goto l2843
 						}
 						position++
-						goto l1934
-					l1933:
-						position, tokenIndex = position1933, tokenIndex1933
+						goto l2844
+					l2843:
+						position, tokenIndex = position2843, tokenIndex2843
 					}
-				l1934:
+				l2844:
 					if c := buffer[position]; c < rune('0') || c > rune('9') {
-						goto l1930
+						goto l2840
 					}
 					position++
-				l1935:
+				l2845:
 					{
-						position1936, tokenIndex1936 := position, tokenIndex
+						position2846, tokenIndex2846 := position, tokenIndex
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l1936
+							goto l2846
 						}
 						position++
-						goto l1935
-					l1936:
-						position, tokenIndex = position1936, tokenIndex1936
+						goto l2845
+					l2846:
+						position, tokenIndex = position2846, tokenIndex2846
 					}
-					add(rulePegText, position1932)
+					add(rulePegText, position2842)
 				}
 				if buffer[position] != rune(']') {
-					goto l1930
+					goto l2840
 				}
 				position++
-				add(rulejsonArrayAccess, position1931)
+				add(rulejsonArrayAccess, position2841)
 			}
 			return true
-		l1930:
-			position, tokenIndex = position1930, tokenIndex1930
+		l2840:
+			position, tokenIndex = position2840, tokenIndex2840
 			return false
 		},
-		/* 179 jsonNonNegativeArrayAccess <- <('[' <[0-9]+> ']')> */
+		/* 220 jsonNonNegativeArrayAccess <- <('[' <[0-9]+> ']')> */
 		func() bool {
-			position1937, tokenIndex1937 := position, tokenIndex
+			position2847, tokenIndex2847 := position, tokenIndex
 			{
-				position1938 := position
+				position2848 := position
 				if buffer[position] != rune('[') {
-					goto l1937
+					goto l2847
 				}
 				position++
 				{
-					position1939 := position
+					position2849 := position
 					if c := buffer[position]; c < rune('0') || c > rune('9') {
-						goto l1937
+						goto l2847
 					}
 					position++
-				l1940:
+				l2850:
 					{
-						position1941, tokenIndex1941 := position, tokenIndex
+						position2851, tokenIndex2851 := position, tokenIndex
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l1941
+							goto l2851
 						}
 						position++
-						goto l1940
-					l1941:
-						position, tokenIndex = position1941, tokenIndex1941
+						goto l2850
+					l2851:
+						position, tokenIndex = position2851, tokenIndex2851
 					}
-					add(rulePegText, position1939)
+					add(rulePegText, position2849)
 				}
 				if buffer[position] != rune(']') {
-					goto l1937
+					goto l2847
 				}
 				position++
-				add(rulejsonNonNegativeArrayAccess, position1938)
+				add(rulejsonNonNegativeArrayAccess, position2848)
 			}
 			return true
-		l1937:
-			position, tokenIndex = position1937, tokenIndex1937
+		l2847:
+			position, tokenIndex = position2847, tokenIndex2847
 			return false
 		},
-		/* 180 jsonArraySlice <- <('[' <('-'? [0-9]+ ':' '-'? [0-9]+ (':' '-'? [0-9]+)?)> ']')> */
+		/* 221 jsonArraySlice <- <('[' <('-'? [0-9]+ ':' '-'? [0-9]+ (':' '-'? [0-9]+)?)> ']')> */
 		func() bool {
-			position1942, tokenIndex1942 := position, tokenIndex
+			position2852, tokenIndex2852 := position, tokenIndex
 			{
-				position1943 := position
+				position2853 := position
 				if buffer[position] != rune('[') {
-					goto l1942
+					goto l2852
 				}
 				position++
 				{
-					position1944 := position
+					position2854 := position
 					{
-						position1945, tokenIndex1945 := position, tokenIndex
+						position2855, tokenIndex2855 := position, tokenIndex
 						if buffer[position] != rune('-') {
-							goto l1945
+							goto l2855
 						}
 						position++
-						goto l1946
-					l1945:
-						position, tokenIndex = position1945, tokenIndex1945
+						goto l2856
+					l2855:
+						position, tokenIndex = position2855, tokenIndex2855
 					}
-				l1946:
+				l2856:
 					if c := buffer[position]; c < rune('0') || c > rune('9') {
-						goto l1942
+						goto l2852
 					}
 					position++
-				l1947:
+				l2857:
 					{
-						position1948, tokenIndex1948 := position, tokenIndex
+						position2858, tokenIndex2858 := position, tokenIndex
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l1948
+							goto l2858
 						}
 						position++
-						goto l1947
-					l1948:
-						position, tokenIndex = position1948, tokenIndex1948
+						goto l2857
+					l2858:
+						position, tokenIndex = position2858, tokenIndex2858
 					}
 					if buffer[position] != rune(':') {
-						goto l1942
+						goto l2852
 					}
 					position++
 					{
-						position1949, tokenIndex1949 := position, tokenIndex
+						position2859, tokenIndex2859 := position, tokenIndex
 						if buffer[position] != rune('-') {
-							goto l1949
+							goto l2859
 						}
 						position++
-						goto l1950
-					l1949:
-						position, tokenIndex = position1949, tokenIndex1949
+						goto l2860
+					l2859:
+						position, tokenIndex = position2859, tokenIndex2859
 					}
-				l1950:
+				l2860:
 					if c := buffer[position]; c < rune('0') || c > rune('9') {
-						goto l1942
+						goto l2852
 					}
 					position++
-				l1951:
+				l2861:
 					{
-						position1952, tokenIndex1952 := position, tokenIndex
+						position2862, tokenIndex2862 := position, tokenIndex
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l1952
+							goto l2862
 						}
 						position++
-						goto l1951
-					l1952:
-						position, tokenIndex = position1952, tokenIndex1952
+						goto l2861
+					l2862:
+						position, tokenIndex = position2862, tokenIndex2862
 					}
 					{
-						position1953, tokenIndex1953 := position, tokenIndex
+						position2863, tokenIndex2863 := position, tokenIndex
 						if buffer[position] != rune(':') {
-							goto l1953
+							goto l2863
 						}
 						position++
 						{
-							position1955, tokenIndex1955 := position, tokenIndex
+							position2865, tokenIndex2865 := position, tokenIndex
 							if buffer[position] != rune('-') {
-								goto l1955
+								goto l2865
 							}
 							position++
-							goto l1956
-						l1955:
-							position, tokenIndex = position1955, tokenIndex1955
+							goto l2866
+						l2865:
+							position, tokenIndex = position2865, tokenIndex2865
 						}
-					l1956:
+					l2866:
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l1953
+							goto l2863
 						}
 						position++
-					l1957:
+					l2867:
 						{
-							position1958, tokenIndex1958 := position, tokenIndex
+							position2868, tokenIndex2868 := position, tokenIndex
 							if c := buffer[position]; c < rune('0') || c > rune('9') {
-								goto l1958
+								goto l2868
 							}
 							position++
-							goto l1957
-						l1958:
-							position, tokenIndex = position1958, tokenIndex1958
+							goto l2867
+						l2868:
+							position, tokenIndex = position2868, tokenIndex2868
 						}
-						goto l1954
-					l1953:
-						position, tokenIndex = position1953, tokenIndex1953
+						goto l2864
+					l2863:
+						position, tokenIndex = position2863, tokenIndex2863
 					}
-				l1954:
-					add(rulePegText, position1944)
+				l2864:
+					add(rulePegText, position2854)
 				}
 				if buffer[position] != rune(']') {
-					goto l1942
+					goto l2852
 				}
 				position++
-				add(rulejsonArraySlice, position1943)
+				add(rulejsonArraySlice, position2853)
 			}
 			return true
-		l1942:
-			position, tokenIndex = position1942, tokenIndex1942
+		l2852:
+			position, tokenIndex = position2852, tokenIndex2852
 			return false
 		},
-		/* 181 jsonArrayPartialSlice <- <('[' <((':' '-'? [0-9]+) / ('-'? [0-9]+ ':'))> ']')> */
+		/* 222 jsonArrayPartialSlice <- <('[' <((':' '-'? [0-9]+) / ('-'? [0-9]+ ':'))> ']')> */
 		func() bool {
-			position1959, tokenIndex1959 := position, tokenIndex
+			position2869, tokenIndex2869 := position, tokenIndex
 			{
-				position1960 := position
+				position2870 := position
 				if buffer[position] != rune('[') {
-					goto l1959
+					goto l2869
 				}
 				position++
 				{
-					position1961 := position
+					position2871 := position
 					{
-						position1962, tokenIndex1962 := position, tokenIndex
+						position2872, tokenIndex2872 := position, tokenIndex
 						if buffer[position] != rune(':') {
-							goto l1963
+							goto l2873
 						}
 						position++
 						{
-							position1964, tokenIndex1964 := position, tokenIndex
+							position2874, tokenIndex2874 := position, tokenIndex
 							if buffer[position] != rune('-') {
-								goto l1964
+								goto l2874
 							}
 							position++
-							goto l1965
-						l1964:
-							position, tokenIndex = position1964, tokenIndex1964
+							goto l2875
+						l2874:
+							position, tokenIndex = position2874, tokenIndex2874
 						}
-					l1965:
+					l2875:
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l1963
+							goto l2873
 						}
 						position++
-					l1966:
+					l2876:
 						{
-							position1967, tokenIndex1967 := position, tokenIndex
+							position2877, tokenIndex2877 := position, tokenIndex
 							if c := buffer[position]; c < rune('0') || c > rune('9') {
-								goto l1967
+								goto l2877
 							}
 							position++
-							goto l1966
-						l1967:
-							position, tokenIndex = position1967, tokenIndex1967
+							goto l2876
+						l2877:
+							position, tokenIndex = position2877, tokenIndex2877
 						}
-						goto l1962
-					l1963:
-						position, tokenIndex = position1962, tokenIndex1962
+						goto l2872
+					l2873:
+						position, tokenIndex = position2872, tokenIndex2872
 						{
-							position1968, tokenIndex1968 := position, tokenIndex
+							position2878, tokenIndex2878 := position, tokenIndex
 							if buffer[position] != rune('-') {
-								goto l1968
+								goto l2878
 							}
 							position++
-							goto l1969
-						l1968:
-							position, tokenIndex = position1968, tokenIndex1968
+							goto l2879
+						l2878:
+							position, tokenIndex = position2878, tokenIndex2878
 						}
-					l1969:
+					l2879:
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l1959
+							goto l2869
 						}
 						position++
-					l1970:
+					l2880:
 						{
-							position1971, tokenIndex1971 := position, tokenIndex
+							position2881, tokenIndex2881 := position, tokenIndex
 							if c := buffer[position]; c < rune('0') || c > rune('9') {
-								goto l1971
+								goto l2881
 							}
 							position++
-							goto l1970
-						l1971:
-							position, tokenIndex = position1971, tokenIndex1971
+							goto l2880
+						l2881:
+							position, tokenIndex = position2881, tokenIndex2881
 						}
 						if buffer[position] != rune(':') {
-							goto l1959
+							goto l2869
 						}
 						position++
 					}
-				l1962:
-					add(rulePegText, position1961)
+				l2872:
+					add(rulePegText, position2871)
 				}
 				if buffer[position] != rune(']') {
-					goto l1959
+					goto l2869
 				}
 				position++
-				add(rulejsonArrayPartialSlice, position1960)
+				add(rulejsonArrayPartialSlice, position2870)
 			}
 			return true
-		l1959:
-			position, tokenIndex = position1959, tokenIndex1959
+		l2869:
+			position, tokenIndex = position2869, tokenIndex2869
 			return false
 		},
-		/* 182 jsonArrayFullSlice <- <('[' ':' ']')> */
+		/* 223 jsonArrayFullSlice <- <('[' ':' ']')> */
 		func() bool {
-			position1972, tokenIndex1972 := position, tokenIndex
+			position2882, tokenIndex2882 := position, tokenIndex
 			{
-				position1973 := position
+				position2883 := position
 				if buffer[position] != rune('[') {
-					goto l1972
+					goto l2882
 				}
 				position++
 				if buffer[position] != rune(':') {
-					goto l1972
+					goto l2882
 				}
 				position++
 				if buffer[position] != rune(']') {
-					goto l1972
+					goto l2882
 				}
 				position++
-				add(rulejsonArrayFullSlice, position1973)
+				add(rulejsonArrayFullSlice, position2883)
 			}
 			return true
-		l1972:
-			position, tokenIndex = position1972, tokenIndex1972
+		l2882:
+			position, tokenIndex = position2882, tokenIndex2882
 			return false
 		},
-		/* 183 spElem <- <(' ' / '\t' / '\n' / '\r' / comment / finalComment)> */
+		/* 224 spElem <- <(' ' / '\t' / '\n' / '\r' / comment / finalComment)> */
 		func() bool {
-			position1974, tokenIndex1974 := position, tokenIndex
+			position2884, tokenIndex2884 := position, tokenIndex
 			{
-				position1975 := position
+				position2885 := position
 				{
-					position1976, tokenIndex1976 := position, tokenIndex
+					position2886, tokenIndex2886 := position, tokenIndex
 					if buffer[position] != rune(' ') {
-						goto l1977
+						goto l2887
 					}
 					position++
-					goto l1976
-				l1977:
-					position, tokenIndex = position1976, tokenIndex1976
+					goto l2886
+				l2887:
+					position, tokenIndex = position2886, tokenIndex2886
 					if buffer[position] != rune('\t') {
-						goto l1978
+						goto l2888
 					}
 					position++
-					goto l1976
-				l1978:
-					position, tokenIndex = position1976, tokenIndex1976
+					goto l2886
+				l2888:
+					position, tokenIndex = position2886, tokenIndex2886
 					if buffer[position] != rune('\n') {
-						goto l1979
+						goto l2889
 					}
 					position++
-					goto l1976
-				l1979:
-					position, tokenIndex = position1976, tokenIndex1976
+					goto l2886
+				l2889:
+					position, tokenIndex = position2886, tokenIndex2886
 					if buffer[position] != rune('\r') {
-						goto l1980
+						goto l2890
 					}
 					position++
-					goto l1976
-				l1980:
-					position, tokenIndex = position1976, tokenIndex1976
+					goto l2886
+				l2890:
+					position, tokenIndex = position2886, tokenIndex2886
 					if !_rules[rulecomment]() {
-						goto l1981
+						goto l2891
 					}
-					goto l1976
-				l1981:
-					position, tokenIndex = position1976, tokenIndex1976
+					goto l2886
+				l2891:
+					position, tokenIndex = position2886, tokenIndex2886
 					if !_rules[rulefinalComment]() {
-						goto l1974
+						goto l2884
 					}
 				}
-			l1976:
-				add(rulespElem, position1975)
+			l2886:
+				add(rulespElem, position2885)
 			}
 			return true
-		l1974:
-			position, tokenIndex = position1974, tokenIndex1974
+		l2884:
+			position, tokenIndex = position2884, tokenIndex2884
 			return false
 		},
-		/* 184 sp <- <spElem+> */
+		/* 225 sp <- <spElem+> */
 		func() bool {
-			position1982, tokenIndex1982 := position, tokenIndex
+			position2892, tokenIndex2892 := position, tokenIndex
 			{
-				position1983 := position
+				position2893 := position
 				if !_rules[rulespElem]() {
-					goto l1982
+					goto l2892
 				}
-			l1984:
+			l2894:
 				{
-					position1985, tokenIndex1985 := position, tokenIndex
+					position2895, tokenIndex2895 := position, tokenIndex
 					if !_rules[rulespElem]() {
-						goto l1985
+						goto l2895
 					}
-					goto l1984
-				l1985:
-					position, tokenIndex = position1985, tokenIndex1985
+					goto l2894
+				l2895:
+					position, tokenIndex = position2895, tokenIndex2895
 				}
-				add(rulesp, position1983)
+				add(rulesp, position2893)
 			}
 			return true
-		l1982:
-			position, tokenIndex = position1982, tokenIndex1982
+		l2892:
+			position, tokenIndex = position2892, tokenIndex2892
 			return false
 		},
-		/* 185 spOpt <- <spElem*> */
+		/* 226 spOpt <- <spElem*> */
 		func() bool {
 			{
-				position1987 := position
-			l1988:
+				position2897 := position
+			l2898:
 				{
-					position1989, tokenIndex1989 := position, tokenIndex
+					position2899, tokenIndex2899 := position, tokenIndex
 					if !_rules[rulespElem]() {
-						goto l1989
+						goto l2899
 					}
-					goto l1988
-				l1989:
-					position, tokenIndex = position1989, tokenIndex1989
+					goto l2898
+				l2899:
+					position, tokenIndex = position2899, tokenIndex2899
 				}
-				add(rulespOpt, position1987)
+				add(rulespOpt, position2897)
 			}
 			return true
 		},
-		/* 186 comment <- <('-' '-' (!('\r' / '\n') .)* ('\r' / '\n'))> */
+		/* 227 comment <- <('-' '-' (!('\r' / '\n') .)* ('\r' / '\n'))> */
 		func() bool {
-			position1990, tokenIndex1990 := position, tokenIndex
+			position2900, tokenIndex2900 := position, tokenIndex
 			{
-				position1991 := position
+				position2901 := position
 				if buffer[position] != rune('-') {
-					goto l1990
+					goto l2900
 				}
 				position++
 				if buffer[position] != rune('-') {
-					goto l1990
+					goto l2900
 				}
 				position++
-			l1992:
+			l2902:
 				{
-					position1993, tokenIndex1993 := position, tokenIndex
+					position2903, tokenIndex2903 := position, tokenIndex
 					{
-						position1994, tokenIndex1994 := position, tokenIndex
+						position2904, tokenIndex2904 := position, tokenIndex
 						{
-							position1995, tokenIndex1995 := position, tokenIndex
+							position2905, tokenIndex2905 := position, tokenIndex
 							if buffer[position] != rune('\r') {
-								goto l1996
+								goto l2906
 							}
 							position++
-							goto l1995
-						l1996:
-							position, tokenIndex = position1995, tokenIndex1995
+							goto l2905
+						l2906:
+							position, tokenIndex = position2905, tokenIndex2905
 							if buffer[position] != rune('\n') {
-								goto l1994
+								goto l2904
 							}
 							position++
 						}
-					l1995:
-						goto l1993
-					l1994:
-						position, tokenIndex = position1994, tokenIndex1994
+					l2905:
+						goto l2903
+					l2904:
+						position, tokenIndex = position2904, tokenIndex2904
 					}
 					if !matchDot() {
-						goto l1993
+						goto l2903
 					}
-					goto l1992
-				l1993:
-					position, tokenIndex = position1993, tokenIndex1993
+					goto l2902
+				l2903:
+					position, tokenIndex = position2903, tokenIndex2903
 				}
 				{
-					position1997, tokenIndex1997 := position, tokenIndex
+					position2907, tokenIndex2907 := position, tokenIndex
 					if buffer[position] != rune('\r') {
-						goto l1998
+						goto l2908
 					}
 					position++
-					goto l1997
-				l1998:
-					position, tokenIndex = position1997, tokenIndex1997
+					goto l2907
+				l2908:
+					position, tokenIndex = position2907, tokenIndex2907
 					if buffer[position] != rune('\n') {
-						goto l1990
+						goto l2900
 					}
 					position++
 				}
-			l1997:
-				add(rulecomment, position1991)
+			l2907:
+				add(rulecomment, position2901)
 			}
 			return true
-		l1990:
-			position, tokenIndex = position1990, tokenIndex1990
+		l2900:
+			position, tokenIndex = position2900, tokenIndex2900
 			return false
 		},
-		/* 187 finalComment <- <('-' '-' (!('\r' / '\n') .)* !.)> */
+		/* 228 finalComment <- <('-' '-' (!('\r' / '\n') .)* !.)> */
 		func() bool {
-			position1999, tokenIndex1999 := position, tokenIndex
+			position2909, tokenIndex2909 := position, tokenIndex
 			{
-				position2000 := position
+				position2910 := position
 				if buffer[position] != rune('-') {
-					goto l1999
+					goto l2909
 				}
 				position++
 				if buffer[position] != rune('-') {
-					goto l1999
+					goto l2909
 				}
 				position++
-			l2001:
+			l2911:
 				{
-					position2002, tokenIndex2002 := position, tokenIndex
+					position2912, tokenIndex2912 := position, tokenIndex
 					{
-						position2003, tokenIndex2003 := position, tokenIndex
+						position2913, tokenIndex2913 := position, tokenIndex
 						{
-							position2004, tokenIndex2004 := position, tokenIndex
+							position2914, tokenIndex2914 := position, tokenIndex
 							if buffer[position] != rune('\r') {
-								goto l2005
+								goto l2915
 							}
 							position++
-							goto l2004
-						l2005:
-							position, tokenIndex = position2004, tokenIndex2004
+							goto l2914
+						l2915:
+							position, tokenIndex = position2914, tokenIndex2914
 							if buffer[position] != rune('\n') {
-								goto l2003
+								goto l2913
 							}
 							position++
 						}
-					l2004:
-						goto l2002
-					l2003:
-						position, tokenIndex = position2003, tokenIndex2003
+					l2914:
+						goto l2912
+					l2913:
+						position, tokenIndex = position2913, tokenIndex2913
 					}
 					if !matchDot() {
-						goto l2002
+						goto l2912
 					}
-					goto l2001
-				l2002:
-					position, tokenIndex = position2002, tokenIndex2002
+					goto l2911
+				l2912:
+					position, tokenIndex = position2912, tokenIndex2912
 				}
 				{
-					position2006, tokenIndex2006 := position, tokenIndex
+					position2916, tokenIndex2916 := position, tokenIndex
 					if !matchDot() {
-						goto l2006
+						goto l2916
 					}
-					goto l1999
-				l2006:
-					position, tokenIndex = position2006, tokenIndex2006
+					goto l2909
+				l2916:
+					position, tokenIndex = position2916, tokenIndex2916
 				}
-				add(rulefinalComment, position2000)
+				add(rulefinalComment, position2910)
+			}
+			return true
+		l2909:
+			position, tokenIndex = position2909, tokenIndex2909
+			return false
+		},
+		nil,
+		/* 231 Action0 <- <{
+		    p.IncludeTrailingWhitespace(begin, end)
+		}> */
+		func() bool {
+			{
+				add(ruleAction0, position)
+			}
+			return true
+		},
+		/* 232 Action1 <- <{
+		    p.IncludeTrailingWhitespace(begin, end)
+		}> */
+		func() bool {
+			{
+				add(ruleAction1, position)
+			}
+			return true
+		},
+		/* 233 Action2 <- <{
+		    p.AssembleSelect()
+		}> */
+		func() bool {
+			{
+				add(ruleAction2, position)
 			}
 			return true
-		l1999:
-			position, tokenIndex = position1999, tokenIndex1999
-			return false
 		},
-		nil,
-		/* 190 Action0 <- <{
-		    p.IncludeTrailingWhitespace(begin, end)
+		/* 234 Action3 <- <{
+		    p.AssembleSelectUnion(begin, end)
 		}> */
 		func() bool {
 			{
-				add(ruleAction0, position)
+				add(ruleAction3, position)
 			}
 			return true
 		},
-		/* 191 Action1 <- <{
-		    p.IncludeTrailingWhitespace(begin, end)
+		/* 235 Action4 <- <{
+		    p.AssembleSelectAlignedUnion(begin, end)
 		}> */
 		func() bool {
 			{
-				add(ruleAction1, position)
+				add(ruleAction4, position)
 			}
 			return true
 		},
-		/* 192 Action2 <- <{
-		    p.AssembleSelect()
+		/* 236 Action5 <- <{
+		    p.AssembleSelectStarting()
 		}> */
 		func() bool {
 			{
-				add(ruleAction2, position)
+				add(ruleAction5, position)
 			}
 			return true
 		},
-		/* 193 Action3 <- <{
-		    p.AssembleSelectUnion(begin, end)
+		/* 237 Action6 <- <{
+		    p.AssembleCreateStreamAsSelect()
 		}> */
 		func() bool {
 			{
-				add(ruleAction3, position)
+				add(ruleAction6, position)
 			}
 			return true
 		},
-		/* 194 Action4 <- <{
-		    p.AssembleCreateStreamAsSelect()
+		/* 238 Action7 <- <{
+		    p.AssembleCreateStreamAsSelectUnion()
 		}> */
 		func() bool {
 			{
-				add(ruleAction4, position)
+				add(ruleAction7, position)
 			}
 			return true
 		},
-		/* 195 Action5 <- <{
-		    p.AssembleCreateStreamAsSelectUnion()
+		/* 239 Action8 <- <{
+		    p.AssembleCreateStreamAsEnrich()
 		}> */
 		func() bool {
 			{
-				add(ruleAction5, position)
+				add(ruleAction8, position)
 			}
 			return true
 		},
-		/* 196 Action6 <- <{
+		/* 240 Action9 <- <{
 		    p.AssembleCreateSource()
 		}> */
 		func() bool {
 			{
-				add(ruleAction6, position)
+				add(ruleAction9, position)
 			}
 			return true
 		},
-		/* 197 Action7 <- <{
+		/* 241 Action10 <- <{
 		    p.AssembleCreateSink()
 		}> */
 		func() bool {
 			{
-				add(ruleAction7, position)
+				add(ruleAction10, position)
 			}
 			return true
 		},
-		/* 198 Action8 <- <{
+		/* 242 Action11 <- <{
 		    p.AssembleCreateState()
 		}> */
 		func() bool {
 			{
-				add(ruleAction8, position)
+				add(ruleAction11, position)
 			}
 			return true
 		},
-		/* 199 Action9 <- <{
+		/* 243 Action12 <- <{
 		    p.AssembleUpdateState()
 		}> */
 		func() bool {
 			{
-				add(ruleAction9, position)
+				add(ruleAction12, position)
 			}
 			return true
 		},
-		/* 200 Action10 <- <{
+		/* 244 Action13 <- <{
 		    p.AssembleUpdateSource()
 		}> */
 		func() bool {
 			{
-				add(ruleAction10, position)
+				add(ruleAction13, position)
 			}
 			return true
 		},
-		/* 201 Action11 <- <{
+		/* 245 Action14 <- <{
 		    p.AssembleUpdateSink()
 		}> */
 		func() bool {
 			{
-				add(ruleAction11, position)
+				add(ruleAction14, position)
+			}
+			return true
+		},
+		/* 246 Action15 <- <{
+		    p.AssembleInsertIntoSelect()
+		}> */
+		func() bool {
+			{
+				add(ruleAction15, position)
 			}
 			return true
 		},
-		/* 202 Action12 <- <{
+		/* 247 Action16 <- <{
 		    p.AssembleInsertIntoFrom()
 		}> */
 		func() bool {
 			{
-				add(ruleAction12, position)
+				add(ruleAction16, position)
+			}
+			return true
+		},
+		/* 248 Action17 <- <{
+		    p.AssembleStreamIdentifiers(begin, end)
+		}> */
+		func() bool {
+			{
+				add(ruleAction17, position)
+			}
+			return true
+		},
+		/* 249 Action18 <- <{
+		    p.AssembleSplit()
+		}> */
+		func() bool {
+			{
+				add(ruleAction18, position)
+			}
+			return true
+		},
+		/* 250 Action19 <- <{
+		    p.AssembleSplitBranches(begin, end)
+		}> */
+		func() bool {
+			{
+				add(ruleAction19, position)
+			}
+			return true
+		},
+		/* 251 Action20 <- <{
+		    p.AssembleSplitBranch()
+		}> */
+		func() bool {
+			{
+				add(ruleAction20, position)
+			}
+			return true
+		},
+		/* 252 Action21 <- <{
+		    p.AssembleSplitOtherwise()
+		}> */
+		func() bool {
+			{
+				add(ruleAction21, position)
+			}
+			return true
+		},
+		/* 253 Action22 <- <{
+		    p.AssembleArchiveStream()
+		}> */
+		func() bool {
+			{
+				add(ruleAction22, position)
+			}
+			return true
+		},
+		/* 254 Action23 <- <{
+		    p.AssembleReplayArchive()
+		}> */
+		func() bool {
+			{
+				add(ruleAction23, position)
+			}
+			return true
+		},
+		/* 255 Action24 <- <{
+		    p.EnsureStringLiteral(begin, end)
+		}> */
+		func() bool {
+			{
+				add(ruleAction24, position)
+			}
+			return true
+		},
+		/* 256 Action25 <- <{
+		    p.EnsureStringLiteral(begin, end)
+		}> */
+		func() bool {
+			{
+				add(ruleAction25, position)
 			}
 			return true
 		},
-		/* 203 Action13 <- <{
+		/* 257 Action26 <- <{
 		    p.AssemblePauseSource()
 		}> */
 		func() bool {
 			{
-				add(ruleAction13, position)
+				add(ruleAction26, position)
 			}
 			return true
 		},
-		/* 204 Action14 <- <{
+		/* 258 Action27 <- <{
 		    p.AssembleResumeSource()
 		}> */
 		func() bool {
 			{
-				add(ruleAction14, position)
+				add(ruleAction27, position)
 			}
 			return true
 		},
-		/* 205 Action15 <- <{
+		/* 259 Action28 <- <{
 		    p.AssembleRewindSource()
 		}> */
 		func() bool {
 			{
-				add(ruleAction15, position)
+				add(ruleAction28, position)
 			}
 			return true
 		},
-		/* 206 Action16 <- <{
+		/* 260 Action29 <- <{
 		    p.AssembleDropSource()
 		}> */
 		func() bool {
 			{
-				add(ruleAction16, position)
+				add(ruleAction29, position)
 			}
 			return true
 		},
-		/* 207 Action17 <- <{
+		/* 261 Action30 <- <{
 		    p.AssembleDropStream()
 		}> */
 		func() bool {
 			{
-				add(ruleAction17, position)
+				add(ruleAction30, position)
 			}
 			return true
 		},
-		/* 208 Action18 <- <{
+		/* 262 Action31 <- <{
 		    p.AssembleDropSink()
 		}> */
 		func() bool {
 			{
-				add(ruleAction18, position)
+				add(ruleAction31, position)
 			}
 			return true
 		},
-		/* 209 Action19 <- <{
+		/* 263 Action32 <- <{
 		    p.AssembleDropState()
 		}> */
 		func() bool {
 			{
-				add(ruleAction19, position)
+				add(ruleAction32, position)
 			}
 			return true
 		},
-		/* 210 Action20 <- <{
+		/* 264 Action33 <- <{
 		    p.AssembleLoadState()
 		}> */
 		func() bool {
 			{
-				add(ruleAction20, position)
+				add(ruleAction33, position)
 			}
 			return true
 		},
-		/* 211 Action21 <- <{
+		/* 265 Action34 <- <{
 		    p.AssembleLoadStateOrCreate()
 		}> */
 		func() bool {
 			{
-				add(ruleAction21, position)
+				add(ruleAction34, position)
 			}
 			return true
 		},
-		/* 212 Action22 <- <{
+		/* 266 Action35 <- <{
 		    p.AssembleSaveState()
 		}> */
 		func() bool {
 			{
-				add(ruleAction22, position)
+				add(ruleAction35, position)
 			}
 			return true
 		},
-		/* 213 Action23 <- <{
+		/* 267 Action36 <- <{
 		    p.AssembleEval(begin, end)
 		}> */
 		func() bool {
 			{
-				add(ruleAction23, position)
+				add(ruleAction36, position)
+			}
+			return true
+		},
+		/* 268 Action37 <- <{
+		    p.AssembleExplainAnalyze()
+		}> */
+		func() bool {
+			{
+				add(ruleAction37, position)
+			}
+			return true
+		},
+		/* 269 Action38 <- <{
+		    p.EnsureExplainAnalyzeLimit(begin, end)
+		}> */
+		func() bool {
+			{
+				add(ruleAction38, position)
+			}
+			return true
+		},
+		/* 270 Action39 <- <{
+		    p.AssembleShowFunctions(begin, end)
+		}> */
+		func() bool {
+			{
+				add(ruleAction39, position)
+			}
+			return true
+		},
+		/* 271 Action40 <- <{
+		    p.AssembleReloadFunction(begin, end)
+		}> */
+		func() bool {
+			{
+				add(ruleAction40, position)
+			}
+			return true
+		},
+		/* 272 Action41 <- <{
+		    p.AssembleSetConstant()
+		}> */
+		func() bool {
+			{
+				add(ruleAction41, position)
 			}
 			return true
 		},
-		/* 214 Action24 <- <{
+		/* 273 Action42 <- <{
 		    p.AssembleEmitter()
 		}> */
 		func() bool {
 			{
-				add(ruleAction24, position)
+				add(ruleAction42, position)
 			}
 			return true
 		},
-		/* 215 Action25 <- <{
+		/* 274 Action43 <- <{
 		    p.AssembleEmitterOptions(begin, end)
 		}> */
 		func() bool {
 			{
-				add(ruleAction25, position)
+				add(ruleAction43, position)
 			}
 			return true
 		},
-		/* 216 Action26 <- <{
+		/* 275 Action44 <- <{
 		    p.AssembleEmitterLimit()
 		}> */
 		func() bool {
 			{
-				add(ruleAction26, position)
+				add(ruleAction44, position)
 			}
 			return true
 		},
-		/* 217 Action27 <- <{
+		/* 276 Action45 <- <{
 		    p.AssembleEmitterSampling(CountBasedSampling, 1)
 		}> */
 		func() bool {
 			{
-				add(ruleAction27, position)
+				add(ruleAction45, position)
 			}
 			return true
 		},
-		/* 218 Action28 <- <{
+		/* 277 Action46 <- <{
 		    p.AssembleEmitterSampling(RandomizedSampling, 1)
 		}> */
 		func() bool {
 			{
-				add(ruleAction28, position)
+				add(ruleAction46, position)
 			}
 			return true
 		},
-		/* 219 Action29 <- <{
+		/* 278 Action47 <- <{
 		    p.AssembleEmitterSampling(TimeBasedSampling, 1)
 		}> */
 		func() bool {
 			{
-				add(ruleAction29, position)
+				add(ruleAction47, position)
 			}
 			return true
 		},
-		/* 220 Action30 <- <{
+		/* 279 Action48 <- <{
 		    p.AssembleEmitterSampling(TimeBasedSampling, 0.001)
 		}> */
 		func() bool {
 			{
-				add(ruleAction30, position)
+				add(ruleAction48, position)
 			}
 			return true
 		},
-		/* 221 Action31 <- <{
+		/* 280 Action49 <- <{
 		    p.AssembleProjections(begin, end)
 		}> */
 		func() bool {
 			{
-				add(ruleAction31, position)
+				add(ruleAction49, position)
 			}
 			return true
 		},
-		/* 222 Action32 <- <{
+		/* 281 Action50 <- <{
 		    p.AssembleAlias()
 		}> */
 		func() bool {
 			{
-				add(ruleAction32, position)
+				add(ruleAction50, position)
 			}
 			return true
 		},
-		/* 223 Action33 <- <{
+		/* 282 Action51 <- <{
 		    // This is *always* executed, even if there is no
 		    // FROM clause present in the statement.
 		    p.AssembleWindowedFrom(begin, end)
 		}> */
 		func() bool {
 			{
-				add(ruleAction33, position)
+				add(ruleAction51, position)
+			}
+			return true
+		},
+		/* 283 Action52 <- <{
+		    p.AssembleInterval()
+		}> */
+		func() bool {
+			{
+				add(ruleAction52, position)
+			}
+			return true
+		},
+		/* 284 Action53 <- <{
+		    p.AssembleInterval()
+		}> */
+		func() bool {
+			{
+				add(ruleAction53, position)
+			}
+			return true
+		},
+		/* 285 Action54 <- <{
+		    p.AssembleJoin()
+		}> */
+		func() bool {
+			{
+				add(ruleAction54, position)
+			}
+			return true
+		},
+		/* 286 Action55 <- <{
+		    // This is *always* executed, even if there is no
+		    // DEDUPLICATE BY clause present in the statement.
+		    p.AssembleDeduplicate(begin, end)
+		}> */
+		func() bool {
+			{
+				add(ruleAction55, position)
+			}
+			return true
+		},
+		/* 287 Action56 <- <{
+		    // This is *always* executed, even if there is no
+		    // WHERE clause present in the statement.
+		    p.AssembleFilter(begin, end)
+		}> */
+		func() bool {
+			{
+				add(ruleAction56, position)
+			}
+			return true
+		},
+		/* 288 Action57 <- <{
+		    // This is *always* executed, even if there is no
+		    // GROUP BY clause present in the statement.
+		    p.AssembleGrouping(begin, end)
+		}> */
+		func() bool {
+			{
+				add(ruleAction57, position)
+			}
+			return true
+		},
+		/* 289 Action58 <- <{
+		    p.AssembleExpressions(begin, end)
+		}> */
+		func() bool {
+			{
+				add(ruleAction58, position)
+			}
+			return true
+		},
+		/* 290 Action59 <- <{
+		    // This is *always* executed, even if there is no
+		    // HAVING clause present in the statement.
+		    p.AssembleHaving(begin, end)
+		}> */
+		func() bool {
+			{
+				add(ruleAction59, position)
+			}
+			return true
+		},
+		/* 291 Action60 <- <{
+		    p.EnsureAliasedStreamWindow()
+		}> */
+		func() bool {
+			{
+				add(ruleAction60, position)
 			}
 			return true
 		},
-		/* 224 Action34 <- <{
-		    p.AssembleInterval()
+		/* 292 Action61 <- <{
+		    p.AssembleAliasedStreamWindow()
 		}> */
 		func() bool {
 			{
-				add(ruleAction34, position)
+				add(ruleAction61, position)
 			}
 			return true
 		},
-		/* 225 Action35 <- <{
-		    p.AssembleInterval()
+		/* 293 Action62 <- <{
+		    p.AssembleStreamWindow()
 		}> */
 		func() bool {
 			{
-				add(ruleAction35, position)
+				add(ruleAction62, position)
 			}
 			return true
 		},
-		/* 226 Action36 <- <{
-		    // This is *always* executed, even if there is no
-		    // WHERE clause present in the statement.
-		    p.AssembleFilter(begin, end)
+		/* 294 Action63 <- <{
+		    p.AssembleWindowFunction(TumbleWindow)
 		}> */
 		func() bool {
 			{
-				add(ruleAction36, position)
+				add(ruleAction63, position)
 			}
 			return true
 		},
-		/* 227 Action37 <- <{
-		    // This is *always* executed, even if there is no
-		    // GROUP BY clause present in the statement.
-		    p.AssembleGrouping(begin, end)
+		/* 295 Action64 <- <{
+		    p.AssembleWindowFunction(HopWindow)
 		}> */
 		func() bool {
 			{
-				add(ruleAction37, position)
+				add(ruleAction64, position)
 			}
 			return true
 		},
-		/* 228 Action38 <- <{
-		    // This is *always* executed, even if there is no
-		    // HAVING clause present in the statement.
-		    p.AssembleHaving(begin, end)
+		/* 296 Action65 <- <{
+		    p.AssembleWindowFunction(SessionWindow)
 		}> */
 		func() bool {
 			{
-				add(ruleAction38, position)
+				add(ruleAction65, position)
 			}
 			return true
 		},
-		/* 229 Action39 <- <{
-		    p.EnsureAliasedStreamWindow()
+		/* 297 Action66 <- <{
+		    p.PushComponent(begin, end, NewRowMeta("", TimestampMeta))
 		}> */
 		func() bool {
 			{
-				add(ruleAction39, position)
+				add(ruleAction66, position)
 			}
 			return true
 		},
-		/* 230 Action40 <- <{
-		    p.AssembleAliasedStreamWindow()
+		/* 298 Action67 <- <{
+		    p.AssembleIntervalLiteral()
 		}> */
 		func() bool {
 			{
-				add(ruleAction40, position)
+				add(ruleAction67, position)
 			}
 			return true
 		},
-		/* 231 Action41 <- <{
-		    p.AssembleStreamWindow()
+		/* 299 Action68 <- <{
+		    substr := string([]rune(buffer)[begin:end])
+		    p.PushComponent(begin, end, Raw{substr})
 		}> */
 		func() bool {
 			{
-				add(ruleAction41, position)
+				add(ruleAction68, position)
 			}
 			return true
 		},
-		/* 232 Action42 <- <{
+		/* 300 Action69 <- <{
 		    p.AssembleUDSFFuncApp()
 		}> */
 		func() bool {
 			{
-				add(ruleAction42, position)
+				add(ruleAction69, position)
 			}
 			return true
 		},
-		/* 233 Action43 <- <{
+		/* 301 Action70 <- <{
 		    p.EnsureCapacitySpec(begin, end)
 		}> */
 		func() bool {
 			{
-				add(ruleAction43, position)
+				add(ruleAction70, position)
 			}
 			return true
 		},
-		/* 234 Action44 <- <{
+		/* 302 Action71 <- <{
 		    p.EnsureSheddingSpec(begin, end)
 		}> */
 		func() bool {
 			{
-				add(ruleAction44, position)
+				add(ruleAction71, position)
 			}
 			return true
 		},
-		/* 235 Action45 <- <{
+		/* 303 Action72 <- <{
 		    p.AssembleSourceSinkSpecs(begin, end)
 		}> */
 		func() bool {
 			{
-				add(ruleAction45, position)
+				add(ruleAction72, position)
 			}
 			return true
 		},
-		/* 236 Action46 <- <{
+		/* 304 Action73 <- <{
 		    p.AssembleSourceSinkSpecs(begin, end)
 		}> */
 		func() bool {
 			{
-				add(ruleAction46, position)
+				add(ruleAction73, position)
 			}
 			return true
 		},
-		/* 237 Action47 <- <{
+		/* 305 Action74 <- <{
 		    p.AssembleSourceSinkSpecs(begin, end)
 		}> */
 		func() bool {
 			{
-				add(ruleAction47, position)
+				add(ruleAction74, position)
 			}
 			return true
 		},
-		/* 238 Action48 <- <{
+		/* 306 Action75 <- <{
 		    p.EnsureIdentifier(begin, end)
 		}> */
 		func() bool {
 			{
-				add(ruleAction48, position)
+				add(ruleAction75, position)
 			}
 			return true
 		},
-		/* 239 Action49 <- <{
+		/* 307 Action76 <- <{
 		    p.AssembleSourceSinkParam()
 		}> */
 		func() bool {
 			{
-				add(ruleAction49, position)
+				add(ruleAction76, position)
 			}
 			return true
 		},
-		/* 240 Action50 <- <{
+		/* 308 Action77 <- <{
 		    p.AssembleExpressions(begin, end)
 		    p.AssembleArray()
 		}> */
 		func() bool {
 			{
-				add(ruleAction50, position)
+				add(ruleAction77, position)
 			}
 			return true
 		},
-		/* 241 Action51 <- <{
+		/* 309 Action78 <- <{
 		    p.AssembleMap(begin, end)
 		}> */
 		func() bool {
 			{
-				add(ruleAction51, position)
+				add(ruleAction78, position)
 			}
 			return true
 		},
-		/* 242 Action52 <- <{
+		/* 310 Action79 <- <{
 		    p.AssembleKeyValuePair()
 		}> */
 		func() bool {
 			{
-				add(ruleAction52, position)
+				add(ruleAction79, position)
 			}
 			return true
 		},
-		/* 243 Action53 <- <{
+		/* 311 Action80 <- <{
 		    p.EnsureKeywordPresent(begin, end)
 		}> */
 		func() bool {
 			{
-				add(ruleAction53, position)
+				add(ruleAction80, position)
 			}
 			return true
 		},
-		/* 244 Action54 <- <{
+		/* 312 Action81 <- <{
 		    p.AssembleBinaryOperation(begin, end)
 		}> */
 		func() bool {
 			{
-				add(ruleAction54, position)
+				add(ruleAction81, position)
 			}
 			return true
 		},
-		/* 245 Action55 <- <{
+		/* 313 Action82 <- <{
 		    p.AssembleBinaryOperation(begin, end)
 		}> */
 		func() bool {
 			{
-				add(ruleAction55, position)
+				add(ruleAction82, position)
 			}
 			return true
 		},
-		/* 246 Action56 <- <{
+		/* 314 Action83 <- <{
 		    p.AssembleUnaryPrefixOperation(begin, end)
 		}> */
 		func() bool {
 			{
-				add(ruleAction56, position)
+				add(ruleAction83, position)
 			}
 			return true
 		},
-		/* 247 Action57 <- <{
+		/* 315 Action84 <- <{
 		    p.AssembleBinaryOperation(begin, end)
 		}> */
 		func() bool {
 			{
-				add(ruleAction57, position)
+				add(ruleAction84, position)
 			}
 			return true
 		},
-		/* 248 Action58 <- <{
+		/* 316 Action85 <- <{
 		    p.AssembleBinaryOperation(begin, end)
 		}> */
 		func() bool {
 			{
-				add(ruleAction58, position)
+				add(ruleAction85, position)
 			}
 			return true
 		},
-		/* 249 Action59 <- <{
+		/* 317 Action86 <- <{
 		    p.AssembleBinaryOperation(begin, end)
 		}> */
 		func() bool {
 			{
-				add(ruleAction59, position)
+				add(ruleAction86, position)
 			}
 			return true
 		},
-		/* 250 Action60 <- <{
+		/* 318 Action87 <- <{
 		    p.AssembleBinaryOperation(begin, end)
 		}> */
 		func() bool {
 			{
-				add(ruleAction60, position)
+				add(ruleAction87, position)
 			}
 			return true
 		},
-		/* 251 Action61 <- <{
+		/* 319 Action88 <- <{
 		    p.AssembleBinaryOperation(begin, end)
 		}> */
 		func() bool {
 			{
-				add(ruleAction61, position)
+				add(ruleAction88, position)
 			}
 			return true
 		},
-		/* 252 Action62 <- <{
+		/* 320 Action89 <- <{
 		    p.AssembleUnaryPrefixOperation(begin, end)
 		}> */
 		func() bool {
 			{
-				add(ruleAction62, position)
+				add(ruleAction89, position)
 			}
 			return true
 		},
-		/* 253 Action63 <- <{
+		/* 321 Action90 <- <{
 		    p.AssembleTypeCast(begin, end)
 		}> */
 		func() bool {
 			{
-				add(ruleAction63, position)
+				add(ruleAction90, position)
 			}
 			return true
 		},
-		/* 254 Action64 <- <{
+		/* 322 Action91 <- <{
 		    p.AssembleTypeCast(begin, end)
 		}> */
 		func() bool {
 			{
-				add(ruleAction64, position)
+				add(ruleAction91, position)
 			}
 			return true
 		},
-		/* 255 Action65 <- <{
+		/* 323 Action92 <- <{
 		    p.AssembleFuncAppSelector()
 		}> */
 		func() bool {
 			{
-				add(ruleAction65, position)
+				add(ruleAction92, position)
 			}
 			return true
 		},
-		/* 256 Action66 <- <{
+		/* 324 Action93 <- <{
 		    substr := string([]rune(buffer)[begin:end])
 		    p.PushComponent(begin, end, NewRaw(substr))
 		}> */
 		func() bool {
 			{
-				add(ruleAction66, position)
+				add(ruleAction93, position)
 			}
 			return true
 		},
-		/* 257 Action67 <- <{
+		/* 325 Action94 <- <{
 		    p.AssembleFuncApp()
 		}> */
 		func() bool {
 			{
-				add(ruleAction67, position)
+				add(ruleAction94, position)
 			}
 			return true
 		},
-		/* 258 Action68 <- <{
+		/* 326 Action95 <- <{
 		    p.AssembleExpressions(begin, end)
 		    p.AssembleFuncApp()
 		}> */
 		func() bool {
 			{
-				add(ruleAction68, position)
+				add(ruleAction95, position)
 			}
 			return true
 		},
-		/* 259 Action69 <- <{
+		/* 327 Action96 <- <{
 		    p.AssembleExpressions(begin, end)
 		}> */
 		func() bool {
 			{
-				add(ruleAction69, position)
+				add(ruleAction96, position)
 			}
 			return true
 		},
-		/* 260 Action70 <- <{
+		/* 328 Action97 <- <{
 		    p.AssembleExpressions(begin, end)
 		}> */
 		func() bool {
 			{
-				add(ruleAction70, position)
+				add(ruleAction97, position)
 			}
 			return true
 		},
-		/* 261 Action71 <- <{
+		/* 329 Action98 <- <{
 		    p.AssembleSortedExpression()
 		}> */
 		func() bool {
 			{
-				add(ruleAction71, position)
+				add(ruleAction98, position)
 			}
 			return true
 		},
-		/* 262 Action72 <- <{
+		/* 330 Action99 <- <{
 		    p.EnsureKeywordPresent(begin, end)
 		}> */
 		func() bool {
 			{
-				add(ruleAction72, position)
+				add(ruleAction99, position)
 			}
 			return true
 		},
-		/* 263 Action73 <- <{
+		/* 331 Action100 <- <{
 		    p.AssembleExpressions(begin, end)
 		    p.AssembleArray()
 		}> */
 		func() bool {
 			{
-				add(ruleAction73, position)
+				add(ruleAction100, position)
 			}
 			return true
 		},
-		/* 264 Action74 <- <{
+		/* 332 Action101 <- <{
 		    p.AssembleMap(begin, end)
 		}> */
 		func() bool {
 			{
-				add(ruleAction74, position)
+				add(ruleAction101, position)
 			}
 			return true
 		},
-		/* 265 Action75 <- <{
+		/* 333 Action102 <- <{
 		    p.AssembleKeyValuePair()
 		}> */
 		func() bool {
 			{
-				add(ruleAction75, position)
+				add(ruleAction102, position)
 			}
 			return true
 		},
-		/* 266 Action76 <- <{
+		/* 334 Action103 <- <{
 		    p.AssembleConditionCase(begin, end)
 		}> */
 		func() bool {
 			{
-				add(ruleAction76, position)
+				add(ruleAction103, position)
 			}
 			return true
 		},
-		/* 267 Action77 <- <{
+		/* 335 Action104 <- <{
 		    p.AssembleExpressionCase(begin, end)
 		}> */
 		func() bool {
 			{
-				add(ruleAction77, position)
+				add(ruleAction104, position)
 			}
 			return true
 		},
-		/* 268 Action78 <- <{
+		/* 336 Action105 <- <{
 		    p.AssembleWhenThenPair()
 		}> */
 		func() bool {
 			{
-				add(ruleAction78, position)
+				add(ruleAction105, position)
 			}
 			return true
 		},
-		/* 269 Action79 <- <{
+		/* 337 Action106 <- <{
 		    substr := string([]rune(buffer)[begin:end])
 		    p.PushComponent(begin, end, NewStream(substr))
 		}> */
 		func() bool {
 			{
-				add(ruleAction79, position)
+				add(ruleAction106, position)
 			}
 			return true
 		},
-		/* 270 Action80 <- <{
+		/* 338 Action107 <- <{
 		    substr := string([]rune(buffer)[begin:end])
 		    p.PushComponent(begin, end, NewRowMeta(substr, TimestampMeta))
 		}> */
 		func() bool {
 			{
-				add(ruleAction80, position)
+				add(ruleAction107, position)
+			}
+			return true
+		},
+		/* 339 Action108 <- <{
+		    substr := string([]rune(buffer)[begin:end])
+		    p.PushComponent(begin, end, NewRowMeta(substr, IDMeta))
+		}> */
+		func() bool {
+			{
+				add(ruleAction108, position)
+			}
+			return true
+		},
+		/* 340 Action109 <- <{
+		    substr := string([]rune(buffer)[begin:end])
+		    p.PushComponent(begin, end, NewRowMeta(substr, BackfillMeta))
+		}> */
+		func() bool {
+			{
+				add(ruleAction109, position)
 			}
 			return true
 		},
-		/* 271 Action81 <- <{
+		/* 341 Action110 <- <{
 		    substr := string([]rune(buffer)[begin:end])
 		    p.PushComponent(begin, end, NewRowValue(substr))
 		}> */
 		func() bool {
 			{
-				add(ruleAction81, position)
+				add(ruleAction110, position)
+			}
+			return true
+		},
+		/* 342 Action111 <- <{
+		    substr := string([]rune(buffer)[begin:end])
+		    p.PushComponent(begin, end, ConstantRef{substr[1:]})
+		}> */
+		func() bool {
+			{
+				add(ruleAction111, position)
 			}
 			return true
 		},
-		/* 272 Action82 <- <{
+		/* 343 Action112 <- <{
 		    substr := string([]rune(buffer)[begin:end])
 		    p.PushComponent(begin, end, NewNumericLiteral(substr))
 		}> */
 		func() bool {
 			{
-				add(ruleAction82, position)
+				add(ruleAction112, position)
 			}
 			return true
 		},
-		/* 273 Action83 <- <{
+		/* 344 Action113 <- <{
 		    substr := string([]rune(buffer)[begin:end])
 		    p.PushComponent(begin, end, NewNumericLiteral(substr))
 		}> */
 		func() bool {
 			{
-				add(ruleAction83, position)
+				add(ruleAction113, position)
 			}
 			return true
 		},
-		/* 274 Action84 <- <{
+		/* 345 Action114 <- <{
 		    substr := string([]rune(buffer)[begin:end])
 		    p.PushComponent(begin, end, NewFloatLiteral(substr))
 		}> */
 		func() bool {
 			{
-				add(ruleAction84, position)
+				add(ruleAction114, position)
 			}
 			return true
 		},
-		/* 275 Action85 <- <{
+		/* 346 Action115 <- <{
 		    substr := string([]rune(buffer)[begin:end])
 		    p.PushComponent(begin, end, FuncName(substr))
 		}> */
 		func() bool {
 			{
-				add(ruleAction85, position)
+				add(ruleAction115, position)
 			}
 			return true
 		},
-		/* 276 Action86 <- <{
+		/* 347 Action116 <- <{
 		    p.PushComponent(begin, end, NewNullLiteral())
 		}> */
 		func() bool {
 			{
-				add(ruleAction86, position)
+				add(ruleAction116, position)
 			}
 			return true
 		},
-		/* 277 Action87 <- <{
+		/* 348 Action117 <- <{
 		    p.PushComponent(begin, end, NewMissing())
 		}> */
 		func() bool {
 			{
-				add(ruleAction87, position)
+				add(ruleAction117, position)
 			}
 			return true
 		},
-		/* 278 Action88 <- <{
+		/* 349 Action118 <- <{
 		    p.PushComponent(begin, end, NewBoolLiteral(true))
 		}> */
 		func() bool {
 			{
-				add(ruleAction88, position)
+				add(ruleAction118, position)
 			}
 			return true
 		},
-		/* 279 Action89 <- <{
+		/* 350 Action119 <- <{
 		    p.PushComponent(begin, end, NewBoolLiteral(false))
 		}> */
 		func() bool {
 			{
-				add(ruleAction89, position)
+				add(ruleAction119, position)
 			}
 			return true
 		},
-		/* 280 Action90 <- <{
+		/* 351 Action120 <- <{
 		    substr := string([]rune(buffer)[begin:end])
 		    p.PushComponent(begin, end, NewWildcard(substr))
 		}> */
 		func() bool {
 			{
-				add(ruleAction90, position)
+				add(ruleAction120, position)
 			}
 			return true
 		},
-		/* 281 Action91 <- <{
+		/* 352 Action121 <- <{
 		    substr := string([]rune(buffer)[begin:end])
 		    p.PushComponent(begin, end, NewStringLiteral(substr))
 		}> */
 		func() bool {
 			{
-				add(ruleAction91, position)
+				add(ruleAction121, position)
 			}
 			return true
 		},
-		/* 282 Action92 <- <{
+		/* 353 Action122 <- <{
 		    p.PushComponent(begin, end, Istream)
 		}> */
 		func() bool {
 			{
-				add(ruleAction92, position)
+				add(ruleAction122, position)
 			}
 			return true
 		},
-		/* 283 Action93 <- <{
+		/* 354 Action123 <- <{
 		    p.PushComponent(begin, end, Dstream)
 		}> */
 		func() bool {
 			{
-				add(ruleAction93, position)
+				add(ruleAction123, position)
 			}
 			return true
 		},
-		/* 284 Action94 <- <{
+		/* 355 Action124 <- <{
 		    p.PushComponent(begin, end, Rstream)
 		}> */
 		func() bool {
 			{
-				add(ruleAction94, position)
+				add(ruleAction124, position)
 			}
 			return true
 		},
-		/* 285 Action95 <- <{
+		/* 356 Action125 <- <{
 		    p.PushComponent(begin, end, Tuples)
 		}> */
 		func() bool {
 			{
-				add(ruleAction95, position)
+				add(ruleAction125, position)
 			}
 			return true
 		},
-		/* 286 Action96 <- <{
+		/* 357 Action126 <- <{
 		    p.PushComponent(begin, end, Seconds)
 		}> */
 		func() bool {
 			{
-				add(ruleAction96, position)
+				add(ruleAction126, position)
 			}
 			return true
 		},
-		/* 287 Action97 <- <{
+		/* 358 Action127 <- <{
 		    p.PushComponent(begin, end, Milliseconds)
 		}> */
 		func() bool {
 			{
-				add(ruleAction97, position)
+				add(ruleAction127, position)
+			}
+			return true
+		},
+		/* 359 Action128 <- <{
+		    p.PushComponent(begin, end, InnerJoin)
+		}> */
+		func() bool {
+			{
+				add(ruleAction128, position)
+			}
+			return true
+		},
+		/* 360 Action129 <- <{
+		    p.PushComponent(begin, end, LeftOuterJoin)
+		}> */
+		func() bool {
+			{
+				add(ruleAction129, position)
+			}
+			return true
+		},
+		/* 361 Action130 <- <{
+		    p.PushComponent(begin, end, RightOuterJoin)
+		}> */
+		func() bool {
+			{
+				add(ruleAction130, position)
+			}
+			return true
+		},
+		/* 362 Action131 <- <{
+		    p.PushComponent(begin, end, FullOuterJoin)
+		}> */
+		func() bool {
+			{
+				add(ruleAction131, position)
 			}
 			return true
 		},
-		/* 288 Action98 <- <{
+		/* 363 Action132 <- <{
 		    p.PushComponent(begin, end, Wait)
 		}> */
 		func() bool {
 			{
-				add(ruleAction98, position)
+				add(ruleAction132, position)
 			}
 			return true
 		},
-		/* 289 Action99 <- <{
+		/* 364 Action133 <- <{
 		    p.PushComponent(begin, end, DropOldest)
 		}> */
 		func() bool {
 			{
-				add(ruleAction99, position)
+				add(ruleAction133, position)
 			}
 			return true
 		},
-		/* 290 Action100 <- <{
+		/* 365 Action134 <- <{
 		    p.PushComponent(begin, end, DropNewest)
 		}> */
 		func() bool {
 			{
-				add(ruleAction100, position)
+				add(ruleAction134, position)
 			}
 			return true
 		},
-		/* 291 Action101 <- <{
+		/* 366 Action135 <- <{
 		    substr := string([]rune(buffer)[begin:end])
 		    p.PushComponent(begin, end, StreamIdentifier(substr))
 		}> */
 		func() bool {
 			{
-				add(ruleAction101, position)
+				add(ruleAction135, position)
 			}
 			return true
 		},
-		/* 292 Action102 <- <{
+		/* 367 Action136 <- <{
 		    substr := string([]rune(buffer)[begin:end])
 		    p.PushComponent(begin, end, SourceSinkType(substr))
 		}> */
 		func() bool {
 			{
-				add(ruleAction102, position)
+				add(ruleAction136, position)
 			}
 			return true
 		},
-		/* 293 Action103 <- <{
+		/* 368 Action137 <- <{
 		    substr := string([]rune(buffer)[begin:end])
 		    p.PushComponent(begin, end, SourceSinkParamKey(substr))
 		}> */
 		func() bool {
 			{
-				add(ruleAction103, position)
+				add(ruleAction137, position)
 			}
 			return true
 		},
-		/* 294 Action104 <- <{
+		/* 369 Action138 <- <{
 		    p.PushComponent(begin, end, Yes)
 		}> */
 		func() bool {
 			{
-				add(ruleAction104, position)
+				add(ruleAction138, position)
 			}
 			return true
 		},
-		/* 295 Action105 <- <{
+		/* 370 Action139 <- <{
 		    p.PushComponent(begin, end, No)
 		}> */
 		func() bool {
 			{
-				add(ruleAction105, position)
+				add(ruleAction139, position)
 			}
 			return true
 		},
-		/* 296 Action106 <- <{
+		/* 371 Action140 <- <{
 		    p.PushComponent(begin, end, Yes)
 		}> */
 		func() bool {
 			{
-				add(ruleAction106, position)
+				add(ruleAction140, position)
 			}
 			return true
 		},
-		/* 297 Action107 <- <{
+		/* 372 Action141 <- <{
 		    p.PushComponent(begin, end, No)
 		}> */
 		func() bool {
 			{
-				add(ruleAction107, position)
+				add(ruleAction141, position)
 			}
 			return true
 		},
-		/* 298 Action108 <- <{
+		/* 373 Action142 <- <{
 		    p.PushComponent(begin, end, Bool)
 		}> */
 		func() bool {
 			{
-				add(ruleAction108, position)
+				add(ruleAction142, position)
 			}
 			return true
 		},
-		/* 299 Action109 <- <{
+		/* 374 Action143 <- <{
 		    p.PushComponent(begin, end, Int)
 		}> */
 		func() bool {
 			{
-				add(ruleAction109, position)
+				add(ruleAction143, position)
 			}
 			return true
 		},
-		/* 300 Action110 <- <{
+		/* 375 Action144 <- <{
 		    p.PushComponent(begin, end, Float)
 		}> */
 		func() bool {
 			{
-				add(ruleAction110, position)
+				add(ruleAction144, position)
 			}
 			return true
 		},
-		/* 301 Action111 <- <{
+		/* 376 Action145 <- <{
 		    p.PushComponent(begin, end, String)
 		}> */
 		func() bool {
 			{
-				add(ruleAction111, position)
+				add(ruleAction145, position)
 			}
 			return true
 		},
-		/* 302 Action112 <- <{
+		/* 377 Action146 <- <{
 		    p.PushComponent(begin, end, Blob)
 		}> */
 		func() bool {
 			{
-				add(ruleAction112, position)
+				add(ruleAction146, position)
 			}
 			return true
 		},
-		/* 303 Action113 <- <{
+		/* 378 Action147 <- <{
 		    p.PushComponent(begin, end, Timestamp)
 		}> */
 		func() bool {
 			{
-				add(ruleAction113, position)
+				add(ruleAction147, position)
 			}
 			return true
 		},
-		/* 304 Action114 <- <{
+		/* 379 Action148 <- <{
 		    p.PushComponent(begin, end, Array)
 		}> */
 		func() bool {
 			{
-				add(ruleAction114, position)
+				add(ruleAction148, position)
 			}
 			return true
 		},
-		/* 305 Action115 <- <{
+		/* 380 Action149 <- <{
 		    p.PushComponent(begin, end, Map)
 		}> */
 		func() bool {
 			{
-				add(ruleAction115, position)
+				add(ruleAction149, position)
 			}
 			return true
 		},
-		/* 306 Action116 <- <{
+		/* 381 Action150 <- <{
 		    p.PushComponent(begin, end, Or)
 		}> */
 		func() bool {
 			{
-				add(ruleAction116, position)
+				add(ruleAction150, position)
 			}
 			return true
 		},
-		/* 307 Action117 <- <{
+		/* 382 Action151 <- <{
 		    p.PushComponent(begin, end, And)
 		}> */
 		func() bool {
 			{
-				add(ruleAction117, position)
+				add(ruleAction151, position)
 			}
 			return true
 		},
-		/* 308 Action118 <- <{
+		/* 383 Action152 <- <{
 		    p.PushComponent(begin, end, Not)
 		}> */
 		func() bool {
 			{
-				add(ruleAction118, position)
+				add(ruleAction152, position)
 			}
 			return true
 		},
-		/* 309 Action119 <- <{
+		/* 384 Action153 <- <{
 		    p.PushComponent(begin, end, Equal)
 		}> */
 		func() bool {
 			{
-				add(ruleAction119, position)
+				add(ruleAction153, position)
 			}
 			return true
 		},
-		/* 310 Action120 <- <{
+		/* 385 Action154 <- <{
 		    p.PushComponent(begin, end, Less)
 		}> */
 		func() bool {
 			{
-				add(ruleAction120, position)
+				add(ruleAction154, position)
 			}
 			return true
 		},
-		/* 311 Action121 <- <{
+		/* 386 Action155 <- <{
 		    p.PushComponent(begin, end, LessOrEqual)
 		}> */
 		func() bool {
 			{
-				add(ruleAction121, position)
+				add(ruleAction155, position)
 			}
 			return true
 		},
-		/* 312 Action122 <- <{
+		/* 387 Action156 <- <{
 		    p.PushComponent(begin, end, Greater)
 		}> */
 		func() bool {
 			{
-				add(ruleAction122, position)
+				add(ruleAction156, position)
 			}
 			return true
 		},
-		/* 313 Action123 <- <{
+		/* 388 Action157 <- <{
 		    p.PushComponent(begin, end, GreaterOrEqual)
 		}> */
 		func() bool {
 			{
-				add(ruleAction123, position)
+				add(ruleAction157, position)
 			}
 			return true
 		},
-		/* 314 Action124 <- <{
+		/* 389 Action158 <- <{
 		    p.PushComponent(begin, end, NotEqual)
 		}> */
 		func() bool {
 			{
-				add(ruleAction124, position)
+				add(ruleAction158, position)
 			}
 			return true
 		},
-		/* 315 Action125 <- <{
+		/* 390 Action159 <- <{
 		    p.PushComponent(begin, end, Concat)
 		}> */
 		func() bool {
 			{
-				add(ruleAction125, position)
+				add(ruleAction159, position)
 			}
 			return true
 		},
-		/* 316 Action126 <- <{
+		/* 391 Action160 <- <{
 		    p.PushComponent(begin, end, Is)
 		}> */
 		func() bool {
 			{
-				add(ruleAction126, position)
+				add(ruleAction160, position)
 			}
 			return true
 		},
-		/* 317 Action127 <- <{
+		/* 392 Action161 <- <{
 		    p.PushComponent(begin, end, IsNot)
 		}> */
 		func() bool {
 			{
-				add(ruleAction127, position)
+				add(ruleAction161, position)
+			}
+			return true
+		},
+		/* 393 Action162 <- <{
+		    p.PushComponent(begin, end, IsDistinctFrom)
+		}> */
+		func() bool {
+			{
+				add(ruleAction162, position)
+			}
+			return true
+		},
+		/* 394 Action163 <- <{
+		    p.PushComponent(begin, end, IsNotDistinctFrom)
+		}> */
+		func() bool {
+			{
+				add(ruleAction163, position)
 			}
 			return true
 		},
-		/* 318 Action128 <- <{
+		/* 395 Action164 <- <{
 		    p.PushComponent(begin, end, Plus)
 		}> */
 		func() bool {
 			{
-				add(ruleAction128, position)
+				add(ruleAction164, position)
 			}
 			return true
 		},
-		/* 319 Action129 <- <{
+		/* 396 Action165 <- <{
 		    p.PushComponent(begin, end, Minus)
 		}> */
 		func() bool {
 			{
-				add(ruleAction129, position)
+				add(ruleAction165, position)
 			}
 			return true
 		},
-		/* 320 Action130 <- <{
+		/* 397 Action166 <- <{
 		    p.PushComponent(begin, end, Multiply)
 		}> */
 		func() bool {
 			{
-				add(ruleAction130, position)
+				add(ruleAction166, position)
 			}
 			return true
 		},
-		/* 321 Action131 <- <{
+		/* 398 Action167 <- <{
 		    p.PushComponent(begin, end, Divide)
 		}> */
 		func() bool {
 			{
-				add(ruleAction131, position)
+				add(ruleAction167, position)
 			}
 			return true
 		},
-		/* 322 Action132 <- <{
+		/* 399 Action168 <- <{
 		    p.PushComponent(begin, end, Modulo)
 		}> */
 		func() bool {
 			{
-				add(ruleAction132, position)
+				add(ruleAction168, position)
 			}
 			return true
 		},
-		/* 323 Action133 <- <{
+		/* 400 Action169 <- <{
 		    p.PushComponent(begin, end, UnaryMinus)
 		}> */
 		func() bool {
 			{
-				add(ruleAction133, position)
+				add(ruleAction169, position)
 			}
 			return true
 		},
-		/* 324 Action134 <- <{
+		/* 401 Action170 <- <{
 		    substr := string([]rune(buffer)[begin:end])
 		    p.PushComponent(begin, end, Identifier(substr))
 		}> */
 		func() bool {
 			{
-				add(ruleAction134, position)
+				add(ruleAction170, position)
 			}
 			return true
 		},
-		/* 325 Action135 <- <{
+		/* 402 Action171 <- <{
 		    substr := string([]rune(buffer)[begin:end])
 		    p.PushComponent(begin, end, Identifier(substr))
 		}> */
 		func() bool {
 			{
-				add(ruleAction135, position)
+				add(ruleAction171, position)
 			}
 			return true
 		},
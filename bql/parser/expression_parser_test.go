@@ -157,6 +157,17 @@ func TestExpressionParser(t *testing.T) {
 		"a IS MISSING":     {[]Expression{BinaryOpAST{Is, RowValue{"", "a"}, Missing{}}}, "a IS MISSING"},
 		"a IS NOT MISSING": {[]Expression{BinaryOpAST{IsNot, RowValue{"", "a"}, Missing{}}}, "a IS NOT MISSING"},
 		"a + 2 IS MISSING": {nil, ""}, // this is invalid, as opposed to IS NULL
+		"a IS DISTINCT FROM b": {[]Expression{BinaryOpAST{IsDistinctFrom, RowValue{"", "a"}, RowValue{"", "b"}}},
+			"a IS DISTINCT FROM b"},
+		"a IS NOT DISTINCT FROM NULL": {[]Expression{BinaryOpAST{IsNotDistinctFrom, RowValue{"", "a"}, NullLiteral{}}},
+			"a IS NOT DISTINCT FROM NULL"},
+		"a + 2 IS DISTINCT FROM b * 3": {[]Expression{BinaryOpAST{IsDistinctFrom,
+			BinaryOpAST{Plus, RowValue{"", "a"}, NumericLiteral{2}},
+			BinaryOpAST{Multiply, RowValue{"", "b"}, NumericLiteral{3}}}}, "a + 2 IS DISTINCT FROM b * 3"},
+		"a IS DISTINCT FROM b = c": {[]Expression{BinaryOpAST{Equal,
+			BinaryOpAST{IsDistinctFrom, RowValue{"", "a"}, RowValue{"", "b"}},
+			RowValue{"", "c"}}}, "a IS DISTINCT FROM b = c"},
+		"a IS DISTINCT b": {nil, ""},
 		// Plus/Minus Terms
 		"a + 2": {[]Expression{BinaryOpAST{Plus, RowValue{"", "a"}, NumericLiteral{2}}}, "a + 2"},
 		"a - 2": {[]Expression{BinaryOpAST{Minus, RowValue{"", "a"}, NumericLiteral{2}}}, "a - 2"},
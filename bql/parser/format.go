@@ -0,0 +1,78 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+)
+
+// formatIndent is the indentation of clauses of a SELECT statement nested in
+// another statement such as CREATE STREAM.
+const formatIndent = "    "
+
+// Format returns a canonical, human-readable representation of a statement
+// returned from ParseStmt. Keywords are written in upper case and each clause
+// of a SELECT statement is written on its own line:
+//
+//	CREATE STREAM s AS
+//	    SELECT RSTREAM a, b
+//	    FROM src [RANGE 1 TUPLES]
+//	    WHERE a > 0
+//
+// Statements not having a SELECT statement are formatted in one line.
+// The result doesn't have a trailing semicolon.
+func Format(stmt interface{}) string {
+	switch s := stmt.(type) {
+	case SelectStmt:
+		return formatSelect(s, "")
+	case SelectUnionStmt:
+		return formatSelectUnion(s, "")
+	case SelectStartingStmt:
+		return formatSelect(s.SelectStmt, "") + "\n" + s.Starting.startingString()
+	case ExplainAnalyzeStmt:
+		str := "EXPLAIN ANALYZE"
+		if s.Limit.Unit != UnspecifiedIntervalUnit {
+			str += " FOR " + s.Limit.FloatLiteral.String() + " " + s.Limit.Unit.String()
+		}
+		return str + "\n" + formatSelect(s.SelectStmt, formatIndent)
+	case CreateStreamAsSelectStmt:
+		str := "CREATE STREAM " + string(s.Name) + " AS\n" + formatSelect(s.Select, formatIndent)
+		if specs := s.SourceSinkSpecsAST.string("WITH"); specs != "" {
+			str += "\n" + specs
+		}
+		return str
+	case CreateStreamAsSelectUnionStmt:
+		return "CREATE STREAM " + string(s.Name) + " AS\n" + formatSelectUnion(s.SelectUnionStmt, formatIndent)
+	case InsertIntoSelectStmt:
+		return "INSERT INTO " + string(s.Sink) + "\n" + formatSelect(s.Select, formatIndent)
+	case fmt.Stringer:
+		return s.String()
+	}
+	return fmt.Sprint(stmt)
+}
+
+func formatSelect(s SelectStmt, indent string) string {
+	clauses := []string{
+		"SELECT " + s.EmitterAST.string() + " " + s.ProjectionsAST.string(),
+		s.WindowedFromAST.string(),
+		s.DeduplicateAST.string(),
+		s.FilterAST.string(),
+		s.GroupingAST.string(),
+		s.HavingAST.string(),
+	}
+
+	lines := []string{}
+	for _, c := range clauses {
+		if c != "" {
+			lines = append(lines, indent+c)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func formatSelectUnion(s SelectUnionStmt, indent string) string {
+	selects := make([]string, len(s.Selects))
+	for i, sel := range s.Selects {
+		selects[i] = formatSelect(sel, indent)
+	}
+	return strings.Join(selects, "\n"+indent+"UNION ALL\n")
+}
@@ -0,0 +1,74 @@
+package parser
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestFormat(t *testing.T) {
+	Convey("Given a BQL parser", t, func() {
+		p := New()
+
+		Convey("When formatting a CREATE STREAM statement", func() {
+			stmt, _, err := p.ParseStmt(`create stream s as select rstream a, b as c
+				from src [range 2 tuples] where a > 0 group by a, b having count(*) > 1`)
+			So(err, ShouldBeNil)
+
+			Convey("Then each clause should be on its own line", func() {
+				So(Format(stmt), ShouldEqual, `CREATE STREAM s AS
+    SELECT RSTREAM a, b AS c
+    FROM src [RANGE 2 TUPLES]
+    WHERE a > 0
+    GROUP BY a, b
+    HAVING count(*) > 1`)
+			})
+		})
+
+		Convey("When formatting a SELECT UNION statement", func() {
+			stmt, _, err := p.ParseStmt(`SELECT ISTREAM a FROM x [RANGE 1 TUPLES]
+				UNION ALL SELECT ISTREAM a FROM y [RANGE 1 TUPLES]`)
+			So(err, ShouldBeNil)
+
+			Convey("Then selects should be separated by UNION ALL", func() {
+				So(Format(stmt), ShouldEqual, `SELECT ISTREAM a
+FROM x [RANGE 1 TUPLES]
+UNION ALL
+SELECT ISTREAM a
+FROM y [RANGE 1 TUPLES]`)
+			})
+		})
+
+		Convey("When formatting a statement without SELECT", func() {
+			stmt, _, err := p.ParseStmt(`create source s type dummy with num=4`)
+			So(err, ShouldBeNil)
+
+			Convey("Then it should be formatted in one line", func() {
+				So(Format(stmt), ShouldEqual, `CREATE SOURCE s TYPE dummy WITH num=4`)
+			})
+		})
+
+		stmts := []string{
+			`CREATE STREAM s AS SELECT ISTREAM [EVERY 2ND TUPLE] x:a, y:* FROM x [RANGE 1 TUPLES], y [RANGE 5 SECONDS] WHERE x:b = y:b`,
+			`CREATE STREAM s AS SELECT ISTREAM a FROM x [RANGE 1 TUPLES] DEDUPLICATE BY a WITHIN 1 SECONDS UNION ALL SELECT ISTREAM a FROM y [RANGE 1 TUPLES]`,
+			`SELECT RSTREAM * FROM gen("x", 3) [RANGE 10 MILLISECONDS, BUFFER SIZE 5, DROP OLDEST IF FULL]`,
+			`SELECT RSTREAM window_start, count(*) FROM HOP(x, t, INTERVAL '1' MINUTE, INTERVAL 0.5 HOURS) AS y GROUP BY window_start`,
+			`SELECT RSTREAM a, b, count(*) FROM x [RANGE 10 SECONDS] GROUP BY GROUPING SETS ((a), (a, b), ()) HAVING count(*) > 1`,
+			`INSERT INTO snk FROM a, b WITH routing_field="origin"`,
+			`INSERT INTO snk SELECT ISTREAM a, b FROM x [RANGE 1 TUPLES] WHERE a > 0`,
+			`EXPLAIN ANALYZE FOR 10 SECONDS SELECT RSTREAM a FROM x [RANGE 1 TUPLES] WHERE a > 0`,
+		}
+		for _, s := range stmts {
+			s := s
+			Convey("When formatting "+s, func() {
+				stmt, _, err := p.ParseStmt(s)
+				So(err, ShouldBeNil)
+
+				Convey("Then the result should be parsed as the same statement", func() {
+					formatted, _, err := p.ParseStmt(Format(stmt))
+					So(err, ShouldBeNil)
+					So(formatted, ShouldResemble, stmt)
+				})
+			})
+		}
+	})
+}
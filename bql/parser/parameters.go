@@ -0,0 +1,259 @@
+package parser
+
+import (
+	"encoding/base64"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// BindParameters replaces placeholders in BQL statements with literals of
+// the given parameters. A placeholder $n refers to params[n-1]. Placeholders
+// in string literals and comments aren't replaced.
+//
+// A placeholder can have an expected type using the cast syntax like
+// $1::int. The parameter must have the type, or be NULL, to be bound. The
+// type annotation is removed from the result except for blob and timestamp.
+// An int parameter can be bound to a float placeholder and a float parameter
+// having an integral value can be bound to an int placeholder, since numbers
+// in JSON don't distinguish them. A string parameter can be bound to a blob
+// placeholder when it's encoded in base64 and to a timestamp placeholder when
+// it's in RFC3339.
+//
+// Parameters are always rendered as literals, so their values cannot change
+// the structure of statements. Blobs and timestamps are rendered as casts
+// of string literals, so they can only be used in expressions and not in
+// WITH clauses. It returns an error when a placeholder doesn't have
+// a corresponding parameter or a parameter isn't referred by any placeholder.
+func BindParameters(s string, params data.Array) (string, error) {
+	rs := []rune(s)
+	used := make([]bool, len(params))
+	res := make([]rune, 0, len(rs))
+	for i := 0; i < len(rs); {
+		switch {
+		case rs[i] == '"':
+			// An escaped double quote in a string literal is processed as
+			// the end of a literal and the beginning of another one.
+			j := i + 1
+			for j < len(rs) && rs[j] != '"' {
+				j++
+			}
+			if j < len(rs) {
+				j++
+			}
+			res = append(res, rs[i:j]...)
+			i = j
+
+		case rs[i] == '-' && i+1 < len(rs) && rs[i+1] == '-':
+			j := i
+			for j < len(rs) && rs[j] != '\n' && rs[j] != '\r' {
+				j++
+			}
+			res = append(res, rs[i:j]...)
+			i = j
+
+		case rs[i] == '$' && i+1 < len(rs) && '1' <= rs[i+1] && rs[i+1] <= '9':
+			j := i + 1
+			for j < len(rs) && unicode.IsDigit(rs[j]) {
+				j++
+			}
+			n, err := strconv.Atoi(string(rs[i+1 : j]))
+			if err != nil || n > len(params) {
+				return "", fmt.Errorf("placeholder $%v doesn't have a parameter", string(rs[i+1:j]))
+			}
+			typ, next := placeholderType(rs, j)
+			lit, err := bindParameter(params[n-1], typ)
+			if err != nil {
+				return "", fmt.Errorf("cannot bind parameter $%v: %v", n, err)
+			}
+			used[n-1] = true
+
+			// "-" followed by a negative number would start a comment.
+			if len(res) > 0 && res[len(res)-1] == '-' {
+				res = append(res, ' ')
+			}
+			res = append(res, []rune(lit)...)
+			i = next
+
+		default:
+			res = append(res, rs[i])
+			i++
+		}
+	}
+
+	for i, u := range used {
+		if !u {
+			return "", fmt.Errorf("parameter $%v isn't used in the statements", i+1)
+		}
+	}
+	return string(res), nil
+}
+
+// placeholderType returns the type annotated to the placeholder ending at
+// rs[i]. It returns an empty string if the placeholder doesn't have one.
+// The second return value is the index of the rune next to the annotation.
+func placeholderType(rs []rune, i int) (string, int) {
+	j := i
+	for j < len(rs) && unicode.IsSpace(rs[j]) {
+		j++
+	}
+	if j+1 >= len(rs) || rs[j] != ':' || rs[j+1] != ':' {
+		return "", i
+	}
+	j += 2
+	for j < len(rs) && unicode.IsSpace(rs[j]) {
+		j++
+	}
+	k := j
+	for k < len(rs) && (unicode.IsLetter(rs[k]) || unicode.IsDigit(rs[k]) || rs[k] == '_') {
+		k++
+	}
+	return strings.ToLower(string(rs[j:k])), k
+}
+
+// bindParameter validates the parameter against the expected type and
+// returns its literal.
+func bindParameter(v data.Value, typ string) (string, error) {
+	if typ == "" || v.Type() == data.TypeNull {
+		return valueLiteral(v)
+	}
+
+	invalid := fmt.Errorf("%v cannot be bound as %v", v.Type(), typ)
+	switch typ {
+	case "bool":
+		if v.Type() != data.TypeBool {
+			return "", invalid
+		}
+	case "int":
+		if v.Type() == data.TypeFloat {
+			f, _ := data.AsFloat(v)
+			if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+				return "", invalid
+			}
+			return valueLiteral(data.Int(f))
+		}
+		if v.Type() != data.TypeInt {
+			return "", invalid
+		}
+	case "float":
+		if v.Type() == data.TypeInt {
+			i, _ := data.AsInt(v)
+			return valueLiteral(data.Float(i))
+		}
+		if v.Type() != data.TypeFloat {
+			return "", invalid
+		}
+	case "string":
+		if v.Type() != data.TypeString {
+			return "", invalid
+		}
+	case "blob":
+		if v.Type() == data.TypeString {
+			b, err := data.ToBlob(v)
+			if err != nil {
+				return "", invalid
+			}
+			return valueLiteral(data.Blob(b))
+		}
+		if v.Type() != data.TypeBlob {
+			return "", invalid
+		}
+	case "timestamp":
+		if v.Type() == data.TypeString {
+			s, _ := data.AsString(v)
+			t, err := time.Parse(time.RFC3339Nano, s)
+			if err != nil {
+				return "", invalid
+			}
+			return valueLiteral(data.Timestamp(t))
+		}
+		if v.Type() != data.TypeTimestamp {
+			return "", invalid
+		}
+	case "array":
+		if v.Type() != data.TypeArray {
+			return "", invalid
+		}
+	case "map":
+		if v.Type() != data.TypeMap {
+			return "", invalid
+		}
+	default:
+		return "", fmt.Errorf("unknown type: %v", typ)
+	}
+	return valueLiteral(v)
+}
+
+// valueLiteral returns the BQL literal of the value.
+func valueLiteral(v data.Value) (string, error) {
+	switch v.Type() {
+	case data.TypeNull:
+		return "NULL", nil
+	case data.TypeBool:
+		b, _ := data.AsBool(v)
+		if b {
+			return "true", nil
+		}
+		return "false", nil
+	case data.TypeInt:
+		i, _ := data.AsInt(v)
+		return strconv.FormatInt(i, 10), nil
+	case data.TypeFloat:
+		f, _ := data.AsFloat(v)
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return "", fmt.Errorf("%v doesn't have a literal", f)
+		}
+		s := strconv.FormatFloat(f, 'f', -1, 64)
+		if !strings.Contains(s, ".") {
+			s += ".0"
+		}
+		return s, nil
+	case data.TypeString:
+		s, _ := data.AsString(v)
+		return stringLiteral(s), nil
+	case data.TypeBlob:
+		b, _ := data.AsBlob(v)
+		return stringLiteral(base64.StdEncoding.EncodeToString(b)) + "::blob", nil
+	case data.TypeTimestamp:
+		t, _ := data.AsTimestamp(v)
+		return stringLiteral(t.Format(time.RFC3339Nano)) + "::timestamp", nil
+	case data.TypeArray:
+		a, _ := data.AsArray(v)
+		elems := make([]string, len(a))
+		for i, e := range a {
+			l, err := valueLiteral(e)
+			if err != nil {
+				return "", err
+			}
+			elems[i] = l
+		}
+		return "[" + strings.Join(elems, ", ") + "]", nil
+	case data.TypeMap:
+		m, _ := data.AsMap(v)
+		keys := make([]string, 0, len(m))
+		for k := range m {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		entries := make([]string, len(keys))
+		for i, k := range keys {
+			l, err := valueLiteral(m[k])
+			if err != nil {
+				return "", err
+			}
+			entries[i] = stringLiteral(k) + ":" + l
+		}
+		return "{" + strings.Join(entries, ", ") + "}", nil
+	}
+	return "", fmt.Errorf("unsupported type: %v", v.Type())
+}
+
+func stringLiteral(s string) string {
+	return `"` + strings.Replace(s, `"`, `""`, -1) + `"`
+}
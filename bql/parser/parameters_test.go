@@ -0,0 +1,92 @@
+package parser
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"testing"
+	"time"
+)
+
+func TestBindParameters(t *testing.T) {
+	Convey("Given a BQL parser", t, func() {
+		p := New()
+
+		Convey("When binding parameters to placeholders", func() {
+			s, err := BindParameters(`SELECT RSTREAM a, $2 FROM s [RANGE 1 TUPLES]
+				WHERE b = $1 AND c = "$1" -- $3
+				AND d - $3 > 0`, data.Array{
+				data.String(`x" OR "y`), data.Map{"k": data.Array{data.Int(1), data.Null{}}}, data.Int(-3),
+			})
+			So(err, ShouldBeNil)
+
+			Convey("Then they should be replaced with literals", func() {
+				So(s, ShouldEqual, `SELECT RSTREAM a, {"k":[1, NULL]} FROM s [RANGE 1 TUPLES]
+				WHERE b = "x"" OR ""y" AND c = "$1" -- $3
+				AND d - -3 > 0`)
+
+				Convey("And the statement should be parsed", func() {
+					stmt, _, err := p.ParseStmt(s)
+					So(err, ShouldBeNil)
+					So(Format(stmt), ShouldContainSubstring, `b = "x"" OR ""y"`)
+				})
+			})
+		})
+
+		Convey("When binding parameters to a WITH clause", func() {
+			s, err := BindParameters(`CREATE SOURCE s TYPE dummy WITH a=$1::float, b=$2::int, c=$3`,
+				data.Array{data.Int(1), data.Float(2), data.Float(1.5)})
+			So(err, ShouldBeNil)
+
+			Convey("Then the statement should have literals", func() {
+				So(s, ShouldEqual, `CREATE SOURCE s TYPE dummy WITH a=1.0, b=2, c=1.5`)
+				_, _, err := p.ParseStmt(s)
+				So(err, ShouldBeNil)
+			})
+		})
+
+		Convey("When binding blobs and timestamps", func() {
+			ts := time.Date(2016, 1, 2, 3, 4, 5, 0, time.UTC)
+			s, err := BindParameters(`EVAL [$1::blob, $2::timestamp, $3]`,
+				data.Array{data.String("AQI="), data.String("2016-01-02T03:04:05Z"), data.Timestamp(ts)})
+			So(err, ShouldBeNil)
+
+			Convey("Then they should be casts of strings", func() {
+				So(s, ShouldEqual, `EVAL ["AQI="::blob, "2016-01-02T03:04:05Z"::timestamp, "2016-01-02T03:04:05Z"::timestamp]`)
+				_, _, err := p.ParseStmt(s)
+				So(err, ShouldBeNil)
+			})
+		})
+
+		Convey("When binding invalid parameters", func() {
+			cases := []struct {
+				title  string
+				stmt   string
+				params data.Array
+			}{
+				{"a missing parameter", `EVAL $1 + $2`, data.Array{data.Int(1)}},
+				{"an unused parameter", `EVAL $1`, data.Array{data.Int(1), data.Int(2)}},
+				{"a placeholder in a string", `EVAL "$1"`, data.Array{data.Int(1)}},
+				{"a wrong type", `EVAL $1::int`, data.Array{data.String("1")}},
+				{"a non-integral float", `EVAL $1::int`, data.Array{data.Float(1.5)}},
+				{"an invalid timestamp", `EVAL $1::timestamp`, data.Array{data.String("now")}},
+				{"an unknown type", `EVAL $1::integer`, data.Array{data.Int(1)}},
+			}
+			for _, c := range cases {
+				c := c
+				Convey("Then it should fail with "+c.title, func() {
+					_, err := BindParameters(c.stmt, c.params)
+					So(err, ShouldNotBeNil)
+				})
+			}
+		})
+
+		Convey("When binding NULL with a type", func() {
+			s, err := BindParameters(`EVAL $1::string`, data.Array{data.Null{}})
+			So(err, ShouldBeNil)
+
+			Convey("Then it should be bound", func() {
+				So(s, ShouldEqual, `EVAL NULL`)
+			})
+		})
+	})
+}
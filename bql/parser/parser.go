@@ -52,22 +52,104 @@ func (p *bqlParser) ParseStmt(s string) (result interface{}, rest string, err er
 	return stackElem.comp, rest, nil
 }
 
+// ParseStmts parses all statements in s. When some of the statements have
+// syntax errors, the parser skips to the next semicolon after each of them and
+// continues parsing so that all syntax errors in s are reported at once. In
+// that case, it returns a ParseErrors having the errors in the order they
+// appear in s. Positions of the errors are relative to the beginning of s.
 func (p *bqlParser) ParseStmts(s string) ([]interface{}, error) {
+	return p.parseStmts(s, nil)
+}
+
+// parseStmts parses all statements in s. onStmt is called with the range of
+// the text parsed as each statement when it isn't nil.
+func (p *bqlParser) parseStmts(s string, onStmt func(begin, end int)) ([]interface{}, error) {
 	// parse all statements
 	results := make([]interface{}, 0)
+	var errs ParseErrors
+	text := []rune(s)
 	rest := strings.TrimSpace(s)
+	// offset is the index of rest in text
+	offset := len(text) - len([]rune(strings.TrimLeftFunc(s, unicode.IsSpace)))
 	for rest != "" {
 		result, rest_, err := p.ParseStmt(rest)
 		if err != nil {
-			return nil, err
+			if pErr, ok := err.(*bqlParseError); ok {
+				pErr.text = text
+				pErr.offset = offset
+			}
+			errs = append(errs, err)
+			rest_ = skipStmt(rest)
+		} else {
+			// append the parsed statement to the result list
+			results = append(results, result)
+			if onStmt != nil {
+				onStmt(offset, offset+len([]rune(rest))-len([]rune(rest_)))
+			}
 		}
-		// append the parsed statement to the result list
-		results = append(results, result)
+		offset += len([]rune(rest)) - len([]rune(rest_))
 		rest = rest_
 	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
 	return results, nil
 }
 
+// skipStmt returns the part of s following the first semicolon which is
+// neither in a string literal nor in a comment. Leading spaces and semicolons
+// of the returned string are trimmed. It returns an empty string when s
+// doesn't have such a semicolon.
+func skipStmt(s string) string {
+	inString, inComment := false, false
+	rs := []rune(s)
+	for i := 0; i < len(rs); i++ {
+		c := rs[i]
+		switch {
+		case inComment:
+			inComment = c != '\n'
+		case inString:
+			if c == '"' {
+				if i+1 < len(rs) && rs[i+1] == '"' {
+					// "" is an escaped double quote
+					i++
+				} else {
+					inString = false
+				}
+			}
+		case c == '"':
+			inString = true
+		case c == '-' && i+1 < len(rs) && rs[i+1] == '-':
+			inComment = true
+		case c == ';':
+			return strings.TrimLeftFunc(string(rs[i+1:]), func(r rune) bool {
+				return unicode.IsSpace(r) || r == ';'
+			})
+		}
+	}
+	return ""
+}
+
+// ParseErrors has all errors found by ParseStmts.
+type ParseErrors []error
+
+// Error returns messages of all errors separated by blank lines.
+func (e ParseErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	if len(e) == 1 {
+		return msgs[0]
+	}
+	return fmt.Sprintf("found %v syntax errors:\n\n%v", len(e), strings.Join(msgs, "\n\n"))
+}
+
+// Code returns "parse_error", which is the same as core.ErrCodeParse.
+func (e ParseErrors) Code() string {
+	return "parse_error"
+}
+
 type bqlPeg struct {
 	bqlPegBackend
 }
@@ -77,7 +159,7 @@ func (b *bqlPeg) Parse(rule ...int) error {
 	// to place our own error before returning
 	if err := b.bqlPegBackend.Parse(rule...); err != nil {
 		if pErr, ok := err.(*parseError); ok {
-			return &bqlParseError{pErr}
+			return &bqlParseError{parseError: pErr}
 		}
 		return err
 	}
@@ -86,11 +168,71 @@ func (b *bqlPeg) Parse(rule ...int) error {
 
 type bqlParseError struct {
 	*parseError
+
+	// text is the whole text given to ParseStmts and offset is the index of
+	// the failed statement in text. text is nil when the error is returned
+	// from ParseStmt.
+	text   []rune
+	offset int
+}
+
+// Code returns "parse_error", which is the same as core.ErrCodeParse.
+func (e *bqlParseError) Code() string {
+	return "parse_error"
+}
+
+// Position returns the 1-origin line and column at which the syntax error
+// was found.
+func (e *bqlParseError) Position() (line, column int) {
+	buf := e.p.buffer
+	if e.text != nil {
+		buf = e.text
+	}
+	end := int(e.max.end) + e.offset
+	if n := len(buf); end >= n {
+		if n == 0 {
+			return 1, 1
+		}
+		end = n - 1
+	}
+	pos := translatePositions(buf, []int{end})[end]
+	return pos.line, pos.symbol
+}
+
+// buffer returns the text in which the error was found.
+func (e *bqlParseError) buffer() []rune {
+	if e.text != nil {
+		return e.text
+	}
+	return []rune(e.p.Buffer)
+}
+
+// ErrorPosition returns the 1-origin line and column of a syntax error
+// returned from Parser. It returns false when the error doesn't have the
+// position. The position of the first error is returned for ParseErrors.
+func ErrorPosition(err error) (line, column int, ok bool) {
+	type positioner interface {
+		Position() (int, int)
+	}
+	if errs, ok := err.(ParseErrors); ok {
+		for _, e := range errs {
+			if line, column, ok := ErrorPosition(e); ok {
+				return line, column, true
+			}
+		}
+		return 0, 0, false
+	}
+	p, ok := err.(positioner)
+	if !ok {
+		return 0, 0, false
+	}
+	line, column = p.Position()
+	return line, column, true
 }
 
 func (e *bqlParseError) Error() string {
 	error := "failed to parse string as BQL statement\n"
-	stmt := []rune(e.p.Buffer)
+	stmt := e.buffer()
 	// now find the offensive line
 	foundError := false
 	for _, token := range e.p.Tokens() {
@@ -111,9 +253,9 @@ func (e *bqlParseError) Error() string {
 		} else if end > 0 {
 			// collect the max token in error and translate their
 			// string indexes into line/symbol pairs
-			end = int(e.max.end)
-			positions := []int{int(e.max.begin), end}
-			translations := translatePositions(e.p.buffer, positions)
+			end = int(e.max.end) + e.offset
+			positions := []int{int(e.max.begin) + e.offset, end}
+			translations := translatePositions(stmt, positions)
 			error += fmt.Sprintf("statement has a syntax error near line %v, symbol %v:\n",
 				translations[end].line, translations[end].symbol)
 			// we want some output like:
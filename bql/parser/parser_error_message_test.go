@@ -68,3 +68,89 @@ consider to look up the documentation for CreateStreamAsSelectStmt`,
 	})
 
 }
+
+func TestParserErrorPosition(t *testing.T) {
+	Convey("Given a BQL parser", t, func() {
+		p := New()
+
+		Convey("When parsing a statement having a syntax error", func() {
+			_, _, err := p.ParseStmt("CREATE STREAM x AS\n  SELECT ISTREAM 2 + x:*")
+			So(err, ShouldNotBeNil)
+
+			Convey("Then the error should have its position", func() {
+				line, col, ok := ErrorPosition(err)
+				So(ok, ShouldBeTrue)
+				So(line, ShouldEqual, 2)
+				So(col, ShouldEqual, 23)
+			})
+
+			Convey("Then the error should have the parse error code", func() {
+				So(err.(interface {
+					Code() string
+				}).Code(), ShouldEqual, "parse_error")
+			})
+		})
+	})
+}
+
+func TestParseStmtsErrors(t *testing.T) {
+	Convey("Given a BQL parser", t, func() {
+		p := New()
+
+		Convey("When parsing statements having several syntax errors", func() {
+			_, err := p.ParseStmts(`CREATE SOURCE s TYPE dummy;
+-- a comment having ; in it
+CREATE STRAEM x AS SELECT ISTREAM ";" FROM s [RANGE 1 TUPLES];
+CREATE STREAM y AS
+  SELECT ISTREAM 2 + x:* FROM s [RANGE 1 TUPLES];
+DROP SOURCE s;
+REWIND SOURCE ab cd`)
+			So(err, ShouldNotBeNil)
+
+			Convey("Then all errors should be reported", func() {
+				errs, ok := err.(ParseErrors)
+				So(ok, ShouldBeTrue)
+				So(len(errs), ShouldEqual, 3)
+
+				Convey("And they should have positions in the whole text", func() {
+					var positions [][]int
+					for _, e := range errs {
+						line, col, ok := ErrorPosition(e)
+						So(ok, ShouldBeTrue)
+						positions = append(positions, []int{line, col})
+					}
+					So(positions, ShouldResemble, [][]int{{3, 8}, {5, 23}, {7, 18}})
+
+					line, col, ok := ErrorPosition(err)
+					So(ok, ShouldBeTrue)
+					So(line, ShouldEqual, 3)
+					So(col, ShouldEqual, 8)
+				})
+
+				Convey("And their messages should have the offending snippets", func() {
+					So(errs[1].Error(), ShouldEndWith, `statement has a syntax error near line 5, symbol 23:
+  ...SELECT ISTREAM 2 + x:* FROM s [RANGE 1 TUPLES]; DR...
+                         ^
+consider to look up the documentation for CreateStreamAsSelectStmt`)
+					So(err.Error(), ShouldStartWith, "found 3 syntax errors:\n\n")
+				})
+
+				Convey("And the error should have the parse error code", func() {
+					So(errs.Code(), ShouldEqual, "parse_error")
+				})
+			})
+		})
+
+		Convey("When parsing statements having a syntax error at the end without a semicolon", func() {
+			_, err := p.ParseStmts("SELECT ISTREAM a;\nSELECT ISTREAM b c")
+
+			Convey("Then the error should be reported", func() {
+				So(err, ShouldNotBeNil)
+				line, col, ok := ErrorPosition(err)
+				So(ok, ShouldBeTrue)
+				So(line, ShouldEqual, 2)
+				So(col, ShouldEqual, 18)
+			})
+		})
+	})
+}
@@ -0,0 +1,142 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// StmtSource is the text of a statement and where it's written. It's
+// returned from ParseStmtsWithSources.
+type StmtSource struct {
+	// File is the path of the file having the statement. It's empty when the
+	// statement isn't read from a file. The parser doesn't set it.
+	File string
+
+	// Line and Column are the 1-origin position of the beginning of the
+	// statement. Column is counted in characters.
+	Line   int
+	Column int
+
+	// Text is the statement as written including comments in it and the
+	// terminating semicolon.
+	Text string
+
+	// Comment is the text of comment lines immediately preceding the
+	// statement without "--". Lines are separated by "\n".
+	Comment string
+}
+
+// String returns the location of the statement such as "topology.bql:3:1".
+func (s *StmtSource) String() string {
+	if s.File == "" {
+		return fmt.Sprintf("%v:%v", s.Line, s.Column)
+	}
+	return fmt.Sprintf("%v:%v:%v", s.File, s.Line, s.Column)
+}
+
+// ParseStmtsWithSources is ParseStmts which also returns the source of each
+// statement. Sources are in the same order as statements.
+func (p *bqlParser) ParseStmtsWithSources(s string) ([]interface{}, []*StmtSource, error) {
+	var srcs []*StmtSource
+	text := []rune(s)
+	prevEnd := 0
+	stmts, err := p.parseStmts(s, func(begin, end int) {
+		b, e := stmtExtent(text[begin:end])
+		b, e = b+begin, e+begin
+		line, column := 1, 1
+		for _, c := range text[:b] {
+			if c == '\n' {
+				line, column = line+1, 1
+			} else {
+				column++
+			}
+		}
+		srcs = append(srcs, &StmtSource{
+			Line:    line,
+			Column:  column,
+			Text:    string(text[b:e]),
+			Comment: leadingComment(text, prevEnd, b),
+		})
+		prevEnd = e
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return stmts, srcs, nil
+}
+
+// stmtExtent returns the range of a statement in the text parsed as the
+// statement, which has spaces and comments around it. The range includes the
+// terminating semicolon.
+func stmtExtent(rs []rune) (begin, end int) {
+	begin = -1
+	inString, inComment := false, false
+	for i := 0; i < len(rs); i++ {
+		c := rs[i]
+		switch {
+		case inComment:
+			inComment = c != '\n' && c != '\r'
+			continue
+		case inString:
+			if c == '"' {
+				if i+1 < len(rs) && rs[i+1] == '"' {
+					// "" is an escaped double quote
+					i++
+				} else {
+					inString = false
+				}
+			}
+		case c == '-' && i+1 < len(rs) && rs[i+1] == '-':
+			inComment = true
+			continue
+		case unicode.IsSpace(c):
+			continue
+		case c == ';':
+			if begin < 0 {
+				continue
+			}
+			return begin, i + 1
+		case c == '"':
+			inString = true
+		}
+		if begin < 0 {
+			begin = i
+		}
+		end = i + 1
+	}
+	if begin < 0 {
+		return 0, 0
+	}
+	return begin, end
+}
+
+// leadingComment returns comment lines between from and the statement at
+// stmtBegin which aren't separated from the statement by a blank line. A
+// comment following another statement on the same line isn't included.
+func leadingComment(text []rune, from, stmtBegin int) string {
+	lines := strings.Split(string(text[from:stmtBegin]), "\n")
+	var comments []string
+	// The last line is the one having the statement and the first one
+	// follows the previous statement unless it starts at the beginning of a
+	// line.
+	first := 0
+	if from > 0 && text[from-1] != '\n' {
+		first = 1
+	}
+	for i := len(lines) - 2; i >= first; i-- {
+		l := strings.TrimSpace(lines[i])
+		if !strings.HasPrefix(l, "--") {
+			break
+		}
+		l = strings.TrimPrefix(l, "--")
+		if strings.HasPrefix(l, " ") {
+			l = l[1:]
+		}
+		comments = append(comments, l)
+	}
+	for i, j := 0, len(comments)-1; i < j; i, j = i+1, j-1 {
+		comments[i], comments[j] = comments[j], comments[i]
+	}
+	return strings.Join(comments, "\n")
+}
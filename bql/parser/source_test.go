@@ -0,0 +1,60 @@
+package parser
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestParseStmtsWithSources(t *testing.T) {
+	Convey("Given a BQL parser", t, func() {
+		p := New()
+
+		Convey("When parsing statements with comments", func() {
+			stmts, srcs, err := p.ParseStmtsWithSources(`-- the header of the file
+
+-- a source
+-- generating tuples
+CREATE SOURCE s TYPE dummy; -- a trailing comment
+  CREATE STREAM t AS SELECT ISTREAM * -- all fields
+    FROM s [RANGE 1 TUPLES] WHERE a = "--;";
+-- a sink
+
+CREATE SINK snk TYPE stdout`)
+			So(err, ShouldBeNil)
+
+			Convey("Then sources should be returned for all statements", func() {
+				So(stmts, ShouldHaveLength, 3)
+				So(srcs, ShouldHaveLength, 3)
+			})
+
+			Convey("Then each source should have the text and the position", func() {
+				So(srcs[0].Text, ShouldEqual, "CREATE SOURCE s TYPE dummy;")
+				So(srcs[0].Line, ShouldEqual, 5)
+				So(srcs[0].Column, ShouldEqual, 1)
+				So(srcs[1].Text, ShouldEqual, `CREATE STREAM t AS SELECT ISTREAM * -- all fields
+    FROM s [RANGE 1 TUPLES] WHERE a = "--;";`)
+				So(srcs[1].Line, ShouldEqual, 6)
+				So(srcs[1].Column, ShouldEqual, 3)
+				So(srcs[1].String(), ShouldEqual, "6:3")
+				So(srcs[2].Text, ShouldEqual, "CREATE SINK snk TYPE stdout")
+				So(srcs[2].Line, ShouldEqual, 10)
+			})
+
+			Convey("Then comments immediately preceding statements should be kept", func() {
+				So(srcs[0].Comment, ShouldEqual, "a source\ngenerating tuples")
+				So(srcs[1].Comment, ShouldEqual, "")
+				So(srcs[2].Comment, ShouldEqual, "")
+			})
+		})
+
+		Convey("When parsing statements having a syntax error", func() {
+			_, srcs, err := p.ParseStmtsWithSources("CREATE SOURCE s TYPE dummy; CREATE SOURCE;")
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+				So(srcs, ShouldBeNil)
+			})
+		})
+	})
+}
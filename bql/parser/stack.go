@@ -2,6 +2,7 @@ package parser
 
 import (
 	"fmt"
+	"strings"
 
 	"gopkg.in/sensorbee/sensorbee.v0/data"
 )
@@ -72,25 +73,27 @@ func (ps *parseStack) Peek() (value *ParsedComponent) {
 //  HavingAST
 //  GroupingAST
 //  FilterAST
+//  DeduplicateAST
 //  WindowedFromAST
 //  ProjectionsAST
 //   =>
-//  SelectStmt{EmitterAST, ProjectionsAST, WindowedFromAST, FilterAST, GroupingAST, HavingAST}
+//  SelectStmt{EmitterAST, ProjectionsAST, WindowedFromAST, DeduplicateAST, FilterAST, GroupingAST, HavingAST}
 func (ps *parseStack) AssembleSelect() {
 	// pop the components from the stack in reverse order
-	_having, _grouping, _filter, _from, _projections, _emitter := ps.pop6()
+	_having, _grouping, _filter, _dedup, _from, _projections, _emitter := ps.pop7()
 
 	// extract and convert the contained structure
 	// (if this fails, this is a fundamental parser bug => panic ok)
 	having := _having.comp.(HavingAST)
 	grouping := _grouping.comp.(GroupingAST)
 	filter := _filter.comp.(FilterAST)
+	dedup := _dedup.comp.(DeduplicateAST)
 	from := _from.comp.(WindowedFromAST)
 	projections := _projections.comp.(ProjectionsAST)
 	emitter := _emitter.comp.(EmitterAST)
 
 	// assemble the SelectStmt and push it back
-	s := SelectStmt{emitter, projections, from, filter, grouping, having}
+	s := SelectStmt{emitter, projections, from, dedup, filter, grouping, having}
 	se := ParsedComponent{_emitter.begin, _having.end, s}
 	ps.Push(&se)
 }
@@ -111,29 +114,62 @@ func (ps *parseStack) AssembleSelectUnion(begin int, end int) {
 		selects[i] = elems[i].(SelectStmt)
 	}
 	// push the grouped list back
-	ps.PushComponent(begin, end, SelectUnionStmt{selects})
+	ps.PushComponent(begin, end, SelectUnionStmt{selects, false})
+}
+
+// AssembleSelectAlignedUnion is AssembleSelectUnion for SELECT statements
+// combined by UNION instead of UNION ALL. The resulting SelectUnionStmt
+// has Aligned set to true.
+func (ps *parseStack) AssembleSelectAlignedUnion(begin int, end int) {
+	ps.AssembleSelectUnion(begin, end)
+	top := ps.Peek()
+	s := top.comp.(SelectUnionStmt)
+	s.Aligned = true
+	top.comp = s
+}
+
+// AssembleSelectStarting takes the topmost elements from the stack, assuming
+// they are components of a SELECT ... STARTING ... AGO statement, and
+// replaces them by a single SelectStartingStmt element.
+//
+//  Raw (the unit)
+//  NumericLiteral or FloatLiteral
+//  SelectStmt
+//   =>
+//  SelectStartingStmt{SelectStmt, IntervalAST}
+func (ps *parseStack) AssembleSelectStarting() {
+	ps.AssembleIntervalLiteral()
+	_starting, _select := ps.pop2()
+
+	s := SelectStartingStmt{
+		_select.comp.(SelectStmt),
+		_starting.comp.(IntervalAST),
+	}
+	ps.PushComponent(_select.begin, _starting.end, s)
 }
 
 // AssembleCreateStreamAsSelect takes the topmost elements from the stack,
 // assuming they are components of a CREATE STREAM statement, and
 // replaces them by a single CreateStreamAsSelectStmt element.
 //
+//  SourceSinkSpecsAST
 //  SelectStmt
 //  StreamIdentifier
 //   =>
-//  CreateStreamAsSelectStmt{StreamIdentifier, SelectStmt}
+//  CreateStreamAsSelectStmt{StreamIdentifier, SelectStmt, SourceSinkSpecsAST}
 func (ps *parseStack) AssembleCreateStreamAsSelect() {
 	// now pop the components from the stack in reverse order
-	_select, _name := ps.pop2()
+	_specs, _select, _name := ps.pop3()
 
 	// extract and convert the contained structure
 	// (if this fails, this is a fundamental parser bug => panic ok)
+	specs := _specs.comp.(SourceSinkSpecsAST)
 	s := _select.comp.(SelectStmt)
 	name := _name.comp.(StreamIdentifier)
 
 	// assemble the SelectStmt and push it back
-	css := CreateStreamAsSelectStmt{name, s}
-	se := ParsedComponent{_name.begin, _select.end, css}
+	css := CreateStreamAsSelectStmt{name, s, specs}
+	se := ParsedComponent{_name.begin, _specs.end, css}
 	ps.Push(&se)
 }
 
@@ -160,6 +196,30 @@ func (ps *parseStack) AssembleCreateStreamAsSelectUnion() {
 	ps.Push(&se)
 }
 
+// AssembleCreateStreamAsEnrich takes the topmost elements from the
+// stack, assuming they are components of a CREATE STREAM AS ENRICH
+// statement, and replaces them by a single CreateStreamAsEnrichStmt element.
+//
+//  SourceSinkSpecsAST
+//  SourceSinkType
+//  StreamIdentifier
+//  StreamIdentifier
+//   =>
+//  CreateStreamAsEnrichStmt{StreamIdentifier, StreamIdentifier,
+//    SourceSinkType, SourceSinkSpecsAST}
+func (ps *parseStack) AssembleCreateStreamAsEnrich() {
+	_specs, _loader, _input, _name := ps.pop4()
+
+	specs := _specs.comp.(SourceSinkSpecsAST)
+	loader := _loader.comp.(SourceSinkType)
+	input := _input.comp.(StreamIdentifier)
+	name := _name.comp.(StreamIdentifier)
+
+	s := CreateStreamAsEnrichStmt{name, input, loader, specs}
+	se := ParsedComponent{_name.begin, _specs.end, s}
+	ps.Push(&se)
+}
+
 // AssembleCreateSource takes the topmost elements from the stack,
 // assuming they are components of a CREATE SOURCE statement, and
 // replaces them by a single CreateSourceStmt element.
@@ -291,21 +351,177 @@ func (ps *parseStack) AssembleUpdateSink() {
 // assuming they are components of a INSERT ... FROM ... statement, and
 // replaces them by a single InsertIntoFromStmt element.
 //
-//  StreamIdentifier
+//  SourceSinkSpecsAST
+//  []StreamIdentifier
 //  StreamIdentifier
 //   =>
-//  InsertIntoFromStmt{StreamIdentifier, StreamIdentifier}
+//  InsertIntoFromStmt{StreamIdentifier, []StreamIdentifier, SourceSinkSpecsAST}
 func (ps *parseStack) AssembleInsertIntoFrom() {
-	_input, _sink := ps.pop2()
+	_specs, _inputs, _sink := ps.pop3()
 
-	input := _input.comp.(StreamIdentifier)
+	specs := _specs.comp.(SourceSinkSpecsAST)
+	inputs := _inputs.comp.([]StreamIdentifier)
+	sink := _sink.comp.(StreamIdentifier)
+
+	s := InsertIntoFromStmt{sink, inputs, specs}
+	se := ParsedComponent{_sink.begin, _specs.end, s}
+	ps.Push(&se)
+}
+
+// AssembleInsertIntoSelect takes the topmost elements from the stack,
+// assuming they are components of a INSERT ... SELECT ... statement, and
+// replaces them by a single InsertIntoSelectStmt element.
+//
+//  SelectStmt
+//  StreamIdentifier
+//   =>
+//  InsertIntoSelectStmt{StreamIdentifier, SelectStmt}
+func (ps *parseStack) AssembleInsertIntoSelect() {
+	_select, _sink := ps.pop2()
+
+	s := _select.comp.(SelectStmt)
 	sink := _sink.comp.(StreamIdentifier)
 
-	s := InsertIntoFromStmt{sink, input}
-	se := ParsedComponent{_sink.begin, _input.end, s}
+	is := InsertIntoSelectStmt{sink, s}
+	se := ParsedComponent{_sink.begin, _select.end, is}
+	ps.Push(&se)
+}
+
+// AssembleSplit takes the topmost elements from the stack, assuming
+// they are components of a SPLIT statement, and replaces them by
+// a single SplitStmt element.
+//
+//  StreamIdentifier
+//  []SplitBranchAST
+//   =>
+//  SplitStmt{StreamIdentifier, []SplitBranchAST}
+func (ps *parseStack) AssembleSplit() {
+	_branches, _input := ps.pop2()
+
+	branches := _branches.comp.([]SplitBranchAST)
+	input := _input.comp.(StreamIdentifier)
+
+	s := SplitStmt{input, branches}
+	se := ParsedComponent{_input.begin, _branches.end, s}
+	ps.Push(&se)
+}
+
+// AssembleArchiveStream takes the topmost elements from the stack,
+// assuming they are components of an ARCHIVE STREAM statement, and
+// replaces them by a single ArchiveStreamStmt element.
+//
+//  StreamIdentifier
+//  StringLiteral
+//  SourceSinkSpecsAST
+//   =>
+//  ArchiveStreamStmt{StreamIdentifier, string, SourceSinkSpecsAST}
+func (ps *parseStack) AssembleArchiveStream() {
+	_specs, _dir, _stream := ps.pop3()
+
+	specs := _specs.comp.(SourceSinkSpecsAST)
+	dir := _dir.comp.(StringLiteral)
+	stream := _stream.comp.(StreamIdentifier)
+
+	s := ArchiveStreamStmt{stream, dir.Value, specs}
+	se := ParsedComponent{_stream.begin, _specs.end, s}
+	ps.Push(&se)
+}
+
+// AssembleReplayArchive takes the topmost elements from the stack,
+// assuming they are components of a REPLAY ARCHIVE statement, and
+// replaces them by a single ReplayArchiveStmt element.
+//
+//  StringLiteral
+//  StreamIdentifier
+//  StringLiteral
+//  StringLiteral
+//  SourceSinkSpecsAST
+//   =>
+//  ReplayArchiveStmt{string, StreamIdentifier, string, string,
+//    SourceSinkSpecsAST}
+func (ps *parseStack) AssembleReplayArchive() {
+	_specs, _to, _from, _stream, _dir := ps.pop5()
+
+	specs := _specs.comp.(SourceSinkSpecsAST)
+	to := _to.comp.(StringLiteral)
+	from := _from.comp.(StringLiteral)
+	stream := _stream.comp.(StreamIdentifier)
+	dir := _dir.comp.(StringLiteral)
+
+	s := ReplayArchiveStmt{dir.Value, stream, from.Value, to.Value, specs}
+	se := ParsedComponent{_dir.begin, _specs.end, s}
 	ps.Push(&se)
 }
 
+// AssembleSplitBranches takes the elements from the stack that
+// correspond to the input[begin:end] string and pushes a slice
+// with all of them back to the stack.
+//
+//  SplitBranchAST
+//  SplitBranchAST
+//   =>
+//  []SplitBranchAST{SplitBranchAST, SplitBranchAST}
+func (ps *parseStack) AssembleSplitBranches(begin int, end int) {
+	elems := ps.collectElements(begin, end)
+	branches := make([]SplitBranchAST, len(elems))
+	for i := range elems {
+		branches[i] = elems[i].(SplitBranchAST)
+	}
+	// push the grouped list back
+	ps.PushComponent(begin, end, branches)
+}
+
+// AssembleSplitBranch takes the topmost elements from the stack, assuming
+// they are components of a WHEN branch of a SPLIT statement, and replaces
+// them by a single SplitBranchAST element.
+//
+//  StreamIdentifier
+//  Expression
+//   =>
+//  SplitBranchAST{StreamIdentifier, Expression}
+func (ps *parseStack) AssembleSplitBranch() {
+	_cond, _name := ps.pop2()
+
+	cond := _cond.comp.(Expression)
+	name := _name.comp.(StreamIdentifier)
+
+	ps.PushComponent(_name.begin, _cond.end, SplitBranchAST{name, cond})
+}
+
+// AssembleSplitOtherwise takes the topmost element from the stack,
+// assuming it is the name of the OTHERWISE branch of a SPLIT statement,
+// and replaces it by a single SplitBranchAST element.
+//
+//  StreamIdentifier
+//   =>
+//  SplitBranchAST{StreamIdentifier, nil}
+func (ps *parseStack) AssembleSplitOtherwise() {
+	_name := ps.Pop()
+
+	name := _name.comp.(StreamIdentifier)
+
+	ps.PushComponent(_name.begin, _name.end, SplitBranchAST{name, nil})
+}
+
+// AssembleStreamIdentifiers takes the elements from the stack that
+// correspond to the input[begin:end] string and pushes a slice
+// with all of them back to the stack.
+//
+//  StreamIdentifier
+//  StreamIdentifier
+//  StreamIdentifier
+//   =>
+//  []StreamIdentifier{StreamIdentifier, StreamIdentifier, StreamIdentifier}
+func (ps *parseStack) AssembleStreamIdentifiers(begin int, end int) {
+	elems := ps.collectElements(begin, end)
+	ids := make([]StreamIdentifier, len(elems))
+	for i := range elems {
+		ids[i] = elems[i].(StreamIdentifier)
+	}
+	// push the grouped list back
+	ps.PushComponent(begin, end, ids)
+}
+
 // AssemblePauseSource takes the topmost elements from the stack,
 // assuming they are components of a PAUSE SOURCE statement, and
 // replaces them by a single PauseSourceStmt element.
@@ -530,6 +746,58 @@ func (ps *parseStack) AssembleEval(begin, end int) {
 	ps.Push(&se)
 }
 
+// AssembleExplainAnalyze takes the topmost elements from the stack, assuming
+// they are components of an EXPLAIN ANALYZE statement, and replaces them by a
+// single ExplainAnalyzeStmt element.
+//
+//  SelectStmt
+//  IntervalAST
+//   =>
+//  ExplainAnalyzeStmt{SelectStmt, IntervalAST}
+func (ps *parseStack) AssembleExplainAnalyze() {
+	_select, _limit := ps.pop2()
+	s := ExplainAnalyzeStmt{
+		_select.comp.(SelectStmt),
+		_limit.comp.(IntervalAST),
+	}
+	ps.PushComponent(_limit.begin, _select.end, s)
+}
+
+// AssembleShowFunctions pushes a ShowFunctionsStmt element on the stack.
+func (ps *parseStack) AssembleShowFunctions(begin, end int) {
+	ps.PushComponent(begin, end, ShowFunctionsStmt{})
+}
+
+// AssembleReloadFunction takes the topmost element from the stack, assuming
+// it is a component of a RELOAD FUNCTION statement, and replaces it by a
+// single ReloadFunctionStmt element.
+//
+//  FuncName
+//   =>
+//  ReloadFunctionStmt{FuncName}
+func (ps *parseStack) AssembleReloadFunction(begin, end int) {
+	_name := ps.Pop()
+	name := _name.comp.(FuncName)
+	ps.PushComponent(begin, end, ReloadFunctionStmt{name})
+}
+
+// AssembleSetConstant takes the topmost elements from the stack, assuming
+// they are components of a SET CONSTANT statement, and replaces them by
+// a single SetConstantStmt element.
+//
+//  Expression
+//  StreamIdentifier
+//   =>
+//  SetConstantStmt{StreamIdentifier, Expression}
+func (ps *parseStack) AssembleSetConstant() {
+	_value, _name := ps.pop2()
+
+	name := _name.comp.(StreamIdentifier)
+	value := _value.comp.(Expression)
+
+	ps.PushComponent(_name.begin, _value.end, SetConstantStmt{name, value})
+}
+
 /* Projections/Columns */
 
 // AssembleEmitter takes the topmost elements from the stack, assuming
@@ -654,11 +922,16 @@ func (ps *parseStack) AssembleAlias() {
 //  AliasedStreamWindowAST
 //  AliasedStreamWindowAST
 //   =>
-//  WindowedFromAST{[AliasedStreamWindowAST, AliasedStreamWindowAST]}
+//  WindowedFromAST{[AliasedStreamWindowAST, AliasedStreamWindowAST], nil}
+//
+// A WindowedFromAST assembled by AssembleJoin is left as it is.
 func (ps *parseStack) AssembleWindowedFrom(begin int, end int) {
 	if begin == end {
 		// push an empty FROM clause
 		ps.PushComponent(begin, end, WindowedFromAST{})
+	} else if _, ok := ps.Peek().comp.(WindowedFromAST); ok {
+		// the FROM clause has a JOIN clause
+		return
 	} else {
 		elems := ps.collectElements(begin, end)
 		rels := make([]AliasedStreamWindowAST, len(elems), len(elems))
@@ -668,10 +941,40 @@ func (ps *parseStack) AssembleWindowedFrom(begin int, end int) {
 			rels[i] = e
 		}
 		// push the grouped list back
-		ps.PushComponent(begin, end, WindowedFromAST{rels})
+		ps.PushComponent(begin, end, WindowedFromAST{rels, nil})
 	}
 }
 
+// AssembleJoin takes the topmost elements from the stack, assuming
+// they are components of a JOIN clause, and replaces them by
+// a single WindowedFromAST element. The type of the join is InnerJoin
+// when JoinType isn't given.
+//
+//  Expression
+//  AliasedStreamWindowAST
+//  JoinType (optional)
+//  AliasedStreamWindowAST
+//   =>
+//  WindowedFromAST{[AliasedStreamWindowAST, AliasedStreamWindowAST],
+//    &JoinAST{JoinType, Expression}}
+func (ps *parseStack) AssembleJoin() {
+	// pop the components from the stack in reverse order
+	_on, _right := ps.pop2()
+	typ := InnerJoin
+	if t, ok := ps.Peek().comp.(JoinType); ok {
+		typ = t
+		ps.Pop()
+	}
+	_left := ps.Pop()
+
+	on := _on.comp.(Expression)
+	right := _right.comp.(AliasedStreamWindowAST)
+	left := _left.comp.(AliasedStreamWindowAST)
+
+	ps.PushComponent(_left.begin, _on.end, WindowedFromAST{
+		[]AliasedStreamWindowAST{left, right}, &JoinAST{typ, on}})
+}
+
 // AssembleInterval takes the topmost elements from the stack, assuming
 // they are components of a RANGE clause, and replaces them by
 // a single IntervalAST element.
@@ -704,6 +1007,32 @@ func (ps *parseStack) AssembleInterval() {
 	ps.PushComponent(_num.begin, _unit.end, IntervalAST{FloatLiteral{val}, unit})
 }
 
+/* DEDUPLICATE BY clause */
+
+// AssembleDeduplicate takes the expression and the interval on top of
+// the stack (if there is a DEDUPLICATE BY clause) and wraps a
+// DeduplicateAST struct around them. If there is no DEDUPLICATE BY
+// clause, an empty DeduplicateAST struct is used.
+//
+//  IntervalAST
+//  Any
+//   =>
+//  DeduplicateAST{Any, IntervalAST}
+func (ps *parseStack) AssembleDeduplicate(begin int, end int) {
+	if begin == end {
+		// push an empty deduplicate clause
+		ps.PushComponent(begin, end, DeduplicateAST{})
+		return
+	}
+	_within, _key := ps.pop2()
+	if begin > _key.begin || end < _within.end {
+		panic("the items on top of the stack are not within given range")
+	}
+	within := _within.comp.(IntervalAST)
+	key := _key.comp.(Expression)
+	ps.PushComponent(begin, end, DeduplicateAST{key, within})
+}
+
 /* WHERE clause */
 
 // AssembleFilter takes the expression on top of the stack
@@ -741,8 +1070,26 @@ func (ps *parseStack) AssembleFilter(begin int, end int) {
 //  Any
 //   =>
 //  GroupingAST{[Any, Any, Any]}
+//
+// When the clause has GROUPING SETS, the elements are ExpressionsAST
+// representing each set:
+//
+//  ExpressionsAST{[Any]}
+//  ExpressionsAST{[Any, Any]}
+//   =>
+//  GroupingAST{[Any, Any], [[Any], [Any, Any]]}
 func (ps *parseStack) AssembleGrouping(begin int, end int) {
 	elems := ps.collectElements(begin, end)
+	if len(elems) > 0 {
+		if _, ok := elems[0].(ExpressionsAST); ok {
+			sets := make([][]Expression, len(elems))
+			for i := range elems {
+				sets[i] = elems[i].(ExpressionsAST).Expressions
+			}
+			ps.PushComponent(begin, end, NewGroupingSetsAST(sets))
+			return
+		}
+	}
 	var exprs []Expression
 	if len(elems) > 0 {
 		exprs = make([]Expression, len(elems))
@@ -751,7 +1098,7 @@ func (ps *parseStack) AssembleGrouping(begin int, end int) {
 		exprs[i] = elems[i].(Expression)
 	}
 	// push the grouped list back
-	ps.PushComponent(begin, end, GroupingAST{exprs})
+	ps.PushComponent(begin, end, GroupingAST{GroupList: exprs})
 }
 
 /* HAVING clause */
@@ -834,7 +1181,72 @@ func (ps *parseStack) AssembleStreamWindow() {
 	shedding := _shedding.comp.(SheddingOption)
 
 	ps.PushComponent(_rel.begin, _shedding.end, StreamWindowAST{rel, rangeAst,
-		capacity.Value, shedding})
+		capacity.Value, shedding, nil})
+}
+
+// AssembleWindowFunction takes the topmost elements from the stack, assuming
+// they are arguments of a window function of the given type, and replaces
+// them by a single StreamWindowAST element.
+//
+//  IntervalAST
+//  IntervalAST (only for HOP)
+//  RowMeta or Identifier
+//  Stream
+//   =>
+//  StreamWindowAST{Stream, IntervalAST, UnspecifiedCapacity,
+//    UnspecifiedSheddingOption, &WindowFunctionAST{...}}
+func (ps *parseStack) AssembleWindowFunction(typ WindowFunctionType) {
+	// pop the components from the stack in reverse order
+	_size := ps.Pop()
+	f := &WindowFunctionAST{Type: typ}
+	if typ == HopWindow {
+		f.Slide = ps.Pop().comp.(IntervalAST)
+	}
+	_timeAttr, _rel := ps.pop2()
+
+	rel := _rel.comp.(Stream)
+	size := _size.comp.(IntervalAST)
+	if col, ok := _timeAttr.comp.(Identifier); ok {
+		f.TimeColumn = string(col)
+	}
+
+	ps.PushComponent(_rel.begin, _size.end, StreamWindowAST{rel, size,
+		UnspecifiedCapacity, UnspecifiedSheddingOption, f})
+}
+
+// AssembleIntervalLiteral takes the topmost elements from the stack, assuming
+// they are components of an INTERVAL literal, and replaces them by a single
+// IntervalAST element. Units larger than seconds are converted to seconds.
+//
+//  Raw (the unit)
+//  NumericLiteral or FloatLiteral
+//   =>
+//  IntervalAST{FloatLiteral, IntervalUnit}
+func (ps *parseStack) AssembleIntervalLiteral() {
+	// pop the components from the stack in reverse order
+	_unit, _num := ps.pop2()
+
+	var val float64
+	if num, ok := _num.comp.(NumericLiteral); ok {
+		val = float64(num.Value)
+	} else {
+		num := _num.comp.(FloatLiteral)
+		val = num.Value
+	}
+
+	unit := Seconds
+	switch strings.TrimSuffix(strings.ToUpper(_unit.comp.(Raw).Expr), "S") {
+	case "MILLISECOND":
+		unit = Milliseconds
+	case "MINUTE":
+		val *= 60
+	case "HOUR":
+		val *= 60 * 60
+	case "DAY":
+		val *= 60 * 60 * 24
+	}
+
+	ps.PushComponent(_num.begin, _unit.end, IntervalAST{FloatLiteral{val}, unit})
 }
 
 // AssembleUDSFFuncApp takes the topmost elements from the stack,
@@ -871,6 +1283,36 @@ func (ps *parseStack) EnsureCapacitySpec(begin int, end int) {
 	}
 }
 
+// EnsureExplainAnalyzeLimit makes sure that the top element of the stack
+// is an IntervalAST element, which is empty when the FOR clause of an
+// EXPLAIN ANALYZE statement is omitted.
+func (ps *parseStack) EnsureExplainAnalyzeLimit(begin int, end int) {
+	if begin == end {
+		ps.PushComponent(begin, end, IntervalAST{})
+		return
+	}
+	top := ps.Peek()
+	if _, ok := top.comp.(IntervalAST); !ok {
+		panic(fmt.Sprintf("begin (%d) != end (%d), but there "+
+			"was a %T on the stack", begin, end, top.comp))
+	}
+}
+
+// EnsureStringLiteral makes sure that the top element of the stack
+// is a StringLiteral element. An empty StringLiteral is pushed when
+// the optional clause is omitted.
+func (ps *parseStack) EnsureStringLiteral(begin int, end int) {
+	if begin == end {
+		ps.PushComponent(begin, end, StringLiteral{})
+		return
+	}
+	top := ps.Peek()
+	if _, ok := top.comp.(StringLiteral); !ok {
+		panic(fmt.Sprintf("begin (%d) != end (%d), but there "+
+			"was a %T on the stack", begin, end, top.comp))
+	}
+}
+
 // EnsureSheddingSpec makes sure that the top element of the stack
 // is a SheddingOption element.
 func (ps *parseStack) EnsureSheddingSpec(begin int, end int) {
@@ -957,10 +1399,13 @@ func (ps *parseStack) AssembleSourceSinkParam() {
 		return value
 	}
 
-	value := toValue(_value.comp)
 	key := _key.comp.(SourceSinkParamKey)
-
-	ss := SourceSinkParamAST{key, value}
+	var ss SourceSinkParamAST
+	if c, ok := _value.comp.(ConstantRef); ok {
+		ss = SourceSinkParamAST{key, data.Null{}, c.Name}
+	} else {
+		ss = SourceSinkParamAST{key, toValue(_value.comp), ""}
+	}
 	ps.PushComponent(_key.begin, _value.end, ss)
 }
 
@@ -0,0 +1,611 @@
+package bql
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"gopkg.in/sensorbee/sensorbee.v0/data/textformat"
+)
+
+// partitionedFileSource reads files in a directory tree partitioned by date
+// and hour such as root/dt=2016-01-01/hour=05/part-0000.jsonl. Partitions are
+// read in lexicographical order of their paths and so are files in each
+// partition.
+type partitionedFileSource struct {
+	root     string
+	ioParams *IOParams
+	tsField  data.Path
+
+	format  string
+	decoder textformat.Decoder
+
+	// jsonLimits limits each line of JSON Lines, which is decoded while
+	// being read rather than by decoder. It's nil for other formats.
+	jsonLimits *data.JSONLimits
+
+	// tail makes the source poll the tree every pollInterval for new lines,
+	// files, and partitions after it has read all existing ones.
+	tail         bool
+	pollInterval time.Duration
+
+	// cursorFile is the file to which the cursor is persisted at most once
+	// in commitInterval. It's empty when the cursor isn't persisted to a
+	// file. offsets is used instead of cursorFile when the cursor is
+	// committed to an offsets state.
+	cursorFile     string
+	offsets        *core.SourceOffsets
+	commitInterval time.Duration
+
+	// m protects fields below.
+	m          sync.Mutex
+	cursor     partitionCursor
+	lastCommit time.Time
+	tailing    bool
+	numEmitted int64
+	numErrors  int64
+
+	stopCh chan struct{}
+}
+
+// partitionCursor is the position up to which a partitionedFileSource has
+// read. All files before File in Partition and all partitions before
+// Partition have been read, and File has been read up to Offset. An empty
+// File means that no file in Partition has been read yet.
+type partitionCursor struct {
+	Partition string `json:"partition"`
+	File      string `json:"file"`
+	Offset    int64  `json:"offset"`
+}
+
+func (s *partitionedFileSource) GenerateStream(ctx *core.Context, w core.Writer) error {
+	defer s.commit(ctx, true)
+	for {
+		if err := s.readPartitions(ctx, w); err != nil {
+			return err
+		}
+		if !s.tail {
+			return nil
+		}
+
+		s.m.Lock()
+		if !s.tailing {
+			s.tailing = true
+			ctx.Log().WithField("node_name", s.ioParams.Name).
+				WithField("partition", s.cursor.Partition).
+				Info("Caught up with the newest partition and started tailing it")
+		}
+		s.m.Unlock()
+
+		select {
+		case <-s.stopCh:
+			// This works as long as the source is wrapped with
+			// core.ImplementSourceStop.
+			return core.ErrSourceStopped
+		case <-ctx.Clock().After(s.pollInterval):
+		}
+	}
+}
+
+// readPartitions reads all lines which haven't been read yet.
+func (s *partitionedFileSource) readPartitions(ctx *core.Context, w core.Writer) error {
+	s.m.Lock()
+	cur := s.cursor
+	s.m.Unlock()
+
+	ps, err := listPartitions(s.root, cur.Partition)
+	if err != nil {
+		return err
+	}
+	for i, p := range ps {
+		files, err := listEntries(filepath.Join(s.root, p), false, "")
+		if err != nil {
+			return err
+		}
+		for j, name := range files {
+			offset := int64(0)
+			if p == cur.Partition {
+				if name < cur.File {
+					continue
+				}
+				if name == cur.File {
+					offset = cur.Offset
+				}
+			}
+
+			// A line at the end of the newest file without a newline may
+			// still be being written while tailing.
+			complete := !s.tail || i < len(ps)-1 || j < len(files)-1
+			if err := s.readFile(ctx, w, p, name, offset, complete); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// readFile reads lines of a file from the offset.
+func (s *partitionedFileSource) readFile(ctx *core.Context, w core.Writer, partition, name string,
+	offset int64, complete bool) error {
+	path := filepath.Join(s.root, partition, name)
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			ctx.ErrLog(err).WithField("node_name", s.ioParams.Name).
+				WithField("path", path).Warning("Cannot close the file")
+		}
+	}()
+
+	if offset > 0 {
+		info, err := f.Stat()
+		if err != nil {
+			return err
+		}
+		if info.Size() < offset {
+			ctx.Log().WithField("node_name", s.ioParams.Name).
+				WithField("path", path).WithField("offset", offset).
+				Warning("The file has been truncated and its rest is ignored")
+			return nil
+		}
+		if info.Size() == offset {
+			return nil
+		}
+		if _, err := f.Seek(offset, io.SeekStart); err != nil {
+			return err
+		}
+	}
+
+	r := bufio.NewReader(f)
+	if s.jsonLimits != nil {
+		return s.readJSONLines(ctx, w, r, path, partitionCursor{partition, name, offset}, complete)
+	}
+	for {
+		line, err := r.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return err
+		}
+		if err == io.EOF && !complete {
+			// The partial line will be read again when it's completed.
+			break
+		}
+		start := offset
+		offset += int64(len(line))
+
+		if line = bytes.TrimSpace(line); len(line) != 0 {
+			if m, err := s.decoder.Decode(line); err != nil {
+				ctx.ErrLog(err).WithField("node_name", s.ioParams.Name).
+					WithField("format", s.format).
+					WithField("path", path).
+					WithField("offset", start).
+					WithField("body", string(line)).Warning("Ignoring the line due to a parse error")
+				s.m.Lock()
+				s.numErrors++
+				s.m.Unlock()
+			} else if err := s.emit(ctx, w, m, path, start); err != nil {
+				return err
+			}
+		}
+		s.advance(ctx, partitionCursor{partition, name, offset})
+		if err == io.EOF {
+			break
+		}
+	}
+	s.advance(ctx, partitionCursor{partition, name, offset})
+	return nil
+}
+
+// readJSONLines reads JSON Lines of a file from the offset of the cursor.
+func (s *partitionedFileSource) readJSONLines(ctx *core.Context, w core.Writer, r *bufio.Reader,
+	path string, cur partitionCursor, complete bool) error {
+	start := cur.Offset
+	dec := newJSONLinesDecoder(r, s.jsonLimits)
+	for {
+		l, err := readJSONLine(dec)
+		if err == io.EOF {
+			// skip trailing whitespace
+			cur.Offset = start + dec.InputOffset()
+			break
+		} else if err != nil {
+			return err
+		}
+		if !l.eol && !complete {
+			// The partial line will be read again when it's completed.
+			break
+		}
+		lineStart := cur.Offset
+		cur.Offset = start + dec.InputOffset()
+
+		if l.err != nil {
+			ctx.ErrLog(l.err).WithField("node_name", s.ioParams.Name).
+				WithField("format", s.format).
+				WithField("path", path).
+				WithField("offset", lineStart).Warning("Ignoring the line due to a parse error")
+			s.m.Lock()
+			s.numErrors++
+			s.m.Unlock()
+		} else if err := s.emit(ctx, w, l.m, path, lineStart); err != nil {
+			return err
+		}
+		s.advance(ctx, cur)
+	}
+	s.advance(ctx, cur)
+	return nil
+}
+
+func (s *partitionedFileSource) emit(ctx *core.Context, w core.Writer, m data.Map, path string, offset int64) error {
+	t := core.NewTuple(m)
+	if s.tsField != nil {
+		if v, err := t.Data.Get(s.tsField); err == nil {
+			if ts, err := data.ToTimestamp(v); err != nil {
+				ctx.ErrLog(err).WithField("node_name", s.ioParams.Name).
+					WithField("path", path).
+					WithField("offset", offset).
+					WithField("timestamp_field", s.tsField).
+					WithField("timestamp_field_value", v).
+					Warning("Cannot convert a value in timestamp_field to a timestamp")
+			} else {
+				t.Timestamp = ts
+			}
+		}
+	}
+	if err := w.Write(ctx, t); err != nil {
+		return err
+	}
+	s.m.Lock()
+	s.numEmitted++
+	s.m.Unlock()
+	return nil
+}
+
+// advance moves the cursor and commits it when commitInterval has passed
+// since the last commit.
+func (s *partitionedFileSource) advance(ctx *core.Context, c partitionCursor) {
+	s.m.Lock()
+	s.cursor = c
+	s.m.Unlock()
+	s.commit(ctx, false)
+}
+
+// commit persists the cursor to cursorFile or commits it to the offsets
+// state. The cursor is only persisted when commitInterval has passed since
+// the last commit unless force is true.
+func (s *partitionedFileSource) commit(ctx *core.Context, force bool) {
+	if s.cursorFile == "" && s.offsets == nil {
+		return
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	now := ctx.Clock().Now()
+	if !force && now.Sub(s.lastCommit) < s.commitInterval {
+		return
+	}
+	s.lastCommit = now
+
+	if s.offsets != nil {
+		if err := s.offsets.Commit(s.cursor.toMap()); err != nil {
+			ctx.ErrLog(err).WithField("node_name", s.ioParams.Name).
+				WithField("offsets", s.offsets.StateName()).
+				Error("Cannot commit the cursor")
+		}
+		return
+	}
+
+	b, err := json.Marshal(s.cursor)
+	if err == nil {
+		err = writeFileAtomically(s.cursorFile, append(b, '\n'))
+	}
+	if err != nil {
+		ctx.ErrLog(err).WithField("node_name", s.ioParams.Name).
+			WithField("cursor_file", s.cursorFile).
+			Error("Cannot persist the cursor")
+	}
+}
+
+func (s *partitionedFileSource) Stop(ctx *core.Context) error {
+	close(s.stopCh)
+	return nil
+}
+
+func (s *partitionedFileSource) Status() data.Map {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return data.Map{
+		"cursor":      s.cursor.toMap(),
+		"tailing":     data.Bool(s.tailing),
+		"num_emitted": data.Int(s.numEmitted),
+		"num_errors":  data.Int(s.numErrors),
+	}
+}
+
+// listPartitions returns paths of partitions relative to root which aren't
+// before from. A "dt=" directory is a partition when it doesn't have any
+// "hour=" directory. Otherwise, each "hour=" directory in it is a partition.
+func listPartitions(root, from string) ([]string, error) {
+	fromDt := strings.SplitN(from, "/", 2)[0]
+	dts, err := listEntries(root, true, "dt=")
+	if err != nil {
+		return nil, err
+	}
+
+	var ps []string
+	for _, dt := range dts {
+		if dt < fromDt {
+			continue
+		}
+		hours, err := listEntries(filepath.Join(root, dt), true, "hour=")
+		if err != nil {
+			return nil, err
+		}
+		if len(hours) == 0 {
+			if dt >= from {
+				ps = append(ps, dt)
+			}
+			continue
+		}
+		for _, h := range hours {
+			if p := dt + "/" + h; p >= from {
+				ps = append(ps, p)
+			}
+		}
+	}
+	return ps, nil
+}
+
+// listEntries returns sorted names of directories or regular files in dir
+// having the prefix. Hidden entries and entries starting with "_", such as
+// _SUCCESS markers, are ignored.
+func listEntries(dir string, dirs bool, prefix string) ([]string, error) {
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, info := range infos {
+		name := info.Name()
+		if strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") {
+			continue
+		}
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		if dirs && info.IsDir() || !dirs && info.Mode().IsRegular() {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// validatePartition checks that the partition has the form of "dt=..." or
+// "dt=.../hour=...".
+func validatePartition(p string) error {
+	segs := strings.Split(p, "/")
+	if len(segs) > 2 || !strings.HasPrefix(segs[0], "dt=") || len(segs[0]) == len("dt=") ||
+		(len(segs) == 2 && (!strings.HasPrefix(segs[1], "hour=") || len(segs[1]) == len("hour="))) {
+		return fmt.Errorf("a partition must be dt=<date> or dt=<date>/hour=<hour>: %v", p)
+	}
+	return nil
+}
+
+func (c *partitionCursor) toMap() data.Map {
+	return data.Map{
+		"partition": data.String(c.Partition),
+		"file":      data.String(c.File),
+		"offset":    data.Int(c.Offset),
+	}
+}
+
+func (c *partitionCursor) validate() error {
+	if c.Partition != "" {
+		// The partition is empty when no partition has been found yet.
+		if err := validatePartition(c.Partition); err != nil {
+			return err
+		}
+	}
+	if c.Offset < 0 {
+		return fmt.Errorf("the offset must not be negative: %v", c.Offset)
+	}
+	if strings.ContainsRune(c.File, '/') {
+		return fmt.Errorf("the file must not have a directory: %v", c.File)
+	}
+	return nil
+}
+
+// loadPartitionCursor loads the cursor persisted in the file. It returns nil
+// when the file doesn't exist.
+func loadPartitionCursor(path string) (*partitionCursor, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	c := &partitionCursor{}
+	if err := json.Unmarshal(b, c); err != nil {
+		return nil, fmt.Errorf("the cursor file '%v' has an invalid cursor: %v", path, err)
+	}
+	if err := c.validate(); err != nil {
+		return nil, fmt.Errorf("the cursor file '%v' has an invalid cursor: %v", path, err)
+	}
+	return c, nil
+}
+
+// loadPartitionCursorOffsets loads the cursor committed to the offsets state.
+// It returns nil when the source hasn't committed a cursor.
+func loadPartitionCursorOffsets(o *core.SourceOffsets) (*partitionCursor, error) {
+	m, ok, err := o.Load()
+	if err != nil || !ok {
+		return nil, err
+	}
+	c := &partitionCursor{}
+	if err := data.NewDecoder(nil).Decode(m, c); err != nil {
+		return nil, fmt.Errorf("the offsets state '%v' has an invalid cursor: %v", o.StateName(), err)
+	}
+	if err := c.validate(); err != nil {
+		return nil, fmt.Errorf("the offsets state '%v' has an invalid cursor: %v", o.StateName(), err)
+	}
+	return c, nil
+}
+
+// createPartitionedFileSource creates a source reading files in a directory
+// tree partitioned by date and hour, which is a common layout of logs synced
+// from object stores:
+//
+//	root/dt=2016-01-01/hour=00/part-0000.jsonl
+//	root/dt=2016-01-01/hour=00/part-0001.jsonl
+//	root/dt=2016-01-01/hour=01/part-0000.jsonl
+//
+// A "dt=" directory not having "hour=" directories is a daily partition.
+// Partitions and files in a partition are read in lexicographical order, so
+// values of "dt=" and "hour=" should have a fixed width. Hidden files and
+// files starting with "_" are ignored. Each line is decoded in "format"
+// parameter (jsonl by default), see the textformat package. Lines of jsonl
+// are limited by "max_document_size", "max_depth", and "max_string_length"
+// parameters as in the file source:
+//
+//	CREATE SOURCE s TYPE partitioned_file WITH path="/data/logs",
+//	    start_partition="dt=2016-01-01/hour=05", timestamp_field="ts";
+//
+// Partitions before "start_partition" are skipped.
+//
+// When "cursor_file" is given, the position up to which the source has read
+// is persisted in the file as JSON at most once in "cursor_commit_interval"
+// (1s by default) and when the source stops. A source created again with the
+// same "cursor_file" resumes from the persisted position and ignores
+// "start_partition". Lines read after the last commit are emitted again
+// after a crash. "offsets" can be given instead of "cursor_file" to commit
+// the position to the offsets state having the name. Then, the position is
+// checkpointed by SAVE STATE of the offsets state and the source created
+// after LOAD STATE resumes from the saved position.
+//
+// When "tail" is true, the source doesn't stop after reading all partitions
+// but checks the tree every "poll_interval" (1s by default) for lines
+// appended to the newest file and for new files and partitions. A line at
+// the end of the newest file isn't read until it ends with a newline.
+func createPartitionedFileSource(ctx *core.Context, ioParams *IOParams, params data.Map) (core.Source, error) {
+	v := &struct {
+		Path                 string `bql:",required"`
+		Format               string
+		TimestampField       string
+		StartPartition       string
+		CursorFile           string
+		Offsets              string
+		CursorCommitInterval time.Duration
+		Tail                 bool
+		PollInterval         time.Duration
+		jsonLimitParams
+	}{
+		Format:               "jsonl",
+		CursorCommitInterval: time.Second,
+		PollInterval:         time.Second,
+		jsonLimitParams:      defaultJSONLimitParams(),
+	}
+	dec := data.NewDecoder(nil)
+	if err := dec.Decode(params, v); err != nil {
+		return nil, err
+	}
+	if v.PollInterval <= 0 {
+		return nil, fmt.Errorf("'poll_interval' parameter must be greater than 0: %v", v.PollInterval)
+	}
+	if v.CursorCommitInterval < 0 {
+		return nil, fmt.Errorf("'cursor_commit_interval' parameter must not be negative: %v", v.CursorCommitInterval)
+	}
+	if info, err := os.Stat(v.Path); err != nil {
+		return nil, fmt.Errorf("cannot access 'path': %v", err)
+	} else if !info.IsDir() {
+		return nil, fmt.Errorf("'path' parameter must be a directory: %v", v.Path)
+	}
+
+	var cursor partitionCursor
+	if v.StartPartition != "" {
+		if err := validatePartition(v.StartPartition); err != nil {
+			return nil, fmt.Errorf("'start_partition' parameter has an invalid value: %v", err)
+		}
+		cursor.Partition = v.StartPartition
+	}
+	if v.CursorFile != "" && v.Offsets != "" {
+		return nil, errors.New("'cursor_file' and 'offsets' parameters cannot be given at the same time")
+	}
+	if v.CursorFile != "" {
+		c, err := loadPartitionCursor(v.CursorFile)
+		if err != nil {
+			return nil, fmt.Errorf("cannot load 'cursor_file': %v", err)
+		}
+		if c != nil {
+			cursor = *c
+		}
+	}
+	var offsets *core.SourceOffsets
+	if v.Offsets != "" {
+		var err error
+		if offsets, err = core.NewSourceOffsets(ctx, v.Offsets, ioParams.Name); err != nil {
+			return nil, fmt.Errorf("'offsets' parameter has an invalid value: %v", err)
+		}
+		c, err := loadPartitionCursorOffsets(offsets)
+		if err != nil {
+			return nil, err
+		}
+		if c != nil {
+			cursor = *c
+		}
+	}
+
+	var tsField data.Path
+	if v.TimestampField != "" {
+		var err error
+		if tsField, err = data.CompilePath(v.TimestampField); err != nil {
+			return nil, fmt.Errorf("'timestamp_field' parameter doesn't have a valid path: %v", err)
+		}
+	}
+
+	var lineDec textformat.Decoder
+	var jsonLimits *data.JSONLimits
+	if isJSONLines(v.Format) {
+		var err error
+		if jsonLimits, err = v.limits(); err != nil {
+			return nil, err
+		}
+	} else {
+		var err error
+		if lineDec, err = textformat.NewDecoder(v.Format); err != nil {
+			return nil, fmt.Errorf("'format' parameter has an invalid value: %v", err)
+		}
+	}
+
+	s := &partitionedFileSource{
+		root:     v.Path,
+		ioParams: ioParams,
+		tsField:  tsField,
+		format:   v.Format,
+		decoder:  lineDec,
+
+		jsonLimits: jsonLimits,
+
+		tail:           v.Tail,
+		pollInterval:   v.PollInterval,
+		cursorFile:     v.CursorFile,
+		offsets:        offsets,
+		commitInterval: v.CursorCommitInterval,
+		cursor:         cursor,
+		stopCh:         make(chan struct{}),
+	}
+	return core.ImplementSourceStop(s), nil
+}
+
+func init() {
+	MustRegisterGlobalSourceCreator("partitioned_file", SourceCreatorFunc(createPartitionedFileSource))
+}
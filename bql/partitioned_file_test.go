@@ -0,0 +1,253 @@
+package bql
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+func TestPartitionedFileSource(t *testing.T) {
+	root, err := ioutil.TempDir("", "sbtest_bql_partitioned_file_source")
+	if err != nil {
+		t.Fatal("Cannot create a temp dir:", err)
+	}
+	defer os.RemoveAll(root)
+
+	writeFile := func(path, content string) {
+		p := filepath.Join(root, path)
+		So(os.MkdirAll(filepath.Dir(p), 0755), ShouldBeNil)
+		So(ioutil.WriteFile(p, []byte(content), 0644), ShouldBeNil)
+	}
+	appendFile := func(path, content string) {
+		f, err := os.OpenFile(filepath.Join(root, path), os.O_APPEND|os.O_WRONLY, 0644)
+		So(err, ShouldBeNil)
+		defer f.Close()
+		_, err = f.WriteString(content)
+		So(err, ShouldBeNil)
+	}
+
+	ctx := core.NewContext(nil)
+	cursorFile := filepath.Join(root, "_cursor")
+	create := func(params data.Map) core.Source {
+		params["path"] = data.String(root)
+		s, err := createPartitionedFileSource(ctx, &IOParams{Name: "source"}, params)
+		So(err, ShouldBeNil)
+		return s
+	}
+
+	// start runs the source in a goroutine and returns a channel receiving
+	// "int" of each tuple.
+	start := func(s core.Source) (chan int64, chan error) {
+		ch := make(chan int64, 100)
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- s.GenerateStream(ctx, core.WriterFunc(func(ctx *core.Context, t *core.Tuple) error {
+				i, _ := data.AsInt(t.Data["int"])
+				ch <- i
+				return nil
+			}))
+		}()
+		return ch, errCh
+	}
+	run := func(s core.Source) []int64 {
+		ch, errCh := start(s)
+		So(<-errCh, ShouldBeNil)
+		close(ch)
+		var res []int64
+		for i := range ch {
+			res = append(res, i)
+		}
+		return res
+	}
+	receive := func(ch chan int64, n int) []int64 {
+		var res []int64
+		for i := 0; i < n; i++ {
+			select {
+			case v := <-ch:
+				res = append(res, v)
+			case <-time.After(5 * time.Second):
+				return res
+			}
+		}
+		return res
+	}
+
+	Convey("Given a partitioned directory tree", t, func() {
+		writeFile("dt=2016-01-01/hour=00/a.jsonl", `{"int":1}
+{"int":2}
+`)
+		writeFile("dt=2016-01-01/hour=00/b.jsonl", `{"int":3}`)
+		writeFile("dt=2016-01-01/hour=00/_SUCCESS", ``)
+		writeFile("dt=2016-01-01/hour=01/a.jsonl", `{"int":4}
+not a json
+{"int":5}
+`)
+		writeFile("dt=2016-01-02/a.jsonl", `{"int":6}
+`)
+		writeFile("other/a.jsonl", `{"int":100}
+`)
+		Reset(func() {
+			infos, _ := ioutil.ReadDir(root)
+			for _, i := range infos {
+				os.RemoveAll(filepath.Join(root, i.Name()))
+			}
+		})
+
+		Convey("When reading it from the beginning", func() {
+			s := create(data.Map{})
+
+			Convey("Then all partitions should be read in order", func() {
+				So(run(s), ShouldResemble, []int64{1, 2, 3, 4, 5, 6})
+			})
+		})
+
+		Convey("When reading it from a partition", func() {
+			s := create(data.Map{"start_partition": data.String("dt=2016-01-01/hour=01")})
+
+			Convey("Then partitions before it should be skipped", func() {
+				So(run(s), ShouldResemble, []int64{4, 5, 6})
+			})
+		})
+
+		Convey("When reading it from a date", func() {
+			s := create(data.Map{"start_partition": data.String("dt=2016-01-02")})
+
+			Convey("Then partitions before the date should be skipped", func() {
+				So(run(s), ShouldResemble, []int64{6})
+			})
+		})
+
+		Convey("When reading it with a cursor file", func() {
+			So(run(create(data.Map{"cursor_file": data.String(cursorFile)})), ShouldResemble,
+				[]int64{1, 2, 3, 4, 5, 6})
+
+			Convey("Then the cursor should point to the end of the last file", func() {
+				b, err := ioutil.ReadFile(cursorFile)
+				So(err, ShouldBeNil)
+				So(string(b), ShouldEqual, `{"partition":"dt=2016-01-02","file":"a.jsonl","offset":10}`+"\n")
+			})
+
+			Convey("Then the source should resume from the cursor next time", func() {
+				appendFile("dt=2016-01-02/a.jsonl", `{"int":7}
+`)
+				writeFile("dt=2016-01-02/b.jsonl", `{"int":8}
+`)
+				s := create(data.Map{
+					"cursor_file":     data.String(cursorFile),
+					"start_partition": data.String("dt=2016-01-01"),
+				})
+				So(run(s), ShouldResemble, []int64{7, 8})
+			})
+		})
+
+		Convey("When reading it with an offsets state", func() {
+			offsets := core.NewOffsetsState()
+			So(ctx.SharedStates.Add("offsets", "offsets", offsets), ShouldBeNil)
+			Reset(func() {
+				ctx.SharedStates.Remove("offsets")
+			})
+			So(run(create(data.Map{"offsets": data.String("offsets")})), ShouldResemble,
+				[]int64{1, 2, 3, 4, 5, 6})
+
+			Convey("Then the cursor should be committed to the state", func() {
+				m, ok := offsets.Get("SOURCE")
+				So(ok, ShouldBeTrue)
+				So(m, ShouldResemble, data.Map{
+					"partition": data.String("dt=2016-01-02"),
+					"file":      data.String("a.jsonl"),
+					"offset":    data.Int(10),
+				})
+			})
+
+			Convey("Then the source should resume from the loaded state next time", func() {
+				buf := bytes.NewBuffer(nil)
+				So(offsets.Save(ctx, buf, data.Map{}), ShouldBeNil)
+				offsets.Reset("source")
+				So(offsets.Load(ctx, buf, data.Map{}), ShouldBeNil)
+
+				appendFile("dt=2016-01-02/a.jsonl", `{"int":7}
+`)
+				So(run(create(data.Map{"offsets": data.String("offsets")})), ShouldResemble, []int64{7})
+			})
+
+			Convey("Then the source should start over after the offsets are reset", func() {
+				So(offsets.Reset("source"), ShouldBeTrue)
+				s := create(data.Map{
+					"offsets":         data.String("offsets"),
+					"start_partition": data.String("dt=2016-01-02"),
+				})
+				So(run(s), ShouldResemble, []int64{6})
+			})
+		})
+
+		Convey("When tailing it", func() {
+			s := create(data.Map{
+				"tail":          data.True,
+				"poll_interval": data.Float(0.01),
+			})
+			ch, errCh := start(s)
+			So(receive(ch, 6), ShouldResemble, []int64{1, 2, 3, 4, 5, 6})
+
+			Convey("Then it should read lines appended to the newest file", func() {
+				appendFile("dt=2016-01-02/a.jsonl", `{"int":7}
+{"int":`)
+				So(receive(ch, 1), ShouldResemble, []int64{7})
+				appendFile("dt=2016-01-02/a.jsonl", `8}
+`)
+				So(receive(ch, 1), ShouldResemble, []int64{8})
+
+				Convey("And it should read new partitions", func() {
+					writeFile("dt=2016-01-03/hour=00/a.jsonl", `{"int":9}
+`)
+					So(receive(ch, 1), ShouldResemble, []int64{9})
+					st := s.(core.Statuser).Status()["internal_source"].(data.Map)
+					So(st["tailing"], ShouldEqual, data.True)
+					So(s.Stop(ctx), ShouldBeNil)
+					So(<-errCh, ShouldBeNil)
+				})
+			})
+		})
+	})
+
+	Convey("Given invalid parameters", t, func() {
+		for i, params := range []data.Map{
+			{},
+			{"path": data.String(filepath.Join(root, "not_exist"))},
+			{"path": data.String(root), "start_partition": data.String("hour=01")},
+			{"path": data.String(root), "start_partition": data.String("dt=2016-01-01/hour=")},
+			{"path": data.String(root), "poll_interval": data.Int(0)},
+			{"path": data.String(root), "format": data.String("unknown")},
+			{"path": data.String(root), "offsets": data.String("not_exist")},
+			{"path": data.String(root), "offsets": data.String("offsets"), "cursor_file": data.String(cursorFile)},
+		} {
+			_, err := createPartitionedFileSource(ctx, &IOParams{}, params)
+
+			Convey(fmt.Sprintf("Then creating a source should fail (%v)", i), func() {
+				So(err, ShouldNotBeNil)
+			})
+		}
+
+		Convey("When the cursor file is broken", func() {
+			So(ioutil.WriteFile(cursorFile, []byte("abc"), 0644), ShouldBeNil)
+			Reset(func() {
+				os.Remove(cursorFile)
+			})
+			_, err := createPartitionedFileSource(ctx, &IOParams{}, data.Map{
+				"path":        data.String(root),
+				"cursor_file": data.String(cursorFile),
+			})
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
@@ -0,0 +1,565 @@
+package bql
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/sensorbee/sensorbee.v0/bql/execution"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/parser"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// TopologyPlan is a plan of nodes which will be created from BQL statements.
+// It's created by TopologyBuilder.Plan without creating any node.
+type TopologyPlan struct {
+	// Nodes has nodes in the plan in the order of creation. Nodes removed
+	// by DROP statements aren't included.
+	Nodes []*PlannedNode
+
+	// States has names of UDSs which will be created.
+	States []string
+
+	// Errors has errors detected while planning statements.
+	Errors []*PlanError
+
+	// Warnings has possible mistakes in nodes created by the statements,
+	// such as streams whose output isn't used.
+	Warnings []*PlanWarning
+}
+
+// PlannedNode is a node which will be created from a statement.
+type PlannedNode struct {
+	// Name is the name of the node.
+	Name string
+
+	// NodeType is the type of the node. A stream created by CREATE STREAM
+	// is a box.
+	NodeType core.NodeType
+
+	// TypeName is the type name of the source or the sink given to TYPE
+	// clause, or the type name of the loader given to USING clause of
+	// CREATE STREAM AS ENRICH. It's empty for other streams.
+	TypeName string
+
+	// Params has parameters given to WITH clause of the statement. It's
+	// nil for streams other than ones created by CREATE STREAM AS ENRICH.
+	Params data.Map
+
+	// Inputs has names of nodes from which the node receives tuples. UDSFs
+	// used in FROM clause are written as function calls such as "f(1)".
+	Inputs []string
+
+	// Existing is true when the node already exists in the topology.
+	Existing bool
+}
+
+// PlanError is an error detected in a statement.
+type PlanError struct {
+	// Index is the 0-origin index of the statement in the statements
+	// given to TopologyBuilder.Plan.
+	Index int
+
+	// Stmt is the statement having the error.
+	Stmt interface{}
+
+	// Err is the error.
+	Err error
+}
+
+func (e *PlanError) Error() string {
+	return fmt.Sprintf("statement #%v: %v", e.Index+1, e.Err)
+}
+
+// Code returns the code of Err.
+func (e *PlanError) Code() string {
+	return core.ErrorCode(e.Err)
+}
+
+type topologyPlanner struct {
+	tb     *TopologyBuilder
+	plan   *TopologyPlan
+	nodes  map[string]*PlannedNode
+	states map[string]bool
+
+	// constants has constants defined by SET CONSTANT statements in the
+	// plan. They're not set to the topology.
+	constants map[string]data.Value
+}
+
+// Plan checks statements as if they were added to the topology by AddStmt
+// and returns a plan of nodes which will be created. It doesn't create or
+// modify any node, state, or file. Therefore, errors which only occur when
+// a node is actually created, such as invalid parameter values, can't be
+// detected by Plan. Nodes already existing in the topology are included in
+// the plan.
+//
+// Plan detects following errors:
+//
+//	- unknown source, sink, UDS, UDSF, or loader types
+//	- unknown or misused functions in SELECT statements
+//	- references to nodes or states which don't exist
+//	- duplicated node names
+//
+// Plan continues planning subsequent statements after it finds an error in
+// a statement. A statement having an error doesn't create a node.
+func (tb *TopologyBuilder) Plan(stmts []interface{}) *TopologyPlan {
+	p := &topologyPlanner{
+		tb:        tb,
+		plan:      &TopologyPlan{},
+		nodes:     map[string]*PlannedNode{},
+		states:    map[string]bool{},
+		constants: map[string]data.Value{},
+	}
+
+	existing := tb.topology.Nodes()
+	names := make([]string, 0, len(existing))
+	for name := range existing {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		p.add(&PlannedNode{
+			Name:     name,
+			NodeType: existing[name].Type(),
+			Existing: true,
+		})
+	}
+	if states, err := tb.topology.Context().SharedStates.List(); err == nil {
+		for name := range states {
+			p.states[strings.ToLower(name)] = true
+			p.plan.States = append(p.plan.States, name)
+		}
+		sort.Strings(p.plan.States)
+	}
+
+	for i, stmt := range stmts {
+		if err := p.planStmt(stmt); err != nil {
+			p.plan.Errors = append(p.plan.Errors, &PlanError{
+				Index: i,
+				Stmt:  stmt,
+				Err:   err,
+			})
+		}
+	}
+
+	nodes := make([]*PlannedNode, 0, len(p.plan.Nodes))
+	for _, n := range p.plan.Nodes {
+		if p.nodes[strings.ToLower(n.Name)] == n {
+			nodes = append(nodes, n)
+		}
+	}
+	p.plan.Nodes = nodes
+	p.plan.Warnings = planWarnings(nodes)
+	return p.plan
+}
+
+func (p *topologyPlanner) add(n *PlannedNode) {
+	p.nodes[strings.ToLower(n.Name)] = n
+	p.plan.Nodes = append(p.plan.Nodes, n)
+}
+
+func (p *topologyPlanner) checkNewNode(name string) error {
+	if err := core.ValidateSymbol(name); err != nil {
+		return err
+	}
+	if _, ok := p.nodes[strings.ToLower(name)]; ok {
+		return fmt.Errorf("the name is already used: %v", name)
+	}
+	return nil
+}
+
+// node returns the node having the name. When types are given, the node must
+// have one of them.
+func (p *topologyPlanner) node(name string, types ...core.NodeType) (*PlannedNode, error) {
+	n, ok := p.nodes[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("node '%v' was not found", name)
+	}
+	if len(types) == 0 {
+		return n, nil
+	}
+	for _, t := range types {
+		if n.NodeType == t {
+			return n, nil
+		}
+	}
+	return nil, fmt.Errorf("node '%v' is a %v", name, n.NodeType)
+}
+
+// mkParamsMap is TopologyBuilder.mkParamsMap which also resolves constants
+// defined in the plan.
+func (p *topologyPlanner) mkParamsMap(params []parser.SourceSinkParamAST) (data.Map, error) {
+	return p.tb.mkParamsMap(p.resolveConstants(params))
+}
+
+// resolveConstants replaces references to constants defined in the plan
+// with their values.
+func (p *topologyPlanner) resolveConstants(params []parser.SourceSinkParamAST) []parser.SourceSinkParamAST {
+	planned := make([]parser.SourceSinkParamAST, 0, len(params))
+	for _, kv := range params {
+		if v, ok := p.constants[kv.Constant]; ok {
+			kv.Value, kv.Constant = v, ""
+		}
+		planned = append(planned, kv)
+	}
+	return planned
+}
+
+func (p *topologyPlanner) state(name string) error {
+	if !p.states[strings.ToLower(name)] {
+		return fmt.Errorf("state '%v' was not found", name)
+	}
+	return nil
+}
+
+func (p *topologyPlanner) planStmt(stmt interface{}) error {
+	tb := p.tb
+	switch stmt := stmt.(type) {
+	case parser.CreateSourceStmt:
+		if err := p.checkNewNode(string(stmt.Name)); err != nil {
+			return err
+		}
+		if _, err := tb.SourceCreators.Lookup(string(stmt.Type)); err != nil {
+			return err
+		}
+		params, err := p.mkParamsMap(stmt.Params)
+		if err != nil {
+			return err
+		}
+		if _, _, err := extractLabels(params); err != nil {
+			return err
+		}
+		p.add(&PlannedNode{
+			Name:     string(stmt.Name),
+			NodeType: core.NTSource,
+			TypeName: string(stmt.Type),
+			Params:   params,
+		})
+
+	case parser.CreateStreamAsSelectStmt:
+		if err := p.checkNewNode(string(stmt.Name)); err != nil {
+			return err
+		}
+		inputs, err := p.planSelect(string(stmt.Name), &stmt.Select)
+		if err != nil {
+			return err
+		}
+		p.add(&PlannedNode{
+			Name:     string(stmt.Name),
+			NodeType: core.NTBox,
+			Inputs:   inputs,
+		})
+
+	case parser.CreateStreamAsSelectUnionStmt:
+		if err := p.checkNewNode(string(stmt.Name)); err != nil {
+			return err
+		}
+		if err := validateSelectUnion(&stmt.SelectUnionStmt); err != nil {
+			return err
+		}
+		var inputs []string
+		for i := range stmt.Selects {
+			ins, err := p.planSelect(string(stmt.Name), &stmt.Selects[i])
+			if err != nil {
+				return err
+			}
+			inputs = append(inputs, ins...)
+		}
+		p.add(&PlannedNode{
+			Name:     string(stmt.Name),
+			NodeType: core.NTBox,
+			Inputs:   inputs,
+		})
+
+	case parser.CreateStreamAsEnrichStmt:
+		if err := p.checkNewNode(string(stmt.Name)); err != nil {
+			return err
+		}
+		if _, err := p.node(string(stmt.Input), core.NTSource, core.NTBox); err != nil {
+			return err
+		}
+		if _, err := tb.LoaderCreators.Lookup(string(stmt.Loader)); err != nil {
+			return err
+		}
+		params, err := p.mkParamsMap(stmt.Params)
+		if err != nil {
+			return err
+		}
+		if _, _, err := newEnrichConfig(params); err != nil {
+			return err
+		}
+		p.add(&PlannedNode{
+			Name:     string(stmt.Name),
+			NodeType: core.NTBox,
+			TypeName: string(stmt.Loader),
+			Params:   params,
+			Inputs:   []string{string(stmt.Input)},
+		})
+
+	case parser.CreateSinkStmt:
+		if err := p.checkNewNode(string(stmt.Name)); err != nil {
+			return err
+		}
+		if _, err := tb.SinkCreators.Lookup(string(stmt.Type)); err != nil {
+			return err
+		}
+		params, err := p.mkParamsMap(stmt.Params)
+		if err != nil {
+			return err
+		}
+		if _, _, err := extractLabels(params); err != nil {
+			return err
+		}
+		if _, _, err := newSinkSchema(params); err != nil {
+			return err
+		}
+		p.add(&PlannedNode{
+			Name:     string(stmt.Name),
+			NodeType: core.NTSink,
+			TypeName: string(stmt.Type),
+			Params:   params,
+		})
+
+	case parser.CreateStateStmt:
+		if p.states[strings.ToLower(string(stmt.Name))] {
+			return fmt.Errorf("state '%v' already exists", stmt.Name)
+		}
+		return p.planCreateState(string(stmt.Name), string(stmt.Type))
+
+	case parser.LoadStateStmt:
+		return p.planCreateState(string(stmt.Name), string(stmt.Type))
+
+	case parser.LoadStateOrCreateStmt:
+		return p.planCreateState(string(stmt.Name), string(stmt.Type))
+
+	case parser.UpdateStateStmt:
+		return p.state(string(stmt.Name))
+
+	case parser.SaveStateStmt:
+		return p.state(string(stmt.Name))
+
+	case parser.DropStateStmt:
+		if err := p.state(string(stmt.State)); err != nil {
+			return err
+		}
+		delete(p.states, strings.ToLower(string(stmt.State)))
+		for i, s := range p.plan.States {
+			if strings.ToLower(s) == strings.ToLower(string(stmt.State)) {
+				p.plan.States = append(p.plan.States[:i], p.plan.States[i+1:]...)
+				break
+			}
+		}
+
+	case parser.UpdateSourceStmt:
+		_, err := p.node(string(stmt.Name), core.NTSource)
+		return err
+
+	case parser.UpdateSinkStmt:
+		_, err := p.node(string(stmt.Name), core.NTSink)
+		return err
+
+	case parser.DropSourceStmt:
+		return p.drop(string(stmt.Source), core.NTSource)
+
+	case parser.DropStreamStmt:
+		return p.drop(string(stmt.Stream), core.NTBox)
+
+	case parser.DropSinkStmt:
+		return p.drop(string(stmt.Sink), core.NTSink)
+
+	case parser.InsertIntoFromStmt:
+		sink, err := p.node(string(stmt.Sink), core.NTSink)
+		if err != nil {
+			return err
+		}
+		if _, err := tb.mkSinkInputConfig(stmt.Params); err != nil {
+			return err
+		}
+		names := map[string]bool{}
+		for _, in := range stmt.Inputs {
+			name := strings.ToLower(string(in))
+			if names[name] {
+				return fmt.Errorf("input %v is specified more than once", in)
+			}
+			names[name] = true
+			if _, err := p.node(string(in), core.NTSource, core.NTBox); err != nil {
+				return err
+			}
+		}
+		for _, in := range stmt.Inputs {
+			sink.Inputs = append(sink.Inputs, string(in))
+		}
+
+	case parser.InsertIntoSelectStmt:
+		sink, err := p.node(string(stmt.Sink), core.NTSink)
+		if err != nil {
+			return err
+		}
+		inputs, err := p.planSelect(string(stmt.Sink), &stmt.Select)
+		if err != nil {
+			return err
+		}
+		sink.Inputs = append(sink.Inputs, inputs...)
+
+	case parser.SplitStmt:
+		if _, err := p.node(string(stmt.Input), core.NTSource, core.NTBox); err != nil {
+			return err
+		}
+		if _, err := newSplitRouterBox(&stmt, tb.Reg); err != nil {
+			return err
+		}
+		for _, br := range stmt.Branches {
+			if err := p.checkNewNode(string(br.Name)); err != nil {
+				return err
+			}
+		}
+		for _, br := range stmt.Branches {
+			p.add(&PlannedNode{
+				Name:     string(br.Name),
+				NodeType: core.NTBox,
+				Inputs:   []string{string(stmt.Input)},
+			})
+		}
+
+	case parser.ArchiveStreamStmt:
+		if _, err := p.node(string(stmt.Stream), core.NTSource, core.NTBox); err != nil {
+			return err
+		}
+		name := archiveSinkName(string(stmt.Stream))
+		if err := p.checkNewNode(name); err != nil {
+			return fmt.Errorf("stream '%v' is already archived", stmt.Stream)
+		}
+		planned := stmt
+		planned.Params = p.resolveConstants(stmt.Params)
+		params, err := tb.archiveSinkParams(&planned)
+		if err != nil {
+			return err
+		}
+		p.add(&PlannedNode{
+			Name:     name,
+			NodeType: core.NTSink,
+			TypeName: "archive",
+			Params:   params,
+			Inputs:   []string{string(stmt.Stream)},
+		})
+
+	case parser.ReplayArchiveStmt:
+		return p.planStmt(replayArchiveSource(&stmt))
+
+	case parser.PauseSourceStmt:
+		_, err := p.node(string(stmt.Source), core.NTSource)
+		return err
+
+	case parser.ResumeSourceStmt:
+		_, err := p.node(string(stmt.Source), core.NTSource)
+		return err
+
+	case parser.RewindSourceStmt:
+		_, err := p.node(string(stmt.Source), core.NTSource)
+		return err
+
+	case parser.SetConstantStmt:
+		if err := core.ValidateConstantName(string(stmt.Name)); err != nil {
+			return err
+		}
+		v, err := execution.EvaluateFoldable(stmt.Value, tb.Reg)
+		if err != nil {
+			return err
+		}
+		p.constants[string(stmt.Name)] = v
+
+	case parser.ReloadFunctionStmt:
+		_, err := p.tb.reloadableFunction(string(stmt.Function))
+		return err
+
+	default:
+		return &unplannableStmtError{stmt: stmt}
+	}
+	return nil
+}
+
+// unplannableStmtError is returned from planStmt when the statement isn't
+// supported by Plan.
+type unplannableStmtError struct {
+	stmt interface{}
+}
+
+func (e *unplannableStmtError) Error() string {
+	return fmt.Sprintf("statement of type %T is unimplemented", e.stmt)
+}
+
+// planSelect checks a SELECT statement of a stream and returns names of its
+// inputs.
+func (p *topologyPlanner) planSelect(name string, stmt *parser.SelectStmt) ([]string, error) {
+	var inputs []string
+	connected := map[string]bool{}
+	for _, rel := range stmt.Relations {
+		switch rel.Type {
+		case parser.ActualStream:
+			if strings.ToLower(rel.Name) == strings.ToLower(name) {
+				return nil, fmt.Errorf("a stream '%v' contains a selfloop", name)
+			}
+			if _, err := p.node(rel.Name, core.NTSource, core.NTBox); err != nil {
+				return nil, err
+			}
+			if !connected[rel.Name] { // self-joins have the same input twice
+				inputs = append(inputs, rel.Name)
+				connected[rel.Name] = true
+			}
+
+		case parser.UDSFStream:
+			for _, expr := range rel.Params {
+				if _, err := execution.EvaluateFoldable(expr, p.tb.Reg); err != nil {
+					return nil, err
+				}
+			}
+			if _, err := p.tb.UDSFCreators.Lookup(rel.Name, len(rel.Params)); err != nil {
+				return nil, err
+			}
+			params := make([]string, len(rel.Params))
+			for i, expr := range rel.Params {
+				params[i] = expr.String()
+			}
+			inputs = append(inputs, fmt.Sprintf("%v(%v)", rel.Name, strings.Join(params, ", ")))
+
+		default:
+			return nil, fmt.Errorf("input stream of type %s not implemented", rel.Type)
+		}
+	}
+
+	// Analyze resolves functions used in the statement.
+	if _, err := execution.Analyze(*stmt, p.tb.Reg); err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func (p *topologyPlanner) planCreateState(name, typeName string) error {
+	if _, err := p.tb.UDSCreators.Lookup(typeName); err != nil {
+		return err
+	}
+	if !p.states[strings.ToLower(name)] {
+		p.states[strings.ToLower(name)] = true
+		p.plan.States = append(p.plan.States, name)
+	}
+	return nil
+}
+
+func (p *topologyPlanner) drop(name string, t core.NodeType) error {
+	if _, err := p.node(name, t); err != nil {
+		return err
+	}
+	delete(p.nodes, strings.ToLower(name))
+	for _, n := range p.nodes {
+		ins := n.Inputs[:0]
+		for _, in := range n.Inputs {
+			if strings.ToLower(in) != strings.ToLower(name) {
+				ins = append(ins, in)
+			}
+		}
+		n.Inputs = ins
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package bql
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/parser"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+func TestTopologyBuilderPlan(t *testing.T) {
+	Convey("Given a BQL TopologyBuilder", t, func() {
+		dt := newTestTopology()
+		Reset(func() {
+			dt.Stop()
+		})
+		tb, err := NewTopologyBuilder(dt)
+		So(err, ShouldBeNil)
+
+		plan := func(bql string) *TopologyPlan {
+			stmts, err := parser.New().ParseStmts(bql)
+			So(err, ShouldBeNil)
+			return tb.Plan(stmts)
+		}
+
+		Convey("When planning valid statements", func() {
+			p := plan(`CREATE SOURCE s TYPE dummy WITH num=4;
+				CREATE STREAM a AS SELECT ISTREAM int, abs(int) AS x FROM s [RANGE 1 TUPLES];
+				CREATE STREAM b AS SELECT ISTREAM * FROM a [RANGE 1 TUPLES], s [RANGE 1 TUPLES];
+				CREATE SINK snk TYPE collector;
+				INSERT INTO snk FROM a, b;
+				CREATE SINK tmp TYPE collector;
+				DROP SINK tmp;`)
+
+			Convey("Then it should have no error", func() {
+				So(p.Errors, ShouldBeEmpty)
+			})
+
+			Convey("Then it should have planned nodes", func() {
+				So(p.Nodes, ShouldHaveLength, 4)
+				So(p.Nodes[0], ShouldResemble, &PlannedNode{
+					Name:     "s",
+					NodeType: core.NTSource,
+					TypeName: "dummy",
+					Params:   data.Map{"num": data.Int(4)},
+				})
+				So(p.Nodes[1].NodeType, ShouldEqual, core.NTBox)
+				So(p.Nodes[1].Inputs, ShouldResemble, []string{"s"})
+				So(p.Nodes[2].Inputs, ShouldResemble, []string{"a", "s"})
+				So(p.Nodes[3].Name, ShouldEqual, "snk")
+				So(p.Nodes[3].Inputs, ShouldResemble, []string{"a", "b"})
+			})
+
+			Convey("Then it shouldn't create any node", func() {
+				So(dt.Nodes(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When planning statements having errors", func() {
+			p := plan(`CREATE SOURCE s TYPE no_such_type;
+				CREATE SOURCE s2 TYPE dummy;
+				CREATE STREAM a AS SELECT ISTREAM no_such_func(int) FROM s2 [RANGE 1 TUPLES];
+				CREATE STREAM b AS SELECT ISTREAM * FROM s [RANGE 1 TUPLES];
+				CREATE SINK snk TYPE collector;
+				INSERT INTO snk FROM a;
+				CREATE SOURCE s2 TYPE dummy;
+				UPDATE STATE st SET x=1;`)
+
+			Convey("Then it should report errors of each statement", func() {
+				idx := []int{}
+				for _, e := range p.Errors {
+					idx = append(idx, e.Index)
+				}
+				So(idx, ShouldResemble, []int{0, 2, 3, 5, 6, 7})
+				So(p.Errors[0].Error(), ShouldStartWith, "statement #1: ")
+			})
+
+			Convey("Then it should only have valid nodes", func() {
+				So(p.Nodes, ShouldHaveLength, 2)
+				So(p.Nodes[0].Name, ShouldEqual, "s2")
+				So(p.Nodes[1].Name, ShouldEqual, "snk")
+			})
+		})
+
+		Convey("When planning a SPLIT statement", func() {
+			p := plan(`CREATE SOURCE s TYPE dummy;
+				SPLIT s INTO hot WHEN int > 2, cold OTHERWISE;
+				SPLIT s INTO hot2 WHEN int > 2, cold OTHERWISE;`)
+
+			Convey("Then it should have a node for each branch", func() {
+				So(p.Nodes, ShouldHaveLength, 3)
+				So(p.Nodes[1].Name, ShouldEqual, "hot")
+				So(p.Nodes[1].Inputs, ShouldResemble, []string{"s"})
+				So(p.Nodes[2].Name, ShouldEqual, "cold")
+			})
+
+			Convey("Then it should report a duplicated branch without adding others", func() {
+				So(p.Errors, ShouldHaveLength, 1)
+				So(p.Errors[0].Index, ShouldEqual, 2)
+			})
+		})
+
+		Convey("When planning an INSERT INTO SELECT statement", func() {
+			p := plan(`CREATE SOURCE s TYPE dummy;
+				CREATE SINK snk TYPE collector;
+				INSERT INTO snk SELECT ISTREAM int FROM s [RANGE 1 TUPLES];
+				INSERT INTO snk SELECT ISTREAM no_such_func(int) FROM s [RANGE 1 TUPLES];`)
+
+			Convey("Then the sink should read from inputs of the SELECT", func() {
+				So(p.Nodes, ShouldHaveLength, 2)
+				So(p.Nodes[1].Inputs, ShouldResemble, []string{"s"})
+			})
+
+			Convey("Then it should report an error in the SELECT", func() {
+				So(p.Errors, ShouldHaveLength, 1)
+				So(p.Errors[0].Index, ShouldEqual, 3)
+			})
+		})
+
+		Convey("When planning statements referring to existing nodes", func() {
+			So(addBQLToTopology(tb, `CREATE SOURCE s TYPE dummy;`), ShouldBeNil)
+			p := plan(`CREATE STREAM a AS SELECT ISTREAM * FROM s [RANGE 1 TUPLES];`)
+
+			Convey("Then it should use the existing nodes", func() {
+				So(p.Errors, ShouldBeEmpty)
+				So(p.Nodes, ShouldHaveLength, 2)
+				So(p.Nodes[0].Existing, ShouldBeTrue)
+				So(p.Nodes[1].Existing, ShouldBeFalse)
+			})
+		})
+	})
+}
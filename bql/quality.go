@@ -0,0 +1,413 @@
+package bql
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/sirupsen/logrus"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/execution"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/parser"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/udf"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// qualityInputRelation is the relation name with which quality rules refer
+// to fields of a tuple emitted from a stream.
+const qualityInputRelation = "input"
+
+// qualityConfig has parameters of data quality rules of a stream.
+type qualityConfig struct {
+	// rules are BQL boolean expressions which tuples must satisfy.
+	rules []string
+
+	// schema is the name of the state having the schema which tuples must
+	// satisfy, such as a json_schema state. It's empty when tuples aren't
+	// validated with a schema.
+	schema string
+
+	// quarantine is the name of the source to which invalid tuples are
+	// written. Invalid tuples are just dropped when it's empty.
+	quarantine string
+}
+
+// schemaValidator is a shared state validating values with a schema, such as
+// a json_schema state. ValidateSchema returns descriptions of errors, which is
+// empty when the value is valid.
+type schemaValidator interface {
+	ValidateSchema(v data.Value) ([]string, error)
+}
+
+// extractQuality extracts "validate", "schema", and "quarantine" parameters
+// from params. "validate" has quality rules which are BQL boolean expressions
+// separated by commas, such as "temp >= -50 AND temp <= 150, ts IS NOT NULL",
+// or an array of them. "schema" is the name of a state, such as a json_schema
+// state, having the schema which tuples must satisfy. "quarantine" is the name
+// of a stream receiving tuples violating the rules or the schema and can only
+// be given with "validate" or "schema". It returns a nil config when neither
+// "validate" nor "schema" is given. The returned map has the rest of
+// parameters and params isn't modified.
+func extractQuality(params data.Map) (*qualityConfig, data.Map, error) {
+	v, ok := params["validate"]
+	sv, hasSchema := params["schema"]
+	if !ok && !hasSchema {
+		if _, ok := params["quarantine"]; ok {
+			return nil, nil, errors.New("quarantine requires validate or schema")
+		}
+		return nil, params, nil
+	}
+	rest := make(data.Map, len(params))
+	for k, v := range params {
+		rest[k] = v
+	}
+	delete(rest, "validate")
+	delete(rest, "schema")
+	delete(rest, "quarantine")
+
+	c := &qualityConfig{}
+	if hasSchema {
+		s, err := data.AsString(sv)
+		if err != nil {
+			return nil, nil, fmt.Errorf("schema must be a string: %v", err)
+		}
+		if err := core.ValidateSymbol(s); err != nil {
+			return nil, nil, fmt.Errorf("schema has an invalid name: %v", err)
+		}
+		c.schema = s
+	}
+	switch v := v.(type) {
+	case nil:
+	case data.String:
+		rules, err := splitQualityRules(string(v))
+		if err != nil {
+			return nil, nil, err
+		}
+		c.rules = rules
+	case data.Array:
+		for _, r := range v {
+			s, err := data.AsString(r)
+			if err != nil {
+				return nil, nil, fmt.Errorf("validate must only have strings: %v", err)
+			}
+			c.rules = append(c.rules, strings.TrimSpace(s))
+		}
+	default:
+		return nil, nil, errors.New("validate must be a string or an array of strings")
+	}
+	if ok && len(c.rules) == 0 {
+		return nil, nil, errors.New("validate must have at least one rule")
+	}
+	names := map[string]bool{}
+	for _, r := range c.rules {
+		if r == "" {
+			return nil, nil, errors.New("validate must not have an empty rule")
+		}
+		if names[r] {
+			return nil, nil, fmt.Errorf("rule '%v' is specified more than once", r)
+		}
+		names[r] = true
+	}
+
+	if v, ok := params["quarantine"]; ok {
+		s, err := data.AsString(v)
+		if err != nil {
+			return nil, nil, fmt.Errorf("quarantine must be a string: %v", err)
+		}
+		if err := core.ValidateSymbol(s); err != nil {
+			return nil, nil, fmt.Errorf("quarantine has an invalid name: %v", err)
+		}
+		c.quarantine = s
+	}
+	return c, rest, nil
+}
+
+// splitQualityRules splits rules at commas which aren't enclosed by
+// parentheses, brackets, braces, or quotes.
+func splitQualityRules(s string) ([]string, error) {
+	var (
+		rules []string
+		depth int
+		quote rune
+		start int
+	)
+	for i, r := range s {
+		if quote != 0 {
+			if r == quote {
+				quote = 0
+			}
+			continue
+		}
+		switch r {
+		case '\'', '"':
+			quote = r
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("validate has an unbalanced '%c'", r)
+			}
+		case ',':
+			if depth == 0 {
+				rules = append(rules, strings.TrimSpace(s[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	if quote != 0 || depth != 0 {
+		return nil, errors.New("validate has an unterminated expression")
+	}
+	return append(rules, strings.TrimSpace(s[start:])), nil
+}
+
+// qualityRules evaluates quality rules on tuples emitted from a stream and
+// counts violations of each rule.
+type qualityRules struct {
+	rules []string
+	evals []execution.Evaluator
+
+	// schema is the name of the state validating tuples. It's empty when
+	// tuples aren't validated with a schema.
+	schema string
+
+	// quarantine is nil when invalid tuples are dropped.
+	quarantine     *quarantineSource
+	quarantineName string
+
+	numValid            int64
+	numInvalid          int64
+	numViolations       []int64
+	numSchemaViolations int64
+}
+
+// newQualityRules parses the rules of the config. Rules can refer to fields
+// of a tuple with or without the name of the stream as a prefix. The state
+// having the schema must exist when the config has it.
+func newQualityRules(c *qualityConfig, stream string, reg udf.FunctionRegistry) (*qualityRules, error) {
+	q := &qualityRules{
+		rules:          c.rules,
+		schema:         c.schema,
+		quarantineName: c.quarantine,
+		numViolations:  make([]int64, len(c.rules)),
+	}
+	if q.schema != "" {
+		if _, err := lookupSchemaValidator(reg.Context(), q.schema); err != nil {
+			return nil, err
+		}
+	}
+	p := parser.New()
+	for _, r := range c.rules {
+		stmt, rest, err := p.ParseStmt("EVAL " + r)
+		if err != nil {
+			return nil, fmt.Errorf("rule '%v' cannot be parsed: %v", r, err)
+		}
+		eval, ok := stmt.(parser.EvalStmt)
+		if !ok || eval.Input != nil || rest != "" {
+			return nil, fmt.Errorf("rule '%v' must be a single expression", r)
+		}
+		for rel := range eval.Expr.ReferencedRelations() {
+			if rel != "" && strings.ToLower(rel) != strings.ToLower(stream) {
+				return nil, fmt.Errorf("rule '%v' refers to an unknown stream: %v", r, rel)
+			}
+		}
+		expr := eval.Expr.RenameReferencedRelation("", qualityInputRelation)
+		if stream != qualityInputRelation {
+			expr = expr.RenameReferencedRelation(stream, qualityInputRelation)
+		}
+		flatExpr, err := execution.ParserExprToFlatExpr(expr, reg)
+		if err != nil {
+			return nil, fmt.Errorf("rule '%v' is invalid: %v", r, err)
+		}
+		e, err := execution.ExpressionToEvaluator(flatExpr, reg)
+		if err != nil {
+			return nil, fmt.Errorf("rule '%v' is invalid: %v", r, err)
+		}
+		q.evals = append(q.evals, e)
+	}
+	return q, nil
+}
+
+// check evaluates all rules on the tuple and returns true when it doesn't
+// violate any of them. A rule is violated when it's evaluated to false or
+// fails. A rule evaluated to NULL is satisfied like a CHECK constraint of
+// SQL, so a rule such as "x IS NOT NULL" is required to reject missing
+// fields. An invalid tuple is written to the quarantine stream if any.
+func (q *qualityRules) check(ctx *core.Context, stream string, t *core.Tuple) bool {
+	// nest the data so that access via JSON path works properly
+	row := data.Map{
+		qualityInputRelation: t.Data,
+		fmt.Sprintf("%s:meta:%s", qualityInputRelation, parser.TimestampMeta): data.Timestamp(t.Timestamp),
+		fmt.Sprintf("%s:meta:%s", qualityInputRelation, parser.BackfillMeta):  data.Bool(t.Flags.IsSet(core.TFBackfill)),
+	}
+
+	var violations data.Array
+	for i, e := range q.evals {
+		v, err := e.Eval(row)
+		if err == nil {
+			if v.Type() == data.TypeNull {
+				continue
+			}
+			var ok bool
+			if ok, err = data.AsBool(v); err == nil && ok {
+				continue
+			}
+		}
+		atomic.AddInt64(&q.numViolations[i], 1)
+		violations = append(violations, data.String(q.rules[i]))
+	}
+	if q.schema != "" {
+		if errs := q.validateSchema(ctx, t.Data); len(errs) > 0 {
+			atomic.AddInt64(&q.numSchemaViolations, 1)
+			for _, e := range errs {
+				violations = append(violations, data.String(e))
+			}
+		}
+	}
+	if len(violations) == 0 {
+		atomic.AddInt64(&q.numValid, 1)
+		return true
+	}
+	atomic.AddInt64(&q.numInvalid, 1)
+
+	if q.quarantine != nil {
+		qt := t.ShallowCopy()
+		qt.Data = data.Map{
+			"stream":     data.String(stream),
+			"violations": violations,
+			"data":       t.Data,
+		}
+		if err := q.quarantine.write(ctx, qt); err != nil {
+			ctx.ErrLog(err).WithFields(logrus.Fields{
+				"node_type": core.NTBox,
+				"node_name": stream,
+			}).Error("Cannot write an invalid tuple to the quarantine stream")
+		}
+	}
+	return false
+}
+
+// status returns the numbers of valid and invalid tuples and violations of
+// each rule.
+func (q *qualityRules) status() data.Map {
+	vs := data.Map{}
+	for i, r := range q.rules {
+		vs[r] = data.Int(atomic.LoadInt64(&q.numViolations[i]))
+	}
+	m := data.Map{
+		"num_valid":   data.Int(atomic.LoadInt64(&q.numValid)),
+		"num_invalid": data.Int(atomic.LoadInt64(&q.numInvalid)),
+		"violations":  vs,
+	}
+	if q.schema != "" {
+		m["schema"] = data.String(q.schema)
+		m["num_schema_violations"] = data.Int(atomic.LoadInt64(&q.numSchemaViolations))
+	}
+	if q.quarantineName != "" {
+		m["quarantine"] = data.String(q.quarantineName)
+	}
+	return m
+}
+
+// validateSchema validates the data with the schema and returns violations.
+// Each violation is prefixed with "schema:". The state is looked up every
+// time so that it can be replaced by LOAD STATE.
+func (q *qualityRules) validateSchema(ctx *core.Context, d data.Map) []string {
+	s, err := lookupSchemaValidator(ctx, q.schema)
+	if err == nil {
+		var errs []string
+		if errs, err = s.ValidateSchema(d); err == nil {
+			for i, e := range errs {
+				errs[i] = "schema: " + e
+			}
+			return errs
+		}
+	}
+	return []string{fmt.Sprintf("schema: %v", err)}
+}
+
+func lookupSchemaValidator(ctx *core.Context, name string) (schemaValidator, error) {
+	st, err := ctx.SharedStates.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	s, ok := st.(schemaValidator)
+	if !ok {
+		return nil, fmt.Errorf("state '%v' cannot validate tuples with a schema", name)
+	}
+	return s, nil
+}
+
+// quarantineSource is a source emitting tuples which violate quality rules
+// of a stream. It's stopped and removed with the stream.
+type quarantineSource struct {
+	m sync.RWMutex
+	w core.Writer
+
+	// ready is closed when the source starts generating the stream.
+	ready   chan struct{}
+	stop    chan struct{}
+	stopped bool
+}
+
+func newQuarantineSource() *quarantineSource {
+	return &quarantineSource{
+		ready: make(chan struct{}),
+		stop:  make(chan struct{}),
+	}
+}
+
+func (s *quarantineSource) GenerateStream(ctx *core.Context, w core.Writer) error {
+	s.m.Lock()
+	if s.stopped {
+		s.m.Unlock()
+		return nil
+	}
+	s.w = w
+	close(s.ready)
+	s.m.Unlock()
+
+	<-s.stop
+	return nil
+}
+
+// write writes the tuple to the stream. It waits until the source starts
+// and discards the tuple after the source stops.
+func (s *quarantineSource) write(ctx *core.Context, t *core.Tuple) error {
+	select {
+	case <-s.ready:
+	case <-s.stop:
+		return nil
+	}
+
+	s.m.RLock()
+	defer s.m.RUnlock()
+	if s.stopped {
+		return nil
+	}
+	return s.w.Write(ctx, t)
+}
+
+func (s *quarantineSource) Stop(ctx *core.Context) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if !s.stopped {
+		s.stopped = true
+		close(s.stop)
+	}
+	return nil
+}
+
+// addQuarantineSource adds a source emitting invalid tuples of the stream
+// with the given name. The source is removed when it's stopped.
+func (tb *TopologyBuilder) addQuarantineSource(name string) (*quarantineSource, error) {
+	s := newQuarantineSource()
+	if _, err := tb.topology.AddSource(name, s, &core.SourceConfig{
+		RemoveOnStop: true,
+	}); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
@@ -0,0 +1,294 @@
+package bql
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+func TestExtractQuality(t *testing.T) {
+	Convey("Given parameters having quality rules", t, func() {
+		params := data.Map{
+			"validate":   data.String(`x >= -50 AND x <= 150, str_len(concat(s, ",")) > 1, ts IS NOT NULL`),
+			"quarantine": data.String("bad"),
+			"num":        data.Int(1),
+		}
+
+		Convey("When extracting them", func() {
+			c, rest, err := extractQuality(params)
+			So(err, ShouldBeNil)
+
+			Convey("Then rules should be split at top-level commas", func() {
+				So(c.rules, ShouldResemble, []string{
+					"x >= -50 AND x <= 150",
+					`str_len(concat(s, ",")) > 1`,
+					"ts IS NOT NULL",
+				})
+				So(c.quarantine, ShouldEqual, "bad")
+			})
+
+			Convey("Then the rest of parameters should only have num", func() {
+				So(rest, ShouldResemble, data.Map{"num": data.Int(1)})
+				So(params, ShouldContainKey, "validate")
+			})
+		})
+	})
+
+	Convey("Given parameters having quality rules in an array", t, func() {
+		params := data.Map{
+			"validate": data.Array{data.String("x > 0, y > 0"), data.String(" z > 0 ")},
+		}
+
+		Convey("When extracting them", func() {
+			c, _, err := extractQuality(params)
+			So(err, ShouldBeNil)
+
+			Convey("Then each element should be a rule", func() {
+				So(c.rules, ShouldResemble, []string{"x > 0, y > 0", "z > 0"})
+				So(c.quarantine, ShouldBeBlank)
+			})
+		})
+	})
+
+	Convey("Given parameters only having a schema", t, func() {
+		params := data.Map{
+			"schema":     data.String("order_schema"),
+			"quarantine": data.String("bad"),
+		}
+
+		Convey("When extracting them", func() {
+			c, rest, err := extractQuality(params)
+			So(err, ShouldBeNil)
+
+			Convey("Then the config should have the schema without rules", func() {
+				So(c.schema, ShouldEqual, "order_schema")
+				So(c.rules, ShouldBeEmpty)
+				So(c.quarantine, ShouldEqual, "bad")
+				So(rest, ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given parameters not having quality rules", t, func() {
+		params := data.Map{"num": data.Int(1)}
+
+		Convey("When extracting them", func() {
+			c, rest, err := extractQuality(params)
+			So(err, ShouldBeNil)
+
+			Convey("Then it should return a nil config", func() {
+				So(c, ShouldBeNil)
+				So(rest, ShouldResemble, params)
+			})
+		})
+	})
+
+	Convey("Given invalid parameters", t, func() {
+		for _, params := range []data.Map{
+			{"quarantine": data.String("bad")},
+			{"validate": data.Int(1)},
+			{"validate": data.String("")},
+			{"validate": data.String("x > 0,")},
+			{"validate": data.String("x > 0, x > 0")},
+			{"validate": data.String("f(x, y > 0")},
+			{"validate": data.String("x = 'a, y > 0")},
+			{"validate": data.Array{data.Int(1)}},
+			{"validate": data.String("x > 0"), "quarantine": data.String("in valid")},
+			{"schema": data.Int(1)},
+			{"schema": data.String("in valid")},
+			{"schema": data.String("s"), "validate": data.String("")},
+		} {
+			Convey("When extracting rules from "+params.String(), func() {
+				_, _, err := extractQuality(params)
+
+				Convey("Then it should fail", func() {
+					So(err, ShouldNotBeNil)
+				})
+			})
+		}
+	})
+}
+
+func TestQualityRules(t *testing.T) {
+	Convey("Given a topology having a stream with quality rules and a quarantine", t, func() {
+		dt := newTestTopology()
+		Reset(func() {
+			dt.Stop()
+		})
+		tb, err := NewTopologyBuilder(dt)
+		So(err, ShouldBeNil)
+
+		So(addBQLToTopology(tb, `
+			CREATE PAUSED SOURCE source TYPE dummy WITH num=4;
+			CREATE STREAM box AS SELECT ISTREAM int FROM source [RANGE 1 TUPLES]
+				WITH validate="int % 2 = 1, box:int < 3", quarantine="bad";
+			CREATE SINK snk TYPE collector;
+			CREATE SINK bad_snk TYPE collector;
+			INSERT INTO snk FROM box;
+			INSERT INTO bad_snk FROM bad;
+			RESUME SOURCE source;`), ShouldBeNil)
+
+		sn, err := dt.Sink("snk")
+		So(err, ShouldBeNil)
+		si := sn.Sink().(*tupleCollectorSink)
+		bn, err := dt.Sink("bad_snk")
+		So(err, ShouldBeNil)
+		bad := bn.Sink().(*tupleCollectorSink)
+
+		Convey("When the source emits tuples", func() {
+			si.Wait(1)
+			bad.Wait(3)
+
+			Convey("Then only valid tuples should be emitted", func() {
+				So(si.len(), ShouldEqual, 1)
+				So(si.get(0).Data, ShouldResemble, data.Map{"int": data.Int(1)})
+			})
+
+			Convey("Then invalid tuples should be written to the quarantine", func() {
+				So(bad.len(), ShouldEqual, 3)
+				So(bad.get(0).Data, ShouldResemble, data.Map{
+					"stream":     data.String("box"),
+					"violations": data.Array{data.String("int % 2 = 1")},
+					"data":       data.Map{"int": data.Int(2)},
+				})
+				So(bad.get(1).Data["violations"], ShouldResemble, data.Array{data.String("box:int < 3")})
+				So(bad.get(2).Data["violations"], ShouldResemble, data.Array{
+					data.String("int % 2 = 1"), data.String("box:int < 3")})
+			})
+
+			Convey("Then the status of the stream should have numbers of violations", func() {
+				bn, err := dt.Box("box")
+				So(err, ShouldBeNil)
+				st := bn.Status()["box"].(data.Map)
+				So(st["quality"], ShouldResemble, data.Map{
+					"num_valid":   data.Int(1),
+					"num_invalid": data.Int(3),
+					"violations": data.Map{
+						"int % 2 = 1": data.Int(2),
+						"box:int < 3": data.Int(2),
+					},
+					"quarantine": data.String("bad"),
+				})
+			})
+
+			Convey("Then the quarantine should be removed with the stream", func() {
+				So(addBQLToTopology(tb, `DROP STREAM box;`), ShouldBeNil)
+				waitForExpectedCondition(func() bool {
+					_, err := dt.Source("bad")
+					return err != nil
+				})
+				_, err := dt.Source("bad")
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+
+	Convey("Given a topology having a stream validated with a schema", t, func() {
+		dt := newTestTopology()
+		Reset(func() {
+			dt.Stop()
+		})
+		tb, err := NewTopologyBuilder(dt)
+		So(err, ShouldBeNil)
+
+		So(addBQLToTopology(tb, `
+			CREATE STATE int_schema TYPE json_schema
+				WITH schema={"type": "object", "properties": {"int": {"maximum": 2}}};
+			CREATE PAUSED SOURCE source TYPE dummy WITH num=4;
+			CREATE STREAM box AS SELECT ISTREAM int FROM source [RANGE 1 TUPLES]
+				WITH validate="int % 2 = 1", schema="int_schema", quarantine="bad";
+			CREATE SINK snk TYPE collector;
+			CREATE SINK bad_snk TYPE collector;
+			INSERT INTO snk FROM box;
+			INSERT INTO bad_snk FROM bad;
+			RESUME SOURCE source;`), ShouldBeNil)
+
+		sn, err := dt.Sink("snk")
+		So(err, ShouldBeNil)
+		si := sn.Sink().(*tupleCollectorSink)
+		bn, err := dt.Sink("bad_snk")
+		So(err, ShouldBeNil)
+		bad := bn.Sink().(*tupleCollectorSink)
+
+		Convey("When the source emits tuples", func() {
+			si.Wait(1)
+			bad.Wait(3)
+
+			Convey("Then only tuples satisfying the rules and the schema should be emitted", func() {
+				So(si.len(), ShouldEqual, 1)
+				So(si.get(0).Data, ShouldResemble, data.Map{"int": data.Int(1)})
+			})
+
+			Convey("Then tuples violating the schema should be written to the quarantine", func() {
+				So(bad.len(), ShouldEqual, 3)
+				So(bad.get(0).Data["violations"], ShouldResemble, data.Array{data.String("int % 2 = 1")})
+				vs := bad.get(1).Data["violations"].(data.Array)
+				So(vs, ShouldHaveLength, 1)
+				v, _ := data.AsString(vs[0])
+				So(v, ShouldStartWith, "schema: int: ")
+				So(bad.get(2).Data["violations"], ShouldHaveLength, 2)
+			})
+
+			Convey("Then the status of the stream should have the number of schema violations", func() {
+				bn, err := dt.Box("box")
+				So(err, ShouldBeNil)
+				st := bn.Status()["box"].(data.Map)["quality"].(data.Map)
+				So(st["schema"], ShouldEqual, data.String("int_schema"))
+				So(st["num_schema_violations"], ShouldEqual, data.Int(2))
+				So(st["num_invalid"], ShouldEqual, data.Int(3))
+			})
+		})
+	})
+
+	Convey("Given a topology builder", t, func() {
+		dt := newTestTopology()
+		Reset(func() {
+			dt.Stop()
+		})
+		tb, err := NewTopologyBuilder(dt)
+		So(err, ShouldBeNil)
+		So(addBQLToTopology(tb, `CREATE PAUSED SOURCE source TYPE dummy WITH num=4;`), ShouldBeNil)
+
+		Convey("When creating streams with invalid rules", func() {
+			for _, r := range []string{
+				"int >",
+				"int > 0; DROP SOURCE source",
+				"count(int) > 0",
+				"other:int > 0",
+			} {
+				err := addBQLToTopology(tb, `CREATE STREAM box AS SELECT ISTREAM int FROM source [RANGE 1 TUPLES]
+					WITH validate="`+r+`";`)
+
+				Convey("Then it should fail with "+r, func() {
+					So(err, ShouldNotBeNil)
+				})
+			}
+		})
+
+		Convey("When creating a stream with a schema which doesn't exist", func() {
+			err := addBQLToTopology(tb, `CREATE STREAM box AS SELECT ISTREAM int FROM source [RANGE 1 TUPLES]
+				WITH schema="no_such_schema";`)
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When creating a stream whose quarantine already exists", func() {
+			err := addBQLToTopology(tb, `CREATE STREAM box AS SELECT ISTREAM int FROM source [RANGE 1 TUPLES]
+				WITH validate="int > 0", quarantine="source";`)
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+
+				Convey("And the stream shouldn't be created", func() {
+					_, err := dt.Box("box")
+					So(err, ShouldNotBeNil)
+					_, err = dt.Source("source")
+					So(err, ShouldBeNil)
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,26 @@
+package bql
+
+import (
+	"gopkg.in/sensorbee/sensorbee.v0/bql/udf"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+)
+
+// randomFunctionRegistry binds the random number generator of a node to
+// UDFs implementing udf.RandomizedUDF looked up from a FunctionRegistry so
+// that random values generated by them only depend on the seed of the
+// topology and the name of the node.
+type randomFunctionRegistry struct {
+	udf.FunctionRegistry
+	random *core.Random
+}
+
+func (r *randomFunctionRegistry) Lookup(name string, arity int) (udf.UDF, error) {
+	f, err := r.FunctionRegistry.Lookup(name, arity)
+	if err != nil {
+		return nil, err
+	}
+	if rf, ok := f.(udf.RandomizedUDF); ok {
+		return rf.WithRandom(r.random), nil
+	}
+	return f, nil
+}
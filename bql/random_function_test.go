@@ -0,0 +1,58 @@
+package bql
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+func TestRandomFunctions(t *testing.T) {
+	run := func(seed int64) []data.Map {
+		dt, err := core.NewDefaultTopology(core.NewContext(&core.ContextConfig{
+			RandomSeed: &seed,
+		}), "testTopology")
+		So(err, ShouldBeNil)
+		defer dt.Stop()
+		tb, err := NewTopologyBuilder(dt)
+		So(err, ShouldBeNil)
+		So(addBQLToTopology(tb, `
+			CREATE PAUSED SOURCE source TYPE dummy WITH num=4;
+			CREATE STREAM box AS SELECT RSTREAM int, random() AS r,
+				random_normal(0, 1) AS n, uuid() AS id FROM source [RANGE 1 TUPLES];
+			CREATE SINK snk TYPE collector;
+			INSERT INTO snk FROM box;
+			RESUME SOURCE source;`), ShouldBeNil)
+		sin, err := dt.Sink("snk")
+		So(err, ShouldBeNil)
+		si := sin.Sink().(*tupleCollectorSink)
+		si.Wait(4)
+		var res []data.Map
+		for i := 0; i < 4; i++ {
+			res = append(res, si.get(i).Data)
+		}
+		return res
+	}
+
+	Convey("Given a topology running random functions with a seed", t, func() {
+		res := run(1)
+
+		Convey("When running it again with the same seed", func() {
+			res2 := run(1)
+
+			Convey("Then it should generate the same values", func() {
+				So(res2, ShouldResemble, res)
+			})
+		})
+
+		Convey("When running it with another seed", func() {
+			res2 := run(2)
+
+			Convey("Then it should generate different values", func() {
+				So(res2[0]["r"], ShouldNotEqual, res[0]["r"])
+				So(res2[0]["id"], ShouldNotEqual, res[0]["id"])
+			})
+		})
+	})
+}
@@ -0,0 +1,441 @@
+package bql
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+var (
+	// retentionSegmentSize is the size of a segment file of a retention
+	// buffer. A new segment is created when the active one exceeds it.
+	retentionSegmentSize int64 = 1 << 20
+
+	// defaultRetentionMaxSize is the default upper limit of the total size
+	// of segment files of a retention buffer.
+	defaultRetentionMaxSize int64 = 64 << 20
+)
+
+// retentionConfig has parameters of the retention buffer of a stream.
+type retentionConfig struct {
+	// retention is how long tuples are kept in the buffer.
+	retention time.Duration
+
+	// maxSize is the upper limit of the total size of segment files in
+	// bytes. The oldest segments are removed even if they're still in the
+	// retention period when the total size exceeds it.
+	maxSize int64
+}
+
+// extractRetention extracts "retention" and "retention_max_size" parameters
+// from params. "retention" is a duration such as "10m" and enables the
+// retention buffer of the stream. "retention_max_size" is the upper limit
+// of the size of the buffer in bytes and can only be given with "retention".
+// It returns a nil config when "retention" isn't given. The returned map has
+// the rest of parameters and params isn't modified.
+func extractRetention(params data.Map) (*retentionConfig, data.Map, error) {
+	v, ok := params["retention"]
+	if !ok {
+		if _, ok := params["retention_max_size"]; ok {
+			return nil, nil, errors.New("retention_max_size requires retention")
+		}
+		return nil, params, nil
+	}
+	rest := make(data.Map, len(params))
+	for k, v := range params {
+		rest[k] = v
+	}
+	delete(rest, "retention")
+	delete(rest, "retention_max_size")
+
+	d, err := data.ToDuration(v)
+	if err != nil {
+		return nil, nil, fmt.Errorf("retention must be a duration: %v", err)
+	}
+	if d <= 0 {
+		return nil, nil, errors.New("retention must be positive")
+	}
+	c := &retentionConfig{
+		retention: d,
+		maxSize:   defaultRetentionMaxSize,
+	}
+	if v, ok := params["retention_max_size"]; ok {
+		s, err := data.AsInt(v)
+		if err != nil {
+			return nil, nil, fmt.Errorf("retention_max_size must be an integer: %v", err)
+		}
+		if s <= 0 {
+			return nil, nil, errors.New("retention_max_size must be positive")
+		}
+		c.maxSize = s
+	}
+	return c, rest, nil
+}
+
+// retentionSinkName returns the name of the hidden sink keeping the retention
+// buffer of the stream.
+func retentionSinkName(stream string) string {
+	return "sensorbee_tmp_retention_" + strings.ToLower(stream)
+}
+
+// addRetentionSink connects a hidden sink writing tuples of the stream to a
+// retention buffer. The sink is stopped and removed with the stream.
+func (tb *TopologyBuilder) addRetentionSink(stream string, c *retentionConfig) error {
+	b, err := newRetentionBuffer(c)
+	if err != nil {
+		return err
+	}
+	name := retentionSinkName(stream)
+	sn, err := tb.topology.AddSink(name, &retentionSink{b: b}, nil)
+	if err != nil {
+		b.close()
+		return err
+	}
+	if err := sn.Input(stream, nil); err != nil {
+		tb.topology.Remove(name)
+		return err
+	}
+	sn.StopOnDisconnect()
+	sn.RemoveOnStop()
+	return nil
+}
+
+// retentionBufferOf returns the retention buffer of the stream.
+func (tb *TopologyBuilder) retentionBufferOf(stream string) (*retentionBuffer, error) {
+	sn, err := tb.topology.Sink(retentionSinkName(stream))
+	if err != nil {
+		return nil, fmt.Errorf("stream '%v' doesn't have a retention buffer", stream)
+	}
+	s, ok := sn.Sink().(*retentionSink)
+	if !ok {
+		return nil, fmt.Errorf("stream '%v' doesn't have a retention buffer", stream)
+	}
+	return s.b, nil
+}
+
+// retentionSegment is a segment file of a retention buffer. Each record in a
+// segment has a 4-byte big endian length followed by a msgpack-encoded map
+// having the time when the tuple was written to the buffer, the timestamp,
+// and the data of the tuple.
+type retentionSegment struct {
+	seq  int64
+	f    *os.File
+	size int64
+	// last is the time when the last record was written.
+	last   time.Time
+	sealed bool
+}
+
+// retentionBuffer keeps tuples written to a stream in segment files for the
+// retention period so that they can be replayed by SELECT ... STARTING ...
+// AGO statements. Expired segments are removed when a new tuple is appended.
+// retentionBuffer is thread-safe.
+type retentionBuffer struct {
+	dir       string
+	retention time.Duration
+	maxSize   int64
+
+	// m protects fields below and cond is signaled when a record is
+	// appended or the buffer is closed.
+	m        sync.Mutex
+	cond     *sync.Cond
+	segments []*retentionSegment
+	nextSeq  int64
+	size     int64
+	closed   bool
+}
+
+func newRetentionBuffer(c *retentionConfig) (*retentionBuffer, error) {
+	dir, err := ioutil.TempDir("", "sensorbee-retention-")
+	if err != nil {
+		return nil, fmt.Errorf("cannot create a directory of a retention buffer: %v", err)
+	}
+	b := &retentionBuffer{
+		dir:       dir,
+		retention: c.retention,
+		maxSize:   c.maxSize,
+	}
+	b.cond = sync.NewCond(&b.m)
+	return b, nil
+}
+
+// append writes the tuple to the active segment.
+func (b *retentionBuffer) append(t *core.Tuple, now time.Time) error {
+	rec, err := data.EncodeMsgpack(data.Map{
+		"at":   data.Timestamp(now),
+		"ts":   data.Timestamp(t.Timestamp),
+		"data": t.Data,
+	})
+	if err != nil {
+		return err
+	}
+	buf := make([]byte, 4+len(rec))
+	binary.BigEndian.PutUint32(buf, uint32(len(rec)))
+	copy(buf[4:], rec)
+
+	b.m.Lock()
+	defer b.m.Unlock()
+	if b.closed {
+		return errors.New("the retention buffer has already been closed")
+	}
+	seg, err := b.activeSegment()
+	if err != nil {
+		return err
+	}
+	if _, err := seg.f.WriteAt(buf, seg.size); err != nil {
+		return fmt.Errorf("cannot write a tuple to a retention buffer: %v", err)
+	}
+	seg.size += int64(len(buf))
+	seg.last = now
+	b.size += int64(len(buf))
+	b.expire(now)
+	b.cond.Broadcast()
+	return nil
+}
+
+// activeSegment returns the segment to which a new record is written. It
+// seals the current one and creates a new one when it's full. The caller
+// must hold b.m.
+func (b *retentionBuffer) activeSegment() (*retentionSegment, error) {
+	if n := len(b.segments); n > 0 {
+		seg := b.segments[n-1]
+		if seg.size < retentionSegmentSize {
+			return seg, nil
+		}
+		seg.sealed = true
+	}
+
+	f, err := ioutil.TempFile(b.dir, "segment-")
+	if err != nil {
+		return nil, fmt.Errorf("cannot create a segment file of a retention buffer: %v", err)
+	}
+	seg := &retentionSegment{
+		seq: b.nextSeq,
+		f:   f,
+	}
+	b.nextSeq++
+	b.segments = append(b.segments, seg)
+	return seg, nil
+}
+
+// expire removes sealed segments which only have records older than the
+// retention period or which make the buffer exceed its size limit. The
+// caller must hold b.m.
+func (b *retentionBuffer) expire(now time.Time) {
+	limit := now.Add(-b.retention)
+	for len(b.segments) > 0 {
+		seg := b.segments[0]
+		if !seg.sealed || (!seg.last.Before(limit) && b.size <= b.maxSize) {
+			break
+		}
+		b.segments = b.segments[1:]
+		b.size -= seg.size
+		seg.f.Close()
+		os.Remove(seg.f.Name())
+	}
+}
+
+// close removes all segments and wakes up cursors reading the buffer.
+func (b *retentionBuffer) close() error {
+	b.m.Lock()
+	defer b.m.Unlock()
+	if b.closed {
+		return nil
+	}
+	b.closed = true
+	for _, seg := range b.segments {
+		seg.f.Close()
+	}
+	b.segments = nil
+	b.cond.Broadcast()
+	return os.RemoveAll(b.dir)
+}
+
+// cursor returns a new cursor reading records written at or after since.
+// Tuples written to the buffer before the cursor is created have
+// core.TFBackfill flag.
+func (b *retentionBuffer) cursor(since time.Time) *retentionCursor {
+	b.m.Lock()
+	defer b.m.Unlock()
+	c := &retentionCursor{
+		b:      b,
+		since:  since,
+		endSeq: b.nextSeq,
+	}
+	if n := len(b.segments); n > 0 {
+		c.endSeq = b.segments[n-1].seq
+		c.endOff = b.segments[n-1].size
+	}
+	return c
+}
+
+// retentionCursor reads records of a retention buffer in the order they
+// were written. It waits for a new record when it has read all records. When
+// segments are removed before the cursor reads them, records in them are
+// skipped.
+type retentionCursor struct {
+	b     *retentionBuffer
+	since time.Time
+
+	// seq and off are the position of the next record.
+	seq int64
+	off int64
+
+	// endSeq and endOff are the end of the buffer when the cursor is
+	// created.
+	endSeq int64
+	endOff int64
+
+	closed bool
+}
+
+// next returns the next tuple. It returns io.EOF when the cursor or the
+// buffer is closed.
+func (c *retentionCursor) next() (*core.Tuple, error) {
+	b := c.b
+	b.m.Lock()
+	defer b.m.Unlock()
+	for {
+		if c.closed || b.closed {
+			return nil, io.EOF
+		}
+		seg := c.segment()
+		if seg == nil {
+			b.cond.Wait()
+			continue
+		}
+		if seg.sealed && seg.last.Before(c.since) {
+			c.seq, c.off = seg.seq+1, 0
+			continue
+		}
+		if c.off >= seg.size {
+			if seg.sealed {
+				c.seq, c.off = seg.seq+1, 0
+			} else {
+				b.cond.Wait()
+			}
+			continue
+		}
+
+		backfill := c.seq < c.endSeq || (c.seq == c.endSeq && c.off < c.endOff)
+		rec, err := c.read(seg)
+		if err != nil {
+			return nil, err
+		}
+		at, err := data.ToTimestamp(rec["at"])
+		if err != nil {
+			return nil, err
+		}
+		if at.Before(c.since) {
+			continue
+		}
+		ts, err := data.ToTimestamp(rec["ts"])
+		if err != nil {
+			return nil, err
+		}
+		m, err := data.AsMap(rec["data"])
+		if err != nil {
+			return nil, err
+		}
+		t := &core.Tuple{
+			Data:          m,
+			Timestamp:     ts,
+			ProcTimestamp: time.Now(),
+		}
+		if backfill {
+			t.Flags.Set(core.TFBackfill)
+		}
+		return t, nil
+	}
+}
+
+// segment returns the segment having the next record. When the segment was
+// removed, the cursor moves to the oldest segment remaining. It returns nil
+// when there's no segment to read. The caller must hold c.b.m.
+func (c *retentionCursor) segment() *retentionSegment {
+	for _, seg := range c.b.segments {
+		if seg.seq == c.seq {
+			return seg
+		}
+		if seg.seq > c.seq {
+			c.seq, c.off = seg.seq, 0
+			return seg
+		}
+	}
+	return nil
+}
+
+// read reads the record at the current position and advances the cursor.
+// The caller must hold c.b.m.
+func (c *retentionCursor) read(seg *retentionSegment) (data.Map, error) {
+	var l [4]byte
+	if _, err := seg.f.ReadAt(l[:], c.off); err != nil {
+		return nil, fmt.Errorf("cannot read a retention buffer: %v", err)
+	}
+	buf := make([]byte, binary.BigEndian.Uint32(l[:]))
+	if _, err := seg.f.ReadAt(buf, c.off+4); err != nil {
+		return nil, fmt.Errorf("cannot read a retention buffer: %v", err)
+	}
+	c.off += int64(4 + len(buf))
+	v, err := data.DecodeMsgpack(buf)
+	if err != nil {
+		return nil, err
+	}
+	return data.AsMap(v)
+}
+
+// close stops the cursor. next returns io.EOF after this method is called.
+func (c *retentionCursor) close() {
+	c.b.m.Lock()
+	defer c.b.m.Unlock()
+	c.closed = true
+	c.b.cond.Broadcast()
+}
+
+// retentionSink writes tuples to a retention buffer.
+type retentionSink struct {
+	b *retentionBuffer
+}
+
+func (s *retentionSink) Write(ctx *core.Context, t *core.Tuple) error {
+	return s.b.append(t, ctx.Clock().Now())
+}
+
+func (s *retentionSink) Close(ctx *core.Context) error {
+	return s.b.close()
+}
+
+// replaySource emits tuples in a retention buffer and then live tuples as
+// they're written to the buffer.
+type replaySource struct {
+	c *retentionCursor
+}
+
+func (s *replaySource) GenerateStream(ctx *core.Context, w core.Writer) error {
+	for {
+		t, err := s.c.next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := w.Write(ctx, t); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *replaySource) Stop(ctx *core.Context) error {
+	s.c.close()
+	return nil
+}
@@ -0,0 +1,267 @@
+package bql
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/parser"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+func TestExtractRetention(t *testing.T) {
+	Convey("Given parameters having retention", t, func() {
+		params := data.Map{
+			"retention":          data.String("10m"),
+			"retention_max_size": data.Int(1024),
+			"num":                data.Int(1),
+		}
+
+		Convey("When extracting retention", func() {
+			c, rest, err := extractRetention(params)
+			So(err, ShouldBeNil)
+
+			Convey("Then it should return the config", func() {
+				So(c.retention, ShouldEqual, 10*time.Minute)
+				So(c.maxSize, ShouldEqual, 1024)
+			})
+
+			Convey("Then the rest of parameters should only have num", func() {
+				So(rest, ShouldResemble, data.Map{"num": data.Int(1)})
+				So(params, ShouldContainKey, "retention")
+			})
+		})
+	})
+
+	Convey("Given parameters not having retention", t, func() {
+		params := data.Map{"num": data.Int(1)}
+
+		Convey("When extracting retention", func() {
+			c, rest, err := extractRetention(params)
+			So(err, ShouldBeNil)
+
+			Convey("Then it should return a nil config", func() {
+				So(c, ShouldBeNil)
+				So(rest, ShouldResemble, params)
+			})
+		})
+	})
+
+	Convey("Given invalid parameters", t, func() {
+		for _, params := range []data.Map{
+			{"retention": data.String("hoge")},
+			{"retention": data.String("-1s")},
+			{"retention": data.String("1m"), "retention_max_size": data.String("a")},
+			{"retention": data.String("1m"), "retention_max_size": data.Int(0)},
+			{"retention_max_size": data.Int(1024)},
+		} {
+			Convey("When extracting retention from "+params.String(), func() {
+				_, _, err := extractRetention(params)
+
+				Convey("Then it should fail", func() {
+					So(err, ShouldNotBeNil)
+				})
+			})
+		}
+	})
+}
+
+func TestRetentionBuffer(t *testing.T) {
+	Convey("Given a retention buffer having small segments", t, func() {
+		segSize := retentionSegmentSize
+		retentionSegmentSize = 1
+		Reset(func() {
+			retentionSegmentSize = segSize
+		})
+		b, err := newRetentionBuffer(&retentionConfig{
+			retention: 10 * time.Second,
+			maxSize:   defaultRetentionMaxSize,
+		})
+		So(err, ShouldBeNil)
+		Reset(func() {
+			b.close()
+		})
+
+		now := time.Date(2015, time.April, 10, 10, 23, 0, 0, time.UTC)
+		tuples := mkTuples(4)
+		for i, t := range tuples {
+			So(b.append(t, now.Add(time.Duration(i)*time.Second)), ShouldBeNil)
+		}
+
+		Convey("When reading it with a cursor", func() {
+			c := b.cursor(now.Add(2 * time.Second))
+			Reset(c.close)
+
+			Convey("Then it should return tuples written since the given time", func() {
+				for _, i := range []int{2, 3} {
+					t, err := c.next()
+					So(err, ShouldBeNil)
+					So(t.Data, ShouldResemble, tuples[i].Data)
+					So(t.Timestamp, ShouldResemble, tuples[i].Timestamp)
+					So(t.Flags.IsSet(core.TFBackfill), ShouldBeTrue)
+				}
+			})
+
+			Convey("Then it should return a tuple written after the cursor is created", func() {
+				for i := 0; i < 2; i++ {
+					_, err := c.next()
+					So(err, ShouldBeNil)
+				}
+				go b.append(mkTuples(5)[4], now.Add(4*time.Second))
+				t, err := c.next()
+				So(err, ShouldBeNil)
+				So(t.Data, ShouldResemble, data.Map{"int": data.Int(5)})
+				So(t.Flags.IsSet(core.TFBackfill), ShouldBeFalse)
+			})
+
+			Convey("Then it should return EOF after it's closed", func() {
+				for i := 0; i < 2; i++ {
+					_, err := c.next()
+					So(err, ShouldBeNil)
+				}
+				go c.close()
+				_, err := c.next()
+				So(err, ShouldEqual, io.EOF)
+			})
+		})
+
+		Convey("When writing a tuple after the retention period", func() {
+			So(b.append(tuples[0], now.Add(12*time.Second+500*time.Millisecond)), ShouldBeNil)
+
+			Convey("Then expired segments should be removed", func() {
+				So(len(b.segments), ShouldEqual, 2)
+			})
+
+			Convey("Then a cursor should skip expired tuples", func() {
+				c := b.cursor(time.Time{})
+				Reset(c.close)
+				t, err := c.next()
+				So(err, ShouldBeNil)
+				So(t.Data, ShouldResemble, tuples[3].Data)
+			})
+		})
+
+		Convey("When the buffer exceeds its size limit", func() {
+			b.maxSize = b.size / 2
+			So(b.append(tuples[0], now.Add(4*time.Second)), ShouldBeNil)
+
+			Convey("Then the oldest segments should be removed", func() {
+				So(b.size, ShouldBeLessThanOrEqualTo, b.maxSize)
+				So(len(b.segments), ShouldEqual, 2)
+			})
+		})
+
+		Convey("When closing the buffer", func() {
+			c := b.cursor(time.Time{})
+			So(b.close(), ShouldBeNil)
+
+			Convey("Then a cursor should return EOF", func() {
+				_, err := c.next()
+				So(err, ShouldEqual, io.EOF)
+			})
+
+			Convey("Then a tuple cannot be written", func() {
+				So(b.append(tuples[0], now), ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+func TestSelectStartingStmt(t *testing.T) {
+	Convey("Given a BQL TopologyBuilder with a source having a retention buffer", t, func() {
+		dt := newTestTopology()
+		Reset(func() {
+			dt.Stop()
+		})
+		tb, err := NewTopologyBuilder(dt)
+		So(err, ShouldBeNil)
+		So(addBQLToTopology(tb, `CREATE PAUSED SOURCE s TYPE dummy WITH num=4, retention="10m";`), ShouldBeNil)
+		b, err := tb.retentionBufferOf("s")
+		So(err, ShouldBeNil)
+
+		So(addBQLToTopology(tb, `RESUME SOURCE s;`), ShouldBeNil)
+		c := b.cursor(time.Time{})
+		for i := 0; i < 4; i++ {
+			_, err := c.next()
+			So(err, ShouldBeNil)
+		}
+		c.close()
+
+		Convey("When issuing a SELECT ... STARTING ... AGO stmt", func() {
+			bp := parser.New()
+			istmt, _, err := bp.ParseStmt(`SELECT RSTREAM * FROM s [RANGE 1 TUPLES] STARTING 1 MINUTE AGO;`)
+			So(err, ShouldBeNil)
+			stmt := istmt.(parser.SelectStartingStmt)
+			sn, ch, err := tb.AddSelectStartingStmt(&stmt)
+			So(err, ShouldBeNil)
+			Reset(func() {
+				sn.Stop()
+			})
+
+			Convey("Then the chan should receive tuples in the buffer and then live tuples", func() {
+				for i := 0; i < 4; i++ {
+					t := <-ch
+					So(t.Data["int"], ShouldEqual, data.Int(i+1))
+					So(t.Flags.IsSet(core.TFBackfill), ShouldBeTrue)
+				}
+
+				So(addBQLToTopology(tb, `REWIND SOURCE s;`), ShouldBeNil)
+				for i := 0; i < 4; i++ {
+					t := <-ch
+					So(t.Data["int"], ShouldEqual, data.Int(i+1))
+					So(t.Flags.IsSet(core.TFBackfill), ShouldBeFalse)
+				}
+			})
+		})
+
+		Convey("When creating a stream having a retention buffer", func() {
+			err := addBQLToTopology(tb, `CREATE STREAM st AS SELECT RSTREAM * FROM s [RANGE 1 TUPLES] WITH retention="1m";`)
+			So(err, ShouldBeNil)
+
+			Convey("Then the stream should have the buffer", func() {
+				_, err := tb.retentionBufferOf("st")
+				So(err, ShouldBeNil)
+			})
+
+			Convey("Then the buffer should be removed with the stream", func() {
+				So(addBQLToTopology(tb, `DROP STREAM st;`), ShouldBeNil)
+				waitForExpectedCondition(func() bool {
+					_, err := tb.retentionBufferOf("st")
+					return err != nil
+				})
+				_, err := tb.retentionBufferOf("st")
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When creating a stream having an unsupported parameter", func() {
+			err := addBQLToTopology(tb, `CREATE STREAM st AS SELECT RSTREAM * FROM s [RANGE 1 TUPLES] WITH hoge=1;`)
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+				_, err := dt.Box("st")
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When issuing a SELECT ... STARTING ... AGO stmt for a stream not having a buffer", func() {
+			So(addBQLToTopology(tb, `CREATE STREAM st AS SELECT RSTREAM * FROM s [RANGE 1 TUPLES];`), ShouldBeNil)
+			numNodes := len(dt.Nodes())
+			bp := parser.New()
+			istmt, _, err := bp.ParseStmt(`SELECT RSTREAM * FROM st [RANGE 1 TUPLES] STARTING 1 MINUTE AGO;`)
+			So(err, ShouldBeNil)
+			stmt := istmt.(parser.SelectStartingStmt)
+			_, _, err = tb.AddSelectStartingStmt(&stmt)
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+				waitForExpectedCondition(func() bool {
+					return len(dt.Nodes()) == numNodes
+				})
+				So(len(dt.Nodes()), ShouldEqual, numNodes)
+			})
+		})
+	})
+}
@@ -0,0 +1,321 @@
+package bql
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
+
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// Serializer encodes tuples written to a sink in an output format.
+type Serializer interface {
+	// Serialize encodes a tuple into a record. A record must be independent
+	// of others, so it includes a delimiter such as a newline when the format
+	// needs it. Serialize isn't called concurrently.
+	Serialize(m data.Map) ([]byte, error)
+}
+
+type serializerFunc func(m data.Map) ([]byte, error)
+
+func (f serializerFunc) Serialize(m data.Map) ([]byte, error) {
+	return f(m)
+}
+
+// SerializerFunc creates a Serializer from a function.
+func SerializerFunc(f func(m data.Map) ([]byte, error)) Serializer {
+	return serializerFunc(f)
+}
+
+// SerializerCreator creates Serializers of a format. A sink creates a new
+// Serializer for each destination, such as a file, because a Serializer can
+// have a state like a header which has already been written.
+type SerializerCreator interface {
+	// CreateSerializer creates a new Serializer from parameters of the sink.
+	// A creator only reads parameters it needs and ignores others.
+	CreateSerializer(ctx *core.Context, params data.Map) (Serializer, error)
+}
+
+type serializerCreatorFunc func(*core.Context, data.Map) (Serializer, error)
+
+func (f serializerCreatorFunc) CreateSerializer(ctx *core.Context, params data.Map) (Serializer, error) {
+	return f(ctx, params)
+}
+
+// SerializerCreatorFunc creates a SerializerCreator from a function.
+func SerializerCreatorFunc(f func(*core.Context, data.Map) (Serializer, error)) SerializerCreator {
+	return serializerCreatorFunc(f)
+}
+
+var (
+	globalSerializerCreatorsMutex sync.RWMutex
+	globalSerializerCreators      = map[string]SerializerCreator{}
+)
+
+// RegisterGlobalSerializerCreator adds a SerializerCreator of the format so
+// that all sinks supporting "format" parameter can write tuples in the format.
+// Call it from init functions.
+func RegisterGlobalSerializerCreator(format string, c SerializerCreator) error {
+	if err := core.ValidateSymbol(format); err != nil {
+		return fmt.Errorf("invalid name for format: %s", err.Error())
+	}
+
+	globalSerializerCreatorsMutex.Lock()
+	defer globalSerializerCreatorsMutex.Unlock()
+	f := strings.ToLower(format)
+	if _, ok := globalSerializerCreators[f]; ok {
+		return fmt.Errorf("format '%v' is already registered", format)
+	}
+	globalSerializerCreators[f] = c
+	return nil
+}
+
+// MustRegisterGlobalSerializerCreator is like RegisterGlobalSerializerCreator
+// but panics if an error occurred.
+func MustRegisterGlobalSerializerCreator(format string, c SerializerCreator) {
+	if err := RegisterGlobalSerializerCreator(format, c); err != nil {
+		panic(fmt.Errorf("bql.MustRegisterGlobalSerializerCreator: cannot register '%v': %v", format, err))
+	}
+}
+
+// LookupGlobalSerializerCreator returns the SerializerCreator of the format.
+// It returns core.NotExistError if the format isn't registered.
+func LookupGlobalSerializerCreator(format string) (SerializerCreator, error) {
+	globalSerializerCreatorsMutex.RLock()
+	defer globalSerializerCreatorsMutex.RUnlock()
+	if c, ok := globalSerializerCreators[strings.ToLower(format)]; ok {
+		return c, nil
+	}
+	return nil, core.NotExistError(fmt.Errorf("format '%v' is not registered", format))
+}
+
+// SerializerFactory creates Serializers of the format given by "format"
+// parameter of a sink. The default format is "jsonl". Parameters of the
+// format are validated when the factory is created, so a sink should create
+// it in its creator and call it for each destination.
+type SerializerFactory func() (Serializer, error)
+
+// NewSerializerFactory creates a SerializerFactory from parameters of a sink.
+func NewSerializerFactory(ctx *core.Context, params data.Map) (SerializerFactory, error) {
+	format := "jsonl"
+	if v, ok := params["format"]; ok {
+		f, err := data.AsString(v)
+		if err != nil {
+			return nil, fmt.Errorf("'format' parameter must be a string: %v", err)
+		}
+		format = f
+	}
+	c, err := LookupGlobalSerializerCreator(format)
+	if err != nil {
+		return nil, fmt.Errorf("'format' parameter has an unsupported format: %v", format)
+	}
+	if _, err := c.CreateSerializer(ctx, params); err != nil {
+		return nil, err
+	}
+	return func() (Serializer, error) {
+		return c.CreateSerializer(ctx, params)
+	}, nil
+}
+
+func createJSONLSerializer(ctx *core.Context, params data.Map) (Serializer, error) {
+	return SerializerFunc(func(m data.Map) ([]byte, error) {
+		return []byte(m.String() + "\n"), nil
+	}), nil
+}
+
+// csvSerializer writes each tuple as a row of CSV. Columns are given by
+// "columns" parameter, which is an array of JSON Paths. When it's omitted,
+// sorted names of fields of the first tuple are used. A header row having
+// names of columns is written before the first row unless "header" is false.
+// "delimiter" changes the delimiter, which is a comma by default.
+//
+// A string is written as it is. A null or a missing field is written as an
+// empty string. An array and a map are written in JSON.
+type csvSerializer struct {
+	columns []string
+
+	// paths has compiled columns. It's nil when columns are names of fields
+	// of the first tuple.
+	paths     []data.Path
+	header    bool
+	delimiter rune
+}
+
+func createCSVSerializer(ctx *core.Context, params data.Map) (Serializer, error) {
+	v := &struct {
+		Columns   []string
+		Header    *bool
+		Delimiter string
+	}{}
+	if err := data.NewDecoder(nil).Decode(params, v); err != nil {
+		return nil, err
+	}
+
+	s := &csvSerializer{
+		header:    true,
+		delimiter: ',',
+	}
+	if v.Header != nil {
+		s.header = *v.Header
+	}
+	if v.Delimiter != "" {
+		rs := []rune(v.Delimiter)
+		if len(rs) != 1 || rs[0] == '"' || rs[0] == '\r' || rs[0] == '\n' {
+			return nil, fmt.Errorf("'delimiter' parameter must be a character other than a quote or a newline: %v", v.Delimiter)
+		}
+		s.delimiter = rs[0]
+	}
+	for _, c := range v.Columns {
+		p, err := data.CompilePath(c)
+		if err != nil {
+			return nil, fmt.Errorf("'columns' parameter has an invalid path '%v': %v", c, err)
+		}
+		s.columns = append(s.columns, c)
+		s.paths = append(s.paths, p)
+	}
+	return s, nil
+}
+
+func (s *csvSerializer) Serialize(m data.Map) ([]byte, error) {
+	if s.columns == nil {
+		s.columns = make([]string, 0, len(m))
+		for k := range m {
+			s.columns = append(s.columns, k)
+		}
+		sort.Strings(s.columns)
+	}
+
+	b := bytes.NewBuffer(nil)
+	w := csv.NewWriter(b)
+	w.Comma = s.delimiter
+	if s.header {
+		if err := w.Write(s.columns); err != nil {
+			return nil, err
+		}
+		s.header = false
+	}
+	row := make([]string, len(s.columns))
+	for i, c := range s.columns {
+		var v data.Value
+		if s.paths == nil {
+			v = m[c]
+		} else if x, err := m.Get(s.paths[i]); err == nil {
+			v = x
+		}
+		if v == nil {
+			continue
+		}
+		var err error
+		if row[i], err = data.ToString(v); err != nil {
+			return nil, err
+		}
+	}
+	if err := w.Write(row); err != nil {
+		return nil, err
+	}
+	w.Flush()
+	return b.Bytes(), w.Error()
+}
+
+// createTemplateSerializer creates a serializer rendering each tuple with
+// text/template given by "template" parameter, e.g.
+// template="{{.id}}: {{.temperature}}". The record is followed by a newline.
+// See NewTupleTemplate for functions available in the template.
+func createTemplateSerializer(ctx *core.Context, params data.Map) (Serializer, error) {
+	v := &struct {
+		Template string `bql:",required"`
+	}{}
+	if err := data.NewDecoder(nil).Decode(params, v); err != nil {
+		return nil, err
+	}
+	t, err := NewTupleTemplate("format", v.Template)
+	if err != nil {
+		return nil, err
+	}
+	return SerializerFunc(func(m data.Map) ([]byte, error) {
+		b := bytes.NewBuffer(nil)
+		if err := t.Execute(b, TemplateValue(m)); err != nil {
+			return nil, err
+		}
+		b.WriteByte('\n')
+		return b.Bytes(), nil
+	}), nil
+}
+
+// NewTupleTemplate parses a text/template rendering tuples converted by
+// TemplateValue. The template can use "json" function, which encodes a value
+// in JSON, e.g. {{json .}} renders the whole tuple.
+func NewTupleTemplate(name, str string) (*template.Template, error) {
+	t, err := template.New(name).Funcs(template.FuncMap{
+		"json": func(v interface{}) (string, error) {
+			b, err := json.Marshal(v)
+			return string(b), err
+		},
+	}).Parse(str)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %v template: %v", name, err)
+	}
+	return t, nil
+}
+
+// TemplateValue converts a value so that it's printed in a template as it
+// is. For example, data.String is printed without quotes.
+func TemplateValue(v data.Value) interface{} {
+	switch v.Type() {
+	case data.TypeBool:
+		b, _ := data.AsBool(v)
+		return b
+	case data.TypeInt:
+		i, _ := data.AsInt(v)
+		return i
+	case data.TypeFloat:
+		f, _ := data.AsFloat(v)
+		return f
+	case data.TypeString:
+		str, _ := data.AsString(v)
+		return str
+	case data.TypeTimestamp:
+		t, _ := data.AsTimestamp(v)
+		return t
+	case data.TypeArray:
+		a, _ := data.AsArray(v)
+		res := make([]interface{}, len(a))
+		for i, e := range a {
+			res[i] = TemplateValue(e)
+		}
+		return res
+	case data.TypeMap:
+		m, _ := data.AsMap(v)
+		res := make(map[string]interface{}, len(m))
+		for k, e := range m {
+			res[k] = TemplateValue(e)
+		}
+		return res
+	default:
+		return v
+	}
+}
+
+func init() {
+	MustRegisterGlobalSerializerCreator("jsonl", SerializerCreatorFunc(createJSONLSerializer))
+	MustRegisterGlobalSerializerCreator("csv", SerializerCreatorFunc(createCSVSerializer))
+	MustRegisterGlobalSerializerCreator("template", SerializerCreatorFunc(createTemplateSerializer))
+	// Each tuple is written as an independent value so that the file can be
+	// read by the file source with the same format.
+	MustRegisterGlobalSerializerCreator("msgpack", SerializerCreatorFunc(func(ctx *core.Context, params data.Map) (Serializer, error) {
+		return SerializerFunc(func(m data.Map) ([]byte, error) {
+			return data.EncodeMsgpack(m)
+		}), nil
+	}))
+	MustRegisterGlobalSerializerCreator("cbor", SerializerCreatorFunc(func(ctx *core.Context, params data.Map) (Serializer, error) {
+		return SerializerFunc(func(m data.Map) ([]byte, error) {
+			return data.EncodeCBOR(m)
+		}), nil
+	}))
+}
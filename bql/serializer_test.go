@@ -0,0 +1,163 @@
+package bql
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+func TestSerializers(t *testing.T) {
+	Convey("Given tuples to be serialized", t, func() {
+		ctx := core.NewContext(nil)
+		tuples := []data.Map{
+			{"id": data.Int(1), "name": data.String("a,b"), "tags": data.Array{data.String("x")}},
+			{"id": data.Int(2), "extra": data.True},
+		}
+		serialize := func(params data.Map) string {
+			f, err := NewSerializerFactory(ctx, params)
+			So(err, ShouldBeNil)
+			s, err := f()
+			So(err, ShouldBeNil)
+			res := ""
+			for _, m := range tuples {
+				b, err := s.Serialize(m)
+				So(err, ShouldBeNil)
+				res += string(b)
+			}
+			return res
+		}
+
+		Convey("When no format is given", func() {
+			res := serialize(data.Map{})
+
+			Convey("Then tuples should be written in JSON Lines", func() {
+				So(res, ShouldEqual, `{"id":1,"name":"a,b","tags":["x"]}`+"\n"+`{"extra":true,"id":2}`+"\n")
+			})
+		})
+
+		Convey("When writing CSV without columns", func() {
+			res := serialize(data.Map{"format": data.String("csv")})
+
+			Convey("Then columns should be fields of the first tuple", func() {
+				So(res, ShouldEqual, "id,name,tags\n1,\"a,b\",\"[\"\"x\"\"]\"\n2,,\n")
+			})
+		})
+
+		Convey("When writing CSV with columns", func() {
+			res := serialize(data.Map{
+				"format":    data.String("CSV"),
+				"columns":   data.Array{data.String("id"), data.String("tags[0]"), data.String("extra")},
+				"header":    data.False,
+				"delimiter": data.String("\t"),
+			})
+
+			Convey("Then values should be written in the order of columns", func() {
+				So(res, ShouldEqual, "1\tx\t\n2\t\ttrue\n")
+			})
+		})
+
+		Convey("When writing with a template", func() {
+			res := serialize(data.Map{
+				"format":   data.String("template"),
+				"template": data.String("{{.id}}: {{json .name}}"),
+			})
+
+			Convey("Then each tuple should be rendered", func() {
+				So(res, ShouldEqual, "1: \"a,b\"\n2: null\n")
+			})
+		})
+
+		Convey("When writing msgpack", func() {
+			f, err := NewSerializerFactory(ctx, data.Map{"format": data.String("msgpack")})
+			So(err, ShouldBeNil)
+			s, err := f()
+			So(err, ShouldBeNil)
+			b, err := s.Serialize(tuples[0])
+			So(err, ShouldBeNil)
+
+			Convey("Then it should be decoded to the same tuple", func() {
+				v, err := data.DecodeMsgpack(b)
+				So(err, ShouldBeNil)
+				So(v, ShouldResemble, tuples[0])
+			})
+		})
+	})
+
+	Convey("Given invalid parameters of serializers", t, func() {
+		ctx := core.NewContext(nil)
+		cases := map[string]data.Map{
+			"unknown format":     {"format": data.String("xml")},
+			"non-string format":  {"format": data.Int(1)},
+			"invalid columns":    {"format": data.String("csv"), "columns": data.Array{data.String("a[")}},
+			"invalid delimiter":  {"format": data.String("csv"), "delimiter": data.String("ab")},
+			"missing template":   {"format": data.String("template")},
+			"malformed template": {"format": data.String("template"), "template": data.String("{{.id")},
+		}
+		for name, params := range cases {
+			params := params
+			Convey("When creating a serializer with "+name, func() {
+				_, err := NewSerializerFactory(ctx, params)
+
+				Convey("Then it should fail", func() {
+					So(err, ShouldNotBeNil)
+				})
+			})
+		}
+	})
+
+	Convey("Given a registered format", t, func() {
+		Convey("When registering the same format again", func() {
+			err := RegisterGlobalSerializerCreator("JSONL", SerializerCreatorFunc(createJSONLSerializer))
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When looking up an unknown format", func() {
+			_, err := LookupGlobalSerializerCreator("no_such_format")
+
+			Convey("Then it should return a not-exist error", func() {
+				So(core.IsNotExist(err), ShouldBeTrue)
+			})
+		})
+	})
+}
+
+func TestFileSinkCSV(t *testing.T) {
+	Convey("Given a file sink writing CSV to a template path", t, func() {
+		ctx := core.NewContext(nil)
+		dir, err := ioutil.TempDir("", "sbtest_bql_file_sink_csv")
+		So(err, ShouldBeNil)
+		Reset(func() {
+			os.RemoveAll(dir)
+		})
+
+		s, err := createFileSink(ctx, &IOParams{}, data.Map{
+			"path":    data.String(dir + "/{{.dev}}.csv"),
+			"format":  data.String("csv"),
+			"columns": data.Array{data.String("dev"), data.String("v")},
+		})
+		So(err, ShouldBeNil)
+
+		Convey("When writing tuples to different files", func() {
+			for i, dev := range []string{"a", "b", "a"} {
+				So(s.Write(ctx, core.NewTuple(data.Map{"dev": data.String(dev), "v": data.Int(i)})), ShouldBeNil)
+			}
+			So(s.Close(ctx), ShouldBeNil)
+
+			Convey("Then each file should have its own header", func() {
+				a, err := ioutil.ReadFile(dir + "/a.csv")
+				So(err, ShouldBeNil)
+				So(string(a), ShouldEqual, "dev,v\na,0\na,2\n")
+				b, err := ioutil.ReadFile(dir + "/b.csv")
+				So(err, ShouldBeNil)
+				So(string(b), ShouldEqual, "dev,v\nb,1\n")
+			})
+		})
+	})
+}
@@ -0,0 +1,252 @@
+package bql
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// SharedClientRegistry manages clients of external systems such as brokers
+// and HTTP endpoints so that sources, sinks, and loaders connecting to the
+// same endpoint share a client and its connection pool instead of opening
+// their own connections. Clients are reference counted and closed when no
+// node uses them.
+type SharedClientRegistry interface {
+	// Acquire returns the client having the key and increments its reference
+	// count. When the registry doesn't have the client, it's created by
+	// create. A key should have the kind of the client and the endpoint,
+	// e.g. "kafka:broker1:9092", so that clients of different systems don't
+	// collide. Each successful call must be paired with a call to Release.
+	Acquire(key string, create func() (io.Closer, error)) (io.Closer, error)
+
+	// Release decrements the reference count of the client having the key
+	// and closes it when the count reaches zero. It returns
+	// core.NotExistError when the registry doesn't have the client.
+	Release(key string) error
+
+	// List returns the reference count of each client. The caller can safely
+	// modify the map returned from this method.
+	List() (map[string]int, error)
+}
+
+type sharedClient struct {
+	c    io.Closer
+	refs int
+}
+
+type defaultSharedClientRegistry struct {
+	m       sync.Mutex
+	clients map[string]*sharedClient
+}
+
+// NewDefaultSharedClientRegistry returns a SharedClientRegistry having a
+// default implementation.
+func NewDefaultSharedClientRegistry() SharedClientRegistry {
+	return &defaultSharedClientRegistry{
+		clients: map[string]*sharedClient{},
+	}
+}
+
+func (r *defaultSharedClientRegistry) Acquire(key string, create func() (io.Closer, error)) (io.Closer, error) {
+	// The lock is held while creating a client so that concurrent calls
+	// having the same key don't create multiple clients.
+	r.m.Lock()
+	defer r.m.Unlock()
+	if s, ok := r.clients[key]; ok {
+		s.refs++
+		return s.c, nil
+	}
+
+	c, err := create()
+	if err != nil {
+		return nil, err
+	}
+	r.clients[key] = &sharedClient{
+		c:    c,
+		refs: 1,
+	}
+	return c, nil
+}
+
+func (r *defaultSharedClientRegistry) Release(key string) error {
+	r.m.Lock()
+	defer r.m.Unlock()
+	s, ok := r.clients[key]
+	if !ok {
+		return core.NotExistError(fmt.Errorf("shared client '%v' is not registered", key))
+	}
+	s.refs--
+	if s.refs > 0 {
+		return nil
+	}
+	delete(r.clients, key)
+	return s.c.Close()
+}
+
+func (r *defaultSharedClientRegistry) List() (map[string]int, error) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	m := make(map[string]int, len(r.clients))
+	for k, s := range r.clients {
+		m[k] = s.refs
+	}
+	return m, nil
+}
+
+var (
+	globalSharedClientRegistry = NewDefaultSharedClientRegistry()
+)
+
+// AcquireGlobalSharedClient acquires a client from the registry shared by all
+// topologies. Because limits of connections are usually imposed on a
+// process or a host, clients are shared across topologies.
+func AcquireGlobalSharedClient(key string, create func() (io.Closer, error)) (io.Closer, error) {
+	return globalSharedClientRegistry.Acquire(key, create)
+}
+
+// ReleaseGlobalSharedClient releases a client acquired by
+// AcquireGlobalSharedClient.
+func ReleaseGlobalSharedClient(key string) error {
+	return globalSharedClientRegistry.Release(key)
+}
+
+// ListGlobalSharedClients returns the reference count of each client in the
+// registry shared by all topologies.
+func ListGlobalSharedClients() (map[string]int, error) {
+	return globalSharedClientRegistry.List()
+}
+
+// SharedHTTPClient is an http.Client whose connection pool is shared by all
+// nodes sending requests to the same endpoint.
+type SharedHTTPClient struct {
+	*http.Client
+	release   func() error
+	closeOnce sync.Once
+}
+
+// Close releases the connection pool of the client. The client cannot be
+// used after it's closed.
+func (c *SharedHTTPClient) Close() error {
+	var err error
+	c.closeOnce.Do(func() {
+		err = c.release()
+	})
+	return err
+}
+
+type sharedTransport struct {
+	*http.Transport
+}
+
+func (t *sharedTransport) Close() error {
+	t.CloseIdleConnections()
+	return nil
+}
+
+// NewSharedHTTPClient creates an HTTP client sending requests to the endpoint,
+// i.e. the scheme and the host, of rawURL. The connection pool of the client
+// is shared with other clients created for the same endpoint. The pool is
+// configured by following optional parameters in params:
+//
+//	- shared_client: false makes the client have its own pool (true by default)
+//	- max_idle_conns_per_host: the number of idle connections kept in the
+//	  pool (16 by default)
+//	- max_conns_per_host: the maximum number of connections to the endpoint,
+//	  0 means no limit (0 by default)
+//	- idle_conn_timeout: the duration after which an idle connection is
+//	  closed (90 seconds by default)
+//
+// Requests are sent through a proxy with TLS parameters given by the global
+// OutboundConfig and parameters described in NewOutboundConfig. Only nodes
+// having the same OutboundConfig share a pool.
+//
+// Parameters of a shared pool are given by the node which created it first.
+// Other parameters in params are ignored. timeout is the timeout of each
+// request and isn't shared. The client must be closed by Close when the node
+// using it is closed.
+func NewSharedHTTPClient(rawURL string, timeout time.Duration, params data.Map) (*SharedHTTPClient, error) {
+	v := &struct {
+		SharedClient        bool
+		MaxIdleConnsPerHost int
+		MaxConnsPerHost     int
+		IdleConnTimeout     time.Duration
+	}{
+		SharedClient:        true,
+		MaxIdleConnsPerHost: 16,
+		IdleConnTimeout:     90 * time.Second,
+	}
+	if err := data.NewDecoder(nil).Decode(params, v); err != nil {
+		return nil, err
+	}
+	if v.MaxIdleConnsPerHost < 0 {
+		return nil, fmt.Errorf("'max_idle_conns_per_host' parameter must not be negative: %v", v.MaxIdleConnsPerHost)
+	}
+	if v.MaxConnsPerHost < 0 {
+		return nil, fmt.Errorf("'max_conns_per_host' parameter must not be negative: %v", v.MaxConnsPerHost)
+	}
+	if v.IdleConnTimeout <= 0 {
+		return nil, fmt.Errorf("'idle_conn_timeout' parameter must be greater than 0: %v", v.IdleConnTimeout)
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	outbound, err := NewOutboundConfig(params)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig, err := outbound.TLSConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	newTransport := func() (io.Closer, error) {
+		return &sharedTransport{&http.Transport{
+			Proxy: outbound.HTTPProxy,
+			DialContext: (&net.Dialer{
+				Timeout:   30 * time.Second,
+				KeepAlive: 30 * time.Second,
+			}).DialContext,
+			TLSClientConfig:     tlsConfig,
+			TLSHandshakeTimeout: 10 * time.Second,
+			ForceAttemptHTTP2:   true,
+			MaxIdleConnsPerHost: v.MaxIdleConnsPerHost,
+			MaxConnsPerHost:     v.MaxConnsPerHost,
+			IdleConnTimeout:     v.IdleConnTimeout,
+		}}, nil
+	}
+
+	var (
+		t       io.Closer
+		release func() error
+	)
+	if v.SharedClient {
+		key := fmt.Sprintf("http:%v://%v", u.Scheme, u.Host)
+		if !outbound.isZero() {
+			key += " " + outbound.String()
+		}
+		if t, err = AcquireGlobalSharedClient(key, newTransport); err != nil {
+			return nil, err
+		}
+		release = func() error {
+			return ReleaseGlobalSharedClient(key)
+		}
+	} else {
+		t, _ = newTransport()
+		release = t.Close
+	}
+	return &SharedHTTPClient{
+		Client: &http.Client{
+			Transport: t.(*sharedTransport).Transport,
+			Timeout:   timeout,
+		},
+		release: release,
+	}, nil
+}
@@ -0,0 +1,160 @@
+package bql
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+type countingCloser struct {
+	closed int
+}
+
+func (c *countingCloser) Close() error {
+	c.closed++
+	return nil
+}
+
+func TestDefaultSharedClientRegistry(t *testing.T) {
+	Convey("Given a default shared client registry", t, func() {
+		r := NewDefaultSharedClientRegistry()
+		created := 0
+		create := func() (io.Closer, error) {
+			created++
+			return &countingCloser{}, nil
+		}
+
+		Convey("When acquiring a client twice with the same key", func() {
+			c1, err := r.Acquire("test:host1", create)
+			So(err, ShouldBeNil)
+			c2, err := r.Acquire("test:host1", create)
+			So(err, ShouldBeNil)
+
+			Convey("Then the client should be created only once", func() {
+				So(created, ShouldEqual, 1)
+				So(c1, ShouldEqual, c2)
+			})
+
+			Convey("Then the registry should have its reference count", func() {
+				m, err := r.List()
+				So(err, ShouldBeNil)
+				So(m, ShouldResemble, map[string]int{"test:host1": 2})
+			})
+
+			Convey("Then the client should be closed when all references are released", func() {
+				So(r.Release("test:host1"), ShouldBeNil)
+				So(c1.(*countingCloser).closed, ShouldEqual, 0)
+				So(r.Release("test:host1"), ShouldBeNil)
+				So(c1.(*countingCloser).closed, ShouldEqual, 1)
+
+				Convey("And releasing it again should fail", func() {
+					So(core.IsNotExist(r.Release("test:host1")), ShouldBeTrue)
+				})
+
+				Convey("And acquiring it again should create a new client", func() {
+					c3, err := r.Acquire("test:host1", create)
+					So(err, ShouldBeNil)
+					So(c3, ShouldNotEqual, c1)
+					So(created, ShouldEqual, 2)
+				})
+			})
+		})
+
+		Convey("When acquiring clients with different keys", func() {
+			c1, err := r.Acquire("test:host1", create)
+			So(err, ShouldBeNil)
+			c2, err := r.Acquire("test:host2", create)
+			So(err, ShouldBeNil)
+
+			Convey("Then they should be different clients", func() {
+				So(created, ShouldEqual, 2)
+				So(c1, ShouldNotEqual, c2)
+			})
+		})
+
+		Convey("When creating a client fails", func() {
+			_, err := r.Acquire("test:host1", func() (io.Closer, error) {
+				return nil, errors.New("cannot connect")
+			})
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+
+			Convey("Then the registry shouldn't have the client", func() {
+				m, err := r.List()
+				So(err, ShouldBeNil)
+				So(m, ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestSharedHTTPClient(t *testing.T) {
+	Convey("Given shared HTTP clients", t, func() {
+		c1, err := NewSharedHTTPClient("http://shared.example.com/a", 0, data.Map{})
+		So(err, ShouldBeNil)
+		c2, err := NewSharedHTTPClient("http://shared.example.com/b", 0, data.Map{})
+		So(err, ShouldBeNil)
+		c3, err := NewSharedHTTPClient("http://other.example.com/a", 0, data.Map{})
+		So(err, ShouldBeNil)
+		c4, err := NewSharedHTTPClient("http://shared.example.com/a", 0, data.Map{
+			"shared_client": data.False,
+		})
+		So(err, ShouldBeNil)
+		Reset(func() {
+			for _, c := range []*SharedHTTPClient{c1, c2, c3, c4} {
+				c.Close()
+			}
+		})
+
+		Convey("Then clients of the same host should share the transport", func() {
+			So(c1.Transport, ShouldEqual, c2.Transport)
+			So(c1.Transport, ShouldNotEqual, c3.Transport)
+		})
+
+		Convey("Then a client having shared_client=false shouldn't share the transport", func() {
+			So(c1.Transport, ShouldNotEqual, c4.Transport)
+		})
+
+		Convey("Then the global registry should have the reference counts", func() {
+			m, err := ListGlobalSharedClients()
+			So(err, ShouldBeNil)
+			So(m["http:http://shared.example.com"], ShouldEqual, 2)
+			So(m["http:http://other.example.com"], ShouldEqual, 1)
+		})
+
+		Convey("When closing all clients of a host", func() {
+			So(c1.Close(), ShouldBeNil)
+			So(c1.Close(), ShouldBeNil)
+			So(c2.Close(), ShouldBeNil)
+
+			Convey("Then the transport should be removed from the registry", func() {
+				m, err := ListGlobalSharedClients()
+				So(err, ShouldBeNil)
+				So(m, ShouldNotContainKey, "http:http://shared.example.com")
+			})
+		})
+	})
+
+	Convey("Given invalid parameters of a shared HTTP client", t, func() {
+		for _, params := range []data.Map{
+			{"max_idle_conns_per_host": data.Int(-1)},
+			{"max_conns_per_host": data.Int(-1)},
+			{"idle_conn_timeout": data.Int(0)},
+			{"shared_client": data.String("yes")},
+		} {
+			Convey("When creating a client with "+params.String(), func() {
+				_, err := NewSharedHTTPClient("http://shared.example.com/", 0, params)
+
+				Convey("Then it should fail", func() {
+					So(err, ShouldNotBeNil)
+				})
+			})
+		}
+	})
+}
@@ -0,0 +1,391 @@
+package bql
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"gopkg.in/sensorbee/sensorbee.v0/bql/execution"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/parser"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/udf"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// sharedFilterRelation is the relation name with which predicates of
+// a shared filter refer to fields of an input tuple.
+const sharedFilterRelation = "input"
+
+// SharedFilter is a predicate common to WHERE clauses of streams selecting
+// from the same input. A temporary box evaluating the predicate is inserted
+// between the input and the streams so that the predicate is evaluated once
+// per tuple instead of once per stream. The box is removed when all the
+// streams are dropped.
+type SharedFilter struct {
+	// Input is the name of the input of the streams.
+	Input string
+
+	// Predicate is the shared predicate. It refers to fields of an input
+	// tuple with "input" as the relation name.
+	Predicate string
+
+	// Streams are names of the streams sharing the filter.
+	Streams []string
+
+	conds []parser.Expression
+	keys  map[string]bool
+
+	// node is the name of the box of the filter. It's empty until the first
+	// stream sharing the filter is created.
+	node string
+}
+
+// filterCandidate is a CREATE STREAM statement whose predicates can be
+// evaluated by a shared filter.
+type filterCandidate struct {
+	stream string
+	input  string
+
+	// exprs are conjuncts of the WHERE clause and exprKeys are their
+	// canonical representations. A key is empty when the conjunct cannot be
+	// shared.
+	exprs    []parser.Expression
+	exprKeys []string
+
+	// keys are distinct keys of conjuncts in the order in which they're
+	// written. conds has the canonical expression of each key.
+	keys  []string
+	conds map[string]parser.Expression
+}
+
+// newFilterCandidate returns a filterCandidate of the statement. It returns
+// nil when pushing predicates of the statement down to a filter would change
+// its results. It's only allowed for RSTREAM statements selecting from one
+// stream with [RANGE 1 TUPLES] without aggregation or deduplication, where
+// a tuple not satisfying WHERE clause doesn't affect the output at all.
+// Volatile predicates, such as ones calling UDFs, aren't shared.
+func (tb *TopologyBuilder) newFilterCandidate(name string, stmt *parser.SelectStmt) *filterCandidate {
+	if len(stmt.Relations) != 1 || stmt.Filter == nil {
+		return nil
+	}
+	rel := stmt.Relations[0]
+	if rel.Type != parser.ActualStream || rel.Function != nil {
+		return nil
+	}
+	lp, err := execution.Analyze(*stmt, tb.Reg)
+	if err != nil {
+		return nil
+	}
+	if lp, err = lp.LogicalOptimize(); err != nil {
+		return nil
+	}
+	if !execution.CanBuildFilterPlan(lp, tb.Reg) || lp.DedupKey != nil {
+		return nil
+	}
+
+	alias := rel.Alias
+	if alias == "" {
+		alias = rel.Name
+	}
+	c := &filterCandidate{
+		stream: name,
+		input:  rel.Name,
+		exprs:  conjuncts(stmt.Filter),
+		conds:  map[string]parser.Expression{},
+	}
+	c.exprKeys = make([]string, len(c.exprs))
+	for i, e := range c.exprs {
+		for r := range e.ReferencedRelations() {
+			if r != "" && r != alias {
+				return nil
+			}
+		}
+		cond := e.RenameReferencedRelation("", sharedFilterRelation)
+		if alias != sharedFilterRelation {
+			cond = cond.RenameReferencedRelation(alias, sharedFilterRelation)
+		}
+		flatExpr, err := execution.ParserExprToFlatExpr(cond, tb.Reg)
+		if err != nil {
+			return nil
+		}
+		if flatExpr.Volatility() == execution.Volatile {
+			continue
+		}
+		key := flatExpr.Repr()
+		c.exprKeys[i] = key
+		if _, ok := c.conds[key]; ok {
+			continue
+		}
+		c.keys = append(c.keys, key)
+		c.conds[key] = cond
+	}
+	return c
+}
+
+// conjuncts splits an expression into terms combined by AND.
+func conjuncts(e parser.Expression) []parser.Expression {
+	if b, ok := e.(parser.BinaryOpAST); ok && b.Op == parser.And {
+		return append(conjuncts(b.Left), conjuncts(b.Right)...)
+	}
+	return []parser.Expression{e}
+}
+
+// OptimizeStmts finds predicates common to WHERE clauses of streams created
+// by the statements which select from the same input. Streams having such a
+// predicate share a filter evaluating it when they're created by AddStmt
+// afterwards. It does nothing and returns nil when shared filters are
+// disabled in the Context of the topology. See SharedFilter for details.
+//
+// A stream shares at most one filter. Streams selecting from the same input
+// are grouped greedily by the predicate shared by the most streams, and the
+// filter of each group has all predicates common to the group.
+func (tb *TopologyBuilder) OptimizeStmts(stmts []interface{}) []*SharedFilter {
+	if !tb.topology.Context().SharedFilters() {
+		return nil
+	}
+
+	var inputs []string
+	candidates := map[string][]*filterCandidate{}
+	for _, stmt := range stmts {
+		s, ok := stmt.(parser.CreateStreamAsSelectStmt)
+		if !ok {
+			continue
+		}
+		c := tb.newFilterCandidate(string(s.Name), &s.Select)
+		if c == nil || len(c.keys) == 0 {
+			continue
+		}
+		input := strings.ToLower(c.input)
+		if _, ok := candidates[input]; !ok {
+			inputs = append(inputs, input)
+		}
+		candidates[input] = append(candidates[input], c)
+	}
+
+	var filters []*SharedFilter
+	for _, input := range inputs {
+		filters = append(filters, groupFilterCandidates(candidates[input])...)
+	}
+
+	tb.filterMutex.Lock()
+	defer tb.filterMutex.Unlock()
+	if tb.sharedFilters == nil {
+		tb.sharedFilters = map[string]*SharedFilter{}
+	}
+	for _, f := range filters {
+		for _, s := range f.Streams {
+			tb.sharedFilters[strings.ToLower(s)] = f
+		}
+	}
+	return filters
+}
+
+// groupFilterCandidates groups candidates having the same input into
+// shared filters.
+func groupFilterCandidates(cs []*filterCandidate) []*SharedFilter {
+	var filters []*SharedFilter
+	for len(cs) >= 2 {
+		// find the predicate shared by the most candidates
+		var keys []string
+		counts := map[string]int{}
+		for _, c := range cs {
+			for _, k := range c.keys {
+				if counts[k] == 0 {
+					keys = append(keys, k)
+				}
+				counts[k]++
+			}
+		}
+		best := ""
+		for _, k := range keys {
+			if counts[k] > counts[best] {
+				best = k
+			}
+		}
+		if counts[best] < 2 {
+			break
+		}
+
+		var members, rest []*filterCandidate
+		for _, c := range cs {
+			if _, ok := c.conds[best]; ok {
+				members = append(members, c)
+			} else {
+				rest = append(rest, c)
+			}
+		}
+		cs = rest
+
+		f := &SharedFilter{
+			Input: members[0].input,
+			keys:  map[string]bool{},
+		}
+		var preds []string
+		for _, k := range members[0].keys {
+			common := true
+			for _, m := range members[1:] {
+				if _, ok := m.conds[k]; !ok {
+					common = false
+					break
+				}
+			}
+			if common {
+				f.conds = append(f.conds, members[0].conds[k])
+				f.keys[k] = true
+				preds = append(preds, members[0].conds[k].String())
+			}
+		}
+		f.Predicate = strings.Join(preds, " AND ")
+		for _, m := range members {
+			f.Streams = append(f.Streams, m.stream)
+		}
+		filters = append(filters, f)
+	}
+	return filters
+}
+
+// pushDownFilter returns the shared filter of the stream and the statement
+// without predicates evaluated by the filter. It returns nil and the
+// statement as is when the stream doesn't share a filter or the statement
+// no longer has the predicates of the filter.
+func (tb *TopologyBuilder) pushDownFilter(name string, stmt *parser.SelectStmt) (*SharedFilter, *parser.SelectStmt) {
+	tb.filterMutex.Lock()
+	f, ok := tb.sharedFilters[strings.ToLower(name)]
+	tb.filterMutex.Unlock()
+	if !ok {
+		return nil, stmt
+	}
+
+	c := tb.newFilterCandidate(name, stmt)
+	if c == nil || strings.ToLower(c.input) != strings.ToLower(f.Input) {
+		return nil, stmt
+	}
+	for k := range f.keys {
+		if _, ok := c.conds[k]; !ok {
+			return nil, stmt
+		}
+	}
+
+	var filter parser.Expression
+	for i, e := range c.exprs {
+		if f.keys[c.exprKeys[i]] {
+			continue
+		}
+		if filter == nil {
+			filter = e
+		} else {
+			filter = parser.BinaryOpAST{Op: parser.And, Left: filter, Right: e}
+		}
+	}
+	s := *stmt
+	s.Filter = filter
+	return f, &s
+}
+
+// sharedFilterNode returns the name of the box of the shared filter. It
+// creates the box when it doesn't exist or has been stopped. The second
+// return value is true when the box is newly created, in which case the
+// caller has to call StopOnDisconnect and RemoveOnStop of the box after
+// connecting a stream to it.
+func (tb *TopologyBuilder) sharedFilterNode(f *SharedFilter) (core.BoxNode, bool, error) {
+	tb.filterMutex.Lock()
+	defer tb.filterMutex.Unlock()
+	if f.node != "" {
+		if n, err := tb.topology.Box(f.node); err == nil && n.State().Get() < core.TSStopping {
+			return n, false, nil
+		}
+	}
+
+	box, err := newSharedFilterBox(f, tb.Reg)
+	if err != nil {
+		return nil, false, err
+	}
+	name := fmt.Sprintf("sensorbee_tmp_filter_%v", topologyBuilderNextTemporaryID())
+	bn, err := tb.topology.AddBox(name, box, nil)
+	if err != nil {
+		return nil, false, err
+	}
+	if err := bn.Input(f.Input, nil); err != nil {
+		tb.topology.Remove(name)
+		return nil, false, err
+	}
+	f.node = name
+	return bn, true, nil
+}
+
+// sharedFilterBox only emits tuples satisfying the predicate of a shared
+// filter. Tuples are emitted as they are.
+type sharedFilterBox struct {
+	input     string
+	predicate string
+	conds     []execution.Evaluator
+
+	numTuples int64
+	numPassed int64
+}
+
+func newSharedFilterBox(f *SharedFilter, reg udf.FunctionRegistry) (*sharedFilterBox, error) {
+	b := &sharedFilterBox{
+		input:     f.Input,
+		predicate: f.Predicate,
+	}
+	for _, cond := range f.conds {
+		flatExpr, err := execution.ParserExprToFlatExpr(cond, reg)
+		if err != nil {
+			return nil, err
+		}
+		eval, err := execution.ExpressionToEvaluator(flatExpr, reg)
+		if err != nil {
+			return nil, err
+		}
+		b.conds = append(b.conds, eval)
+	}
+	return b, nil
+}
+
+func (b *sharedFilterBox) Process(ctx *core.Context, t *core.Tuple, w core.Writer) error {
+	atomic.AddInt64(&b.numTuples, 1)
+
+	// nest the data so that access via JSON path works properly
+	row := data.Map{
+		sharedFilterRelation: t.Data,
+		fmt.Sprintf("%s:meta:%s", sharedFilterRelation, parser.TimestampMeta): data.Timestamp(t.Timestamp),
+		fmt.Sprintf("%s:meta:%s", sharedFilterRelation, parser.BackfillMeta):  data.Bool(t.Flags.IsSet(core.TFBackfill)),
+	}
+	if t.ID == "" {
+		row[fmt.Sprintf("%s:meta:%s", sharedFilterRelation, parser.IDMeta)] = data.Null{}
+	} else {
+		row[fmt.Sprintf("%s:meta:%s", sharedFilterRelation, parser.IDMeta)] = data.String(t.ID)
+	}
+
+	for _, cond := range b.conds {
+		v, err := cond.Eval(row)
+		if err != nil {
+			return err
+		}
+		// NULL is treated as false like WHERE clause
+		if v.Type() == data.TypeNull {
+			return nil
+		}
+		ok, err := data.AsBool(v)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return nil
+		}
+	}
+	atomic.AddInt64(&b.numPassed, 1)
+	return w.Write(ctx, t)
+}
+
+// Status returns the status of the filter. It has "input", "predicate",
+// "num_tuples", and "num_passed" fields.
+func (b *sharedFilterBox) Status() data.Map {
+	return data.Map{
+		"input":      data.String(b.input),
+		"predicate":  data.String(b.predicate),
+		"num_tuples": data.Int(atomic.LoadInt64(&b.numTuples)),
+		"num_passed": data.Int(atomic.LoadInt64(&b.numPassed)),
+	}
+}
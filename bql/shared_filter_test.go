@@ -0,0 +1,143 @@
+package bql
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/parser"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+func TestSharedFilters(t *testing.T) {
+	stmts, err := parser.New().ParseStmts(`
+		CREATE PAUSED SOURCE source TYPE dummy WITH num=6;
+		CREATE STREAM a AS SELECT RSTREAM int FROM source [RANGE 1 TUPLES]
+			WHERE int > 1 AND int % 2 = 0;
+		CREATE STREAM b AS SELECT RSTREAM * FROM source [RANGE 1 TUPLES] AS s
+			WHERE s:int < 4 AND s:int > 1;
+		CREATE STREAM c AS SELECT ISTREAM * FROM source [RANGE 1 TUPLES]
+			WHERE int > 1;
+		CREATE STREAM d AS SELECT RSTREAM * FROM source [RANGE 1 TUPLES]
+			WHERE int = 5;
+		CREATE SINK snk_a TYPE collector;
+		CREATE SINK snk_b TYPE collector;
+		INSERT INTO snk_a FROM a;
+		INSERT INTO snk_b FROM b;`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	filterNodes := func(dt core.Topology) []core.BoxNode {
+		var ns []core.BoxNode
+		for name, n := range dt.Boxes() {
+			if strings.HasPrefix(name, "sensorbee_tmp_filter_") {
+				ns = append(ns, n)
+			}
+		}
+		return ns
+	}
+
+	Convey("Given a topology with shared filters enabled", t, func() {
+		dt, err := core.NewDefaultTopology(core.NewContext(&core.ContextConfig{
+			SharedFilters: true,
+		}), "testTopology")
+		So(err, ShouldBeNil)
+		Reset(func() {
+			dt.Stop()
+		})
+		tb, err := NewTopologyBuilder(dt)
+		So(err, ShouldBeNil)
+
+		Convey("When optimizing statements having common predicates", func() {
+			fs := tb.OptimizeStmts(stmts)
+
+			Convey("Then streams selecting from the same input should share a filter", func() {
+				So(fs, ShouldHaveLength, 1)
+				So(fs[0].Input, ShouldEqual, "source")
+				So(fs[0].Streams, ShouldResemble, []string{"a", "b"})
+				So(fs[0].Predicate, ShouldEqual, "input:int > 1")
+			})
+
+			Convey("Then the streams should only emit tuples satisfying their WHERE clauses", func() {
+				for _, stmt := range stmts {
+					_, err := tb.AddStmt(stmt)
+					So(err, ShouldBeNil)
+				}
+				So(filterNodes(dt), ShouldHaveLength, 1)
+				So(addBQLToTopology(tb, `RESUME SOURCE source;`), ShouldBeNil)
+
+				sa, err := dt.Sink("snk_a")
+				So(err, ShouldBeNil)
+				ca := sa.Sink().(*tupleCollectorSink)
+				sb, err := dt.Sink("snk_b")
+				So(err, ShouldBeNil)
+				cb := sb.Sink().(*tupleCollectorSink)
+				ca.Wait(3)
+				cb.Wait(2)
+
+				So(ca.len(), ShouldEqual, 3)
+				So(ca.get(0).Data, ShouldResemble, data.Map{"int": data.Int(2)})
+				So(ca.get(2).Data, ShouldResemble, data.Map{"int": data.Int(6)})
+				So(cb.len(), ShouldEqual, 2)
+				So(cb.get(1).Data["int"], ShouldEqual, data.Int(3))
+
+				st := filterNodes(dt)[0].Status()["box"].(data.Map)
+				So(st["predicate"], ShouldEqual, data.String("input:int > 1"))
+				So(st["num_tuples"], ShouldEqual, data.Int(6))
+				So(st["num_passed"], ShouldEqual, data.Int(5))
+			})
+
+			Convey("Then the filter should be removed with the streams sharing it", func() {
+				for _, stmt := range stmts {
+					_, err := tb.AddStmt(stmt)
+					So(err, ShouldBeNil)
+				}
+				So(addBQLToTopology(tb, `DROP STREAM a;`), ShouldBeNil)
+				So(filterNodes(dt), ShouldHaveLength, 1)
+
+				So(addBQLToTopology(tb, `DROP STREAM b;`), ShouldBeNil)
+				waitForExpectedCondition(func() bool {
+					return len(filterNodes(dt)) == 0
+				})
+				So(filterNodes(dt), ShouldBeEmpty)
+
+				Convey("And it should be recreated with a stream sharing it", func() {
+					_, err := tb.AddStmt(stmts[2])
+					So(err, ShouldBeNil)
+					So(filterNodes(dt), ShouldHaveLength, 1)
+				})
+			})
+		})
+
+		Convey("When optimizing statements having a stream redefined differently", func() {
+			tb.OptimizeStmts(stmts)
+			So(addBQLToTopology(tb, `
+				CREATE PAUSED SOURCE source TYPE dummy WITH num=6;
+				CREATE STREAM a AS SELECT RSTREAM int FROM source [RANGE 1 TUPLES]
+					WHERE int % 2 = 0;`), ShouldBeNil)
+
+			Convey("Then the stream shouldn't use the filter", func() {
+				So(filterNodes(dt), ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given a topology with shared filters disabled", t, func() {
+		dt := newTestTopology()
+		Reset(func() {
+			dt.Stop()
+		})
+		tb, err := NewTopologyBuilder(dt)
+		So(err, ShouldBeNil)
+
+		Convey("When optimizing statements having common predicates", func() {
+			fs := tb.OptimizeStmts(stmts)
+
+			Convey("Then no filter should be shared", func() {
+				So(fs, ShouldBeEmpty)
+			})
+		})
+	})
+}
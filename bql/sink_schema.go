@@ -0,0 +1,263 @@
+package bql
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync/atomic"
+
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// sinkSchemaParams are parameters of CREATE SINK handled by the topology
+// builder instead of sink creators. They're removed from parameters passed
+// to a sink creator.
+var sinkSchemaParams = []string{"strict_fields", "schema", "on_schema_violation"}
+
+// schemaViolationAction is the action taken when a tuple doesn't conform to
+// the schema of a sink.
+type schemaViolationAction int
+
+const (
+	// svaDrop silently drops the tuple.
+	svaDrop schemaViolationAction = iota
+
+	// svaErrorOutput drops the tuple and reports it as a dropped tuple with
+	// the reason so that it can be read from the dropped tuple stream.
+	svaErrorOutput
+
+	// svaFail stops the sink.
+	svaFail
+)
+
+func parseSchemaViolationAction(s string) (schemaViolationAction, error) {
+	switch strings.ToLower(s) {
+	case "drop":
+		return svaDrop, nil
+	case "error_output":
+		return svaErrorOutput, nil
+	case "fail":
+		return svaFail, nil
+	default:
+		return 0, fmt.Errorf("unsupported action: %v", s)
+	}
+}
+
+// sinkSchema is the set of fields that tuples written to a sink must have.
+type sinkSchema struct {
+	// fields has names of fields and their types. A field having
+	// "any" type can have a value of any type.
+	fields map[string]string
+	action schemaViolationAction
+}
+
+// newSinkSchema extracts schema parameters from params. It returns nil
+// when params don't have any schema parameter. The returned map has
+// the rest of parameters.
+//
+// It accepts following parameters:
+//
+//	- strict_fields: an array of names of fields which tuples must have
+//	- schema: a map from names of fields to names of types such as "int" or
+//	  "string". "any" means the field can have a value of any type
+//	- on_schema_violation: "drop", "error_output" (default), or "fail"
+//
+// strict_fields and schema cannot be used at the same time.
+func newSinkSchema(params data.Map) (*sinkSchema, data.Map, error) {
+	rest := make(data.Map, len(params))
+	schemaParams := data.Map{}
+	for k, v := range params {
+		rest[k] = v
+	}
+	for _, k := range sinkSchemaParams {
+		if v, ok := rest[k]; ok {
+			schemaParams[k] = v
+			delete(rest, k)
+		}
+	}
+	if len(schemaParams) == 0 {
+		return nil, params, nil
+	}
+
+	s := &sinkSchema{
+		fields: map[string]string{},
+		action: svaErrorOutput,
+	}
+	sf, hasSF := schemaParams["strict_fields"]
+	sc, hasSC := schemaParams["schema"]
+	switch {
+	case hasSF && hasSC:
+		return nil, nil, errors.New("strict_fields and schema cannot be used at the same time")
+
+	case hasSF:
+		a, err := data.AsArray(sf)
+		if err != nil {
+			return nil, nil, fmt.Errorf("strict_fields must be an array: %v", err)
+		}
+		for _, f := range a {
+			name, err := data.AsString(f)
+			if err != nil {
+				return nil, nil, fmt.Errorf("strict_fields must only have strings: %v", err)
+			}
+			s.fields[name] = "any"
+		}
+
+	case hasSC:
+		m, err := data.AsMap(sc)
+		if err != nil {
+			return nil, nil, fmt.Errorf("schema must be a map: %v", err)
+		}
+		for name, t := range m {
+			typeName, err := data.AsString(t)
+			if err != nil {
+				return nil, nil, fmt.Errorf("the type of field '%v' must be a string: %v", name, err)
+			}
+			typeName = strings.ToLower(typeName)
+			if !isSchemaTypeName(typeName) {
+				return nil, nil, fmt.Errorf("the type of field '%v' is unsupported: %v", name, typeName)
+			}
+			s.fields[name] = typeName
+		}
+
+	default:
+		return nil, nil, errors.New("on_schema_violation requires strict_fields or schema")
+	}
+
+	if v, ok := schemaParams["on_schema_violation"]; ok {
+		a, err := data.AsString(v)
+		if err != nil {
+			return nil, nil, fmt.Errorf("on_schema_violation must be a string: %v", err)
+		}
+		if s.action, err = parseSchemaViolationAction(a); err != nil {
+			return nil, nil, fmt.Errorf("on_schema_violation has an invalid value: %v", err)
+		}
+	}
+	return s, rest, nil
+}
+
+func isSchemaTypeName(n string) bool {
+	switch n {
+	case "any", "null", "bool", "int", "float", "string", "blob", "timestamp", "array", "map":
+		return true
+	}
+	return false
+}
+
+// validate returns the numbers of unknown fields, missing fields, and
+// fields having a wrong type in the tuple. It also returns an error
+// describing the violations.
+func (s *sinkSchema) validate(m data.Map) (unknown, missing, mismatch int, err error) {
+	var msgs []string
+	for k := range m {
+		if _, ok := s.fields[k]; !ok {
+			unknown++
+			msgs = append(msgs, fmt.Sprintf("unknown field '%v'", k))
+		}
+	}
+	for k, t := range s.fields {
+		v, ok := m[k]
+		if !ok {
+			missing++
+			msgs = append(msgs, fmt.Sprintf("missing field '%v'", k))
+			continue
+		}
+		if t != "any" && v.Type().String() != t {
+			mismatch++
+			msgs = append(msgs, fmt.Sprintf("field '%v' must be %v but is %v", k, t, v.Type()))
+		}
+	}
+	if len(msgs) == 0 {
+		return
+	}
+	sort.Strings(msgs)
+	err = fmt.Errorf("the tuple doesn't conform to the schema: %v", strings.Join(msgs, ", "))
+	return
+}
+
+// schemaGuardSink validates each tuple before writing it to the sink. The
+// numbers of violations are reported in its status.
+type schemaGuardSink struct {
+	sink   core.Sink
+	schema *sinkSchema
+
+	numUnknown  int64
+	numMissing  int64
+	numMismatch int64
+	numInvalid  int64
+}
+
+var (
+	_ core.Statuser = &schemaGuardSink{}
+	_ core.Updater  = &schemaGuardSink{}
+	_ core.Flusher  = &schemaGuardSink{}
+)
+
+func newSchemaGuardSink(sink core.Sink, schema *sinkSchema) *schemaGuardSink {
+	return &schemaGuardSink{
+		sink:   sink,
+		schema: schema,
+	}
+}
+
+func (s *schemaGuardSink) Write(ctx *core.Context, t *core.Tuple) error {
+	unknown, missing, mismatch, err := s.schema.validate(t.Data)
+	if err == nil {
+		return s.sink.Write(ctx, t)
+	}
+	atomic.AddInt64(&s.numUnknown, int64(unknown))
+	atomic.AddInt64(&s.numMissing, int64(missing))
+	atomic.AddInt64(&s.numMismatch, int64(mismatch))
+	atomic.AddInt64(&s.numInvalid, 1)
+
+	switch s.schema.action {
+	case svaDrop:
+		return nil
+	case svaFail:
+		return core.FatalError(err)
+	default:
+		// A non-fatal error makes the sink node report the tuple as
+		// a dropped tuple.
+		return err
+	}
+}
+
+func (s *schemaGuardSink) Close(ctx *core.Context) error {
+	return s.sink.Close(ctx)
+}
+
+// Flush flushes the sink when it implements core.Flusher.
+func (s *schemaGuardSink) Flush(ctx *core.Context) error {
+	if f, ok := s.sink.(core.Flusher); ok {
+		return f.Flush(ctx)
+	}
+	return nil
+}
+
+// Update updates the sink when it implements core.Updater.
+func (s *schemaGuardSink) Update(ctx *core.Context, params data.Map) error {
+	u, ok := s.sink.(core.Updater)
+	if !ok {
+		return errors.New("the sink cannot be updated")
+	}
+	return u.Update(ctx, params)
+}
+
+// Status returns the status of the sink with the numbers of violations
+// in "schema_violations" field.
+func (s *schemaGuardSink) Status() data.Map {
+	m := data.Map{}
+	if st, ok := s.sink.(core.Statuser); ok {
+		for k, v := range st.Status() {
+			m[k] = v
+		}
+	}
+	m["schema_violations"] = data.Map{
+		"invalid_tuples":  data.Int(atomic.LoadInt64(&s.numInvalid)),
+		"unknown_fields":  data.Int(atomic.LoadInt64(&s.numUnknown)),
+		"missing_fields":  data.Int(atomic.LoadInt64(&s.numMissing)),
+		"type_mismatches": data.Int(atomic.LoadInt64(&s.numMismatch)),
+	}
+	return m
+}
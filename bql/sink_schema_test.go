@@ -0,0 +1,146 @@
+package bql
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+func TestSinkSchema(t *testing.T) {
+	Convey("Given sink parameters", t, func() {
+		Convey("When they don't have schema parameters", func() {
+			params := data.Map{"a": data.Int(1)}
+			s, rest, err := newSinkSchema(params)
+
+			Convey("Then the schema should be nil", func() {
+				So(err, ShouldBeNil)
+				So(s, ShouldBeNil)
+				So(rest, ShouldResemble, params)
+			})
+		})
+
+		Convey("When they have strict_fields", func() {
+			params := data.Map{
+				"a":                   data.Int(1),
+				"strict_fields":       data.Array{data.String("x"), data.String("y")},
+				"on_schema_violation": data.String("fail"),
+			}
+			s, rest, err := newSinkSchema(params)
+
+			Convey("Then schema parameters should be removed", func() {
+				So(err, ShouldBeNil)
+				So(rest, ShouldResemble, data.Map{"a": data.Int(1)})
+				So(params, ShouldHaveLength, 3)
+			})
+
+			Convey("Then the schema should have the fields", func() {
+				So(s.fields, ShouldResemble, map[string]string{"x": "any", "y": "any"})
+				So(s.action, ShouldEqual, svaFail)
+			})
+		})
+
+		Convey("When they have invalid schema parameters", func() {
+			for _, params := range []data.Map{
+				{"strict_fields": data.String("x")},
+				{"strict_fields": data.Array{data.Int(1)}},
+				{"schema": data.Map{"x": data.String("integer")}},
+				{"schema": data.Map{}, "strict_fields": data.Array{}},
+				{"strict_fields": data.Array{}, "on_schema_violation": data.String("ignore")},
+				{"on_schema_violation": data.String("drop")},
+			} {
+				_, _, err := newSinkSchema(params)
+
+				Convey("Then it should fail: "+params.String(), func() {
+					So(err, ShouldNotBeNil)
+				})
+			}
+		})
+	})
+}
+
+func TestSchemaGuardSink(t *testing.T) {
+	Convey("Given a sink with a schema", t, func() {
+		ctx := core.NewContext(nil)
+		si := &tupleCollectorSink{}
+		schema, _, err := newSinkSchema(data.Map{
+			"schema": data.Map{"id": data.String("int"), "name": data.String("any")},
+		})
+		So(err, ShouldBeNil)
+		s := newSchemaGuardSink(si, schema)
+		violations := func() data.Value {
+			return s.Status()["schema_violations"]
+		}
+
+		Convey("When writing a valid tuple", func() {
+			err := s.Write(ctx, core.NewTuple(data.Map{"id": data.Int(1), "name": data.Null{}}))
+
+			Convey("Then it should be written to the sink", func() {
+				So(err, ShouldBeNil)
+				So(si.Tuples, ShouldHaveLength, 1)
+			})
+		})
+
+		Convey("When writing tuples having unknown or missing fields", func() {
+			err := s.Write(ctx, core.NewTuple(data.Map{"id": data.Int(1), "name": data.Null{}, "x": data.Int(1)}))
+			So(err, ShouldNotBeNil)
+			So(core.IsFatalError(err), ShouldBeFalse)
+			err = s.Write(ctx, core.NewTuple(data.Map{"id": data.String("1")}))
+			So(err, ShouldNotBeNil)
+
+			Convey("Then they shouldn't be written to the sink", func() {
+				So(si.Tuples, ShouldBeEmpty)
+			})
+
+			Convey("Then violations should be counted", func() {
+				So(violations(), ShouldResemble, data.Map{
+					"invalid_tuples":  data.Int(2),
+					"unknown_fields":  data.Int(1),
+					"missing_fields":  data.Int(1),
+					"type_mismatches": data.Int(1),
+				})
+			})
+		})
+
+		Convey("When the action is drop", func() {
+			schema.action = svaDrop
+			err := s.Write(ctx, core.NewTuple(data.Map{"x": data.Int(1)}))
+
+			Convey("Then the tuple should silently be dropped", func() {
+				So(err, ShouldBeNil)
+				So(si.Tuples, ShouldBeEmpty)
+			})
+		})
+
+		Convey("When the action is fail", func() {
+			schema.action = svaFail
+			err := s.Write(ctx, core.NewTuple(data.Map{"x": data.Int(1)}))
+
+			Convey("Then it should return a fatal error", func() {
+				So(core.IsFatalError(err), ShouldBeTrue)
+			})
+		})
+	})
+
+	Convey("Given a BQL TopologyBuilder", t, func() {
+		dt := newTestTopology()
+		Reset(func() {
+			dt.Stop()
+		})
+		tb, err := NewTopologyBuilder(dt)
+		So(err, ShouldBeNil)
+
+		Convey("When creating a sink with strict_fields", func() {
+			err := addBQLToTopology(tb, `CREATE SINK snk TYPE collector WITH strict_fields=["a"], on_schema_violation="drop"`)
+
+			Convey("Then the sink should be guarded", func() {
+				So(err, ShouldBeNil)
+				sn, err := dt.Sink("snk")
+				So(err, ShouldBeNil)
+				So(sn.Sink(), ShouldHaveSameTypeAs, &schemaGuardSink{})
+				So(sn.Status()["sink"], ShouldContainKey, "schema_violations")
+			})
+		})
+	})
+}
@@ -0,0 +1,197 @@
+package bql
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/sensorbee/sensorbee.v0/bql/execution"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/parser"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/udf"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+const (
+	// splitInputRelation is the relation name with which conditions of a
+	// SPLIT statement refer to fields of an input tuple.
+	splitInputRelation = "input"
+
+	// splitBranchKey and splitDataKey are keys of a tuple emitted from
+	// a router of a SPLIT statement. splitBranchKey has the index of the
+	// branch to which the tuple is routed and splitDataKey has the original
+	// data of the tuple.
+	splitBranchKey = "branch"
+	splitDataKey   = "data"
+)
+
+// splitRouterBox evaluates conditions of a SPLIT statement in order and tags
+// a tuple with the index of the first branch whose condition is true. Each
+// condition is evaluated at most once per tuple. A tuple is dropped when no
+// condition is true and there's no OTHERWISE branch.
+type splitRouterBox struct {
+	conds []execution.Evaluator
+
+	// otherwise is the index of the OTHERWISE branch or -1.
+	otherwise int
+}
+
+// newSplitRouterBox validates a SPLIT statement and creates a router box of
+// it. Conditions can refer to fields of an input tuple with or without
+// the name of the input stream as a prefix.
+func newSplitRouterBox(stmt *parser.SplitStmt, reg udf.FunctionRegistry) (*splitRouterBox, error) {
+	input := strings.ToLower(string(stmt.Input))
+	names := map[string]bool{}
+	b := &splitRouterBox{
+		otherwise: -1,
+	}
+	for i, br := range stmt.Branches {
+		name := strings.ToLower(string(br.Name))
+		if name == input {
+			return nil, fmt.Errorf("a stream '%v' contains a selfloop", br.Name)
+		}
+		if names[name] {
+			return nil, fmt.Errorf("stream %v is specified more than once", br.Name)
+		}
+		names[name] = true
+
+		if br.Cond == nil {
+			if i != len(stmt.Branches)-1 {
+				return nil, fmt.Errorf("OTHERWISE must be the last branch: %v", br.Name)
+			}
+			b.otherwise = i
+			continue
+		}
+		for rel := range br.Cond.ReferencedRelations() {
+			if rel != "" && strings.ToLower(rel) != input {
+				return nil, fmt.Errorf("the condition of stream %v refers to an unknown stream: %v", br.Name, rel)
+			}
+		}
+		cond := br.Cond.RenameReferencedRelation("", splitInputRelation)
+		if rel := string(stmt.Input); rel != splitInputRelation {
+			cond = cond.RenameReferencedRelation(rel, splitInputRelation)
+		}
+		flatExpr, err := execution.ParserExprToFlatExpr(cond, reg)
+		if err != nil {
+			return nil, fmt.Errorf("the condition of stream %v is invalid: %v", br.Name, err)
+		}
+		eval, err := execution.ExpressionToEvaluator(flatExpr, reg)
+		if err != nil {
+			return nil, fmt.Errorf("the condition of stream %v is invalid: %v", br.Name, err)
+		}
+		b.conds = append(b.conds, eval)
+	}
+	return b, nil
+}
+
+func (b *splitRouterBox) Process(ctx *core.Context, t *core.Tuple, w core.Writer) error {
+	// nest the data so that access via JSON path works properly
+	row := data.Map{
+		splitInputRelation: t.Data,
+		fmt.Sprintf("%s:meta:%s", splitInputRelation, parser.TimestampMeta): data.Timestamp(t.Timestamp),
+		fmt.Sprintf("%s:meta:%s", splitInputRelation, parser.BackfillMeta):  data.Bool(t.Flags.IsSet(core.TFBackfill)),
+	}
+
+	branch := b.otherwise
+	for i, cond := range b.conds {
+		v, err := cond.Eval(row)
+		if err != nil {
+			return err
+		}
+		// NULL is treated as false like WHERE clause
+		if v.Type() == data.TypeNull {
+			continue
+		}
+		ok, err := data.AsBool(v)
+		if err != nil {
+			return err
+		}
+		if ok {
+			branch = i
+			break
+		}
+	}
+	if branch < 0 {
+		return nil
+	}
+
+	out := t.ShallowCopy()
+	out.Data = data.Map{
+		splitBranchKey: data.Int(branch),
+		splitDataKey:   t.Data,
+	}
+	return w.Write(ctx, out)
+}
+
+// newSplitBranchBox returns a box which only emits tuples routed to the
+// branch having the given index.
+func newSplitBranchBox(index int) core.Box {
+	return core.BoxFunc(func(ctx *core.Context, t *core.Tuple, w core.Writer) error {
+		if b, ok := t.Data[splitBranchKey].(data.Int); !ok || int(b) != index {
+			return nil
+		}
+		d, ok := t.Data[splitDataKey].(data.Map)
+		if !ok {
+			return nil
+		}
+		t.Data = d
+		return w.Write(ctx, t)
+	})
+}
+
+// splitStmt adds nodes of a SPLIT statement to the topology. A temporary
+// router box is connected to the input stream and each branch stream
+// receives tuples from the router. The node of the first branch is returned.
+func (tb *TopologyBuilder) splitStmt(stmt *parser.SplitStmt) (core.Node, error) {
+	in, err := tb.topology.Node(string(stmt.Input))
+	if err != nil {
+		return nil, err
+	}
+	if in.Type() == core.NTSink {
+		return nil, fmt.Errorf("data source node %v was not found", stmt.Input)
+	}
+	router, err := newSplitRouterBox(stmt, tb.Reg)
+	if err != nil {
+		return nil, err
+	}
+
+	routerName := fmt.Sprintf("sensorbee_tmp_split_%v", topologyBuilderNextTemporaryID())
+	var names []string
+	removeNodes := func() {
+		for _, name := range names {
+			tb.topology.Remove(name)
+		}
+	}
+
+	rn, err := tb.topology.AddBox(routerName, router, nil)
+	if err != nil {
+		return nil, err
+	}
+	names = append(names, routerName)
+	if err := rn.Input(string(stmt.Input), nil); err != nil {
+		removeNodes()
+		return nil, err
+	}
+
+	nodes := make([]core.BoxNode, 0, len(stmt.Branches))
+	for i, br := range stmt.Branches {
+		node, err := tb.topology.AddBox(string(br.Name), newSplitBranchBox(i), nil)
+		if err != nil {
+			removeNodes()
+			return nil, err
+		}
+		names = append(names, string(br.Name))
+		if err := node.Input(routerName, nil); err != nil {
+			removeNodes()
+			return nil, err
+		}
+		nodes = append(nodes, node)
+	}
+
+	rn.StopOnDisconnect(core.Inbound | core.Outbound)
+	rn.RemoveOnStop()
+	for _, node := range nodes {
+		node.StopOnDisconnect(core.Inbound)
+		node.RemoveOnStop()
+	}
+	return nodes[0], nil
+}
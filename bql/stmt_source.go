@@ -0,0 +1,91 @@
+package bql
+
+import (
+	"fmt"
+
+	"gopkg.in/sensorbee/sensorbee.v0/bql/parser"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+)
+
+// StmtError is an error of a statement having its source returned by
+// parser.ParseStmtsWithSources.
+type StmtError struct {
+	// Source is the source of the statement having the error.
+	Source *parser.StmtSource
+
+	// Err is the error.
+	Err error
+}
+
+func (e *StmtError) Error() string {
+	return fmt.Sprintf("%v: %v", e.Source, e.Err)
+}
+
+// Code returns the code of Err.
+func (e *StmtError) Code() string {
+	return core.ErrorCode(e.Err)
+}
+
+// ValidateStmtsWithSources is ValidateStmts for statements parsed by
+// parser.ParseStmtsWithSources. Sources are returned in the same order as
+// the reordered statements. The returned error is a StmtError having the
+// source of the invalid statement.
+func (tb *TopologyBuilder) ValidateStmtsWithSources(stmts []interface{}, srcs []*parser.StmtSource) (
+	[]interface{}, []*parser.StmtSource, []*PlanWarning, error) {
+	if len(stmts) != len(srcs) {
+		return nil, nil, nil, fmt.Errorf("the number of sources (%v) doesn't match the number of statements (%v)",
+			len(srcs), len(stmts))
+	}
+
+	order, ws, err := tb.validateStmts(stmts)
+	if err != nil {
+		if pe, ok := err.(*PlanError); ok {
+			return nil, nil, nil, &StmtError{
+				Source: srcs[pe.Index],
+				Err:    pe.Err,
+			}
+		}
+		return nil, nil, nil, err
+	}
+	ordered := make([]interface{}, len(order))
+	orderedSrcs := make([]*parser.StmtSource, len(order))
+	for i, idx := range order {
+		ordered[i] = stmts[idx]
+		orderedSrcs[i] = srcs[idx]
+	}
+	return ordered, orderedSrcs, ws, nil
+}
+
+// AddStmtWithSource is AddStmt which records the source of the statement in
+// meta information of the created node so that the status of the node shows
+// which statement created it and where it's written. The meta information has
+// "statement", "source", and "comment" fields. The returned error is a
+// StmtError having the source.
+func (tb *TopologyBuilder) AddStmtWithSource(stmt interface{}, src *parser.StmtSource) (core.Node, error) {
+	n, err := tb.AddStmt(stmt)
+	if err != nil {
+		return n, &StmtError{
+			Source: src,
+			Err:    err,
+		}
+	}
+	if n == nil {
+		return nil, nil
+	}
+
+	if m, ok := n.Meta().(map[string]interface{}); ok {
+		m["statement"] = src.Text
+		s := map[string]interface{}{
+			"line":   src.Line,
+			"column": src.Column,
+		}
+		if src.File != "" {
+			s["file"] = src.File
+		}
+		m["source"] = s
+		if src.Comment != "" {
+			m["comment"] = src.Comment
+		}
+	}
+	return n, nil
+}
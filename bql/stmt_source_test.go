@@ -0,0 +1,88 @@
+package bql
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/parser"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+)
+
+func TestTopologyBuilderStmtSource(t *testing.T) {
+	Convey("Given a BQL TopologyBuilder", t, func() {
+		dt := newTestTopology()
+		Reset(func() {
+			dt.Stop()
+		})
+		tb, err := NewTopologyBuilder(dt)
+		So(err, ShouldBeNil)
+
+		parse := func(bql string) ([]interface{}, []*parser.StmtSource) {
+			stmts, srcs, err := parser.New().ParseStmtsWithSources(bql)
+			So(err, ShouldBeNil)
+			for _, s := range srcs {
+				s.File = "test.bql"
+			}
+			return stmts, srcs
+		}
+
+		Convey("When adding statements with their sources", func() {
+			stmts, srcs := parse(`CREATE SINK snk TYPE collector;
+
+-- the source of the test
+CREATE PAUSED SOURCE s TYPE dummy;`)
+			stmts, srcs, _, err := tb.ValidateStmtsWithSources(stmts, srcs)
+			So(err, ShouldBeNil)
+			for i, stmt := range stmts {
+				_, err := tb.AddStmtWithSource(stmt, srcs[i])
+				So(err, ShouldBeNil)
+			}
+
+			Convey("Then nodes should have the sources in their meta information", func() {
+				n, err := dt.Source("s")
+				So(err, ShouldBeNil)
+				m := n.Meta().(map[string]interface{})
+				So(m["statement"], ShouldEqual, "CREATE PAUSED SOURCE s TYPE dummy;")
+				So(m["source"], ShouldResemble, map[string]interface{}{
+					"file":   "test.bql",
+					"line":   4,
+					"column": 1,
+				})
+				So(m["comment"], ShouldEqual, "the source of the test")
+
+				n2, err := dt.Sink("snk")
+				So(err, ShouldBeNil)
+				m = n2.Meta().(map[string]interface{})
+				So(m["statement"], ShouldEqual, "CREATE SINK snk TYPE collector;")
+				So(m, ShouldNotContainKey, "comment")
+			})
+		})
+
+		Convey("When validating statements having an error", func() {
+			stmts, srcs := parse(`CREATE PAUSED SOURCE s TYPE dummy;
+CREATE SINK snk TYPE collector;
+  INSERT INTO snk FROM a;`)
+			_, _, _, err := tb.ValidateStmtsWithSources(stmts, srcs)
+
+			Convey("Then the error should have the location of the statement", func() {
+				So(err, ShouldNotBeNil)
+				So(err.(*StmtError).Source, ShouldEqual, srcs[2])
+				So(err.Error(), ShouldStartWith, "test.bql:3:3: ")
+			})
+		})
+
+		Convey("When adding a statement failing with its source", func() {
+			_, srcs := parse(`CREATE PAUSED SOURCE s TYPE dummy;
+CREATE PAUSED SOURCE s TYPE dummy;`)
+			So(addBQLToTopology(tb, `CREATE PAUSED SOURCE s TYPE dummy;`), ShouldBeNil)
+			stmts, _ := parse(`CREATE PAUSED SOURCE s TYPE dummy;`)
+			_, err := tb.AddStmtWithSource(stmts[0], srcs[1])
+
+			Convey("Then the error should have the location and the code", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldStartWith, "test.bql:2:1: ")
+				So(core.ErrorCode(err), ShouldEqual, core.ErrorCode(err.(*StmtError).Err))
+			})
+		})
+	})
+}
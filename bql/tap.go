@@ -0,0 +1,221 @@
+package bql
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// DefaultTapMaxRate is the default maximum number of tuples per second sent
+// from a tap.
+const DefaultTapMaxRate = 10
+
+// TapConfig has parameters of a tap created by TopologyBuilder.AddTap.
+type TapConfig struct {
+	// MaxRate is the maximum number of tuples per second sent from the tap.
+	// Tuples exceeding the rate are skipped. DefaultTapMaxRate is used when
+	// it's 0.
+	MaxRate float64
+
+	// SamplingRate is the probability that each tuple is sampled. All tuples
+	// are sampled (as long as they don't exceed MaxRate) when it's 0.
+	SamplingRate float64
+}
+
+// Validate validates values of TapConfig.
+func (c *TapConfig) Validate() error {
+	if c.MaxRate < 0 {
+		return fmt.Errorf("max rate must not be negative: %v", c.MaxRate)
+	}
+	if c.SamplingRate < 0 || c.SamplingRate > 1 {
+		return fmt.Errorf("sampling rate must be in [0, 1]: %v", c.SamplingRate)
+	}
+	return nil
+}
+
+// tapBufferSize is the number of sampled tuples buffered in a tap. Tuples are
+// skipped when the buffer is full so that a slow observer never blocks
+// the node being tapped.
+const tapBufferSize = 16
+
+// tapSink is a sink observing the output of a node. Its Write never blocks.
+type tapSink struct {
+	m        sync.RWMutex
+	ch       chan data.Map
+	closed   bool
+	interval time.Duration
+	sampling float64
+
+	// Following fields are protected by cm.
+	cm      sync.Mutex
+	seq     int64
+	skipped int64
+	last    time.Time
+}
+
+func (s *tapSink) Write(ctx *core.Context, t *core.Tuple) error {
+	s.m.RLock()
+	defer s.m.RUnlock()
+	if s.closed {
+		return errors.New("the sink has already been closed")
+	}
+
+	s.cm.Lock()
+	defer s.cm.Unlock()
+	s.seq++
+	now := ctx.Clock().Now()
+	if (s.sampling > 0 && rand.Float64() >= s.sampling) || now.Sub(s.last) < s.interval {
+		s.skipped++
+		return nil
+	}
+
+	trace := make(data.Array, len(t.Trace))
+	for i, ev := range t.Trace {
+		e := data.Map{
+			"timestamp": data.Timestamp(ev.Timestamp),
+			"type":      data.String(ev.Type.String()),
+			"msg":       data.String(ev.Msg),
+		}
+		if ev.Inputs != nil {
+			inputs := make(data.Array, len(ev.Inputs))
+			for j, id := range ev.Inputs {
+				inputs[j] = data.String(id)
+			}
+			e["inputs"] = inputs
+		}
+		trace[i] = e
+	}
+	m := data.Map{
+		"seq":       data.Int(s.seq),
+		"skipped":   data.Int(s.skipped),
+		"timestamp": data.Timestamp(t.Timestamp),
+		"data":      t.Data.Copy(),
+		"trace":     trace,
+	}
+	select {
+	case s.ch <- m:
+		s.skipped = 0
+		s.last = now
+	default:
+		s.skipped++
+	}
+	return nil
+}
+
+func (s *tapSink) Close(ctx *core.Context) error {
+	// Write doesn't block, so the lock can be acquired without vacuuming
+	// the chan.
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	close(s.ch)
+	return nil
+}
+
+// AddTap attaches a temporary sink observing tuples emitted from the source
+// or the stream. It returns the sink node and the chan receiving sampled
+// tuples. The data of each tuple received from the chan is a map having
+// following fields:
+//
+//	- seq: the number of tuples the tap has observed including this one
+//	- skipped: the number of tuples skipped since the previous sampled one
+//	- timestamp: the timestamp of the observed tuple
+//	- data: the data of the observed tuple
+//	- trace: trace events of the observed tuple, which are only recorded
+//	  when tuple tracing is enabled. An output event of a box recording
+//	  lineage also has "inputs", IDs of tuples contributing to the tuple
+//	- queues: the output queues of the node at the time the tuple is sent,
+//	  having "num_queued" and "queue_size" for each destination
+//
+// The tap never slows down the node. Tuples are skipped when they exceed
+// the rate or when the caller doesn't read the chan fast enough. The sink is
+// stopped when the node is removed. The caller must stop the sink node once
+// it gets unnecessary and keep reading the chan until it's closed.
+func (tb *TopologyBuilder) AddTap(nodeName string, config *TapConfig) (core.SinkNode, <-chan *core.Tuple, error) {
+	if config == nil {
+		config = &TapConfig{}
+	}
+	if err := config.Validate(); err != nil {
+		return nil, nil, err
+	}
+	node, err := tb.topology.Node(nodeName)
+	if err != nil {
+		return nil, nil, err
+	}
+	if node.Type() == core.NTSink {
+		return nil, nil, fmt.Errorf("sink '%v' doesn't emit tuples", nodeName)
+	}
+
+	rate := config.MaxRate
+	if rate == 0 {
+		rate = DefaultTapMaxRate
+	}
+	sink := &tapSink{
+		ch:       make(chan data.Map, tapBufferSize),
+		interval: time.Duration(float64(time.Second) / rate),
+		sampling: config.SamplingRate,
+	}
+	tapName := fmt.Sprintf("sensorbee_tmp_tap_%v", topologyBuilderNextTemporaryID())
+	sn, err := tb.topology.AddSink(tapName, sink, nil)
+	if err != nil {
+		sink.Close(tb.topology.Context())
+		return nil, nil, err
+	}
+	if err := sn.Input(nodeName, nil); err != nil {
+		tb.topology.Remove(tapName)
+		return nil, nil, err
+	}
+
+	// Status of the node is fetched in a separate goroutine because it
+	// acquires locks which the node can hold while writing to the tap.
+	out := make(chan *core.Tuple)
+	go func() {
+		defer close(out)
+		for m := range sink.ch {
+			m["queues"] = outputQueues(node, tapName)
+			out <- core.NewTuple(m)
+		}
+	}()
+	go func() {
+		sn.RemoveOnStop()
+		sn.StopOnDisconnect()
+	}()
+	return sn, out, nil
+}
+
+// outputQueues returns the status of output queues of the node except the
+// queue to the given sink.
+func outputQueues(node core.Node, except string) data.Map {
+	res := data.Map{}
+	outputs, err := node.Status().Get(data.MustCompilePath("output_stats.outputs"))
+	if err != nil {
+		return res
+	}
+	m, err := data.AsMap(outputs)
+	if err != nil {
+		return res
+	}
+	for name, v := range m {
+		if strings.EqualFold(name, except) {
+			continue
+		}
+		st, err := data.AsMap(v)
+		if err != nil {
+			continue
+		}
+		res[name] = data.Map{
+			"num_queued": st["num_queued"],
+			"queue_size": st["queue_size"],
+		}
+	}
+	return res
+}
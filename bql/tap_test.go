@@ -0,0 +1,123 @@
+package bql
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+func TestTap(t *testing.T) {
+	Convey("Given a BQL TopologyBuilder having a paused source", t, func() {
+		dt := newTestTopology()
+		Reset(func() {
+			dt.Stop()
+		})
+		tb, err := NewTopologyBuilder(dt)
+		So(err, ShouldBeNil)
+		So(addBQLToTopology(tb, `CREATE PAUSED SOURCE s TYPE dummy WITH resumable=false;
+			CREATE SINK snk TYPE collector;
+			INSERT INTO snk FROM s;`), ShouldBeNil)
+
+		Convey("When tapping the source and resuming it", func() {
+			sn, ch, err := tb.AddTap("s", nil)
+			So(err, ShouldBeNil)
+			Reset(func() {
+				sn.Stop()
+			})
+			src, err := dt.Source("s")
+			So(err, ShouldBeNil)
+			So(src.Resume(), ShouldBeNil)
+
+			Convey("Then the first tuple should be sampled", func() {
+				var ts []*core.Tuple
+				for t := range ch {
+					ts = append(ts, t)
+				}
+				So(ts, ShouldNotBeEmpty)
+				So(ts[0].Data["seq"], ShouldEqual, data.Int(1))
+				So(ts[0].Data["skipped"], ShouldEqual, data.Int(0))
+				So(ts[0].Data["data"], ShouldResemble, data.Map{"int": data.Int(1)})
+
+				// The source may have already stopped and the content of
+				// queues isn't deterministic.
+				_, err := data.AsMap(ts[0].Data["queues"])
+				So(err, ShouldBeNil)
+			})
+		})
+
+		Convey("When tapping a sink", func() {
+			_, _, err := tb.AddTap("snk", nil)
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When tapping a node which doesn't exist", func() {
+			_, _, err := tb.AddTap("no_such_node", nil)
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When tapping with an invalid config", func() {
+			_, _, err := tb.AddTap("s", &TapConfig{SamplingRate: 2})
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+
+	Convey("Given a tap sink", t, func() {
+		ctx := core.NewContext(nil)
+		s := &tapSink{
+			ch:       make(chan data.Map, tapBufferSize),
+			interval: time.Hour,
+		}
+
+		Convey("When writing tuples faster than the rate", func() {
+			for i := 0; i < 3; i++ {
+				So(s.Write(ctx, core.NewTuple(data.Map{"i": data.Int(i)})), ShouldBeNil)
+			}
+			s.interval = 0
+			So(s.Write(ctx, core.NewTuple(data.Map{"i": data.Int(3)})), ShouldBeNil)
+			So(s.Close(ctx), ShouldBeNil)
+
+			Convey("Then tuples exceeding the rate should be skipped", func() {
+				var ms []data.Map
+				for m := range s.ch {
+					ms = append(ms, m)
+				}
+				So(len(ms), ShouldEqual, 2)
+				So(ms[0]["data"], ShouldResemble, data.Map{"i": data.Int(0)})
+				So(ms[1]["seq"], ShouldEqual, data.Int(4))
+				So(ms[1]["skipped"], ShouldEqual, data.Int(2))
+			})
+		})
+
+		Convey("When writing tuples while nobody reads them", func() {
+			s.interval = 0
+			for i := 0; i < tapBufferSize+4; i++ {
+				So(s.Write(ctx, core.NewTuple(data.Map{"i": data.Int(i)})), ShouldBeNil)
+			}
+
+			Convey("Then the write shouldn't block and the tuples should be skipped", func() {
+				So(len(s.ch), ShouldEqual, tapBufferSize)
+				So(s.skipped, ShouldEqual, 4)
+			})
+		})
+
+		Convey("When writing a tuple after it's closed", func() {
+			So(s.Close(ctx), ShouldBeNil)
+
+			Convey("Then it should fail", func() {
+				So(s.Write(ctx, core.NewTuple(data.Map{})), ShouldNotBeNil)
+			})
+		})
+	})
+}
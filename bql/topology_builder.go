@@ -4,9 +4,11 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"sort"
 	"strings"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"gopkg.in/sensorbee/sensorbee.v0/bql/execution"
 	"gopkg.in/sensorbee/sensorbee.v0/bql/parser"
@@ -15,6 +17,11 @@ import (
 	"gopkg.in/sensorbee/sensorbee.v0/data"
 )
 
+// TopologyBuilder creates nodes in a core.Topology from BQL statements or
+// from Go code. Applications embedding SensorBee as a library can build a
+// topology with AddSource, AddBQLStream, and AddSink without writing whole
+// BQL statements. See the package documentation for compatibility
+// guarantees of these methods.
 type TopologyBuilder struct {
 	topology       core.Topology
 	Reg            udf.FunctionManager
@@ -22,7 +29,19 @@ type TopologyBuilder struct {
 	UDSCreators    udf.UDSCreatorRegistry
 	SourceCreators SourceCreatorRegistry
 	SinkCreators   SinkCreatorRegistry
+	LoaderCreators EnrichmentLoaderCreatorRegistry
 	UDSStorage     udf.UDSStorage
+
+	// nodeDefs and stateDefs have definitions of nodes and states created
+	// by AddStmt. Keys are names in lower case.
+	defMutex  sync.Mutex
+	nodeDefs  map[string]*definition
+	stateDefs map[string]*definition
+
+	// sharedFilters has filters shared by streams found by OptimizeStmts.
+	// Keys are names of streams in lower case.
+	filterMutex   sync.Mutex
+	sharedFilters map[string]*SharedFilter
 }
 
 // TODO: Provide AtomicTopologyBuilder which support building multiple nodes
@@ -59,12 +78,22 @@ func NewTopologyBuilder(t core.Topology) (*TopologyBuilder, error) {
 	if err := srcs.Register("edge_statuses", createEdgeStatusSourceCreator(t)); err != nil {
 		return nil, err
 	}
+	// backfill builtin source creates other sources from the registry of
+	// the topology.
+	if err := srcs.Register("backfill", createBackfillSourceCreator(srcs)); err != nil {
+		return nil, err
+	}
 
 	sinks, err := CopyGlobalSinkCreatorRegistry()
 	if err != nil {
 		return nil, err
 	}
 
+	loaders, err := CopyGlobalEnrichmentLoaderCreatorRegistry()
+	if err != nil {
+		return nil, err
+	}
+
 	tb := &TopologyBuilder{
 		topology:       t,
 		Reg:            udf.CopyGlobalUDFRegistry(t.Context()),
@@ -72,6 +101,7 @@ func NewTopologyBuilder(t core.Topology) (*TopologyBuilder, error) {
 		UDSCreators:    udss,
 		SourceCreators: srcs,
 		SinkCreators:   sinks,
+		LoaderCreators: loaders,
 		UDSStorage:     udf.NewInMemoryUDSStorage(),
 	}
 	return tb, nil
@@ -96,36 +126,41 @@ func (tb *TopologyBuilder) Topology() core.Topology {
 // AddStmt add a node created from a statement to the topology. It returns
 // a created node. It returns a nil node when the statement is CREATE STATE.
 func (tb *TopologyBuilder) AddStmt(stmt interface{}) (core.Node, error) {
+	n, err := tb.addStmt(stmt)
+	if err != nil {
+		return n, err
+	}
+	tb.recordDefinition(stmt, n)
+	return n, nil
+}
+
+// addStmt is AddStmt which doesn't record the definition of the node. It's
+// used for temporary nodes.
+func (tb *TopologyBuilder) addStmt(stmt interface{}) (core.Node, error) {
 	// TODO: Enable StopOnDisconnect properly
 
 	// check the type of statement
 	switch stmt := stmt.(type) {
 	case parser.CreateSourceStmt:
-		// load params into map for faster access
-		paramsMap := tb.mkParamsMap(stmt.Params)
-
-		// check if we know this type of source
-		creator, err := tb.SourceCreators.Lookup(string(stmt.Type))
-		if err != nil {
-			return nil, err
-		}
-
-		// if so, try to create such a source
-		source, err := creator.CreateSource(tb.topology.Context(), &IOParams{
-			TypeName: string(stmt.Type),
-			Name:     string(stmt.Name),
-		}, paramsMap)
+		params, err := tb.mkParamsMap(stmt.Params)
 		if err != nil {
 			return nil, err
 		}
-		return tb.topology.AddSource(string(stmt.Name), source, &core.SourceConfig{
-			PausedOnStartup: stmt.Paused == parser.Yes,
-		})
+		return tb.AddSource(string(stmt.Name), string(stmt.Type), params,
+			&core.SourceConfig{
+				PausedOnStartup: stmt.Paused == parser.Yes,
+			})
 
 	case parser.CreateStreamAsSelectStmt:
-		return tb.createStreamAsSelectStmt(&stmt)
+		return tb.createStreamAsSelectStmt(&stmt, time.Time{}, nil)
+
+	case parser.CreateStreamAsEnrichStmt:
+		return tb.enrichStmt(&stmt)
 
 	case parser.CreateStreamAsSelectUnionStmt:
+		if err := validateSelectUnion(&stmt.SelectUnionStmt); err != nil {
+			return nil, err
+		}
 		// idea: create an intermediate box for each SELECT substatement,
 		// then connect them with a simple forwarder box
 		names := make([]string, 0, len(stmt.Selects))
@@ -141,8 +176,9 @@ func (tb *TopologyBuilder) AddStmt(stmt interface{}) (core.Node, error) {
 			tmpStmt := parser.CreateStreamAsSelectStmt{
 				parser.StreamIdentifier(tmpName),
 				selStmt,
+				parser.SourceSinkSpecsAST{},
 			}
-			box, err := tb.AddStmt(tmpStmt)
+			box, err := tb.addStmt(tmpStmt)
 			if err != nil {
 				removeTmpNodes()
 				return nil, err
@@ -175,36 +211,27 @@ func (tb *TopologyBuilder) AddStmt(stmt interface{}) (core.Node, error) {
 		return node, nil
 
 	case parser.CreateSinkStmt:
-		// load params into map for faster access
-		paramsMap := tb.mkParamsMap(stmt.Params)
-
-		// check if we know this type of sink
-		creator, err := tb.SinkCreators.Lookup(string(stmt.Type))
+		// we insert a sink, but cannot connect it to
+		// any streams yet, therefore we have to keep track
+		// of the SinkDeclarer
+		params, err := tb.mkParamsMap(stmt.Params)
 		if err != nil {
 			return nil, err
 		}
+		return tb.AddSink(string(stmt.Name), string(stmt.Type), params, nil)
 
-		// if so, try to create such a sink
-		sink, err := creator.CreateSink(tb.topology.Context(), &IOParams{
-			TypeName: string(stmt.Type),
-			Name:     string(stmt.Name),
-		}, paramsMap)
+	case parser.CreateStateStmt:
+		c, err := tb.UDSCreators.Lookup(string(stmt.Type))
 		if err != nil {
 			return nil, err
 		}
-		// we insert a sink, but cannot connect it to
-		// any streams yet, therefore we have to keep track
-		// of the SinkDeclarer
-		return tb.topology.AddSink(string(stmt.Name), sink, nil)
 
-	case parser.CreateStateStmt:
-		c, err := tb.UDSCreators.Lookup(string(stmt.Type))
+		params, err := tb.mkParamsMap(stmt.Params)
 		if err != nil {
 			return nil, err
 		}
-
 		ctx := tb.topology.Context()
-		s, err := c.CreateState(ctx, tb.mkParamsMap(stmt.Params))
+		s, err := c.CreateState(ctx, params)
 		if err != nil {
 			return nil, err
 		}
@@ -224,23 +251,39 @@ func (tb *TopologyBuilder) AddStmt(stmt interface{}) (core.Node, error) {
 		if !ok {
 			return nil, fmt.Errorf("%s cannot be updated", string(stmt.Name))
 		}
-		return nil, u.Update(ctx, tb.mkParamsMap(stmt.Params))
+		params, err := tb.mkParamsMap(stmt.Params)
+		if err != nil {
+			return nil, err
+		}
+		if err := u.Update(ctx, params); err != nil {
+			return nil, err
+		}
+		ctx.NotifyStateUpdate(string(stmt.Name), core.SUEUpdated, data.Map{"params": params.Copy()})
+		return nil, nil
 
 	case parser.SaveStateStmt:
 		return nil, tb.saveState(string(stmt.Name), stmt.Tag)
 
 	case parser.LoadStateStmt:
-		_, err := tb.loadState(string(stmt.Type), string(stmt.Name), stmt.Tag, tb.mkParamsMap(stmt.Params))
+		params, err := tb.mkParamsMap(stmt.Params)
+		if err != nil {
+			return nil, err
+		}
+		_, err = tb.loadState(string(stmt.Type), string(stmt.Name), stmt.Tag, params)
 		return nil, err
 
 	case parser.LoadStateOrCreateStmt:
-		shouldCreate, err := tb.loadState(string(stmt.Type), string(stmt.Name), stmt.Tag, tb.mkParamsMap(stmt.LoadSpecs.Params))
+		params, err := tb.mkParamsMap(stmt.LoadSpecs.Params)
+		if err != nil {
+			return nil, err
+		}
+		shouldCreate, err := tb.loadState(string(stmt.Type), string(stmt.Name), stmt.Tag, params)
 		if shouldCreate {
 			c := parser.CreateStateStmt{}
 			c.Type = stmt.Type
 			c.Name = stmt.Name
 			c.Params = stmt.CreateSpecs.Params
-			return tb.AddStmt(c)
+			return tb.addStmt(c)
 		}
 		return nil, err
 
@@ -254,7 +297,11 @@ func (tb *TopologyBuilder) AddStmt(stmt interface{}) (core.Node, error) {
 		if !ok {
 			return nil, fmt.Errorf("%s cannot be updated", string(stmt.Name))
 		}
-		return nil, u.Update(tb.topology.Context(), tb.mkParamsMap(stmt.Params))
+		params, err := tb.mkParamsMap(stmt.Params)
+		if err != nil {
+			return nil, err
+		}
+		return nil, u.Update(tb.topology.Context(), params)
 
 	case parser.UpdateSinkStmt:
 		sink, err := tb.topology.Sink(string(stmt.Name))
@@ -266,7 +313,11 @@ func (tb *TopologyBuilder) AddStmt(stmt interface{}) (core.Node, error) {
 		if !ok {
 			return nil, fmt.Errorf("%s cannot be updated", string(stmt.Name))
 		}
-		return nil, u.Update(tb.topology.Context(), tb.mkParamsMap(stmt.Params))
+		params, err := tb.mkParamsMap(stmt.Params)
+		if err != nil {
+			return nil, err
+		}
+		return nil, u.Update(tb.topology.Context(), params)
 
 	case parser.DropSourceStmt:
 		_, err := tb.topology.Source(string(stmt.Source))
@@ -308,12 +359,53 @@ func (tb *TopologyBuilder) AddStmt(stmt interface{}) (core.Node, error) {
 		if err != nil {
 			return nil, err
 		}
-		// now connect the sink to the specified box
-		if err := sink.Input(string(stmt.Input), nil); err != nil {
+		config, err := tb.mkSinkInputConfig(stmt.Params)
+		if err != nil {
 			return nil, err
 		}
+
+		// check all inputs before connecting any of them so that the sink
+		// isn't partially connected on failure
+		names := map[string]bool{}
+		for _, in := range stmt.Inputs {
+			name := strings.ToLower(string(in))
+			if names[name] {
+				return nil, fmt.Errorf("input %v is specified more than once", in)
+			}
+			names[name] = true
+
+			n, err := tb.topology.Node(string(in))
+			if err != nil {
+				return nil, err
+			}
+			if n.Type() == core.NTSink {
+				return nil, core.NotExistError(fmt.Errorf("data source node %v was not found", in))
+			}
+		}
+
+		// now connect the sink to the specified nodes. Each tuple is tagged
+		// with the name of the node it came from.
+		for _, in := range stmt.Inputs {
+			c := *config
+			c.InputName = string(in)
+			if err := sink.Input(string(in), &c); err != nil {
+				return nil, err
+			}
+		}
 		return sink, nil
 
+	case parser.InsertIntoSelectStmt:
+		return tb.insertIntoSelectStmt(&stmt)
+
+	case parser.SplitStmt:
+		return tb.splitStmt(&stmt)
+
+	case parser.ArchiveStreamStmt:
+		return tb.archiveStream(&stmt)
+
+	case parser.ReplayArchiveStmt:
+		return tb.addStmt(replayArchiveSource(&stmt))
+
 	case parser.PauseSourceStmt:
 		src, err := tb.topology.Source(string(stmt.Source))
 		if err != nil {
@@ -334,6 +426,17 @@ func (tb *TopologyBuilder) AddStmt(stmt interface{}) (core.Node, error) {
 		}
 		return src, nil
 
+	case parser.SetConstantStmt:
+		v, err := execution.EvaluateFoldable(stmt.Value, tb.Reg)
+		if err != nil {
+			return nil, err
+		}
+		return nil, tb.topology.Context().SetConstant(string(stmt.Name), v)
+
+	case parser.ReloadFunctionStmt:
+		_, err := tb.RunReloadFunctionStmt(&stmt)
+		return nil, err
+
 	case parser.RewindSourceStmt:
 		src, err := tb.topology.Source(string(stmt.Source))
 		if err != nil {
@@ -348,6 +451,151 @@ func (tb *TopologyBuilder) AddStmt(stmt interface{}) (core.Node, error) {
 	return nil, fmt.Errorf("statement of type %T is unimplemented", stmt)
 }
 
+// AddSource creates a source of the given type with parameters and adds it to
+// the topology. It's equivalent to the following statement:
+//
+//	CREATE [PAUSED] SOURCE name TYPE typeName WITH params...
+//
+// params can be nil when the source doesn't require any parameter. config is
+// passed to core.Topology.AddSource and can also be nil. When params have
+// "labels" parameter, which is an array of strings, they're attached to the
+// source in addition to labels in config and aren't passed to the creator.
+// "retention" and "retention_max_size" parameters aren't passed to the
+// creator, either. They enable the retention buffer of the source used by
+// SELECT ... STARTING ... AGO statements. See extractRetention for details.
+func (tb *TopologyBuilder) AddSource(name, typeName string, params data.Map, config *core.SourceConfig) (core.SourceNode, error) {
+	if params == nil {
+		params = data.Map{}
+	}
+	labels, params, err := extractLabels(params)
+	if err != nil {
+		return nil, err
+	}
+	if labels != nil {
+		c := core.SourceConfig{}
+		if config != nil {
+			c = *config
+		}
+		c.Labels = append(append([]string{}, c.Labels...), labels...)
+		config = &c
+	}
+	retention, params, err := extractRetention(params)
+	if err != nil {
+		return nil, err
+	}
+
+	// check if we know this type of source
+	creator, err := tb.SourceCreators.Lookup(typeName)
+	if err != nil {
+		return nil, err
+	}
+
+	// if so, try to create such a source
+	source, err := creator.CreateSource(tb.topology.Context(), &IOParams{
+		TypeName: typeName,
+		Name:     name,
+	}, params)
+	if err != nil {
+		return nil, err
+	}
+	sn, err := tb.topology.AddSource(name, source, config)
+	if err != nil {
+		return nil, err
+	}
+	if retention != nil {
+		if err := tb.addRetentionSink(name, retention); err != nil {
+			tb.topology.Remove(name)
+			return nil, err
+		}
+	}
+	return sn, nil
+}
+
+// AddBQLStream creates a stream from a SELECT or SELECT ... UNION ALL
+// statement written in BQL and adds it to the topology. It's equivalent to
+// the following statement:
+//
+//	CREATE STREAM name AS stmt
+//
+// Only one statement can be passed and a trailing semicolon is optional.
+func (tb *TopologyBuilder) AddBQLStream(name, stmt string) (core.BoxNode, error) {
+	p := parser.New()
+	s, rest, err := p.ParseStmt(stmt)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(rest) != "" {
+		return nil, fmt.Errorf("only one SELECT statement can be given: %v", rest)
+	}
+
+	var n core.Node
+	switch s := s.(type) {
+	case parser.SelectStmt:
+		n, err = tb.AddStmt(parser.CreateStreamAsSelectStmt{parser.StreamIdentifier(name), s, parser.SourceSinkSpecsAST{}})
+	case parser.SelectUnionStmt:
+		n, err = tb.AddStmt(parser.CreateStreamAsSelectUnionStmt{parser.StreamIdentifier(name), s})
+	default:
+		return nil, fmt.Errorf("the statement must be SELECT or SELECT ... UNION ALL: %v", stmt)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return n.(core.BoxNode), nil
+}
+
+// AddSink creates a sink of the given type with parameters and adds it to the
+// topology. It's equivalent to the following statement:
+//
+//	CREATE SINK name TYPE typeName WITH params...
+//
+// params and config can be nil. Inputs of the sink can be added by
+// core.SinkNode.Input, which is equivalent to INSERT INTO statement.
+//
+// When params have strict_fields or schema parameter, tuples written to the
+// sink are validated before they're passed to the sink. Those parameters
+// aren't passed to the creator of the sink. See newSinkSchema for details.
+// "labels" parameter is handled in the same way as AddSource.
+func (tb *TopologyBuilder) AddSink(name, typeName string, params data.Map, config *core.SinkConfig) (core.SinkNode, error) {
+	if params == nil {
+		params = data.Map{}
+	}
+	labels, params, err := extractLabels(params)
+	if err != nil {
+		return nil, err
+	}
+	if labels != nil {
+		c := core.SinkConfig{}
+		if config != nil {
+			c = *config
+		}
+		c.Labels = append(append([]string{}, c.Labels...), labels...)
+		config = &c
+	}
+	schema, params, err := newSinkSchema(params)
+	if err != nil {
+		return nil, err
+	}
+
+	// check if we know this type of sink
+	creator, err := tb.SinkCreators.Lookup(typeName)
+	if err != nil {
+		return nil, err
+	}
+
+	// if so, try to create such a sink
+	sink, err := creator.CreateSink(tb.topology.Context(), &IOParams{
+		TypeName: typeName,
+		Name:     name,
+	}, params)
+	if err != nil {
+		return nil, err
+	}
+	if schema != nil {
+		sink = newSchemaGuardSink(sink, schema)
+	}
+	return tb.topology.AddSink(name, sink, config)
+}
+
 // udsfBox is a core.Box which runs a UDSF in the stream mode.
 type udsfBox struct {
 	f udf.UDSF
@@ -408,10 +656,80 @@ func (s *udsfSource) Stop(ctx *core.Context) error {
 	return s.f.Terminate(ctx)
 }
 
-func (tb *TopologyBuilder) createStreamAsSelectStmt(stmt *parser.CreateStreamAsSelectStmt) (core.Node, error) {
+// insertIntoSelectStmt creates an anonymous stream from the SELECT statement
+// and connects the sink to it. The stream is stopped and removed when it's
+// disconnected from either its inputs or the sink like streams created for
+// SELECT ... UNION ALL.
+func (tb *TopologyBuilder) insertIntoSelectStmt(stmt *parser.InsertIntoSelectStmt) (core.Node, error) {
+	sink, err := tb.topology.Sink(string(stmt.Sink))
+	if err != nil {
+		return nil, err
+	}
+
+	tmpName := fmt.Sprintf("sensorbee_tmp_%v", topologyBuilderNextTemporaryID())
+	n, err := tb.addStmt(parser.CreateStreamAsSelectStmt{
+		parser.StreamIdentifier(tmpName),
+		stmt.Select,
+		parser.SourceSinkSpecsAST{},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := sink.Input(tmpName, nil); err != nil {
+		tb.topology.Remove(tmpName)
+		return nil, err
+	}
+	box := n.(core.BoxNode)
+	box.StopOnDisconnect(core.Inbound | core.Outbound)
+	box.RemoveOnStop()
+	return sink, nil
+}
+
+// createStreamAsSelectStmt creates a stream from the statement. When since
+// isn't zero, input streams are replayed from their retention buffers
+// starting at since instead of being connected directly. The WITH clause of
+// the statement only accepts parameters of the retention buffer of the
+// stream.
+func (tb *TopologyBuilder) createStreamAsSelectStmt(stmt *parser.CreateStreamAsSelectStmt, since time.Time, explain *explainAnalysis) (core.Node, error) {
+	params, err := tb.mkParamsMap(stmt.Params)
+	if err != nil {
+		return nil, err
+	}
+	retention, params, err := extractRetention(params)
+	if err != nil {
+		return nil, err
+	}
+	timeout, params, err := extractExecutionTimeout(params, tb.topology.Context().ExecutionTimeout())
+	if err != nil {
+		return nil, err
+	}
+	quality, params, err := extractQuality(params)
+	if err != nil {
+		return nil, err
+	}
+	for k := range params {
+		return nil, fmt.Errorf("unsupported parameter for CREATE STREAM: %v", k)
+	}
+
 	// insert a bqlBox that executes the SELECT statement
 	outName := string(stmt.Name)
-	box := NewBQLBox(&stmt.Select, tb.Reg)
+	sel := &stmt.Select
+	var filter *SharedFilter
+	if since.IsZero() && explain == nil {
+		filter, sel = tb.pushDownFilter(outName, sel)
+	}
+	box := NewBQLBox(sel, tb.Reg)
+	box.timeout = timeout
+	box.name = outName
+	box.explain = explain
+	if quality != nil {
+		if quality.quarantine != "" && strings.ToLower(quality.quarantine) == strings.ToLower(outName) {
+			return nil, fmt.Errorf("a stream '%v' cannot be its own quarantine", outName)
+		}
+		if box.quality, err = newQualityRules(quality, outName, tb.Reg); err != nil {
+			return nil, err
+		}
+	}
 	// add all the referenced relations as named inputs
 	dbox, err := tb.topology.AddBox(outName, box, nil)
 	if err != nil {
@@ -441,6 +759,15 @@ func (tb *TopologyBuilder) createStreamAsSelectStmt(stmt *parser.CreateStreamAsS
 		tb.topology.Remove(outName)
 	}()
 
+	if quality != nil && quality.quarantine != "" {
+		q, err := tb.addQuarantineSource(quality.quarantine)
+		if err != nil {
+			return nil, err
+		}
+		temporaryNodes = append(temporaryNodes, quality.quarantine)
+		box.quality.quarantine = q
+	}
+
 	connected := map[string]bool{}
 	var pausedSources []core.SourceNode
 	for _, rel := range stmt.Select.Relations {
@@ -481,8 +808,34 @@ func (tb *TopologyBuilder) createStreamAsSelectStmt(stmt *parser.CreateStreamAsS
 			} else if rel.Shedding == parser.Wait {
 				conf.DropMode = core.DropNone
 			}
-			if err := dbox.Input(rel.Name, conf); err != nil {
-				return nil, err
+			if filter != nil {
+				// the input is connected via the shared filter with the
+				// original input name so that the statement works as is
+				fn, created, err := tb.sharedFilterNode(filter)
+				if err != nil {
+					return nil, err
+				}
+				if err := dbox.Input(fn.Name(), conf); err != nil {
+					if created {
+						tb.topology.Remove(fn.Name())
+					}
+					return nil, err
+				}
+				if created {
+					fn.StopOnDisconnect(core.Inbound | core.Outbound)
+					fn.RemoveOnStop()
+				}
+			} else if since.IsZero() {
+				if err := dbox.Input(rel.Name, conf); err != nil {
+					return nil, err
+				}
+			} else {
+				sn, name, err := tb.setUpReplayStream(dbox, rel.Name, conf, since)
+				if err != nil {
+					return nil, err
+				}
+				temporaryNodes = append(temporaryNodes, name)
+				pausedSources = append(pausedSources, sn)
 			}
 			connected[rel.Name] = true
 
@@ -501,9 +854,15 @@ func (tb *TopologyBuilder) createStreamAsSelectStmt(stmt *parser.CreateStreamAsS
 				rel.Type)
 		}
 	}
+	if retention != nil {
+		if err := tb.addRetentionSink(outName, retention); err != nil {
+			return nil, err
+		}
+	}
 	dbox.(core.BoxNode).StopOnDisconnect(core.Inbound)
 
-	// Resume all UDSFs running in the source mode as fairly as possible.
+	// Resume all UDSFs running in the source mode and sources replaying
+	// retention buffers as fairly as possible.
 	for _, sn := range pausedSources {
 		if err := sn.Resume(); err != nil {
 			return nil, err
@@ -643,12 +1002,122 @@ func (tb *TopologyBuilder) setUpUDSFStream(subsequentBox core.BoxNode, rel *pars
 	return nil, temporaryName, nil
 }
 
-func (tb *TopologyBuilder) mkParamsMap(params []parser.SourceSinkParamAST) data.Map {
+// setUpReplayStream creates a temporary source replaying tuples written to the
+// retention buffer of the stream at or after since and connects it to the box
+// as the stream. The source keeps emitting live tuples written to the buffer
+// after replaying them. It returns the source and its temporary name. The
+// source is paused and has to be resumed by the caller.
+func (tb *TopologyBuilder) setUpReplayStream(subsequentBox core.BoxNode, stream string,
+	conf *core.BoxInputConfig, since time.Time) (core.SourceNode, string, error) {
+	b, err := tb.retentionBufferOf(stream)
+	if err != nil {
+		return nil, "", err
+	}
+	c := b.cursor(since)
+
+	temporaryName := fmt.Sprintf("sensorbee_tmp_replay_%v", topologyBuilderNextTemporaryID())
+	sn, err := tb.topology.AddSource(temporaryName, &replaySource{c: c}, &core.SourceConfig{
+		PausedOnStartup: true,
+	})
+	if err != nil {
+		c.close()
+		return nil, "", err
+	}
+	if err := subsequentBox.Input(temporaryName, conf); err != nil {
+		tb.topology.Remove(temporaryName)
+		return nil, "", err
+	}
+	sn.StopOnDisconnect()
+	sn.RemoveOnStop()
+	return sn, temporaryName, nil
+}
+
+// mkParamsMap creates a data.Map from parameters given to a WITH clause.
+// A parameter referring to a constant of the topology such as key=$name has
+// the current value of the constant.
+func (tb *TopologyBuilder) mkParamsMap(params []parser.SourceSinkParamAST) (data.Map, error) {
 	paramsMap := make(data.Map, len(params))
 	for _, kv := range params {
-		paramsMap[string(kv.Key)] = kv.Value
+		if kv.Constant == "" {
+			paramsMap[string(kv.Key)] = kv.Value
+			continue
+		}
+		v, err := tb.topology.Context().Constant(kv.Constant)
+		if err != nil {
+			return nil, fmt.Errorf("cannot resolve parameter '%v': %v", kv.Key, err)
+		}
+		paramsMap[string(kv.Key)] = v
+	}
+	return paramsMap, nil
+}
+
+// mkSinkInputConfig creates a core.SinkInputConfig from parameters given to
+// the WITH clause of an INSERT INTO statement. It accepts following
+// parameters:
+//
+//	- routing_field: the name of a field to which the name of the input
+//	  stream is written
+//	- buffer_size: the capacity of the input queue of the sink
+//	- drop: what to do when the input queue is full. "none" (or "block")
+//	  blocks the input stream, "latest" (or "newest") drops the tuple being
+//	  inserted, and "oldest" drops the oldest tuple in the queue
+//	- report_drops: log every tuple dropped due to the drop parameter as a
+//	  warning
+func (tb *TopologyBuilder) mkSinkInputConfig(params []parser.SourceSinkParamAST) (*core.SinkInputConfig, error) {
+	config := &core.SinkInputConfig{}
+	paramsMap, err := tb.mkParamsMap(params)
+	if err != nil {
+		return nil, err
 	}
-	return paramsMap
+	for k, v := range paramsMap {
+		switch k {
+		case "routing_field":
+			f, err := data.AsString(v)
+			if err != nil {
+				return nil, fmt.Errorf("routing_field must be a string: %v", err)
+			}
+			if f == "" {
+				return nil, errors.New("routing_field must not be empty")
+			}
+			config.RoutingField = f
+		case "buffer_size":
+			n, err := data.AsInt(v)
+			if err != nil {
+				return nil, fmt.Errorf("buffer_size must be an integer: %v", err)
+			}
+			if n <= 0 {
+				return nil, fmt.Errorf("buffer_size must be positive: %v", n)
+			}
+			if n > int64(core.MaxCapacity) {
+				return nil, fmt.Errorf("buffer_size %v is too large (max: %v)", n, core.MaxCapacity)
+			}
+			config.Capacity = int(n)
+		case "drop":
+			m, err := data.AsString(v)
+			if err != nil {
+				return nil, fmt.Errorf("drop must be a string: %v", err)
+			}
+			switch strings.ToLower(m) {
+			case "none", "block":
+				config.DropMode = core.DropNone
+			case "latest", "newest":
+				config.DropMode = core.DropLatest
+			case "oldest":
+				config.DropMode = core.DropOldest
+			default:
+				return nil, fmt.Errorf("drop must be one of 'none', 'latest', or 'oldest': %v", m)
+			}
+		case "report_drops":
+			b, err := data.AsBool(v)
+			if err != nil {
+				return nil, fmt.Errorf("report_drops must be a boolean: %v", err)
+			}
+			config.ReportDrops = b
+		default:
+			return nil, fmt.Errorf("unsupported parameter for INSERT INTO: %v", k)
+		}
+	}
+	return config, nil
 }
 
 type chanSink struct {
@@ -693,21 +1162,81 @@ func (s *chanSink) Close(ctx *core.Context) error {
 	return nil
 }
 
+// validateSelectUnion checks that all SELECT statements combined by UNION
+// have the same output fields so that tuples of the resulting stream are
+// aligned. The order of fields doesn't matter. A statement having a
+// wildcard isn't checked because its fields depend on input tuples. SELECT
+// statements combined by UNION ALL can have different fields.
+func validateSelectUnion(stmt *parser.SelectUnionStmt) error {
+	if !stmt.Aligned {
+		return nil
+	}
+	var (
+		expected []string
+		first    int
+	)
+	for i := range stmt.Selects {
+		fields, ok := execution.OutputFields(&stmt.Selects[i])
+		if !ok {
+			continue
+		}
+		sort.Strings(fields)
+		if expected == nil {
+			expected, first = fields, i
+			continue
+		}
+		same := len(fields) == len(expected)
+		for j := 0; same && j < len(fields); j++ {
+			same = fields[j] == expected[j]
+		}
+		if !same {
+			return fmt.Errorf("SELECT statements combined by UNION must have the same fields: "+
+				"SELECT #%v has %v but SELECT #%v has %v (use UNION ALL to combine different fields)",
+				first+1, expected, i+1, fields)
+		}
+	}
+	return nil
+}
+
 // AddSelectStmt creates nodes handling a SELECT statement in the topology.
 // It returns the Sink node and the channel tied to it, the chan receiving
 // tuples from the Sink, and an error if happens. The caller must stop the
 // Sink node once it get unnecessary.
 func (tb *TopologyBuilder) AddSelectStmt(stmt *parser.SelectStmt) (core.SinkNode, <-chan *core.Tuple, error) {
 	// wrap this in a UNION statement
-	tmpStmt := parser.SelectUnionStmt{[]parser.SelectStmt{*stmt}}
+	tmpStmt := parser.SelectUnionStmt{Selects: []parser.SelectStmt{*stmt}}
 	return tb.AddSelectUnionStmt(&tmpStmt)
 }
 
-// AddSelectUnionStmt creates nodes handling a SELECT ... UNION ALL statement
+// AddSelectUnionStmt creates nodes handling a SELECT ... UNION [ALL] statement
 // in the topology. It returns the Sink node and the channel tied to it, the
 // chan receiving tuples from the Sink, and an error if happens. The caller must
 // stop the Sink node once it get unnecessary.
 func (tb *TopologyBuilder) AddSelectUnionStmt(stmts *parser.SelectUnionStmt) (core.SinkNode, <-chan *core.Tuple, error) {
+	return tb.addSelectUnionStmt(stmts, time.Time{})
+}
+
+// AddSelectStartingStmt creates nodes handling a SELECT ... STARTING ... AGO
+// statement in the topology. It's same as AddSelectStmt except that tuples
+// written to input streams during the given period are replayed from their
+// retention buffers before live tuples. Replayed tuples have core.TFBackfill
+// flag. All input streams must have retention buffers.
+func (tb *TopologyBuilder) AddSelectStartingStmt(stmt *parser.SelectStartingStmt) (core.SinkNode, <-chan *core.Tuple, error) {
+	d := time.Duration(stmt.Starting.Value * float64(time.Second))
+	if stmt.Starting.Unit == parser.Milliseconds {
+		d = time.Duration(stmt.Starting.Value * float64(time.Millisecond))
+	}
+	since := tb.topology.Context().Clock().Now().Add(-d)
+	tmpStmt := parser.SelectUnionStmt{Selects: []parser.SelectStmt{stmt.SelectStmt}}
+	return tb.addSelectUnionStmt(&tmpStmt, since)
+}
+
+// addSelectUnionStmt is AddSelectUnionStmt replaying input streams from since.
+// Input streams are connected directly when since is zero.
+func (tb *TopologyBuilder) addSelectUnionStmt(stmts *parser.SelectUnionStmt, since time.Time) (core.SinkNode, <-chan *core.Tuple, error) {
+	if err := validateSelectUnion(stmts); err != nil {
+		return nil, nil, err
+	}
 	sink, ch := newChanSink()
 	tmpUnionNodeName := fmt.Sprintf("sensorbee_tmp_select_sink_%v", topologyBuilderNextTemporaryID())
 	sn, err := tb.topology.AddSink(tmpUnionNodeName, sink, nil)
@@ -743,12 +1272,14 @@ func (tb *TopologyBuilder) AddSelectUnionStmt(stmts *parser.SelectUnionStmt) (co
 					stmt.EmitterAST,
 					stmt.ProjectionsAST,
 					stmt.WindowedFromAST,
+					stmt.DeduplicateAST,
 					stmt.FilterAST,
 					stmt.GroupingAST,
 					stmt.HavingAST,
 				},
+				parser.SourceSinkSpecsAST{},
 			}
-			box, err := tb.AddStmt(tmpStmt)
+			box, err := tb.createStreamAsSelectStmt(&tmpStmt, since, nil)
 			if err != nil {
 				return nil, err
 			}
@@ -832,6 +1363,74 @@ func (tb *TopologyBuilder) RunEvalStmt(stmt *parser.EvalStmt) (data.Value, error
 	return execution.EvaluateOnInput(expr, inputRow, tb.Reg)
 }
 
+// RunShowFunctionsStmt returns functions available in the topology sorted by
+// their names. Each element of the result is a data.Map having following
+// fields:
+//
+//	- name: the name of the function
+//	- arity: numbers of arguments the function accepts, e.g. "1, 2" or "2+"
+//	- aggregate: true if the function is an aggregate function
+//	- doc: the documentation of the function, which can be empty
+func (tb *TopologyBuilder) RunShowFunctionsStmt(stmt *parser.ShowFunctionsStmt) (data.Array, error) {
+	fs, err := tb.Reg.List()
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(fs))
+	for n := range fs {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	res := make(data.Array, 0, len(names))
+	for _, n := range names {
+		f := fs[n]
+		res = append(res, data.Map{
+			"name":      data.String(n),
+			"arity":     data.String(f.Arity()),
+			"aggregate": data.Bool(f.IsAggregate()),
+			"doc":       data.String(f.Doc),
+		})
+	}
+	return res, nil
+}
+
+// RunReloadFunctionStmt reloads the implementation of a function
+// implementing udf.ReloadableUDF and returns the version of the new
+// implementation. Because functions registered globally are shared by all
+// topologies, they're reloaded in all topologies.
+func (tb *TopologyBuilder) RunReloadFunctionStmt(stmt *parser.ReloadFunctionStmt) (int64, error) {
+	f, err := tb.reloadableFunction(string(stmt.Function))
+	if err != nil {
+		return 0, err
+	}
+	ver, err := f.Reload(tb.topology.Context())
+	if err != nil {
+		return 0, fmt.Errorf("cannot reload function '%v': %v", stmt.Function, err)
+	}
+	return ver, nil
+}
+
+// reloadableFunction returns a function which can be reloaded.
+func (tb *TopologyBuilder) reloadableFunction(name string) (udf.ReloadableUDF, error) {
+	fs, err := tb.Reg.List()
+	if err != nil {
+		return nil, err
+	}
+	i, ok := fs[strings.ToLower(name)]
+	if !ok {
+		return nil, core.NotExistError(fmt.Errorf("function '%v' is unknown", name))
+	}
+	f, ok := i.UDF.(udf.ReloadableUDF)
+	if !ok {
+		return nil, fmt.Errorf("function '%v' cannot be reloaded", name)
+	}
+	return f, nil
+}
+
+// saveState saves the state as a checkpoint. Sinks are flushed before the
+// state is saved so that results written before the checkpoint are durable.
+// The state isn't saved when a sink cannot be flushed.
 func (tb *TopologyBuilder) saveState(name, tag string) error {
 	st, err := tb.topology.Context().SharedStates.Get(name)
 	if err != nil {
@@ -841,6 +1440,9 @@ func (tb *TopologyBuilder) saveState(name, tag string) error {
 	if !ok {
 		return fmt.Errorf("the state '%v-%v' cannot be saved", name, tag)
 	}
+	if err := tb.topology.FlushSinks(); err != nil {
+		return fmt.Errorf("cannot flush sinks before saving the state '%v-%v': %v", name, tag, err)
+	}
 
 	// Appropriate header information should be written by the storage.
 	w, err := tb.UDSStorage.Save(tb.topology.Name(), name, tag)
@@ -896,7 +1498,11 @@ func (tb *TopologyBuilder) loadState(typeName, name, tag string, params data.Map
 	}
 
 	if l, ok := s.(core.LoadableSharedState); ok {
-		return false, l.Load(tb.topology.Context(), r, params)
+		if err := l.Load(tb.topology.Context(), r, params); err != nil {
+			return false, err
+		}
+		tb.notifyStateLoaded(name, tag)
+		return false, nil
 	}
 
 	newState, err := loader.LoadState(tb.topology.Context(), r, params)
@@ -913,5 +1519,11 @@ func (tb *TopologyBuilder) loadState(typeName, name, tag string, params data.Map
 				Error("Cannot terminate the previous instance of the loaded state")
 		}
 	}
+	tb.notifyStateLoaded(name, tag)
 	return false, nil
 }
+
+// notifyStateLoaded reports that the state is overwritten by LOAD STATE.
+func (tb *TopologyBuilder) notifyStateLoaded(name, tag string) {
+	tb.topology.Context().NotifyStateUpdate(name, core.SUELoaded, data.Map{"tag": data.String(tag)})
+}
@@ -391,6 +391,42 @@ func TestCreateStreamAsSelectUnionStmt(t *testing.T) {
 			})
 		})
 
+		Convey("When running CREATE STREAM AS SELECT UNION with the same fields", func() {
+			err := addBQLToTopology(tb, `CREATE STREAM t AS SELECT ISTREAM int, "a" AS src FROM s [RANGE 2 SECONDS]
+				UNION SELECT ISTREAM "b" AS src, int+1 AS int FROM s [RANGE 2 SECONDS]
+				UNION SELECT ISTREAM * FROM s [RANGE 2 SECONDS]`)
+
+			Convey("Then there should be no error", func() {
+				So(err, ShouldBeNil)
+			})
+		})
+
+		Convey("When running CREATE STREAM AS SELECT UNION with different fields", func() {
+			prevNodes := len(tb.topology.Nodes())
+			err := addBQLToTopology(tb, `CREATE STREAM t AS SELECT ISTREAM int, "a" AS src FROM s [RANGE 2 SECONDS]
+				UNION SELECT ISTREAM int, "b" AS source FROM s [RANGE 2 SECONDS]`)
+
+			Convey("Then an error should be returned", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "same fields")
+				So(len(tb.topology.Nodes()), ShouldEqual, prevNodes)
+			})
+		})
+
+		Convey("When running SELECT UNION with different fields", func() {
+			istmt, _, err := parser.New().ParseStmt(`SELECT ISTREAM int FROM s [RANGE 2 SECONDS]
+				UNION SELECT ISTREAM int+1 FROM s [RANGE 2 SECONDS]`)
+			So(err, ShouldBeNil)
+			stmt := istmt.(parser.SelectUnionStmt)
+			So(stmt.Aligned, ShouldBeTrue)
+			_, _, err = tb.AddSelectUnionStmt(&stmt)
+
+			Convey("Then an error should be returned", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "[col_0]")
+			})
+		})
+
 		Convey("When running CREATE STREAM AS SELECT on a non-existing stream (1)", func() {
 			prevNodes := len(tb.topology.Nodes())
 			err := addBQLToTopology(tb, `CREATE STREAM x AS SELECT ISTREAM int FROM bar [RANGE 2 SECONDS]
@@ -492,6 +528,108 @@ func TestInsertIntoFromStmt(t *testing.T) {
 			})
 		})
 
+		Convey("When running INSERT INTO with multiple inputs and a routing field", func() {
+			err := addBQLToTopology(tb, `INSERT INTO foo FROM s, t WITH routing_field="origin"`)
+
+			Convey("Then there should be no error", func() {
+				So(err, ShouldBeNil)
+
+				Convey("And the sink should receive tuples tagged with their origins", func() {
+					sn, err := dt.Sink("foo")
+					So(err, ShouldBeNil)
+					si := sn.Sink().(*tupleCollectorSink)
+					So(addBQLToTopology(tb, `RESUME SOURCE s`), ShouldBeNil)
+					si.Wait(8)
+
+					origins := map[string]int{}
+					si.forEachTuple(func(t *core.Tuple) {
+						So(t.Data["origin"], ShouldEqual, data.String(t.InputName))
+						origins[t.InputName]++
+					})
+					So(origins, ShouldResemble, map[string]int{"s": 4, "t": 4})
+				})
+			})
+		})
+
+		Convey("When running INSERT INTO with the same input twice", func() {
+			err := addBQLToTopology(tb, `INSERT INTO foo FROM s, S`)
+
+			Convey("Then an error should be returned", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "more than once")
+			})
+		})
+
+		Convey("When running INSERT INTO with an unsupported parameter", func() {
+			err := addBQLToTopology(tb, `INSERT INTO foo FROM s WITH hoge="origin"`)
+
+			Convey("Then an error should be returned", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "unsupported parameter")
+			})
+		})
+
+		Convey("When running INSERT INTO with drop policy parameters", func() {
+			err := addBQLToTopology(tb, `INSERT INTO foo FROM s WITH buffer_size=16, drop="oldest", report_drops=true`)
+
+			Convey("Then there should be no error", func() {
+				So(err, ShouldBeNil)
+			})
+		})
+
+		Convey("When creating a sink input config with drop policy parameters", func() {
+			c, err := tb.mkSinkInputConfig([]parser.SourceSinkParamAST{
+				{Key: "buffer_size", Value: data.Int(16)},
+				{Key: "drop", Value: data.String("Newest")},
+				{Key: "report_drops", Value: data.True},
+			})
+
+			Convey("Then the config should have them", func() {
+				So(err, ShouldBeNil)
+				So(c.Capacity, ShouldEqual, 16)
+				So(c.DropMode, ShouldEqual, core.DropLatest)
+				So(c.ReportDrops, ShouldBeTrue)
+			})
+		})
+
+		Convey("When running INSERT INTO with invalid drop policy parameters", func() {
+			for _, p := range []string{
+				"buffer_size=0",
+				"buffer_size=-1",
+				"buffer_size=1000000000",
+				`buffer_size="a"`,
+				`drop="random"`,
+				"drop=1",
+				`report_drops="a"`,
+			} {
+				err := addBQLToTopology(tb, `INSERT INTO foo FROM s WITH `+p)
+
+				Convey("Then an error should be returned: "+p, func() {
+					So(err, ShouldNotBeNil)
+				})
+			}
+		})
+
+		Convey("When running INSERT INTO with a non-string routing field", func() {
+			err := addBQLToTopology(tb, `INSERT INTO foo FROM s WITH routing_field=1`)
+
+			Convey("Then an error should be returned", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When running INSERT INTO with a non-existing stream among inputs", func() {
+			err := addBQLToTopology(tb, `INSERT INTO foo FROM s, bar`)
+
+			Convey("Then an error should be returned", func() {
+				So(err, ShouldNotBeNil)
+
+				Convey("And the sink should not be connected to any input", func() {
+					So(addBQLToTopology(tb, `INSERT INTO foo FROM s`), ShouldBeNil)
+				})
+			})
+		})
+
 		Convey("When running INSERT INTO on a non-existing stream", func() {
 			err := addBQLToTopology(tb, `INSERT INTO foo FROM foo`)
 
@@ -512,6 +650,183 @@ func TestInsertIntoFromStmt(t *testing.T) {
 	})
 }
 
+func TestInsertIntoSelectStmt(t *testing.T) {
+	Convey("Given a BQL TopologyBuilder with source and sink", t, func() {
+		dt := newTestTopology()
+		Reset(func() {
+			dt.Stop()
+		})
+		tb, err := NewTopologyBuilder(dt)
+		So(err, ShouldBeNil)
+		err = addBQLToTopology(tb, `CREATE PAUSED SOURCE s TYPE dummy`)
+		So(err, ShouldBeNil)
+		err = addBQLToTopology(tb, `CREATE SINK foo TYPE collector`)
+		So(err, ShouldBeNil)
+
+		Convey("When running INSERT INTO SELECT", func() {
+			err := addBQLToTopology(tb, `INSERT INTO foo SELECT RSTREAM int * 2 AS x FROM s [RANGE 1 TUPLES]`)
+			So(err, ShouldBeNil)
+
+			Convey("Then the sink should receive results of the SELECT", func() {
+				sn, err := dt.Sink("foo")
+				So(err, ShouldBeNil)
+				si := sn.Sink().(*tupleCollectorSink)
+				So(addBQLToTopology(tb, `RESUME SOURCE s`), ShouldBeNil)
+				si.Wait(4)
+
+				i := 0
+				si.forEachTuple(func(t *core.Tuple) {
+					i++
+					So(t.Data, ShouldResemble, data.Map{"x": data.Int(2 * i)})
+				})
+			})
+
+			Convey("Then an anonymous stream should be created", func() {
+				boxes := dt.Boxes()
+				So(len(boxes), ShouldEqual, 1)
+				for name := range boxes {
+					So(name, ShouldStartWith, "sensorbee_tmp_")
+				}
+
+				Convey("And it should be removed when the sink is dropped", func() {
+					So(addBQLToTopology(tb, `DROP SINK foo`), ShouldBeNil)
+					for i := 0; i < 100 && len(dt.Boxes()) > 0; i++ {
+						time.Sleep(10 * time.Millisecond)
+					}
+					So(dt.Boxes(), ShouldBeEmpty)
+				})
+			})
+		})
+
+		Convey("When running INSERT INTO SELECT on a non-existing sink", func() {
+			err := addBQLToTopology(tb, `INSERT INTO bar SELECT RSTREAM * FROM s [RANGE 1 TUPLES]`)
+
+			Convey("Then an error should be returned", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "was not found")
+				So(dt.Boxes(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When running INSERT INTO SELECT from a non-existing stream", func() {
+			err := addBQLToTopology(tb, `INSERT INTO foo SELECT RSTREAM * FROM bar [RANGE 1 TUPLES]`)
+
+			Convey("Then an error should be returned", func() {
+				So(err, ShouldNotBeNil)
+				So(dt.Boxes(), ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestSplitStmt(t *testing.T) {
+	Convey("Given a BQL TopologyBuilder with a source", t, func() {
+		dt := newTestTopology()
+		Reset(func() {
+			dt.Stop()
+		})
+		tb, err := NewTopologyBuilder(dt)
+		So(err, ShouldBeNil)
+		err = addBQLToTopology(tb, `CREATE PAUSED SOURCE s TYPE dummy`)
+		So(err, ShouldBeNil)
+
+		Convey("When running SPLIT with an OTHERWISE branch", func() {
+			err := addBQLToTopology(tb, `SPLIT s INTO odd WHEN int % 2 = 1, big WHEN s:int > 3, rest OTHERWISE`)
+
+			Convey("Then there should be no error", func() {
+				So(err, ShouldBeNil)
+
+				Convey("And each tuple should be routed to the first matching branch", func() {
+					sinks := map[string]*tupleCollectorSink{}
+					for _, name := range []string{"odd", "big", "rest"} {
+						So(addBQLToTopology(tb, fmt.Sprintf(`CREATE SINK %v_snk TYPE collector;
+							INSERT INTO %v_snk FROM %v`, name, name, name)), ShouldBeNil)
+						sn, err := dt.Sink(name + "_snk")
+						So(err, ShouldBeNil)
+						sinks[name] = sn.Sink().(*tupleCollectorSink)
+					}
+					So(addBQLToTopology(tb, `RESUME SOURCE s`), ShouldBeNil)
+
+					ints := func(name string, n int) []data.Value {
+						si := sinks[name]
+						si.Wait(n)
+						var vs []data.Value
+						si.forEachTuple(func(t *core.Tuple) {
+							vs = append(vs, t.Data["int"])
+						})
+						return vs
+					}
+					So(ints("odd", 2), ShouldResemble, []data.Value{data.Int(1), data.Int(3)})
+					So(ints("big", 1), ShouldResemble, []data.Value{data.Int(4)})
+					So(ints("rest", 1), ShouldResemble, []data.Value{data.Int(2)})
+				})
+			})
+
+			Convey("And dropping all branches", func() {
+				So(err, ShouldBeNil)
+				for _, name := range []string{"odd", "big", "rest"} {
+					So(addBQLToTopology(tb, `DROP STREAM `+name), ShouldBeNil)
+				}
+
+				Convey("Then the router should also be removed", func() {
+					for i := 0; i < 100 && len(dt.Nodes()) > 1; i++ {
+						time.Sleep(10 * time.Millisecond)
+					}
+					So(dt.Nodes(), ShouldHaveLength, 1)
+				})
+			})
+		})
+
+		Convey("When running SPLIT with a branch having the same name as an existing node", func() {
+			So(addBQLToTopology(tb, `CREATE STREAM t AS SELECT RSTREAM * FROM s [RANGE 1 TUPLES]`), ShouldBeNil)
+			err := addBQLToTopology(tb, `SPLIT s INTO a WHEN int > 1, t OTHERWISE`)
+
+			Convey("Then an error should be returned", func() {
+				So(err, ShouldNotBeNil)
+
+				Convey("And no node should be left", func() {
+					So(dt.Nodes(), ShouldHaveLength, 2)
+				})
+			})
+		})
+
+		Convey("When running SPLIT with the same branch twice", func() {
+			err := addBQLToTopology(tb, `SPLIT s INTO a WHEN int > 1, A OTHERWISE`)
+
+			Convey("Then an error should be returned", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "more than once")
+			})
+		})
+
+		Convey("When running SPLIT with a condition referring to another stream", func() {
+			err := addBQLToTopology(tb, `SPLIT s INTO a WHEN t:int > 1`)
+
+			Convey("Then an error should be returned", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "unknown stream")
+			})
+		})
+
+		Convey("When running SPLIT with an aggregate function", func() {
+			err := addBQLToTopology(tb, `SPLIT s INTO a WHEN count(int) > 1`)
+
+			Convey("Then an error should be returned", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When running SPLIT on a non-existing stream", func() {
+			err := addBQLToTopology(tb, `SPLIT foo INTO a WHEN int > 1`)
+
+			Convey("Then an error should be returned", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "was not found")
+			})
+		})
+	})
+}
+
 func TestMultipleStatements(t *testing.T) {
 	Convey("Given an empty BQL TopologyBuilder", t, func() {
 		dt := newTestTopology()
@@ -801,6 +1116,90 @@ func TestSaveLoadStateStmt(t *testing.T) {
 	})
 }
 
+func TestStateUpdatesUDSF(t *testing.T) {
+	Convey("Given a BQL TopologyBuilder with a stream of state updates", t, func() {
+		dt := newTestTopology()
+		Reset(func() {
+			dt.Stop()
+		})
+		tb, err := NewTopologyBuilder(dt)
+		So(err, ShouldBeNil)
+		So(addBQLToTopology(tb, `
+			CREATE STATE s1 TYPE dummy_updatable_uds WITH num=1;
+			CREATE STATE s2 TYPE dummy_updatable_uds WITH num=2;
+			CREATE STREAM updates AS SELECT RSTREAM state_name, event_type, info
+				FROM state_updates("s2") [RANGE 1 TUPLES];
+			CREATE SINK snk TYPE collector;
+			INSERT INTO snk FROM updates;
+		`), ShouldBeNil)
+		sn, err := dt.Sink("snk")
+		So(err, ShouldBeNil)
+		si := sn.Sink().(*tupleCollectorSink)
+
+		// The UDSF starts receiving events asynchronously.
+		for si.len() == 0 {
+			So(addBQLToTopology(tb, `UPDATE STATE s2 SET num=2;`), ShouldBeNil)
+			time.Sleep(10 * time.Millisecond)
+		}
+
+		// waitEvent returns the event having the type and the info. It
+		// returns nil when such event doesn't arrive.
+		waitEvent := func(eventType string, info data.Map) data.Map {
+			for i := 0; i < 500; i++ {
+				for j := si.len() - 1; j >= 0; j-- {
+					m := si.get(j).Data
+					if m["event_type"] == data.String(eventType) && (info == nil || data.Equal(m["info"], info)) {
+						return m
+					}
+				}
+				time.Sleep(10 * time.Millisecond)
+			}
+			return nil
+		}
+
+		Convey("When updating the state", func() {
+			So(addBQLToTopology(tb, `UPDATE STATE s2 SET num=20;`), ShouldBeNil)
+
+			Convey("Then the stream should have an event with the parameters", func() {
+				m := waitEvent("updated", data.Map{"params": data.Map{"num": data.Int(20)}})
+				So(m, ShouldNotBeNil)
+				So(m["state_name"], ShouldEqual, "s2")
+			})
+		})
+
+		Convey("When loading the state", func() {
+			So(addBQLToTopology(tb, `SAVE STATE s2 TAG v1;`), ShouldBeNil)
+			So(addBQLToTopology(tb, `LOAD STATE s2 TYPE dummy_updatable_uds TAG v1;`), ShouldBeNil)
+
+			Convey("Then the stream should have an event with the tag", func() {
+				m := waitEvent("loaded", data.Map{"tag": data.String("v1")})
+				So(m, ShouldNotBeNil)
+				So(m["state_name"], ShouldEqual, "s2")
+			})
+		})
+
+		Convey("When dropping the state", func() {
+			So(addBQLToTopology(tb, `DROP STATE s2;`), ShouldBeNil)
+
+			Convey("Then the stream should have an event", func() {
+				So(waitEvent("dropped", nil), ShouldNotBeNil)
+			})
+		})
+
+		Convey("When updating another state", func() {
+			So(addBQLToTopology(tb, `UPDATE STATE s1 SET num=10;`), ShouldBeNil)
+			So(addBQLToTopology(tb, `DROP STATE s2;`), ShouldBeNil)
+
+			Convey("Then the stream shouldn't have its event", func() {
+				So(waitEvent("dropped", nil), ShouldNotBeNil)
+				si.forEachTuple(func(t *core.Tuple) {
+					So(t.Data["state_name"], ShouldEqual, "s2")
+				})
+			})
+		})
+	})
+}
+
 func TestUpdateSourceStmt(t *testing.T) {
 	Convey("Given a BQL TopologyBuilder", t, func() {
 		dt := newTestTopology()
@@ -1008,6 +1407,214 @@ func TestSelectUnionStmt(t *testing.T) {
 	})
 }
 
+func TestShowFunctionsStmt(t *testing.T) {
+	Convey("Given a BQL TopologyBuilder having a documented UDF", t, func() {
+		dt := newTestTopology()
+		Reset(func() {
+			dt.Stop()
+		})
+		tb, err := NewTopologyBuilder(dt)
+		So(err, ShouldBeNil)
+		So(tb.Reg.Register("my_func", udf.BinaryFunc(func(ctx *core.Context, a, b data.Value) (data.Value, error) {
+			return a, nil
+		}), udf.WithDoc("my_func(a, b) returns a")), ShouldBeNil)
+
+		Convey("When issuing a SHOW FUNCTIONS stmt", func() {
+			bp := parser.New()
+			istmt, _, err := bp.ParseStmt(`SHOW FUNCTIONS`)
+			So(err, ShouldBeNil)
+			stmt := istmt.(parser.ShowFunctionsStmt)
+			res, err := tb.RunShowFunctionsStmt(&stmt)
+			So(err, ShouldBeNil)
+
+			find := func(name string) data.Map {
+				for _, v := range res {
+					m := v.(data.Map)
+					if m["name"] == data.String(name) {
+						return m
+					}
+				}
+				return nil
+			}
+
+			Convey("Then it should return the UDF with its documentation", func() {
+				So(find("my_func"), ShouldResemble, data.Map{
+					"name":      data.String("my_func"),
+					"arity":     data.String("2"),
+					"aggregate": data.Bool(false),
+					"doc":       data.String("my_func(a, b) returns a"),
+				})
+			})
+
+			Convey("Then it should return built-in functions", func() {
+				So(find("str"), ShouldNotBeNil)
+			})
+
+			Convey("Then functions should be sorted by their names", func() {
+				for i := 1; i < len(res); i++ {
+					prev, _ := data.AsString(res[i-1].(data.Map)["name"])
+					cur, _ := data.AsString(res[i].(data.Map)["name"])
+					So(prev, ShouldBeLessThan, cur)
+				}
+			})
+		})
+	})
+}
+
+func TestExplainAnalyzeStmt(t *testing.T) {
+	Convey("Given a BQL TopologyBuilder with a paused source", t, func() {
+		dt := newTestTopology()
+		Reset(func() {
+			dt.Stop()
+		})
+		tb, err := NewTopologyBuilder(dt)
+		So(err, ShouldBeNil)
+		So(addBQLToTopology(tb, `CREATE PAUSED SOURCE s TYPE dummy WITH num=4, resumable=false;`), ShouldBeNil)
+
+		run := func(stmtStr string) (data.Map, error) {
+			bp := parser.New()
+			istmt, _, err := bp.ParseStmt(stmtStr)
+			So(err, ShouldBeNil)
+			stmt := istmt.(parser.ExplainAnalyzeStmt)
+			return tb.RunExplainAnalyzeStmt(&stmt)
+		}
+
+		Convey("When issuing an EXPLAIN ANALYZE stmt and resuming the source", func() {
+			numBoxes := len(dt.Boxes())
+			go func() {
+				// resume the source after the statement is connected to it
+				waitForExpectedCondition(func() bool {
+					return len(dt.Boxes()) > numBoxes
+				})
+				addBQLToTopology(tb, `RESUME SOURCE s;`)
+			}()
+			res, err := run(`EXPLAIN ANALYZE FOR 4 TUPLES
+				SELECT ISTREAM int FROM s [RANGE 2 TUPLES] WHERE int % 2 = 0;`)
+			So(err, ShouldBeNil)
+
+			Convey("Then it should report the numbers of tuples and results", func() {
+				So(res["tuples"], ShouldEqual, data.Int(4))
+				So(res["results"], ShouldEqual, data.Int(2))
+			})
+
+			Convey("Then it should report each operator", func() {
+				ops, err := data.AsArray(res["operators"])
+				So(err, ShouldBeNil)
+				calls := map[string]data.Value{}
+				for _, o := range ops {
+					m := o.(data.Map)
+					name, _ := data.AsString(m["operator"])
+					calls[name] = m["calls"]
+					So(m["time"], ShouldHaveSameTypeAs, data.Float(0))
+					So(m["time_ratio"], ShouldHaveSameTypeAs, data.Float(0))
+					So(m["alloc_bytes"], ShouldHaveSameTypeAs, data.Int(0))
+					So(m["alloc_objects"], ShouldHaveSameTypeAs, data.Int(0))
+				}
+				So(calls, ShouldResemble, map[string]data.Value{
+					"other":      data.Int(4),
+					"window":     data.Int(4),
+					"filter":     data.Int(4),
+					"projection": data.Int(4),
+					"emit":       data.Int(4),
+				})
+			})
+
+			Convey("Then the temporary stream should be removed", func() {
+				So(dt.Boxes(), ShouldHaveLength, numBoxes)
+			})
+		})
+
+		Convey("When issuing an EXPLAIN ANALYZE stmt referencing an unknown stream", func() {
+			numNodes := len(dt.Nodes())
+			_, err := run(`EXPLAIN ANALYZE SELECT ISTREAM * FROM hoge [RANGE 1 TUPLES];`)
+
+			Convey("Then it should fail without leaving any node", func() {
+				So(err, ShouldNotBeNil)
+				So(dt.Nodes(), ShouldHaveLength, numNodes)
+			})
+		})
+
+		Convey("When issuing an EXPLAIN ANALYZE stmt with a zero limit", func() {
+			_, err := run(`EXPLAIN ANALYZE FOR 0 SECONDS SELECT ISTREAM * FROM s [RANGE 1 TUPLES];`)
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+func TestReloadFunctionStmt(t *testing.T) {
+	Convey("Given a BQL TopologyBuilder having a reloadable UDF", t, func() {
+		dt := newTestTopology()
+		Reset(func() {
+			dt.Stop()
+		})
+		tb, err := NewTopologyBuilder(dt)
+		So(err, ShouldBeNil)
+		c := int64(0)
+		f, err := udf.NewReloadableFunc(dt.Context(), func(ctx *core.Context) (udf.UDF, error) {
+			c++
+			v := data.Int(c)
+			return udf.NullaryFunc(func(ctx *core.Context) (data.Value, error) {
+				return v, nil
+			}), nil
+		})
+		So(err, ShouldBeNil)
+		So(tb.Reg.Register("my_script", f), ShouldBeNil)
+		So(tb.Reg.Register("my_func", udf.NullaryFunc(func(ctx *core.Context) (data.Value, error) {
+			return data.Null{}, nil
+		})), ShouldBeNil)
+
+		Convey("When issuing a RELOAD FUNCTION stmt", func() {
+			err := addBQLToTopology(tb, `RELOAD FUNCTION my_script`)
+			So(err, ShouldBeNil)
+
+			Convey("Then the function should have the new implementation", func() {
+				So(f.Version(), ShouldEqual, 2)
+				bp := parser.New()
+				istmt, _, err := bp.ParseStmt(`EVAL my_script()`)
+				So(err, ShouldBeNil)
+				stmt := istmt.(parser.EvalStmt)
+				v, err := tb.RunEvalStmt(&stmt)
+				So(err, ShouldBeNil)
+				So(v, ShouldEqual, data.Int(2))
+			})
+		})
+
+		Convey("When planning a RELOAD FUNCTION stmt", func() {
+			stmts, err := parser.New().ParseStmts(`RELOAD FUNCTION my_script; RELOAD FUNCTION my_func;
+				RELOAD FUNCTION no_such_func;`)
+			So(err, ShouldBeNil)
+			p := tb.Plan(stmts)
+
+			Convey("Then it should report functions which cannot be reloaded", func() {
+				So(p.Errors, ShouldHaveLength, 2)
+				So(p.Errors[0].Index, ShouldEqual, 1)
+				So(p.Errors[1].Index, ShouldEqual, 2)
+				So(f.Version(), ShouldEqual, 1)
+			})
+		})
+
+		Convey("When reloading a function which isn't reloadable", func() {
+			err := addBQLToTopology(tb, `RELOAD FUNCTION my_func`)
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When reloading an unknown function", func() {
+			err := addBQLToTopology(tb, `RELOAD FUNCTION no_such_func`)
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+				So(core.IsNotExist(err), ShouldBeTrue)
+			})
+		})
+	})
+}
+
 func TestEvalStmt(t *testing.T) {
 	Convey("Given a BQL TopologyBuilder", t, func() {
 		dt := newTestTopology()
@@ -10,14 +10,40 @@ import (
 	"sort"
 )
 
+const (
+	// ignoreNulls is the mode of aggregate functions in which Null
+	// values are removed from the input before aggregation.
+	ignoreNulls = "ignore_nulls"
+
+	// respectNulls is the mode of aggregate functions in which Null
+	// values are aggregated like other values.
+	respectNulls = "respect_nulls"
+)
+
 // singleParamAggFunc is a template for aggregate functions that
-// have exactly one parameter
+// have exactly one aggregated parameter. The optional second
+// parameter is the mode specifying how Null values are handled:
+//
+//   - "ignore_nulls": Null values are ignored
+//   - "respect_nulls": Null values are aggregated. The result is
+//     Null when the input contains Null unless the function
+//     aggregates Null values by itself, e.g. count and array_agg.
+//
+// The default mode is "ignore_nulls" unless respectNullsByDefault
+// is true.
 type singleParamAggFunc struct {
 	aggFun func([]data.Value) (data.Value, error)
+
+	// respectNullsByDefault makes "respect_nulls" the default mode.
+	respectNullsByDefault bool
+
+	// aggregatesNulls is true when aggFun computes a result from
+	// Null values in the "respect_nulls" mode.
+	aggregatesNulls bool
 }
 
 func (f *singleParamAggFunc) Accept(arity int) bool {
-	return arity == 1
+	return arity == 1 || arity == 2
 }
 
 func (f *singleParamAggFunc) IsAggregationParameter(k int) bool {
@@ -25,16 +51,63 @@ func (f *singleParamAggFunc) IsAggregationParameter(k int) bool {
 }
 
 func (f *singleParamAggFunc) Call(ctx *core.Context, args ...data.Value) (data.Value, error) {
-	if len(args) != 1 {
-		return nil, fmt.Errorf("function takes exactly one argument")
+	if len(args) != 1 && len(args) != 2 {
+		return nil, fmt.Errorf("function takes one or two arguments")
 	}
 	arr, err := data.AsArray(args[0])
 	if err != nil {
 		return nil, fmt.Errorf("function needs array input, not %T", args[0])
 	}
+	respect := f.respectNullsByDefault
+	if len(args) == 2 {
+		mode, err := data.AsString(args[1])
+		if err != nil {
+			return nil, fmt.Errorf("the mode must be a string, not %T", args[1])
+		}
+		switch mode {
+		case ignoreNulls:
+			respect = false
+		case respectNulls:
+			respect = true
+		default:
+			return nil, fmt.Errorf("the mode must be '%v' or '%v': %v", ignoreNulls, respectNulls, mode)
+		}
+	}
+
+	if !respect {
+		return f.aggFun(withoutNulls(arr))
+	}
+	if !f.aggregatesNulls {
+		for _, item := range arr {
+			if item.Type() == data.TypeNull {
+				return data.Null{}, nil
+			}
+		}
+	}
 	return f.aggFun(arr)
 }
 
+// withoutNulls returns arr without Null values. It returns arr as
+// is when it doesn't have any Null value.
+func withoutNulls(arr data.Array) data.Array {
+	n := 0
+	for _, item := range arr {
+		if item.Type() == data.TypeNull {
+			n++
+		}
+	}
+	if n == 0 {
+		return arr
+	}
+	res := make(data.Array, 0, len(arr)-n)
+	for _, item := range arr {
+		if item.Type() != data.TypeNull {
+			res = append(res, item)
+		}
+	}
+	return res
+}
+
 // twoParamAggFunc is a template for aggregate functions that
 // have exactly two (aggregation) parameters
 type twoParamAggFunc struct {
@@ -65,31 +138,27 @@ func (f *twoParamAggFunc) Call(ctx *core.Context, args ...data.Value) (data.Valu
 }
 
 // countFunc is an aggregate function that counts the number
-// of non-null values passed in.
+// of non-null values passed in. Null values are also counted in
+// the "respect_nulls" mode.
 //
 // It can be used in BQL as `count`.
 //
-//  Input: anything (aggregated)
+//  Input: anything (aggregated), mode (optional)
 //  Return Type: Int
 var countFunc udf.UDF = &singleParamAggFunc{
 	aggFun: func(arr []data.Value) (data.Value, error) {
-		// count() is O(n) in the spirit of PostgreSQL
-		c := int64(0)
-		for _, item := range arr {
-			if item.Type() != data.TypeNull {
-				c++
-			}
-		}
-		return data.Int(c), nil
+		return data.Int(len(arr)), nil
 	},
+	aggregatesNulls: true,
 }
 
 // arrayAggFunc is an aggregate function that concatenates
-// input values (including nulls), into an array.
+// input values (including nulls), into an array. Null values
+// are removed in the "ignore_nulls" mode.
 //
 // It can be used in BQL as `array_agg`.
 //
-//  Input: any (aggregated)
+//  Input: any (aggregated), mode (optional)
 //  Return Type: Array (Null on empty input)
 var arrayAggFunc udf.UDF = &singleParamAggFunc{
 	aggFun: func(arr []data.Value) (data.Value, error) {
@@ -98,6 +167,8 @@ var arrayAggFunc udf.UDF = &singleParamAggFunc{
 		}
 		return data.Array(arr), nil
 	},
+	respectNullsByDefault: true,
+	aggregatesNulls:       true,
 }
 
 // avgFunc is an aggregate function that computes the average
@@ -106,7 +177,7 @@ var arrayAggFunc udf.UDF = &singleParamAggFunc{
 //
 // It can be used in BQL as `avg`.
 //
-//  Input: Int or Float (aggregated)
+//  Input: Int or Float (aggregated), mode (optional)
 //  Return Type: Float (Null on empty input)
 var avgFunc udf.UDF = &singleParamAggFunc{
 	aggFun: func(arr []data.Value) (data.Value, error) {
@@ -145,7 +216,7 @@ var avgFunc udf.UDF = &singleParamAggFunc{
 //
 // It can be used in BQL as `median`.
 //
-//  Input: Int or Float (aggregated)
+//  Input: Int or Float (aggregated), mode (optional)
 //  Return Type: Float (Null on empty input)
 var medianFunc udf.UDF = &singleParamAggFunc{
 	// this uses a simple algorithm that first sorts all numbers,
@@ -195,7 +266,7 @@ var medianFunc udf.UDF = &singleParamAggFunc{
 //
 // It can be used in BQL as `bool_and`.
 //
-//  Input: Bool (aggregated)
+//  Input: Bool (aggregated), mode (optional)
 //  Return Type: Bool (Null on empty input)
 var boolAndFunc udf.UDF = &singleParamAggFunc{
 	aggFun: func(arr []data.Value) (data.Value, error) {
@@ -236,7 +307,7 @@ var boolAndFunc udf.UDF = &singleParamAggFunc{
 //
 // It can be used in BQL as `bool_or`.
 //
-//  Input: Bool (aggregated)
+//  Input: Bool (aggregated), mode (optional)
 //  Return Type: Bool (Null on empty input)
 var boolOrFunc udf.UDF = &singleParamAggFunc{
 	aggFun: func(arr []data.Value) (data.Value, error) {
@@ -320,7 +391,7 @@ var jsonObjectAggFunc udf.UDF = &twoParamAggFunc{
 //
 // It can be used in BQL as `max`.
 //
-//  Input: Int or Float (aggregated)
+//  Input: Int or Float (aggregated), mode (optional)
 //  Return Type: same as maximal input value (Null on empty input)
 var maxFunc udf.UDF = &singleParamAggFunc{
 	aggFun: func(arr []data.Value) (data.Value, error) {
@@ -390,7 +461,7 @@ var maxFunc udf.UDF = &singleParamAggFunc{
 //
 // It can be used in BQL as `min`.
 //
-//  Input: Int or Float (aggregated)
+//  Input: Int or Float (aggregated), mode (optional)
 //  Return Type: same as minimal input value (Null on empty input)
 var minFunc udf.UDF = &singleParamAggFunc{
 	aggFun: func(arr []data.Value) (data.Value, error) {
@@ -510,7 +581,7 @@ var stringAggFunc udf.UDF = &stringAggFuncTmpl{}
 //
 // It can be used in BQL as `sum`.
 //
-//  Input: Int or Float (aggregated)
+//  Input: Int or Float (aggregated), mode (optional)
 //  Return Type: Float if the input contains a Float, Int otherwise
 //   (Null on empty input)
 var sumFunc udf.UDF = &singleParamAggFunc{
@@ -374,3 +374,64 @@ func TestBinaryAggregateFuncs(t *testing.T) {
 		})
 	}
 }
+
+func TestAggregateNullModes(t *testing.T) {
+	input := data.Array{data.Int(7), data.Null{}, data.Int(3)}
+	onlyNulls := data.Array{data.Null{}, data.Null{}}
+
+	testCases := []struct {
+		name        string
+		f           udf.UDF
+		input       data.Array
+		ignoreNulls data.Value
+		respectNull data.Value
+	}{
+		{"count", countFunc, input, data.Int(2), data.Int(3)},
+		{"count", countFunc, onlyNulls, data.Int(0), data.Int(2)},
+		{"array_agg", arrayAggFunc, input, data.Array{data.Int(7), data.Int(3)}, input},
+		{"array_agg", arrayAggFunc, onlyNulls, data.Null{}, onlyNulls},
+		{"avg", avgFunc, input, data.Float(5), data.Null{}},
+		{"sum", sumFunc, input, data.Int(10), data.Null{}},
+		{"max", maxFunc, input, data.Int(7), data.Null{}},
+		{"min", minFunc, onlyNulls, data.Null{}, data.Null{}},
+		{"first_value", firstValueFunc, data.Array{data.Null{}, data.Int(1)}, data.Int(1), data.Null{}},
+		{"row_number", rowNumberFunc, input, data.Int(2), data.Int(3)},
+	}
+
+	for i, tc := range testCases {
+		tc := tc
+
+		Convey(fmt.Sprintf("Given the %s function and %v (%v)", tc.name, tc.input, i), t, func() {
+			Convey("When evaluating it in the ignore_nulls mode", func() {
+				val, err := tc.f.Call(nil, tc.input, data.String("ignore_nulls"))
+
+				Convey("Then Null values should be ignored", func() {
+					So(err, ShouldBeNil)
+					So(val, ShouldResemble, tc.ignoreNulls)
+				})
+			})
+
+			Convey("When evaluating it in the respect_nulls mode", func() {
+				val, err := tc.f.Call(nil, tc.input, data.String("respect_nulls"))
+
+				Convey("Then Null values should be aggregated", func() {
+					So(err, ShouldBeNil)
+					So(val, ShouldResemble, tc.respectNull)
+				})
+			})
+
+			Convey("When evaluating it with an invalid mode", func() {
+				_, err := tc.f.Call(nil, tc.input, data.String("skip_nulls"))
+
+				Convey("Then it should fail", func() {
+					So(err, ShouldNotBeNil)
+				})
+			})
+
+			Convey("Then it should accept the mode in the default registry", func() {
+				_, err := udf.CopyGlobalUDFRegistry(nil).Lookup(tc.name, 2)
+				So(err, ShouldBeNil)
+			})
+		})
+	}
+}
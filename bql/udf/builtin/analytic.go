@@ -0,0 +1,162 @@
+package builtin
+
+import (
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/udf"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// Analytic functions are aggregate functions which pick up a value at a
+// specific position of the ordered input. They're usually called with
+// ORDER BY, e.g. `lag(value, 1 ORDER BY ts)`. Without ORDER BY, the input is
+// ordered as tuples arrived at the window.
+//
+// Because a SELECT statement having aggregates emits one row per group,
+// the "current row" of an analytic function is the last row of the group
+// in the given order. For example,
+//
+//  SELECT RSTREAM device, last_value(v ORDER BY ts) - lag(v ORDER BY ts) AS delta
+//  FROM s [RANGE 1 MINUTES] GROUP BY device
+//
+// computes the difference between the latest value and the previous one for
+// each device.
+
+// firstValueFunc is an analytic function that returns the first
+// value of the ordered input (including null). Null values are
+// skipped in the "ignore_nulls" mode.
+//
+// It can be used in BQL as `first_value`.
+//
+//  Input: any (aggregated), mode (optional)
+//  Return Type: any (Null on empty input)
+var firstValueFunc udf.UDF = &singleParamAggFunc{
+	aggFun: func(arr []data.Value) (data.Value, error) {
+		if len(arr) == 0 {
+			return data.Null{}, nil
+		}
+		return arr[0], nil
+	},
+	respectNullsByDefault: true,
+	aggregatesNulls:       true,
+}
+
+// lastValueFunc is an analytic function that returns the last
+// value of the ordered input (including null). Null values are
+// skipped in the "ignore_nulls" mode.
+//
+// It can be used in BQL as `last_value`.
+//
+//  Input: any (aggregated), mode (optional)
+//  Return Type: any (Null on empty input)
+var lastValueFunc udf.UDF = &singleParamAggFunc{
+	aggFun: func(arr []data.Value) (data.Value, error) {
+		if len(arr) == 0 {
+			return data.Null{}, nil
+		}
+		return arr[len(arr)-1], nil
+	},
+	respectNullsByDefault: true,
+	aggregatesNulls:       true,
+}
+
+// rowNumberFunc is an analytic function that returns the 1-based
+// row number of the current row, i.e., the number of rows in the
+// group including rows having null. Rows having null aren't
+// counted in the "ignore_nulls" mode.
+//
+// It can be used in BQL as `row_number`.
+//
+//  Input: any (aggregated), mode (optional)
+//  Return Type: Int
+var rowNumberFunc udf.UDF = &singleParamAggFunc{
+	aggFun: func(arr []data.Value) (data.Value, error) {
+		return data.Int(len(arr)), nil
+	},
+	respectNullsByDefault: true,
+	aggregatesNulls:       true,
+}
+
+// offsetFuncTmpl is a template for analytic functions which
+// return the value at an offset from the current row.
+//
+// The first parameter is aggregated. The second parameter is the
+// offset (1 by default) and the third parameter is the default
+// value returned when the offset is out of the input (Null by
+// default).
+type offsetFuncTmpl struct {
+	// pos returns the index of the value n rows away from the
+	// current row in an input having the given length.
+	pos func(length int, n int64) int64
+}
+
+func (f *offsetFuncTmpl) Accept(arity int) bool {
+	return 1 <= arity && arity <= 3
+}
+
+func (f *offsetFuncTmpl) IsAggregationParameter(k int) bool {
+	return k == 0
+}
+
+func (f *offsetFuncTmpl) Call(ctx *core.Context, args ...data.Value) (data.Value, error) {
+	if len(args) < 1 || len(args) > 3 {
+		return nil, fmt.Errorf("function takes one to three arguments")
+	}
+	arr, err := data.AsArray(args[0])
+	if err != nil {
+		return nil, fmt.Errorf("function needs array input, not %T", args[0])
+	}
+	n := int64(1)
+	if len(args) >= 2 {
+		n, err = data.AsInt(args[1])
+		if err != nil {
+			return nil, fmt.Errorf("offset must be an integer, not %T", args[1])
+		}
+		if n < 0 {
+			return nil, fmt.Errorf("offset must not be negative: %v", n)
+		}
+	}
+	var def data.Value = data.Null{}
+	if len(args) == 3 {
+		def = args[2]
+	}
+
+	i := f.pos(len(arr), n)
+	if i < 0 || i >= int64(len(arr)) {
+		return def, nil
+	}
+	return arr[i], nil
+}
+
+// lagFunc(expr, n, default) is an analytic function that returns
+// the value n rows before the current (i.e. last) row. It returns
+// the default value when there isn't such a row.
+//
+// It can be used in BQL as `lag`.
+//
+//  Input: any (aggregated), Int (optional, 1 by default),
+//   any (optional, Null by default)
+//  Return Type: any
+var lagFunc udf.UDF = &offsetFuncTmpl{
+	pos: func(length int, n int64) int64 {
+		return int64(length) - 1 - n
+	},
+}
+
+// leadFunc(expr, n, default) is an analytic function that returns
+// the value n rows after the first row. It returns the default value
+// when there isn't such a row.
+//
+// Since the current row is the last row of the input, lead is
+// defined relative to the first row so that it's useful in BQL.
+//
+// It can be used in BQL as `lead`.
+//
+//  Input: any (aggregated), Int (optional, 1 by default),
+//   any (optional, Null by default)
+//  Return Type: any
+var leadFunc udf.UDF = &offsetFuncTmpl{
+	pos: func(length int, n int64) int64 {
+		return n
+	},
+}
@@ -0,0 +1,78 @@
+package builtin
+
+import (
+	"fmt"
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/udf"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"testing"
+)
+
+func TestAnalyticFuncs(t *testing.T) {
+	arr := data.Array{data.Int(1), data.Null{}, data.Int(3), data.Int(4)}
+
+	testCases := []struct {
+		name     string
+		f        udf.UDF
+		args     []data.Value
+		expected data.Value
+	}{
+		{"first_value", firstValueFunc, []data.Value{arr}, data.Int(1)},
+		{"first_value", firstValueFunc, []data.Value{data.Array{}}, data.Null{}},
+		{"first_value", firstValueFunc, []data.Value{data.Int(1)}, nil},
+		{"last_value", lastValueFunc, []data.Value{arr}, data.Int(4)},
+		{"last_value", lastValueFunc, []data.Value{data.Array{}}, data.Null{}},
+		{"row_number", rowNumberFunc, []data.Value{arr}, data.Int(4)},
+		{"row_number", rowNumberFunc, []data.Value{data.Array{}}, data.Int(0)},
+		{"lag", lagFunc, []data.Value{arr}, data.Int(3)},
+		{"lag", lagFunc, []data.Value{arr, data.Int(0)}, data.Int(4)},
+		{"lag", lagFunc, []data.Value{arr, data.Int(2)}, data.Null{}},
+		{"lag", lagFunc, []data.Value{arr, data.Int(3)}, data.Int(1)},
+		{"lag", lagFunc, []data.Value{arr, data.Int(4)}, data.Null{}},
+		{"lag", lagFunc, []data.Value{arr, data.Int(4), data.Int(0)}, data.Int(0)},
+		{"lag", lagFunc, []data.Value{data.Array{}}, data.Null{}},
+		{"lag", lagFunc, []data.Value{arr, data.Int(-1)}, nil},
+		{"lag", lagFunc, []data.Value{arr, data.String("1")}, nil},
+		{"lag", lagFunc, []data.Value{data.Null{}}, nil},
+		{"lead", leadFunc, []data.Value{arr}, data.Null{}},
+		{"lead", leadFunc, []data.Value{arr, data.Int(0)}, data.Int(1)},
+		{"lead", leadFunc, []data.Value{arr, data.Int(2)}, data.Int(3)},
+		{"lead", leadFunc, []data.Value{arr, data.Int(4), data.String("x")}, data.String("x")},
+		{"lead", leadFunc, []data.Value{arr, data.Int(-1)}, nil},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		Convey(fmt.Sprintf("Given the %s function", tc.name), t, func() {
+			Convey(fmt.Sprintf("When evaluating it on %v", tc.args), func() {
+				val, err := tc.f.Call(nil, tc.args...)
+
+				if tc.expected == nil {
+					Convey("Then evaluation should fail", func() {
+						So(err, ShouldNotBeNil)
+					})
+				} else {
+					Convey(fmt.Sprintf("Then the result should be %s", tc.expected), func() {
+						So(err, ShouldBeNil)
+						So(val, ShouldResemble, tc.expected)
+					})
+				}
+			})
+		})
+	}
+
+	Convey("Given the lag function", t, func() {
+		Convey("Then it should accept one to three arguments", func() {
+			So(lagFunc.Accept(0), ShouldBeFalse)
+			So(lagFunc.Accept(1), ShouldBeTrue)
+			So(lagFunc.Accept(3), ShouldBeTrue)
+			So(lagFunc.Accept(4), ShouldBeFalse)
+		})
+
+		Convey("Then only the first parameter should be aggregated", func() {
+			So(lagFunc.IsAggregationParameter(0), ShouldBeTrue)
+			So(lagFunc.IsAggregationParameter(1), ShouldBeFalse)
+			So(lagFunc.IsAggregationParameter(2), ShouldBeFalse)
+		})
+	})
+}
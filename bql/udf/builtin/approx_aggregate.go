@@ -0,0 +1,357 @@
+package builtin
+
+import (
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/udf"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"math"
+)
+
+// Approximate aggregate functions compute statistics using sketches whose
+// sizes don't depend on the number or the cardinality of input values. Each
+// of them has two variants: a *_sketch function returning the sketch itself
+// as a map, and a *_merge function merging such sketches into the result.
+// For example,
+//
+//  CREATE STREAM partial AS SELECT RSTREAM
+//      approx_percentile_sketch(latency) AS latency,
+//      approx_count_distinct_sketch(user) AS users
+//  FROM s [RANGE 1 MINUTES] WHERE shard = 0;
+//
+//  SELECT RSTREAM approx_percentile_merge(latency, 0.99) AS p99,
+//      approx_count_distinct_merge(users) AS users
+//  FROM partial [RANGE 1 TUPLES];
+//
+// computes the 99th percentile and the number of distinct users from
+// sketches computed by boxes processing each partition of a stream. Null
+// values are ignored.
+
+// approxAggFuncTmpl is a template for approximate aggregate functions. The
+// first parameter is aggregated and other parameters aren't.
+type approxAggFuncTmpl struct {
+	minArity int
+	maxArity int
+	compute  func(arr data.Array, params []data.Value) (data.Value, error)
+}
+
+func (f *approxAggFuncTmpl) Accept(arity int) bool {
+	return f.minArity <= arity && arity <= f.maxArity
+}
+
+func (f *approxAggFuncTmpl) IsAggregationParameter(k int) bool {
+	return k == 0
+}
+
+func (f *approxAggFuncTmpl) Call(ctx *core.Context, args ...data.Value) (data.Value, error) {
+	if !f.Accept(len(args)) {
+		return nil, fmt.Errorf("invalid number of arguments: %v", len(args))
+	}
+	arr, err := data.AsArray(args[0])
+	if err != nil {
+		return nil, fmt.Errorf("function needs array input, not %T", args[0])
+	}
+	return f.compute(arr, args[1:])
+}
+
+func buildTDigest(arr data.Array) (*tDigest, error) {
+	t := newTDigest(defaultTDigestCompression)
+	for _, item := range arr {
+		switch item.Type() {
+		case data.TypeInt:
+			i, _ := data.AsInt(item)
+			t.add(float64(i), 1)
+		case data.TypeFloat:
+			f, _ := data.AsFloat(item)
+			t.add(f, 1)
+		case data.TypeNull:
+			continue
+		default:
+			return nil, fmt.Errorf("cannot interpret %s (%T) as a number",
+				item, item)
+		}
+	}
+	return t, nil
+}
+
+func mergeTDigests(arr data.Array) (*tDigest, error) {
+	t := newTDigest(defaultTDigestCompression)
+	for _, item := range arr {
+		if item.Type() == data.TypeNull {
+			continue
+		}
+		o, err := tDigestFromValue(item)
+		if err != nil {
+			return nil, err
+		}
+		t.merge(o)
+	}
+	return t, nil
+}
+
+func percentileOf(t *tDigest, param data.Value) (data.Value, error) {
+	p, err := data.ToFloat(param)
+	if err != nil {
+		return nil, fmt.Errorf("the percentile must be a number: %v", err)
+	}
+	if !(p >= 0 && p <= 1) {
+		return nil, fmt.Errorf("the percentile must be in [0, 1]: %v", p)
+	}
+	q := t.quantile(p)
+	if math.IsNaN(q) {
+		return data.Null{}, nil
+	}
+	return data.Float(q), nil
+}
+
+// approxPercentileFunc(expr, p) is an approximate aggregate function that
+// estimates the p-th percentile of input values with a t-digest. p must be
+// in [0, 1].
+//
+// It can be used in BQL as `approx_percentile`.
+//
+//  Input: Int or Float (aggregated), Float
+//  Return Type: Float (Null on empty input)
+var approxPercentileFunc udf.UDF = &approxAggFuncTmpl{
+	minArity: 2,
+	maxArity: 2,
+	compute: func(arr data.Array, params []data.Value) (data.Value, error) {
+		t, err := buildTDigest(arr)
+		if err != nil {
+			return nil, err
+		}
+		return percentileOf(t, params[0])
+	},
+}
+
+// approxPercentileSketchFunc(expr) is an approximate aggregate function that
+// returns a t-digest of input values, which can be merged by
+// approx_percentile_merge.
+//
+// It can be used in BQL as `approx_percentile_sketch`.
+//
+//  Input: Int or Float (aggregated)
+//  Return Type: Map
+var approxPercentileSketchFunc udf.UDF = &approxAggFuncTmpl{
+	minArity: 1,
+	maxArity: 1,
+	compute: func(arr data.Array, params []data.Value) (data.Value, error) {
+		t, err := buildTDigest(arr)
+		if err != nil {
+			return nil, err
+		}
+		return t.toMap(), nil
+	},
+}
+
+// approxPercentileMergeFunc(sketch, p) is an approximate aggregate function
+// that merges t-digests returned from approx_percentile_sketch and estimates
+// the p-th percentile of values in them. p must be in [0, 1].
+//
+// It can be used in BQL as `approx_percentile_merge`.
+//
+//  Input: Map (aggregated), Float
+//  Return Type: Float (Null on empty input)
+var approxPercentileMergeFunc udf.UDF = &approxAggFuncTmpl{
+	minArity: 2,
+	maxArity: 2,
+	compute: func(arr data.Array, params []data.Value) (data.Value, error) {
+		t, err := mergeTDigests(arr)
+		if err != nil {
+			return nil, err
+		}
+		return percentileOf(t, params[0])
+	},
+}
+
+func buildHyperLogLog(arr data.Array) *hyperLogLog {
+	h := newHyperLogLog(defaultHLLPrecision)
+	for _, item := range arr {
+		if item.Type() == data.TypeNull {
+			continue
+		}
+		h.add(item)
+	}
+	return h
+}
+
+// approxCountDistinctFunc(expr) is an approximate aggregate function that
+// estimates the number of distinct non-null input values with a
+// HyperLogLog. The standard error of the estimate is about 0.8%.
+//
+// It can be used in BQL as `approx_count_distinct`.
+//
+//  Input: Any (aggregated)
+//  Return Type: Int
+var approxCountDistinctFunc udf.UDF = &approxAggFuncTmpl{
+	minArity: 1,
+	maxArity: 1,
+	compute: func(arr data.Array, params []data.Value) (data.Value, error) {
+		h := buildHyperLogLog(arr)
+		return data.Int(math.Floor(h.estimate() + 0.5)), nil
+	},
+}
+
+// approxCountDistinctSketchFunc(expr) is an approximate aggregate function
+// that returns a HyperLogLog of non-null input values, which can be merged by
+// approx_count_distinct_merge.
+//
+// It can be used in BQL as `approx_count_distinct_sketch`.
+//
+//  Input: Any (aggregated)
+//  Return Type: Map
+var approxCountDistinctSketchFunc udf.UDF = &approxAggFuncTmpl{
+	minArity: 1,
+	maxArity: 1,
+	compute: func(arr data.Array, params []data.Value) (data.Value, error) {
+		return buildHyperLogLog(arr).toMap(), nil
+	},
+}
+
+// approxCountDistinctMergeFunc(sketch) is an approximate aggregate function
+// that merges HyperLogLogs returned from approx_count_distinct_sketch and
+// estimates the number of distinct values in them.
+//
+// It can be used in BQL as `approx_count_distinct_merge`.
+//
+//  Input: Map (aggregated)
+//  Return Type: Int
+var approxCountDistinctMergeFunc udf.UDF = &approxAggFuncTmpl{
+	minArity: 1,
+	maxArity: 1,
+	compute: func(arr data.Array, params []data.Value) (data.Value, error) {
+		h := newHyperLogLog(defaultHLLPrecision)
+		for _, item := range arr {
+			if item.Type() == data.TypeNull {
+				continue
+			}
+			o, err := hyperLogLogFromValue(item)
+			if err != nil {
+				return nil, err
+			}
+			if err := h.merge(o); err != nil {
+				return nil, err
+			}
+		}
+		return data.Int(math.Floor(h.estimate() + 0.5)), nil
+	},
+}
+
+func topKParam(param data.Value, name string) (int, error) {
+	k, err := data.AsInt(param)
+	if err != nil {
+		return 0, fmt.Errorf("%v must be an integer: %v", name, err)
+	}
+	if k <= 0 {
+		return 0, fmt.Errorf("%v must be positive: %v", name, k)
+	}
+	return int(k), nil
+}
+
+func buildTopKSketch(arr data.Array, capacity int) *topKSketch {
+	s := newTopKSketch(capacity)
+	for _, item := range arr {
+		if item.Type() == data.TypeNull {
+			continue
+		}
+		s.add(item, 1)
+	}
+	return s
+}
+
+func topKResult(s *topKSketch, k int) data.Value {
+	hs := s.top(k)
+	res := make(data.Array, len(hs))
+	for i, hh := range hs {
+		res[i] = data.Map{
+			"value": hh.value,
+			"count": data.Int(hh.count),
+		}
+	}
+	return res
+}
+
+// approxTopKFunc(expr, k) is an approximate aggregate function that returns
+// the k most frequent non-null input values with their estimated counts
+// computed by a Count-Min sketch. The result is an array of maps having
+// "value" and "count" fields in descending order of counts. Counts can be
+// overestimated but never underestimated.
+//
+// It can be used in BQL as `approx_top_k`.
+//
+//  Input: Any (aggregated), Int
+//  Return Type: Array
+var approxTopKFunc udf.UDF = &approxAggFuncTmpl{
+	minArity: 2,
+	maxArity: 2,
+	compute: func(arr data.Array, params []data.Value) (data.Value, error) {
+		k, err := topKParam(params[0], "k")
+		if err != nil {
+			return nil, err
+		}
+		// keep more candidates than k so that values getting frequent
+		// later aren't missed
+		capacity := defaultTopKCapacity
+		if k > capacity {
+			capacity = k
+		}
+		return topKResult(buildTopKSketch(arr, capacity), k), nil
+	},
+}
+
+// approxTopKSketchFunc(expr[, capacity]) is an approximate aggregate function
+// that returns a Count-Min sketch of non-null input values, which can be
+// merged by approx_top_k_merge. The sketch keeps at most capacity most
+// frequent values, 100 by default, so k given to approx_top_k_merge must not
+// exceed it to get accurate results.
+//
+// It can be used in BQL as `approx_top_k_sketch`.
+//
+//  Input: Any (aggregated), Int
+//  Return Type: Map
+var approxTopKSketchFunc udf.UDF = &approxAggFuncTmpl{
+	minArity: 1,
+	maxArity: 2,
+	compute: func(arr data.Array, params []data.Value) (data.Value, error) {
+		capacity := defaultTopKCapacity
+		if len(params) > 0 {
+			c, err := topKParam(params[0], "the capacity")
+			if err != nil {
+				return nil, err
+			}
+			capacity = c
+		}
+		return buildTopKSketch(arr, capacity).toMap(), nil
+	},
+}
+
+// approxTopKMergeFunc(sketch, k) is an approximate aggregate function that
+// merges Count-Min sketches returned from approx_top_k_sketch and returns the
+// k most frequent values in them in the same format as approx_top_k.
+//
+// It can be used in BQL as `approx_top_k_merge`.
+//
+//  Input: Map (aggregated), Int
+//  Return Type: Array
+var approxTopKMergeFunc udf.UDF = &approxAggFuncTmpl{
+	minArity: 2,
+	maxArity: 2,
+	compute: func(arr data.Array, params []data.Value) (data.Value, error) {
+		k, err := topKParam(params[0], "k")
+		if err != nil {
+			return nil, err
+		}
+		s := newTopKSketch(k)
+		for _, item := range arr {
+			if item.Type() == data.TypeNull {
+				continue
+			}
+			o, err := topKSketchFromValue(item)
+			if err != nil {
+				return nil, err
+			}
+			s.merge(o)
+		}
+		return topKResult(s, k), nil
+	},
+}
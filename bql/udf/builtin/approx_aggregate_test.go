@@ -0,0 +1,258 @@
+package builtin
+
+import (
+	"fmt"
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"math"
+	"testing"
+)
+
+func TestApproxPercentile(t *testing.T) {
+	Convey("Given numbers from 1 to 10000 in random order", t, func() {
+		arr := make(data.Array, 0, 10000)
+		for i := 0; i < 10000; i++ {
+			arr = append(arr, data.Int((i*7919)%10000+1))
+		}
+		arr = append(arr, data.Null{})
+
+		Convey("When computing approx_percentile", func() {
+			for _, p := range []float64{0, 0.01, 0.25, 0.5, 0.9, 0.99, 1} {
+				Convey(fmt.Sprintf("Then the %vth percentile should be close to the exact one", p), func() {
+					v, err := approxPercentileFunc.Call(nil, arr, data.Float(p))
+					So(err, ShouldBeNil)
+					f, err := data.AsFloat(v)
+					So(err, ShouldBeNil)
+					So(f, ShouldAlmostEqual, 1+p*9999, 50)
+				})
+			}
+		})
+
+		Convey("When merging sketches of partitions", func() {
+			var sketches data.Array
+			for i := 0; i < 4; i++ {
+				s, err := approxPercentileSketchFunc.Call(nil, arr[i*2500:(i+1)*2500])
+				So(err, ShouldBeNil)
+				sketches = append(sketches, s)
+			}
+			sketches = append(sketches, data.Null{})
+
+			Convey("Then percentiles should be close to the exact ones", func() {
+				for _, p := range []float64{0, 0.5, 0.99, 1} {
+					v, err := approxPercentileMergeFunc.Call(nil, sketches, data.Float(p))
+					So(err, ShouldBeNil)
+					f, err := data.AsFloat(v)
+					So(err, ShouldBeNil)
+					So(f, ShouldAlmostEqual, 1+p*9999, 50)
+				}
+			})
+		})
+	})
+
+	Convey("Given invalid inputs", t, func() {
+		Convey("When computing approx_percentile of an empty array", func() {
+			v, err := approxPercentileFunc.Call(nil, data.Array{data.Null{}}, data.Float(0.5))
+
+			Convey("Then it should return null", func() {
+				So(err, ShouldBeNil)
+				So(v, ShouldResemble, data.Null{})
+			})
+		})
+
+		Convey("When computing approx_percentile of non-numeric values", func() {
+			_, err := approxPercentileFunc.Call(nil, data.Array{data.String("a")}, data.Float(0.5))
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When computing approx_percentile with an invalid percentile", func() {
+			_, err := approxPercentileFunc.Call(nil, data.Array{data.Int(1)}, data.Float(1.5))
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When merging values other than t-digests", func() {
+			hll, err := approxCountDistinctSketchFunc.Call(nil, data.Array{data.Int(1)})
+			So(err, ShouldBeNil)
+			for _, v := range []data.Value{data.Int(1), data.Map{}, hll} {
+				_, err := approxPercentileMergeFunc.Call(nil, data.Array{v}, data.Float(0.5))
+				So(err, ShouldNotBeNil)
+			}
+		})
+	})
+}
+
+func TestApproxCountDistinct(t *testing.T) {
+	Convey("Given 20000 values having 5000 distinct values", t, func() {
+		arr := make(data.Array, 0, 20000)
+		for i := 0; i < 20000; i++ {
+			arr = append(arr, data.String(fmt.Sprintf("user%v", i%5000)))
+		}
+		arr = append(arr, data.Null{})
+
+		Convey("When computing approx_count_distinct", func() {
+			v, err := approxCountDistinctFunc.Call(nil, arr)
+			So(err, ShouldBeNil)
+
+			Convey("Then it should be close to the exact count", func() {
+				n, err := data.AsInt(v)
+				So(err, ShouldBeNil)
+				So(n, ShouldAlmostEqual, 5000, 150)
+			})
+		})
+
+		Convey("When merging sketches of overlapping partitions", func() {
+			var sketches data.Array
+			for i := 0; i < 4; i++ {
+				s, err := approxCountDistinctSketchFunc.Call(nil, arr[i*4000:i*4000+8000])
+				So(err, ShouldBeNil)
+				sketches = append(sketches, s)
+			}
+
+			Convey("Then the count should be close to the exact count", func() {
+				v, err := approxCountDistinctMergeFunc.Call(nil, sketches)
+				So(err, ShouldBeNil)
+				n, err := data.AsInt(v)
+				So(err, ShouldBeNil)
+				So(n, ShouldAlmostEqual, 5000, 150)
+			})
+		})
+	})
+
+	Convey("Given a small number of values", t, func() {
+		arr := data.Array{data.Int(1), data.Float(1), data.Int(2), data.Int(1), data.String("1")}
+
+		Convey("When computing approx_count_distinct", func() {
+			v, err := approxCountDistinctFunc.Call(nil, arr)
+			So(err, ShouldBeNil)
+
+			Convey("Then it should be exact", func() {
+				So(v, ShouldEqual, data.Int(4))
+			})
+		})
+
+		Convey("When computing approx_count_distinct of an empty array", func() {
+			v, err := approxCountDistinctFunc.Call(nil, data.Array{})
+			So(err, ShouldBeNil)
+
+			Convey("Then it should be 0", func() {
+				So(v, ShouldEqual, data.Int(0))
+			})
+		})
+
+		Convey("When merging a broken sketch", func() {
+			s, err := approxCountDistinctSketchFunc.Call(nil, arr)
+			So(err, ShouldBeNil)
+			m := s.(data.Map)
+			m["registers"] = data.Blob([]byte{1, 2, 3})
+			_, err = approxCountDistinctMergeFunc.Call(nil, data.Array{m})
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+func TestApproxTopK(t *testing.T) {
+	Convey("Given values having a skewed distribution", t, func() {
+		// value i appears 1000/i times
+		var arr data.Array
+		for i := 1; i <= 300; i++ {
+			for j := 0; j < 1000/i; j++ {
+				arr = append(arr, data.String(fmt.Sprintf("v%v", i)))
+			}
+		}
+		// shuffle values deterministically
+		for i := range arr {
+			j := (i * 7919) % len(arr)
+			arr[i], arr[j] = arr[j], arr[i]
+		}
+
+		expected := data.Array{
+			data.Map{"value": data.String("v1"), "count": data.Int(1000)},
+			data.Map{"value": data.String("v2"), "count": data.Int(500)},
+			data.Map{"value": data.String("v3"), "count": data.Int(333)},
+		}
+
+		Convey("When computing approx_top_k", func() {
+			v, err := approxTopKFunc.Call(nil, arr, data.Int(3))
+			So(err, ShouldBeNil)
+
+			Convey("Then it should return the most frequent values", func() {
+				So(v, ShouldResemble, expected)
+			})
+		})
+
+		Convey("When merging sketches of partitions", func() {
+			var sketches data.Array
+			n := len(arr) / 3
+			for i := 0; i < 3; i++ {
+				end := (i + 1) * n
+				if i == 2 {
+					end = len(arr)
+				}
+				s, err := approxTopKSketchFunc.Call(nil, arr[i*n:end], data.Int(10))
+				So(err, ShouldBeNil)
+				sketches = append(sketches, s)
+			}
+
+			Convey("Then it should return the most frequent values", func() {
+				v, err := approxTopKMergeFunc.Call(nil, sketches, data.Int(3))
+				So(err, ShouldBeNil)
+				So(v, ShouldResemble, expected)
+			})
+		})
+	})
+
+	Convey("Given invalid inputs", t, func() {
+		Convey("When computing approx_top_k with an invalid k", func() {
+			for _, k := range []data.Value{data.Int(0), data.String("a")} {
+				_, err := approxTopKFunc.Call(nil, data.Array{data.Int(1)}, k)
+				So(err, ShouldNotBeNil)
+			}
+		})
+
+		Convey("When merging a broken sketch", func() {
+			s, err := approxTopKSketchFunc.Call(nil, data.Array{data.Int(1)})
+			So(err, ShouldBeNil)
+			m := s.(data.Map)
+			delete(m, "table")
+			_, err = approxTopKMergeFunc.Call(nil, data.Array{m}, data.Int(1))
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+func TestTDigest(t *testing.T) {
+	Convey("Given a t-digest having many values", t, func() {
+		d := newTDigest(defaultTDigestCompression)
+		for i := 0; i < 100000; i++ {
+			d.add(float64((i*7919)%100000), 1)
+		}
+
+		Convey("Then the number of centroids should be bounded", func() {
+			So(d.count(), ShouldEqual, 100000)
+			So(len(d.centroids), ShouldBeLessThanOrEqualTo, defaultTDigestCompression)
+		})
+
+		Convey("Then extreme quantiles should be accurate", func() {
+			So(d.quantile(0.001), ShouldAlmostEqual, 100, 50)
+			So(d.quantile(0.999), ShouldAlmostEqual, 99900, 50)
+			So(math.IsNaN(newTDigest(10).quantile(0.5)), ShouldBeTrue)
+		})
+
+		Convey("Then it should be restored from its map", func() {
+			r, err := tDigestFromValue(d.toMap())
+			So(err, ShouldBeNil)
+			So(r.quantile(0.5), ShouldEqual, d.quantile(0.5))
+		})
+	})
+}
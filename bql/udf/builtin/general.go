@@ -22,3 +22,31 @@ var coalesceFunc udf.UDF = &variadicFunc{
 		return data.Null{}, nil
 	},
 }
+
+// nvl2Func returns the second argument if the first one is not null,
+// the third argument otherwise.
+//
+// It can be used in BQL as `nvl2`.
+//
+//  Input: Any, Any, Any
+//  Return Type: same as the second or the third argument
+var nvl2Func udf.UDF = udf.MustConvertGeneric(func(v, ifNotNull, ifNull data.Value) data.Value {
+	if v.Type() == data.TypeNull {
+		return ifNull
+	}
+	return ifNotNull
+})
+
+// nullifFunc returns null if both arguments are not null and equal,
+// the first argument otherwise.
+//
+// It can be used in BQL as `nullif`.
+//
+//  Input: Any, Any
+//  Return Type: same as the first argument
+var nullifFunc udf.UDF = udf.MustConvertGeneric(func(v1, v2 data.Value) data.Value {
+	if v1.Type() != data.TypeNull && v2.Type() != data.TypeNull && data.Equal(v1, v2) {
+		return data.Null{}
+	}
+	return v1
+})
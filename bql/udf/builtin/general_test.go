@@ -62,3 +62,60 @@ func TestVariadicGeneralFuncs(t *testing.T) {
 		})
 	}
 }
+
+func TestNullHandlingFuncs(t *testing.T) {
+	Convey("Given the nvl2 function", t, func() {
+		Convey("When the first argument isn't null", func() {
+			val, err := nvl2Func.Call(nil, data.Int(0), data.String("a"), data.String("b"))
+
+			Convey("Then it should return the second argument", func() {
+				So(err, ShouldBeNil)
+				So(val, ShouldResemble, data.String("a"))
+			})
+		})
+
+		Convey("When the first argument is null", func() {
+			val, err := nvl2Func.Call(nil, data.Null{}, data.String("a"), data.String("b"))
+
+			Convey("Then it should return the third argument", func() {
+				So(err, ShouldBeNil)
+				So(val, ShouldResemble, data.String("b"))
+			})
+		})
+
+		Convey("Then it should be in the default registry", func() {
+			_, err := udf.CopyGlobalUDFRegistry(nil).Lookup("nvl2", 3)
+			So(err, ShouldBeNil)
+		})
+	})
+
+	Convey("Given the nullif function", t, func() {
+		for i, tc := range []struct {
+			v1, v2   data.Value
+			expected data.Value
+		}{
+			{data.Int(1), data.Int(1), data.Null{}},
+			{data.Int(1), data.Float(1), data.Null{}},
+			{data.Int(1), data.Int(2), data.Int(1)},
+			{data.Int(1), data.Null{}, data.Int(1)},
+			{data.Null{}, data.Null{}, data.Null{}},
+			{data.String("a"), data.Int(1), data.String("a")},
+		} {
+			tc := tc
+
+			Convey(fmt.Sprintf("When evaluating it on %v and %v (%v)", tc.v1, tc.v2, i), func() {
+				val, err := nullifFunc.Call(nil, tc.v1, tc.v2)
+
+				Convey(fmt.Sprintf("Then the result should be %v", tc.expected), func() {
+					So(err, ShouldBeNil)
+					So(val, ShouldResemble, tc.expected)
+				})
+			})
+		}
+
+		Convey("Then it should be in the default registry", func() {
+			_, err := udf.CopyGlobalUDFRegistry(nil).Lookup("nullif", 2)
+			So(err, ShouldBeNil)
+		})
+	})
+}
@@ -0,0 +1,156 @@
+package builtin
+
+import (
+	"gopkg.in/sensorbee/sensorbee.v0/bql/udf"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"gopkg.in/sensorbee/sensorbee.v0/data/geo"
+)
+
+// stPointFunc creates a GeoJSON point from a longitude and a latitude
+// in degrees.
+//
+// It can be used in BQL as `st_point`.
+//
+//  Input: 2 * Int or Float (longitude, latitude)
+//  Return Type: Map
+var stPointFunc udf.UDF = udf.BinaryFunc(func(ctx *core.Context, lon, lat data.Value) (data.Value, error) {
+	if lon.Type() == data.TypeNull || lat.Type() == data.TypeNull {
+		return data.Null{}, nil
+	}
+	x, err := data.ToFloat(lon)
+	if err != nil {
+		return nil, err
+	}
+	y, err := data.ToFloat(lat)
+	if err != nil {
+		return nil, err
+	}
+	p, err := geo.NewPoint(x, y)
+	if err != nil {
+		return nil, err
+	}
+	return p.ToMap(), nil
+})
+
+// stDistanceFunc computes the distance between two geometries in meters.
+// One of them must be a point. See geo.Distance for details.
+//
+// It can be used in BQL as `st_distance`.
+//
+//  Input: 2 * Map (geometries)
+//  Return Type: Float
+var stDistanceFunc udf.UDF = udf.BinaryFunc(func(ctx *core.Context, a, b data.Value) (data.Value, error) {
+	return applyGeoBinary(a, b, func(g, h geo.Geometry) (data.Value, error) {
+		d, err := geo.Distance(g, h)
+		if err != nil {
+			return nil, err
+		}
+		return data.Float(d), nil
+	})
+})
+
+// stWithinFunc returns true when the first geometry lies in the second one.
+// See geo.Contains for supported geometries.
+//
+// It can be used in BQL as `st_within`.
+//
+//  Input: 2 * Map (geometries)
+//  Return Type: Bool
+var stWithinFunc udf.UDF = udf.BinaryFunc(func(ctx *core.Context, a, b data.Value) (data.Value, error) {
+	return applyGeoBinary(a, b, func(g, h geo.Geometry) (data.Value, error) {
+		ok, err := geo.Within(g, h)
+		if err != nil {
+			return nil, err
+		}
+		return data.Bool(ok), nil
+	})
+})
+
+// stContainsFunc returns true when the second geometry lies in the first
+// one. See geo.Contains for supported geometries.
+//
+// It can be used in BQL as `st_contains`.
+//
+//  Input: 2 * Map (geometries)
+//  Return Type: Bool
+var stContainsFunc udf.UDF = udf.BinaryFunc(func(ctx *core.Context, a, b data.Value) (data.Value, error) {
+	return applyGeoBinary(a, b, func(g, h geo.Geometry) (data.Value, error) {
+		ok, err := geo.Contains(g, h)
+		if err != nil {
+			return nil, err
+		}
+		return data.Bool(ok), nil
+	})
+})
+
+func applyGeoBinary(a, b data.Value, f func(g, h geo.Geometry) (data.Value, error)) (data.Value, error) {
+	if a.Type() == data.TypeNull || b.Type() == data.TypeNull {
+		return data.Null{}, nil
+	}
+	g, err := geo.FromValue(a)
+	if err != nil {
+		return nil, err
+	}
+	h, err := geo.FromValue(b)
+	if err != nil {
+		return nil, err
+	}
+	return f(g, h)
+}
+
+// geohashEncodeFunc encodes a point into a geohash. The precision, i.e.
+// the number of characters, is 12 when it's omitted.
+//
+// It can be used in BQL as `geohash_encode`.
+//
+//  Input: Map (point), optional Int (precision)
+//  Return Type: String
+var geohashEncodeFunc udf.UDF = &arityDispatcher{
+	unary: udf.UnaryFunc(func(ctx *core.Context, p data.Value) (data.Value, error) {
+		return geohashEncode(p, data.Int(geo.MaxGeohashPrecision))
+	}),
+	binary: udf.BinaryFunc(func(ctx *core.Context, p, precision data.Value) (data.Value, error) {
+		return geohashEncode(p, precision)
+	}),
+}
+
+func geohashEncode(v, precision data.Value) (data.Value, error) {
+	if v.Type() == data.TypeNull || precision.Type() == data.TypeNull {
+		return data.Null{}, nil
+	}
+	p, err := geo.PointFromValue(v)
+	if err != nil {
+		return nil, err
+	}
+	n, err := data.AsInt(precision)
+	if err != nil {
+		return nil, err
+	}
+	h, err := geo.EncodeGeohash(p, int(n))
+	if err != nil {
+		return nil, err
+	}
+	return data.String(h), nil
+}
+
+// geohashDecodeFunc decodes a geohash into the center point of its cell.
+//
+// It can be used in BQL as `geohash_decode`.
+//
+//  Input: String
+//  Return Type: Map (point)
+var geohashDecodeFunc udf.UDF = udf.UnaryFunc(func(ctx *core.Context, h data.Value) (data.Value, error) {
+	if h.Type() == data.TypeNull {
+		return data.Null{}, nil
+	}
+	s, err := data.AsString(h)
+	if err != nil {
+		return nil, err
+	}
+	p, _, _, err := geo.DecodeGeohash(s)
+	if err != nil {
+		return nil, err
+	}
+	return p.ToMap(), nil
+})
@@ -0,0 +1,213 @@
+package builtin
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/udf"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+func geoPoint(lon, lat float64) data.Map {
+	return data.Map{
+		"type":        data.String("Point"),
+		"coordinates": data.Array{data.Float(lon), data.Float(lat)},
+	}
+}
+
+func geoSquare(x, y, size float64) data.Map {
+	pos := func(lon, lat float64) data.Array {
+		return data.Array{data.Float(lon), data.Float(lat)}
+	}
+	return data.Map{
+		"type": data.String("Polygon"),
+		"coordinates": data.Array{data.Array{
+			pos(x, y), pos(x+size, y), pos(x+size, y+size), pos(x, y+size), pos(x, y),
+		}},
+	}
+}
+
+func TestGeoFuncs(t *testing.T) {
+	Convey("Given geo functions", t, func() {
+		reg := udf.CopyGlobalUDFRegistry(nil)
+		call := func(name string, args ...data.Value) (data.Value, error) {
+			f, err := reg.Lookup(name, len(args))
+			So(err, ShouldBeNil)
+			return f.Call(nil, args...)
+		}
+
+		Convey("When creating a point", func() {
+			v, err := call("st_point", data.Float(139.5), data.Int(35))
+
+			Convey("Then it should be a GeoJSON point", func() {
+				So(err, ShouldBeNil)
+				So(v, ShouldResemble, geoPoint(139.5, 35))
+			})
+		})
+
+		Convey("When creating a point out of range", func() {
+			_, err := call("st_point", data.Float(200), data.Int(35))
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When computing the distance between points", func() {
+			v, err := call("st_distance", geoPoint(0, 0), data.Map{"lat": data.Int(0), "lon": data.Int(1)})
+
+			Convey("Then it should be in meters", func() {
+				So(err, ShouldBeNil)
+				So(v, ShouldAlmostEqual, data.Float(111195.08), 0.01)
+			})
+		})
+
+		Convey("When checking if a point is within a polygon", func() {
+			v, err := call("st_within", geoPoint(0.5, 0.5), geoSquare(0, 0, 1))
+			So(err, ShouldBeNil)
+			So(v, ShouldEqual, data.True)
+
+			v, err = call("st_contains", geoSquare(0, 0, 1), geoPoint(1.5, 0.5))
+			So(err, ShouldBeNil)
+			So(v, ShouldEqual, data.False)
+		})
+
+		Convey("When giving NULL", func() {
+			for _, name := range []string{"st_point", "st_distance", "st_within", "st_contains"} {
+				v, err := call(name, data.Null{}, geoPoint(0, 0))
+				So(err, ShouldBeNil)
+				So(v, ShouldResemble, data.Null{})
+			}
+		})
+
+		Convey("When giving an invalid geometry", func() {
+			_, err := call("st_within", data.String("point"), geoSquare(0, 0, 1))
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When encoding a point into a geohash", func() {
+			v, err := call("geohash_encode", geoPoint(-5.6, 42.6), data.Int(5))
+			So(err, ShouldBeNil)
+			So(v, ShouldEqual, data.String("ezs42"))
+
+			v, err = call("geohash_encode", geoPoint(-5.6, 42.6))
+			So(err, ShouldBeNil)
+			s, _ := data.AsString(v)
+			So(s, ShouldHaveLength, 12)
+			So(s, ShouldStartWith, "ezs42")
+		})
+
+		Convey("When decoding a geohash", func() {
+			v, err := call("geohash_decode", data.String("ezs42"))
+			So(err, ShouldBeNil)
+			m, _ := data.AsMap(v)
+			So(m["type"], ShouldEqual, data.String("Point"))
+			c, _ := data.AsArray(m["coordinates"])
+			So(c[0], ShouldAlmostEqual, data.Float(-5.603), 0.001)
+			So(c[1], ShouldAlmostEqual, data.Float(42.605), 0.001)
+		})
+	})
+}
+
+func TestGeofence(t *testing.T) {
+	Convey("Given a geo_regions state", t, func() {
+		ctx := core.NewContext(nil)
+		st, err := createGeoRegionsState(ctx, data.Map{
+			"regions": data.Map{
+				"a": geoSquare(0, 0, 2),
+				"b": geoSquare(1, 1, 2),
+			},
+		})
+		So(err, ShouldBeNil)
+		So(ctx.SharedStates.Add("zones", "geo_regions", st), ShouldBeNil)
+
+		Convey("When creating a state with a region which isn't a polygon", func() {
+			_, err := createGeoRegionsState(ctx, data.Map{
+				"regions": data.Map{"a": geoPoint(0, 0)},
+			})
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("And a geofence UDSF", func() {
+			decl := udf.NewUDSFDeclarer()
+			f, err := createGeofenceUDSF(ctx, decl, "locations", "zones", "loc", "id")
+			So(err, ShouldBeNil)
+			So(decl.ListInputs(), ShouldContainKey, "locations")
+
+			var tuples []*core.Tuple
+			w := core.WriterFunc(func(ctx *core.Context, t *core.Tuple) error {
+				tuples = append(tuples, t)
+				return nil
+			})
+			move := func(id string, lon, lat float64) []data.Map {
+				tuples = nil
+				So(f.Process(ctx, core.NewTuple(data.Map{
+					"id":  data.String(id),
+					"loc": geoPoint(lon, lat),
+				}), w), ShouldBeNil)
+				var events []data.Map
+				for _, t := range tuples {
+					events = append(events, data.Map{"region": t.Data["region"], "event": t.Data["event"]})
+				}
+				return events
+			}
+			event := func(region, e string) data.Map {
+				return data.Map{"region": data.String(region), "event": data.String(e)}
+			}
+
+			Convey("When objects move across regions", func() {
+				So(move("x", -1, -1), ShouldBeEmpty)
+				So(move("x", 0.5, 0.5), ShouldResemble, []data.Map{event("a", "enter")})
+				So(move("x", 1.5, 1.5), ShouldResemble, []data.Map{event("b", "enter")})
+				So(move("y", 1.5, 1.5), ShouldResemble, []data.Map{event("a", "enter"), event("b", "enter")})
+				So(move("x", 2.5, 2.5), ShouldResemble, []data.Map{event("a", "exit")})
+				So(move("x", 5, 5), ShouldResemble, []data.Map{event("b", "exit")})
+
+				Convey("Then output tuples should have the location and the input", func() {
+					So(move("x", 0.5, 0.5), ShouldHaveLength, 1)
+					So(tuples[0].Data["id"], ShouldEqual, data.String("x"))
+					So(tuples[0].Data["location"], ShouldResemble, geoPoint(0.5, 0.5))
+					So(tuples[0].Data["data"], ShouldContainKey, "loc")
+				})
+			})
+
+			Convey("When a region is removed from the state", func() {
+				So(move("x", 0.5, 0.5), ShouldHaveLength, 1)
+				So(st.(core.Writer).Write(ctx, core.NewTuple(data.Map{
+					"name":     data.String("a"),
+					"geometry": data.Null{},
+				})), ShouldBeNil)
+
+				Convey("Then the object should exit the region", func() {
+					So(move("x", 0.5, 0.5), ShouldResemble, []data.Map{event("a", "exit")})
+				})
+			})
+
+			Convey("When a tuple doesn't have a valid location", func() {
+				err := f.Process(ctx, core.NewTuple(data.Map{
+					"id":  data.String("x"),
+					"loc": geoSquare(0, 0, 1),
+				}), w)
+
+				Convey("Then it should fail", func() {
+					So(err, ShouldNotBeNil)
+				})
+			})
+		})
+
+		Convey("When creating a geofence UDSF with a missing state", func() {
+			_, err := createGeofenceUDSF(ctx, udf.NewUDSFDeclarer(), "locations", "no_such_state", "loc", "id")
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
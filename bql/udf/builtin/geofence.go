@@ -0,0 +1,260 @@
+package builtin
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"gopkg.in/sensorbee/sensorbee.v0/bql/udf"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"gopkg.in/sensorbee/sensorbee.v0/data/geo"
+)
+
+// geoRegionsState is a UDS having named polygons used by the geofence UDSF.
+// It can be created in BQL as follows:
+//
+//	CREATE STATE zones TYPE geo_regions WITH regions={
+//	    "factory": {"type": "Polygon", "coordinates": [[[139.74, 35.65], ...]]}
+//	};
+//
+// UPDATE STATE replaces all regions with the ones given to regions parameter.
+// Regions can also be added by writing tuples having "name" and "geometry"
+// fields to the state via the uds sink. A region is removed when its geometry
+// is NULL.
+type geoRegionsState struct {
+	m       sync.RWMutex
+	regions map[string]geo.Polygon
+}
+
+var (
+	_ core.Updater = &geoRegionsState{}
+	_ core.Writer  = &geoRegionsState{}
+)
+
+func createGeoRegionsState(ctx *core.Context, params data.Map) (core.SharedState, error) {
+	s := &geoRegionsState{}
+	if err := s.Update(ctx, params); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *geoRegionsState) Update(ctx *core.Context, params data.Map) error {
+	regions := map[string]geo.Polygon{}
+	if v, ok := params["regions"]; ok {
+		m, err := data.AsMap(v)
+		if err != nil {
+			return fmt.Errorf("'regions' parameter must be a map: %v", err)
+		}
+		for name, g := range m {
+			p, err := toRegion(g)
+			if err != nil {
+				return fmt.Errorf("region '%v' is invalid: %v", name, err)
+			}
+			regions[name] = p
+		}
+	}
+	for k := range params {
+		if k != "regions" {
+			return fmt.Errorf("unsupported parameter: %v", k)
+		}
+	}
+
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.regions = regions
+	return nil
+}
+
+func toRegion(v data.Value) (geo.Polygon, error) {
+	g, err := geo.FromValue(v)
+	if err != nil {
+		return nil, err
+	}
+	p, ok := g.(geo.Polygon)
+	if !ok {
+		return nil, fmt.Errorf("a region must be a polygon: %v", g.Type())
+	}
+	return p, nil
+}
+
+func (s *geoRegionsState) Write(ctx *core.Context, t *core.Tuple) error {
+	n, ok := t.Data["name"]
+	if !ok {
+		return fmt.Errorf("the tuple doesn't have 'name' field")
+	}
+	name, err := data.AsString(n)
+	if err != nil {
+		return fmt.Errorf("'name' field must be a string: %v", err)
+	}
+	g, ok := t.Data["geometry"]
+	if !ok {
+		return fmt.Errorf("the tuple doesn't have 'geometry' field")
+	}
+
+	var p geo.Polygon
+	if g.Type() != data.TypeNull {
+		if p, err = toRegion(g); err != nil {
+			return fmt.Errorf("region '%v' is invalid: %v", name, err)
+		}
+	}
+
+	s.m.Lock()
+	defer s.m.Unlock()
+	if p == nil {
+		delete(s.regions, name)
+	} else {
+		s.regions[name] = p
+	}
+	return nil
+}
+
+func (s *geoRegionsState) Terminate(ctx *core.Context) error {
+	return nil
+}
+
+// regionsContaining returns names of regions containing the point in
+// a map.
+func (s *geoRegionsState) regionsContaining(p geo.Point) map[string]bool {
+	s.m.RLock()
+	defer s.m.RUnlock()
+	res := map[string]bool{}
+	for name, r := range s.regions {
+		if ok, _ := geo.Contains(r, p); ok {
+			res[name] = true
+		}
+	}
+	return res
+}
+
+// geofenceUDSF emits an event when an object enters or exits a region in
+// a geo_regions state. It can be used in BQL as follows:
+//
+//	SELECT RSTREAM * FROM geofence("locations", "zones", "location", "device_id") [RANGE 1 TUPLES];
+//
+// The arguments are the name of the input stream, the name of the state,
+// the path to the location of an object in an input tuple, and the path to
+// the ID of the object. A location is a point accepted by geo.FromValue.
+//
+// Each output tuple has following fields:
+//
+//	- id: the ID of the object
+//	- region: the name of the region
+//	- event: "enter" or "exit"
+//	- location: the location of the object as a GeoJSON point
+//	- data: the input tuple
+//
+// Regions where each object is are kept in the UDSF. An object is forgotten
+// after it exits all regions. Changes of regions in the state are detected
+// when the next location of an object arrives.
+type geofenceUDSF struct {
+	state    string
+	location data.Path
+	id       data.Path
+
+	// inside has names of regions where each object is.
+	inside map[string]map[string]bool
+}
+
+func createGeofenceUDSF(ctx *core.Context, decl udf.UDSFDeclarer, stream, state, location, id string) (udf.UDSF, error) {
+	if err := decl.Input(stream, nil); err != nil {
+		return nil, err
+	}
+	f := &geofenceUDSF{
+		state:  state,
+		inside: map[string]map[string]bool{},
+	}
+	var err error
+	if f.location, err = data.CompilePath(location); err != nil {
+		return nil, fmt.Errorf("the path to the location is invalid: %v", err)
+	}
+	if f.id, err = data.CompilePath(id); err != nil {
+		return nil, fmt.Errorf("the path to the ID is invalid: %v", err)
+	}
+	if _, err := lookupGeoRegionsState(ctx, state); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func lookupGeoRegionsState(ctx *core.Context, name string) (*geoRegionsState, error) {
+	st, err := ctx.SharedStates.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	s, ok := st.(*geoRegionsState)
+	if !ok {
+		return nil, fmt.Errorf("state '%v' isn't a geo_regions state", name)
+	}
+	return s, nil
+}
+
+func (f *geofenceUDSF) Process(ctx *core.Context, t *core.Tuple, w core.Writer) error {
+	s, err := lookupGeoRegionsState(ctx, f.state)
+	if err != nil {
+		return err
+	}
+	l, err := t.Data.Get(f.location)
+	if err != nil {
+		return err
+	}
+	p, err := geo.PointFromValue(l)
+	if err != nil {
+		return err
+	}
+	i, err := t.Data.Get(f.id)
+	if err != nil {
+		return err
+	}
+	id, err := data.ToString(i)
+	if err != nil {
+		return err
+	}
+
+	cur := s.regionsContaining(p)
+	prev := f.inside[id]
+	if len(cur) == 0 {
+		delete(f.inside, id)
+	} else {
+		f.inside[id] = cur
+	}
+
+	emit := func(regions []string, event string) error {
+		sort.Strings(regions)
+		for _, r := range regions {
+			out := t.ShallowCopy()
+			out.Data = data.Map{
+				"id":       data.String(id),
+				"region":   data.String(r),
+				"event":    data.String(event),
+				"location": p.ToMap(),
+				"data":     t.Data,
+			}
+			if err := w.Write(ctx, out); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	var exits, enters []string
+	for r := range prev {
+		if !cur[r] {
+			exits = append(exits, r)
+		}
+	}
+	for r := range cur {
+		if !prev[r] {
+			enters = append(enters, r)
+		}
+	}
+	if err := emit(exits, "exit"); err != nil {
+		return err
+	}
+	return emit(enters, "enter")
+}
+
+func (f *geofenceUDSF) Terminate(ctx *core.Context) error {
+	return nil
+}
@@ -27,7 +27,9 @@ func init() {
 	udf.RegisterGlobalUDF("width_bucket", widthBucketFunc)
 	// random functions
 	udf.RegisterGlobalUDF("random", randomFunc)
+	udf.RegisterGlobalUDF("random_normal", randomNormalFunc)
 	udf.RegisterGlobalUDF("setseed", setseedFunc)
+	udf.RegisterGlobalUDF("uuid", uuidFunc)
 	// trigonometric functions
 	udf.RegisterGlobalUDF("acos", acosFunc)
 	udf.RegisterGlobalUDF("asin", asinFunc)
@@ -61,9 +63,17 @@ func init() {
 	udf.RegisterGlobalUDF("upper", upperFunc)
 	udf.RegisterGlobalUDF("encode_json", udf.UnaryFunc(encodeJSON))
 	udf.RegisterGlobalUDF("decode_json", udf.UnaryFunc(decodeJSON))
+	udf.RegisterGlobalUDF("parse_json", udf.UnaryFunc(parseJSON))
+	udf.RegisterGlobalUDF("parse_csv_line", &arityDispatcher{
+		unary: udf.VariadicFunc(parseCSVLine), binary: udf.VariadicFunc(parseCSVLine)})
+	udf.RegisterGlobalUDF("parse_kv", parseKVFunc)
 	// time functions
 	udf.RegisterGlobalUDF("distance_us", diffUsFunc)
 	udf.RegisterGlobalUDF("clock_timestamp", clockTimestampFunc)
+	udf.RegisterGlobalUDF("parse_timestamp", &arityDispatcher{
+		binary: udf.VariadicFunc(parseTimestamp), ternary: udf.VariadicFunc(parseTimestamp)})
+	udf.RegisterGlobalUDF("format_timestamp", &arityDispatcher{
+		binary: udf.VariadicFunc(formatTimestamp), ternary: udf.VariadicFunc(formatTimestamp)})
 	// array functions
 	udf.RegisterGlobalUDF("array_length", arrayLengthFunc)
 	// aggregate functions
@@ -78,8 +88,54 @@ func init() {
 	udf.RegisterGlobalUDF("min", minFunc)
 	udf.RegisterGlobalUDF("string_agg", stringAggFunc)
 	udf.RegisterGlobalUDF("sum", sumFunc)
+	// approximate aggregate functions
+	udf.RegisterGlobalUDF("approx_percentile", approxPercentileFunc)
+	udf.RegisterGlobalUDF("approx_percentile_sketch", approxPercentileSketchFunc)
+	udf.RegisterGlobalUDF("approx_percentile_merge", approxPercentileMergeFunc)
+	udf.RegisterGlobalUDF("approx_count_distinct", approxCountDistinctFunc)
+	udf.RegisterGlobalUDF("approx_count_distinct_sketch", approxCountDistinctSketchFunc)
+	udf.RegisterGlobalUDF("approx_count_distinct_merge", approxCountDistinctMergeFunc)
+	udf.RegisterGlobalUDF("approx_top_k", approxTopKFunc)
+	udf.RegisterGlobalUDF("approx_top_k_sketch", approxTopKSketchFunc)
+	udf.RegisterGlobalUDF("approx_top_k_merge", approxTopKMergeFunc)
+	// analytic functions
+	udf.RegisterGlobalUDF("first_value", firstValueFunc)
+	udf.RegisterGlobalUDF("last_value", lastValueFunc)
+	udf.RegisterGlobalUDF("lag", lagFunc)
+	udf.RegisterGlobalUDF("lead", leadFunc)
+	udf.RegisterGlobalUDF("row_number", rowNumberFunc)
+	// numeric window functions
+	udf.RegisterGlobalUDF("moving_avg", movingAvgFunc)
+	udf.RegisterGlobalUDF("ewma", ewmaFunc)
+	udf.RegisterGlobalUDF("percentile_cont", percentileContFunc)
+	// state functions
+	udf.RegisterGlobalUDF("kv_get", kvGetFunc)
+	udf.RegisterGlobalUDF("offsets_get", offsetsGetFunc)
+	udf.RegisterGlobalUDF("offsets_list", offsetsListFunc)
+	udf.RegisterGlobalUDF("offsets_reset", offsetsResetFunc)
+	udf.MustRegisterGlobalUDSFCreator("state_updates", udf.MustConvertToUDSFCreator(createStateUpdatesUDSF))
+	// metric functions
+	udf.RegisterGlobalUDF("metric_inc", metricIncFunc)
+	udf.RegisterGlobalUDF("metric_add", metricAddFunc)
+	udf.RegisterGlobalUDF("metric_set", metricSetFunc)
+	udf.RegisterGlobalUDF("metric_observe", metricObserveFunc)
+	udf.MustRegisterGlobalUDSCreator("metrics", udf.UDSCreatorFunc(createMetricsState))
+	// geo functions
+	udf.RegisterGlobalUDF("st_point", stPointFunc)
+	udf.RegisterGlobalUDF("st_distance", stDistanceFunc)
+	udf.RegisterGlobalUDF("st_within", stWithinFunc)
+	udf.RegisterGlobalUDF("st_contains", stContainsFunc)
+	udf.RegisterGlobalUDF("geohash_encode", geohashEncodeFunc)
+	udf.RegisterGlobalUDF("geohash_decode", geohashDecodeFunc)
+	udf.MustRegisterGlobalUDSCreator("geo_regions", udf.UDSCreatorFunc(createGeoRegionsState))
+	udf.MustRegisterGlobalUDSFCreator("geofence", udf.MustConvertToUDSFCreator(createGeofenceUDSF))
+	// schema functions
+	udf.RegisterGlobalUDF("validate_schema", validateSchemaFunc)
+	udf.MustRegisterGlobalUDSCreator("json_schema", &jsonSchemaStateCreator{})
 	// conversion functions
 	udf.RegisterGlobalUDF("blob_to_raw_string", udf.MustConvertGeneric(blobToRawString))
 	// other functions
 	udf.RegisterGlobalUDF("coalesce", coalesceFunc)
+	udf.RegisterGlobalUDF("nvl2", nvl2Func)
+	udf.RegisterGlobalUDF("nullif", nullifFunc)
 }
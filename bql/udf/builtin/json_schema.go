@@ -0,0 +1,189 @@
+package builtin
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sync"
+
+	"github.com/xeipuuv/gojsonschema"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/udf"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// jsonSchemaState is a UDS having a compiled JSON Schema. It can be created
+// in BQL as follows:
+//
+//	CREATE STATE order_schema TYPE json_schema WITH schema={
+//	    "type": "object",
+//	    "required": ["id", "price"],
+//	    "properties": {"price": {"type": "number", "minimum": 0}}
+//	};
+//
+// schema parameter is a map or a string having the schema in JSON. UPDATE
+// STATE replaces the schema. The state is saved as the schema in JSON, so a
+// JSON Schema file put in the UDS storage can also be loaded by LOAD STATE.
+//
+// validate_schema function validates a value with the schema. "schema"
+// parameter of CREATE STREAM validates all tuples emitted from the stream.
+type jsonSchemaState struct {
+	m      sync.RWMutex
+	source []byte
+	schema *gojsonschema.Schema
+}
+
+var (
+	_ core.LoadableSharedState = &jsonSchemaState{}
+	_ core.Updater             = &jsonSchemaState{}
+)
+
+// jsonSchemaStateCreator creates and loads jsonSchemaState.
+type jsonSchemaStateCreator struct{}
+
+var _ udf.UDSLoader = &jsonSchemaStateCreator{}
+
+func (c *jsonSchemaStateCreator) CreateState(ctx *core.Context, params data.Map) (core.SharedState, error) {
+	s := &jsonSchemaState{}
+	if err := s.Update(ctx, params); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (c *jsonSchemaStateCreator) LoadState(ctx *core.Context, r io.Reader, params data.Map) (core.SharedState, error) {
+	s := &jsonSchemaState{}
+	if err := s.Load(ctx, r, params); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *jsonSchemaState) Update(ctx *core.Context, params data.Map) error {
+	v, ok := params["schema"]
+	if !ok {
+		return errors.New("'schema' parameter is missing")
+	}
+	for k := range params {
+		if k != "schema" {
+			return fmt.Errorf("unsupported parameter: %v", k)
+		}
+	}
+
+	var src []byte
+	switch v.Type() {
+	case data.TypeString:
+		str, _ := data.AsString(v)
+		src = []byte(str)
+	case data.TypeMap:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Errorf("'schema' parameter cannot be encoded in JSON: %v", err)
+		}
+		src = b
+	default:
+		return fmt.Errorf("'schema' parameter must be a map or a string: %v", v.Type())
+	}
+	return s.set(src)
+}
+
+// set compiles the schema and replaces the current one with it.
+func (s *jsonSchemaState) set(src []byte) error {
+	schema, err := gojsonschema.NewSchema(gojsonschema.NewBytesLoader(src))
+	if err != nil {
+		return fmt.Errorf("the schema is invalid: %v", err)
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.source = src
+	s.schema = schema
+	return nil
+}
+
+// Save writes the schema in JSON.
+func (s *jsonSchemaState) Save(ctx *core.Context, w io.Writer, params data.Map) error {
+	s.m.RLock()
+	defer s.m.RUnlock()
+	_, err := w.Write(s.source)
+	return err
+}
+
+// Load replaces the schema with the one in JSON read from r.
+func (s *jsonSchemaState) Load(ctx *core.Context, r io.Reader, params data.Map) error {
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	return s.set(b)
+}
+
+func (s *jsonSchemaState) Terminate(ctx *core.Context) error {
+	return nil
+}
+
+// ValidateSchema validates the value with the schema. It returns
+// descriptions of errors, such as "price: Must be greater than or equal to
+// 0", or an empty slice when the value is valid.
+func (s *jsonSchemaState) ValidateSchema(v data.Value) ([]string, error) {
+	s.m.RLock()
+	schema := s.schema
+	s.m.RUnlock()
+
+	res, err := schema.Validate(gojsonschema.NewGoLoader(v))
+	if err != nil {
+		return nil, err
+	}
+	errs := make([]string, 0, len(res.Errors()))
+	for _, e := range res.Errors() {
+		errs = append(errs, e.String())
+	}
+	return errs, nil
+}
+
+func lookupJSONSchemaState(ctx *core.Context, name data.Value) (*jsonSchemaState, error) {
+	n, err := data.AsString(name)
+	if err != nil {
+		return nil, fmt.Errorf("the name of the state must be a string: %v", err)
+	}
+	st, err := ctx.SharedStates.Get(n)
+	if err != nil {
+		return nil, err
+	}
+	s, ok := st.(*jsonSchemaState)
+	if !ok {
+		return nil, fmt.Errorf("state '%v' isn't a json_schema state", n)
+	}
+	return s, nil
+}
+
+// validateSchemaFunc validates a value with the schema in a json_schema
+// state. It returns a map having "valid" and "errors" fields. "errors" is an
+// array of descriptions of errors, which is empty when the value is valid.
+//
+// It can be used in BQL as `validate_schema`:
+//
+//	SELECT RSTREAM * FROM orders [RANGE 1 TUPLES]
+//	    WHERE validate_schema(orders:*, "order_schema").valid;
+//
+//  Input: Any, String (the name of the state)
+//  Return Type: Map
+var validateSchemaFunc udf.UDF = udf.BinaryFunc(func(ctx *core.Context, doc data.Value, name data.Value) (data.Value, error) {
+	s, err := lookupJSONSchemaState(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	errs, err := s.ValidateSchema(doc)
+	if err != nil {
+		return nil, err
+	}
+	arr := make(data.Array, len(errs))
+	for i, e := range errs {
+		arr[i] = data.String(e)
+	}
+	return data.Map{
+		"valid":  data.Bool(len(errs) == 0),
+		"errors": arr,
+	}, nil
+})
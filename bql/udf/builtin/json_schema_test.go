@@ -0,0 +1,132 @@
+package builtin
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+func TestJSONSchemaState(t *testing.T) {
+	schema := data.Map{
+		"type":     data.String("object"),
+		"required": data.Array{data.String("id"), data.String("price")},
+		"properties": data.Map{
+			"price": data.Map{"type": data.String("number"), "minimum": data.Int(0)},
+		},
+	}
+
+	Convey("Given a json_schema state", t, func() {
+		ctx := core.NewContext(nil)
+		c := &jsonSchemaStateCreator{}
+		st, err := c.CreateState(ctx, data.Map{"schema": schema})
+		So(err, ShouldBeNil)
+		So(ctx.SharedStates.Add("order_schema", "json_schema", st), ShouldBeNil)
+
+		Convey("When validating a valid value", func() {
+			v, err := validateSchemaFunc.Call(ctx, data.Map{
+				"id":    data.String("a"),
+				"price": data.Float(1.5),
+			}, data.String("order_schema"))
+			So(err, ShouldBeNil)
+
+			Convey("Then it should be valid", func() {
+				So(v, ShouldResemble, data.Map{
+					"valid":  data.True,
+					"errors": data.Array{},
+				})
+			})
+		})
+
+		Convey("When validating an invalid value", func() {
+			v, err := validateSchemaFunc.Call(ctx, data.Map{
+				"price": data.Int(-1),
+			}, data.String("order_schema"))
+			So(err, ShouldBeNil)
+
+			Convey("Then it should return errors", func() {
+				m := v.(data.Map)
+				So(m["valid"], ShouldEqual, data.False)
+				errs := m["errors"].(data.Array)
+				So(errs, ShouldHaveLength, 2)
+				msgs := []string{}
+				for _, e := range errs {
+					s, _ := data.AsString(e)
+					msgs = append(msgs, s)
+				}
+				So(strings.Join(msgs, "\n"), ShouldContainSubstring, "id is required")
+				So(strings.Join(msgs, "\n"), ShouldContainSubstring, "price")
+			})
+		})
+
+		Convey("When saving and loading the state", func() {
+			buf := bytes.NewBuffer(nil)
+			So(st.(core.SavableSharedState).Save(ctx, buf, data.Map{}), ShouldBeNil)
+			st2, err := c.LoadState(ctx, bytes.NewReader(buf.Bytes()), data.Map{})
+			So(err, ShouldBeNil)
+
+			Convey("Then the loaded state should have the same schema", func() {
+				errs, err := st2.(*jsonSchemaState).ValidateSchema(data.Map{"id": data.Int(1)})
+				So(err, ShouldBeNil)
+				So(errs, ShouldHaveLength, 1)
+			})
+		})
+
+		Convey("When loading a JSON Schema file", func() {
+			err := st.(core.LoadableSharedState).Load(ctx, strings.NewReader(`{"type": "string"}`), data.Map{})
+			So(err, ShouldBeNil)
+
+			Convey("Then the schema should be replaced", func() {
+				errs, err := st.(*jsonSchemaState).ValidateSchema(data.String("a"))
+				So(err, ShouldBeNil)
+				So(errs, ShouldBeEmpty)
+			})
+		})
+
+		Convey("When updating the state with a schema in a string", func() {
+			err := st.(core.Updater).Update(ctx, data.Map{"schema": data.String(`{"type": "integer"}`)})
+			So(err, ShouldBeNil)
+
+			Convey("Then the schema should be replaced", func() {
+				v, err := validateSchemaFunc.Call(ctx, data.String("a"), data.String("order_schema"))
+				So(err, ShouldBeNil)
+				So(v.(data.Map)["valid"], ShouldEqual, data.False)
+			})
+		})
+
+		Convey("When calling the function with a wrong state", func() {
+			So(ctx.SharedStates.Add("offsets", "offsets", core.NewOffsetsState()), ShouldBeNil)
+
+			Convey("Then it should fail", func() {
+				_, err := validateSchemaFunc.Call(ctx, data.Map{}, data.String("offsets"))
+				So(err, ShouldNotBeNil)
+				_, err = validateSchemaFunc.Call(ctx, data.Map{}, data.String("missing"))
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+
+	Convey("Given invalid parameters of a json_schema state", t, func() {
+		ctx := core.NewContext(nil)
+		c := &jsonSchemaStateCreator{}
+		for title, params := range map[string]data.Map{
+			"no schema":          {},
+			"an invalid schema":  {"schema": data.Map{"type": data.Int(1)}},
+			"a broken JSON":      {"schema": data.String(`{"type":`)},
+			"an unknown param":   {"schema": schema, "x": data.Int(1)},
+			"a non-map non-json": {"schema": data.Int(1)},
+		} {
+			params := params
+			Convey("When creating a state with "+title, func() {
+				_, err := c.CreateState(ctx, params)
+
+				Convey("Then it should fail", func() {
+					So(err, ShouldNotBeNil)
+				})
+			})
+		}
+	})
+}
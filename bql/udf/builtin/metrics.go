@@ -0,0 +1,397 @@
+package builtin
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/sensorbee/sensorbee.v0/bql/udf"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// metricsState is a UDS having counters, gauges, and histograms written by
+// metric_* functions. Its metrics are exposed by the server in the Prometheus
+// text exposition format. It can be created in BQL as follows:
+//
+//	CREATE STATE kpis TYPE metrics WITH window="1m",
+//	    buckets=[0.1, 0.5, 1.0, 5.0];
+//
+// The state has following optional parameters:
+//
+//	- window: the length of tumbling windows (no window by default)
+//	- buckets: upper bounds of buckets of histograms
+//	  (Prometheus' default buckets by default)
+//
+// Without a window, metrics are accumulated from the creation of the state.
+// With a window, metrics are reset at the beginning of each window and the
+// values of the last completed window are exposed, so that each scrape
+// returns stable per-window values such as the number of orders per minute.
+type metricsState struct {
+	m          sync.Mutex
+	window     time.Duration
+	buckets    []float64
+	terminated bool
+
+	// types has the type of each metric. A metric keeps its type even after
+	// its series are reset by a window.
+	types map[string]core.MetricType
+
+	// current has series of the current window. Counts of buckets of
+	// histograms aren't cumulative here.
+	current     map[string]*core.Metric
+	windowStart time.Time
+
+	// completed has series of the last completed window. It's only used when
+	// the state has a window.
+	completed map[string]*core.Metric
+
+	now func() time.Time
+}
+
+var _ core.MetricsExporter = &metricsState{}
+
+var (
+	metricNamePattern  = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+	metricLabelPattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+	// reservedMetricLabels are labels added by histograms and the server.
+	reservedMetricLabels = map[string]bool{"le": true, "topology": true, "state": true}
+
+	defaultMetricBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+)
+
+func createMetricsState(ctx *core.Context, params data.Map) (core.SharedState, error) {
+	c := &struct {
+		Window  time.Duration
+		Buckets []float64
+	}{
+		Buckets: defaultMetricBuckets,
+	}
+	if err := data.NewDecoder(nil).Decode(params, c); err != nil {
+		return nil, err
+	}
+	if c.Window < 0 {
+		return nil, fmt.Errorf("window must not be negative: %v", c.Window)
+	}
+	if len(c.Buckets) == 0 {
+		return nil, errors.New("buckets must not be empty")
+	}
+	for i := 1; i < len(c.Buckets); i++ {
+		if c.Buckets[i-1] >= c.Buckets[i] {
+			return nil, fmt.Errorf("buckets must be sorted in increasing order: %v", c.Buckets)
+		}
+	}
+
+	s := &metricsState{
+		window:  c.Window,
+		buckets: c.Buckets,
+		types:   map[string]core.MetricType{},
+		current: map[string]*core.Metric{},
+		now:     time.Now,
+	}
+	if s.window > 0 {
+		s.windowStart = s.now().Truncate(s.window)
+	}
+	return s, nil
+}
+
+func (s *metricsState) Terminate(ctx *core.Context) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.terminated = true
+	s.current = nil
+	s.completed = nil
+	return nil
+}
+
+// rotate moves to the window containing the current time. The caller must
+// hold the lock.
+func (s *metricsState) rotate() {
+	if s.window <= 0 {
+		return
+	}
+	now := s.now()
+	if now.Before(s.windowStart.Add(s.window)) {
+		return
+	}
+	if now.Before(s.windowStart.Add(2 * s.window)) {
+		s.completed = s.current
+	} else {
+		// Nothing was written in the last completed window.
+		s.completed = nil
+	}
+	s.current = map[string]*core.Metric{}
+	s.windowStart = now.Truncate(s.window)
+}
+
+// series returns the series of the metric having the labels in the current
+// window. It creates a new series when the window doesn't have it. The caller
+// must hold the lock.
+func (s *metricsState) series(name string, typ core.MetricType, labels map[string]string) (*core.Metric, error) {
+	if s.terminated {
+		return nil, errors.New("the state is already terminated")
+	}
+	if t, ok := s.types[name]; ok && t != typ {
+		return nil, fmt.Errorf("metric '%v' is a %v, not a %v", name, t, typ)
+	}
+	s.rotate()
+
+	key := metricKey(name, labels)
+	if m, ok := s.current[key]; ok {
+		return m, nil
+	}
+	m := &core.Metric{
+		Name:   name,
+		Type:   typ,
+		Labels: labels,
+	}
+	if typ == core.MetricHistogram {
+		m.Buckets = make([]core.MetricBucket, len(s.buckets))
+		for i, b := range s.buckets {
+			m.Buckets[i].UpperBound = b
+		}
+	}
+	s.types[name] = typ
+	s.current[key] = m
+	return m, nil
+}
+
+func metricKey(name string, labels map[string]string) string {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := []string{name}
+	for _, k := range keys {
+		parts = append(parts, k, labels[k])
+	}
+	return strings.Join(parts, "\xff")
+}
+
+// add adds delta to a counter and returns the new value.
+func (s *metricsState) add(name string, labels map[string]string, delta float64) (float64, error) {
+	if delta < 0 {
+		return 0, fmt.Errorf("a counter cannot be decreased: %v", delta)
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	m, err := s.series(name, core.MetricCounter, labels)
+	if err != nil {
+		return 0, err
+	}
+	m.Value += delta
+	return m.Value, nil
+}
+
+// set sets the value of a gauge.
+func (s *metricsState) set(name string, labels map[string]string, v float64) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	m, err := s.series(name, core.MetricGauge, labels)
+	if err != nil {
+		return err
+	}
+	m.Value = v
+	return nil
+}
+
+// observe adds a value to a histogram.
+func (s *metricsState) observe(name string, labels map[string]string, v float64) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	m, err := s.series(name, core.MetricHistogram, labels)
+	if err != nil {
+		return err
+	}
+	for i := range m.Buckets {
+		if v <= m.Buckets[i].UpperBound {
+			m.Buckets[i].Count++
+			break
+		}
+	}
+	m.Sum += v
+	m.Count++
+	return nil
+}
+
+// Metrics returns copies of series of the current window, or the last
+// completed window when the state has a window.
+func (s *metricsState) Metrics() []*core.Metric {
+	s.m.Lock()
+	defer s.m.Unlock()
+	series := s.current
+	if s.window > 0 {
+		s.rotate()
+		series = s.completed
+	}
+
+	res := make([]*core.Metric, 0, len(series))
+	for _, m := range series {
+		c := *m
+		if m.Buckets != nil {
+			c.Buckets = make([]core.MetricBucket, len(m.Buckets))
+			var n int64
+			for i, b := range m.Buckets {
+				n += b.Count
+				c.Buckets[i] = core.MetricBucket{UpperBound: b.UpperBound, Count: n}
+			}
+		}
+		res = append(res, &c)
+	}
+	return res
+}
+
+// metricFuncTmpl is a template of functions writing a value to a metric in
+// a metrics state. Arguments are the name of the state, the name of the
+// metric, the value (if hasValue is true), and optional labels given as a map.
+type metricFuncTmpl struct {
+	hasValue bool
+	write    func(s *metricsState, name string, labels map[string]string, v float64) (data.Value, error)
+}
+
+func (f *metricFuncTmpl) Accept(arity int) bool {
+	n := 2
+	if f.hasValue {
+		n = 3
+	}
+	return arity == n || arity == n+1
+}
+
+func (f *metricFuncTmpl) IsAggregationParameter(k int) bool {
+	return false
+}
+
+func (f *metricFuncTmpl) Call(ctx *core.Context, args ...data.Value) (data.Value, error) {
+	if !f.Accept(len(args)) {
+		return nil, fmt.Errorf("invalid number of arguments: %v", len(args))
+	}
+	s, err := lookupMetricsState(ctx, args[0])
+	if err != nil {
+		return nil, err
+	}
+	name, err := data.AsString(args[1])
+	if err != nil {
+		return nil, fmt.Errorf("the name of the metric must be a string: %v", err)
+	}
+	if !metricNamePattern.MatchString(name) {
+		return nil, fmt.Errorf("invalid metric name: %v", name)
+	}
+	args = args[2:]
+
+	v := 1.0
+	if f.hasValue {
+		if v, err = data.ToFloat(args[0]); err != nil {
+			return nil, fmt.Errorf("the value must be a number: %v", err)
+		}
+		args = args[1:]
+	}
+
+	var labels map[string]string
+	if len(args) == 1 && args[0].Type() != data.TypeNull {
+		m, err := data.AsMap(args[0])
+		if err != nil {
+			return nil, fmt.Errorf("labels must be a map: %v", err)
+		}
+		labels = make(map[string]string, len(m))
+		for k, l := range m {
+			if !metricLabelPattern.MatchString(k) || strings.HasPrefix(k, "__") || reservedMetricLabels[k] {
+				return nil, fmt.Errorf("invalid label name: %v", k)
+			}
+			if labels[k], err = data.ToString(l); err != nil {
+				return nil, fmt.Errorf("label '%v' cannot be converted to a string: %v", k, err)
+			}
+		}
+	}
+	return f.write(s, name, labels, v)
+}
+
+func lookupMetricsState(ctx *core.Context, name data.Value) (*metricsState, error) {
+	n, err := data.AsString(name)
+	if err != nil {
+		return nil, fmt.Errorf("the name of the state must be a string: %v", err)
+	}
+	st, err := ctx.SharedStates.Get(n)
+	if err != nil {
+		return nil, err
+	}
+	s, ok := st.(*metricsState)
+	if !ok {
+		return nil, fmt.Errorf("state '%v' isn't a metrics state", n)
+	}
+	return s, nil
+}
+
+// metricIncFunc increments a counter in a metrics state by 1 and returns
+// the new value.
+//
+// It can be used in BQL as `metric_inc`.
+//
+//  Input: String (the name of the state), String (the name of the metric),
+//   Map (optional, labels)
+//  Return Type: Float
+var metricIncFunc udf.UDF = &metricFuncTmpl{
+	write: addToCounter,
+}
+
+// metricAddFunc adds a non-negative value to a counter in a metrics state
+// and returns the new value.
+//
+// It can be used in BQL as `metric_add`.
+//
+//  Input: String (the name of the state), String (the name of the metric),
+//   Float, Map (optional, labels)
+//  Return Type: Float
+var metricAddFunc udf.UDF = &metricFuncTmpl{
+	hasValue: true,
+	write:    addToCounter,
+}
+
+func addToCounter(s *metricsState, name string, labels map[string]string, v float64) (data.Value, error) {
+	n, err := s.add(name, labels, v)
+	if err != nil {
+		return nil, err
+	}
+	return data.Float(n), nil
+}
+
+// metricSetFunc sets the value of a gauge in a metrics state and returns
+// the value.
+//
+// It can be used in BQL as `metric_set`.
+//
+//  Input: String (the name of the state), String (the name of the metric),
+//   Float, Map (optional, labels)
+//  Return Type: Float
+var metricSetFunc udf.UDF = &metricFuncTmpl{
+	hasValue: true,
+	write: func(s *metricsState, name string, labels map[string]string, v float64) (data.Value, error) {
+		if err := s.set(name, labels, v); err != nil {
+			return nil, err
+		}
+		return data.Float(v), nil
+	},
+}
+
+// metricObserveFunc adds a value to a histogram in a metrics state and
+// returns the value.
+//
+// It can be used in BQL as `metric_observe`.
+//
+//  Input: String (the name of the state), String (the name of the metric),
+//   Float, Map (optional, labels)
+//  Return Type: Float
+var metricObserveFunc udf.UDF = &metricFuncTmpl{
+	hasValue: true,
+	write: func(s *metricsState, name string, labels map[string]string, v float64) (data.Value, error) {
+		if err := s.observe(name, labels, v); err != nil {
+			return nil, err
+		}
+		return data.Float(v), nil
+	},
+}
@@ -0,0 +1,115 @@
+package builtin
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"testing"
+	"time"
+)
+
+func TestMetricsState(t *testing.T) {
+	Convey("Given a metrics state", t, func() {
+		ctx := core.NewContext(nil)
+		st, err := createMetricsState(ctx, data.Map{"buckets": data.Array{data.Float(1), data.Float(10)}})
+		So(err, ShouldBeNil)
+		So(ctx.SharedStates.Add("kpis", "metrics", st), ShouldBeNil)
+		s := st.(*metricsState)
+		labels := data.Map{"region": data.String("eu")}
+
+		Convey("When writing metrics with functions", func() {
+			v, err := metricIncFunc.Call(ctx, data.String("kpis"), data.String("orders"), labels)
+			So(err, ShouldBeNil)
+			So(v, ShouldEqual, data.Float(1))
+			v, err = metricAddFunc.Call(ctx, data.String("kpis"), data.String("orders"), data.Int(2), labels)
+			So(err, ShouldBeNil)
+			So(v, ShouldEqual, data.Float(3))
+			_, err = metricIncFunc.Call(ctx, data.String("kpis"), data.String("orders"))
+			So(err, ShouldBeNil)
+			_, err = metricSetFunc.Call(ctx, data.String("kpis"), data.String("temp"), data.Float(21.5))
+			So(err, ShouldBeNil)
+			for _, x := range []float64{0.5, 5, 50} {
+				_, err = metricObserveFunc.Call(ctx, data.String("kpis"), data.String("latency"), data.Float(x))
+				So(err, ShouldBeNil)
+			}
+
+			Convey("Then the state should export them", func() {
+				ms := map[string]*core.Metric{}
+				for _, m := range s.Metrics() {
+					ms[metricKey(m.Name, m.Labels)] = m
+				}
+				So(ms, ShouldHaveLength, 4)
+				So(ms[metricKey("orders", map[string]string{"region": "eu"})].Value, ShouldEqual, 3)
+				So(ms[metricKey("orders", nil)].Value, ShouldEqual, 1)
+				So(ms[metricKey("temp", nil)].Type, ShouldEqual, core.MetricGauge)
+
+				h := ms[metricKey("latency", nil)]
+				So(h.Buckets, ShouldResemble, []core.MetricBucket{{1, 1}, {10, 2}})
+				So(h.Count, ShouldEqual, 3)
+				So(h.Sum, ShouldEqual, 55.5)
+			})
+
+			Convey("Then writing a metric with a different type should fail", func() {
+				_, err := metricSetFunc.Call(ctx, data.String("kpis"), data.String("orders"), data.Int(1))
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When calling functions with invalid arguments", func() {
+			cases := map[string][]data.Value{
+				"a missing state":    {data.String("nokpis"), data.String("orders")},
+				"an invalid name":    {data.String("kpis"), data.String("a-b")},
+				"a reserved label":   {data.String("kpis"), data.String("orders"), data.Map{"state": data.String("x")}},
+				"labels not a map":   {data.String("kpis"), data.String("orders"), data.String("x")},
+				"too many arguments": {data.String("kpis"), data.String("orders"), labels, labels},
+			}
+			for title, args := range cases {
+				args := args
+				Convey("Then it should fail with "+title, func() {
+					_, err := metricIncFunc.Call(ctx, args...)
+					So(err, ShouldNotBeNil)
+				})
+			}
+
+			Convey("Then decreasing a counter should fail", func() {
+				_, err := metricAddFunc.Call(ctx, data.String("kpis"), data.String("orders"), data.Int(-1))
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+
+	Convey("Given a metrics state having a window", t, func() {
+		ctx := core.NewContext(nil)
+		st, err := createMetricsState(ctx, data.Map{"window": data.String("1m")})
+		So(err, ShouldBeNil)
+		s := st.(*metricsState)
+		now := time.Date(2016, 1, 2, 3, 4, 5, 0, time.UTC)
+		s.now = func() time.Time { return now }
+		s.windowStart = now.Truncate(time.Minute)
+
+		Convey("When writing metrics in a window", func() {
+			_, err := s.add("orders", nil, 2)
+			So(err, ShouldBeNil)
+
+			Convey("Then they shouldn't be exported until the window ends", func() {
+				So(s.Metrics(), ShouldBeEmpty)
+
+				now = now.Add(time.Minute)
+				ms := s.Metrics()
+				So(ms, ShouldHaveLength, 1)
+				So(ms[0].Value, ShouldEqual, 2)
+
+				Convey("And the next window should start from zero", func() {
+					v, err := s.add("orders", nil, 1)
+					So(err, ShouldBeNil)
+					So(v, ShouldEqual, 1)
+				})
+
+				Convey("And a window without writes should export nothing", func() {
+					now = now.Add(time.Minute)
+					So(s.Metrics(), ShouldBeEmpty)
+				})
+			})
+		})
+	})
+}
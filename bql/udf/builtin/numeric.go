@@ -6,7 +6,6 @@ import (
 	"gopkg.in/sensorbee/sensorbee.v0/core"
 	"gopkg.in/sensorbee/sensorbee.v0/data"
 	"math"
-	"math/rand"
 )
 
 // singleParamFunc is a template for functions that
@@ -632,17 +631,135 @@ func (f *widthBucketFuncTmpl) Call(ctx *core.Context, args ...data.Value) (val d
 //  Return Type: Int
 var widthBucketFunc udf.UDF = &widthBucketFuncTmpl{}
 
+// randomSource is embedded in UDFs generating random values. It has the
+// random number generator bound by udf.RandomizedUDF.WithRandom.
+type randomSource struct {
+	r *core.Random
+}
+
+// random returns the bound generator or the one of the Context when no
+// generator is bound.
+func (s *randomSource) random(ctx *core.Context) *core.Random {
+	if s.r != nil {
+		return s.r
+	}
+	return ctx.Random()
+}
+
+type randomFuncTmpl struct {
+	randomSource
+}
+
+func (f *randomFuncTmpl) Accept(arity int) bool {
+	return arity == 0
+}
+
+func (f *randomFuncTmpl) IsAggregationParameter(k int) bool {
+	return false
+}
+
+func (f *randomFuncTmpl) Call(ctx *core.Context, args ...data.Value) (data.Value, error) {
+	if len(args) != 0 {
+		return nil, fmt.Errorf("function takes no arguments")
+	}
+	return data.Float(f.random(ctx).Float64()), nil
+}
+
+func (f *randomFuncTmpl) WithRandom(r *core.Random) udf.UDF {
+	return &randomFuncTmpl{randomSource{r}}
+}
+
 // randomFunc returns a random number in the range [0,1[.
-// See also: math/rand.Float64()
+// See also: core.Context.Random
 //
 // It can be used in BQL as `random`.
 //
 //  Input: None
 //  Return Type: Float
-var randomFunc, _ = udf.ConvertGeneric(func() float64 { return rand.Float64() })
+var randomFunc udf.UDF = &randomFuncTmpl{}
+
+type randomNormalFuncTmpl struct {
+	twoParamFunc
+	randomSource
+}
+
+func (f *randomNormalFuncTmpl) Call(ctx *core.Context, args ...data.Value) (data.Value, error) {
+	if len(args) != 2 {
+		return nil, fmt.Errorf("function takes exactly two arguments")
+	}
+	if args[0].Type() == data.TypeNull || args[1].Type() == data.TypeNull {
+		return data.Null{}, nil
+	}
+	var ps [2]float64
+	for i, arg := range args {
+		switch arg.Type() {
+		case data.TypeInt:
+			n, _ := data.AsInt(arg)
+			ps[i] = float64(n)
+		case data.TypeFloat:
+			ps[i], _ = data.AsFloat(arg)
+		default:
+			return nil, fmt.Errorf("%d-th parameter must be Int or Float", i)
+		}
+	}
+	mu, sigma := ps[0], ps[1]
+	if sigma < 0 {
+		return nil, fmt.Errorf("standard deviation must not be negative")
+	}
+	return data.Float(f.random(ctx).NormFloat64()*sigma + mu), nil
+}
+
+func (f *randomNormalFuncTmpl) WithRandom(r *core.Random) udf.UDF {
+	return &randomNormalFuncTmpl{randomSource: randomSource{r}}
+}
+
+// randomNormalFunc(mu, sigma) returns a normally distributed random number
+// with the mean mu and the standard deviation sigma.
+// See also: math/rand.NormFloat64()
+//
+// It can be used in BQL as `random_normal`.
+//
+//  Input: 2 * Int or Float
+//  Return Type: Float
+var randomNormalFunc udf.UDF = &randomNormalFuncTmpl{}
+
+type uuidFuncTmpl struct {
+	randomSource
+}
+
+func (f *uuidFuncTmpl) Accept(arity int) bool {
+	return arity == 0
+}
+
+func (f *uuidFuncTmpl) IsAggregationParameter(k int) bool {
+	return false
+}
+
+func (f *uuidFuncTmpl) Call(ctx *core.Context, args ...data.Value) (data.Value, error) {
+	if len(args) != 0 {
+		return nil, fmt.Errorf("function takes no arguments")
+	}
+	return data.String(f.random(ctx).UUID()), nil
+}
+
+func (f *uuidFuncTmpl) WithRandom(r *core.Random) udf.UDF {
+	return &uuidFuncTmpl{randomSource{r}}
+}
+
+// uuidFunc returns a random UUID (version 4) such as
+// "3d813cbb-47fb-42ba-91df-831e1593ac29". UUIDs are generated by the same
+// random number generator as randomFunc, so they're predictable and must
+// not be used for security purposes.
+//
+// It can be used in BQL as `uuid`.
+//
+//  Input: None
+//  Return Type: String
+var uuidFunc udf.UDF = &uuidFuncTmpl{}
 
 type setseedFuncTmpl struct {
 	singleParamFunc
+	randomSource
 }
 
 func (f *setseedFuncTmpl) Call(ctx *core.Context, args ...data.Value) (val data.Value, err error) {
@@ -663,15 +780,20 @@ func (f *setseedFuncTmpl) Call(ctx *core.Context, args ...data.Value) (val data.
 			return nil, fmt.Errorf("seed out of range [-1,1]")
 		}
 		s := int64(d * float64(math.MaxInt64))
-		rand.Seed(s)
+		f.random(ctx).Seed(s)
 		return data.Null{}, nil
 	}
 	return nil, fmt.Errorf("cannot interpret %s as float", arg)
 }
 
-// setseed initializes the seed for subsequent randomFunc calls.
-// The argument must be a float in the range [-1,1].
-// See also: math/rand.Seed()
+func (f *setseedFuncTmpl) WithRandom(r *core.Random) udf.UDF {
+	return &setseedFuncTmpl{randomSource: randomSource{r}}
+}
+
+// setseed initializes the seed for subsequent randomFunc, randomNormalFunc,
+// and uuidFunc calls in the same node. The argument must be a float in the
+// range [-1,1].
+// See also: core.Random.Seed
 //
 // It can be used in BQL as `setseed`.
 //
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	. "github.com/smartystreets/goconvey/convey"
 	"gopkg.in/sensorbee/sensorbee.v0/bql/udf"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
 	"gopkg.in/sensorbee/sensorbee.v0/data"
 	"math"
 	"testing"
@@ -37,6 +38,111 @@ func TestNullaryNumericFuncs(t *testing.T) {
 		So(err, ShouldBeNil)
 		So(regFun, ShouldHaveSameTypeAs, randomFunc)
 	})
+
+	Convey("Given random functions bound to generators with the same seed", t, func() {
+		fs := map[string]udf.UDF{}
+		reg := udf.CopyGlobalUDFRegistry(nil)
+		for n, arity := range map[string]int{"random": 0, "random_normal": 2, "uuid": 0, "setseed": 1} {
+			f, err := reg.Lookup(n, arity)
+			So(err, ShouldBeNil)
+			So(f, ShouldImplement, (*udf.RandomizedUDF)(nil))
+			fs[n] = f
+		}
+		bind := func(seed int64) map[string]udf.UDF {
+			r := core.NewRandom(seed)
+			m := map[string]udf.UDF{}
+			for n, f := range fs {
+				m[n] = f.(udf.RandomizedUDF).WithRandom(r)
+			}
+			return m
+		}
+		f1, f2 := bind(1), bind(1)
+
+		Convey("When calling them", func() {
+			call := func(m map[string]udf.UDF) []data.Value {
+				var vs []data.Value
+				for i := 0; i < 10; i++ {
+					v, err := m["random"].Call(nil)
+					So(err, ShouldBeNil)
+					vs = append(vs, v)
+					v, err = m["random_normal"].Call(nil, data.Int(10), data.Float(0.5))
+					So(err, ShouldBeNil)
+					vs = append(vs, v)
+					v, err = m["uuid"].Call(nil)
+					So(err, ShouldBeNil)
+					vs = append(vs, v)
+				}
+				return vs
+			}
+			vs := call(f1)
+
+			Convey("Then they should return the same values", func() {
+				So(call(f2), ShouldResemble, vs)
+			})
+
+			Convey("Then setseed should reset the sequence", func() {
+				_, err := f1["setseed"].Call(nil, data.Float(0.5))
+				So(err, ShouldBeNil)
+				_, err = f2["setseed"].Call(nil, data.Float(0.5))
+				So(err, ShouldBeNil)
+				So(call(f1), ShouldResemble, call(f2))
+			})
+
+			Convey("Then functions in the global registry should not be modified", func() {
+				So(fs["random"], ShouldEqual, randomFunc)
+				So(randomFunc.(*randomFuncTmpl).r, ShouldBeNil)
+			})
+		})
+	})
+
+	Convey("Given random_normal", t, func() {
+		f := randomNormalFunc
+
+		Convey("When calling it with a standard deviation of 0", func() {
+			v, err := f.Call(nil, data.Float(1.5), data.Int(0))
+
+			Convey("Then it should return the mean", func() {
+				So(err, ShouldBeNil)
+				So(v, ShouldEqual, data.Float(1.5))
+			})
+		})
+
+		for _, args := range [][]data.Value{
+			{data.Null{}, data.Float(1)},
+			{data.Float(1), data.Null{}},
+		} {
+			Convey(fmt.Sprintf("When calling it with %v", args), func() {
+				v, err := f.Call(nil, args...)
+
+				Convey("Then it should return Null", func() {
+					So(err, ShouldBeNil)
+					So(v, ShouldResemble, data.Null{})
+				})
+			})
+		}
+
+		for _, args := range [][]data.Value{
+			{data.Float(1), data.Float(-1)},
+			{data.String("a"), data.Float(1)},
+			{data.Float(1)},
+		} {
+			Convey(fmt.Sprintf("When calling it with invalid arguments %v", args), func() {
+				_, err := f.Call(nil, args...)
+
+				Convey("Then it should fail", func() {
+					So(err, ShouldNotBeNil)
+				})
+			})
+		}
+	})
+
+	Convey("uuid() should return a UUID", t, func() {
+		v, err := uuidFunc.Call(nil)
+		So(err, ShouldBeNil)
+		s, err := data.AsString(v)
+		So(err, ShouldBeNil)
+		So(s, ShouldHaveLength, 36)
+	})
 }
 
 type udfUnaryTestCase struct {
@@ -0,0 +1,587 @@
+package builtin
+
+import (
+	"container/heap"
+	"encoding/binary"
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"hash/fnv"
+	"math"
+	"sort"
+)
+
+// Sketches are compact summaries of a large number of values used by
+// approximate aggregate functions. Every sketch can be converted to a
+// data.Map and back so that it can be emitted in a tuple, and sketches of the
+// same kind can be merged. This allows boxes processing partitions of a
+// stream to emit sketches which a downstream box merges into one result.
+
+// sketchKindKey is the key of the field of a sketch map holding its kind.
+const sketchKindKey = "sketch"
+
+func sketchKind(v data.Value) (data.Map, string, error) {
+	m, err := data.AsMap(v)
+	if err != nil {
+		return nil, "", fmt.Errorf("a sketch must be a map, not %T", v)
+	}
+	k, err := data.AsString(m[sketchKindKey])
+	if err != nil {
+		return nil, "", fmt.Errorf("the map isn't a sketch: %v", err)
+	}
+	return m, k, nil
+}
+
+// hashValue returns a hash of the value. Values having the same type and
+// the same string representation have the same hash.
+func hashValue(v data.Value) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte{byte(v.Type())})
+	h.Write([]byte(v.String()))
+	x := h.Sum64()
+
+	// FNV doesn't distribute bits well enough for HyperLogLog, so the
+	// finalizer of MurmurHash3 is applied.
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	x *= 0xc4ceb9fe1a85ec53
+	x ^= x >> 33
+	return x
+}
+
+func toFloats(a data.Array) ([]float64, error) {
+	fs := make([]float64, len(a))
+	for i, v := range a {
+		f, err := data.ToFloat(v)
+		if err != nil {
+			return nil, err
+		}
+		fs[i] = f
+	}
+	return fs, nil
+}
+
+func fromFloats(fs []float64) data.Array {
+	a := make(data.Array, len(fs))
+	for i, f := range fs {
+		a[i] = data.Float(f)
+	}
+	return a
+}
+
+/// t-digest
+
+const (
+	tDigestKind = "tdigest"
+
+	// defaultTDigestCompression bounds the number of centroids of a t-digest
+	// to about this value.
+	defaultTDigestCompression = 100
+)
+
+type centroid struct {
+	mean   float64
+	weight float64
+}
+
+// tDigest is a merging t-digest estimating quantiles of numbers. Centroids
+// near both ends of the distribution are kept small so that extreme
+// quantiles are accurate.
+type tDigest struct {
+	compression float64
+	centroids   []centroid
+	buffer      []centroid
+	min         float64
+	max         float64
+}
+
+func newTDigest(compression float64) *tDigest {
+	return &tDigest{
+		compression: compression,
+		min:         math.Inf(1),
+		max:         math.Inf(-1),
+	}
+}
+
+func (t *tDigest) add(x float64, w float64) {
+	if math.IsNaN(x) || w <= 0 {
+		return
+	}
+	t.buffer = append(t.buffer, centroid{x, w})
+	t.min = math.Min(t.min, x)
+	t.max = math.Max(t.max, x)
+	if len(t.buffer) >= 5*int(t.compression) {
+		t.compress()
+	}
+}
+
+func (t *tDigest) merge(o *tDigest) {
+	o.compress()
+	if len(o.centroids) == 0 {
+		return
+	}
+	t.buffer = append(t.buffer, o.centroids...)
+	t.min = math.Min(t.min, o.min)
+	t.max = math.Max(t.max, o.max)
+	t.compress()
+}
+
+// compress merges buffered values into centroids.
+func (t *tDigest) compress() {
+	if len(t.buffer) == 0 {
+		return
+	}
+	cs := append(t.centroids, t.buffer...)
+	t.buffer = nil
+	sort.Sort(byMean(cs))
+
+	total := 0.0
+	for _, c := range cs {
+		total += c.weight
+	}
+	res := make([]centroid, 0, int(t.compression))
+	cur := cs[0]
+	sofar := 0.0
+	for _, c := range cs[1:] {
+		proposed := cur.weight + c.weight
+		if t.scale((sofar+proposed)/total)-t.scale(sofar/total) <= 1 {
+			cur.mean += (c.mean - cur.mean) * c.weight / proposed
+			cur.weight = proposed
+			continue
+		}
+		sofar += cur.weight
+		res = append(res, cur)
+		cur = c
+	}
+	t.centroids = append(res, cur)
+}
+
+// scale maps a quantile to the index of centroids. A centroid can only cover
+// quantiles whose indices differ by at most 1, so centroids near both ends
+// are small and the number of centroids is bounded by the compression.
+func (t *tDigest) scale(q float64) float64 {
+	return t.compression / (2 * math.Pi) * math.Asin(2*math.Min(q, 1)-1)
+}
+
+func (t *tDigest) count() float64 {
+	t.compress()
+	n := 0.0
+	for _, c := range t.centroids {
+		n += c.weight
+	}
+	return n
+}
+
+// quantile returns the estimated q-quantile. q must be in [0, 1]. It returns
+// NaN when the digest is empty.
+func (t *tDigest) quantile(q float64) float64 {
+	total := t.count()
+	cs := t.centroids
+	switch {
+	case len(cs) == 0:
+		return math.NaN()
+	case q <= 0:
+		return t.min
+	case q >= 1:
+		return t.max
+	case len(cs) == 1:
+		return cs[0].mean
+	}
+
+	target := q * total
+	// the center of the first centroid is at cs[0].weight / 2
+	left := cs[0].weight / 2
+	if target < left {
+		return t.min + (cs[0].mean-t.min)*target/left
+	}
+	for i := 0; i < len(cs)-1; i++ {
+		right := left + (cs[i].weight+cs[i+1].weight)/2
+		if target < right {
+			return cs[i].mean + (cs[i+1].mean-cs[i].mean)*(target-left)/(right-left)
+		}
+		left = right
+	}
+	last := cs[len(cs)-1]
+	return last.mean + (t.max-last.mean)*(target-left)/(total-left)
+}
+
+func (t *tDigest) toMap() data.Map {
+	t.compress()
+	means := make([]float64, len(t.centroids))
+	weights := make([]float64, len(t.centroids))
+	for i, c := range t.centroids {
+		means[i] = c.mean
+		weights[i] = c.weight
+	}
+	m := data.Map{
+		sketchKindKey: data.String(tDigestKind),
+		"compression": data.Float(t.compression),
+		"means":       fromFloats(means),
+		"weights":     fromFloats(weights),
+	}
+	if len(t.centroids) > 0 {
+		m["min"] = data.Float(t.min)
+		m["max"] = data.Float(t.max)
+	}
+	return m
+}
+
+func tDigestFromValue(v data.Value) (*tDigest, error) {
+	m, kind, err := sketchKind(v)
+	if err != nil {
+		return nil, err
+	}
+	if kind != tDigestKind {
+		return nil, fmt.Errorf("the sketch isn't a t-digest: %v", kind)
+	}
+	compression, err := data.ToFloat(m["compression"])
+	if err != nil || compression <= 0 {
+		return nil, fmt.Errorf("the t-digest has an invalid compression: %v", m["compression"])
+	}
+	t := newTDigest(compression)
+	ma, err := data.AsArray(m["means"])
+	if err != nil {
+		return nil, fmt.Errorf("the t-digest has invalid means: %v", err)
+	}
+	wa, err := data.AsArray(m["weights"])
+	if err != nil {
+		return nil, fmt.Errorf("the t-digest has invalid weights: %v", err)
+	}
+	means, err := toFloats(ma)
+	if err != nil {
+		return nil, fmt.Errorf("the t-digest has invalid means: %v", err)
+	}
+	weights, err := toFloats(wa)
+	if err != nil {
+		return nil, fmt.Errorf("the t-digest has invalid weights: %v", err)
+	}
+	if len(means) != len(weights) {
+		return nil, fmt.Errorf("the t-digest has %v means but %v weights", len(means), len(weights))
+	}
+	if len(means) == 0 {
+		return t, nil
+	}
+	if t.min, err = data.ToFloat(m["min"]); err != nil {
+		return nil, fmt.Errorf("the t-digest has an invalid min: %v", err)
+	}
+	if t.max, err = data.ToFloat(m["max"]); err != nil {
+		return nil, fmt.Errorf("the t-digest has an invalid max: %v", err)
+	}
+	for i := range means {
+		t.centroids = append(t.centroids, centroid{means[i], weights[i]})
+	}
+	sort.Sort(byMean(t.centroids))
+	return t, nil
+}
+
+type byMean []centroid
+
+func (c byMean) Len() int           { return len(c) }
+func (c byMean) Less(i, j int) bool { return c[i].mean < c[j].mean }
+func (c byMean) Swap(i, j int)      { c[i], c[j] = c[j], c[i] }
+
+/// HyperLogLog
+
+const (
+	hllKind = "hll"
+
+	// defaultHLLPrecision is the number of bits of a hash used to choose a
+	// register. The standard error of the estimate is 1.04/sqrt(2^p), which
+	// is about 0.8% with 14.
+	defaultHLLPrecision = 14
+)
+
+// hyperLogLog estimates the number of distinct values.
+type hyperLogLog struct {
+	precision uint
+	registers []byte
+}
+
+func newHyperLogLog(precision uint) *hyperLogLog {
+	return &hyperLogLog{
+		precision: precision,
+		registers: make([]byte, 1<<precision),
+	}
+}
+
+func (h *hyperLogLog) add(v data.Value) {
+	x := hashValue(v)
+	idx := x >> (64 - h.precision)
+	// the number of leading zeros of the rest of bits plus one; a sentinel
+	// bit bounds it when all of them are zero
+	w := x<<h.precision | 1<<(h.precision-1)
+	rank := byte(1)
+	for w&(1<<63) == 0 {
+		rank++
+		w <<= 1
+	}
+	if rank > h.registers[idx] {
+		h.registers[idx] = rank
+	}
+}
+
+func (h *hyperLogLog) merge(o *hyperLogLog) error {
+	if h.precision != o.precision {
+		return fmt.Errorf("cannot merge HyperLogLogs having different precisions: %v and %v",
+			h.precision, o.precision)
+	}
+	for i, r := range o.registers {
+		if r > h.registers[i] {
+			h.registers[i] = r
+		}
+	}
+	return nil
+}
+
+func (h *hyperLogLog) estimate() float64 {
+	m := float64(len(h.registers))
+	sum := 0.0
+	zeros := 0
+	for _, r := range h.registers {
+		sum += math.Ldexp(1, -int(r))
+		if r == 0 {
+			zeros++
+		}
+	}
+	alpha := 0.7213 / (1 + 1.079/m)
+	e := alpha * m * m / sum
+	if e <= 2.5*m && zeros > 0 {
+		// linear counting is more accurate for small cardinalities
+		return m * math.Log(m/float64(zeros))
+	}
+	return e
+}
+
+func (h *hyperLogLog) toMap() data.Map {
+	return data.Map{
+		sketchKindKey: data.String(hllKind),
+		"precision":   data.Int(h.precision),
+		"registers":   data.Blob(append([]byte(nil), h.registers...)),
+	}
+}
+
+func hyperLogLogFromValue(v data.Value) (*hyperLogLog, error) {
+	m, kind, err := sketchKind(v)
+	if err != nil {
+		return nil, err
+	}
+	if kind != hllKind {
+		return nil, fmt.Errorf("the sketch isn't a HyperLogLog: %v", kind)
+	}
+	p, err := data.AsInt(m["precision"])
+	if err != nil || p < 4 || p > 18 {
+		return nil, fmt.Errorf("the HyperLogLog has an invalid precision: %v", m["precision"])
+	}
+	regs, err := data.AsBlob(m["registers"])
+	if err != nil {
+		return nil, fmt.Errorf("the HyperLogLog has invalid registers: %v", err)
+	}
+	if len(regs) != 1<<uint(p) {
+		return nil, fmt.Errorf("the HyperLogLog has %v registers but it must have %v",
+			len(regs), 1<<uint(p))
+	}
+	h := newHyperLogLog(uint(p))
+	copy(h.registers, regs)
+	return h, nil
+}
+
+/// Count-Min sketch with a heap of heavy hitters
+
+const (
+	topKKind = "top_k"
+
+	// defaultTopKCapacity is the default number of heavy hitters kept in
+	// a top-k sketch.
+	defaultTopKCapacity = 100
+
+	countMinDepth = 4
+	countMinWidth = 2048
+)
+
+type heavyHitter struct {
+	value data.Value
+	hash  uint64
+	count int64
+}
+
+// topKSketch estimates the most frequent values with a Count-Min sketch. It
+// keeps at most capacity values having the largest estimated counts in a
+// min-heap.
+type topKSketch struct {
+	capacity int
+	table    []int64 // countMinDepth rows of countMinWidth counters
+	hitters  []*heavyHitter
+	index    map[uint64]int // from hashes to indices of hitters
+}
+
+func newTopKSketch(capacity int) *topKSketch {
+	return &topKSketch{
+		capacity: capacity,
+		table:    make([]int64, countMinDepth*countMinWidth),
+		index:    map[uint64]int{},
+	}
+}
+
+// cells returns indices of counters of the hash in the table.
+func (s *topKSketch) cells(h uint64) [countMinDepth]int {
+	var cs [countMinDepth]int
+	h1, h2 := h&0xffffffff, h>>32
+	for i := range cs {
+		cs[i] = i*countMinWidth + int((h1+uint64(i)*h2)%countMinWidth)
+	}
+	return cs
+}
+
+func (s *topKSketch) estimate(h uint64) int64 {
+	var est int64 = math.MaxInt64
+	for _, c := range s.cells(h) {
+		if s.table[c] < est {
+			est = s.table[c]
+		}
+	}
+	return est
+}
+
+func (s *topKSketch) add(v data.Value, n int64) {
+	h := hashValue(v)
+	for _, c := range s.cells(h) {
+		s.table[c] += n
+	}
+	s.offer(v, h, s.estimate(h))
+}
+
+// offer updates the count of the value in the heap or adds it to the heap
+// when it's one of the most frequent values.
+func (s *topKSketch) offer(v data.Value, h uint64, count int64) {
+	if i, ok := s.index[h]; ok {
+		s.hitters[i].count = count
+		heap.Fix(s, i)
+		return
+	}
+	if len(s.hitters) < s.capacity {
+		heap.Push(s, &heavyHitter{v, h, count})
+		return
+	}
+	if len(s.hitters) > 0 && s.hitters[0].count < count {
+		delete(s.index, s.hitters[0].hash)
+		s.hitters[0] = &heavyHitter{v, h, count}
+		s.index[h] = 0
+		heap.Fix(s, 0)
+	}
+}
+
+func (s *topKSketch) merge(o *topKSketch) {
+	for i, c := range o.table {
+		s.table[i] += c
+	}
+	hs := append(append([]*heavyHitter(nil), s.hitters...), o.hitters...)
+	s.hitters = nil
+	s.index = map[uint64]int{}
+	if o.capacity > s.capacity {
+		s.capacity = o.capacity
+	}
+	for _, hh := range hs {
+		s.offer(hh.value, hh.hash, s.estimate(hh.hash))
+	}
+}
+
+// top returns at most k values having the largest counts in descending order
+// of their counts.
+func (s *topKSketch) top(k int) []*heavyHitter {
+	hs := append([]*heavyHitter(nil), s.hitters...)
+	sort.Stable(byCountDesc(hs))
+	if len(hs) > k {
+		hs = hs[:k]
+	}
+	return hs
+}
+
+func (s *topKSketch) Len() int { return len(s.hitters) }
+func (s *topKSketch) Less(i, j int) bool {
+	return s.hitters[i].count < s.hitters[j].count
+}
+func (s *topKSketch) Swap(i, j int) {
+	s.hitters[i], s.hitters[j] = s.hitters[j], s.hitters[i]
+	s.index[s.hitters[i].hash] = i
+	s.index[s.hitters[j].hash] = j
+}
+func (s *topKSketch) Push(x interface{}) {
+	hh := x.(*heavyHitter)
+	s.index[hh.hash] = len(s.hitters)
+	s.hitters = append(s.hitters, hh)
+}
+func (s *topKSketch) Pop() interface{} {
+	hh := s.hitters[len(s.hitters)-1]
+	s.hitters = s.hitters[:len(s.hitters)-1]
+	delete(s.index, hh.hash)
+	return hh
+}
+
+func (s *topKSketch) toMap() data.Map {
+	table := make([]byte, 8*len(s.table))
+	for i, c := range s.table {
+		binary.LittleEndian.PutUint64(table[8*i:], uint64(c))
+	}
+	items := make(data.Array, len(s.hitters))
+	for i, hh := range s.top(len(s.hitters)) {
+		items[i] = data.Map{
+			"value": hh.value,
+			"count": data.Int(hh.count),
+		}
+	}
+	return data.Map{
+		sketchKindKey: data.String(topKKind),
+		"capacity":    data.Int(s.capacity),
+		"table":       data.Blob(table),
+		"items":       items,
+	}
+}
+
+func topKSketchFromValue(v data.Value) (*topKSketch, error) {
+	m, kind, err := sketchKind(v)
+	if err != nil {
+		return nil, err
+	}
+	if kind != topKKind {
+		return nil, fmt.Errorf("the sketch isn't a top-k sketch: %v", kind)
+	}
+	capacity, err := data.AsInt(m["capacity"])
+	if err != nil || capacity <= 0 {
+		return nil, fmt.Errorf("the top-k sketch has an invalid capacity: %v", m["capacity"])
+	}
+	table, err := data.AsBlob(m["table"])
+	if err != nil {
+		return nil, fmt.Errorf("the top-k sketch has an invalid table: %v", err)
+	}
+	if len(table) != 8*countMinDepth*countMinWidth {
+		return nil, fmt.Errorf("the table of the top-k sketch has an invalid size: %v", len(table))
+	}
+	items, err := data.AsArray(m["items"])
+	if err != nil {
+		return nil, fmt.Errorf("the top-k sketch has invalid items: %v", err)
+	}
+
+	s := newTopKSketch(int(capacity))
+	for i := range s.table {
+		s.table[i] = int64(binary.LittleEndian.Uint64(table[8*i:]))
+	}
+	for _, item := range items {
+		im, err := data.AsMap(item)
+		if err != nil {
+			return nil, fmt.Errorf("an item of the top-k sketch must be a map: %v", err)
+		}
+		v, ok := im["value"]
+		if !ok {
+			return nil, fmt.Errorf("an item of the top-k sketch doesn't have a value")
+		}
+		h := hashValue(v)
+		s.offer(v, h, s.estimate(h))
+	}
+	return s, nil
+}
+
+type byCountDesc []*heavyHitter
+
+func (h byCountDesc) Len() int           { return len(h) }
+func (h byCountDesc) Less(i, j int) bool { return h[i].count > h[j].count }
+func (h byCountDesc) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
@@ -0,0 +1,160 @@
+package builtin
+
+import (
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/udf"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// kvGetFunc returns the value of a key in a key-value state
+// (i.e. a state of type `kv`). It returns Null when the state
+// doesn't have the key.
+//
+// It can be used in BQL as `kv_get`.
+//
+//  Input: String (the name of the state), any (the key)
+//  Return Type: any
+var kvGetFunc udf.UDF = udf.BinaryFunc(func(ctx *core.Context, name data.Value, key data.Value) (data.Value, error) {
+	s, err := lookupKeyValueState(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	k, err := data.ToString(key)
+	if err != nil {
+		return nil, err
+	}
+	if v, ok := s.Get(k); ok {
+		return v, nil
+	}
+	return data.Null{}, nil
+})
+
+func lookupKeyValueState(ctx *core.Context, name data.Value) (*core.KeyValueState, error) {
+	n, err := data.AsString(name)
+	if err != nil {
+		return nil, fmt.Errorf("the name of the state must be a string: %v", err)
+	}
+	st, err := ctx.SharedStates.Get(n)
+	if err != nil {
+		return nil, err
+	}
+	s, ok := st.(*core.KeyValueState)
+	if !ok {
+		return nil, fmt.Errorf("state '%v' isn't a key-value state", n)
+	}
+	return s, nil
+}
+
+// offsetsGetFunc returns offsets committed by a source to an offsets state
+// (i.e. a state of type `offsets`). The format of offsets is defined by each
+// source. It returns Null when the source hasn't committed offsets.
+//
+// It can be used in BQL as `offsets_get`:
+//
+//	EVAL offsets_get("offsets", "logs");
+//
+//  Input: String (the name of the state), String (the name of the source)
+//  Return Type: Map
+var offsetsGetFunc udf.UDF = udf.BinaryFunc(func(ctx *core.Context, name data.Value, source data.Value) (data.Value, error) {
+	s, err := lookupOffsetsState(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	src, err := data.AsString(source)
+	if err != nil {
+		return nil, fmt.Errorf("the name of the source must be a string: %v", err)
+	}
+	if m, ok := s.Get(src); ok {
+		return m, nil
+	}
+	return data.Null{}, nil
+})
+
+// offsetsListFunc returns offsets of all sources in an offsets state. Each
+// key of the result is the name of a source and its value is a map having
+// "offsets" and "updated_at" fields.
+//
+// It can be used in BQL as `offsets_list`.
+//
+//  Input: String (the name of the state)
+//  Return Type: Map
+var offsetsListFunc udf.UDF = udf.UnaryFunc(func(ctx *core.Context, name data.Value) (data.Value, error) {
+	s, err := lookupOffsetsState(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return s.ToMap(), nil
+})
+
+// offsetsResetFunc removes offsets of a source from an offsets state so
+// that the source starts over when it's created next time. It doesn't affect
+// the source running now, which should be dropped before its offsets are
+// reset. It returns false when the state doesn't have offsets of the source.
+//
+// It can be used in BQL as `offsets_reset`:
+//
+//	DROP SOURCE logs;
+//	EVAL offsets_reset("offsets", "logs");
+//
+//  Input: String (the name of the state), String (the name of the source)
+//  Return Type: Bool
+var offsetsResetFunc udf.UDF = udf.BinaryFunc(func(ctx *core.Context, name data.Value, source data.Value) (data.Value, error) {
+	s, err := lookupOffsetsState(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	src, err := data.AsString(source)
+	if err != nil {
+		return nil, fmt.Errorf("the name of the source must be a string: %v", err)
+	}
+	return data.Bool(s.Reset(src)), nil
+})
+
+func lookupOffsetsState(ctx *core.Context, name data.Value) (*core.OffsetsState, error) {
+	n, err := data.AsString(name)
+	if err != nil {
+		return nil, fmt.Errorf("the name of the state must be a string: %v", err)
+	}
+	st, err := ctx.SharedStates.Get(n)
+	if err != nil {
+		return nil, err
+	}
+	s, ok := st.(*core.OffsetsState)
+	if !ok {
+		return nil, fmt.Errorf("state '%v' isn't an offsets state", n)
+	}
+	return s, nil
+}
+
+// stateUpdatesUDSF emits events reported when states are changed. It can be
+// used in BQL as follows:
+//
+//	SELECT RSTREAM * FROM state_updates("model_x") [RANGE 1 TUPLES];
+//
+// The arguments are names of states whose events are emitted. Events of all
+// states are emitted when no argument is given. A state doesn't have to
+// exist when the UDSF is created so that a state created later can also be
+// watched.
+//
+// Each output tuple has fields described in core.NewStateUpdateSource. For
+// example, "event_type" is "loaded" when the state is overwritten by LOAD
+// STATE and "updated" when it's updated by UPDATE STATE.
+type stateUpdatesUDSF struct {
+	src core.Source
+}
+
+func createStateUpdatesUDSF(decl udf.UDSFDeclarer, names ...string) (udf.UDSF, error) {
+	return &stateUpdatesUDSF{
+		src: core.NewStateUpdateSource(names...),
+	}, nil
+}
+
+func (f *stateUpdatesUDSF) Process(ctx *core.Context, t *core.Tuple, w core.Writer) error {
+	// This UDSF always runs in the source mode.
+	return f.src.GenerateStream(ctx, w)
+}
+
+func (f *stateUpdatesUDSF) Terminate(ctx *core.Context) error {
+	return f.src.Stop(ctx)
+}
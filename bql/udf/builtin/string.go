@@ -5,6 +5,7 @@ import (
 	"crypto/md5"
 	"crypto/sha1"
 	"crypto/sha256"
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"gopkg.in/sensorbee/sensorbee.v0/bql/udf"
@@ -645,3 +646,207 @@ func decodeJSON(ctx *core.Context, v data.Value) (data.Value, error) {
 		return nil, fmt.Errorf("ill-formed JSON (starting with %c)", first)
 	}
 }
+
+// parseJSON parses a JSON value stored as string or blob. Unlike decode_json,
+// it accepts any JSON value including scalars. When the parsed value is a
+// string which contains a JSON object or array, the string is parsed again so
+// that double-encoded payloads can be parsed at once. Strings in nested
+// objects and arrays aren't parsed. It returns NULL when the argument is NULL.
+//
+// It can be used in BQL as `parse_json`.
+//
+//  Input: String or Blob
+//  Return Type: Any
+func parseJSON(ctx *core.Context, v data.Value) (data.Value, error) {
+	var b []byte
+	switch v.Type() {
+	case data.TypeNull:
+		return data.Null{}, nil
+	case data.TypeString:
+		s, _ := data.AsString(v)
+		b = []byte(s)
+	case data.TypeBlob:
+		b, _ = data.AsBlob(v)
+	default:
+		return nil, fmt.Errorf("a JSON should be a string or a blob: %v", v.Type())
+	}
+
+	res, err := unmarshalJSONValue(b)
+	if err != nil {
+		return nil, err
+	}
+	for res.Type() == data.TypeString {
+		s, _ := data.AsString(res)
+		s = strings.TrimSpace(s)
+		if s == "" || (s[0] != '{' && s[0] != '[') {
+			break
+		}
+		inner, err := unmarshalJSONValue([]byte(s))
+		if err != nil {
+			// The string just looks like JSON.
+			break
+		}
+		res = inner
+	}
+	return res, nil
+}
+
+// unmarshalJSONValue decodes a single JSON value. Numbers are decoded as Int
+// when they're integers.
+func unmarshalJSONValue(b []byte) (data.Value, error) {
+	if len(bytes.TrimSpace(b)) == 0 {
+		return nil, fmt.Errorf("cannot decode empty data")
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, fmt.Errorf("JSON has extra data after the value")
+	}
+	return data.NewValue(v)
+}
+
+// parseCSVLine parses a line of CSV. It returns an array of strings when
+// only the line is given. When names of columns are given as the second
+// argument, it returns a map having the names as keys. The names can be
+// a comma separated string such as 'a,b,c' or an array of strings. The number
+// of fields must be the same as the number of the names. It returns NULL when
+// the line is NULL.
+//
+// It can be used in BQL as `parse_csv_line`.
+//
+//  Input: String, (String or Array of Strings)
+//  Return Type: Array or Map
+func parseCSVLine(ctx *core.Context, args ...data.Value) (data.Value, error) {
+	if len(args) != 1 && len(args) != 2 {
+		return nil, fmt.Errorf("function takes one or two arguments")
+	}
+	if args[0].Type() == data.TypeNull {
+		return data.Null{}, nil
+	}
+	line, err := data.AsString(args[0])
+	if err != nil {
+		return nil, fmt.Errorf("cannot interpret %s as a string", args[0])
+	}
+
+	var names []string
+	if len(args) == 2 {
+		names, err = csvColumnNames(args[1])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var fields []string
+	if strings.TrimSpace(line) != "" {
+		r := csv.NewReader(strings.NewReader(line))
+		r.FieldsPerRecord = -1
+		fields, err = r.Read()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := r.Read(); err != io.EOF {
+			return nil, fmt.Errorf("the line has more than one record")
+		}
+	}
+
+	if names == nil {
+		a := make(data.Array, len(fields))
+		for i, f := range fields {
+			a[i] = data.String(f)
+		}
+		return a, nil
+	}
+	if len(fields) != len(names) {
+		return nil, fmt.Errorf("the line has %v fields but %v columns are given",
+			len(fields), len(names))
+	}
+	m := make(data.Map, len(fields))
+	for i, f := range fields {
+		m[names[i]] = data.String(f)
+	}
+	return m, nil
+}
+
+// csvColumnNames returns names of columns given to parse_csv_line.
+func csvColumnNames(v data.Value) ([]string, error) {
+	var names []string
+	switch v.Type() {
+	case data.TypeString:
+		s, _ := data.AsString(v)
+		for _, n := range strings.Split(s, ",") {
+			names = append(names, strings.TrimSpace(n))
+		}
+	case data.TypeArray:
+		a, _ := data.AsArray(v)
+		for _, e := range a {
+			n, err := data.AsString(e)
+			if err != nil {
+				return nil, fmt.Errorf("a name of a column must be a string: %v", e)
+			}
+			names = append(names, n)
+		}
+	default:
+		return nil, fmt.Errorf("names of columns must be a string or an array: %v", v.Type())
+	}
+
+	seen := make(map[string]bool, len(names))
+	for _, n := range names {
+		if n == "" {
+			return nil, fmt.Errorf("a name of a column must not be empty")
+		}
+		if seen[n] {
+			return nil, fmt.Errorf("a name of a column is duplicated: %v", n)
+		}
+		seen[n] = true
+	}
+	return names, nil
+}
+
+// parseKV parses a string of key-value pairs such as 'a=1;b=2'. The second
+// argument is the separator of pairs and the third argument is the separator
+// of a key and a value. Spaces around keys and values are trimmed and empty
+// pairs are ignored. When a key appears more than once, the last value is
+// used. It returns a map whose values are strings. It returns NULL when any
+// argument is NULL.
+//
+// It can be used in BQL as `parse_kv`.
+//
+//  Input: String, String, String
+//  Return Type: Map
+var parseKVFunc udf.UDF = udf.TernaryFunc(func(ctx *core.Context, str, pairSep, kvSep data.Value) (data.Value, error) {
+	for _, v := range []data.Value{str, pairSep, kvSep} {
+		if v.Type() == data.TypeNull {
+			return data.Null{}, nil
+		}
+		if v.Type() != data.TypeString {
+			return nil, fmt.Errorf("cannot interpret %s as a string", v)
+		}
+	}
+	s, _ := data.AsString(str)
+	ps, _ := data.AsString(pairSep)
+	kvs, _ := data.AsString(kvSep)
+	if ps == "" || kvs == "" {
+		return nil, fmt.Errorf("separators must not be empty")
+	}
+
+	m := data.Map{}
+	for _, p := range strings.Split(s, ps) {
+		if strings.TrimSpace(p) == "" {
+			continue
+		}
+		i := strings.Index(p, kvs)
+		if i < 0 {
+			return nil, fmt.Errorf("a pair doesn't have a separator of a key and a value: %v", p)
+		}
+		k := strings.TrimSpace(p[:i])
+		if k == "" {
+			return nil, fmt.Errorf("a key must not be empty: %v", p)
+		}
+		m[k] = data.String(strings.TrimSpace(p[i+len(kvs):]))
+	}
+	return m, nil
+})
@@ -636,3 +636,182 @@ func TestDecodeJSON(t *testing.T) {
 		})
 	})
 }
+
+func TestParseJSON(t *testing.T) {
+	Convey("Given parse_json udf", t, func() {
+		f := udf.UnaryFunc(parseJSON)
+
+		Convey("When passing JSON values", func() {
+			for i, c := range []struct {
+				input    data.Value
+				expected data.Value
+			}{
+				{data.String(`{"a":1,"b":[2.5,"c"]}`), data.Map{
+					"a": data.Int(1),
+					"b": data.Array{data.Float(2.5), data.String("c")},
+				}},
+				{data.Blob(` [1, null] `), data.Array{data.Int(1), data.Null{}}},
+				{data.String(`123`), data.Int(123)},
+				{data.String(`true`), data.Bool(true)},
+				{data.String(`null`), data.Null{}},
+				{data.String(`"abc"`), data.String("abc")},
+				{data.String(`"{not json"`), data.String("{not json")},
+				{data.Null{}, data.Null{}},
+			} {
+				c := c
+
+				Convey(fmt.Sprintf("Then it should parse %v (%v)", c.input, i), func() {
+					v, err := f.Call(nil, c.input)
+					So(err, ShouldBeNil)
+					So(v, ShouldResemble, c.expected)
+				})
+			}
+		})
+
+		Convey("When passing a double-encoded JSON", func() {
+			v, err := f.Call(nil, data.String(`"{\"a\":\"[1,2]\"}"`))
+
+			Convey("Then it should parse the outer string", func() {
+				So(err, ShouldBeNil)
+				So(v, ShouldResemble, data.Map{"a": data.String("[1,2]")})
+			})
+		})
+
+		Convey("When passing invalid values", func() {
+			for i, input := range []data.Value{
+				data.String(""), data.String(`{"a":`), data.String(`1 2`), data.Int(1),
+			} {
+				input := input
+
+				Convey(fmt.Sprintf("Then it should fail (%v)", i), func() {
+					_, err := f.Call(nil, input)
+					So(err, ShouldNotBeNil)
+				})
+			}
+		})
+	})
+}
+
+func TestParseCSVLine(t *testing.T) {
+	Convey("Given parse_csv_line udf", t, func() {
+		f := udf.VariadicFunc(parseCSVLine)
+
+		Convey("When passing only a line", func() {
+			v, err := f.Call(nil, data.String(`1,"a,b",,"c""d"`))
+
+			Convey("Then it should return an array", func() {
+				So(err, ShouldBeNil)
+				So(v, ShouldResemble, data.Array{
+					data.String("1"), data.String("a,b"), data.String(""), data.String(`c"d`),
+				})
+			})
+		})
+
+		Convey("When passing an empty line", func() {
+			v, err := f.Call(nil, data.String(""))
+
+			Convey("Then it should return an empty array", func() {
+				So(err, ShouldBeNil)
+				So(v, ShouldResemble, data.Array{})
+			})
+		})
+
+		Convey("When passing names of columns as a string", func() {
+			v, err := f.Call(nil, data.String("1,2,3"), data.String("a, b ,c"))
+
+			Convey("Then it should return a map", func() {
+				So(err, ShouldBeNil)
+				So(v, ShouldResemble, data.Map{
+					"a": data.String("1"), "b": data.String("2"), "c": data.String("3"),
+				})
+			})
+		})
+
+		Convey("When passing names of columns as an array", func() {
+			v, err := f.Call(nil, data.String("1,2"), data.Array{data.String("a"), data.String("b")})
+
+			Convey("Then it should return a map", func() {
+				So(err, ShouldBeNil)
+				So(v, ShouldResemble, data.Map{"a": data.String("1"), "b": data.String("2")})
+			})
+		})
+
+		Convey("When passing NULL", func() {
+			v, err := f.Call(nil, data.Null{}, data.String("a"))
+
+			Convey("Then it should return NULL", func() {
+				So(err, ShouldBeNil)
+				So(v, ShouldResemble, data.Null{})
+			})
+		})
+
+		Convey("When passing invalid arguments", func() {
+			for i, args := range [][]data.Value{
+				{data.String("1,2"), data.String("a,b,c")},
+				{data.String("1,2"), data.String("a,a")},
+				{data.String("1,2"), data.String("a,")},
+				{data.String("1,2"), data.Array{data.String("a"), data.Int(1)}},
+				{data.String("1,2"), data.Int(1)},
+				{data.String("1,2\n3,4")},
+				{data.String(`1,"2`)},
+				{data.Int(1)},
+			} {
+				args := args
+
+				Convey(fmt.Sprintf("Then it should fail (%v)", i), func() {
+					_, err := f.Call(nil, args...)
+					So(err, ShouldNotBeNil)
+				})
+			}
+		})
+	})
+}
+
+func TestParseKV(t *testing.T) {
+	Convey("Given parse_kv udf", t, func() {
+		f := parseKVFunc
+
+		Convey("When passing key-value pairs", func() {
+			v, err := f.Call(nil, data.String(" a=1; b = x=y ;;a=2;"), data.String(";"), data.String("="))
+
+			Convey("Then it should return a map", func() {
+				So(err, ShouldBeNil)
+				So(v, ShouldResemble, data.Map{"a": data.String("2"), "b": data.String("x=y")})
+			})
+		})
+
+		Convey("When passing multi-character separators", func() {
+			v, err := f.Call(nil, data.String("a: 1, b: 2"), data.String(", "), data.String(": "))
+
+			Convey("Then it should return a map", func() {
+				So(err, ShouldBeNil)
+				So(v, ShouldResemble, data.Map{"a": data.String("1"), "b": data.String("2")})
+			})
+		})
+
+		Convey("When passing NULL", func() {
+			v, err := f.Call(nil, data.String("a=1"), data.Null{}, data.String("="))
+
+			Convey("Then it should return NULL", func() {
+				So(err, ShouldBeNil)
+				So(v, ShouldResemble, data.Null{})
+			})
+		})
+
+		Convey("When passing invalid arguments", func() {
+			for i, args := range [][]data.Value{
+				{data.String("a=1;b"), data.String(";"), data.String("=")},
+				{data.String("=1"), data.String(";"), data.String("=")},
+				{data.String("a=1"), data.String(""), data.String("=")},
+				{data.Int(1), data.String(";"), data.String("=")},
+			} {
+				args := args
+
+				Convey(fmt.Sprintf("Then it should fail (%v)", i), func() {
+					_, err := f.Call(nil, args...)
+					So(err, ShouldNotBeNil)
+				})
+			}
+		})
+	})
+}
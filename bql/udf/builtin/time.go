@@ -45,12 +45,108 @@ func (f *diffUsFuncTmpl) Call(ctx *core.Context, args ...data.Value) (val data.V
 var diffUsFunc udf.UDF = &diffUsFuncTmpl{}
 
 // clockTimestampFunc returns the local time (in UTC) as a Timestamp.
-// See also: time.Now
+// The time is given by the clock of the context.
+// See also: core.Context.Clock
 //
 // It can be used in BQL as `clock_timestamp`.
 //
 //  Input: None
 //  Return Type: Timestamp
-var clockTimestampFunc = udf.MustConvertGeneric(func() time.Time {
-	return time.Now().In(time.UTC)
+var clockTimestampFunc = udf.MustConvertGeneric(func(ctx *core.Context) time.Time {
+	return ctx.Clock().Now().In(time.UTC)
 })
+
+// parseTimestamp(str, layout[, zone]) parses str with layout and returns a
+// Timestamp. layout is a layout of Go's time package such as
+// "2006-01-02 15:04:05". When str doesn't have a time zone, it's interpreted
+// as a local time in zone, which is a name like "Asia/Tokyo" or an offset
+// like "+09:00". The time zone of the topology is used when zone is omitted.
+// It returns NULL when any argument is NULL.
+// See also: core.LoadLocation, core.Context.Location
+//
+// It can be used in BQL as `parse_timestamp`.
+//
+//  Input: String, String[, String]
+//  Return Type: Timestamp
+func parseTimestamp(ctx *core.Context, args ...data.Value) (data.Value, error) {
+	if len(args) != 2 && len(args) != 3 {
+		return nil, fmt.Errorf("function takes two or three arguments")
+	}
+	if hasNull(args) {
+		return data.Null{}, nil
+	}
+	str, err := data.AsString(args[0])
+	if err != nil {
+		return nil, fmt.Errorf("cannot interpret %s as a string", args[0])
+	}
+	layout, err := data.AsString(args[1])
+	if err != nil {
+		return nil, fmt.Errorf("cannot interpret %s as a layout", args[1])
+	}
+	loc, err := locationArg(ctx, args, 2)
+	if err != nil {
+		return nil, err
+	}
+	t, err := time.ParseInLocation(layout, str, loc)
+	if err != nil {
+		return nil, err
+	}
+	return data.Timestamp(t), nil
+}
+
+// formatTimestamp(ts, layout[, zone]) formats ts as a local time in zone
+// with layout, which is a layout of Go's time package such as
+// "2006-01-02 15:04:05 MST". zone is a name like "Asia/Tokyo" or an offset
+// like "+09:00". The time zone of the topology is used when zone is omitted.
+// ts can also be a value which can be converted to a Timestamp, and a string
+// without a time zone is interpreted as a local time in zone. It returns NULL
+// when any argument is NULL.
+// See also: core.LoadLocation, core.Context.Location, data.ToTimestampIn
+//
+// It can be used in BQL as `format_timestamp`.
+//
+//  Input: Timestamp, String[, String]
+//  Return Type: String
+func formatTimestamp(ctx *core.Context, args ...data.Value) (data.Value, error) {
+	if len(args) != 2 && len(args) != 3 {
+		return nil, fmt.Errorf("function takes two or three arguments")
+	}
+	if hasNull(args) {
+		return data.Null{}, nil
+	}
+	layout, err := data.AsString(args[1])
+	if err != nil {
+		return nil, fmt.Errorf("cannot interpret %s as a layout", args[1])
+	}
+	loc, err := locationArg(ctx, args, 2)
+	if err != nil {
+		return nil, err
+	}
+	t, err := data.ToTimestampIn(args[0], loc)
+	if err != nil {
+		return nil, fmt.Errorf("cannot interpret %s as a timestamp", args[0])
+	}
+	return data.String(t.In(loc).Format(layout)), nil
+}
+
+// locationArg returns the time zone given as the i-th argument. It returns
+// the time zone of the topology when the argument is omitted.
+func locationArg(ctx *core.Context, args []data.Value, i int) (*time.Location, error) {
+	if len(args) <= i {
+		return ctx.Location(), nil
+	}
+	name, err := data.AsString(args[i])
+	if err != nil {
+		return nil, fmt.Errorf("cannot interpret %s as a time zone", args[i])
+	}
+	return core.LoadLocation(name)
+}
+
+func hasNull(args []data.Value) bool {
+	for _, a := range args {
+		if a.Type() == data.TypeNull {
+			return true
+		}
+	}
+	return false
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	. "github.com/smartystreets/goconvey/convey"
 	"gopkg.in/sensorbee/sensorbee.v0/bql/udf"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
 	"gopkg.in/sensorbee/sensorbee.v0/data"
 	"math"
 	"testing"
@@ -123,3 +124,133 @@ func TestBinaryDateFuncs(t *testing.T) {
 		})
 	}
 }
+
+func TestParseTimestamp(t *testing.T) {
+	Convey("Given parse_timestamp udf", t, func() {
+		f := udf.VariadicFunc(parseTimestamp)
+		ctx := core.NewContext(&core.ContextConfig{
+			Location: time.FixedZone("JST", 9*60*60),
+		})
+
+		Convey("When passing a string without a time zone", func() {
+			v, err := f.Call(ctx, data.String("1970-01-01 09:00:02"), data.String("2006-01-02 15:04:05"))
+
+			Convey("Then it should be interpreted in the time zone of the topology", func() {
+				So(err, ShouldBeNil)
+				ts, err := data.AsTimestamp(v)
+				So(err, ShouldBeNil)
+				So(ts.Equal(time.Unix(2, 0)), ShouldBeTrue)
+			})
+		})
+
+		Convey("When passing an explicit time zone", func() {
+			v, err := f.Call(ctx, data.String("1970/01/01 00:00:02"), data.String("2006/01/02 15:04:05"),
+				data.String("UTC"))
+
+			Convey("Then it should be interpreted in the zone", func() {
+				So(err, ShouldBeNil)
+				ts, err := data.AsTimestamp(v)
+				So(err, ShouldBeNil)
+				So(ts.Equal(time.Unix(2, 0)), ShouldBeTrue)
+			})
+		})
+
+		Convey("When passing a string having a time zone", func() {
+			v, err := f.Call(ctx, data.String("1970-01-01 01:00:02 +0100"), data.String("2006-01-02 15:04:05 -0700"),
+				data.String("Asia/Tokyo"))
+
+			Convey("Then the time zone of the string should be used", func() {
+				So(err, ShouldBeNil)
+				ts, err := data.AsTimestamp(v)
+				So(err, ShouldBeNil)
+				So(ts.Equal(time.Unix(2, 0)), ShouldBeTrue)
+			})
+		})
+
+		Convey("When passing NULL", func() {
+			v, err := f.Call(ctx, data.Null{}, data.String("2006-01-02"))
+
+			Convey("Then it should return NULL", func() {
+				So(err, ShouldBeNil)
+				So(v, ShouldResemble, data.Null{})
+			})
+		})
+
+		Convey("When passing invalid arguments", func() {
+			for i, args := range [][]data.Value{
+				{data.String("1970-01-01"), data.String("2006/01/02")},
+				{data.String("1970-01-01"), data.String("2006-01-02"), data.String("Mars/Olympus")},
+				{data.String("1970-01-01"), data.String("2006-01-02"), data.String("+9")},
+				{data.Int(1), data.String("2006-01-02")},
+			} {
+				args := args
+
+				Convey(fmt.Sprintf("Then it should fail (%v)", i), func() {
+					_, err := f.Call(ctx, args...)
+					So(err, ShouldNotBeNil)
+				})
+			}
+		})
+	})
+}
+
+func TestFormatTimestamp(t *testing.T) {
+	Convey("Given format_timestamp udf", t, func() {
+		f := udf.VariadicFunc(formatTimestamp)
+		ctx := core.NewContext(&core.ContextConfig{
+			Location: time.FixedZone("JST", 9*60*60),
+		})
+		ts := data.Timestamp(time.Unix(2, 0).UTC())
+
+		Convey("When formatting a timestamp", func() {
+			v, err := f.Call(ctx, ts, data.String("2006-01-02 15:04:05 MST"))
+
+			Convey("Then it should be formatted in the time zone of the topology", func() {
+				So(err, ShouldBeNil)
+				So(v, ShouldEqual, data.String("1970-01-01 09:00:02 JST"))
+			})
+		})
+
+		Convey("When formatting a timestamp with an explicit time zone", func() {
+			v, err := f.Call(ctx, ts, data.String("2006-01-02T15:04:05Z07:00"), data.String("-05:30"))
+
+			Convey("Then it should be formatted in the zone", func() {
+				So(err, ShouldBeNil)
+				So(v, ShouldEqual, data.String("1969-12-31T18:30:02-05:30"))
+			})
+		})
+
+		Convey("When formatting a string without a time zone", func() {
+			v, err := f.Call(ctx, data.String("1970-01-01 00:00:02"), data.String("15:04:05"), data.String("UTC"))
+
+			Convey("Then it should be interpreted in the zone", func() {
+				So(err, ShouldBeNil)
+				So(v, ShouldEqual, data.String("00:00:02"))
+			})
+		})
+
+		Convey("When passing NULL", func() {
+			v, err := f.Call(ctx, ts, data.String("15:04"), data.Null{})
+
+			Convey("Then it should return NULL", func() {
+				So(err, ShouldBeNil)
+				So(v, ShouldResemble, data.Null{})
+			})
+		})
+
+		Convey("When passing invalid arguments", func() {
+			for i, args := range [][]data.Value{
+				{data.String("now"), data.String("15:04")},
+				{ts, data.Int(1)},
+				{ts, data.String("15:04"), data.String("Mars/Olympus")},
+			} {
+				args := args
+
+				Convey(fmt.Sprintf("Then it should fail (%v)", i), func() {
+					_, err := f.Call(ctx, args...)
+					So(err, ShouldNotBeNil)
+				})
+			}
+		})
+	})
+}
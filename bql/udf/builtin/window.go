@@ -0,0 +1,164 @@
+package builtin
+
+import (
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/udf"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"math"
+	"sort"
+)
+
+// Numeric window functions are aggregate functions computing statistics
+// over numbers in the window. Like analytic functions, their input is
+// ordered by ORDER BY, or as tuples arrived at the window without it. Since
+// they're evaluated on tuples currently in the window, tuples leaving the
+// window are no longer reflected in their results. For example,
+//
+//  SELECT RSTREAM device, moving_avg(temp, 5 ORDER BY ts) AS temp,
+//      ewma(temp, 0.3 ORDER BY ts) AS smoothed,
+//      percentile_cont(temp, 0.95) AS p95
+//  FROM s [RANGE 1 MINUTES] GROUP BY device
+//
+// computes a moving average of the last 5 values, an exponentially weighted
+// moving average, and the 95th percentile of values in the last minute.
+// Null values are ignored and non-numeric values lead to an error.
+
+// numericWindowFuncTmpl is a template for numeric window functions. The first
+// parameter is aggregated and other parameters aren't.
+type numericWindowFuncTmpl struct {
+	minArity int
+	maxArity int
+	compute  func(vals []float64, params []data.Value) (data.Value, error)
+}
+
+func (f *numericWindowFuncTmpl) Accept(arity int) bool {
+	return f.minArity <= arity && arity <= f.maxArity
+}
+
+func (f *numericWindowFuncTmpl) IsAggregationParameter(k int) bool {
+	return k == 0
+}
+
+func (f *numericWindowFuncTmpl) Call(ctx *core.Context, args ...data.Value) (data.Value, error) {
+	if !f.Accept(len(args)) {
+		return nil, fmt.Errorf("invalid number of arguments: %v", len(args))
+	}
+	arr, err := data.AsArray(args[0])
+	if err != nil {
+		return nil, fmt.Errorf("function needs array input, not %T", args[0])
+	}
+	vals := make([]float64, 0, len(arr))
+	for _, item := range arr {
+		switch item.Type() {
+		case data.TypeInt:
+			i, _ := data.AsInt(item)
+			vals = append(vals, float64(i))
+		case data.TypeFloat:
+			f, _ := data.AsFloat(item)
+			vals = append(vals, f)
+		case data.TypeNull:
+		default:
+			return nil, fmt.Errorf("cannot interpret %s (%T) as a number", item, item)
+		}
+	}
+	return f.compute(vals, args[1:])
+}
+
+// movingAvgFunc(expr, n) is a numeric window function that computes
+// the average of the last n values in the window. It computes the
+// average of all values when n is omitted.
+//
+// It can be used in BQL as `moving_avg`.
+//
+//  Input: Int or Float (aggregated), Int (optional)
+//  Return Type: Float (Null on empty input)
+var movingAvgFunc udf.UDF = &numericWindowFuncTmpl{
+	minArity: 1,
+	maxArity: 2,
+	compute: func(vals []float64, params []data.Value) (data.Value, error) {
+		if len(params) == 1 {
+			n, err := data.AsInt(params[0])
+			if err != nil {
+				return nil, fmt.Errorf("the number of values must be an integer, not %T", params[0])
+			}
+			if n <= 0 {
+				return nil, fmt.Errorf("the number of values must be positive: %v", n)
+			}
+			if int64(len(vals)) > n {
+				vals = vals[int64(len(vals))-n:]
+			}
+		}
+		if len(vals) == 0 {
+			return data.Null{}, nil
+		}
+		sum := 0.0
+		for _, v := range vals {
+			sum += v
+		}
+		return data.Float(sum / float64(len(vals))), nil
+	},
+}
+
+// ewmaFunc(expr, alpha) is a numeric window function that computes
+// the exponentially weighted moving average of values in the window.
+// The first value is used as the initial average, and then each value
+// v updates the average as alpha * v + (1 - alpha) * average. alpha
+// must be in (0, 1].
+//
+// It can be used in BQL as `ewma`.
+//
+//  Input: Int or Float (aggregated), Float
+//  Return Type: Float (Null on empty input)
+var ewmaFunc udf.UDF = &numericWindowFuncTmpl{
+	minArity: 2,
+	maxArity: 2,
+	compute: func(vals []float64, params []data.Value) (data.Value, error) {
+		alpha, err := data.ToFloat(params[0])
+		if err != nil {
+			return nil, fmt.Errorf("alpha must be a number: %v", err)
+		}
+		if !(alpha > 0 && alpha <= 1) {
+			return nil, fmt.Errorf("alpha must be in (0, 1]: %v", alpha)
+		}
+		if len(vals) == 0 {
+			return data.Null{}, nil
+		}
+		avg := vals[0]
+		for _, v := range vals[1:] {
+			avg = alpha*v + (1-alpha)*avg
+		}
+		return data.Float(avg), nil
+	},
+}
+
+// percentileContFunc(expr, p) is a numeric window function that
+// computes the p-th percentile of values in the window. The result is
+// linearly interpolated between the two nearest values, so it isn't
+// necessarily one of the input values. p must be in [0, 1].
+//
+// It can be used in BQL as `percentile_cont`.
+//
+//  Input: Int or Float (aggregated), Float
+//  Return Type: Float (Null on empty input)
+var percentileContFunc udf.UDF = &numericWindowFuncTmpl{
+	minArity: 2,
+	maxArity: 2,
+	compute: func(vals []float64, params []data.Value) (data.Value, error) {
+		p, err := data.ToFloat(params[0])
+		if err != nil {
+			return nil, fmt.Errorf("the percentile must be a number: %v", err)
+		}
+		if !(p >= 0 && p <= 1) {
+			return nil, fmt.Errorf("the percentile must be in [0, 1]: %v", p)
+		}
+		if len(vals) == 0 {
+			return data.Null{}, nil
+		}
+		sort.Float64s(vals)
+		pos := p * float64(len(vals)-1)
+		lo, hi := math.Floor(pos), math.Ceil(pos)
+		res := vals[int(lo)] + (pos-lo)*(vals[int(hi)]-vals[int(lo)])
+		return data.Float(res), nil
+	},
+}
@@ -0,0 +1,85 @@
+package builtin
+
+import (
+	"fmt"
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/udf"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"testing"
+)
+
+func TestNumericWindowFuncs(t *testing.T) {
+	arr := data.Array{data.Int(4), data.Null{}, data.Float(1), data.Int(3), data.Int(2)}
+
+	testCases := []struct {
+		name     string
+		f        udf.UDF
+		args     []data.Value
+		expected data.Value
+	}{
+		{"moving_avg", movingAvgFunc, []data.Value{arr}, data.Float(2.5)},
+		{"moving_avg", movingAvgFunc, []data.Value{arr, data.Int(2)}, data.Float(2.5)},
+		{"moving_avg", movingAvgFunc, []data.Value{arr, data.Int(3)}, data.Float(2)},
+		{"moving_avg", movingAvgFunc, []data.Value{arr, data.Int(10)}, data.Float(2.5)},
+		{"moving_avg", movingAvgFunc, []data.Value{data.Array{}}, data.Null{}},
+		{"moving_avg", movingAvgFunc, []data.Value{data.Array{data.Null{}}, data.Int(1)}, data.Null{}},
+		{"moving_avg", movingAvgFunc, []data.Value{arr, data.Int(0)}, nil},
+		{"moving_avg", movingAvgFunc, []data.Value{arr, data.Float(1.5)}, nil},
+		{"moving_avg", movingAvgFunc, []data.Value{data.Array{data.String("1")}}, nil},
+		{"moving_avg", movingAvgFunc, []data.Value{data.Int(1)}, nil},
+		{"ewma", ewmaFunc, []data.Value{arr, data.Float(0.5)}, data.Float(2.375)},
+		{"ewma", ewmaFunc, []data.Value{arr, data.Int(1)}, data.Float(2)},
+		{"ewma", ewmaFunc, []data.Value{data.Array{data.Int(3)}, data.Float(0.1)}, data.Float(3)},
+		{"ewma", ewmaFunc, []data.Value{data.Array{}, data.Float(0.5)}, data.Null{}},
+		{"ewma", ewmaFunc, []data.Value{arr, data.Float(0)}, nil},
+		{"ewma", ewmaFunc, []data.Value{arr, data.Float(1.5)}, nil},
+		{"ewma", ewmaFunc, []data.Value{arr, data.Null{}}, nil},
+		{"percentile_cont", percentileContFunc, []data.Value{arr, data.Float(0)}, data.Float(1)},
+		{"percentile_cont", percentileContFunc, []data.Value{arr, data.Float(0.5)}, data.Float(2.5)},
+		{"percentile_cont", percentileContFunc, []data.Value{arr, data.Float(0.9)}, data.Float(3.7)},
+		{"percentile_cont", percentileContFunc, []data.Value{arr, data.Int(1)}, data.Float(4)},
+		{"percentile_cont", percentileContFunc, []data.Value{data.Array{data.Int(5)}, data.Float(0.3)}, data.Float(5)},
+		{"percentile_cont", percentileContFunc, []data.Value{data.Array{}, data.Float(0.5)}, data.Null{}},
+		{"percentile_cont", percentileContFunc, []data.Value{arr, data.Float(-0.1)}, nil},
+		{"percentile_cont", percentileContFunc, []data.Value{arr, data.Float(1.1)}, nil},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		Convey(fmt.Sprintf("Given the %s function", tc.name), t, func() {
+			Convey(fmt.Sprintf("When evaluating it on %v", tc.args), func() {
+				val, err := tc.f.Call(nil, tc.args...)
+
+				if tc.expected == nil {
+					Convey("Then evaluation should fail", func() {
+						So(err, ShouldNotBeNil)
+					})
+				} else {
+					Convey(fmt.Sprintf("Then the result should be %s", tc.expected), func() {
+						So(err, ShouldBeNil)
+						if f, ok := tc.expected.(data.Float); ok {
+							So(val, ShouldHaveSameTypeAs, f)
+							So(float64(val.(data.Float)), ShouldAlmostEqual, float64(f), 1e-9)
+						} else {
+							So(val, ShouldResemble, tc.expected)
+						}
+					})
+				}
+			})
+		})
+	}
+
+	Convey("Given the moving_avg function", t, func() {
+		Convey("Then it should accept one or two arguments", func() {
+			So(movingAvgFunc.Accept(0), ShouldBeFalse)
+			So(movingAvgFunc.Accept(1), ShouldBeTrue)
+			So(movingAvgFunc.Accept(2), ShouldBeTrue)
+			So(movingAvgFunc.Accept(3), ShouldBeFalse)
+		})
+
+		Convey("Then only the first parameter should be aggregated", func() {
+			So(movingAvgFunc.IsAggregationParameter(0), ShouldBeTrue)
+			So(movingAvgFunc.IsAggregationParameter(1), ShouldBeFalse)
+		})
+	})
+}
@@ -25,6 +25,34 @@ type UDF interface {
 	IsAggregationParameter(k int) bool
 }
 
+// BatchUDF is an optional interface of a UDF which can compute results of
+// multiple calls at once. When a UDF implements it, the evaluator calls
+// CallBatch with arguments computed from multiple inputs, e.g. all rows in
+// a window, instead of calling Call for each of them. It amortizes the
+// per-call overhead of functions such as remote or scripted ones.
+type BatchUDF interface {
+	UDF
+
+	// CallBatch calls the UDF with each element of args as arguments and
+	// returns results in the same order. The number of results must be the
+	// same as len(args). An error fails all calls in the batch.
+	CallBatch(ctx *core.Context, args [][]data.Value) ([]data.Value, error)
+}
+
+// RandomizedUDF is an optional interface of a UDF generating random values.
+// A node calling such a UDF binds its own random number generator, which is
+// returned from core.Context.NodeRandom, to the UDF so that values generated
+// by the UDF can be reproduced regardless of other nodes. The UDF should use
+// core.Context.Random when a generator isn't bound.
+type RandomizedUDF interface {
+	UDF
+
+	// WithRandom returns a copy of the UDF generating random values with r.
+	// The UDF itself must not be modified since it can be shared by other
+	// nodes.
+	WithRandom(r *core.Random) UDF
+}
+
 type function struct {
 	f     func(*core.Context, ...data.Value) (data.Value, error)
 	arity int
@@ -133,13 +161,91 @@ type FunctionManager interface {
 	FunctionRegistry
 
 	// Register allows to add a function.
-	Register(name string, f UDF) error
+	Register(name string, f UDF, opts ...FunctionOption) error
+
+	// List returns information of all registered functions. The key of the
+	// map is the name of a function in lower case.
+	List() (map[string]*FunctionInfo, error)
+}
+
+// FunctionInfo has information of a registered function.
+type FunctionInfo struct {
+	// Name is the name of the function.
+	Name string
+
+	// UDF is the function.
+	UDF UDF
+
+	// Doc is the documentation of the function given by WithDoc. It's empty
+	// when the function doesn't have one.
+	Doc string
+}
+
+// maxProbedArity is the number of arguments up to which Arity and IsAggregate
+// probe a UDF. A UDF accepting this number of arguments is considered to be
+// variadic.
+const maxProbedArity = 8
+
+// Arity returns numbers of arguments the function accepts in a
+// human-readable form such as "1", "1, 2", or "2+" which means two or more.
+// Because UDF only reports whether it accepts a given number of arguments,
+// the result is computed by probing the function.
+func (i *FunctionInfo) Arity() string {
+	variadicFrom := -1
+	if i.UDF.Accept(maxProbedArity) {
+		variadicFrom = maxProbedArity
+		for variadicFrom > 0 && i.UDF.Accept(variadicFrom-1) {
+			variadicFrom--
+		}
+	}
+
+	var as []string
+	for n := 0; n < maxProbedArity; n++ {
+		if variadicFrom >= 0 && n >= variadicFrom {
+			as = append(as, fmt.Sprintf("%v+", n))
+			break
+		}
+		if i.UDF.Accept(n) {
+			as = append(as, fmt.Sprint(n))
+		}
+	}
+	return strings.Join(as, ", ")
+}
+
+// IsAggregate returns true if the function is an aggregate function with
+// any number of arguments it accepts.
+func (i *FunctionInfo) IsAggregate() bool {
+	for n := 0; n <= maxProbedArity; n++ {
+		if !i.UDF.Accept(n) {
+			continue
+		}
+		for k := 0; k < n; k++ {
+			if i.UDF.IsAggregationParameter(k) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// FunctionOption is an optional parameter given when registering a function.
+type FunctionOption func(i *FunctionInfo)
+
+// WithDoc sets the documentation of a function, which is shown by
+// SHOW FUNCTIONS statement:
+//
+//	udf.MustRegisterGlobalUDF("inc", udf.UnaryFunc(inc),
+//		udf.WithDoc("inc(x) returns x + 1"))
+func WithDoc(doc string) FunctionOption {
+	return func(i *FunctionInfo) {
+		i.Doc = doc
+	}
 }
 
 type defaultFunctionRegistry struct {
 	ctx   *core.Context
 	m     sync.RWMutex
-	funcs map[string]UDF
+	funcs map[string]*FunctionInfo
 }
 
 // NewDefaultFunctionRegistry returns a new instance of the default
@@ -147,7 +253,7 @@ type defaultFunctionRegistry struct {
 func NewDefaultFunctionRegistry(ctx *core.Context) FunctionManager {
 	reg := &defaultFunctionRegistry{
 		ctx:   ctx,
-		funcs: map[string]UDF{},
+		funcs: map[string]*FunctionInfo{},
 	}
 	return reg
 }
@@ -159,16 +265,16 @@ func (fr *defaultFunctionRegistry) Context() *core.Context {
 func (fr *defaultFunctionRegistry) Lookup(name string, arity int) (UDF, error) {
 	fr.m.RLock()
 	defer fr.m.RUnlock()
-	if f, exists := fr.funcs[strings.ToLower(name)]; exists {
-		if f.Accept(arity) {
-			return f, nil
+	if i, exists := fr.funcs[strings.ToLower(name)]; exists {
+		if i.UDF.Accept(arity) {
+			return i.UDF, nil
 		}
 		return nil, fmt.Errorf("function '%s' is not %d-ary", name, arity)
 	}
 	return nil, core.NotExistError(fmt.Errorf("function '%s' is unknown", name))
 }
 
-func (fr *defaultFunctionRegistry) Register(name string, f UDF) error {
+func (fr *defaultFunctionRegistry) Register(name string, f UDF, opts ...FunctionOption) error {
 	fr.m.Lock()
 	defer fr.m.Unlock()
 
@@ -177,7 +283,7 @@ func (fr *defaultFunctionRegistry) Register(name string, f UDF) error {
 	// words, so we need to add exceptions for them
 	switch lowerName {
 	case "count", "avg", "max", "min", "sum",
-		"coalesce", "lower", "upper", "octet_length",
+		"coalesce", "nullif", "lower", "upper", "octet_length",
 		"substring":
 		// skip check
 	default:
@@ -188,10 +294,28 @@ func (fr *defaultFunctionRegistry) Register(name string, f UDF) error {
 	if _, exists := fr.funcs[lowerName]; exists {
 		return fmt.Errorf("there is already a function named '%s'", name)
 	}
-	fr.funcs[lowerName] = f
+	i := &FunctionInfo{
+		Name: name,
+		UDF:  f,
+	}
+	for _, o := range opts {
+		o(i)
+	}
+	fr.funcs[lowerName] = i
 	return nil
 }
 
+func (fr *defaultFunctionRegistry) List() (map[string]*FunctionInfo, error) {
+	fr.m.RLock()
+	defer fr.m.RUnlock()
+	m := make(map[string]*FunctionInfo, len(fr.funcs))
+	for n, i := range fr.funcs {
+		c := *i
+		m[n] = &c
+	}
+	return m, nil
+}
+
 var (
 	// globalUDFRegistry has UDFs visible to all topologies. Do NOT use this
 	// instance in running topologies because it doesn't have an actual
@@ -202,14 +326,14 @@ var (
 // RegisterGlobalUDF adds a UDF which is visible to all topologies. UDFs
 // registered after running topologies might not be seen by those topologies.
 // Call it from init functions to avoid such conditions.
-func RegisterGlobalUDF(name string, f UDF) error {
-	return globalUDFRegistry.Register(name, f)
+func RegisterGlobalUDF(name string, f UDF, opts ...FunctionOption) error {
+	return globalUDFRegistry.Register(name, f, opts...)
 }
 
 // MustRegisterGlobalUDF is like RegisterGlobalUDF but
 // panics if an error occurred.
-func MustRegisterGlobalUDF(name string, f UDF) {
-	if err := globalUDFRegistry.Register(name, f); err != nil {
+func MustRegisterGlobalUDF(name string, f UDF, opts ...FunctionOption) {
+	if err := globalUDFRegistry.Register(name, f, opts...); err != nil {
 		panic(fmt.Errorf("udf.MustRegisterGlobalUDF: cannot register '%v': %v", name, err))
 	}
 }
@@ -221,11 +345,11 @@ func CopyGlobalUDFRegistry(ctx *core.Context) FunctionManager {
 	defer globalUDFRegistry.m.RUnlock()
 	reg := &defaultFunctionRegistry{
 		ctx:   ctx,
-		funcs: make(map[string]UDF, len(globalUDFRegistry.funcs)),
+		funcs: make(map[string]*FunctionInfo, len(globalUDFRegistry.funcs)),
 	}
 
-	for n, f := range globalUDFRegistry.funcs {
-		reg.funcs[n] = f
+	for n, i := range globalUDFRegistry.funcs {
+		reg.funcs[n] = i
 	}
 	return reg
 }
@@ -235,5 +359,6 @@ func init() {
 	toString := func(ctx *core.Context, v data.Value) (data.Value, error) {
 		return data.String(v.String()), nil
 	}
-	globalUDFRegistry.Register("str", UnaryFunc(toString))
+	globalUDFRegistry.Register("str", UnaryFunc(toString),
+		WithDoc("str(x) converts x to a string"))
 }
@@ -119,5 +119,51 @@ func TestDefaultFunctionRegistry(t *testing.T) {
 				So(err, ShouldNotBeNil)
 			})
 		})
+
+		Convey("When adding functions with and without documentation", func() {
+			fun := func(ctx *core.Context, vs ...data.Value) (data.Value, error) {
+				return data.Bool(true), nil
+			}
+			So(fr.Register("Documented", Func(fun, 2), WithDoc("documented(a, b)")), ShouldBeNil)
+			So(fr.Register("variadic", VariadicFunc(fun)), ShouldBeNil)
+
+			Convey("Then List should return them with their documentation", func() {
+				fs, err := fr.List()
+				So(err, ShouldBeNil)
+				So(fs["documented"].Name, ShouldEqual, "Documented")
+				So(fs["documented"].Doc, ShouldEqual, "documented(a, b)")
+				So(fs["variadic"].Doc, ShouldBeEmpty)
+			})
+
+			Convey("Then List should also return global functions", func() {
+				fs, err := fr.List()
+				So(err, ShouldBeNil)
+				So(fs, ShouldContainKey, "str")
+			})
+
+			Convey("Then their arities should be described", func() {
+				fs, err := fr.List()
+				So(err, ShouldBeNil)
+				So(fs["documented"].Arity(), ShouldEqual, "2")
+				So(fs["variadic"].Arity(), ShouldEqual, "0+")
+				So(fs["documented"].IsAggregate(), ShouldBeFalse)
+			})
+		})
+
+		Convey("When adding an aggregate function accepting one or two arguments", func() {
+			fun := func(a []int, b ...int) int {
+				return 0
+			}
+			f, err := ConvertGenericAggregate(fun, []bool{true, false})
+			So(err, ShouldBeNil)
+			So(fr.Register("agg", f), ShouldBeNil)
+
+			Convey("Then its arity should be described", func() {
+				fs, err := fr.List()
+				So(err, ShouldBeNil)
+				So(fs["agg"].Arity(), ShouldEqual, "1+")
+				So(fs["agg"].IsAggregate(), ShouldBeTrue)
+			})
+		})
 	})
 }
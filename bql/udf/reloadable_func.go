@@ -0,0 +1,126 @@
+package udf
+
+import (
+	"fmt"
+	"sync"
+
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// ReloadableUDF is a UDF whose implementation can be replaced while
+// topologies are using it, e.g. a function defined by a script file. It can
+// be reloaded by RELOAD FUNCTION statement without dropping streams calling
+// the function.
+type ReloadableUDF interface {
+	UDF
+
+	// Reload loads the implementation of the function again and replaces
+	// the current one with it. It returns the version of the new
+	// implementation. The previous implementation is kept when Reload
+	// fails.
+	//
+	// The replacement is atomic: each call of the function is processed
+	// entirely by either of the previous or the new implementation. The new
+	// implementation must accept the same numbers of arguments as the
+	// previous one because statements using the function have already been
+	// checked against them.
+	Reload(ctx *core.Context) (int64, error)
+
+	// Version returns the version of the current implementation. It starts
+	// from 1 and is incremented by each successful Reload.
+	Version() int64
+}
+
+// FunctionLoader loads an implementation of a function. It's called by
+// NewReloadableFunc and each time the function is reloaded.
+type FunctionLoader func(ctx *core.Context) (UDF, error)
+
+type reloadableFunc struct {
+	load FunctionLoader
+
+	// reloadMutex serializes Reload so that versions are assigned in order
+	// without blocking calls while loading.
+	reloadMutex sync.Mutex
+
+	m       sync.RWMutex
+	f       UDF
+	version int64
+}
+
+// NewReloadableFunc creates a ReloadableUDF whose implementation is loaded
+// by load. load is called once to load the initial implementation.
+func NewReloadableFunc(ctx *core.Context, load FunctionLoader) (ReloadableUDF, error) {
+	f, err := load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &reloadableFunc{
+		load:    load,
+		f:       f,
+		version: 1,
+	}, nil
+}
+
+func (r *reloadableFunc) current() UDF {
+	r.m.RLock()
+	defer r.m.RUnlock()
+	return r.f
+}
+
+func (r *reloadableFunc) Call(ctx *core.Context, args ...data.Value) (data.Value, error) {
+	return r.current().Call(ctx, args...)
+}
+
+func (r *reloadableFunc) Accept(arity int) bool {
+	return r.current().Accept(arity)
+}
+
+func (r *reloadableFunc) IsAggregationParameter(k int) bool {
+	return r.current().IsAggregationParameter(k)
+}
+
+func (r *reloadableFunc) Reload(ctx *core.Context) (int64, error) {
+	r.reloadMutex.Lock()
+	defer r.reloadMutex.Unlock()
+
+	f, err := r.load(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if err := checkSameSignature(r.current(), f); err != nil {
+		return 0, err
+	}
+
+	r.m.Lock()
+	defer r.m.Unlock()
+	r.f = f
+	r.version++
+	return r.version, nil
+}
+
+func (r *reloadableFunc) Version() int64 {
+	r.m.RLock()
+	defer r.m.RUnlock()
+	return r.version
+}
+
+// checkSameSignature returns an error when the new implementation of a
+// function accepts different numbers of arguments or aggregation parameters
+// from the previous one.
+func checkSameSignature(prev, f UDF) error {
+	for n := 0; n <= maxProbedArity; n++ {
+		if prev.Accept(n) != f.Accept(n) {
+			return fmt.Errorf("the new implementation of the function accepts different numbers of arguments")
+		}
+		if !f.Accept(n) {
+			continue
+		}
+		for k := 0; k < n; k++ {
+			if prev.IsAggregationParameter(k) != f.IsAggregationParameter(k) {
+				return fmt.Errorf("the new implementation of the function has different aggregation parameters")
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package udf
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+func TestReloadableFunc(t *testing.T) {
+	Convey("Given a reloadable function", t, func() {
+		ctx := core.NewContext(nil)
+		var next UDF
+		var loadErr error
+		addConst := func(c int64) UDF {
+			return UnaryFunc(func(ctx *core.Context, v data.Value) (data.Value, error) {
+				i, err := data.ToInt(v)
+				if err != nil {
+					return nil, err
+				}
+				return data.Int(i + c), nil
+			})
+		}
+		next = addConst(1)
+		f, err := NewReloadableFunc(ctx, func(ctx *core.Context) (UDF, error) {
+			if loadErr != nil {
+				return nil, loadErr
+			}
+			return next, nil
+		})
+		So(err, ShouldBeNil)
+
+		Convey("Then it should call the initial implementation", func() {
+			v, err := f.Call(ctx, data.Int(1))
+			So(err, ShouldBeNil)
+			So(v, ShouldEqual, data.Int(2))
+			So(f.Version(), ShouldEqual, 1)
+			So(f.Accept(1), ShouldBeTrue)
+			So(f.Accept(2), ShouldBeFalse)
+		})
+
+		Convey("When reloading it with a new implementation", func() {
+			next = addConst(10)
+			ver, err := f.Reload(ctx)
+			So(err, ShouldBeNil)
+
+			Convey("Then it should call the new implementation", func() {
+				So(ver, ShouldEqual, 2)
+				So(f.Version(), ShouldEqual, 2)
+				v, err := f.Call(ctx, data.Int(1))
+				So(err, ShouldBeNil)
+				So(v, ShouldEqual, data.Int(11))
+			})
+		})
+
+		Convey("When the new implementation cannot be loaded", func() {
+			loadErr = errors.New("syntax error")
+			_, err := f.Reload(ctx)
+
+			Convey("Then it should keep the previous implementation", func() {
+				So(err, ShouldNotBeNil)
+				So(f.Version(), ShouldEqual, 1)
+				v, err := f.Call(ctx, data.Int(1))
+				So(err, ShouldBeNil)
+				So(v, ShouldEqual, data.Int(2))
+			})
+		})
+
+		Convey("When the new implementation accepts different arguments", func() {
+			next = BinaryFunc(func(ctx *core.Context, a, b data.Value) (data.Value, error) {
+				return a, nil
+			})
+			_, err := f.Reload(ctx)
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+				So(f.Version(), ShouldEqual, 1)
+			})
+		})
+	})
+
+	Convey("Given a loader which fails", t, func() {
+		_, err := NewReloadableFunc(core.NewContext(nil), func(ctx *core.Context) (UDF, error) {
+			return nil, errors.New("cannot load")
+		})
+
+		Convey("Then the function cannot be created", func() {
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
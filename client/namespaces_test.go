@@ -0,0 +1,77 @@
+package client
+
+import (
+	"net/http"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"gopkg.in/sensorbee/sensorbee.v0/server"
+	"gopkg.in/sensorbee/sensorbee.v0/server/config"
+	"gopkg.in/sensorbee/sensorbee.v0/server/testutil"
+)
+
+func TestNamespaces(t *testing.T) {
+	conf, err := config.New(data.Map{
+		"namespaces": data.Map{
+			"team_a": data.Map{
+				"tokens": data.Array{data.String("secret")},
+			},
+			"team_b": data.Null{},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := testutil.NewServer(server.WithConfig(conf))
+	defer s.Close()
+	r := newTestRequester(s)
+	ra := r.WithNamespace("team_a", "secret")
+	rb := r.WithNamespace("team_b", "")
+
+	Convey("Given an API server having namespaces", t, func() {
+		Convey("When creating topologies having the same name in different namespaces", func() {
+			for _, req := range []*Requester{r, ra, rb} {
+				res, _, err := do(req, Post, "/topologies", map[string]interface{}{
+					"name": "test_topology",
+				})
+				So(err, ShouldBeNil)
+				So(res.Raw.StatusCode, ShouldEqual, http.StatusOK)
+			}
+			Reset(func() {
+				for _, req := range []*Requester{r, ra, rb} {
+					do(req, Delete, "/topologies/test_topology", nil)
+				}
+			})
+
+			Convey("Then a topology in a namespace should be isolated", func() {
+				So(statusOf(rb, Delete, "/topologies/test_topology"), ShouldEqual, http.StatusOK)
+				So(statusOf(rb, Get, "/topologies/test_topology"), ShouldEqual, http.StatusNotFound)
+				So(statusOf(ra, Get, "/topologies/test_topology"), ShouldEqual, http.StatusOK)
+				So(statusOf(r, Get, "/topologies/test_topology"), ShouldEqual, http.StatusOK)
+			})
+		})
+
+		Convey("When accessing a namespace without a valid token", func() {
+			Convey("Then it should be rejected", func() {
+				So(statusOf(r.WithNamespace("team_a", ""), Get, "/topologies"), ShouldEqual, http.StatusUnauthorized)
+				So(statusOf(r.WithNamespace("team_a", "wrong"), Get, "/topologies"), ShouldEqual, http.StatusUnauthorized)
+				So(statusOf(r.WithNamespace("team_b", "secret"), Get, "/topologies"), ShouldEqual, http.StatusOK)
+			})
+		})
+
+		Convey("When accessing a namespace which doesn't exist", func() {
+			Convey("Then it should fail", func() {
+				So(statusOf(r.WithNamespace("team_c", ""), Get, "/topologies"), ShouldEqual, http.StatusNotFound)
+			})
+		})
+	})
+}
+
+// statusOf sends a request without a body and returns the status code.
+func statusOf(r *Requester, m Method, path string) int {
+	res, err := r.Do(m, path, nil)
+	So(err, ShouldBeNil)
+	defer res.Close()
+	return res.Raw.StatusCode
+}
@@ -17,6 +17,9 @@ type Requester struct {
 	cli    *http.Client
 	url    string
 	prefix string
+
+	namespace string
+	token     string
 }
 
 // NewRequester creates a new requester
@@ -44,6 +47,17 @@ func NewRequesterWithClient(url, version string, cli *http.Client) (*Requester,
 	}, nil
 }
 
+// WithNamespace returns a copy of the requester which accesses topologies in
+// the namespace. Paths starting with "/topologies" are sent to the namespace
+// and other paths aren't affected. The token is sent as a bearer token when
+// it isn't empty.
+func (r *Requester) WithNamespace(namespace, token string) *Requester {
+	c := *r
+	c.namespace = namespace
+	c.token = token
+	return &c
+}
+
 // Do sends a JSON request to server. The caller has to close the body of
 // the response.
 func (r *Requester) Do(method Method, path string, body interface{}) (*Response, error) {
@@ -68,11 +82,17 @@ func (r *Requester) NewRequest(method Method, apiPath string, bodyJSON interface
 		body = bytes.NewReader(bd)
 	}
 
+	if r.namespace != "" && strings.HasPrefix(strings.TrimPrefix(apiPath, "/"), "topologies") {
+		apiPath = path.Join("namespaces", r.namespace, apiPath)
+	}
 	req, err := http.NewRequest(method.String(), r.url+path.Join(r.prefix, apiPath), body)
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Add("Content-Type", "application/json")
+	if r.token != "" {
+		req.Header.Set("Authorization", "Bearer "+r.token)
+	}
 	return req, nil
 }
 
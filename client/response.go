@@ -24,6 +24,7 @@ type Response struct {
 	closeStream  chan struct{}
 	streamClosed chan struct{}
 	streamErr    error
+	dropped      int64
 
 	closed   bool
 	closeErr error
@@ -146,7 +147,10 @@ func (r *Response) ReadJSON(js interface{}) error {
 }
 
 // ReadStreamJSON returns multiple parsed JSONs as interface{}. The caller
-// must call Response.Close when it require no more JSONs.
+// must call Response.Close when it require no more JSONs. Notices sent by the
+// server when the client cannot keep up with the stream aren't returned: the
+// number of dropped JSONs is returned from DroppedTuples and a disconnection
+// is reported by StreamError.
 func (r *Response) ReadStreamJSON() (<-chan interface{}, error) {
 	if !r.IsStream() {
 		return nil, errors.New("the response isn't a stream")
@@ -291,10 +295,14 @@ func (r *Response) ReadStreamJSON() (<-chan interface{}, error) {
 				r.streamErr = fmt.Errorf("cannot parse JSON: %v", err)
 				return
 			}
-			select {
-			case <-r.closeStream:
-				return
-			case ch <- js:
+			if notice := header.Get("X-Sensorbee-Notice"); notice != "" {
+				r.handleNotice(notice, js)
+			} else {
+				select {
+				case <-r.closeStream:
+					return
+				case ch <- js:
+				}
 			}
 
 			if !boundaryFound {
@@ -325,3 +333,27 @@ func (r *Response) ReadStreamJSON() (<-chan interface{}, error) {
 func (r *Response) StreamError() error {
 	return r.streamErr
 }
+
+// handleNotice processes a notice part sent by the server in a stream.
+func (r *Response) handleNotice(typ string, js interface{}) {
+	var n map[string]interface{}
+	if m, ok := js.(map[string]interface{}); ok {
+		n, _ = m["notice"].(map[string]interface{})
+	}
+	switch typ {
+	case "dropped":
+		if d, ok := n["dropped"].(float64); ok {
+			r.dropped += int64(d)
+		}
+	case "disconnected":
+		msg, _ := n["message"].(string)
+		r.streamErr = fmt.Errorf("the server disconnected the stream: %v", msg)
+	}
+}
+
+// DroppedTuples returns the number of JSONs which the server dropped because
+// the client was too slow to receive them. Don't call this method before the
+// channel returned from ReadStreamJSON is closed.
+func (r *Response) DroppedTuples() int64 {
+	return r.dropped
+}
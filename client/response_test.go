@@ -0,0 +1,72 @@
+package client
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestResponseStreamNotice(t *testing.T) {
+	newResponse := func(parts ...string) *Response {
+		body := "--b\r\n" + strings.Join(parts, "\r\n--b\r\n") + "\r\n--b--\r\n"
+		return &Response{
+			Raw: &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": []string{`multipart/mixed; boundary="b"`}},
+				Body:       ioutil.NopCloser(strings.NewReader(body)),
+			},
+		}
+	}
+	readAll := func(res *Response) []interface{} {
+		ch, err := res.ReadStreamJSON()
+		So(err, ShouldBeNil)
+		var js []interface{}
+		for v := range ch {
+			js = append(js, v)
+		}
+		return js
+	}
+
+	Convey("Given a stream response having a notice of dropped tuples", t, func() {
+		res := newResponse(
+			"Content-Type: application/json\r\n\r\n{\"a\":1}",
+			"Content-Type: application/json\r\nX-Sensorbee-Notice: dropped\r\n\r\n"+
+				`{"notice":{"type":"dropped","message":"dropped","dropped":3}}`,
+			"Content-Type: application/json\r\n\r\n{\"a\":5}",
+		)
+
+		Convey("When reading it", func() {
+			js := readAll(res)
+
+			Convey("Then the notice shouldn't be returned as a JSON", func() {
+				So(js, ShouldHaveLength, 2)
+				So(res.StreamError(), ShouldBeNil)
+			})
+
+			Convey("Then it should have the number of dropped tuples", func() {
+				So(res.DroppedTuples(), ShouldEqual, 3)
+			})
+		})
+	})
+
+	Convey("Given a stream response having a notice of disconnection", t, func() {
+		res := newResponse(
+			"Content-Type: application/json\r\n\r\n{\"a\":1}",
+			"Content-Type: application/json\r\nX-Sensorbee-Notice: disconnected\r\n\r\n"+
+				`{"notice":{"type":"disconnected","message":"too slow"}}`,
+		)
+
+		Convey("When reading it", func() {
+			js := readAll(res)
+
+			Convey("Then it should report the disconnection as an error", func() {
+				So(js, ShouldHaveLength, 1)
+				So(res.StreamError(), ShouldNotBeNil)
+				So(res.StreamError().Error(), ShouldContainSubstring, "too slow")
+			})
+		})
+	})
+}
@@ -2,12 +2,16 @@ package main
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"text/template"
 
 	"gopkg.in/sensorbee/sensorbee.v0/version"
@@ -48,6 +52,12 @@ func main() {
 			Name:  "only-generate-source",
 			Usage: "only generating a main source file and not building a binary",
 		},
+		cli.StringFlag{
+			Name: "target, t",
+			Usage: "comma separated list of GOOS/GOARCH to cross-compile the command for " +
+				"(e.g. linux/amd64,linux/arm64,linux/arm/7). Binaries are built without cgo " +
+				"and named like sensorbee_linux_amd64 with a SHA256SUMS file",
+		},
 	}
 	app.Action = func(c *cli.Context) error {
 		if err := action(c); err != nil {
@@ -69,6 +79,9 @@ func action(c *cli.Context) (retErr error) {
 		if fn := c.String("source-filename"); fn != filepath.Base(fn) {
 			return fmt.Errorf("the output file name must only contain a filename: %v", fn)
 		}
+		if _, err := parseTargets(c.String("target")); err != nil {
+			return err
+		}
 		config, err := loadConfig(c.String("config"))
 		if err != nil {
 			return err
@@ -90,7 +103,11 @@ func action(c *cli.Context) (retErr error) {
 type Config struct {
 	PluginPaths []string                 `yaml:"plugins"`
 	SubCommands map[string]commandDetail `yaml:"commands"`
-	Version     string                   `yaml:"-"`
+
+	// ForceUTC makes the command use UTC as its local time zone regardless
+	// of the time zone of the host.
+	ForceUTC bool   `yaml:"force_utc"`
+	Version  string `yaml:"-"`
 }
 
 type commandDetail struct {
@@ -170,6 +187,14 @@ func create(c *cli.Context, config *Config) error {
 }
 
 func build(c *cli.Context, config *Config) error {
+	targets, err := parseTargets(c.String("target"))
+	if err != nil {
+		return err
+	}
+	if len(targets) > 0 {
+		return crossBuild(c, targets)
+	}
+
 	if c.Bool("only-generate-source") {
 		fmt.Println("The custom command isn't built yet. Run the command below to build it:")
 		fmt.Printf("go build -o \"%v\" %v\n", c.String("out"), c.String("source-filename"))
@@ -184,6 +209,148 @@ func build(c *cli.Context, config *Config) error {
 	return nil
 }
 
+// buildTarget is a platform for which the custom command is cross-compiled.
+type buildTarget struct {
+	OS   string
+	Arch string
+
+	// ARM is the value of GOARM. It's optional and only valid when Arch is
+	// "arm".
+	ARM string
+}
+
+func (t *buildTarget) String() string {
+	if t.ARM != "" {
+		return fmt.Sprintf("%v/%v/%v", t.OS, t.Arch, t.ARM)
+	}
+	return fmt.Sprintf("%v/%v", t.OS, t.Arch)
+}
+
+// artifactName returns the filename of the binary built for the target.
+// out is the value of --out flag, e.g. "bin/sensorbee" results in
+// "bin/sensorbee_linux_arm64".
+func (t *buildTarget) artifactName(out string) string {
+	name := fmt.Sprintf("%v_%v_%v", strings.TrimSuffix(out, ".exe"), t.OS, t.Arch)
+	if t.ARM != "" {
+		name += "v" + t.ARM
+	}
+	if t.OS == "windows" {
+		name += ".exe"
+	}
+	return name
+}
+
+// env returns environment variables for go build. The command is always
+// built without cgo so that it can be cross-compiled without C toolchains of
+// targets.
+func (t *buildTarget) env() []string {
+	env := []string{"GOOS=" + t.OS, "GOARCH=" + t.Arch, "CGO_ENABLED=0"}
+	if t.ARM != "" {
+		env = append(env, "GOARM="+t.ARM)
+	}
+	return env
+}
+
+// parseTargets parses the value of --target flag. It returns nil when the
+// value is empty.
+func parseTargets(s string) ([]*buildTarget, error) {
+	var targets []*buildTarget
+	seen := map[string]bool{}
+	for _, e := range strings.Split(s, ",") {
+		e = strings.TrimSpace(e)
+		if e == "" {
+			continue
+		}
+		fs := strings.Split(e, "/")
+		if len(fs) < 2 || len(fs) > 3 || fs[0] == "" || fs[1] == "" {
+			return nil, fmt.Errorf("a target must be in the form of GOOS/GOARCH[/GOARM]: %v", e)
+		}
+		t := &buildTarget{OS: fs[0], Arch: fs[1]}
+		if len(fs) == 3 {
+			if t.Arch != "arm" {
+				return nil, fmt.Errorf("GOARM can only be specified for arm: %v", e)
+			}
+			switch fs[2] {
+			case "5", "6", "7":
+			default:
+				return nil, fmt.Errorf("GOARM must be 5, 6, or 7: %v", e)
+			}
+			t.ARM = fs[2]
+		}
+		if seen[t.String()] {
+			return nil, fmt.Errorf("the target is specified more than once: %v", e)
+		}
+		seen[t.String()] = true
+		targets = append(targets, t)
+	}
+	return targets, nil
+}
+
+// crossBuild builds the custom command for each target and writes SHA256
+// checksums of the binaries to SHA256SUMS in the directory of the binaries.
+func crossBuild(c *cli.Context, targets []*buildTarget) error {
+	out := c.String("out")
+	src := c.String("source-filename")
+	if c.Bool("only-generate-source") {
+		fmt.Println("The custom command isn't built yet. Run the commands below to build it:")
+		for _, t := range targets {
+			fmt.Printf("env %v go build -o \"%v\" %v\n", strings.Join(t.env(), " "), t.artifactName(out), src)
+		}
+		return nil
+	}
+
+	var artifacts []string
+	for _, t := range targets {
+		name := t.artifactName(out)
+		fmt.Printf("Building %v for %v\n", name, t)
+		cmd := exec.Command("go", "build", "-o", name, src)
+		cmd.Env = append(os.Environ(), t.env()...)
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("cannot build a custom sensorbee command for %v: %v", t, err)
+		}
+		artifacts = append(artifacts, name)
+	}
+
+	sums := filepath.Join(filepath.Dir(out), "SHA256SUMS")
+	if err := writeChecksums(sums, artifacts); err != nil {
+		return err
+	}
+	fmt.Printf("Checksums are written to %v\n", sums)
+	return nil
+}
+
+// writeChecksums writes SHA256 checksums of files in the format of the
+// sha256sum command so that they can be verified by `sha256sum -c`.
+func writeChecksums(path string, files []string) error {
+	var b bytes.Buffer
+	for _, name := range files {
+		sum, err := sha256File(name)
+		if err != nil {
+			return fmt.Errorf("cannot compute the checksum of '%v': %v", name, err)
+		}
+		fmt.Fprintf(&b, "%v  %v\n", sum, filepath.Base(name))
+	}
+	if err := ioutil.WriteFile(path, b.Bytes(), 0644); err != nil {
+		return fmt.Errorf("cannot write checksums to '%v': %v", path, err)
+	}
+	return nil
+}
+
+func sha256File(name string) (string, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
 const (
 	mainGoTemplate = `package main
 
@@ -193,15 +360,14 @@ import (
 	"gopkg.in/sensorbee/sensorbee.v0/version"
 	_ "gopkg.in/sensorbee/sensorbee.v0/bql/udf/builtin"{{range $sub, $path := .SubCommands}}{{if $path.Path}}
 	{{$sub}} "{{$path.Path}}"{{else}}
-	"gopkg.in/sensorbee/sensorbee.v0/cmd/lib/{{$sub}}"{{end}}{{end}}
-	"time"
+	"gopkg.in/sensorbee/sensorbee.v0/cmd/lib/{{$sub}}"{{end}}{{end}}{{if .ForceUTC}}
+	"time"{{end}}
 {{range $_, $path := .PluginPaths}}	_ "{{$path}}"
-{{end}})
+{{end}}){{if .ForceUTC}}
 
 func init() {
-	// TODO
 	time.Local = time.UTC
-}
+}{{end}}
 
 func main() {
 	app := cli.NewApp()
@@ -219,5 +385,5 @@ func main() {
 )
 
 var (
-	defaultCommands = []string{"run", "shell", "topology", "runfile"}
+	defaultCommands = []string{"run", "shell", "topology", "runfile", "bqltool", "test"}
 )
@@ -31,6 +31,7 @@ commands:
     path: path/to/repo
   repo2:
     path: path/to/repo2.v1
+force_utc: true
 `
 			confName := filepath.Join(dir, "build_test.yaml")
 			So(ioutil.WriteFile(confName, []byte(cfgstr), 0644), ShouldBeNil)
@@ -45,7 +46,8 @@ commands:
 						"repo1":   commandDetail{Path: "path/to/repo"},
 						"repo2":   commandDetail{Path: "path/to/repo2.v1"},
 					},
-					Version: version.Version,
+					ForceUTC: true,
+					Version:  version.Version,
 				}
 				So(*conf, ShouldResemble, expectedConf)
 			})
@@ -64,6 +66,8 @@ commands:
 						"shell":    commandDetail{},
 						"topology": commandDetail{},
 						"runfile":  commandDetail{},
+						"bqltool":  commandDetail{},
+						"test":     commandDetail{},
 					},
 					Version: version.Version,
 				}
@@ -107,14 +111,8 @@ import (
 	"gopkg.in/urfave/cli.v1"
 	"os"
 	_ "path/to/plugin"
-	"time"
 )
 
-func init() {
-	// TODO
-	time.Local = time.UTC
-}
-
 func main() {
 	app := cli.NewApp()
 	app.Name = "sensorbee"
@@ -131,6 +129,29 @@ func main() {
 				So(string(b), ShouldEqual, expectedMainFile)
 			})
 		})
+
+		Convey("When create a main file forcing UTC", func() {
+			config := &Config{
+				SubCommands: map[string]commandDetail{
+					"run": commandDetail{},
+				},
+				ForceUTC: true,
+				Version:  version.Version,
+			}
+			So(create(c, config), ShouldBeNil)
+			Convey("Then the main file should set the local time zone to UTC", func() {
+				b, err := ioutil.ReadFile(outFilename)
+				So(err, ShouldBeNil)
+				So(string(b), ShouldContainSubstring, `
+	"time"
+)
+
+func init() {
+	time.Local = time.UTC
+}
+`)
+			})
+		})
 	})
 }
 
@@ -184,3 +205,83 @@ func TestCreateMainFileWithCommandConfig(t *testing.T) {
 		}
 	})
 }
+
+func TestParseTargets(t *testing.T) {
+	Convey("Given build_sensorbee tool", t, func() {
+		Convey("When parsing targets", func() {
+			ts, err := parseTargets("linux/amd64, linux/arm/7,windows/amd64")
+			So(err, ShouldBeNil)
+
+			Convey("Then they should have GOOS, GOARCH, and GOARM", func() {
+				So(ts, ShouldResemble, []*buildTarget{
+					{OS: "linux", Arch: "amd64"},
+					{OS: "linux", Arch: "arm", ARM: "7"},
+					{OS: "windows", Arch: "amd64"},
+				})
+				So(ts[1].env(), ShouldResemble, []string{"GOOS=linux", "GOARCH=arm", "CGO_ENABLED=0", "GOARM=7"})
+			})
+
+			Convey("Then they should have artifact names", func() {
+				So(ts[0].artifactName("bin/sensorbee"), ShouldEqual, "bin/sensorbee_linux_amd64")
+				So(ts[1].artifactName("sensorbee"), ShouldEqual, "sensorbee_linux_armv7")
+				So(ts[2].artifactName("sensorbee.exe"), ShouldEqual, "sensorbee_windows_amd64.exe")
+			})
+		})
+
+		Convey("When parsing an empty value", func() {
+			ts, err := parseTargets("")
+
+			Convey("Then there should be no target", func() {
+				So(err, ShouldBeNil)
+				So(ts, ShouldBeEmpty)
+			})
+		})
+
+		Convey("When parsing invalid targets", func() {
+			for _, s := range []string{"linux", "linux/", "/amd64", "linux/amd64/7", "linux/arm/8",
+				"linux/amd64,linux/amd64", "linux/arm/7/1"} {
+				_, err := parseTargets(s)
+
+				Convey("Then it should fail: "+s, func() {
+					So(err, ShouldNotBeNil)
+				})
+			}
+		})
+	})
+}
+
+func TestWriteChecksums(t *testing.T) {
+	dir, err := ioutil.TempDir("", "build_sensorbee_checksums_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	Convey("Given built binaries", t, func() {
+		a := filepath.Join(dir, "sensorbee_linux_amd64")
+		So(ioutil.WriteFile(a, []byte("hello"), 0644), ShouldBeNil)
+		b := filepath.Join(dir, "sensorbee_linux_arm64")
+		So(ioutil.WriteFile(b, []byte(""), 0644), ShouldBeNil)
+
+		Convey("When writing checksums", func() {
+			sums := filepath.Join(dir, "SHA256SUMS")
+			So(writeChecksums(sums, []string{a, b}), ShouldBeNil)
+
+			Convey("Then the file should be in the format of sha256sum", func() {
+				res, err := ioutil.ReadFile(sums)
+				So(err, ShouldBeNil)
+				So(string(res), ShouldEqual,
+					"2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824  sensorbee_linux_amd64\n"+
+						"e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855  sensorbee_linux_arm64\n")
+			})
+		})
+
+		Convey("When writing checksums of a missing file", func() {
+			err := writeChecksums(filepath.Join(dir, "SHA256SUMS"), []string{filepath.Join(dir, "no_such_file")})
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
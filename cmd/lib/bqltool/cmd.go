@@ -0,0 +1,57 @@
+/*
+Package bqltool implements sensorbee bql command, which has subcommands to
+maintain BQL files:
+
+	sensorbee bql fmt [-w] [-l] [files...]
+	sensorbee bql lint [-u sink_type] files...
+
+fmt formats BQL files with canonical casing and indentation while keeping
+comments between statements. lint reports unused streams, shadowed names,
+SELECT * into typed sinks, and windows without emitter options.
+*/
+package bqltool
+
+import (
+	"gopkg.in/urfave/cli.v1"
+)
+
+var (
+	testMode     bool
+	testExitCode int
+)
+
+// SetUp sets up a command for BQL files.
+func SetUp() cli.Command {
+	cmd := cli.Command{
+		Name:        "bql",
+		Usage:       "format and lint BQL files",
+		Description: "bql command provides tools to maintain BQL files",
+		Action:      cli.ShowSubcommandHelp,
+		Subcommands: []cli.Command{
+			setUpFmt(),
+			setUpLint(),
+		},
+	}
+	return cmd
+}
+
+func actionWrapper(f cli.ActionFunc) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		testExitCode = 0
+		if err := f(c); err != nil {
+			if testMode {
+				testExitCode = 1
+				return err
+			}
+			return cli.NewExitError(err.Error(), 1)
+		}
+		return nil
+	}
+}
+
+// silentError is an error which only provides a non-zero exit code.
+type silentError struct{}
+
+func (e *silentError) Error() string {
+	return ""
+}
@@ -0,0 +1,251 @@
+package bqltool
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/urfave/cli.v1"
+)
+
+func init() {
+	// Workaround. See https://github.com/urfave/cli/issues/565
+	cli.OsExiter = func(int) {}
+}
+
+func TestFormatBQL(t *testing.T) {
+	Convey("Given a BQL file", t, func() {
+		src := `-- sources
+create paused source s type dummy with num=4; -- trailing
+
+
+-- a stream
+create stream t as select rstream a, b from s [range 1 tuples] where a>0;
+create stream u as select istream
+  -- a comment inside
+  a from t [range 1 tuples];
+create sink snk type stdout;insert into snk from t, u
+-- footer
+`
+
+		Convey("When formatting it", func() {
+			res, err := formatBQL(src)
+			So(err, ShouldBeNil)
+
+			Convey("Then statements should be formatted with comments", func() {
+				So(res, ShouldEqual, `-- sources
+CREATE PAUSED SOURCE s TYPE dummy WITH num=4; -- trailing
+
+-- a stream
+CREATE STREAM t AS
+    SELECT RSTREAM a, b
+    FROM s [RANGE 1 TUPLES]
+    WHERE a > 0;
+create stream u as select istream
+  -- a comment inside
+  a from t [range 1 tuples];
+CREATE SINK snk TYPE stdout;
+INSERT INTO snk FROM t, u;
+-- footer
+`)
+			})
+
+			Convey("Then formatting the result again should not change it", func() {
+				res2, err := formatBQL(res)
+				So(err, ShouldBeNil)
+				So(res2, ShouldEqual, res)
+			})
+		})
+
+		Convey("When formatting a file having a string containing special characters", func() {
+			res, err := formatBQL(`SELECT RSTREAM "a;--""b" AS x FROM s [RANGE 1 TUPLES]`)
+			So(err, ShouldBeNil)
+
+			Convey("Then the string should be kept", func() {
+				So(res, ShouldEqual, `SELECT RSTREAM "a;--""b" AS x
+FROM s [RANGE 1 TUPLES];
+`)
+			})
+		})
+
+		Convey("When formatting an invalid file", func() {
+			_, err := formatBQL("CREATE SOURCE s TYPE dummy;\n\nCREATE STREAM")
+
+			Convey("Then it should fail with the line number", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "line 3")
+			})
+		})
+	})
+}
+
+func TestLint(t *testing.T) {
+	lint := func(src string, untyped ...string) []*lintWarning {
+		f, err := parseFile(src)
+		So(err, ShouldBeNil)
+		return newLinter(untyped).lint(f)
+	}
+
+	Convey("Given a linter", t, func() {
+		Convey("When linting a file without problems", func() {
+			ws := lint(`CREATE SOURCE s TYPE dummy;
+CREATE STREAM t AS SELECT ISTREAM * FROM s [RANGE 10 TUPLES];
+CREATE STREAM u AS SELECT RSTREAM count(*) AS c FROM t [RANGE 1 SECONDS];
+CREATE SINK snk TYPE stdout;
+INSERT INTO snk FROM u;
+INSERT INTO snk FROM t;`)
+
+			Convey("Then there should be no warning", func() {
+				So(ws, ShouldBeEmpty)
+			})
+		})
+
+		Convey("When linting a file having unused streams", func() {
+			ws := lint(`CREATE SOURCE s TYPE dummy;
+CREATE STREAM t AS SELECT ISTREAM * FROM s [RANGE 1 TUPLES];
+CREATE SOURCE s2 TYPE dummy;
+CREATE STREAM u AS SELECT ISTREAM * FROM duplicate("s2", 2) [RANGE 1 TUPLES];`)
+
+			Convey("Then they should be reported", func() {
+				So(ws, ShouldHaveLength, 2)
+				So(ws[0].line, ShouldEqual, 2)
+				So(ws[0].msg, ShouldEqual, "stream t is never used")
+				So(ws[1].line, ShouldEqual, 4)
+				So(ws[1].msg, ShouldEqual, "stream u is never used")
+			})
+		})
+
+		Convey("When linting a file having shadowed names", func() {
+			ws := lint(`CREATE SOURCE s TYPE dummy;
+CREATE STREAM S AS SELECT ISTREAM * FROM s [RANGE 1 TUPLES];
+DROP STREAM s;
+CREATE SINK s TYPE stdout;
+INSERT INTO s FROM s;
+CREATE STATE st TYPE counter;
+CREATE STATE st TYPE counter;`)
+
+			Convey("Then they should be reported", func() {
+				So(ws, ShouldHaveLength, 3)
+				So(ws[0].line, ShouldEqual, 2)
+				So(ws[0].msg, ShouldEqual, "stream S shadows source s defined at line 1")
+				So(ws[1].line, ShouldEqual, 2)
+				So(ws[1].msg, ShouldEqual, "stream S is never used")
+				So(ws[2].line, ShouldEqual, 7)
+				So(ws[2].msg, ShouldEqual, "state st shadows the state defined at line 6")
+			})
+		})
+
+		Convey("When linting a file having SELECT * into a typed sink", func() {
+			src := `CREATE SOURCE s TYPE dummy;
+CREATE STREAM t AS SELECT ISTREAM a FROM s [RANGE 1 TUPLES]
+  UNION ALL SELECT ISTREAM * FROM s [RANGE 1 TUPLES];
+CREATE SINK snk TYPE mysql;
+INSERT INTO snk FROM t;
+INSERT INTO snk FROM s;`
+
+			Convey("Then it should be reported", func() {
+				ws := lint(src)
+				So(ws, ShouldHaveLength, 1)
+				So(ws[0].line, ShouldEqual, 5)
+				So(ws[0].msg, ShouldContainSubstring, "stream t has SELECT *")
+			})
+
+			Convey("Then it should not be reported when the sink type is untyped", func() {
+				So(lint(src, "MySQL"), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When linting a file having SPLIT", func() {
+			ws := lint(`CREATE SOURCE s TYPE dummy;
+CREATE STREAM t AS SELECT ISTREAM * FROM s [RANGE 1 TUPLES];
+SPLIT t INTO hot WHEN a > 80, cold OTHERWISE;
+CREATE SINK snk TYPE mysql;
+INSERT INTO snk FROM hot;`)
+
+			Convey("Then branches should be defined as streams", func() {
+				So(ws, ShouldHaveLength, 2)
+				So(ws[0].line, ShouldEqual, 3)
+				So(ws[0].msg, ShouldEqual, "stream cold is never used")
+				So(ws[1].line, ShouldEqual, 5)
+				So(ws[1].msg, ShouldContainSubstring, "stream hot has SELECT *")
+			})
+		})
+
+		Convey("When linting a file having RSTREAM without emitter options", func() {
+			ws := lint(`CREATE SOURCE s TYPE dummy;
+SELECT RSTREAM * FROM s [RANGE 2 TUPLES];
+SELECT RSTREAM [EVERY 2ND TUPLE] * FROM s [RANGE 2 TUPLES];
+SELECT RSTREAM a FROM s [RANGE 1 TUPLES];
+SELECT RSTREAM a FROM s [RANGE 1 SECONDS] GROUP BY a;`)
+
+			Convey("Then it should be reported", func() {
+				So(ws, ShouldHaveLength, 1)
+				So(ws[0].line, ShouldEqual, 2)
+				So(ws[0].msg, ShouldStartWith, "RSTREAM emits the whole window of s")
+			})
+		})
+	})
+}
+
+func TestBQLCommand(t *testing.T) {
+	testMode = true
+	defer func() {
+		testMode = false
+	}()
+
+	dir, err := ioutil.TempDir("", "bqltool_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	Convey("Given a sensorbee command with bql subcommand", t, func() {
+		a := cli.NewApp()
+		a.Name = "sensorbee"
+		a.Commands = []cli.Command{SetUp()}
+		buf := bytes.NewBuffer(nil)
+		a.Writer = buf
+
+		path := filepath.Join(dir, "test.bql")
+		So(ioutil.WriteFile(path, []byte("create source s type dummy;\n"), 0644), ShouldBeNil)
+
+		Convey("When running fmt", func() {
+			So(a.Run([]string{"sensorbee", "bql", "fmt", path}), ShouldBeNil)
+
+			Convey("Then it should print the formatted file", func() {
+				So(buf.String(), ShouldEqual, "CREATE SOURCE s TYPE dummy;\n")
+			})
+		})
+
+		Convey("When running fmt with --list", func() {
+			So(a.Run([]string{"sensorbee", "bql", "fmt", "-l", path}), ShouldBeNil)
+
+			Convey("Then it should print the filename", func() {
+				So(buf.String(), ShouldEqual, path+"\n")
+			})
+		})
+
+		Convey("When running fmt with --write", func() {
+			So(a.Run([]string{"sensorbee", "bql", "fmt", "-w", path}), ShouldBeNil)
+
+			Convey("Then it should update the file", func() {
+				b, err := ioutil.ReadFile(path)
+				So(err, ShouldBeNil)
+				So(string(b), ShouldEqual, "CREATE SOURCE s TYPE dummy;\n")
+				So(buf.String(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When running lint", func() {
+			a.Run([]string{"sensorbee", "bql", "lint", path})
+
+			Convey("Then it should report warnings and fail", func() {
+				So(testExitCode, ShouldEqual, 1)
+				So(buf.String(), ShouldEqual, path+":1: source s is never used\n")
+			})
+		})
+	})
+}
@@ -0,0 +1,139 @@
+package bqltool
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"strings"
+
+	"gopkg.in/sensorbee/sensorbee.v0/bql/parser"
+	"gopkg.in/urfave/cli.v1"
+)
+
+func setUpFmt() cli.Command {
+	return cli.Command{
+		Name:      "fmt",
+		Usage:     "format BQL files",
+		ArgsUsage: "[BQL files...]",
+		Description: "fmt command formats BQL files with canonical casing and indentation. " +
+			"When no file is given, it formats the standard input.",
+		Action: actionWrapper(runFmt),
+		Flags: []cli.Flag{
+			cli.BoolFlag{
+				Name:  "write, w",
+				Usage: "write the result to the source file instead of the standard output",
+			},
+			cli.BoolFlag{
+				Name:  "list, l",
+				Usage: "only list files whose formatting differs from the result",
+			},
+		},
+	}
+}
+
+func runFmt(c *cli.Context) error {
+	if len(c.Args()) == 0 {
+		if c.Bool("write") {
+			return fmt.Errorf("cannot use --write with the standard input")
+		}
+		src, err := ioutil.ReadAll(os.Stdin)
+		if err != nil {
+			return fmt.Errorf("cannot read the standard input: %v", err)
+		}
+		res, err := formatBQL(string(src))
+		if err != nil {
+			return err
+		}
+		if c.Bool("list") {
+			if res != string(src) {
+				fmt.Fprintln(c.App.Writer, "<standard input>")
+			}
+			return nil
+		}
+		fmt.Fprint(c.App.Writer, res)
+		return nil
+	}
+
+	for _, path := range c.Args() {
+		src, err := ioutil.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("cannot read %v: %v", path, err)
+		}
+		res, err := formatBQL(string(src))
+		if err != nil {
+			return fmt.Errorf("%v: %v", path, err)
+		}
+
+		if c.Bool("list") {
+			if res != string(src) {
+				fmt.Fprintln(c.App.Writer, path)
+			}
+			continue
+		}
+		if c.Bool("write") {
+			if res == string(src) {
+				continue
+			}
+			st, err := os.Stat(path)
+			if err != nil {
+				return fmt.Errorf("cannot write %v: %v", path, err)
+			}
+			if err := ioutil.WriteFile(path, []byte(res), st.Mode()); err != nil {
+				return fmt.Errorf("cannot write %v: %v", path, err)
+			}
+			continue
+		}
+		fmt.Fprint(c.App.Writer, res)
+	}
+	return nil
+}
+
+// formatBQL formats BQL statements in src. Each statement is terminated by a
+// semicolon and a newline. Comments and blank lines between statements are
+// kept. A statement having comments inside it is written as it is so that
+// the comments aren't lost.
+func formatBQL(src string) (string, error) {
+	f, err := parseFile(src)
+	if err != nil {
+		return "", err
+	}
+
+	buf := bytes.NewBuffer(nil)
+	writeComments := func(cs []string) {
+		for _, c := range cs {
+			buf.WriteString(c)
+			buf.WriteString("\n")
+		}
+	}
+	for _, s := range f.stmts {
+		writeComments(s.leading)
+		buf.WriteString(formatStatement(s))
+		buf.WriteString(";")
+		if s.trailing != "" {
+			buf.WriteString(" ")
+			buf.WriteString(s.trailing)
+		}
+		buf.WriteString("\n")
+	}
+	writeComments(f.footer)
+	return buf.String(), nil
+}
+
+func formatStatement(s *statement) string {
+	if s.hasInnerComments {
+		return s.text
+	}
+
+	res := parser.Format(s.stmt)
+
+	// The formatted statement must have the same meaning as the original
+	// one. If it doesn't, the formatter has a bug and the original text is
+	// used instead.
+	stmt, rest, err := parser.New().ParseStmt(res)
+	if err != nil || strings.TrimSpace(rest) != "" || !reflect.DeepEqual(stmt, s.stmt) {
+		return s.text
+	}
+	return res
+}
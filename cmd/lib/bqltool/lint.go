@@ -0,0 +1,316 @@
+package bqltool
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"gopkg.in/sensorbee/sensorbee.v0/bql/parser"
+	"gopkg.in/urfave/cli.v1"
+)
+
+func setUpLint() cli.Command {
+	return cli.Command{
+		Name:      "lint",
+		Usage:     "report suspicious constructs in BQL files",
+		ArgsUsage: "<BQL files...>",
+		Description: "lint command reports unused streams, shadowed names, " +
+			"SELECT * into typed sinks, and windows without emitter options. " +
+			"It exits with a non-zero status when any problem is found.",
+		Action: actionWrapper(runLint),
+		Flags: []cli.Flag{
+			cli.StringSliceFlag{
+				Name:  "untyped-sink, u",
+				Usage: "a sink type which accepts tuples having any fields, stdout and file are always untyped",
+			},
+		},
+	}
+}
+
+func runLint(c *cli.Context) error {
+	if len(c.Args()) == 0 {
+		cli.ShowSubcommandHelp(c)
+		return fmt.Errorf("no BQL file is given")
+	}
+
+	l := newLinter(c.StringSlice("untyped-sink"))
+	found := false
+	for _, path := range c.Args() {
+		src, err := ioutil.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("cannot read %v: %v", path, err)
+		}
+		f, err := parseFile(string(src))
+		if err != nil {
+			return fmt.Errorf("%v: %v", path, err)
+		}
+		for _, w := range l.lint(f) {
+			found = true
+			fmt.Fprintf(c.App.Writer, "%v:%v: %v\n", path, w.line, w.msg)
+		}
+	}
+	if found {
+		return errLintWarnings
+	}
+	return nil
+}
+
+// errLintWarnings is returned from runLint to exit with a non-zero status
+// without printing an additional message.
+var errLintWarnings = &silentError{}
+
+type lintWarning struct {
+	line int
+	msg  string
+}
+
+// linter checks statements in a BQL file.
+type linter struct {
+	// untypedSinkTypes has sink types which accept tuples having any fields.
+	// Other sink types may require specific fields and SELECT * into them
+	// is reported.
+	untypedSinkTypes map[string]bool
+}
+
+func newLinter(untypedSinkTypes []string) *linter {
+	l := &linter{
+		untypedSinkTypes: map[string]bool{
+			"stdout": true,
+			"file":   true,
+		},
+	}
+	for _, t := range untypedSinkTypes {
+		l.untypedSinkTypes[strings.ToLower(t)] = true
+	}
+	return l
+}
+
+// lintNode is a node defined in a BQL file.
+type lintNode struct {
+	kind string // "source", "stream", or "sink"
+	name string
+	line int
+
+	// typeName is the type of a source or a sink.
+	typeName string
+
+	// used is true when the node is referred by other statements.
+	used bool
+
+	// selectsAll is true when the stream has SELECT *.
+	selectsAll bool
+}
+
+// lint returns warnings found in f in the order of their line numbers.
+func (l *linter) lint(f *bqlFile) []*lintWarning {
+	var (
+		ws     []*lintWarning
+		nodes  = map[string]*lintNode{}
+		states = map[string]int{}
+
+		// defined has all nodes including dropped or shadowed ones so
+		// that unused ones can be reported
+		defined []*lintNode
+	)
+	warn := func(line int, format string, args ...interface{}) {
+		ws = append(ws, &lintWarning{line, fmt.Sprintf(format, args...)})
+	}
+	define := func(n *lintNode) {
+		key := strings.ToLower(n.name)
+		if prev, ok := nodes[key]; ok {
+			warn(n.line, "%v %v shadows %v %v defined at line %v", n.kind, n.name, prev.kind, prev.name, prev.line)
+		}
+		nodes[key] = n
+		defined = append(defined, n)
+	}
+	use := func(name string) *lintNode {
+		n, ok := nodes[strings.ToLower(name)]
+		if !ok {
+			return nil
+		}
+		n.used = true
+		return n
+	}
+	useSelect := func(s *parser.SelectStmt) {
+		for _, rel := range s.Relations {
+			switch rel.Type {
+			case parser.ActualStream:
+				use(rel.Name)
+			case parser.UDSFStream:
+				// A UDSF may refer to a stream by its name given as an argument.
+				for _, p := range rel.Params {
+					if str, ok := p.(parser.StringLiteral); ok {
+						use(str.Value)
+					}
+				}
+			}
+		}
+	}
+
+	for _, s := range f.stmts {
+		switch stmt := s.stmt.(type) {
+		case parser.CreateSourceStmt:
+			define(&lintNode{kind: "source", name: string(stmt.Name), line: s.line, typeName: string(stmt.Type)})
+
+		case parser.CreateStreamAsSelectStmt:
+			useSelect(&stmt.Select)
+			l.checkEmitter(&stmt.Select, s.line, warn)
+			define(&lintNode{kind: "stream", name: string(stmt.Name), line: s.line,
+				selectsAll: hasWildcard(&stmt.Select)})
+
+		case parser.CreateStreamAsSelectUnionStmt:
+			all := false
+			for i := range stmt.Selects {
+				useSelect(&stmt.Selects[i])
+				l.checkEmitter(&stmt.Selects[i], s.line, warn)
+				all = all || hasWildcard(&stmt.Selects[i])
+			}
+			define(&lintNode{kind: "stream", name: string(stmt.Name), line: s.line, selectsAll: all})
+
+		case parser.CreateStreamAsEnrichStmt:
+			// ENRICH forwards tuples with an additional field.
+			in := use(string(stmt.Input))
+			define(&lintNode{kind: "stream", name: string(stmt.Name), line: s.line,
+				selectsAll: in != nil && in.selectsAll})
+
+		case parser.SelectStmt:
+			useSelect(&stmt)
+			l.checkEmitter(&stmt, s.line, warn)
+
+		case parser.SelectUnionStmt:
+			for i := range stmt.Selects {
+				useSelect(&stmt.Selects[i])
+				l.checkEmitter(&stmt.Selects[i], s.line, warn)
+			}
+
+		case parser.SelectStartingStmt:
+			useSelect(&stmt.SelectStmt)
+			l.checkEmitter(&stmt.SelectStmt, s.line, warn)
+
+		case parser.ExplainAnalyzeStmt:
+			useSelect(&stmt.SelectStmt)
+
+		case parser.CreateSinkStmt:
+			define(&lintNode{kind: "sink", name: string(stmt.Name), line: s.line, typeName: string(stmt.Type)})
+
+		case parser.InsertIntoFromStmt:
+			sink := use(string(stmt.Sink))
+			for _, in := range stmt.Inputs {
+				n := use(string(in))
+				if n == nil || sink == nil || sink.kind != "sink" || !n.selectsAll {
+					continue
+				}
+				if !l.untypedSinkTypes[strings.ToLower(sink.typeName)] {
+					warn(s.line, "stream %v has SELECT * but is inserted into sink %v of type %v, "+
+						"which may require specific fields", n.name, sink.name, sink.typeName)
+				}
+			}
+
+		case parser.InsertIntoSelectStmt:
+			useSelect(&stmt.Select)
+			l.checkEmitter(&stmt.Select, s.line, warn)
+			sink := use(string(stmt.Sink))
+			if sink != nil && sink.kind == "sink" && hasWildcard(&stmt.Select) &&
+				!l.untypedSinkTypes[strings.ToLower(sink.typeName)] {
+				warn(s.line, "SELECT * is inserted into sink %v of type %v, "+
+					"which may require specific fields", sink.name, sink.typeName)
+			}
+
+		case parser.SplitStmt:
+			// SPLIT forwards tuples as they are, so branches select all
+			// fields if the input does.
+			in := use(string(stmt.Input))
+			for _, br := range stmt.Branches {
+				define(&lintNode{kind: "stream", name: string(br.Name), line: s.line,
+					selectsAll: in != nil && in.selectsAll})
+			}
+
+		case parser.ArchiveStreamStmt:
+			use(string(stmt.Stream))
+
+		case parser.ReplayArchiveStmt:
+			define(&lintNode{kind: "source", name: string(stmt.Stream), line: s.line, typeName: "archive"})
+
+		case parser.CreateStateStmt:
+			key := strings.ToLower(string(stmt.Name))
+			if line, ok := states[key]; ok {
+				warn(s.line, "state %v shadows the state defined at line %v", stmt.Name, line)
+			}
+			states[key] = s.line
+
+		case parser.DropSourceStmt:
+			delete(nodes, strings.ToLower(string(stmt.Source)))
+		case parser.DropStreamStmt:
+			delete(nodes, strings.ToLower(string(stmt.Stream)))
+		case parser.DropSinkStmt:
+			delete(nodes, strings.ToLower(string(stmt.Sink)))
+		case parser.DropStateStmt:
+			delete(states, strings.ToLower(string(stmt.State)))
+		}
+	}
+
+	for _, n := range defined {
+		if n.used || n.kind == "sink" {
+			continue
+		}
+		warn(n.line, "%v %v is never used", n.kind, n.name)
+	}
+
+	sort.Stable(lintWarnings(ws))
+	return ws
+}
+
+type lintWarnings []*lintWarning
+
+func (ws lintWarnings) Len() int {
+	return len(ws)
+}
+
+func (ws lintWarnings) Less(i, j int) bool {
+	return ws[i].line < ws[j].line
+}
+
+func (ws lintWarnings) Swap(i, j int) {
+	ws[i], ws[j] = ws[j], ws[i]
+}
+
+// checkEmitter reports a SELECT statement which emits the whole content of
+// its window for every input tuple. This happens when RSTREAM is used with a
+// window larger than one tuple and no emitter option such as EVERY, LIMIT,
+// or SAMPLE. A statement having GROUP BY or function calls in its
+// projections isn't reported because it's likely to be an aggregation.
+func (l *linter) checkEmitter(s *parser.SelectStmt, line int,
+	warn func(line int, format string, args ...interface{})) {
+	if s.EmitterType != parser.Rstream || len(s.EmitterOptions) > 0 {
+		return
+	}
+	if len(s.GroupList) > 0 {
+		return
+	}
+	for _, p := range s.Projections {
+		if a, ok := p.(parser.AliasAST); ok {
+			p = a.Expr
+		}
+		if _, ok := p.(parser.FuncAppAST); ok {
+			return
+		}
+	}
+	for _, rel := range s.Relations {
+		if rel.Unit == parser.Tuples && rel.Value <= 1 {
+			continue
+		}
+		warn(line, "RSTREAM emits the whole window of %v for every input tuple; "+
+			"use ISTREAM or an emitter option such as EVERY, LIMIT, or SAMPLE", rel.Name)
+		return
+	}
+}
+
+func hasWildcard(s *parser.SelectStmt) bool {
+	for _, p := range s.Projections {
+		if _, ok := p.(parser.Wildcard); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,164 @@
+package bqltool
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"gopkg.in/sensorbee/sensorbee.v0/bql/parser"
+)
+
+// statement is a statement in a BQL file with comments around it.
+type statement struct {
+	stmt interface{}
+
+	// line is the line number (1-origin) at which the statement begins.
+	line int
+
+	// text is the source text of the statement without comments around it
+	// and a trailing semicolon.
+	text string
+
+	// leading has comments written before the statement. An empty string
+	// represents one or more blank lines.
+	leading []string
+
+	// trailing is a comment written on the same line as the end of the
+	// statement.
+	trailing string
+
+	// hasInnerComments is true when the statement has comments inside it.
+	// Such a statement cannot be formatted without losing comments.
+	hasInnerComments bool
+}
+
+// bqlFile is a parsed BQL file.
+type bqlFile struct {
+	stmts []*statement
+
+	// footer has comments after the last statement. An empty string
+	// represents one or more blank lines.
+	footer []string
+}
+
+// parseFile parses all statements in src while keeping comments.
+func parseFile(src string) (*bqlFile, error) {
+	p := parser.New()
+	f := &bqlFile{}
+	rs := []rune(src)
+	pos := 0
+	for {
+		// collect comments and blank lines until the next statement
+		var (
+			items     []string
+			prev      *statement
+			newlines  = 0
+			codeStart = -1
+		)
+		if len(f.stmts) > 0 {
+			prev = f.stmts[len(f.stmts)-1]
+		}
+		for pos < len(rs) {
+			r := rs[pos]
+			if r == '-' && pos+1 < len(rs) && rs[pos+1] == '-' {
+				end := pos
+				for end < len(rs) && rs[end] != '\n' {
+					end++
+				}
+				c := strings.TrimRightFunc(string(rs[pos:end]), unicode.IsSpace)
+				if prev != nil && newlines == 0 && len(items) == 0 {
+					prev.trailing = c
+				} else {
+					if newlines > 1 && (len(items) > 0 || prev != nil) {
+						items = append(items, "")
+					}
+					items = append(items, c)
+				}
+				newlines = 0
+				pos = end
+				continue
+			}
+			if r == '\n' {
+				newlines++
+			} else if !unicode.IsSpace(r) && r != ';' {
+				codeStart = pos
+				break
+			}
+			pos++
+		}
+		if newlines > 1 && (len(items) > 0 || prev != nil) {
+			items = append(items, "")
+		}
+		if codeStart < 0 {
+			// there's no more statement
+			if len(items) > 0 && items[len(items)-1] == "" {
+				items = items[:len(items)-1]
+			}
+			f.footer = items
+			return f, nil
+		}
+
+		line := strings.Count(string(rs[:codeStart]), "\n") + 1
+		stmt, _, err := p.ParseStmt(string(rs[codeStart:]))
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse the statement at line %v: %v", line, err)
+		}
+
+		bodyEnd, next, hasComments := scanStatement(rs, codeStart)
+		f.stmts = append(f.stmts, &statement{
+			stmt:             stmt,
+			line:             line,
+			text:             string(rs[codeStart:bodyEnd]),
+			leading:          items,
+			hasInnerComments: hasComments,
+		})
+		pos = next
+	}
+}
+
+// scanStatement scans a statement beginning at rs[begin] and returns the end
+// of the statement's text, the position right after the semicolon
+// terminating the statement (or the end of the statement's text when there's
+// no semicolon), and whether the statement has comments inside it. The
+// statement must be a valid BQL statement.
+func scanStatement(rs []rune, begin int) (bodyEnd int, next int, hasComments bool) {
+	var (
+		inString       = false
+		commentBetween = false
+	)
+	bodyEnd = begin
+	for pos := begin; pos < len(rs); pos++ {
+		r := rs[pos]
+		switch {
+		case inString:
+			// "" is an escaped double quote, which can be handled by
+			// toggling inString twice.
+			if r == '"' {
+				inString = false
+				bodyEnd = pos + 1
+			}
+		case r == '"':
+			inString = true
+			if commentBetween {
+				hasComments = true
+			}
+			commentBetween = false
+		case r == '-' && pos+1 < len(rs) && rs[pos+1] == '-':
+			commentBetween = true
+			for pos < len(rs) && rs[pos] != '\n' {
+				pos++
+			}
+		case r == ';':
+			return bodyEnd, pos + 1, hasComments
+		case !unicode.IsSpace(r):
+			if commentBetween {
+				hasComments = true
+			}
+			commentBetween = false
+			bodyEnd = pos + 1
+		}
+	}
+	// the last statement in a file may not have a semicolon. Comments after
+	// it are processed as a footer.
+	return bodyEnd, bodyEnd, hasComments
+}
@@ -361,7 +361,8 @@ func setUpState(tb *bql.TopologyBuilder, latestEnt *CacheEntry, stmt *Statement)
 
 func executeTarget(t core.Topology, ent *CacheEntry, stmt *Statement, resumeAll func() error) error {
 	switch stmt.Stmt.(type) {
-	case parser.CreateSourceStmt, parser.CreateStreamAsSelectStmt, parser.CreateStreamAsSelectUnionStmt:
+	case parser.CreateSourceStmt, parser.CreateStreamAsSelectStmt, parser.CreateStreamAsSelectUnionStmt,
+		parser.CreateStreamAsEnrichStmt:
 		sink, err := newJSONLSink(CacheFilename(ent))
 		if err != nil {
 			return fmt.Errorf("cannot create a cache file %v: %v", CacheFilename(ent), err)
@@ -379,7 +380,7 @@ func executeTarget(t core.Topology, ent *CacheEntry, stmt *Statement, resumeAll
 		}
 		sn.State().Wait(core.TSStopped)
 
-	case parser.InsertIntoFromStmt:
+	case parser.InsertIntoFromStmt, parser.InsertIntoSelectStmt:
 		if err := resumeAll(); err != nil {
 			return err
 		}
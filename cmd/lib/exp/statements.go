@@ -18,16 +18,19 @@ type Statement struct {
 // NewStatement creates a Statement from a BQL statement. Currently, following
 // statements are not supported:
 //
-//	* SELECT (CREATE STREAM is supported)
+//	* SELECT and EXPLAIN ANALYZE (CREATE STREAM is supported)
 //	* UPDATE SOURCE/SINK/STATE
 //	* DROP SOURCE/STREAM/SINK/STATE
 //	* SAVE/LOAD STATE (they are automatically saved)
+//	* SPLIT (it creates multiple streams)
+//	* ARCHIVE STREAM and REPLAY ARCHIVE
 func NewStatement(s interface{}) (*Statement, error) {
 	switch s.(type) {
-	case parser.SelectStmt, parser.SelectUnionStmt,
+	case parser.SelectStmt, parser.SelectUnionStmt, parser.SelectStartingStmt, parser.ExplainAnalyzeStmt,
 		parser.UpdateSourceStmt, parser.UpdateSinkStmt, parser.UpdateStateStmt,
 		parser.DropSourceStmt, parser.DropStreamStmt, parser.DropSinkStmt, parser.DropStateStmt,
-		parser.SaveStateStmt, parser.LoadStateStmt, parser.LoadStateOrCreateStmt:
+		parser.SaveStateStmt, parser.LoadStateStmt, parser.LoadStateOrCreateStmt,
+		parser.SplitStmt, parser.ArchiveStreamStmt, parser.ReplayArchiveStmt:
 		return nil, fmt.Errorf("statement isn't supported by sensorbee exp command: %v", s)
 	}
 
@@ -53,8 +56,14 @@ func (s *Statement) Input() ([]string, error) {
 			}
 			names = append(names, ns...)
 		}
+	case parser.CreateStreamAsEnrichStmt:
+		names = []string{string(stmt.Input)}
 	case parser.InsertIntoFromStmt:
-		return []string{string(stmt.Input)}, nil
+		for _, in := range stmt.Inputs {
+			names = append(names, string(in))
+		}
+	case parser.InsertIntoSelectStmt:
+		names, err = inputFromSelect(&stmt.Select)
 	}
 	if err != nil {
 		return nil, err
@@ -138,8 +147,12 @@ func (s *Statement) NodeName() string {
 		return string(stmt.Name)
 	case parser.CreateStreamAsSelectUnionStmt:
 		return string(stmt.Name)
+	case parser.CreateStreamAsEnrichStmt:
+		return string(stmt.Name)
 	case parser.InsertIntoFromStmt:
 		return string(stmt.Sink)
+	case parser.InsertIntoSelectStmt:
+		return string(stmt.Sink)
 	}
 	// There's no need to cache the result of sinks.
 	// CREATE STATE returns "" because it doesn't create a node.
@@ -154,7 +167,8 @@ func (s *Statement) String() string {
 // IsStream returns true if the statement is a stream.
 func (s *Statement) IsStream() bool {
 	switch s.Stmt.(type) {
-	case parser.CreateStreamAsSelectStmt, parser.CreateStreamAsSelectUnionStmt:
+	case parser.CreateStreamAsSelectStmt, parser.CreateStreamAsSelectUnionStmt,
+		parser.CreateStreamAsEnrichStmt:
 		return true
 	}
 	return false
@@ -165,7 +179,7 @@ func (s *Statement) IsStream() bool {
 func (s *Statement) IsDataSourceNodeQuery() bool {
 	switch s.Stmt.(type) {
 	case parser.CreateSourceStmt, parser.CreateStreamAsSelectStmt,
-		parser.CreateStreamAsSelectUnionStmt:
+		parser.CreateStreamAsSelectUnionStmt, parser.CreateStreamAsEnrichStmt:
 		return true
 	}
 	return false
@@ -174,7 +188,7 @@ func (s *Statement) IsDataSourceNodeQuery() bool {
 // IsInsertStatement returns true when the statement is INSERT INTO.
 func (s *Statement) IsInsertStatement() bool {
 	switch s.Stmt.(type) {
-	case parser.InsertIntoFromStmt:
+	case parser.InsertIntoFromStmt, parser.InsertIntoSelectStmt:
 		return true
 	}
 	return false
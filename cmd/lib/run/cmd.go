@@ -3,25 +3,31 @@
 package run
 
 import (
+	"context"
 	"fmt"
-	"gopkg.in/pfnet/jasco.v1"
 	"gopkg.in/sensorbee/sensorbee.v0/data"
 	"gopkg.in/sensorbee/sensorbee.v0/server"
 	"gopkg.in/sensorbee/sensorbee.v0/server/config"
 	"gopkg.in/urfave/cli.v1"
 	"gopkg.in/yaml.v2"
 	"io/ioutil"
-	"net"
-	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 )
 
+// handoffTimeout is the maximum duration for which the server waits for a new
+// process to get ready when handing off the listener.
+const handoffTimeout = 5 * time.Minute
+
 // SetUp sets up SensorBee's HTTP server. The URL or port ID is set with server
 // configuration file, or command line arguments.
 func SetUp() cli.Command {
 	cmd := cli.Command{
 		Name:        "run",
 		Usage:       "run the server",
-		Description: "run command starts a new server process",
+		Description: "run command starts a new server process. When the process receives SIGUSR2, it starts a new process with the same arguments, hands off the listener to it, and stops after draining active requests",
 		Action:      Run,
 	}
 	cmd.Flags = []cli.Flag{
@@ -35,6 +41,28 @@ func SetUp() cli.Command {
 	return cmd
 }
 
+// handoffServer starts a new process with the same arguments, hands off the
+// listener to it, and then drains the server.
+func handoffServer(s *server.Server) error {
+	path, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("cannot find the path of the executable: %v", err)
+	}
+	s.Logger().Info("Handing off the listener to a new process")
+	if err := s.Handoff(path, os.Args[1:], handoffTimeout); err != nil {
+		return err
+	}
+
+	s.Logger().Info("Draining the server")
+	ctx, cancel := context.WithTimeout(context.Background(),
+		time.Duration(s.Config().Network.DrainTimeout)*time.Second)
+	defer cancel()
+	if err := s.Shutdown(ctx); err != nil {
+		s.Logger().WithField("err", err).Error("Cannot stop the server gracefully")
+	}
+	return nil
+}
+
 // Run run the HTTP server.
 func Run(c *cli.Context) error {
 	err := func() error {
@@ -71,38 +99,70 @@ func Run(c *cli.Context) error {
 			conf = c
 		}
 
-		cgvars, err := server.SetUpContextGlobalVariables(conf)
+		opts := []server.Option{server.WithConfig(conf)}
+		l, err := server.InheritedListener()
 		if err != nil {
-			return fmt.Errorf("Cannot set up the server context: %v", err)
+			return fmt.Errorf("Cannot use the listener of the parent process: %v", err)
 		}
-
-		cgvars.Logger.WithField("config", conf.ToMap()).Info("Setting up the server context")
-
-		jascoRoot := jasco.New("/", cgvars.Logger)
-		router, err := server.SetUpContextAndRouter("/", jascoRoot, cgvars)
+		if l != nil {
+			opts = append(opts, server.WithListener(l))
+		}
+		w, err := server.InheritedWorker()
 		if err != nil {
-			return fmt.Errorf("Cannot set up the server context: %v", err)
+			if l != nil {
+				l.Close()
+			}
+			return fmt.Errorf("Cannot run as a worker process: %v", err)
 		}
-		server.SetUpAPIRouter("/", router, nil)
-
-		bind := c.String("listen-on")
-		if _, err := net.ResolveTCPAddr("tcp", bind); err != nil {
-			return fmt.Errorf("--listen-on(-l) parameter has an invalid address: %v", err)
+		if w != nil {
+			opts = append(opts, server.AsWorker(w))
 		}
-
-		// TODO: support listening on multiple addresses
-		// TODO: support stopping the server
-		// TODO: support graceful shutdown
-		s := &http.Server{
-			Addr:    conf.Network.ListenOn,
-			Handler: jascoRoot,
+		s, err := server.New(opts...)
+		if err != nil {
+			if l != nil {
+				l.Close()
+			}
+			return err
 		}
-		cgvars.Logger.Infof("Starting the server on %v", conf.Network.ListenOn)
-		if err := s.ListenAndServe(); err != nil {
+		if err := s.Start(); err != nil {
+			s.Stop()
 			return fmt.Errorf("Cannot start the server: %v", err)
 		}
-		cgvars.Logger.Infof("The server stopped")
-		return nil
+
+		sig := make(chan os.Signal, 1)
+		signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+		defer signal.Stop(sig)
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- s.Wait()
+		}()
+
+		handoff := make(chan os.Signal, 1)
+		if len(handoffSignals) > 0 {
+			signal.Notify(handoff, handoffSignals...)
+			defer signal.Stop(handoff)
+		}
+
+		for {
+			select {
+			case <-sig:
+				s.Logger().Info("Stopping the server")
+				if err := s.Stop(); err != nil {
+					return fmt.Errorf("Cannot stop the server: %v", err)
+				}
+			case <-handoff:
+				if err := handoffServer(s); err != nil {
+					s.Logger().WithField("err", err).Error("Cannot hand off the listener to a new process")
+					continue
+				}
+			case err := <-errCh:
+				s.Stop()
+				if err != nil {
+					return fmt.Errorf("The server stopped with an error: %v", err)
+				}
+			}
+			return nil
+		}
 	}()
 	if err != nil {
 		// NOTE: using something like this allows tests to check exit codes.
@@ -0,0 +1,12 @@
+//go:build windows || plan9
+// +build windows plan9
+
+package run
+
+import (
+	"os"
+)
+
+// handoffSignals is empty because handing off the listener isn't supported
+// on this platform.
+var handoffSignals []os.Signal
@@ -0,0 +1,13 @@
+//go:build !windows && !plan9
+// +build !windows,!plan9
+
+package run
+
+import (
+	"os"
+	"syscall"
+)
+
+// handoffSignals are signals requesting the server to hand off its listener
+// to a new process.
+var handoffSignals = []os.Signal{syscall.SIGUSR2}
@@ -13,6 +13,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"gopkg.in/sensorbee/sensorbee.v0/bql"
@@ -52,6 +53,15 @@ func SetUp() cli.Command {
 			Value: "",
 			Usage: "name of the topology",
 		},
+		cli.BoolFlag{
+			Name:  "dry-run, n",
+			Usage: "print the plan of the topology without running it",
+		},
+		cli.BoolFlag{
+			Name: "simulate",
+			Usage: "run the topology in virtual time driven by timestamps of tuples " +
+				"emitted by sources instead of the wall clock",
+		},
 	}
 	return cmd
 }
@@ -121,12 +131,23 @@ func Run(c *cli.Context) error {
 			topologyName = n
 		}
 
-		tb, err := setUpTopology(topologyName, logger, conf, udsStorage)
+		// In a simulation, the clock starts at the timestamp of the first
+		// tuple emitted by sources.
+		var clock *core.SimulatedClock
+		if c.Bool("simulate") {
+			clock = core.NewSimulatedClock(time.Time{})
+		}
+
+		tb, err := setUpTopology(topologyName, logger, conf, udsStorage, clock)
 		if err != nil {
 			logger.WithField("err", err).Error("Cannot set up the topology")
 			return emptyError
 		}
 
+		if c.Bool("dry-run") {
+			return dryRun(os.Stdout, tb, bqlFile)
+		}
+
 		if err := setUpBQLStmt(tb, bqlFile); err != nil {
 			logger.WithFields(logrus.Fields{
 				"err":      err,
@@ -197,6 +218,9 @@ func Run(c *cli.Context) error {
 			s.State().Wait(core.TSStopped)
 		}
 		logger.Info("All sources has been stopped.")
+		if clock != nil {
+			logger.WithField("simulated_time", clock.Now()).Info("The simulation has finished")
+		}
 		return nil
 	}()
 	if err != nil {
@@ -223,11 +247,14 @@ func setUpUDSStorage(conf *config.UDSStorage) (udf.UDSStorage, error) {
 	}
 }
 
-func setUpTopology(name string, logger *logrus.Logger, conf *config.Config, us udf.UDSStorage) (
-	*bql.TopologyBuilder, error) {
+func setUpTopology(name string, logger *logrus.Logger, conf *config.Config, us udf.UDSStorage,
+	clock *core.SimulatedClock) (*bql.TopologyBuilder, error) {
 	cc := &core.ContextConfig{
 		Logger: logger,
 	}
+	if clock != nil {
+		cc.Clock = clock
+	}
 	cc.Flags.DroppedTupleLog.Set(conf.Logging.LogDroppedTuples)
 	cc.Flags.DestinationlessTupleLog.Set(conf.Logging.LogDestinationlessTuples)
 	cc.Flags.DroppedTupleSummarization.Set(conf.Logging.SummarizeDroppedTuples)
@@ -245,7 +272,12 @@ func setUpTopology(name string, logger *logrus.Logger, conf *config.Config, us u
 	return tb, nil
 }
 
-func setUpBQLStmt(tb *bql.TopologyBuilder, bqlFile string) error {
+func readBQLFile(bqlFile string) ([]interface{}, error) {
+	stmts, _, err := readBQLFileWithSources(bqlFile)
+	return stmts, err
+}
+
+func readBQLFileWithSources(bqlFile string) ([]interface{}, []*parser.StmtSource, error) {
 	queries, err := func() (string, error) {
 		f, err := os.Open(bqlFile)
 		if err != nil {
@@ -259,19 +291,37 @@ func setUpBQLStmt(tb *bql.TopologyBuilder, bqlFile string) error {
 		return string(b), nil
 	}()
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
 	bp := parser.New()
-	// TODO: provide better parse error reporting using ParseStmt instead of ParseStmts
-	stmts, err := bp.ParseStmts(string(queries))
+	stmts, srcs, err := bp.ParseStmtsWithSources(string(queries))
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot parse %v: %v", bqlFile, err)
+	}
+	for _, src := range srcs {
+		src.File = bqlFile
+	}
+	return stmts, srcs, nil
+}
+
+func setUpBQLStmt(tb *bql.TopologyBuilder, bqlFile string) error {
+	stmts, srcs, err := readBQLFileWithSources(bqlFile)
 	if err != nil {
 		return err
 	}
 
-	for _, stmt := range stmts {
+	stmts, srcs, warnings, err := tb.ValidateStmtsWithSources(stmts, srcs)
+	if err != nil {
+		return err
+	}
+	for _, w := range warnings {
+		tb.Topology().Context().Log().WithField("node", w.Node).Warn(w.Message)
+	}
+
+	for i, stmt := range stmts {
 		// TODO: if stmt is CREATE SOURCE, create it with PAUSED
-		if n, err := tb.AddStmt(stmt); err != nil {
+		if n, err := tb.AddStmtWithSource(stmt, srcs[i]); err != nil {
 			tb.Topology().Context().ErrLog(err).WithField("stmt", stmt).Error(
 				"Cannot add a statement to the topology")
 			return err // FIXME: logger output "err" two twice
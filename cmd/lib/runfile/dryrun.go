@@ -0,0 +1,85 @@
+package runfile
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"gopkg.in/sensorbee/sensorbee.v0/bql"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+)
+
+// dryRun prints the plan of the topology built from the BQL file without
+// creating any node. It returns an error when the file has any error so that
+// the command exits with a non-zero code.
+func dryRun(w io.Writer, tb *bql.TopologyBuilder, bqlFile string) error {
+	stmts, err := readBQLFile(bqlFile)
+	if err != nil {
+		fmt.Fprintf(w, "Cannot parse %v: %v\n", bqlFile, err)
+		return fmt.Errorf("")
+	}
+
+	plan := tb.Plan(stmts)
+	printPlan(w, plan)
+	if len(plan.Errors) > 0 {
+		return fmt.Errorf("")
+	}
+	return nil
+}
+
+func printPlan(w io.Writer, plan *bql.TopologyPlan) {
+	fmt.Fprintln(w, "Nodes:")
+	if len(plan.Nodes) == 0 {
+		fmt.Fprintln(w, "  (none)")
+	}
+	for _, n := range plan.Nodes {
+		kind := n.NodeType.String()
+		if n.NodeType == core.NTBox {
+			kind = "stream"
+		}
+		line := fmt.Sprintf("  %-6v %v", kind, n.Name)
+		if n.TypeName != "" {
+			line += " TYPE " + n.TypeName
+		}
+		if len(n.Inputs) > 0 {
+			line += " FROM " + strings.Join(n.Inputs, ", ")
+		}
+		fmt.Fprintln(w, line)
+
+		keys := make([]string, 0, len(n.Params))
+		for k := range n.Params {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			fmt.Fprintf(w, "           %v = %v\n", k, n.Params[k])
+		}
+	}
+
+	if len(plan.States) > 0 {
+		fmt.Fprintln(w, "States:")
+		for _, s := range plan.States {
+			fmt.Fprintf(w, "  %v\n", s)
+		}
+	}
+
+	if len(plan.Warnings) > 0 {
+		fmt.Fprintln(w, "Warnings:")
+		for _, wa := range plan.Warnings {
+			fmt.Fprintf(w, "  %v\n", wa)
+		}
+	}
+
+	if len(plan.Errors) == 0 {
+		fmt.Fprintln(w, "No errors found.")
+		return
+	}
+	fmt.Fprintln(w, "Errors:")
+	for _, e := range plan.Errors {
+		fmt.Fprintf(w, "  %v\n", e)
+		if s, ok := e.Stmt.(fmt.Stringer); ok {
+			fmt.Fprintf(w, "    in: %v\n", s)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package shell
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/client"
+	"os"
+	"strings"
+)
+
+// NewJSONPathCommands returns command list to debug JSON Paths.
+func NewJSONPathCommands() []Command {
+	return []Command{
+		&jsonPathCmd{},
+	}
+}
+
+const (
+	jsonPathCmdName = `\path`
+)
+
+// jsonPathCmd evaluates a JSON Path against a JSON document on the server
+// and shows how each component of the path is applied:
+//
+//	\path 'a.b[0]["c"]' {"a": {"b": [{"c": 1}]}}
+//
+// A single quote in the path can be escaped by another single quote.
+type jsonPathCmd struct {
+	path     string
+	document interface{}
+}
+
+func (p *jsonPathCmd) Init() error {
+	return nil
+}
+
+func (p *jsonPathCmd) Name() []string {
+	return []string{jsonPathCmdName}
+}
+
+func (p *jsonPathCmd) Input(input string) (cmdInputStatusType, error) {
+	rest := strings.TrimSpace(input[len(jsonPathCmdName):])
+	path, rest, err := parseQuotedJSONPath(rest)
+	if err != nil {
+		return invalidCMD, err
+	}
+	rest = strings.TrimSpace(rest)
+	if rest == "" {
+		return invalidCMD, errors.New("a JSON document is missing")
+	}
+	var doc interface{}
+	if err := json.Unmarshal([]byte(rest), &doc); err != nil {
+		return invalidCMD, fmt.Errorf("the document is not a valid JSON: %v", err)
+	}
+	p.path = path
+	p.document = doc
+	return preparedCMD, nil
+}
+
+// parseQuotedJSONPath parses a path quoted by single quotes at the beginning
+// of s. It returns the path and the rest of s.
+func parseQuotedJSONPath(s string) (string, string, error) {
+	if !strings.HasPrefix(s, "'") {
+		return "", "", errors.New("a JSON Path must be quoted by single quotes")
+	}
+	path := ""
+	for i := 1; i < len(s); i++ {
+		if s[i] != '\'' {
+			path += s[i : i+1]
+			continue
+		}
+		if i+1 < len(s) && s[i+1] == '\'' {
+			path += "'"
+			i++
+			continue
+		}
+		return path, s[i+1:], nil
+	}
+	return "", "", errors.New("a JSON Path isn't closed by a single quote")
+}
+
+func (p *jsonPathCmd) Eval(requester *client.Requester) {
+	res, err := requester.Do(client.Post, "/jsonpath", map[string]interface{}{
+		"path":     p.path,
+		"document": p.document,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "request failed: %v\n", err)
+		return
+	}
+	defer res.Close()
+
+	if res.IsError() {
+		errRes, err := res.Error()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return
+		}
+		printBQLError(errRes)
+		return
+	}
+
+	var js struct {
+		Steps []struct {
+			Extractor string      `json:"extractor"`
+			Value     interface{} `json:"value"`
+			Error     string      `json:"error"`
+		} `json:"steps"`
+		Result interface{} `json:"result"`
+		Error  string      `json:"error"`
+	}
+	if err := res.ReadJSON(&js); err != nil {
+		fmt.Fprintf(os.Stderr, "cannot read the response: %v\n", err)
+		return
+	}
+
+	width := 0
+	for _, s := range js.Steps {
+		if len(s.Extractor) > width {
+			width = len(s.Extractor)
+		}
+	}
+	for _, s := range js.Steps {
+		if s.Error != "" {
+			fmt.Printf("%-*v => error: %v\n", width, s.Extractor, s.Error)
+			continue
+		}
+		fmt.Printf("%-*v => ", width, s.Extractor)
+		printJSONResult(s.Value)
+	}
+	if js.Error != "" {
+		fmt.Fprintf(os.Stderr, "evaluation failed: %v\n", js.Error)
+		return
+	}
+	fmt.Print("result: ")
+	printJSONResult(js.Result)
+}
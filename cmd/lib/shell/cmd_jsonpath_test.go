@@ -0,0 +1,53 @@
+package shell
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestJSONPathCommand(t *testing.T) {
+	Convey("Given a JSON Path command struct", t, func() {
+		cmd := jsonPathCmd{}
+
+		Convey("When input a path and a document", func() {
+			status, err := cmd.Input(`\path 'a.b[0]["c"]' {"a": {"b": [{"c": 1}]}}`)
+
+			Convey("Then the command should be prepared", func() {
+				So(err, ShouldBeNil)
+				So(status, ShouldEqual, preparedCMD)
+				So(cmd.path, ShouldEqual, `a.b[0]["c"]`)
+				So(cmd.document, ShouldResemble, map[string]interface{}{
+					"a": map[string]interface{}{
+						"b": []interface{}{map[string]interface{}{"c": 1.0}},
+					},
+				})
+			})
+		})
+
+		Convey("When input a path having an escaped single quote", func() {
+			status, err := cmd.Input(`\path '["it''s"]' {"it's": 1}`)
+
+			Convey("Then the quote should be unescaped", func() {
+				So(err, ShouldBeNil)
+				So(status, ShouldEqual, preparedCMD)
+				So(cmd.path, ShouldEqual, `["it's"]`)
+			})
+		})
+
+		for _, in := range []string{
+			`\path a.b {"a": 1}`,
+			`\path 'a.b {"a": 1}`,
+			`\path 'a.b'`,
+			`\path 'a.b' {"a": `,
+		} {
+			Convey("When input an invalid command "+in, func() {
+				status, err := cmd.Input(in)
+
+				Convey("Then it should fail", func() {
+					So(err, ShouldNotBeNil)
+					So(status, ShouldEqual, invalidCMD)
+				})
+			})
+		}
+	})
+}
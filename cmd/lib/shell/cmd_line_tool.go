@@ -55,6 +55,12 @@ func Launch(c *cli.Context) error {
 		for _, c := range NewFileLoadCommands() {
 			cmds = append(cmds, c)
 		}
+		for _, c := range NewJSONPathCommands() {
+			cmds = append(cmds, c)
+		}
+		for _, c := range NewMetaCommands() {
+			cmds = append(cmds, c)
+		}
 		app := SetUpCommands(cmds)
 		req, err := newRequester(c)
 		if err != nil {
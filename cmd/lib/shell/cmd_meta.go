@@ -0,0 +1,432 @@
+package shell
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/sensorbee/sensorbee.v0/client"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"gopkg.in/sensorbee/sensorbee.v0/server/response"
+)
+
+const (
+	// nodeCacheTTL is the duration for which the list of nodes fetched from
+	// the server is used for autocompletion.
+	nodeCacheTTL = 5 * time.Second
+)
+
+// shellNode is a node of the current topology fetched from the server.
+type shellNode struct {
+	nodeType core.NodeType
+	name     string
+	state    string
+}
+
+// resource returns the name of the resource of the node in the API.
+func (n *shellNode) resource() string {
+	switch n.nodeType {
+	case core.NTSource:
+		return "sources"
+	case core.NTBox:
+		return "streams"
+	default:
+		return "sinks"
+	}
+}
+
+// bqlType returns the type of the node in BQL statements.
+func (n *shellNode) bqlType() string {
+	switch n.nodeType {
+	case core.NTSource:
+		return "SOURCE"
+	case core.NTBox:
+		return "STREAM"
+	default:
+		return "SINK"
+	}
+}
+
+// nodeCache has nodes of the topology fetched most recently. It's used for
+// autocompletion of node names.
+type nodeCache struct {
+	topology  string
+	nodes     []*shellNode
+	fetchedAt time.Time
+}
+
+var (
+	currentNodes nodeCache
+)
+
+// NewMetaCommands returns meta-commands which show and manage nodes of the
+// current topology. Their names start with a backslash.
+func NewMetaCommands() []Command {
+	return []Command{
+		&nodesCmd{},
+		&nodeStatusCmd{},
+		&dropNodeCmd{},
+		&graphCmd{},
+	}
+}
+
+// argumentCompleter is implemented by commands whose arguments can be
+// completed in the shell.
+type argumentCompleter interface {
+	// completeArgument returns candidates of the argument starting with the
+	// prefix.
+	completeArgument(requester *client.Requester, prefix string) []string
+}
+
+// nodeArgument returns the name of the node given to a meta-command as its
+// only argument.
+func nodeArgument(input string) (string, error) {
+	fields := strings.Fields(input)
+	switch len(fields) {
+	case 1:
+		return "", fmt.Errorf("the name of the node is missing")
+	case 2:
+		return strings.ToLower(fields[1]), nil
+	default:
+		return "", fmt.Errorf("too many arguments: %v", strings.Join(fields[1:], " "))
+	}
+}
+
+// noArgument validates that a meta-command doesn't have arguments.
+func noArgument(input string) error {
+	if fields := strings.Fields(input); len(fields) > 1 {
+		return fmt.Errorf("the command doesn't take arguments: %v", strings.Join(fields[1:], " "))
+	}
+	return nil
+}
+
+// fetchShellNodes returns all nodes of the current topology and caches them
+// for autocompletion.
+func fetchShellNodes(requester *client.Requester) ([]*shellNode, error) {
+	if currentTopology.name == "" {
+		return nil, fmt.Errorf("no topology set")
+	}
+	var nodes []*shellNode
+	for _, resource := range []string{"sources", "streams", "sinks"} {
+		res := struct {
+			Sources []*response.Source `json:"sources"`
+			Streams []*response.Stream `json:"streams"`
+			Sinks   []*response.Sink   `json:"sinks"`
+		}{}
+		if err := getJSON(requester, topologiesHeader+"/"+currentTopology.name+"/"+resource, &res); err != nil {
+			return nil, err
+		}
+		for _, s := range res.Sources {
+			nodes = append(nodes, &shellNode{core.NTSource, s.Name, s.State})
+		}
+		for _, s := range res.Streams {
+			nodes = append(nodes, &shellNode{core.NTBox, s.Name, s.State})
+		}
+		for _, s := range res.Sinks {
+			nodes = append(nodes, &shellNode{core.NTSink, s.Name, s.State})
+		}
+	}
+	sort.Sort(shellNodesByName(nodes))
+	currentNodes = nodeCache{
+		topology:  currentTopology.name,
+		nodes:     nodes,
+		fetchedAt: time.Now(),
+	}
+	return nodes, nil
+}
+
+// findShellNode fetches nodes from the server and returns the node having the
+// name.
+func findShellNode(requester *client.Requester, name string) (*shellNode, error) {
+	nodes, err := fetchShellNodes(requester)
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range nodes {
+		if n.name == name {
+			return n, nil
+		}
+	}
+	return nil, fmt.Errorf("node '%v' was not found in topology '%v'", name, currentTopology.name)
+}
+
+// getJSON sends a GET request and reads the JSON response into v.
+func getJSON(requester *client.Requester, uri string, v interface{}) error {
+	res, err := requester.Do(client.Get, uri, nil)
+	if err != nil {
+		return fmt.Errorf("request failed: %v", err)
+	}
+	if res.IsError() {
+		defer res.Close()
+		errRes, err := res.Error()
+		if err != nil {
+			return err
+		}
+		return fmt.Errorf("request failed: %v: %v", errRes.Code, errRes.Message)
+	}
+	if err := res.ReadJSON(v); err != nil { // ReadJSON closes the body
+		return fmt.Errorf("cannot read a response: %v", err)
+	}
+	return nil
+}
+
+// completeNodeName returns names of nodes starting with the prefix. Nodes
+// are fetched from the server when the cache is stale.
+func completeNodeName(requester *client.Requester, prefix string) []string {
+	nodes := currentNodes.nodes
+	if currentNodes.topology != currentTopology.name || time.Since(currentNodes.fetchedAt) > nodeCacheTTL {
+		ns, err := fetchShellNodes(requester)
+		if err != nil {
+			return nil
+		}
+		nodes = ns
+	}
+	var c []string
+	for _, n := range nodes {
+		if strings.HasPrefix(n.name, strings.ToLower(prefix)) {
+			c = append(c, n.name)
+		}
+	}
+	return c
+}
+
+type shellNodesByName []*shellNode
+
+func (s shellNodesByName) Len() int           { return len(s) }
+func (s shellNodesByName) Less(i, j int) bool { return s[i].name < s[j].name }
+func (s shellNodesByName) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+
+// nodesCmd shows nodes of the current topology.
+type nodesCmd struct {
+}
+
+func (n *nodesCmd) Init() error {
+	return nil
+}
+
+func (n *nodesCmd) Name() []string {
+	return []string{`\nodes`}
+}
+
+func (n *nodesCmd) Input(input string) (cmdInputStatusType, error) {
+	if err := noArgument(input); err != nil {
+		return invalidCMD, err
+	}
+	return preparedCMD, nil
+}
+
+func (n *nodesCmd) Eval(requester *client.Requester) {
+	nodes, err := fetchShellNodes(requester)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "cannot get nodes: %v\n", err)
+		return
+	}
+	for _, n := range nodes {
+		fmt.Printf("%v\t%v\t%v\n", n.nodeType, n.name, n.state)
+	}
+}
+
+// nodeStatusCmd shows the detailed status of a node.
+type nodeStatusCmd struct {
+	name string
+}
+
+func (s *nodeStatusCmd) Init() error {
+	return nil
+}
+
+func (s *nodeStatusCmd) Name() []string {
+	return []string{`\status`}
+}
+
+func (s *nodeStatusCmd) Input(input string) (cmdInputStatusType, error) {
+	name, err := nodeArgument(input)
+	if err != nil {
+		return invalidCMD, err
+	}
+	s.name = name
+	return preparedCMD, nil
+}
+
+func (s *nodeStatusCmd) Eval(requester *client.Requester) {
+	n, err := findShellNode(requester, s.name)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "cannot get the status: %v\n", err)
+		return
+	}
+	var res map[string]interface{}
+	if err := getJSON(requester, topologiesHeader+"/"+currentTopology.name+"/"+n.resource()+"/"+n.name, &res); err != nil {
+		fmt.Fprintf(os.Stderr, "cannot get the status: %v\n", err)
+		return
+	}
+	delete(res, "topology")
+	js, err := json.MarshalIndent(res, "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "cannot marshal the result into a JSON: %v\n", err)
+		return
+	}
+	fmt.Printf("%s\n", js)
+}
+
+func (s *nodeStatusCmd) completeArgument(requester *client.Requester, prefix string) []string {
+	return completeNodeName(requester, prefix)
+}
+
+// dropNodeCmd drops a node from the current topology.
+type dropNodeCmd struct {
+	name string
+}
+
+func (d *dropNodeCmd) Init() error {
+	return nil
+}
+
+func (d *dropNodeCmd) Name() []string {
+	return []string{`\drop`}
+}
+
+func (d *dropNodeCmd) Input(input string) (cmdInputStatusType, error) {
+	name, err := nodeArgument(input)
+	if err != nil {
+		return invalidCMD, err
+	}
+	d.name = name
+	return preparedCMD, nil
+}
+
+func (d *dropNodeCmd) Eval(requester *client.Requester) {
+	n, err := findShellNode(requester, d.name)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "cannot drop the node: %v\n", err)
+		return
+	}
+	sendBQLQueries(requester, fmt.Sprintf("DROP %v %v;", n.bqlType(), n.name))
+}
+
+func (d *dropNodeCmd) completeArgument(requester *client.Requester, prefix string) []string {
+	return completeNodeName(requester, prefix)
+}
+
+// graphCmd renders nodes of the current topology and their connections as
+// an ASCII DAG.
+type graphCmd struct {
+}
+
+func (g *graphCmd) Init() error {
+	return nil
+}
+
+func (g *graphCmd) Name() []string {
+	return []string{`\graph`}
+}
+
+func (g *graphCmd) Input(input string) (cmdInputStatusType, error) {
+	if err := noArgument(input); err != nil {
+		return invalidCMD, err
+	}
+	return preparedCMD, nil
+}
+
+func (g *graphCmd) Eval(requester *client.Requester) {
+	nodes, err := fetchShellNodes(requester)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "cannot get nodes: %v\n", err)
+		return
+	}
+
+	// inputs of streams and sinks are obtained from their status
+	inputs := map[string][]string{}
+	for _, n := range nodes {
+		if n.nodeType == core.NTSource {
+			continue
+		}
+		var res struct {
+			Stream *response.Stream `json:"stream"`
+			Sink   *response.Sink   `json:"sink"`
+		}
+		if err := getJSON(requester, topologiesHeader+"/"+currentTopology.name+"/"+n.resource()+"/"+n.name, &res); err != nil {
+			fmt.Fprintf(os.Stderr, "cannot get the status of %v: %v\n", n.name, err)
+			return
+		}
+		var status data.Map
+		if res.Stream != nil {
+			status = res.Stream.Status
+		} else if res.Sink != nil {
+			status = res.Sink.Status
+		}
+		v, err := status.Get(data.MustCompilePath("input_stats.inputs"))
+		if err != nil {
+			continue
+		}
+		m, err := data.AsMap(v)
+		if err != nil {
+			continue
+		}
+		for in := range m {
+			inputs[n.name] = append(inputs[n.name], in)
+		}
+	}
+	fmt.Print(renderGraph(nodes, inputs))
+}
+
+// renderGraph renders nodes as a tree for each node without inputs. inputs
+// has names of input nodes of each node. A node having multiple inputs
+// appears under each of them, but its outputs are only rendered at the first
+// appearance and later appearances are marked with "(*)".
+func renderGraph(nodes []*shellNode, inputs map[string][]string) string {
+	byName := make(map[string]*shellNode, len(nodes))
+	for _, n := range nodes {
+		byName[n.name] = n
+	}
+	outputs := map[string][]string{}
+	for name, ins := range inputs {
+		for _, in := range ins {
+			if _, ok := byName[in]; ok {
+				outputs[in] = append(outputs[in], name)
+			}
+		}
+	}
+	for _, outs := range outputs {
+		sort.Strings(outs)
+	}
+
+	var buf bytes.Buffer
+	rendered := map[string]bool{}
+	var render func(n *shellNode, prefix, branch, childPrefix string)
+	render = func(n *shellNode, prefix, branch, childPrefix string) {
+		fmt.Fprintf(&buf, "%v%v[%v] %v (%v)", prefix, branch, n.nodeType, n.name, n.state)
+		if rendered[n.name] {
+			fmt.Fprintln(&buf, " (*)")
+			return
+		}
+		fmt.Fprintln(&buf)
+		rendered[n.name] = true
+		outs := outputs[n.name]
+		for i, o := range outs {
+			if i == len(outs)-1 {
+				render(byName[o], prefix+childPrefix, "`-> ", "    ")
+			} else {
+				render(byName[o], prefix+childPrefix, "|-> ", "|   ")
+			}
+		}
+	}
+
+	// nodes without inputs are roots. Nodes which are still not rendered,
+	// e.g. ones in a cycle, are rendered as roots at last.
+	for _, n := range nodes {
+		if len(inputs[n.name]) == 0 {
+			render(n, "", "", "")
+		}
+	}
+	for _, n := range nodes {
+		if !rendered[n.name] {
+			render(n, "", "", "")
+		}
+	}
+	return buf.String()
+}
@@ -0,0 +1,171 @@
+package shell
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"testing"
+	"time"
+)
+
+func TestMetaCommands(t *testing.T) {
+	Convey("Given meta-commands taking a node name", t, func() {
+		status := &nodeStatusCmd{}
+		drop := &dropNodeCmd{}
+
+		Convey("When input a node name", func() {
+			s1, err1 := status.Input(`\status Box1`)
+			s2, err2 := drop.Input(`\drop sink`)
+
+			Convey("Then the commands should be prepared", func() {
+				So(err1, ShouldBeNil)
+				So(s1, ShouldEqual, preparedCMD)
+				So(status.name, ShouldEqual, "box1")
+				So(err2, ShouldBeNil)
+				So(s2, ShouldEqual, preparedCMD)
+				So(drop.name, ShouldEqual, "sink")
+			})
+		})
+
+		Convey("When input no node name", func() {
+			s, err := status.Input(`\status`)
+
+			Convey("Then the command should be invalid", func() {
+				So(err, ShouldNotBeNil)
+				So(s, ShouldEqual, invalidCMD)
+			})
+		})
+
+		Convey("When input too many arguments", func() {
+			s, err := drop.Input(`\drop a b`)
+
+			Convey("Then the command should be invalid", func() {
+				So(err, ShouldNotBeNil)
+				So(s, ShouldEqual, invalidCMD)
+			})
+		})
+	})
+
+	Convey("Given meta-commands taking no argument", t, func() {
+		for _, cmd := range []Command{&nodesCmd{}, &graphCmd{}} {
+			name := cmd.Name()[0]
+
+			Convey("When input only the name of "+name, func() {
+				s, err := cmd.Input(name)
+
+				Convey("Then the command should be prepared", func() {
+					So(err, ShouldBeNil)
+					So(s, ShouldEqual, preparedCMD)
+				})
+			})
+
+			Convey("When input an argument to "+name, func() {
+				s, err := cmd.Input(name + " a")
+
+				Convey("Then the command should be invalid", func() {
+					So(err, ShouldNotBeNil)
+					So(s, ShouldEqual, invalidCMD)
+				})
+			})
+		}
+	})
+}
+
+func TestRenderGraph(t *testing.T) {
+	Convey("Given nodes of a topology", t, func() {
+		nodes := []*shellNode{
+			{core.NTBox, "box1", "running"},
+			{core.NTBox, "box2", "running"},
+			{core.NTBox, "joined", "running"},
+			{core.NTSink, "out", "running"},
+			{core.NTSource, "src", "running"},
+			{core.NTSource, "src2", "paused"},
+		}
+		inputs := map[string][]string{
+			"box1":   {"src"},
+			"box2":   {"src"},
+			"joined": {"box1", "box2"},
+			"out":    {"joined"},
+		}
+
+		Convey("When rendering them", func() {
+			g := renderGraph(nodes, inputs)
+
+			Convey("Then each path from sources should be rendered", func() {
+				So(g, ShouldEqual, `[source] src (running)
+|-> [box] box1 (running)
+|   `+"`"+`-> [box] joined (running)
+|       `+"`"+`-> [sink] out (running)
+`+"`"+`-> [box] box2 (running)
+    `+"`"+`-> [box] joined (running) (*)
+[source] src2 (paused)
+`)
+			})
+		})
+
+		Convey("When nodes are in a cycle", func() {
+			g := renderGraph(nodes[:2], map[string][]string{
+				"box1": {"box2"},
+				"box2": {"box1"},
+			})
+
+			Convey("Then they should still be rendered", func() {
+				So(g, ShouldEqual, `[box] box1 (running)
+`+"`"+`-> [box] box2 (running)
+    `+"`"+`-> [box] box1 (running) (*)
+`)
+			})
+		})
+	})
+}
+
+func TestComplete(t *testing.T) {
+	Convey("Given an app with meta-commands and cached nodes", t, func() {
+		app := SetUpCommands(append(NewMetaCommands(), NewJSONPathCommands()...))
+		currentTopology.name = "test"
+		currentNodes = nodeCache{
+			topology: "test",
+			nodes: []*shellNode{
+				{core.NTSource, "src", "running"},
+				{core.NTBox, "stream", "running"},
+				{core.NTSink, "out", "running"},
+			},
+			fetchedAt: time.Now(),
+		}
+		Reset(func() {
+			currentTopology.name = ""
+			currentNodes = nodeCache{}
+		})
+
+		Convey("When completing the name of a command", func() {
+			c := app.complete(`\`)
+
+			Convey("Then all meta-commands should be returned", func() {
+				So(c, ShouldResemble, []string{`\drop`, `\graph`, `\nodes`, `\path`, `\status`})
+			})
+		})
+
+		Convey("When completing the name of a node", func() {
+			c := app.complete(`\status s`)
+
+			Convey("Then nodes having the prefix should be returned", func() {
+				So(c, ShouldResemble, []string{`\status src`, `\status stream`})
+			})
+		})
+
+		Convey("When completing the argument after a space", func() {
+			c := app.complete(`\drop `)
+
+			Convey("Then all nodes should be returned", func() {
+				So(c, ShouldResemble, []string{`\drop src`, `\drop stream`, `\drop out`})
+			})
+		})
+
+		Convey("When completing an argument of a command not supporting it", func() {
+			c := app.complete(`\path a`)
+
+			Convey("Then nothing should be returned", func() {
+				So(c, ShouldBeEmpty)
+			})
+		})
+	})
+}
@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"gopkg.in/sensorbee/sensorbee.v0/client"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"gopkg.in/sensorbee/sensorbee.v0/server/response"
 	"os"
 	"os/signal"
 	"strings"
@@ -72,7 +74,7 @@ func (b *bqlCmd) Init() error {
 // Name returns BQL start words.
 func (b *bqlCmd) Name() []string {
 	return []string{"select", "create", "update", "insert", "pause", "resume",
-		"rewind", "drop", "save", "load", "eval"}
+		"rewind", "drop", "save", "load", "eval", "show"}
 }
 
 func (b *bqlCmd) Input(input string) (cmdInputStatusType, error) {
@@ -97,6 +99,32 @@ func (b *bqlCmd) Eval(requester *client.Requester) {
 	sendBQLQueries(requester, queries)
 }
 
+// printBQLError prints an error returned from the server on processing BQL
+// statements with its machine-readable code and the position of a parse
+// error if they're available.
+func printBQLError(e *response.Error) {
+	msg := e.Message
+	if code, err := data.AsString(e.Meta["error_code"]); err == nil {
+		msg = fmt.Sprintf("%v [%v]", msg, code)
+	}
+	if line, err := data.ToInt(e.Meta["line"]); err == nil {
+		if col, err := data.ToInt(e.Meta["column"]); err == nil {
+			msg = fmt.Sprintf("%v at line %v, column %v", msg, line, col)
+		}
+	}
+	fmt.Fprintf(os.Stderr, "request failed: %v: %v\n", e.Code, msg)
+
+	if errs, err := data.AsArray(e.Meta["parse_errors"]); err == nil {
+		for _, pe := range errs {
+			if str, err := data.AsString(pe); err == nil {
+				fmt.Fprintf(os.Stderr, "  %v\n", str)
+			}
+		}
+	} else if cause, err := data.AsString(e.Meta["error"]); err == nil {
+		fmt.Fprintf(os.Stderr, "  %v\n", cause)
+	}
+}
+
 func sendBQLQueries(requester *client.Requester, queries string) {
 	if currentTopology.name == "" {
 		fmt.Fprintln(os.Stderr, "cannot make request: no topology set")
@@ -120,9 +148,7 @@ func sendBQLQueries(requester *client.Requester, queries string) {
 			return
 		}
 
-		// TODO: enhance error message
-		fmt.Fprintf(os.Stderr, "request failed: %v: %v: %v\n", errRes.Code,
-			errRes.Message, errRes.Meta)
+		printBQLError(errRes)
 		return
 	}
 
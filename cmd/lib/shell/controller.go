@@ -9,6 +9,7 @@ import (
 	"os"
 	"path"
 	"runtime"
+	"sort"
 	"strings"
 )
 
@@ -164,10 +165,7 @@ func (a *App) Run(requester *client.Requester) {
 	line := liner.NewLiner()
 	defer line.Close()
 
-	line.SetCompleter(func(line string) (c []string) {
-		// set auto complete command, if need
-		return
-	})
+	line.SetCompleter(a.complete)
 
 	if f, err := os.Open(a.historyFn); err == nil {
 		line.ReadHistory(f)
@@ -185,6 +183,48 @@ func (a *App) Run(requester *client.Requester) {
 	}
 }
 
+// complete returns candidates of the line. Names of commands are completed
+// at the beginning of the line, and arguments are completed when the command
+// supports it, e.g. names of nodes given to \status.
+func (a *App) complete(line string) (c []string) {
+	fields := strings.Fields(line)
+	switch {
+	case len(fields) == 0:
+		return
+	case len(fields) == 1 && !strings.HasSuffix(line, " "):
+		prefix := strings.ToLower(fields[0])
+		for name := range a.commandMap {
+			if strings.HasPrefix(name, prefix) {
+				c = append(c, name)
+			}
+		}
+		sort.Strings(c)
+		return
+	}
+
+	cmd, ok := a.commandMap[strings.ToLower(fields[0])]
+	if !ok {
+		return
+	}
+	ac, ok := cmd.(argumentCompleter)
+	if !ok {
+		return
+	}
+	prefix := ""
+	if !strings.HasSuffix(line, " ") {
+		if len(fields) > 2 {
+			return
+		}
+		prefix = fields[1]
+	} else if len(fields) > 1 {
+		return
+	}
+	for _, arg := range ac.completeArgument(a.requester, prefix) {
+		c = append(c, fields[0]+" "+arg)
+	}
+	return
+}
+
 const (
 	promptLineStart = "> "
 )
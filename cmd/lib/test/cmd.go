@@ -0,0 +1,126 @@
+/*
+Package test implements sensorbee test command. This command runs topologies
+against fixture input files and compares tuples emitted from them with golden
+files so that topologies can be tested in CI. See Spec for the format of a
+test.
+*/
+package test
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/sirupsen/logrus"
+	"gopkg.in/urfave/cli.v1"
+)
+
+// SetUp sets up a command for testing topologies.
+func SetUp() cli.Command {
+	cmd := cli.Command{
+		Name:      "test",
+		Usage:     "test topologies with golden files",
+		ArgsUsage: "SPEC_FILE...",
+		Description: "test command runs topologies defined by the YAML spec files against " +
+			"fixture input files and compares tuples emitted from them with golden files",
+		Action: Run,
+	}
+
+	cmd.Flags = []cli.Flag{
+		cli.BoolFlag{
+			Name:  "update, u",
+			Usage: "overwrite golden files with the tuples emitted from topologies",
+		},
+		cli.StringFlag{
+			Name:  "log-level",
+			Value: "warn",
+			Usage: "log level of topologies (debug, info, warn, error)",
+		},
+	}
+	return cmd
+}
+
+// Run runs "test" command.
+func Run(c *cli.Context) error {
+	if len(c.Args()) == 0 {
+		cli.ShowSubcommandHelp(c)
+		os.Exit(1)
+	}
+
+	logLevel, err := logrus.ParseLevel(c.String("log-level"))
+	if err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+	logger := logrus.New()
+	logger.Out = os.Stderr
+	logger.Level = logLevel
+
+	failed := 0
+	for _, path := range c.Args() {
+		if !runTest(os.Stdout, logger, path, c.Bool("update")) {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return cli.NewExitError(fmt.Sprintf("%v of %v tests failed", failed, len(c.Args())), 1)
+	}
+	return nil
+}
+
+// runTest runs a test of the spec file and writes the result to w. It returns
+// false when the test fails. When update is true, golden files are
+// overwritten instead of being compared.
+func runTest(w io.Writer, logger *logrus.Logger, path string, update bool) bool {
+	fail := func(format string, args ...interface{}) bool {
+		fmt.Fprintf(w, "FAIL %v\n    "+format+"\n", append([]interface{}{path}, args...)...)
+		return false
+	}
+
+	spec, err := loadSpec(path)
+	if err != nil {
+		return fail("%v", err)
+	}
+	outputs, err := runSpec(spec, logger)
+	if err != nil {
+		return fail("%v", err)
+	}
+
+	names := make([]string, 0, len(spec.Outputs))
+	for n := range spec.Outputs {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	if update {
+		for _, n := range names {
+			if err := writeGolden(spec.Outputs[n].Golden, outputs[n]); err != nil {
+				return fail("cannot write the golden file of %v: %v", n, err)
+			}
+		}
+		fmt.Fprintf(w, "updated %v\n", path)
+		return true
+	}
+
+	c := newComparer(spec)
+	var diffs []string
+	for _, n := range names {
+		out := spec.Outputs[n]
+		expected, err := readGolden(out.Golden)
+		if err != nil {
+			return fail("%v", err)
+		}
+		if ds := c.compareOutput(out, expected, outputs[n]); len(ds) > 0 {
+			diffs = append(diffs, formatDiffs(n, ds))
+		}
+	}
+	if len(diffs) > 0 {
+		fmt.Fprintf(w, "FAIL %v\n", path)
+		for _, d := range diffs {
+			fmt.Fprintln(w, d)
+		}
+		return false
+	}
+	fmt.Fprintf(w, "ok   %v\n", path)
+	return true
+}
@@ -0,0 +1,162 @@
+package test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRunTest(t *testing.T) {
+	Convey("Given a test of a topology", t, func() {
+		dir, err := ioutil.TempDir("", "sensorbee_test_cmd_test")
+		So(err, ShouldBeNil)
+		Reset(func() {
+			os.RemoveAll(dir)
+		})
+		write := func(name, body string) {
+			p := filepath.Join(dir, name)
+			So(os.MkdirAll(filepath.Dir(p), 0755), ShouldBeNil)
+			So(ioutil.WriteFile(p, []byte(body), 0644), ShouldBeNil)
+		}
+
+		// The source and the sink have types which don't exist because
+		// they're replaced.
+		write("topology.bql", `
+CREATE SOURCE sensors TYPE no_such_source;
+CREATE STREAM hot AS SELECT RSTREAM id, temp / 3.0 AS t FROM sensors [RANGE 1 TUPLES]
+    WHERE temp > 20;
+CREATE SINK alerts TYPE no_such_sink;
+INSERT INTO alerts FROM hot;
+`)
+		write("fixtures/sensors.jsonl", `{"id": 1, "temp": 25}
+{"id": 2, "temp": 10}
+{"id": 3, "temp": 30}
+`)
+		spec := filepath.Join(dir, "spec.yaml")
+		write("spec.yaml", `
+bql: topology.bql
+inputs:
+  sensors:
+    path: fixtures/sensors.jsonl
+outputs:
+  alerts:
+    golden: golden/alerts.jsonl
+  hot:
+    golden: golden/hot.jsonl
+    ordered: false
+tolerance:
+  fields:
+    t:
+      float: 0.01
+`)
+		logger := logrus.New()
+		logger.Out = ioutil.Discard
+		run := func(update bool) (string, bool) {
+			buf := bytes.NewBuffer(nil)
+			ok := runTest(buf, logger, spec, update)
+			return buf.String(), ok
+		}
+
+		Convey("When updating golden files", func() {
+			out, ok := run(true)
+			So(ok, ShouldBeTrue)
+			So(out, ShouldContainSubstring, "updated")
+
+			Convey("Then they should have emitted tuples", func() {
+				b, err := ioutil.ReadFile(filepath.Join(dir, "golden/alerts.jsonl"))
+				So(err, ShouldBeNil)
+				lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+				So(lines, ShouldHaveLength, 2)
+				So(lines[0], ShouldContainSubstring, `"data":{"id":1,"t":8.333333333333334}`)
+				So(lines[1], ShouldContainSubstring, `"data":{"id":3,"t":10}`)
+			})
+
+			Convey("Then the test should pass", func() {
+				out, ok := run(false)
+				So(ok, ShouldBeTrue)
+				So(out, ShouldStartWith, "ok")
+			})
+
+			Convey("And changing the golden files within the tolerance", func() {
+				write("golden/alerts.jsonl", `{"data":{"id":1,"t":8.33}}
+{"data":{"id":3,"t":10.001}}
+`)
+				write("golden/hot.jsonl", `{"data":{"id":3,"t":10}}
+{"data":{"id":1,"t":8.34}}
+`)
+
+				Convey("Then the test should pass", func() {
+					out, ok := run(false)
+					So(ok, ShouldBeTrue)
+					So(out, ShouldStartWith, "ok")
+				})
+			})
+
+			Convey("And changing the golden files beyond the tolerance", func() {
+				write("golden/alerts.jsonl", `{"data":{"id":1,"t":8.2}}
+{"data":{"id":3,"t":10}}
+{"data":{"id":4,"t":11}}
+`)
+
+				Convey("Then the test should fail with differences", func() {
+					out, ok := run(false)
+					So(ok, ShouldBeFalse)
+					So(out, ShouldStartWith, "FAIL")
+					So(out, ShouldContainSubstring, "alerts: tuple 0: t: expected 8.2 but got 8.333333333333334")
+					So(out, ShouldContainSubstring, "alerts: expected 3 tuples but got 2")
+					So(out, ShouldContainSubstring, `alerts: missing tuple 2: {"id":4,"t":11}`)
+					So(out, ShouldNotContainSubstring, "hot:")
+				})
+			})
+		})
+
+		Convey("When the golden file doesn't exist", func() {
+			out, ok := run(false)
+
+			Convey("Then the test should fail", func() {
+				So(ok, ShouldBeFalse)
+				So(out, ShouldContainSubstring, "cannot open the golden file")
+			})
+		})
+
+		Convey("When an input isn't created in the BQL file", func() {
+			write("spec.yaml", `
+bql: topology.bql
+inputs:
+  sensors:
+    path: fixtures/sensors.jsonl
+  others:
+    path: fixtures/sensors.jsonl
+outputs:
+  alerts:
+    golden: golden/alerts.jsonl
+`)
+			out, ok := run(true)
+
+			Convey("Then the test should fail", func() {
+				So(ok, ShouldBeFalse)
+				So(out, ShouldContainSubstring, "doesn't create source others")
+			})
+		})
+
+		Convey("When the spec has an unknown key", func() {
+			write("spec.yaml", `
+bql: topology.bql
+output:
+  alerts:
+    golden: golden/alerts.jsonl
+`)
+			_, ok := run(true)
+
+			Convey("Then the test should fail", func() {
+				So(ok, ShouldBeFalse)
+			})
+		})
+	})
+}
@@ -0,0 +1,236 @@
+package test
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// comparer compares records with tolerances.
+type comparer struct {
+	tolerance Tolerance
+	ignore    map[string]bool
+}
+
+func newComparer(spec *Spec) *comparer {
+	c := &comparer{
+		tolerance: spec.Tolerance,
+		ignore:    map[string]bool{},
+	}
+	for _, f := range spec.Ignore {
+		c.ignore[f] = true
+	}
+	return c
+}
+
+// floatTolerance returns the tolerance of numbers of the field.
+func (c *comparer) floatTolerance(field string) float64 {
+	if t := c.tolerance.Fields[field]; t != nil && t.Float != 0 {
+		return t.Float
+	}
+	return c.tolerance.Float
+}
+
+// timestampTolerance returns the tolerance of timestamps of the field. The
+// field of timestamps of tuples is "timestamp".
+func (c *comparer) timestampTolerance(field string) time.Duration {
+	if t := c.tolerance.Fields[field]; t != nil && t.Timestamp != 0 {
+		return t.Timestamp
+	}
+	return c.tolerance.Timestamp
+}
+
+// compareOutput compares tuples emitted from a node with records in the
+// golden file. It returns descriptions of differences.
+func (c *comparer) compareOutput(out *Output, expected, actual []*record) []string {
+	if !out.ordered() {
+		return c.compareUnordered(out, expected, actual)
+	}
+
+	var diffs []string
+	for i := 0; i < len(expected) && i < len(actual); i++ {
+		for _, d := range c.compareRecord(out, expected[i], actual[i]) {
+			diffs = append(diffs, fmt.Sprintf("tuple %v: %v", i, d))
+		}
+	}
+	if len(expected) != len(actual) {
+		diffs = append(diffs, fmt.Sprintf("expected %v tuples but got %v", len(expected), len(actual)))
+	}
+	for i := len(actual); i < len(expected); i++ {
+		diffs = append(diffs, fmt.Sprintf("missing tuple %v: %v", i, expected[i].Data))
+	}
+	for i := len(expected); i < len(actual); i++ {
+		diffs = append(diffs, fmt.Sprintf("unexpected tuple %v: %v", i, actual[i].Data))
+	}
+	return diffs
+}
+
+// compareUnordered compares records ignoring their order. Each expected
+// record is matched with the first actual record which hasn't been matched
+// and has no difference.
+func (c *comparer) compareUnordered(out *Output, expected, actual []*record) []string {
+	var diffs []string
+	matched := make([]bool, len(actual))
+	var missing []int
+	for i, e := range expected {
+		found := false
+		for j, a := range actual {
+			if !matched[j] && len(c.compareRecord(out, e, a)) == 0 {
+				matched[j] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, i)
+		}
+	}
+
+	if len(expected) != len(actual) {
+		diffs = append(diffs, fmt.Sprintf("expected %v tuples but got %v", len(expected), len(actual)))
+	}
+	for _, i := range missing {
+		diffs = append(diffs, fmt.Sprintf("missing tuple %v: %v", i, expected[i].Data))
+	}
+	for j, a := range actual {
+		if !matched[j] {
+			diffs = append(diffs, fmt.Sprintf("unexpected tuple %v: %v", j, a.Data))
+		}
+	}
+	return diffs
+}
+
+// compareRecord returns differences of two records.
+func (c *comparer) compareRecord(out *Output, expected, actual *record) []string {
+	var diffs []string
+	if out.CheckTimestamp {
+		e, a := time.Time(expected.Timestamp), time.Time(actual.Timestamp)
+		if !withinDuration(e, a, c.timestampTolerance("timestamp")) {
+			diffs = append(diffs, fmt.Sprintf("timestamp: expected %v but got %v",
+				expected.Timestamp, actual.Timestamp))
+		}
+	}
+	c.compareMap("", "", expected.Data, actual.Data, &diffs)
+	return diffs
+}
+
+// compareValue compares two values. path is the path of the value shown in
+// differences and field is the path without indexes of arrays, which is used
+// to look up tolerances.
+func (c *comparer) compareValue(path, field string, expected, actual data.Value, diffs *[]string) {
+	if c.ignore[field] {
+		return
+	}
+	mismatch := func() {
+		*diffs = append(*diffs, fmt.Sprintf("%v: expected %v but got %v", path, expected, actual))
+	}
+
+	et, at := expected.Type(), actual.Type()
+	switch {
+	case et == data.TypeMap && at == data.TypeMap:
+		e, _ := data.AsMap(expected)
+		a, _ := data.AsMap(actual)
+		c.compareMap(path+".", field+".", e, a, diffs)
+
+	case et == data.TypeArray && at == data.TypeArray:
+		e, _ := data.AsArray(expected)
+		a, _ := data.AsArray(actual)
+		if len(e) != len(a) {
+			*diffs = append(*diffs, fmt.Sprintf("%v: expected %v elements but got %v: %v",
+				path, len(e), len(a), actual))
+			return
+		}
+		for i := range e {
+			c.compareValue(fmt.Sprintf("%v[%v]", path, i), field, e[i], a[i], diffs)
+		}
+
+	case isNumber(et) && isNumber(at):
+		if et == data.TypeInt && at == data.TypeInt {
+			e, _ := data.AsInt(expected)
+			a, _ := data.AsInt(actual)
+			if e == a {
+				return
+			}
+		}
+		e, _ := data.ToFloat(expected)
+		a, _ := data.ToFloat(actual)
+		if math.Abs(e-a) > c.floatTolerance(field) || math.IsNaN(e-a) {
+			mismatch()
+		}
+
+	case et == data.TypeString && at == data.TypeString:
+		e, _ := data.AsString(expected)
+		a, _ := data.AsString(actual)
+		if e == a {
+			return
+		}
+		// Timestamps in data are encoded as strings in RFC3339 format.
+		te, err1 := time.Parse(time.RFC3339Nano, e)
+		ta, err2 := time.Parse(time.RFC3339Nano, a)
+		if err1 != nil || err2 != nil || !withinDuration(te, ta, c.timestampTolerance(field)) {
+			mismatch()
+		}
+
+	default:
+		if !data.Equal(expected, actual) {
+			mismatch()
+		}
+	}
+}
+
+// compareMap compares two maps. Keys are compared in sorted order so that
+// differences are reported deterministically.
+func (c *comparer) compareMap(prefix, fieldPrefix string, expected, actual data.Map, diffs *[]string) {
+	keys := make([]string, 0, len(expected)+len(actual))
+	for k := range expected {
+		keys = append(keys, k)
+	}
+	for k := range actual {
+		if _, ok := expected[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		path, field := prefix+k, fieldPrefix+k
+		if c.ignore[field] {
+			continue
+		}
+		e, eok := expected[k]
+		a, aok := actual[k]
+		switch {
+		case !aok:
+			*diffs = append(*diffs, fmt.Sprintf("%v: expected %v but it's missing", path, e))
+		case !eok:
+			*diffs = append(*diffs, fmt.Sprintf("%v: unexpected field having %v", path, a))
+		default:
+			c.compareValue(path, field, e, a, diffs)
+		}
+	}
+}
+
+func isNumber(t data.TypeID) bool {
+	return t == data.TypeInt || t == data.TypeFloat
+}
+
+func withinDuration(expected, actual time.Time, d time.Duration) bool {
+	diff := expected.Sub(actual)
+	if diff < 0 {
+		diff = -diff
+	}
+	return diff <= d
+}
+
+// formatDiffs formats differences of an output.
+func formatDiffs(name string, diffs []string) string {
+	lines := make([]string, len(diffs))
+	for i, d := range diffs {
+		lines[i] = fmt.Sprintf("    %v: %v", name, d)
+	}
+	return strings.Join(lines, "\n")
+}
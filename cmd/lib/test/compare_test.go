@@ -0,0 +1,115 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+func TestComparer(t *testing.T) {
+	Convey("Given a comparer with tolerances", t, func() {
+		c := newComparer(&Spec{
+			Tolerance: Tolerance{
+				Float:     0.1,
+				Timestamp: time.Second,
+				Fields: map[string]*FieldTolerance{
+					"a.b":       {Float: 1},
+					"timestamp": {Timestamp: time.Minute},
+				},
+			},
+			Ignore: []string{"id", "x.y"},
+		})
+		ts := time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC)
+		rec := func(t time.Time, m data.Map) *record {
+			return &record{Timestamp: data.Timestamp(t), Data: m}
+		}
+		ordered := &Output{}
+
+		Convey("When comparing values within tolerances", func() {
+			e := rec(ts, data.Map{
+				"f":  data.Float(1.0),
+				"i":  data.Int(1),
+				"a":  data.Array{data.Map{"b": data.Float(5)}, data.Map{"b": data.Int(7)}},
+				"t":  data.String("2016-01-01T00:00:00Z"),
+				"id": data.Int(1),
+				"x":  data.Map{"y": data.String("a"), "z": data.Null{}},
+			})
+			a := rec(ts.Add(30*time.Second), data.Map{
+				"f":  data.Float(1.05),
+				"i":  data.Float(1.01),
+				"a":  data.Array{data.Map{"b": data.Float(5.9)}, data.Map{"b": data.Int(8)}},
+				"t":  data.String("2016-01-01T00:00:00.5Z"),
+				"id": data.Int(2),
+				"x":  data.Map{"y": data.String("b"), "z": data.Null{}},
+			})
+
+			Convey("Then there should be no difference", func() {
+				So(c.compareRecord(&Output{CheckTimestamp: true}, e, a), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When comparing values beyond tolerances", func() {
+			e := rec(ts, data.Map{
+				"f": data.Float(1.0),
+				"a": data.Array{data.Map{"b": data.Float(5)}},
+				"t": data.String("2016-01-01T00:00:00Z"),
+				"s": data.String("abc"),
+				"m": data.Int(1),
+			})
+			a := rec(ts.Add(2*time.Minute), data.Map{
+				"f": data.Float(1.2),
+				"a": data.Array{data.Map{"b": data.Float(6.5)}},
+				"t": data.String("2016-01-01T00:00:02Z"),
+				"s": data.Int(1),
+				"u": data.Bool(true),
+			})
+
+			Convey("Then differences should be reported", func() {
+				So(c.compareRecord(&Output{CheckTimestamp: true}, e, a), ShouldResemble, []string{
+					`timestamp: expected "2016-01-01T00:00:00Z" but got "2016-01-01T00:02:00Z"`,
+					"a[0].b: expected 5 but got 6.5",
+					"f: expected 1 but got 1.2",
+					"m: expected 1 but it's missing",
+					`s: expected "abc" but got 1`,
+					`t: expected "2016-01-01T00:00:00Z" but got "2016-01-01T00:00:02Z"`,
+					"u: unexpected field having true",
+				})
+			})
+
+			Convey("Then timestamps shouldn't be compared unless it's enabled", func() {
+				So(c.compareRecord(ordered, e, a), ShouldHaveLength, 6)
+			})
+		})
+
+		Convey("When comparing unordered outputs", func() {
+			out := &Output{Ordered: new(bool)}
+			e := []*record{
+				rec(ts, data.Map{"v": data.Int(1)}),
+				rec(ts, data.Map{"v": data.Int(2)}),
+				rec(ts, data.Map{"v": data.Int(3)}),
+			}
+			a := []*record{
+				rec(ts, data.Map{"v": data.Int(3)}),
+				rec(ts, data.Map{"v": data.Int(4)}),
+				rec(ts, data.Map{"v": data.Int(1)}),
+			}
+
+			Convey("Then only unmatched tuples should be reported", func() {
+				So(c.compareOutput(out, e, a), ShouldResemble, []string{
+					`missing tuple 1: {"v":2}`,
+					`unexpected tuple 1: {"v":4}`,
+				})
+			})
+
+			Convey("Then the order should matter when the output is ordered", func() {
+				So(c.compareOutput(ordered, e, a), ShouldResemble, []string{
+					"tuple 0: v: expected 1 but got 3",
+					"tuple 1: v: expected 2 but got 4",
+					"tuple 2: v: expected 3 but got 1",
+				})
+			})
+		})
+	})
+}
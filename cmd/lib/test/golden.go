@@ -0,0 +1,97 @@
+package test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// record is a tuple written in a golden file.
+type record struct {
+	Timestamp data.Timestamp `json:"timestamp"`
+	Data      data.Map       `json:"data"`
+}
+
+// newRecord creates a record from a tuple. The tuple is encoded to JSON and
+// decoded again so that it can be compared with records read from a golden
+// file. For example, a timestamp in the tuple becomes a string.
+func newRecord(t *core.Tuple) (*record, error) {
+	b, err := json.Marshal(&record{
+		Timestamp: data.Timestamp(t.Timestamp),
+		Data:      t.Data,
+	})
+	if err != nil {
+		return nil, err
+	}
+	r := &record{}
+	if err := json.Unmarshal(b, r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// readGolden reads records from a golden file. Empty lines are ignored.
+func readGolden(path string) ([]*record, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open the golden file: %v", err)
+	}
+	defer f.Close()
+
+	rs := []*record{}
+	r := bufio.NewReader(f)
+	for lineNumber := 1; ; lineNumber++ {
+		line, err := r.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return nil, fmt.Errorf("cannot read the golden file %v: %v", path, err)
+		}
+		if l := bytes.TrimSpace(line); len(l) > 0 {
+			rec := &record{}
+			if err := json.Unmarshal(l, rec); err != nil {
+				return nil, fmt.Errorf("line %v of the golden file %v is invalid: %v",
+					lineNumber, path, err)
+			}
+			if rec.Data == nil {
+				rec.Data = data.Map{}
+			}
+			rs = append(rs, rec)
+		}
+		if err == io.EOF {
+			return rs, nil
+		}
+	}
+}
+
+// writeGolden writes records to a golden file. The directory of the file is
+// created if it doesn't exist.
+func writeGolden(path string, rs []*record) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	for _, r := range rs {
+		b, err := json.Marshal(r)
+		if err != nil {
+			f.Close()
+			return err
+		}
+		w.Write(b)
+		w.WriteByte('\n')
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,222 @@
+package test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gopkg.in/sensorbee/sensorbee.v0/bql"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/parser"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/udf"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// collectorSink collects tuples written to an output.
+type collectorSink struct {
+	m       sync.Mutex
+	records []*record
+	err     error
+}
+
+func (s *collectorSink) Write(ctx *core.Context, t *core.Tuple) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	r, err := newRecord(t)
+	if err != nil {
+		if s.err == nil {
+			s.err = fmt.Errorf("cannot encode a tuple: %v", err)
+		}
+		return err
+	}
+	s.records = append(s.records, r)
+	return nil
+}
+
+func (s *collectorSink) Close(ctx *core.Context) error {
+	return nil
+}
+
+func (s *collectorSink) result() ([]*record, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.records, s.err
+}
+
+// runSpec runs the topology of the spec with fixtures and returns tuples
+// emitted from each output. Keys of the returned map are the names of
+// outputs in the spec.
+func runSpec(spec *Spec, logger *logrus.Logger) (map[string][]*record, error) {
+	b, err := ioutil.ReadFile(spec.BQL)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read the BQL file: %v", err)
+	}
+	stmts, err := parser.New().ParseStmts(string(b))
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse the BQL file %v: %v", spec.BQL, err)
+	}
+
+	cc := &core.ContextConfig{
+		Logger: logger,
+	}
+	var clock *core.SimulatedClock
+	if spec.Simulate {
+		clock = core.NewSimulatedClock(time.Time{})
+		cc.Clock = clock
+	}
+	name := filepath.Base(spec.BQL)
+	name = name[:len(name)-len(filepath.Ext(name))]
+	tp, err := core.NewDefaultTopology(core.NewContext(cc), name)
+	if err != nil {
+		return nil, err
+	}
+	defer tp.Stop()
+	tb, err := bql.NewTopologyBuilder(tp)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create a new topology builder: %v", err)
+	}
+	tb.UDSStorage = udf.NewInMemoryUDSStorage()
+
+	inputs := map[string]*Input{}
+	for n, in := range spec.Inputs {
+		inputs[strings.ToLower(n)] = in
+	}
+	outputs := map[string]string{} // lower case name -> name in the spec
+	for n := range spec.Outputs {
+		outputs[strings.ToLower(n)] = n
+	}
+
+	usedInputs := map[string]bool{}
+	sinks := map[string]*collectorSink{}
+	for _, stmt := range stmts {
+		switch s := stmt.(type) {
+		case parser.CreateSourceStmt:
+			// All sources are paused until the whole topology is built so
+			// that no tuple is dropped.
+			s.Paused = parser.Yes
+			lname := strings.ToLower(string(s.Name))
+			if in, ok := inputs[lname]; ok {
+				s = fixtureSourceStmt(s.Name, in)
+				usedInputs[lname] = true
+			}
+			stmt = s
+
+		case parser.CreateSinkStmt:
+			lname := strings.ToLower(string(s.Name))
+			if _, ok := outputs[lname]; ok {
+				c := &collectorSink{}
+				if _, err := tp.AddSink(string(s.Name), c, nil); err != nil {
+					return nil, fmt.Errorf("cannot add sink %v: %v", s.Name, err)
+				}
+				sinks[lname] = c
+				continue
+			}
+		}
+		if _, err := tb.AddStmt(stmt); err != nil {
+			return nil, fmt.Errorf("cannot add a statement to the topology: %v: %v", err, stmt)
+		}
+	}
+
+	for n := range spec.Inputs {
+		if !usedInputs[strings.ToLower(n)] {
+			return nil, fmt.Errorf("the BQL file doesn't create source %v given as an input", n)
+		}
+	}
+	for lname, n := range outputs {
+		if _, ok := sinks[lname]; ok {
+			continue
+		}
+		node, err := tp.Node(n)
+		if err != nil {
+			return nil, fmt.Errorf("the BQL file doesn't create node %v given as an output", n)
+		}
+		if node.Type() == core.NTSink {
+			return nil, fmt.Errorf("sink %v given as an output must be created in the BQL file", n)
+		}
+		c := &collectorSink{}
+		sn, err := tp.AddSink(n+"_test_output", c, nil)
+		if err != nil {
+			return nil, fmt.Errorf("cannot add a sink collecting tuples from %v: %v", n, err)
+		}
+		if err := sn.Input(n, nil); err != nil {
+			return nil, fmt.Errorf("cannot collect tuples from %v: %v", n, err)
+		}
+		sinks[lname] = c
+	}
+
+	if err := runUntilSourcesStop(tp, spec.Timeout); err != nil {
+		return nil, err
+	}
+	if err := tp.Stop(); err != nil {
+		return nil, fmt.Errorf("cannot stop the topology: %v", err)
+	}
+	if clock != nil {
+		logger.WithField("simulated_time", clock.Now()).Info("The simulation has finished")
+	}
+
+	res := map[string][]*record{}
+	for lname, n := range outputs {
+		rs, err := sinks[lname].result()
+		if err != nil {
+			return nil, fmt.Errorf("cannot collect tuples from %v: %v", n, err)
+		}
+		res[n] = rs
+	}
+	return res, nil
+}
+
+// fixtureSourceStmt returns a statement creating a paused file source which
+// reads the fixture.
+func fixtureSourceStmt(name parser.StreamIdentifier, in *Input) parser.CreateSourceStmt {
+	params := []parser.SourceSinkParamAST{
+		{Key: "path", Value: data.String(in.Path)},
+	}
+	if in.Format != "" {
+		params = append(params, parser.SourceSinkParamAST{
+			Key: "format", Value: data.String(in.Format)})
+	}
+	if in.TimestampField != "" {
+		params = append(params, parser.SourceSinkParamAST{
+			Key: "timestamp_field", Value: data.String(in.TimestampField)})
+	}
+	return parser.CreateSourceStmt{
+		Paused:             parser.Yes,
+		Name:               name,
+		Type:               "file",
+		SourceSinkSpecsAST: parser.SourceSinkSpecsAST{Params: params},
+	}
+}
+
+// runUntilSourcesStop resumes all sources and waits until all of them stop.
+func runUntilSourcesStop(tp core.Topology, timeout time.Duration) error {
+	srcs := tp.Sources()
+	for name, s := range srcs {
+		if err := s.Resume(); err != nil {
+			return fmt.Errorf("cannot resume source %v: %v", name, err)
+		}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for _, s := range srcs {
+			s.State().Wait(core.TSStopped)
+		}
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-time.After(timeout):
+		var running []string
+		for name, s := range srcs {
+			if s.State().Get() != core.TSStopped {
+				running = append(running, name)
+			}
+		}
+		return fmt.Errorf("sources didn't stop within %v: %v", timeout, strings.Join(running, ", "))
+	}
+}
@@ -0,0 +1,215 @@
+package test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	defaultTimeout = time.Minute
+)
+
+// Spec is a specification of a test of a topology written in YAML:
+//
+//	bql: topology.bql
+//	simulate: true
+//	timeout: 30s
+//	inputs:
+//	  sensors:
+//	    path: fixtures/sensors.jsonl
+//	    timestamp_field: ts
+//	outputs:
+//	  alerts:
+//	    golden: golden/alerts.jsonl
+//	    ordered: false
+//	tolerance:
+//	  float: 1e-9
+//	  timestamp: 1ms
+//	  fields:
+//	    avg_temp:
+//	      float: 0.01
+//	ignore:
+//	  - id
+//
+// Keys of inputs are names of sources created in the BQL file. Each of them
+// is replaced with a file source reading the fixture. Keys of outputs are
+// names of sinks, streams, or sources. A sink created in the BQL file is
+// replaced with a sink collecting tuples, and tuples emitted from a stream or
+// a source are collected by a sink connected to it. Relative paths are
+// resolved from the directory having the spec file.
+type Spec struct {
+	// BQL is the path to the BQL file creating the topology.
+	BQL string `bql:"bql,required"`
+
+	// Simulate runs the topology in virtual time driven by timestamps of
+	// tuples emitted by sources. See core.SimulatedClock for details.
+	Simulate bool
+
+	// Timeout is the maximum time to wait for all sources to stop. The
+	// default value is 1m.
+	Timeout time.Duration
+
+	Inputs  map[string]*Input
+	Outputs map[string]*Output `bql:",required"`
+
+	Tolerance Tolerance
+
+	// Ignore has paths of fields which aren't compared. See Tolerance for
+	// the format of paths.
+	Ignore []string
+}
+
+// Input is a fixture of a source.
+type Input struct {
+	// Path is the path to the fixture file.
+	Path string `bql:",required"`
+
+	// Format and TimestampField are passed to the file source as "format"
+	// and "timestamp_field" parameters.
+	Format         string
+	TimestampField string
+}
+
+// Output is a golden file of tuples emitted from a node.
+type Output struct {
+	// Golden is the path to the golden file. Each line of the file is a
+	// JSON object having "timestamp" and "data" of a tuple.
+	Golden string `bql:",required"`
+
+	// Ordered is false when tuples can be emitted in any order. The default
+	// value is true.
+	Ordered *bool
+
+	// CheckTimestamp compares timestamps of tuples in addition to their
+	// data. It's mostly used with simulation because timestamps of tuples
+	// are the wall-clock time otherwise.
+	CheckTimestamp bool
+}
+
+// Tolerance has tolerances used when comparing values. Fields has tolerances
+// of specific fields, which are used instead of the default ones when they're
+// non-zero. A path of a field is keys joined with "." such as "a.b" and it
+// matches all elements of arrays, e.g. "a.b" matches both a[0].b and a[1].b.
+type Tolerance struct {
+	// Float is the maximum absolute difference of numbers.
+	Float float64
+
+	// Timestamp is the maximum difference of timestamps of tuples and
+	// strings in RFC3339 format.
+	Timestamp time.Duration
+
+	Fields map[string]*FieldTolerance
+}
+
+// FieldTolerance has tolerances of a field.
+type FieldTolerance struct {
+	Float     float64
+	Timestamp time.Duration
+}
+
+// loadSpec reads a spec from the file. Paths in the spec are converted to
+// be relative to the current directory.
+func loadSpec(path string) (*Spec, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read the spec file %v: %v", path, err)
+	}
+	var yml map[string]interface{}
+	if err := yaml.Unmarshal(b, &yml); err != nil {
+		return nil, fmt.Errorf("cannot parse the spec file %v: %v", path, err)
+	}
+	m, err := data.NewMap(yml)
+	if err != nil {
+		return nil, fmt.Errorf("the spec file %v has invalid values: %v", path, err)
+	}
+
+	spec := &Spec{}
+	dec := data.NewDecoder(&data.DecoderConfig{ErrorUnused: true})
+	if err := dec.Decode(m, spec); err != nil {
+		return nil, fmt.Errorf("the spec file %v has invalid values: %v", path, err)
+	}
+	if err := spec.validate(); err != nil {
+		return nil, fmt.Errorf("the spec file %v has invalid values: %v", path, err)
+	}
+
+	dir := filepath.Dir(path)
+	resolve := func(p string) string {
+		if filepath.IsAbs(p) {
+			return p
+		}
+		return filepath.Join(dir, p)
+	}
+	spec.BQL = resolve(spec.BQL)
+	for _, in := range spec.Inputs {
+		in.Path = resolve(in.Path)
+	}
+	for _, out := range spec.Outputs {
+		out.Golden = resolve(out.Golden)
+	}
+	return spec, nil
+}
+
+func (s *Spec) validate() error {
+	if s.Timeout < 0 {
+		return fmt.Errorf("timeout must not be negative: %v", s.Timeout)
+	} else if s.Timeout == 0 {
+		s.Timeout = defaultTimeout
+	}
+	if len(s.Outputs) == 0 {
+		return fmt.Errorf("at least one output must be given")
+	}
+
+	// Names of nodes are case-insensitive.
+	checkDuplicates := func(kind string, names []string) error {
+		seen := map[string]bool{}
+		for _, n := range names {
+			l := strings.ToLower(n)
+			if seen[l] {
+				return fmt.Errorf("%v %v is given more than once", kind, n)
+			}
+			seen[l] = true
+		}
+		return nil
+	}
+	var names []string
+	for n, in := range s.Inputs {
+		if in == nil {
+			return fmt.Errorf("input %v doesn't have a path", n)
+		}
+		names = append(names, n)
+	}
+	if err := checkDuplicates("input", names); err != nil {
+		return err
+	}
+	names = nil
+	for n, out := range s.Outputs {
+		if out == nil {
+			return fmt.Errorf("output %v doesn't have a golden file", n)
+		}
+		names = append(names, n)
+	}
+	if err := checkDuplicates("output", names); err != nil {
+		return err
+	}
+
+	if s.Tolerance.Float < 0 || s.Tolerance.Timestamp < 0 {
+		return fmt.Errorf("tolerance must not be negative")
+	}
+	for f, t := range s.Tolerance.Fields {
+		if t == nil || t.Float < 0 || t.Timestamp < 0 {
+			return fmt.Errorf("tolerance of field %v must not be negative or empty", f)
+		}
+	}
+	return nil
+}
+
+// ordered returns whether the order of tuples of the output is compared.
+func (o *Output) ordered() bool {
+	return o.Ordered == nil || *o.Ordered
+}
@@ -0,0 +1,103 @@
+package topology
+
+import (
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/client"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/urfave/cli.v1"
+	"io/ioutil"
+	"path"
+)
+
+func setUpApply() cli.Command {
+	return cli.Command{
+		Name:  "apply",
+		Usage: "make a topology have nodes defined in a BQL file",
+		Description: "sensorbee topology apply <topology_name> -f <file> compares nodes defined in the file with the running topology " +
+			"and only executes necessary CREATE, DROP, and UPDATE statements. Nodes not defined in the file are dropped with --prune flag. " +
+			"--dry-run flag only shows the plan",
+		Action: actionWrapper(runApply),
+		Flags: append([]cli.Flag{
+			cli.StringFlag{
+				Name:  "file, f",
+				Usage: "a BQL file defining the topology",
+			},
+			cli.BoolFlag{
+				Name:  "prune",
+				Usage: "drop nodes and states not defined in the file",
+			},
+			cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "show the plan without executing it",
+			},
+		}, commonFlags...),
+	}
+}
+
+// applyOperation is an operation returned from the server.
+type applyOperation struct {
+	Action    string `json:"action"`
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Statement string `json:"statement"`
+	Reason    string `json:"reason"`
+}
+
+func runApply(c *cli.Context) error {
+	if err := validateFlags(c); err != nil {
+		return err
+	}
+
+	args := c.Args()
+	switch l := len(args); l {
+	case 1:
+		// ok
+	case 0:
+		return fmt.Errorf("topology_name is missing")
+	default:
+		return fmt.Errorf("too many command line arguments")
+	}
+	name := args[0]
+	if err := core.ValidateSymbol(name); err != nil {
+		return fmt.Errorf("The name of the topology is invalid: %v", err)
+	}
+
+	file := c.String("file")
+	if file == "" {
+		return fmt.Errorf("--file flag is required")
+	}
+	queries, err := ioutil.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("Cannot read the BQL file: %v", err)
+	}
+
+	dryRun := c.Bool("dry-run")
+	r, err := do(c, client.Post, path.Join("topologies", name, "apply"), map[string]interface{}{
+		"queries": string(queries),
+		"prune":   c.Bool("prune"),
+		"dry_run": dryRun,
+	}, "Cannot apply the BQL file")
+	if err != nil {
+		return err
+	}
+	res := struct {
+		Operations []*applyOperation `json:"operations"`
+	}{}
+	if err := r.ReadJSON(&res); err != nil { // ReadJSON closes the body
+		return fmt.Errorf("Cannot read a response: %v", err)
+	}
+
+	if len(res.Operations) == 0 {
+		fmt.Fprintln(c.App.Writer, "No changes")
+		return nil
+	}
+	for _, op := range res.Operations {
+		fmt.Fprintf(c.App.Writer, "%v %v %v: %v (%v)\n", op.Action, op.Kind, op.Name, op.Statement, op.Reason)
+	}
+	if dryRun {
+		fmt.Fprintf(c.App.Writer, "%v operation(s) planned but not executed (dry run)\n", len(res.Operations))
+	} else {
+		fmt.Fprintf(c.App.Writer, "%v operation(s) executed\n", len(res.Operations))
+	}
+	return nil
+}
@@ -24,6 +24,12 @@ func SetUp() cli.Command {
 			setUpCreate(),
 			setUpList(),
 			setUpDrop(),
+			setUpNodes(),
+			setUpPause(),
+			setUpResume(),
+			setUpDropNodes(),
+			setUpApply(),
+			setUpLogs(),
 		},
 	}
 	return cmd
@@ -4,8 +4,13 @@ import (
 	"bytes"
 	"io/ioutil"
 	"testing"
+	"time"
 
 	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/client"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"gopkg.in/sensorbee/sensorbee.v0/server"
+	"gopkg.in/sensorbee/sensorbee.v0/server/config"
 	"gopkg.in/sensorbee/sensorbee.v0/server/testutil"
 	"gopkg.in/urfave/cli.v1"
 )
@@ -241,3 +246,81 @@ func TestTopologyDropCommandValidation(t *testing.T) {
 		}
 	})
 }
+
+func TestTopologyLogsCommand(t *testing.T) {
+	testMode = true
+	testutil.TestAPIWithRealHTTPServer = true
+	conf, err := config.New(data.Map{
+		"logging": data.Map{
+			"log_dropped_tuples": data.True,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := testutil.NewServer(server.WithConfig(conf))
+	defer s.Close()
+
+	Convey("Given a topology having a box failing on every tuple", t, func() {
+		_, err := newApp(s.URL()).run("create", "test_topology")
+		So(err, ShouldBeNil)
+		Reset(func() {
+			newApp(s.URL()).run("drop", "test_topology")
+		})
+
+		r, err := client.NewRequester(s.URL(), "v1")
+		So(err, ShouldBeNil)
+		res, err := r.Do(client.Post, "topologies/test_topology/queries", map[string]interface{}{
+			"queries": `CREATE SOURCE src TYPE node_statuses WITH interval=0.01;
+				CREATE STREAM failing_box AS SELECT RSTREAM node_name + 1 AS x FROM src [RANGE 1 TUPLES];`,
+		})
+		So(err, ShouldBeNil)
+		So(res.IsError(), ShouldBeFalse)
+		So(res.Close(), ShouldBeNil)
+
+		Convey("When getting logs of the box", func() {
+			var out string
+			for i := 0; i < 100; i++ {
+				out, err = newApp(s.URL()).run("logs", "--node", "failing_box", "test_topology")
+				if err != nil || out != "" {
+					break
+				}
+				time.Sleep(10 * time.Millisecond)
+			}
+
+			Convey("Then it should show entries of dropped tuples", func() {
+				So(err, ShouldBeNil)
+				So(testExitCode, ShouldEqual, 0)
+				So(out, ShouldContainSubstring, "box failing_box: A tuple was dropped from the topology")
+				So(out, ShouldContainSubstring, "event_type=")
+			})
+		})
+
+		Convey("When getting logs of a nonexistent node", func() {
+			out, err := newApp(s.URL()).run("logs", "-n", "no_such_node", "test_topology")
+
+			Convey("Then it should show nothing", func() {
+				So(err, ShouldBeNil)
+				So(out, ShouldBeBlank)
+			})
+		})
+
+		Convey("When getting logs of a nonexistent topology", func() {
+			_, err := newApp(s.URL()).run("logs", "no_such_topology")
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+				So(testExitCode, ShouldNotEqual, 0)
+			})
+		})
+
+		Convey("When getting logs with an invalid node name", func() {
+			_, err := newApp(s.URL()).run("logs", "--node", "invalid-name", "test_topology")
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+				So(testExitCode, ShouldNotEqual, 0)
+			})
+		})
+	})
+}
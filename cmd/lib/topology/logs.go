@@ -0,0 +1,127 @@
+package topology
+
+import (
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/client"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/urfave/cli.v1"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// logsFollowWait is how long the server waits for new log entries in a
+// request while following the log.
+const logsFollowWait = 30 * time.Second
+
+func setUpLogs() cli.Command {
+	return cli.Command{
+		Name:        "logs",
+		Usage:       "show log entries of a topology",
+		Description: "sensorbee topology logs <topology_name> shows log entries written by the topology and its nodes. Entries can be filtered by --node flag. With --follow flag, it keeps showing new entries until it's interrupted",
+		Action:      actionWrapper(runLogs),
+		Flags: append([]cli.Flag{
+			cli.StringFlag{
+				Name:  "node, n",
+				Usage: "only show entries written by the node",
+			},
+			cli.BoolFlag{
+				Name:  "follow, f",
+				Usage: "keep showing new entries",
+			},
+		}, commonFlags...),
+	}
+}
+
+// logEntry is a log entry returned from the server.
+type logEntry struct {
+	Time     time.Time              `json:"time"`
+	Level    string                 `json:"level"`
+	Message  string                 `json:"message"`
+	NodeType string                 `json:"node_type"`
+	NodeName string                 `json:"node_name"`
+	Fields   map[string]interface{} `json:"fields"`
+}
+
+func (e *logEntry) String() string {
+	node := ""
+	if e.NodeName != "" {
+		node = fmt.Sprintf(" %v %v:", e.NodeType, e.NodeName)
+	}
+
+	keys := make([]string, 0, len(e.Fields))
+	for k := range e.Fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	fields := make([]string, 0, len(keys))
+	for _, k := range keys {
+		fields = append(fields, fmt.Sprintf(" %v=%v", k, e.Fields[k]))
+	}
+	return fmt.Sprintf("%v %-5v%v %v%v", e.Time.Local().Format(time.RFC3339),
+		strings.ToUpper(e.Level), node, e.Message, strings.Join(fields, ""))
+}
+
+func runLogs(c *cli.Context) error {
+	if err := validateFlags(c); err != nil {
+		return err
+	}
+
+	args := c.Args()
+	switch l := len(args); l {
+	case 1:
+		// ok
+	case 0:
+		return fmt.Errorf("topology_name is missing")
+	default:
+		return fmt.Errorf("too many command line arguments")
+	}
+	topology := args[0]
+	if err := core.ValidateSymbol(topology); err != nil {
+		return fmt.Errorf("The name of the topology is invalid: %v", err)
+	}
+	node := c.String("node")
+	if node != "" {
+		if err := core.ValidateSymbol(node); err != nil {
+			return fmt.Errorf("--node flag has an invalid value: %v", err)
+		}
+	}
+	follow := c.Bool("follow")
+
+	var since int64
+	for {
+		q := url.Values{}
+		q.Set("since", fmt.Sprint(since))
+		if node != "" {
+			q.Set("node", node)
+		}
+		if follow {
+			q.Set("wait", logsFollowWait.String())
+		}
+		r, err := do(c, client.Get, path.Join("topologies", topology, "logs")+"?"+q.Encode(),
+			nil, "Cannot get log entries")
+		if err != nil {
+			return err
+		}
+		res := struct {
+			Seq     int64       `json:"seq"`
+			Entries []*logEntry `json:"entries"`
+		}{}
+		if err := r.ReadJSON(&res); err != nil { // ReadJSON closes the body
+			return fmt.Errorf("Cannot read a response: %v", err)
+		}
+		for _, e := range res.Entries {
+			fmt.Fprintln(c.App.Writer, e)
+		}
+		if !follow {
+			return nil
+		}
+		if res.Seq < since {
+			// The topology was dropped and created again.
+			res.Seq = 0
+		}
+		since = res.Seq
+	}
+}
@@ -0,0 +1,228 @@
+package topology
+
+import (
+	"bytes"
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/client"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/server/response"
+	"gopkg.in/urfave/cli.v1"
+	"net/url"
+	"path"
+	"strings"
+)
+
+var (
+	labelsFlag = cli.StringFlag{
+		Name:  "labels, l",
+		Usage: "a label selector such as 'ingest,!v1' to select nodes",
+	}
+)
+
+func nodesFlags() []cli.Flag {
+	return append([]cli.Flag{labelsFlag}, commonFlags...)
+}
+
+func setUpNodes() cli.Command {
+	return cli.Command{
+		Name:        "nodes",
+		Aliases:     []string{"n"},
+		Usage:       "get a list of nodes in a topology",
+		Description: "sensorbee topology nodes <topology_name> shows nodes in the topology. Nodes can be filtered by --labels flag",
+		Action:      actionWrapper(runNodes),
+		Flags:       nodesFlags(),
+	}
+}
+
+func setUpPause() cli.Command {
+	return cli.Command{
+		Name:        "pause",
+		Usage:       "pause sources in a topology",
+		Description: "sensorbee topology pause <topology_name> --labels <selector> pauses all sources matching the selector",
+		Action:      actionWrapper(runPause),
+		Flags:       nodesFlags(),
+	}
+}
+
+func setUpResume() cli.Command {
+	return cli.Command{
+		Name:        "resume",
+		Usage:       "resume sources in a topology",
+		Description: "sensorbee topology resume <topology_name> --labels <selector> resumes all sources matching the selector",
+		Action:      actionWrapper(runResume),
+		Flags:       nodesFlags(),
+	}
+}
+
+func setUpDropNodes() cli.Command {
+	return cli.Command{
+		Name:        "drop-nodes",
+		Usage:       "drop nodes in a topology",
+		Description: "sensorbee topology drop-nodes <topology_name> --labels <selector> drops all nodes matching the selector. --labels flag is required",
+		Action:      actionWrapper(runDropNodes),
+		Flags:       nodesFlags(),
+	}
+}
+
+// node is a node returned from the server.
+type node struct {
+	nodeType core.NodeType
+	name     string
+	state    string
+	labels   []string
+}
+
+// topologyArg validates flags and returns the name of the topology given as
+// a command line argument and the label selector.
+func topologyArg(c *cli.Context) (string, string, error) {
+	if err := validateFlags(c); err != nil {
+		return "", "", err
+	}
+
+	args := c.Args()
+	switch l := len(args); l {
+	case 1:
+		// ok
+	case 0:
+		return "", "", fmt.Errorf("topology_name is missing")
+	default:
+		return "", "", fmt.Errorf("too many command line arguments")
+	}
+
+	name := args[0]
+	if err := core.ValidateSymbol(name); err != nil {
+		return "", "", fmt.Errorf("The name of the topology is invalid: %v", err)
+	}
+	sel := c.String("labels")
+	if _, err := core.ParseLabelSelector(sel); err != nil {
+		return "", "", fmt.Errorf("--labels flag has an invalid value: %v", err)
+	}
+	return name, sel, nil
+}
+
+// fetchNodes returns nodes of the given types matching the label selector.
+func fetchNodes(c *cli.Context, topology, sel string, types ...core.NodeType) ([]*node, error) {
+	var nodes []*node
+	for _, t := range types {
+		var (
+			resource string
+			res      = struct {
+				Sources []*response.Source `json:"sources"`
+				Streams []*response.Stream `json:"streams"`
+				Sinks   []*response.Sink   `json:"sinks"`
+			}{}
+		)
+		switch t {
+		case core.NTSource:
+			resource = "sources"
+		case core.NTBox:
+			resource = "streams"
+		case core.NTSink:
+			resource = "sinks"
+		}
+
+		p := path.Join("topologies", topology, resource) + "?labels=" + url.QueryEscape(sel)
+		r, err := do(c, client.Get, p, nil, fmt.Sprintf("Cannot get a list of %v", resource))
+		if err != nil {
+			return nil, err
+		}
+		if err := r.ReadJSON(&res); err != nil { // ReadJSON closes the body
+			return nil, fmt.Errorf("Cannot read a response: %v", err)
+		}
+		for _, s := range res.Sources {
+			nodes = append(nodes, &node{core.NTSource, s.Name, s.State, s.Labels})
+		}
+		for _, s := range res.Streams {
+			nodes = append(nodes, &node{core.NTBox, s.Name, s.State, s.Labels})
+		}
+		for _, s := range res.Sinks {
+			nodes = append(nodes, &node{core.NTSink, s.Name, s.State, s.Labels})
+		}
+	}
+	return nodes, nil
+}
+
+// sendQueries sends BQL statements to the topology.
+func sendQueries(c *cli.Context, topology, queries string) error {
+	res, err := do(c, client.Post, path.Join("topologies", topology, "queries"), map[string]interface{}{
+		"queries": queries,
+	}, "Cannot execute queries")
+	if err != nil {
+		return err
+	}
+	return res.Close()
+}
+
+func runNodes(c *cli.Context) error {
+	topology, sel, err := topologyArg(c)
+	if err != nil {
+		return err
+	}
+	nodes, err := fetchNodes(c, topology, sel, core.NTSource, core.NTBox, core.NTSink)
+	if err != nil {
+		return err
+	}
+	for _, n := range nodes {
+		fmt.Fprintf(c.App.Writer, "%v\t%v\t%v\t%v\n", n.nodeType, n.name, n.state, strings.Join(n.labels, ","))
+	}
+	return nil
+}
+
+func runPause(c *cli.Context) error {
+	return controlSources(c, "PAUSE")
+}
+
+func runResume(c *cli.Context) error {
+	return controlSources(c, "RESUME")
+}
+
+func controlSources(c *cli.Context, stmt string) error {
+	topology, sel, err := topologyArg(c)
+	if err != nil {
+		return err
+	}
+	nodes, err := fetchNodes(c, topology, sel, core.NTSource)
+	if err != nil {
+		return err
+	}
+	if len(nodes) == 0 {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	for _, n := range nodes {
+		fmt.Fprintf(&buf, "%v SOURCE %v;\n", stmt, n.name)
+	}
+	return sendQueries(c, topology, buf.String())
+}
+
+func runDropNodes(c *cli.Context) error {
+	topology, sel, err := topologyArg(c)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(sel) == "" {
+		// This prevents all nodes from being dropped by mistake.
+		return fmt.Errorf("--labels flag is required")
+	}
+	nodes, err := fetchNodes(c, topology, sel, core.NTSink, core.NTBox, core.NTSource)
+	if err != nil {
+		return err
+	}
+	if len(nodes) == 0 {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	for _, n := range nodes {
+		t := "SOURCE"
+		switch n.nodeType {
+		case core.NTBox:
+			t = "STREAM"
+		case core.NTSink:
+			t = "SINK"
+		}
+		fmt.Fprintf(&buf, "DROP %v %v;\n", t, n.name)
+	}
+	return sendQueries(c, topology, buf.String())
+}
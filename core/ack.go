@@ -0,0 +1,143 @@
+package core
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+// AckHandler receives the result of processing a tuple emitted by a Source
+// which requested acknowledgment by Tuple.SetAckHandler. It provides
+// at-least-once delivery: a Source only commits its position (e.g. a Kafka
+// offset or an MQTT PUBACK) when Ack is called with a nil error, and
+// reads the tuple again after restarting otherwise.
+//
+// A tuple is acknowledged when all tuples derived from it have been processed
+// by all nodes which received them. A tuple is derived from another tuple
+// when it's created by Tuple.Copy or Tuple.ShallowCopy of the original tuple,
+// which is how most Boxes including BQL streams emit tuples. Because a tuple
+// created by NewTuple doesn't inherit the acknowledgment, a Box doing so,
+// such as an aggregation over a window, acknowledges the input tuple when
+// its Process method returns.
+//
+// The error passed to Ack is the first error occurred while processing the
+// tuple, e.g. an error returned from Box.Process or Sink.Write, or the one
+// reported when the tuple was dropped from a full queue or a node without
+// any destination. Ack is called at most once for each tuple.
+//
+// Ack can be called concurrently from multiple goroutines and shouldn't
+// block long because it's called from the goroutine processing the tuple.
+// A tuple which is never acknowledged, e.g. because the topology stopped
+// while processing it, won't have its Ack called.
+type AckHandler interface {
+	Ack(ctx *Context, err error)
+}
+
+// AckFunc is an AckHandler implemented by a function.
+type AckFunc func(ctx *Context, err error)
+
+// Ack calls the function.
+func (f AckFunc) Ack(ctx *Context, err error) {
+	f(ctx, err)
+}
+
+var (
+	errAckQueueFull     = errors.New("the output queue is full")
+	errAckNoDestination = errors.New("no output destination is connected")
+)
+
+// ackToken is shared by a tuple and all tuples derived from it. It counts
+// references from nodes processing those tuples.
+type ackToken struct {
+	// refs must be the first field for 64-bit alignment.
+	refs int64
+	done int32
+
+	m   sync.Mutex
+	err error
+
+	handler AckHandler
+}
+
+// retain adds a reference to the token. It can be called on a nil token.
+func (a *ackToken) retain() {
+	if a == nil {
+		return
+	}
+	atomic.AddInt64(&a.refs, 1)
+}
+
+// fail records the error as a cause of failure without releasing a
+// reference. It can be called on a nil token.
+func (a *ackToken) fail(err error) {
+	if a == nil || err == nil {
+		return
+	}
+	a.m.Lock()
+	defer a.m.Unlock()
+	if a.err == nil {
+		a.err = err
+	}
+}
+
+// release removes a reference to the token. When the error isn't nil, it's
+// recorded as a cause of failure. The handler is called when the last
+// reference is removed. It can be called on a nil token.
+func (a *ackToken) release(ctx *Context, err error) {
+	if a == nil {
+		return
+	}
+	a.fail(err)
+	if atomic.AddInt64(&a.refs, -1) > 0 {
+		return
+	}
+
+	// A tuple derived from an acknowledged one can still be emitted later
+	// (e.g. by a Box emitting the last tuple periodically). done prevents
+	// the handler from being called again in that case.
+	if !atomic.CompareAndSwapInt32(&a.done, 0, 1) {
+		return
+	}
+	a.m.Lock()
+	err = a.err
+	a.m.Unlock()
+	a.handler.Ack(ctx, err)
+}
+
+// SetAckHandler requests acknowledgment of the tuple. It must only be called
+// by a Source on a new tuple before writing it. The handler is called once
+// the tuple and all tuples derived from it have been processed. See
+// AckHandler for details.
+func (t *Tuple) SetAckHandler(h AckHandler) {
+	t.ack = &ackToken{
+		refs:    1, // released after the source writes the tuple
+		handler: h,
+	}
+}
+
+// RetainAck delays acknowledgment of the tuple until the returned function is
+// called. A Sink which doesn't complete writing a tuple in Write, e.g. one
+// buffering tuples and flushing them later, can call this method in Write and
+// call the returned function with the result of flushing. The function must
+// be called exactly once. It does nothing when the tuple doesn't request
+// acknowledgment.
+func (t *Tuple) RetainAck() func(ctx *Context, err error) {
+	a := t.ack
+	if a == nil {
+		return func(*Context, error) {}
+	}
+	a.retain()
+	return a.release
+}
+
+// ackWriter releases the reference of the Source which wrote a tuple.
+type ackWriter struct {
+	w Writer
+}
+
+func (w *ackWriter) Write(ctx *Context, t *Tuple) error {
+	a := t.ack
+	err := w.w.Write(ctx, t)
+	a.release(ctx, err)
+	return err
+}
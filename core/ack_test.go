@@ -0,0 +1,147 @@
+package core
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+type ackResult struct {
+	i   int64
+	err error
+}
+
+// failingSink fails to write a tuple having the given value in "i" field.
+type failingSink struct {
+	i int64
+}
+
+func (s *failingSink) Write(ctx *Context, t *Tuple) error {
+	if i, _ := data.AsInt(t.Data["i"]); i == s.i {
+		return errors.New("sink write error")
+	}
+	return nil
+}
+
+func (s *failingSink) Close(ctx *Context) error {
+	return nil
+}
+
+func TestAck(t *testing.T) {
+	Convey("Given a topology with a source requesting acknowledgment", t, func() {
+		dt, err := NewDefaultTopology(NewContext(nil), "dt1")
+		So(err, ShouldBeNil)
+		Reset(func() {
+			dt.Stop()
+		})
+
+		acks := make(chan ackResult, 4)
+		var ts []*Tuple
+		for i := int64(0); i < 4; i++ {
+			i := i
+			t := NewTuple(data.Map{"i": data.Int(i)})
+			t.SetAckHandler(AckFunc(func(ctx *Context, err error) {
+				acks <- ackResult{i, err}
+			}))
+			ts = append(ts, t)
+		}
+		son, err := dt.AddSource("source", NewTupleEmitterSource(ts), &SourceConfig{
+			PausedOnStartup: true,
+		})
+		So(err, ShouldBeNil)
+
+		results := func() map[int64]error {
+			m := map[int64]error{}
+			for range ts {
+				r := <-acks
+				m[r.i] = r.err
+			}
+			return m
+		}
+
+		Convey("When tuples are processed by a box and sinks", func() {
+			bn, err := dt.AddBox("box", BoxFunc(func(ctx *Context, t *Tuple, w Writer) error {
+				switch i, _ := data.AsInt(t.Data["i"]); i {
+				case 1:
+					return nil // filtered out
+				case 2:
+					return errors.New("box process error")
+				}
+				return w.Write(ctx, t.ShallowCopy())
+			}), nil)
+			So(err, ShouldBeNil)
+			So(bn.Input("source", nil), ShouldBeNil)
+			si := NewTupleCollectorSink()
+			sin, err := dt.AddSink("sink", si, nil)
+			So(err, ShouldBeNil)
+			So(sin.Input("box", nil), ShouldBeNil)
+			sin2, err := dt.AddSink("sink2", &failingSink{i: 3}, nil)
+			So(err, ShouldBeNil)
+			So(sin2.Input("box", nil), ShouldBeNil)
+			So(son.Resume(), ShouldBeNil)
+
+			Convey("Then all tuples should be acknowledged with their results", func() {
+				m := results()
+				So(m[0], ShouldBeNil)
+				So(m[1], ShouldBeNil)
+				So(m[2], ShouldNotBeNil)
+				So(m[3], ShouldNotBeNil)
+				So(si.len(), ShouldEqual, 2)
+			})
+		})
+
+		Convey("When the source doesn't have any destination", func() {
+			So(son.Resume(), ShouldBeNil)
+
+			Convey("Then all tuples should fail", func() {
+				for _, err := range results() {
+					So(err, ShouldNotBeNil)
+				}
+			})
+		})
+	})
+
+	Convey("Given a tuple requesting acknowledgment", t, func() {
+		ctx := NewContext(nil)
+		var (
+			acked  int
+			ackErr error
+		)
+		t := NewTuple(data.Map{})
+		t.SetAckHandler(AckFunc(func(ctx *Context, err error) {
+			acked++
+			ackErr = err
+		}))
+
+		Convey("When a sink retains the acknowledgment", func() {
+			done := t.RetainAck()
+			w := &ackWriter{WriterFunc(func(ctx *Context, t *Tuple) error {
+				return nil
+			})}
+			So(w.Write(ctx, t), ShouldBeNil)
+
+			Convey("Then it shouldn't be acknowledged until released", func() {
+				So(acked, ShouldEqual, 0)
+				done(ctx, errors.New("flush error"))
+				So(acked, ShouldEqual, 1)
+				So(ackErr, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When a derived tuple is acknowledged again", func() {
+			w := &ackWriter{WriterFunc(func(ctx *Context, t *Tuple) error {
+				return nil
+			})}
+			So(w.Write(ctx, t), ShouldBeNil)
+			So(acked, ShouldEqual, 1)
+			t.ShallowCopy().RetainAck()(ctx, nil)
+
+			Convey("Then the handler shouldn't be called twice", func() {
+				So(acked, ShouldEqual, 1)
+				So(ackErr, ShouldBeNil)
+			})
+		})
+	})
+}
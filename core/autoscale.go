@@ -0,0 +1,276 @@
+package core
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// AutoscaleConfig has parameters of the autoscaler which adjusts the number
+// of goroutines calling Box.Process of a box according to its load.
+//
+// The autoscaler checks the queue depth of the input pipes of the box and
+// the average latency of Box.Process every Interval. It adds a goroutine when
+// the queues are filled more than ScaleUpQueueRatio, or when the latency
+// exceeds MaxLatency while tuples are queued. It removes a goroutine when
+// the queues are filled less than ScaleDownQueueRatio and the latency doesn't
+// exceed MaxLatency. A condition has to hold for Sustain consecutive checks
+// before the number of goroutines is changed so that the box doesn't flap
+// between two numbers.
+//
+// Note that the latency of Box.Process includes the time spent in writing
+// output tuples, which blocks when a pipe to a subsequent node is full.
+//
+// Zero values except MaxLatency are replaced with default values.
+type AutoscaleConfig struct {
+	// MinParallelism is the minimum number of goroutines. The default value
+	// is 1.
+	MinParallelism int
+
+	// MaxParallelism is the maximum number of goroutines. It must be greater
+	// than or equal to MinParallelism.
+	MaxParallelism int
+
+	// Interval is the interval between checks. The default value is 1s.
+	Interval time.Duration
+
+	// ScaleUpQueueRatio is the ratio of queued tuples to the capacity of
+	// the input pipes above which a goroutine is added. The default value
+	// is 0.5.
+	ScaleUpQueueRatio float64
+
+	// ScaleDownQueueRatio is the ratio of queued tuples to the capacity of
+	// the input pipes below which a goroutine is removed. It must be less
+	// than ScaleUpQueueRatio. The default value is 0.05.
+	ScaleDownQueueRatio float64
+
+	// MaxLatency is the average latency of Box.Process above which a
+	// goroutine is added while tuples are queued. The latency isn't checked
+	// when it's 0.
+	MaxLatency time.Duration
+
+	// Sustain is the number of consecutive checks for which a condition has
+	// to hold before the number of goroutines is changed. The default value
+	// is 3.
+	Sustain int
+}
+
+// withDefaults returns a copy of the config whose zero values are replaced
+// with default values.
+func (c *AutoscaleConfig) withDefaults() *AutoscaleConfig {
+	conf := *c
+	if conf.MinParallelism == 0 {
+		conf.MinParallelism = 1
+	}
+	if conf.Interval == 0 {
+		conf.Interval = time.Second
+	}
+	if conf.ScaleUpQueueRatio == 0 {
+		conf.ScaleUpQueueRatio = 0.5
+	}
+	if conf.ScaleDownQueueRatio == 0 {
+		conf.ScaleDownQueueRatio = 0.05
+	}
+	if conf.Sustain == 0 {
+		conf.Sustain = 3
+	}
+	return &conf
+}
+
+// Validate checks if the config has valid values. Zero values are validated
+// after they're replaced with default values.
+func (c *AutoscaleConfig) Validate() error {
+	conf := c.withDefaults()
+	if conf.MinParallelism < 1 {
+		return fmt.Errorf("min parallelism must be positive: %v", conf.MinParallelism)
+	}
+	if conf.MaxParallelism < conf.MinParallelism {
+		return fmt.Errorf("max parallelism (%v) must be greater than or equal to min parallelism (%v)",
+			conf.MaxParallelism, conf.MinParallelism)
+	}
+	if conf.Interval < 0 {
+		return fmt.Errorf("interval must be positive: %v", conf.Interval)
+	}
+	if conf.ScaleUpQueueRatio <= 0 || conf.ScaleUpQueueRatio > 1 {
+		return fmt.Errorf("scale up queue ratio must be in (0, 1]: %v", conf.ScaleUpQueueRatio)
+	}
+	if conf.ScaleDownQueueRatio < 0 || conf.ScaleDownQueueRatio >= conf.ScaleUpQueueRatio {
+		return fmt.Errorf("scale down queue ratio must be in [0, %v): %v",
+			conf.ScaleUpQueueRatio, conf.ScaleDownQueueRatio)
+	}
+	if conf.MaxLatency < 0 {
+		return fmt.Errorf("max latency must not be negative: %v", conf.MaxLatency)
+	}
+	if conf.Sustain < 1 {
+		return fmt.Errorf("sustain must be positive: %v", conf.Sustain)
+	}
+	return nil
+}
+
+// clamp returns n limited to the range of the parallelism.
+func (c *AutoscaleConfig) clamp(n int) int {
+	if n < c.MinParallelism {
+		return c.MinParallelism
+	}
+	if n > c.MaxParallelism {
+		return c.MaxParallelism
+	}
+	return n
+}
+
+// autoscaler adjusts the number of goroutines pouring tuples to a box.
+type autoscaler struct {
+	// processed and busy must be here for 64-bit alignment. busy is the
+	// total latency of Box.Process in nanoseconds.
+	processed int64
+	busy      int64
+
+	numScaleUps   int64
+	numScaleDowns int64
+
+	config   *AutoscaleConfig
+	nodeName string
+	srcs     *dataSources
+
+	// up and down are the numbers of consecutive checks for which the
+	// condition to add or remove a goroutine has held.
+	up   int
+	down int
+
+	// lastProcessed and lastBusy are processed and busy at the last check.
+	lastProcessed int64
+	lastBusy      int64
+
+	stopOnce sync.Once
+	stopCh   chan struct{}
+	done     chan struct{}
+}
+
+func newAutoscaler(config *AutoscaleConfig, nodeName string, srcs *dataSources) *autoscaler {
+	return &autoscaler{
+		config:   config.withDefaults(),
+		nodeName: nodeName,
+		srcs:     srcs,
+		stopCh:   make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// writer returns a Writer measuring the latency of w.
+func (a *autoscaler) writer(w Writer) Writer {
+	return WriterFunc(func(ctx *Context, t *Tuple) error {
+		start := time.Now()
+		err := w.Write(ctx, t)
+		atomic.AddInt64(&a.busy, int64(time.Now().Sub(start)))
+		atomic.AddInt64(&a.processed, 1)
+		return err
+	})
+}
+
+// run checks the load of the box every interval until stop is called.
+func (a *autoscaler) run(ctx *Context) {
+	defer close(a.done)
+	ticker := ctx.Clock().NewTicker(a.config.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-a.stopCh:
+			return
+		case <-ticker.C():
+			a.check(ctx)
+		}
+	}
+}
+
+func (a *autoscaler) stop() {
+	a.stopOnce.Do(func() {
+		close(a.stopCh)
+	})
+	<-a.done
+}
+
+// check checks the current load and changes the number of goroutines if
+// necessary.
+func (a *autoscaler) check(ctx *Context) {
+	cur := a.srcs.parallelism()
+	if cur == 0 {
+		return
+	}
+
+	queued, capacity := a.srcs.queueUsage()
+	ratio := 0.0
+	if capacity > 0 {
+		ratio = float64(queued) / float64(capacity)
+	}
+
+	processed, busy := atomic.LoadInt64(&a.processed), atomic.LoadInt64(&a.busy)
+	var latency time.Duration
+	if n := processed - a.lastProcessed; n > 0 {
+		latency = time.Duration((busy - a.lastBusy) / n)
+	}
+	a.lastProcessed, a.lastBusy = processed, busy
+
+	next := a.decide(cur, queued, ratio, latency)
+	if next == cur {
+		return
+	}
+	next = a.srcs.setParallelism(next)
+	if next == 0 || next == cur {
+		return
+	}
+
+	log := ctx.Log().WithFields(nodeLogFields(NTBox, a.nodeName)).WithFields(logrus.Fields{
+		"from":        cur,
+		"to":          next,
+		"queue_ratio": ratio,
+		"latency":     latency.String(),
+	})
+	if next > cur {
+		atomic.AddInt64(&a.numScaleUps, 1)
+		log.Info("Scaled up the box")
+	} else {
+		atomic.AddInt64(&a.numScaleDowns, 1)
+		log.Info("Scaled down the box")
+	}
+}
+
+// decide returns the number of goroutines from the current number and
+// the load observed by a check.
+func (a *autoscaler) decide(cur, queued int, ratio float64, latency time.Duration) int {
+	c := a.config
+	slow := c.MaxLatency > 0 && latency > c.MaxLatency
+	switch {
+	case ratio > c.ScaleUpQueueRatio || (slow && queued > 0):
+		a.up++
+		a.down = 0
+	case ratio < c.ScaleDownQueueRatio && !slow:
+		a.down++
+		a.up = 0
+	default:
+		a.up = 0
+		a.down = 0
+	}
+
+	switch {
+	case a.up >= c.Sustain && cur < c.MaxParallelism:
+		a.up = 0
+		return cur + 1
+	case a.down >= c.Sustain && cur > c.MinParallelism:
+		a.down = 0
+		return cur - 1
+	}
+	return c.clamp(cur)
+}
+
+func (a *autoscaler) status() data.Map {
+	return data.Map{
+		"min_parallelism": data.Int(a.config.MinParallelism),
+		"max_parallelism": data.Int(a.config.MaxParallelism),
+		"num_scale_ups":   data.Int(atomic.LoadInt64(&a.numScaleUps)),
+		"num_scale_downs": data.Int(atomic.LoadInt64(&a.numScaleDowns)),
+	}
+}
@@ -0,0 +1,217 @@
+package core
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// blockingBox blocks in Process until it's released and records the
+// maximum number of concurrent calls.
+type blockingBox struct {
+	m        sync.Mutex
+	running  int
+	max      int
+	released chan struct{}
+}
+
+func (b *blockingBox) Process(ctx *Context, t *Tuple, w Writer) error {
+	b.m.Lock()
+	b.running++
+	if b.running > b.max {
+		b.max = b.running
+	}
+	b.m.Unlock()
+	defer func() {
+		b.m.Lock()
+		b.running--
+		b.m.Unlock()
+	}()
+
+	<-b.released
+	return w.Write(ctx, t)
+}
+
+func (b *blockingBox) concurrency() (running, max int) {
+	b.m.Lock()
+	defer b.m.Unlock()
+	return b.running, b.max
+}
+
+// waitForParallelism waits until the parallelism of the box becomes n.
+func waitForParallelism(bn BoxNode, n int) bool {
+	for i := 0; i < 500; i++ {
+		if bn.Status()["parallelism"] == data.Int(n) {
+			return true
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return false
+}
+
+func TestBoxParallelism(t *testing.T) {
+	Convey("Given a topology having a blocking box", t, func() {
+		tp, err := NewDefaultTopology(NewContext(nil), "test")
+		So(err, ShouldBeNil)
+		Reset(func() {
+			tp.Stop()
+		})
+
+		ts := make([]*Tuple, 20)
+		for i := range ts {
+			ts[i] = NewTuple(data.Map{"v": data.Int(i)})
+		}
+		so := NewTupleEmitterSource(ts)
+		src, err := tp.AddSource("source", so, &SourceConfig{PausedOnStartup: true})
+		So(err, ShouldBeNil)
+		b := &blockingBox{released: make(chan struct{})}
+		release := func() {
+			select {
+			case <-b.released:
+			default:
+				close(b.released)
+			}
+		}
+		Reset(release)
+		si := NewTupleCollectorSink()
+
+		add := func(config *BoxConfig) BoxNode {
+			bn, err := tp.AddBox("box", b, config)
+			So(err, ShouldBeNil)
+			So(bn.Input("source", &BoxInputConfig{Capacity: 4}), ShouldBeNil)
+			sn, err := tp.AddSink("sink", si, nil)
+			So(err, ShouldBeNil)
+			So(sn.Input("box", nil), ShouldBeNil)
+			So(src.Resume(), ShouldBeNil)
+			return bn
+		}
+
+		Convey("When the box has static parallelism", func() {
+			bn := add(&BoxConfig{Parallelism: 3})
+			for {
+				if running, _ := b.concurrency(); running == 3 {
+					break
+				}
+				time.Sleep(time.Millisecond)
+			}
+
+			Convey("Then Process should be called concurrently", func() {
+				So(bn.Status()["parallelism"], ShouldEqual, data.Int(3))
+				release()
+				si.Wait(len(ts))
+				_, max := b.concurrency()
+				So(max, ShouldEqual, 3)
+			})
+		})
+
+		Convey("When the box has the autoscaler", func() {
+			bn := add(&BoxConfig{
+				Autoscale: &AutoscaleConfig{
+					MaxParallelism: 3,
+					Interval:       10 * time.Millisecond,
+					Sustain:        2,
+				},
+			})
+
+			Convey("Then it should be scaled up to the max while its queue is full", func() {
+				So(waitForParallelism(bn, 3), ShouldBeTrue)
+				st := bn.Status()["autoscale"].(data.Map)
+				So(st["num_scale_ups"], ShouldEqual, data.Int(2))
+
+				Convey("And it should be scaled down to the min after the load goes away", func() {
+					release()
+					si.Wait(len(ts))
+					So(waitForParallelism(bn, 1), ShouldBeTrue)
+					_, max := b.concurrency()
+					So(max, ShouldEqual, 3)
+				})
+			})
+		})
+
+		Convey("When adding the box with invalid configs", func() {
+			for i, c := range []*BoxConfig{
+				{Parallelism: -1},
+				{Autoscale: &AutoscaleConfig{}},
+				{Autoscale: &AutoscaleConfig{MinParallelism: 3, MaxParallelism: 2}},
+				{Autoscale: &AutoscaleConfig{MaxParallelism: 2, ScaleUpQueueRatio: 1.5}},
+				{Autoscale: &AutoscaleConfig{MaxParallelism: 2, ScaleUpQueueRatio: 0.2, ScaleDownQueueRatio: 0.3}},
+			} {
+				c := c
+
+				Convey(fmt.Sprintf("Then it should fail (%v)", i), func() {
+					_, err := tp.AddBox("box", b, c)
+					So(err, ShouldNotBeNil)
+				})
+			}
+		})
+	})
+}
+
+func TestAutoscalerDecision(t *testing.T) {
+	Convey("Given an autoscaler", t, func() {
+		a := newAutoscaler(&AutoscaleConfig{
+			MinParallelism: 1,
+			MaxParallelism: 3,
+			MaxLatency:     time.Second,
+			Sustain:        2,
+		}, "box", nil)
+
+		Convey("When the queue is full only once", func() {
+			n := a.decide(1, 10, 1, 0)
+			n = a.decide(n, 0, 0.3, 0)
+			n = a.decide(n, 10, 1, 0)
+
+			Convey("Then it shouldn't scale up", func() {
+				So(n, ShouldEqual, 1)
+			})
+		})
+
+		Convey("When the queue keeps being full", func() {
+			var ns []int
+			n := 1
+			for i := 0; i < 6; i++ {
+				n = a.decide(n, 10, 1, 0)
+				ns = append(ns, n)
+			}
+
+			Convey("Then it should scale up every sustain checks until the max", func() {
+				So(ns, ShouldResemble, []int{1, 2, 2, 3, 3, 3})
+			})
+		})
+
+		Convey("When the latency keeps exceeding the max while tuples are queued", func() {
+			n := a.decide(1, 1, 0.1, 2*time.Second)
+			n = a.decide(n, 1, 0.1, 2*time.Second)
+
+			Convey("Then it should scale up", func() {
+				So(n, ShouldEqual, 2)
+			})
+		})
+
+		Convey("When the latency keeps exceeding the max without queued tuples", func() {
+			n := a.decide(2, 0, 0, 2*time.Second)
+			n = a.decide(n, 0, 0, 2*time.Second)
+
+			Convey("Then it shouldn't scale", func() {
+				So(n, ShouldEqual, 2)
+			})
+		})
+
+		Convey("When the queue keeps being empty", func() {
+			var ns []int
+			n := 3
+			for i := 0; i < 6; i++ {
+				n = a.decide(n, 0, 0, 0)
+				ns = append(ns, n)
+			}
+
+			Convey("Then it should scale down every sustain checks until the min", func() {
+				So(ns, ShouldResemble, []int{3, 2, 2, 1, 1, 1})
+			})
+		})
+	})
+}
@@ -0,0 +1,346 @@
+package core
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Clock provides the current time, timers, and tickers. Components depending
+// on the wall clock, such as sources emitting tuples at intervals, should use
+// the Clock returned from Context.Clock instead of functions in the time
+// package so that tests can control time deterministically with ManualClock.
+//
+// Durations measuring how long processing takes, such as latencies observed
+// by the watchdog, aren't affected by the Clock.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+
+	// After waits for the duration to elapse and then sends the current time
+	// on the returned channel. It's equivalent to NewTimer(d).C().
+	After(d time.Duration) <-chan time.Time
+
+	// NewTimer creates a new Timer that will send the current time on its
+	// channel after at least duration d.
+	NewTimer(d time.Duration) Timer
+
+	// NewTicker returns a new Ticker sending the time on its channel every
+	// d. It panics when d isn't positive.
+	NewTicker(d time.Duration) Ticker
+}
+
+// Timer is a timer created by Clock. It behaves like time.Timer.
+type Timer interface {
+	// C returns the channel on which the time is delivered.
+	C() <-chan time.Time
+
+	// Stop prevents the Timer from firing. It returns false if the timer
+	// has already expired or been stopped.
+	Stop() bool
+
+	// Reset changes the timer to expire after duration d. It returns true
+	// if the timer had been active.
+	Reset(d time.Duration) bool
+}
+
+// Ticker is a ticker created by Clock. It behaves like time.Ticker.
+type Ticker interface {
+	// C returns the channel on which the ticks are delivered.
+	C() <-chan time.Time
+
+	// Stop turns off the ticker.
+	Stop()
+}
+
+// SystemClock is a Clock using the time package.
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+func (systemClock) NewTimer(d time.Duration) Timer {
+	return &systemTimer{time.NewTimer(d)}
+}
+
+func (systemClock) NewTicker(d time.Duration) Ticker {
+	return &systemTicker{time.NewTicker(d)}
+}
+
+type systemTimer struct {
+	*time.Timer
+}
+
+func (t *systemTimer) C() <-chan time.Time {
+	return t.Timer.C
+}
+
+type systemTicker struct {
+	*time.Ticker
+}
+
+func (t *systemTicker) C() <-chan time.Time {
+	return t.Ticker.C
+}
+
+// ManualClock is a Clock whose time only advances when Advance is called. It
+// is intended to be used in tests. Timers and tickers fire during Advance
+// when their deadlines are reached. Like time.Ticker, a ticker drops ticks
+// which its receiver doesn't read in time.
+type ManualClock struct {
+	m       sync.Mutex
+	cond    *sync.Cond
+	now     time.Time
+	waiters []*manualWaiter
+}
+
+// NewManualClock creates a ManualClock starting at the time.
+func NewManualClock(t time.Time) *ManualClock {
+	c := &ManualClock{
+		now: t,
+	}
+	c.cond = sync.NewCond(&c.m)
+	return c
+}
+
+// Now returns the current time of the clock.
+func (c *ManualClock) Now() time.Time {
+	c.m.Lock()
+	defer c.m.Unlock()
+	return c.now
+}
+
+// After waits until the clock advances by the duration.
+func (c *ManualClock) After(d time.Duration) <-chan time.Time {
+	return c.NewTimer(d).C()
+}
+
+// NewTimer creates a Timer firing when the clock advances by the duration.
+// The timer fires immediately when d isn't positive.
+func (c *ManualClock) NewTimer(d time.Duration) Timer {
+	w := &manualWaiter{
+		clock: c,
+		ch:    make(chan time.Time, 1),
+	}
+	w.Reset(d)
+	return w
+}
+
+// NewTicker creates a Ticker ticking every time the clock advances by the
+// duration.
+func (c *ManualClock) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("non-positive interval for ManualClock.NewTicker")
+	}
+	c.m.Lock()
+	defer c.m.Unlock()
+	w := &manualWaiter{
+		clock:    c,
+		ch:       make(chan time.Time, 1),
+		deadline: c.now.Add(d),
+		period:   d,
+	}
+	c.add(w)
+	return &manualTicker{w}
+}
+
+// Advance advances the clock by the duration and fires timers and tickers
+// whose deadlines are reached in the order of their deadlines.
+func (c *ManualClock) Advance(d time.Duration) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	c.advanceTo(c.now.Add(d))
+}
+
+// advanceTo advances the clock to end. The caller must hold the lock.
+func (c *ManualClock) advanceTo(end time.Time) {
+	for len(c.waiters) > 0 {
+		sort.Stable(manualWaiters(c.waiters))
+		w := c.waiters[0]
+		if w.deadline.After(end) {
+			break
+		}
+		c.now = w.deadline
+		w.fire()
+		if w.period > 0 {
+			w.deadline = w.deadline.Add(w.period)
+		} else {
+			c.remove(w)
+		}
+	}
+	c.now = end
+}
+
+// BlockUntil blocks until the clock has at least n active timers and
+// tickers. It's used to wait for a goroutine under test to start waiting
+// before advancing the clock.
+func (c *ManualClock) BlockUntil(n int) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	for len(c.waiters) < n {
+		c.cond.Wait()
+	}
+}
+
+// add registers a waiter. The caller must hold the lock.
+func (c *ManualClock) add(w *manualWaiter) {
+	c.waiters = append(c.waiters, w)
+	c.cond.Broadcast()
+}
+
+// remove unregisters a waiter and returns true if it had been registered.
+// The caller must hold the lock.
+func (c *ManualClock) remove(w *manualWaiter) bool {
+	for i, v := range c.waiters {
+		if v == w {
+			c.waiters = append(c.waiters[:i], c.waiters[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// manualWaiter is a timer or a ticker of ManualClock. It's a ticker when
+// period is positive.
+type manualWaiter struct {
+	clock    *ManualClock
+	ch       chan time.Time
+	deadline time.Time
+	period   time.Duration
+}
+
+func (w *manualWaiter) C() <-chan time.Time {
+	return w.ch
+}
+
+// fire sends the deadline to the channel unless the previous one is still
+// in the channel.
+func (w *manualWaiter) fire() {
+	select {
+	case w.ch <- w.deadline:
+	default:
+	}
+}
+
+func (w *manualWaiter) Stop() bool {
+	w.clock.m.Lock()
+	defer w.clock.m.Unlock()
+	return w.clock.remove(w)
+}
+
+func (w *manualWaiter) Reset(d time.Duration) bool {
+	c := w.clock
+	c.m.Lock()
+	defer c.m.Unlock()
+	active := c.remove(w)
+	w.deadline = c.now.Add(d)
+	if d <= 0 {
+		w.fire()
+	} else {
+		c.add(w)
+	}
+	return active
+}
+
+type manualTicker struct {
+	w *manualWaiter
+}
+
+func (t *manualTicker) C() <-chan time.Time {
+	return t.w.ch
+}
+
+func (t *manualTicker) Stop() {
+	t.w.Stop()
+}
+
+// SimulatedClock is a Clock for simulating a topology against recorded input
+// in virtual time. The time of the clock is driven by timestamps of tuples
+// emitted by sources: the clock advances to the timestamp of each tuple when
+// it's later than the current time. Therefore, hours of data can be processed
+// in seconds while timers and tickers, such as those used by Boxes to emit
+// results periodically, fire as if the data were processed in real time.
+//
+// Since nothing has to be waited for in a simulation, a timer advances the
+// clock to its deadline as soon as it's created. As a result, sources emitting
+// tuples at intervals run as fast as possible. Sources should assign proper
+// timestamps to tuples because a tuple having the wall-clock time advances the
+// clock to the present.
+type SimulatedClock struct {
+	*ManualClock
+}
+
+// NewSimulatedClock creates a SimulatedClock starting at the time.
+func NewSimulatedClock(start time.Time) *SimulatedClock {
+	return &SimulatedClock{
+		ManualClock: NewManualClock(start),
+	}
+}
+
+// AdvanceTo advances the clock to the time. It does nothing when the time
+// isn't after the current time of the clock.
+func (c *SimulatedClock) AdvanceTo(t time.Time) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	if t.After(c.now) {
+		c.advanceTo(t)
+	}
+}
+
+// After advances the clock by the duration and returns a channel having the
+// time.
+func (c *SimulatedClock) After(d time.Duration) <-chan time.Time {
+	return c.NewTimer(d).C()
+}
+
+// NewTimer creates a Timer and advances the clock to its deadline, so the
+// timer fires immediately. Reset of the timer doesn't advance the clock.
+func (c *SimulatedClock) NewTimer(d time.Duration) Timer {
+	c.m.Lock()
+	defer c.m.Unlock()
+	w := &manualWaiter{
+		clock:    c.ManualClock,
+		ch:       make(chan time.Time, 1),
+		deadline: c.now.Add(d),
+	}
+	if d <= 0 {
+		w.fire()
+		return w
+	}
+	c.add(w)
+	c.advanceTo(w.deadline)
+	return w
+}
+
+// simulatedClockWriter advances a SimulatedClock to timestamps of tuples
+// written by a source.
+type simulatedClockWriter struct {
+	w     Writer
+	clock *SimulatedClock
+}
+
+func (s *simulatedClockWriter) Write(ctx *Context, t *Tuple) error {
+	s.clock.AdvanceTo(t.Timestamp)
+	return s.w.Write(ctx, t)
+}
+
+type manualWaiters []*manualWaiter
+
+func (w manualWaiters) Len() int {
+	return len(w)
+}
+
+func (w manualWaiters) Less(i, j int) bool {
+	return w[i].deadline.Before(w[j].deadline)
+}
+
+func (w manualWaiters) Swap(i, j int) {
+	w[i], w[j] = w[j], w[i]
+}
@@ -0,0 +1,163 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+func TestManualClock(t *testing.T) {
+	base := time.Date(2016, time.January, 2, 3, 4, 5, 0, time.UTC)
+
+	Convey("Given a manual clock", t, func() {
+		c := NewManualClock(base)
+
+		Convey("When creating a timer", func() {
+			tm := c.NewTimer(time.Second)
+
+			Convey("Then it shouldn't fire before the deadline", func() {
+				c.Advance(999 * time.Millisecond)
+				So(c.Now(), ShouldResemble, base.Add(999*time.Millisecond))
+				So(tm.C(), ShouldBeEmpty)
+			})
+
+			Convey("Then it should fire at the deadline", func() {
+				c.Advance(2 * time.Second)
+				So(<-tm.C(), ShouldResemble, base.Add(time.Second))
+				So(tm.Stop(), ShouldBeFalse)
+			})
+
+			Convey("Then it shouldn't fire after it's stopped", func() {
+				So(tm.Stop(), ShouldBeTrue)
+				c.Advance(time.Second)
+				So(tm.C(), ShouldBeEmpty)
+			})
+
+			Convey("Then it should be able to be reset", func() {
+				c.Advance(500 * time.Millisecond)
+				So(tm.Reset(time.Second), ShouldBeTrue)
+				c.Advance(999 * time.Millisecond)
+				So(tm.C(), ShouldBeEmpty)
+				c.Advance(time.Millisecond)
+				So(<-tm.C(), ShouldResemble, base.Add(1500*time.Millisecond))
+			})
+		})
+
+		Convey("When creating a timer with a non-positive duration", func() {
+			tm := c.NewTimer(0)
+
+			Convey("Then it should fire immediately", func() {
+				So(<-tm.C(), ShouldResemble, base)
+			})
+		})
+
+		Convey("When creating a ticker", func() {
+			tk := c.NewTicker(time.Second)
+			defer tk.Stop()
+
+			Convey("Then it should tick every interval", func() {
+				c.Advance(time.Second)
+				So(<-tk.C(), ShouldResemble, base.Add(time.Second))
+				c.Advance(time.Second)
+				So(<-tk.C(), ShouldResemble, base.Add(2*time.Second))
+			})
+
+			Convey("Then it should drop ticks which aren't received", func() {
+				c.Advance(3 * time.Second)
+				So(<-tk.C(), ShouldResemble, base.Add(time.Second))
+				So(tk.C(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When a goroutine waits for the clock", func() {
+			ch := make(chan time.Time)
+			go func() {
+				ch <- <-c.After(time.Minute)
+			}()
+
+			Convey("Then it should be woken up by advancing the clock", func() {
+				c.BlockUntil(1)
+				c.Advance(time.Minute)
+				So(<-ch, ShouldResemble, base.Add(time.Minute))
+			})
+		})
+	})
+
+	Convey("Given a context without a clock", t, func() {
+		ctx := NewContext(nil)
+
+		Convey("Then it should use the system clock", func() {
+			So(ctx.Clock(), ShouldResemble, SystemClock)
+		})
+	})
+}
+
+func TestSimulatedClock(t *testing.T) {
+	base := time.Date(2016, time.January, 2, 3, 4, 5, 0, time.UTC)
+
+	Convey("Given a simulated clock", t, func() {
+		c := NewSimulatedClock(base)
+
+		Convey("When advancing it to a time", func() {
+			tk := c.NewTicker(time.Minute)
+			defer tk.Stop()
+			c.AdvanceTo(base.Add(90 * time.Second))
+
+			Convey("Then it should have the time", func() {
+				So(c.Now(), ShouldResemble, base.Add(90*time.Second))
+			})
+
+			Convey("Then tickers should fire", func() {
+				So(<-tk.C(), ShouldResemble, base.Add(time.Minute))
+			})
+
+			Convey("Then advancing it to an older time shouldn't change it", func() {
+				c.AdvanceTo(base)
+				So(c.Now(), ShouldResemble, base.Add(90*time.Second))
+			})
+		})
+
+		Convey("When creating a timer", func() {
+			tm := c.NewTimer(time.Hour)
+
+			Convey("Then it should advance the clock and fire immediately", func() {
+				So(<-tm.C(), ShouldResemble, base.Add(time.Hour))
+				So(c.Now(), ShouldResemble, base.Add(time.Hour))
+			})
+		})
+	})
+
+	Convey("Given a topology having a simulated clock", t, func() {
+		c := NewSimulatedClock(time.Time{})
+		dt, err := NewDefaultTopology(NewContext(&ContextConfig{Clock: c}), "dt1")
+		So(err, ShouldBeNil)
+		Reset(func() {
+			dt.Stop()
+		})
+
+		var ts []*Tuple
+		for _, d := range []time.Duration{0, 2 * time.Hour, time.Hour, 3 * time.Hour} {
+			t := NewTuple(data.Map{})
+			t.Timestamp = base.Add(d)
+			ts = append(ts, t)
+		}
+		son, err := dt.AddSource("source", NewTupleEmitterSource(ts), &SourceConfig{
+			PausedOnStartup: true,
+		})
+		So(err, ShouldBeNil)
+		sin, err := dt.AddSink("sink", NewTupleCollectorSink(), nil)
+		So(err, ShouldBeNil)
+		So(sin.Input("source", nil), ShouldBeNil)
+
+		Convey("When the source emits tuples", func() {
+			So(son.Resume(), ShouldBeNil)
+			son.State().Wait(TSStopped)
+
+			Convey("Then the clock should advance to the latest timestamp", func() {
+				So(c.Now(), ShouldResemble, base.Add(3*time.Hour))
+			})
+		})
+	})
+}
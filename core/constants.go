@@ -0,0 +1,59 @@
+package core
+
+import (
+	"fmt"
+
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// ValidateConstantName validates the name of a constant. It has the same
+// format as names of nodes. Unlike them, reserved words can be used since
+// a constant is always referred with the '$' prefix.
+func ValidateConstantName(name string) error {
+	if l := len(name); l < 1 {
+		return fmt.Errorf("the name of a constant is empty")
+	} else if l > 127 {
+		return fmt.Errorf("the name of a constant can be at most 127 letters: %v", len(name))
+	}
+	if !nodeNameRegexp.MatchString(name) {
+		return fmt.Errorf("the name of a constant doesn't follow the format [a-zA-Z][a-zA-Z0-9_]*: %v", name)
+	}
+	return nil
+}
+
+// SetConstant sets the value of a constant of the topology. Constants can be
+// referred by $name in any expression and WITH parameter of BQL statements
+// issued to the topology. A reference is resolved when the statement is
+// executed, so updating the value of a constant doesn't affect existing
+// nodes.
+func (c *Context) SetConstant(name string, v data.Value) error {
+	if err := ValidateConstantName(name); err != nil {
+		return err
+	}
+	c.constMutex.Lock()
+	defer c.constMutex.Unlock()
+	c.constants[name] = v
+	return nil
+}
+
+// Constant returns the value of a constant. It returns NotExistError when
+// the constant isn't defined.
+func (c *Context) Constant(name string) (data.Value, error) {
+	if c == nil {
+		return nil, NotExistError(fmt.Errorf("constant '%v' is not defined", name))
+	}
+	c.constMutex.RLock()
+	defer c.constMutex.RUnlock()
+	v, ok := c.constants[name]
+	if !ok {
+		return nil, NotExistError(fmt.Errorf("constant '%v' is not defined", name))
+	}
+	return v, nil
+}
+
+// Constants returns a copy of all constants of the topology.
+func (c *Context) Constants() data.Map {
+	c.constMutex.RLock()
+	defer c.constMutex.RUnlock()
+	return c.constants.Copy()
+}
@@ -0,0 +1,52 @@
+package core
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"testing"
+)
+
+func TestContextConstants(t *testing.T) {
+	Convey("Given a context created with constants", t, func() {
+		ctx := NewContext(&ContextConfig{
+			Constants: data.Map{"a": data.Int(1)},
+		})
+
+		Convey("When getting a defined constant", func() {
+			v, err := ctx.Constant("a")
+
+			Convey("Then it should return the value", func() {
+				So(err, ShouldBeNil)
+				So(v, ShouldEqual, data.Int(1))
+			})
+		})
+
+		Convey("When getting an undefined constant", func() {
+			_, err := ctx.Constant("b")
+
+			Convey("Then it should fail", func() {
+				So(IsNotExist(err), ShouldBeTrue)
+			})
+		})
+
+		Convey("When setting a constant", func() {
+			So(ctx.SetConstant("b", data.String("x")), ShouldBeNil)
+			So(ctx.SetConstant("a", data.Int(2)), ShouldBeNil)
+
+			Convey("Then all constants should have the new values", func() {
+				So(ctx.Constants(), ShouldResemble, data.Map{
+					"a": data.Int(2),
+					"b": data.String("x"),
+				})
+			})
+		})
+
+		Convey("When setting a constant having an invalid name", func() {
+			err := ctx.SetConstant("1a", data.Int(1))
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
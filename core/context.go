@@ -6,6 +6,7 @@ import (
 	"runtime"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"gopkg.in/sensorbee/sensorbee.v0/data"
@@ -31,8 +32,59 @@ type Context struct {
 	Flags        ContextFlags
 	SharedStates SharedStateRegistry
 
+	// TupleIDGenerator is the default generator of tuple IDs used by sources
+	// which don't have their own generator. IDs aren't assigned when this
+	// is nil.
+	TupleIDGenerator TupleIDGenerator
+
 	dtMutex   sync.RWMutex
 	dtSources map[int64]*droppedTupleCollectorSource
+
+	// watchdog is nil when the watchdog is disabled.
+	watchdog  *WatchdogConfig
+	wdMutex   sync.RWMutex
+	wdSources map[int64]*watchdogEventSource
+
+	suMutex   sync.RWMutex
+	suSources map[int64]*stateUpdateSource
+
+	// scheduler is nil when nodes aren't scheduled.
+	scheduler *Scheduler
+
+	// maxNodes is the maximum number of nodes in the topology. It's
+	// unlimited when maxNodes is 0.
+	maxNodes int
+
+	clock Clock
+
+	// location is nil when the topology uses time.Local.
+	location *time.Location
+
+	// lineage is nil when lineage isn't recorded.
+	lineage *LineageStore
+
+	// windowSpill is nil when windows aren't spilled.
+	windowSpill *WindowSpillConfig
+
+	// executionTimeout is nil when UDFs and boxes don't time out by default.
+	executionTimeout *ExecutionTimeoutConfig
+
+	columnarExecution bool
+
+	sharedFilters bool
+
+	constMutex sync.RWMutex
+	constants  data.Map
+
+	// faultInjector is nil when faults aren't injected.
+	faultInjector FaultInjector
+
+	metrics *MetricRegistry
+
+	randomSeed  int64
+	random      *Random
+	rndMutex    sync.Mutex
+	nodeRandoms map[string]*Random
 }
 
 // ContextConfig has configuration parameters of a Context.
@@ -40,6 +92,76 @@ type ContextConfig struct {
 	// Logger provides a logrus's logger used by the Context.
 	Logger *logrus.Logger
 	Flags  ContextFlags
+
+	// TupleIDGenerator is used to assign IDs to tuples emitted from sources.
+	// See SourceConfig.TupleIDGenerator.
+	TupleIDGenerator TupleIDGenerator
+
+	// Watchdog enables the watchdog detecting sinks and boxes whose latency
+	// is degrading. The watchdog is disabled when this is nil. See
+	// WatchdogConfig for details.
+	Watchdog *WatchdogConfig
+
+	// Scheduler limits the number of nodes processing tuples concurrently.
+	// It can be shared by multiple Contexts. Nodes aren't limited when this
+	// is nil.
+	Scheduler *Scheduler
+
+	// MaxNodes is the maximum number of sources, boxes, and sinks the
+	// topology can have at the same time, including temporary nodes created
+	// for SELECT statements. Adding a node beyond the limit fails. The number
+	// of nodes isn't limited when it's 0.
+	MaxNodes int
+
+	// Clock is used by components depending on time. SystemClock is used
+	// when this is nil. Tests can set ManualClock to control time.
+	Clock Clock
+
+	// Location is the time zone of the topology. It's used to interpret
+	// timestamps without time zones, such as ones read by sources, and to
+	// format timestamps in local time. time.Local is used when this is nil.
+	// See LoadLocation to obtain a time zone from its name.
+	Location *time.Location
+
+	// Lineage enables recording lineage of tuples emitted from Boxes
+	// aggregating or joining tuples while tuple tracing is enabled. Lineage
+	// isn't recorded when this is nil. See LineageConfig for details.
+	Lineage *LineageConfig
+
+	// WindowSpill enables spilling contents of large windows to disk.
+	// Windows are always kept in memory when this is nil. See
+	// WindowSpillConfig for details.
+	WindowSpill *WindowSpillConfig
+
+	// ExecutionTimeout has default timeouts of UDF calls and processing of
+	// tuples in Boxes. Nothing times out by default when this is nil. See
+	// ExecutionTimeoutConfig for details.
+	ExecutionTimeout *ExecutionTimeoutConfig
+
+	// ColumnarExecution makes Boxes aggregating tuples process their windows
+	// as columnar batches while all tuples in a window have the same
+	// schema. This is experimental.
+	ColumnarExecution bool
+
+	// SharedFilters lets the BQL optimizer push predicates common to streams
+	// selecting from the same input down to a filter shared by them so that
+	// the predicates are evaluated once per tuple instead of once per stream.
+	SharedFilters bool
+
+	// Constants has initial values of constants of the topology. See
+	// Context.SetConstant for details.
+	Constants data.Map
+
+	// FaultInjector injects faults into nodes of the topology for testing.
+	// Faults aren't injected when this is nil. See FaultInjector for
+	// details.
+	FaultInjector FaultInjector
+
+	// RandomSeed is the seed of random number generators returned from
+	// Context.Random and Context.NodeRandom. When this is nil, the current
+	// time of Clock is used as the seed so that random numbers can be
+	// reproduced by a ManualClock or a SimulatedClock.
+	RandomSeed *int64
 }
 
 // NewContext creates a new Context based on the config. If config is nil,
@@ -53,14 +175,122 @@ func NewContext(config *ContextConfig) *Context {
 		logger = logrus.StandardLogger()
 	}
 	c := &Context{
-		logger:    logger,
-		Flags:     config.Flags,
-		dtSources: map[int64]*droppedTupleCollectorSource{},
+		logger:           logger,
+		Flags:            config.Flags,
+		TupleIDGenerator: config.TupleIDGenerator,
+		dtSources:        map[int64]*droppedTupleCollectorSource{},
+		wdSources:        map[int64]*watchdogEventSource{},
+		suSources:        map[int64]*stateUpdateSource{},
+		scheduler:        config.Scheduler,
+		maxNodes:         config.MaxNodes,
+		clock:            config.Clock,
+		location:         config.Location,
+		metrics:          newMetricRegistry(),
+	}
+	if c.clock == nil {
+		c.clock = SystemClock
+	}
+	if config.Watchdog != nil {
+		c.watchdog = config.Watchdog.withDefaults()
 	}
+	if config.Lineage != nil {
+		c.lineage = newLineageStore(config.Lineage.withDefaults())
+	}
+	if config.WindowSpill != nil {
+		c.windowSpill = config.WindowSpill.withDefaults()
+	}
+	if config.ExecutionTimeout != nil {
+		t := *config.ExecutionTimeout
+		c.executionTimeout = &t
+	}
+	c.columnarExecution = config.ColumnarExecution
+	c.sharedFilters = config.SharedFilters
+	c.constants = data.Map{}
+	for n, v := range config.Constants {
+		c.constants[n] = v
+	}
+	c.faultInjector = config.FaultInjector
+	if config.RandomSeed != nil {
+		c.randomSeed = *config.RandomSeed
+	} else {
+		c.randomSeed = c.clock.Now().UnixNano()
+	}
+	c.random = NewRandom(c.randomSeed)
+	c.nodeRandoms = map[string]*Random{}
 	c.SharedStates = NewDefaultSharedStateRegistry(c)
 	return c
 }
 
+// schedulerPool returns the pool of the scheduler for the node type. It
+// returns nil when the scheduler isn't set.
+func (c *Context) schedulerPool(t NodeType) *schedulerPool {
+	if c.scheduler == nil {
+		return nil
+	}
+	return c.scheduler.pool(t)
+}
+
+// Clock returns the clock of the Context. Components depending on time
+// should use it instead of the time package. It returns SystemClock when the
+// Context is nil.
+func (c *Context) Clock() Clock {
+	if c == nil || c.clock == nil {
+		return SystemClock
+	}
+	return c.clock
+}
+
+// Lineage returns the store of lineage records of the topology. It returns
+// nil when lineage isn't recorded.
+func (c *Context) Lineage() *LineageStore {
+	if c == nil {
+		return nil
+	}
+	return c.lineage
+}
+
+// WindowSpill returns the config of spilling windows to disk. It returns
+// nil when windows aren't spilled.
+func (c *Context) WindowSpill() *WindowSpillConfig {
+	if c == nil {
+		return nil
+	}
+	return c.windowSpill
+}
+
+// ColumnarExecution returns true when Boxes should process their windows as
+// columnar batches.
+func (c *Context) ColumnarExecution() bool {
+	if c == nil {
+		return false
+	}
+	return c.columnarExecution
+}
+
+// SharedFilters returns true when predicates common to streams selecting
+// from the same input can be evaluated by a filter shared by them.
+func (c *Context) SharedFilters() bool {
+	if c == nil {
+		return false
+	}
+	return c.sharedFilters
+}
+
+// ExecutionTimeout returns the default timeouts of UDF calls and processing
+// of tuples in Boxes. It returns nil when they don't time out by default.
+func (c *Context) ExecutionTimeout() *ExecutionTimeoutConfig {
+	if c == nil {
+		return nil
+	}
+	return c.executionTimeout
+}
+
+// Metrics returns the registry of metrics exported by nodes of the topology.
+// See MetricRegistry for details.
+func (c *Context) Metrics() *MetricRegistry {
+	return c.metrics
+}
+
 // Log returns the logger tied to the Context.
 func (c *Context) Log() *logrus.Entry {
 	return c.log(1)
@@ -120,8 +350,11 @@ func (c *Context) droppedTuple(t *Tuple, nodeType NodeType, nodeName string, et
 	}
 
 	dt := t
-	if t.Flags.IsSet(TFShared) {
+	if t.Flags.IsSet(TFShared) || t.ack != nil {
+		// A tuple requesting acknowledgment is copied so that the dropped
+		// tuple doesn't hold the acknowledgment of the original one.
 		dt = t.ShallowCopy()
+		dt.ack = nil
 	}
 
 	dt.Data = data.Map{
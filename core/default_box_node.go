@@ -12,6 +12,9 @@ type defaultBoxNode struct {
 	box    Box
 	dsts   *dataDestinations
 
+	// autoscaler is nil when the autoscaler isn't enabled.
+	autoscaler *autoscaler
+
 	gracefulStopEnabled bool
 	stopOnDisconnectDir ConnDir
 	runErr              error
@@ -44,6 +47,9 @@ func (db *defaultBoxNode) Input(refname string, config *BoxInputConfig) error {
 
 	recv, send := newPipe(config.inputName(), config.capacity())
 	send.dropMode = config.DropMode
+	send.reportDrops = config.ReportDrops
+	send.dstType = NTBox
+	send.dstName = db.name
 	if err := s.destinations().add(db.name, send); err != nil {
 		return err
 	}
@@ -85,8 +91,46 @@ func (db *defaultBoxNode) run() (runErr error) {
 		}
 	}()
 	db.state.Set(TSRunning)
-	w := newBoxWriterAdapter(db.box, db.name, db.dsts)
-	db.runErr = db.srcs.pour(db.topology.ctx, w, 1) // TODO: make parallelism configurable
+
+	ctx := db.topology.ctx
+	var watcher *latencyWatcher
+	if ctx.watchdog != nil {
+		watcher = newLatencyWatcher(ctx.watchdog, NTBox, db.name)
+	}
+
+	// Each goroutine calling Process has its own Writer because
+	// scheduledWriter keeps the state of the goroutine holding a slot.
+	newWriter := func() Writer {
+		var (
+			dst = newFaultInjectingWriteCloser(ctx, db.dsts, FPWrite, NTBox, db.name)
+			sw  *scheduledWriter
+		)
+		if pool := ctx.schedulerPool(NTBox); pool != nil {
+			// The box releases its slot while writing output tuples.
+			sw = &scheduledWriter{pool: pool}
+			dst = &yieldingWriter{w: dst, sw: sw}
+		}
+		w := newFaultInjectingWriter(ctx, newBoxWriterAdapter(db.box, db.name, dst), FPProcess, NTBox, db.name)
+		if watcher != nil {
+			w = &latencyWatchingWriter{w: w, watcher: watcher}
+		}
+		if db.autoscaler != nil {
+			w = db.autoscaler.writer(w)
+		}
+		if sw != nil {
+			sw.w = w
+			w = sw
+		}
+		return w
+	}
+
+	parallelism := db.config.Parallelism
+	if db.autoscaler != nil {
+		parallelism = db.autoscaler.config.clamp(parallelism)
+		go db.autoscaler.run(ctx)
+		defer db.autoscaler.stop()
+	}
+	db.runErr = db.srcs.pourWithWriters(ctx, newWriter, parallelism)
 	return
 }
 
@@ -140,6 +184,7 @@ func (db *defaultBoxNode) Status() data.Map {
 		"state":        data.String(st.String()),
 		"input_stats":  db.srcs.status(),
 		"output_stats": db.dsts.status(),
+		"parallelism":  data.Int(db.srcs.parallelism()),
 		"behaviors": data.Map{
 			"stop_on_inbound_disconnect":  data.Bool((connDir & Inbound) != 0),
 			"stop_on_outbound_disconnect": data.Bool((connDir & Outbound) != 0),
@@ -150,6 +195,12 @@ func (db *defaultBoxNode) Status() data.Map {
 	if st == TSStopped && db.runErr != nil {
 		m["error"] = data.String(db.runErr.Error())
 	}
+	if db.autoscaler != nil {
+		m["autoscale"] = db.autoscaler.status()
+	}
+	if ms := db.topology.ctx.Metrics().status(db.name); ms != nil {
+		m["metrics"] = ms
+	}
 	if b, ok := db.box.(Statuser); ok {
 		m["box"] = b.Status()
 	}
@@ -11,6 +11,9 @@ type defaultSinkNode struct {
 	srcs   *dataSources
 	sink   Sink
 
+	// flushable serializes Write, Flush, and Close of sink.
+	flushable *flushableSink
+
 	gracefulStopEnabled     bool
 	stopOnDisconnectEnabled bool
 	runErr                  error
@@ -45,8 +48,12 @@ func (ds *defaultSinkNode) Input(refname string, config *SinkInputConfig) error
 		return err
 	}
 
-	recv, send := newPipe("output", config.capacity())
+	recv, send := newPipe(config.inputName(), config.capacity())
 	send.dropMode = config.DropMode
+	send.reportDrops = config.ReportDrops
+	send.dstType = NTSink
+	send.dstName = ds.name
+	send.routingField = config.RoutingField
 	if err := s.destinations().add(ds.name, send); err != nil {
 		return err
 	}
@@ -75,17 +82,31 @@ func (ds *defaultSinkNode) run() (runErr error) {
 			}
 			runErr = ds.runErr
 		}()
-		if err := ds.sink.Close(ds.topology.ctx); err != nil {
+		// Tuples buffered in the sink are flushed before closing it so that
+		// a sink doesn't have to flush them in Close. The sink is closed
+		// even if it cannot be flushed.
+		if err := ds.flushable.flush(ds.topology.ctx); err != nil {
+			ds.topology.ctx.ErrLog(err).WithFields(nodeLogFields(NTSink, ds.name)).
+				Error("Cannot flush the sink before closing it")
+		}
+		if err := ds.flushable.Close(ds.topology.ctx); err != nil {
 			ds.runErr = err
 			ds.topology.ctx.ErrLog(err).WithFields(nodeLogFields(NTSink, ds.name)).
 				Error("Cannot stop the sink")
 		}
 	}()
 	ds.state.Set(TSRunning)
-	ds.runErr = ds.srcs.pour(ds.topology.ctx, newTraceWriter(ds.sink, ETInput, ds.name), 1)
+	w := newFaultInjectingWriter(ds.topology.ctx, newTraceWriter(ds.flushable, ETInput, ds.name), FPProcess, NTSink, ds.name)
+	w = newLatencyWatchingWriter(ds.topology.ctx, w, NTSink, ds.name)
+	w = newScheduledWriter(ds.topology.ctx.schedulerPool(NTSink), w)
+	ds.runErr = ds.srcs.pour(ds.topology.ctx, w, 1)
 	return
 }
 
+func (ds *defaultSinkNode) Flush() error {
+	return ds.flushable.flush(ds.topology.ctx)
+}
+
 func (ds *defaultSinkNode) Stop() error {
 	ds.stop()
 	return nil
@@ -133,6 +154,9 @@ func (ds *defaultSinkNode) Status() data.Map {
 	if st == TSStopped && ds.runErr != nil {
 		m["error"] = data.String(ds.runErr.Error())
 	}
+	if ms := ds.topology.ctx.Metrics().status(ds.name); ms != nil {
+		m["metrics"] = ms
+	}
 	if s, ok := ds.sink.(Statuser); ok {
 		m["sink"] = s.Status()
 	}
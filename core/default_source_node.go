@@ -56,7 +56,20 @@ func (ds *defaultSourceNode) run() (runErr error) {
 		return
 	}
 
-	ds.runErr = ds.source.GenerateStream(ds.topology.ctx, newTraceWriter(ds.dsts, ETOutput, ds.name))
+	gen := ds.config.TupleIDGenerator
+	if gen == nil {
+		gen = ds.topology.ctx.TupleIDGenerator
+	}
+	if pool := ds.topology.ctx.schedulerPool(NTSource); pool != nil {
+		ds.dsts.setSchedulerPool(pool)
+		defer pool.enter()()
+	}
+	dsts := newFaultInjectingWriteCloser(ds.topology.ctx, ds.dsts, FPWrite, NTSource, ds.name)
+	var w Writer = &ackWriter{newTupleIDWriter(newTraceWriter(dsts, ETOutput, ds.name), gen)}
+	if c, ok := ds.topology.ctx.Clock().(*SimulatedClock); ok {
+		w = &simulatedClockWriter{w: w, clock: c}
+	}
+	ds.runErr = ds.source.GenerateStream(ds.topology.ctx, w)
 	return
 }
 
@@ -107,19 +120,30 @@ func (ds *defaultSourceNode) Stop() error {
 }
 
 func (ds *defaultSourceNode) Pause() error {
-	ds.stateMutex.Lock()
-	defer ds.stateMutex.Unlock()
+	paused, err := func() (bool, error) {
+		ds.stateMutex.Lock()
+		defer ds.stateMutex.Unlock()
 
-	// Because defaultSourceNode will be returned after run method is
-	// called by defaultTopology, the possible states are limited.
-	switch ds.state.getWithoutLock() {
-	case TSRunning:
-	case TSPaused:
-		return nil
-	default:
-		return fmt.Errorf("source '%v' is already stopped", ds.name)
+		// Because defaultSourceNode will be returned after run method is
+		// called by defaultTopology, the possible states are limited.
+		switch ds.state.getWithoutLock() {
+		case TSRunning:
+		case TSPaused:
+			return false, nil
+		default:
+			return false, fmt.Errorf("source '%v' is already stopped", ds.name)
+		}
+		return true, ds.pause()
+	}()
+	if !paused || err != nil {
+		return err
 	}
-	return ds.pause()
+
+	// Tuples written to sinks so far are flushed while the source isn't
+	// generating tuples. Errors are logged by FlushSinks and they don't
+	// fail Pause since the source has been paused.
+	ds.topology.FlushSinks()
+	return nil
 }
 
 func (ds *defaultSourceNode) pause() error {
@@ -209,10 +233,18 @@ func (ds *defaultSourceNode) Status() data.Map {
 	}
 	if st == TSStopped && ds.runErr != nil {
 		m["error"] = data.String(ds.runErr.Error())
+		m["error_fatal"] = data.Bool(IsFatalError(ds.runErr))
+		m["error_temporary"] = data.Bool(IsTemporaryError(ds.runErr))
+	}
+	if ms := ds.topology.ctx.Metrics().status(ds.name); ms != nil {
+		m["metrics"] = ms
 	}
 	if s, ok := ds.source.(Statuser); ok {
 		m["source"] = s.Status()
 	}
+	if rs, ok := ds.source.(RetryingSource); ok {
+		m["retry"] = rs.Reconnector().Status()
+	}
 	return m
 }
 
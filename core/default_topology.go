@@ -62,6 +62,10 @@ func (t *defaultTopology) AddSource(name string, s Source, config *SourceConfig)
 	if config == nil {
 		config = &SourceConfig{}
 	}
+	labels, err := normalizeLabels(config.Labels)
+	if err != nil {
+		return nil, err
+	}
 
 	// This method assumes adding a Source having a duplicated name is rare.
 	// Under this assumption, acquiring wlock without checking the existence
@@ -78,11 +82,11 @@ func (t *defaultTopology) AddSource(name string, s Source, config *SourceConfig)
 	// while the state is TSStopping is safe although the source will get
 	// removed just after it's added to the topology.
 	if t.state.Get() >= TSStopping {
-		return nil, fmt.Errorf("the topology is already stopped")
+		return nil, CodedError(ErrCodeInvalidState, fmt.Errorf("the topology is already stopped"))
 	}
 
 	ds := &defaultSourceNode{
-		defaultNode:     newDefaultNode(t, name, config.Meta),
+		defaultNode:     newDefaultNode(t, name, config.Meta, labels),
 		source:          s,
 		dsts:            newDataDestinations(NTSource, name),
 		pausedOnStartup: config.PausedOnStartup,
@@ -90,7 +94,7 @@ func (t *defaultTopology) AddSource(name string, s Source, config *SourceConfig)
 	ds.config = &SourceConfig{}
 	*ds.config = *config
 	ds.dsts.callback = ds.dstCallback
-	if err := t.checkNodeNameDuplication(name); err != nil {
+	if err := t.checkNewNode(name); err != nil {
 		// Because the source isn't started yet, it doesn't return an error.
 		ds.Stop()
 		return nil, err
@@ -124,19 +128,32 @@ func (t *defaultTopology) AddSource(name string, s Source, config *SourceConfig)
 	return ds, nil
 }
 
+// checkNewNode checks if a node having the given name can be added to the
+// topology. The caller must acquire the lock as checkNodeNameDuplication.
+func (t *defaultTopology) checkNewNode(name string) error {
+	if err := t.checkNodeNameDuplication(name); err != nil {
+		return err
+	}
+	if max := t.ctx.maxNodes; max > 0 && len(t.sources)+len(t.boxes)+len(t.sinks) >= max {
+		return CodedError(ErrCodeResourceExhausted,
+			fmt.Errorf("the topology cannot have more than %v nodes", max))
+	}
+	return nil
+}
+
 // checkNodeNameDuplication checks if the given name is unique in the topology.
 // This method doesn't acquire the lock and it's the caller's responsibility
 // to do it before calling this method.
 func (t *defaultTopology) checkNodeNameDuplication(name string) error {
 	lowerName := strings.ToLower(name)
 	if _, ok := t.sources[lowerName]; ok {
-		return fmt.Errorf("the name is already used by a source: %v", name)
+		return CodedError(ErrCodeAlreadyExists, fmt.Errorf("the name is already used by a source: %v", name))
 	}
 	if _, ok := t.boxes[lowerName]; ok {
-		return fmt.Errorf("the name is already used by a box: %v", name)
+		return CodedError(ErrCodeAlreadyExists, fmt.Errorf("the name is already used by a box: %v", name))
 	}
 	if _, ok := t.sinks[lowerName]; ok {
-		return fmt.Errorf("the name is already used by a sink: %v", name)
+		return CodedError(ErrCodeAlreadyExists, fmt.Errorf("the name is already used by a sink: %v", name))
 	}
 	return nil
 }
@@ -149,14 +166,26 @@ func (t *defaultTopology) AddBox(name string, b Box, config *BoxConfig) (BoxNode
 	if config == nil {
 		config = &BoxConfig{}
 	}
+	labels, err := normalizeLabels(config.Labels)
+	if err != nil {
+		return nil, err
+	}
+	if config.Parallelism < 0 {
+		return nil, fmt.Errorf("parallelism must not be negative: %v", config.Parallelism)
+	}
+	if config.Autoscale != nil {
+		if err := config.Autoscale.Validate(); err != nil {
+			return nil, err
+		}
+	}
 
 	t.nodeMutex.Lock()
 	defer t.nodeMutex.Unlock()
 	if t.state.Get() >= TSStopping {
-		return nil, fmt.Errorf("the topology is already stopped")
+		return nil, CodedError(ErrCodeInvalidState, fmt.Errorf("the topology is already stopped"))
 	}
 
-	if err := t.checkNodeNameDuplication(name); err != nil {
+	if err := t.checkNewNode(name); err != nil {
 		return nil, err
 	}
 
@@ -179,13 +208,16 @@ func (t *defaultTopology) AddBox(name string, b Box, config *BoxConfig) (BoxNode
 	}
 
 	db := &defaultBoxNode{
-		defaultNode: newDefaultNode(t, name, config.Meta),
+		defaultNode: newDefaultNode(t, name, config.Meta, labels),
 		srcs:        newDataSources(NTBox, name),
 		box:         b,
 		dsts:        newDataDestinations(NTBox, name),
 	}
 	db.config = &BoxConfig{}
 	*db.config = *config
+	if config.Autoscale != nil {
+		db.autoscaler = newAutoscaler(config.Autoscale, name, db.srcs)
+	}
 	db.dsts.callback = db.dstCallback
 	t.boxes[strings.ToLower(name)] = db
 
@@ -238,23 +270,29 @@ func (t *defaultTopology) AddSink(name string, s Sink, config *SinkConfig) (Sink
 	if config == nil {
 		config = &SinkConfig{}
 	}
+	labels, err := normalizeLabels(config.Labels)
+	if err != nil {
+		closeSinkFlag = true
+		return nil, err
+	}
 
 	t.nodeMutex.Lock()
 	defer t.nodeMutex.Unlock()
 	if t.state.Get() >= TSStopping {
 		closeSinkFlag = true
-		return nil, fmt.Errorf("the topology is already stopped")
+		return nil, CodedError(ErrCodeInvalidState, fmt.Errorf("the topology is already stopped"))
 	}
 
-	if err := t.checkNodeNameDuplication(name); err != nil {
+	if err := t.checkNewNode(name); err != nil {
 		closeSinkFlag = true
 		return nil, err
 	}
 
 	ds := &defaultSinkNode{
-		defaultNode: newDefaultNode(t, name, config.Meta),
+		defaultNode: newDefaultNode(t, name, config.Meta, labels),
 		srcs:        newDataSources(NTSink, name),
 		sink:        s,
+		flushable:   &flushableSink{sink: s},
 	}
 	ds.config = &SinkConfig{}
 	*ds.config = *config
@@ -333,6 +371,29 @@ func (t *defaultTopology) Stop() error {
 	return lastErr
 }
 
+func (t *defaultTopology) FlushSinks() error {
+	t.nodeMutex.RLock()
+	sinks := make([]*defaultSinkNode, 0, len(t.sinks))
+	for _, s := range t.sinks {
+		if s.state.Get() < TSStopping {
+			sinks = append(sinks, s)
+		}
+	}
+	t.nodeMutex.RUnlock()
+
+	// Sinks are flushed outside the lock because flushing can take a long
+	// time and it doesn't modify the topology.
+	var lastErr error
+	for _, s := range sinks {
+		if err := s.Flush(); err != nil {
+			lastErr = err
+			t.ctx.ErrLog(err).WithFields(nodeLogFields(NTSink, s.name)).
+				Error("Cannot flush the sink")
+		}
+	}
+	return lastErr
+}
+
 func (t *defaultTopology) State() TopologyStateHolder {
 	return t.state
 }
@@ -360,6 +421,7 @@ func (t *defaultTopology) Remove(name string) error {
 	if err != nil {
 		return err
 	}
+	defer t.ctx.Metrics().remove(name)
 
 	if err := n.Stop(); err != nil { // stop never panics
 		if n.Type() == NTSource {
@@ -495,10 +557,11 @@ type defaultNode struct {
 	state      *topologyStateHolder
 	stateMutex sync.Mutex
 
-	meta interface{}
+	meta   interface{}
+	labels []string
 }
 
-func newDefaultNode(t *defaultTopology, name string, meta interface{}) *defaultNode {
+func newDefaultNode(t *defaultTopology, name string, meta interface{}, labels []string) *defaultNode {
 	if meta == nil {
 		meta = map[string]interface{}{}
 	}
@@ -506,6 +569,7 @@ func newDefaultNode(t *defaultTopology, name string, meta interface{}) *defaultN
 		topology: t,
 		name:     name,
 		meta:     meta,
+		labels:   labels,
 	}
 	dn.state = newTopologyStateHolder(&dn.stateMutex)
 	return dn
@@ -523,6 +587,10 @@ func (dn *defaultNode) Meta() interface{} {
 	return dn.meta
 }
 
+func (dn *defaultNode) Labels() []string {
+	return dn.labels
+}
+
 func (dn *defaultNode) checkAndPrepareForRunning(nodeType string) error {
 	dn.stateMutex.Lock()
 	defer dn.stateMutex.Unlock()
@@ -533,9 +601,9 @@ func (dn *defaultNode) checkAndPrepareForRunningWithoutLock(nodeType string) err
 	if st, err := dn.state.checkAndPrepareForRunningWithoutLock(); err != nil {
 		switch st {
 		case TSRunning, TSPaused:
-			return fmt.Errorf("%v '%v' is already running", nodeType, dn.name)
+			return CodedError(ErrCodeInvalidState, fmt.Errorf("%v '%v' is already running", nodeType, dn.name))
 		case TSStopped:
-			return fmt.Errorf("%v '%v' is already stopped", nodeType, dn.name)
+			return CodedError(ErrCodeInvalidState, fmt.Errorf("%v '%v' is already stopped", nodeType, dn.name))
 		default:
 			return fmt.Errorf("%v '%v' has an invalid state: %v", nodeType, dn.name, st)
 		}
@@ -2,6 +2,7 @@ package core
 
 import (
 	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
 	"testing"
 )
 
@@ -110,6 +111,62 @@ func TestDefaultTopologyInputNamesChecks(t *testing.T) {
 	})
 }
 
+// TestDefaultTopologySinkInputNames tests that input names and routing fields
+// are attached to tuples received by sinks.
+func TestDefaultTopologySinkInputNames(t *testing.T) {
+	Convey("Given a default topology with two sources", t, func() {
+		t, err := NewDefaultTopology(NewContext(nil), "dt1")
+		So(err, ShouldBeNil)
+		Reset(func() {
+			t.Stop()
+		})
+
+		s1 := NewTupleEmitterSource(freshTuples()[:2])
+		son1, err := t.AddSource("source1", s1, &SourceConfig{
+			PausedOnStartup: true,
+		})
+		So(err, ShouldBeNil)
+		s2 := NewTupleEmitterSource(freshTuples()[:2])
+		son2, err := t.AddSource("source2", s2, &SourceConfig{
+			PausedOnStartup: true,
+		})
+		So(err, ShouldBeNil)
+
+		Convey("When a sink receives tuples from both sources with a routing field", func() {
+			si := NewTupleCollectorSink()
+			sin, err := t.AddSink("sink", si, nil)
+			So(err, ShouldBeNil)
+			So(sin.Input("source1", &SinkInputConfig{InputName: "source1", RoutingField: "origin"}), ShouldBeNil)
+			So(sin.Input("source2", &SinkInputConfig{InputName: "source2", RoutingField: "origin"}), ShouldBeNil)
+
+			// another sink to check that Data of shared tuples isn't modified
+			si2 := NewTupleCollectorSink()
+			sin2, err := t.AddSink("sink2", si2, nil)
+			So(err, ShouldBeNil)
+			So(sin2.Input("source1", nil), ShouldBeNil)
+
+			So(son1.Resume(), ShouldBeNil)
+			So(son2.Resume(), ShouldBeNil)
+			si.Wait(4)
+			si2.Wait(2)
+
+			Convey("Then each tuple should have its origin", func() {
+				for _, tu := range si.Tuples {
+					So(tu.InputName, ShouldBeIn, []string{"source1", "source2"})
+					So(tu.Data["origin"], ShouldEqual, data.String(tu.InputName))
+				}
+			})
+
+			Convey("Then tuples received by the other sink should not have the routing field", func() {
+				for _, tu := range si2.Tuples {
+					So(tu.InputName, ShouldEqual, "output")
+					So(tu.Data, ShouldNotContainKey, "origin")
+				}
+			})
+		})
+	})
+}
+
 /**************************************************/
 
 // DoesNothingBoxWithInputNames is basically like DoNothingBox, but it is
@@ -89,6 +89,44 @@ func TestDefaultTopologySetup(t *testing.T) {
 		})
 	})
 
+	Convey("Given a default topology limiting the number of nodes", t, func() {
+		dt, err := NewDefaultTopology(NewContext(&ContextConfig{MaxNodes: 2}), "dt1")
+		So(err, ShouldBeNil)
+		Reset(func() {
+			dt.Stop()
+		})
+		_, err = dt.AddSource("source", &DoesNothingSource{}, nil)
+		So(err, ShouldBeNil)
+		_, err = dt.AddBox("box", &DoesNothingBox{}, nil)
+		So(err, ShouldBeNil)
+
+		Convey("Then adding a sink beyond the limit should fail", func() {
+			si := NewTupleCollectorSink()
+			sic := &sinkCloseChecker{s: si}
+			_, err := dt.AddSink("sink", sic, nil)
+			So(err, ShouldNotBeNil)
+
+			Convey("And the sink shoud be closed", func() {
+				So(sic.closeCnt, ShouldEqual, 1)
+			})
+		})
+
+		Convey("Then adding a source beyond the limit should fail", func() {
+			_, err := dt.AddSource("source2", &DoesNothingSource{}, nil)
+			So(err, ShouldNotBeNil)
+			So(ErrorCode(err), ShouldEqual, ErrCodeResourceExhausted)
+		})
+
+		Convey("When removing a node", func() {
+			So(dt.Remove("box"), ShouldBeNil)
+
+			Convey("Then a new node can be added", func() {
+				_, err := dt.AddSink("sink", NewTupleCollectorSink(), nil)
+				So(err, ShouldBeNil)
+			})
+		})
+	})
+
 	Convey("Given a default topology", t, func() {
 		dt, err := NewDefaultTopology(NewContext(nil), "dt1")
 		So(err, ShouldBeNil)
@@ -101,6 +139,7 @@ func TestDefaultTopologySetup(t *testing.T) {
 			Convey("Then adding a source having the same name should fail", func() {
 				_, err := t.AddSource(name, &DoesNothingSource{}, nil)
 				So(err, ShouldNotBeNil)
+				So(ErrorCode(err), ShouldEqual, ErrCodeAlreadyExists)
 			})
 
 			Convey("Then adding a box having the same name should fail", func() {
@@ -200,3 +200,119 @@ func NotExistError(err error) error {
 		err: err,
 	}
 }
+
+// Error codes are machine-readable identifiers of kinds of errors. They're
+// returned from ErrorCode so that, for example, the server can map errors to
+// proper HTTP statuses without inspecting error messages. Packages which
+// cannot depend on core, such as data, use the same string values.
+const (
+	// ErrCodeNotFound means that a node, a state, a topology, or any other
+	// resource was not found.
+	ErrCodeNotFound = "not_found"
+
+	// ErrCodeAlreadyExists means that a resource having the same name
+	// already exists.
+	ErrCodeAlreadyExists = "already_exists"
+
+	// ErrCodeInvalidArgument means that a given value or parameter is
+	// invalid.
+	ErrCodeInvalidArgument = "invalid_argument"
+
+	// ErrCodeParse means that a BQL statement cannot be parsed.
+	ErrCodeParse = "parse_error"
+
+	// ErrCodeTypeMismatch means that a value cannot be used as a value of
+	// the required type.
+	ErrCodeTypeMismatch = "type_mismatch"
+
+	// ErrCodeResourceExhausted means that a limit such as the maximum
+	// number of nodes has been reached.
+	ErrCodeResourceExhausted = "resource_exhausted"
+
+	// ErrCodeInvalidState means that an operation cannot be performed in
+	// the current state of a node or a topology.
+	ErrCodeInvalidState = "invalid_state"
+)
+
+// ErrorCode returns the machine-readable code of the error. If the error
+// implements the following interface, ErrorCode returns the return value of
+// Code method:
+//
+//	interface {
+//		Code() string
+//	}
+//
+// When the error doesn't have a code but IsNotExist returns true for it,
+// ErrCodeNotFound is returned. Otherwise, it returns an empty string.
+func ErrorCode(err error) string {
+	type coder interface {
+		Code() string
+	}
+
+	if c, ok := err.(coder); ok {
+		if code := c.Code(); code != "" {
+			return code
+		}
+	}
+	if IsNotExist(err) {
+		return ErrCodeNotFound
+	}
+	return ""
+}
+
+// Code returns the code shared by all errors, or an empty string when they
+// have different codes.
+func (b *bulkErrors) Code() string {
+	code := ""
+	for i, e := range b.errs {
+		c := ErrorCode(e)
+		if i == 0 {
+			code = c
+		} else if c != code {
+			return ""
+		}
+	}
+	return code
+}
+
+func (n *notExistError) Code() string {
+	return ErrCodeNotFound
+}
+
+type codedError struct {
+	code string
+	err  error
+}
+
+func (c *codedError) Error() string {
+	return c.err.Error()
+}
+
+func (c *codedError) Code() string {
+	return c.code
+}
+
+func (c *codedError) Fatal() bool {
+	return IsFatalError(c.err)
+}
+
+func (c *codedError) Temporary() bool {
+	return IsTemporaryError(c.err)
+}
+
+func (c *codedError) NotExist() bool {
+	return IsNotExist(c.err)
+}
+
+// CodedError decorates the given error so that ErrorCode returns the code.
+// IsFatalError, IsTemporaryError, and IsNotExist return the same values for
+// the decorated error as for err. It will panic if err is nil.
+func CodedError(code string, err error) error {
+	if err == nil {
+		panic(fmt.Errorf("the error cannot be nil"))
+	}
+	return &codedError{
+		code: code,
+		err:  err,
+	}
+}
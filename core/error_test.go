@@ -225,3 +225,56 @@ func TestNotExistError(t *testing.T) {
 		})
 	})
 }
+
+func TestErrorCode(t *testing.T) {
+	Convey("Given an error", t, func() {
+		Convey("When the error is nil or doesn't have a code", func() {
+			Convey("Then the code should be empty", func() {
+				So(ErrorCode(nil), ShouldBeEmpty)
+				So(ErrorCode(errors.New("test failure")), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When the error is a not exist error", func() {
+			Convey("Then the code should be not_found", func() {
+				So(ErrorCode(NotExistError(errors.New("test failure"))), ShouldEqual, ErrCodeNotFound)
+				So(ErrorCode(os.ErrNotExist), ShouldEqual, ErrCodeNotFound)
+			})
+		})
+
+		Convey("When the error is decorated with a code", func() {
+			err := CodedError(ErrCodeInvalidState, FatalError(errors.New("test failure")))
+
+			Convey("Then it should have the code", func() {
+				So(ErrorCode(err), ShouldEqual, ErrCodeInvalidState)
+			})
+
+			Convey("Then it should keep properties of the original error", func() {
+				So(IsFatalError(err), ShouldBeTrue)
+				So(IsTemporaryError(err), ShouldBeFalse)
+				So(err.Error(), ShouldEqual, "test failure")
+			})
+
+			Convey("Then CodedError should panic with nil", func() {
+				So(func() {
+					CodedError(ErrCodeInvalidState, nil)
+				}, ShouldPanic)
+			})
+		})
+
+		Convey("When the error has multiple errors", func() {
+			b := &bulkErrors{}
+			b.append(CodedError(ErrCodeAlreadyExists, errors.New("a")))
+			b.append(CodedError(ErrCodeAlreadyExists, errors.New("b")))
+
+			Convey("Then it should have the code shared by all errors", func() {
+				So(ErrorCode(b), ShouldEqual, ErrCodeAlreadyExists)
+			})
+
+			Convey("Then it shouldn't have a code when errors have different codes", func() {
+				b.append(errors.New("c"))
+				So(ErrorCode(b), ShouldBeEmpty)
+			})
+		})
+	})
+}
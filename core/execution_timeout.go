@@ -0,0 +1,49 @@
+package core
+
+import (
+	"fmt"
+	"time"
+)
+
+// ExecutionTimeoutConfig has default timeouts of executing UDFs and
+// processing tuples in Boxes created by BQL statements. Nodes can override
+// them by their own parameters.
+//
+// Timeouts are measured by the wall clock regardless of the Clock of the
+// Context since they limit how long processing takes.
+type ExecutionTimeoutConfig struct {
+	// UDF is the maximum duration of a single call of a UDF. A call running
+	// longer than it is abandoned and fails with TimeoutError. UDF calls
+	// don't time out when it's 0.
+	UDF time.Duration
+
+	// Box is the maximum duration of processing a single tuple in a Box.
+	// Boxes don't time out when it's 0.
+	Box time.Duration
+
+	// ErrorOutput controls what happens when a timeout occurs in a Box. When
+	// it's false, the Box stops with a fatal error. When it's true, the tuple
+	// being processed is reported as a dropped tuple with TimeoutError and
+	// the Box continues to process subsequent tuples.
+	ErrorOutput bool
+}
+
+// TimeoutError is returned when a UDF call or processing of a tuple in a Box
+// doesn't finish within its timeout.
+type TimeoutError struct {
+	// Target describes what timed out, e.g. "function 'f'" or "box 'b'".
+	Target string
+
+	// Timeout is the timeout which has been exceeded.
+	Timeout time.Duration
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("%v didn't finish within %v", e.Target, e.Timeout)
+}
+
+// IsTimeoutError returns true when the given error is a TimeoutError.
+func IsTimeoutError(err error) bool {
+	_, ok := err.(*TimeoutError)
+	return ok
+}
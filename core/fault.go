@@ -0,0 +1,86 @@
+package core
+
+// FaultPoint is a point in a node at which a FaultInjector can inject a
+// fault.
+type FaultPoint int
+
+const (
+	// FPProcess is the point just before a box or a sink processes an input
+	// tuple.
+	FPProcess FaultPoint = iota
+
+	// FPWrite is the point just before a source or a box writes an output
+	// tuple to its destinations.
+	FPWrite
+)
+
+func (p FaultPoint) String() string {
+	switch p {
+	case FPProcess:
+		return "process"
+	case FPWrite:
+		return "write"
+	default:
+		return "unknown"
+	}
+}
+
+// FaultInjector injects faults into nodes of a topology. It's only for
+// testing recovery and backpressure of topologies and must not be used in
+// production. The faultinject package provides an implementation which
+// injects faults by names of nodes.
+type FaultInjector interface {
+	// Inject is called at the point p of a node every time the node
+	// processes or writes a tuple. It can sleep to add latency, block to
+	// stall the node, or panic as if the node panicked. When it returns an
+	// error, the tuple isn't processed or written and the error is returned
+	// to the caller instead. Inject is called concurrently from multiple
+	// nodes.
+	Inject(ctx *Context, p FaultPoint, nodeType NodeType, nodeName string, t *Tuple) error
+}
+
+// faultInjectingWriter calls a FaultInjector before writing a tuple.
+type faultInjectingWriter struct {
+	w        Writer
+	fi       FaultInjector
+	point    FaultPoint
+	nodeType NodeType
+	nodeName string
+}
+
+// newFaultInjectingWriter returns w as is when the Context doesn't have
+// a FaultInjector.
+func newFaultInjectingWriter(ctx *Context, w Writer, p FaultPoint, nodeType NodeType, nodeName string) Writer {
+	if ctx.faultInjector == nil {
+		return w
+	}
+	return &faultInjectingWriter{
+		w:        w,
+		fi:       ctx.faultInjector,
+		point:    p,
+		nodeType: nodeType,
+		nodeName: nodeName,
+	}
+}
+
+// newFaultInjectingWriteCloser is newFaultInjectingWriter for WriteCloser.
+func newFaultInjectingWriteCloser(ctx *Context, w WriteCloser, p FaultPoint, nodeType NodeType, nodeName string) WriteCloser {
+	if ctx.faultInjector == nil {
+		return w
+	}
+	return newFaultInjectingWriter(ctx, w, p, nodeType, nodeName).(*faultInjectingWriter)
+}
+
+func (fw *faultInjectingWriter) Write(ctx *Context, t *Tuple) error {
+	if err := fw.fi.Inject(ctx, fw.point, fw.nodeType, fw.nodeName, t); err != nil {
+		return err
+	}
+	return fw.w.Write(ctx, t)
+}
+
+func (fw *faultInjectingWriter) Close(ctx *Context) error {
+	if c, ok := fw.w.(WriteCloser); ok {
+		return c.Close(ctx)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"sync"
+	"testing"
+)
+
+// recordingFaultInjector records points at which it's called and returns
+// an error at the point of the node given by failAt.
+type recordingFaultInjector struct {
+	m      sync.Mutex
+	points []string
+	failAt string
+}
+
+func (fi *recordingFaultInjector) Inject(ctx *Context, p FaultPoint, nodeType NodeType, nodeName string, t *Tuple) error {
+	fi.m.Lock()
+	defer fi.m.Unlock()
+	point := fmt.Sprintf("%v:%v:%v", nodeType, nodeName, p)
+	fi.points = append(fi.points, point)
+	if point == fi.failAt {
+		return errors.New("injected")
+	}
+	return nil
+}
+
+func (fi *recordingFaultInjector) recorded() []string {
+	fi.m.Lock()
+	defer fi.m.Unlock()
+	return append([]string{}, fi.points...)
+}
+
+func TestFaultInjector(t *testing.T) {
+	Convey("Given a topology having a fault injector", t, func() {
+		fi := &recordingFaultInjector{}
+		tp, err := NewDefaultTopology(NewContext(&ContextConfig{
+			FaultInjector: fi,
+		}), "test")
+		So(err, ShouldBeNil)
+		Reset(func() {
+			tp.Stop()
+		})
+
+		so := NewTupleEmitterSource([]*Tuple{NewTuple(data.Map{"v": data.Int(1)})})
+		src, err := tp.AddSource("source", so, &SourceConfig{PausedOnStartup: true})
+		So(err, ShouldBeNil)
+		bn, err := tp.AddBox("box", BoxFunc(forwardBox), nil)
+		So(err, ShouldBeNil)
+		So(bn.Input("source", nil), ShouldBeNil)
+		si := NewTupleCollectorSink()
+		sn, err := tp.AddSink("sink", si, nil)
+		So(err, ShouldBeNil)
+		So(sn.Input("box", nil), ShouldBeNil)
+
+		Convey("When a tuple flows through the topology", func() {
+			So(src.Resume(), ShouldBeNil)
+			si.Wait(1)
+
+			Convey("Then the injector should be called at each point", func() {
+				So(fi.recorded(), ShouldResemble, []string{
+					"source:source:write",
+					"box:box:process",
+					"box:box:write",
+					"sink:sink:process",
+				})
+			})
+		})
+
+		Convey("When the injector returns an error at the box", func() {
+			fi.failAt = "box:box:write"
+			So(src.Resume(), ShouldBeNil)
+			src.State().Wait(TSStopped)
+			So(tp.Stop(), ShouldBeNil)
+
+			Convey("Then the tuple shouldn't reach the sink", func() {
+				So(si.len(), ShouldEqual, 0)
+				So(fi.recorded(), ShouldNotContain, "sink:sink:process")
+			})
+		})
+	})
+}
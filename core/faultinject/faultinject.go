@@ -0,0 +1,293 @@
+// Package faultinject provides a core.FaultInjector which injects faults
+// such as write errors, panics, latency, and stalls into nodes selected by
+// their names. It's intended for chaos-style integration tests of recovery
+// and backpressure of topologies:
+//
+//	inj := faultinject.New()
+//	ctx := core.NewContext(&core.ContextConfig{FaultInjector: inj})
+//	t, _ := core.NewDefaultTopology(ctx, "test")
+//	...
+//	id := inj.Add(&faultinject.Rule{
+//		Node:  "box1",
+//		Point: core.FPWrite,
+//		Fault: faultinject.Error(errors.New("injected write error")),
+//	})
+//	...
+//	inj.Remove(id)
+//
+// Rules can be added and removed while the topology is running.
+package faultinject
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+)
+
+// Fault is a fault injected into a node.
+type Fault interface {
+	// Inject injects the fault into a node which is processing or writing
+	// the tuple. Returning an error makes the node fail to process or write
+	// the tuple.
+	Inject(ctx *core.Context, t *core.Tuple) error
+}
+
+// FaultFunc is a Fault implemented by a function.
+type FaultFunc func(ctx *core.Context, t *core.Tuple) error
+
+// Inject calls the function.
+func (f FaultFunc) Inject(ctx *core.Context, t *core.Tuple) error {
+	return f(ctx, t)
+}
+
+// Error returns a Fault which always returns the error. At core.FPWrite, it
+// simulates an error of writing a tuple to a pipe.
+func Error(err error) Fault {
+	return FaultFunc(func(ctx *core.Context, t *core.Tuple) error {
+		return err
+	})
+}
+
+// Panic returns a Fault which panics with the value as if the node
+// panicked.
+func Panic(v interface{}) Fault {
+	return FaultFunc(func(ctx *core.Context, t *core.Tuple) error {
+		panic(v)
+	})
+}
+
+// Latency returns a Fault which delays processing or writing a tuple for
+// the duration. It waits on the clock of the Context so that tests can
+// control it with core.ManualClock.
+func Latency(d time.Duration) Fault {
+	return FaultFunc(func(ctx *core.Context, t *core.Tuple) error {
+		<-ctx.Clock().After(d)
+		return nil
+	})
+}
+
+// Stall is a Fault which blocks nodes until it's released. Injecting it at
+// core.FPWrite of a source stalls the source.
+type Stall struct {
+	released chan struct{}
+	once     sync.Once
+	waiting  int64
+}
+
+// NewStall creates a new Stall.
+func NewStall() *Stall {
+	return &Stall{
+		released: make(chan struct{}),
+	}
+}
+
+// Inject blocks until Release is called.
+func (s *Stall) Inject(ctx *core.Context, t *core.Tuple) error {
+	atomic.AddInt64(&s.waiting, 1)
+	defer atomic.AddInt64(&s.waiting, -1)
+	<-s.released
+	return nil
+}
+
+// Waiting returns the number of nodes currently blocked by the stall.
+func (s *Stall) Waiting() int {
+	return int(atomic.LoadInt64(&s.waiting))
+}
+
+// Release unblocks all nodes blocked by the stall. Once released, the
+// stall doesn't block anymore. It's safe to call Release multiple times.
+func (s *Stall) Release() {
+	s.once.Do(func() {
+		close(s.released)
+	})
+}
+
+// Rule specifies when and where a fault is injected.
+type Rule struct {
+	// Node is the name of the node into which the fault is injected. The
+	// name is case-insensitive. The fault is injected into all nodes when
+	// it's empty.
+	Node string
+
+	// NodeTypes limits types of nodes into which the fault is injected. All
+	// types of nodes are selected when it's empty.
+	NodeTypes []core.NodeType
+
+	// Point is the point in the node at which the fault is injected.
+	Point core.FaultPoint
+
+	// Fault is the fault to be injected.
+	Fault Fault
+
+	// Count is the maximum number of times the fault is injected. The rule
+	// is automatically removed after the fault is injected the number of
+	// times. It's unlimited when Count is 0.
+	Count int64
+
+	// Probability is the probability of injecting the fault each time the
+	// node reaches the point. The fault is always injected when it's 0.
+	Probability float64
+}
+
+func (r *Rule) validate() error {
+	if r.Fault == nil {
+		return fmt.Errorf("fault must be given")
+	}
+	if r.Count < 0 {
+		return fmt.Errorf("count must not be negative: %v", r.Count)
+	}
+	if r.Probability < 0 || r.Probability > 1 {
+		return fmt.Errorf("probability must be in [0, 1]: %v", r.Probability)
+	}
+	return nil
+}
+
+func (r *Rule) match(p core.FaultPoint, nodeType core.NodeType, nodeName string) bool {
+	if r.Point != p {
+		return false
+	}
+	if r.Node != "" && !strings.EqualFold(r.Node, nodeName) {
+		return false
+	}
+	if len(r.NodeTypes) == 0 {
+		return true
+	}
+	for _, t := range r.NodeTypes {
+		if t == nodeType {
+			return true
+		}
+	}
+	return false
+}
+
+type rule struct {
+	id   int64
+	rule Rule
+}
+
+// Injector is a core.FaultInjector which injects faults according to
+// rules.
+type Injector struct {
+	m      sync.RWMutex
+	nextID int64
+	rules  []*rule
+
+	// counts has the number of times faults of each rule were injected.
+	// It's kept after the rule is removed.
+	counts map[int64]int64
+}
+
+var _ core.FaultInjector = &Injector{}
+
+// New creates a new Injector which doesn't have any rule.
+func New() *Injector {
+	return &Injector{
+		counts: map[int64]int64{},
+	}
+}
+
+// Add adds a rule and returns its ID. It panics when the rule is invalid.
+func (i *Injector) Add(r *Rule) int64 {
+	if err := r.validate(); err != nil {
+		panic(fmt.Errorf("faultinject: invalid rule: %v", err))
+	}
+	i.m.Lock()
+	defer i.m.Unlock()
+	i.nextID++
+	i.rules = append(i.rules, &rule{
+		id:   i.nextID,
+		rule: *r,
+	})
+	return i.nextID
+}
+
+// Remove removes a rule having the ID. It doesn't release Stalls of the
+// rule, so nodes blocked by them keep being blocked until they're
+// released.
+func (i *Injector) Remove(id int64) {
+	i.m.Lock()
+	defer i.m.Unlock()
+	i.removeWithoutLock(id)
+}
+
+func (i *Injector) removeWithoutLock(id int64) {
+	for n, r := range i.rules {
+		if r.id == id {
+			i.rules = append(i.rules[:n], i.rules[n+1:]...)
+			return
+		}
+	}
+}
+
+// Clear removes all rules.
+func (i *Injector) Clear() {
+	i.m.Lock()
+	defer i.m.Unlock()
+	i.rules = nil
+}
+
+// Injected returns the number of times the fault of the rule has been
+// injected. It's available even after the rule is removed.
+func (i *Injector) Injected(id int64) int64 {
+	i.m.RLock()
+	defer i.m.RUnlock()
+	return i.counts[id]
+}
+
+// Inject injects faults of rules matching the node. Faults are injected in
+// the order the rules were added until one of them returns an error.
+func (i *Injector) Inject(ctx *core.Context, p core.FaultPoint, nodeType core.NodeType, nodeName string, t *core.Tuple) error {
+	faults := i.matchingFaults(p, nodeType, nodeName)
+	for _, f := range faults {
+		if err := f.Inject(ctx, t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// matchingFaults returns faults to be injected and counts them. Faults are
+// injected without holding the lock because they can block.
+func (i *Injector) matchingFaults(p core.FaultPoint, nodeType core.NodeType, nodeName string) []Fault {
+	i.m.RLock()
+	matched := false
+	for _, r := range i.rules {
+		if r.rule.match(p, nodeType, nodeName) {
+			matched = true
+			break
+		}
+	}
+	i.m.RUnlock()
+	if !matched {
+		return nil
+	}
+
+	i.m.Lock()
+	defer i.m.Unlock()
+	var (
+		faults  []Fault
+		expired []int64
+	)
+	for _, r := range i.rules {
+		if !r.rule.match(p, nodeType, nodeName) {
+			continue
+		}
+		if r.rule.Probability > 0 && rand.Float64() >= r.rule.Probability {
+			continue
+		}
+		i.counts[r.id]++
+		faults = append(faults, r.rule.Fault)
+		if r.rule.Count > 0 && i.counts[r.id] >= r.rule.Count {
+			expired = append(expired, r.id)
+		}
+	}
+	for _, id := range expired {
+		i.removeWithoutLock(id)
+	}
+	return faults
+}
@@ -0,0 +1,250 @@
+package faultinject
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// sequenceSource emits n tuples having "n" from 1 to n and stops. It
+// starts emitting tuples after start is closed.
+type sequenceSource struct {
+	n     int
+	start chan struct{}
+	stop  chan struct{}
+	once  sync.Once
+}
+
+func newSequenceSource(n int) *sequenceSource {
+	return &sequenceSource{
+		n:     n,
+		start: make(chan struct{}),
+		stop:  make(chan struct{}),
+	}
+}
+
+func (s *sequenceSource) GenerateStream(ctx *core.Context, w core.Writer) error {
+	select {
+	case <-s.start:
+	case <-s.stop:
+		return nil
+	}
+	for i := 1; i <= s.n; i++ {
+		t := core.NewTuple(data.Map{"n": data.Int(i)})
+		if err := w.Write(ctx, t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *sequenceSource) Stop(ctx *core.Context) error {
+	s.once.Do(func() {
+		close(s.stop)
+	})
+	return nil
+}
+
+type collectorSink struct {
+	m  sync.Mutex
+	ns []int64
+}
+
+func (s *collectorSink) Write(ctx *core.Context, t *core.Tuple) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	n, _ := data.AsInt(t.Data["n"])
+	s.ns = append(s.ns, n)
+	return nil
+}
+
+func (s *collectorSink) Close(ctx *core.Context) error {
+	return nil
+}
+
+func (s *collectorSink) received() []int64 {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return append([]int64{}, s.ns...)
+}
+
+func TestInjector(t *testing.T) {
+	Convey("Given a topology having a fault injector", t, func() {
+		inj := New()
+		tp, err := core.NewDefaultTopology(core.NewContext(&core.ContextConfig{
+			FaultInjector: inj,
+		}), "test")
+		So(err, ShouldBeNil)
+		Reset(func() {
+			tp.Stop()
+		})
+
+		so := newSequenceSource(5)
+		src, err := tp.AddSource("source", so, nil)
+		So(err, ShouldBeNil)
+		bn, err := tp.AddBox("box", core.BoxFunc(func(ctx *core.Context, t *core.Tuple, w core.Writer) error {
+			return w.Write(ctx, t)
+		}), nil)
+		So(err, ShouldBeNil)
+		So(bn.Input("source", nil), ShouldBeNil)
+		si := &collectorSink{}
+		sn, err := tp.AddSink("sink", si, nil)
+		So(err, ShouldBeNil)
+		So(sn.Input("box", nil), ShouldBeNil)
+
+		// run starts the source and waits until the source stops and all
+		// tuples are processed by the box and the sink.
+		run := func() {
+			close(so.start)
+			src.State().Wait(core.TSStopped)
+			bn.State().Wait(core.TSStopped)
+			sn.State().Wait(core.TSStopped)
+		}
+		bn.StopOnDisconnect(core.Inbound)
+		sn.StopOnDisconnect()
+
+		Convey("When injecting a write error into the box once", func() {
+			id := inj.Add(&Rule{
+				Node:  "BOX",
+				Point: core.FPWrite,
+				Fault: Error(errors.New("injected")),
+				Count: 1,
+			})
+			run()
+
+			Convey("Then the sink should miss the first tuple", func() {
+				So(si.received(), ShouldResemble, []int64{2, 3, 4, 5})
+				So(inj.Injected(id), ShouldEqual, 1)
+			})
+		})
+
+		Convey("When injecting a panic into the box", func() {
+			inj.Add(&Rule{
+				Node:      "box",
+				NodeTypes: []core.NodeType{core.NTBox},
+				Point:     core.FPProcess,
+				Fault:     Panic("injected"),
+			})
+			close(so.start)
+
+			Convey("Then the box should stop", func() {
+				So(bn.State().Wait(core.TSStopped), ShouldEqual, core.TSStopped)
+				So(si.received(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When injecting a fault into a node of another type", func() {
+			id := inj.Add(&Rule{
+				Node:      "box",
+				NodeTypes: []core.NodeType{core.NTSink},
+				Point:     core.FPProcess,
+				Fault:     Panic("injected"),
+			})
+			run()
+
+			Convey("Then the fault shouldn't be injected", func() {
+				So(si.received(), ShouldResemble, []int64{1, 2, 3, 4, 5})
+				So(inj.Injected(id), ShouldEqual, 0)
+			})
+		})
+
+		Convey("When injecting latency into the sink", func() {
+			inj.Add(&Rule{
+				Node:  "sink",
+				Point: core.FPProcess,
+				Fault: Latency(10 * time.Millisecond),
+			})
+			start := time.Now()
+			run()
+
+			Convey("Then processing tuples should be delayed", func() {
+				So(si.received(), ShouldHaveLength, 5)
+				So(time.Now().Sub(start), ShouldBeGreaterThanOrEqualTo, 50*time.Millisecond)
+			})
+		})
+
+		Convey("When stalling the source", func() {
+			stall := NewStall()
+			Reset(stall.Release)
+			id := inj.Add(&Rule{
+				Node:  "source",
+				Point: core.FPWrite,
+				Fault: stall,
+			})
+			close(so.start)
+			for stall.Waiting() == 0 {
+				time.Sleep(time.Millisecond)
+			}
+
+			Convey("Then the sink shouldn't receive any tuple", func() {
+				So(si.received(), ShouldBeEmpty)
+			})
+
+			Convey("Then the sink should receive all tuples after releasing it", func() {
+				inj.Remove(id)
+				stall.Release()
+				src.State().Wait(core.TSStopped)
+				bn.State().Wait(core.TSStopped)
+				sn.State().Wait(core.TSStopped)
+				So(si.received(), ShouldResemble, []int64{1, 2, 3, 4, 5})
+				So(inj.Injected(id), ShouldEqual, 1)
+			})
+		})
+
+		Convey("When clearing rules", func() {
+			inj.Add(&Rule{
+				Point: core.FPProcess,
+				Fault: Error(errors.New("injected")),
+			})
+			inj.Clear()
+			run()
+
+			Convey("Then no fault should be injected", func() {
+				So(si.received(), ShouldResemble, []int64{1, 2, 3, 4, 5})
+			})
+		})
+	})
+
+	Convey("Given a fault injector", t, func() {
+		inj := New()
+
+		Convey("When adding invalid rules", func() {
+			for i, r := range []*Rule{
+				{Point: core.FPWrite},
+				{Point: core.FPWrite, Fault: Error(errors.New("e")), Count: -1},
+				{Point: core.FPWrite, Fault: Error(errors.New("e")), Probability: 1.5},
+			} {
+				r := r
+
+				Convey(fmt.Sprintf("Then it should panic (%v)", i), func() {
+					So(func() { inj.Add(r) }, ShouldPanic)
+				})
+			}
+		})
+
+		Convey("When adding a rule having a probability", func() {
+			id := inj.Add(&Rule{
+				Point:       core.FPWrite,
+				Fault:       Error(errors.New("injected")),
+				Probability: 0.5,
+			})
+			failed := 0
+			for i := 0; i < 1000; i++ {
+				if inj.Inject(nil, core.FPWrite, core.NTSource, "source", nil) != nil {
+					failed++
+				}
+			}
+
+			Convey("Then the fault should be injected with the probability", func() {
+				So(failed, ShouldBeBetween, 300, 700)
+				So(inj.Injected(id), ShouldEqual, failed)
+			})
+		})
+	})
+}
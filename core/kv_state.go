@@ -0,0 +1,367 @@
+package core
+
+import (
+	"container/list"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// RetentionPolicy has limits of the size of a map-like SharedState. Entries
+// are evicted when any of the limits is exceeded. A zero value of each field
+// means that the state isn't limited by it.
+type RetentionPolicy struct {
+	// MaxEntries is the maximum number of entries. When the state has more
+	// entries than this, the least recently used entries are evicted.
+	MaxEntries int
+
+	// MaxAge is the maximum duration since an entry was last read or
+	// written. Entries older than this are removed.
+	MaxAge time.Duration
+
+	// MaxBytes is the maximum total size of entries in bytes. The size of an
+	// entry is the size of its key and value encoded in msgpack. When the
+	// state gets larger than this, the least recently used entries are
+	// evicted. An entry larger than MaxBytes is never kept.
+	MaxBytes int64
+}
+
+// Validate validates the policy.
+func (p *RetentionPolicy) Validate() error {
+	if p.MaxEntries < 0 {
+		return fmt.Errorf("max_entries must not be negative: %v", p.MaxEntries)
+	}
+	if p.MaxAge < 0 {
+		return fmt.Errorf("max_age must not be negative: %v", p.MaxAge)
+	}
+	if p.MaxBytes < 0 {
+		return fmt.Errorf("max_bytes must not be negative: %v", p.MaxBytes)
+	}
+	return nil
+}
+
+// ToMap returns the policy as data.Map. MaxAge is in seconds.
+func (p *RetentionPolicy) ToMap() data.Map {
+	return data.Map{
+		"max_entries": data.Int(p.MaxEntries),
+		"max_age":     data.Float(p.MaxAge.Seconds()),
+		"max_bytes":   data.Int(p.MaxBytes),
+	}
+}
+
+// KeyValueState is a SharedState having a map from a string key to a value.
+// The size of the map is limited by a RetentionPolicy so that the state
+// doesn't grow unboundedly when keys are, for example, IDs of devices.
+//
+// When the state is updated via SharedStateSink, the value at the key path of
+// the tuple is used as the key and the whole tuple data is stored as the
+// value.
+type KeyValueState struct {
+	keyPath data.Path
+	policy  RetentionPolicy
+
+	m          sync.Mutex
+	entries    map[string]*list.Element
+	lru        *list.List // the front is the most recently used entry.
+	bytes      int64
+	terminated bool
+
+	numEvictedByEntries int64
+	numEvictedByBytes   int64
+	numExpired          int64
+
+	log StateChangeLog
+
+	now func() time.Time
+}
+
+type kvEntry struct {
+	key      string
+	value    data.Value
+	size     int64
+	accessed time.Time
+}
+
+var (
+	_ Writer      = &KeyValueState{}
+	_ Statuser    = &KeyValueState{}
+	_ LoggedState = &KeyValueState{}
+)
+
+// NewKeyValueState creates a new KeyValueState. keyPath is used to get a key
+// from a tuple written to the state. It can be nil when the state isn't
+// updated by SharedStateSink.
+func NewKeyValueState(keyPath data.Path, policy *RetentionPolicy) (*KeyValueState, error) {
+	if policy == nil {
+		policy = &RetentionPolicy{}
+	}
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+	return &KeyValueState{
+		keyPath: keyPath,
+		policy:  *policy,
+		entries: map[string]*list.Element{},
+		lru:     list.New(),
+		now:     time.Now,
+	}, nil
+}
+
+// Get returns the value of the key. It returns false when the state doesn't
+// have the key.
+func (s *KeyValueState) Get(key string) (data.Value, bool) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	now := s.now()
+	s.expire(now)
+	e, ok := s.entries[key]
+	if !ok {
+		return nil, false
+	}
+	ent := e.Value.(*kvEntry)
+	ent.accessed = now
+	s.lru.MoveToFront(e)
+	return ent.value, true
+}
+
+// Put sets the value of the key. It may evict other entries to satisfy the
+// retention policy.
+func (s *KeyValueState) Put(key string, v data.Value) error {
+	var size int64
+	if s.policy.MaxBytes > 0 {
+		b, err := data.MarshalMsgpack(data.Map{key: v})
+		if err != nil {
+			return err
+		}
+		size = int64(len(b))
+	}
+
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.terminated {
+		return errors.New("the state is already terminated")
+	}
+	now := s.now()
+	s.expire(now)
+	if e, ok := s.entries[key]; ok {
+		s.remove(e)
+	}
+	s.entries[key] = s.lru.PushFront(&kvEntry{
+		key:      key,
+		value:    v,
+		size:     size,
+		accessed: now,
+	})
+	s.bytes += size
+	if s.log != nil {
+		s.log.Append(data.Map{
+			"op":    data.String("put"),
+			"key":   data.String(key),
+			"value": v,
+		})
+	}
+
+	for s.policy.MaxEntries > 0 && s.lru.Len() > s.policy.MaxEntries {
+		s.remove(s.lru.Back())
+		s.numEvictedByEntries++
+	}
+	for s.policy.MaxBytes > 0 && s.bytes > s.policy.MaxBytes {
+		s.remove(s.lru.Back())
+		s.numEvictedByBytes++
+	}
+	return nil
+}
+
+// Delete removes the key from the state. It returns false when the state
+// doesn't have the key.
+func (s *KeyValueState) Delete(key string) bool {
+	s.m.Lock()
+	defer s.m.Unlock()
+	e, ok := s.entries[key]
+	if !ok {
+		return false
+	}
+	s.remove(e)
+	if s.log != nil {
+		s.log.Append(data.Map{
+			"op":  data.String("delete"),
+			"key": data.String(key),
+		})
+	}
+	return true
+}
+
+// Len returns the number of entries in the state.
+func (s *KeyValueState) Len() int {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.expire(s.now())
+	return s.lru.Len()
+}
+
+// expire removes entries older than MaxAge. The caller must hold the lock.
+func (s *KeyValueState) expire(now time.Time) {
+	if s.policy.MaxAge <= 0 {
+		return
+	}
+	for e := s.lru.Back(); e != nil; e = s.lru.Back() {
+		if now.Sub(e.Value.(*kvEntry).accessed) <= s.policy.MaxAge {
+			break
+		}
+		s.remove(e)
+		s.numExpired++
+	}
+}
+
+// remove removes an entry. The caller must hold the lock.
+func (s *KeyValueState) remove(e *list.Element) {
+	ent := s.lru.Remove(e).(*kvEntry)
+	delete(s.entries, ent.key)
+	s.bytes -= ent.size
+}
+
+// Write puts the data of the tuple to the state with the value at the key
+// path as its key.
+func (s *KeyValueState) Write(ctx *Context, t *Tuple) error {
+	if s.keyPath == nil {
+		return errors.New("the state doesn't have a key path")
+	}
+	k, err := t.Data.Get(s.keyPath)
+	if err != nil {
+		return err
+	}
+	key, err := data.ToString(k)
+	if err != nil {
+		return err
+	}
+	return s.Put(key, t.Data.Copy())
+}
+
+// Terminate removes all entries in the state.
+func (s *KeyValueState) Terminate(ctx *Context) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.entries = map[string]*list.Element{}
+	s.lru.Init()
+	s.bytes = 0
+	s.terminated = true
+	s.log = nil
+	return nil
+}
+
+// LogChanges sends change records of the state to the log. A record has "op"
+// field which is one of following values:
+//
+//	- clear: removes all entries
+//	- put: sets "value" to "key"
+//	- delete: removes "key"
+//
+// The state first sends a clear record and put records of all entries from
+// the least recently used one. Entries evicted or expired by the retention
+// policy aren't sent because a replica has its own policy.
+func (s *KeyValueState) LogChanges(log StateChangeLog) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.terminated {
+		return
+	}
+	s.log = log
+	if log == nil {
+		return
+	}
+	s.expire(s.now())
+	log.Append(data.Map{"op": data.String("clear")})
+	for e := s.lru.Back(); e != nil; e = e.Prev() {
+		ent := e.Value.(*kvEntry)
+		log.Append(data.Map{
+			"op":    data.String("put"),
+			"key":   data.String(ent.key),
+			"value": ent.value,
+		})
+	}
+}
+
+// ApplyChange applies a change record sent from LogChanges of another
+// KeyValueState.
+func (s *KeyValueState) ApplyChange(ctx *Context, rec data.Map) error {
+	op, err := kvRecordString(rec, "op")
+	if err != nil {
+		return err
+	}
+
+	switch op {
+	case "clear":
+		s.m.Lock()
+		defer s.m.Unlock()
+		if s.terminated {
+			return errors.New("the state is already terminated")
+		}
+		s.entries = map[string]*list.Element{}
+		s.lru.Init()
+		s.bytes = 0
+		if s.log != nil {
+			s.log.Append(data.Map{"op": data.String("clear")})
+		}
+		return nil
+
+	case "put":
+		key, err := kvRecordString(rec, "key")
+		if err != nil {
+			return err
+		}
+		v, ok := rec["value"]
+		if !ok {
+			return errors.New("the record doesn't have value")
+		}
+		return s.Put(key, v)
+
+	case "delete":
+		key, err := kvRecordString(rec, "key")
+		if err != nil {
+			return err
+		}
+		s.Delete(key)
+		return nil
+
+	default:
+		return fmt.Errorf("unsupported operation: %v", op)
+	}
+}
+
+func kvRecordString(rec data.Map, field string) (string, error) {
+	v, ok := rec[field]
+	if !ok {
+		return "", fmt.Errorf("the record doesn't have %v", field)
+	}
+	s, err := data.AsString(v)
+	if err != nil {
+		return "", fmt.Errorf("%v of the record must be a string: %v", field, err)
+	}
+	return s, nil
+}
+
+// Status returns the status of the state. It has following fields:
+//
+//	- num_entries: the number of entries
+//	- bytes: the total size of entries in bytes. It's only computed when
+//	  the policy has MaxBytes
+//	- retention: the retention policy
+//	- num_evicted_by_entries: the number of entries evicted by MaxEntries
+//	- num_evicted_by_bytes: the number of entries evicted by MaxBytes
+//	- num_expired: the number of entries removed by MaxAge
+func (s *KeyValueState) Status() data.Map {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.expire(s.now())
+	return data.Map{
+		"num_entries":            data.Int(s.lru.Len()),
+		"bytes":                  data.Int(s.bytes),
+		"retention":              s.policy.ToMap(),
+		"num_evicted_by_entries": data.Int(s.numEvictedByEntries),
+		"num_evicted_by_bytes":   data.Int(s.numEvictedByBytes),
+		"num_expired":            data.Int(s.numExpired),
+	}
+}
@@ -0,0 +1,248 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+func TestKeyValueState(t *testing.T) {
+	Convey("Given a key-value state without any limit", t, func() {
+		s, err := NewKeyValueState(data.MustCompilePath("id"), nil)
+		So(err, ShouldBeNil)
+
+		Convey("When writing tuples", func() {
+			for i := 0; i < 3; i++ {
+				So(s.Write(nil, NewTuple(data.Map{"id": data.Int(i), "v": data.Int(i * 10)})), ShouldBeNil)
+			}
+
+			Convey("Then it should have all entries", func() {
+				So(s.Len(), ShouldEqual, 3)
+				v, ok := s.Get("1")
+				So(ok, ShouldBeTrue)
+				So(v, ShouldResemble, data.Map{"id": data.Int(1), "v": data.Int(10)})
+			})
+
+			Convey("And deleting an entry", func() {
+				So(s.Delete("1"), ShouldBeTrue)
+
+				Convey("Then it shouldn't have the entry", func() {
+					_, ok := s.Get("1")
+					So(ok, ShouldBeFalse)
+					So(s.Delete("1"), ShouldBeFalse)
+				})
+			})
+		})
+
+		Convey("When writing a tuple without the key", func() {
+			err := s.Write(nil, NewTuple(data.Map{"v": data.Int(1)}))
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When writing after termination", func() {
+			So(s.Terminate(nil), ShouldBeNil)
+			err := s.Put("a", data.Int(1))
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+
+	Convey("Given a key-value state with max entries", t, func() {
+		s, err := NewKeyValueState(nil, &RetentionPolicy{MaxEntries: 2})
+		So(err, ShouldBeNil)
+		So(s.Put("a", data.Int(1)), ShouldBeNil)
+		So(s.Put("b", data.Int(2)), ShouldBeNil)
+
+		Convey("When putting more entries after reading an old entry", func() {
+			_, ok := s.Get("a")
+			So(ok, ShouldBeTrue)
+			So(s.Put("c", data.Int(3)), ShouldBeNil)
+
+			Convey("Then the least recently used entry should be evicted", func() {
+				So(s.Len(), ShouldEqual, 2)
+				_, ok := s.Get("b")
+				So(ok, ShouldBeFalse)
+				_, ok = s.Get("a")
+				So(ok, ShouldBeTrue)
+			})
+
+			Convey("Then the status should have the number of evicted entries", func() {
+				st := s.Status()
+				So(st["num_entries"], ShouldEqual, 2)
+				So(st["num_evicted_by_entries"], ShouldEqual, 1)
+				So(st["retention"], ShouldResemble, data.Map{
+					"max_entries": data.Int(2),
+					"max_age":     data.Float(0),
+					"max_bytes":   data.Int(0),
+				})
+			})
+		})
+
+		Convey("When updating an existing entry", func() {
+			So(s.Put("a", data.Int(10)), ShouldBeNil)
+
+			Convey("Then nothing should be evicted", func() {
+				So(s.Len(), ShouldEqual, 2)
+				So(s.Status()["num_evicted_by_entries"], ShouldEqual, 0)
+			})
+		})
+	})
+
+	Convey("Given a key-value state with max age", t, func() {
+		s, err := NewKeyValueState(nil, &RetentionPolicy{MaxAge: time.Minute})
+		So(err, ShouldBeNil)
+		now := time.Now()
+		s.now = func() time.Time { return now }
+		So(s.Put("a", data.Int(1)), ShouldBeNil)
+		now = now.Add(30 * time.Second)
+		So(s.Put("b", data.Int(2)), ShouldBeNil)
+
+		Convey("When the first entry gets old", func() {
+			now = now.Add(40 * time.Second)
+
+			Convey("Then it should be expired", func() {
+				_, ok := s.Get("a")
+				So(ok, ShouldBeFalse)
+				_, ok = s.Get("b")
+				So(ok, ShouldBeTrue)
+				So(s.Status()["num_expired"], ShouldEqual, 1)
+			})
+		})
+	})
+
+	Convey("Given a key-value state with max bytes", t, func() {
+		s, err := NewKeyValueState(nil, &RetentionPolicy{MaxBytes: 20})
+		So(err, ShouldBeNil)
+
+		Convey("When putting entries larger than the limit in total", func() {
+			So(s.Put("a", data.String("0123456789")), ShouldBeNil)
+			So(s.Put("b", data.String("0123456789")), ShouldBeNil)
+
+			Convey("Then old entries should be evicted", func() {
+				So(s.Len(), ShouldEqual, 1)
+				st := s.Status()
+				So(st["bytes"], ShouldBeLessThanOrEqualTo, 20)
+				So(st["num_evicted_by_bytes"], ShouldEqual, 1)
+			})
+		})
+
+		Convey("When putting an entry larger than the limit", func() {
+			So(s.Put("a", data.String("0123456789012345678901234567890123456789")), ShouldBeNil)
+
+			Convey("Then it shouldn't be kept", func() {
+				So(s.Len(), ShouldEqual, 0)
+				So(s.Status()["bytes"], ShouldEqual, 0)
+			})
+		})
+	})
+
+	Convey("Given an invalid retention policy", t, func() {
+		_, err := NewKeyValueState(nil, &RetentionPolicy{MaxEntries: -1})
+
+		Convey("Then the state shouldn't be created", func() {
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+type testStateChangeLog struct {
+	recs []data.Map
+}
+
+func (l *testStateChangeLog) Append(rec data.Map) {
+	l.recs = append(l.recs, rec)
+}
+
+func TestKeyValueStateLogChanges(t *testing.T) {
+	Convey("Given a key-value state having entries", t, func() {
+		s, err := NewKeyValueState(nil, nil)
+		So(err, ShouldBeNil)
+		So(s.Put("a", data.Int(1)), ShouldBeNil)
+		So(s.Put("b", data.Int(2)), ShouldBeNil)
+
+		Convey("When starting logging changes", func() {
+			l := &testStateChangeLog{}
+			s.LogChanges(l)
+
+			Convey("Then the log should receive the current entries", func() {
+				So(l.recs, ShouldResemble, []data.Map{
+					{"op": data.String("clear")},
+					{"op": data.String("put"), "key": data.String("a"), "value": data.Int(1)},
+					{"op": data.String("put"), "key": data.String("b"), "value": data.Int(2)},
+				})
+			})
+
+			Convey("And mutating the state", func() {
+				l.recs = nil
+				So(s.Put("c", data.Int(3)), ShouldBeNil)
+				So(s.Delete("a"), ShouldBeTrue)
+				So(s.Delete("x"), ShouldBeFalse)
+
+				Convey("Then the log should receive the mutations", func() {
+					So(l.recs, ShouldResemble, []data.Map{
+						{"op": data.String("put"), "key": data.String("c"), "value": data.Int(3)},
+						{"op": data.String("delete"), "key": data.String("a")},
+					})
+				})
+			})
+
+			Convey("And stopping logging", func() {
+				l.recs = nil
+				s.LogChanges(nil)
+				So(s.Put("c", data.Int(3)), ShouldBeNil)
+
+				Convey("Then the log shouldn't receive anything", func() {
+					So(l.recs, ShouldBeEmpty)
+				})
+			})
+		})
+
+		Convey("When applying records to a replica having other entries", func() {
+			r, err := NewKeyValueState(nil, nil)
+			So(err, ShouldBeNil)
+			So(r.Put("z", data.Int(26)), ShouldBeNil)
+
+			l := &testStateChangeLog{}
+			s.LogChanges(l)
+			So(s.Put("c", data.Int(3)), ShouldBeNil)
+			So(s.Delete("b"), ShouldBeTrue)
+			for _, rec := range l.recs {
+				So(r.ApplyChange(nil, rec), ShouldBeNil)
+			}
+
+			Convey("Then the replica should have the same entries", func() {
+				So(r.Len(), ShouldEqual, 2)
+				v, ok := r.Get("a")
+				So(ok, ShouldBeTrue)
+				So(v, ShouldEqual, data.Int(1))
+				v, ok = r.Get("c")
+				So(ok, ShouldBeTrue)
+				So(v, ShouldEqual, data.Int(3))
+			})
+		})
+
+		Convey("When applying invalid records", func() {
+			recs := []data.Map{
+				{},
+				{"op": data.Int(1)},
+				{"op": data.String("merge")},
+				{"op": data.String("put"), "value": data.Int(1)},
+				{"op": data.String("put"), "key": data.String("a")},
+				{"op": data.String("delete")},
+			}
+
+			Convey("Then they should fail", func() {
+				for _, rec := range recs {
+					So(s.ApplyChange(nil, rec), ShouldNotBeNil)
+				}
+			})
+		})
+	})
+}
@@ -0,0 +1,130 @@
+package core
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	labelRegexp = regexp.MustCompile("^[a-zA-Z0-9][a-zA-Z0-9_.-]*$")
+)
+
+// ValidateLabel validates if the given string can be used as a label of a
+// node. The maximum length of a label is 63. The format has to be
+// [a-zA-Z0-9][a-zA-Z0-9_.-]*. Labels are case-insensitive.
+func ValidateLabel(label string) error {
+	if l := len(label); l < 1 {
+		return fmt.Errorf("the label is empty")
+	} else if l > 63 {
+		return fmt.Errorf("the label can be at most 63 letters: %v", len(label))
+	}
+	if !labelRegexp.MatchString(label) {
+		return fmt.Errorf("the label doesn't follow the format [a-zA-Z0-9][a-zA-Z0-9_.-]*: %v", label)
+	}
+	return nil
+}
+
+// normalizeLabels validates labels and returns a sorted copy of them in
+// lower case without duplicates.
+func normalizeLabels(labels []string) ([]string, error) {
+	if len(labels) == 0 {
+		return nil, nil
+	}
+	m := make(map[string]struct{}, len(labels))
+	for _, l := range labels {
+		if err := ValidateLabel(l); err != nil {
+			return nil, err
+		}
+		m[strings.ToLower(l)] = struct{}{}
+	}
+	res := make([]string, 0, len(m))
+	for l := range m {
+		res = append(res, l)
+	}
+	sort.Strings(res)
+	return res, nil
+}
+
+// LabelSelector selects nodes by their labels. A node matches the selector
+// when it has all the required labels and doesn't have any of the excluded
+// labels. An empty selector matches all nodes.
+type LabelSelector struct {
+	// Required has labels which nodes must have.
+	Required []string
+
+	// Excluded has labels which nodes must not have.
+	Excluded []string
+}
+
+// ParseLabelSelector parses a comma-separated list of labels. A label
+// prefixed with '!' is excluded. For example, "ingest,!v1" selects nodes
+// having "ingest" label but not having "v1" label.
+func ParseLabelSelector(s string) (*LabelSelector, error) {
+	sel := &LabelSelector{}
+	for _, l := range strings.Split(s, ",") {
+		l = strings.TrimSpace(l)
+		if l == "" {
+			continue
+		}
+		exclude := strings.HasPrefix(l, "!")
+		if exclude {
+			l = strings.TrimSpace(l[1:])
+		}
+		if err := ValidateLabel(l); err != nil {
+			return nil, err
+		}
+		if exclude {
+			sel.Excluded = append(sel.Excluded, strings.ToLower(l))
+		} else {
+			sel.Required = append(sel.Required, strings.ToLower(l))
+		}
+	}
+	return sel, nil
+}
+
+// Matches returns true when the labels satisfy the selector.
+func (s *LabelSelector) Matches(labels []string) bool {
+	has := func(label string) bool {
+		for _, l := range labels {
+			if strings.EqualFold(l, label) {
+				return true
+			}
+		}
+		return false
+	}
+	for _, l := range s.Required {
+		if !has(l) {
+			return false
+		}
+	}
+	for _, l := range s.Excluded {
+		if has(l) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *LabelSelector) String() string {
+	ls := make([]string, 0, len(s.Required)+len(s.Excluded))
+	ls = append(ls, s.Required...)
+	for _, l := range s.Excluded {
+		ls = append(ls, "!"+l)
+	}
+	return strings.Join(ls, ",")
+}
+
+// SelectNodes returns nodes in the topology matching the selector. Keys of
+// the returned map are names of nodes in lower case as Topology.Nodes
+// returns.
+func SelectNodes(t Topology, sel *LabelSelector) map[string]Node {
+	m := t.Nodes()
+	for name, n := range m {
+		if !sel.Matches(n.Labels()) {
+			delete(m, name)
+		}
+	}
+	return m
+}
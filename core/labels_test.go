@@ -0,0 +1,98 @@
+package core
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestLabelSelector(t *testing.T) {
+	Convey("Given a label selector", t, func() {
+		sel, err := ParseLabelSelector(" ingest, !V1 ")
+		So(err, ShouldBeNil)
+
+		Convey("Then it should have required and excluded labels", func() {
+			So(sel.Required, ShouldResemble, []string{"ingest"})
+			So(sel.Excluded, ShouldResemble, []string{"v1"})
+			So(sel.String(), ShouldEqual, "ingest,!v1")
+		})
+
+		Convey("Then it should match labels", func() {
+			So(sel.Matches([]string{"ingest"}), ShouldBeTrue)
+			So(sel.Matches([]string{"Ingest", "v2"}), ShouldBeTrue)
+			So(sel.Matches([]string{"ingest", "v1"}), ShouldBeFalse)
+			So(sel.Matches(nil), ShouldBeFalse)
+		})
+	})
+
+	Convey("Given an empty label selector", t, func() {
+		sel, err := ParseLabelSelector("")
+		So(err, ShouldBeNil)
+
+		Convey("Then it should match any labels", func() {
+			So(sel.Matches(nil), ShouldBeTrue)
+			So(sel.Matches([]string{"a"}), ShouldBeTrue)
+		})
+	})
+
+	Convey("Given invalid label selectors", t, func() {
+		for _, s := range []string{"!", "a b", "-a", "a,!!b"} {
+			_, err := ParseLabelSelector(s)
+
+			Convey("Then parsing it should fail: "+s, func() {
+				So(err, ShouldNotBeNil)
+			})
+		}
+	})
+}
+
+func TestNodeLabels(t *testing.T) {
+	Convey("Given a default topology", t, func() {
+		dt, err := NewDefaultTopology(NewContext(nil), "dt1")
+		So(err, ShouldBeNil)
+		Reset(func() {
+			dt.Stop()
+		})
+
+		Convey("When adding nodes with labels", func() {
+			_, err := dt.AddSource("src", &DoesNothingSource{}, &SourceConfig{
+				Labels: []string{"v1", "Ingest", "ingest"},
+			})
+			So(err, ShouldBeNil)
+			_, err = dt.AddBox("box", &DoesNothingBox{}, &BoxConfig{
+				Labels: []string{"v2"},
+			})
+			So(err, ShouldBeNil)
+			sink, err := dt.AddSink("sink", &DoesNothingSink{}, nil)
+			So(err, ShouldBeNil)
+
+			Convey("Then nodes should have normalized labels", func() {
+				src, err := dt.Source("src")
+				So(err, ShouldBeNil)
+				So(src.Labels(), ShouldResemble, []string{"ingest", "v1"})
+				So(sink.Labels(), ShouldBeEmpty)
+			})
+
+			Convey("Then nodes should be selected by labels", func() {
+				sel, err := ParseLabelSelector("!v1")
+				So(err, ShouldBeNil)
+				ns := SelectNodes(dt, sel)
+				So(ns, ShouldHaveLength, 2)
+				So(ns, ShouldContainKey, "box")
+				So(ns, ShouldContainKey, "sink")
+			})
+		})
+
+		Convey("When adding a node with an invalid label", func() {
+			si := &sinkCloseChecker{s: &DoesNothingSink{}}
+			_, err := dt.AddSink("sink", si, &SinkConfig{
+				Labels: []string{"not valid"},
+			})
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+				So(si.closeCnt, ShouldEqual, 1)
+			})
+		})
+	})
+}
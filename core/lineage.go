@@ -0,0 +1,169 @@
+package core
+
+import (
+	"sync"
+	"time"
+)
+
+// LineageConfig has parameters of lineage recording. When it's given to a
+// Context, Boxes aggregating or joining tuples record IDs of input tuples
+// which contributed to each output tuple while tuple tracing is enabled.
+// The IDs are added to the trace of the output tuple and kept in the
+// LineageStore of the Context so that the lineage of a tuple arriving at a
+// sink can be traced back to tuples emitted from sources.
+//
+// Only tuples having IDs are recorded. See ContextConfig.TupleIDGenerator.
+//
+// Zero or invalid values in the config are replaced with default values.
+type LineageConfig struct {
+	// MaxInputs is the maximum number of IDs of input tuples recorded for
+	// an output tuple. The lineage is truncated when more tuples
+	// contributed. The default value is 100.
+	MaxInputs int
+
+	// MaxRecords is the maximum number of records kept in the store. The
+	// oldest record is discarded when a new record exceeds the limit. The
+	// default value is 10000.
+	MaxRecords int
+}
+
+func (c *LineageConfig) withDefaults() *LineageConfig {
+	conf := *c
+	if conf.MaxInputs <= 0 {
+		conf.MaxInputs = 100
+	}
+	if conf.MaxRecords <= 0 {
+		conf.MaxRecords = 10000
+	}
+	return &conf
+}
+
+// Lineage has IDs of input tuples which contributed to an output tuple of a
+// Box.
+type Lineage struct {
+	// Inputs are IDs of the input tuples. Tuples without IDs aren't
+	// included.
+	Inputs []string
+
+	// Truncated is true when more tuples contributed than the limit.
+	Truncated bool
+}
+
+// Add adds an ID of an input tuple to the lineage unless it's already
+// included. It doesn't add more than max IDs. An empty ID is ignored.
+func (l *Lineage) Add(id string, max int) {
+	if id == "" {
+		return
+	}
+	for _, i := range l.Inputs {
+		if i == id {
+			return
+		}
+	}
+	if len(l.Inputs) >= max {
+		l.Truncated = true
+		return
+	}
+	l.Inputs = append(l.Inputs, id)
+}
+
+// Merge adds all IDs in the other lineage to the lineage.
+func (l *Lineage) Merge(o *Lineage, max int) {
+	if o == nil {
+		return
+	}
+	for _, id := range o.Inputs {
+		l.Add(id, max)
+	}
+	if o.Truncated {
+		l.Truncated = true
+	}
+}
+
+// LineageRecord is a record of the lineage of a tuple emitted from a node.
+type LineageRecord struct {
+	// TupleID is the ID of the output tuple.
+	TupleID string
+
+	// Node is the name of the node which emitted the tuple.
+	Node string
+
+	// Timestamp is the time when the tuple was emitted.
+	Timestamp time.Time
+
+	// Inputs are IDs of the input tuples which contributed to the tuple.
+	Inputs []string
+
+	// Truncated is true when more tuples contributed than
+	// LineageConfig.MaxInputs.
+	Truncated bool
+}
+
+// LineageStore keeps a bounded number of LineageRecords of a topology. It's
+// safe for concurrent use.
+type LineageStore struct {
+	m         sync.RWMutex
+	maxInputs int
+	records   []*LineageRecord
+	next      int
+	byID      map[string][]*LineageRecord
+}
+
+func newLineageStore(conf *LineageConfig) *LineageStore {
+	return &LineageStore{
+		maxInputs: conf.MaxInputs,
+		records:   make([]*LineageRecord, 0, conf.MaxRecords),
+		byID:      map[string][]*LineageRecord{},
+	}
+}
+
+// MaxInputs returns the maximum number of IDs of input tuples which should
+// be recorded for an output tuple.
+func (s *LineageStore) MaxInputs() int {
+	return s.maxInputs
+}
+
+// Add adds a record to the store. It discards the oldest record when the
+// store is full.
+func (s *LineageStore) Add(r *LineageRecord) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if len(s.records) < cap(s.records) {
+		s.records = append(s.records, r)
+	} else {
+		old := s.records[s.next]
+		s.records[s.next] = r
+		s.next = (s.next + 1) % len(s.records)
+
+		// Records of the same ID are added in order, so the oldest one is
+		// always at the head.
+		rs := s.byID[old.TupleID]
+		if len(rs) <= 1 {
+			delete(s.byID, old.TupleID)
+		} else {
+			s.byID[old.TupleID] = rs[1:]
+		}
+	}
+	s.byID[r.TupleID] = append(s.byID[r.TupleID], r)
+}
+
+// Lookup returns records of the tuple having the ID in the order they were
+// added. A tuple can have multiple records when it passed through multiple
+// nodes recording the lineage. It returns an empty slice when the store
+// doesn't have a record of the tuple.
+func (s *LineageStore) Lookup(id string) []*LineageRecord {
+	s.m.RLock()
+	defer s.m.RUnlock()
+	rs := s.byID[id]
+	res := make([]*LineageRecord, len(rs))
+	copy(res, rs)
+	return res
+}
+
+// Len returns the number of records in the store.
+func (s *LineageStore) Len() int {
+	s.m.RLock()
+	defer s.m.RUnlock()
+	return len(s.records)
+}
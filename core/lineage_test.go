@@ -0,0 +1,55 @@
+package core
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestLineageStore(t *testing.T) {
+	Convey("Given a lineage store", t, func() {
+		s := newLineageStore((&LineageConfig{MaxRecords: 3}).withDefaults())
+		So(s.MaxInputs(), ShouldEqual, 100)
+
+		Convey("When adding records", func() {
+			s.Add(&LineageRecord{TupleID: "a", Node: "b1", Inputs: []string{"x"}})
+			s.Add(&LineageRecord{TupleID: "b", Node: "b1", Inputs: []string{"y"}})
+			s.Add(&LineageRecord{TupleID: "a", Node: "b2", Inputs: []string{"z"}})
+
+			Convey("Then records should be looked up by the tuple ID", func() {
+				rs := s.Lookup("a")
+				So(len(rs), ShouldEqual, 2)
+				So(rs[0].Node, ShouldEqual, "b1")
+				So(rs[1].Node, ShouldEqual, "b2")
+				So(s.Lookup("c"), ShouldBeEmpty)
+			})
+
+			Convey("Then the oldest record should be discarded when the store is full", func() {
+				s.Add(&LineageRecord{TupleID: "c", Node: "b1"})
+				So(s.Len(), ShouldEqual, 3)
+				rs := s.Lookup("a")
+				So(len(rs), ShouldEqual, 1)
+				So(rs[0].Node, ShouldEqual, "b2")
+
+				s.Add(&LineageRecord{TupleID: "d", Node: "b1"})
+				So(s.Lookup("b"), ShouldBeEmpty)
+				So(len(s.Lookup("c")), ShouldEqual, 1)
+			})
+		})
+	})
+
+	Convey("Given a lineage", t, func() {
+		l := &Lineage{}
+
+		Convey("When adding IDs beyond the limit", func() {
+			for _, id := range []string{"a", "", "b", "a", "c"} {
+				l.Add(id, 2)
+			}
+
+			Convey("Then it should be truncated", func() {
+				So(l.Inputs, ShouldResemble, []string{"a", "b"})
+				So(l.Truncated, ShouldBeTrue)
+			})
+		})
+	})
+}
@@ -0,0 +1,142 @@
+package core
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MetricType is the type of a Metric.
+type MetricType string
+
+const (
+	// MetricCounter is a value which only increases.
+	MetricCounter MetricType = "counter"
+
+	// MetricGauge is a value which can arbitrarily go up and down.
+	MetricGauge MetricType = "gauge"
+
+	// MetricHistogram is a distribution of observed values counted in
+	// buckets.
+	MetricHistogram MetricType = "histogram"
+)
+
+// Metric is a sample of a metric having a set of labels.
+type Metric struct {
+	// Name is the name of the metric. Metrics having the same name must
+	// have the same type.
+	Name string
+
+	Type   MetricType
+	Labels map[string]string
+
+	// Value is the value of a counter or a gauge.
+	Value float64
+
+	// Buckets are buckets of a histogram sorted by their upper bounds.
+	// Counts of buckets are cumulative as in Prometheus. A bucket for +Inf
+	// is implicit and has Count.
+	Buckets []MetricBucket
+
+	// Sum and Count are the sum and the number of observed values of
+	// a histogram.
+	Sum   float64
+	Count int64
+}
+
+// MetricBucket is a bucket of a histogram.
+type MetricBucket struct {
+	UpperBound float64
+	Count      int64
+}
+
+// MetricsExporter is a SharedState which exports metrics. The server exposes
+// metrics of all states implementing this interface.
+type MetricsExporter interface {
+	SharedState
+
+	// Metrics returns the current samples of metrics the state has.
+	Metrics() []*Metric
+}
+
+// WriteMetrics writes metrics in the Prometheus text exposition format.
+// Samples are grouped by the names of metrics. It fails when metrics having
+// the same name have different types.
+func WriteMetrics(w io.Writer, ms []*Metric) error {
+	families := map[string][]*Metric{}
+	names := []string{}
+	for _, m := range ms {
+		f, ok := families[m.Name]
+		if !ok {
+			names = append(names, m.Name)
+		} else if f[0].Type != m.Type {
+			return fmt.Errorf("metric '%v' has different types: %v and %v", m.Name, f[0].Type, m.Type)
+		}
+		families[m.Name] = append(f, m)
+	}
+	sort.Strings(names)
+
+	bw := bufio.NewWriter(w)
+	for _, n := range names {
+		f := families[n]
+		fmt.Fprintf(bw, "# TYPE %v %v\n", n, f[0].Type)
+		for _, m := range f {
+			if m.Type != MetricHistogram {
+				writeSample(bw, n, m.Labels, "", "", m.Value)
+				continue
+			}
+			for _, b := range m.Buckets {
+				writeSample(bw, n+"_bucket", m.Labels, "le", formatMetricValue(b.UpperBound), float64(b.Count))
+			}
+			writeSample(bw, n+"_bucket", m.Labels, "le", "+Inf", float64(m.Count))
+			writeSample(bw, n+"_sum", m.Labels, "", "", m.Sum)
+			writeSample(bw, n+"_count", m.Labels, "", "", float64(m.Count))
+		}
+	}
+	return bw.Flush()
+}
+
+// writeSample writes a line of a sample. extraLabel is added to labels when
+// it isn't empty.
+func writeSample(w io.Writer, name string, labels map[string]string, extraLabel, extraValue string, v float64) {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys)+1)
+	for _, k := range keys {
+		pairs = append(pairs, k+"="+quoteLabelValue(labels[k]))
+	}
+	if extraLabel != "" {
+		pairs = append(pairs, extraLabel+"="+quoteLabelValue(extraValue))
+	}
+	if len(pairs) == 0 {
+		fmt.Fprintf(w, "%v %v\n", name, formatMetricValue(v))
+		return
+	}
+	fmt.Fprintf(w, "%v{%v} %v\n", name, strings.Join(pairs, ","), formatMetricValue(v))
+}
+
+var labelValueReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func quoteLabelValue(s string) string {
+	return `"` + labelValueReplacer.Replace(s) + `"`
+}
+
+func formatMetricValue(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
@@ -0,0 +1,47 @@
+package core
+
+import (
+	"bytes"
+	. "github.com/smartystreets/goconvey/convey"
+	"math"
+	"testing"
+)
+
+func TestWriteMetrics(t *testing.T) {
+	Convey("Given metrics", t, func() {
+		ms := []*Metric{
+			{Name: "orders_total", Type: MetricCounter, Labels: map[string]string{"region": `e"u`}, Value: 3},
+			{Name: "latency", Type: MetricHistogram, Buckets: []MetricBucket{{0.5, 1}, {1, 3}}, Sum: 2.5, Count: 4},
+			{Name: "orders_total", Type: MetricCounter, Value: 1},
+			{Name: "temperature", Type: MetricGauge, Value: math.Inf(-1)},
+		}
+
+		Convey("When writing them", func() {
+			var buf bytes.Buffer
+			So(WriteMetrics(&buf, ms), ShouldBeNil)
+
+			Convey("Then they should be written in the text exposition format", func() {
+				So(buf.String(), ShouldEqual, `# TYPE latency histogram
+latency_bucket{le="0.5"} 1
+latency_bucket{le="1"} 3
+latency_bucket{le="+Inf"} 4
+latency_sum 2.5
+latency_count 4
+# TYPE orders_total counter
+orders_total{region="e\"u"} 3
+orders_total 1
+# TYPE temperature gauge
+temperature -Inf
+`)
+			})
+		})
+
+		Convey("When metrics having the same name have different types", func() {
+			ms = append(ms, &Metric{Name: "temperature", Type: MetricCounter})
+
+			Convey("Then writing them should fail", func() {
+				So(WriteMetrics(&bytes.Buffer{}, ms), ShouldNotBeNil)
+			})
+		})
+	})
+}
@@ -179,6 +179,10 @@ type Node interface {
 	// not protected from concurrent writes and the caller has to care about it.
 	Meta() interface{}
 
+	// Labels returns labels attached to the node. Labels are sorted and
+	// in lower case. The caller must not modify the returned slice.
+	Labels() []string
+
 	// RemoveOnStop tells the Node that it may automatically remove from the
 	// topology when it stops.
 	RemoveOnStop()
@@ -192,7 +196,8 @@ type SourceNode interface {
 	Source() Source
 
 	// Pause pauses a running source. A paused source can be resumed by calling
-	// Resume method. Pause is idempotent.
+	// Resume method. Pause is idempotent. When a running source is paused,
+	// sinks of the topology are flushed (see Flusher).
 	Pause() error
 
 	// Resume resumes a paused source. Resume is idempotent.
@@ -295,6 +300,10 @@ type BoxInputConfig struct {
 	// DropMode is a mode which controls the behavior of dropping tuples at the
 	// output side of the queue when it is full.
 	DropMode QueueDropMode
+
+	// ReportDrops makes the input log every tuple dropped due to DropMode as
+	// a warning even if dropped tuple logging is disabled in the Context.
+	ReportDrops bool
 }
 
 // Validate validates values of BoxInputConfig.
@@ -325,6 +334,11 @@ type SinkNode interface {
 	// Sink returns internal source passed to Topology.AddSink.
 	Sink() Sink
 
+	// Flush flushes the Sink when it implements Flusher. It does nothing
+	// otherwise. Flush waits for a tuple being written to the Sink and
+	// returns an error if the Sink has already been closed.
+	Flush() error
+
 	// Input adds a new input from a Source or a Box. refname refers a name of
 	// node from which the Box want to receive tuples. There must be a Source
 	// or a Box having the name.
@@ -347,6 +361,15 @@ type SinkNode interface {
 // SinkInputConfig has parameters to customize input behavior of a Sink on
 // each input pipe.
 type SinkInputConfig struct {
+	// InputName is a custom name attached to incoming tuples. When it is empty,
+	// "output" will be used.
+	InputName string
+
+	// RoutingField is the name of a field to which InputName is written. When
+	// a sink has multiple inputs, this field can be used to tell where a tuple
+	// came from. The field isn't added when RoutingField is empty.
+	RoutingField string
+
 	// Capacity is the maximum capacity (length) of input pipe. When this
 	// parameter is 0, the default value is used. This parameter is only used
 	// as a hint and doesn't guarantee that the pipe can actually have the
@@ -356,6 +379,10 @@ type SinkInputConfig struct {
 	// DropMode is a mode which controls the behavior of dropping tuples at the
 	// output side of the queue when it is full.
 	DropMode QueueDropMode
+
+	// ReportDrops makes the input log every tuple dropped due to DropMode as
+	// a warning even if dropped tuple logging is disabled in the Context.
+	ReportDrops bool
 }
 
 // Validate validates values of SinkInputConfig.
@@ -363,6 +390,13 @@ func (c *SinkInputConfig) Validate() error {
 	return validateCapacity(c.Capacity)
 }
 
+func (c *SinkInputConfig) inputName() string {
+	if c.InputName == "" {
+		return "output"
+	}
+	return c.InputName
+}
+
 func (c *SinkInputConfig) capacity() int {
 	if c.Capacity == 0 {
 		return 1024
@@ -0,0 +1,423 @@
+package core
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// MetricRegistry has metrics which sources, boxes, sinks, and UDFs used by
+// them export to monitor their own operations. Metrics are scoped to nodes:
+// a component registers its metrics to the NodeMetrics of its node, which is
+// obtained by Node with the name of the node (e.g. IOParams.Name given to
+// creators of BQL sources and sinks). Metrics of a node are shown in the
+// status of the node and exposed by the server in the Prometheus text
+// exposition format with "topology" and "node" labels. They're removed when
+// the node is removed from the topology.
+//
+// A plugin can export its metrics as follows:
+//
+//	func createSource(ctx *core.Context, ioParams *bql.IOParams, params data.Map) (core.Source, error) {
+//		m := ctx.Metrics().Node(ioParams.Name)
+//		received, err := m.Counter("messages_received_total", nil)
+//		if err != nil {
+//			return nil, err
+//		}
+//		...
+//	}
+type MetricRegistry struct {
+	m     sync.RWMutex
+	nodes map[string]*NodeMetrics
+}
+
+func newMetricRegistry() *MetricRegistry {
+	return &MetricRegistry{
+		nodes: map[string]*NodeMetrics{},
+	}
+}
+
+// Node returns metrics of the node having the name. The node doesn't have
+// to exist yet so that metrics can be registered while creating a node.
+func (r *MetricRegistry) Node(name string) *NodeMetrics {
+	lowerName := strings.ToLower(name)
+	r.m.RLock()
+	n, ok := r.nodes[lowerName]
+	r.m.RUnlock()
+	if ok {
+		return n
+	}
+
+	r.m.Lock()
+	defer r.m.Unlock()
+	if n, ok := r.nodes[lowerName]; ok {
+		return n
+	}
+	n = &NodeMetrics{
+		node:   name,
+		types:  map[string]MetricType{},
+		series: map[string]nodeMetric{},
+	}
+	r.nodes[lowerName] = n
+	return n
+}
+
+// Metrics returns samples of metrics of all nodes. Each sample has "node"
+// label having the name of its node.
+func (r *MetricRegistry) Metrics() []*Metric {
+	r.m.RLock()
+	nodes := make([]*NodeMetrics, 0, len(r.nodes))
+	for _, n := range r.nodes {
+		nodes = append(nodes, n)
+	}
+	r.m.RUnlock()
+
+	var res []*Metric
+	for _, n := range nodes {
+		for _, m := range n.Metrics() {
+			labels := make(map[string]string, len(m.Labels)+1)
+			for k, v := range m.Labels {
+				labels[k] = v
+			}
+			labels["node"] = n.node
+			m.Labels = labels
+			res = append(res, m)
+		}
+	}
+	return res
+}
+
+// status returns the status of metrics of the node. It returns nil when the
+// node doesn't have any metric.
+func (r *MetricRegistry) status(name string) data.Array {
+	r.m.RLock()
+	n, ok := r.nodes[strings.ToLower(name)]
+	r.m.RUnlock()
+	if !ok {
+		return nil
+	}
+	return n.status()
+}
+
+// remove removes all metrics of the node.
+func (r *MetricRegistry) remove(name string) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	delete(r.nodes, strings.ToLower(name))
+}
+
+var (
+	metricNamePattern  = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+	metricLabelPattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+	// reservedNodeMetricLabels are labels added by histograms, the registry,
+	// and the server.
+	reservedNodeMetricLabels = map[string]bool{"le": true, "node": true, "topology": true}
+
+	// DefaultMetricBuckets are upper bounds of buckets of histograms used
+	// when no bucket is given. They're the same as Prometheus' default
+	// buckets.
+	DefaultMetricBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+)
+
+// nodeMetric is a Counter, a Gauge, or a Histogram.
+type nodeMetric interface {
+	sample() *Metric
+}
+
+// NodeMetrics has metrics of a node. Registering a metric having the same
+// name and labels as a registered one returns the registered metric, so
+// multiple components of a node can update the same metric. All methods are
+// thread-safe.
+type NodeMetrics struct {
+	node string
+
+	m      sync.Mutex
+	types  map[string]MetricType
+	series map[string]nodeMetric
+}
+
+// Counter registers a counter, which only increases. labels can be nil.
+func (n *NodeMetrics) Counter(name string, labels map[string]string) (*Counter, error) {
+	m, err := n.register(name, MetricCounter, labels, func(meta *Metric) nodeMetric {
+		return &Counter{meta: meta}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return m.(*Counter), nil
+}
+
+// Gauge registers a gauge, which can arbitrarily go up and down. labels can
+// be nil.
+func (n *NodeMetrics) Gauge(name string, labels map[string]string) (*Gauge, error) {
+	m, err := n.register(name, MetricGauge, labels, func(meta *Metric) nodeMetric {
+		return &Gauge{meta: meta}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return m.(*Gauge), nil
+}
+
+// Histogram registers a histogram counting observed values in buckets having
+// the upper bounds. DefaultMetricBuckets is used when buckets is empty.
+// Buckets of a registered histogram aren't changed. labels can be nil.
+func (n *NodeMetrics) Histogram(name string, labels map[string]string, buckets []float64) (*Histogram, error) {
+	if len(buckets) == 0 {
+		buckets = DefaultMetricBuckets
+	}
+	for i := 1; i < len(buckets); i++ {
+		if buckets[i-1] >= buckets[i] {
+			return nil, fmt.Errorf("buckets must be sorted in increasing order: %v", buckets)
+		}
+	}
+	m, err := n.register(name, MetricHistogram, labels, func(meta *Metric) nodeMetric {
+		h := &Histogram{
+			meta:    meta,
+			buckets: make([]MetricBucket, len(buckets)),
+		}
+		for i, b := range buckets {
+			h.buckets[i].UpperBound = b
+		}
+		return h
+	})
+	if err != nil {
+		return nil, err
+	}
+	return m.(*Histogram), nil
+}
+
+func (n *NodeMetrics) register(name string, typ MetricType, labels map[string]string,
+	create func(meta *Metric) nodeMetric) (nodeMetric, error) {
+	if !metricNamePattern.MatchString(name) {
+		return nil, fmt.Errorf("invalid metric name: %v", name)
+	}
+	ls := make(map[string]string, len(labels))
+	for k, v := range labels {
+		if !metricLabelPattern.MatchString(k) || strings.HasPrefix(k, "__") {
+			return nil, fmt.Errorf("invalid label name of metric '%v': %v", name, k)
+		}
+		if reservedNodeMetricLabels[k] {
+			return nil, fmt.Errorf("label '%v' of metric '%v' is reserved", k, name)
+		}
+		ls[k] = v
+	}
+
+	n.m.Lock()
+	defer n.m.Unlock()
+	if t, ok := n.types[name]; ok && t != typ {
+		return nil, fmt.Errorf("metric '%v' is a %v, not a %v", name, t, typ)
+	}
+	key := nodeMetricKey(name, ls)
+	if m, ok := n.series[key]; ok {
+		return m, nil
+	}
+	m := create(&Metric{
+		Name:   name,
+		Type:   typ,
+		Labels: ls,
+	})
+	n.types[name] = typ
+	n.series[key] = m
+	return m, nil
+}
+
+func nodeMetricKey(name string, labels map[string]string) string {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := []string{name}
+	for _, k := range keys {
+		parts = append(parts, k, labels[k])
+	}
+	return strings.Join(parts, "\xff")
+}
+
+// Metrics returns samples of all metrics of the node sorted by their names
+// and labels.
+func (n *NodeMetrics) Metrics() []*Metric {
+	n.m.Lock()
+	keys := make([]string, 0, len(n.series))
+	for k := range n.series {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	series := make([]nodeMetric, len(keys))
+	for i, k := range keys {
+		series[i] = n.series[k]
+	}
+	n.m.Unlock()
+
+	res := make([]*Metric, len(series))
+	for i, m := range series {
+		res[i] = m.sample()
+	}
+	return res
+}
+
+// status returns samples of metrics as an array of maps having "name",
+// "type", "labels", and values of the metric. It returns nil when the node
+// doesn't have any metric.
+func (n *NodeMetrics) status() data.Array {
+	ms := n.Metrics()
+	if len(ms) == 0 {
+		return nil
+	}
+	res := make(data.Array, len(ms))
+	for i, m := range ms {
+		labels := make(data.Map, len(m.Labels))
+		for k, v := range m.Labels {
+			labels[k] = data.String(v)
+		}
+		s := data.Map{
+			"name":   data.String(m.Name),
+			"type":   data.String(string(m.Type)),
+			"labels": labels,
+		}
+		if m.Type == MetricHistogram {
+			buckets := make(data.Array, len(m.Buckets))
+			for j, b := range m.Buckets {
+				buckets[j] = data.Map{
+					"le":    data.Float(b.UpperBound),
+					"count": data.Int(b.Count),
+				}
+			}
+			s["buckets"] = buckets
+			s["sum"] = data.Float(m.Sum)
+			s["count"] = data.Int(m.Count)
+		} else {
+			s["value"] = data.Float(m.Value)
+		}
+		res[i] = s
+	}
+	return res
+}
+
+// atomicFloat64 is a float64 which can be updated atomically.
+type atomicFloat64 struct {
+	bits uint64
+}
+
+func (f *atomicFloat64) load() float64 {
+	return math.Float64frombits(atomic.LoadUint64(&f.bits))
+}
+
+func (f *atomicFloat64) store(v float64) {
+	atomic.StoreUint64(&f.bits, math.Float64bits(v))
+}
+
+func (f *atomicFloat64) add(v float64) {
+	for {
+		old := atomic.LoadUint64(&f.bits)
+		n := math.Float64bits(math.Float64frombits(old) + v)
+		if atomic.CompareAndSwapUint64(&f.bits, old, n) {
+			return
+		}
+	}
+}
+
+// Counter is a metric which only increases.
+type Counter struct {
+	meta  *Metric
+	value atomicFloat64
+}
+
+// Inc increments the counter by 1.
+func (c *Counter) Inc() {
+	c.value.add(1)
+}
+
+// Add adds v to the counter. A negative value is ignored because a counter
+// cannot decrease.
+func (c *Counter) Add(v float64) {
+	if v < 0 {
+		return
+	}
+	c.value.add(v)
+}
+
+// Value returns the current value of the counter.
+func (c *Counter) Value() float64 {
+	return c.value.load()
+}
+
+func (c *Counter) sample() *Metric {
+	m := *c.meta
+	m.Value = c.Value()
+	return &m
+}
+
+// Gauge is a metric which can arbitrarily go up and down.
+type Gauge struct {
+	meta  *Metric
+	value atomicFloat64
+}
+
+// Set sets the value of the gauge.
+func (g *Gauge) Set(v float64) {
+	g.value.store(v)
+}
+
+// Add adds v, which can be negative, to the gauge.
+func (g *Gauge) Add(v float64) {
+	g.value.add(v)
+}
+
+// Value returns the current value of the gauge.
+func (g *Gauge) Value() float64 {
+	return g.value.load()
+}
+
+func (g *Gauge) sample() *Metric {
+	m := *g.meta
+	m.Value = g.Value()
+	return &m
+}
+
+// Histogram is a metric counting observed values in buckets.
+type Histogram struct {
+	meta *Metric
+
+	m sync.Mutex
+	// buckets aren't cumulative.
+	buckets []MetricBucket
+	sum     float64
+	count   int64
+}
+
+// Observe adds a value to the histogram.
+func (h *Histogram) Observe(v float64) {
+	h.m.Lock()
+	defer h.m.Unlock()
+	for i := range h.buckets {
+		if v <= h.buckets[i].UpperBound {
+			h.buckets[i].Count++
+			break
+		}
+	}
+	h.sum += v
+	h.count++
+}
+
+func (h *Histogram) sample() *Metric {
+	h.m.Lock()
+	defer h.m.Unlock()
+	m := *h.meta
+	m.Buckets = make([]MetricBucket, len(h.buckets))
+	var n int64
+	for i, b := range h.buckets {
+		n += b.Count
+		m.Buckets[i] = MetricBucket{UpperBound: b.UpperBound, Count: n}
+	}
+	m.Sum = h.sum
+	m.Count = h.count
+	return &m
+}
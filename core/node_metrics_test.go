@@ -0,0 +1,123 @@
+package core
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+func TestNodeMetrics(t *testing.T) {
+	Convey("Given metrics of a node", t, func() {
+		ctx := NewContext(nil)
+		n := ctx.Metrics().Node("Node1")
+
+		Convey("When registering metrics", func() {
+			c, err := n.Counter("requests_total", map[string]string{"code": "200"})
+			So(err, ShouldBeNil)
+			g, err := n.Gauge("queue_length", nil)
+			So(err, ShouldBeNil)
+			h, err := n.Histogram("latency_seconds", nil, []float64{0.1, 1})
+			So(err, ShouldBeNil)
+
+			c.Inc()
+			c.Add(2)
+			c.Add(-1)
+			g.Set(10)
+			g.Add(-3)
+			h.Observe(0.05)
+			h.Observe(0.5)
+			h.Observe(5)
+
+			Convey("Then they should have updated values", func() {
+				So(c.Value(), ShouldEqual, 3)
+				So(g.Value(), ShouldEqual, 7)
+			})
+
+			Convey("Then registering the same metric should return the registered one", func() {
+				c2, err := ctx.Metrics().Node("node1").Counter("requests_total", map[string]string{"code": "200"})
+				So(err, ShouldBeNil)
+				So(c2, ShouldEqual, c)
+			})
+
+			Convey("Then registering a metric having the same name with a different type should fail", func() {
+				_, err := n.Gauge("requests_total", nil)
+				So(err, ShouldNotBeNil)
+			})
+
+			Convey("Then the registry should return samples having the node label", func() {
+				ms := ctx.Metrics().Metrics()
+				So(len(ms), ShouldEqual, 3)
+				So(ms[0].Name, ShouldEqual, "latency_seconds")
+				So(ms[0].Labels, ShouldResemble, map[string]string{"node": "Node1"})
+				So(ms[0].Buckets, ShouldResemble, []MetricBucket{
+					{UpperBound: 0.1, Count: 1},
+					{UpperBound: 1, Count: 2},
+				})
+				So(ms[0].Count, ShouldEqual, 3)
+				So(ms[0].Sum, ShouldEqual, 5.55)
+				So(ms[2].Name, ShouldEqual, "requests_total")
+				So(ms[2].Labels, ShouldResemble, map[string]string{"node": "Node1", "code": "200"})
+				So(ms[2].Value, ShouldEqual, 3)
+			})
+
+			Convey("Then the status should have the metrics", func() {
+				st := ctx.Metrics().status("NODE1")
+				So(len(st), ShouldEqual, 3)
+				So(st[1], ShouldResemble, data.Map{
+					"name":   data.String("queue_length"),
+					"type":   data.String("gauge"),
+					"labels": data.Map{},
+					"value":  data.Float(7),
+				})
+			})
+		})
+
+		Convey("When registering metrics having invalid names or labels", func() {
+			Convey("Then it should fail", func() {
+				_, err := n.Counter("invalid-name", nil)
+				So(err, ShouldNotBeNil)
+				_, err = n.Counter("c", map[string]string{"invalid-label": "a"})
+				So(err, ShouldNotBeNil)
+				_, err = n.Counter("c", map[string]string{"node": "a"})
+				So(err, ShouldNotBeNil)
+				_, err = n.Histogram("h", nil, []float64{1, 0.5})
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+
+	Convey("Given a topology having a node with metrics", t, func() {
+		dt, err := NewDefaultTopology(NewContext(nil), "dt1")
+		So(err, ShouldBeNil)
+		Reset(func() {
+			dt.Stop()
+		})
+		sin, err := dt.AddSink("sink", NewTupleCollectorSink(), nil)
+		So(err, ShouldBeNil)
+		c, err := dt.Context().Metrics().Node("sink").Counter("written_total", nil)
+		So(err, ShouldBeNil)
+		c.Inc()
+
+		Convey("When getting the status of the node", func() {
+			st := sin.Status()
+
+			Convey("Then it should have the metrics", func() {
+				So(st["metrics"], ShouldResemble, data.Array{data.Map{
+					"name":   data.String("written_total"),
+					"type":   data.String("counter"),
+					"labels": data.Map{},
+					"value":  data.Float(1),
+				}})
+			})
+		})
+
+		Convey("When removing the node", func() {
+			So(dt.Remove("sink"), ShouldBeNil)
+
+			Convey("Then its metrics should be removed", func() {
+				So(dt.Context().Metrics().Metrics(), ShouldBeEmpty)
+			})
+		})
+	})
+}
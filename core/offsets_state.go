@@ -0,0 +1,291 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// OffsetsState is a SharedState having the progress of sources, such as byte
+// offsets of files, offsets of Kafka partitions, or cursors of HTTP APIs.
+// Each source records its offsets as a data.Map whose format is defined by
+// the source. The state can be saved by SAVE STATE together with other states
+// of the topology so that sources created after LOAD STATE resume from the
+// saved progress.
+//
+// Sources usually access the state through SourceOffsets rather than using
+// it directly. Names of sources are case-insensitive.
+type OffsetsState struct {
+	m          sync.RWMutex
+	offsets    map[string]*sourceOffsetsEntry
+	terminated bool
+}
+
+type sourceOffsetsEntry struct {
+	source    string
+	offsets   data.Map
+	updatedAt time.Time
+}
+
+var (
+	_ LoadableSharedState = &OffsetsState{}
+	_ Statuser            = &OffsetsState{}
+)
+
+// offsetsStateFormatVersion is the version of the format of saved data.
+const offsetsStateFormatVersion = 1
+
+// NewOffsetsState creates a new empty OffsetsState.
+func NewOffsetsState() *OffsetsState {
+	return &OffsetsState{
+		offsets: map[string]*sourceOffsetsEntry{},
+	}
+}
+
+// Get returns a copy of offsets of the source. It returns false when the
+// source hasn't committed offsets yet or its offsets have been reset.
+func (s *OffsetsState) Get(source string) (data.Map, bool) {
+	s.m.RLock()
+	defer s.m.RUnlock()
+	e, ok := s.offsets[strings.ToLower(source)]
+	if !ok {
+		return nil, false
+	}
+	return e.offsets.Copy(), true
+}
+
+// Commit replaces offsets of the source with a copy of the given offsets.
+func (s *OffsetsState) Commit(source string, offsets data.Map) error {
+	if offsets == nil {
+		return errors.New("offsets must not be nil")
+	}
+	e := &sourceOffsetsEntry{
+		source:    source,
+		offsets:   offsets.Copy(),
+		updatedAt: time.Now(),
+	}
+
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.terminated {
+		return errors.New("the state is already terminated")
+	}
+	s.offsets[strings.ToLower(source)] = e
+	return nil
+}
+
+// Reset removes offsets of the source so that the source starts from the
+// beginning, or from the position given by its parameters, when it's created
+// next time. It returns false when the state doesn't have offsets of the
+// source. Offsets committed by a running source after Reset are kept.
+func (s *OffsetsState) Reset(source string) bool {
+	s.m.Lock()
+	defer s.m.Unlock()
+	key := strings.ToLower(source)
+	if _, ok := s.offsets[key]; !ok {
+		return false
+	}
+	delete(s.offsets, key)
+	return true
+}
+
+// ToMap returns offsets of all sources. Each key is the name of a source and
+// its value is a map having "offsets" and "updated_at" fields.
+func (s *OffsetsState) ToMap() data.Map {
+	s.m.RLock()
+	defer s.m.RUnlock()
+	m := make(data.Map, len(s.offsets))
+	for _, e := range s.offsets {
+		m[e.source] = data.Map{
+			"offsets":    e.offsets.Copy(),
+			"updated_at": data.Timestamp(e.updatedAt),
+		}
+	}
+	return m
+}
+
+// Sources returns sorted names of sources having offsets.
+func (s *OffsetsState) Sources() []string {
+	s.m.RLock()
+	defer s.m.RUnlock()
+	names := make([]string, 0, len(s.offsets))
+	for _, e := range s.offsets {
+		names = append(names, e.source)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Terminate removes all offsets in the state.
+func (s *OffsetsState) Terminate(ctx *Context) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.offsets = map[string]*sourceOffsetsEntry{}
+	s.terminated = true
+	return nil
+}
+
+// Save writes offsets of all sources in msgpack.
+func (s *OffsetsState) Save(ctx *Context, w io.Writer, params data.Map) error {
+	b, err := data.EncodeMsgpack(data.Map{
+		"version": data.Int(offsetsStateFormatVersion),
+		"sources": s.ToMap(),
+	})
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+// Load overwrites offsets of all sources with ones saved by Save.
+func (s *OffsetsState) Load(ctx *Context, r io.Reader, params data.Map) error {
+	offsets, err := readOffsets(r)
+	if err != nil {
+		return err
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.terminated {
+		return errors.New("the state is already terminated")
+	}
+	s.offsets = offsets
+	return nil
+}
+
+// LoadOffsetsState creates a new OffsetsState from data saved by Save.
+func LoadOffsetsState(r io.Reader) (*OffsetsState, error) {
+	offsets, err := readOffsets(r)
+	if err != nil {
+		return nil, err
+	}
+	return &OffsetsState{
+		offsets: offsets,
+	}, nil
+}
+
+func readOffsets(r io.Reader) (map[string]*sourceOffsetsEntry, error) {
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	v, err := data.DecodeMsgpack(b)
+	if err != nil {
+		return nil, fmt.Errorf("the saved offsets are broken: %v", err)
+	}
+	m, err := data.AsMap(v)
+	if err != nil {
+		return nil, fmt.Errorf("the saved offsets are broken: %v", err)
+	}
+	if v, ok := m["version"]; !ok {
+		return nil, errors.New("the saved offsets don't have the version")
+	} else if ver, err := data.AsInt(v); err != nil || ver != offsetsStateFormatVersion {
+		return nil, fmt.Errorf("unsupported version of the saved offsets: %v", v)
+	}
+
+	sources := data.Map{}
+	if v, ok := m["sources"]; ok {
+		if sources, err = data.AsMap(v); err != nil {
+			return nil, fmt.Errorf("the saved offsets are broken: %v", err)
+		}
+	}
+	offsets := make(map[string]*sourceOffsetsEntry, len(sources))
+	for name, v := range sources {
+		src, err := data.AsMap(v)
+		if err != nil {
+			return nil, fmt.Errorf("offsets of source '%v' are broken: %v", name, err)
+		}
+		o, err := data.AsMap(src["offsets"])
+		if err != nil {
+			return nil, fmt.Errorf("offsets of source '%v' are broken: %v", name, err)
+		}
+		e := &sourceOffsetsEntry{
+			source:  name,
+			offsets: o,
+		}
+		if v, ok := src["updated_at"]; ok {
+			if e.updatedAt, err = data.AsTimestamp(v); err != nil {
+				return nil, fmt.Errorf("offsets of source '%v' are broken: %v", name, err)
+			}
+		}
+		offsets[strings.ToLower(name)] = e
+	}
+	return offsets, nil
+}
+
+// Status returns the status of the state. It has "num_sources" field.
+func (s *OffsetsState) Status() data.Map {
+	s.m.RLock()
+	defer s.m.RUnlock()
+	return data.Map{
+		"num_sources": data.Int(len(s.offsets)),
+	}
+}
+
+// SourceOffsets records the progress of a source to an OffsetsState. It looks
+// up the state by its name every time so that the source keeps working with
+// the state replaced by LOAD STATE.
+type SourceOffsets struct {
+	ctx    *Context
+	state  string
+	source string
+}
+
+// NewSourceOffsets returns SourceOffsets of the source using the
+// OffsetsState having the given name. It fails when the state doesn't exist
+// or isn't an OffsetsState.
+func NewSourceOffsets(ctx *Context, stateName, sourceName string) (*SourceOffsets, error) {
+	o := &SourceOffsets{
+		ctx:    ctx,
+		state:  stateName,
+		source: sourceName,
+	}
+	if _, err := o.lookup(); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+func (o *SourceOffsets) lookup() (*OffsetsState, error) {
+	st, err := o.ctx.SharedStates.Get(o.state)
+	if err != nil {
+		return nil, err
+	}
+	s, ok := st.(*OffsetsState)
+	if !ok {
+		return nil, fmt.Errorf("state '%v' isn't an offsets state", o.state)
+	}
+	return s, nil
+}
+
+// StateName returns the name of the OffsetsState.
+func (o *SourceOffsets) StateName() string {
+	return o.state
+}
+
+// Load returns offsets committed by the source. It returns false when the
+// source hasn't committed offsets yet.
+func (o *SourceOffsets) Load() (data.Map, bool, error) {
+	s, err := o.lookup()
+	if err != nil {
+		return nil, false, err
+	}
+	m, ok := s.Get(o.source)
+	return m, ok, nil
+}
+
+// Commit records offsets of the source.
+func (o *SourceOffsets) Commit(offsets data.Map) error {
+	s, err := o.lookup()
+	if err != nil {
+		return err
+	}
+	return s.Commit(o.source, offsets)
+}
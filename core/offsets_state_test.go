@@ -0,0 +1,114 @@
+package core
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+func TestOffsetsState(t *testing.T) {
+	Convey("Given an offsets state", t, func() {
+		s := NewOffsetsState()
+
+		Convey("When committing offsets of sources", func() {
+			o := data.Map{"partition": data.Int(0), "offset": data.Int(10)}
+			So(s.Commit("Kafka", o), ShouldBeNil)
+			So(s.Commit("file", data.Map{"offset": data.Int(100)}), ShouldBeNil)
+			o["offset"] = data.Int(20)
+
+			Convey("Then they should be returned case-insensitively", func() {
+				m, ok := s.Get("kafka")
+				So(ok, ShouldBeTrue)
+				So(m, ShouldResemble, data.Map{"partition": data.Int(0), "offset": data.Int(10)})
+				So(s.Sources(), ShouldResemble, []string{"Kafka", "file"})
+				So(s.Status()["num_sources"], ShouldEqual, data.Int(2))
+			})
+
+			Convey("Then resetting offsets should remove them", func() {
+				So(s.Reset("KAFKA"), ShouldBeTrue)
+				_, ok := s.Get("kafka")
+				So(ok, ShouldBeFalse)
+				So(s.Reset("kafka"), ShouldBeFalse)
+			})
+
+			Convey("Then they should be restored from saved data", func() {
+				buf := bytes.NewBuffer(nil)
+				So(s.Save(nil, buf, data.Map{}), ShouldBeNil)
+				b := buf.Bytes()
+
+				s2, err := LoadOffsetsState(bytes.NewReader(b))
+				So(err, ShouldBeNil)
+				So(s2.Sources(), ShouldResemble, s.Sources())
+				m, ok := s2.Get("kafka")
+				So(ok, ShouldBeTrue)
+				So(m, ShouldResemble, data.Map{"partition": data.Int(0), "offset": data.Int(10)})
+				ts, err := data.AsTimestamp(s2.ToMap()["Kafka"].(data.Map)["updated_at"])
+				So(err, ShouldBeNil)
+				So(ts.IsZero(), ShouldBeFalse)
+
+				So(s.Reset("file"), ShouldBeTrue)
+				So(s.Load(nil, bytes.NewReader(b), data.Map{}), ShouldBeNil)
+				m, ok = s.Get("file")
+				So(ok, ShouldBeTrue)
+				So(m, ShouldResemble, data.Map{"offset": data.Int(100)})
+			})
+		})
+
+		Convey("When loading broken data", func() {
+			b, err := data.EncodeMsgpack(data.Map{"version": data.Int(100)})
+			So(err, ShouldBeNil)
+
+			Convey("Then it should fail", func() {
+				So(s.Load(nil, bytes.NewReader(b), data.Map{}), ShouldNotBeNil)
+				_, err := LoadOffsetsState(bytes.NewReader([]byte("abc")))
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When the state is terminated", func() {
+			So(s.Terminate(nil), ShouldBeNil)
+
+			Convey("Then committing offsets should fail", func() {
+				So(s.Commit("file", data.Map{}), ShouldNotBeNil)
+			})
+		})
+	})
+
+	Convey("Given a context having states", t, func() {
+		ctx := NewContext(nil)
+		s := NewOffsetsState()
+		So(ctx.SharedStates.Add("offsets", "offsets", s), ShouldBeNil)
+		So(ctx.SharedStates.Add("kv", "kv", &stubSharedState{}), ShouldBeNil)
+
+		Convey("When creating SourceOffsets with the offsets state", func() {
+			o, err := NewSourceOffsets(ctx, "offsets", "src")
+			So(err, ShouldBeNil)
+
+			Convey("Then it should commit offsets of the source", func() {
+				_, ok, err := o.Load()
+				So(err, ShouldBeNil)
+				So(ok, ShouldBeFalse)
+
+				So(o.Commit(data.Map{"offset": data.Int(1)}), ShouldBeNil)
+				m, ok, err := o.Load()
+				So(err, ShouldBeNil)
+				So(ok, ShouldBeTrue)
+				So(m, ShouldResemble, data.Map{"offset": data.Int(1)})
+				_, ok = s.Get("src")
+				So(ok, ShouldBeTrue)
+			})
+		})
+
+		Convey("When creating SourceOffsets with a wrong state", func() {
+			_, err1 := NewSourceOffsets(ctx, "kv", "src")
+			_, err2 := NewSourceOffsets(ctx, "not_exist", "src")
+
+			Convey("Then it should fail", func() {
+				So(err1, ShouldNotBeNil)
+				So(err2, ShouldNotBeNil)
+			})
+		})
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"sync"
 	"sync/atomic"
 
+	"github.com/sirupsen/logrus"
 	"gopkg.in/sensorbee/sensorbee.v0/data"
 )
 
@@ -18,8 +19,9 @@ func newPipe(inputName string, capacity int) (*pipeReceiver, *pipeSender) {
 	}
 
 	s := &pipeSender{
-		inputName: inputName,
-		out:       p,
+		inputName:    inputName,
+		routingValue: data.String(inputName),
+		out:          p,
 	}
 	r.sender = s
 	return r, s
@@ -66,6 +68,20 @@ const (
 	DropOldest
 )
 
+// String returns a string representation of a QueueDropMode.
+func (m QueueDropMode) String() string {
+	switch m {
+	case DropNone:
+		return "none"
+	case DropLatest:
+		return "latest"
+	case DropOldest:
+		return "oldest"
+	default:
+		return "unknown"
+	}
+}
+
 // pipeSender represents a pipe sender. An object of this struct must be
 // placed in a global variable or in memory allocated from the heap.
 // Using an array or a slice of pipeSender may cause panic even if it is
@@ -85,6 +101,20 @@ type pipeSender struct {
 	out       chan *Tuple
 	dropMode  QueueDropMode
 
+	// routingField is the name of a field to which inputName is written.
+	// It's empty when the field isn't written. routingValue is inputName
+	// converted to a Value in advance so that writing it doesn't allocate
+	// memory for each tuple.
+	routingField string
+	routingValue data.Value
+
+	// reportDrops is true when tuples dropped by this pipe are logged
+	// regardless of Context.Flags.DroppedTupleLog. dstType and dstName
+	// identify the node receiving tuples from this pipe in the log.
+	reportDrops bool
+	dstType     NodeType
+	dstName     string
+
 	// rwm protects out from write-close conflicts.
 	rwm sync.RWMutex
 
@@ -116,6 +146,17 @@ func (s *pipeSender) write(ctx *Context, in *Tuple, droppedTuple func(*Tuple)) e
 		t = in.ShallowCopy()
 	}
 	t.InputName = s.inputName
+	if s.routingField != "" {
+		if t.Flags.IsSet(TFSharedData) {
+			t.Data = t.Data.Copy()
+			t.Flags.Clear(TFSharedData)
+		}
+		t.Data[s.routingField] = s.routingValue
+	}
+
+	// The receiver of the tuple releases the reference after processing it.
+	ack := t.ack
+	ack.retain()
 
 	if s.dropMode == DropNone {
 		s.out <- t
@@ -128,6 +169,8 @@ func (s *pipeSender) write(ctx *Context, in *Tuple, droppedTuple func(*Tuple)) e
 			default:
 				if s.dropMode == DropLatest {
 					droppedTuple(t)
+					s.reportDrop(ctx, t)
+					ack.release(ctx, errAckQueueFull)
 					return nil
 				}
 
@@ -135,7 +178,10 @@ func (s *pipeSender) write(ctx *Context, in *Tuple, droppedTuple func(*Tuple)) e
 				// again in the next iteration. This loop can cause starvation.
 				select {
 				case dropped := <-s.out:
+					droppedAck := dropped.ack
 					droppedTuple(dropped)
+					s.reportDrop(ctx, dropped)
+					droppedAck.release(ctx, errAckQueueFull)
 				default: // Another thread may drop it before this thread does.
 				}
 			}
@@ -145,6 +191,22 @@ func (s *pipeSender) write(ctx *Context, in *Tuple, droppedTuple func(*Tuple)) e
 	return nil
 }
 
+// reportDrop logs a tuple dropped because the pipe was full when reportDrops
+// is true.
+func (s *pipeSender) reportDrop(ctx *Context, t *Tuple) {
+	if !s.reportDrops {
+		return
+	}
+	ctx.Log().WithFields(nodeLogFields(s.dstType, s.dstName)).WithFields(logrus.Fields{
+		"input_name": s.inputName,
+		"drop_mode":  s.dropMode.String(),
+		"tuple": logrus.Fields{
+			"timestamp": data.Timestamp(t.Timestamp),
+			"data":      data.Summarize(t.Data),
+		},
+	}).Warn("A tuple was dropped because the input queue was full")
+}
+
 // Close closes a channel. When multiple goroutines try to close the channel,
 // only one goroutine can actually close it. Other goroutines don't wait until
 // the channel is actually closed. Close never fails.
@@ -228,6 +290,47 @@ type dataSources struct {
 	// msgChs is a slice of channels which are connected to goroutines
 	// pouring tuples. They receive controlling messages through this channel.
 	msgChs []chan<- *dataSourcesMessage
+
+	// pouring has goroutines pouring tuples. It's nil until pour is called.
+	pouring *pouringThreads
+
+	// gracefulStopEnabled and stopOnDisconnectEnabled are given to
+	// goroutines started after enableGracefulStop or stopOnDisconnect
+	// is called.
+	gracefulStopEnabled     bool
+	stopOnDisconnectEnabled bool
+}
+
+// pouringThreads has values shared by goroutines pouring tuples.
+type pouringThreads struct {
+	ctx       *Context
+	newWriter func() Writer
+
+	wg            sync.WaitGroup
+	logOnce       sync.Once
+	collectInputs sync.Once
+	inputs        []reflect.SelectCase
+	err           error
+
+	// threads has goroutines which are running and not retired.
+	threads []*pouringThreadInfo
+}
+
+// pouringThreadInfo has the information of a goroutine pouring tuples.
+type pouringThreadInfo struct {
+	msgCh chan *dataSourcesMessage
+
+	// retired is true when the goroutine is stopped by setParallelism.
+	retired bool
+}
+
+func (p *pouringThreads) remove(t *pouringThreadInfo) {
+	for i, th := range p.threads {
+		if th == t {
+			p.threads = append(p.threads[:i], p.threads[i+1:]...)
+			return
+		}
+	}
 }
 
 func newDataSources(nodeType NodeType, nodeName string) *dataSources {
@@ -252,6 +355,7 @@ const (
 	ddscStop
 	ddscToggleGracefulStop
 	ddscStopOnDisconnect
+	ddscRetire
 )
 
 func (s *dataSources) add(name string, r *pipeReceiver) error {
@@ -323,18 +427,23 @@ func (s *dataSources) remove(name string) {
 // pour pours out tuples for the target Writer. The target must directly be
 // connected to a Box or a Sink.
 func (s *dataSources) pour(ctx *Context, w Writer, parallelism int) error {
+	return s.pourWithWriters(ctx, func() Writer {
+		return w
+	}, parallelism)
+}
+
+// pourWithWriters pours out tuples like pour. newWriter is called for each
+// goroutine pouring tuples so that each of them can have its own Writer.
+// The number of goroutines can be changed by setParallelism while pouring.
+func (s *dataSources) pourWithWriters(ctx *Context, newWriter func() Writer, parallelism int) error {
 	if parallelism == 0 {
 		parallelism = 1
 	}
 
-	var (
-		wg            sync.WaitGroup
-		logOnce       sync.Once
-		collectInputs sync.Once
-		inputs        []reflect.SelectCase
-		threadErr     error
-	)
-
+	p := &pouringThreads{
+		ctx:       ctx,
+		newWriter: newWriter,
+	}
 	err := func() error {
 		s.m.Lock()
 		defer s.m.Unlock()
@@ -349,81 +458,10 @@ func (s *dataSources) pour(ctx *Context, w Writer, parallelism int) error {
 			}
 		}
 
-		genCases := func(msgCh <-chan *dataSourcesMessage) []reflect.SelectCase {
-			cs := make([]reflect.SelectCase, 0, len(s.recvs)+2)
-			cs = append(cs, reflect.SelectCase{
-				Dir:  reflect.SelectRecv,
-				Chan: reflect.ValueOf(msgCh),
-			})
-
-			// This case is used as a default case after stopCh is closed.
-			// Currently, it only has recv with nil channel so that
-			// reflect.Select does nothing on it.
-			cs = append(cs, reflect.SelectCase{
-				Dir: reflect.SelectRecv,
-			})
-
-			for _, r := range s.recvs {
-				cs = append(cs, reflect.SelectCase{
-					Dir:  reflect.SelectRecv,
-					Chan: reflect.ValueOf(r.in),
-				})
-			}
-			return cs
-		}
-
-		// ensureLocked ensures proper lock for s. Removing this introduces
-		// race conditions because genCases requires locked s and genCases is
-		// called in goroutines.
-		var ensureLocked sync.WaitGroup
+		s.pouring = p
 		for i := 0; i < parallelism; i++ {
-			msgCh := make(chan *dataSourcesMessage)
-			s.msgChs = append(s.msgChs, msgCh)
-
-			wg.Add(1)
-			ensureLocked.Add(1)
-			go func() {
-				defer wg.Done()
-				needDone := true
-				defer func() {
-					if needDone {
-						ensureLocked.Done()
-					}
-				}()
-				cs := genCases(msgCh)
-				ensureLocked.Done()
-				needDone = false
-				ins, err := s.pouringThread(ctx, w, cs)
-				collectInputs.Do(func() {
-					// It's sufficient to collect input only once. The only
-					// problem which might happen is that ins has old receivers.
-					// However, they will simply be removed when calling
-					// reflect.Select. There might be a case that only one
-					// pouringThread has a newly added receiver but it isn't
-					// assigned to inputs. To solve that problem, pour method
-					// also reads tuples from s.recvs.
-					//
-					// In addition, when pouringThread panics while remove
-					// method is called, s.recvs might not have receivers
-					// which pour method should read tuples. However, inputs
-					// returned from pouringThread has them. If the returned
-					// inputs doesn't have them, that means they were already
-					// removed successfully.
-					//
-					// In conclusion, by combining s.recvs and inputs, all
-					// inputs can be drained and no sender will be blocked.
-					inputs = ins
-				})
-				if err != nil {
-					logOnce.Do(func() {
-						threadErr = err // return only one error
-						ctx.ErrLog(err).WithFields(nodeLogFields(s.nodeType, s.nodeName)).
-							Error("the node stopped with a fatal error")
-					})
-				}
-			}()
+			s.startPouringThreadWithoutLock()
 		}
-		ensureLocked.Wait()
 		return nil
 	}()
 	if err != nil {
@@ -431,7 +469,8 @@ func (s *dataSources) pour(ctx *Context, w Writer, parallelism int) error {
 	}
 
 	s.state.Set(TSRunning)
-	wg.Wait()
+	p.wg.Wait()
+	inputs := p.inputs
 
 	s.m.Lock()
 	defer func() {
@@ -467,10 +506,163 @@ func (s *dataSources) pour(ctx *Context, w Writer, parallelism int) error {
 		close(ch)
 	}
 	s.msgChs = nil
-	return threadErr
+	return p.err
+}
+
+func (s *dataSources) genCasesWithoutLock(msgCh <-chan *dataSourcesMessage) []reflect.SelectCase {
+	cs := make([]reflect.SelectCase, 0, len(s.recvs)+2)
+	cs = append(cs, reflect.SelectCase{
+		Dir:  reflect.SelectRecv,
+		Chan: reflect.ValueOf(msgCh),
+	})
+
+	// This case is used as a default case after stopCh is closed.
+	// Currently, it only has recv with nil channel so that
+	// reflect.Select does nothing on it.
+	cs = append(cs, reflect.SelectCase{
+		Dir: reflect.SelectRecv,
+	})
+
+	for _, r := range s.recvs {
+		cs = append(cs, reflect.SelectCase{
+			Dir:  reflect.SelectRecv,
+			Chan: reflect.ValueOf(r.in),
+		})
+	}
+	return cs
+}
+
+// startPouringThreadWithoutLock starts a new goroutine pouring tuples. The
+// caller must hold the lock and s.pouring must be set.
+func (s *dataSources) startPouringThreadWithoutLock() {
+	p := s.pouring
+	msgCh := make(chan *dataSourcesMessage)
+	s.msgChs = append(s.msgChs, msgCh)
+	info := &pouringThreadInfo{msgCh: msgCh}
+	p.threads = append(p.threads, info)
+
+	// Cases are generated with the lock so that the new goroutine has all
+	// receivers. Receivers added later are sent through msgCh.
+	cs := s.genCasesWithoutLock(msgCh)
+	w := p.newWriter()
+	gracefulStop, stopOnDisconnect := s.gracefulStopEnabled, s.stopOnDisconnectEnabled
+
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		defer enterScheduler(w)()
+		ins, err := s.pouringThread(p.ctx, w, cs, gracefulStop, stopOnDisconnect)
+
+		s.m.Lock()
+		retired := info.retired
+		p.remove(info)
+		s.m.Unlock()
+
+		if !retired {
+			p.collectInputs.Do(func() {
+				// It's sufficient to collect input only once. The only
+				// problem which might happen is that ins has old receivers.
+				// However, they will simply be removed when calling
+				// reflect.Select. There might be a case that only one
+				// pouringThread has a newly added receiver but it isn't
+				// assigned to inputs. To solve that problem, pour method
+				// also reads tuples from s.recvs.
+				//
+				// In addition, when pouringThread panics while remove
+				// method is called, s.recvs might not have receivers
+				// which pour method should read tuples. However, inputs
+				// returned from pouringThread has them. If the returned
+				// inputs doesn't have them, that means they were already
+				// removed successfully.
+				//
+				// In conclusion, by combining s.recvs and inputs, all
+				// inputs can be drained and no sender will be blocked.
+				//
+				// Inputs of a retired goroutine aren't collected because
+				// other goroutines have newer receivers.
+				p.inputs = ins
+			})
+		}
+		if err != nil {
+			p.logOnce.Do(func() {
+				p.err = err // return only one error
+				p.ctx.ErrLog(err).WithFields(nodeLogFields(s.nodeType, s.nodeName)).
+					Error("the node stopped with a fatal error")
+			})
+		}
+	}()
 }
 
-func (s *dataSources) pouringThread(ctx *Context, w Writer, cs []reflect.SelectCase) (inputs []reflect.SelectCase, retErr error) {
+// setParallelism changes the number of goroutines pouring tuples and
+// returns the new number. It doesn't change anything and returns 0 when
+// the dataSources isn't running or all goroutines have stopped.
+func (s *dataSources) setParallelism(n int) int {
+	if n < 1 {
+		n = 1
+	}
+
+	s.m.Lock()
+	defer s.m.Unlock()
+	p := s.pouring
+	if p == nil || len(p.threads) == 0 {
+		// Starting a goroutine here might result in calling p.wg.Add
+		// after p.wg.Wait has returned.
+		return 0
+	}
+	if st := s.state.getWithoutLock(); st != TSRunning && st != TSPaused {
+		return len(p.threads)
+	}
+
+	for len(p.threads) < n {
+		s.startPouringThreadWithoutLock()
+	}
+	for len(p.threads) > n {
+		info := p.threads[len(p.threads)-1]
+		p.threads = p.threads[:len(p.threads)-1]
+		info.retired = true
+		for i, ch := range s.msgChs {
+			if ch == info.msgCh {
+				s.msgChs = append(s.msgChs[:i], s.msgChs[i+1:]...)
+				break
+			}
+		}
+
+		// The message is sent asynchronously because the goroutine might
+		// be blocked in Write for a long time. The goroutine or its drainer
+		// eventually receives it.
+		go func(ch chan *dataSourcesMessage) {
+			ch <- &dataSourcesMessage{cmd: ddscRetire}
+			close(ch)
+		}(info.msgCh)
+	}
+	return len(p.threads)
+}
+
+// parallelism returns the current number of goroutines pouring tuples.
+func (s *dataSources) parallelism() int {
+	s.m.RLock()
+	defer s.m.RUnlock()
+	if s.pouring == nil {
+		return 0
+	}
+	return len(s.pouring.threads)
+}
+
+// queueUsage returns the total number of tuples queued in input pipes and
+// the total capacity of them.
+func (s *dataSources) queueUsage() (queued, capacity int) {
+	s.m.RLock()
+	defer s.m.RUnlock()
+	for _, recv := range s.recvs {
+		l, c := recv.sender.queueStatus()
+		queued += l
+		capacity += c
+	}
+	return
+}
+
+func (s *dataSources) pouringThread(ctx *Context, w Writer, cs []reflect.SelectCase,
+	gracefulStopEnabled, stopOnDisconnect bool) (inputs []reflect.SelectCase, retErr error) {
 	const (
 		message = iota
 		defaultCase
@@ -512,9 +704,6 @@ func (s *dataSources) pouringThread(ctx *Context, w Writer, cs []reflect.SelectC
 		}()
 	}()
 
-	gracefulStopEnabled := false
-	stopOnDisconnect := false
-
 	reportDT := func(t *Tuple, err error) {
 		ctx.droppedTuple(t, s.nodeType, s.nodeName, ETInput, err)
 	}
@@ -574,6 +763,9 @@ receiveLoop:
 
 			case ddscStopOnDisconnect:
 				stopOnDisconnect = true
+
+			case ddscRetire:
+				break receiveLoop
 			}
 
 		case defaultCase:
@@ -590,7 +782,9 @@ receiveLoop:
 				break
 			}
 
+			ack := t.ack
 			err := w.Write(ctx, t)
+			ack.release(ctx, err)
 			if err == nil {
 				break
 			}
@@ -622,7 +816,10 @@ receiveLoop:
 func (s *dataSources) enableGracefulStop() {
 	// Perhaps this function should be something like 'toggle', but it wasn't
 	// necessary at the time of this writing.
-	s.sendMessage(&dataSourcesMessage{
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.gracefulStopEnabled = true
+	s.sendMessageWithoutLock(&dataSourcesMessage{
 		cmd: ddscToggleGracefulStop,
 	})
 }
@@ -630,7 +827,10 @@ func (s *dataSources) enableGracefulStop() {
 // stopOnDisconnect activates automatic stop when the dataSources has
 // no receiver.
 func (s *dataSources) stopOnDisconnect() {
-	s.sendMessage(&dataSourcesMessage{
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.stopOnDisconnectEnabled = true
+	s.sendMessageWithoutLock(&dataSourcesMessage{
 		cmd: ddscStopOnDisconnect,
 	})
 }
@@ -710,6 +910,10 @@ type dataDestinations struct {
 	dsts     map[string]*pipeSender
 	paused   bool
 
+	// pool is the pool of the scheduler in which a slot is occupied while
+	// writing a tuple. It's nil when the node isn't scheduled.
+	pool *schedulerPool
+
 	callback func(ddEvent)
 }
 
@@ -813,16 +1017,24 @@ func (d *dataDestinations) Write(ctx *Context, t *Tuple) error {
 	}
 	// It's safe even if Close method is called while waiting in the loop above.
 
+	// A slot is acquired after the loop so that paused sources don't occupy
+	// slots.
+	if d.pool != nil {
+		d.pool.acquire()
+		defer d.pool.release()
+	}
+
 	if len(d.dsts) == 0 {
 		atomic.AddInt64(&d.numDropped, 1)
+		t.ack.fail(errAckNoDestination)
 		if ctx.Flags.DestinationlessTupleLog.Enabled() {
-			ctx.droppedTuple(t, d.nodeType, d.nodeName, ETOutput, errors.New("no output destination is connected"))
+			ctx.droppedTuple(t, d.nodeType, d.nodeName, ETOutput, errAckNoDestination)
 		}
 		return nil
 	}
 
 	reportFunc := func(dropped *Tuple) {
-		ctx.droppedTuple(t, d.nodeType, d.nodeName, ETOutput, errors.New("the output queue is full"))
+		ctx.droppedTuple(dropped, d.nodeType, d.nodeName, ETOutput, errors.New("the output queue is full"))
 	}
 
 	if len(d.dsts) > 1 {
@@ -866,6 +1078,12 @@ func (d *dataDestinations) Write(ctx *Context, t *Tuple) error {
 	return nil
 }
 
+func (d *dataDestinations) setSchedulerPool(p *schedulerPool) {
+	d.rwm.Lock()
+	defer d.rwm.Unlock()
+	d.pool = p
+}
+
 func (d *dataDestinations) pause() {
 	d.rwm.Lock()
 	defer d.rwm.Unlock()
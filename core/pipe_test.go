@@ -1,10 +1,12 @@
 package core
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"testing"
 
+	"github.com/sirupsen/logrus"
 	. "github.com/smartystreets/goconvey/convey"
 	"gopkg.in/sensorbee/sensorbee.v0/data"
 )
@@ -28,6 +30,25 @@ func BenchmarkPipe(b *testing.B) {
 	})
 }
 
+func BenchmarkPipeRouting(b *testing.B) {
+	ctx := NewContext(nil)
+	r, s := newPipe("test", 1024)
+	s.routingField = "input"
+	go func() {
+		for _ = range r.in {
+		}
+	}()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.Write(ctx, &Tuple{Data: data.Map{
+			"id":   data.Int(i),
+			"temp": data.Float(20.5),
+		}})
+	}
+}
+
 func drainReceiver(r *pipeReceiver) {
 	for _ = range r.in {
 	}
@@ -123,6 +144,37 @@ func TestPipe(t *testing.T) {
 				So(len(r.in), ShouldEqual, 0)
 			})
 		})
+
+		Convey("When sending tuples with drops reported", func() {
+			buf := bytes.NewBuffer(nil)
+			logger := logrus.New()
+			logger.Out = buf
+			ctx := NewContext(&ContextConfig{Logger: logger})
+
+			t2 := t.Copy()
+			t2.Data["v"] = data.Int(2)
+			s.reportDrops = true
+			s.dstType = NTSink
+			s.dstName = "test_sink"
+
+			Convey("Then a tuple dropped in DropLatest mode should be logged", func() {
+				s.dropMode = DropLatest
+				So(s.Write(ctx, t), ShouldBeNil)
+				So(buf.Len(), ShouldEqual, 0)
+				So(s.Write(ctx, t2), ShouldBeNil)
+				So(buf.String(), ShouldContainSubstring, "A tuple was dropped")
+				So(buf.String(), ShouldContainSubstring, "test_sink")
+				So(buf.String(), ShouldContainSubstring, "drop_mode=latest")
+			})
+
+			Convey("Then a tuple dropped in DropOldest mode should be logged", func() {
+				s.dropMode = DropOldest
+				So(s.Write(ctx, t), ShouldBeNil)
+				So(s.Write(ctx, t2), ShouldBeNil)
+				So(buf.String(), ShouldContainSubstring, "A tuple was dropped")
+				So(buf.String(), ShouldContainSubstring, "drop_mode=oldest")
+			})
+		})
 	})
 }
 
@@ -397,6 +449,46 @@ func TestDataSourcesFailure(t *testing.T) {
 			})
 		})
 	})
+
+	for i, f := range []func(ctx *Context, t *Tuple) error{
+		func(ctx *Context, t *Tuple) error {
+			return FatalError(errors.New("fatal error"))
+		},
+		func(ctx *Context, t *Tuple) error {
+			panic(errors.New("panic"))
+		},
+		func(ctx *Context, t *Tuple) error {
+			panic("panic with a non-error value")
+		},
+	} {
+		f := f
+		Convey(fmt.Sprintf("Given a data source connected to a node failing fatally (%v)", i), t, func() {
+			ctx := NewContext(nil)
+			srcs := newDataSources(NTBox, "test_component")
+			r, s := newPipe("test", 1)
+			srcs.add("test_node", r)
+			Reset(func() {
+				s.close()
+			})
+			stopped := make(chan error, 1)
+			go func() {
+				stopped <- srcs.pour(ctx, WriterFunc(f), 1)
+			}()
+			srcs.state.Wait(TSRunning)
+			Reset(func() {
+				srcs.stop(ctx)
+			})
+
+			Convey("When writing a tuple to it", func() {
+				So(s.Write(ctx, &Tuple{Data: data.Map{"v": data.Int(1)}}), ShouldBeNil)
+
+				Convey("Then it should stop with an error", func() {
+					So(<-stopped, ShouldNotBeNil)
+					So(srcs.numErrors, ShouldBeLessThanOrEqualTo, 1)
+				})
+			})
+		})
+	}
 }
 
 func (s *pipeSender) waitUntilClosed() {
@@ -410,8 +502,6 @@ func (s *pipeSender) waitUntilClosed() {
 	}
 }
 
-// TODO: add fail tests of dataSources
-
 func TestDataDestinations(t *testing.T) {
 	ctx := NewContext(nil)
 
@@ -0,0 +1,131 @@
+package core
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"hash/fnv"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Random is a pseudo-random number generator which can be used by multiple
+// goroutines concurrently. It generates the same sequence of numbers for the
+// same seed as long as it's used in the same order.
+type Random struct {
+	m sync.Mutex
+	r *rand.Rand
+}
+
+// NewRandom creates a Random with the seed.
+func NewRandom(seed int64) *Random {
+	return &Random{
+		r: rand.New(rand.NewSource(seed)),
+	}
+}
+
+// Seed resets the generator with the seed.
+func (r *Random) Seed(seed int64) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	r.r.Seed(seed)
+}
+
+// Int63 returns a non-negative pseudo-random 63-bit integer.
+func (r *Random) Int63() int64 {
+	r.m.Lock()
+	defer r.m.Unlock()
+	return r.r.Int63()
+}
+
+// Intn returns a pseudo-random number in [0, n). It panics when n <= 0.
+func (r *Random) Intn(n int) int {
+	r.m.Lock()
+	defer r.m.Unlock()
+	return r.r.Intn(n)
+}
+
+// Float64 returns a pseudo-random number in [0.0, 1.0).
+func (r *Random) Float64() float64 {
+	r.m.Lock()
+	defer r.m.Unlock()
+	return r.r.Float64()
+}
+
+// NormFloat64 returns a normally distributed number with the mean 0 and the
+// standard deviation 1.
+func (r *Random) NormFloat64() float64 {
+	r.m.Lock()
+	defer r.m.Unlock()
+	return r.r.NormFloat64()
+}
+
+// Read fills p with pseudo-random bytes. It always returns len(p) and nil.
+func (r *Random) Read(p []byte) (int, error) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	return r.r.Read(p)
+}
+
+// UUID returns a UUID version 4 string such as
+// "3d813cbb-47fb-42ba-91df-831e1593ac29" generated from the generator. UUIDs
+// aren't suitable for security purposes since they're predictable.
+func (r *Random) UUID() string {
+	var u [16]byte
+	r.Read(u[:])
+	u[6] = 0x40 | (u[6] & 0x0f) // version 4
+	u[8] = 0x80 | (u[8] & 0x3f) // variant 10
+
+	var buf [36]byte
+	hex.Encode(buf[0:8], u[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], u[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], u[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], u[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], u[10:])
+	return string(buf[:])
+}
+
+// globalRandom is returned from Random of a nil Context.
+var globalRandom = NewRandom(time.Now().UnixNano())
+
+// Random returns the random number generator of the topology. Components
+// generating random numbers should use it, or NodeRandom when they belong to
+// a node, instead of the math/rand package so that the results can be
+// reproduced by ContextConfig.RandomSeed. It returns a generator seeded with
+// the current time when the Context is nil.
+func (c *Context) Random() *Random {
+	if c == nil {
+		return globalRandom
+	}
+	return c.random
+}
+
+// NodeRandom returns the random number generator of the node. Each node has
+// its own generator whose seed is derived from the seed of the Context and
+// the type and the name of the node, so that numbers generated by a node
+// don't depend on how nodes are scheduled. The same generator is returned
+// for the same type and name even after the node is removed.
+func (c *Context) NodeRandom(nodeType NodeType, name string) *Random {
+	if c == nil {
+		return globalRandom
+	}
+	key := nodeType.String() + ":" + strings.ToLower(name)
+	c.rndMutex.Lock()
+	defer c.rndMutex.Unlock()
+	r, ok := c.nodeRandoms[key]
+	if !ok {
+		h := fnv.New64a()
+		var b [8]byte
+		binary.BigEndian.PutUint64(b[:], uint64(c.randomSeed))
+		h.Write(b[:])
+		h.Write([]byte(key))
+		r = NewRandom(int64(h.Sum64()))
+		c.nodeRandoms[key] = r
+	}
+	return r
+}
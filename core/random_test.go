@@ -0,0 +1,99 @@
+package core
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestRandom(t *testing.T) {
+	Convey("Given two Randoms with the same seed", t, func() {
+		r1 := NewRandom(42)
+		r2 := NewRandom(42)
+
+		Convey("When generating numbers", func() {
+			Convey("Then they should generate the same sequence", func() {
+				for i := 0; i < 100; i++ {
+					So(r1.Float64(), ShouldEqual, r2.Float64())
+					So(r1.NormFloat64(), ShouldEqual, r2.NormFloat64())
+				}
+				So(r1.UUID(), ShouldEqual, r2.UUID())
+			})
+		})
+
+		Convey("When reseeding one of them", func() {
+			v := r1.Int63()
+			r2.Int63()
+			r2.Seed(42)
+
+			Convey("Then it should generate the sequence from the beginning", func() {
+				So(r2.Int63(), ShouldEqual, v)
+			})
+		})
+	})
+
+	Convey("Given a Random", t, func() {
+		r := NewRandom(1)
+
+		Convey("When generating UUIDs", func() {
+			ids := map[string]bool{}
+			for i := 0; i < 1000; i++ {
+				ids[r.UUID()] = true
+			}
+
+			Convey("Then they should be unique version 4 UUIDs", func() {
+				So(len(ids), ShouldEqual, 1000)
+				re := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+				for id := range ids {
+					So(re.MatchString(id), ShouldBeTrue)
+				}
+			})
+		})
+	})
+
+	Convey("Given two Contexts with the same seed", t, func() {
+		seed := int64(10)
+		c1 := NewContext(&ContextConfig{RandomSeed: &seed})
+		c2 := NewContext(&ContextConfig{RandomSeed: &seed})
+
+		Convey("When getting generators of nodes", func() {
+			Convey("Then the same node should have the same sequence", func() {
+				So(c1.Random().Int63(), ShouldEqual, c2.Random().Int63())
+				So(c1.NodeRandom(NTBox, "b").Int63(), ShouldEqual, c2.NodeRandom(NTBox, "B").Int63())
+			})
+
+			Convey("Then the generator should be cached", func() {
+				So(c1.NodeRandom(NTSource, "s"), ShouldPointTo, c1.NodeRandom(NTSource, "S"))
+			})
+
+			Convey("Then different nodes should have different sequences", func() {
+				So(c1.NodeRandom(NTBox, "a").Int63(), ShouldNotEqual, c2.NodeRandom(NTBox, "b").Int63())
+				So(c1.NodeRandom(NTBox, "a").Int63(), ShouldNotEqual, c2.NodeRandom(NTSink, "a").Int63())
+			})
+		})
+	})
+
+	Convey("Given two Contexts with ManualClocks at the same time", t, func() {
+		now := time.Date(2024, time.May, 1, 0, 0, 0, 0, time.UTC)
+		c1 := NewContext(&ContextConfig{Clock: NewManualClock(now)})
+		c2 := NewContext(&ContextConfig{Clock: NewManualClock(now)})
+
+		Convey("When generating numbers", func() {
+			Convey("Then they should generate the same sequence", func() {
+				So(c1.NodeRandom(NTSource, "s").Int63(), ShouldEqual, c2.NodeRandom(NTSource, "s").Int63())
+			})
+		})
+	})
+
+	Convey("Given a nil Context", t, func() {
+		var c *Context
+
+		Convey("When getting a generator", func() {
+			Convey("Then it should return a global one", func() {
+				So(c.Random(), ShouldNotBeNil)
+				So(c.NodeRandom(NTBox, "b"), ShouldNotBeNil)
+			})
+		})
+	})
+}
@@ -0,0 +1,202 @@
+package core
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// Backoff has parameters of exponential backoff used by Reconnector.
+type Backoff struct {
+	// InitialInterval is the interval before the first retry. The default
+	// value is 1 second.
+	InitialInterval time.Duration
+
+	// MaxInterval is the upper bound of intervals. The default value is 1
+	// minute.
+	MaxInterval time.Duration
+
+	// Multiplier is the factor by which the interval is multiplied after
+	// each failed attempt. The default value is 2.
+	Multiplier float64
+
+	// MaxAttempts is the maximum number of consecutive failed attempts. When
+	// it's reached, Reconnector gives up and returns the last error. There's
+	// no limit when it's 0.
+	MaxAttempts int
+}
+
+// interval returns the interval before the retry following the n-th
+// consecutive failure. n starts from 1.
+func (b *Backoff) interval(n int) time.Duration {
+	d := float64(b.InitialInterval)
+	for i := 1; i < n && d < float64(b.MaxInterval); i++ {
+		d *= b.Multiplier
+	}
+	if d > float64(b.MaxInterval) {
+		return b.MaxInterval
+	}
+	return time.Duration(d)
+}
+
+// ErrReconnectorStopped is returned from Reconnector.Run when the Reconnector
+// is stopped.
+var ErrReconnectorStopped = errors.New("the reconnector has been stopped")
+
+// Reconnector repeatedly runs a function connecting to an endpoint and
+// processing data from it, and retries the function with exponential backoff
+// while it fails with retryable errors. It provides the standard behavior of
+// network sources when their endpoints flap.
+//
+// An error is retryable when IsTemporaryError returns true and IsFatalError
+// returns false for it. Other errors are returned from Run immediately, so
+// functions should decorate errors such as connection refused or timeouts by
+// TemporaryError and errors such as authentication failures by FatalError.
+//
+// A source using Reconnector should implement RetryingSource so that the
+// status of retries is reported as a part of the status of the source node.
+type Reconnector struct {
+	backoff Backoff
+
+	m         sync.Mutex
+	attempts  int
+	lastErr   error
+	nextRetry time.Time
+	stopped   bool
+	stopCh    chan struct{}
+}
+
+// NewReconnector creates a new Reconnector. Default values are used for
+// unspecified parameters of b. b can be nil.
+func NewReconnector(b *Backoff) *Reconnector {
+	r := &Reconnector{
+		stopCh: make(chan struct{}),
+	}
+	if b != nil {
+		r.backoff = *b
+	}
+	if r.backoff.InitialInterval <= 0 {
+		r.backoff.InitialInterval = time.Second
+	}
+	if r.backoff.MaxInterval <= 0 {
+		r.backoff.MaxInterval = time.Minute
+	}
+	if r.backoff.MaxInterval < r.backoff.InitialInterval {
+		r.backoff.MaxInterval = r.backoff.InitialInterval
+	}
+	if r.backoff.Multiplier < 1 {
+		r.backoff.Multiplier = 2
+	}
+	return r
+}
+
+// Run calls f until it returns nil or a non-retryable error, or the
+// Reconnector is stopped. It waits before each retry according to the
+// backoff parameters using ctx.Clock().
+//
+// f should call Connected once it has established a connection so that the
+// backoff is reset. Otherwise, a connection which repeatedly breaks after a
+// while would be retried with longer and longer intervals.
+//
+// Run returns nil when f returns nil or the Reconnector is stopped while
+// waiting for a retry. It returns the last error when the maximum number of
+// attempts is reached.
+func (r *Reconnector) Run(ctx *Context, f func() error) error {
+	for {
+		r.m.Lock()
+		stopped := r.stopped
+		r.m.Unlock()
+		if stopped {
+			return nil
+		}
+
+		err := f()
+		if err == nil {
+			return nil
+		}
+		if IsFatalError(err) || !IsTemporaryError(err) {
+			r.m.Lock()
+			r.lastErr = err
+			r.nextRetry = time.Time{}
+			r.m.Unlock()
+			return err
+		}
+
+		r.m.Lock()
+		r.attempts++
+		r.lastErr = err
+		if r.backoff.MaxAttempts > 0 && r.attempts >= r.backoff.MaxAttempts {
+			r.nextRetry = time.Time{}
+			r.m.Unlock()
+			return err
+		}
+		d := r.backoff.interval(r.attempts)
+		r.nextRetry = ctx.Clock().Now().Add(d)
+		attempts := r.attempts
+		r.m.Unlock()
+
+		ctx.ErrLog(err).WithField("attempts", attempts).WithField("retry_in", d.String()).
+			Warning("Retrying after a temporary error")
+
+		t := ctx.Clock().NewTimer(d)
+		select {
+		case <-t.C():
+		case <-r.stopCh:
+			t.Stop()
+			return nil
+		}
+	}
+}
+
+// Connected resets the number of consecutive failed attempts and the backoff
+// interval. It should be called by the function passed to Run when it has
+// established a connection.
+func (r *Reconnector) Connected() {
+	r.m.Lock()
+	defer r.m.Unlock()
+	r.attempts = 0
+	r.nextRetry = time.Time{}
+}
+
+// Stop stops the Reconnector. Run returns without calling the function again
+// after Stop is called. It doesn't stop the function which is currently
+// running, so the caller has to stop it separately.
+func (r *Reconnector) Stop() {
+	r.m.Lock()
+	defer r.m.Unlock()
+	if r.stopped {
+		return
+	}
+	r.stopped = true
+	close(r.stopCh)
+}
+
+// Status returns the status of the Reconnector. It has the number of
+// consecutive failed attempts, the last error, and the time of the next
+// retry when the Reconnector is waiting for it.
+func (r *Reconnector) Status() data.Map {
+	r.m.Lock()
+	defer r.m.Unlock()
+	m := data.Map{
+		"attempts": data.Int(r.attempts),
+	}
+	if r.lastErr != nil {
+		m["last_error"] = data.String(r.lastErr.Error())
+	}
+	if !r.nextRetry.IsZero() {
+		m["next_retry"] = data.Timestamp(r.nextRetry)
+	}
+	return m
+}
+
+// RetryingSource is a Source which reconnects to its endpoint by Reconnector.
+// The status of the Reconnector is reported as "retry" in the status of the
+// source node.
+type RetryingSource interface {
+	Source
+
+	// Reconnector returns the Reconnector used by the source.
+	Reconnector() *Reconnector
+}
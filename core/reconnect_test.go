@@ -0,0 +1,145 @@
+package core
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+func TestBackoff(t *testing.T) {
+	Convey("Given a backoff", t, func() {
+		r := NewReconnector(&Backoff{
+			InitialInterval: time.Second,
+			MaxInterval:     5 * time.Second,
+		})
+
+		Convey("Then intervals should grow exponentially up to the maximum", func() {
+			So(r.backoff.interval(1), ShouldEqual, time.Second)
+			So(r.backoff.interval(2), ShouldEqual, 2*time.Second)
+			So(r.backoff.interval(3), ShouldEqual, 4*time.Second)
+			So(r.backoff.interval(4), ShouldEqual, 5*time.Second)
+			So(r.backoff.interval(100), ShouldEqual, 5*time.Second)
+		})
+	})
+}
+
+func TestReconnector(t *testing.T) {
+	Convey("Given a reconnector with a manual clock", t, func() {
+		base := time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC)
+		clock := NewManualClock(base)
+		ctx := NewContext(&ContextConfig{Clock: clock})
+		r := NewReconnector(&Backoff{
+			InitialInterval: time.Second,
+			MaxInterval:     time.Minute,
+			MaxAttempts:     3,
+		})
+
+		Convey("When the function succeeds", func() {
+			err := r.Run(ctx, func() error { return nil })
+
+			Convey("Then Run should return nil", func() {
+				So(err, ShouldBeNil)
+				So(r.Status(), ShouldResemble, data.Map{"attempts": data.Int(0)})
+			})
+		})
+
+		Convey("When the function fails with a fatal error", func() {
+			calls := 0
+			err := r.Run(ctx, func() error {
+				calls++
+				return FatalError(TemporaryError(errors.New("unauthorized")))
+			})
+
+			Convey("Then Run should return it without retrying", func() {
+				So(IsFatalError(err), ShouldBeTrue)
+				So(calls, ShouldEqual, 1)
+				So(r.Status()["last_error"], ShouldEqual, data.String("unauthorized"))
+			})
+		})
+
+		Convey("When the function fails with a non-temporary error", func() {
+			calls := 0
+			err := r.Run(ctx, func() error {
+				calls++
+				return errors.New("unknown")
+			})
+
+			Convey("Then Run should return it without retrying", func() {
+				So(err, ShouldNotBeNil)
+				So(calls, ShouldEqual, 1)
+			})
+		})
+
+		Convey("When the function fails with temporary errors", func() {
+			calls := make(chan int, 10)
+			n := 0
+			done := make(chan error, 1)
+			go func() {
+				done <- r.Run(ctx, func() error {
+					n++
+					calls <- n
+					return TemporaryError(errors.New("connection refused"))
+				})
+			}()
+
+			Convey("Then it should be retried with backoff", func() {
+				So(<-calls, ShouldEqual, 1)
+				clock.BlockUntil(1)
+				st := r.Status()
+				So(st["attempts"], ShouldEqual, data.Int(1))
+				So(st["next_retry"], ShouldResemble, data.Timestamp(base.Add(time.Second)))
+				So(st["last_error"], ShouldEqual, data.String("connection refused"))
+
+				clock.Advance(time.Second)
+				So(<-calls, ShouldEqual, 2)
+				clock.BlockUntil(1)
+				So(r.Status()["next_retry"], ShouldResemble, data.Timestamp(base.Add(3*time.Second)))
+
+				Convey("And Run should give up after the maximum number of attempts", func() {
+					clock.Advance(2 * time.Second)
+					So(<-calls, ShouldEqual, 3)
+					err := <-done
+					So(IsTemporaryError(err), ShouldBeTrue)
+					_, ok := r.Status()["next_retry"]
+					So(ok, ShouldBeFalse)
+				})
+			})
+
+			Convey("Then stopping the reconnector should stop retrying", func() {
+				So(<-calls, ShouldEqual, 1)
+				clock.BlockUntil(1)
+				r.Stop()
+				So(<-done, ShouldBeNil)
+			})
+		})
+
+		Convey("When the function connects before failing", func() {
+			n := 0
+			done := make(chan error, 1)
+			go func() {
+				done <- r.Run(ctx, func() error {
+					n++
+					if n > 1 {
+						r.Connected()
+					}
+					if n > 5 {
+						return nil
+					}
+					return TemporaryError(errors.New("connection lost"))
+				})
+			}()
+
+			Convey("Then the number of attempts should be reset", func() {
+				for i := 0; i < 5; i++ {
+					clock.BlockUntil(1)
+					So(r.Status()["attempts"], ShouldEqual, data.Int(1))
+					clock.Advance(time.Second)
+				}
+				So(<-done, ShouldBeNil)
+			})
+		})
+	})
+}
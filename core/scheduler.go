@@ -0,0 +1,246 @@
+package core
+
+import (
+	"runtime"
+	"sync/atomic"
+	"time"
+
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// SchedulerConfig has parameters of a Scheduler. Sources, boxes, and sinks
+// are assigned to separate pools so that a burst of tuples from sources
+// doesn't starve boxes and sinks, and vice versa.
+type SchedulerConfig struct {
+	// Sources is the config of the pool shared by sources.
+	Sources SchedulerPoolConfig
+
+	// Boxes is the config of the pool shared by boxes.
+	Boxes SchedulerPoolConfig
+
+	// Sinks is the config of the pool shared by sinks.
+	Sinks SchedulerPoolConfig
+}
+
+// SchedulerPoolConfig has parameters of a pool of a Scheduler.
+type SchedulerPoolConfig struct {
+	// Size is the maximum number of nodes in the pool which can process
+	// tuples concurrently. When it's 0 or negative, runtime.GOMAXPROCS(0) is
+	// used.
+	Size int
+
+	// LockOSThread is a hint to dedicate OS threads to goroutines of nodes in
+	// the pool. When it's true, goroutines running nodes in the pool are
+	// locked to their OS threads by runtime.LockOSThread so that an OS
+	// level CPU affinity, such as taskset or cgroups cpusets, can be applied
+	// to them.
+	LockOSThread bool
+}
+
+// Scheduler limits the number of nodes processing tuples concurrently for
+// each node type. A Scheduler can be shared by multiple Contexts (i.e.
+// topologies) through ContextConfig.Scheduler.
+//
+// A source occupies a slot of its pool while it's writing a tuple to its
+// destinations. A box occupies a slot while it's processing a tuple, but it
+// releases the slot while it's writing output tuples to subsequent nodes so
+// that nodes waiting for each other don't dead-lock. A sink occupies a slot
+// while it's writing a tuple.
+type Scheduler struct {
+	sources *schedulerPool
+	boxes   *schedulerPool
+	sinks   *schedulerPool
+}
+
+// NewScheduler creates a new Scheduler. If config is nil, the default config
+// will be used.
+func NewScheduler(config *SchedulerConfig) *Scheduler {
+	if config == nil {
+		config = &SchedulerConfig{}
+	}
+	return &Scheduler{
+		sources: newSchedulerPool(&config.Sources),
+		boxes:   newSchedulerPool(&config.Boxes),
+		sinks:   newSchedulerPool(&config.Sinks),
+	}
+}
+
+func (s *Scheduler) pool(t NodeType) *schedulerPool {
+	switch t {
+	case NTSource:
+		return s.sources
+	case NTBox:
+		return s.boxes
+	case NTSink:
+		return s.sinks
+	default:
+		return nil
+	}
+}
+
+// Status returns statistics of pools in the Scheduler. It has "sources",
+// "boxes", and "sinks" fields and each of them has following fields:
+//
+//	- size: the maximum number of nodes processing tuples concurrently
+//	- lock_os_thread: true when goroutines are locked to OS threads
+//	- nodes: the number of running nodes assigned to the pool
+//	- running: the number of nodes processing tuples at the moment
+//	- waiting: the number of nodes waiting for a slot at the moment
+//	- num_executions: the total number of slots acquired
+//	- num_waits: the total number of times nodes waited for a slot
+//	- wait_time: the total time nodes waited for a slot in seconds
+func (s *Scheduler) Status() data.Map {
+	return data.Map{
+		"sources": s.sources.status(),
+		"boxes":   s.boxes.status(),
+		"sinks":   s.sinks.status(),
+	}
+}
+
+// schedulerPool is a semaphore limiting the number of nodes processing tuples
+// concurrently.
+//
+// Because int64 fields are accessed atomically, schedulerPool must be
+// allocated on heap. See godoc of dataDestinations for details.
+type schedulerPool struct {
+	numExecutions int64
+	numWaits      int64
+	waitTime      int64
+	nodes         int64
+	waiting       int64
+
+	slots        chan struct{}
+	lockOSThread bool
+}
+
+func newSchedulerPool(config *SchedulerPoolConfig) *schedulerPool {
+	size := config.Size
+	if size <= 0 {
+		size = runtime.GOMAXPROCS(0)
+	}
+	return &schedulerPool{
+		slots:        make(chan struct{}, size),
+		lockOSThread: config.LockOSThread,
+	}
+}
+
+// acquire blocks until a slot becomes available.
+func (p *schedulerPool) acquire() {
+	atomic.AddInt64(&p.numExecutions, 1)
+	select {
+	case p.slots <- struct{}{}:
+		return
+	default:
+	}
+
+	atomic.AddInt64(&p.numWaits, 1)
+	atomic.AddInt64(&p.waiting, 1)
+	start := time.Now()
+	p.slots <- struct{}{}
+	atomic.AddInt64(&p.waitTime, int64(time.Now().Sub(start)))
+	atomic.AddInt64(&p.waiting, -1)
+}
+
+// release releases a slot acquired by acquire.
+func (p *schedulerPool) release() {
+	<-p.slots
+}
+
+// enter is called when a goroutine of a node assigned to the pool starts. The
+// function returned must be called when the goroutine finishes.
+func (p *schedulerPool) enter() func() {
+	atomic.AddInt64(&p.nodes, 1)
+	if p.lockOSThread {
+		runtime.LockOSThread()
+	}
+	return func() {
+		if p.lockOSThread {
+			runtime.UnlockOSThread()
+		}
+		atomic.AddInt64(&p.nodes, -1)
+	}
+}
+
+func (p *schedulerPool) status() data.Map {
+	return data.Map{
+		"size":           data.Int(cap(p.slots)),
+		"lock_os_thread": data.Bool(p.lockOSThread),
+		"nodes":          data.Int(atomic.LoadInt64(&p.nodes)),
+		"running":        data.Int(len(p.slots)),
+		"waiting":        data.Int(atomic.LoadInt64(&p.waiting)),
+		"num_executions": data.Int(atomic.LoadInt64(&p.numExecutions)),
+		"num_waits":      data.Int(atomic.LoadInt64(&p.numWaits)),
+		"wait_time":      data.Float(time.Duration(atomic.LoadInt64(&p.waitTime)).Seconds()),
+	}
+}
+
+// enterScheduler calls schedulerPool.enter when w is a scheduledWriter. The
+// function returned must be called when the goroutine writing tuples to w
+// finishes.
+func enterScheduler(w Writer) func() {
+	sw, ok := w.(*scheduledWriter)
+	if !ok {
+		return func() {}
+	}
+	return sw.pool.enter()
+}
+
+// scheduledWriter occupies a slot of a pool while writing a tuple to the
+// underlying Writer.
+type scheduledWriter struct {
+	w    Writer
+	pool *schedulerPool
+
+	// holding is 1 while a slot is held by Write. It's used by
+	// yieldingWriter to release the slot temporarily.
+	holding int32
+}
+
+// newScheduledWriter returns a Writer occupying a slot of the pool while
+// writing a tuple. It returns w as it is when pool is nil.
+func newScheduledWriter(pool *schedulerPool, w Writer) Writer {
+	if pool == nil {
+		return w
+	}
+	return &scheduledWriter{
+		w:    w,
+		pool: pool,
+	}
+}
+
+func (sw *scheduledWriter) Write(ctx *Context, t *Tuple) error {
+	sw.pool.acquire()
+	atomic.StoreInt32(&sw.holding, 1)
+	defer func() {
+		if atomic.CompareAndSwapInt32(&sw.holding, 1, 0) {
+			sw.pool.release()
+		}
+	}()
+	return sw.w.Write(ctx, t)
+}
+
+// yieldingWriter releases the slot held by a scheduledWriter while writing a
+// tuple to the underlying WriteCloser. It's used to write output tuples of a
+// box so that the box doesn't occupy a slot while it's blocked by subsequent
+// nodes.
+type yieldingWriter struct {
+	w  WriteCloser
+	sw *scheduledWriter
+}
+
+func (yw *yieldingWriter) Write(ctx *Context, t *Tuple) error {
+	// The slot isn't held when a box writes a tuple outside of Process
+	// (e.g. from its own goroutine).
+	if atomic.CompareAndSwapInt32(&yw.sw.holding, 1, 0) {
+		yw.sw.pool.release()
+		defer func() {
+			yw.sw.pool.acquire()
+			atomic.StoreInt32(&yw.sw.holding, 1)
+		}()
+	}
+	return yw.w.Write(ctx, t)
+}
+
+func (yw *yieldingWriter) Close(ctx *Context) error {
+	return yw.w.Close(ctx)
+}
@@ -0,0 +1,92 @@
+package core
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+func TestScheduler(t *testing.T) {
+	Convey("Given a scheduler", t, func() {
+		s := NewScheduler(&SchedulerConfig{
+			Boxes: SchedulerPoolConfig{
+				Size: 1,
+			},
+			Sinks: SchedulerPoolConfig{
+				Size:         2,
+				LockOSThread: true,
+			},
+		})
+
+		Convey("Then it should have pools having given sizes", func() {
+			st := s.Status()
+			So(st["boxes"].(data.Map)["size"], ShouldEqual, 1)
+			So(st["sinks"].(data.Map)["size"], ShouldEqual, 2)
+			So(st["sinks"].(data.Map)["lock_os_thread"], ShouldEqual, true)
+		})
+
+		Convey("Then a pool having no size should have the default size", func() {
+			So(s.Status()["sources"].(data.Map)["size"], ShouldBeGreaterThan, 0)
+		})
+
+		Convey("And a topology using the scheduler", func() {
+			ctx := NewContext(&ContextConfig{
+				Scheduler: s,
+			})
+			t, err := NewDefaultTopology(ctx, "sched1")
+			So(err, ShouldBeNil)
+			Reset(func() {
+				t.Stop()
+			})
+
+			ts := make([]*Tuple, 10)
+			for i := range ts {
+				ts[i] = NewTuple(data.Map{"seq": data.Int(i)})
+			}
+			son, err := t.AddSource("source", NewTupleEmitterSource(ts), &SourceConfig{
+				PausedOnStartup: true,
+			})
+			So(err, ShouldBeNil)
+
+			var running, maxRunning int32
+			box := BoxFunc(func(ctx *Context, t *Tuple, w Writer) error {
+				n := atomic.AddInt32(&running, 1)
+				if n > atomic.LoadInt32(&maxRunning) {
+					atomic.StoreInt32(&maxRunning, n)
+				}
+				time.Sleep(time.Millisecond)
+				// The slot is released while writing the output.
+				atomic.AddInt32(&running, -1)
+				return w.Write(ctx, t)
+			})
+			si := NewTupleCollectorSink()
+			sin, err := t.AddSink("sink", si, nil)
+			So(err, ShouldBeNil)
+			for _, name := range []string{"box1", "box2"} {
+				bn, err := t.AddBox(name, box, nil)
+				So(err, ShouldBeNil)
+				So(bn.Input("source", nil), ShouldBeNil)
+				So(sin.Input(name, nil), ShouldBeNil)
+			}
+
+			Convey("When the source emits tuples", func() {
+				So(son.Resume(), ShouldBeNil)
+				si.Wait(20)
+
+				Convey("Then boxes shouldn't process tuples concurrently", func() {
+					So(atomic.LoadInt32(&maxRunning), ShouldEqual, 1)
+				})
+
+				Convey("Then the status should have the number of executions", func() {
+					st := s.Status()
+					So(st["boxes"].(data.Map)["num_executions"], ShouldBeGreaterThanOrEqualTo, 20)
+					So(st["sinks"].(data.Map)["num_executions"], ShouldEqual, 20)
+					So(st["boxes"].(data.Map)["nodes"], ShouldEqual, 2)
+				})
+			})
+		})
+	})
+}
@@ -68,6 +68,37 @@ type LoadableSharedState interface {
 	Load(ctx *Context, r io.Reader, params data.Map) error
 }
 
+// LoggedState is a SharedState which reports its mutations as change records
+// so that a replica of the state, e.g. on a standby server, can be kept up to
+// date by applying the records in the same order. The format of a record is
+// defined by each state.
+type LoggedState interface {
+	SharedState
+
+	// LogChanges starts sending change records to the log. The state first
+	// sends records which make a replica identical to the current state
+	// regardless of the data the replica has, and then sends a record for
+	// each mutation. No mutation may be missed or sent twice between them.
+	// Calling this method again replaces the previous log and sends records
+	// of the current state again. The state stops sending records when log
+	// is nil.
+	LogChanges(log StateChangeLog)
+
+	// ApplyChange applies a change record sent by another instance of the
+	// same type of state. A mutation made by this method is also sent to
+	// the log of this state if it has one.
+	ApplyChange(ctx *Context, rec data.Map) error
+}
+
+// StateChangeLog receives change records from a LoggedState.
+type StateChangeLog interface {
+	// Append receives a change record. It's called while the state is
+	// locked so that records are received in the order of mutations.
+	// Therefore, it must not block and must not call methods of the state.
+	// The record must not be modified.
+	Append(rec data.Map)
+}
+
 // TODO: Add MixiableSharedState interface
 
 // SharedStateRegistry manages SharedState with names assigned to each state.
@@ -80,6 +111,8 @@ type SharedStateRegistry interface {
 	// Don't add the same instance of SharedState more than once to registries.
 	// Otherwise, Init and Terminate methods of the state will be called
 	// multiple times.
+	//
+	// Add reports a SUECreated event on success.
 	Add(name, typeName string, s SharedState) error
 
 	// Get returns a SharedState having the name in the registry. It returns
@@ -111,6 +144,8 @@ type SharedStateRegistry interface {
 	// SharedState.Terminate fails, Remove returns both the removed SharedState
 	// and an error. If the registry doesn't have a SharedState having the name,
 	// it returns a nil SharedState and NotExistError.
+	//
+	// Remove reports a SUEDropped event when the state is removed.
 	Remove(name string) (SharedState, error)
 }
 
@@ -156,6 +191,7 @@ func (r *defaultSharedStateRegistry) Add(name, typeName string, s SharedState) e
 		}
 		return err // This is the original error
 	}
+	r.ctx.notifyStateUpdate(name, typeName, SUECreated, nil)
 	return nil
 }
 
@@ -225,18 +261,20 @@ func (r *defaultSharedStateRegistry) List() (map[string]SharedState, error) {
 }
 
 func (r *defaultSharedStateRegistry) Remove(name string) (SharedState, error) {
-	s := func() SharedState {
+	info := func() *defaultSharedStateInfo {
 		r.m.Lock()
 		defer r.m.Unlock()
 		if s, ok := r.states[name]; ok {
 			delete(r.states, name)
-			return s.state
+			return s
 		}
 		return nil
 	}()
-	if s == nil {
+	if info == nil {
 		return nil, NotExistError(fmt.Errorf("state '%v' was not found", name))
 	}
+	s := info.state
+	r.ctx.notifyStateUpdate(name, info.typeName, SUEDropped, nil)
 
 	if err := s.Terminate(r.ctx); err != nil {
 		return s, err
@@ -1,5 +1,11 @@
 package core
 
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
 // A Sink describes a location that data can be written to after it
 // was processed by a topology, i.e., it represents an entity
 // outside of the topology (e.g., a fluentd instance).
@@ -9,3 +15,68 @@ package core
 type Sink interface {
 	WriteCloser
 }
+
+// Flusher is implemented by a Sink buffering tuples before writing them to
+// the destination, such as a sink writing tuples in batches. Flush writes out
+// all tuples buffered so far so that they're durable when it returns.
+//
+// A topology flushes sinks at following points:
+//
+//   - when a source is paused
+//   - when a checkpoint is made, e.g. by SAVE STATE statement in BQL
+//   - before a sink is closed, including when it's stopped after draining
+//     its inputs by the graceful stop
+//
+// Flush isn't called concurrently with Write or Close of the Sink, so the
+// Sink doesn't have to be thread-safe to implement Flusher. Flush isn't
+// called after Close. A Sink still has to flush tuples in Close since Close
+// may be called without Flush, e.g. when Flush failed.
+type Flusher interface {
+	// Flush writes out tuples buffered in the Sink.
+	Flush(ctx *Context) error
+}
+
+// flushableSink serializes calls of Write, Flush, and Close of a Sink so
+// that a sink node can flush the Sink from a goroutine other than the one
+// writing tuples to it.
+type flushableSink struct {
+	m      sync.Mutex
+	sink   Sink
+	closed bool
+}
+
+func (s *flushableSink) Write(ctx *Context, t *Tuple) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.sink.Write(ctx, t)
+}
+
+// flush calls Flush of the Sink if it implements Flusher.
+func (s *flushableSink) flush(ctx *Context) (err error) {
+	f, ok := s.sink.(Flusher)
+	if !ok {
+		return nil
+	}
+
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.closed {
+		return errors.New("the sink is already closed")
+	}
+	defer func() {
+		if e := recover(); e != nil {
+			err = fmt.Errorf("the sink couldn't be flushed due to panic: %v", e)
+		}
+	}()
+	return f.Flush(ctx)
+}
+
+func (s *flushableSink) Close(ctx *Context) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	return s.sink.Close(ctx)
+}
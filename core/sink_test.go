@@ -0,0 +1,187 @@
+package core
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// bufferingSink buffers tuples until it's flushed.
+type bufferingSink struct {
+	m        sync.Mutex
+	c        *sync.Cond
+	written  int
+	buffered []*Tuple
+	flushed  []*Tuple
+	flushes  int
+	closed   bool
+
+	// flushedBeforeClose is true when the sink had no buffered tuple when
+	// it was closed.
+	flushedBeforeClose bool
+	flushErr           error
+}
+
+func (s *bufferingSink) Write(ctx *Context, t *Tuple) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.buffered = append(s.buffered, t)
+	s.written++
+	s.c.Broadcast()
+	return nil
+}
+
+// wait waits until the sink receives at least n tuples.
+func (s *bufferingSink) wait(n int) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	for s.written < n {
+		s.c.Wait()
+	}
+}
+
+func (s *bufferingSink) Flush(ctx *Context) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.flushes++
+	if s.flushErr != nil {
+		return s.flushErr
+	}
+	s.flushed = append(s.flushed, s.buffered...)
+	s.buffered = nil
+	return nil
+}
+
+func (s *bufferingSink) Close(ctx *Context) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.closed = true
+	s.flushedBeforeClose = len(s.buffered) == 0
+	return nil
+}
+
+func (s *bufferingSink) numFlushed() int {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return len(s.flushed)
+}
+
+func TestSinkFlusher(t *testing.T) {
+	Convey("Given a topology with a sink implementing Flusher", t, func() {
+		ctx := NewContext(nil)
+		tp, err := NewDefaultTopology(ctx, "test")
+		So(err, ShouldBeNil)
+		Reset(func() {
+			tp.Stop()
+		})
+
+		so := NewTupleIncrementalEmitterSource(freshTuples())
+		son, err := tp.AddSource("source", so, nil)
+		So(err, ShouldBeNil)
+
+		si := &bufferingSink{}
+		si.c = sync.NewCond(&si.m)
+		sin, err := tp.AddSink("sink", si, nil)
+		So(err, ShouldBeNil)
+		So(sin.Input("source", nil), ShouldBeNil)
+
+		Convey("When writing tuples", func() {
+			so.EmitTuples(2)
+			si.wait(2)
+
+			Convey("Then they shouldn't be flushed yet", func() {
+				So(si.numFlushed(), ShouldEqual, 0)
+			})
+
+			Convey("And flushing the sink node", func() {
+				So(sin.Flush(), ShouldBeNil)
+
+				Convey("Then buffered tuples should be flushed", func() {
+					So(si.numFlushed(), ShouldEqual, 2)
+					So(si.flushes, ShouldEqual, 1)
+				})
+			})
+
+			Convey("And flushing all sinks of the topology", func() {
+				So(tp.FlushSinks(), ShouldBeNil)
+
+				Convey("Then the sink should be flushed", func() {
+					So(si.flushes, ShouldEqual, 1)
+				})
+			})
+
+			Convey("And pausing the source", func() {
+				So(son.Pause(), ShouldBeNil)
+
+				Convey("Then the sink should be flushed", func() {
+					So(si.flushes, ShouldEqual, 1)
+				})
+
+				Convey("Then pausing the paused source again shouldn't flush the sink", func() {
+					So(son.Pause(), ShouldBeNil)
+					So(si.flushes, ShouldEqual, 1)
+				})
+			})
+
+			Convey("And stopping the topology", func() {
+				so.EmitTuples(2)
+				So(tp.Stop(), ShouldBeNil)
+
+				Convey("Then the sink should be flushed before it's closed", func() {
+					So(si.closed, ShouldBeTrue)
+					So(si.flushedBeforeClose, ShouldBeTrue)
+					So(si.numFlushed(), ShouldEqual, 4)
+				})
+
+				Convey("Then flushing the stopped sink node should fail", func() {
+					So(sin.Flush(), ShouldNotBeNil)
+				})
+
+				Convey("Then flushing all sinks should skip the stopped sink", func() {
+					So(tp.FlushSinks(), ShouldBeNil)
+					So(si.flushes, ShouldEqual, 1)
+				})
+			})
+		})
+
+		Convey("When the sink fails to flush", func() {
+			si.flushErr = errors.New("failure")
+
+			Convey("Then flushing all sinks should fail", func() {
+				So(tp.FlushSinks(), ShouldNotBeNil)
+			})
+
+			Convey("Then pausing the source should succeed", func() {
+				So(son.Pause(), ShouldBeNil)
+				So(si.flushes, ShouldEqual, 1)
+			})
+
+			Convey("Then stopping the topology should close the sink", func() {
+				So(tp.Stop(), ShouldBeNil)
+				So(si.closed, ShouldBeTrue)
+			})
+		})
+	})
+
+	Convey("Given a topology with a sink not implementing Flusher", t, func() {
+		ctx := NewContext(nil)
+		tp, err := NewDefaultTopology(ctx, "test")
+		So(err, ShouldBeNil)
+		Reset(func() {
+			tp.Stop()
+		})
+		sin, err := tp.AddSink("sink", NewTupleCollectorSink(), nil)
+		So(err, ShouldBeNil)
+
+		Convey("When flushing the sink", func() {
+			err := sin.Flush()
+
+			Convey("Then it should do nothing", func() {
+				So(err, ShouldBeNil)
+				So(tp.FlushSinks(), ShouldBeNil)
+			})
+		})
+	})
+}
@@ -15,6 +15,14 @@ type Source interface {
 	// have been written (in the case of a finite data source) or if
 	// there was a severe error. The context that is passed in will be
 	// used as a parameter to the Write method of the given Writer.
+	//
+	// Errors returned from GenerateStream should be classified so that
+	// users and tools can tell whether the source might work again. An
+	// error which cannot be fixed by retrying, such as an authentication
+	// failure, should be decorated by FatalError. An error caused by an
+	// unavailable endpoint, such as a refused or lost connection, should be
+	// decorated by TemporaryError. A network source should retry temporary
+	// errors by itself with Reconnector instead of returning them.
 	GenerateStream(ctx *Context, w Writer) error
 
 	// Stop will tell the Source to stop emitting tuples. After this
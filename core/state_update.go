@@ -0,0 +1,196 @@
+package core
+
+import (
+	"errors"
+	"sync"
+
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// StateUpdateEventType is the type of an event reported when a SharedState is
+// changed.
+type StateUpdateEventType int
+
+const (
+	// SUECreated is reported when a state is added to the registry.
+	SUECreated StateUpdateEventType = iota
+
+	// SUELoaded is reported when a state is overwritten by saved data, e.g.
+	// by LOAD STATE.
+	SUELoaded
+
+	// SUEUpdated is reported when parameters of a state are updated by
+	// Updater.Update, e.g. by UPDATE STATE.
+	SUEUpdated
+
+	// SUEChanged is reported by a state itself through
+	// Context.NotifyStateChange.
+	SUEChanged
+
+	// SUEDropped is reported when a state is removed from the registry.
+	SUEDropped
+)
+
+func (t StateUpdateEventType) String() string {
+	switch t {
+	case SUECreated:
+		return "created"
+	case SUELoaded:
+		return "loaded"
+	case SUEUpdated:
+		return "updated"
+	case SUEChanged:
+		return "changed"
+	case SUEDropped:
+		return "dropped"
+	default:
+		return "unknown"
+	}
+}
+
+// NotifyStateUpdate reports an event of the state having the name to sources
+// created by NewStateUpdateSource. info is additional information of the
+// event and can be nil.
+func (c *Context) NotifyStateUpdate(name string, et StateUpdateEventType, info data.Map) {
+	typeName, _ := c.SharedStates.Type(name)
+	c.notifyStateUpdate(name, typeName, et, info)
+}
+
+// NotifyStateChange reports a SUEChanged event of the state. A SharedState
+// can call this method when its internal data is changed, e.g. when a new
+// model is trained, so that downstream nodes can react to the change. The
+// event is reported for each name under which the state is registered.
+func (c *Context) NotifyStateChange(s SharedState, info data.Map) {
+	states, err := c.SharedStates.List()
+	if err != nil {
+		return
+	}
+	for name, st := range states {
+		if st == s {
+			c.NotifyStateUpdate(name, SUEChanged, info)
+		}
+	}
+}
+
+func (c *Context) notifyStateUpdate(name, typeName string, et StateUpdateEventType, info data.Map) {
+	if c == nil {
+		return
+	}
+	c.suMutex.RLock()
+	defer c.suMutex.RUnlock()
+	if len(c.suSources) == 0 {
+		return
+	}
+
+	if info == nil {
+		info = data.Map{}
+	}
+	now := c.Clock().Now()
+	t := NewTuple(data.Map{
+		"state_name": data.String(name),
+		"state_type": data.String(typeName),
+		"event_type": data.String(et.String()),
+		"info":       info,
+	})
+	t.Timestamp = now
+	t.ProcTimestamp = now
+	var targets []*stateUpdateSource
+	for _, s := range c.suSources {
+		if s.accepts(name) {
+			targets = append(targets, s)
+		}
+	}
+	if len(targets) > 1 {
+		t.Flags.Set(TFShared)
+	}
+	for _, s := range targets {
+		s.w.Write(c, t) // There isn't much meaning to report errors here.
+	}
+}
+
+// addStateUpdateSource adds a listener which receives state update events.
+// The return value is the ID of the listener and it'll be required for
+// removeStateUpdateSource.
+func (c *Context) addStateUpdateSource(s *stateUpdateSource) int64 {
+	c.suMutex.Lock()
+	defer c.suMutex.Unlock()
+	id := NewTemporaryID()
+	c.suSources[id] = s
+	return id
+}
+
+func (c *Context) removeStateUpdateSource(id int64) {
+	c.suMutex.Lock()
+	defer c.suMutex.Unlock()
+	delete(c.suSources, id)
+}
+
+type stateUpdateSource struct {
+	// names has names of states whose events are emitted. Events of all
+	// states are emitted when it's empty.
+	names map[string]bool
+
+	w     Writer
+	id    int64
+	m     sync.Mutex
+	state *topologyStateHolder
+}
+
+// NewStateUpdateSource returns a source which generates a stream of events
+// reported when the states having the given names are changed. Events of all
+// states are generated when no name is given.
+//
+// Tuples generated from this source has the following fields in Data:
+//
+//	- state_name: the name of the state
+//	- state_type: the type of the state
+//	- event_type: "created", "loaded", "updated", "changed", or "dropped"
+//	- info: additional information of the event
+func NewStateUpdateSource(names ...string) Source {
+	src := &stateUpdateSource{
+		names: make(map[string]bool, len(names)),
+	}
+	for _, n := range names {
+		src.names[n] = true
+	}
+	src.state = newTopologyStateHolder(&src.m)
+	return src
+}
+
+func (s *stateUpdateSource) accepts(name string) bool {
+	return len(s.names) == 0 || s.names[name]
+}
+
+func (s *stateUpdateSource) GenerateStream(ctx *Context, w Writer) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.state.getWithoutLock() >= TSStopping {
+		return errors.New("the source is already stopped")
+	}
+	s.w = w
+	s.id = ctx.addStateUpdateSource(s)
+	s.state.setWithoutLock(TSRunning)
+	defer s.state.setWithoutLock(TSStopped)
+	s.state.waitWithoutLock(TSStopping)
+	return nil
+}
+
+func (s *stateUpdateSource) Stop(ctx *Context) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	switch s.state.getWithoutLock() {
+	case TSInitialized:
+		// GenerateStream hasn't been called yet and it'll fail.
+		s.state.setWithoutLock(TSStopped)
+		return nil
+	case TSStopping:
+		s.state.waitWithoutLock(TSStopped)
+		return nil
+	case TSStopped:
+		return nil
+	}
+	ctx.removeStateUpdateSource(s.id)
+	s.state.setWithoutLock(TSStopping)
+	s.state.waitWithoutLock(TSStopped)
+	return nil
+}
@@ -0,0 +1,82 @@
+package core
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+func TestStateUpdateSource(t *testing.T) {
+	Convey("Given a topology with a state update source", t, func() {
+		ctx := NewContext(nil)
+		t, err := NewDefaultTopology(ctx, "su1")
+		So(err, ShouldBeNil)
+		Reset(func() {
+			t.Stop()
+		})
+
+		src := NewStateUpdateSource("s1").(*stateUpdateSource)
+		_, err = t.AddSource("updates", src, nil)
+		So(err, ShouldBeNil)
+		src.state.Wait(TSRunning)
+		si := NewTupleCollectorSink()
+		sin, err := t.AddSink("events", si, nil)
+		So(err, ShouldBeNil)
+		So(sin.Input("updates", nil), ShouldBeNil)
+
+		s1 := &stubSharedState{}
+		So(ctx.SharedStates.Add("s1", "stub", s1), ShouldBeNil)
+		So(ctx.SharedStates.Add("s2", "stub", &stubSharedState{}), ShouldBeNil)
+
+		Convey("When the state is created", func() {
+			Convey("Then the source should emit an event", func() {
+				si.Wait(1)
+				m := si.get(0).Data
+				So(m["state_name"], ShouldEqual, "s1")
+				So(m["state_type"], ShouldEqual, "stub")
+				So(m["event_type"], ShouldEqual, SUECreated.String())
+			})
+		})
+
+		Convey("When the state reports its change", func() {
+			ctx.NotifyStateChange(s1, data.Map{"version": data.Int(2)})
+
+			Convey("Then the source should emit an event with the info", func() {
+				si.Wait(2)
+				m := si.get(1).Data
+				So(m["state_name"], ShouldEqual, "s1")
+				So(m["event_type"], ShouldEqual, SUEChanged.String())
+				So(m["info"], ShouldResemble, data.Map{"version": data.Int(2)})
+			})
+		})
+
+		Convey("When states are removed", func() {
+			_, err := ctx.SharedStates.Remove("s2")
+			So(err, ShouldBeNil)
+			_, err = ctx.SharedStates.Remove("s1")
+			So(err, ShouldBeNil)
+
+			Convey("Then the source should only emit an event of the state", func() {
+				si.Wait(2)
+				So(si.len(), ShouldEqual, 2)
+				m := si.get(1).Data
+				So(m["state_name"], ShouldEqual, "s1")
+				So(m["state_type"], ShouldEqual, "stub")
+				So(m["event_type"], ShouldEqual, SUEDropped.String())
+			})
+		})
+	})
+
+	Convey("Given a state update source which isn't started", t, func() {
+		src := NewStateUpdateSource()
+
+		Convey("When stopping it", func() {
+			So(src.Stop(NewContext(nil)), ShouldBeNil)
+
+			Convey("Then it cannot be started", func() {
+				So(src.GenerateStream(NewContext(nil), nil), ShouldNotBeNil)
+			})
+		})
+	})
+}
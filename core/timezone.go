@@ -0,0 +1,78 @@
+package core
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	locationCacheMutex sync.RWMutex
+	locationCache      = map[string]*time.Location{}
+)
+
+// LoadLocation returns the time zone having the name. The name is either a
+// name in the IANA Time Zone database such as "Asia/Tokyo", "UTC", "Local",
+// or a fixed offset from UTC such as "+09:00" and "-0530". Loaded time zones
+// are cached because loading one from the database is costly.
+func LoadLocation(name string) (*time.Location, error) {
+	locationCacheMutex.RLock()
+	loc, ok := locationCache[name]
+	locationCacheMutex.RUnlock()
+	if ok {
+		return loc, nil
+	}
+
+	loc, err := loadLocation(name)
+	if err != nil {
+		return nil, err
+	}
+	locationCacheMutex.Lock()
+	defer locationCacheMutex.Unlock()
+	locationCache[name] = loc
+	return loc, nil
+}
+
+func loadLocation(name string) (*time.Location, error) {
+	if name == "" {
+		return nil, fmt.Errorf("the name of a time zone must not be empty")
+	}
+	if name[0] != '+' && name[0] != '-' {
+		loc, err := time.LoadLocation(name)
+		if err != nil {
+			return nil, fmt.Errorf("unknown time zone '%v': %v", name, err)
+		}
+		return loc, nil
+	}
+
+	invalid := fmt.Errorf("a time zone offset must be in the form of +hh:mm or +hhmm: %v", name)
+	offset := strings.Replace(name[1:], ":", "", 1)
+	if len(offset) != 4 {
+		return nil, invalid
+	}
+	h, err := strconv.Atoi(offset[:2])
+	if err != nil || h > 23 {
+		return nil, invalid
+	}
+	m, err := strconv.Atoi(offset[2:])
+	if err != nil || m > 59 {
+		return nil, invalid
+	}
+	sec := h*3600 + m*60
+	if name[0] == '-' {
+		sec = -sec
+	}
+	return time.FixedZone(name, sec), nil
+}
+
+// Location returns the time zone of the topology. It's used to interpret
+// timestamps without time zones and to format timestamps in local time. It
+// returns time.Local when the Context is nil or doesn't have a time zone.
+func (c *Context) Location() *time.Location {
+	if c == nil || c.location == nil {
+		return time.Local
+	}
+	return c.location
+}
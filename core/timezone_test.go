@@ -0,0 +1,71 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestLoadLocation(t *testing.T) {
+	Convey("Given names of time zones", t, func() {
+		Convey("When loading a time zone in the database", func() {
+			loc, err := LoadLocation("UTC")
+
+			Convey("Then it should be loaded", func() {
+				So(err, ShouldBeNil)
+				So(loc, ShouldEqual, time.UTC)
+			})
+		})
+
+		Convey("When loading fixed offsets", func() {
+			for _, c := range []struct {
+				name   string
+				offset int
+			}{
+				{"+09:00", 9 * 60 * 60},
+				{"-0530", -(5*60*60 + 30*60)},
+				{"+00:00", 0},
+			} {
+				c := c
+
+				Convey("Then it should have the offset: "+c.name, func() {
+					loc, err := LoadLocation(c.name)
+					So(err, ShouldBeNil)
+					_, offset := time.Unix(0, 0).In(loc).Zone()
+					So(offset, ShouldEqual, c.offset)
+				})
+			}
+		})
+
+		Convey("When loading invalid names", func() {
+			for _, name := range []string{"", "Mars/Olympus", "+9", "+24:00", "+09:60", "+0a:00", "+09:00:00"} {
+				name := name
+
+				Convey("Then it should fail: "+name, func() {
+					_, err := LoadLocation(name)
+					So(err, ShouldNotBeNil)
+				})
+			}
+		})
+	})
+
+	Convey("Given a context", t, func() {
+		Convey("When it doesn't have a time zone", func() {
+			ctx := NewContext(nil)
+
+			Convey("Then it should use the local time zone", func() {
+				So(ctx.Location(), ShouldEqual, time.Local)
+			})
+		})
+
+		Convey("When it has a time zone", func() {
+			loc := time.FixedZone("JST", 9*60*60)
+			ctx := NewContext(&ContextConfig{Location: loc})
+
+			Convey("Then it should use the time zone", func() {
+				So(ctx.Location(), ShouldEqual, loc)
+			})
+		})
+	})
+}
@@ -47,6 +47,11 @@ type Topology interface {
 	// BUG: Currently Stop method doesn't work if the topology has a cycle.
 	Stop() error
 
+	// FlushSinks flushes all sinks implementing Flusher. An error of a sink
+	// doesn't prevent other sinks from being flushed and the last error is
+	// returned. Sinks which are stopping or have stopped are skipped.
+	FlushSinks() error
+
 	// State returns the current state of the topology. The topology's state
 	// isn't relevant to those nodes have.
 	State() TopologyStateHolder
@@ -97,15 +102,37 @@ type SourceConfig struct {
 	// If it is true, the source is removed.
 	RemoveOnStop bool
 
+	// TupleIDGenerator is used to assign IDs to tuples emitted from the source
+	// which don't have IDs yet. When it's nil, Context.TupleIDGenerator of
+	// the topology is used instead.
+	TupleIDGenerator TupleIDGenerator
+
 	// Meta contains meta information of the source. This field won't be used
 	// by core package and application can store any form of information
 	// related to the source.
 	Meta interface{}
+
+	// Labels are labels attached to the source. Nodes can be selected by
+	// their labels with LabelSelector. See ValidateLabel for the format of
+	// a label.
+	Labels []string
 }
 
 // BoxConfig has configuration parameters of a Box node.
 type BoxConfig struct {
-	// TODO: parallelism
+	// Parallelism is the number of goroutines calling Box.Process
+	// concurrently. It's 1 when it's 0. When it's greater than 1, the box
+	// must support concurrent calls of Process and the order of output
+	// tuples isn't guaranteed.
+	Parallelism int
+
+	// Autoscale enables the autoscaler which adjusts the number of
+	// goroutines calling Box.Process between Autoscale.MinParallelism and
+	// Autoscale.MaxParallelism according to the load of the box. Parallelism
+	// is used as the initial number when it's in the range. The box must
+	// support concurrent calls of Process when MaxParallelism is greater
+	// than 1.
+	Autoscale *AutoscaleConfig
 
 	// RemoveOnStop is a flag which indicates the stop state of the topology.
 	// If it is true, the box is removed.
@@ -115,6 +142,11 @@ type BoxConfig struct {
 	// by core package and application can store any form of information
 	// related to the box.
 	Meta interface{}
+
+	// Labels are labels attached to the box. Nodes can be selected by
+	// their labels with LabelSelector. See ValidateLabel for the format of
+	// a label.
+	Labels []string
 }
 
 // SinkConfig has configuration parameters of a Sink node.
@@ -127,4 +159,9 @@ type SinkConfig struct {
 	// by core package and application can store any form of information
 	// related to the sink.
 	Meta interface{}
+
+	// Labels are labels attached to the sink. Nodes can be selected by
+	// their labels with LabelSelector. See ValidateLabel for the format of
+	// a label.
+	Labels []string
 }
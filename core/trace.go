@@ -35,6 +35,11 @@ type TraceEvent struct {
 	// Msg is any message, but for transitions it makes sense to use the
 	// name of the Source/Box/Sink that was left/entered.
 	Msg string
+
+	// Inputs are IDs of input tuples which contributed to the Tuple when
+	// it's emitted from a Box aggregating or joining tuples. It's only set
+	// to OUTPUT events when lineage is recorded. See LineageConfig.
+	Inputs []string
 }
 
 func (t EventType) String() string {
@@ -51,19 +56,45 @@ func (t EventType) String() string {
 }
 
 func tracing(t *Tuple, ctx *Context, inout EventType, msg string) {
+	l := t.lineage
+	if inout == ETOutput {
+		// The lineage only belongs to the output event of the Box which
+		// set it.
+		t.lineage = nil
+	}
 	if !ctx.Flags.TupleTrace.Enabled() {
 		return
 	}
-	ev := newDefaultEvent(inout, msg)
+	ev := newDefaultEvent(ctx.Clock().Now(), inout, msg)
+	if inout == ETOutput && l != nil {
+		ev.Inputs = l.Inputs
+		recordLineage(ctx, t, msg, ev.Timestamp, l)
+	}
 	t.AddEvent(ev)
 }
 
-func newDefaultEvent(inout EventType, msg string) TraceEvent {
+func newDefaultEvent(now time.Time, inout EventType, msg string) TraceEvent {
 	return TraceEvent{
-		time.Now(),
-		inout,
-		msg,
+		Timestamp: now,
+		Type:      inout,
+		Msg:       msg,
+	}
+}
+
+// recordLineage adds the lineage of the tuple emitted from the node to the
+// store of the Context.
+func recordLineage(ctx *Context, t *Tuple, node string, now time.Time, l *Lineage) {
+	s := ctx.Lineage()
+	if s == nil || t.ID == "" {
+		return
 	}
+	s.Add(&LineageRecord{
+		TupleID:   t.ID,
+		Node:      node,
+		Timestamp: now,
+		Inputs:    l.Inputs,
+		Truncated: l.Truncated,
+	})
 }
 
 type traceWriter struct {
@@ -33,6 +33,13 @@ type Tuple struct {
 	// BatchID is reserved for future use.
 	BatchID int64
 
+	// ID is a globally unique ID of this tuple. It's assigned by a source
+	// node when the topology or the source has a TupleIDGenerator and the
+	// Source didn't set the ID by itself. Boxes emitting a tuple derived from
+	// a received one should keep the ID so that the tuple can be identified
+	// (e.g. deduplicated) in downstream nodes.
+	ID string
+
 	// Flags has bit flags which controls behavior of this tuple. When a Box
 	// emits a tuple derived from a received one, it must copy this field
 	// otherwise a problem like infinite reporting of a dropped tuple could
@@ -42,6 +49,14 @@ type Tuple struct {
 	// Trace is used during debugging to trace to way of a Tuple through
 	// a topology. See the documentation for TraceEvent.
 	Trace []TraceEvent
+
+	// lineage is set by a Box through SetLineage and is moved to the trace
+	// when the tuple is written to the next node.
+	lineage *Lineage
+
+	// ack is shared by all tuples derived from a tuple whose Source
+	// requested acknowledgment. It's nil otherwise. See AckHandler.
+	ack *ackToken
 }
 
 // AddEvent adds a TraceEvent to this Tuple's trace. This is not
@@ -51,6 +66,15 @@ func (t *Tuple) AddEvent(ev TraceEvent) {
 	t.Trace = append(t.Trace, ev)
 }
 
+// SetLineage sets IDs of input tuples which contributed to this tuple. A Box
+// aggregating or joining tuples calls it on an output tuple before writing
+// it. When tuple tracing is enabled, the lineage is added to the output
+// event of the trace and recorded in the LineageStore of the Context.
+// Otherwise, it's discarded.
+func (t *Tuple) SetLineage(l *Lineage) {
+	t.lineage = l
+}
+
 // Copy creates a deep copy of a Tuple, including the contained
 // data. This can be used, e.g., by fan-out pipes. When Tuple.Data doesn't
 // need to be cloned, call ShallowCopy. NEVER do newTuple := *oldTuple.
@@ -132,6 +156,13 @@ const (
 	//	(false, true): a tuple returned from ShallowCopy
 	//	(false, false): a tuple returned from NewTuple or Copy
 	TFSharedData
+
+	// TFBackfill is a flag which is set when a tuple is emitted while a
+	// source is replaying historical data before switching to live data.
+	// Because Boxes copy Flags to derived tuples, results computed from
+	// historical tuples also have this flag. It can be referred in BQL by
+	// is_backfill().
+	TFBackfill
 )
 
 // Set sets a set of flags at once.
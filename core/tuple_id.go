@@ -0,0 +1,203 @@
+package core
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// TupleIDGenerator generates IDs of tuples. An ID generated by a generator
+// must be unique among all tuples emitted from all sources of all processes
+// using the same kind of generator (as long as the generator is configured
+// correctly, e.g. with distinct worker IDs for snowflake).
+//
+// NewID can be called concurrently from multiple sources.
+type TupleIDGenerator interface {
+	// NewID returns a new unique ID.
+	NewID() string
+}
+
+// NewTupleIDGenerator creates a TupleIDGenerator from its name. Supported
+// names are:
+//
+//	- uuidv7: see NewUUIDv7Generator
+//	- snowflake: see NewSnowflakeGenerator, a worker ID is derived from
+//	  the hostname and the process ID
+func NewTupleIDGenerator(name string) (TupleIDGenerator, error) {
+	switch name {
+	case "uuidv7":
+		return NewUUIDv7Generator(), nil
+	case "snowflake":
+		return NewSnowflakeGenerator(defaultSnowflakeWorkerID())
+	default:
+		return nil, fmt.Errorf("unsupported tuple ID generator: %v", name)
+	}
+}
+
+type uuidv7Generator struct {
+	m      sync.Mutex
+	lastMS int64
+	seq    uint16
+}
+
+// NewUUIDv7Generator returns a TupleIDGenerator generating UUID version 7
+// (time-ordered UUID) strings such as "01890a5d-ac96-774b-bcce-b302099a8057".
+// IDs generated by the same generator within the same millisecond are
+// monotonically increasing.
+func NewUUIDv7Generator() TupleIDGenerator {
+	return &uuidv7Generator{}
+}
+
+func (g *uuidv7Generator) NewID() string {
+	var u [16]byte
+	if _, err := rand.Read(u[6:]); err != nil {
+		// crypto/rand doesn't fail on supported platforms.
+		panic(fmt.Errorf("cannot read random bytes: %v", err))
+	}
+
+	g.m.Lock()
+	ms := time.Now().UnixNano() / int64(time.Millisecond)
+	if ms <= g.lastMS {
+		// Keep IDs monotonic even if the clock goes backward or multiple
+		// IDs are generated within the same millisecond.
+		ms = g.lastMS
+		g.seq++
+		if g.seq >= 1<<12 {
+			ms++
+			g.seq = 0
+		}
+	} else {
+		g.seq = 0
+	}
+	g.lastMS = ms
+	seq := g.seq
+	g.m.Unlock()
+
+	u[0] = byte(ms >> 40)
+	u[1] = byte(ms >> 32)
+	u[2] = byte(ms >> 24)
+	u[3] = byte(ms >> 16)
+	u[4] = byte(ms >> 8)
+	u[5] = byte(ms)
+	u[6] = 0x70 | byte(seq>>8) // version 7 + the higher bits of rand_a
+	u[7] = byte(seq)
+	u[8] = 0x80 | (u[8] & 0x3f) // variant 10
+
+	var buf [36]byte
+	hex.Encode(buf[0:8], u[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], u[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], u[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], u[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], u[10:])
+	return string(buf[:])
+}
+
+const (
+	snowflakeWorkerIDBits = 10
+	snowflakeSequenceBits = 12
+
+	// MaxSnowflakeWorkerID is the maximum worker ID of snowflake IDs.
+	MaxSnowflakeWorkerID = 1<<snowflakeWorkerIDBits - 1
+)
+
+var (
+	// snowflakeEpoch is the custom epoch of snowflake IDs:
+	// 2015-01-01T00:00:00Z in milliseconds.
+	snowflakeEpoch int64 = 1420070400000
+)
+
+type snowflakeGenerator struct {
+	m        sync.Mutex
+	workerID int64
+	lastMS   int64
+	seq      int64
+}
+
+// NewSnowflakeGenerator returns a TupleIDGenerator generating 63-bit
+// snowflake IDs formatted as decimal strings. An ID consists of 41 bits of
+// milliseconds elapsed since 2015-01-01, 10 bits of the worker ID, and 12
+// bits of a sequence number. Each process (or each generator in a process)
+// sharing IDs must have a distinct workerID between 0 and
+// MaxSnowflakeWorkerID.
+func NewSnowflakeGenerator(workerID int64) (TupleIDGenerator, error) {
+	if workerID < 0 || workerID > MaxSnowflakeWorkerID {
+		return nil, fmt.Errorf("worker ID of snowflake must be in [0, %v]: %v",
+			MaxSnowflakeWorkerID, workerID)
+	}
+	return &snowflakeGenerator{
+		workerID: workerID,
+	}, nil
+}
+
+func (g *snowflakeGenerator) NewID() string {
+	g.m.Lock()
+	ms := time.Now().UnixNano()/int64(time.Millisecond) - snowflakeEpoch
+	if ms <= g.lastMS {
+		ms = g.lastMS
+		g.seq++
+		if g.seq >= 1<<snowflakeSequenceBits {
+			// Borrow the next millisecond rather than sleeping. The clock
+			// will catch up soon unless more than 4M IDs per second are
+			// generated constantly.
+			ms++
+			g.seq = 0
+		}
+	} else {
+		g.seq = 0
+	}
+	g.lastMS = ms
+	id := ms<<(snowflakeWorkerIDBits+snowflakeSequenceBits) |
+		g.workerID<<snowflakeSequenceBits | g.seq
+	g.m.Unlock()
+	return strconv.FormatInt(id, 10)
+}
+
+// defaultSnowflakeWorkerID derives a worker ID from the hostname and the
+// process ID. It doesn't guarantee uniqueness, so a worker ID should
+// explicitly be given when multiple processes share IDs.
+func defaultSnowflakeWorkerID() int64 {
+	h := fnv.New32a()
+	if name, err := os.Hostname(); err == nil {
+		h.Write([]byte(name))
+	}
+	var pid [8]byte
+	binary.BigEndian.PutUint64(pid[:], uint64(os.Getpid()))
+	h.Write(pid[:])
+	return int64(h.Sum32() % (MaxSnowflakeWorkerID + 1))
+}
+
+// tupleIDWriter assigns an ID to each tuple which doesn't have one yet.
+type tupleIDWriter struct {
+	w   Writer
+	gen TupleIDGenerator
+}
+
+func newTupleIDWriter(w Writer, gen TupleIDGenerator) Writer {
+	if gen == nil {
+		return w
+	}
+	return &tupleIDWriter{
+		w:   w,
+		gen: gen,
+	}
+}
+
+func (w *tupleIDWriter) Write(ctx *Context, t *Tuple) error {
+	if t.ID == "" {
+		if t.Flags.IsSet(TFShared) {
+			t = t.ShallowCopy()
+		}
+		t.ID = w.gen.NewID()
+	}
+	return w.w.Write(ctx, t)
+}
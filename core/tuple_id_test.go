@@ -0,0 +1,131 @@
+package core
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+func TestTupleIDGenerator(t *testing.T) {
+	Convey("Given a UUIDv7 generator", t, func() {
+		g, err := NewTupleIDGenerator("uuidv7")
+		So(err, ShouldBeNil)
+
+		Convey("When generating IDs", func() {
+			ids := make([]string, 10000)
+			for i := range ids {
+				ids[i] = g.NewID()
+			}
+
+			Convey("Then they should be valid UUIDv7 strings", func() {
+				re := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-7[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+				for _, id := range ids {
+					So(re.MatchString(id), ShouldBeTrue)
+				}
+			})
+
+			Convey("Then they should be unique and increasing", func() {
+				for i := 1; i < len(ids); i++ {
+					So(ids[i-1] < ids[i], ShouldBeTrue)
+				}
+			})
+		})
+	})
+
+	Convey("Given a snowflake generator", t, func() {
+		g, err := NewSnowflakeGenerator(5)
+		So(err, ShouldBeNil)
+
+		Convey("When generating IDs", func() {
+			ids := make([]int64, 10000)
+			for i := range ids {
+				id, err := strconv.ParseInt(g.NewID(), 10, 64)
+				So(err, ShouldBeNil)
+				ids[i] = id
+			}
+
+			Convey("Then they should have the worker ID", func() {
+				for _, id := range ids {
+					So(id>>snowflakeSequenceBits&MaxSnowflakeWorkerID, ShouldEqual, 5)
+				}
+			})
+
+			Convey("Then they should be unique and increasing", func() {
+				for i := 1; i < len(ids); i++ {
+					So(ids[i-1], ShouldBeLessThan, ids[i])
+				}
+			})
+		})
+	})
+
+	Convey("Given invalid parameters", t, func() {
+		Convey("When creating a snowflake generator with a negative worker ID", func() {
+			_, err := NewSnowflakeGenerator(-1)
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When creating a snowflake generator with a too large worker ID", func() {
+			_, err := NewSnowflakeGenerator(MaxSnowflakeWorkerID + 1)
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When creating an unknown generator", func() {
+			_, err := NewTupleIDGenerator("no_such_generator")
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+func TestSourceTupleID(t *testing.T) {
+	Convey("Given a topology having a tuple ID generator", t, func() {
+		ctx := NewContext(&ContextConfig{
+			TupleIDGenerator: NewUUIDv7Generator(),
+		})
+		t, err := NewDefaultTopology(ctx, "dt1")
+		So(err, ShouldBeNil)
+		Reset(func() {
+			t.Stop()
+		})
+
+		ts := freshTuples()
+		ts[0].ID = "predefined"
+		so := NewTupleEmitterSource(ts)
+		son, err := t.AddSource("source", so, &SourceConfig{
+			PausedOnStartup: true,
+		})
+		So(err, ShouldBeNil)
+
+		si := NewTupleCollectorSink()
+		sin, err := t.AddSink("sink", si, nil)
+		So(err, ShouldBeNil)
+		So(sin.Input("source", nil), ShouldBeNil)
+
+		Convey("When the source emits tuples", func() {
+			So(son.Resume(), ShouldBeNil)
+			si.Wait(len(ts))
+
+			Convey("Then all tuples should have unique IDs", func() {
+				ids := map[string]bool{}
+				si.forEachTuple(func(t *Tuple) {
+					So(t.ID, ShouldNotBeBlank)
+					ids[t.ID] = true
+				})
+				So(len(ids), ShouldEqual, len(ts))
+			})
+
+			Convey("Then an ID set by the source should be kept", func() {
+				So(si.get(0).ID, ShouldEqual, "predefined")
+			})
+		})
+	})
+}
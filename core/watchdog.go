@@ -0,0 +1,299 @@
+package core
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// WatchdogConfig has parameters of the watchdog which measures the latency of
+// Sink.Write and Box.Process calls and detects nodes whose latency is
+// degrading.
+//
+// The watchdog keeps two moving averages of the latency for each node: a
+// baseline which slowly follows the latency while the node is healthy, and a
+// recent average which quickly follows it. When the recent average stays
+// above Threshold times the baseline for Sustain consecutive calls, the node
+// is reported as degraded. It's reported as recovered once the recent average
+// goes back under the threshold.
+//
+// Note that the latency of Box.Process includes the time spent in writing
+// output tuples, which blocks when a pipe to a subsequent node is full.
+//
+// Zero or invalid values in the config are replaced with default values.
+type WatchdogConfig struct {
+	// Threshold is the ratio of the recent latency to the baseline above
+	// which a node is considered to be degrading. It must be greater than 1.
+	// The default value is 3.
+	Threshold float64
+
+	// MinLatency is the recent latency under which a node is never reported
+	// even if it exceeds the threshold. It prevents very fast nodes from
+	// being reported due to jitters. The default value is 1ms.
+	MinLatency time.Duration
+
+	// WarmUp is the number of calls used to establish the initial baseline.
+	// Nodes aren't reported until they're called this many times. The
+	// default value is 100.
+	WarmUp int
+
+	// Sustain is the number of consecutive calls whose recent latency
+	// exceeds the threshold before a node is reported as degraded. The
+	// default value is 50.
+	Sustain int
+}
+
+const (
+	// watchdogBaselineWeight is the weight of a new sample in the baseline.
+	watchdogBaselineWeight = 0.01
+
+	// watchdogRecentWeight is the weight of a new sample in the recent
+	// average.
+	watchdogRecentWeight = 0.1
+)
+
+// withDefaults returns a copy of the config whose invalid or zero values are
+// replaced with default values.
+func (c *WatchdogConfig) withDefaults() *WatchdogConfig {
+	conf := *c
+	if conf.Threshold <= 1 {
+		conf.Threshold = 3
+	}
+	if conf.MinLatency <= 0 {
+		conf.MinLatency = time.Millisecond
+	}
+	if conf.WarmUp <= 0 {
+		conf.WarmUp = 100
+	}
+	if conf.Sustain <= 0 {
+		conf.Sustain = 50
+	}
+	return &conf
+}
+
+// WatchdogEventType is the type of an event reported by the watchdog.
+type WatchdogEventType int
+
+const (
+	// WETDegraded is reported when the latency of a node is degrading.
+	WETDegraded WatchdogEventType = iota
+
+	// WETRecovered is reported when the latency of a degraded node gets
+	// back to its baseline.
+	WETRecovered
+)
+
+func (t WatchdogEventType) String() string {
+	switch t {
+	case WETDegraded:
+		return "degraded"
+	case WETRecovered:
+		return "recovered"
+	default:
+		return "unknown"
+	}
+}
+
+// latencyWatcher keeps the latency statistics of a node.
+type latencyWatcher struct {
+	config   *WatchdogConfig
+	nodeType NodeType
+	nodeName string
+
+	m        sync.Mutex
+	samples  int
+	baseline float64
+	recent   float64
+	exceeded int
+	degraded bool
+}
+
+func newLatencyWatcher(config *WatchdogConfig, nodeType NodeType, nodeName string) *latencyWatcher {
+	return &latencyWatcher{
+		config:   config,
+		nodeType: nodeType,
+		nodeName: nodeName,
+	}
+}
+
+// observe records the latency of a call. It returns true and the type of an
+// event when the state of the node changed.
+func (l *latencyWatcher) observe(d time.Duration) (WatchdogEventType, bool) {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	x := float64(d)
+	l.samples++
+	if l.samples <= l.config.WarmUp {
+		// the baseline during the warm up is a simple average
+		l.baseline += (x - l.baseline) / float64(l.samples)
+		l.recent = l.baseline
+		return 0, false
+	}
+
+	l.recent += watchdogRecentWeight * (x - l.recent)
+	if l.recent > l.baseline*l.config.Threshold && l.recent > float64(l.config.MinLatency) {
+		// The baseline isn't updated while the latency is exceeding the
+		// threshold so that sustained degradation doesn't become the new
+		// normal.
+		l.exceeded++
+		if !l.degraded && l.exceeded >= l.config.Sustain {
+			l.degraded = true
+			return WETDegraded, true
+		}
+		return 0, false
+	}
+
+	l.exceeded = 0
+	l.baseline += watchdogBaselineWeight * (x - l.baseline)
+	if l.degraded {
+		l.degraded = false
+		return WETRecovered, true
+	}
+	return 0, false
+}
+
+// latencies returns the current baseline and recent latency.
+func (l *latencyWatcher) latencies() (baseline, recent time.Duration) {
+	l.m.Lock()
+	defer l.m.Unlock()
+	return time.Duration(l.baseline), time.Duration(l.recent)
+}
+
+// latencyWatchingWriter measures the latency of Write calls of the underlying
+// Writer.
+type latencyWatchingWriter struct {
+	w       Writer
+	watcher *latencyWatcher
+}
+
+// newLatencyWatchingWriter returns a Writer measuring the latency of w when
+// the watchdog is enabled in the Context. Otherwise, it returns w as it is.
+func newLatencyWatchingWriter(ctx *Context, w Writer, nodeType NodeType, nodeName string) Writer {
+	if ctx.watchdog == nil {
+		return w
+	}
+	return &latencyWatchingWriter{
+		w:       w,
+		watcher: newLatencyWatcher(ctx.watchdog, nodeType, nodeName),
+	}
+}
+
+func (lw *latencyWatchingWriter) Write(ctx *Context, t *Tuple) error {
+	start := time.Now()
+	err := lw.w.Write(ctx, t)
+	if et, ok := lw.watcher.observe(time.Now().Sub(start)); ok {
+		ctx.watchdogEvent(lw.watcher, et)
+	}
+	return err
+}
+
+// watchdogEvent logs and reports an event detected by the watchdog.
+func (c *Context) watchdogEvent(l *latencyWatcher, et WatchdogEventType) {
+	baseline, recent := l.latencies()
+	log := c.Log().WithFields(nodeLogFields(l.nodeType, l.nodeName)).WithFields(logrus.Fields{
+		"event_type":       et.String(),
+		"baseline_latency": baseline.String(),
+		"recent_latency":   recent.String(),
+	})
+	switch et {
+	case WETDegraded:
+		log.Warning("The latency of the node is degrading")
+	default:
+		log.Info("The latency of the node has recovered")
+	}
+
+	c.wdMutex.RLock()
+	defer c.wdMutex.RUnlock()
+	if len(c.wdSources) == 0 {
+		return
+	}
+
+	t := NewTuple(data.Map{
+		"node_type":        data.String(l.nodeType.String()),
+		"node_name":        data.String(l.nodeName),
+		"event_type":       data.String(et.String()),
+		"baseline_latency": data.Float(baseline.Seconds()),
+		"recent_latency":   data.Float(recent.Seconds()),
+	})
+	if len(c.wdSources) > 1 {
+		t.Flags.Set(TFShared)
+	}
+	for _, s := range c.wdSources {
+		s.w.Write(c, t) // There isn't much meaning to report errors here.
+	}
+}
+
+// addWatchdogEventSource adds a listener which receives watchdog events. The
+// return value is the ID of the listener and it'll be required for
+// removeWatchdogEventSource.
+func (c *Context) addWatchdogEventSource(s *watchdogEventSource) int64 {
+	c.wdMutex.Lock()
+	defer c.wdMutex.Unlock()
+	id := NewTemporaryID()
+	c.wdSources[id] = s
+	return id
+}
+
+func (c *Context) removeWatchdogEventSource(id int64) {
+	c.wdMutex.Lock()
+	defer c.wdMutex.Unlock()
+	delete(c.wdSources, id)
+}
+
+type watchdogEventSource struct {
+	w     Writer
+	id    int64
+	m     sync.Mutex
+	state *topologyStateHolder
+}
+
+// NewWatchdogEventSource returns a source which generates a stream of events
+// reported by the watchdog. The source doesn't emit anything unless the
+// watchdog is enabled by ContextConfig.Watchdog.
+//
+// Tuples generated from this source has the following fields in Data:
+//
+//	- node_type: the type of the node, "box" or "sink"
+//	- node_name: the name of the node
+//	- event_type: "degraded" or "recovered"
+//	- baseline_latency: the baseline latency of the node in seconds
+//	- recent_latency: the recent latency of the node in seconds
+func NewWatchdogEventSource() Source {
+	src := &watchdogEventSource{}
+	src.state = newTopologyStateHolder(&src.m)
+	return src
+}
+
+func (s *watchdogEventSource) GenerateStream(ctx *Context, w Writer) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.state.getWithoutLock() >= TSStopping {
+		return errors.New("the source is already stopped")
+	}
+	s.w = w
+	s.id = ctx.addWatchdogEventSource(s)
+	s.state.setWithoutLock(TSRunning)
+	defer s.state.setWithoutLock(TSStopped)
+	s.state.waitWithoutLock(TSStopping)
+	return nil
+}
+
+func (s *watchdogEventSource) Stop(ctx *Context) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	switch s.state.getWithoutLock() {
+	case TSStopping:
+		s.state.waitWithoutLock(TSStopped)
+		return nil
+	case TSStopped:
+		return nil
+	}
+	ctx.removeWatchdogEventSource(s.id)
+	s.state.setWithoutLock(TSStopping)
+	s.state.waitWithoutLock(TSStopped)
+	return nil
+}
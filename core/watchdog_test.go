@@ -0,0 +1,159 @@
+package core
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+func TestLatencyWatcher(t *testing.T) {
+	Convey("Given a latency watcher", t, func() {
+		w := newLatencyWatcher((&WatchdogConfig{
+			WarmUp:  10,
+			Sustain: 5,
+		}).withDefaults(), NTSink, "sink")
+		observe := func(d time.Duration, n int) []WatchdogEventType {
+			var evs []WatchdogEventType
+			for i := 0; i < n; i++ {
+				if et, ok := w.observe(d); ok {
+					evs = append(evs, et)
+				}
+			}
+			return evs
+		}
+		So(observe(time.Millisecond, 10), ShouldBeEmpty)
+
+		Convey("When the latency stays around the baseline", func() {
+			evs := observe(2*time.Millisecond, 100)
+
+			Convey("Then it shouldn't report anything", func() {
+				So(evs, ShouldBeEmpty)
+			})
+		})
+
+		Convey("When the latency gets much larger than the baseline", func() {
+			evs := observe(10*time.Millisecond, 100)
+
+			Convey("Then it should report degradation only once", func() {
+				So(evs, ShouldResemble, []WatchdogEventType{WETDegraded})
+			})
+
+			Convey("Then the baseline shouldn't follow the degraded latency", func() {
+				baseline, recent := w.latencies()
+				So(baseline, ShouldBeLessThan, 2*time.Millisecond)
+				So(recent, ShouldBeGreaterThan, 3*time.Millisecond)
+			})
+
+			Convey("And the latency gets back to the baseline", func() {
+				evs := observe(time.Millisecond, 100)
+
+				Convey("Then it should report recovery", func() {
+					So(evs, ShouldResemble, []WatchdogEventType{WETRecovered})
+				})
+			})
+		})
+
+		Convey("When the latency is large only for a short period", func() {
+			evs := observe(10*time.Millisecond, 3)
+			evs = append(evs, observe(time.Millisecond, 100)...)
+
+			Convey("Then it shouldn't report anything", func() {
+				So(evs, ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given a latency watcher of a very fast node", t, func() {
+		w := newLatencyWatcher((&WatchdogConfig{
+			WarmUp:     10,
+			Sustain:    5,
+			MinLatency: time.Millisecond,
+		}).withDefaults(), NTBox, "box")
+		for i := 0; i < 10; i++ {
+			w.observe(time.Microsecond)
+		}
+
+		Convey("When the latency increases but is still smaller than MinLatency", func() {
+			reported := false
+			for i := 0; i < 100; i++ {
+				if _, ok := w.observe(100 * time.Microsecond); ok {
+					reported = true
+				}
+			}
+
+			Convey("Then it shouldn't report anything", func() {
+				So(reported, ShouldBeFalse)
+			})
+		})
+	})
+}
+
+type slowDownSink struct {
+	cnt  int64
+	fast int64
+}
+
+func (s *slowDownSink) Write(ctx *Context, t *Tuple) error {
+	if atomic.AddInt64(&s.cnt, 1) > s.fast {
+		time.Sleep(5 * time.Millisecond)
+	}
+	return nil
+}
+
+func (s *slowDownSink) Close(ctx *Context) error {
+	return nil
+}
+
+func TestWatchdogEventSource(t *testing.T) {
+	Convey("Given a topology with the watchdog enabled", t, func() {
+		ctx := NewContext(&ContextConfig{
+			Watchdog: &WatchdogConfig{
+				WarmUp:  10,
+				Sustain: 5,
+			},
+		})
+		t, err := NewDefaultTopology(ctx, "wd1")
+		So(err, ShouldBeNil)
+		Reset(func() {
+			t.Stop()
+		})
+
+		ts := make([]*Tuple, 40)
+		for i := range ts {
+			ts[i] = NewTuple(data.Map{"seq": data.Int(i)})
+		}
+		son, err := t.AddSource("source", NewTupleEmitterSource(ts), &SourceConfig{
+			PausedOnStartup: true,
+		})
+		So(err, ShouldBeNil)
+
+		wdso := NewWatchdogEventSource().(*watchdogEventSource)
+		_, err = t.AddSource("watchdog", wdso, nil)
+		So(err, ShouldBeNil)
+		wdso.state.Wait(TSRunning)
+		si := NewTupleCollectorSink()
+		sin, err := t.AddSink("events", si, nil)
+		So(err, ShouldBeNil)
+		So(sin.Input("watchdog", nil), ShouldBeNil)
+
+		slow, err := t.AddSink("slow", &slowDownSink{fast: 10}, nil)
+		So(err, ShouldBeNil)
+		So(slow.Input("source", nil), ShouldBeNil)
+
+		Convey("When the sink slows down", func() {
+			So(son.Resume(), ShouldBeNil)
+
+			Convey("Then the watchdog should report the sink", func() {
+				si.Wait(1)
+				m := si.get(0).Data
+				So(m["node_type"], ShouldEqual, NTSink.String())
+				So(m["node_name"], ShouldEqual, "slow")
+				So(m["event_type"], ShouldEqual, WETDegraded.String())
+				So(m["recent_latency"], ShouldBeGreaterThan, m["baseline_latency"])
+			})
+		})
+	})
+}
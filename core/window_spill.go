@@ -0,0 +1,35 @@
+package core
+
+// WindowSpillConfig has parameters of spilling contents of windows to disk.
+// When it's given to a Context, Boxes having large windows write older rows
+// of the windows to segment files on disk instead of keeping them in memory.
+// Spilled rows are read again when they're aggregated and their segment
+// files are removed when all rows in them expired.
+//
+// Zero or invalid values in the config are replaced with default values.
+type WindowSpillConfig struct {
+	// Dir is the directory in which segment files are created. The default
+	// directory for temporary files is used when it's empty.
+	Dir string
+
+	// MaxRowsInMemory is the number of rows of a window kept in memory.
+	// Older rows are spilled when the window has more rows. The default
+	// value is 100000.
+	MaxRowsInMemory int
+
+	// SegmentSize is the size of a segment file in bytes. A new segment is
+	// created when the current one exceeds the size. The default value is
+	// 64MB.
+	SegmentSize int64
+}
+
+func (c *WindowSpillConfig) withDefaults() *WindowSpillConfig {
+	conf := *c
+	if conf.MaxRowsInMemory <= 0 {
+		conf.MaxRowsInMemory = 100000
+	}
+	if conf.SegmentSize <= 0 {
+		conf.SegmentSize = 64 * 1024 * 1024
+	}
+	return &conf
+}
@@ -0,0 +1,220 @@
+package data
+
+import (
+	"bufio"
+	"io"
+	"reflect"
+
+	"github.com/ugorji/go/codec"
+)
+
+// cborHandle and msgpackExtHandle are used by CBOR and MessagePack codecs
+// preserving types of values. Unlike msgpackHandle used by MarshalMsgpack,
+// msgpackExtHandle distinguishes strings from blobs and encodes timestamps
+// with the timestamp extension type.
+var (
+	cborHandle       = &codec.CborHandle{}
+	msgpackExtHandle = &codec.MsgpackHandle{}
+)
+
+func init() {
+	cborHandle.MapType = reflect.TypeOf(map[string]interface{}(nil))
+
+	msgpackExtHandle.MapType = reflect.TypeOf(map[string]interface{}(nil))
+	msgpackExtHandle.WriteExt = true
+}
+
+// EncodeCBOR encodes a value in CBOR (RFC 7049). A String is encoded as a
+// text string and a Blob is encoded as a byte string. A Timestamp is encoded
+// as an epoch-based date/time (tag 1) with microsecond precision. Note that
+// the zero time is encoded as null.
+func EncodeCBOR(v Value) ([]byte, error) {
+	var b []byte
+	if err := codec.NewEncoderBytes(&b, cborHandle).Encode(newCodecValue(v)); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// DecodeCBOR decodes a value encoded in CBOR. A key of a map must be a text
+// string. Both standard date/time tags (0 and 1) are decoded as a Timestamp
+// with microsecond precision.
+func DecodeCBOR(b []byte) (Value, error) {
+	return decodeValue(codec.NewDecoderBytes(b, cborHandle))
+}
+
+// EncodeMsgpack encodes a value in MessagePack. Unlike MarshalMsgpack, it
+// uses the str and bin format families for a String and a Blob respectively
+// and encodes a Timestamp with the timestamp extension type (-1). Note that
+// the zero time is encoded as nil.
+func EncodeMsgpack(v Value) ([]byte, error) {
+	var b []byte
+	if err := codec.NewEncoderBytes(&b, msgpackExtHandle).Encode(newCodecValue(v)); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// DecodeMsgpack decodes a value encoded in MessagePack. A key of a map must
+// be a string.
+func DecodeMsgpack(b []byte) (Value, error) {
+	return decodeValue(codec.NewDecoderBytes(b, msgpackExtHandle))
+}
+
+// ValueEncoder writes a stream of values to a writer. Each value is written
+// as an independent item of the format, so a stream can be read by
+// ValueDecoder one value at a time. ValueEncoder isn't thread-safe.
+type ValueEncoder struct {
+	enc *codec.Encoder
+}
+
+// NewCBOREncoder returns an encoder writing a stream of values encoded by
+// EncodeCBOR.
+func NewCBOREncoder(w io.Writer) *ValueEncoder {
+	return &ValueEncoder{
+		enc: codec.NewEncoder(w, cborHandle),
+	}
+}
+
+// NewMsgpackEncoder returns an encoder writing a stream of values encoded by
+// EncodeMsgpack.
+func NewMsgpackEncoder(w io.Writer) *ValueEncoder {
+	return &ValueEncoder{
+		enc: codec.NewEncoder(w, msgpackExtHandle),
+	}
+}
+
+// Encode writes a value to the stream.
+func (e *ValueEncoder) Encode(v Value) error {
+	return e.enc.Encode(newCodecValue(v))
+}
+
+// ValueDecoder reads a stream of values written by ValueEncoder or any
+// other program writing consecutive items of the format. ValueDecoder isn't
+// thread-safe.
+type ValueDecoder struct {
+	r   *countingScanner
+	dec *codec.Decoder
+}
+
+// NewCBORDecoder returns a decoder reading a stream of values encoded in
+// CBOR. When r doesn't implement io.ByteScanner, it's buffered and the
+// decoder may read bytes beyond the last value decoded.
+func NewCBORDecoder(r io.Reader) *ValueDecoder {
+	return newValueDecoder(r, cborHandle)
+}
+
+// NewMsgpackDecoder returns a decoder reading a stream of values encoded in
+// MessagePack. It reads r in the same way as NewCBORDecoder.
+func NewMsgpackDecoder(r io.Reader) *ValueDecoder {
+	return newValueDecoder(r, msgpackExtHandle)
+}
+
+func newValueDecoder(r io.Reader, h codec.Handle) *ValueDecoder {
+	s, ok := r.(io.ByteScanner)
+	if !ok {
+		s = bufio.NewReader(r)
+	}
+	c := &countingScanner{s: s}
+	return &ValueDecoder{
+		r:   c,
+		dec: codec.NewDecoder(c, h),
+	}
+}
+
+// Decode reads the next value from the stream. It returns io.EOF when the
+// stream ends at a boundary of values and io.ErrUnexpectedEOF when it ends
+// in the middle of a value. Because the stream cannot be resynchronized,
+// Decode shouldn't be called again after it returns an error unless the
+// error is a type conversion error such as an out of range integer.
+func (d *ValueDecoder) Decode() (Value, error) {
+	start := d.r.n
+	v, err := decodeValue(d.dec)
+	if err == io.EOF && d.r.n != start {
+		return nil, io.ErrUnexpectedEOF
+	}
+	return v, err
+}
+
+// InputOffset returns the number of bytes read from the stream, which is the
+// end offset of the last value decoded.
+func (d *ValueDecoder) InputOffset() int64 {
+	return d.r.n
+}
+
+// countingScanner counts the number of bytes read from the underlying
+// io.ByteScanner. Since it implements io.ByteScanner, the codec doesn't read
+// bytes ahead.
+type countingScanner struct {
+	s io.ByteScanner
+	n int64
+}
+
+func (c *countingScanner) Read(p []byte) (int, error) {
+	var n int
+	var err error
+	if r, ok := c.s.(io.Reader); ok {
+		n, err = r.Read(p)
+	} else {
+		for n < len(p) {
+			var b byte
+			if b, err = c.s.ReadByte(); err != nil {
+				break
+			}
+			p[n] = b
+			n++
+		}
+	}
+	c.n += int64(n)
+	return n, err
+}
+
+func (c *countingScanner) ReadByte() (byte, error) {
+	b, err := c.s.ReadByte()
+	if err == nil {
+		c.n++
+	}
+	return b, err
+}
+
+func (c *countingScanner) UnreadByte() error {
+	err := c.s.UnreadByte()
+	if err == nil {
+		c.n--
+	}
+	return err
+}
+
+func decodeValue(dec *codec.Decoder) (Value, error) {
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return NewValue(v)
+}
+
+// newCodecValue converts a value to a Go value for codecs. Unlike newIValue,
+// it keeps a Timestamp as time.Time.
+func newCodecValue(v Value) interface{} {
+	switch v.Type() {
+	case TypeTimestamp:
+		t, _ := v.asTimestamp()
+		return t
+	case TypeArray:
+		a, _ := v.asArray()
+		res := make([]interface{}, len(a))
+		for i, e := range a {
+			res[i] = newCodecValue(e)
+		}
+		return res
+	case TypeMap:
+		m, _ := v.asMap()
+		res := make(map[string]interface{}, len(m))
+		for k, e := range m {
+			res[k] = newCodecValue(e)
+		}
+		return res
+	default:
+		return newIValue(v)
+	}
+}
@@ -0,0 +1,134 @@
+package data
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestBinaryCodecs(t *testing.T) {
+	v := Map{
+		"bool":   True,
+		"int":    Int(-3),
+		"uint":   Int(10),
+		"float":  Float(1.5),
+		"string": String("str"),
+		"blob":   Blob("blob"),
+		"ts":     Timestamp(time.Date(2016, time.January, 2, 3, 4, 5, 123456000, time.UTC)),
+		"null":   Null{},
+		"array":  Array{Int(1), String("a"), Array{}},
+		"map":    Map{"a": Map{"b": Blob{1, 2}}},
+	}
+
+	codecs := []struct {
+		name   string
+		encode func(Value) ([]byte, error)
+		decode func([]byte) (Value, error)
+		newEnc func(io.Writer) *ValueEncoder
+		newDec func(io.Reader) *ValueDecoder
+	}{
+		{"CBOR", EncodeCBOR, DecodeCBOR, NewCBOREncoder, NewCBORDecoder},
+		{"MessagePack", EncodeMsgpack, DecodeMsgpack, NewMsgpackEncoder, NewMsgpackDecoder},
+	}
+
+	for _, c := range codecs {
+		c := c
+		Convey("Given a value having all types and the "+c.name+" codec", t, func() {
+			Convey("When encoding and decoding it", func() {
+				b, err := c.encode(v)
+				So(err, ShouldBeNil)
+				d, err := c.decode(b)
+				So(err, ShouldBeNil)
+
+				Convey("Then types should be preserved", func() {
+					m, err := AsMap(d)
+					So(err, ShouldBeNil)
+					So(m["string"].Type(), ShouldEqual, TypeString)
+					So(m["blob"].Type(), ShouldEqual, TypeBlob)
+					So(m["ts"].Type(), ShouldEqual, TypeTimestamp)
+					ts, _ := AsTimestamp(m["ts"])
+					So(ts.Equal(time.Date(2016, time.January, 2, 3, 4, 5, 123456000, time.UTC)), ShouldBeTrue)
+					m["ts"] = v["ts"] // time.Location may differ
+					So(m, ShouldResemble, v)
+				})
+			})
+
+			Convey("When writing values to a stream", func() {
+				buf := bytes.NewBuffer(nil)
+				enc := c.newEnc(buf)
+				So(enc.Encode(v), ShouldBeNil)
+				So(enc.Encode(Int(1)), ShouldBeNil)
+				So(enc.Encode(Null{}), ShouldBeNil)
+
+				Convey("Then they should be read one by one", func() {
+					size := int64(buf.Len())
+					dec := c.newDec(buf)
+					d, err := dec.Decode()
+					So(err, ShouldBeNil)
+					So(d.Type(), ShouldEqual, TypeMap)
+					d, err = dec.Decode()
+					So(err, ShouldBeNil)
+					So(d, ShouldEqual, Int(1))
+					d, err = dec.Decode()
+					So(err, ShouldBeNil)
+					So(d, ShouldResemble, Null{})
+					So(dec.InputOffset(), ShouldEqual, size)
+					_, err = dec.Decode()
+					So(err, ShouldEqual, io.EOF)
+				})
+
+				Convey("Then a truncated stream should result in an unexpected EOF", func() {
+					dec := c.newDec(bytes.NewReader(buf.Bytes()[:10]))
+					_, err := dec.Decode()
+					So(err, ShouldEqual, io.ErrUnexpectedEOF)
+				})
+			})
+
+			Convey("When decoding a truncated value", func() {
+				b, err := c.encode(v)
+				So(err, ShouldBeNil)
+				_, err = c.decode(b[:len(b)/2])
+
+				Convey("Then it should fail", func() {
+					So(err, ShouldNotBeNil)
+				})
+			})
+		})
+	}
+}
+
+// sensorTuple is a typical small tuple emitted by a sensor.
+var sensorTuple = Map{
+	"device_id": String("dev-1"),
+	"ts":        Timestamp(time.Date(2015, time.May, 1, 14, 27, 0, 0, time.UTC)),
+	"temp":      Float(21.5),
+	"humidity":  Float(0.45),
+	"battery":   Int(87),
+	"location":  Map{"lat": Float(35.68), "lon": Float(139.76)},
+}
+
+func BenchmarkEncodeMsgpack(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := EncodeMsgpack(sensorTuple); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodeMsgpack(b *testing.B) {
+	bs, err := EncodeMsgpack(sensorTuple)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := DecodeMsgpack(bs); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
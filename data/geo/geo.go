@@ -0,0 +1,253 @@
+// Package geo provides geometries represented as data.Map and functions to
+// compute spatial relationships between them.
+//
+// Geometries are compatible with GeoJSON (RFC 7946). Coordinates are written
+// in [longitude, latitude] order in degrees:
+//
+//	{"type": "Point", "coordinates": [139.7454, 35.6586]}
+//	{"type": "LineString", "coordinates": [[139.74, 35.65], [139.75, 35.66]]}
+//	{"type": "Polygon", "coordinates": [[[139.74, 35.65], [139.75, 35.65], [139.75, 35.66], [139.74, 35.65]]]}
+//
+// A point can also be written as a map having "lat" and "lon" (or "lng")
+// fields since many sensors emit locations in that form:
+//
+//	{"lat": 35.6586, "lon": 139.7454}
+package geo
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// Geometry is a geometry such as a point, a line string, or a polygon.
+type Geometry interface {
+	// Type returns the GeoJSON type name of the geometry.
+	Type() string
+
+	// ToMap returns the geometry as a GeoJSON object.
+	ToMap() data.Map
+}
+
+// Point is a point on the earth.
+type Point struct {
+	// Lon is the longitude in degrees.
+	Lon float64
+
+	// Lat is the latitude in degrees.
+	Lat float64
+}
+
+// NewPoint creates a new Point. It returns an error when the longitude or
+// the latitude is out of range.
+func NewPoint(lon, lat float64) (Point, error) {
+	p := Point{lon, lat}
+	if err := p.validate(); err != nil {
+		return Point{}, err
+	}
+	return p, nil
+}
+
+func (p Point) validate() error {
+	if math.IsNaN(p.Lon) || p.Lon < -180 || p.Lon > 180 {
+		return fmt.Errorf("longitude must be in [-180, 180]: %v", p.Lon)
+	}
+	if math.IsNaN(p.Lat) || p.Lat < -90 || p.Lat > 90 {
+		return fmt.Errorf("latitude must be in [-90, 90]: %v", p.Lat)
+	}
+	return nil
+}
+
+// Type returns "Point".
+func (p Point) Type() string {
+	return "Point"
+}
+
+// ToMap returns the point as a GeoJSON object.
+func (p Point) ToMap() data.Map {
+	return data.Map{
+		"type":        data.String(p.Type()),
+		"coordinates": p.coordinates(),
+	}
+}
+
+func (p Point) coordinates() data.Array {
+	return data.Array{data.Float(p.Lon), data.Float(p.Lat)}
+}
+
+// LineString is a sequence of two or more points connected by segments.
+type LineString []Point
+
+// Type returns "LineString".
+func (l LineString) Type() string {
+	return "LineString"
+}
+
+// ToMap returns the line string as a GeoJSON object.
+func (l LineString) ToMap() data.Map {
+	return data.Map{
+		"type":        data.String(l.Type()),
+		"coordinates": pointsToArray(l),
+	}
+}
+
+// Polygon is a polygon having an exterior ring and zero or more holes. The
+// first ring is the exterior ring and the rest are holes. Each ring is closed,
+// that is, its first and last points are the same.
+type Polygon [][]Point
+
+// Type returns "Polygon".
+func (p Polygon) Type() string {
+	return "Polygon"
+}
+
+// ToMap returns the polygon as a GeoJSON object.
+func (p Polygon) ToMap() data.Map {
+	rings := make(data.Array, len(p))
+	for i, r := range p {
+		rings[i] = pointsToArray(r)
+	}
+	return data.Map{
+		"type":        data.String(p.Type()),
+		"coordinates": rings,
+	}
+}
+
+func pointsToArray(ps []Point) data.Array {
+	a := make(data.Array, len(ps))
+	for i, p := range ps {
+		a[i] = p.coordinates()
+	}
+	return a
+}
+
+// FromValue converts a value to a Geometry. The value must be a GeoJSON
+// object of Point, LineString, or Polygon, or a map having "lat" and "lon"
+// (or "lng") fields.
+func FromValue(v data.Value) (Geometry, error) {
+	m, err := data.AsMap(v)
+	if err != nil {
+		return nil, fmt.Errorf("a geometry must be a map: %v", err)
+	}
+	if _, ok := m["lat"]; ok {
+		return latLonToPoint(m)
+	}
+
+	t, ok := m["type"]
+	if !ok {
+		return nil, errors.New("a geometry must have 'type' field")
+	}
+	typ, err := data.AsString(t)
+	if err != nil {
+		return nil, fmt.Errorf("'type' field must be a string: %v", err)
+	}
+	c, ok := m["coordinates"]
+	if !ok {
+		return nil, errors.New("a geometry must have 'coordinates' field")
+	}
+
+	switch typ {
+	case "Point":
+		return toPoint(c)
+	case "LineString":
+		ps, err := toPoints(c)
+		if err != nil {
+			return nil, err
+		}
+		if len(ps) < 2 {
+			return nil, errors.New("a line string must have two or more points")
+		}
+		return LineString(ps), nil
+	case "Polygon":
+		a, err := data.AsArray(c)
+		if err != nil {
+			return nil, fmt.Errorf("coordinates of a polygon must be an array of rings: %v", err)
+		}
+		if len(a) == 0 {
+			return nil, errors.New("a polygon must have an exterior ring")
+		}
+		p := make(Polygon, len(a))
+		for i, r := range a {
+			ring, err := toPoints(r)
+			if err != nil {
+				return nil, err
+			}
+			if len(ring) < 4 {
+				return nil, errors.New("a ring of a polygon must have four or more points")
+			}
+			if ring[0] != ring[len(ring)-1] {
+				return nil, errors.New("a ring of a polygon must be closed")
+			}
+			p[i] = ring
+		}
+		return p, nil
+	default:
+		return nil, fmt.Errorf("unsupported geometry type: %v", typ)
+	}
+}
+
+// PointFromValue is like FromValue but the value must be a point.
+func PointFromValue(v data.Value) (Point, error) {
+	g, err := FromValue(v)
+	if err != nil {
+		return Point{}, err
+	}
+	p, ok := g.(Point)
+	if !ok {
+		return Point{}, fmt.Errorf("the geometry must be a point: %v", g.Type())
+	}
+	return p, nil
+}
+
+func latLonToPoint(m data.Map) (Point, error) {
+	lon, ok := m["lon"]
+	if !ok {
+		if lon, ok = m["lng"]; !ok {
+			return Point{}, errors.New("a point must have 'lon' or 'lng' field")
+		}
+	}
+	x, err := data.ToFloat(lon)
+	if err != nil {
+		return Point{}, fmt.Errorf("the longitude must be a number: %v", err)
+	}
+	y, err := data.ToFloat(m["lat"])
+	if err != nil {
+		return Point{}, fmt.Errorf("the latitude must be a number: %v", err)
+	}
+	return NewPoint(x, y)
+}
+
+func toPoint(v data.Value) (Point, error) {
+	a, err := data.AsArray(v)
+	if err != nil {
+		return Point{}, fmt.Errorf("a position must be an array: %v", err)
+	}
+	if len(a) < 2 {
+		return Point{}, errors.New("a position must have a longitude and a latitude")
+	}
+	lon, err := data.ToFloat(a[0])
+	if err != nil {
+		return Point{}, fmt.Errorf("the longitude must be a number: %v", err)
+	}
+	lat, err := data.ToFloat(a[1])
+	if err != nil {
+		return Point{}, fmt.Errorf("the latitude must be a number: %v", err)
+	}
+	return NewPoint(lon, lat)
+}
+
+func toPoints(v data.Value) ([]Point, error) {
+	a, err := data.AsArray(v)
+	if err != nil {
+		return nil, fmt.Errorf("positions must be an array: %v", err)
+	}
+	ps := make([]Point, len(a))
+	for i, e := range a {
+		if ps[i], err = toPoint(e); err != nil {
+			return nil, err
+		}
+	}
+	return ps, nil
+}
@@ -0,0 +1,263 @@
+package geo
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+func TestFromValue(t *testing.T) {
+	Convey("Given GeoJSON objects", t, func() {
+		Convey("When converting a point", func() {
+			g, err := FromValue(data.Map{
+				"type":        data.String("Point"),
+				"coordinates": data.Array{data.Float(139.7454), data.Int(35)},
+			})
+
+			Convey("Then it should be a Point", func() {
+				So(err, ShouldBeNil)
+				So(g, ShouldResemble, Point{139.7454, 35})
+			})
+
+			Convey("Then it should be converted back to the map", func() {
+				So(g.ToMap(), ShouldResemble, data.Map{
+					"type":        data.String("Point"),
+					"coordinates": data.Array{data.Float(139.7454), data.Float(35)},
+				})
+			})
+		})
+
+		Convey("When converting a point having lat and lon", func() {
+			g, err := FromValue(data.Map{"lat": data.Float(35.5), "lng": data.Float(139.5)})
+
+			Convey("Then it should be a Point", func() {
+				So(err, ShouldBeNil)
+				So(g, ShouldResemble, Point{139.5, 35.5})
+			})
+		})
+
+		Convey("When converting a polygon", func() {
+			m := data.Map{
+				"type": data.String("Polygon"),
+				"coordinates": data.Array{
+					data.Array{
+						data.Array{data.Float(0), data.Float(0)},
+						data.Array{data.Float(1), data.Float(0)},
+						data.Array{data.Float(1), data.Float(1)},
+						data.Array{data.Float(0), data.Float(0)},
+					},
+				},
+			}
+			g, err := FromValue(m)
+
+			Convey("Then it should be a Polygon", func() {
+				So(err, ShouldBeNil)
+				So(g, ShouldResemble, Polygon{{{0, 0}, {1, 0}, {1, 1}, {0, 0}}})
+				So(g.ToMap(), ShouldResemble, m)
+			})
+		})
+
+		Convey("When converting invalid objects", func() {
+			for _, v := range []data.Value{
+				data.Int(1),
+				data.Map{"type": data.String("Point")},
+				data.Map{"type": data.String("Circle"), "coordinates": data.Array{}},
+				data.Map{"type": data.String("Point"), "coordinates": data.Array{data.Float(181), data.Float(0)}},
+				data.Map{"type": data.String("LineString"), "coordinates": data.Array{
+					data.Array{data.Float(0), data.Float(0)},
+				}},
+				data.Map{"type": data.String("Polygon"), "coordinates": data.Array{
+					data.Array{
+						data.Array{data.Float(0), data.Float(0)},
+						data.Array{data.Float(1), data.Float(0)},
+						data.Array{data.Float(1), data.Float(1)},
+						data.Array{data.Float(0), data.Float(1)},
+					},
+				}},
+				data.Map{"lat": data.Float(100), "lon": data.Float(0)},
+			} {
+				_, err := FromValue(v)
+
+				Convey("Then it should fail: "+v.String(), func() {
+					So(err, ShouldNotBeNil)
+				})
+			}
+		})
+	})
+}
+
+func TestDistance(t *testing.T) {
+	Convey("Given geometries", t, func() {
+		tower := Point{139.7454, 35.6586}
+		skytree := Point{139.8107, 35.7101}
+		square := Polygon{{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}}}
+
+		Convey("When computing the distance between points", func() {
+			d, err := Distance(tower, skytree)
+
+			Convey("Then it should be the great-circle distance", func() {
+				So(err, ShouldBeNil)
+				So(d, ShouldAlmostEqual, 8220.49, 0.01)
+			})
+		})
+
+		Convey("When computing the distance from a point to a line string", func() {
+			d, err := Distance(LineString{{0, -1}, {0, 1}}, Point{1, 0})
+
+			Convey("Then it should be the distance to the nearest segment", func() {
+				So(err, ShouldBeNil)
+				So(d, ShouldAlmostEqual, 111195.08, 1)
+			})
+		})
+
+		Convey("When computing the distance from a point to a polygon", func() {
+			Convey("Then it should be 0 if the point is inside", func() {
+				d, err := Distance(Point{0.5, 0.5}, square)
+				So(err, ShouldBeNil)
+				So(d, ShouldEqual, 0)
+			})
+
+			Convey("Then it should be the distance to the boundary if the point is outside", func() {
+				d, err := Distance(Point{2, 0.5}, square)
+				So(err, ShouldBeNil)
+				So(d, ShouldAlmostEqual, 111195.08*0.99996, 20)
+			})
+		})
+
+		Convey("When computing the distance between polygons", func() {
+			_, err := Distance(square, square)
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+func TestContains(t *testing.T) {
+	Convey("Given a polygon having a hole", t, func() {
+		poly := Polygon{
+			{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}},
+			{{4, 4}, {6, 4}, {6, 6}, {4, 6}, {4, 4}},
+		}
+
+		Convey("Then it should contain points inside it", func() {
+			for _, p := range []Point{{1, 1}, {0, 5}, {10, 10}, {4, 5}} {
+				ok, err := Contains(poly, p)
+				So(err, ShouldBeNil)
+				So(ok, ShouldBeTrue)
+			}
+		})
+
+		Convey("Then it shouldn't contain points outside it or in the hole", func() {
+			for _, p := range []Point{{-1, 1}, {11, 5}, {5, 5}} {
+				ok, err := Contains(poly, p)
+				So(err, ShouldBeNil)
+				So(ok, ShouldBeFalse)
+			}
+		})
+
+		Convey("Then it should contain a line string not crossing the hole", func() {
+			ok, err := Contains(poly, LineString{{1, 1}, {9, 1}, {9, 9}})
+			So(err, ShouldBeNil)
+			So(ok, ShouldBeTrue)
+		})
+
+		Convey("Then it shouldn't contain a line string crossing the hole", func() {
+			ok, err := Contains(poly, LineString{{1, 5}, {9, 5}})
+			So(err, ShouldBeNil)
+			So(ok, ShouldBeFalse)
+		})
+
+		Convey("Then it shouldn't contain a polygon surrounding the hole", func() {
+			ok, err := Contains(poly, Polygon{{{2, 2}, {8, 2}, {8, 8}, {2, 8}, {2, 2}}})
+			So(err, ShouldBeNil)
+			So(ok, ShouldBeFalse)
+		})
+
+		Convey("Then it should contain a polygon not overlapping the hole", func() {
+			ok, err := Contains(poly, Polygon{{{1, 1}, {3, 1}, {3, 3}, {1, 1}}})
+			So(err, ShouldBeNil)
+			So(ok, ShouldBeTrue)
+		})
+
+		Convey("Then a point should be within it", func() {
+			ok, err := Within(Point{1, 1}, poly)
+			So(err, ShouldBeNil)
+			So(ok, ShouldBeTrue)
+		})
+
+		Convey("Then it shouldn't be within a point", func() {
+			ok, err := Within(poly, Point{1, 1})
+			So(err, ShouldBeNil)
+			So(ok, ShouldBeFalse)
+		})
+	})
+
+	Convey("Given a line string", t, func() {
+		l := LineString{{0, 0}, {2, 2}}
+
+		Convey("Then it should contain a point on it", func() {
+			ok, err := Contains(l, Point{1, 1})
+			So(err, ShouldBeNil)
+			So(ok, ShouldBeTrue)
+		})
+
+		Convey("Then it shouldn't contain a point off it", func() {
+			ok, err := Contains(l, Point{1, 1.1})
+			So(err, ShouldBeNil)
+			So(ok, ShouldBeFalse)
+		})
+
+		Convey("Then checking if it contains another line string should fail", func() {
+			_, err := Contains(l, l)
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+func TestGeohash(t *testing.T) {
+	Convey("Given a point", t, func() {
+		p := Point{-5.6, 42.6}
+
+		Convey("When encoding it into a geohash", func() {
+			h, err := EncodeGeohash(p, 5)
+
+			Convey("Then it should be the right geohash", func() {
+				So(err, ShouldBeNil)
+				So(h, ShouldEqual, "ezs42")
+			})
+		})
+
+		Convey("When encoding it with an invalid precision", func() {
+			_, err := EncodeGeohash(p, 13)
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+
+	Convey("Given a geohash", t, func() {
+		Convey("When decoding it", func() {
+			p, lonErr, latErr, err := DecodeGeohash("EZS42")
+
+			Convey("Then it should be the center of the cell", func() {
+				So(err, ShouldBeNil)
+				So(p.Lon, ShouldAlmostEqual, -5.60302734375, 1e-9)
+				So(p.Lat, ShouldAlmostEqual, 42.60498046875, 1e-9)
+				So(lonErr, ShouldAlmostEqual, 0.02197265625, 1e-9)
+				So(latErr, ShouldAlmostEqual, 0.02197265625, 1e-9)
+			})
+		})
+
+		Convey("When decoding an invalid geohash", func() {
+			_, _, _, err := DecodeGeohash("ezs4a")
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
@@ -0,0 +1,94 @@
+package geo
+
+import (
+	"fmt"
+	"strings"
+)
+
+const geohashBase32 = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// MaxGeohashPrecision is the maximum length of a geohash supported by
+// EncodeGeohash.
+const MaxGeohashPrecision = 12
+
+// EncodeGeohash encodes a point into a geohash having the given number of
+// characters. precision must be in [1, MaxGeohashPrecision].
+func EncodeGeohash(p Point, precision int) (string, error) {
+	if precision < 1 || precision > MaxGeohashPrecision {
+		return "", fmt.Errorf("precision must be in [1, %v]: %v", MaxGeohashPrecision, precision)
+	}
+	if err := p.validate(); err != nil {
+		return "", err
+	}
+
+	lonMin, lonMax := -180.0, 180.0
+	latMin, latMax := -90.0, 90.0
+	buf := make([]byte, precision)
+	even := true // longitude is encoded in even bits
+	for i := range buf {
+		var c byte
+		for bit := 4; bit >= 0; bit-- {
+			if even {
+				mid := (lonMin + lonMax) / 2
+				if p.Lon >= mid {
+					c |= 1 << uint(bit)
+					lonMin = mid
+				} else {
+					lonMax = mid
+				}
+			} else {
+				mid := (latMin + latMax) / 2
+				if p.Lat >= mid {
+					c |= 1 << uint(bit)
+					latMin = mid
+				} else {
+					latMax = mid
+				}
+			}
+			even = !even
+		}
+		buf[i] = geohashBase32[c]
+	}
+	return string(buf), nil
+}
+
+// DecodeGeohash decodes a geohash into the center point of its cell. It also
+// returns the error of the longitude and the latitude in degrees, i.e. the
+// half width and the half height of the cell. Upper case letters are
+// accepted.
+func DecodeGeohash(hash string) (Point, float64, float64, error) {
+	if hash == "" {
+		return Point{}, 0, 0, fmt.Errorf("a geohash must not be empty")
+	}
+
+	lonMin, lonMax := -180.0, 180.0
+	latMin, latMax := -90.0, 90.0
+	even := true
+	for _, r := range strings.ToLower(hash) {
+		c := strings.IndexRune(geohashBase32, r)
+		if c < 0 {
+			return Point{}, 0, 0, fmt.Errorf("a geohash has an invalid character: %q", r)
+		}
+		for bit := 4; bit >= 0; bit-- {
+			set := c&(1<<uint(bit)) != 0
+			if even {
+				mid := (lonMin + lonMax) / 2
+				if set {
+					lonMin = mid
+				} else {
+					lonMax = mid
+				}
+			} else {
+				mid := (latMin + latMax) / 2
+				if set {
+					latMin = mid
+				} else {
+					latMax = mid
+				}
+			}
+			even = !even
+		}
+	}
+	p := Point{(lonMin + lonMax) / 2, (latMin + latMax) / 2}
+	return p, (lonMax - lonMin) / 2, (latMax - latMin) / 2, nil
+}
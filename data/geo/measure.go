@@ -0,0 +1,248 @@
+package geo
+
+import (
+	"fmt"
+	"math"
+)
+
+// EarthRadius is the mean radius of the earth in meters used to compute
+// distances.
+const EarthRadius = 6371008.8
+
+// Distance returns the distance between two geometries in meters. One of
+// them must be a point. The distance between points is the great-circle
+// distance computed by the haversine formula. The distance from a point to
+// a line string or a polygon is the distance to the nearest segment, which
+// is computed on a local plane around the point and is only accurate when
+// segments are sufficiently short (e.g. less than a few hundred kilometers).
+// The distance from a point inside a polygon to the polygon is 0.
+func Distance(a, b Geometry) (float64, error) {
+	p, ok := a.(Point)
+	if !ok {
+		if p, ok = b.(Point); !ok {
+			return 0, fmt.Errorf("the distance between %v and %v isn't supported", a.Type(), b.Type())
+		}
+		a, b = b, a
+	}
+
+	switch g := b.(type) {
+	case Point:
+		return haversine(p, g), nil
+	case LineString:
+		return distanceToSegments(p, g), nil
+	case Polygon:
+		if polygonContainsPoint(g, p) {
+			return 0, nil
+		}
+		d := math.Inf(1)
+		for _, r := range g {
+			d = math.Min(d, distanceToSegments(p, r))
+		}
+		return d, nil
+	default:
+		return 0, fmt.Errorf("unsupported geometry type: %v", b.Type())
+	}
+}
+
+// Contains returns true when b lies in a. Points on the boundary of a are
+// treated as in a. Following combinations are supported:
+//
+//	- a point contains a point when they're the same
+//	- a line string contains a point on it
+//	- a polygon contains a point, a line string, or a polygon
+//
+// Coordinates are treated as planar, so polygons crossing the antimeridian
+// aren't supported. A polygon contains another polygon when it contains the
+// exterior ring of the other one. Other combinations which can't contain b,
+// such as a point and a polygon, return false.
+func Contains(a, b Geometry) (bool, error) {
+	switch g := a.(type) {
+	case Point:
+		p, ok := b.(Point)
+		return ok && p == g, nil
+
+	case LineString:
+		switch h := b.(type) {
+		case Point:
+			for i := 1; i < len(g); i++ {
+				if onSegment(h, g[i-1], g[i]) {
+					return true, nil
+				}
+			}
+			return false, nil
+		case Polygon:
+			return false, nil
+		}
+
+	case Polygon:
+		switch h := b.(type) {
+		case Point:
+			return polygonContainsPoint(g, h), nil
+		case LineString:
+			return polygonContainsPath(g, h), nil
+		case Polygon:
+			if !polygonContainsPath(g, h[0]) {
+				return false, nil
+			}
+			// a hole of g inside h makes a part of h outside g
+			for _, hole := range g[1:] {
+				for _, p := range hole {
+					if ringLocation(p, h[0]) == inside {
+						return false, nil
+					}
+				}
+			}
+			return true, nil
+		}
+	}
+	return false, fmt.Errorf("whether %v contains %v isn't supported", a.Type(), b.Type())
+}
+
+// Within returns true when a lies in b. It's same as Contains(b, a).
+func Within(a, b Geometry) (bool, error) {
+	return Contains(b, a)
+}
+
+func radians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+func haversine(p, q Point) float64 {
+	lat1, lat2 := radians(p.Lat), radians(q.Lat)
+	dLat := lat2 - lat1
+	dLon := radians(q.Lon - p.Lon)
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * EarthRadius * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// distanceToSegments returns the distance from p to the nearest segment of
+// the path.
+func distanceToSegments(p Point, path []Point) float64 {
+	if len(path) == 1 {
+		return haversine(p, path[0])
+	}
+	d := math.Inf(1)
+	for i := 1; i < len(path); i++ {
+		d = math.Min(d, distanceToSegment(p, path[i-1], path[i]))
+	}
+	return d
+}
+
+// distanceToSegment projects the segment to the plane tangent at p and
+// returns the distance from p to the segment in meters.
+func distanceToSegment(p, a, b Point) float64 {
+	cos := math.Cos(radians(p.Lat))
+	project := func(q Point) (float64, float64) {
+		dLon := q.Lon - p.Lon
+		if dLon > 180 {
+			dLon -= 360
+		} else if dLon < -180 {
+			dLon += 360
+		}
+		return radians(dLon) * cos * EarthRadius, radians(q.Lat-p.Lat) * EarthRadius
+	}
+	ax, ay := project(a)
+	bx, by := project(b)
+
+	dx, dy := bx-ax, by-ay
+	l := dx*dx + dy*dy
+	if l == 0 {
+		return haversine(p, a)
+	}
+	// the parameter of the nearest point on the segment
+	t := math.Max(0, math.Min(1, -(ax*dx+ay*dy)/l))
+	return math.Hypot(ax+t*dx, ay+t*dy)
+}
+
+const (
+	outside = iota
+	boundary
+	inside
+)
+
+// ringLocation returns whether p is inside, on the boundary of, or outside
+// the closed ring.
+func ringLocation(p Point, ring []Point) int {
+	in := false
+	for i := 1; i < len(ring); i++ {
+		a, b := ring[i-1], ring[i]
+		if onSegment(p, a, b) {
+			return boundary
+		}
+		if (a.Lat > p.Lat) != (b.Lat > p.Lat) &&
+			p.Lon < (b.Lon-a.Lon)*(p.Lat-a.Lat)/(b.Lat-a.Lat)+a.Lon {
+			in = !in
+		}
+	}
+	if in {
+		return inside
+	}
+	return outside
+}
+
+func polygonContainsPoint(poly Polygon, p Point) bool {
+	if ringLocation(p, poly[0]) == outside {
+		return false
+	}
+	for _, hole := range poly[1:] {
+		if ringLocation(p, hole) == inside {
+			return false
+		}
+	}
+	return true
+}
+
+// polygonContainsPath returns true when all points of the path are in the
+// polygon and no segment of the path crosses the boundary of the polygon.
+func polygonContainsPath(poly Polygon, path []Point) bool {
+	for _, p := range path {
+		if !polygonContainsPoint(poly, p) {
+			return false
+		}
+	}
+	for i := 1; i < len(path); i++ {
+		for _, r := range poly {
+			for j := 1; j < len(r); j++ {
+				if segmentsCross(path[i-1], path[i], r[j-1], r[j]) {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// orientation returns a positive value when a, b, and c are in
+// counter-clockwise order, a negative value when they're in clockwise order,
+// and 0 when they're collinear.
+func orientation(a, b, c Point) float64 {
+	return (b.Lon-a.Lon)*(c.Lat-a.Lat) - (b.Lat-a.Lat)*(c.Lon-a.Lon)
+}
+
+// epsilon is the tolerance in degrees used to check if a point is on a
+// segment. It's about 0.1 mm at the equator.
+const epsilon = 1e-9
+
+func onSegment(p, a, b Point) bool {
+	if p.Lon < math.Min(a.Lon, b.Lon)-epsilon || p.Lon > math.Max(a.Lon, b.Lon)+epsilon ||
+		p.Lat < math.Min(a.Lat, b.Lat)-epsilon || p.Lat > math.Max(a.Lat, b.Lat)+epsilon {
+		return false
+	}
+	l := math.Hypot(b.Lon-a.Lon, b.Lat-a.Lat)
+	if l == 0 {
+		return math.Hypot(p.Lon-a.Lon, p.Lat-a.Lat) <= epsilon
+	}
+	return math.Abs(orientation(a, b, p))/l <= epsilon
+}
+
+// segmentsCross returns true when segments p1-p2 and q1-q2 cross each other
+// at a point which isn't an end point of them.
+func segmentsCross(p1, p2, q1, q2 Point) bool {
+	d1 := orientation(q1, q2, p1)
+	d2 := orientation(q1, q2, p2)
+	d3 := orientation(p1, p2, q1)
+	d4 := orientation(p1, p2, q2)
+	return ((d1 > 0 && d2 < 0) || (d1 < 0 && d2 > 0)) &&
+		((d3 > 0 && d4 < 0) || (d3 < 0 && d4 > 0))
+}
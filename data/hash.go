@@ -2,34 +2,76 @@ package data
 
 import (
 	"bytes"
+	"encoding/binary"
 	"hash/fnv"
 	"io"
 	"math"
-	"sync/atomic"
+	"time"
 )
 
-// HashValue is a type which hold hash values of Values.
-type HashValue uint64
+// HashValue is a 128-bit hash value of a Value computed by Hash. It's
+// comparable and can be used as a key of a map.
+type HashValue struct {
+	High uint64
+	Low  uint64
+}
+
+// Less returns true when h is less than o. It provides an arbitrary but
+// deterministic order of hash values.
+func (h HashValue) Less(o HashValue) bool {
+	if h.High != o.High {
+		return h.High < o.High
+	}
+	return h.Low < o.Low
+}
 
-// Hash computes a hash value of a Value. A hash value of a Float having an
-// integer value is computed as a Int's hash value so that Hash(Float(2.0))
-// equals Hash(Int(2)). The hash value of Null is always the same. Hash values
-// of NaNs always varies. For example, Hash(Float(math.NaN())) isn't equal to
-// Hash(Float(math.NaN())). Therefore, if an array or a map has a NaN, the hash
-// value changes everytime calling Hash function.
+// Hash computes a 128-bit hash value of a Value. Hash is consistent with
+// Equal, that is, Hash(v1) equals Hash(v2) when Equal(v1, v2) is true.
+// Therefore, Hash follows the rules described in Equal:
+//
+//	- a Float having an integer value has the same hash value as the Int
+//	  (e.g. Hash(Float(2.0)) equals Hash(Int(2))), and so do -0.0 and 0.0
+//	- all NaNs have the same hash value
+//	- Timestamps only differing less than a microsecond or in their
+//	  locations have the same hash value
+//
+// Hash values are stable: they only depend on the content of the Value and
+// don't change across calls, processes, or the iteration order of Maps.
+// So, they can be used to partition Values among processes.
 func Hash(v Value) HashValue {
-	h := fnv.New64a()
+	h := fnv.New128a()
 	buffer := make([]byte, 0, 16)
 	updateHash(v, h, buffer)
-	return HashValue(h.Sum64())
+	var sum [16]byte
+	return newHashValue(h.Sum(sum[:0]))
 }
 
-// Equal tests equality of two Values. When comparing a Float and an Int, Int is
-// implicitly converted to float so that they can be true when the Float has an
-// integer value. For example, Equal(Float(2.0), Int(2)) is true.
+func newHashValue(b []byte) HashValue {
+	return HashValue{
+		High: binary.BigEndian.Uint64(b[:8]),
+		Low:  binary.BigEndian.Uint64(b[8:16]),
+	}
+}
+
+// Equal tests equality of two Values. It's the equality used by the =
+// operator, GROUP BY, DEDUPLICATE BY, ISTREAM and DSTREAM, and equi-joins
+// of BQL, and Hash is consistent with it. Following rules apply:
 //
-// If either one is NaN, Equal always returns false. Equal(Null, Null) is true
-// although this is inconsistent with the three-valued logic.
+//	- an Int and a Float are equal when the Float has exactly the same
+//	  integer value, e.g. Equal(Float(2.0), Int(2)) is true. Unlike
+//	  converting the Int to a float, precision is never lost
+//	- -0.0 equals 0.0
+//	- NaN equals NaN and doesn't equal any other value. This differs from
+//	  IEEE 754 but allows NaN to be a key of GROUP BY like PostgreSQL does
+//	- Timestamps are compared in microsecond precision, which is the
+//	  precision SensorBee keeps when encoding them, regardless of their
+//	  locations
+//	- Arrays are equal when they have equal elements in the same order,
+//	  and Maps are equal when they have the same keys having equal values
+//
+// Equal(Null, Null) is true although this is inconsistent with the
+// three-valued logic. Comparison operators of BQL handle NULL before
+// calling Equal.
 func Equal(v1 Value, v2 Value) bool {
 	lType := v1.Type()
 	rType := v2.Type()
@@ -54,23 +96,8 @@ func Equal(v1 Value, v2 Value) bool {
 		rhs, _ := v2.asBool()
 		return lhs == rhs
 
-	case TypeInt:
-		lhs, _ := v1.asInt()
-		if rType == TypeFloat {
-			rhs, _ := v2.asFloat()
-			return float64(lhs) == rhs
-		}
-		rhs, _ := v2.asInt()
-		return lhs == rhs
-
-	case TypeFloat:
-		lhs, _ := v1.asFloat()
-		if rType == TypeInt {
-			rhs, _ := v2.asInt()
-			return lhs == float64(rhs)
-		}
-		rhs, _ := v2.asFloat()
-		return lhs == rhs // NaN == NaN is false
+	case TypeInt, TypeFloat:
+		return compareNumbers(v1, v2) == 0
 
 	case TypeString:
 		lhs, _ := v1.asString()
@@ -85,7 +112,7 @@ func Equal(v1 Value, v2 Value) bool {
 	case TypeTimestamp:
 		lhs, _ := v1.asTimestamp()
 		rhs, _ := v2.asTimestamp()
-		return lhs.Equal(rhs)
+		return lhs.Truncate(time.Microsecond).Equal(rhs.Truncate(time.Microsecond))
 
 	case TypeArray:
 		lhs, _ := v1.asArray()
@@ -132,8 +159,9 @@ func Equal(v1 Value, v2 Value) bool {
 //   - Null: always false
 //   - Bool: false < true
 //   - Int/Float: usual < comparison; Ints and Floats can also be compared
+//     without losing precision. NaN is greater than any other number
 //   - String: usual < comparison
-//   - Timestamp: value as returned by Time.Before()
+//   - Timestamp: value as returned by Time.Before() in microsecond precision
 //   - Blob, Array, Map: shorter collections are less than longer collections;
 //     when length is equal hash values are compared
 func Less(v1 Value, v2 Value) bool {
@@ -157,23 +185,8 @@ func Less(v1 Value, v2 Value) bool {
 		rhs, _ := v2.asBool()
 		return !lhs && rhs
 
-	case TypeInt:
-		lhs, _ := v1.asInt()
-		if rType == TypeFloat {
-			rhs, _ := v2.asFloat()
-			return float64(lhs) < rhs
-		}
-		rhs, _ := v2.asInt()
-		return lhs < rhs
-
-	case TypeFloat:
-		lhs, _ := v1.asFloat()
-		if rType == TypeInt {
-			rhs, _ := v2.asInt()
-			return lhs < float64(rhs)
-		}
-		rhs, _ := v2.asFloat()
-		return lhs < rhs
+	case TypeInt, TypeFloat:
+		return compareNumbers(v1, v2) < 0
 
 	case TypeString:
 		lhs, _ := v1.asString()
@@ -184,20 +197,20 @@ func Less(v1 Value, v2 Value) bool {
 		lhs, _ := v1.asBlob()
 		rhs, _ := v2.asBlob()
 		if len(lhs) == len(rhs) {
-			return Hash(v1) < Hash(v2)
+			return Hash(v1).Less(Hash(v2))
 		}
 		return len(lhs) < len(rhs)
 
 	case TypeTimestamp:
 		lhs, _ := v1.asTimestamp()
 		rhs, _ := v2.asTimestamp()
-		return lhs.Before(rhs)
+		return lhs.Truncate(time.Microsecond).Before(rhs.Truncate(time.Microsecond))
 
 	case TypeArray:
 		lhs, _ := v1.asArray()
 		rhs, _ := v2.asArray()
 		if len(lhs) == len(rhs) {
-			return Hash(v1) < Hash(v2)
+			return Hash(v1).Less(Hash(v2))
 		}
 		return len(lhs) < len(rhs)
 
@@ -205,7 +218,7 @@ func Less(v1 Value, v2 Value) bool {
 		lhs, _ := v1.asMap()
 		rhs, _ := v2.asMap()
 		if len(lhs) == len(rhs) {
-			return Hash(v1) < Hash(v2)
+			return Hash(v1).Less(Hash(v2))
 		}
 		return len(lhs) < len(rhs)
 
@@ -215,6 +228,87 @@ func Less(v1 Value, v2 Value) bool {
 	}
 }
 
+// compareNumbers compares two Ints or Floats. It returns a negative value
+// when v1 < v2, 0 when v1 == v2, and a positive value when v1 > v2. NaN
+// equals NaN and is greater than any other number.
+func compareNumbers(v1, v2 Value) int {
+	if v1.Type() == TypeInt {
+		l, _ := v1.asInt()
+		if v2.Type() == TypeInt {
+			r, _ := v2.asInt()
+			switch {
+			case l < r:
+				return -1
+			case l > r:
+				return 1
+			}
+			return 0
+		}
+		r, _ := v2.asFloat()
+		return compareIntFloat(l, r)
+	}
+
+	l, _ := v1.asFloat()
+	if v2.Type() == TypeInt {
+		r, _ := v2.asInt()
+		return -compareIntFloat(r, l)
+	}
+	r, _ := v2.asFloat()
+	ln, rn := math.IsNaN(l), math.IsNaN(r)
+	switch {
+	case ln && rn:
+		return 0
+	case ln:
+		return 1
+	case rn:
+		return -1
+	case l < r:
+		return -1
+	case l > r:
+		return 1
+	}
+	return 0 // including -0.0 vs 0.0
+}
+
+// compareIntFloat compares an int and a float without converting the int to
+// a float, which may lose precision.
+func compareIntFloat(i int64, f float64) int {
+	switch {
+	case math.IsNaN(f) || f >= twoPow63:
+		return -1
+	case f < -twoPow63:
+		return 1
+	}
+	t := int64(f) // truncated toward zero, and it's in the range of int64
+	switch {
+	case i < t:
+		return -1
+	case i > t:
+		return 1
+	}
+	// i equals to the integer part of f
+	frac := f - float64(t)
+	switch {
+	case frac > 0:
+		return -1
+	case frac < 0:
+		return 1
+	}
+	return 0
+}
+
+// twoPow63 is 2^63, which is the smallest float greater than any int64.
+const twoPow63 = float64(1 << 63)
+
+// floatAsInt returns the Int equal to the Float when the Float has an
+// integer value in the range of int64.
+func floatAsInt(f float64) (int64, bool) {
+	if f < -twoPow63 || f >= twoPow63 || f != math.Trunc(f) {
+		return 0, false // NaN also comes here
+	}
+	return int64(f), true
+}
+
 func appendInt32(b []byte, t TypeID, i int32) []byte {
 	i *= 16777619 // multiply fnv.prime32 due to the same reason as appendInt64
 	return append(b, byte(t),
@@ -241,9 +335,9 @@ func appendInt64(b []byte, t TypeID, i int64) []byte {
 	)
 }
 
-var (
-	nullHashCounter int64
-)
+// nanHashBits is the bit pattern from which the hash value of NaN is
+// computed. All NaNs have the same hash value regardless of their payloads.
+var nanHashBits = int64(math.Float64bits(math.NaN()))
 
 func updateHash(v Value, h io.Writer, buffer []byte) []byte {
 	switch v.Type() {
@@ -268,15 +362,13 @@ func updateHash(v Value, h io.Writer, buffer []byte) []byte {
 
 	case TypeFloat:
 		f, _ := v.asFloat()
-		if float64(int64(f)) == f {
-			return updateHash(Int(f), h, buffer)
+		if i, ok := floatAsInt(f); ok {
+			// -0.0 also comes here
+			return updateHash(Int(i), h, buffer)
 		}
 
 		if math.IsNaN(f) {
-			// NaN is processed as Null with a unique counter which results in
-			// generating different hash values for each NaN.
-			cnt := atomic.AddInt64(&nullHashCounter, 1)
-			buffer = appendInt64(buffer, TypeNull, cnt)
+			buffer = appendInt64(buffer, TypeFloat, nanHashBits)
 		} else {
 			buffer = appendInt64(buffer, TypeFloat, int64(math.Float64bits(f)))
 		}
@@ -321,15 +413,16 @@ func updateHash(v Value, h io.Writer, buffer []byte) []byte {
 		// The following implementation computes consistent hash values very
 		// quickly by sacrificing the possibility of collisions. Instead of
 		// sorting keys, it sums up all hash values computed from each key
-		// value pair. Therefore, when numbers of elements in two Maps are
-		// the same, possibility of collisions will increase. However, the hash
-		// value is 64bit and SensorBee usually process less than 1B tuples
-		// at once, the possibility is considered sufficiently low.
+		// value pair so that the result doesn't depend on the iteration
+		// order of the map. Therefore, when numbers of elements in two Maps
+		// are the same, possibility of collisions will increase. However,
+		// the sum is 128bit and SensorBee usually process less than 1B
+		// tuples at once, the possibility is considered sufficiently low.
 
-		var upper uint32
-		var lower uint64
+		var high, low uint64
+		var sum [16]byte
 
-		subHash := fnv.New64a() // TODO: reduce this allocation
+		subHash := fnv.New128a() // TODO: reduce this allocation
 		for k, v := range m {
 			subHash.Reset()
 
@@ -338,16 +431,17 @@ func updateHash(v Value, h io.Writer, buffer []byte) []byte {
 			// values.
 			buffer = updateHash(v, subHash, buffer[:0])
 			buffer = updateHash(String(k), subHash, buffer[:0])
-			sh := subHash.Sum64()
-			if sh+lower < sh|lower { // carried
-				upper++
+			sh := newHashValue(subHash.Sum(sum[:0]))
+			low += sh.Low
+			if low < sh.Low { // carried
+				high++
 			}
-			lower += sh
+			high += sh.High
 		}
 
 		buffer = appendInt32(buffer[:0], TypeMap, int32(len(m)))
-		buffer = appendInt32(buffer, TypeMap, int32(upper))
-		buffer = appendInt64(buffer, TypeMap, int64(lower))
+		buffer = appendInt64(buffer, TypeMap, int64(high))
+		buffer = appendInt64(buffer, TypeMap, int64(low))
 		h.Write(buffer)
 	}
 	return buffer
@@ -21,7 +21,7 @@ var testCases = []struct {
 	{Bool(true)},
 	// Float
 	{Float(2.34)},
-	{Float(math.NaN())}, // NaN == NaN is false
+	{Float(math.NaN())}, // NaN only equals NaN
 	{Float(math.Inf(-1))},
 	{Float(2.000000000000000000000000000000000000000000000000000000000001)},
 	{Float(2.0)},
@@ -105,7 +105,7 @@ func TestHash(t *testing.T) {
 
 		Convey("When a map doesn't contain something invalid", func() {
 			Convey("Then Hash should always return the same value", func() {
-				So(Hash(m), ShouldEqual, Hash(m))
+				So(Hash(m), ShouldResemble, Hash(m))
 			})
 		})
 
@@ -118,7 +118,7 @@ func TestHash(t *testing.T) {
 			So(m.Set(MustCompilePath("map.int"), Float(10.0)), ShouldBeNil)
 
 			Convey("Then Hash should return the same value", func() {
-				So(Hash(c), ShouldEqual, Hash(m))
+				So(Hash(c), ShouldResemble, Hash(m))
 			})
 		})
 
@@ -127,34 +127,65 @@ func TestHash(t *testing.T) {
 			c := m.Copy()
 			Convey("Then Hash should return the same value", func() {
 				c["timestamp"] = t
-				So(Hash(c), ShouldEqual, Hash(m))
+				So(Hash(c), ShouldResemble, Hash(m))
 			})
 
 			Convey("Then Hash should behave samely when they're in an array", func() {
 				So(c.Set(MustCompilePath("array[7]"), t), ShouldBeNil)
-				So(Hash(c), ShouldEqual, Hash(m))
+				So(Hash(c), ShouldResemble, Hash(m))
 			})
 
 			Convey("Then Hash should behave samely when they're in a map", func() {
 				So(c.Set(MustCompilePath("map.timestamp"), t), ShouldBeNil)
-				So(Hash(c), ShouldEqual, Hash(m))
+				So(Hash(c), ShouldResemble, Hash(m))
 			})
 		})
 
 		Convey("When a map contains NaN", func() {
-			Convey("Then Hash should alwasy return different values", func() {
+			c := m.Copy()
+			Convey("Then Hash should always return the same value", func() {
 				m["nan"] = Float(math.NaN())
-				So(Hash(m), ShouldNotEqual, Hash(m))
+				c["nan"] = Float(math.NaN())
+				So(Hash(m), ShouldResemble, Hash(m))
+				So(Hash(c), ShouldResemble, Hash(m))
 			})
 
 			Convey("Then NaN in an array should behave samely", func() {
 				So(m.Set(MustCompilePath("array[0]"), Float(math.NaN())), ShouldBeNil)
-				So(Hash(m), ShouldNotEqual, Hash(m))
+				So(c.Set(MustCompilePath("array[0]"), Float(math.NaN())), ShouldBeNil)
+				So(Hash(c), ShouldResemble, Hash(m))
 			})
 
 			Convey("Then NaN in a map should behave samely", func() {
 				So(m.Set(MustCompilePath("map.float"), Float(math.NaN())), ShouldBeNil)
-				So(Hash(m), ShouldNotEqual, Hash(m))
+				So(c.Set(MustCompilePath("map.float"), Float(math.NaN())), ShouldBeNil)
+				So(Hash(c), ShouldResemble, Hash(m))
+			})
+		})
+
+		Convey("When comparing -0.0 to 0.0", func() {
+			c := m.Copy()
+			m["float"] = Float(math.Copysign(0, -1))
+			c["float"] = Float(0)
+
+			Convey("Then Hash should return the same value", func() {
+				So(Hash(c), ShouldResemble, Hash(m))
+			})
+		})
+
+		Convey("When comparing maps having the same content", func() {
+			// Maps having many keys are iterated in different orders.
+			c := Map{}
+			for k, v := range m {
+				c[k] = v
+			}
+			for i := 0; i < 100; i++ {
+				m[fmt.Sprint("key", i)] = Int(i)
+				c[fmt.Sprint("key", 99-i)] = Int(99 - i)
+			}
+
+			Convey("Then Hash should return the same value", func() {
+				So(Hash(c), ShouldResemble, Hash(m))
 			})
 		})
 	})
@@ -176,7 +207,9 @@ func TestEquality(t *testing.T) {
 						// array
 						((i == 21 && j == 22) || (j == 21 && i == 22)) ||
 						// map
-						((i == 26 && j == 27) || (j == 26 && i == 27)) {
+						((i == 26 && j == 27) || (j == 26 && i == 27)) ||
+						// NaN
+						(i == 6 && j == 6) {
 						So(de, ShouldBeFalse)
 						So(he, ShouldBeTrue)
 					} else {
@@ -191,20 +224,8 @@ func TestEquality(t *testing.T) {
 					if he {
 						So(Less(left, right) || Less(right, left), ShouldBeFalse)
 					} else {
-						if i == 6 { // NaN
-							if right.Type() == TypeInt || right.Type() == TypeFloat {
-								So(Less(left, right), ShouldBeFalse)
-								So(Less(right, left), ShouldBeFalse)
-							}
-						} else if j == 6 { // NaN
-							if left.Type() == TypeInt || left.Type() == TypeFloat {
-								So(Less(left, right), ShouldBeFalse)
-								So(Less(right, left), ShouldBeFalse)
-							}
-						} else {
-							// if the values are not equal, exactly one of them should be less
-							So(Less(left, right), ShouldNotEqual, Less(right, left))
-						}
+						// if the values are not equal, exactly one of them should be less
+						So(Less(left, right), ShouldNotEqual, Less(right, left))
 					}
 				})
 			})
@@ -312,8 +333,8 @@ func TestLess(t *testing.T) {
 		{Array{Int(1)}, Array{Int(1)}, false},         // equal
 		{Array{Int(1)}, Array{Int(1), Int(2)}, true},  // less entries
 		{Array{Int(1), Int(2)}, Array{Int(1)}, false}, // more entries
-		{Array{Int(1)}, Array{Int(6)}, true},          // hash is smaller
-		{Array{Int(1)}, Array{Int(2)}, false},         // hash is larger
+		{Array{Int(1)}, Array{Int(2)}, true},          // hash is smaller
+		{Array{Int(1)}, Array{Int(19)}, false},        // hash is larger
 		{Array{Int(1)}, Map{"a": Int(1)}, true},
 		{Map{"a": Int(1)}, Null{}, false},
 		{Map{"a": Int(1)}, Bool(true), false},
@@ -327,7 +348,7 @@ func TestLess(t *testing.T) {
 		{Map{"a": Int(1)}, Map{"a": Int(1), "b": Int(2)}, true},  // less entries
 		{Map{"a": Int(1), "b": Int(2)}, Map{"a": Int(1)}, false}, // more entries
 		{Map{"a": Int(1)}, Map{"a": Int(8)}, true},               // hash is smaller
-		{Map{"a": Int(1)}, Map{"a": Int(3)}, false},              // hash is larger
+		{Map{"a": Int(1)}, Map{"a": Int(9)}, false},              // hash is larger
 	}
 
 	Convey("When comparing a < b", t, func() {
@@ -345,6 +366,80 @@ func TestLess(t *testing.T) {
 	})
 }
 
+func TestEqualitySemantics(t *testing.T) {
+	now := time.Now()
+	for now.Nanosecond()%1000 == 999 {
+		now = time.Now()
+	}
+
+	Convey("Given values which are equal in terms of Equal", t, func() {
+		cases := []struct {
+			title string
+			l, r  Value
+		}{
+			{"NaN", Float(math.NaN()), Float(math.NaN())},
+			{"-0.0 and 0.0", Float(math.Copysign(0, -1)), Float(0)},
+			{"-0.0 and 0", Float(math.Copysign(0, -1)), Int(0)},
+			{"a large int and a float", Int(1 << 62), Float(1 << 62)},
+			{"timestamps differing less than 1us", Timestamp(now), Timestamp(now.Add(1))},
+			{"timestamps in different locations", Timestamp(now), Timestamp(now.UTC())},
+			{"arrays having NaN", Array{Float(math.NaN())}, Array{Float(math.NaN())}},
+		}
+
+		for _, c := range cases {
+			Convey(fmt.Sprintf("When comparing %v", c.title), func() {
+				Convey("Then they should be equal and have the same hash value", func() {
+					So(Equal(c.l, c.r), ShouldBeTrue)
+					So(Less(c.l, c.r) || Less(c.r, c.l), ShouldBeFalse)
+					So(Hash(c.l), ShouldResemble, Hash(c.r))
+				})
+			})
+		}
+	})
+
+	Convey("Given values which aren't equal in terms of Equal", t, func() {
+		cases := []struct {
+			title string
+			l, r  Value // l < r
+		}{
+			{"NaN and a number", Float(math.Inf(1)), Float(math.NaN())},
+			{"NaN and an int", Int(math.MaxInt64), Float(math.NaN())},
+			{"an int and a float losing precision", Int(1<<53 + 1), Float(1<<53 + 2)},
+			{"an int and a float having the same integer part", Int(2), Float(2.5)},
+			{"a negative int and a float", Float(-2.5), Int(-2)},
+			{"the max int and 2^63", Int(math.MaxInt64), Float(1 << 63)},
+			{"timestamps differing 1us", Timestamp(now), Timestamp(now.Add(time.Microsecond))},
+		}
+
+		for _, c := range cases {
+			Convey(fmt.Sprintf("When comparing %v", c.title), func() {
+				Convey("Then they shouldn't be equal", func() {
+					So(Equal(c.l, c.r), ShouldBeFalse)
+					So(Equal(c.r, c.l), ShouldBeFalse)
+				})
+
+				Convey("Then the first one should be less", func() {
+					So(Less(c.l, c.r), ShouldBeTrue)
+					So(Less(c.r, c.l), ShouldBeFalse)
+				})
+			})
+		}
+	})
+
+	Convey("Given a hash value of a value", t, func() {
+		h := Hash(Map{
+			"a": Array{Int(1), Float(2.5), String("hoge")},
+			"b": Map{"c": Null{}, "d": True},
+		})
+
+		Convey("Then it should be the same as the value computed before", func() {
+			// This value must not change because hash values can be
+			// persisted or shared among processes.
+			So(h, ShouldResemble, HashValue{High: 1489063824663151175, Low: 4721540561589704600})
+		})
+	})
+}
+
 func BenchmarkDeepEqual(b *testing.B) {
 	for n := 0; n < b.N; n++ {
 		for _, tc1 := range testCases {
@@ -365,11 +460,12 @@ func BenchmarkEqual(b *testing.B) {
 	}
 }
 
+var benchmarkHashValue HashValue
+
 func BenchmarkHash(b *testing.B) {
-	var h HashValue
 	for n := 0; n < b.N; n++ {
 		for _, tc := range testCases {
-			h += Hash(tc.input)
+			benchmarkHashValue = Hash(tc.input)
 		}
 	}
 }
@@ -391,8 +487,7 @@ func BenchmarkHashLargeMap(b *testing.B) {
 	}
 	b.ResetTimer()
 
-	var h HashValue
 	for n := 0; n < b.N; n++ {
-		h += Hash(m)
+		benchmarkHashValue = Hash(m)
 	}
 }
@@ -0,0 +1,54 @@
+package data
+
+import (
+	"sync"
+)
+
+const (
+	// maxInternedKeys is the maximum number of keys interned by InternKey.
+	// Keys are no longer interned once the table gets full so that tuples
+	// having random keys don't make the table grow without bound.
+	maxInternedKeys = 4096
+
+	// maxInternedKeyLen is the maximum length of a key interned by
+	// InternKey. Long keys are rarely common among tuples.
+	maxInternedKeyLen = 64
+)
+
+var internedKeys = struct {
+	m    sync.RWMutex
+	keys map[string]string
+}{
+	keys: map[string]string{},
+}
+
+// InternKey returns the canonical instance of a key of a Map. Maps created
+// from decoded data have many keys with the same content, such as "id" or
+// "temp", and interning them lets those Maps share one string instead of
+// keeping a copy per Map, which reduces memory held by windows and GC
+// pressure.
+//
+// The key is returned as is when it's longer than 64 bytes or when 4096 keys
+// have already been interned.
+func InternKey(k string) string {
+	if len(k) > maxInternedKeyLen {
+		return k
+	}
+	internedKeys.m.RLock()
+	s, ok := internedKeys.keys[k]
+	internedKeys.m.RUnlock()
+	if ok {
+		return s
+	}
+
+	internedKeys.m.Lock()
+	defer internedKeys.m.Unlock()
+	if s, ok := internedKeys.keys[k]; ok {
+		return s
+	}
+	if len(internedKeys.keys) >= maxInternedKeys {
+		return k
+	}
+	internedKeys.keys[k] = k
+	return k
+}
@@ -0,0 +1,59 @@
+package data
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"unsafe"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// stringData returns the address of the content of the string.
+func stringData(s string) uintptr {
+	return (*reflect.StringHeader)(unsafe.Pointer(&s)).Data
+}
+
+func TestInternKey(t *testing.T) {
+	Convey("Given a key", t, func() {
+		k := InternKey(string([]byte("intern_test_key")))
+
+		Convey("When interning another key having the same content", func() {
+			k2 := InternKey(string([]byte("intern_test_key")))
+
+			Convey("Then it should share the content with the first key", func() {
+				So(k2, ShouldEqual, k)
+				So(stringData(k2), ShouldEqual, stringData(k))
+			})
+		})
+
+		Convey("When creating Maps having the key", func() {
+			m1, err := NewMap(map[string]interface{}{string([]byte("intern_test_key")): 1})
+			So(err, ShouldBeNil)
+			m2, err := NewMap(map[string]interface{}{string([]byte("intern_test_key")): 2})
+			So(err, ShouldBeNil)
+
+			Convey("Then the Maps should share the key", func() {
+				for k1 := range m1 {
+					for k2 := range m2 {
+						So(stringData(k1), ShouldEqual, stringData(k))
+						So(stringData(k2), ShouldEqual, stringData(k))
+					}
+				}
+			})
+		})
+	})
+
+	Convey("Given a long key", t, func() {
+		k := strings.Repeat("a", maxInternedKeyLen+1)
+
+		Convey("When interning it", func() {
+			k2 := InternKey(k)
+
+			Convey("Then it should be returned as is", func() {
+				So(stringData(k2), ShouldEqual, stringData(k))
+				So(stringData(InternKey(string([]byte(k)))), ShouldNotEqual, stringData(k))
+			})
+		})
+	})
+}
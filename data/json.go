@@ -0,0 +1,666 @@
+package data
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// JSONLimits has limits on a JSON document read by JSONDecoder. They bound
+// the memory used for decoding a malformed or malicious input, such as a
+// single huge line or deeply nested arrays. A limit of 0 means no limit.
+type JSONLimits struct {
+	// MaxDocumentSize is the maximum number of bytes of a document
+	// including whitespace inside it.
+	MaxDocumentSize int64
+
+	// MaxDepth is the maximum nesting depth of arrays and objects. A scalar
+	// value has the depth 0 and {"a":[1]} has the depth 2.
+	MaxDepth int
+
+	// MaxStringLength is the maximum number of bytes of a string or a key of
+	// an object after escape sequences are decoded.
+	MaxStringLength int
+}
+
+// DefaultJSONLimits has limits used when sources don't specify them.
+var DefaultJSONLimits = JSONLimits{
+	MaxDocumentSize: 16 * 1024 * 1024,
+	MaxDepth:        256,
+	MaxStringLength: 4 * 1024 * 1024,
+}
+
+// JSONLimitError is returned when a JSON document exceeds one of JSONLimits.
+type JSONLimitError struct {
+	// Limit is the name of the limit, which is "document size", "depth", or
+	// "string length".
+	Limit string
+
+	// Max is the value of the limit.
+	Max int64
+
+	// Offset is the offset in the input at which the limit was exceeded.
+	Offset int64
+}
+
+func (e *JSONLimitError) Error() string {
+	return fmt.Sprintf("json: %v exceeds the limit of %v at offset %v", e.Limit, e.Max, e.Offset)
+}
+
+// JSONSyntaxError is returned when an input isn't valid JSON.
+type JSONSyntaxError struct {
+	msg string
+
+	// Offset is the offset in the input at which the error was found.
+	Offset int64
+}
+
+func (e *JSONSyntaxError) Error() string {
+	return fmt.Sprintf("json: %v at offset %v", e.msg, e.Offset)
+}
+
+// JSONDecoder reads a stream of JSON values and converts each of them to a
+// Value while reading it, without buffering the whole document. Values are
+// converted in the same way as NewValue does for values unmarshaled by
+// encoding/json with json.Number, i.e. a number becomes an Int if it's an
+// integer in the range of int64 and a Float otherwise.
+//
+// Values may be separated by whitespace. JSONDecoder doesn't read bytes
+// beyond the last value decoded, so a stream of JSON Lines can be read with
+// Decode and EndLine, and a malformed line can be skipped by SkipLine.
+// JSONDecoder isn't thread-safe.
+type JSONDecoder struct {
+	r      io.ByteScanner
+	limits JSONLimits
+
+	// n is the number of bytes read from r and limit is the offset up to
+	// which bytes of the current document can be read.
+	n     int64
+	limit int64
+
+	// line is the number of newlines read from r and valueLine is the line
+	// at which the last value decoded started.
+	line      int64
+	valueLine int64
+
+	// singleLine disallows newlines in a value.
+	singleLine bool
+	inValue    bool
+
+	depth int
+	buf   []byte
+}
+
+// NewJSONDecoder returns a decoder reading JSON values from r with the given
+// limits. When r doesn't implement io.ByteScanner, it's buffered and the
+// decoder may read bytes beyond the last value decoded.
+func NewJSONDecoder(r io.Reader, limits JSONLimits) *JSONDecoder {
+	s, ok := r.(io.ByteScanner)
+	if !ok {
+		s = bufio.NewReader(r)
+	}
+	return &JSONDecoder{
+		r:      s,
+		limits: limits,
+		limit:  math.MaxInt64,
+	}
+}
+
+// DecodeJSON decodes a JSON document in b with the given limits. b must not
+// have anything other than whitespace after the document.
+func DecodeJSON(b []byte, limits JSONLimits) (Value, error) {
+	d := NewJSONDecoder(bytes.NewReader(b), limits)
+	v, err := d.Decode()
+	if err == io.EOF {
+		return nil, &JSONSyntaxError{msg: "unexpected end of JSON input", Offset: d.n}
+	} else if err != nil {
+		return nil, err
+	}
+	if c, err := d.skipSpace(); err == nil {
+		return nil, d.syntaxError("after top-level value", c)
+	} else if err != io.EOF {
+		return nil, err
+	}
+	return v, nil
+}
+
+// DisallowNewlines makes Decode return *JSONSyntaxError when a value spans
+// multiple lines, which isn't allowed in JSON Lines. Without it, a line
+// truncated in the middle of a value would be continued by the next line.
+func (d *JSONDecoder) DisallowNewlines() {
+	d.singleLine = true
+}
+
+// Decode reads the next value from the stream. It returns io.EOF when the
+// stream ends before a value begins and io.ErrUnexpectedEOF when it ends in
+// the middle of a value. It returns *JSONSyntaxError for a malformed value
+// and *JSONLimitError when the value exceeds the limits. In these cases, the
+// rest of the value remains in the stream.
+func (d *JSONDecoder) Decode() (Value, error) {
+	d.limit = math.MaxInt64
+	c, err := d.skipSpace()
+	if err != nil {
+		return nil, err
+	}
+	d.valueLine = d.line
+	if max := d.limits.MaxDocumentSize; max > 0 {
+		d.limit = d.n - 1 + max
+	}
+	d.depth = 0
+	d.inValue = true
+	v, err := d.value(c)
+	d.inValue = false
+	d.limit = math.MaxInt64
+	if err == io.EOF {
+		return nil, io.ErrUnexpectedEOF
+	}
+	return v, err
+}
+
+// EndLine reads the rest of the line after the last value decoded including
+// the newline terminating it. It returns *JSONSyntaxError when the line has
+// something other than whitespace and io.EOF when the stream ends before a
+// newline.
+func (d *JSONDecoder) EndLine() error {
+	for {
+		c, err := d.next()
+		if err != nil {
+			return err
+		}
+		switch c {
+		case '\n':
+			d.line++
+			return nil
+		case ' ', '\t', '\r':
+		default:
+			d.unread()
+			return d.syntaxError("after top-level value", c)
+		}
+	}
+}
+
+// SkipLine discards bytes up to and including the next newline. It returns
+// io.EOF when the stream ends before a newline.
+func (d *JSONDecoder) SkipLine() error {
+	for {
+		c, err := d.next()
+		if err != nil {
+			return err
+		}
+		if c == '\n' {
+			d.line++
+			return nil
+		}
+	}
+}
+
+// InputOffset returns the number of bytes read from the stream, which is the
+// end offset of the last value or line read.
+func (d *JSONDecoder) InputOffset() int64 {
+	return d.n
+}
+
+// Line returns the 0-based line number at which the last value decoded
+// started.
+func (d *JSONDecoder) Line() int64 {
+	return d.valueLine
+}
+
+func (d *JSONDecoder) next() (byte, error) {
+	if d.n >= d.limit {
+		return 0, d.sizeLimitError()
+	}
+	c, err := d.r.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+	d.n++
+	return c, nil
+}
+
+func (d *JSONDecoder) sizeLimitError() error {
+	return &JSONLimitError{
+		Limit:  "document size",
+		Max:    d.limits.MaxDocumentSize,
+		Offset: d.n,
+	}
+}
+
+// unread puts back the byte read by the last call of next.
+func (d *JSONDecoder) unread() {
+	if err := d.r.UnreadByte(); err == nil {
+		d.n--
+	}
+}
+
+// accept reads the next byte if f returns true for it. It returns false when
+// the stream or the document ends.
+func (d *JSONDecoder) accept(f func(c byte) bool) (byte, bool, error) {
+	if d.n >= d.limit {
+		// A number ending at the limit is still valid, so the next byte is
+		// only peeked.
+		c, err := d.r.ReadByte()
+		if err == io.EOF {
+			return 0, false, nil
+		} else if err != nil {
+			return 0, false, err
+		}
+		if err := d.r.UnreadByte(); err != nil {
+			return 0, false, err
+		}
+		if f(c) {
+			return 0, false, d.sizeLimitError()
+		}
+		return 0, false, nil
+	}
+	c, err := d.next()
+	if err == io.EOF {
+		return 0, false, nil
+	} else if err != nil {
+		return 0, false, err
+	}
+	if !f(c) {
+		d.unread()
+		return 0, false, nil
+	}
+	return c, true, nil
+}
+
+func (d *JSONDecoder) skipSpace() (byte, error) {
+	for {
+		c, err := d.next()
+		if err != nil {
+			return 0, err
+		}
+		switch c {
+		case '\n':
+			if d.singleLine && d.inValue {
+				d.unread()
+				return 0, &JSONSyntaxError{msg: "unexpected newline in value", Offset: d.n}
+			}
+			d.line++
+		case ' ', '\t', '\r':
+		default:
+			return c, nil
+		}
+	}
+}
+
+// syntaxError returns an error for the invalid character c which has just
+// been read. A newline is put back so that SkipLine doesn't skip the next
+// line.
+func (d *JSONDecoder) syntaxError(context string, c byte) error {
+	if c == '\n' {
+		d.unread()
+	}
+	return &JSONSyntaxError{
+		msg:    fmt.Sprintf("invalid character %q %v", c, context),
+		Offset: d.n,
+	}
+}
+
+// value decodes a value beginning with c.
+func (d *JSONDecoder) value(c byte) (Value, error) {
+	switch c {
+	case '{':
+		return d.object()
+	case '[':
+		return d.array()
+	case '"':
+		s, err := d.str()
+		if err != nil {
+			return nil, err
+		}
+		return String(s), nil
+	case 't':
+		return Bool(true), d.literal("rue")
+	case 'f':
+		return Bool(false), d.literal("alse")
+	case 'n':
+		return Null{}, d.literal("ull")
+	}
+	if c == '-' || isJSONDigit(c) {
+		return d.number(c)
+	}
+	return nil, d.syntaxError("looking for beginning of value", c)
+}
+
+func (d *JSONDecoder) literal(rest string) error {
+	for i := 0; i < len(rest); i++ {
+		c, err := d.next()
+		if err != nil {
+			return err
+		}
+		if c != rest[i] {
+			return d.syntaxError("in literal", c)
+		}
+	}
+	return nil
+}
+
+// enter increments the nesting depth for an array or an object.
+func (d *JSONDecoder) enter() error {
+	d.depth++
+	if max := d.limits.MaxDepth; max > 0 && d.depth > max {
+		return &JSONLimitError{
+			Limit:  "depth",
+			Max:    int64(max),
+			Offset: d.n,
+		}
+	}
+	return nil
+}
+
+func (d *JSONDecoder) object() (Value, error) {
+	if err := d.enter(); err != nil {
+		return nil, err
+	}
+	m := Map{}
+	c, err := d.skipSpace()
+	if err != nil {
+		return nil, err
+	}
+	if c == '}' {
+		d.depth--
+		return m, nil
+	}
+	for {
+		if c != '"' {
+			return nil, d.syntaxError("looking for beginning of object key string", c)
+		}
+		k, err := d.str()
+		if err != nil {
+			return nil, err
+		}
+		if c, err = d.skipSpace(); err != nil {
+			return nil, err
+		}
+		if c != ':' {
+			return nil, d.syntaxError("after object key", c)
+		}
+		if c, err = d.skipSpace(); err != nil {
+			return nil, err
+		}
+		v, err := d.value(c)
+		if err != nil {
+			return nil, err
+		}
+		m[k] = v
+
+		if c, err = d.skipSpace(); err != nil {
+			return nil, err
+		}
+		switch c {
+		case ',':
+			if c, err = d.skipSpace(); err != nil {
+				return nil, err
+			}
+		case '}':
+			d.depth--
+			return m, nil
+		default:
+			return nil, d.syntaxError("after object key:value pair", c)
+		}
+	}
+}
+
+func (d *JSONDecoder) array() (Value, error) {
+	if err := d.enter(); err != nil {
+		return nil, err
+	}
+	a := Array{}
+	c, err := d.skipSpace()
+	if err != nil {
+		return nil, err
+	}
+	if c == ']' {
+		d.depth--
+		return a, nil
+	}
+	for {
+		v, err := d.value(c)
+		if err != nil {
+			return nil, err
+		}
+		a = append(a, v)
+
+		if c, err = d.skipSpace(); err != nil {
+			return nil, err
+		}
+		switch c {
+		case ',':
+			if c, err = d.skipSpace(); err != nil {
+				return nil, err
+			}
+		case ']':
+			d.depth--
+			return a, nil
+		default:
+			return nil, d.syntaxError("after array element", c)
+		}
+	}
+}
+
+// str decodes a string after its opening quote.
+func (d *JSONDecoder) str() (string, error) {
+	d.buf = d.buf[:0]
+	for {
+		c, err := d.next()
+		if err != nil {
+			return "", err
+		}
+		switch {
+		case c == '"':
+			return validUTF8(d.buf), nil
+		case c == '\\':
+			if c, err = d.next(); err != nil {
+				return "", err
+			}
+			if err := d.escape(c); err != nil {
+				return "", err
+			}
+		case c < 0x20:
+			return "", d.syntaxError("in string literal", c)
+		default:
+			d.buf = append(d.buf, c)
+		}
+		if max := d.limits.MaxStringLength; max > 0 && len(d.buf) > max {
+			return "", &JSONLimitError{
+				Limit:  "string length",
+				Max:    int64(max),
+				Offset: d.n,
+			}
+		}
+	}
+}
+
+// escape decodes an escape sequence whose backslash is followed by c.
+func (d *JSONDecoder) escape(c byte) error {
+	switch c {
+	case '"', '\\', '/':
+		d.buf = append(d.buf, c)
+	case 'b':
+		d.buf = append(d.buf, '\b')
+	case 'f':
+		d.buf = append(d.buf, '\f')
+	case 'n':
+		d.buf = append(d.buf, '\n')
+	case 'r':
+		d.buf = append(d.buf, '\r')
+	case 't':
+		d.buf = append(d.buf, '\t')
+	case 'u':
+		r, err := d.hex4()
+		if err != nil {
+			return err
+		}
+		return d.escapedRune(r)
+	default:
+		return d.syntaxError("in string escape code", c)
+	}
+	return nil
+}
+
+// escapedRune appends a rune written as \uXXXX. A surrogate pair is written
+// as two escape sequences, so it reads the next one when r is a surrogate.
+// An invalid surrogate is replaced with U+FFFD as encoding/json does.
+func (d *JSONDecoder) escapedRune(r rune) error {
+	for utf16.IsSurrogate(r) {
+		c, err := d.next()
+		if err != nil {
+			return err
+		}
+		if c != '\\' {
+			d.unread()
+			break
+		}
+		if c, err = d.next(); err != nil {
+			return err
+		}
+		if c != 'u' {
+			d.buf = utf8.AppendRune(d.buf, unicode.ReplacementChar)
+			return d.escape(c)
+		}
+		r2, err := d.hex4()
+		if err != nil {
+			return err
+		}
+		if dec := utf16.DecodeRune(r, r2); dec != unicode.ReplacementChar {
+			r = dec
+			break
+		}
+		d.buf = utf8.AppendRune(d.buf, unicode.ReplacementChar)
+		r = r2
+	}
+	if utf16.IsSurrogate(r) {
+		r = unicode.ReplacementChar
+	}
+	d.buf = utf8.AppendRune(d.buf, r)
+	return nil
+}
+
+func (d *JSONDecoder) hex4() (rune, error) {
+	var r rune
+	for i := 0; i < 4; i++ {
+		c, err := d.next()
+		if err != nil {
+			return 0, err
+		}
+		switch {
+		case '0' <= c && c <= '9':
+			c -= '0'
+		case 'a' <= c && c <= 'f':
+			c = c - 'a' + 10
+		case 'A' <= c && c <= 'F':
+			c = c - 'A' + 10
+		default:
+			return 0, d.syntaxError("in \\u hexadecimal character escape", c)
+		}
+		r = r*16 + rune(c)
+	}
+	return r, nil
+}
+
+// validUTF8 replaces each byte of an invalid UTF-8 sequence in b with
+// U+FFFD as encoding/json does.
+func validUTF8(b []byte) string {
+	if utf8.Valid(b) {
+		return string(b)
+	}
+	var s strings.Builder
+	for len(b) > 0 {
+		r, size := utf8.DecodeRune(b)
+		if r == utf8.RuneError && size == 1 {
+			s.WriteRune(unicode.ReplacementChar)
+		} else {
+			s.Write(b[:size])
+		}
+		b = b[size:]
+	}
+	return s.String()
+}
+
+func isJSONDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+// digits appends consecutive digits to buf and returns the number of them.
+func (d *JSONDecoder) digits() (int, error) {
+	n := 0
+	for {
+		c, ok, err := d.accept(isJSONDigit)
+		if err != nil {
+			return 0, err
+		}
+		if !ok {
+			return n, nil
+		}
+		d.buf = append(d.buf, c)
+		n++
+	}
+}
+
+// number decodes a number beginning with c. Unlike other values, a number
+// at the end of the stream is complete.
+func (d *JSONDecoder) number(c byte) (Value, error) {
+	d.buf = append(d.buf[:0], c)
+	if c == '-' {
+		var err error
+		if c, err = d.next(); err != nil {
+			return nil, err
+		}
+		if !isJSONDigit(c) {
+			return nil, d.syntaxError("in numeric literal", c)
+		}
+		d.buf = append(d.buf, c)
+	}
+	if c != '0' {
+		if _, err := d.digits(); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, ok, err := d.accept(func(c byte) bool { return c == '.' }); err != nil {
+		return nil, err
+	} else if ok {
+		d.buf = append(d.buf, '.')
+		if n, err := d.digits(); err != nil {
+			return nil, err
+		} else if n == 0 {
+			return nil, &JSONSyntaxError{msg: "missing digits after decimal point", Offset: d.n}
+		}
+	}
+
+	if c, ok, err := d.accept(func(c byte) bool { return c == 'e' || c == 'E' }); err != nil {
+		return nil, err
+	} else if ok {
+		d.buf = append(d.buf, c)
+		if c, ok, err := d.accept(func(c byte) bool { return c == '+' || c == '-' }); err != nil {
+			return nil, err
+		} else if ok {
+			d.buf = append(d.buf, c)
+		}
+		if n, err := d.digits(); err != nil {
+			return nil, err
+		} else if n == 0 {
+			return nil, &JSONSyntaxError{msg: "missing digits in exponent", Offset: d.n}
+		}
+	}
+
+	s := string(d.buf)
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return Int(i), nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil, &JSONSyntaxError{msg: fmt.Sprintf("cannot convert %v to a number", s), Offset: d.n}
+	}
+	return Float(f), nil
+}
@@ -0,0 +1,204 @@
+package data
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDecodeJSON(t *testing.T) {
+	Convey("Given valid JSON documents", t, func() {
+		docs := []string{
+			`{"a":1,"b":-2.5,"c":"str","d":[true,false,null],"e":{"f":{}},"g":[]}`,
+			` 123 `,
+			`-0`,
+			`1e3`,
+			`9223372036854775808`,
+			`"esc\"\\\/\b\f\n\r\té😀"`,
+			`"\ud83d" `,
+			`"\ud83dx"`,
+			`"\ud83dA"`,
+			`"\ud83d\n"`,
+			"\"\xff\xfeok\"",
+			`{"a":1,"a":2}`,
+			"[1 ,\n 2]",
+		}
+
+		for i, doc := range docs {
+			doc := doc
+			Convey(fmt.Sprintf("When decoding %v", i), func() {
+				v, err := DecodeJSON([]byte(doc), DefaultJSONLimits)
+				So(err, ShouldBeNil)
+
+				Convey("Then it should be the same as encoding/json with NewValue", func() {
+					var j interface{}
+					dec := json.NewDecoder(strings.NewReader(doc))
+					dec.UseNumber()
+					So(dec.Decode(&j), ShouldBeNil)
+					expected, err := NewValue(j)
+					So(err, ShouldBeNil)
+					So(v, ShouldResemble, expected)
+				})
+			})
+		}
+	})
+
+	Convey("Given invalid JSON documents", t, func() {
+		docs := []string{
+			``,
+			` `,
+			`{`,
+			`{"a"}`,
+			`{"a":1,}`,
+			`[1,]`,
+			`[1 2]`,
+			`01`,
+			`1.`,
+			`1e`,
+			`-`,
+			`tru`,
+			`nul1`,
+			`"\x"`,
+			`"\u12g4"`,
+			"\"a\tb\"",
+			`1e400`,
+			`{} {}`,
+		}
+
+		for i, doc := range docs {
+			doc := doc
+			Convey(fmt.Sprintf("When decoding %v", i), func() {
+				_, err := DecodeJSON([]byte(doc), DefaultJSONLimits)
+
+				Convey("Then it should fail", func() {
+					So(err, ShouldNotBeNil)
+				})
+			})
+		}
+	})
+
+	Convey("Given limits", t, func() {
+		l := JSONLimits{
+			MaxDocumentSize: 16,
+			MaxDepth:        2,
+			MaxStringLength: 4,
+		}
+
+		Convey("When decoding documents within the limits", func() {
+			for _, doc := range []string{`[["abcd"],[12]]`, `1234567890123456`} {
+				_, err := DecodeJSON([]byte(doc), l)
+
+				Convey("Then it should succeed: "+doc, func() {
+					So(err, ShouldBeNil)
+				})
+			}
+		})
+
+		Convey("When decoding documents exceeding the limits", func() {
+			cases := []struct {
+				doc   string
+				limit string
+			}{
+				{`{"a":"b","c":"d","e":1}`, "document size"},
+				{`12345678901234567`, "document size"},
+				{`[[[]]]`, "depth"},
+				{`{"a":{"b":[]}}`, "depth"},
+				{`"abcde"`, "string length"},
+				{`{"abcde":1}`, "string length"},
+				{`"ééé"`, "string length"},
+			}
+			for _, c := range cases {
+				_, err := DecodeJSON([]byte(c.doc), l)
+
+				Convey("Then it should fail due to "+c.limit+": "+c.doc, func() {
+					So(err, ShouldHaveSameTypeAs, &JSONLimitError{})
+					So(err.(*JSONLimitError).Limit, ShouldEqual, c.limit)
+				})
+			}
+		})
+
+		Convey("When a limit is 0", func() {
+			l.MaxDepth = 0
+			_, err := DecodeJSON([]byte(`[[[[[]]]]]`), l)
+
+			Convey("Then it shouldn't be limited", func() {
+				So(err, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestJSONDecoder(t *testing.T) {
+	Convey("Given a stream of JSON Lines", t, func() {
+		in := `{"a":1}
+{"a":"` + strings.Repeat("x", 100) + `"}
+
+{"a":3}   x
+{"a":
+4}
+{"a":5}`
+		dec := NewJSONDecoder(strings.NewReader(in), JSONLimits{MaxDocumentSize: 32})
+		dec.DisallowNewlines()
+
+		Convey("When reading it line by line", func() {
+			var vs []Value
+			var errs []error
+			var lines []int64
+			for {
+				v, err := dec.Decode()
+				if err == io.EOF {
+					break
+				}
+				lines = append(lines, dec.Line())
+				if err == nil {
+					err = dec.EndLine()
+					if err == io.EOF {
+						err = nil
+					}
+					if err == nil {
+						vs = append(vs, v)
+						continue
+					}
+				}
+				errs = append(errs, err)
+				if err := dec.SkipLine(); err == io.EOF {
+					break
+				}
+			}
+
+			Convey("Then it should decode valid lines and skip others", func() {
+				So(vs, ShouldResemble, []Value{Map{"a": Int(1)}, Map{"a": Int(5)}})
+				So(errs, ShouldHaveLength, 4)
+				So(errs[0], ShouldHaveSameTypeAs, &JSONLimitError{})
+				So(errs[1], ShouldHaveSameTypeAs, &JSONSyntaxError{})
+				So(errs[2], ShouldHaveSameTypeAs, &JSONSyntaxError{})
+				So(errs[3], ShouldHaveSameTypeAs, &JSONSyntaxError{})
+				So(lines, ShouldResemble, []int64{0, 1, 3, 4, 5, 6})
+			})
+
+			Convey("Then it should have read the whole stream", func() {
+				So(dec.InputOffset(), ShouldEqual, len(in))
+			})
+		})
+	})
+
+	Convey("Given a stream ending in the middle of a value", t, func() {
+		dec := NewJSONDecoder(strings.NewReader(`{"a":1} {"a":`), JSONLimits{})
+
+		Convey("When decoding values", func() {
+			v, err := dec.Decode()
+			So(err, ShouldBeNil)
+			So(v, ShouldResemble, Map{"a": Int(1)})
+			So(dec.InputOffset(), ShouldEqual, 7)
+			_, err = dec.Decode()
+
+			Convey("Then it should return io.ErrUnexpectedEOF", func() {
+				So(err, ShouldEqual, io.ErrUnexpectedEOF)
+			})
+		})
+	})
+}
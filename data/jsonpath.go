@@ -3,10 +3,12 @@ package data
 //go:generate peg jsonpath.peg
 
 import (
+	"container/list"
 	"errors"
 	"fmt"
 	"strconv"
 	"strings"
+	"sync"
 )
 
 type multiplicity int
@@ -26,7 +28,11 @@ type Path interface {
 
 // MustCompilePath takes a JSON Path as a string and returns
 // an instance of Path representing that JSON Path, or panics
-// if the parameter is not a valid JSON Path.
+// if the parameter is not a valid JSON Path. It's intended for
+// paths fixed at initialization, e.g. in a package level variable
+// or an init function of a plugin:
+//
+//	var idPath = data.MustCompilePath("meta.id")
 func MustCompilePath(s string) Path {
 	p, err := CompilePath(s)
 	if err != nil {
@@ -38,7 +44,24 @@ func MustCompilePath(s string) Path {
 // CompilePath takes a JSON Path as a string and returns an
 // instance of Path representing that JSON Path, or an error
 // if the parameter is not a valid JSON Path.
-func CompilePath(s string) (p Path, err error) {
+//
+// Compiled paths are kept in an LRU cache so that compiling the
+// same path again doesn't parse it. The returned Path can be
+// shared by multiple goroutines. See SetPathCacheSize to change
+// the size of the cache.
+func CompilePath(s string) (Path, error) {
+	if p := pathCache.get(s); p != nil {
+		return p, nil
+	}
+	p, err := compilePath(s)
+	if err != nil {
+		return nil, err
+	}
+	pathCache.add(s, p)
+	return p, nil
+}
+
+func compilePath(s string) (p *jsonPeg, err error) {
 	// TODO: reject this pattern by PEG
 	if s == "" {
 		return nil, errors.New("path cannot be an empty string")
@@ -47,6 +70,7 @@ func CompilePath(s string) (p Path, err error) {
 	// catch any parser errors
 	defer func() {
 		if r := recover(); r != nil {
+			p = nil
 			err = fmt.Errorf("%v", r)
 		}
 	}()
@@ -68,12 +92,197 @@ func CompilePath(s string) (p Path, err error) {
 			containsSlice = true
 		}
 	}
-	return j, nil
+
+	// Only components are required to evaluate the path. The parser's
+	// internal states such as the token tree are released here because
+	// the path can be kept in the cache for a long time.
+	return &jsonPeg{
+		Buffer:     s,
+		components: j.components,
+	}, nil
+}
+
+// DefaultPathCacheSize is the default number of paths cached by
+// CompilePath.
+const DefaultPathCacheSize = 1024
+
+// SetPathCacheSize changes the maximum number of paths cached by
+// CompilePath. Least recently used paths are removed when the cache
+// has more paths than the size. A size of 0 or less disables the cache.
+func SetPathCacheSize(size int) {
+	pathCache.resize(size)
+}
+
+var pathCache = newCompiledPathCache(DefaultPathCacheSize)
+
+// compiledPathCache is an LRU cache of compiled paths.
+type compiledPathCache struct {
+	m       sync.Mutex
+	size    int
+	entries map[string]*list.Element
+	lru     *list.List // front is the most recently used one
+}
+
+type compiledPathCacheEntry struct {
+	key  string
+	path *jsonPeg
+}
+
+func newCompiledPathCache(size int) *compiledPathCache {
+	return &compiledPathCache{
+		size:    size,
+		entries: map[string]*list.Element{},
+		lru:     list.New(),
+	}
+}
+
+// get returns the cached path. It returns nil when the path isn't cached.
+func (c *compiledPathCache) get(s string) Path {
+	c.m.Lock()
+	defer c.m.Unlock()
+	e, ok := c.entries[s]
+	if !ok {
+		return nil
+	}
+	c.lru.MoveToFront(e)
+	return e.Value.(*compiledPathCacheEntry).path
+}
+
+func (c *compiledPathCache) add(s string, p *jsonPeg) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	if c.size <= 0 {
+		return
+	}
+	if e, ok := c.entries[s]; ok {
+		// another goroutine has compiled the same path concurrently
+		c.lru.MoveToFront(e)
+		return
+	}
+	c.entries[s] = c.lru.PushFront(&compiledPathCacheEntry{s, p})
+	c.evict()
+}
+
+func (c *compiledPathCache) resize(size int) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	c.size = size
+	c.evict()
+}
+
+// evict removes least recently used paths exceeding the size. The caller
+// must hold the lock.
+func (c *compiledPathCache) evict() {
+	for c.lru.Len() > 0 && c.lru.Len() > c.size {
+		e := c.lru.Back()
+		c.lru.Remove(e)
+		delete(c.entries, e.Value.(*compiledPathCacheEntry).key)
+	}
+}
+
+// len returns the number of cached paths.
+func (c *compiledPathCache) len() int {
+	c.m.Lock()
+	defer c.m.Unlock()
+	return c.lru.Len()
+}
+
+// SplitPath splits a path into paths each of which starts with a map access
+// except the first one, e.g. `a.b[0]["c"]` is split into `["a"]`, `["b"][0]`,
+// and `["c"]`. Getting values from a Map with the returned paths one after another
+// gives the same result as getting a value with the original path. It returns
+// false when the path has a slice or a recursive access because such a path
+// cannot be evaluated partially.
+func SplitPath(p Path) ([]Path, bool) {
+	j, ok := p.(*jsonPeg)
+	if !ok {
+		return nil, false
+	}
+
+	var res []Path
+	var cur *jsonPeg
+	for _, c := range j.components {
+		switch c.(type) {
+		case *mapValueExtractor:
+			if cur != nil {
+				res = append(res, cur)
+				cur = nil
+			}
+		case *arrayElementExtractor:
+		default:
+			return nil, false
+		}
+		if cur == nil {
+			cur = &jsonPeg{}
+		}
+		cur.Buffer += c.String()
+		cur.components = append(cur.components, c)
+	}
+	if cur != nil {
+		res = append(res, cur)
+	}
+	return res, true
+}
+
+// PathStep is a step of evaluating a Path returned from TracePath. Each step
+// corresponds to a component of the Path such as `["a"]`, `[0]`, `[1:3]`, or
+// `..["b"]`.
+type PathStep struct {
+	// Extractor is the canonical representation of the component.
+	Extractor string
+
+	// Value is the intermediate result after the component is applied. It's
+	// nil when the component failed.
+	Value Value
+
+	// Err is the error returned from the component. Components after the
+	// failed one aren't applied.
+	Err error
+}
+
+// TracePath evaluates the path with v in the same way as Map.Get and returns
+// all steps of the evaluation. The Value of the last step is the result of
+// the evaluation unless its Err is set. It's intended to help debugging
+// paths using brackets, quotes, slices, or recursive accesses. It returns an
+// error when the path wasn't created by CompilePath.
+func TracePath(p Path, v Value) ([]PathStep, error) {
+	j, ok := p.(*jsonPeg)
+	if !ok {
+		return nil, fmt.Errorf("path %v cannot be traced", p)
+	}
+
+	var steps []PathStep
+	j.evaluateWithTrace(v, func(c extractor, v Value, err error) {
+		s := PathStep{
+			Extractor: c.String(),
+			Err:       err,
+		}
+		if err == nil {
+			// v can be modified by subsequent components when it's an array
+			// returned from a component like a slice.
+			s.Value = v.clone()
+		}
+		steps = append(steps, s)
+	})
+	return steps, nil
 }
 
 // evaluate returns the entry of a map or an array located at the JSON Path
 // represented by this jsonPeg instance.
 func (j *jsonPeg) evaluate(v Value) (Value, error) {
+	return j.evaluateWithTrace(v, nil)
+}
+
+// evaluateWithTrace is evaluate which calls trace, if it isn't nil, with the
+// intermediate result or the error after each component is applied.
+func (j *jsonPeg) evaluateWithTrace(v Value, trace func(c extractor, v Value, err error)) (Value, error) {
+	fail := func(c extractor, err error) (Value, error) {
+		if trace != nil {
+			trace(c, nil, err)
+		}
+		return nil, err
+	}
+
 	// `current` holds the Value into which we descend, the extracted
 	// value is then written to `next` by `c.extract()`. By assigning
 	// `current = next` after `c.extract()` returns, we can go deeper.
@@ -92,12 +301,12 @@ func (j *jsonPeg) evaluate(v Value) (Value, error) {
 			// replace each item in `current` by its extracted child item
 			arr, err := current.asArray()
 			if err != nil {
-				return nil, err
+				return fail(c, err)
 			}
 			for i, currentElem := range arr {
 				err := c.extract(currentElem, &next)
 				if err != nil {
-					return nil, err
+					return fail(c, err)
 				}
 				if c.resultMultiplicity() == many && next.Type() == TypeArray {
 					// if we get an nil array result, turn it into an empty array instead
@@ -117,7 +326,7 @@ func (j *jsonPeg) evaluate(v Value) (Value, error) {
 			// replace `current` by its extracted child item
 			err := c.extract(current, &next)
 			if err != nil {
-				return nil, err
+				return fail(c, err)
 			}
 			if c.resultMultiplicity() == many && next.Type() == TypeArray {
 				// if we get an nil array result, turn it into an empty array instead
@@ -128,6 +337,9 @@ func (j *jsonPeg) evaluate(v Value) (Value, error) {
 			current = next
 		}
 		resultIsArray = resultIsArray || (c.resultMultiplicity() == many)
+		if trace != nil {
+			trace(c, current, nil)
+		}
 	}
 	return current, nil
 }
@@ -171,6 +383,9 @@ type extractor interface {
 	extract(v Value, next *Value) error
 	extractForSet(Value, *Value, *func(Value)) error
 	resultMultiplicity() multiplicity
+
+	// String returns the representation of the extractor in a JSON Path.
+	String() string
 }
 
 // addMapAccess is called when we discover `foo` or `["bar"]`
@@ -226,6 +441,10 @@ func (a *mapValueExtractor) resultMultiplicity() multiplicity {
 	return one
 }
 
+func (a *mapValueExtractor) String() string {
+	return fmt.Sprintf(`["%v"]`, strings.Replace(a.key, `"`, `""`, -1))
+}
+
 // addRecursiveAccess is called when we discover `..foo` or `..["bar"]`
 // in a JSON Path string.
 func (j *jsonPeg) addRecursiveAccess(s string) {
@@ -307,6 +526,10 @@ func (a *recursiveExtractor) resultMultiplicity() multiplicity {
 	return many
 }
 
+func (a *recursiveExtractor) String() string {
+	return fmt.Sprintf(`..["%v"]`, strings.Replace(a.key, `"`, `""`, -1))
+}
+
 // addArrayAccess is called when we discover `[1]` in a JSON Path
 // string.
 func (j *jsonPeg) addArrayAccess(s string) {
@@ -382,6 +605,10 @@ func (a *arrayElementExtractor) resultMultiplicity() multiplicity {
 	return one
 }
 
+func (a *arrayElementExtractor) String() string {
+	return fmt.Sprintf("[%v]", a.idx)
+}
+
 // addArraySlice is called when we discover `[1:3]` or `[1:3:2]` in a
 // JSON Path string.
 func (j *jsonPeg) addArraySlice(s string) {
@@ -524,3 +751,18 @@ func (a *arraySliceExtractor) extractForSet(v Value, next *Value, setInParent *f
 func (a *arraySliceExtractor) resultMultiplicity() multiplicity {
 	return many
 }
+
+func (a *arraySliceExtractor) String() string {
+	s := "["
+	if a.startSet {
+		s += strconv.Itoa(a.start)
+	}
+	s += ":"
+	if a.endSet {
+		s += strconv.Itoa(a.end)
+	}
+	if a.stepSet {
+		s += ":" + strconv.Itoa(a.step)
+	}
+	return s + "]"
+}
@@ -0,0 +1,147 @@
+package data
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestTracePath(t *testing.T) {
+	Convey("Given a nested map", t, func() {
+		m := Map{
+			"a": Map{
+				"b": Array{
+					Map{"c": Int(1), "d": Map{"c": Int(2)}},
+					Map{"c": Int(3)},
+					Map{"c": Int(4)},
+				},
+				`x"y`: String("quoted"),
+			},
+		}
+
+		Convey("When tracing a path accessing a map and an array", func() {
+			steps, err := TracePath(MustCompilePath(`a.b[-1].c`), m)
+			So(err, ShouldBeNil)
+
+			Convey("Then it should return each step", func() {
+				So(len(steps), ShouldEqual, 4)
+				So(steps[0].Extractor, ShouldEqual, `["a"]`)
+				So(steps[1].Extractor, ShouldEqual, `["b"]`)
+				So(steps[2].Extractor, ShouldEqual, `[-1]`)
+				So(steps[2].Value, ShouldResemble, Map{"c": Int(4)})
+				So(steps[3].Extractor, ShouldEqual, `["c"]`)
+				So(steps[3].Value, ShouldEqual, Int(4))
+				So(steps[3].Err, ShouldBeNil)
+			})
+		})
+
+		Convey("When tracing a path having a slice", func() {
+			steps, err := TracePath(MustCompilePath(`a.b[:2].c`), m)
+			So(err, ShouldBeNil)
+
+			Convey("Then the step of the slice shouldn't be modified by the subsequent step", func() {
+				So(len(steps), ShouldEqual, 4)
+				So(steps[2].Extractor, ShouldEqual, `[:2]`)
+				So(steps[2].Value, ShouldResemble, Array{
+					Map{"c": Int(1), "d": Map{"c": Int(2)}},
+					Map{"c": Int(3)},
+				})
+				So(steps[3].Value, ShouldResemble, Array{Int(1), Int(3)})
+			})
+		})
+
+		Convey("When tracing a path having a recursive access and a quoted key", func() {
+			steps, err := TracePath(MustCompilePath(`a..c`), m)
+			So(err, ShouldBeNil)
+			qsteps, err := TracePath(MustCompilePath(`a["x""y"]`), m)
+			So(err, ShouldBeNil)
+
+			Convey("Then extractors should be canonical", func() {
+				So(steps[1].Extractor, ShouldEqual, `..["c"]`)
+				So(len(steps[1].Value.(Array)), ShouldEqual, 4)
+				So(qsteps[1].Extractor, ShouldEqual, `["x""y"]`)
+				So(qsteps[1].Value, ShouldEqual, String("quoted"))
+			})
+		})
+
+		Convey("When tracing a path failing in the middle", func() {
+			steps, err := TracePath(MustCompilePath(`a.b[5].c`), m)
+			So(err, ShouldBeNil)
+
+			Convey("Then the last step should have the error", func() {
+				So(len(steps), ShouldEqual, 3)
+				So(steps[1].Err, ShouldBeNil)
+				So(steps[2].Extractor, ShouldEqual, `[5]`)
+				So(steps[2].Value, ShouldBeNil)
+				So(steps[2].Err, ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+func TestCompilePathCache(t *testing.T) {
+	Convey("Given the path cache with a small size", t, func() {
+		SetPathCacheSize(2)
+		Reset(func() {
+			SetPathCacheSize(DefaultPathCacheSize)
+		})
+		m := Map{"a": Map{"b": Int(1)}, "c": Int(2), "d": Int(3)}
+
+		Convey("When compiling the same path twice", func() {
+			p1, err := CompilePath("a.b")
+			So(err, ShouldBeNil)
+			p2, err := CompilePath("a.b")
+			So(err, ShouldBeNil)
+
+			Convey("Then the cached path should be returned", func() {
+				So(p2, ShouldEqual, p1)
+			})
+
+			Convey("Then the path should be evaluated correctly", func() {
+				v, err := m.Get(p2)
+				So(err, ShouldBeNil)
+				So(v, ShouldEqual, Int(1))
+				So(p2.(*jsonPeg).String(), ShouldEqual, "a.b")
+			})
+		})
+
+		Convey("When compiling more paths than the size", func() {
+			pa := MustCompilePath("a.b")
+			MustCompilePath("c")
+			MustCompilePath("a.b") // a.b is now the most recently used one
+			MustCompilePath("d")
+
+			Convey("Then the least recently used path should be evicted", func() {
+				So(pathCache.len(), ShouldEqual, 2)
+				So(MustCompilePath("a.b"), ShouldEqual, pa)
+				So(pathCache.get("c"), ShouldBeNil)
+			})
+		})
+
+		Convey("When compiling an invalid path", func() {
+			_, err := CompilePath("a..")
+			So(err, ShouldNotBeNil)
+
+			Convey("Then it shouldn't be cached", func() {
+				So(pathCache.get("a.."), ShouldBeNil)
+				_, err := CompilePath("a..")
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When disabling the cache", func() {
+			MustCompilePath("c")
+			SetPathCacheSize(0)
+
+			Convey("Then cached paths should be removed", func() {
+				So(pathCache.len(), ShouldEqual, 0)
+			})
+
+			Convey("Then paths shouldn't be cached", func() {
+				p := MustCompilePath("c")
+				So(MustCompilePath("c"), ShouldNotEqual, p)
+				So(pathCache.len(), ShouldEqual, 0)
+			})
+		})
+	})
+}
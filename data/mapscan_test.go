@@ -1110,3 +1110,46 @@ func BenchmarkMapAccess(b *testing.B) {
 		}
 	}
 }
+
+func TestSplitPath(t *testing.T) {
+	m := Map{
+		"a": Map{
+			"b": Array{Map{`x"y`: Int(1)}},
+		},
+	}
+
+	Convey("Given a path only having map and array accesses", t, func() {
+		p := MustCompilePath(`a.b[0]["x""y"]`)
+
+		Convey("When splitting it", func() {
+			ps, ok := SplitPath(p)
+
+			Convey("Then it should be split at map accesses", func() {
+				So(ok, ShouldBeTrue)
+				So(ps, ShouldHaveLength, 3)
+				So(fmt.Sprint(ps), ShouldEqual, `[["a"] ["b"][0] ["x""y"]]`)
+			})
+
+			Convey("Then getting values with them should be the same as the original path", func() {
+				var v Value = m
+				for _, sp := range ps {
+					mv, err := AsMap(v)
+					So(err, ShouldBeNil)
+					v, err = mv.Get(sp)
+					So(err, ShouldBeNil)
+				}
+				So(v, ShouldEqual, Int(1))
+			})
+		})
+	})
+
+	Convey("Given paths having slices or recursive accesses", t, func() {
+		for _, s := range []string{"a.b[:]", "a..b"} {
+			_, ok := SplitPath(MustCompilePath(s))
+
+			Convey("Then they shouldn't be split: "+s, func() {
+				So(ok, ShouldBeFalse)
+			})
+		}
+	})
+}
@@ -0,0 +1,108 @@
+package textformat
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// DecodeKeyValue decodes key=value pairs separated by spaces or commas such
+// as
+//
+//	id=dev1 temp=21.5 humidity=40 door_open=false msg="battery low"
+//
+// A value is converted to a typed value as follows:
+//
+//	- a double-quoted string: a string (Go's escape sequences are supported)
+//	- true or false: a bool
+//	- an integer: an int
+//	- a floating point number: a float
+//	- an empty value: NULL
+//	- otherwise: a string as it is
+//
+// A key must not be empty. When the same key appears more than once, the
+// last value is used.
+func DecodeKeyValue(line []byte) (data.Map, error) {
+	m := data.Map{}
+	s := strings.TrimSpace(string(line))
+	isSep := func(r rune) bool {
+		return unicode.IsSpace(r) || r == ','
+	}
+
+	for s != "" {
+		eq := strings.IndexByte(s, '=')
+		if eq < 0 {
+			return nil, fmt.Errorf("a pair doesn't have '=': %v", s)
+		}
+		key := s[:eq]
+		if key == "" || strings.IndexFunc(key, isSep) >= 0 {
+			return nil, fmt.Errorf("invalid key: %v", key)
+		}
+		s = s[eq+1:]
+
+		var raw string
+		if strings.HasPrefix(s, `"`) {
+			// find the closing quote which isn't escaped
+			end := -1
+			for i := 1; i < len(s); i++ {
+				if s[i] == '\\' {
+					i++
+				} else if s[i] == '"' {
+					end = i + 1
+					break
+				}
+			}
+			if end < 0 {
+				return nil, fmt.Errorf("the value of %v doesn't have a closing quote", key)
+			}
+			raw = s[:end]
+			if end < len(s) && !isSep(rune(s[end])) {
+				return nil, fmt.Errorf("the value of %v has extra characters after the quote", key)
+			}
+		} else if end := strings.IndexFunc(s, isSep); end >= 0 {
+			raw = s[:end]
+		} else {
+			raw = s
+		}
+		v, err := parseKeyValueValue(raw)
+		if err != nil {
+			return nil, fmt.Errorf("the value of %v is invalid: %v", key, err)
+		}
+		m[key] = v
+		s = strings.TrimLeftFunc(s[len(raw):], isSep)
+	}
+	return m, nil
+}
+
+func parseKeyValueValue(s string) (data.Value, error) {
+	switch s {
+	case "":
+		return data.Null{}, nil
+	case "true":
+		return data.True, nil
+	case "false":
+		return data.False, nil
+	}
+	if s[0] == '"' {
+		str, err := strconv.Unquote(s)
+		if err != nil {
+			return nil, err
+		}
+		return data.String(str), nil
+	}
+	// strconv.ParseFloat accepts words like "inf" or "nan", which should be
+	// strings here
+	if !strings.ContainsRune("+-.0123456789", rune(s[0])) {
+		return data.String(s), nil
+	}
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return data.Int(i), nil
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return data.Float(f), nil
+	}
+	return data.String(s), nil
+}
@@ -0,0 +1,82 @@
+package textformat
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+func TestDecodeKeyValue(t *testing.T) {
+	Convey("Given key=value lines", t, func() {
+		Convey("When decoding a line having various types of values", func() {
+			m, err := DecodeKeyValue([]byte(`id=dev1 temp=21.5, humidity=40 door_open=false msg="battery \"low\", 10%" empty= nan=nan`))
+			So(err, ShouldBeNil)
+
+			Convey("Then values should be typed", func() {
+				So(m, ShouldResemble, data.Map{
+					"id":        data.String("dev1"),
+					"temp":      data.Float(21.5),
+					"humidity":  data.Int(40),
+					"door_open": data.False,
+					"msg":       data.String(`battery "low", 10%`),
+					"empty":     data.Null{},
+					"nan":       data.String("nan"),
+				})
+			})
+		})
+
+		Convey("When decoding an empty line", func() {
+			m, err := DecodeKeyValue([]byte("  "))
+			So(err, ShouldBeNil)
+
+			Convey("Then it should be an empty map", func() {
+				So(m, ShouldBeEmpty)
+			})
+		})
+
+		for _, s := range []string{
+			"a=1 b",
+			"=1",
+			`a="x`,
+			`a="x"y`,
+		} {
+			s := s
+			Convey("When decoding an invalid line "+s, func() {
+				_, err := DecodeKeyValue([]byte(s))
+
+				Convey("Then it should fail", func() {
+					So(err, ShouldNotBeNil)
+				})
+			})
+		}
+	})
+}
+
+func TestNewDecoder(t *testing.T) {
+	Convey("Given format names", t, func() {
+		Convey("When creating a decoder for each format", func() {
+			Convey("Then it should decode a line in the format", func() {
+				for f, l := range map[string]string{
+					"":      `{"a":1}`,
+					"JSONL": `{"a":1}`,
+					"kv":    `a=1`,
+					"nmea":  `$GPXYZ,1,2`,
+				} {
+					d, err := NewDecoder(f)
+					So(err, ShouldBeNil)
+					_, err = d.Decode([]byte(l))
+					So(err, ShouldBeNil)
+				}
+			})
+		})
+
+		Convey("When creating a decoder for an unknown format", func() {
+			_, err := NewDecoder("csv")
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
@@ -0,0 +1,323 @@
+package textformat
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// nmeaSentenceParsers has parsers of sentences having typed fields. A key is
+// a sentence type without a talker ID.
+var nmeaSentenceParsers = map[string]func(m data.Map, fs []string) error{
+	"GGA": parseNMEAGGA,
+	"RMC": parseNMEARMC,
+	"GLL": parseNMEAGLL,
+	"VTG": parseNMEAVTG,
+	"GSA": parseNMEAGSA,
+}
+
+// DecodeNMEA decodes an NMEA 0183 sentence such as
+//
+//	$GPGGA,123519,4807.038,N,01131.000,E,1,08,0.9,545.4,M,46.9,M,,*47
+//
+// When the sentence has a checksum, it's validated. The result always has
+// following fields:
+//
+//	- talker: the talker ID such as "GP" ("P" for proprietary sentences)
+//	- sentence: the sentence type such as "GGA"
+//	- fields: an array of all fields in the sentence as strings
+//
+// GGA, RMC, GLL, VTG, and GSA sentences have typed fields in addition to
+// them. Latitudes and longitudes are converted to signed decimal degrees.
+// An empty field in a sentence results in NULL. See nmeaSentenceParsers and
+// the functions registered to it for the names of typed fields.
+func DecodeNMEA(line []byte) (data.Map, error) {
+	line = bytes.TrimSpace(line)
+	if len(line) == 0 || (line[0] != '$' && line[0] != '!') {
+		return nil, errors.New("an NMEA sentence must start with '$' or '!'")
+	}
+	body := line[1:]
+
+	if i := bytes.LastIndexByte(body, '*'); i >= 0 {
+		sum, err := strconv.ParseUint(string(body[i+1:]), 16, 8)
+		if err != nil || len(body)-i-1 != 2 {
+			return nil, fmt.Errorf("invalid checksum: %s", body[i+1:])
+		}
+		body = body[:i]
+
+		var c byte
+		for _, b := range body {
+			c ^= b
+		}
+		if byte(sum) != c {
+			return nil, fmt.Errorf("checksum mismatch: expected %02X but %02X was given", c, sum)
+		}
+	}
+
+	fs := strings.Split(string(body), ",")
+	addr := fs[0]
+	var talker, sentence string
+	switch {
+	case strings.HasPrefix(addr, "P") && len(addr) > 1:
+		talker, sentence = "P", addr[1:]
+	case len(addr) == 5:
+		talker, sentence = addr[:2], addr[2:]
+	default:
+		return nil, fmt.Errorf("invalid address field: %v", addr)
+	}
+	fs = fs[1:]
+
+	arr := make(data.Array, len(fs))
+	for i, f := range fs {
+		arr[i] = data.String(f)
+	}
+	m := data.Map{
+		"talker":   data.String(talker),
+		"sentence": data.String(sentence),
+		"fields":   arr,
+	}
+	if talker == "P" {
+		return m, nil
+	}
+	if p, ok := nmeaSentenceParsers[sentence]; ok {
+		if err := p(m, fs); err != nil {
+			return nil, fmt.Errorf("invalid %v sentence: %v", sentence, err)
+		}
+	}
+	return m, nil
+}
+
+// nmeaFields sets typed fields of a sentence to m. When fields have invalid
+// values, the first error is kept in err.
+type nmeaFields struct {
+	m   data.Map
+	fs  []string
+	err error
+}
+
+func (n *nmeaFields) get(i int) string {
+	if i >= len(n.fs) {
+		return ""
+	}
+	return n.fs[i]
+}
+
+func (n *nmeaFields) setError(name string, err error) {
+	if n.err == nil {
+		n.err = fmt.Errorf("%v: %v", name, err)
+	}
+}
+
+func (n *nmeaFields) setString(name string, i int) {
+	if s := n.get(i); s != "" {
+		n.m[name] = data.String(s)
+	} else {
+		n.m[name] = data.Null{}
+	}
+}
+
+func (n *nmeaFields) setInt(name string, i int) {
+	s := n.get(i)
+	if s == "" {
+		n.m[name] = data.Null{}
+		return
+	}
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		n.setError(name, err)
+		return
+	}
+	n.m[name] = data.Int(v)
+}
+
+func (n *nmeaFields) setFloat(name string, i int) {
+	s := n.get(i)
+	if s == "" {
+		n.m[name] = data.Null{}
+		return
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		n.setError(name, err)
+		return
+	}
+	n.m[name] = data.Float(v)
+}
+
+// setSignedFloat sets a float value whose sign is determined by a direction
+// field at j. neg is the direction meaning a negative value such as "W".
+func (n *nmeaFields) setSignedFloat(name string, i, j int, neg string) {
+	n.setFloat(name, i)
+	if v, ok := n.m[name].(data.Float); ok && n.get(j) == neg {
+		n.m[name] = -v
+	}
+}
+
+// setCoordinate sets a latitude or a longitude written in (d)ddmm.mmmm format
+// as signed decimal degrees. neg is "S" for a latitude and "W" for a
+// longitude.
+func (n *nmeaFields) setCoordinate(name string, i, j int, neg string) {
+	s := n.get(i)
+	if s == "" {
+		n.m[name] = data.Null{}
+		return
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		n.setError(name, err)
+		return
+	}
+	deg := float64(int64(v / 100))
+	deg += (v - deg*100) / 60
+	switch d := n.get(j); d {
+	case neg:
+		deg = -deg
+	case "N", "S", "E", "W":
+	default:
+		n.setError(name, fmt.Errorf("invalid direction: %v", d))
+		return
+	}
+	n.m[name] = data.Float(deg)
+}
+
+// setStatus sets true when the field is "A" (valid) and false otherwise.
+func (n *nmeaFields) setStatus(name string, i int) {
+	if s := n.get(i); s != "" {
+		n.m[name] = data.Bool(s == "A")
+	} else {
+		n.m[name] = data.Null{}
+	}
+}
+
+// parseNMEAGGA parses a GGA (fix data) sentence.
+func parseNMEAGGA(m data.Map, fs []string) error {
+	n := &nmeaFields{m: m, fs: fs}
+	n.setString("utc_time", 0)
+	n.setCoordinate("latitude", 1, 2, "S")
+	n.setCoordinate("longitude", 3, 4, "W")
+	n.setInt("fix_quality", 5)
+	n.setInt("num_satellites", 6)
+	n.setFloat("hdop", 7)
+	n.setFloat("altitude", 8)
+	n.setFloat("geoid_separation", 10)
+	n.setFloat("dgps_age", 12)
+	n.setString("dgps_station_id", 13)
+	return n.err
+}
+
+// parseNMEARMC parses an RMC (recommended minimum data) sentence. The time
+// and the date are combined into "timestamp".
+func parseNMEARMC(m data.Map, fs []string) error {
+	n := &nmeaFields{m: m, fs: fs}
+	n.setString("utc_time", 0)
+	n.setStatus("valid", 1)
+	n.setCoordinate("latitude", 2, 3, "S")
+	n.setCoordinate("longitude", 4, 5, "W")
+	n.setFloat("speed_knots", 6)
+	n.setFloat("course", 7)
+	n.setString("date", 8)
+	n.setSignedFloat("magnetic_variation", 9, 10, "W")
+	n.setString("mode", 11)
+	if n.err != nil {
+		return n.err
+	}
+
+	m["timestamp"] = data.Null{}
+	if t, d := n.get(0), n.get(8); t != "" && d != "" {
+		ts, err := parseNMEATime(d, t)
+		if err != nil {
+			return fmt.Errorf("timestamp: %v", err)
+		}
+		m["timestamp"] = data.Timestamp(ts)
+	}
+	return nil
+}
+
+// parseNMEAGLL parses a GLL (geographic position) sentence.
+func parseNMEAGLL(m data.Map, fs []string) error {
+	n := &nmeaFields{m: m, fs: fs}
+	n.setCoordinate("latitude", 0, 1, "S")
+	n.setCoordinate("longitude", 2, 3, "W")
+	n.setString("utc_time", 4)
+	n.setStatus("valid", 5)
+	n.setString("mode", 6)
+	return n.err
+}
+
+// parseNMEAVTG parses a VTG (track and ground speed) sentence.
+func parseNMEAVTG(m data.Map, fs []string) error {
+	n := &nmeaFields{m: m, fs: fs}
+	n.setFloat("course", 0)
+	n.setFloat("course_magnetic", 2)
+	n.setFloat("speed_knots", 4)
+	n.setFloat("speed_kmh", 6)
+	n.setString("mode", 8)
+	return n.err
+}
+
+// parseNMEAGSA parses a GSA (DOP and active satellites) sentence.
+func parseNMEAGSA(m data.Map, fs []string) error {
+	n := &nmeaFields{m: m, fs: fs}
+	n.setString("selection_mode", 0)
+	n.setInt("fix_type", 1)
+	n.setFloat("pdop", 14)
+	n.setFloat("hdop", 15)
+	n.setFloat("vdop", 16)
+
+	sats := data.Array{}
+	for i := 2; i < 14; i++ {
+		s := n.get(i)
+		if s == "" {
+			continue
+		}
+		v, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			n.setError("satellites", err)
+			break
+		}
+		sats = append(sats, data.Int(v))
+	}
+	m["satellites"] = sats
+	return n.err
+}
+
+// parseNMEATime parses a date in ddmmyy format and a time in hhmmss(.sss)
+// format as UTC.
+func parseNMEATime(d, t string) (time.Time, error) {
+	if len(d) != 6 || len(t) < 6 {
+		return time.Time{}, fmt.Errorf("invalid date or time: %v %v", d, t)
+	}
+	var (
+		nums [6]int
+		str  = d + t[:6]
+	)
+	for i := range nums {
+		v, err := strconv.Atoi(str[i*2 : i*2+2])
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid date or time: %v %v", d, t)
+		}
+		nums[i] = v
+	}
+
+	nsec := 0
+	if len(t) > 6 {
+		f, err := strconv.ParseFloat("0"+t[6:], 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid time: %v", t)
+		}
+		nsec = int(f * float64(time.Second))
+	}
+
+	// two-digit years are interpreted as 1980-2079 because GPS didn't exist
+	// before 1980
+	year := nums[2] + 2000
+	if nums[2] >= 80 {
+		year = nums[2] + 1900
+	}
+	return time.Date(year, time.Month(nums[1]), nums[0], nums[3], nums[4], nums[5], nsec, time.UTC), nil
+}
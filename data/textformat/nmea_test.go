@@ -0,0 +1,133 @@
+package textformat
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+func TestDecodeNMEA(t *testing.T) {
+	Convey("Given NMEA sentences", t, func() {
+		Convey("When decoding a GGA sentence", func() {
+			m, err := DecodeNMEA([]byte("$GPGGA,123519,4807.038,N,01131.000,E,1,08,0.9,545.4,M,46.9,M,,*47\r\n"))
+			So(err, ShouldBeNil)
+
+			Convey("Then it should have typed fields", func() {
+				So(m["talker"], ShouldEqual, data.String("GP"))
+				So(m["sentence"], ShouldEqual, data.String("GGA"))
+				So(m["fields"], ShouldHaveLength, 14)
+				So(m["utc_time"], ShouldEqual, data.String("123519"))
+				So(m["latitude"], ShouldAlmostEqual, 48.1173, 0.0001)
+				So(m["longitude"], ShouldAlmostEqual, 11.516667, 0.0001)
+				So(m["fix_quality"], ShouldEqual, data.Int(1))
+				So(m["num_satellites"], ShouldEqual, data.Int(8))
+				So(m["hdop"], ShouldEqual, data.Float(0.9))
+				So(m["altitude"], ShouldEqual, data.Float(545.4))
+				So(m["geoid_separation"], ShouldEqual, data.Float(46.9))
+				So(m["dgps_age"], ShouldResemble, data.Null{})
+				So(m["dgps_station_id"], ShouldResemble, data.Null{})
+			})
+		})
+
+		Convey("When decoding an RMC sentence", func() {
+			m, err := DecodeNMEA([]byte("$GPRMC,123519,A,4807.038,N,01131.000,E,022.4,084.4,230394,003.1,W*6A"))
+			So(err, ShouldBeNil)
+
+			Convey("Then it should have typed fields", func() {
+				So(m["valid"], ShouldEqual, data.True)
+				So(m["speed_knots"], ShouldEqual, data.Float(22.4))
+				So(m["course"], ShouldEqual, data.Float(84.4))
+				So(m["magnetic_variation"], ShouldEqual, data.Float(-3.1))
+				So(m["mode"], ShouldResemble, data.Null{})
+				So(m["timestamp"], ShouldResemble, data.Timestamp(time.Date(1994, 3, 23, 12, 35, 19, 0, time.UTC)))
+			})
+		})
+
+		Convey("When decoding a GLL sentence in the western hemisphere", func() {
+			m, err := DecodeNMEA([]byte("$GPGLL,4916.45,N,12311.12,W,225444,A,*1D"))
+			So(err, ShouldBeNil)
+
+			Convey("Then the longitude should be negative", func() {
+				So(m["latitude"], ShouldAlmostEqual, 49.274167, 0.0001)
+				So(m["longitude"], ShouldAlmostEqual, -123.185333, 0.0001)
+				So(m["valid"], ShouldEqual, data.True)
+			})
+		})
+
+		Convey("When decoding a VTG sentence", func() {
+			m, err := DecodeNMEA([]byte("$GPVTG,054.7,T,034.4,M,005.5,N,010.2,K*48"))
+			So(err, ShouldBeNil)
+
+			Convey("Then it should have typed fields", func() {
+				So(m["course"], ShouldEqual, data.Float(54.7))
+				So(m["course_magnetic"], ShouldEqual, data.Float(34.4))
+				So(m["speed_knots"], ShouldEqual, data.Float(5.5))
+				So(m["speed_kmh"], ShouldEqual, data.Float(10.2))
+			})
+		})
+
+		Convey("When decoding a GSA sentence", func() {
+			m, err := DecodeNMEA([]byte("$GPGSA,A,3,04,05,,09,12,,,24,,,,,2.5,1.3,2.1*39"))
+			So(err, ShouldBeNil)
+
+			Convey("Then it should have typed fields", func() {
+				So(m["selection_mode"], ShouldEqual, data.String("A"))
+				So(m["fix_type"], ShouldEqual, data.Int(3))
+				So(m["satellites"], ShouldResemble, data.Array{data.Int(4), data.Int(5), data.Int(9), data.Int(12), data.Int(24)})
+				So(m["pdop"], ShouldEqual, data.Float(2.5))
+				So(m["hdop"], ShouldEqual, data.Float(1.3))
+				So(m["vdop"], ShouldEqual, data.Float(2.1))
+			})
+		})
+
+		Convey("When decoding a proprietary sentence", func() {
+			m, err := DecodeNMEA([]byte("$PGRME,15.0,M,45.0,M,25.0,M*1C"))
+			So(err, ShouldBeNil)
+
+			Convey("Then it should only have raw fields", func() {
+				So(m, ShouldResemble, data.Map{
+					"talker":   data.String("P"),
+					"sentence": data.String("GRME"),
+					"fields": data.Array{data.String("15.0"), data.String("M"), data.String("45.0"),
+						data.String("M"), data.String("25.0"), data.String("M")},
+				})
+			})
+		})
+
+		Convey("When decoding an unknown sentence without a checksum", func() {
+			m, err := DecodeNMEA([]byte("$GPXYZ,1,2"))
+			So(err, ShouldBeNil)
+
+			Convey("Then it should only have raw fields", func() {
+				So(m, ShouldResemble, data.Map{
+					"talker":   data.String("GP"),
+					"sentence": data.String("XYZ"),
+					"fields":   data.Array{data.String("1"), data.String("2")},
+				})
+			})
+		})
+
+		for _, s := range []string{
+			"",
+			"GPGGA,123519",
+			"$GPXYZ,1,2*4E",  // wrong checksum
+			"$GPXYZ,1,2*4",   // too short checksum
+			"$GPXYZ,1,2*XY",  // invalid checksum
+			"$GPXYZW,1,2",    // invalid address
+			"$GPGGA,1,x,N,,", // invalid latitude
+			"$GPGGA,1,4807.038,X,,",
+			"$GPRMC,123519,A,,,,,,,2303,,",
+		} {
+			s := s
+			Convey("When decoding an invalid sentence "+s, func() {
+				_, err := DecodeNMEA([]byte(s))
+
+				Convey("Then it should fail", func() {
+					So(err, ShouldNotBeNil)
+				})
+			})
+		}
+	})
+}
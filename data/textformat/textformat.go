@@ -0,0 +1,61 @@
+/*
+Package textformat decodes a line of text written in a common sensor data
+protocol into a data.Map. Supported formats are:
+
+	- jsonl: a JSON object in a line
+	- nmea: an NMEA 0183 sentence, see DecodeNMEA
+	- kv: key=value pairs, see DecodeKeyValue
+
+Sources reading text lines, such as the file source, can use NewDecoder to
+support these formats with their "format" parameter.
+*/
+package textformat
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// Decoder decodes a line of text into a data.Map.
+type Decoder interface {
+	// Decode decodes a line. The line doesn't have a trailing newline.
+	Decode(line []byte) (data.Map, error)
+}
+
+// DecoderFunc is a function which implements Decoder.
+type DecoderFunc func(line []byte) (data.Map, error)
+
+// Decode decodes a line by calling the function itself.
+func (f DecoderFunc) Decode(line []byte) (data.Map, error) {
+	return f(line)
+}
+
+// NewDecoder returns a Decoder for the given format name. The name is case
+// insensitive and an empty name means "jsonl".
+func NewDecoder(format string) (Decoder, error) {
+	switch strings.ToLower(format) {
+	case "", "jsonl":
+		return DecoderFunc(DecodeJSON), nil
+	case "nmea":
+		return DecoderFunc(DecodeNMEA), nil
+	case "kv":
+		return DecoderFunc(DecodeKeyValue), nil
+	default:
+		return nil, fmt.Errorf("unsupported format: %v", format)
+	}
+}
+
+// DecodeJSON decodes a JSON object with data.DefaultJSONLimits.
+func DecodeJSON(line []byte) (data.Map, error) {
+	v, err := data.DecodeJSON(line, data.DefaultJSONLimits)
+	if err != nil {
+		return nil, err
+	}
+	m, err := data.AsMap(v)
+	if err != nil {
+		return nil, fmt.Errorf("the line isn't a JSON object: %v", err)
+	}
+	return m, nil
+}
@@ -140,7 +140,7 @@ func ToBool(v Value) (bool, error) {
 		return len(val) > 0, nil
 	default:
 		return defaultValue,
-			fmt.Errorf("cannot convert %T to bool", v)
+			conversionError(v, TypeBool, "bool")
 	}
 }
 
@@ -188,7 +188,7 @@ func ToInt(v Value) (int64, error) {
 		return int64(seconds), nil
 	default:
 		return defaultValue,
-			fmt.Errorf("cannot convert %T to int64", v)
+			conversionError(v, TypeInt, "int64")
 	}
 }
 
@@ -234,7 +234,7 @@ func ToFloat(v Value) (float64, error) {
 		return float64(val.Unix()) + float64(val.Nanosecond())/1e9, nil
 	default:
 		return defaultValue,
-			fmt.Errorf("cannot convert %T to float64", v)
+			conversionError(v, TypeFloat, "float64")
 	}
 }
 
@@ -298,7 +298,7 @@ func ToBlob(v Value) ([]byte, error) {
 		}
 		return b, nil
 	default:
-		return nil, fmt.Errorf("cannot convert %T to Blob", v)
+		return nil, conversionError(v, TypeBlob, "Blob")
 	}
 }
 
@@ -339,10 +339,44 @@ func ToTimestamp(v Value) (time.Time, error) {
 		return v.asTimestamp()
 	default:
 		return defaultValue,
-			fmt.Errorf("cannot convert %T to Time", v)
+			conversionError(v, TypeTimestamp, "Time")
 	}
 }
 
+// localTimestampLayouts are layouts of strings without time zones accepted
+// by ToTimestampIn.
+var localTimestampLayouts = []string{
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// ToTimestampIn converts a given Value to a time.Time struct in the same way
+// as ToTimestamp except that a String without a time zone is interpreted as a
+// local time in loc. Following formats are accepted in addition to
+// RFC3339/ISO8601:
+//
+//  * 2006-01-02T15:04:05 (with an optional fraction of a second)
+//  * 2006-01-02 15:04:05 (with an optional fraction of a second)
+//  * 2006-01-02T15:04
+//  * 2006-01-02 15:04
+//  * 2006-01-02
+func ToTimestampIn(v Value, loc *time.Location) (time.Time, error) {
+	t, err := ToTimestamp(v)
+	if err == nil || v.Type() != TypeString {
+		return t, err
+	}
+	val, _ := v.asString()
+	for _, l := range localTimestampLayouts {
+		if lt, e := time.ParseInLocation(l, val, loc); e == nil {
+			return lt, nil
+		}
+	}
+	return t, err
+}
+
 // ToDuration converts a Value to time.Duration, if possible.
 // The conversion rules are as follows:
 //
@@ -366,6 +400,6 @@ func ToDuration(v Value) (time.Duration, error) {
 		s, _ := v.asString()
 		return time.ParseDuration(s)
 	default:
-		return 0, fmt.Errorf("cannot convert %T to Duration", v)
+		return 0, conversionError(v, typeUnknown, "Duration")
 	}
 }
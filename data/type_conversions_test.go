@@ -387,6 +387,69 @@ func TestToTimestamp(t *testing.T) {
 	runConversionTestCases(t, toFun, "ToTimestamp", testCases)
 }
 
+func TestToTimestampIn(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*60*60)
+
+	Convey("Given a time zone", t, func() {
+		Convey("When converting a string without a time zone", func() {
+			for i, s := range []string{"1970-01-01T09:00:02", "1970-01-01 09:00:02", "1970-01-01T09:00:02.000"} {
+				ts, err := ToTimestampIn(String(s), tokyo)
+
+				Convey(fmt.Sprintf("Then it should be a local time in the zone: %v (%v)", s, i), func() {
+					So(err, ShouldBeNil)
+					So(ts.Equal(time.Unix(2, 0)), ShouldBeTrue)
+				})
+			}
+		})
+
+		Convey("When converting a string without seconds", func() {
+			for _, s := range []string{"1970-01-01T09:01", "1970-01-01 09:01"} {
+				ts, err := ToTimestampIn(String(s), tokyo)
+
+				Convey("Then it should be a local time in the zone: "+s, func() {
+					So(err, ShouldBeNil)
+					So(ts.Equal(time.Unix(60, 0)), ShouldBeTrue)
+				})
+			}
+		})
+
+		Convey("When converting a date", func() {
+			ts, err := ToTimestampIn(String("1970-01-02"), tokyo)
+
+			Convey("Then it should be the midnight in the zone", func() {
+				So(err, ShouldBeNil)
+				So(ts.Equal(time.Unix(15*60*60, 0)), ShouldBeTrue)
+			})
+		})
+
+		Convey("When converting a string with a time zone", func() {
+			ts, err := ToTimestampIn(String("1970-01-01T00:00:02Z"), tokyo)
+
+			Convey("Then the time zone of the string should be used", func() {
+				So(err, ShouldBeNil)
+				So(ts.Equal(time.Unix(2, 0)), ShouldBeTrue)
+			})
+		})
+
+		Convey("When converting other values", func() {
+			ts, err := ToTimestampIn(Int(2), tokyo)
+
+			Convey("Then it should be the same as ToTimestamp", func() {
+				So(err, ShouldBeNil)
+				So(ts.Equal(time.Unix(2, 0)), ShouldBeTrue)
+			})
+		})
+
+		Convey("When converting an invalid string", func() {
+			_, err := ToTimestampIn(String("yesterday"), tokyo)
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
+
 func TestToDuration(t *testing.T) {
 	testCases := map[string][]convTestInput{
 		"Null": {
@@ -523,3 +586,24 @@ func TestAsTypeWithNil(t *testing.T) {
 		})
 	})
 }
+
+func TestTypeMismatchError(t *testing.T) {
+	Convey("Given a value which cannot be converted to an int", t, func() {
+		v := Map{}
+
+		Convey("When converting it", func() {
+			_, err1 := AsInt(v)
+			_, err2 := ToInt(v)
+
+			Convey("Then the errors should be TypeMismatchError", func() {
+				for _, err := range []error{err1, err2} {
+					e, ok := err.(*TypeMismatchError)
+					So(ok, ShouldBeTrue)
+					So(e.From, ShouldEqual, TypeMap)
+					So(e.To, ShouldEqual, TypeInt)
+					So(e.Code(), ShouldEqual, "type_mismatch")
+				}
+			})
+		})
+	})
+}
@@ -38,8 +38,43 @@ type Value interface {
 	String() string
 }
 
+// TypeMismatchError is returned when a value cannot be used as a value of
+// another type.
+type TypeMismatchError struct {
+	// From is the type of the value.
+	From TypeID
+
+	// To is the required type. It's an unknown type when the value was
+	// required to be something other than Value such as time.Duration.
+	To TypeID
+
+	msg string
+}
+
+func (e *TypeMismatchError) Error() string {
+	return e.msg
+}
+
+// Code returns "type_mismatch", which is the same as
+// core.ErrCodeTypeMismatch.
+func (e *TypeMismatchError) Code() string {
+	return "type_mismatch"
+}
+
 func castError(from TypeID, to TypeID) error {
-	return fmt.Errorf("unsupported cast %v from %v", to.String(), from.String())
+	return &TypeMismatchError{
+		From: from,
+		To:   to,
+		msg:  fmt.Sprintf("unsupported cast %v from %v", to.String(), from.String()),
+	}
+}
+
+func conversionError(v Value, to TypeID, name string) error {
+	return &TypeMismatchError{
+		From: v.Type(),
+		To:   to,
+		msg:  fmt.Sprintf("cannot convert %T to %v", v, name),
+	}
 }
 
 // TypeID is an ID of a type. A unique value is assigned to each type.
@@ -113,6 +148,7 @@ func UnmarshalMsgpack(b []byte) (Map, error) {
 
 // NewMap returns a Map object from map[string]interface{}.
 // Returns an error when value type is not supported in SensorBee.
+// Keys of the returned Map are interned by InternKey.
 //
 // Example:
 // The following sample interface{} will be converted to mapSample Map.
@@ -152,13 +188,13 @@ func UnmarshalMsgpack(b []byte) (Map, error) {
 //  }
 //
 func NewMap(m map[string]interface{}) (Map, error) {
-	result := Map{}
+	result := make(Map, len(m))
 	for k, v := range m {
 		value, err := NewValue(v)
 		if err != nil {
 			return nil, err
 		}
-		result[k] = value
+		result[InternKey(k)] = value
 	}
 	return result, nil
 }
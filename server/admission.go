@@ -0,0 +1,117 @@
+package server
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"gopkg.in/sensorbee/sensorbee.v0/server/config"
+)
+
+// admissionController reserves resources declared by topologies and rejects
+// topologies which would exceed the limits of the server. All methods can be
+// called on nil, which doesn't limit anything.
+type admissionController struct {
+	m        sync.Mutex
+	conf     config.Admission
+	reserved map[string]config.Resources
+}
+
+func newAdmissionController(conf *config.Admission) *admissionController {
+	a := &admissionController{
+		reserved: map[string]config.Resources{},
+	}
+	if conf != nil {
+		a.conf = *conf
+	}
+	return a
+}
+
+// resourceViolation describes a limit which a topology would exceed.
+type resourceViolation struct {
+	Resource  string `json:"resource"`
+	Requested int64  `json:"requested"`
+	Used      int64  `json:"used"`
+	Limit     int64  `json:"limit"`
+}
+
+// admissionError is returned when a topology doesn't declare a required
+// resource or would exceed limits.
+type admissionError struct {
+	// Undeclared has names of resources which must be declared.
+	Undeclared []string
+
+	Violations []*resourceViolation
+}
+
+func (e *admissionError) Error() string {
+	var msgs []string
+	for _, r := range e.Undeclared {
+		msgs = append(msgs, fmt.Sprintf("%v must be declared", r))
+	}
+	for _, v := range e.Violations {
+		msgs = append(msgs, fmt.Sprintf("%v exceeds the limit: %v requested, %v used, %v limit",
+			v.Resource, v.Requested, v.Used, v.Limit))
+	}
+	return strings.Join(msgs, ", ")
+}
+
+// admit reserves resources for the topology. It returns *admissionError
+// when the topology cannot be admitted, and os.ErrExist when the name is
+// already reserved.
+func (a *admissionController) admit(name string, r config.Resources) error {
+	if a == nil {
+		return nil
+	}
+	a.m.Lock()
+	defer a.m.Unlock()
+
+	n := strings.ToLower(name)
+	if _, ok := a.reserved[n]; ok {
+		return os.ErrExist
+	}
+
+	var used config.Resources
+	for _, res := range a.reserved {
+		used.MaxNodes += res.MaxNodes
+		used.MaxMemory += res.MaxMemory
+	}
+
+	e := &admissionError{}
+	check := func(resource string, requested, used, limit int64, countable bool) {
+		if limit <= 0 {
+			return
+		}
+		if !countable && requested <= 0 {
+			e.Undeclared = append(e.Undeclared, resource)
+			return
+		}
+		if used+requested > limit {
+			e.Violations = append(e.Violations, &resourceViolation{
+				Resource:  resource,
+				Requested: requested,
+				Used:      used,
+				Limit:     limit,
+			})
+		}
+	}
+	check("topologies", 1, int64(len(a.reserved)), int64(a.conf.MaxTopologies), true)
+	check("max_nodes", int64(r.MaxNodes), int64(used.MaxNodes), int64(a.conf.MaxNodes), false)
+	check("max_memory", r.MaxMemory, used.MaxMemory, a.conf.MaxMemory, false)
+	if len(e.Undeclared) > 0 || len(e.Violations) > 0 {
+		return e
+	}
+	a.reserved[n] = r
+	return nil
+}
+
+// release releases resources reserved for the topology.
+func (a *admissionController) release(name string) {
+	if a == nil {
+		return
+	}
+	a.m.Lock()
+	defer a.m.Unlock()
+	delete(a.reserved, strings.ToLower(name))
+}
@@ -9,15 +9,45 @@ type APIContext struct {
 	*Context
 }
 
+// apiVersions are versions of the API served by the server. Each version is
+// served at "/api/{version}". All versions have the same routes and only
+// differ in formats of responses:
+//
+//	- v1: the original version
+//	- v2: errors have structured error codes. See v2ErrorCode for details
+var apiVersions = []string{"v1", "v2"}
+
 // SetUpAPIRouter sets up a router for APIs with user defined custom route.
-// Subrouters needs to have APIContext as their first field.
+// Subrouters needs to have APIContext as their first field. route is called
+// once for each version of the API with the router of the version such as
+// "/api/v1".
+//
+// The OpenAPI document of routes registered by the server is served at
+// "/api/openapi.json". It doesn't contain routes added by route.
 func SetUpAPIRouter(prefix string, router *web.Router, route func(prefix string, r *web.Router)) {
-	root := router.Subrouter(APIContext{}, "/api/v1")
+	doc := newOpenAPIDocument(prefix, apiVersions)
+	for i, v := range apiVersions {
+		version := i + 1
+		r := router.Subrouter(APIContext{}, "/api/"+v)
+		r.Middleware(func(c *APIContext, rw web.ResponseWriter, req *web.Request, next web.NextMiddlewareFunc) {
+			c.apiVersion = version
+			next(rw, req)
+		})
+		root := newAPIRouter(r, doc)
 
-	setUpTopologiesRouter(prefix, root)
-	setUpServerStatusRouter(prefix, root)
+		setUpTopologiesRouter(prefix, root)
+		setUpServerStatusRouter(prefix, root)
+		setUpMetricsRouter(prefix, root)
+		setUpJSONPathRouter(prefix, root)
+		setUpReplicationRouter(prefix, root)
 
-	if route != nil {
-		route(prefix, root)
+		if route != nil {
+			route(prefix, r)
+		}
 	}
+
+	router.Subrouter(APIContext{}, "/api").Get("/openapi.json",
+		func(c *APIContext, rw web.ResponseWriter, req *web.Request) {
+			c.Render(doc)
+		})
 }
@@ -0,0 +1,174 @@
+package server
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gocraft/web"
+	"github.com/sirupsen/logrus"
+)
+
+// auditEntry is a record of an operation which modified a topology.
+type auditEntry struct {
+	// Version is the version of the topology after the operation.
+	Version int64 `json:"version"`
+
+	Time       time.Time `json:"time"`
+	User       string    `json:"user,omitempty"`
+	RemoteAddr string    `json:"remote_addr,omitempty"`
+
+	// Operation is one of "create", "queries", "apply", "reload_function",
+	// and "destroy".
+	Operation  string   `json:"operation"`
+	Statements []string `json:"statements,omitempty"`
+
+	// Functions has the new versions of functions reloaded by the
+	// operation. The key is the name of a function in lower case.
+	Functions map[string]int64 `json:"functions,omitempty"`
+
+	// Error is set when the operation failed after it might have modified
+	// the topology.
+	Error string `json:"error,omitempty"`
+}
+
+// auditActor identifies who performed an operation.
+type auditActor struct {
+	User       string
+	RemoteAddr string
+}
+
+// newAuditActor extracts the actor from a request. The user is taken from
+// the basic authentication or X-Remote-User header set by a proxy in front
+// of the server. The server doesn't authenticate the user by itself.
+func newAuditActor(req *web.Request) auditActor {
+	user, _, ok := req.BasicAuth()
+	if !ok {
+		user = req.Header.Get("X-Remote-User")
+	}
+	return auditActor{
+		User:       user,
+		RemoteAddr: req.RemoteAddr,
+	}
+}
+
+type topologyAuditLog struct {
+	version int64
+	entries []*auditEntry
+}
+
+// auditLog is an append-only log of operations which modified topologies.
+// It also manages versions of topologies, which are incremented by each
+// operation. A log of a topology is kept after the topology is destroyed so
+// that a topology created again with the same name has greater versions.
+// The log isn't persisted and it's lost when the server stops. All methods
+// can be called on nil, which doesn't record anything.
+type auditLog struct {
+	m          sync.RWMutex
+	logger     *logrus.Logger
+	topologies map[string]*topologyAuditLog
+}
+
+func newAuditLog(logger *logrus.Logger) *auditLog {
+	return &auditLog{
+		logger:     logger,
+		topologies: map[string]*topologyAuditLog{},
+	}
+}
+
+// record appends an entry of the operation to the log of the topology and
+// increments its version. err is the error which the operation returned.
+// The entry is also written to the server log.
+func (a *auditLog) record(name string, actor auditActor, op string, stmts []string, err error) *auditEntry {
+	return a.recordFunctions(name, actor, op, stmts, nil, err)
+}
+
+// recordFunctions is like record but also records the new versions of
+// functions reloaded by the operation. funcs can be nil.
+func (a *auditLog) recordFunctions(name string, actor auditActor, op string, stmts []string,
+	funcs map[string]int64, err error) *auditEntry {
+	if a == nil {
+		return nil
+	}
+	a.m.Lock()
+	defer a.m.Unlock()
+
+	n := strings.ToLower(name)
+	l, ok := a.topologies[n]
+	if !ok {
+		l = &topologyAuditLog{}
+		a.topologies[n] = l
+	}
+	l.version++
+	e := &auditEntry{
+		Version:    l.version,
+		Time:       time.Now().In(time.UTC),
+		User:       actor.User,
+		RemoteAddr: actor.RemoteAddr,
+		Operation:  op,
+		Statements: stmts,
+	}
+	if len(funcs) > 0 {
+		e.Functions = funcs
+	}
+	if err != nil {
+		e.Error = err.Error()
+	}
+	l.entries = append(l.entries, e)
+
+	if a.logger != nil {
+		a.logger.WithFields(logrus.Fields{
+			"topology":    name,
+			"version":     e.Version,
+			"user":        e.User,
+			"remote_addr": e.RemoteAddr,
+			"operation":   e.Operation,
+			"statements":  e.Statements,
+			"functions":   e.Functions,
+			"audit":       true,
+		}).Info("The topology was modified")
+	}
+	return e
+}
+
+// version returns the current version of the topology. It returns 0 when
+// nothing has been recorded for the topology.
+func (a *auditLog) version(name string) int64 {
+	if a == nil {
+		return 0
+	}
+	a.m.RLock()
+	defer a.m.RUnlock()
+	if l, ok := a.topologies[strings.ToLower(name)]; ok {
+		return l.version
+	}
+	return 0
+}
+
+// entries returns entries of the topology whose versions are greater than
+// since in the order they were recorded. At most limit entries are returned
+// when limit is positive. It also returns the current version of the
+// topology, and false when nothing has been recorded for the topology.
+func (a *auditLog) entries(name string, since int64, limit int) ([]*auditEntry, int64, bool) {
+	if a == nil {
+		return nil, 0, false
+	}
+	a.m.RLock()
+	defer a.m.RUnlock()
+	l, ok := a.topologies[strings.ToLower(name)]
+	if !ok {
+		return nil, 0, false
+	}
+
+	res := []*auditEntry{}
+	for _, e := range l.entries {
+		if e.Version <= since {
+			continue
+		}
+		if limit > 0 && len(res) >= limit {
+			break
+		}
+		res = append(res, e)
+	}
+	return res, l.version, true
+}
@@ -0,0 +1,138 @@
+package config
+
+import (
+	"github.com/xeipuuv/gojsonschema"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// Admission has configuration parameters of admission control of topologies.
+// Each topology declares Resources it needs on creation, and the server
+// rejects a topology when the total of declared resources of all topologies
+// would exceed the limits. A limit is disabled when it's 0. When a limit is
+// enabled, topologies must declare the corresponding resource.
+type Admission struct {
+	// MaxTopologies is the maximum number of topologies in the server.
+	MaxTopologies int `json:"max_topologies" yaml:"max_topologies"`
+
+	// MaxNodes is the maximum total of nodes declared by topologies.
+	MaxNodes int `json:"max_nodes" yaml:"max_nodes"`
+
+	// MaxMemory is the maximum total of memory in bytes declared by
+	// topologies.
+	MaxMemory int64 `json:"max_memory" yaml:"max_memory"`
+}
+
+// Resources are resources a topology declares. A topology cannot have more
+// nodes than MaxNodes. MaxMemory is only used for admission control and
+// isn't enforced at runtime. Each resource isn't declared when it's 0.
+type Resources struct {
+	// MaxNodes is the maximum number of nodes in the topology including
+	// temporary nodes created for SELECT statements.
+	MaxNodes int `json:"max_nodes" yaml:"max_nodes"`
+
+	// MaxMemory is the expected maximum memory usage of the topology in
+	// bytes.
+	MaxMemory int64 `json:"max_memory" yaml:"max_memory"`
+}
+
+var (
+	admissionSchemaString = `{
+	"type": "object",
+	"properties": {
+		"max_topologies": {
+			"type": "integer",
+			"minimum": 0
+		},
+		"max_nodes": {
+			"type": "integer",
+			"minimum": 0
+		},
+		"max_memory": {
+			"type": "integer",
+			"minimum": 0
+		}
+	},
+	"additionalProperties": false
+}`
+	admissionSchema *gojsonschema.Schema
+
+	resourcesSchemaString = `{
+	"type": "object",
+	"properties": {
+		"max_nodes": {
+			"type": "integer",
+			"minimum": 0
+		},
+		"max_memory": {
+			"type": "integer",
+			"minimum": 0
+		}
+	},
+	"additionalProperties": false
+}`
+	resourcesSchema *gojsonschema.Schema
+)
+
+func init() {
+	s, err := gojsonschema.NewSchema(gojsonschema.NewStringLoader(admissionSchemaString))
+	if err != nil {
+		panic(err)
+	}
+	admissionSchema = s
+
+	s, err = gojsonschema.NewSchema(gojsonschema.NewStringLoader(resourcesSchemaString))
+	if err != nil {
+		panic(err)
+	}
+	resourcesSchema = s
+}
+
+// NewAdmission creates an Admission config parameters from a given map.
+func NewAdmission(m data.Map) (*Admission, error) {
+	if err := validate(admissionSchema, m); err != nil {
+		return nil, err
+	}
+	return newAdmission(m), nil
+}
+
+func newAdmission(m data.Map) *Admission {
+	return &Admission{
+		MaxTopologies: int(mustToInt(getWithDefault(m, "max_topologies", data.Int(0)))),
+		MaxNodes:      int(mustToInt(getWithDefault(m, "max_nodes", data.Int(0)))),
+		MaxMemory:     mustToInt(getWithDefault(m, "max_memory", data.Int(0))),
+	}
+}
+
+// ToMap returns admission config information as data.Map.
+func (a *Admission) ToMap() data.Map {
+	return data.Map{
+		"max_topologies": data.Int(a.MaxTopologies),
+		"max_nodes":      data.Int(a.MaxNodes),
+		"max_memory":     data.Int(a.MaxMemory),
+	}
+}
+
+// NewResources creates Resources from a given map such as a part of a
+// request.
+func NewResources(m data.Map) (*Resources, error) {
+	if err := validate(resourcesSchema, m); err != nil {
+		return nil, err
+	}
+	r := newResources(m)
+	return &r, nil
+}
+
+func newResources(m data.Map) Resources {
+	return Resources{
+		MaxNodes:  int(mustToInt(getWithDefault(m, "max_nodes", data.Int(0)))),
+		MaxMemory: mustToInt(getWithDefault(m, "max_memory", data.Int(0))),
+	}
+}
+
+// ToMap returns resources as data.Map.
+func (r *Resources) ToMap() data.Map {
+	return data.Map{
+		"max_nodes":  data.Int(r.MaxNodes),
+		"max_memory": data.Int(r.MaxMemory),
+	}
+}
@@ -0,0 +1,51 @@
+package config
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestAdmission(t *testing.T) {
+	Convey("Given a JSON config for admission section", t, func() {
+		Convey("When the config is valid", func() {
+			a, err := NewAdmission(toMap(`{"max_topologies":4,"max_nodes":100,"max_memory":1073741824}`))
+			So(err, ShouldBeNil)
+
+			Convey("Then it should have given parameters", func() {
+				So(a.MaxTopologies, ShouldEqual, 4)
+				So(a.MaxNodes, ShouldEqual, 100)
+				So(a.MaxMemory, ShouldEqual, 1073741824)
+			})
+		})
+
+		Convey("When the config only has required parameters", func() {
+			// no required parameter at the moment
+			a, err := NewAdmission(toMap(`{}`))
+
+			Convey("Then it should have default values", func() {
+				So(err, ShouldBeNil)
+				So(a.MaxTopologies, ShouldEqual, 0)
+				So(a.MaxNodes, ShouldEqual, 0)
+				So(a.MaxMemory, ShouldEqual, 0)
+			})
+		})
+
+		Convey("When the config has an undefined field", func() {
+			_, err := NewAdmission(toMap(`{"max_cpu":1}`))
+
+			Convey("Then it should be invalid", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When validating limits", func() {
+			for _, v := range []string{`-1`, `1.5`, `"1"`} {
+				v := v
+				Convey("Then it should reject "+v, func() {
+					_, err := NewAdmission(toMap(`{"max_nodes":` + v + `}`))
+					So(err, ShouldNotBeNil)
+				})
+			}
+		})
+	})
+}
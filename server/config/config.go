@@ -22,6 +22,21 @@ type Config struct {
 
 	// Logging section has parameters related to logging.
 	Logging *Logging
+
+	// Scheduler section has parameters of the scheduler shared by all
+	// topologies.
+	Scheduler *Scheduler
+
+	// Admission section has limits of resources declared by topologies.
+	Admission *Admission
+
+	// Replication section has parameters of the replication of shared states
+	// from a primary server to a standby server.
+	Replication *Replication
+
+	// Namespaces section has namespaces created on startup. The "default"
+	// namespace always exists even if it isn't defined in this section.
+	Namespaces Namespaces
 }
 
 var (
@@ -31,10 +46,15 @@ var (
 		"network": %v,
 		"topologies": %v,
 		"storage": %v,
-		"logging": %v
+		"logging": %v,
+		"scheduler": %v,
+		"admission": %v,
+		"replication": %v,
+		"namespaces": %v
 	},
 	"additionalProperties": false
-}`, networkSchemaString, topologiesSchemaString, storageSchemaString, loggingSchemaString)
+}`, networkSchemaString, topologiesSchemaString, storageSchemaString, loggingSchemaString, schedulerSchemaString,
+		admissionSchemaString, replicationSchemaString, namespacesSchemaString)
 	rootSchema *gojsonschema.Schema
 )
 
@@ -52,20 +72,28 @@ func New(m data.Map) (*Config, error) {
 		return nil, err
 	}
 	return &Config{
-		Network:    newNetwork(mustAsMap(getWithDefault(m, "network", data.Map{}))),
-		Topologies: newTopologies(mustAsMap(getWithDefault(m, "topologies", data.Map{}))),
-		Storage:    newStorage(mustAsMap(getWithDefault(m, "storage", data.Map{}))),
-		Logging:    newLogging(mustAsMap(getWithDefault(m, "logging", data.Map{}))),
+		Network:     newNetwork(mustAsMap(getWithDefault(m, "network", data.Map{}))),
+		Topologies:  newTopologies(mustAsMap(getWithDefault(m, "topologies", data.Map{}))),
+		Storage:     newStorage(mustAsMap(getWithDefault(m, "storage", data.Map{}))),
+		Logging:     newLogging(mustAsMap(getWithDefault(m, "logging", data.Map{}))),
+		Scheduler:   newScheduler(mustAsMap(getWithDefault(m, "scheduler", data.Map{}))),
+		Admission:   newAdmission(mustAsMap(getWithDefault(m, "admission", data.Map{}))),
+		Replication: newReplication(mustAsMap(getWithDefault(m, "replication", data.Map{}))),
+		Namespaces:  newNamespaces(mustAsMap(getWithDefault(m, "namespaces", data.Map{}))),
 	}, nil
 }
 
 // ToMap returns server config information as data.Map.
 func (c *Config) ToMap() data.Map {
 	return data.Map{
-		"network":    c.Network.ToMap(),
-		"topologies": c.Topologies.ToMap(),
-		"storage":    c.Storage.ToMap(),
-		"logging":    c.Logging.ToMap(),
+		"network":     c.Network.ToMap(),
+		"topologies":  c.Topologies.ToMap(),
+		"storage":     c.Storage.ToMap(),
+		"logging":     c.Logging.ToMap(),
+		"scheduler":   c.Scheduler.ToMap(),
+		"admission":   c.Admission.ToMap(),
+		"replication": c.Replication.ToMap(),
+		"namespaces":  c.Namespaces.ToMap(),
 	}
 }
 
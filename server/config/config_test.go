@@ -33,6 +33,10 @@ func TestConfig(t *testing.T) {
 				So(c.Topologies["test1"].Name, ShouldEqual, "test1")
 				So(c.Topologies["test2"].BQLFile, ShouldEqual, "/path/to/hoge.bql")
 				So(c.Logging.Target, ShouldEqual, "stdout")
+				So(c.Scheduler.Enabled, ShouldBeFalse)
+				So(c.Admission.MaxTopologies, ShouldEqual, 0)
+				So(c.Replication.Role, ShouldEqual, ReplicationNone)
+				So(c.Namespaces, ShouldBeEmpty)
 			})
 		})
 
@@ -80,20 +84,98 @@ func TestConfigToMap(t *testing.T) {
 				LogDestinationlessTuples: true,
 				SummarizeDroppedTuples:   true,
 			},
+			Scheduler: &Scheduler{
+				Enabled: true,
+				Boxes: SchedulerPool{
+					Size:         4,
+					LockOSThread: true,
+				},
+			},
+			Admission: &Admission{
+				MaxNodes: 100,
+			},
+			Replication: &Replication{
+				Role:          ReplicationStandby,
+				Primary:       "http://primary:15601",
+				Token:         "secret",
+				RetryInterval: 1,
+			},
+			Namespaces: Namespaces{
+				"team_a": &Namespace{
+					Tokens: []string{"secret"},
+				},
+			},
 		}
 		Convey("When convert to data.Map", func() {
 			ac := c.ToMap()
 			Convey("Then map should be equal as the config", func() {
 				ex := data.Map{
 					"network": data.Map{
-						"listen_on": data.String("12345"),
+						"listen_on":            data.String("12345"),
+						"reuse_port":           data.False,
+						"drain_timeout":        data.Int(0),
+						"request_timeout":      data.Int(0),
+						"gzip":                 data.False,
+						"grpc_listen_on":       data.String(""),
+						"stream_write_timeout": data.Int(0),
+						"stream_buffer_size":   data.Int(0),
+						"slow_client_policy":   data.String(""),
+						"outbound": data.Map{
+							"proxy":           data.String(""),
+							"no_proxy":        data.String(""),
+							"ca_cert_file":    data.String(""),
+							"tls_server_name": data.String(""),
+							"tls_min_version": data.String(""),
+						},
 					},
 					"topologies": data.Map{
 						"t1": data.Map{
 							"bql_file": data.String("t1.bql"),
+							"tuple_id": data.String(""),
+							"watchdog": data.False,
+							"resources": data.Map{
+								"max_nodes":  data.Int(0),
+								"max_memory": data.Int(0),
+							},
+							"lineage": data.Map{
+								"enabled":     data.False,
+								"max_inputs":  data.Int(0),
+								"max_records": data.Int(0),
+							},
+							"window_spill": data.Map{
+								"enabled":            data.False,
+								"dir":                data.String(""),
+								"max_rows_in_memory": data.Int(0),
+								"segment_size":       data.Int(0),
+							},
+							"columnar_execution": data.False,
+							"shared_filters":     data.False,
+							"isolation":          data.String(""),
+							"timezone":           data.String(""),
 						},
 						"t2": data.Map{
 							"bql_file": data.String("t2.bql"),
+							"tuple_id": data.String(""),
+							"watchdog": data.False,
+							"resources": data.Map{
+								"max_nodes":  data.Int(0),
+								"max_memory": data.Int(0),
+							},
+							"lineage": data.Map{
+								"enabled":     data.False,
+								"max_inputs":  data.Int(0),
+								"max_records": data.Int(0),
+							},
+							"window_spill": data.Map{
+								"enabled":            data.False,
+								"dir":                data.String(""),
+								"max_rows_in_memory": data.Int(0),
+								"segment_size":       data.Int(0),
+							},
+							"columnar_execution": data.False,
+							"shared_filters":     data.False,
+							"isolation":          data.String(""),
+							"timezone":           data.String(""),
 						},
 					},
 					"storage": data.Map{
@@ -110,6 +192,38 @@ func TestConfigToMap(t *testing.T) {
 						"log_dropped_tuples":         data.True,
 						"log_destinationless_tuples": data.True,
 						"summarize_dropped_tuples":   data.True,
+						"log_access":                 data.False,
+					},
+					"scheduler": data.Map{
+						"enabled": data.True,
+						"sources": data.Map{
+							"size":           data.Int(0),
+							"lock_os_thread": data.False,
+						},
+						"boxes": data.Map{
+							"size":           data.Int(4),
+							"lock_os_thread": data.True,
+						},
+						"sinks": data.Map{
+							"size":           data.Int(0),
+							"lock_os_thread": data.False,
+						},
+					},
+					"admission": data.Map{
+						"max_topologies": data.Int(0),
+						"max_nodes":      data.Int(100),
+						"max_memory":     data.Int(0),
+					},
+					"replication": data.Map{
+						"role":           data.String("standby"),
+						"primary":        data.String("http://primary:15601"),
+						"token":          data.String("********"),
+						"retry_interval": data.Int(1),
+					},
+					"namespaces": data.Map{
+						"team_a": data.Map{
+							"tokens": data.Array{data.String("********")},
+						},
 					},
 				}
 				So(ac, ShouldResemble, ex)
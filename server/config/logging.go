@@ -46,6 +46,11 @@ type Logging struct {
 	// JSON parsers. This parameter only works when LogDroppedTuples is true.
 	SummarizeDroppedTuples bool `json:"summarize_dropped_tuples" yaml:"summarize_dropped_tuples"`
 
+	// LogAccess controls access logging of the HTTP server. If this parameter
+	// is true, which is the default, each request is logged with its method,
+	// path, status, and elapsed time.
+	LogAccess bool `json:"log_access" yaml:"log_access"`
+
 	// TODO: add log rotation
 	// TODO: add log formatting
 }
@@ -68,6 +73,9 @@ var (
 		},
 		"summarize_dropped_tuples": {
 			"type": "boolean"
+		},
+		"log_access": {
+			"type": "boolean"
 		}
 	},
 	"additionalProperties": false
@@ -98,6 +106,7 @@ func newLogging(m data.Map) *Logging {
 		LogDroppedTuples:         mustToBool(getWithDefault(m, "log_dropped_tuples", data.False)),
 		LogDestinationlessTuples: mustToBool(getWithDefault(m, "log_destinationless_tuples", data.False)),
 		SummarizeDroppedTuples:   mustToBool(getWithDefault(m, "summarize_dropped_tuples", data.False)),
+		LogAccess:                mustToBool(getWithDefault(m, "log_access", data.True)),
 	}
 }
 
@@ -147,5 +156,6 @@ func (l *Logging) ToMap() data.Map {
 		"log_dropped_tuples":         data.Bool(l.LogDroppedTuples),
 		"log_destinationless_tuples": data.Bool(l.LogDestinationlessTuples),
 		"summarize_dropped_tuples":   data.Bool(l.SummarizeDroppedTuples),
+		"log_access":                 data.Bool(l.LogAccess),
 	}
 }
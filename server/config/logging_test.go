@@ -11,7 +11,7 @@ import (
 func TestLogging(t *testing.T) {
 	Convey("Given a JSON config for logging section", t, func() {
 		Convey("When the config is valid", func() {
-			l, err := NewLogging(toMap(`{"target":"stdout","min_log_level":"error","log_dropped_tuples":true,"log_destinationless_tuples":true,"summarize_dropped_tuples":true}`))
+			l, err := NewLogging(toMap(`{"target":"stdout","min_log_level":"error","log_dropped_tuples":true,"log_destinationless_tuples":true,"summarize_dropped_tuples":true,"log_access":false}`))
 			So(err, ShouldBeNil)
 
 			Convey("Then it should have given parameters", func() {
@@ -19,6 +19,7 @@ func TestLogging(t *testing.T) {
 				So(l.MinLogLevel, ShouldEqual, "error")
 				So(l.LogDroppedTuples, ShouldBeTrue)
 				So(l.SummarizeDroppedTuples, ShouldBeTrue)
+				So(l.LogAccess, ShouldBeFalse)
 			})
 		})
 
@@ -32,6 +33,7 @@ func TestLogging(t *testing.T) {
 				So(l.MinLogLevel, ShouldEqual, "info")
 				So(l.LogDroppedTuples, ShouldBeFalse)
 				So(l.SummarizeDroppedTuples, ShouldBeFalse)
+				So(l.LogAccess, ShouldBeTrue)
 			})
 		})
 
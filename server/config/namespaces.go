@@ -0,0 +1,105 @@
+package config
+
+import (
+	"github.com/xeipuuv/gojsonschema"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// Namespace has configuration parameters of a namespace isolating topologies
+// of a tenant. Topologies in the "default" namespace are accessed by paths
+// without a namespace segment, and they're created from Topologies section.
+type Namespace struct {
+	// Name is the name of the namespace. This field isn't directly used in
+	// a config file.
+	Name string `json:"-" yaml:"-"`
+
+	// Tokens are bearer tokens which clients must send to access the
+	// namespace. The namespace can be accessed without a token when it's
+	// empty. A token only grants access to the namespace having it.
+	Tokens []string `json:"tokens" yaml:"tokens"`
+}
+
+// Namespaces is a set of configuration of namespaces created on startup.
+type Namespaces map[string]*Namespace
+
+var (
+	namespacesSchemaString = `{
+	"type": "object",
+	"patternProperties": {
+		"^[a-zA-Z][a-zA-Z0-9_]*$": {
+			"anyOf": [
+				{
+					"type": "object",
+					"properties": {
+						"tokens": {
+							"type": "array",
+							"items": {
+								"type": "string",
+								"minLength": 1
+							}
+						}
+					},
+					"additionalProperties": false
+				},
+				{
+					"type": "null"
+				}
+			]
+		}
+	},
+	"additionalProperties": false
+}`
+	namespacesSchema *gojsonschema.Schema
+)
+
+func init() {
+	s, err := gojsonschema.NewSchema(gojsonschema.NewStringLoader(namespacesSchemaString))
+	if err != nil {
+		panic(err)
+	}
+	namespacesSchema = s
+}
+
+// NewNamespaces creates a Namespaces config parameters from a given map.
+func NewNamespaces(m data.Map) (Namespaces, error) {
+	if err := validate(namespacesSchema, m); err != nil {
+		return nil, err
+	}
+	return newNamespaces(m), nil
+}
+
+func newNamespaces(m data.Map) Namespaces {
+	ns := Namespaces{}
+	for name, conf := range m {
+		if conf.Type() == data.TypeNull {
+			conf = data.Map{}
+		}
+		n := &Namespace{
+			Name: name,
+		}
+		if v, ok := mustAsMap(conf)["tokens"]; ok {
+			a, _ := data.AsArray(v)
+			for _, t := range a {
+				n.Tokens = append(n.Tokens, mustAsString(t))
+			}
+		}
+		ns[name] = n
+	}
+	return ns
+}
+
+// ToMap returns namespaces config information as data.Map. Tokens are
+// masked so that they aren't written to logs.
+func (ns *Namespaces) ToMap() data.Map {
+	m := data.Map{}
+	for k, v := range *ns {
+		tokens := make(data.Array, len(v.Tokens))
+		for i := range v.Tokens {
+			tokens[i] = data.String("********")
+		}
+		m[k] = data.Map{
+			"tokens": tokens,
+		}
+	}
+	return m
+}
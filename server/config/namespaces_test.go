@@ -0,0 +1,52 @@
+package config
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestNamespaces(t *testing.T) {
+	Convey("Given a JSON config for namespaces section", t, func() {
+		Convey("When the config is valid", func() {
+			ns, err := NewNamespaces(toMap(`{"team_a":{"tokens":["t1","t2"]},"team_b":null,"team_c":{}}`))
+			So(err, ShouldBeNil)
+
+			Convey("Then it should have given parameters", func() {
+				So(ns, ShouldHaveLength, 3)
+				So(ns["team_a"].Name, ShouldEqual, "team_a")
+				So(ns["team_a"].Tokens, ShouldResemble, []string{"t1", "t2"})
+				So(ns["team_b"].Tokens, ShouldBeEmpty)
+				So(ns["team_c"].Tokens, ShouldBeEmpty)
+			})
+
+			Convey("Then ToMap should mask tokens", func() {
+				m := ns.ToMap()
+				So(m["team_a"].String(), ShouldNotContainSubstring, "t1")
+			})
+		})
+
+		Convey("When the config has an invalid namespace name", func() {
+			_, err := NewNamespaces(toMap(`{"team-a":{}}`))
+
+			Convey("Then it should be invalid", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When the config has an undefined field", func() {
+			_, err := NewNamespaces(toMap(`{"team_a":{"token":"t1"}}`))
+
+			Convey("Then it should be invalid", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When the config has an empty token", func() {
+			_, err := NewNamespaces(toMap(`{"team_a":{"tokens":[""]}}`))
+
+			Convey("Then it should be invalid", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
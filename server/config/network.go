@@ -9,25 +9,168 @@ import (
 const (
 	// DefaultPort is the default port number used by the SensorBee server.
 	DefaultPort = 15601
+
+	// DefaultDrainTimeout is the default number of seconds for which the
+	// server waits for active requests to finish when it hands off its
+	// listener to a new process.
+	DefaultDrainTimeout = 30
+
+	// DefaultStreamWriteTimeout is the default number of seconds allowed for
+	// writing a result of a streaming SELECT statement to a client.
+	DefaultStreamWriteTimeout = 30
+
+	// DefaultStreamBufferSize is the default number of results buffered for
+	// each client of a streaming SELECT statement.
+	DefaultStreamBufferSize = 1024
+
+	// SlowClientDisconnect is a slow client policy which disconnects a client
+	// whose buffer is full.
+	SlowClientDisconnect = "disconnect"
+
+	// SlowClientDrop is a slow client policy which drops the oldest buffered
+	// result of a client whose buffer is full and notifies the client of it.
+	SlowClientDrop = "drop"
 )
 
 // Network has configuration parameters related to the network.
 type Network struct {
 	// ListenOn has binding information in "host:port" format.
 	ListenOn string `json:"listen_on" yaml:"listen_on"`
+
+	// ReusePort enables SO_REUSEPORT on the listening socket so that a new
+	// server process can listen on the same address while the old process
+	// is draining. It's only supported on Linux, macOS, and BSDs.
+	ReusePort bool `json:"reuse_port" yaml:"reuse_port"`
+
+	// DrainTimeout is the number of seconds for which the server waits for
+	// active requests to finish after handing off its listener to a new
+	// process. Remaining connections are closed after the timeout.
+	DrainTimeout int `json:"drain_timeout" yaml:"drain_timeout"`
+
+	// RequestTimeout is the number of seconds after which the context of a
+	// request is canceled. Long-lived responses such as results of SELECT
+	// statements aren't affected because they're written after the request
+	// is handed over. 0 means no timeout.
+	RequestTimeout int `json:"request_timeout" yaml:"request_timeout"`
+
+	// Gzip enables gzip compression of responses when clients accept it.
+	Gzip bool `json:"gzip" yaml:"gzip"`
+
+	// GRPCListenOn has binding information of the gRPC API in "host:port"
+	// format. The gRPC API is disabled when it's empty.
+	GRPCListenOn string `json:"grpc_listen_on" yaml:"grpc_listen_on"`
+
+	// StreamWriteTimeout is the number of seconds allowed for writing a
+	// result of a streaming SELECT statement to a client. The client is
+	// disconnected when a write doesn't finish in time. 0 means no timeout.
+	StreamWriteTimeout int `json:"stream_write_timeout" yaml:"stream_write_timeout"`
+
+	// StreamBufferSize is the number of results of a streaming SELECT
+	// statement buffered for each client. Results are buffered so that a
+	// slow client doesn't block the topology.
+	StreamBufferSize int `json:"stream_buffer_size" yaml:"stream_buffer_size"`
+
+	// SlowClientPolicy decides what happens when a client's buffer is full.
+	// It's either "disconnect" or "drop".
+	SlowClientPolicy string `json:"slow_client_policy" yaml:"slow_client_policy"`
+
+	// Outbound has parameters of outbound connections made by sources,
+	// sinks, and loaders to external systems.
+	Outbound Outbound `json:"outbound" yaml:"outbound"`
+}
+
+// Outbound has configuration parameters of outbound connections such as
+// proxies and TLS. They're the defaults of all nodes and each node can
+// override them by its parameters. When the proxy isn't given, standard
+// environment variables such as HTTPS_PROXY and NO_PROXY are used.
+type Outbound struct {
+	// Proxy is the URL of the proxy like "http://proxy:8080" or
+	// "socks5://proxy:1080".
+	Proxy string `json:"proxy" yaml:"proxy"`
+
+	// NoProxy is a comma-separated list of hosts, domains, and CIDRs
+	// connected without the proxy.
+	NoProxy string `json:"no_proxy" yaml:"no_proxy"`
+
+	// CACertFile is the path to a PEM file having certificates of CAs trusted
+	// in addition to the system's CAs.
+	CACertFile string `json:"ca_cert_file" yaml:"ca_cert_file"`
+
+	// TLSServerName is the server name used by SNI and verification of
+	// certificates. The host of each connection is used when it's empty.
+	TLSServerName string `json:"tls_server_name" yaml:"tls_server_name"`
+
+	// TLSMinVersion is the minimum TLS version, which is one of "1.0",
+	// "1.1", "1.2", and "1.3". Go's default is used when it's empty.
+	TLSMinVersion string `json:"tls_min_version" yaml:"tls_min_version"`
 }
 
 var (
-	networkSchemaString = `{
+	outboundSchemaString = `{
 	"type": "object",
 	"properties": {
-		"listen_on": {
+		"proxy": {
 			"type": "string",
-			"pattern": "^.*:[0-9]+$"
+			"pattern": "^((https?|socks5h?)://.+)?$"
+		},
+		"no_proxy": {
+			"type": "string"
+		},
+		"ca_cert_file": {
+			"type": "string"
+		},
+		"tls_server_name": {
+			"type": "string"
+		},
+		"tls_min_version": {
+			"type": "string",
+			"enum": ["", "1.0", "1.1", "1.2", "1.3"]
 		}
 	},
 	"additionalProperties": false
 }`
+
+	networkSchemaString = fmt.Sprintf(`{
+	"type": "object",
+	"properties": {
+		"listen_on": {
+			"type": "string",
+			"pattern": "^.*:[0-9]+$"
+		},
+		"reuse_port": {
+			"type": "boolean"
+		},
+		"drain_timeout": {
+			"type": "integer",
+			"minimum": 0
+		},
+		"request_timeout": {
+			"type": "integer",
+			"minimum": 0
+		},
+		"gzip": {
+			"type": "boolean"
+		},
+		"grpc_listen_on": {
+			"type": "string",
+			"pattern": "^(.*:[0-9]+)?$"
+		},
+		"stream_write_timeout": {
+			"type": "integer",
+			"minimum": 0
+		},
+		"stream_buffer_size": {
+			"type": "integer",
+			"minimum": 1
+		},
+		"slow_client_policy": {
+			"type": "string",
+			"enum": ["disconnect", "drop"]
+		},
+		"outbound": %v
+	},
+	"additionalProperties": false
+}`, outboundSchemaString)
 	networkSchema *gojsonschema.Schema
 )
 
@@ -49,13 +192,51 @@ func NewNetwork(m data.Map) (*Network, error) {
 
 func newNetwork(m data.Map) *Network {
 	return &Network{
-		ListenOn: mustAsString(getWithDefault(m, "listen_on", data.String(fmt.Sprintf(":%d", DefaultPort)))),
+		ListenOn:           mustAsString(getWithDefault(m, "listen_on", data.String(fmt.Sprintf(":%d", DefaultPort)))),
+		ReusePort:          mustToBool(getWithDefault(m, "reuse_port", data.False)),
+		DrainTimeout:       int(mustToInt(getWithDefault(m, "drain_timeout", data.Int(DefaultDrainTimeout)))),
+		RequestTimeout:     int(mustToInt(getWithDefault(m, "request_timeout", data.Int(0)))),
+		Gzip:               mustToBool(getWithDefault(m, "gzip", data.False)),
+		GRPCListenOn:       mustAsString(getWithDefault(m, "grpc_listen_on", data.String(""))),
+		StreamWriteTimeout: int(mustToInt(getWithDefault(m, "stream_write_timeout", data.Int(DefaultStreamWriteTimeout)))),
+		StreamBufferSize:   int(mustToInt(getWithDefault(m, "stream_buffer_size", data.Int(DefaultStreamBufferSize)))),
+		SlowClientPolicy:   mustAsString(getWithDefault(m, "slow_client_policy", data.String(SlowClientDisconnect))),
+		Outbound:           newOutbound(mustAsMap(getWithDefault(m, "outbound", data.Map{}))),
+	}
+}
+
+func newOutbound(m data.Map) Outbound {
+	return Outbound{
+		Proxy:         mustAsString(getWithDefault(m, "proxy", data.String(""))),
+		NoProxy:       mustAsString(getWithDefault(m, "no_proxy", data.String(""))),
+		CACertFile:    mustAsString(getWithDefault(m, "ca_cert_file", data.String(""))),
+		TLSServerName: mustAsString(getWithDefault(m, "tls_server_name", data.String(""))),
+		TLSMinVersion: mustAsString(getWithDefault(m, "tls_min_version", data.String(""))),
 	}
 }
 
 // ToMap returns network config information as data.Map.
 func (n *Network) ToMap() data.Map {
 	return data.Map{
-		"listen_on": data.String(n.ListenOn),
+		"listen_on":            data.String(n.ListenOn),
+		"reuse_port":           data.Bool(n.ReusePort),
+		"drain_timeout":        data.Int(n.DrainTimeout),
+		"request_timeout":      data.Int(n.RequestTimeout),
+		"gzip":                 data.Bool(n.Gzip),
+		"grpc_listen_on":       data.String(n.GRPCListenOn),
+		"stream_write_timeout": data.Int(n.StreamWriteTimeout),
+		"stream_buffer_size":   data.Int(n.StreamBufferSize),
+		"slow_client_policy":   data.String(n.SlowClientPolicy),
+		"outbound":             n.Outbound.toMap(),
+	}
+}
+
+func (o *Outbound) toMap() data.Map {
+	return data.Map{
+		"proxy":           data.String(o.Proxy),
+		"no_proxy":        data.String(o.NoProxy),
+		"ca_cert_file":    data.String(o.CACertFile),
+		"tls_server_name": data.String(o.TLSServerName),
+		"tls_min_version": data.String(o.TLSMinVersion),
 	}
 }
@@ -9,11 +9,20 @@ import (
 func TestNetwork(t *testing.T) {
 	Convey("Given a JSON config for network section", t, func() {
 		Convey("When the config is valid", func() {
-			n, err := NewNetwork(toMap(`{"listen_on":":12345"}`))
+			n, err := NewNetwork(toMap(`{"listen_on":":12345","reuse_port":true,"drain_timeout":5,"request_timeout":10,"gzip":true,"grpc_listen_on":":12346",
+				"stream_write_timeout":3,"stream_buffer_size":16,"slow_client_policy":"drop"}`))
 			So(err, ShouldBeNil)
 
 			Convey("Then it should have given parameters", func() {
 				So(n.ListenOn, ShouldEqual, ":12345")
+				So(n.ReusePort, ShouldBeTrue)
+				So(n.DrainTimeout, ShouldEqual, 5)
+				So(n.RequestTimeout, ShouldEqual, 10)
+				So(n.Gzip, ShouldBeTrue)
+				So(n.GRPCListenOn, ShouldEqual, ":12346")
+				So(n.StreamWriteTimeout, ShouldEqual, 3)
+				So(n.StreamBufferSize, ShouldEqual, 16)
+				So(n.SlowClientPolicy, ShouldEqual, SlowClientDrop)
 			})
 		})
 
@@ -24,6 +33,15 @@ func TestNetwork(t *testing.T) {
 			Convey("Then it should have given parameters and default values", func() {
 				So(err, ShouldBeNil)
 				So(n.ListenOn, ShouldEqual, fmt.Sprintf(":%d", DefaultPort))
+				So(n.ReusePort, ShouldBeFalse)
+				So(n.DrainTimeout, ShouldEqual, DefaultDrainTimeout)
+				So(n.RequestTimeout, ShouldEqual, 0)
+				So(n.Gzip, ShouldBeFalse)
+				So(n.GRPCListenOn, ShouldBeBlank)
+				So(n.StreamWriteTimeout, ShouldEqual, DefaultStreamWriteTimeout)
+				So(n.StreamBufferSize, ShouldEqual, DefaultStreamBufferSize)
+				So(n.SlowClientPolicy, ShouldEqual, SlowClientDisconnect)
+				So(n.Outbound, ShouldResemble, Outbound{})
 			})
 		})
 
@@ -54,5 +72,87 @@ func TestNetwork(t *testing.T) {
 				})
 			}
 		})
+
+		Convey("When validating grpc_listen_on", func() {
+			for _, lv := range [][]interface{}{{"no port", `":"`},
+				{"no :", fmt.Sprintf(`"localhost%d"`, DefaultPort)},
+				{"invalid type", 1}} {
+				Convey(fmt.Sprintf("Then it should reject %v", lv[0]), func() {
+					_, err := NewNetwork(toMap(fmt.Sprintf(`{"grpc_listen_on":%v}`, lv[1])))
+					So(err, ShouldNotBeNil)
+				})
+			}
+		})
+
+		Convey("When validating drain_timeout", func() {
+			for _, lv := range [][]interface{}{{"a negative value", -1},
+				{"a float", 1.5},
+				{"invalid type", `"1"`}} {
+				Convey(fmt.Sprintf("Then it should reject %v", lv[0]), func() {
+					_, err := NewNetwork(toMap(fmt.Sprintf(`{"drain_timeout":%v}`, lv[1])))
+					So(err, ShouldNotBeNil)
+				})
+			}
+		})
+
+		Convey("When validating request_timeout", func() {
+			for _, lv := range [][]interface{}{{"a negative value", -1},
+				{"a float", 1.5},
+				{"invalid type", `"1"`}} {
+				Convey(fmt.Sprintf("Then it should reject %v", lv[0]), func() {
+					_, err := NewNetwork(toMap(fmt.Sprintf(`{"request_timeout":%v}`, lv[1])))
+					So(err, ShouldNotBeNil)
+				})
+			}
+		})
+
+		Convey("When validating stream_buffer_size", func() {
+			for _, lv := range [][]interface{}{{"zero", 0},
+				{"a negative value", -1},
+				{"invalid type", `"1"`}} {
+				Convey(fmt.Sprintf("Then it should reject %v", lv[0]), func() {
+					_, err := NewNetwork(toMap(fmt.Sprintf(`{"stream_buffer_size":%v}`, lv[1])))
+					So(err, ShouldNotBeNil)
+				})
+			}
+		})
+
+		Convey("When validating slow_client_policy", func() {
+			for _, lv := range [][]interface{}{{"an undefined policy", `"block"`},
+				{"invalid type", 1}} {
+				Convey(fmt.Sprintf("Then it should reject %v", lv[0]), func() {
+					_, err := NewNetwork(toMap(fmt.Sprintf(`{"slow_client_policy":%v}`, lv[1])))
+					So(err, ShouldNotBeNil)
+				})
+			}
+		})
+
+		Convey("When the config has the outbound section", func() {
+			n, err := NewNetwork(toMap(`{"outbound":{"proxy":"socks5://proxy:1080","no_proxy":"localhost,.internal",
+				"ca_cert_file":"/etc/ca.pem","tls_server_name":"example.com","tls_min_version":"1.2"}}`))
+			So(err, ShouldBeNil)
+
+			Convey("Then it should have given parameters", func() {
+				So(n.Outbound, ShouldResemble, Outbound{
+					Proxy:         "socks5://proxy:1080",
+					NoProxy:       "localhost,.internal",
+					CACertFile:    "/etc/ca.pem",
+					TLSServerName: "example.com",
+					TLSMinVersion: "1.2",
+				})
+			})
+		})
+
+		Convey("When validating outbound", func() {
+			for _, lv := range [][]interface{}{{"a proxy without a scheme", `{"proxy":"proxy:8080"}`},
+				{"a proxy having an unsupported scheme", `{"proxy":"ftp://proxy"}`},
+				{"an undefined TLS version", `{"tls_min_version":"1.4"}`},
+				{"an undefined field", `{"proxy_url":"http://proxy"}`}} {
+				Convey(fmt.Sprintf("Then it should reject %v", lv[0]), func() {
+					_, err := NewNetwork(toMap(fmt.Sprintf(`{"outbound":%v}`, lv[1])))
+					So(err, ShouldNotBeNil)
+				})
+			}
+		})
 	})
 }
@@ -0,0 +1,126 @@
+package config
+
+import (
+	"github.com/xeipuuv/gojsonschema"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+const (
+	// ReplicationNone is a replication role of a server which doesn't
+	// replicate shared states.
+	ReplicationNone = "none"
+
+	// ReplicationPrimary is a replication role of a server which sends
+	// changes of its shared states to a standby server.
+	ReplicationPrimary = "primary"
+
+	// ReplicationStandby is a replication role of a server which receives
+	// changes of shared states from a primary server.
+	ReplicationStandby = "standby"
+
+	// DefaultReplicationRetryInterval is the default number of seconds for
+	// which a standby server waits before reconnecting to the primary server.
+	DefaultReplicationRetryInterval = 1
+)
+
+// Replication has configuration parameters of the asynchronous replication
+// of shared states from a primary server to a standby server. Only shared
+// states implementing core.LoggedState are replicated.
+type Replication struct {
+	// Role is the role of the server. It's one of "none", "primary", or
+	// "standby".
+	Role string `json:"role" yaml:"role"`
+
+	// Primary is the base URL of the primary server such as
+	// "http://primary:15601". It's required for a standby server.
+	Primary string `json:"primary" yaml:"primary"`
+
+	// Token is a token of the default namespace of the primary server. It's
+	// only used by a standby server when the primary server requires it.
+	Token string `json:"token" yaml:"token"`
+
+	// RetryInterval is the number of seconds for which a standby server
+	// waits before reconnecting to the primary server.
+	RetryInterval int `json:"retry_interval" yaml:"retry_interval"`
+}
+
+var (
+	replicationSchemaString = `{
+	"type": "object",
+	"properties": {
+		"role": {
+			"enum": ["none", "primary", "standby"]
+		},
+		"primary": {
+			"type": "string",
+			"minLength": 1
+		},
+		"token": {
+			"type": "string"
+		},
+		"retry_interval": {
+			"type": "integer",
+			"minimum": 1
+		}
+	},
+	"oneOf": [
+		{
+			"properties": {
+				"role": {
+					"enum": ["standby"]
+				}
+			},
+			"required": ["role", "primary"]
+		},
+		{
+			"properties": {
+				"role": {
+					"enum": ["none", "primary"]
+				}
+			}
+		}
+	],
+	"additionalProperties": false
+}`
+	replicationSchema *gojsonschema.Schema
+)
+
+func init() {
+	s, err := gojsonschema.NewSchema(gojsonschema.NewStringLoader(replicationSchemaString))
+	if err != nil {
+		panic(err)
+	}
+	replicationSchema = s
+}
+
+// NewReplication creates a Replication config parameters from a given map.
+func NewReplication(m data.Map) (*Replication, error) {
+	if err := validate(replicationSchema, m); err != nil {
+		return nil, err
+	}
+	return newReplication(m), nil
+}
+
+func newReplication(m data.Map) *Replication {
+	return &Replication{
+		Role:          mustAsString(getWithDefault(m, "role", data.String(ReplicationNone))),
+		Primary:       mustAsString(getWithDefault(m, "primary", data.String(""))),
+		Token:         mustAsString(getWithDefault(m, "token", data.String(""))),
+		RetryInterval: int(mustToInt(getWithDefault(m, "retry_interval", data.Int(DefaultReplicationRetryInterval)))),
+	}
+}
+
+// ToMap returns replication config information as data.Map. The token is
+// masked.
+func (r *Replication) ToMap() data.Map {
+	token := ""
+	if r.Token != "" {
+		token = "********"
+	}
+	return data.Map{
+		"role":           data.String(r.Role),
+		"primary":        data.String(r.Primary),
+		"token":          data.String(token),
+		"retry_interval": data.Int(r.RetryInterval),
+	}
+}
@@ -0,0 +1,67 @@
+package config
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestReplication(t *testing.T) {
+	Convey("Given a JSON config for replication section", t, func() {
+		Convey("When the config is valid", func() {
+			r, err := NewReplication(toMap(`{"role":"standby","primary":"http://primary:15601","token":"secret","retry_interval":5}`))
+			So(err, ShouldBeNil)
+
+			Convey("Then it should have given parameters", func() {
+				So(r.Role, ShouldEqual, ReplicationStandby)
+				So(r.Primary, ShouldEqual, "http://primary:15601")
+				So(r.Token, ShouldEqual, "secret")
+				So(r.RetryInterval, ShouldEqual, 5)
+			})
+		})
+
+		Convey("When the config only has required parameters", func() {
+			// no required parameter at the moment
+			r, err := NewReplication(toMap(`{}`))
+
+			Convey("Then it should have default values", func() {
+				So(err, ShouldBeNil)
+				So(r.Role, ShouldEqual, ReplicationNone)
+				So(r.Primary, ShouldBeEmpty)
+				So(r.Token, ShouldBeEmpty)
+				So(r.RetryInterval, ShouldEqual, DefaultReplicationRetryInterval)
+			})
+		})
+
+		Convey("When the config is for a primary server", func() {
+			r, err := NewReplication(toMap(`{"role":"primary"}`))
+
+			Convey("Then it should be valid", func() {
+				So(err, ShouldBeNil)
+				So(r.Role, ShouldEqual, ReplicationPrimary)
+			})
+		})
+
+		Convey("When the config has an undefined field", func() {
+			_, err := NewReplication(toMap(`{"role":"primary","port":15601}`))
+
+			Convey("Then it should be invalid", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When validating parameters", func() {
+			for _, v := range []string{
+				`{"role":"standby"}`,
+				`{"role":"replica"}`,
+				`{"role":"standby","primary":""}`,
+				`{"role":"standby","primary":"http://primary:15601","retry_interval":0}`,
+			} {
+				v := v
+				Convey("Then it should reject "+v, func() {
+					_, err := NewReplication(toMap(v))
+					So(err, ShouldNotBeNil)
+				})
+			}
+		})
+	})
+}
@@ -0,0 +1,114 @@
+package config
+
+import (
+	"fmt"
+	"github.com/xeipuuv/gojsonschema"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// Scheduler has configuration parameters of the scheduler which limits the
+// number of sources, boxes, and sinks processing tuples concurrently. The
+// scheduler is shared by all topologies in the server.
+type Scheduler struct {
+	// Enabled enables the scheduler. Nodes aren't limited when it's false.
+	Enabled bool `json:"enabled" yaml:"enabled"`
+
+	// Sources has parameters of the pool shared by sources.
+	Sources SchedulerPool `json:"sources" yaml:"sources"`
+
+	// Boxes has parameters of the pool shared by boxes.
+	Boxes SchedulerPool `json:"boxes" yaml:"boxes"`
+
+	// Sinks has parameters of the pool shared by sinks.
+	Sinks SchedulerPool `json:"sinks" yaml:"sinks"`
+}
+
+// SchedulerPool has configuration parameters of a pool of the scheduler.
+type SchedulerPool struct {
+	// Size is the maximum number of nodes in the pool processing tuples
+	// concurrently. When it's 0, GOMAXPROCS is used.
+	Size int `json:"size" yaml:"size"`
+
+	// LockOSThread is a hint to lock goroutines of nodes in the pool to
+	// their OS threads so that CPU affinity can be applied to them.
+	LockOSThread bool `json:"lock_os_thread" yaml:"lock_os_thread"`
+}
+
+var (
+	schedulerPoolSchemaString = `{
+	"type": "object",
+	"properties": {
+		"size": {
+			"type": "integer",
+			"minimum": 0
+		},
+		"lock_os_thread": {
+			"type": "boolean"
+		}
+	},
+	"additionalProperties": false
+}`
+
+	schedulerSchemaString = fmt.Sprintf(`{
+	"type": "object",
+	"properties": {
+		"enabled": {
+			"type": "boolean"
+		},
+		"sources": %v,
+		"boxes": %v,
+		"sinks": %v
+	},
+	"additionalProperties": false
+}`, schedulerPoolSchemaString, schedulerPoolSchemaString, schedulerPoolSchemaString)
+	schedulerSchema *gojsonschema.Schema
+)
+
+func init() {
+	s, err := gojsonschema.NewSchema(gojsonschema.NewStringLoader(schedulerSchemaString))
+	if err != nil {
+		panic(err)
+	}
+	schedulerSchema = s
+}
+
+// NewScheduler creates a Scheduler config parameters from a given map.
+func NewScheduler(m data.Map) (*Scheduler, error) {
+	if err := validate(schedulerSchema, m); err != nil {
+		return nil, err
+	}
+	return newScheduler(m), nil
+}
+
+func newScheduler(m data.Map) *Scheduler {
+	return &Scheduler{
+		Enabled: mustToBool(getWithDefault(m, "enabled", data.False)),
+		Sources: newSchedulerPool(mustAsMap(getWithDefault(m, "sources", data.Map{}))),
+		Boxes:   newSchedulerPool(mustAsMap(getWithDefault(m, "boxes", data.Map{}))),
+		Sinks:   newSchedulerPool(mustAsMap(getWithDefault(m, "sinks", data.Map{}))),
+	}
+}
+
+func newSchedulerPool(m data.Map) SchedulerPool {
+	return SchedulerPool{
+		Size:         int(mustToInt(getWithDefault(m, "size", data.Int(0)))),
+		LockOSThread: mustToBool(getWithDefault(m, "lock_os_thread", data.False)),
+	}
+}
+
+// ToMap returns scheduler config information as data.Map.
+func (s *Scheduler) ToMap() data.Map {
+	return data.Map{
+		"enabled": data.Bool(s.Enabled),
+		"sources": s.Sources.toMap(),
+		"boxes":   s.Boxes.toMap(),
+		"sinks":   s.Sinks.toMap(),
+	}
+}
+
+func (p *SchedulerPool) toMap() data.Map {
+	return data.Map{
+		"size":           data.Int(p.Size),
+		"lock_os_thread": data.Bool(p.LockOSThread),
+	}
+}
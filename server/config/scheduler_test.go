@@ -0,0 +1,55 @@
+package config
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestScheduler(t *testing.T) {
+	Convey("Given a JSON config for scheduler section", t, func() {
+		Convey("When the config is valid", func() {
+			s, err := NewScheduler(toMap(`{"enabled":true,"boxes":{"size":2,"lock_os_thread":true},"sinks":{"size":1}}`))
+			So(err, ShouldBeNil)
+
+			Convey("Then it should have given parameters", func() {
+				So(s.Enabled, ShouldBeTrue)
+				So(s.Boxes.Size, ShouldEqual, 2)
+				So(s.Boxes.LockOSThread, ShouldBeTrue)
+				So(s.Sinks.Size, ShouldEqual, 1)
+				So(s.Sinks.LockOSThread, ShouldBeFalse)
+				So(s.Sources.Size, ShouldEqual, 0)
+			})
+		})
+
+		Convey("When the config only has required parameters", func() {
+			// no required parameter at the moment
+			s, err := NewScheduler(toMap(`{}`))
+
+			Convey("Then it should have given parameters and default values", func() {
+				So(err, ShouldBeNil)
+				So(s.Enabled, ShouldBeFalse)
+				So(s.Sources.Size, ShouldEqual, 0)
+				So(s.Boxes.Size, ShouldEqual, 0)
+				So(s.Sinks.Size, ShouldEqual, 0)
+			})
+		})
+
+		Convey("When the config has an undefined field", func() {
+			_, err := NewScheduler(toMap(`{"enabled":true,"streams":{"size":1}}`))
+
+			Convey("Then it should be invalid", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When validating size", func() {
+			for _, size := range []string{`-1`, `1.5`, `"1"`} {
+				size := size
+				Convey("Then it should reject "+size, func() {
+					_, err := NewScheduler(toMap(`{"boxes":{"size":` + size + `}}`))
+					So(err, ShouldNotBeNil)
+				})
+			}
+		})
+	})
+}
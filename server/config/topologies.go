@@ -1,6 +1,7 @@
 package config
 
 import (
+	"fmt"
 	"github.com/xeipuuv/gojsonschema"
 	"gopkg.in/sensorbee/sensorbee.v0/data"
 )
@@ -13,13 +14,92 @@ type Topology struct {
 
 	// BQLFile is a file path to the BQL file executed on start up.
 	BQLFile string `json:"bql_file" yaml:"bql_file"`
+
+	// TupleID is the name of the generator of tuple IDs assigned at sources.
+	// It's one of "uuidv7" and "snowflake". IDs aren't assigned when it's
+	// empty.
+	TupleID string `json:"tuple_id" yaml:"tuple_id"`
+
+	// Watchdog enables the watchdog which reports sinks and boxes whose
+	// latency is degrading. Reports are logged and emitted from
+	// "watchdog_events" sources.
+	Watchdog bool `json:"watchdog" yaml:"watchdog"`
+
+	// Resources are resources the topology declares for admission control.
+	Resources Resources `json:"resources" yaml:"resources"`
+
+	// Lineage has parameters of lineage recording of tuples.
+	Lineage Lineage `json:"lineage" yaml:"lineage"`
+
+	// WindowSpill has parameters of spilling large windows to disk.
+	WindowSpill WindowSpill `json:"window_spill" yaml:"window_spill"`
+
+	// ColumnarExecution makes boxes aggregating tuples process windows of
+	// fixed-schema streams as columnar batches. This is experimental.
+	ColumnarExecution bool `json:"columnar_execution" yaml:"columnar_execution"`
+
+	// SharedFilters makes streams created from the BQL file or by a batch of
+	// statements share the evaluation of predicates common to their WHERE
+	// clauses when they select from the same input.
+	SharedFilters bool `json:"shared_filters" yaml:"shared_filters"`
+
+	// Isolation is how the topology is isolated from other topologies in
+	// the server. It's one of "none" and "process". When it's "process", the
+	// topology runs in its own worker process supervised by the server so
+	// that a crash of the topology doesn't affect others. The default value
+	// is "none".
+	Isolation string `json:"isolation" yaml:"isolation"`
+
+	// Timezone is the time zone of the topology. It's an IANA time zone name
+	// such as "Asia/Tokyo" or a fixed offset such as "+09:00". Timestamps
+	// without time zones, e.g. ones read by sources, are interpreted in this
+	// time zone. The local time zone of the server is used when it's empty.
+	Timezone string `json:"timezone" yaml:"timezone"`
+}
+
+// Lineage has parameters of lineage recording. When it's enabled, boxes
+// aggregating or joining tuples record IDs of input tuples contributing to
+// each output tuple while tuple tracing is enabled. Tuple IDs must also be
+// enabled by TupleID.
+type Lineage struct {
+	// Enabled enables lineage recording.
+	Enabled bool `json:"enabled" yaml:"enabled"`
+
+	// MaxInputs is the maximum number of IDs of input tuples recorded for
+	// an output tuple. The default value is used when it's 0.
+	MaxInputs int `json:"max_inputs" yaml:"max_inputs"`
+
+	// MaxRecords is the maximum number of lineage records kept in the
+	// topology. The default value is used when it's 0.
+	MaxRecords int `json:"max_records" yaml:"max_records"`
+}
+
+// WindowSpill has parameters of spilling windows to disk. When it's
+// enabled, older rows of a window of a SELECT statement over a single stream
+// are written to segment files on disk when the window has more rows than
+// MaxRowsInMemory.
+type WindowSpill struct {
+	// Enabled enables spilling windows.
+	Enabled bool `json:"enabled" yaml:"enabled"`
+
+	// Dir is the directory in which segment files are created. The
+	// directory for temporary files is used when it's empty.
+	Dir string `json:"dir" yaml:"dir"`
+
+	// MaxRowsInMemory is the number of rows of a window kept in memory. The
+	// default value is used when it's 0.
+	MaxRowsInMemory int `json:"max_rows_in_memory" yaml:"max_rows_in_memory"`
+
+	// SegmentSize is the size of a segment file in bytes. The default value
+	// is used when it's 0.
+	SegmentSize int64 `json:"segment_size" yaml:"segment_size"`
 }
 
 // Topologies is a set of configuration of topologies.
 type Topologies map[string]*Topology
 
 var (
-	topologiesSchemaString = `{
+	topologiesSchemaString = fmt.Sprintf(`{
 	"type": "object",
 	"properties": {
 	},
@@ -32,6 +112,64 @@ var (
 						"bql_file": {
 							"type": "string",
 							"minLength": 1
+						},
+						"tuple_id": {
+							"type": "string",
+							"enum": ["", "uuidv7", "snowflake"]
+						},
+						"watchdog": {
+							"type": "boolean"
+						},
+						"resources": %v,
+						"lineage": {
+							"type": "object",
+							"properties": {
+								"enabled": {
+									"type": "boolean"
+								},
+								"max_inputs": {
+									"type": "integer",
+									"minimum": 0
+								},
+								"max_records": {
+									"type": "integer",
+									"minimum": 0
+								}
+							},
+							"additionalProperties": false
+						},
+						"window_spill": {
+							"type": "object",
+							"properties": {
+								"enabled": {
+									"type": "boolean"
+								},
+								"dir": {
+									"type": "string"
+								},
+								"max_rows_in_memory": {
+									"type": "integer",
+									"minimum": 0
+								},
+								"segment_size": {
+									"type": "integer",
+									"minimum": 0
+								}
+							},
+							"additionalProperties": false
+						},
+						"columnar_execution": {
+							"type": "boolean"
+						},
+						"shared_filters": {
+							"type": "boolean"
+						},
+						"isolation": {
+							"type": "string",
+							"enum": ["none", "process"]
+						},
+						"timezone": {
+							"type": "string"
 						}
 					},
 					"additionalProperties": false
@@ -42,7 +180,7 @@ var (
 			]
 		}
 	}
-}`
+}`, resourcesSchemaString)
 
 	// Because gojsonschema doesn't support partial schema validation, this
 	// has to be defined separately from
@@ -73,8 +211,17 @@ func newTopologies(m data.Map) Topologies {
 			conf = data.Map{}
 		}
 		t := &Topology{
-			Name:    name,
-			BQLFile: mustAsString(getWithDefault(mustAsMap(conf), "bql_file", data.String(""))),
+			Name:              name,
+			BQLFile:           mustAsString(getWithDefault(mustAsMap(conf), "bql_file", data.String(""))),
+			TupleID:           mustAsString(getWithDefault(mustAsMap(conf), "tuple_id", data.String(""))),
+			Watchdog:          mustToBool(getWithDefault(mustAsMap(conf), "watchdog", data.False)),
+			Resources:         newResources(mustAsMap(getWithDefault(mustAsMap(conf), "resources", data.Map{}))),
+			Lineage:           newLineage(mustAsMap(getWithDefault(mustAsMap(conf), "lineage", data.Map{}))),
+			WindowSpill:       newWindowSpill(mustAsMap(getWithDefault(mustAsMap(conf), "window_spill", data.Map{}))),
+			ColumnarExecution: mustToBool(getWithDefault(mustAsMap(conf), "columnar_execution", data.False)),
+			SharedFilters:     mustToBool(getWithDefault(mustAsMap(conf), "shared_filters", data.False)),
+			Isolation:         mustAsString(getWithDefault(mustAsMap(conf), "isolation", data.String("none"))),
+			Timezone:          mustAsString(getWithDefault(mustAsMap(conf), "timezone", data.String(""))),
 		}
 		ts[name] = t
 	}
@@ -87,8 +234,53 @@ func (ts *Topologies) ToMap() data.Map {
 	for k, v := range *ts {
 		v := v
 		m[k] = data.Map{
-			"bql_file": data.String(v.BQLFile),
+			"bql_file":           data.String(v.BQLFile),
+			"tuple_id":           data.String(v.TupleID),
+			"watchdog":           data.Bool(v.Watchdog),
+			"resources":          v.Resources.ToMap(),
+			"lineage":            v.Lineage.ToMap(),
+			"window_spill":       v.WindowSpill.ToMap(),
+			"columnar_execution": data.Bool(v.ColumnarExecution),
+			"shared_filters":     data.Bool(v.SharedFilters),
+			"isolation":          data.String(v.Isolation),
+			"timezone":           data.String(v.Timezone),
 		}
 	}
 	return m
 }
+
+func newLineage(m data.Map) Lineage {
+	return Lineage{
+		Enabled:    mustToBool(getWithDefault(m, "enabled", data.False)),
+		MaxInputs:  int(mustToInt(getWithDefault(m, "max_inputs", data.Int(0)))),
+		MaxRecords: int(mustToInt(getWithDefault(m, "max_records", data.Int(0)))),
+	}
+}
+
+// ToMap returns lineage config information as data.Map.
+func (l *Lineage) ToMap() data.Map {
+	return data.Map{
+		"enabled":     data.Bool(l.Enabled),
+		"max_inputs":  data.Int(l.MaxInputs),
+		"max_records": data.Int(l.MaxRecords),
+	}
+}
+
+func newWindowSpill(m data.Map) WindowSpill {
+	return WindowSpill{
+		Enabled:         mustToBool(getWithDefault(m, "enabled", data.False)),
+		Dir:             mustAsString(getWithDefault(m, "dir", data.String(""))),
+		MaxRowsInMemory: int(mustToInt(getWithDefault(m, "max_rows_in_memory", data.Int(0)))),
+		SegmentSize:     mustToInt(getWithDefault(m, "segment_size", data.Int(0))),
+	}
+}
+
+// ToMap returns window spill config information as data.Map.
+func (w *WindowSpill) ToMap() data.Map {
+	return data.Map{
+		"enabled":            data.Bool(w.Enabled),
+		"dir":                data.String(w.Dir),
+		"max_rows_in_memory": data.Int(w.MaxRowsInMemory),
+		"segment_size":       data.Int(w.SegmentSize),
+	}
+}
@@ -19,6 +19,8 @@ func TestTopologies(t *testing.T) {
 				So(ts["test2"].BQLFile, ShouldEqual, "/path/to/hoge.bql")
 				So(ts["test3"].Name, ShouldEqual, "test3")
 				So(ts["test3"].BQLFile, ShouldEqual, "")
+				So(ts["test3"].TupleID, ShouldEqual, "")
+				So(ts["test3"].Watchdog, ShouldBeFalse)
 			})
 		})
 
@@ -57,5 +59,172 @@ func TestTopologies(t *testing.T) {
 				})
 			}
 		})
+
+		Convey("When validating tuple_id", func() {
+			for _, g := range []string{"", "uuidv7", "snowflake"} {
+				Convey(fmt.Sprint("Then it should accept ", g), func() {
+					ts, err := NewTopologies(toMap(fmt.Sprintf(`{"test":{"tuple_id":"%v"}}`, g)))
+					So(err, ShouldBeNil)
+					So(ts["test"].TupleID, ShouldEqual, g)
+				})
+			}
+
+			for _, g := range [][]interface{}{{"unknown", `"uuidv4"`}, {"invalid type", 1}} {
+				Convey(fmt.Sprintf("Then it should reject %v value", g[0]), func() {
+					_, err := NewTopologies(toMap(fmt.Sprintf(`{"test":{"tuple_id":%v}}`, g[1])))
+					So(err, ShouldNotBeNil)
+				})
+			}
+		})
+
+		Convey("When validating watchdog", func() {
+			Convey("Then it should accept a bool", func() {
+				ts, err := NewTopologies(toMap(`{"test":{"watchdog":true}}`))
+				So(err, ShouldBeNil)
+				So(ts["test"].Watchdog, ShouldBeTrue)
+			})
+
+			Convey("Then it should reject a non-bool value", func() {
+				_, err := NewTopologies(toMap(`{"test":{"watchdog":"true"}}`))
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When validating lineage", func() {
+			Convey("Then it should accept parameters", func() {
+				ts, err := NewTopologies(toMap(`{"test":{"lineage":{"enabled":true,"max_inputs":10,"max_records":100}}}`))
+				So(err, ShouldBeNil)
+				So(ts["test"].Lineage, ShouldResemble, Lineage{Enabled: true, MaxInputs: 10, MaxRecords: 100})
+			})
+
+			Convey("Then it should be disabled by default", func() {
+				ts, err := NewTopologies(toMap(`{"test":{}}`))
+				So(err, ShouldBeNil)
+				So(ts["test"].Lineage.Enabled, ShouldBeFalse)
+			})
+
+			for _, l := range []string{`{"enabled":"true"}`, `{"max_inputs":-1}`, `{"max_records":1.5}`, `{"depth":1}`} {
+				l := l
+				Convey("Then it should reject "+l, func() {
+					_, err := NewTopologies(toMap(`{"test":{"lineage":` + l + `}}`))
+					So(err, ShouldNotBeNil)
+				})
+			}
+		})
+
+		Convey("When validating window_spill", func() {
+			Convey("Then it should accept parameters", func() {
+				ts, err := NewTopologies(toMap(`{"test":{"window_spill":{"enabled":true,"dir":"/tmp/spill","max_rows_in_memory":1000,"segment_size":1048576}}}`))
+				So(err, ShouldBeNil)
+				So(ts["test"].WindowSpill, ShouldResemble, WindowSpill{Enabled: true, Dir: "/tmp/spill", MaxRowsInMemory: 1000, SegmentSize: 1048576})
+			})
+
+			Convey("Then it should be disabled by default", func() {
+				ts, err := NewTopologies(toMap(`{"test":{}}`))
+				So(err, ShouldBeNil)
+				So(ts["test"].WindowSpill.Enabled, ShouldBeFalse)
+			})
+
+			for _, w := range []string{`{"enabled":1}`, `{"dir":1}`, `{"max_rows_in_memory":-1}`, `{"segment_size":"64MB"}`, `{"threshold":1}`} {
+				w := w
+				Convey("Then it should reject "+w, func() {
+					_, err := NewTopologies(toMap(`{"test":{"window_spill":` + w + `}}`))
+					So(err, ShouldNotBeNil)
+				})
+			}
+		})
+
+		Convey("When validating columnar_execution", func() {
+			Convey("Then it should accept a boolean", func() {
+				ts, err := NewTopologies(toMap(`{"test":{"columnar_execution":true}}`))
+				So(err, ShouldBeNil)
+				So(ts["test"].ColumnarExecution, ShouldBeTrue)
+			})
+
+			Convey("Then it should be disabled by default", func() {
+				ts, err := NewTopologies(toMap(`{"test":{}}`))
+				So(err, ShouldBeNil)
+				So(ts["test"].ColumnarExecution, ShouldBeFalse)
+			})
+
+			Convey("Then it should reject a non-boolean value", func() {
+				_, err := NewTopologies(toMap(`{"test":{"columnar_execution":"true"}}`))
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When validating shared_filters", func() {
+			Convey("Then it should accept a boolean", func() {
+				ts, err := NewTopologies(toMap(`{"test":{"shared_filters":true}}`))
+				So(err, ShouldBeNil)
+				So(ts["test"].SharedFilters, ShouldBeTrue)
+			})
+
+			Convey("Then it should be disabled by default", func() {
+				ts, err := NewTopologies(toMap(`{"test":{}}`))
+				So(err, ShouldBeNil)
+				So(ts["test"].SharedFilters, ShouldBeFalse)
+			})
+
+			Convey("Then it should reject a non-boolean value", func() {
+				_, err := NewTopologies(toMap(`{"test":{"shared_filters":"true"}}`))
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When validating isolation", func() {
+			Convey("Then it should accept process", func() {
+				ts, err := NewTopologies(toMap(`{"test":{"isolation":"process"}}`))
+				So(err, ShouldBeNil)
+				So(ts["test"].Isolation, ShouldEqual, "process")
+			})
+
+			Convey("Then it should be none by default", func() {
+				ts, err := NewTopologies(toMap(`{"test":{}}`))
+				So(err, ShouldBeNil)
+				So(ts["test"].Isolation, ShouldEqual, "none")
+			})
+
+			Convey("Then it should reject an unknown mode", func() {
+				_, err := NewTopologies(toMap(`{"test":{"isolation":"thread"}}`))
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When validating timezone", func() {
+			Convey("Then it should accept a time zone", func() {
+				ts, err := NewTopologies(toMap(`{"test":{"timezone":"Asia/Tokyo"}}`))
+				So(err, ShouldBeNil)
+				So(ts["test"].Timezone, ShouldEqual, "Asia/Tokyo")
+			})
+
+			Convey("Then it should be empty by default", func() {
+				ts, err := NewTopologies(toMap(`{"test":{}}`))
+				So(err, ShouldBeNil)
+				So(ts["test"].Timezone, ShouldBeEmpty)
+			})
+
+			Convey("Then it should reject a non-string value", func() {
+				_, err := NewTopologies(toMap(`{"test":{"timezone":9}}`))
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When validating resources", func() {
+			Convey("Then it should accept declared resources", func() {
+				ts, err := NewTopologies(toMap(`{"test":{"resources":{"max_nodes":10,"max_memory":1048576}}}`))
+				So(err, ShouldBeNil)
+				So(ts["test"].Resources.MaxNodes, ShouldEqual, 10)
+				So(ts["test"].Resources.MaxMemory, ShouldEqual, 1048576)
+			})
+
+			for _, r := range []string{`{"max_nodes":-1}`, `{"max_memory":"1MB"}`, `{"cpu":1}`} {
+				r := r
+				Convey("Then it should reject "+r, func() {
+					_, err := NewTopologies(toMap(`{"test":{"resources":` + r + `}}`))
+					So(err, ShouldNotBeNil)
+				})
+			}
+		})
 	})
 }
@@ -21,9 +21,20 @@ import (
 type Context struct {
 	*jasco.Context
 
-	udsStorage udf.UDSStorage
-	topologies TopologyRegistry
-	config     *config.Config
+	udsStorage  udf.UDSStorage
+	topologies  TopologyRegistry
+	namespaces  *NamespaceRegistry
+	config      *config.Config
+	scheduler   *core.Scheduler
+	admission   *admissionController
+	audit       *auditLog
+	logs        *topologyLogs
+	history     *statusHistory
+	replication *replicator
+	uploads     *stateUploadRegistry
+	// apiVersion is the version of the API to which the request is sent.
+	// It's 0 when the request isn't sent to the versioned API.
+	apiVersion int
 	// logger is used by core.Context, not for the server's Context. This logger
 	// can be shared with jasco.Context.
 	logger *logrus.Logger
@@ -44,12 +55,54 @@ type ContextGlobalVariables struct {
 	// LogDestination is a writer to which logs are written.
 	LogDestination io.WriteCloser
 
-	// Topologies is a registry which manages topologies to support multi
-	// tenancy.
+	// Topologies is a registry which manages topologies in the default
+	// namespace.
 	Topologies TopologyRegistry
 
+	// Namespaces manages namespaces isolating topologies of tenants. The
+	// default namespace in it always uses Topologies as its registry. A
+	// namespace having its own registries of sources, sinks, UDSs, or UDFs
+	// can be registered before calling SetUpContextAndRouter.
+	Namespaces *NamespaceRegistry
+
 	// Config has configuration parameters.
 	Config *config.Config
+
+	// Scheduler is shared by all topologies in the server. It's nil when
+	// the scheduler is disabled.
+	Scheduler *core.Scheduler
+
+	// admission reserves resources declared by topologies. Topologies
+	// aren't limited when it's nil.
+	admission *admissionController
+
+	// audit records operations which modify topologies. Nothing is recorded
+	// when it's nil.
+	audit *auditLog
+
+	// logs keeps recent log entries of each topology. Nothing is kept when
+	// it's nil.
+	logs *topologyLogs
+
+	// history keeps samples of statuses of nodes. Nothing is kept when it's
+	// nil or it isn't started.
+	history *statusHistory
+
+	// replication replicates states from a primary server to a standby
+	// server. It's nil when the replication is disabled.
+	replication *replicator
+
+	// uploads manages uploads of saved states. It's shared by all
+	// namespaces.
+	uploads *stateUploadRegistry
+
+	// udsStorage is the storage of UDSs set up by SetUpContextAndRouter. It's
+	// shared with the gRPC API.
+	udsStorage udf.UDSStorage
+
+	// workerTopology is the name of the topology hosted by the process when
+	// it's a worker process. Only the topology is set up in that case.
+	workerTopology string
 }
 
 // SetUpContextGlobalVariables create a new ContextGlobalVariables from a config.
@@ -76,29 +129,85 @@ func SetUpContextGlobalVariables(conf *config.Config) (*ContextGlobalVariables,
 	}()
 	logger.Out = w
 
+	o := &conf.Network.Outbound
+	if err := bql.SetGlobalOutboundConfig(&bql.OutboundConfig{
+		Proxy:         o.Proxy,
+		NoProxy:       o.NoProxy,
+		CACertFile:    o.CACertFile,
+		TLSServerName: o.TLSServerName,
+		TLSMinVersion: o.TLSMinVersion,
+	}); err != nil {
+		return nil, fmt.Errorf("the outbound section of the network config is invalid: %v", err)
+	}
+
+	topologies := NewDefaultTopologyRegistry()
+	namespaces, err := newNamespaceRegistry(topologies, conf.Namespaces)
+	if err != nil {
+		return nil, err
+	}
+
 	closeWriter = false
 	return &ContextGlobalVariables{
 		Logger:         logger,
 		LogDestination: w,
-		Topologies:     NewDefaultTopologyRegistry(),
+		Topologies:     topologies,
+		Namespaces:     namespaces,
 		Config:         conf,
+		Scheduler:      newScheduler(conf.Scheduler),
+		admission:      newAdmissionController(conf.Admission),
+		audit:          newAuditLog(logger),
+		logs:           newTopologyLogs(),
+		history:        newStatusHistory(statusHistoryInterval, statusHistoryRetention),
+		replication:    newReplicator(conf.Replication, logger),
+		uploads:        newStateUploadRegistry(stateUploadTimeout),
 	}, nil
 }
 
+func newScheduler(conf *config.Scheduler) *core.Scheduler {
+	if !conf.Enabled {
+		return nil
+	}
+	pool := func(p *config.SchedulerPool) core.SchedulerPoolConfig {
+		return core.SchedulerPoolConfig{
+			Size:         p.Size,
+			LockOSThread: p.LockOSThread,
+		}
+	}
+	return core.NewScheduler(&core.SchedulerConfig{
+		Sources: pool(&conf.Sources),
+		Boxes:   pool(&conf.Boxes),
+		Sinks:   pool(&conf.Sinks),
+	})
+}
+
 // SetUpContextAndRouter creates a router of the API server and its context.
 // jascoRoot is a root router returned from jasco.New.
 //
 // This function returns a new web.Router. Don't use the router returned from
 // this function as a handler of HTTP server, but use jascoRoot instead.
 func SetUpContextAndRouter(prefix string, jascoRoot *web.Router, gvariables *ContextGlobalVariables) (*web.Router, error) {
+	udsStorage, err := setUpUDSStorage(&gvariables.Config.Storage.UDS)
+	if err != nil {
+		return nil, err
+	}
+	gvariables.udsStorage = udsStorage
+	if gvariables.uploads == nil {
+		gvariables.uploads = newStateUploadRegistry(stateUploadTimeout)
+	}
 	gvars := *gvariables
-	udsStorage, err := setUpUDSStorage(&gvars.Config.Storage.UDS)
+
+	if gvars.Namespaces == nil {
+		gvars.Namespaces = NewNamespaceRegistry(gvars.Topologies)
+	}
+	defaultNamespace, err := gvars.Namespaces.Lookup(DefaultNamespace)
 	if err != nil {
 		return nil, err
 	}
+	// Topologies may have been replaced after creating the registry.
+	defaultNamespace.Topologies = gvars.Topologies
 
 	// Topologies should be created after setting up everything necessary for it.
-	if err := setUpTopologies(gvars.Logger, gvars.Topologies, gvars.Config, udsStorage); err != nil {
+	if err := setUpTopologies(gvars.Logger, defaultNamespace, gvars.Config, gvars.Scheduler, gvars.admission, gvars.audit, gvars.logs, udsStorage, gvars.workerTopology); err != nil {
 		return nil, err
 	}
 
@@ -107,7 +216,15 @@ func SetUpContextAndRouter(prefix string, jascoRoot *web.Router, gvariables *Con
 		c.logger = gvars.Logger
 		c.udsStorage = udsStorage
 		c.topologies = gvars.Topologies
+		c.namespaces = gvars.Namespaces
 		c.config = gvars.Config
+		c.scheduler = gvars.Scheduler
+		c.admission = gvars.admission
+		c.audit = gvars.audit
+		c.logs = gvars.logs
+		c.history = gvars.history
+		c.replication = gvars.replication
+		c.uploads = gvars.uploads
 		next(rw, req)
 	})
 	return router, nil
@@ -130,11 +247,12 @@ func setUpUDSStorage(conf *config.UDSStorage) (udf.UDSStorage, error) {
 	}
 }
 
-func setUpTopologies(logger *logrus.Logger, r TopologyRegistry, conf *config.Config, us udf.UDSStorage) error {
+func setUpTopologies(logger *logrus.Logger, ns *Namespace, conf *config.Config, sched *core.Scheduler,
+	admission *admissionController, audit *auditLog, logs *topologyLogs, us udf.UDSStorage, workerTopology string) error {
 	stopAll := true
 	defer func() {
 		if stopAll {
-			ts, err := r.List()
+			ts, err := ns.Topologies.List()
 			if err != nil {
 				logger.WithField("err", err).Error("Cannot list topologies for clean up")
 				return
@@ -152,12 +270,23 @@ func setUpTopologies(logger *logrus.Logger, r TopologyRegistry, conf *config.Con
 	}()
 
 	for name := range conf.Topologies {
+		if !hostsTopology(conf, name, workerTopology) {
+			continue
+		}
 		logger.WithField("topology", name).Info("Setting up the topology")
-		tb, err := setUpTopology(logger, name, conf, us)
+		if err := admission.admit(ns.qualifiedName(name), conf.Topologies[name].Resources); err != nil {
+			logger.WithFields(logrus.Fields{
+				"err":      err,
+				"topology": name,
+			}).Error("Cannot admit the topology")
+			return err
+		}
+		tb, stmts, err := setUpTopology(logger, ns, name, conf, sched, logs, us)
 		if err != nil {
 			return err
 		}
-		if err := r.Register(name, tb); err != nil {
+		audit.record(ns.qualifiedName(name), auditActor{}, "create", stmts, nil)
+		if err := ns.Topologies.Register(name, tb); err != nil {
 			logger.WithFields(logrus.Fields{
 				"err":      err,
 				"topology": name,
@@ -170,31 +299,114 @@ func setUpTopologies(logger *logrus.Logger, r TopologyRegistry, conf *config.Con
 	return nil
 }
 
-func setUpTopology(logger *logrus.Logger, name string, conf *config.Config, us udf.UDSStorage) (*bql.TopologyBuilder, error) {
+// hostsTopology returns true when the process hosts the topology defined in
+// the config. A worker process only hosts its topology, and other processes
+// host topologies which don't run in worker processes.
+func hostsTopology(conf *config.Config, name, workerTopology string) bool {
+	if workerTopology != "" {
+		return name == workerTopology
+	}
+	return conf.Topologies[name].Isolation != isolationProcess
+}
+
+// newTopology creates a topology which isn't defined in the config, such as
+// one created through the API, and its topology builder. The log buffer of the
+// topology needs to be added to logs after the topology is registered.
+// constants are initial values of constants of the topology and can be nil.
+func newTopology(logger *logrus.Logger, ns *Namespace, name string, conf *config.Config, sched *core.Scheduler,
+	logs *topologyLogs, us udf.UDSStorage, res *config.Resources, constants data.Map) (*bql.TopologyBuilder, *logBuffer, error) {
+	topologyLogger, logBuf := logs.newLogger(logger)
 	cc := &core.ContextConfig{
-		Logger: logger,
+		Logger:    topologyLogger,
+		Scheduler: sched,
+		MaxNodes:  res.MaxNodes,
+		Constants: constants,
 	}
+	// TODO: Be careful of race conditions on these fields.
 	cc.Flags.DroppedTupleLog.Set(conf.Logging.LogDroppedTuples)
 	cc.Flags.DestinationlessTupleLog.Set(conf.Logging.LogDestinationlessTuples)
 	cc.Flags.DroppedTupleSummarization.Set(conf.Logging.SummarizeDroppedTuples)
 
 	tp, err := core.NewDefaultTopology(core.NewContext(cc), name)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	tb, err := ns.newTopologyBuilder(tp, us)
+	if err != nil {
+		if err := tp.Stop(); err != nil {
+			logger.WithFields(logrus.Fields{
+				"err":      err,
+				"topology": name,
+			}).Error("Cannot stop the topology")
+		}
+		return nil, nil, err
+	}
+	return tb, logBuf, nil
+}
+
+// setUpTopology creates a topology defined in the config. It also returns
+// statements executed in the BQL file of the topology.
+func setUpTopology(logger *logrus.Logger, ns *Namespace, name string, conf *config.Config, sched *core.Scheduler,
+	logs *topologyLogs, us udf.UDSStorage) (*bql.TopologyBuilder, []string, error) {
+	topologyLogger, logBuf := logs.newLogger(logger)
+	cc := &core.ContextConfig{
+		Logger:    topologyLogger,
+		Scheduler: sched,
+		MaxNodes:  conf.Topologies[name].Resources.MaxNodes,
+	}
+	cc.Flags.DroppedTupleLog.Set(conf.Logging.LogDroppedTuples)
+	cc.Flags.DestinationlessTupleLog.Set(conf.Logging.LogDestinationlessTuples)
+	cc.Flags.DroppedTupleSummarization.Set(conf.Logging.SummarizeDroppedTuples)
+	if g := conf.Topologies[name].TupleID; g != "" {
+		gen, err := core.NewTupleIDGenerator(g)
+		if err != nil {
+			return nil, nil, err
+		}
+		cc.TupleIDGenerator = gen
+	}
+	if tz := conf.Topologies[name].Timezone; tz != "" {
+		loc, err := core.LoadLocation(tz)
+		if err != nil {
+			return nil, nil, err
+		}
+		cc.Location = loc
+	}
+	if conf.Topologies[name].Watchdog {
+		cc.Watchdog = &core.WatchdogConfig{}
+	}
+	if l := conf.Topologies[name].Lineage; l.Enabled {
+		cc.Lineage = &core.LineageConfig{
+			MaxInputs:  l.MaxInputs,
+			MaxRecords: l.MaxRecords,
+		}
+	}
+	if w := conf.Topologies[name].WindowSpill; w.Enabled {
+		cc.WindowSpill = &core.WindowSpillConfig{
+			Dir:             w.Dir,
+			MaxRowsInMemory: w.MaxRowsInMemory,
+			SegmentSize:     w.SegmentSize,
+		}
+	}
+	cc.ColumnarExecution = conf.Topologies[name].ColumnarExecution
+	cc.SharedFilters = conf.Topologies[name].SharedFilters
+
+	tp, err := core.NewDefaultTopology(core.NewContext(cc), name)
+	if err != nil {
+		return nil, nil, err
 	}
-	tb, err := bql.NewTopologyBuilder(tp)
+	tb, err := ns.newTopologyBuilder(tp, us)
 	if err != nil {
 		logger.WithFields(logrus.Fields{
 			"err":      err,
 			"topology": name,
 		}).Error("Cannot create a topology builder")
-		return nil, err
+		return nil, nil, err
 	}
-	tb.UDSStorage = us
+	logs.add(ns.qualifiedName(name), logBuf)
 
 	bqlFilePath := conf.Topologies[name].BQLFile
 	if bqlFilePath == "" {
-		return tb, nil
+		return tb, nil, nil
 	}
 
 	shouldStop := true
@@ -216,27 +428,62 @@ func setUpTopology(logger *logrus.Logger, name string, conf *config.Config, us u
 			"topology": name,
 			"path":     bqlFilePath,
 		}).Error("Cannot read a BQL file")
-		return nil, err
+		return nil, nil, err
 	}
 
-	// TODO: improve error handling
 	bp := parser.New()
-	stmts, err := bp.ParseStmts(string(queries))
+	stmts, srcs, err := bp.ParseStmtsWithSources(string(queries))
 	if err != nil {
-		return nil, err
+		logger.WithFields(logrus.Fields{
+			"err":      err,
+			"topology": name,
+			"path":     bqlFilePath,
+		}).Error("Cannot parse a BQL file")
+		return nil, nil, err
+	}
+	for _, src := range srcs {
+		src.File = bqlFilePath
+	}
+
+	stmts, srcs, warnings, err := tb.ValidateStmtsWithSources(stmts, srcs)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"err":      err,
+			"topology": name,
+			"path":     bqlFilePath,
+		}).Error("Invalid statements in a BQL file")
+		return nil, nil, err
+	}
+	for _, w := range warnings {
+		logger.WithFields(logrus.Fields{
+			"topology": name,
+			"node":     w.Node,
+			"kind":     w.Kind,
+		}).Warn(w.Message)
+	}
+	for _, f := range tb.OptimizeStmts(stmts) {
+		logger.WithFields(logrus.Fields{
+			"topology":  name,
+			"input":     f.Input,
+			"predicate": f.Predicate,
+			"streams":   f.Streams,
+		}).Info("Streams share a filter")
 	}
 
-	for _, stmt := range stmts {
-		if _, err := tb.AddStmt(stmt); err != nil {
+	strs := make([]string, len(stmts))
+	for i, stmt := range stmts {
+		strs[i] = fmt.Sprint(stmt)
+		if _, err := tb.AddStmtWithSource(stmt, srcs[i]); err != nil {
 			logger.WithFields(logrus.Fields{
 				"err":      err,
 				"topology": name,
 				"stmt":     stmt,
+				"source":   srcs[i].String(),
 			}).Error("Cannot add a statement to the topology")
-			return nil, err
+			return nil, nil, err
 		}
 	}
 
 	shouldStop = false
-	return tb, nil
+	return tb, strs, nil
 }
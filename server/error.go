@@ -1,5 +1,15 @@
 package server
 
+import (
+	"net/http"
+	"strings"
+
+	"gopkg.in/pfnet/jasco.v1"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"gopkg.in/sensorbee/sensorbee.v0/server/response"
+)
+
 const (
 	// requestResourceNotFoundErrorCode means that the request URI was
 	// correct but the requested resource was not found.
@@ -14,15 +24,139 @@ const (
 	// bqlStmtParseErrorCode is returned when a statement cannot be parsed.
 	// When this error happens, Error.Meta should have parse error messages
 	// in Meta["parse_errors"] as an array of strings and the statement which
-	// couldn't be parsed in Meta["statement"].
+	// couldn't be parsed in Meta["statement"]. Meta["line"] and
+	// Meta["column"] have the 1-origin position of the error if it's known.
 	bqlStmtParseErrorCode = "E0006"
 
 	// bqlStmtProcessingErrorCode is returned when a statement cannot be
 	// processed successfully. When this error happens, Error.Meta should have
 	// an error message in Meta["error"] and statement in Meta["statement"].
+	// Meta["error_code"] has a machine-readable code of the error such as
+	// "not_found" when it's known. See newBQLStmtError for details.
 	bqlStmtProcessingErrorCode = "E0007"
 
 	// nonWebSocketRequestErrorCode is returned when a requested action only
 	// supports WebSocket and a request is a regular HTTP request.
 	nonWebSocketRequestErrorCode = "E0008"
+
+	// resourceLimitExceededErrorCode is returned when a topology cannot be
+	// created because resources it declares would exceed limits of the
+	// server. When this error happens, Error.Meta should have an array of
+	// objects describing exceeded limits in Meta["violations"]. Each object
+	// has "resource", "requested", "used", and "limit".
+	resourceLimitExceededErrorCode = "E0009"
+
+	// unauthorizedErrorCode is returned when a request to a namespace
+	// requiring a token doesn't have a valid one.
+	unauthorizedErrorCode = "E0010"
+
+	// workerUnavailableErrorCode is returned when a request to a topology
+	// running in a worker process cannot be forwarded to the process or the
+	// process crashes while processing it.
+	workerUnavailableErrorCode = "E0012"
+
+	// replicationRoleErrorCode is returned when a request to the replication
+	// isn't supported by the replication role of the server, e.g. when a
+	// standby server is requested to send change records.
+	replicationRoleErrorCode = "E0013"
+
+	// stateUploadErrorCode is returned when a chunk of an upload of a state
+	// cannot be written or the upload cannot be committed. When this error
+	// happens, Error.Meta should have an error message in Meta["error"].
+	stateUploadErrorCode = "E0014"
 )
+
+// newBQLStmtError creates an error with bqlStmtProcessingErrorCode. Its HTTP
+// status is chosen from the code of err returned by core.ErrorCode:
+//
+//	- not_found: 404
+//	- already_exists and invalid_state: 409
+//	- resource_exhausted: 429
+//	- others: 400
+func newBQLStmtError(msg string, err error) *jasco.Error {
+	code := core.ErrorCode(err)
+	status := http.StatusBadRequest
+	switch code {
+	case core.ErrCodeNotFound:
+		status = http.StatusNotFound
+	case core.ErrCodeAlreadyExists, core.ErrCodeInvalidState:
+		status = http.StatusConflict
+	case core.ErrCodeResourceExhausted:
+		status = http.StatusTooManyRequests
+	}
+
+	e := jasco.NewError(bqlStmtProcessingErrorCode, msg, status, err)
+	e.Meta["error"] = err.Error()
+	if code != "" {
+		e.Meta["error_code"] = code
+	}
+	return e
+}
+
+// v2ErrorCodes are structured error codes of API version 2 corresponding to
+// error codes of version 1. A structured error code has the category and
+// the reason of the error separated by a dot.
+var v2ErrorCodes = map[string]string{
+	requestResourceNotFoundErrorCode: "request.not_found",
+	formValidationErrorCode:          "request.invalid",
+	bqlStmtParseErrorCode:            "bql.parse_error",
+	bqlStmtProcessingErrorCode:       "bql.error",
+	nonWebSocketRequestErrorCode:     "request.websocket_required",
+	resourceLimitExceededErrorCode:   "resource.limit_exceeded",
+	unauthorizedErrorCode:            "auth.unauthorized",
+	internalServerErrorCode:          "server.internal",
+	workerUnavailableErrorCode:       "worker.unavailable",
+	replicationRoleErrorCode:         "replication.invalid_role",
+	stateUploadErrorCode:             "state.upload_failed",
+}
+
+// v2ErrorCode returns the structured error code of API version 2
+// corresponding to an error code of version 1. bqlCode is the code returned
+// by core.ErrorCode for errors of BQL statements and it replaces the reason
+// of bqlStmtProcessingErrorCode when it isn't empty, e.g. "bql.not_found".
+// An unknown code is returned as is.
+func v2ErrorCode(code, bqlCode string) string {
+	if code == bqlStmtProcessingErrorCode && bqlCode != "" {
+		return "bql." + bqlCode
+	}
+	if c, ok := v2ErrorCodes[code]; ok {
+		return c
+	}
+	return code
+}
+
+// isV2Request returns true when the request is sent to API version 2.
+func isV2Request(req *http.Request) bool {
+	return strings.Contains(req.URL.Path, "/api/v2/")
+}
+
+// newErrorResponse creates an error response which is written without
+// jasco, e.g. by middleware. Its code is converted to the structured one
+// when the request is sent to API version 2.
+func newErrorResponse(req *http.Request, code, msg string) *response.Error {
+	e := &response.Error{
+		Code:      code,
+		Message:   msg,
+		RequestID: RequestIDFromContext(req.Context()),
+		Meta:      data.Map{},
+	}
+	if isV2Request(req) {
+		e.Code = v2ErrorCode(code, "")
+		e.Meta["legacy_code"] = data.String(code)
+	}
+	return e
+}
+
+// RenderError renders the error. The code of the error is converted to the
+// structured one when the request is sent to API version 2.
+func (c *Context) RenderError(e *jasco.Error) {
+	if c.apiVersion >= 2 {
+		if e.Meta == nil {
+			e.Meta = map[string]interface{}{}
+		}
+		bqlCode, _ := e.Meta["error_code"].(string)
+		e.Meta["legacy_code"] = e.Code
+		e.Code = v2ErrorCode(e.Code, bqlCode)
+	}
+	c.Context.RenderError(e)
+}
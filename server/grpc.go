@@ -0,0 +1,533 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gopkg.in/sensorbee/sensorbee.v0/bql"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/parser"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"gopkg.in/sensorbee/sensorbee.v0/server/config"
+	"gopkg.in/sensorbee/sensorbee.v0/server/grpcapi"
+)
+
+// grpcService implements the gRPC API. It provides the same operations on
+// topologies as the HTTP API and shares topologies, namespaces, and logs with
+// it.
+type grpcService struct {
+	grpcapi.UnimplementedSensorBeeServer
+	gvars *ContextGlobalVariables
+
+	// stopping is closed when the server starts shutting down so that Select
+	// calls don't block graceful shutdown.
+	stopping <-chan struct{}
+}
+
+// newGRPCServer creates a gRPC server serving the API on topologies in gvars.
+// Panics in handlers are recovered and calls are logged as access logs when
+// logging.log_access is true.
+func newGRPCServer(gvars *ContextGlobalVariables, stopping <-chan struct{}) *grpc.Server {
+	s := &grpcService{
+		gvars:    gvars,
+		stopping: stopping,
+	}
+	gs := grpc.NewServer(
+		grpc.UnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+			handler grpc.UnaryHandler) (res interface{}, err error) {
+			err = s.intercept(ctx, info.FullMethod, func() error {
+				res, err = handler(ctx, req)
+				return err
+			})
+			return
+		}),
+		grpc.StreamInterceptor(func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+			handler grpc.StreamHandler) error {
+			return s.intercept(ss.Context(), info.FullMethod, func() error {
+				return handler(srv, ss)
+			})
+		}),
+	)
+	grpcapi.RegisterSensorBeeServer(gs, s)
+	return gs
+}
+
+// intercept calls f while recovering from a panic and logs the call.
+func (s *grpcService) intercept(ctx context.Context, method string, f func() error) (err error) {
+	start := time.Now()
+	defer func() {
+		if e := recover(); e != nil {
+			stack := make([]byte, 64*1024)
+			stack = stack[:runtime.Stack(stack, false)]
+			s.gvars.Logger.WithFields(logrus.Fields{
+				"method": method,
+				"err":    fmt.Sprint(e),
+				"stack":  string(stack),
+			}).Error("Recovered from a panic while processing a gRPC call")
+			err = status.Error(codes.Internal, "an internal server error occurred")
+		}
+
+		if !s.gvars.Config.Logging.LogAccess {
+			return
+		}
+		code := status.Code(err)
+		l := s.gvars.Logger.WithFields(logrus.Fields{
+			"method":      method,
+			"code":        code.String(),
+			"elapsed":     time.Since(start).String(),
+			"remote_addr": grpcAuditActor(ctx).RemoteAddr,
+		})
+		if code == codes.Internal || code == codes.Unknown {
+			l.WithField("err", err).Warn("gRPC access")
+		} else {
+			l.Info("gRPC access")
+		}
+	}()
+	return f()
+}
+
+// grpcAuditActor extracts the actor from a gRPC call. The user is taken from
+// "x-remote-user" metadata set by a proxy in front of the server.
+func grpcAuditActor(ctx context.Context) auditActor {
+	a := auditActor{}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if u := md.Get("x-remote-user"); len(u) > 0 {
+			a.User = u[0]
+		}
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		a.RemoteAddr = p.Addr.String()
+	}
+	return a
+}
+
+// namespace looks up the namespace and checks the token in "authorization"
+// metadata of the call. An empty name means the default namespace.
+func (s *grpcService) namespace(ctx context.Context, name string) (*Namespace, error) {
+	if name == "" {
+		name = DefaultNamespace
+	}
+	ns, err := s.gvars.Namespaces.Lookup(name)
+	if err != nil {
+		if core.IsNotExist(err) {
+			return nil, status.Errorf(codes.NotFound, "the namespace '%v' doesn't exist", name)
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	token := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		for _, a := range md.Get("authorization") {
+			if len(a) > 7 && strings.EqualFold(a[:7], "Bearer ") {
+				token = a[7:]
+			}
+		}
+	}
+	if !ns.authorize(token) {
+		return nil, status.Errorf(codes.Unauthenticated,
+			"the call doesn't have a valid token for the namespace '%v'", ns.Name)
+	}
+	return ns, nil
+}
+
+// topology returns the topology in the namespace.
+func (s *grpcService) topology(ctx context.Context, namespace, name string) (*Namespace, *bql.TopologyBuilder, error) {
+	ns, err := s.namespace(ctx, namespace)
+	if err != nil {
+		return nil, nil, err
+	}
+	tb, err := ns.Topologies.Lookup(name)
+	if err != nil {
+		if core.IsNotExist(err) {
+			return nil, nil, status.Errorf(codes.NotFound, "the topology '%v' doesn't exist", name)
+		}
+		return nil, nil, status.Error(codes.Internal, err.Error())
+	}
+	return ns, tb, nil
+}
+
+func (s *grpcService) newTopologyResponse(ns *Namespace, tb *bql.TopologyBuilder) *grpcapi.Topology {
+	name := tb.Topology().Name()
+	return &grpcapi.Topology{
+		Name:    name,
+		Version: s.gvars.audit.version(ns.qualifiedName(name)),
+	}
+}
+
+func (s *grpcService) ListTopologies(ctx context.Context, req *grpcapi.ListTopologiesRequest) (*grpcapi.ListTopologiesResponse, error) {
+	ns, err := s.namespace(ctx, req.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	ts, err := ns.Topologies.List()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	res := &grpcapi.ListTopologiesResponse{
+		Topologies: make([]*grpcapi.Topology, 0, len(ts)),
+	}
+	for _, tb := range ts {
+		res.Topologies = append(res.Topologies, s.newTopologyResponse(ns, tb))
+	}
+	sort.Slice(res.Topologies, func(i, j int) bool {
+		return res.Topologies[i].Name < res.Topologies[j].Name
+	})
+	return res, nil
+}
+
+func (s *grpcService) GetTopology(ctx context.Context, req *grpcapi.GetTopologyRequest) (*grpcapi.Topology, error) {
+	ns, tb, err := s.topology(ctx, req.Namespace, req.Name)
+	if err != nil {
+		return nil, err
+	}
+	return s.newTopologyResponse(ns, tb), nil
+}
+
+func (s *grpcService) CreateTopology(ctx context.Context, req *grpcapi.CreateTopologyRequest) (*grpcapi.Topology, error) {
+	ns, err := s.namespace(ctx, req.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	if err := core.ValidateSymbol(req.Name); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "the name of the topology is invalid: %v", err)
+	}
+	res := &config.Resources{}
+	if req.Resources != nil {
+		m, err := grpcapi.ToDataMap(req.Resources.Fields)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "resources has invalid values: %v", err)
+		}
+		if res, err = config.NewResources(m); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "resources has invalid values: %v", err)
+		}
+	}
+	var constants data.Map
+	if req.Constants != nil {
+		m, err := grpcapi.ToDataMap(req.Constants.Fields)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "constants has invalid values: %v", err)
+		}
+		for n := range m {
+			if err := core.ValidateConstantName(n); err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "constants has an invalid name: %v", err)
+			}
+		}
+		constants = m
+	}
+
+	name := ns.qualifiedName(req.Name)
+	if err := s.gvars.admission.admit(name, *res); err != nil {
+		return nil, admissionErrorStatus(err)
+	}
+	admitted := false
+	defer func() {
+		if !admitted {
+			s.gvars.admission.release(name)
+		}
+	}()
+
+	tb, logs, err := newTopology(s.gvars.Logger, ns, req.Name, s.gvars.Config, s.gvars.Scheduler,
+		s.gvars.logs, s.gvars.udsStorage, res, constants)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot create a new topology: %v", err)
+	}
+	if err := ns.Topologies.Register(req.Name, tb); err != nil {
+		if err := tb.Topology().Stop(); err != nil {
+			s.gvars.Logger.WithFields(logrus.Fields{
+				"err":      err,
+				"topology": name,
+			}).Error("Cannot stop the created topology")
+		}
+		if os.IsExist(err) {
+			return nil, status.Errorf(codes.AlreadyExists, "the name '%v' is already taken", req.Name)
+		}
+		return nil, status.Errorf(codes.Internal, "cannot register the topology: %v", err)
+	}
+
+	admitted = true
+	s.gvars.logs.add(name, logs)
+	s.gvars.audit.record(name, grpcAuditActor(ctx), "create", nil, nil)
+	return s.newTopologyResponse(ns, tb), nil
+}
+
+// admissionErrorStatus converts an error returned from admissionController
+// to a status.
+func admissionErrorStatus(err error) error {
+	if os.IsExist(err) {
+		return status.Error(codes.AlreadyExists, "the name is already taken")
+	}
+	ae, ok := err.(*admissionError)
+	if !ok {
+		return status.Errorf(codes.Internal, "cannot admit the topology: %v", err)
+	}
+	if len(ae.Undeclared) > 0 {
+		return status.Errorf(codes.InvalidArgument, "the topology doesn't declare required resources: %v", err)
+	}
+	return status.Errorf(codes.ResourceExhausted, "the topology would exceed resource limits: %v", err)
+}
+
+func (s *grpcService) DeleteTopology(ctx context.Context, req *grpcapi.DeleteTopologyRequest) (*grpcapi.DeleteTopologyResponse, error) {
+	ns, err := s.namespace(ctx, req.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	tb, err := ns.Topologies.Unregister(req.Name)
+	if err != nil && !core.IsNotExist(err) {
+		return nil, status.Errorf(codes.Internal, "cannot unregister the topology: %v", err)
+	}
+
+	res := &grpcapi.DeleteTopologyResponse{Stopped: true}
+	if tb != nil {
+		name := ns.qualifiedName(req.Name)
+		s.gvars.admission.release(name)
+		err := tb.Topology().Stop()
+		if err != nil {
+			res.Stopped = false
+			s.gvars.Logger.WithFields(logrus.Fields{
+				"err":      err,
+				"topology": name,
+			}).Error("Cannot stop the topology")
+		}
+		s.gvars.audit.record(name, grpcAuditActor(ctx), "destroy", nil, err)
+		s.gvars.logs.remove(name)
+	}
+	return res, nil
+}
+
+// parseGRPCQueries binds parameters to placeholders in queries and parses
+// them.
+func parseGRPCQueries(queries string, params []*grpcapi.Value) ([]interface{}, error) {
+	if len(params) > 0 {
+		ps := make(data.Array, len(params))
+		for i, p := range params {
+			v, err := grpcapi.ToDataValue(p)
+			if err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "parameter %v is invalid: %v", i+1, err)
+			}
+			ps[i] = v
+		}
+		q, err := parser.BindParameters(queries, ps)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "cannot bind parameters to placeholders: %v", err)
+		}
+		queries = q
+	}
+
+	stmts, err := parser.New().ParseStmts(queries)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "cannot parse BQL statements: %v", err)
+	}
+	return stmts, nil
+}
+
+// bqlStmtErrorStatus converts an error returned from a BQL statement to
+// a status. Its code is chosen from the code of err returned by
+// core.ErrorCode in the same way as newBQLStmtError.
+func bqlStmtErrorStatus(stmt interface{}, err error) error {
+	c := codes.InvalidArgument
+	switch core.ErrorCode(err) {
+	case core.ErrCodeNotFound:
+		c = codes.NotFound
+	case core.ErrCodeAlreadyExists:
+		c = codes.AlreadyExists
+	case core.ErrCodeInvalidState:
+		c = codes.FailedPrecondition
+	case core.ErrCodeResourceExhausted:
+		c = codes.ResourceExhausted
+	}
+	return status.Errorf(c, "cannot process a statement '%v': %v", stmt, err)
+}
+
+func (s *grpcService) Execute(ctx context.Context, req *grpcapi.ExecuteRequest) (*grpcapi.ExecuteResponse, error) {
+	ns, tb, err := s.topology(ctx, req.Namespace, req.Topology)
+	if err != nil {
+		return nil, err
+	}
+	stmts, err := parseGRPCQueries(req.Queries, req.Parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &grpcapi.ExecuteResponse{
+		Statements: []string{},
+	}
+	for _, stmt := range stmts {
+		var (
+			result data.Value
+			err    error
+		)
+		switch stmt := stmt.(type) {
+		case parser.SelectStmt, parser.SelectUnionStmt, parser.SelectStartingStmt:
+			return nil, status.Error(codes.InvalidArgument, "a SELECT statement must be issued by Select")
+		case parser.EvalStmt:
+			if len(stmts) != 1 {
+				break
+			}
+			result, err = tb.RunEvalStmt(&stmt)
+		case parser.ExplainAnalyzeStmt:
+			if len(stmts) != 1 {
+				break
+			}
+			result, err = tb.RunExplainAnalyzeStmt(&stmt)
+		case parser.ShowFunctionsStmt:
+			if len(stmts) != 1 {
+				break
+			}
+			result, err = tb.RunShowFunctionsStmt(&stmt)
+		default:
+			continue
+		}
+		if result == nil && err == nil {
+			return nil, status.Error(codes.InvalidArgument,
+				"an EVAL, EXPLAIN ANALYZE, or SHOW FUNCTIONS statement cannot be issued with other statements")
+		}
+		if err != nil {
+			return nil, bqlStmtErrorStatus(stmt, err)
+		}
+		if res.Result, err = grpcapi.NewValue(result); err != nil {
+			return nil, status.Errorf(codes.Internal, "cannot convert the result: %v", err)
+		}
+		res.Statements = append(res.Statements, fmt.Sprint(stmt))
+		return res, nil
+	}
+
+	name := ns.qualifiedName(req.Topology)
+	actor := grpcAuditActor(ctx)
+	funcs := map[string]int64{}
+	for _, stmt := range stmts {
+		if err := addStmt(tb, stmt, funcs); err != nil {
+			if len(res.Statements) > 0 {
+				s.gvars.audit.recordFunctions(name, actor, "queries", res.Statements, funcs, nil)
+			}
+			return nil, bqlStmtErrorStatus(stmt, err)
+		}
+		res.Statements = append(res.Statements, fmt.Sprint(stmt))
+	}
+	if len(res.Statements) > 0 {
+		s.gvars.audit.recordFunctions(name, actor, "queries", res.Statements, funcs, nil)
+	}
+	return res, nil
+}
+
+// Select streams results of a SELECT statement. Results are buffered for the
+// client as they're in the HTTP API. When the client cannot keep up with
+// them, the call fails with ResourceExhausted or the oldest results are
+// dropped depending on the slow client policy. The number of dropped results
+// is reported in "x-sensorbee-dropped-tuples" trailer.
+func (s *grpcService) Select(req *grpcapi.SelectRequest, stream grpcapi.SensorBee_SelectServer) error {
+	ctx := stream.Context()
+	_, tb, err := s.topology(ctx, req.Namespace, req.Topology)
+	if err != nil {
+		return err
+	}
+	stmts, err := parseGRPCQueries(req.Query, req.Parameters)
+	if err != nil {
+		return err
+	}
+	if len(stmts) != 1 {
+		return status.Error(codes.InvalidArgument, "the query must have exactly one SELECT statement")
+	}
+
+	var (
+		sn core.SinkNode
+		ch <-chan *core.Tuple
+	)
+	switch stmt := stmts[0].(type) {
+	case parser.SelectStmt:
+		u := parser.SelectUnionStmt{Selects: []parser.SelectStmt{stmt}}
+		sn, ch, err = tb.AddSelectUnionStmt(&u)
+	case parser.SelectUnionStmt:
+		sn, ch, err = tb.AddSelectUnionStmt(&stmt)
+	case parser.SelectStartingStmt:
+		sn, ch, err = tb.AddSelectStartingStmt(&stmt)
+	default:
+		return status.Error(codes.InvalidArgument, "the query must be a SELECT statement")
+	}
+	if err != nil {
+		return bqlStmtErrorStatus(stmts[0], err)
+	}
+
+	l := s.gvars.Logger.WithFields(logrus.Fields{
+		"topology":  req.Topology,
+		"statement": fmt.Sprint(stmts[0]),
+	})
+	l.Info("Start streaming SELECT responses over gRPC")
+	defer func() {
+		go func() {
+			// vacuum all tuples to avoid blocking the sink.
+			for _ = range ch {
+			}
+		}()
+		if err := sn.Stop(); err != nil {
+			l.WithFields(logrus.Fields{
+				"err":       err,
+				"node_type": core.NTSink,
+				"node_name": sn.Name(),
+			}).Error("Cannot stop the temporary sink")
+		}
+		l.Info("Finish streaming SELECT responses over gRPC")
+	}()
+
+	var m *core.NodeMetrics
+	if tb.Topology() != nil {
+		m = tb.Topology().Context().Metrics().Node(sn.Name())
+	}
+	remoteAddr := ""
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		remoteAddr = p.Addr.String()
+	}
+	results, err := newSelectStream(ch, s.gvars.Config.Network, m, remoteAddr)
+	if err != nil {
+		return status.Errorf(codes.Internal, "cannot set up a stream of results: %v", err)
+	}
+	defer results.close()
+
+	var dropped int64
+	defer func() {
+		if dropped > 0 {
+			stream.SetTrailer(metadata.Pairs("x-sensorbee-dropped-tuples", fmt.Sprint(dropped)))
+		}
+	}()
+
+	for {
+		select {
+		case r, ok := <-results.results():
+			if !ok {
+				if err := results.err(); err != nil {
+					l.WithField("err", err).Info("Disconnecting the client")
+					return status.Error(codes.ResourceExhausted, err.Error())
+				}
+				return nil
+			}
+			dropped += r.dropped
+			t := r.tuple
+			fields, err := grpcapi.NewFields(t.Data)
+			if err != nil {
+				return status.Errorf(codes.Internal, "cannot convert a tuple: %v", err)
+			}
+			if err := stream.Send(&grpcapi.Tuple{
+				Timestamp: timestamppb.New(t.Timestamp),
+				Data:      fields,
+			}); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-s.stopping:
+			return status.Error(codes.Unavailable, "the server is shutting down")
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package server
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"gopkg.in/sensorbee/sensorbee.v0/server/config"
+	"gopkg.in/sensorbee/sensorbee.v0/server/grpcapi"
+)
+
+func TestGRPCAPI(t *testing.T) {
+	Convey("Given a server serving the gRPC API", t, func() {
+		conf, err := config.New(data.Map{
+			"network": data.Map{"listen_on": data.String("127.0.0.1:0")},
+			"logging": data.Map{"target": data.String("stderr"), "min_log_level": data.String("error")},
+			"namespaces": data.Map{
+				"tenant1": data.Map{"tokens": data.Array{data.String("secret")}},
+			},
+		})
+		So(err, ShouldBeNil)
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		So(err, ShouldBeNil)
+		s, err := New(WithConfig(conf), WithGRPCListener(l))
+		So(err, ShouldBeNil)
+		So(s.Start(), ShouldBeNil)
+		Reset(func() {
+			s.Stop()
+		})
+		So(s.GRPCAddr().String(), ShouldEqual, l.Addr().String())
+
+		conn, err := grpc.NewClient(l.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+		So(err, ShouldBeNil)
+		Reset(func() {
+			conn.Close()
+		})
+		c := grpcapi.NewSensorBeeClient(conn)
+		ctx := context.Background()
+
+		Convey("When creating a topology with constants", func() {
+			fields, err := grpcapi.NewFields(data.Map{"base": data.Int(10)})
+			So(err, ShouldBeNil)
+			_, err = c.CreateTopology(ctx, &grpcapi.CreateTopologyRequest{
+				Name:      "test",
+				Constants: &grpcapi.MapValue{Fields: fields},
+			})
+			So(err, ShouldBeNil)
+
+			Convey("Then EVAL should be able to refer to them", func() {
+				res, err := c.Execute(ctx, &grpcapi.ExecuteRequest{
+					Topology: "test",
+					Queries:  "EVAL $base + 1",
+				})
+				So(err, ShouldBeNil)
+				v, err := grpcapi.ToDataValue(res.Result)
+				So(err, ShouldBeNil)
+				So(v, ShouldEqual, data.Int(11))
+			})
+		})
+
+		Convey("When creating a topology with a constant having an invalid name", func() {
+			fields, err := grpcapi.NewFields(data.Map{"1a": data.Int(10)})
+			So(err, ShouldBeNil)
+			_, err = c.CreateTopology(ctx, &grpcapi.CreateTopologyRequest{
+				Name:      "test",
+				Constants: &grpcapi.MapValue{Fields: fields},
+			})
+
+			Convey("Then it should fail", func() {
+				So(status.Code(err), ShouldEqual, codes.InvalidArgument)
+			})
+		})
+
+		Convey("When creating a topology", func() {
+			tp, err := c.CreateTopology(ctx, &grpcapi.CreateTopologyRequest{Name: "test"})
+			So(err, ShouldBeNil)
+			So(tp.Name, ShouldEqual, "test")
+
+			Convey("Then it should be listed", func() {
+				res, err := c.ListTopologies(ctx, &grpcapi.ListTopologiesRequest{})
+				So(err, ShouldBeNil)
+				So(res.Topologies, ShouldHaveLength, 1)
+				So(res.Topologies[0].Name, ShouldEqual, "test")
+			})
+
+			Convey("Then creating it again should fail", func() {
+				_, err := c.CreateTopology(ctx, &grpcapi.CreateTopologyRequest{Name: "test"})
+				So(status.Code(err), ShouldEqual, codes.AlreadyExists)
+			})
+
+			Convey("Then EVAL should return its result", func() {
+				res, err := c.Execute(ctx, &grpcapi.ExecuteRequest{
+					Topology:   "test",
+					Queries:    "EVAL $1 + 1",
+					Parameters: []*grpcapi.Value{{Kind: &grpcapi.Value_IntValue{IntValue: 1}}},
+				})
+				So(err, ShouldBeNil)
+				v, err := grpcapi.ToDataValue(res.Result)
+				So(err, ShouldBeNil)
+				So(v, ShouldEqual, data.Int(2))
+			})
+
+			Convey("Then EVAL with other statements should fail", func() {
+				_, err := c.Execute(ctx, &grpcapi.ExecuteRequest{
+					Topology: "test",
+					Queries:  "CREATE SOURCE s TYPE static WITH tuples=[{}]; EVAL 1",
+				})
+				So(status.Code(err), ShouldEqual, codes.InvalidArgument)
+			})
+
+			Convey("Then executing SELECT should fail", func() {
+				_, err := c.Execute(ctx, &grpcapi.ExecuteRequest{
+					Topology: "test",
+					Queries:  "SELECT RSTREAM * FROM s [RANGE 1 TUPLES]",
+				})
+				So(status.Code(err), ShouldEqual, codes.InvalidArgument)
+			})
+
+			Convey("Then SELECT should stream results", func() {
+				res, err := c.Execute(ctx, &grpcapi.ExecuteRequest{
+					Topology: "test",
+					Queries:  `CREATE SOURCE s TYPE static WITH tuples=[{"a":1}], repeat=-1, interval=0.01;`,
+				})
+				So(err, ShouldBeNil)
+				So(res.Statements, ShouldHaveLength, 1)
+
+				sctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+				defer cancel()
+				st, err := c.Select(sctx, &grpcapi.SelectRequest{
+					Topology:   "test",
+					Query:      "SELECT RSTREAM a, $1 AS b FROM s [RANGE 1 TUPLES]",
+					Parameters: []*grpcapi.Value{{Kind: &grpcapi.Value_StringValue{StringValue: "x"}}},
+				})
+				So(err, ShouldBeNil)
+				for i := 0; i < 2; i++ {
+					t, err := st.Recv()
+					So(err, ShouldBeNil)
+					m, err := grpcapi.ToDataMap(t.Data)
+					So(err, ShouldBeNil)
+					So(m, ShouldResemble, data.Map{"a": data.Int(1), "b": data.String("x")})
+					So(t.Timestamp.AsTime().IsZero(), ShouldBeFalse)
+				}
+			})
+
+			Convey("Then SELECT on a missing stream should fail", func() {
+				st, err := c.Select(ctx, &grpcapi.SelectRequest{
+					Topology: "test",
+					Query:    "SELECT RSTREAM * FROM missing [RANGE 1 TUPLES]",
+				})
+				So(err, ShouldBeNil)
+				_, err = st.Recv()
+				So(status.Code(err), ShouldEqual, codes.NotFound)
+			})
+
+			Convey("Then deleting it should remove it", func() {
+				res, err := c.DeleteTopology(ctx, &grpcapi.DeleteTopologyRequest{Name: "test"})
+				So(err, ShouldBeNil)
+				So(res.Stopped, ShouldBeTrue)
+				_, err = c.GetTopology(ctx, &grpcapi.GetTopologyRequest{Name: "test"})
+				So(status.Code(err), ShouldEqual, codes.NotFound)
+			})
+		})
+
+		Convey("When accessing a namespace having tokens", func() {
+			req := &grpcapi.ListTopologiesRequest{Namespace: "tenant1"}
+
+			Convey("Then it should fail without a token", func() {
+				_, err := c.ListTopologies(ctx, req)
+				So(status.Code(err), ShouldEqual, codes.Unauthenticated)
+			})
+
+			Convey("Then it should succeed with a valid token", func() {
+				actx := metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer secret")
+				res, err := c.ListTopologies(actx, req)
+				So(err, ShouldBeNil)
+				So(res.Topologies, ShouldBeEmpty)
+			})
+		})
+
+		Convey("When accessing a missing namespace", func() {
+			_, err := c.ListTopologies(ctx, &grpcapi.ListTopologiesRequest{Namespace: "missing"})
+
+			Convey("Then it should fail", func() {
+				So(status.Code(err), ShouldEqual, codes.NotFound)
+			})
+		})
+	})
+}
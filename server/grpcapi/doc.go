@@ -0,0 +1,34 @@
+// Package grpcapi has the gRPC API of the SensorBee server and its Go client
+// generated from sensorbee.proto. The server serves the API in addition to
+// the HTTP API when network.grpc_listen_on is set in the config.
+//
+// A client is created from a connection to the address:
+//
+//	conn, err := grpc.NewClient("localhost:15602",
+//		grpc.WithTransportCredentials(insecure.NewCredentials()))
+//	if err != nil {
+//		return err
+//	}
+//	defer conn.Close()
+//	c := grpcapi.NewSensorBeeClient(conn)
+//
+//	stream, err := c.Select(ctx, &grpcapi.SelectRequest{
+//		Topology: "my_topology",
+//		Query:    "SELECT RSTREAM * FROM my_stream [RANGE 1 TUPLES];",
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	for {
+//		t, err := stream.Recv()
+//		if err != nil {
+//			return err // io.EOF when the stream ends
+//		}
+//		m, err := grpcapi.ToDataMap(t.Data)
+//		...
+//	}
+//
+// Values of BQL are converted from and to Value by NewValue and ToDataValue.
+package grpcapi
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative sensorbee.proto
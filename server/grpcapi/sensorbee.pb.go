@@ -0,0 +1,1157 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: sensorbee.proto
+
+package grpcapi
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// NullValue is the value of null_value of Value.
+type NullValue int32
+
+const (
+	NullValue_NULL_VALUE NullValue = 0
+)
+
+// Enum value maps for NullValue.
+var (
+	NullValue_name = map[int32]string{
+		0: "NULL_VALUE",
+	}
+	NullValue_value = map[string]int32{
+		"NULL_VALUE": 0,
+	}
+)
+
+func (x NullValue) Enum() *NullValue {
+	p := new(NullValue)
+	*p = x
+	return p
+}
+
+func (x NullValue) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (NullValue) Descriptor() protoreflect.EnumDescriptor {
+	return file_sensorbee_proto_enumTypes[0].Descriptor()
+}
+
+func (NullValue) Type() protoreflect.EnumType {
+	return &file_sensorbee_proto_enumTypes[0]
+}
+
+func (x NullValue) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use NullValue.Descriptor instead.
+func (NullValue) EnumDescriptor() ([]byte, []int) {
+	return file_sensorbee_proto_rawDescGZIP(), []int{0}
+}
+
+// Value is a value of BQL. It corresponds to data.Value.
+type Value struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Kind:
+	//
+	//	*Value_NullValue
+	//	*Value_BoolValue
+	//	*Value_IntValue
+	//	*Value_FloatValue
+	//	*Value_StringValue
+	//	*Value_BlobValue
+	//	*Value_TimestampValue
+	//	*Value_ArrayValue
+	//	*Value_MapValue
+	Kind          isValue_Kind `protobuf_oneof:"kind"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Value) Reset() {
+	*x = Value{}
+	mi := &file_sensorbee_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Value) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Value) ProtoMessage() {}
+
+func (x *Value) ProtoReflect() protoreflect.Message {
+	mi := &file_sensorbee_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Value.ProtoReflect.Descriptor instead.
+func (*Value) Descriptor() ([]byte, []int) {
+	return file_sensorbee_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Value) GetKind() isValue_Kind {
+	if x != nil {
+		return x.Kind
+	}
+	return nil
+}
+
+func (x *Value) GetNullValue() NullValue {
+	if x != nil {
+		if x, ok := x.Kind.(*Value_NullValue); ok {
+			return x.NullValue
+		}
+	}
+	return NullValue_NULL_VALUE
+}
+
+func (x *Value) GetBoolValue() bool {
+	if x != nil {
+		if x, ok := x.Kind.(*Value_BoolValue); ok {
+			return x.BoolValue
+		}
+	}
+	return false
+}
+
+func (x *Value) GetIntValue() int64 {
+	if x != nil {
+		if x, ok := x.Kind.(*Value_IntValue); ok {
+			return x.IntValue
+		}
+	}
+	return 0
+}
+
+func (x *Value) GetFloatValue() float64 {
+	if x != nil {
+		if x, ok := x.Kind.(*Value_FloatValue); ok {
+			return x.FloatValue
+		}
+	}
+	return 0
+}
+
+func (x *Value) GetStringValue() string {
+	if x != nil {
+		if x, ok := x.Kind.(*Value_StringValue); ok {
+			return x.StringValue
+		}
+	}
+	return ""
+}
+
+func (x *Value) GetBlobValue() []byte {
+	if x != nil {
+		if x, ok := x.Kind.(*Value_BlobValue); ok {
+			return x.BlobValue
+		}
+	}
+	return nil
+}
+
+func (x *Value) GetTimestampValue() *timestamppb.Timestamp {
+	if x != nil {
+		if x, ok := x.Kind.(*Value_TimestampValue); ok {
+			return x.TimestampValue
+		}
+	}
+	return nil
+}
+
+func (x *Value) GetArrayValue() *ArrayValue {
+	if x != nil {
+		if x, ok := x.Kind.(*Value_ArrayValue); ok {
+			return x.ArrayValue
+		}
+	}
+	return nil
+}
+
+func (x *Value) GetMapValue() *MapValue {
+	if x != nil {
+		if x, ok := x.Kind.(*Value_MapValue); ok {
+			return x.MapValue
+		}
+	}
+	return nil
+}
+
+type isValue_Kind interface {
+	isValue_Kind()
+}
+
+type Value_NullValue struct {
+	NullValue NullValue `protobuf:"varint,1,opt,name=null_value,json=nullValue,proto3,enum=sensorbee.v1.NullValue,oneof"`
+}
+
+type Value_BoolValue struct {
+	BoolValue bool `protobuf:"varint,2,opt,name=bool_value,json=boolValue,proto3,oneof"`
+}
+
+type Value_IntValue struct {
+	IntValue int64 `protobuf:"varint,3,opt,name=int_value,json=intValue,proto3,oneof"`
+}
+
+type Value_FloatValue struct {
+	FloatValue float64 `protobuf:"fixed64,4,opt,name=float_value,json=floatValue,proto3,oneof"`
+}
+
+type Value_StringValue struct {
+	StringValue string `protobuf:"bytes,5,opt,name=string_value,json=stringValue,proto3,oneof"`
+}
+
+type Value_BlobValue struct {
+	BlobValue []byte `protobuf:"bytes,6,opt,name=blob_value,json=blobValue,proto3,oneof"`
+}
+
+type Value_TimestampValue struct {
+	TimestampValue *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=timestamp_value,json=timestampValue,proto3,oneof"`
+}
+
+type Value_ArrayValue struct {
+	ArrayValue *ArrayValue `protobuf:"bytes,8,opt,name=array_value,json=arrayValue,proto3,oneof"`
+}
+
+type Value_MapValue struct {
+	MapValue *MapValue `protobuf:"bytes,9,opt,name=map_value,json=mapValue,proto3,oneof"`
+}
+
+func (*Value_NullValue) isValue_Kind() {}
+
+func (*Value_BoolValue) isValue_Kind() {}
+
+func (*Value_IntValue) isValue_Kind() {}
+
+func (*Value_FloatValue) isValue_Kind() {}
+
+func (*Value_StringValue) isValue_Kind() {}
+
+func (*Value_BlobValue) isValue_Kind() {}
+
+func (*Value_TimestampValue) isValue_Kind() {}
+
+func (*Value_ArrayValue) isValue_Kind() {}
+
+func (*Value_MapValue) isValue_Kind() {}
+
+// ArrayValue is an array of values.
+type ArrayValue struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Values        []*Value               `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ArrayValue) Reset() {
+	*x = ArrayValue{}
+	mi := &file_sensorbee_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ArrayValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArrayValue) ProtoMessage() {}
+
+func (x *ArrayValue) ProtoReflect() protoreflect.Message {
+	mi := &file_sensorbee_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArrayValue.ProtoReflect.Descriptor instead.
+func (*ArrayValue) Descriptor() ([]byte, []int) {
+	return file_sensorbee_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ArrayValue) GetValues() []*Value {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+// MapValue is a map from strings to values.
+type MapValue struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Fields        map[string]*Value      `protobuf:"bytes,1,rep,name=fields,proto3" json:"fields,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MapValue) Reset() {
+	*x = MapValue{}
+	mi := &file_sensorbee_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MapValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MapValue) ProtoMessage() {}
+
+func (x *MapValue) ProtoReflect() protoreflect.Message {
+	mi := &file_sensorbee_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MapValue.ProtoReflect.Descriptor instead.
+func (*MapValue) Descriptor() ([]byte, []int) {
+	return file_sensorbee_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *MapValue) GetFields() map[string]*Value {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+// Topology is information of a topology.
+type Topology struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// version is incremented every time the topology is modified through the
+	// API. It's 0 when the topology hasn't been modified since the server
+	// started.
+	Version       int64 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Topology) Reset() {
+	*x = Topology{}
+	mi := &file_sensorbee_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Topology) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Topology) ProtoMessage() {}
+
+func (x *Topology) ProtoReflect() protoreflect.Message {
+	mi := &file_sensorbee_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Topology.ProtoReflect.Descriptor instead.
+func (*Topology) Descriptor() ([]byte, []int) {
+	return file_sensorbee_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Topology) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Topology) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+// Tuple is a result of a SELECT statement.
+type Tuple struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Data          map[string]*Value      `protobuf:"bytes,2,rep,name=data,proto3" json:"data,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Tuple) Reset() {
+	*x = Tuple{}
+	mi := &file_sensorbee_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Tuple) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tuple) ProtoMessage() {}
+
+func (x *Tuple) ProtoReflect() protoreflect.Message {
+	mi := &file_sensorbee_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tuple.ProtoReflect.Descriptor instead.
+func (*Tuple) Descriptor() ([]byte, []int) {
+	return file_sensorbee_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Tuple) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *Tuple) GetData() map[string]*Value {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type ListTopologiesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTopologiesRequest) Reset() {
+	*x = ListTopologiesRequest{}
+	mi := &file_sensorbee_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTopologiesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTopologiesRequest) ProtoMessage() {}
+
+func (x *ListTopologiesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sensorbee_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTopologiesRequest.ProtoReflect.Descriptor instead.
+func (*ListTopologiesRequest) Descriptor() ([]byte, []int) {
+	return file_sensorbee_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListTopologiesRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+type ListTopologiesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Topologies    []*Topology            `protobuf:"bytes,1,rep,name=topologies,proto3" json:"topologies,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTopologiesResponse) Reset() {
+	*x = ListTopologiesResponse{}
+	mi := &file_sensorbee_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTopologiesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTopologiesResponse) ProtoMessage() {}
+
+func (x *ListTopologiesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sensorbee_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTopologiesResponse.ProtoReflect.Descriptor instead.
+func (*ListTopologiesResponse) Descriptor() ([]byte, []int) {
+	return file_sensorbee_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListTopologiesResponse) GetTopologies() []*Topology {
+	if x != nil {
+		return x.Topologies
+	}
+	return nil
+}
+
+type GetTopologyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTopologyRequest) Reset() {
+	*x = GetTopologyRequest{}
+	mi := &file_sensorbee_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTopologyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTopologyRequest) ProtoMessage() {}
+
+func (x *GetTopologyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sensorbee_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTopologyRequest.ProtoReflect.Descriptor instead.
+func (*GetTopologyRequest) Descriptor() ([]byte, []int) {
+	return file_sensorbee_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetTopologyRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *GetTopologyRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type CreateTopologyRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Namespace string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Name      string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// resources has resources declared by the topology such as max_nodes. It
+	// has the same format as resources of the HTTP API.
+	Resources *MapValue `protobuf:"bytes,3,opt,name=resources,proto3" json:"resources,omitempty"`
+	// constants has initial values of constants of the topology which can be
+	// referred by $name in BQL statements.
+	Constants     *MapValue `protobuf:"bytes,4,opt,name=constants,proto3" json:"constants,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTopologyRequest) Reset() {
+	*x = CreateTopologyRequest{}
+	mi := &file_sensorbee_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTopologyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTopologyRequest) ProtoMessage() {}
+
+func (x *CreateTopologyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sensorbee_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTopologyRequest.ProtoReflect.Descriptor instead.
+func (*CreateTopologyRequest) Descriptor() ([]byte, []int) {
+	return file_sensorbee_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CreateTopologyRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *CreateTopologyRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateTopologyRequest) GetResources() *MapValue {
+	if x != nil {
+		return x.Resources
+	}
+	return nil
+}
+
+func (x *CreateTopologyRequest) GetConstants() *MapValue {
+	if x != nil {
+		return x.Constants
+	}
+	return nil
+}
+
+type DeleteTopologyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTopologyRequest) Reset() {
+	*x = DeleteTopologyRequest{}
+	mi := &file_sensorbee_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTopologyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTopologyRequest) ProtoMessage() {}
+
+func (x *DeleteTopologyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sensorbee_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTopologyRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTopologyRequest) Descriptor() ([]byte, []int) {
+	return file_sensorbee_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *DeleteTopologyRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *DeleteTopologyRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type DeleteTopologyResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// stopped is false when the topology wasn't stopped correctly.
+	Stopped       bool `protobuf:"varint,1,opt,name=stopped,proto3" json:"stopped,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTopologyResponse) Reset() {
+	*x = DeleteTopologyResponse{}
+	mi := &file_sensorbee_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTopologyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTopologyResponse) ProtoMessage() {}
+
+func (x *DeleteTopologyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sensorbee_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTopologyResponse.ProtoReflect.Descriptor instead.
+func (*DeleteTopologyResponse) Descriptor() ([]byte, []int) {
+	return file_sensorbee_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *DeleteTopologyResponse) GetStopped() bool {
+	if x != nil {
+		return x.Stopped
+	}
+	return false
+}
+
+type ExecuteRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Namespace string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Topology  string                 `protobuf:"bytes,2,opt,name=topology,proto3" json:"topology,omitempty"`
+	// queries has BQL statements separated by semicolons.
+	Queries string `protobuf:"bytes,3,opt,name=queries,proto3" json:"queries,omitempty"`
+	// parameters are bound to placeholders such as $1 in queries.
+	Parameters    []*Value `protobuf:"bytes,4,rep,name=parameters,proto3" json:"parameters,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExecuteRequest) Reset() {
+	*x = ExecuteRequest{}
+	mi := &file_sensorbee_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecuteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecuteRequest) ProtoMessage() {}
+
+func (x *ExecuteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sensorbee_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecuteRequest.ProtoReflect.Descriptor instead.
+func (*ExecuteRequest) Descriptor() ([]byte, []int) {
+	return file_sensorbee_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ExecuteRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *ExecuteRequest) GetTopology() string {
+	if x != nil {
+		return x.Topology
+	}
+	return ""
+}
+
+func (x *ExecuteRequest) GetQueries() string {
+	if x != nil {
+		return x.Queries
+	}
+	return ""
+}
+
+func (x *ExecuteRequest) GetParameters() []*Value {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+type ExecuteResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// statements are the executed statements.
+	Statements []string `protobuf:"bytes,1,rep,name=statements,proto3" json:"statements,omitempty"`
+	// result is the result of an EVAL or SHOW FUNCTIONS statement.
+	Result        *Value `protobuf:"bytes,2,opt,name=result,proto3" json:"result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExecuteResponse) Reset() {
+	*x = ExecuteResponse{}
+	mi := &file_sensorbee_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecuteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecuteResponse) ProtoMessage() {}
+
+func (x *ExecuteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sensorbee_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecuteResponse.ProtoReflect.Descriptor instead.
+func (*ExecuteResponse) Descriptor() ([]byte, []int) {
+	return file_sensorbee_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ExecuteResponse) GetStatements() []string {
+	if x != nil {
+		return x.Statements
+	}
+	return nil
+}
+
+func (x *ExecuteResponse) GetResult() *Value {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+type SelectRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Namespace string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Topology  string                 `protobuf:"bytes,2,opt,name=topology,proto3" json:"topology,omitempty"`
+	// query has a SELECT statement.
+	Query string `protobuf:"bytes,3,opt,name=query,proto3" json:"query,omitempty"`
+	// parameters are bound to placeholders such as $1 in query.
+	Parameters    []*Value `protobuf:"bytes,4,rep,name=parameters,proto3" json:"parameters,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SelectRequest) Reset() {
+	*x = SelectRequest{}
+	mi := &file_sensorbee_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SelectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SelectRequest) ProtoMessage() {}
+
+func (x *SelectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sensorbee_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SelectRequest.ProtoReflect.Descriptor instead.
+func (*SelectRequest) Descriptor() ([]byte, []int) {
+	return file_sensorbee_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SelectRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *SelectRequest) GetTopology() string {
+	if x != nil {
+		return x.Topology
+	}
+	return ""
+}
+
+func (x *SelectRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SelectRequest) GetParameters() []*Value {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+var File_sensorbee_proto protoreflect.FileDescriptor
+
+const file_sensorbee_proto_rawDesc = "" +
+	"\n" +
+	"\x0fsensorbee.proto\x12\fsensorbee.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xad\x03\n" +
+	"\x05Value\x128\n" +
+	"\n" +
+	"null_value\x18\x01 \x01(\x0e2\x17.sensorbee.v1.NullValueH\x00R\tnullValue\x12\x1f\n" +
+	"\n" +
+	"bool_value\x18\x02 \x01(\bH\x00R\tboolValue\x12\x1d\n" +
+	"\tint_value\x18\x03 \x01(\x03H\x00R\bintValue\x12!\n" +
+	"\vfloat_value\x18\x04 \x01(\x01H\x00R\n" +
+	"floatValue\x12#\n" +
+	"\fstring_value\x18\x05 \x01(\tH\x00R\vstringValue\x12\x1f\n" +
+	"\n" +
+	"blob_value\x18\x06 \x01(\fH\x00R\tblobValue\x12E\n" +
+	"\x0ftimestamp_value\x18\a \x01(\v2\x1a.google.protobuf.TimestampH\x00R\x0etimestampValue\x12;\n" +
+	"\varray_value\x18\b \x01(\v2\x18.sensorbee.v1.ArrayValueH\x00R\n" +
+	"arrayValue\x125\n" +
+	"\tmap_value\x18\t \x01(\v2\x16.sensorbee.v1.MapValueH\x00R\bmapValueB\x06\n" +
+	"\x04kind\"9\n" +
+	"\n" +
+	"ArrayValue\x12+\n" +
+	"\x06values\x18\x01 \x03(\v2\x13.sensorbee.v1.ValueR\x06values\"\x96\x01\n" +
+	"\bMapValue\x12:\n" +
+	"\x06fields\x18\x01 \x03(\v2\".sensorbee.v1.MapValue.FieldsEntryR\x06fields\x1aN\n" +
+	"\vFieldsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12)\n" +
+	"\x05value\x18\x02 \x01(\v2\x13.sensorbee.v1.ValueR\x05value:\x028\x01\"8\n" +
+	"\bTopology\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x03R\aversion\"\xc2\x01\n" +
+	"\x05Tuple\x128\n" +
+	"\ttimestamp\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x121\n" +
+	"\x04data\x18\x02 \x03(\v2\x1d.sensorbee.v1.Tuple.DataEntryR\x04data\x1aL\n" +
+	"\tDataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12)\n" +
+	"\x05value\x18\x02 \x01(\v2\x13.sensorbee.v1.ValueR\x05value:\x028\x01\"5\n" +
+	"\x15ListTopologiesRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\"P\n" +
+	"\x16ListTopologiesResponse\x126\n" +
+	"\n" +
+	"topologies\x18\x01 \x03(\v2\x16.sensorbee.v1.TopologyR\n" +
+	"topologies\"F\n" +
+	"\x12GetTopologyRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"\xb5\x01\n" +
+	"\x15CreateTopologyRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x124\n" +
+	"\tresources\x18\x03 \x01(\v2\x16.sensorbee.v1.MapValueR\tresources\x124\n" +
+	"\tconstants\x18\x04 \x01(\v2\x16.sensorbee.v1.MapValueR\tconstants\"I\n" +
+	"\x15DeleteTopologyRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"2\n" +
+	"\x16DeleteTopologyResponse\x12\x18\n" +
+	"\astopped\x18\x01 \x01(\bR\astopped\"\x99\x01\n" +
+	"\x0eExecuteRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x1a\n" +
+	"\btopology\x18\x02 \x01(\tR\btopology\x12\x18\n" +
+	"\aqueries\x18\x03 \x01(\tR\aqueries\x123\n" +
+	"\n" +
+	"parameters\x18\x04 \x03(\v2\x13.sensorbee.v1.ValueR\n" +
+	"parameters\"^\n" +
+	"\x0fExecuteResponse\x12\x1e\n" +
+	"\n" +
+	"statements\x18\x01 \x03(\tR\n" +
+	"statements\x12+\n" +
+	"\x06result\x18\x02 \x01(\v2\x13.sensorbee.v1.ValueR\x06result\"\x94\x01\n" +
+	"\rSelectRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x1a\n" +
+	"\btopology\x18\x02 \x01(\tR\btopology\x12\x14\n" +
+	"\x05query\x18\x03 \x01(\tR\x05query\x123\n" +
+	"\n" +
+	"parameters\x18\x04 \x03(\v2\x13.sensorbee.v1.ValueR\n" +
+	"parameters*\x1b\n" +
+	"\tNullValue\x12\x0e\n" +
+	"\n" +
+	"NULL_VALUE\x10\x002\xe3\x03\n" +
+	"\tSensorBee\x12[\n" +
+	"\x0eListTopologies\x12#.sensorbee.v1.ListTopologiesRequest\x1a$.sensorbee.v1.ListTopologiesResponse\x12G\n" +
+	"\vGetTopology\x12 .sensorbee.v1.GetTopologyRequest\x1a\x16.sensorbee.v1.Topology\x12M\n" +
+	"\x0eCreateTopology\x12#.sensorbee.v1.CreateTopologyRequest\x1a\x16.sensorbee.v1.Topology\x12[\n" +
+	"\x0eDeleteTopology\x12#.sensorbee.v1.DeleteTopologyRequest\x1a$.sensorbee.v1.DeleteTopologyResponse\x12F\n" +
+	"\aExecute\x12\x1c.sensorbee.v1.ExecuteRequest\x1a\x1d.sensorbee.v1.ExecuteResponse\x12<\n" +
+	"\x06Select\x12\x1b.sensorbee.v1.SelectRequest\x1a\x13.sensorbee.v1.Tuple0\x01B0Z.gopkg.in/sensorbee/sensorbee.v0/server/grpcapib\x06proto3"
+
+var (
+	file_sensorbee_proto_rawDescOnce sync.Once
+	file_sensorbee_proto_rawDescData []byte
+)
+
+func file_sensorbee_proto_rawDescGZIP() []byte {
+	file_sensorbee_proto_rawDescOnce.Do(func() {
+		file_sensorbee_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_sensorbee_proto_rawDesc), len(file_sensorbee_proto_rawDesc)))
+	})
+	return file_sensorbee_proto_rawDescData
+}
+
+var file_sensorbee_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_sensorbee_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_sensorbee_proto_goTypes = []any{
+	(NullValue)(0),                 // 0: sensorbee.v1.NullValue
+	(*Value)(nil),                  // 1: sensorbee.v1.Value
+	(*ArrayValue)(nil),             // 2: sensorbee.v1.ArrayValue
+	(*MapValue)(nil),               // 3: sensorbee.v1.MapValue
+	(*Topology)(nil),               // 4: sensorbee.v1.Topology
+	(*Tuple)(nil),                  // 5: sensorbee.v1.Tuple
+	(*ListTopologiesRequest)(nil),  // 6: sensorbee.v1.ListTopologiesRequest
+	(*ListTopologiesResponse)(nil), // 7: sensorbee.v1.ListTopologiesResponse
+	(*GetTopologyRequest)(nil),     // 8: sensorbee.v1.GetTopologyRequest
+	(*CreateTopologyRequest)(nil),  // 9: sensorbee.v1.CreateTopologyRequest
+	(*DeleteTopologyRequest)(nil),  // 10: sensorbee.v1.DeleteTopologyRequest
+	(*DeleteTopologyResponse)(nil), // 11: sensorbee.v1.DeleteTopologyResponse
+	(*ExecuteRequest)(nil),         // 12: sensorbee.v1.ExecuteRequest
+	(*ExecuteResponse)(nil),        // 13: sensorbee.v1.ExecuteResponse
+	(*SelectRequest)(nil),          // 14: sensorbee.v1.SelectRequest
+	nil,                            // 15: sensorbee.v1.MapValue.FieldsEntry
+	nil,                            // 16: sensorbee.v1.Tuple.DataEntry
+	(*timestamppb.Timestamp)(nil),  // 17: google.protobuf.Timestamp
+}
+var file_sensorbee_proto_depIdxs = []int32{
+	0,  // 0: sensorbee.v1.Value.null_value:type_name -> sensorbee.v1.NullValue
+	17, // 1: sensorbee.v1.Value.timestamp_value:type_name -> google.protobuf.Timestamp
+	2,  // 2: sensorbee.v1.Value.array_value:type_name -> sensorbee.v1.ArrayValue
+	3,  // 3: sensorbee.v1.Value.map_value:type_name -> sensorbee.v1.MapValue
+	1,  // 4: sensorbee.v1.ArrayValue.values:type_name -> sensorbee.v1.Value
+	15, // 5: sensorbee.v1.MapValue.fields:type_name -> sensorbee.v1.MapValue.FieldsEntry
+	17, // 6: sensorbee.v1.Tuple.timestamp:type_name -> google.protobuf.Timestamp
+	16, // 7: sensorbee.v1.Tuple.data:type_name -> sensorbee.v1.Tuple.DataEntry
+	4,  // 8: sensorbee.v1.ListTopologiesResponse.topologies:type_name -> sensorbee.v1.Topology
+	3,  // 9: sensorbee.v1.CreateTopologyRequest.resources:type_name -> sensorbee.v1.MapValue
+	3,  // 10: sensorbee.v1.CreateTopologyRequest.constants:type_name -> sensorbee.v1.MapValue
+	1,  // 11: sensorbee.v1.ExecuteRequest.parameters:type_name -> sensorbee.v1.Value
+	1,  // 12: sensorbee.v1.ExecuteResponse.result:type_name -> sensorbee.v1.Value
+	1,  // 13: sensorbee.v1.SelectRequest.parameters:type_name -> sensorbee.v1.Value
+	1,  // 14: sensorbee.v1.MapValue.FieldsEntry.value:type_name -> sensorbee.v1.Value
+	1,  // 15: sensorbee.v1.Tuple.DataEntry.value:type_name -> sensorbee.v1.Value
+	6,  // 16: sensorbee.v1.SensorBee.ListTopologies:input_type -> sensorbee.v1.ListTopologiesRequest
+	8,  // 17: sensorbee.v1.SensorBee.GetTopology:input_type -> sensorbee.v1.GetTopologyRequest
+	9,  // 18: sensorbee.v1.SensorBee.CreateTopology:input_type -> sensorbee.v1.CreateTopologyRequest
+	10, // 19: sensorbee.v1.SensorBee.DeleteTopology:input_type -> sensorbee.v1.DeleteTopologyRequest
+	12, // 20: sensorbee.v1.SensorBee.Execute:input_type -> sensorbee.v1.ExecuteRequest
+	14, // 21: sensorbee.v1.SensorBee.Select:input_type -> sensorbee.v1.SelectRequest
+	7,  // 22: sensorbee.v1.SensorBee.ListTopologies:output_type -> sensorbee.v1.ListTopologiesResponse
+	4,  // 23: sensorbee.v1.SensorBee.GetTopology:output_type -> sensorbee.v1.Topology
+	4,  // 24: sensorbee.v1.SensorBee.CreateTopology:output_type -> sensorbee.v1.Topology
+	11, // 25: sensorbee.v1.SensorBee.DeleteTopology:output_type -> sensorbee.v1.DeleteTopologyResponse
+	13, // 26: sensorbee.v1.SensorBee.Execute:output_type -> sensorbee.v1.ExecuteResponse
+	5,  // 27: sensorbee.v1.SensorBee.Select:output_type -> sensorbee.v1.Tuple
+	22, // [22:28] is the sub-list for method output_type
+	16, // [16:22] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
+}
+
+func init() { file_sensorbee_proto_init() }
+func file_sensorbee_proto_init() {
+	if File_sensorbee_proto != nil {
+		return
+	}
+	file_sensorbee_proto_msgTypes[0].OneofWrappers = []any{
+		(*Value_NullValue)(nil),
+		(*Value_BoolValue)(nil),
+		(*Value_IntValue)(nil),
+		(*Value_FloatValue)(nil),
+		(*Value_StringValue)(nil),
+		(*Value_BlobValue)(nil),
+		(*Value_TimestampValue)(nil),
+		(*Value_ArrayValue)(nil),
+		(*Value_MapValue)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_sensorbee_proto_rawDesc), len(file_sensorbee_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   16,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_sensorbee_proto_goTypes,
+		DependencyIndexes: file_sensorbee_proto_depIdxs,
+		EnumInfos:         file_sensorbee_proto_enumTypes,
+		MessageInfos:      file_sensorbee_proto_msgTypes,
+	}.Build()
+	File_sensorbee_proto = out.File
+	file_sensorbee_proto_goTypes = nil
+	file_sensorbee_proto_depIdxs = nil
+}
@@ -0,0 +1,345 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: sensorbee.proto
+
+package grpcapi
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	SensorBee_ListTopologies_FullMethodName = "/sensorbee.v1.SensorBee/ListTopologies"
+	SensorBee_GetTopology_FullMethodName    = "/sensorbee.v1.SensorBee/GetTopology"
+	SensorBee_CreateTopology_FullMethodName = "/sensorbee.v1.SensorBee/CreateTopology"
+	SensorBee_DeleteTopology_FullMethodName = "/sensorbee.v1.SensorBee/DeleteTopology"
+	SensorBee_Execute_FullMethodName        = "/sensorbee.v1.SensorBee/Execute"
+	SensorBee_Select_FullMethodName         = "/sensorbee.v1.SensorBee/Select"
+)
+
+// SensorBeeClient is the client API for SensorBee service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// SensorBee manages topologies and executes BQL statements on them.
+type SensorBeeClient interface {
+	// ListTopologies returns topologies in a namespace.
+	ListTopologies(ctx context.Context, in *ListTopologiesRequest, opts ...grpc.CallOption) (*ListTopologiesResponse, error)
+	// GetTopology returns a topology. It fails with NOT_FOUND when the
+	// topology doesn't exist.
+	GetTopology(ctx context.Context, in *GetTopologyRequest, opts ...grpc.CallOption) (*Topology, error)
+	// CreateTopology creates a new topology. It fails with ALREADY_EXISTS when
+	// the name is taken and RESOURCE_EXHAUSTED when the topology would exceed
+	// resource limits of the server.
+	CreateTopology(ctx context.Context, in *CreateTopologyRequest, opts ...grpc.CallOption) (*Topology, error)
+	// DeleteTopology stops and removes a topology. It succeeds even if the
+	// topology doesn't exist.
+	DeleteTopology(ctx context.Context, in *DeleteTopologyRequest, opts ...grpc.CallOption) (*DeleteTopologyResponse, error)
+	// Execute executes BQL statements other than SELECT statements. An EVAL or
+	// SHOW FUNCTIONS statement returns its result and cannot be issued with
+	// other statements.
+	Execute(ctx context.Context, in *ExecuteRequest, opts ...grpc.CallOption) (*ExecuteResponse, error)
+	// Select executes a SELECT statement and streams its results until the
+	// client cancels the call or the topology stops.
+	Select(ctx context.Context, in *SelectRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Tuple], error)
+}
+
+type sensorBeeClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSensorBeeClient(cc grpc.ClientConnInterface) SensorBeeClient {
+	return &sensorBeeClient{cc}
+}
+
+func (c *sensorBeeClient) ListTopologies(ctx context.Context, in *ListTopologiesRequest, opts ...grpc.CallOption) (*ListTopologiesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTopologiesResponse)
+	err := c.cc.Invoke(ctx, SensorBee_ListTopologies_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sensorBeeClient) GetTopology(ctx context.Context, in *GetTopologyRequest, opts ...grpc.CallOption) (*Topology, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Topology)
+	err := c.cc.Invoke(ctx, SensorBee_GetTopology_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sensorBeeClient) CreateTopology(ctx context.Context, in *CreateTopologyRequest, opts ...grpc.CallOption) (*Topology, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Topology)
+	err := c.cc.Invoke(ctx, SensorBee_CreateTopology_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sensorBeeClient) DeleteTopology(ctx context.Context, in *DeleteTopologyRequest, opts ...grpc.CallOption) (*DeleteTopologyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteTopologyResponse)
+	err := c.cc.Invoke(ctx, SensorBee_DeleteTopology_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sensorBeeClient) Execute(ctx context.Context, in *ExecuteRequest, opts ...grpc.CallOption) (*ExecuteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExecuteResponse)
+	err := c.cc.Invoke(ctx, SensorBee_Execute_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sensorBeeClient) Select(ctx context.Context, in *SelectRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Tuple], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &SensorBee_ServiceDesc.Streams[0], SensorBee_Select_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SelectRequest, Tuple]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SensorBee_SelectClient = grpc.ServerStreamingClient[Tuple]
+
+// SensorBeeServer is the server API for SensorBee service.
+// All implementations must embed UnimplementedSensorBeeServer
+// for forward compatibility.
+//
+// SensorBee manages topologies and executes BQL statements on them.
+type SensorBeeServer interface {
+	// ListTopologies returns topologies in a namespace.
+	ListTopologies(context.Context, *ListTopologiesRequest) (*ListTopologiesResponse, error)
+	// GetTopology returns a topology. It fails with NOT_FOUND when the
+	// topology doesn't exist.
+	GetTopology(context.Context, *GetTopologyRequest) (*Topology, error)
+	// CreateTopology creates a new topology. It fails with ALREADY_EXISTS when
+	// the name is taken and RESOURCE_EXHAUSTED when the topology would exceed
+	// resource limits of the server.
+	CreateTopology(context.Context, *CreateTopologyRequest) (*Topology, error)
+	// DeleteTopology stops and removes a topology. It succeeds even if the
+	// topology doesn't exist.
+	DeleteTopology(context.Context, *DeleteTopologyRequest) (*DeleteTopologyResponse, error)
+	// Execute executes BQL statements other than SELECT statements. An EVAL or
+	// SHOW FUNCTIONS statement returns its result and cannot be issued with
+	// other statements.
+	Execute(context.Context, *ExecuteRequest) (*ExecuteResponse, error)
+	// Select executes a SELECT statement and streams its results until the
+	// client cancels the call or the topology stops.
+	Select(*SelectRequest, grpc.ServerStreamingServer[Tuple]) error
+	mustEmbedUnimplementedSensorBeeServer()
+}
+
+// UnimplementedSensorBeeServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSensorBeeServer struct{}
+
+func (UnimplementedSensorBeeServer) ListTopologies(context.Context, *ListTopologiesRequest) (*ListTopologiesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTopologies not implemented")
+}
+func (UnimplementedSensorBeeServer) GetTopology(context.Context, *GetTopologyRequest) (*Topology, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTopology not implemented")
+}
+func (UnimplementedSensorBeeServer) CreateTopology(context.Context, *CreateTopologyRequest) (*Topology, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTopology not implemented")
+}
+func (UnimplementedSensorBeeServer) DeleteTopology(context.Context, *DeleteTopologyRequest) (*DeleteTopologyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTopology not implemented")
+}
+func (UnimplementedSensorBeeServer) Execute(context.Context, *ExecuteRequest) (*ExecuteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Execute not implemented")
+}
+func (UnimplementedSensorBeeServer) Select(*SelectRequest, grpc.ServerStreamingServer[Tuple]) error {
+	return status.Errorf(codes.Unimplemented, "method Select not implemented")
+}
+func (UnimplementedSensorBeeServer) mustEmbedUnimplementedSensorBeeServer() {}
+func (UnimplementedSensorBeeServer) testEmbeddedByValue()                   {}
+
+// UnsafeSensorBeeServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SensorBeeServer will
+// result in compilation errors.
+type UnsafeSensorBeeServer interface {
+	mustEmbedUnimplementedSensorBeeServer()
+}
+
+func RegisterSensorBeeServer(s grpc.ServiceRegistrar, srv SensorBeeServer) {
+	// If the following call pancis, it indicates UnimplementedSensorBeeServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&SensorBee_ServiceDesc, srv)
+}
+
+func _SensorBee_ListTopologies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTopologiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SensorBeeServer).ListTopologies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SensorBee_ListTopologies_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SensorBeeServer).ListTopologies(ctx, req.(*ListTopologiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SensorBee_GetTopology_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTopologyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SensorBeeServer).GetTopology(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SensorBee_GetTopology_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SensorBeeServer).GetTopology(ctx, req.(*GetTopologyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SensorBee_CreateTopology_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTopologyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SensorBeeServer).CreateTopology(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SensorBee_CreateTopology_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SensorBeeServer).CreateTopology(ctx, req.(*CreateTopologyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SensorBee_DeleteTopology_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTopologyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SensorBeeServer).DeleteTopology(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SensorBee_DeleteTopology_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SensorBeeServer).DeleteTopology(ctx, req.(*DeleteTopologyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SensorBee_Execute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExecuteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SensorBeeServer).Execute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SensorBee_Execute_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SensorBeeServer).Execute(ctx, req.(*ExecuteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SensorBee_Select_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SelectRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SensorBeeServer).Select(m, &grpc.GenericServerStream[SelectRequest, Tuple]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SensorBee_SelectServer = grpc.ServerStreamingServer[Tuple]
+
+// SensorBee_ServiceDesc is the grpc.ServiceDesc for SensorBee service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SensorBee_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "sensorbee.v1.SensorBee",
+	HandlerType: (*SensorBeeServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListTopologies",
+			Handler:    _SensorBee_ListTopologies_Handler,
+		},
+		{
+			MethodName: "GetTopology",
+			Handler:    _SensorBee_GetTopology_Handler,
+		},
+		{
+			MethodName: "CreateTopology",
+			Handler:    _SensorBee_CreateTopology_Handler,
+		},
+		{
+			MethodName: "DeleteTopology",
+			Handler:    _SensorBee_DeleteTopology_Handler,
+		},
+		{
+			MethodName: "Execute",
+			Handler:    _SensorBee_Execute_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Select",
+			Handler:       _SensorBee_Select_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "sensorbee.proto",
+}
@@ -0,0 +1,111 @@
+package grpcapi
+
+import (
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// NewValue converts a data.Value to a Value.
+func NewValue(v data.Value) (*Value, error) {
+	switch v := v.(type) {
+	case nil, data.Null:
+		return &Value{Kind: &Value_NullValue{}}, nil
+	case data.Bool:
+		return &Value{Kind: &Value_BoolValue{BoolValue: bool(v)}}, nil
+	case data.Int:
+		return &Value{Kind: &Value_IntValue{IntValue: int64(v)}}, nil
+	case data.Float:
+		return &Value{Kind: &Value_FloatValue{FloatValue: float64(v)}}, nil
+	case data.String:
+		return &Value{Kind: &Value_StringValue{StringValue: string(v)}}, nil
+	case data.Blob:
+		return &Value{Kind: &Value_BlobValue{BlobValue: []byte(v)}}, nil
+	case data.Timestamp:
+		return &Value{Kind: &Value_TimestampValue{TimestampValue: timestamppb.New(time.Time(v))}}, nil
+	case data.Array:
+		a := &ArrayValue{Values: make([]*Value, len(v))}
+		for i, e := range v {
+			ev, err := NewValue(e)
+			if err != nil {
+				return nil, err
+			}
+			a.Values[i] = ev
+		}
+		return &Value{Kind: &Value_ArrayValue{ArrayValue: a}}, nil
+	case data.Map:
+		fields, err := NewFields(v)
+		if err != nil {
+			return nil, err
+		}
+		return &Value{Kind: &Value_MapValue{MapValue: &MapValue{Fields: fields}}}, nil
+	default:
+		return nil, fmt.Errorf("unsupported value type: %T", v)
+	}
+}
+
+// NewFields converts a data.Map to fields of a MapValue or a Tuple.
+func NewFields(m data.Map) (map[string]*Value, error) {
+	fields := make(map[string]*Value, len(m))
+	for k, e := range m {
+		ev, err := NewValue(e)
+		if err != nil {
+			return nil, fmt.Errorf("cannot convert field '%v': %v", k, err)
+		}
+		fields[k] = ev
+	}
+	return fields, nil
+}
+
+// ToDataValue converts v to a data.Value. A nil Value or a Value not having
+// any kind is converted to data.Null.
+func ToDataValue(v *Value) (data.Value, error) {
+	switch k := v.GetKind().(type) {
+	case nil, *Value_NullValue:
+		return data.Null{}, nil
+	case *Value_BoolValue:
+		return data.Bool(k.BoolValue), nil
+	case *Value_IntValue:
+		return data.Int(k.IntValue), nil
+	case *Value_FloatValue:
+		return data.Float(k.FloatValue), nil
+	case *Value_StringValue:
+		return data.String(k.StringValue), nil
+	case *Value_BlobValue:
+		return data.Blob(k.BlobValue), nil
+	case *Value_TimestampValue:
+		if err := k.TimestampValue.CheckValid(); err != nil {
+			return nil, err
+		}
+		return data.Timestamp(k.TimestampValue.AsTime()), nil
+	case *Value_ArrayValue:
+		a := make(data.Array, len(k.ArrayValue.GetValues()))
+		for i, e := range k.ArrayValue.GetValues() {
+			dv, err := ToDataValue(e)
+			if err != nil {
+				return nil, err
+			}
+			a[i] = dv
+		}
+		return a, nil
+	case *Value_MapValue:
+		return ToDataMap(k.MapValue.GetFields())
+	default:
+		return nil, fmt.Errorf("unsupported value kind: %T", k)
+	}
+}
+
+// ToDataMap converts fields of a MapValue or a Tuple to a data.Map.
+func ToDataMap(fields map[string]*Value) (data.Map, error) {
+	m := make(data.Map, len(fields))
+	for k, e := range fields {
+		dv, err := ToDataValue(e)
+		if err != nil {
+			return nil, fmt.Errorf("cannot convert field '%v': %v", k, err)
+		}
+		m[k] = dv
+	}
+	return m, nil
+}
@@ -0,0 +1,173 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// listenerFDEnv is the environment variable having the file descriptor
+	// of the listener handed off from the parent process.
+	listenerFDEnv = "SENSORBEE_LISTENER_FD"
+
+	// readyFDEnv is the environment variable having the file descriptor of
+	// the pipe to which the new process writes when it gets ready.
+	readyFDEnv = "SENSORBEE_READY_FD"
+)
+
+// inheritedListener is a listener handed off from the parent process. It
+// notifies the parent process when the server starts serving the API.
+type inheritedListener struct {
+	net.Listener
+
+	readyOnce sync.Once
+	ready     *os.File
+}
+
+// InheritedListener returns the listener handed off from the parent process
+// by Server.Handoff. It returns nil when the process didn't inherit a
+// listener. The returned listener should be passed to New by WithListener,
+// and then Start notifies the parent process that the new process is ready
+// so that the parent process can start draining.
+func InheritedListener() (net.Listener, error) {
+	v := os.Getenv(listenerFDEnv)
+	if v == "" {
+		return nil, nil
+	}
+	// The variables must not be inherited by processes started from this
+	// process.
+	defer os.Unsetenv(listenerFDEnv)
+	defer os.Unsetenv(readyFDEnv)
+
+	fd, err := strconv.Atoi(v)
+	if err != nil {
+		return nil, fmt.Errorf("%v has an invalid file descriptor: %v", listenerFDEnv, v)
+	}
+	f := os.NewFile(uintptr(fd), "listener")
+	defer f.Close() // FileListener duplicates the file descriptor.
+	l, err := net.FileListener(f)
+	if err != nil {
+		return nil, fmt.Errorf("cannot use the inherited listener: %v", err)
+	}
+
+	il := &inheritedListener{
+		Listener: l,
+	}
+	if v := os.Getenv(readyFDEnv); v != "" {
+		fd, err := strconv.Atoi(v)
+		if err != nil {
+			l.Close()
+			return nil, fmt.Errorf("%v has an invalid file descriptor: %v", readyFDEnv, v)
+		}
+		il.ready = os.NewFile(uintptr(fd), "ready")
+	}
+	return il, nil
+}
+
+// notifyReady tells the parent process that the server is ready.
+func (l *inheritedListener) notifyReady() error {
+	var err error
+	l.readyOnce.Do(func() {
+		if l.ready == nil {
+			return
+		}
+		defer l.ready.Close()
+		_, err = l.ready.Write([]byte{1})
+	})
+	return err
+}
+
+// File returns a duplicated file of the listener so that the listener can be
+// handed off again.
+func (l *inheritedListener) File() (*os.File, error) {
+	fl, ok := l.Listener.(interface {
+		File() (*os.File, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("the listener doesn't have a file: %T", l.Listener)
+	}
+	return fl.File()
+}
+
+// Handoff starts a new process of the program at the path with args and
+// hands off the listener of the server to it. The new process must pass the
+// listener returned from InheritedListener to New. Handoff returns after the
+// new process starts serving the API, and then the caller should drain the
+// server by Shutdown so that clients don't observe any downtime. The new
+// process is killed when it doesn't get ready within the timeout, and the
+// server keeps serving the API in that case.
+//
+// Topologies aren't handed off. The new process creates topologies written
+// in its config while the old process is still running its topologies until
+// it's stopped.
+func (s *Server) Handoff(path string, args []string, timeout time.Duration) error {
+	s.m.Lock()
+	l := s.listener
+	running := s.started && !s.stopped
+	s.m.Unlock()
+	if !running {
+		return errors.New("the server isn't running")
+	}
+
+	fl, ok := l.(interface {
+		File() (*os.File, error)
+	})
+	if !ok {
+		return fmt.Errorf("the listener cannot be handed off: %T", l)
+	}
+	lf, err := fl.File()
+	if err != nil {
+		return fmt.Errorf("cannot get the file of the listener: %v", err)
+	}
+	defer lf.Close()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		return fmt.Errorf("cannot create a pipe: %v", err)
+	}
+	defer r.Close()
+
+	cmd := exec.Command(path, args...)
+	// ExtraFiles[i] becomes the file descriptor 3+i in the new process.
+	cmd.Env = append(os.Environ(), listenerFDEnv+"=3", readyFDEnv+"=4")
+	cmd.ExtraFiles = []*os.File{lf, w}
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	err = cmd.Start()
+	w.Close() // only the new process has the write end
+	if err != nil {
+		return fmt.Errorf("cannot start a new process: %v", err)
+	}
+
+	ready := make(chan error, 1)
+	go func() {
+		b := make([]byte, 1)
+		_, err := r.Read(b)
+		if err == io.EOF {
+			err = errors.New("the new process exited before getting ready")
+		}
+		ready <- err
+	}()
+
+	select {
+	case err = <-ready:
+	case <-time.After(timeout):
+		err = fmt.Errorf("the new process didn't get ready within %v", timeout)
+	}
+	if err != nil {
+		cmd.Process.Kill()
+		cmd.Wait()
+		return err
+	}
+
+	s.gvars.Logger.WithField("pid", cmd.Process.Pid).Info("Handed off the listener to the new process")
+	return cmd.Process.Release()
+}
@@ -0,0 +1,104 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gocraft/web"
+	"gopkg.in/pfnet/jasco.v1"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+type jsonPath struct {
+	*APIContext
+}
+
+func setUpJSONPathRouter(prefix string, router *apiRouter) {
+	root := router.Subrouter(jsonPath{}, "")
+	root.Post("/jsonpath", (*jsonPath).Eval, &apiOperation{
+		ID:      "evalJSONPath",
+		Summary: "Evaluate a JSON Path",
+		Request: apiObject(map[string]*apiSchema{
+			"path":     apiString,
+			"document": apiAnyObject,
+		}, "path", "document"),
+		Response: apiObject(map[string]*apiSchema{
+			"path":   apiString,
+			"steps":  apiArray(apiRef("JSONPathStep")),
+			"result": apiAny,
+			"error":  apiString,
+		}, "path", "steps"),
+	})
+}
+
+// Eval evaluates a JSON Path given in "path" field of the request body against
+// a document given in "document" field. It returns the result with all steps
+// of the evaluation so that users can see how each component of the path is
+// interpreted.
+func (jc *jsonPath) Eval(rw web.ResponseWriter, req *web.Request) {
+	var js map[string]interface{}
+	if apiErr := jc.ParseBody(&js); apiErr != nil {
+		jc.ErrLog(apiErr.Err).Error("Cannot parse the request json")
+		jc.RenderError(apiErr)
+		return
+	}
+	form, err := data.NewMap(js)
+	if err != nil {
+		jc.ErrLog(err).WithField("body", js).Error("The request json may contain invalid value")
+		jc.RenderError(jasco.NewError(formValidationErrorCode, "The request json may contain invalid values.",
+			http.StatusBadRequest, err))
+		return
+	}
+
+	p, err := data.AsString(form["path"])
+	if err != nil {
+		jc.ErrLog(err).Error("The required 'path' field is missing or not a string")
+		jc.RenderError(jasco.NewError(formValidationErrorCode, "The request body is invalid.",
+			http.StatusBadRequest, err))
+		return
+	}
+	doc, ok := form["document"]
+	if !ok {
+		err := errors.New("document field is missing")
+		jc.ErrLog(err).Error("The required 'document' field is missing")
+		jc.RenderError(jasco.NewError(formValidationErrorCode, "The request body is invalid.",
+			http.StatusBadRequest, err))
+		return
+	}
+
+	path, err := data.CompilePath(p)
+	if err != nil {
+		jc.ErrLog(err).WithField("path", p).Error("Cannot compile the path")
+		e := jasco.NewError(formValidationErrorCode, "The path is invalid.", http.StatusBadRequest, err)
+		e.Meta["error"] = err.Error()
+		jc.RenderError(e)
+		return
+	}
+	steps, err := data.TracePath(path, doc)
+	if err != nil {
+		jc.ErrLog(err).WithField("path", p).Error("Cannot evaluate the path")
+		jc.RenderError(jasco.NewInternalServerError(err))
+		return
+	}
+
+	res := map[string]interface{}{
+		"path": p,
+	}
+	ss := make([]map[string]interface{}, len(steps))
+	for i, s := range steps {
+		ss[i] = map[string]interface{}{
+			"extractor": s.Extractor,
+		}
+		if s.Err != nil {
+			ss[i]["error"] = s.Err.Error()
+			res["error"] = s.Err.Error()
+		} else {
+			ss[i]["value"] = s.Value
+		}
+	}
+	res["steps"] = ss
+	if _, ok := res["error"]; !ok && len(steps) > 0 {
+		res["result"] = steps[len(steps)-1].Value
+	}
+	jc.Render(res)
+}
@@ -0,0 +1,185 @@
+package server
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// logBufferSize is the number of log entries of a topology kept in memory.
+const logBufferSize = 1000
+
+// logEntry is a log entry written through the core.Context of a topology.
+type logEntry struct {
+	// Seq is a sequence number of the entry in the topology. It starts from 1.
+	Seq int64 `json:"seq"`
+
+	Time    time.Time `json:"time"`
+	Level   string    `json:"level"`
+	Message string    `json:"message"`
+
+	// NodeType and NodeName are set when the entry was written by a node.
+	NodeType string `json:"node_type,omitempty"`
+	NodeName string `json:"node_name,omitempty"`
+
+	// Fields has other fields of the entry.
+	Fields map[string]interface{} `json:"fields,omitempty"`
+}
+
+// logBuffer keeps the latest log entries of a topology. It's added to the
+// logger of the topology as a hook.
+type logBuffer struct {
+	m       sync.RWMutex
+	entries []*logEntry
+	seq     int64
+
+	// updated is closed and replaced when a new entry is added.
+	updated chan struct{}
+}
+
+func newLogBuffer() *logBuffer {
+	return &logBuffer{
+		updated: make(chan struct{}),
+	}
+}
+
+func (b *logBuffer) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (b *logBuffer) Fire(e *logrus.Entry) error {
+	le := &logEntry{
+		Time:    e.Time.In(time.UTC),
+		Level:   e.Level.String(),
+		Message: e.Message,
+	}
+	for k, v := range e.Data {
+		switch k {
+		case "topology":
+			continue
+		case "node_type":
+			le.NodeType = fmt.Sprint(v)
+			continue
+		case "node_name":
+			le.NodeName = fmt.Sprint(v)
+			continue
+		}
+		switch v.(type) {
+		case string, bool, int, int64, float64:
+		default:
+			// values such as errors cannot be encoded in JSON as they are
+			v = fmt.Sprint(v)
+		}
+		if le.Fields == nil {
+			le.Fields = map[string]interface{}{}
+		}
+		le.Fields[k] = v
+	}
+
+	b.m.Lock()
+	defer b.m.Unlock()
+	b.seq++
+	le.Seq = b.seq
+	if len(b.entries) >= logBufferSize {
+		copy(b.entries, b.entries[1:])
+		b.entries = b.entries[:len(b.entries)-1]
+	}
+	b.entries = append(b.entries, le)
+	close(b.updated)
+	b.updated = make(chan struct{})
+	return nil
+}
+
+// read returns entries whose sequence numbers are greater than since. When
+// node isn't empty, only entries written by the node are returned. At most
+// limit entries are returned when limit is positive. It also returns the
+// sequence number up to which entries have been read, which can be passed as
+// since of the next call, and a channel which is closed when a new entry is
+// added.
+func (b *logBuffer) read(since int64, node string, limit int) ([]*logEntry, int64, <-chan struct{}) {
+	b.m.RLock()
+	defer b.m.RUnlock()
+	res := []*logEntry{}
+	for _, e := range b.entries {
+		if e.Seq <= since {
+			continue
+		}
+		if node != "" && !strings.EqualFold(e.NodeName, node) {
+			continue
+		}
+		if limit > 0 && len(res) >= limit {
+			return res, res[len(res)-1].Seq, b.updated
+		}
+		res = append(res, e)
+	}
+	return res, b.seq, b.updated
+}
+
+// topologyLogs manages log buffers of topologies. All methods can be called
+// on nil, which doesn't keep any log.
+type topologyLogs struct {
+	m       sync.RWMutex
+	buffers map[string]*logBuffer
+}
+
+func newTopologyLogs() *topologyLogs {
+	return &topologyLogs{
+		buffers: map[string]*logBuffer{},
+	}
+}
+
+// newLogger returns a logger for the core.Context of a topology and its log
+// buffer. The logger writes entries in the same way as base and also keeps
+// them in the buffer. The buffer needs to be added by add after the topology
+// is registered. It returns base and nil when t is nil.
+func (t *topologyLogs) newLogger(base *logrus.Logger) (*logrus.Logger, *logBuffer) {
+	if t == nil {
+		return base, nil
+	}
+	l := logrus.New()
+	l.Out = base.Out
+	l.Formatter = base.Formatter
+	l.Level = base.Level
+	for level, hs := range base.Hooks {
+		l.Hooks[level] = append([]logrus.Hook(nil), hs...)
+	}
+
+	b := newLogBuffer()
+	l.AddHook(b)
+	return l, b
+}
+
+// add adds the log buffer of the topology. It replaces the old buffer when
+// the topology has already had one.
+func (t *topologyLogs) add(name string, b *logBuffer) {
+	if t == nil || b == nil {
+		return
+	}
+	t.m.Lock()
+	defer t.m.Unlock()
+	t.buffers[strings.ToLower(name)] = b
+}
+
+// buffer returns the log buffer of the topology. It returns nil when the
+// topology doesn't have a buffer.
+func (t *topologyLogs) buffer(name string) *logBuffer {
+	if t == nil {
+		return nil
+	}
+	t.m.RLock()
+	defer t.m.RUnlock()
+	return t.buffers[strings.ToLower(name)]
+}
+
+// remove removes the log buffer of the topology.
+func (t *topologyLogs) remove(name string) {
+	if t == nil {
+		return
+	}
+	t.m.Lock()
+	defer t.m.Unlock()
+	delete(t.buffers, strings.ToLower(name))
+}
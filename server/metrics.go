@@ -0,0 +1,84 @@
+package server
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/gocraft/web"
+	"gopkg.in/pfnet/jasco.v1"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+)
+
+type metrics struct {
+	*APIContext
+}
+
+func setUpMetricsRouter(prefix string, router *apiRouter) {
+	root := router.Subrouter(metrics{}, "")
+	root.Get("/metrics", (*metrics).Index, &apiOperation{
+		ID:      "getMetrics",
+		Summary: "Get metrics in the Prometheus text exposition format",
+		Stream:  "text/plain",
+	})
+}
+
+// Index returns metrics of all states implementing core.MetricsExporter and
+// metrics registered by nodes to core.MetricRegistry in the Prometheus text
+// exposition format. Each sample has "topology" label and "state" or "node"
+// label in addition to its own labels.
+func (mc *metrics) Index(rw web.ResponseWriter, req *web.Request) {
+	ts, err := mc.topologies.List()
+	if err != nil {
+		mc.ErrLog(err).Error("Cannot list topologies")
+		mc.RenderError(jasco.NewInternalServerError(err))
+		return
+	}
+	names := make([]string, 0, len(ts))
+	for n := range ts {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	var ms []*core.Metric
+	for _, tn := range names {
+		states, err := ts[tn].Topology().Context().SharedStates.List()
+		if err != nil {
+			mc.ErrLog(err).WithField("topology", tn).Error("Cannot list states")
+			mc.RenderError(jasco.NewInternalServerError(err))
+			return
+		}
+		for sn, st := range states {
+			e, ok := st.(core.MetricsExporter)
+			if !ok {
+				continue
+			}
+			for _, m := range e.Metrics() {
+				labels := make(map[string]string, len(m.Labels)+2)
+				for k, v := range m.Labels {
+					labels[k] = v
+				}
+				labels["topology"] = tn
+				labels["state"] = sn
+				m.Labels = labels
+				ms = append(ms, m)
+			}
+		}
+
+		for _, m := range ts[tn].Topology().Context().Metrics().Metrics() {
+			// Labels of m are already copied by the registry.
+			m.Labels["topology"] = tn
+			ms = append(ms, m)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := core.WriteMetrics(&buf, ms); err != nil {
+		mc.ErrLog(err).Error("Cannot write metrics")
+		mc.RenderError(jasco.NewInternalServerError(err))
+		return
+	}
+	rw.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	if _, err := buf.WriteTo(rw); err != nil {
+		mc.ErrLog(err).Error("Cannot write metrics to the response")
+	}
+}
@@ -0,0 +1,351 @@
+package server
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gopkg.in/sensorbee/sensorbee.v0/server/config"
+)
+
+// Middleware wraps an http.Handler to add a feature common to all requests
+// such as logging. Middleware can be added to a Server by WithMiddleware.
+type Middleware func(h http.Handler) http.Handler
+
+// ChainMiddleware returns a handler processing a request with the middleware
+// in the given order before h. The first middleware is the outermost one.
+func ChainMiddleware(h http.Handler, ms ...Middleware) http.Handler {
+	for i := len(ms) - 1; i >= 0; i-- {
+		h = ms[i](h)
+	}
+	return h
+}
+
+// defaultMiddleware returns the middleware applied to all requests of the
+// server according to the config. User defined middleware is called after
+// them so that it can use the request ID and it's protected by the panic
+// recovery.
+func defaultMiddleware(conf *config.Config, logger *logrus.Logger, user []Middleware) []Middleware {
+	ms := []Middleware{RequestIDMiddleware()}
+	if conf.Logging.LogAccess {
+		ms = append(ms, AccessLogMiddleware(logger))
+	}
+	ms = append(ms, RecoveryMiddleware(logger))
+	if conf.Network.RequestTimeout > 0 {
+		ms = append(ms, TimeoutMiddleware(time.Duration(conf.Network.RequestTimeout)*time.Second))
+	}
+	if conf.Network.Gzip {
+		ms = append(ms, GzipMiddleware())
+	}
+	return append(ms, user...)
+}
+
+type requestIDKey struct{}
+
+// requestIDHeader is the header having the ID of a request. When a request
+// has it, the ID is used as is so that requests can be traced across
+// services.
+const requestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength is the maximum length of a request ID given by a client.
+const maxRequestIDLength = 128
+
+// RequestIDMiddleware returns middleware assigning an ID to each request. The
+// ID is written in X-Request-ID header of the response and can be obtained
+// by RequestIDFromContext.
+func RequestIDMiddleware() Middleware {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			id := req.Header.Get(requestIDHeader)
+			if !validRequestID(id) {
+				id = newRequestID()
+			}
+			rw.Header().Set(requestIDHeader, id)
+			h.ServeHTTP(rw, req.WithContext(context.WithValue(req.Context(), requestIDKey{}, id)))
+		})
+	}
+}
+
+// RequestIDFromContext returns the ID of a request assigned by
+// RequestIDMiddleware. It returns an empty string when the request doesn't
+// have an ID.
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, r := range id {
+		if r < 0x21 || r > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+func newRequestID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		// This rarely happens and the ID only needs to be unique in logs.
+		return fmt.Sprintf("%x", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b[:])
+}
+
+// AccessLogMiddleware returns middleware logging each request with its
+// method, path, status, size of the response body, and elapsed time after
+// the request is processed. Requests whose connections are hijacked, such as
+// WebSockets, are logged when the handler returns.
+func AccessLogMiddleware(logger *logrus.Logger) Middleware {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			start := time.Now()
+			w := &statusRecorder{ResponseWriter: rw}
+			defer func() {
+				status := w.status
+				if status == 0 && !w.hijacked {
+					status = http.StatusOK
+				}
+				l := logger.WithFields(logrus.Fields{
+					"request_id":  RequestIDFromContext(req.Context()),
+					"method":      req.Method,
+					"path":        req.URL.RequestURI(),
+					"status":      status,
+					"bytes":       w.bytes,
+					"elapsed":     time.Since(start).String(),
+					"remote_addr": req.RemoteAddr,
+					"user_agent":  req.UserAgent(),
+				})
+				if w.hijacked {
+					l = l.WithField("hijacked", true)
+				}
+				if status >= http.StatusInternalServerError {
+					l.Warn("Access")
+				} else {
+					l.Info("Access")
+				}
+			}()
+			h.ServeHTTP(w, req)
+		})
+	}
+}
+
+// internalServerErrorCode is returned when the server panics while
+// processing a request.
+const internalServerErrorCode = "E0011"
+
+// RecoveryMiddleware returns middleware recovering from a panic in a handler.
+// It logs the panic with its stack trace and returns 500 Internal Server
+// Error in the same JSON format as other errors if nothing has been written
+// to the response yet.
+func RecoveryMiddleware(logger *logrus.Logger) Middleware {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			w := &statusRecorder{ResponseWriter: rw}
+			defer func() {
+				e := recover()
+				if e == nil {
+					return
+				}
+				if e == http.ErrAbortHandler {
+					panic(e) // net/http aborts the response silently
+				}
+
+				stack := make([]byte, 64*1024)
+				stack = stack[:runtime.Stack(stack, false)]
+				id := RequestIDFromContext(req.Context())
+				logger.WithFields(logrus.Fields{
+					"request_id": id,
+					"method":     req.Method,
+					"path":       req.URL.RequestURI(),
+					"err":        fmt.Sprint(e),
+					"stack":      string(stack),
+				}).Error("Recovered from a panic while processing a request")
+
+				if w.status != 0 || w.hijacked {
+					return // too late to respond
+				}
+				body, err := json.Marshal(map[string]interface{}{
+					"error": newErrorResponse(req, internalServerErrorCode, "An internal server error occurred."),
+				})
+				if err != nil {
+					return
+				}
+				rw.Header().Set("Content-Type", "application/json")
+				rw.WriteHeader(http.StatusInternalServerError)
+				rw.Write(body)
+			}()
+			h.ServeHTTP(w, req)
+		})
+	}
+}
+
+// TimeoutMiddleware returns middleware canceling the context of a request
+// after the timeout. Handlers observing the context, such as ones waiting
+// for new log entries, stop waiting when it's canceled. Connections hijacked
+// by handlers aren't affected after the handlers return.
+func TimeoutMiddleware(timeout time.Duration) Middleware {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			ctx, cancel := context.WithTimeout(req.Context(), timeout)
+			defer cancel()
+			h.ServeHTTP(rw, req.WithContext(ctx))
+		})
+	}
+}
+
+// GzipMiddleware returns middleware compressing responses with gzip when
+// clients accept it. Responses to WebSocket requests aren't compressed.
+func GzipMiddleware() Middleware {
+	pool := sync.Pool{
+		New: func() interface{} {
+			return gzip.NewWriter(nil)
+		},
+	}
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			if !acceptsGzip(req) || strings.EqualFold(req.Header.Get("Upgrade"), "websocket") {
+				h.ServeHTTP(rw, req)
+				return
+			}
+			rw.Header().Add("Vary", "Accept-Encoding")
+
+			gz := pool.Get().(*gzip.Writer)
+			gz.Reset(rw)
+			w := &gzipResponseWriter{
+				statusRecorder: statusRecorder{ResponseWriter: rw},
+				gz:             gz,
+			}
+			defer func() {
+				if !w.hijacked && !w.plain && w.status != 0 {
+					gz.Close()
+				}
+				pool.Put(gz)
+			}()
+			h.ServeHTTP(w, req)
+		})
+	}
+}
+
+func acceptsGzip(req *http.Request) bool {
+	for _, e := range strings.Split(req.Header.Get("Accept-Encoding"), ",") {
+		if i := strings.Index(e, ";"); i >= 0 {
+			e = e[:i]
+		}
+		if strings.EqualFold(strings.TrimSpace(e), "gzip") {
+			return true
+		}
+	}
+	return false
+}
+
+// statusRecorder is an http.ResponseWriter recording the status and the
+// number of bytes written. It supports http.Flusher, http.Hijacker, and
+// http.CloseNotifier when the underlying writer supports them.
+type statusRecorder struct {
+	http.ResponseWriter
+	status   int
+	bytes    int64
+	hijacked bool
+}
+
+func (w *statusRecorder) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *statusRecorder) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	n, err := w.ResponseWriter.Write(b)
+	w.bytes += int64(n)
+	return n, err
+}
+
+func (w *statusRecorder) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (w *statusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("the response writer doesn't support hijacking")
+	}
+	conn, rw, err := h.Hijack()
+	if err == nil {
+		w.hijacked = true
+	}
+	return conn, rw, err
+}
+
+func (w *statusRecorder) CloseNotify() <-chan bool {
+	if c, ok := w.ResponseWriter.(http.CloseNotifier); ok {
+		return c.CloseNotify()
+	}
+	// never notified
+	return make(chan bool)
+}
+
+// gzipResponseWriter compresses the response body with gzip.
+type gzipResponseWriter struct {
+	statusRecorder
+	gz *gzip.Writer
+
+	// plain is true when the response cannot have a body.
+	plain bool
+}
+
+func (w *gzipResponseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		if status < 200 || status == http.StatusNoContent || status == http.StatusNotModified {
+			w.plain = true
+		} else {
+			h := w.Header()
+			h.Del("Content-Length")
+			h.Set("Content-Encoding", "gzip")
+		}
+	}
+	w.statusRecorder.WriteHeader(status)
+}
+
+func (w *gzipResponseWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		if w.Header().Get("Content-Type") == "" {
+			w.Header().Set("Content-Type", http.DetectContentType(b))
+		}
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.plain {
+		return w.statusRecorder.Write(b)
+	}
+	n, err := w.gz.Write(b)
+	w.bytes += int64(n)
+	return n, err
+}
+
+func (w *gzipResponseWriter) Flush() {
+	if !w.plain {
+		w.gz.Flush()
+	}
+	w.statusRecorder.Flush()
+}
@@ -0,0 +1,149 @@
+package server
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestMiddleware(t *testing.T) {
+	Convey("Given a logger writing to a buffer", t, func() {
+		buf := bytes.NewBuffer(nil)
+		logger := logrus.New()
+		logger.Out = buf
+		logger.Formatter = &logrus.JSONFormatter{}
+
+		ok := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			rw.Write([]byte(`{"id":"` + RequestIDFromContext(req.Context()) + `"}`))
+		})
+
+		Convey("When requesting a handler with request IDs and access logs", func() {
+			h := ChainMiddleware(ok, RequestIDMiddleware(), AccessLogMiddleware(logger))
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest("GET", "/api/v1/topologies", nil))
+
+			Convey("Then the response should have the request ID", func() {
+				id := rec.Header().Get("X-Request-ID")
+				So(id, ShouldHaveLength, 32)
+				So(rec.Body.String(), ShouldEqual, `{"id":"`+id+`"}`)
+
+				Convey("And the request should be logged with the ID", func() {
+					var l map[string]interface{}
+					So(json.Unmarshal(buf.Bytes(), &l), ShouldBeNil)
+					So(l["msg"], ShouldEqual, "Access")
+					So(l["request_id"], ShouldEqual, id)
+					So(l["path"], ShouldEqual, "/api/v1/topologies")
+					So(l["status"], ShouldEqual, 200)
+				})
+			})
+		})
+
+		Convey("When requesting a handler with a request ID given by the client", func() {
+			h := ChainMiddleware(ok, RequestIDMiddleware())
+			for _, c := range []struct {
+				id    string
+				valid bool
+			}{{"abc-123", true}, {"has space", false}, {string(make([]byte, 200)), false}} {
+				req := httptest.NewRequest("GET", "/", nil)
+				req.Header.Set("X-Request-ID", c.id)
+				rec := httptest.NewRecorder()
+				h.ServeHTTP(rec, req)
+
+				if c.valid {
+					So(rec.Header().Get("X-Request-ID"), ShouldEqual, c.id)
+				} else {
+					So(rec.Header().Get("X-Request-ID"), ShouldNotEqual, c.id)
+				}
+			}
+		})
+
+		Convey("When a handler panics", func() {
+			h := ChainMiddleware(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				panic("test")
+			}), RequestIDMiddleware(), RecoveryMiddleware(logger))
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+
+			Convey("Then it should return 500 in JSON", func() {
+				So(rec.Code, ShouldEqual, http.StatusInternalServerError)
+				var res struct {
+					Error map[string]interface{} `json:"error"`
+				}
+				So(json.Unmarshal(rec.Body.Bytes(), &res), ShouldBeNil)
+				So(res.Error["code"], ShouldEqual, internalServerErrorCode)
+				So(res.Error["request_id"], ShouldEqual, rec.Header().Get("X-Request-ID"))
+				So(buf.String(), ShouldContainSubstring, "Recovered from a panic")
+			})
+		})
+
+		Convey("When a handler of API version 2 panics", func() {
+			h := ChainMiddleware(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				panic("test")
+			}), RequestIDMiddleware(), RecoveryMiddleware(logger))
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest("GET", "/api/v2/topologies", nil))
+
+			Convey("Then it should return the structured error code", func() {
+				So(rec.Code, ShouldEqual, http.StatusInternalServerError)
+				var res struct {
+					Error map[string]interface{} `json:"error"`
+				}
+				So(json.Unmarshal(rec.Body.Bytes(), &res), ShouldBeNil)
+				So(res.Error["code"], ShouldEqual, "server.internal")
+				So(res.Error["meta"], ShouldResemble, map[string]interface{}{
+					"legacy_code": internalServerErrorCode,
+				})
+			})
+		})
+
+		Convey("When a handler times out", func() {
+			h := ChainMiddleware(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				select {
+				case <-req.Context().Done():
+					rw.WriteHeader(http.StatusServiceUnavailable)
+				case <-time.After(time.Second):
+				}
+			}), TimeoutMiddleware(10*time.Millisecond))
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+
+			Convey("Then the context of the request should be canceled", func() {
+				So(rec.Code, ShouldEqual, http.StatusServiceUnavailable)
+			})
+		})
+
+		Convey("When requesting a handler with gzip compression", func() {
+			h := ChainMiddleware(ok, GzipMiddleware())
+
+			Convey("Then the response should be compressed if the client accepts it", func() {
+				req := httptest.NewRequest("GET", "/", nil)
+				req.Header.Set("Accept-Encoding", "deflate, gzip;q=1.0")
+				rec := httptest.NewRecorder()
+				h.ServeHTTP(rec, req)
+
+				So(rec.Header().Get("Content-Encoding"), ShouldEqual, "gzip")
+				r, err := gzip.NewReader(rec.Body)
+				So(err, ShouldBeNil)
+				b, err := ioutil.ReadAll(r)
+				So(err, ShouldBeNil)
+				So(string(b), ShouldEqual, `{"id":""}`)
+			})
+
+			Convey("Then the response shouldn't be compressed if the client doesn't accept it", func() {
+				rec := httptest.NewRecorder()
+				h.ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+
+				So(rec.Header().Get("Content-Encoding"), ShouldBeBlank)
+				So(rec.Body.String(), ShouldEqual, `{"id":""}`)
+			})
+		})
+	})
+}
@@ -0,0 +1,279 @@
+package server
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"gopkg.in/sensorbee/sensorbee.v0/bql"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/udf"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/server/config"
+)
+
+// DefaultNamespace is the name of the namespace to which topologies accessed
+// by paths without a namespace segment belong.
+const DefaultNamespace = "default"
+
+// Namespace isolates topologies of a tenant from other namespaces. Topologies
+// in different namespaces can have the same name, and their UDSs are saved
+// separately. A namespace can also have its own source, sink, and UDS
+// creators and UDFs, which are only available to topologies in it.
+type Namespace struct {
+	// Name is the name of the namespace.
+	Name string
+
+	// Topologies is a registry of topologies in the namespace.
+	Topologies TopologyRegistry
+
+	// SourceCreators, SinkCreators, and UDSCreators have creators added to
+	// topologies in the namespace in addition to global ones. A creator
+	// having the same type name as a global one overrides it. They can be
+	// nil.
+	SourceCreators bql.SourceCreatorRegistry
+	SinkCreators   bql.SinkCreatorRegistry
+	UDSCreators    udf.UDSCreatorRegistry
+
+	// UDFs are functions added to topologies in the namespace in addition to
+	// global ones. Their names must differ from names of global functions.
+	UDFs map[string]udf.UDF
+
+	// Tokens are bearer tokens which clients must send to access the
+	// namespace. The namespace can be accessed without a token when it's
+	// empty.
+	Tokens []string
+}
+
+// NewNamespace creates a new namespace having no topology.
+func NewNamespace(name string) (*Namespace, error) {
+	if err := core.ValidateSymbol(name); err != nil {
+		return nil, err
+	}
+	return &Namespace{
+		Name:       name,
+		Topologies: NewDefaultTopologyRegistry(),
+	}, nil
+}
+
+// isDefault returns true if the namespace is the default namespace.
+func (ns *Namespace) isDefault() bool {
+	return strings.EqualFold(ns.Name, DefaultNamespace)
+}
+
+// authorize returns true when the token grants access to the namespace.
+func (ns *Namespace) authorize(token string) bool {
+	if len(ns.Tokens) == 0 {
+		return true
+	}
+	ok := false
+	for _, t := range ns.Tokens {
+		// Every token is compared to avoid leaking which one matched.
+		if subtle.ConstantTimeCompare([]byte(t), []byte(token)) == 1 {
+			ok = true
+		}
+	}
+	return ok
+}
+
+// qualifiedName returns the name of the topology which is unique in the
+// server. Names of topologies in the default namespace don't change.
+func (ns *Namespace) qualifiedName(topology string) string {
+	if ns.isDefault() {
+		return topology
+	}
+	return ns.Name + "/" + topology
+}
+
+// newTopologyBuilder creates a TopologyBuilder having registries of the
+// namespace. UDSs of the topology are saved to the storage separately from
+// other namespaces.
+func (ns *Namespace) newTopologyBuilder(tp core.Topology, us udf.UDSStorage) (*bql.TopologyBuilder, error) {
+	tb, err := bql.NewTopologyBuilder(tp)
+	if err != nil {
+		return nil, err
+	}
+	tb.UDSStorage = newNamespacedUDSStorage(ns, us)
+
+	if ns.SourceCreators != nil {
+		cs, err := ns.SourceCreators.List()
+		if err != nil {
+			return nil, err
+		}
+		for name, c := range cs {
+			tb.SourceCreators.Unregister(name)
+			if err := tb.SourceCreators.Register(name, c); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if ns.SinkCreators != nil {
+		cs, err := ns.SinkCreators.List()
+		if err != nil {
+			return nil, err
+		}
+		for name, c := range cs {
+			tb.SinkCreators.Unregister(name)
+			if err := tb.SinkCreators.Register(name, c); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if ns.UDSCreators != nil {
+		cs, err := ns.UDSCreators.List()
+		if err != nil {
+			return nil, err
+		}
+		for name, c := range cs {
+			tb.UDSCreators.Unregister(name)
+			if err := tb.UDSCreators.Register(name, c); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for name, f := range ns.UDFs {
+		if err := tb.Reg.Register(name, f); err != nil {
+			return nil, err
+		}
+	}
+	return tb, nil
+}
+
+// NamespaceRegistry manages namespaces of the server. The default namespace
+// always exists.
+type NamespaceRegistry struct {
+	m          sync.RWMutex
+	namespaces map[string]*Namespace
+}
+
+// NewNamespaceRegistry creates a registry having the default namespace whose
+// topologies are managed by the given registry.
+func NewNamespaceRegistry(defaultTopologies TopologyRegistry) *NamespaceRegistry {
+	return &NamespaceRegistry{
+		namespaces: map[string]*Namespace{
+			DefaultNamespace: {
+				Name:       DefaultNamespace,
+				Topologies: defaultTopologies,
+			},
+		},
+	}
+}
+
+// newNamespaceRegistry creates a registry having namespaces defined in the
+// config.
+func newNamespaceRegistry(defaultTopologies TopologyRegistry, conf config.Namespaces) (*NamespaceRegistry, error) {
+	r := NewNamespaceRegistry(defaultTopologies)
+	for name, c := range conf {
+		if strings.EqualFold(name, DefaultNamespace) {
+			ns, _ := r.Lookup(DefaultNamespace)
+			ns.Tokens = c.Tokens
+			continue
+		}
+		ns, err := NewNamespace(name)
+		if err != nil {
+			return nil, fmt.Errorf("invalid namespace name '%v': %v", name, err)
+		}
+		ns.Tokens = c.Tokens
+		if err := r.Register(ns); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// Register adds a namespace. It returns os.ErrExist when the registry
+// already has a namespace having the same name.
+func (r *NamespaceRegistry) Register(ns *Namespace) error {
+	if err := core.ValidateSymbol(ns.Name); err != nil {
+		return err
+	}
+	if ns.Topologies == nil {
+		return fmt.Errorf("the namespace '%v' doesn't have a topology registry", ns.Name)
+	}
+	r.m.Lock()
+	defer r.m.Unlock()
+	n := strings.ToLower(ns.Name)
+	if _, ok := r.namespaces[n]; ok {
+		return os.ErrExist
+	}
+	r.namespaces[n] = ns
+	return nil
+}
+
+// Lookup returns the namespace having the name. It returns
+// core.NotExistError when the namespace doesn't exist.
+func (r *NamespaceRegistry) Lookup(name string) (*Namespace, error) {
+	r.m.RLock()
+	defer r.m.RUnlock()
+	if ns, ok := r.namespaces[strings.ToLower(name)]; ok {
+		return ns, nil
+	}
+	return nil, core.NotExistError(fmt.Errorf("namespace '%v' doesn't exist", name))
+}
+
+// List returns all namespaces. The caller can safely modify the returned map.
+func (r *NamespaceRegistry) List() map[string]*Namespace {
+	r.m.RLock()
+	defer r.m.RUnlock()
+	m := make(map[string]*Namespace, len(r.namespaces))
+	for n, ns := range r.namespaces {
+		m[n] = ns
+	}
+	return m
+}
+
+// namespacedUDSStorage saves UDSs of topologies in a namespace separately
+// from other namespaces by prefixing names of topologies with the name of
+// the namespace. Names in the default namespace aren't prefixed so that
+// states saved before namespaces were introduced can still be loaded.
+type namespacedUDSStorage struct {
+	prefix string
+	s      udf.UDSStorage
+}
+
+func newNamespacedUDSStorage(ns *Namespace, s udf.UDSStorage) udf.UDSStorage {
+	if s == nil {
+		return nil
+	}
+	prefix := ""
+	if !ns.isDefault() {
+		// A name of a topology cannot have a period.
+		prefix = strings.ToLower(ns.Name) + "."
+	}
+	return &namespacedUDSStorage{
+		prefix: prefix,
+		s:      s,
+	}
+}
+
+func (s *namespacedUDSStorage) Save(topology, state, tag string) (udf.UDSStorageWriter, error) {
+	return s.s.Save(s.prefix+topology, state, tag)
+}
+
+func (s *namespacedUDSStorage) Load(topology, state, tag string) (io.ReadCloser, error) {
+	return s.s.Load(s.prefix+topology, state, tag)
+}
+
+func (s *namespacedUDSStorage) ListTopologies() ([]string, error) {
+	ts, err := s.s.ListTopologies()
+	if err != nil {
+		return nil, err
+	}
+	res := []string{}
+	for _, t := range ts {
+		if s.prefix == "" {
+			if !strings.Contains(t, ".") {
+				res = append(res, t)
+			}
+		} else if strings.HasPrefix(t, s.prefix) {
+			res = append(res, strings.TrimPrefix(t, s.prefix))
+		}
+	}
+	return res, nil
+}
+
+func (s *namespacedUDSStorage) List(topology string) (map[string][]string, error) {
+	return s.s.List(s.prefix + topology)
+}
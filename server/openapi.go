@@ -0,0 +1,367 @@
+package server
+
+import (
+	"path"
+	"strings"
+
+	"github.com/gocraft/web"
+)
+
+// openAPIVersion is the version of the OpenAPI specification to which the
+// document of the server conforms.
+const openAPIVersion = "3.0.3"
+
+// apiSchema is a Schema Object of OpenAPI, which is a subset of JSON Schema.
+type apiSchema struct {
+	Ref                  string                `json:"$ref,omitempty"`
+	Type                 string                `json:"type,omitempty"`
+	Format               string                `json:"format,omitempty"`
+	Description          string                `json:"description,omitempty"`
+	Enum                 []string              `json:"enum,omitempty"`
+	Properties           map[string]*apiSchema `json:"properties,omitempty"`
+	Required             []string              `json:"required,omitempty"`
+	Items                *apiSchema            `json:"items,omitempty"`
+	AdditionalProperties interface{}           `json:"additionalProperties,omitempty"`
+}
+
+var (
+	apiString    = &apiSchema{Type: "string"}
+	apiInteger   = &apiSchema{Type: "integer", Format: "int64"}
+	apiNumber    = &apiSchema{Type: "number"}
+	apiBoolean   = &apiSchema{Type: "boolean"}
+	apiTime      = &apiSchema{Type: "string", Format: "date-time"}
+	apiAnyObject = &apiSchema{Type: "object", AdditionalProperties: true}
+
+	// apiAny accepts any JSON value.
+	apiAny = &apiSchema{}
+)
+
+func apiRef(name string) *apiSchema {
+	return &apiSchema{Ref: "#/components/schemas/" + name}
+}
+
+func apiArray(items *apiSchema) *apiSchema {
+	return &apiSchema{Type: "array", Items: items}
+}
+
+func apiEnum(values ...string) *apiSchema {
+	return &apiSchema{Type: "string", Enum: values}
+}
+
+// apiObject returns a schema of an object having the given properties. Names
+// of required properties are given by required.
+func apiObject(props map[string]*apiSchema, required ...string) *apiSchema {
+	return &apiSchema{Type: "object", Properties: props, Required: required}
+}
+
+// apiSchemas are schemas referred by operations of the server. They're
+// placed in components of the document.
+var apiSchemas = map[string]*apiSchema{
+	"Topology": apiObject(map[string]*apiSchema{
+		"name":      apiString,
+		"version":   apiInteger,
+		"constants": apiAnyObject,
+	}, "name", "version"),
+	"Resources": apiObject(map[string]*apiSchema{
+		"max_nodes":  apiInteger,
+		"max_memory": apiInteger,
+	}),
+	"Node": apiObject(map[string]*apiSchema{
+		"node_type": apiEnum("source", "box", "sink"),
+		"name":      apiString,
+		"state":     apiString,
+		"labels":    apiArray(apiString),
+		"status":    apiAnyObject,
+		"meta":      apiAny,
+	}, "node_type", "name", "state"),
+	"QueriesRequest": apiObject(map[string]*apiSchema{
+		"queries":    apiString,
+		"parameters": apiArray(apiAny),
+		"format":     apiEnum("object", "array"),
+		"fields":     apiArray(apiString),
+	}, "queries"),
+	"QueriesResponse": apiObject(map[string]*apiSchema{
+		"topology_name": apiString,
+		"status":        apiString,
+		"queries":       apiArray(apiAny),
+		"result":        apiAny,
+	}),
+	"ApplyOperation": apiObject(map[string]*apiSchema{
+		"action":    apiEnum("create", "drop", "update", "connect"),
+		"kind":      apiEnum("source", "stream", "sink", "state"),
+		"name":      apiString,
+		"statement": apiString,
+		"reason":    apiString,
+	}, "action", "kind", "name", "statement"),
+	"AuditEntry": apiObject(map[string]*apiSchema{
+		"version":     apiInteger,
+		"time":        apiTime,
+		"user":        apiString,
+		"remote_addr": apiString,
+		"operation":   apiEnum("create", "queries", "apply", "reload_function", "destroy"),
+		"statements":  apiArray(apiString),
+		"functions":   &apiSchema{Type: "object", AdditionalProperties: apiInteger},
+		"error":       apiString,
+	}, "version", "time", "operation"),
+	"LogEntry": apiObject(map[string]*apiSchema{
+		"seq":       apiInteger,
+		"time":      apiTime,
+		"level":     apiString,
+		"message":   apiString,
+		"node_type": apiString,
+		"node_name": apiString,
+		"fields":    apiAnyObject,
+	}, "seq", "time", "level", "message"),
+	"NodeStatusHistory": apiObject(map[string]*apiSchema{
+		"node_type": apiString,
+		"node_name": apiString,
+		"samples":   apiArray(apiAnyObject),
+	}, "node_type", "node_name", "samples"),
+	"LineageRecord": apiObject(map[string]*apiSchema{
+		"tuple_id":  apiString,
+		"node":      apiString,
+		"timestamp": apiTime,
+		"inputs":    apiArray(apiString),
+		"truncated": apiBoolean,
+	}, "tuple_id", "node", "inputs"),
+	"JSONPathStep": apiObject(map[string]*apiSchema{
+		"extractor": apiString,
+		"value":     apiAny,
+		"error":     apiString,
+	}, "extractor"),
+	"StateUpload": apiObject(map[string]*apiSchema{
+		"id":         apiString,
+		"state":      apiString,
+		"tag":        apiString,
+		"offset":     apiInteger,
+		"size":       apiInteger,
+		"updated_at": apiTime,
+	}, "id", "state", "tag", "offset", "updated_at"),
+	"Error": apiObject(map[string]*apiSchema{
+		"code":       apiString,
+		"message":    apiString,
+		"request_id": apiAny,
+		"meta":       apiAnyObject,
+	}, "code", "message"),
+	"ErrorResponse": apiObject(map[string]*apiSchema{
+		"error": apiRef("Error"),
+	}, "error"),
+}
+
+// apiOperation describes an operation of a route registered to apiRouter.
+type apiOperation struct {
+	// ID is the operationId of the operation. It must be unique in the
+	// routes registered to the same apiRouter.
+	ID      string
+	Summary string
+
+	// Query has parameters given by the query string. Parameters in the path
+	// are taken from the path of the route.
+	Query []*openAPIParameter
+
+	// Request is the schema of the JSON request body. The operation doesn't
+	// have a body when it's nil.
+	Request *apiSchema
+
+	// Response is the schema of the JSON response on success.
+	Response *apiSchema
+
+	// Stream is the content type of a response other than JSON, such as
+	// "multipart/mixed" of results of SELECT statements.
+	Stream string
+}
+
+func apiQuery(name string, s *apiSchema, desc string) *openAPIParameter {
+	return &openAPIParameter{
+		Name:        name,
+		In:          "query",
+		Description: desc,
+		Schema:      s,
+	}
+}
+
+type openAPIParameter struct {
+	Name        string     `json:"name"`
+	In          string     `json:"in"`
+	Description string     `json:"description,omitempty"`
+	Required    bool       `json:"required,omitempty"`
+	Schema      *apiSchema `json:"schema"`
+}
+
+type openAPIMediaType struct {
+	Schema *apiSchema `json:"schema"`
+}
+
+type openAPIRequestBody struct {
+	Required bool                         `json:"required"`
+	Content  map[string]*openAPIMediaType `json:"content"`
+}
+
+type openAPIResponse struct {
+	Description string                       `json:"description"`
+	Content     map[string]*openAPIMediaType `json:"content,omitempty"`
+}
+
+type openAPIOperation struct {
+	OperationID string                      `json:"operationId"`
+	Summary     string                      `json:"summary,omitempty"`
+	Parameters  []*openAPIParameter         `json:"parameters,omitempty"`
+	RequestBody *openAPIRequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*openAPIResponse `json:"responses"`
+}
+
+// openAPIDocument is the OpenAPI document of the server.
+type openAPIDocument struct {
+	OpenAPI string `json:"openapi"`
+	Info    struct {
+		Title   string `json:"title"`
+		Version string `json:"version"`
+	} `json:"info"`
+	Servers []struct {
+		URL string `json:"url"`
+	} `json:"servers"`
+	Paths      map[string]map[string]*openAPIOperation `json:"paths"`
+	Components struct {
+		Schemas map[string]*apiSchema `json:"schemas"`
+	} `json:"components"`
+}
+
+// newOpenAPIDocument creates an empty document of the API having the given
+// versions. The last version is the latest one and is listed first in
+// servers of the document.
+func newOpenAPIDocument(prefix string, versions []string) *openAPIDocument {
+	d := &openAPIDocument{
+		OpenAPI: openAPIVersion,
+		Paths:   map[string]map[string]*openAPIOperation{},
+	}
+	d.Info.Title = "SensorBee API"
+	d.Info.Version = versions[len(versions)-1]
+	for i := len(versions) - 1; i >= 0; i-- {
+		v := versions[i]
+		d.Servers = append(d.Servers, struct {
+			URL string `json:"url"`
+		}{path.Join("/", prefix, "api", v)})
+	}
+	d.Components.Schemas = apiSchemas
+	return d
+}
+
+// add adds an operation of the route. Segments of the path like
+// ":topologyName" are converted to path parameters.
+func (d *openAPIDocument) add(method, routePath, idSuffix string, op *apiOperation) {
+	var params []*openAPIParameter
+	segs := strings.Split(strings.Trim(routePath, "/"), "/")
+	for i, s := range segs {
+		if !strings.HasPrefix(s, ":") {
+			continue
+		}
+		name := s[1:]
+		segs[i] = "{" + name + "}"
+		params = append(params, &openAPIParameter{
+			Name:     name,
+			In:       "path",
+			Required: true,
+			Schema:   apiString,
+		})
+	}
+	p := "/" + strings.Join(segs, "/")
+
+	o := &openAPIOperation{
+		OperationID: op.ID + idSuffix,
+		Summary:     op.Summary,
+		Parameters:  append(params, op.Query...),
+		Responses: map[string]*openAPIResponse{
+			"200": {
+				Description: "The request succeeded.",
+				Content:     map[string]*openAPIMediaType{},
+			},
+			"default": {
+				Description: "The request failed.",
+				Content: map[string]*openAPIMediaType{
+					"application/json": {Schema: apiRef("ErrorResponse")},
+				},
+			},
+		},
+	}
+	if op.Request != nil {
+		o.RequestBody = &openAPIRequestBody{
+			Content: map[string]*openAPIMediaType{
+				"application/json": {Schema: op.Request},
+			},
+		}
+	}
+	if op.Response != nil {
+		o.Responses["200"].Content["application/json"] = &openAPIMediaType{Schema: op.Response}
+	}
+	if op.Stream != "" {
+		o.Responses["200"].Content[op.Stream] = &openAPIMediaType{Schema: apiString}
+	}
+
+	if d.Paths[p] == nil {
+		d.Paths[p] = map[string]*openAPIOperation{}
+	}
+	d.Paths[p][method] = o
+}
+
+// apiRouter is a web.Router recording routes registered through it to the
+// OpenAPI document of the server. Its Subrouter, Get, Post, Put, and Delete
+// shadow ones of web.Router so that routes cannot be registered without
+// their descriptions.
+type apiRouter struct {
+	*web.Router
+
+	// path is the path of the router relative to the version path like
+	// "/api/v1".
+	path string
+
+	// idSuffix is appended to IDs of operations registered to the router so
+	// that routes registered at multiple paths have unique IDs.
+	idSuffix string
+
+	doc *openAPIDocument
+}
+
+func newAPIRouter(r *web.Router, doc *openAPIDocument) *apiRouter {
+	return &apiRouter{
+		Router: r,
+		doc:    doc,
+	}
+}
+
+// Subrouter creates a new apiRouter having the given context and path.
+func (r *apiRouter) Subrouter(ctx interface{}, pathPrefix string) *apiRouter {
+	return &apiRouter{
+		Router:   r.Router.Subrouter(ctx, pathPrefix),
+		path:     r.path + pathPrefix,
+		idSuffix: r.idSuffix,
+		doc:      r.doc,
+	}
+}
+
+// Get registers a GET route with its operation.
+func (r *apiRouter) Get(p string, fn interface{}, op *apiOperation) *apiRouter {
+	r.Router.Get(p, fn)
+	r.doc.add("get", r.path+p, r.idSuffix, op)
+	return r
+}
+
+// Post registers a POST route with its operation.
+func (r *apiRouter) Post(p string, fn interface{}, op *apiOperation) *apiRouter {
+	r.Router.Post(p, fn)
+	r.doc.add("post", r.path+p, r.idSuffix, op)
+	return r
+}
+
+// Put registers a PUT route with its operation.
+func (r *apiRouter) Put(p string, fn interface{}, op *apiOperation) *apiRouter {
+	r.Router.Put(p, fn)
+	r.doc.add("put", r.path+p, r.idSuffix, op)
+	return r
+}
+
+// Delete registers a DELETE route with its operation.
+func (r *apiRouter) Delete(p string, fn interface{}, op *apiOperation) *apiRouter {
+	r.Router.Delete(p, fn)
+	r.doc.add("delete", r.path+p, r.idSuffix, op)
+	return r
+}
@@ -0,0 +1,120 @@
+package server
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gocraft/web"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestOpenAPIDocument(t *testing.T) {
+	Convey("Given an OpenAPI document of routes of the server", t, func() {
+		doc := newOpenAPIDocument("/", apiVersions)
+		root := newAPIRouter(web.New(APIContext{}), doc)
+		setUpTopologiesRouter("/", root)
+		setUpServerStatusRouter("/", root)
+		setUpMetricsRouter("/", root)
+		setUpJSONPathRouter("/", root)
+		setUpReplicationRouter("/", root)
+
+		Convey("Then it should list servers of all versions with the latest first", func() {
+			So(doc.Info.Version, ShouldEqual, "v2")
+			So(doc.Servers, ShouldHaveLength, 2)
+			So(doc.Servers[0].URL, ShouldEqual, "/api/v2")
+			So(doc.Servers[1].URL, ShouldEqual, "/api/v1")
+		})
+
+		Convey("Then it should have operations of routes with path parameters", func() {
+			So(doc.Paths["/topologies"]["get"].OperationID, ShouldEqual, "listTopologies")
+			So(doc.Paths["/topologies"]["post"].OperationID, ShouldEqual, "createTopology")
+
+			op := doc.Paths["/topologies/{topologyName}/queries"]["post"]
+			So(op, ShouldNotBeNil)
+			So(op.RequestBody.Content["application/json"].Schema, ShouldResemble, apiRef("QueriesRequest"))
+			So(op.Responses["200"].Content, ShouldContainKey, "multipart/mixed")
+			So(op.Responses["default"].Content["application/json"].Schema, ShouldResemble, apiRef("ErrorResponse"))
+			So(op.Parameters, ShouldHaveLength, 1)
+			So(op.Parameters[0].Name, ShouldEqual, "topologyName")
+			So(op.Parameters[0].In, ShouldEqual, "path")
+
+			op = doc.Paths["/topologies/{topologyName}/sources/{sourceName}"]["get"]
+			So(op, ShouldNotBeNil)
+			So(op.OperationID, ShouldEqual, "getSource")
+			So(op.Responses["200"].Content["application/json"].Schema.Properties["source"], ShouldResemble, apiRef("Node"))
+
+			op = doc.Paths["/topologies/{topologyName}/states/{stateName}/uploads/{uploadID}"]["put"]
+			So(op, ShouldNotBeNil)
+			So(op.OperationID, ShouldEqual, "writeStateUploadChunk")
+			So(op.Parameters, ShouldHaveLength, 5)
+
+			So(doc.Paths, ShouldContainKey, "/metrics")
+			So(doc.Paths, ShouldContainKey, "/replication")
+		})
+
+		Convey("Then operations of topologies in namespaces should have the namespace parameter", func() {
+			op := doc.Paths["/namespaces/{namespace}/topologies/{topologyName}"]["get"]
+			So(op, ShouldNotBeNil)
+			So(op.OperationID, ShouldEqual, "getTopologyInNamespace")
+			So(op.Parameters[0].Name, ShouldEqual, "namespace")
+			So(op.Parameters[1].Name, ShouldEqual, "topologyName")
+		})
+
+		Convey("Then all operations should have unique IDs", func() {
+			ids := map[string]bool{}
+			for _, ops := range doc.Paths {
+				for _, op := range ops {
+					So(op.OperationID, ShouldNotBeBlank)
+					So(ids[op.OperationID], ShouldBeFalse)
+					ids[op.OperationID] = true
+				}
+			}
+		})
+
+		Convey("Then all referred schemas should be defined", func() {
+			js, err := json.Marshal(doc)
+			So(err, ShouldBeNil)
+			var check func(v interface{})
+			check = func(v interface{}) {
+				switch v := v.(type) {
+				case map[string]interface{}:
+					if r, ok := v["$ref"].(string); ok {
+						So(doc.Components.Schemas, ShouldContainKey, r[len("#/components/schemas/"):])
+					}
+					for _, e := range v {
+						check(e)
+					}
+				case []interface{}:
+					for _, e := range v {
+						check(e)
+					}
+				}
+			}
+			var m map[string]interface{}
+			So(json.Unmarshal(js, &m), ShouldBeNil)
+			So(m["openapi"], ShouldEqual, openAPIVersion)
+			check(m)
+		})
+	})
+}
+
+func TestV2ErrorCode(t *testing.T) {
+	Convey("Given error codes of API version 1", t, func() {
+		Convey("When converting them to structured error codes", func() {
+			Convey("Then known codes should be converted", func() {
+				So(v2ErrorCode(requestResourceNotFoundErrorCode, ""), ShouldEqual, "request.not_found")
+				So(v2ErrorCode(bqlStmtParseErrorCode, ""), ShouldEqual, "bql.parse_error")
+				So(v2ErrorCode(unauthorizedErrorCode, ""), ShouldEqual, "auth.unauthorized")
+			})
+
+			Convey("Then errors of BQL statements should have their reasons", func() {
+				So(v2ErrorCode(bqlStmtProcessingErrorCode, "already_exists"), ShouldEqual, "bql.already_exists")
+				So(v2ErrorCode(bqlStmtProcessingErrorCode, ""), ShouldEqual, "bql.error")
+			})
+
+			Convey("Then unknown codes should be returned as is", func() {
+				So(v2ErrorCode("E9999", ""), ShouldEqual, "E9999")
+			})
+		})
+	})
+}
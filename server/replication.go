@@ -0,0 +1,712 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gocraft/web"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/pfnet/jasco.v1"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"gopkg.in/sensorbee/sensorbee.v0/server/config"
+)
+
+const (
+	// replicationScanInterval is the interval at which the primary server
+	// looks for states created or replaced after a standby server connected.
+	replicationScanInterval = time.Second
+
+	// replicationHeartbeatInterval is the interval of heartbeats sent from
+	// the primary server while there's no change.
+	replicationHeartbeatInterval = 5 * time.Second
+
+	// replicationReceiveTimeout is how long a standby server waits for a
+	// record or a heartbeat before reconnecting to the primary server.
+	replicationReceiveTimeout = 3 * replicationHeartbeatInterval
+
+	// replicationBufferSize is the number of change records buffered for a
+	// standby server. The standby server is disconnected and resynchronized
+	// when the buffer overflows.
+	replicationBufferSize = 65536
+)
+
+// replicationStreamPath is the path of the stream of change records served
+// by the primary server.
+const replicationStreamPath = "/api/v1/replication/stream"
+
+// replicator replicates states implementing core.LoggedState from a primary
+// server to a standby server. The primary server sends change records of the
+// states to the standby server, which applies them to the states having the
+// same names in the topologies having the same names. Topologies and states
+// aren't created by the replication, so the standby server needs to create
+// them in the same way as the primary server does, e.g. with the same config.
+//
+// The replication is asynchronous and the standby server may lag behind the
+// primary server. When the standby server is disconnected, it reconnects and
+// the primary server sends the whole data of the states again. All methods
+// can be called on nil, which means the replication is disabled.
+type replicator struct {
+	conf   *config.Replication
+	logger *logrus.Logger
+
+	m          sync.Mutex
+	role       string
+	namespaces *NamespaceRegistry
+
+	// session and attached are used by the primary server. attached has
+	// states sending change records to the session. Keys are qualified
+	// names of topologies and names of states joined by "\x00".
+	session  *replicationSession
+	attached map[string]core.LoggedState
+
+	// Following fields are used by the standby server.
+	connected      bool
+	numApplied     int64
+	numErrors      int64
+	lastRecordTime time.Time
+	lastError      string
+
+	stopOnce     sync.Once
+	stopCh       chan struct{}
+	unfollowOnce sync.Once
+	unfollowCh   chan struct{}
+	followDone   chan struct{}
+	wg           sync.WaitGroup
+}
+
+// newReplicator creates a replicator from the config. It returns nil when
+// the replication is disabled.
+func newReplicator(conf *config.Replication, logger *logrus.Logger) *replicator {
+	if conf.Role == config.ReplicationNone {
+		return nil
+	}
+	return &replicator{
+		conf:       conf,
+		logger:     logger,
+		role:       conf.Role,
+		attached:   map[string]core.LoggedState{},
+		stopCh:     make(chan struct{}),
+		unfollowCh: make(chan struct{}),
+		followDone: make(chan struct{}),
+	}
+}
+
+// start starts the replication of states of topologies in the namespaces.
+func (r *replicator) start(namespaces *NamespaceRegistry) {
+	if r == nil {
+		return
+	}
+	r.m.Lock()
+	defer r.m.Unlock()
+	r.namespaces = namespaces
+	if r.role == config.ReplicationStandby {
+		r.wg.Add(1)
+		go func() {
+			defer r.wg.Done()
+			defer close(r.followDone)
+			r.follow()
+		}()
+		return
+	}
+	close(r.followDone)
+	r.startScanning()
+}
+
+// startScanning starts looking for states which aren't sending change
+// records. The caller must hold the lock.
+func (r *replicator) startScanning() {
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		t := time.NewTicker(replicationScanInterval)
+		defer t.Stop()
+		for {
+			select {
+			case <-r.stopCh:
+				return
+			case <-t.C:
+				r.scan()
+			}
+		}
+	}()
+}
+
+// stop stops the replication. It must not be called when start hasn't been
+// called.
+func (r *replicator) stop() {
+	if r == nil {
+		return
+	}
+	r.stopOnce.Do(func() {
+		close(r.stopCh)
+	})
+	r.unfollowOnce.Do(func() {
+		close(r.unfollowCh)
+	})
+	r.wg.Wait()
+}
+
+// promote makes the standby server a primary server. The standby server
+// stops receiving change records before this method returns.
+func (r *replicator) promote() error {
+	if r == nil {
+		return errors.New("the replication is disabled")
+	}
+	r.m.Lock()
+	if err := r.checkRoleLocked(config.ReplicationStandby); err != nil {
+		r.m.Unlock()
+		return err
+	}
+	r.unfollowOnce.Do(func() {
+		close(r.unfollowCh)
+	})
+	r.m.Unlock()
+	<-r.followDone
+
+	r.m.Lock()
+	defer r.m.Unlock()
+	select {
+	case <-r.stopCh:
+		return errors.New("the server is stopping")
+	default:
+	}
+	r.role = config.ReplicationPrimary
+	r.startScanning()
+	r.logger.Info("The standby server has been promoted to a primary server")
+	return nil
+}
+
+// status returns the status of the replication.
+func (r *replicator) status() map[string]interface{} {
+	if r == nil {
+		return map[string]interface{}{
+			"role": config.ReplicationNone,
+		}
+	}
+	r.m.Lock()
+	defer r.m.Unlock()
+	if r.role == config.ReplicationPrimary {
+		return map[string]interface{}{
+			"role":       r.role,
+			"connected":  r.session != nil,
+			"num_states": len(r.attached),
+		}
+	}
+	st := map[string]interface{}{
+		"role":        r.role,
+		"primary":     r.conf.Primary,
+		"connected":   r.connected,
+		"num_applied": r.numApplied,
+		"num_errors":  r.numErrors,
+	}
+	if !r.lastRecordTime.IsZero() {
+		st["last_record_time"] = r.lastRecordTime
+	}
+	if r.lastError != "" {
+		st["last_error"] = r.lastError
+	}
+	return st
+}
+
+// replicationSession is a connection from a standby server to the primary
+// server.
+type replicationSession struct {
+	ch        chan data.Map
+	closeOnce sync.Once
+	closed    chan struct{}
+	err       error
+}
+
+func newReplicationSession() *replicationSession {
+	return &replicationSession{
+		ch:     make(chan data.Map, replicationBufferSize),
+		closed: make(chan struct{}),
+	}
+}
+
+// send sends a record to the standby server without blocking. The session is
+// closed when the buffer is full.
+func (s *replicationSession) send(rec data.Map) {
+	select {
+	case s.ch <- rec:
+	default:
+		s.close(errors.New("the buffer of change records overflowed"))
+	}
+}
+
+func (s *replicationSession) close(err error) {
+	s.closeOnce.Do(func() {
+		s.err = err
+		close(s.closed)
+	})
+}
+
+// replicationLog is a core.StateChangeLog sending records of a state to a
+// session.
+type replicationLog struct {
+	session  *replicationSession
+	topology string
+	state    string
+	typeName string
+}
+
+func (l *replicationLog) Append(rec data.Map) {
+	l.session.send(data.Map{
+		"topology": data.String(l.topology),
+		"state":    data.String(l.state),
+		"type":     data.String(l.typeName),
+		"record":   rec,
+	})
+}
+
+// serve sends change records to a standby server in a MessagePack stream
+// until the standby server is disconnected, another standby server connects,
+// or the buffer of records overflows. flush is called when there's no more
+// record to be sent for the moment.
+func (r *replicator) serve(w io.Writer, flush func() error) error {
+	s, err := r.newSession()
+	if err != nil {
+		return err
+	}
+	defer r.closeSession(s)
+	r.scan()
+
+	enc := data.NewMsgpackEncoder(w)
+	t := time.NewTicker(replicationHeartbeatInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-s.closed:
+			return s.err
+		case <-r.stopCh:
+			return errors.New("the server is stopping")
+		case rec := <-s.ch:
+			if err := enc.Encode(rec); err != nil {
+				return err
+			}
+			if len(s.ch) > 0 {
+				continue
+			}
+		case <-t.C:
+			if err := enc.Encode(data.Map{"heartbeat": data.True}); err != nil {
+				return err
+			}
+		}
+		if err := flush(); err != nil {
+			return err
+		}
+	}
+}
+
+// checkRole returns an error when the server doesn't have the role.
+func (r *replicator) checkRole(role string) error {
+	if r == nil {
+		return errors.New("the replication is disabled")
+	}
+	r.m.Lock()
+	defer r.m.Unlock()
+	return r.checkRoleLocked(role)
+}
+
+func (r *replicator) checkRoleLocked(role string) error {
+	if r.role != role {
+		return fmt.Errorf("the server isn't a %v server but a %v server", role, r.role)
+	}
+	return nil
+}
+
+// newSession creates a new session replacing the previous one.
+func (r *replicator) newSession() (*replicationSession, error) {
+	if r == nil {
+		return nil, errors.New("the replication is disabled")
+	}
+	r.m.Lock()
+	defer r.m.Unlock()
+	if err := r.checkRoleLocked(config.ReplicationPrimary); err != nil {
+		return nil, err
+	}
+	if r.session != nil {
+		r.session.close(errors.New("another standby server connected"))
+		r.detachAll()
+	}
+	r.session = newReplicationSession()
+	return r.session, nil
+}
+
+func (r *replicator) closeSession(s *replicationSession) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	s.close(nil)
+	if r.session == s {
+		r.detachAll()
+		r.session = nil
+	}
+}
+
+// detachAll stops sending change records of all states. The caller must
+// hold the lock.
+func (r *replicator) detachAll() {
+	for key, st := range r.attached {
+		st.LogChanges(nil)
+		delete(r.attached, key)
+	}
+}
+
+// scan starts sending change records of states which aren't sending them to
+// the current session, and forgets states which no longer exist.
+func (r *replicator) scan() {
+	r.m.Lock()
+	defer r.m.Unlock()
+	if r.session == nil {
+		return
+	}
+
+	found := map[string]bool{}
+	for _, ns := range r.namespaces.List() {
+		ts, err := ns.Topologies.List()
+		if err != nil {
+			continue
+		}
+		for tn, tb := range ts {
+			qn := ns.qualifiedName(tn)
+			reg := tb.Topology().Context().SharedStates
+			states, err := reg.List()
+			if err != nil {
+				continue
+			}
+			for sn, st := range states {
+				ls, ok := st.(core.LoggedState)
+				if !ok {
+					continue
+				}
+				typeName, err := reg.Type(sn)
+				if err != nil {
+					continue // removed after List
+				}
+				key := qn + "\x00" + sn
+				found[key] = true
+				if r.attached[key] == ls {
+					continue
+				}
+				ls.LogChanges(&replicationLog{
+					session:  r.session,
+					topology: qn,
+					state:    sn,
+					typeName: typeName,
+				})
+				r.attached[key] = ls
+			}
+		}
+	}
+
+	for key, st := range r.attached {
+		if !found[key] {
+			st.LogChanges(nil)
+			delete(r.attached, key)
+		}
+	}
+}
+
+// follow receives change records from the primary server and reconnects to
+// it until the standby server is promoted or stopped.
+func (r *replicator) follow() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-r.unfollowCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	retry := time.Duration(r.conf.RetryInterval) * time.Second
+	for {
+		err := r.receive(ctx)
+		r.m.Lock()
+		r.connected = false
+		r.m.Unlock()
+		if ctx.Err() != nil {
+			return
+		}
+		r.logger.WithField("err", err).WithField("primary", r.conf.Primary).
+			Warn("Disconnected from the primary server")
+
+		t := time.NewTimer(retry)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// receive connects to the primary server and applies change records sent
+// from it until the connection is lost.
+func (r *replicator) receive(parent context.Context) error {
+	ctx, cancel := context.WithCancel(parent)
+	defer cancel()
+
+	req, err := http.NewRequest("GET", strings.TrimRight(r.conf.Primary, "/")+replicationStreamPath, nil)
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	if r.conf.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+r.conf.Token)
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		b, _ := ioutil.ReadAll(io.LimitReader(res.Body, 1024))
+		return fmt.Errorf("the primary server returned %v: %s", res.Status, b)
+	}
+
+	r.m.Lock()
+	r.connected = true
+	r.m.Unlock()
+	r.logger.WithField("primary", r.conf.Primary).Info("Connected to the primary server")
+
+	// The primary server sends heartbeats, so the connection is considered
+	// lost when nothing arrives for a while.
+	timedOut := make(chan struct{})
+	timer := time.AfterFunc(replicationReceiveTimeout, func() {
+		close(timedOut)
+		cancel()
+	})
+	defer timer.Stop()
+
+	dec := data.NewMsgpackDecoder(res.Body)
+	for {
+		v, err := dec.Decode()
+		if err != nil {
+			select {
+			case <-timedOut:
+				return fmt.Errorf("the primary server didn't send anything for %v", replicationReceiveTimeout)
+			default:
+				return err
+			}
+		}
+		timer.Reset(replicationReceiveTimeout)
+
+		rec, err := data.AsMap(v)
+		if err != nil {
+			return fmt.Errorf("the primary server sent an invalid record: %v", err)
+		}
+		if _, ok := rec["heartbeat"]; ok {
+			continue
+		}
+		r.apply(rec)
+	}
+}
+
+// apply applies a change record sent from the primary server.
+func (r *replicator) apply(rec data.Map) {
+	topology, state, err := r.applyChange(rec)
+
+	r.m.Lock()
+	defer r.m.Unlock()
+	r.lastRecordTime = time.Now()
+	if err == nil {
+		r.numApplied++
+		return
+	}
+	r.numErrors++
+	msg := err.Error()
+	if msg != r.lastError {
+		// Only the first of consecutive same errors is logged because every
+		// record of a missing state fails in the same way.
+		r.logger.WithFields(logrus.Fields{
+			"err":      err,
+			"topology": topology,
+			"state":    state,
+		}).Error("Cannot apply a change record from the primary server")
+	}
+	r.lastError = msg
+}
+
+func (r *replicator) applyChange(rec data.Map) (topology, state string, err error) {
+	str := func(field string) string {
+		s, e := data.AsString(rec[field])
+		if e != nil && err == nil {
+			err = fmt.Errorf("%v of the record must be a string: %v", field, e)
+		}
+		return s
+	}
+	topology, state, typeName := str("topology"), str("state"), str("type")
+	if err != nil {
+		return
+	}
+	change, err := data.AsMap(rec["record"])
+	if err != nil {
+		err = fmt.Errorf("the record doesn't have a change: %v", err)
+		return
+	}
+
+	nsName, tn := DefaultNamespace, topology
+	if i := strings.Index(topology, "/"); i >= 0 {
+		nsName, tn = topology[:i], topology[i+1:]
+	}
+	ns, err := r.namespaces.Lookup(nsName)
+	if err != nil {
+		return
+	}
+	tb, err := ns.Topologies.Lookup(tn)
+	if err != nil {
+		return
+	}
+	ctx := tb.Topology().Context()
+	t, err := ctx.SharedStates.Type(state)
+	if err != nil {
+		return
+	}
+	if t != typeName {
+		err = fmt.Errorf("the type of the state is %v but the primary server's one is %v", t, typeName)
+		return
+	}
+	st, err := ctx.SharedStates.Get(state)
+	if err != nil {
+		return
+	}
+	ls, ok := st.(core.LoggedState)
+	if !ok {
+		err = errors.New("the state doesn't support the replication")
+		return
+	}
+	err = ls.ApplyChange(ctx, change)
+	return
+}
+
+type replication struct {
+	*APIContext
+}
+
+func setUpReplicationRouter(prefix string, router *apiRouter) {
+	root := router.Subrouter(replication{}, "/replication")
+	root.Middleware((*replication).authorize)
+	root.Get("/", (*replication).Status, &apiOperation{
+		ID:       "getReplicationStatus",
+		Summary:  "Get the replication status",
+		Response: apiAnyObject,
+	})
+	root.Get("/stream", (*replication).Stream, &apiOperation{
+		ID:      "streamReplication",
+		Summary: "Stream changes of states to a standby server",
+		Stream:  "application/x-msgpack",
+	})
+	root.Post("/promote", (*replication).Promote, &apiOperation{
+		ID:       "promoteStandby",
+		Summary:  "Promote the standby server",
+		Response: apiAnyObject,
+	})
+}
+
+// authorize checks the token of the request with tokens of the default
+// namespace.
+func (rc *replication) authorize(rw web.ResponseWriter, req *web.Request, next web.NextMiddlewareFunc) {
+	ns, err := rc.namespaces.Lookup(DefaultNamespace)
+	if err != nil {
+		rc.ErrLog(err).Error("Cannot lookup the default namespace")
+		rc.RenderError(jasco.NewInternalServerError(err))
+		return
+	}
+	token := ""
+	if a := req.Header.Get("Authorization"); len(a) > 7 && strings.EqualFold(a[:7], "Bearer ") {
+		token = a[7:]
+	}
+	if !ns.authorize(token) {
+		err := errors.New("the request doesn't have a valid token for the replication")
+		rc.ErrLog(err).Error("Unauthorized access to the replication")
+		rw.Header().Set("WWW-Authenticate", `Bearer realm="sensorbee"`)
+		rc.RenderError(jasco.NewError(unauthorizedErrorCode, "The request doesn't have a valid token for the default namespace.",
+			http.StatusUnauthorized, err))
+		return
+	}
+	next(rw, req)
+}
+
+// Status returns the status of the replication.
+func (rc *replication) Status(rw web.ResponseWriter, req *web.Request) {
+	rc.Render(rc.replication.status())
+}
+
+// Stream sends change records of states to a standby server. The connection
+// is hijacked so that it isn't affected by the request timeout or the gzip
+// compression.
+func (rc *replication) Stream(rw web.ResponseWriter, req *web.Request) {
+	if err := rc.replication.checkRole(config.ReplicationPrimary); err != nil {
+		rc.ErrLog(err).Error("Cannot start the replication")
+		rc.RenderError(jasco.NewError(replicationRoleErrorCode, "The server isn't a primary server.",
+			http.StatusConflict, err))
+		return
+	}
+
+	conn, bufrw, err := rw.Hijack()
+	if err != nil {
+		rc.ErrLog(err).Error("Cannot hijack a connection")
+		rc.RenderError(jasco.NewInternalServerError(err))
+		return
+	}
+	defer conn.Close()
+
+	netConf := rc.config.Network
+	w := bufio.NewWriter(&deadlineWriter{conn: conn, netConf: netConf})
+	res := []string{
+		"HTTP/1.1 200 OK",
+		"Content-Type: application/x-msgpack",
+		"Connection: close",
+		"\r\n",
+	}
+	if _, err := w.WriteString(strings.Join(res, "\r\n")); err != nil {
+		rc.ErrLog(err).Error("Cannot write a header to the hijacked connection")
+		return
+	}
+	if err := w.Flush(); err != nil {
+		rc.ErrLog(err).Info("Cannot write a header to the hijacked connection")
+		return
+	}
+	bufrw.Flush()
+
+	fields := logrus.Fields{"standby": conn.RemoteAddr().String()}
+	rc.Log().WithFields(fields).Info("A standby server connected")
+	if err := rc.replication.serve(w, w.Flush); err != nil {
+		fields["err"] = err
+	}
+	rc.Log().WithFields(fields).Info("The standby server disconnected")
+}
+
+// deadlineWriter sets the write deadline of a connection before each write.
+type deadlineWriter struct {
+	conn    net.Conn
+	netConf *config.Network
+}
+
+func (w *deadlineWriter) Write(p []byte) (int, error) {
+	w.conn.SetWriteDeadline(streamWriteDeadline(w.netConf))
+	return w.conn.Write(p)
+}
+
+// Promote makes the standby server a primary server.
+func (rc *replication) Promote(rw web.ResponseWriter, req *web.Request) {
+	if err := rc.replication.promote(); err != nil {
+		rc.ErrLog(err).Error("Cannot promote the server")
+		rc.RenderError(jasco.NewError(replicationRoleErrorCode, "The server isn't a standby server.",
+			http.StatusConflict, err))
+		return
+	}
+	rc.Render(rc.replication.status())
+}
@@ -0,0 +1,177 @@
+package server
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/bql"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"gopkg.in/sensorbee/sensorbee.v0/server/config"
+)
+
+// newReplicationTestServer creates namespaces having a topology "test" which
+// has a key-value state "kv".
+func newReplicationTestServer() (*NamespaceRegistry, *core.KeyValueState, core.Topology) {
+	reg := NewDefaultTopologyRegistry()
+	namespaces := NewNamespaceRegistry(reg)
+	tp, err := core.NewDefaultTopology(core.NewContext(nil), "test")
+	So(err, ShouldBeNil)
+	tb, err := bql.NewTopologyBuilder(tp)
+	So(err, ShouldBeNil)
+	So(reg.Register("test", tb), ShouldBeNil)
+	kv, err := core.NewKeyValueState(nil, nil)
+	So(err, ShouldBeNil)
+	So(tp.Context().SharedStates.Add("kv", "key_value", kv), ShouldBeNil)
+	return namespaces, kv, tp
+}
+
+// eventually returns true when f returns true within a few seconds.
+func eventually(f func() bool) bool {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if f() {
+			return true
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return f()
+}
+
+func TestReplication(t *testing.T) {
+	Convey("Given a primary server and a standby server having the same state", t, func() {
+		logger := logrus.New()
+		logger.Out = ioutil.Discard
+
+		pns, pkv, ptp := newReplicationTestServer()
+		So(pkv.Put("a", data.Int(1)), ShouldBeNil)
+		primary := newReplicator(&config.Replication{Role: config.ReplicationPrimary}, logger)
+		primary.start(pns)
+
+		mux := http.NewServeMux()
+		mux.HandleFunc(replicationStreamPath, func(rw http.ResponseWriter, req *http.Request) {
+			rw.Header().Set("Content-Type", "application/x-msgpack")
+			rw.WriteHeader(http.StatusOK)
+			primary.serve(rw, func() error {
+				rw.(http.Flusher).Flush()
+				return nil
+			})
+		})
+		ts := httptest.NewServer(mux)
+
+		sns, skv, stp := newReplicationTestServer()
+		So(skv.Put("z", data.Int(26)), ShouldBeNil)
+		standby := newReplicator(&config.Replication{
+			Role:          config.ReplicationStandby,
+			Primary:       ts.URL,
+			RetryInterval: 1,
+		}, logger)
+		standby.start(sns)
+
+		// synced returns true when the standby server has received the
+		// initial entries of the primary server.
+		synced := func() bool {
+			_, ok := skv.Get("a")
+			return ok && skv.Len() == 1
+		}
+
+		Reset(func() {
+			standby.stop()
+			primary.stop()
+			ts.Close()
+			ptp.Stop()
+			stp.Stop()
+		})
+
+		Convey("When the standby server connects to the primary server", func() {
+			Convey("Then the standby server should have the same entries", func() {
+				So(eventually(synced), ShouldBeTrue)
+			})
+
+			Convey("Then statuses should show the connection", func() {
+				So(eventually(func() bool {
+					return primary.status()["num_states"] == 1 && standby.status()["connected"] == true
+				}), ShouldBeTrue)
+				So(primary.status()["connected"], ShouldBeTrue)
+			})
+		})
+
+		Convey("When updating the state of the primary server", func() {
+			So(eventually(synced), ShouldBeTrue)
+			So(pkv.Put("b", data.Map{"x": data.Timestamp(time.Unix(1, 0).UTC())}), ShouldBeNil)
+			So(pkv.Delete("a"), ShouldBeTrue)
+
+			Convey("Then the standby server should receive the updates", func() {
+				So(eventually(func() bool {
+					_, ok := skv.Get("a")
+					return !ok && skv.Len() == 1
+				}), ShouldBeTrue)
+				v, ok := skv.Get("b")
+				So(ok, ShouldBeTrue)
+				So(v, ShouldResemble, data.Map{"x": data.Timestamp(time.Unix(1, 0).UTC())})
+				So(standby.status()["num_errors"], ShouldEqual, int64(0))
+			})
+		})
+
+		Convey("When the state of the primary server is replaced", func() {
+			So(eventually(func() bool {
+				return primary.status()["num_states"] == 1
+			}), ShouldBeTrue)
+			kv, err := core.NewKeyValueState(nil, nil)
+			So(err, ShouldBeNil)
+			So(kv.Put("c", data.Int(3)), ShouldBeNil)
+			prev, err := ptp.Context().SharedStates.Replace("kv", "key_value", kv)
+			So(err, ShouldBeNil)
+			So(prev.Terminate(ptp.Context()), ShouldBeNil)
+
+			Convey("Then the standby server should have entries of the new state", func() {
+				So(eventually(func() bool {
+					_, ok := skv.Get("c")
+					return ok && skv.Len() == 1
+				}), ShouldBeTrue)
+			})
+		})
+
+		Convey("When promoting the standby server", func() {
+			So(eventually(synced), ShouldBeTrue)
+			So(standby.promote(), ShouldBeNil)
+
+			Convey("Then it should become a primary server", func() {
+				So(standby.status()["role"], ShouldEqual, config.ReplicationPrimary)
+			})
+
+			Convey("Then it shouldn't receive updates anymore", func() {
+				So(pkv.Put("d", data.Int(4)), ShouldBeNil)
+				time.Sleep(100 * time.Millisecond)
+				_, ok := skv.Get("d")
+				So(ok, ShouldBeFalse)
+			})
+
+			Convey("Then it cannot be promoted again", func() {
+				So(standby.promote(), ShouldNotBeNil)
+			})
+		})
+
+		Convey("When a primary server is requested to be promoted", func() {
+			err := primary.promote()
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+
+	Convey("Given a disabled replication", t, func() {
+		var r *replicator
+
+		Convey("Then it should report its role", func() {
+			So(r.status(), ShouldResemble, map[string]interface{}{"role": config.ReplicationNone})
+			So(r.promote(), ShouldNotBeNil)
+		})
+	})
+}
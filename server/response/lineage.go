@@ -0,0 +1,41 @@
+package response
+
+import (
+	"time"
+
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+)
+
+// LineageRecord is a part of the response which topologies.lineage action
+// returns.
+type LineageRecord struct {
+	// TupleID is the ID of the tuple emitted from the node.
+	TupleID string `json:"tuple_id"`
+
+	// Node is the name of the node which emitted the tuple.
+	Node string `json:"node"`
+
+	// Timestamp is the time when the tuple was emitted.
+	Timestamp time.Time `json:"timestamp"`
+
+	// Inputs are IDs of input tuples which contributed to the tuple.
+	Inputs []string `json:"inputs"`
+
+	// Truncated is true when more tuples contributed than the limit.
+	Truncated bool `json:"truncated"`
+}
+
+// NewLineageRecord creates a new response of a lineage record.
+func NewLineageRecord(r *core.LineageRecord) *LineageRecord {
+	inputs := r.Inputs
+	if inputs == nil {
+		inputs = []string{}
+	}
+	return &LineageRecord{
+		TupleID:   r.TupleID,
+		Node:      r.Node,
+		Timestamp: r.Timestamp,
+		Inputs:    inputs,
+		Truncated: r.Truncated,
+	}
+}
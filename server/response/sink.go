@@ -10,6 +10,7 @@ type Sink struct {
 	NodeType string      `json:"node_type"`
 	Name     string      `json:"name"`
 	State    string      `json:"state"`
+	Labels   []string    `json:"labels,omitempty"`
 	Status   data.Map    `json:"status,omitempty"`
 	Meta     interface{} `json:"meta,omitempty"`
 }
@@ -21,6 +22,7 @@ func NewSink(sn core.SinkNode, detailed bool) *Sink {
 		NodeType: core.NTSink.String(),
 		Name:     sn.Name(),
 		State:    sn.State().Get().String(),
+		Labels:   sn.Labels(),
 	}
 
 	if detailed {
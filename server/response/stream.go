@@ -10,6 +10,7 @@ type Stream struct {
 	NodeType string      `json:"node_type"`
 	Name     string      `json:"name"`
 	State    string      `json:"state"`
+	Labels   []string    `json:"labels,omitempty"`
 	Status   data.Map    `json:"status,omitempty"`
 	Meta     interface{} `json:"meta,omitempty"`
 }
@@ -21,6 +22,7 @@ func NewStream(bn core.BoxNode, detailed bool) *Stream {
 		NodeType: core.NTBox.String(),
 		Name:     bn.Name(),
 		State:    bn.State().Get().String(),
+		Labels:   bn.Labels(),
 	}
 
 	if detailed {
@@ -2,12 +2,22 @@ package response
 
 import (
 	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
 )
 
 // Topology is a part of the response which topologies.show action returns.
 type Topology struct {
 	// Name is the name of the topology.
 	Name string `json:"name"`
+
+	// Version is incremented every time the topology is modified through
+	// the API. It's 0 when the topology hasn't been modified since the
+	// server started.
+	Version int64 `json:"version"`
+
+	// Constants has constants of the topology which can be referred by $name
+	// in BQL statements.
+	Constants data.Map `json:"constants,omitempty"`
 }
 
 // NewTopology creates a new response of a topology.
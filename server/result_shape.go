@@ -0,0 +1,166 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+
+	"gopkg.in/pfnet/jasco.v1"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+const (
+	// resultFormatObject encodes each result as a JSON object.
+	resultFormatObject = "object"
+
+	// resultFormatArray encodes each result as a JSON array of values.
+	// Names of columns are sent separately.
+	resultFormatArray = "array"
+)
+
+// resultShape controls how results of a SELECT statement are encoded for a
+// client. It's specified by "format" and "fields" fields of a request:
+//
+//   - format: "object" (default) sends each result as an object. "array"
+//     sends each result as an array of values and sends names of columns
+//     before the first result and whenever they change.
+//   - fields: an array of JSON Paths of fields to be sent. All fields are sent
+//     when it's omitted. With "array" format, columns are fields in the given
+//     order and a missing field is null.
+//
+// A nil resultShape sends results as they are.
+type resultShape struct {
+	format string
+	fields []string
+	paths  []data.Path
+
+	// columns is the list of columns last sent to the client in the array
+	// format.
+	columns []string
+}
+
+// parseResultShape parses "format" and "fields" fields of the form. It
+// returns nil when neither of them is given.
+func parseResultShape(form data.Map) (*resultShape, *jasco.Error) {
+	fv, hasFormat := form["format"]
+	lv, hasFields := form["fields"]
+	if !hasFormat && !hasFields {
+		return nil, nil
+	}
+
+	s := &resultShape{
+		format: resultFormatObject,
+	}
+	if hasFormat {
+		f, err := data.AsString(fv)
+		if err == nil && f != resultFormatObject && f != resultFormatArray {
+			err = fmt.Errorf("unsupported format: %v", f)
+		}
+		if err != nil {
+			e := jasco.NewError(formValidationErrorCode, "The request body is invalid.",
+				http.StatusBadRequest, err)
+			e.Meta["format"] = []string{`value must be "object" or "array"`}
+			return nil, e
+		}
+		s.format = f
+	}
+
+	if hasFields {
+		a, err := data.AsArray(lv)
+		if err != nil {
+			e := jasco.NewError(formValidationErrorCode, "The request body is invalid.",
+				http.StatusBadRequest, err)
+			e.Meta["fields"] = []string{"value must be an array of strings"}
+			return nil, e
+		}
+		for _, v := range a {
+			f, err := data.AsString(v)
+			if err != nil {
+				e := jasco.NewError(formValidationErrorCode, "The request body is invalid.",
+					http.StatusBadRequest, err)
+				e.Meta["fields"] = []string{"value must be an array of strings"}
+				return nil, e
+			}
+			p, err := data.CompilePath(f)
+			if err != nil {
+				e := jasco.NewError(formValidationErrorCode, "The request body is invalid.",
+					http.StatusBadRequest, err)
+				e.Meta["fields"] = []string{fmt.Sprintf("'%v' isn't a valid path: %v", f, err)}
+				return nil, e
+			}
+			s.fields = append(s.fields, f)
+			s.paths = append(s.paths, p)
+		}
+	}
+	return s, nil
+}
+
+// shape converts a result to the value sent to the client. columns isn't nil
+// when names of columns have to be sent to the client before the value.
+func (s *resultShape) shape(m data.Map) (columns []string, v data.Value) {
+	if s == nil {
+		return nil, m
+	}
+
+	if s.format == resultFormatObject {
+		if s.paths == nil {
+			return nil, m
+		}
+		res := data.Map{}
+		for i, p := range s.paths {
+			if x, err := m.Get(p); err == nil {
+				res[s.fields[i]] = x
+			}
+		}
+		return nil, res
+	}
+
+	cols := s.fields
+	if s.paths == nil {
+		cols = make([]string, 0, len(m))
+		for k := range m {
+			cols = append(cols, k)
+		}
+		sort.Strings(cols)
+	}
+	if !sameColumns(s.columns, cols) {
+		s.columns = cols
+		columns = cols
+	}
+
+	res := make(data.Array, len(cols))
+	for i, c := range cols {
+		var x data.Value
+		if s.paths == nil {
+			x = m[c]
+		} else if y, err := m.Get(s.paths[i]); err == nil {
+			x = y
+		}
+		if x == nil {
+			x = data.Null{}
+		}
+		res[i] = x
+	}
+	return columns, res
+}
+
+func sameColumns(a, b []string) bool {
+	if a == nil || len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// columnsHeader returns the body of a part having names of columns.
+func columnsHeader(columns []string) data.Map {
+	cs := make(data.Array, len(columns))
+	for i, c := range columns {
+		cs[i] = data.String(c)
+	}
+	return data.Map{"columns": cs}
+}
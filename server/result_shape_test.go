@@ -0,0 +1,109 @@
+package server
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+func TestResultShape(t *testing.T) {
+	Convey("Given a result of a SELECT statement", t, func() {
+		m := data.Map{
+			"a": data.Int(1),
+			"b": data.String("x"),
+			"c": data.Map{"d": data.Float(1.5)},
+		}
+
+		Convey("When neither format nor fields is given", func() {
+			s, err := parseResultShape(data.Map{"queries": data.String("SELECT RSTREAM * FROM s [RANGE 1 TUPLES];")})
+			So(err, ShouldBeNil)
+
+			Convey("Then the result should be sent as it is", func() {
+				So(s, ShouldBeNil)
+				cols, v := s.shape(m)
+				So(cols, ShouldBeNil)
+				So(v, ShouldResemble, m)
+			})
+		})
+
+		Convey("When selecting fields in the object format", func() {
+			s, err := parseResultShape(data.Map{
+				"fields": data.Array{data.String("a"), data.String("c.d"), data.String("z")},
+			})
+			So(err, ShouldBeNil)
+
+			Convey("Then the result should only have the fields", func() {
+				cols, v := s.shape(m)
+				So(cols, ShouldBeNil)
+				So(v, ShouldResemble, data.Map{"a": data.Int(1), "c.d": data.Float(1.5)})
+			})
+		})
+
+		Convey("When using the array format without fields", func() {
+			s, err := parseResultShape(data.Map{"format": data.String("array")})
+			So(err, ShouldBeNil)
+
+			Convey("Then columns should be sorted names of fields", func() {
+				cols, v := s.shape(m)
+				So(cols, ShouldResemble, []string{"a", "b", "c"})
+				So(v, ShouldResemble, data.Array{data.Int(1), data.String("x"), data.Map{"d": data.Float(1.5)}})
+
+				Convey("And columns shouldn't be sent again while they're same", func() {
+					cols, v := s.shape(data.Map{"a": data.Int(2), "b": data.String("y"), "c": data.Null{}})
+					So(cols, ShouldBeNil)
+					So(v, ShouldResemble, data.Array{data.Int(2), data.String("y"), data.Null{}})
+				})
+
+				Convey("And columns should be sent again when they change", func() {
+					cols, v := s.shape(data.Map{"a": data.Int(3)})
+					So(cols, ShouldResemble, []string{"a"})
+					So(v, ShouldResemble, data.Array{data.Int(3)})
+				})
+			})
+		})
+
+		Convey("When using the array format with fields", func() {
+			s, err := parseResultShape(data.Map{
+				"format": data.String("array"),
+				"fields": data.Array{data.String("b"), data.String("z"), data.String("c.d")},
+			})
+			So(err, ShouldBeNil)
+
+			Convey("Then values should be in the order of fields", func() {
+				cols, v := s.shape(m)
+				So(cols, ShouldResemble, []string{"b", "z", "c.d"})
+				So(v, ShouldResemble, data.Array{data.String("x"), data.Null{}, data.Float(1.5)})
+
+				cols, _ = s.shape(data.Map{"b": data.String("y")})
+				So(cols, ShouldBeNil)
+			})
+		})
+
+		Convey("When the format is invalid", func() {
+			_, err := parseResultShape(data.Map{"format": data.String("csv")})
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Meta["format"], ShouldNotBeNil)
+			})
+		})
+
+		Convey("When fields have an invalid path", func() {
+			_, err := parseResultShape(data.Map{"fields": data.Array{data.String("a[")}})
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Meta["fields"], ShouldNotBeNil)
+			})
+		})
+
+		Convey("When fields isn't an array of strings", func() {
+			_, err := parseResultShape(data.Map{"fields": data.Array{data.Int(1)}})
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
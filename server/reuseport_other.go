@@ -0,0 +1,15 @@
+//go:build !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd
+// +build !darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd
+
+package server
+
+import (
+	"errors"
+	"syscall"
+)
+
+// reusePortControl always fails because SO_REUSEPORT isn't supported on
+// this platform.
+func reusePortControl(network, address string, c syscall.RawConn) error {
+	return errors.New("network.reuse_port isn't supported on this platform")
+}
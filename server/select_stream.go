@@ -0,0 +1,209 @@
+package server
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"gopkg.in/sensorbee/sensorbee.v0/server/config"
+)
+
+// errSlowClient is the reason why a stream of results is ended when the
+// client cannot keep up with it under the "disconnect" policy.
+var errSlowClient = errors.New("the client is too slow to receive results")
+
+// selectResult is a tuple to be written to a client. dropped is the number of
+// tuples dropped right before the tuple because the client was too slow.
+type selectResult struct {
+	tuple   *core.Tuple
+	dropped int64
+}
+
+type bufferedTuple struct {
+	tuple *core.Tuple
+	at    time.Time
+}
+
+// selectStream relays tuples from the channel of a temporary sink to a client
+// through a bounded buffer so that a slow client doesn't block the sink and,
+// by extension, the topology. When the buffer is full, the stream either ends
+// with errSlowClient or drops the oldest buffered tuple depending on the slow
+// client policy.
+//
+// selectStream exports the following metrics of the client to the node
+// metrics of the sink with "remote_addr" label:
+//
+//   - select_client_buffered_tuples: the number of buffered tuples
+//   - select_client_lag_seconds: how long the oldest buffered tuple has waited
+//   - select_client_dropped_tuples_total: the number of dropped tuples
+type selectStream struct {
+	in     <-chan *core.Tuple
+	out    chan selectResult
+	stop   chan struct{}
+	once   sync.Once
+	policy string
+
+	buf  []bufferedTuple
+	head int
+	n    int
+
+	// e is set before out is closed.
+	e error
+
+	buffered *core.Gauge
+	lag      *core.Gauge
+	dropped  *core.Counter
+}
+
+// newSelectStream starts relaying tuples from ch. m is the node metrics of
+// the sink and can be nil when metrics aren't exported.
+func newSelectStream(ch <-chan *core.Tuple, conf *config.Network, m *core.NodeMetrics, remoteAddr string) (*selectStream, error) {
+	size := conf.StreamBufferSize
+	if size < 1 {
+		size = config.DefaultStreamBufferSize
+	}
+	s := &selectStream{
+		in:     ch,
+		out:    make(chan selectResult),
+		stop:   make(chan struct{}),
+		policy: conf.SlowClientPolicy,
+		buf:    make([]bufferedTuple, size),
+	}
+
+	if m != nil {
+		labels := map[string]string{"remote_addr": remoteAddr}
+		var err error
+		if s.buffered, err = m.Gauge("select_client_buffered_tuples", labels); err != nil {
+			return nil, err
+		}
+		if s.lag, err = m.Gauge("select_client_lag_seconds", labels); err != nil {
+			return nil, err
+		}
+		if s.dropped, err = m.Counter("select_client_dropped_tuples_total", labels); err != nil {
+			return nil, err
+		}
+	}
+	go s.run()
+	return s, nil
+}
+
+// results returns a channel from which the client receives results. It's
+// closed when the sink's channel is closed, the client is too slow, or the
+// stream is closed.
+func (s *selectStream) results() <-chan selectResult {
+	return s.out
+}
+
+// err returns the reason why the stream ended. It returns nil when the
+// stream ended normally. It must be called after the channel returned from
+// results is closed.
+func (s *selectStream) err() error {
+	return s.e
+}
+
+// close stops relaying tuples. The caller still has to vacuum the sink's
+// channel.
+func (s *selectStream) close() {
+	s.once.Do(func() {
+		close(s.stop)
+	})
+}
+
+func (s *selectStream) run() {
+	defer close(s.out)
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	in := s.in
+	var dropped int64
+	for {
+		var (
+			out chan selectResult
+			r   selectResult
+		)
+		if s.n > 0 {
+			out = s.out
+			r = selectResult{tuple: s.buf[s.head].tuple, dropped: dropped}
+		} else if in == nil {
+			return
+		}
+
+		select {
+		case t, ok := <-in:
+			if !ok {
+				in = nil
+				break
+			}
+			if s.n == len(s.buf) {
+				if s.policy != config.SlowClientDrop {
+					s.e = errSlowClient
+					return
+				}
+				s.pop()
+				dropped++
+				if s.dropped != nil {
+					s.dropped.Inc()
+				}
+			}
+			s.push(t)
+
+		case out <- r:
+			s.pop()
+			dropped = 0
+
+		case <-ticker.C:
+		case <-s.stop:
+			return
+		}
+		s.updateMetrics()
+	}
+}
+
+func (s *selectStream) push(t *core.Tuple) {
+	s.buf[(s.head+s.n)%len(s.buf)] = bufferedTuple{tuple: t, at: time.Now()}
+	s.n++
+}
+
+func (s *selectStream) pop() {
+	s.buf[s.head] = bufferedTuple{}
+	s.head = (s.head + 1) % len(s.buf)
+	s.n--
+}
+
+func (s *selectStream) updateMetrics() {
+	if s.buffered == nil {
+		return
+	}
+	s.buffered.Set(float64(s.n))
+	if s.n == 0 {
+		s.lag.Set(0)
+	} else {
+		s.lag.Set(time.Now().Sub(s.buf[s.head].at).Seconds())
+	}
+}
+
+// newStreamNotice creates a notice sent to a client of a stream of results.
+// typ is "dropped" or "disconnected". dropped is only included when it's
+// positive.
+func newStreamNotice(typ, msg string, dropped int64) data.Map {
+	n := data.Map{
+		"type":    data.String(typ),
+		"message": data.String(msg),
+	}
+	if dropped > 0 {
+		n["dropped"] = data.Int(dropped)
+	}
+	return data.Map{"notice": n}
+}
+
+// streamWriteDeadline returns the deadline of a write to a client of a stream
+// of results. It returns the zero time, which means no deadline, when the
+// timeout is disabled.
+func streamWriteDeadline(conf *config.Network) time.Time {
+	if conf.StreamWriteTimeout <= 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(time.Duration(conf.StreamWriteTimeout) * time.Second)
+}
@@ -0,0 +1,121 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"gopkg.in/sensorbee/sensorbee.v0/server/config"
+)
+
+func TestSelectStream(t *testing.T) {
+	newTuple := func(i int) *core.Tuple {
+		return core.NewTuple(data.Map{"i": data.Int(i)})
+	}
+
+	send := func(ch chan<- *core.Tuple, from, to int) {
+		for i := from; i < to; i++ {
+			ch <- newTuple(i)
+		}
+	}
+
+	Convey("Given a stream of results having a small buffer", t, func() {
+		ch := make(chan *core.Tuple)
+		conf := &config.Network{StreamBufferSize: 2}
+		m := core.NewContext(nil).Metrics().Node("sink")
+
+		Convey("When the buffer has enough room for all tuples", func() {
+			conf.StreamBufferSize = 5
+			conf.SlowClientPolicy = config.SlowClientDisconnect
+			s, err := newSelectStream(ch, conf, m, "127.0.0.1:1234")
+			So(err, ShouldBeNil)
+			defer s.close()
+			go func() {
+				send(ch, 0, 5)
+				close(ch)
+			}()
+
+			Convey("Then it should relay all tuples in order", func() {
+				i := 0
+				for r := range s.results() {
+					So(r.tuple.Data["i"], ShouldEqual, data.Int(i))
+					So(r.dropped, ShouldEqual, 0)
+					i++
+				}
+				So(i, ShouldEqual, 5)
+				So(s.err(), ShouldBeNil)
+			})
+		})
+
+		Convey("When the client is too slow under the disconnect policy", func() {
+			conf.SlowClientPolicy = config.SlowClientDisconnect
+			s, err := newSelectStream(ch, conf, m, "127.0.0.1:1234")
+			So(err, ShouldBeNil)
+			defer s.close()
+
+			Convey("Then it shouldn't block the sink", func() {
+				send(ch, 0, 3)
+
+				Convey("And it should end with an error", func() {
+					n := 0
+					for _ = range s.results() {
+						n++
+					}
+					So(n, ShouldEqual, 0)
+					So(s.err(), ShouldEqual, errSlowClient)
+				})
+			})
+		})
+
+		Convey("When the client is too slow under the drop policy", func() {
+			conf.SlowClientPolicy = config.SlowClientDrop
+			s, err := newSelectStream(ch, conf, m, "127.0.0.1:1234")
+			So(err, ShouldBeNil)
+			defer s.close()
+
+			Convey("Then it shouldn't block the sink", func() {
+				send(ch, 0, 5)
+				close(ch)
+
+				Convey("And it should drop the oldest tuples", func() {
+					var rs []selectResult
+					for r := range s.results() {
+						rs = append(rs, r)
+					}
+					So(rs, ShouldHaveLength, 2)
+					So(rs[0].tuple.Data["i"], ShouldEqual, data.Int(3))
+					So(rs[0].dropped, ShouldEqual, 3)
+					So(rs[1].tuple.Data["i"], ShouldEqual, data.Int(4))
+					So(rs[1].dropped, ShouldEqual, 0)
+					So(s.err(), ShouldBeNil)
+				})
+
+				Convey("And it should export the number of dropped tuples", func() {
+					for _ = range s.results() {
+					}
+					c, err := m.Counter("select_client_dropped_tuples_total", map[string]string{"remote_addr": "127.0.0.1:1234"})
+					So(err, ShouldBeNil)
+					So(c.Value(), ShouldEqual, 3)
+				})
+			})
+		})
+
+		Convey("When the stream is closed", func() {
+			s, err := newSelectStream(ch, conf, m, "127.0.0.1:1234")
+			So(err, ShouldBeNil)
+			s.close()
+
+			Convey("Then the results should be closed", func() {
+				select {
+				case _, ok := <-s.results():
+					So(ok, ShouldBeFalse)
+				case <-time.After(5 * time.Second):
+					So("timeout", ShouldBeNil)
+				}
+				So(s.err(), ShouldBeNil)
+			})
+		})
+	})
+}
@@ -0,0 +1,551 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+
+	"github.com/gocraft/web"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"gopkg.in/pfnet/jasco.v1"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"gopkg.in/sensorbee/sensorbee.v0/server/config"
+)
+
+// Server is a SensorBee server which can be embedded in other programs. It
+// has topologies, registries, a logger, and an HTTP server providing the API.
+// It also serves the gRPC API when network.grpc_listen_on is set in the
+// config or WithGRPCListener is given.
+//
+// A Server is created by New and starts serving the API by Start:
+//
+//	s, err := server.New(server.WithConfig(conf))
+//	if err != nil {
+//		return err
+//	}
+//	if err := s.Start(); err != nil {
+//		return err
+//	}
+//	defer s.Stop()
+type Server struct {
+	gvars    *ContextGlobalVariables
+	handler  http.Handler
+	listener net.Listener
+
+	m          sync.Mutex
+	httpServer *http.Server
+	started    bool
+	stopped    bool
+	done       chan struct{}
+	serveErr   error
+
+	// grpcServer is nil when the gRPC API is disabled.
+	grpcServer   *grpc.Server
+	grpcListener net.Listener
+	grpcDone     chan struct{}
+	grpcStopping chan struct{}
+
+	// worker is non-nil when the server runs as a worker process.
+	worker *Worker
+
+	// workers supervises worker processes of isolated topologies. It's nil
+	// when there's no such topology.
+	workers *workerSupervisor
+}
+
+type serverOptions struct {
+	config     *config.Config
+	listener   net.Listener
+	grpcLis    net.Listener
+	routes     []func(prefix string, r *web.Router)
+	namespaces []*Namespace
+	middleware []Middleware
+	worker     *Worker
+	workerPath string
+	workerArgs []string
+}
+
+// Option is an option of New.
+type Option func(o *serverOptions) error
+
+// WithConfig sets the config of the server. When this option isn't given,
+// the default config is used. Don't make any change on the config after
+// passing it to New.
+func WithConfig(c *config.Config) Option {
+	return func(o *serverOptions) error {
+		if c == nil {
+			return errors.New("the config must not be nil")
+		}
+		o.config = c
+		return nil
+	}
+}
+
+// WithListener sets the listener on which the server accepts connections.
+// When this option is given, network.listen_on in the config is ignored. The
+// listener is closed when the server stops.
+func WithListener(l net.Listener) Option {
+	return func(o *serverOptions) error {
+		if l == nil {
+			return errors.New("the listener must not be nil")
+		}
+		o.listener = l
+		return nil
+	}
+}
+
+// WithGRPCListener sets the listener on which the server accepts connections
+// of the gRPC API. When this option is given, the gRPC API is enabled and
+// network.grpc_listen_on in the config is ignored. The listener is closed
+// when the server stops.
+func WithGRPCListener(l net.Listener) Option {
+	return func(o *serverOptions) error {
+		if l == nil {
+			return errors.New("the listener must not be nil")
+		}
+		o.grpcLis = l
+		return nil
+	}
+}
+
+// WithRoute adds user defined routes to the API router. The function is
+// called with the router of each version of the API such as "/api/v1". See
+// SetUpAPIRouter for details. This option can be given multiple times.
+func WithRoute(route func(prefix string, r *web.Router)) Option {
+	return func(o *serverOptions) error {
+		if route == nil {
+			return errors.New("the route must not be nil")
+		}
+		o.routes = append(o.routes, route)
+		return nil
+	}
+}
+
+// WithNamespace adds a namespace to the server in addition to namespaces
+// defined in the config. It's used to give a namespace its own registries
+// of sources, sinks, UDSs, and UDFs. This option can be given multiple times.
+func WithNamespace(ns *Namespace) Option {
+	return func(o *serverOptions) error {
+		if ns == nil {
+			return errors.New("the namespace must not be nil")
+		}
+		o.namespaces = append(o.namespaces, ns)
+		return nil
+	}
+}
+
+// WithMiddleware adds user defined middleware to the HTTP handler of the
+// server. It's called for all requests after the middleware set up by the
+// config, i.e. request IDs, access logs, panic recovery, timeouts, and gzip
+// compression. This option can be given multiple times and the middleware
+// is called in the given order.
+func WithMiddleware(m Middleware) Option {
+	return func(o *serverOptions) error {
+		if m == nil {
+			return errors.New("the middleware must not be nil")
+		}
+		o.middleware = append(o.middleware, m)
+		return nil
+	}
+}
+
+// AsWorker makes the server run as a worker process hosting the topology of
+// the Worker returned from InheritedWorker. A worker process only sets up the
+// topology and serves the API on the listener handed off from the parent
+// server, which must be given by WithListener. The gRPC API is disabled. The
+// server stops when the parent server stops it or exits.
+func AsWorker(w *Worker) Option {
+	return func(o *serverOptions) error {
+		if w == nil {
+			return errors.New("the worker must not be nil")
+		}
+		o.worker = w
+		return nil
+	}
+}
+
+// WithWorkerCommand sets the program and its arguments with which worker
+// processes of topologies isolated by the "process" isolation mode are
+// started. The program must create a server with AsWorker and the same
+// config as this server. When this option isn't given, the executable of the
+// current process is started with the same arguments.
+func WithWorkerCommand(path string, args ...string) Option {
+	return func(o *serverOptions) error {
+		if path == "" {
+			return errors.New("the path must not be empty")
+		}
+		o.workerPath = path
+		o.workerArgs = args
+		return nil
+	}
+}
+
+// New creates a new Server. It sets up the logger, the storage of UDSs, and
+// topologies written in the config, but doesn't start serving the API until
+// Start is called. Stop must be called to release resources even if Start
+// isn't called.
+func New(opts ...Option) (*Server, error) {
+	o := &serverOptions{}
+	for _, opt := range opts {
+		if err := opt(o); err != nil {
+			return nil, err
+		}
+	}
+	if o.config == nil {
+		c, err := config.New(data.Map{})
+		if err != nil {
+			return nil, fmt.Errorf("cannot apply the default config: %v", err)
+		}
+		o.config = c
+	}
+
+	if o.worker != nil {
+		if o.listener == nil {
+			return nil, errors.New("a worker process must be given the listener of the parent server")
+		}
+		if _, ok := o.config.Topologies[o.worker.Topology]; !ok {
+			return nil, fmt.Errorf("the topology '%v' of the worker process isn't defined in the config", o.worker.Topology)
+		}
+	} else if o.workerPath == "" {
+		p, err := os.Executable()
+		if err != nil {
+			return nil, fmt.Errorf("cannot find the executable for worker processes: %v", err)
+		}
+		o.workerPath = p
+		o.workerArgs = os.Args[1:]
+	}
+
+	gvars, err := SetUpContextGlobalVariables(o.config)
+	if err != nil {
+		return nil, fmt.Errorf("cannot set up the server context: %v", err)
+	}
+	if o.worker != nil {
+		gvars.workerTopology = o.worker.Topology
+	}
+	gvars.Logger.WithField("config", o.config.ToMap()).Info("Setting up the server context")
+	for _, ns := range o.namespaces {
+		if err := gvars.Namespaces.Register(ns); err != nil {
+			gvars.LogDestination.Close()
+			return nil, fmt.Errorf("cannot add the namespace '%v': %v", ns.Name, err)
+		}
+	}
+
+	jascoRoot := jasco.New("/", gvars.Logger)
+	router, err := SetUpContextAndRouter("/", jascoRoot, gvars)
+	if err != nil {
+		gvars.LogDestination.Close()
+		return nil, fmt.Errorf("cannot set up the server context: %v", err)
+	}
+	SetUpAPIRouter("/", router, func(prefix string, r *web.Router) {
+		for _, route := range o.routes {
+			route(prefix, r)
+		}
+	})
+
+	var workers *workerSupervisor
+	if o.worker == nil {
+		workers, err = newWorkerSupervisor(o.config, o.workerPath, o.workerArgs, gvars.Logger)
+		if err != nil {
+			stopTopologies(gvars)
+			gvars.LogDestination.Close()
+			return nil, fmt.Errorf("cannot set up worker processes: %v", err)
+		}
+		workers.start()
+	}
+	gvars.history.start(gvars.Namespaces)
+	if o.worker == nil {
+		// States in worker processes aren't replicated.
+		gvars.replication.start(gvars.Namespaces)
+	}
+	ms := defaultMiddleware(o.config, gvars.Logger, o.middleware)
+	if workers != nil {
+		ms = append(ms, workers.middleware())
+	}
+
+	s := &Server{
+		gvars:        gvars,
+		handler:      ChainMiddleware(jascoRoot, ms...),
+		listener:     o.listener,
+		done:         make(chan struct{}),
+		grpcListener: o.grpcLis,
+		grpcDone:     make(chan struct{}),
+		grpcStopping: make(chan struct{}),
+		worker:       o.worker,
+		workers:      workers,
+	}
+	if o.worker == nil && (o.grpcLis != nil || o.config.Network.GRPCListenOn != "") {
+		s.grpcServer = newGRPCServer(gvars, s.grpcStopping)
+	}
+	return s, nil
+}
+
+// Config returns the config of the server.
+func (s *Server) Config() *config.Config {
+	return s.gvars.Config
+}
+
+// Logger returns the logger of the server.
+func (s *Server) Logger() *logrus.Logger {
+	return s.gvars.Logger
+}
+
+// Topologies returns the registry of topologies in the server.
+func (s *Server) Topologies() TopologyRegistry {
+	return s.gvars.Topologies
+}
+
+// Workers returns statuses of worker processes of topologies isolated by the
+// "process" isolation mode.
+func (s *Server) Workers() []*WorkerStatus {
+	return s.workers.statuses()
+}
+
+// Handler returns the handler of the HTTP API. It can be used to serve the
+// API without calling Start, e.g. with httptest.
+func (s *Server) Handler() http.Handler {
+	return s.handler
+}
+
+// Start starts serving the API in a new goroutine. It returns after the
+// server starts listening.
+func (s *Server) Start() error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.stopped {
+		return errors.New("the server is already stopped")
+	}
+	if s.started {
+		return errors.New("the server is already started")
+	}
+
+	l := s.listener
+	if l == nil {
+		lc := net.ListenConfig{}
+		if s.gvars.Config.Network.ReusePort {
+			lc.Control = reusePortControl
+		}
+		var err error
+		l, err = lc.Listen(context.Background(), "tcp", s.gvars.Config.Network.ListenOn)
+		if err != nil {
+			return fmt.Errorf("cannot listen on %v: %v", s.gvars.Config.Network.ListenOn, err)
+		}
+		s.listener = l
+	}
+	gl := s.grpcListener
+	if s.grpcServer != nil && gl == nil {
+		var err error
+		gl, err = net.Listen("tcp", s.gvars.Config.Network.GRPCListenOn)
+		if err != nil {
+			if s.listener != nil {
+				s.listener.Close()
+				s.listener = nil
+			}
+			return fmt.Errorf("cannot listen on %v: %v", s.gvars.Config.Network.GRPCListenOn, err)
+		}
+		s.grpcListener = gl
+	}
+	s.httpServer = &http.Server{
+		Handler: s.handler,
+	}
+	s.started = true
+
+	s.gvars.Logger.Infof("Starting the server on %v", l.Addr())
+	go func() {
+		defer close(s.done)
+		err := s.httpServer.Serve(l)
+		if err == http.ErrServerClosed {
+			err = nil
+		}
+		s.m.Lock()
+		s.serveErr = err
+		s.m.Unlock()
+		s.gvars.Logger.Info("The server stopped")
+	}()
+
+	if s.grpcServer != nil {
+		s.gvars.Logger.Infof("Starting the gRPC server on %v", gl.Addr())
+		go func() {
+			defer close(s.grpcDone)
+			if err := s.grpcServer.Serve(gl); err != nil {
+				s.gvars.Logger.WithField("err", err).Error("The gRPC server stopped with an error")
+			}
+		}()
+	}
+
+	if s.worker != nil {
+		go func() {
+			select {
+			case <-s.worker.ParentDone():
+				s.gvars.Logger.Info("Stopping the worker process")
+				s.Stop()
+			case <-s.done:
+			}
+		}()
+	}
+
+	if il, ok := l.(*inheritedListener); ok {
+		if err := il.notifyReady(); err != nil {
+			s.gvars.Logger.WithField("err", err).Error("Cannot notify the parent process that the server is ready")
+		}
+	}
+	return nil
+}
+
+// Addr returns the address on which the server is listening. It returns nil
+// when the server isn't listening.
+func (s *Server) Addr() net.Addr {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.listener == nil {
+		return nil
+	}
+	return s.listener.Addr()
+}
+
+// GRPCAddr returns the address on which the server is listening for the
+// gRPC API. It returns nil when the gRPC API isn't being served.
+func (s *Server) GRPCAddr() net.Addr {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.grpcServer == nil || s.grpcListener == nil {
+		return nil
+	}
+	return s.grpcListener.Addr()
+}
+
+// Wait blocks until the server stops serving the API. It returns an error
+// which stopped the server, or nil when it's stopped by Stop. It returns
+// immediately when the server hasn't been started.
+func (s *Server) Wait() error {
+	s.m.Lock()
+	started := s.started
+	s.m.Unlock()
+	if !started {
+		return nil
+	}
+
+	<-s.done
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.serveErr
+}
+
+// Stop stops the HTTP server and all topologies in the server. It's safe to
+// call Stop multiple times.
+func (s *Server) Stop() error {
+	return s.stop(nil)
+}
+
+// Shutdown gracefully stops the server. It stops accepting new connections
+// and waits for active requests to finish before stopping topologies.
+// Connections still active when ctx is done are closed. Long-lived
+// connections such as SELECT statements' responses and WebSockets aren't
+// waited for. Select calls of the gRPC API are also ended. It's safe to call
+// Shutdown and Stop multiple times.
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.stop(ctx)
+}
+
+// stop stops the server. The HTTP server is gracefully shut down when ctx
+// isn't nil.
+func (s *Server) stop(ctx context.Context) error {
+	s.m.Lock()
+	if s.stopped {
+		s.m.Unlock()
+		return nil
+	}
+	s.stopped = true
+	hs := s.httpServer
+	l := s.listener
+	started := s.started
+	gl := s.grpcListener
+	s.m.Unlock()
+
+	var err error
+	if hs != nil && ctx != nil {
+		if err = hs.Shutdown(ctx); err != nil {
+			s.gvars.Logger.WithField("err", err).Warning("Closing remaining connections")
+			err = hs.Close()
+		}
+	} else if hs != nil {
+		err = hs.Close() // also closes the listener
+	} else if l != nil {
+		l.Close()
+	}
+	if s.grpcServer != nil {
+		close(s.grpcStopping)
+		if !started {
+			if gl != nil {
+				gl.Close()
+			}
+		} else if ctx != nil {
+			s.gracefulStopGRPC(ctx)
+		} else {
+			s.grpcServer.Stop() // also closes the listener
+		}
+	}
+
+	s.workers.stop(workerStopTimeout)
+	s.gvars.history.stop()
+	if s.worker == nil {
+		s.gvars.replication.stop()
+	}
+	if e := stopTopologies(s.gvars); e != nil && err == nil {
+		err = e
+	}
+
+	if hs != nil {
+		<-s.done
+	}
+	if s.grpcServer != nil && started {
+		<-s.grpcDone
+	}
+	if e := s.gvars.LogDestination.Close(); e != nil && err == nil {
+		err = e
+	}
+	return err
+}
+
+// stopTopologies stops all topologies in the default namespace. It returns
+// the first error.
+func stopTopologies(gvars *ContextGlobalVariables) error {
+	ts, err := gvars.Topologies.List()
+	if err != nil {
+		gvars.Logger.WithField("err", err).Error("Cannot list topologies")
+		return err
+	}
+	for name, tb := range ts {
+		if e := tb.Topology().Stop(); e != nil {
+			gvars.Logger.WithFields(logrus.Fields{
+				"err":      e,
+				"topology": name,
+			}).Error("Cannot stop the topology")
+			if err == nil {
+				err = e
+			}
+		}
+	}
+	return err
+}
+
+// gracefulStopGRPC gracefully stops the gRPC server. Calls still active when
+// ctx is done are canceled.
+func (s *Server) gracefulStopGRPC(ctx context.Context) {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		s.grpcServer.GracefulStop()
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		s.gvars.Logger.Warning("Canceling remaining gRPC calls")
+		s.grpcServer.Stop()
+		<-done
+	}
+}
@@ -18,9 +18,13 @@ type serverStatus struct {
 	*APIContext
 }
 
-func setUpServerStatusRouter(prefix string, router *web.Router) {
+func setUpServerStatusRouter(prefix string, router *apiRouter) {
 	root := router.Subrouter(serverStatus{}, "")
-	root.Get("/runtime_status", (*serverStatus).RuntimeStatus)
+	root.Get("/runtime_status", (*serverStatus).RuntimeStatus, &apiOperation{
+		ID:       "getRuntimeStatus",
+		Summary:  "Get the runtime status of the server",
+		Response: apiAnyObject,
+	})
 }
 
 func (ss *serverStatus) RuntimeStatus(rw web.ResponseWriter, req *web.Request) {
@@ -55,5 +59,8 @@ func (ss *serverStatus) RuntimeStatus(rw web.ResponseWriter, req *web.Request) {
 	} else {
 		res["user"] = user.Username
 	}
+	if ss.scheduler != nil {
+		res["scheduler"] = ss.scheduler.Status()
+	}
 	ss.Render(res)
 }
@@ -13,11 +13,29 @@ type sinks struct {
 	sink core.SinkNode
 }
 
-func setUpSinksRouter(prefix string, router *web.Router) {
+func setUpSinksRouter(prefix string, router *apiRouter) {
 	root := router.Subrouter(sinks{}, "/:topologyName/sinks")
 	root.Middleware((*sinks).fetchSink)
-	root.Get("/", (*sinks).Index)
-	root.Get("/:sinkName", (*sinks).Show)
+	root.Get("/", (*sinks).Index, &apiOperation{
+		ID:      "listSinks",
+		Summary: "List sinks of a topology",
+		Query: []*openAPIParameter{
+			apiQuery("labels", apiString, "Comma separated labels which sinks must have. A label prefixed with '!' must not be attached"),
+		},
+		Response: apiObject(map[string]*apiSchema{
+			"topology": apiString,
+			"count":    apiInteger,
+			"sinks":    apiArray(apiRef("Node")),
+		}, "topology", "count", "sinks"),
+	})
+	root.Get("/:sinkName", (*sinks).Show, &apiOperation{
+		ID:      "getSink",
+		Summary: "View a sink detail",
+		Response: apiObject(map[string]*apiSchema{
+			"topology": apiString,
+			"sink":     apiRef("Node"),
+		}, "topology", "sink"),
+	})
 }
 
 func (sc *sinks) fetchSink(rw web.ResponseWriter, req *web.Request, next web.NextMiddlewareFunc) {
@@ -44,9 +62,17 @@ func (sc *sinks) fetchSink(rw web.ResponseWriter, req *web.Request, next web.Nex
 func (sc *sinks) Index(rw web.ResponseWriter, req *web.Request) {
 	// TODO: support pagination
 
+	sel := sc.labelSelector(req)
+	if sel == nil {
+		return
+	}
+
 	sinks := sc.topology.Topology().Sinks()
 	res := make([]*response.Sink, 0, len(sinks))
 	for _, s := range sinks {
+		if !sel.Matches(s.Labels()) {
+			continue
+		}
 		res = append(res, response.NewSink(s, false))
 	}
 	sc.Render(map[string]interface{}{
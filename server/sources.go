@@ -13,11 +13,29 @@ type sources struct {
 	src core.SourceNode
 }
 
-func setUpSourcesRouter(prefix string, router *web.Router) {
+func setUpSourcesRouter(prefix string, router *apiRouter) {
 	root := router.Subrouter(sources{}, "/:topologyName/sources")
 	root.Middleware((*sources).fetchSource)
-	root.Get("/", (*sources).Index)
-	root.Get("/:sourceName", (*sources).Show)
+	root.Get("/", (*sources).Index, &apiOperation{
+		ID:      "listSources",
+		Summary: "List sources of a topology",
+		Query: []*openAPIParameter{
+			apiQuery("labels", apiString, "Comma separated labels which sources must have. A label prefixed with '!' must not be attached"),
+		},
+		Response: apiObject(map[string]*apiSchema{
+			"topology": apiString,
+			"count":    apiInteger,
+			"sources":  apiArray(apiRef("Node")),
+		}, "topology", "count", "sources"),
+	})
+	root.Get("/:sourceName", (*sources).Show, &apiOperation{
+		ID:      "getSource",
+		Summary: "View a source detail",
+		Response: apiObject(map[string]*apiSchema{
+			"topology": apiString,
+			"source":   apiRef("Node"),
+		}, "topology", "source"),
+	})
 }
 
 func (sc *sources) fetchSource(rw web.ResponseWriter, req *web.Request, next web.NextMiddlewareFunc) {
@@ -44,9 +62,17 @@ func (sc *sources) fetchSource(rw web.ResponseWriter, req *web.Request, next web
 func (sc *sources) Index(rw web.ResponseWriter, req *web.Request) {
 	// TODO: support pagination
 
+	sel := sc.labelSelector(req)
+	if sel == nil {
+		return
+	}
+
 	srcs := sc.topology.Topology().Sources()
 	res := make([]*response.Source, 0, len(srcs))
 	for _, s := range srcs {
+		if !sel.Matches(s.Labels()) {
+			continue
+		}
 		res = append(res, response.NewSource(s, false))
 	}
 	sc.Render(map[string]interface{}{
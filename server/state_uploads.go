@@ -0,0 +1,649 @@
+package server
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"mime"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gocraft/web"
+	"gopkg.in/pfnet/jasco.v1"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/parser"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/udf"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// stateUploadTimeout is the duration after which an upload of a state which
+// doesn't receive any chunk is aborted.
+const stateUploadTimeout = time.Hour
+
+// stateUploadChunkBufferSize is the size of the buffer used to copy a chunk
+// to the storage. A chunk is never buffered entirely in memory.
+const stateUploadChunkBufferSize = 64 * 1024
+
+var sha256Pattern = regexp.MustCompile("^[0-9a-f]{64}$")
+
+// stateUploadRegistry manages uploads of saved states of UDSs. An upload
+// writes the data of a state to the UDS storage chunk by chunk so that a
+// large state such as a multi-GB model can be uploaded without buffering it
+// in memory and can be resumed after a connection is lost. The uploaded
+// state can be loaded by LOAD STATE once the upload is committed.
+type stateUploadRegistry struct {
+	m       sync.Mutex
+	uploads map[string]*stateUpload
+	timeout time.Duration
+}
+
+func newStateUploadRegistry(timeout time.Duration) *stateUploadRegistry {
+	return &stateUploadRegistry{
+		uploads: map[string]*stateUpload{},
+		timeout: timeout,
+	}
+}
+
+// create starts a new upload of the state of the topology. owner is the
+// name of the topology unique in the server, which is used to look up the
+// upload since the registry is shared by all namespaces. size is the total
+// size of the data in bytes and is unknown when it's negative. checksum is
+// the expected hex-encoded SHA-256 digest of the whole data and can be
+// empty.
+func (r *stateUploadRegistry) create(owner string, storage udf.UDSStorage, topology, state, tag string,
+	size int64, checksum string) (*stateUpload, error) {
+	if checksum != "" && !sha256Pattern.MatchString(checksum) {
+		return nil, core.CodedError(core.ErrCodeInvalidArgument,
+			errors.New("sha256 must be a hex-encoded SHA-256 digest in lower case"))
+	}
+	r.sweep()
+
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return nil, err
+	}
+	w, err := storage.Save(topology, state, tag)
+	if err != nil {
+		return nil, err
+	}
+	u := &stateUpload{
+		id:       hex.EncodeToString(b[:]),
+		owner:    owner,
+		state:    state,
+		tag:      tag,
+		size:     size,
+		checksum: checksum,
+		w:        w,
+		hash:     sha256.New(),
+		updated:  time.Now(),
+	}
+	u.done = func() {
+		r.m.Lock()
+		defer r.m.Unlock()
+		delete(r.uploads, u.id)
+	}
+
+	r.m.Lock()
+	defer r.m.Unlock()
+	r.uploads[u.id] = u
+	return u, nil
+}
+
+// lookup returns the upload having the id. It returns core.NotExistError
+// when the upload doesn't exist or it isn't an upload of the state of the
+// topology owning it.
+func (r *stateUploadRegistry) lookup(owner, state, id string) (*stateUpload, error) {
+	r.sweep()
+	r.m.Lock()
+	defer r.m.Unlock()
+	u, ok := r.uploads[id]
+	if !ok || u.owner != owner || !strings.EqualFold(u.state, state) {
+		return nil, core.NotExistError(fmt.Errorf("the upload '%v' doesn't exist", id))
+	}
+	return u, nil
+}
+
+// sweep aborts uploads which haven't received any chunk within the timeout.
+func (r *stateUploadRegistry) sweep() {
+	var expired []*stateUpload
+	now := time.Now()
+	r.m.Lock()
+	for _, u := range r.uploads {
+		if u.expired(now, r.timeout) {
+			expired = append(expired, u)
+		}
+	}
+	r.m.Unlock()
+	for _, u := range expired {
+		u.abort() // the upload removes itself from the registry
+	}
+}
+
+// stateUpload is an upload of a state. Chunks of the data have to be written
+// in order and only one chunk can be written at a time.
+type stateUpload struct {
+	id       string
+	owner    string
+	state    string
+	tag      string
+	size     int64
+	checksum string
+
+	m sync.Mutex
+	w udf.UDSStorageWriter
+
+	// hash has the digest of data written so far.
+	hash    hash.Hash
+	offset  int64
+	busy    bool
+	closed  bool
+	updated time.Time
+
+	// done removes the upload from the registry.
+	done func()
+}
+
+// stateUploadStatus is the status of an upload returned from the API.
+type stateUploadStatus struct {
+	ID        string    `json:"id"`
+	State     string    `json:"state"`
+	Tag       string    `json:"tag"`
+	Offset    int64     `json:"offset"`
+	Size      *int64    `json:"size,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (u *stateUpload) status() *stateUploadStatus {
+	u.m.Lock()
+	defer u.m.Unlock()
+	s := &stateUploadStatus{
+		ID:        u.id,
+		State:     u.state,
+		Tag:       u.tag,
+		Offset:    u.offset,
+		UpdatedAt: u.updated,
+	}
+	if u.size >= 0 {
+		size := u.size
+		s.Size = &size
+	}
+	return s
+}
+
+func (u *stateUpload) expired(now time.Time, timeout time.Duration) bool {
+	u.m.Lock()
+	defer u.m.Unlock()
+	return !u.busy && now.Sub(u.updated) > timeout
+}
+
+// begin marks the upload busy. It fails when another chunk is being written
+// or the upload is already closed.
+func (u *stateUpload) begin() error {
+	u.m.Lock()
+	defer u.m.Unlock()
+	if u.closed {
+		return core.NotExistError(fmt.Errorf("the upload '%v' doesn't exist", u.id))
+	}
+	if u.busy {
+		return core.CodedError(core.ErrCodeInvalidState,
+			errors.New("another request to the upload is being processed"))
+	}
+	u.busy = true
+	return nil
+}
+
+func (u *stateUpload) end() {
+	u.m.Lock()
+	defer u.m.Unlock()
+	u.busy = false
+	u.updated = time.Now()
+}
+
+// write appends a chunk read from r to the data. offset must be the size of
+// the data uploaded so far. checksum is the expected hex-encoded SHA-256
+// digest of the chunk and can be empty.
+//
+// When reading r fails, e.g. because the client disconnected, the bytes read
+// before the failure are kept and the upload can be resumed from the offset
+// returned from status. Since the data is written directly to the storage,
+// a chunk cannot be discarded after it's written. Therefore, the upload is
+// aborted when the chunk doesn't match the checksum, exceeds the size of the
+// upload, or cannot be written to the storage.
+func (u *stateUpload) write(offset int64, r io.Reader, checksum string) error {
+	if checksum != "" && !sha256Pattern.MatchString(checksum) {
+		return core.CodedError(core.ErrCodeInvalidArgument,
+			errors.New("sha256 must be a hex-encoded SHA-256 digest in lower case"))
+	}
+	if err := u.begin(); err != nil {
+		return err
+	}
+	defer u.end()
+	if offset != u.offset { // u.offset is only modified by the busy request
+		return core.CodedError(core.ErrCodeInvalidState,
+			fmt.Errorf("the offset must be %v but %v is given", u.offset, offset))
+	}
+
+	abort := func(e error) error {
+		u.abortLocked()
+		return e
+	}
+	h := sha256.New()
+	buf := make([]byte, stateUploadChunkBufferSize)
+	for {
+		n, rerr := r.Read(buf)
+		if n > 0 {
+			if u.size >= 0 && u.offset+int64(n) > u.size {
+				return abort(core.CodedError(core.ErrCodeInvalidArgument,
+					fmt.Errorf("the data exceeds the size of the upload (%v bytes)", u.size)))
+			}
+			if _, err := u.w.Write(buf[:n]); err != nil {
+				return abort(err)
+			}
+			u.hash.Write(buf[:n])
+			h.Write(buf[:n])
+			u.m.Lock()
+			u.offset += int64(n)
+			u.m.Unlock()
+		}
+		if rerr == io.EOF {
+			break
+		}
+		if rerr != nil {
+			return rerr
+		}
+	}
+
+	if checksum != "" && hex.EncodeToString(h.Sum(nil)) != checksum {
+		return abort(core.CodedError(core.ErrCodeInvalidArgument,
+			errors.New("the chunk doesn't match the checksum")))
+	}
+	return nil
+}
+
+// commit persists the uploaded data in the storage. checksum is the
+// expected hex-encoded SHA-256 digest of the whole data. The one given when
+// the upload was created is used when it's empty. The upload is aborted
+// when the data doesn't match the checksum.
+func (u *stateUpload) commit(checksum string) error {
+	if checksum != "" && !sha256Pattern.MatchString(checksum) {
+		return core.CodedError(core.ErrCodeInvalidArgument,
+			errors.New("sha256 must be a hex-encoded SHA-256 digest in lower case"))
+	}
+	if err := u.begin(); err != nil {
+		return err
+	}
+	defer u.end()
+	if u.size >= 0 && u.offset != u.size {
+		return core.CodedError(core.ErrCodeInvalidState,
+			fmt.Errorf("only %v of %v bytes have been uploaded", u.offset, u.size))
+	}
+	if checksum == "" {
+		checksum = u.checksum
+	}
+	if checksum != "" && hex.EncodeToString(u.hash.Sum(nil)) != checksum {
+		u.abortLocked()
+		return core.CodedError(core.ErrCodeInvalidArgument,
+			errors.New("the uploaded data doesn't match the checksum"))
+	}
+
+	u.close()
+	return u.w.Commit()
+}
+
+// abort discards the uploaded data.
+func (u *stateUpload) abort() error {
+	if err := u.begin(); err != nil {
+		return err
+	}
+	defer u.end()
+	return u.abortLocked()
+}
+
+// abortLocked aborts the upload while the caller marks it busy.
+func (u *stateUpload) abortLocked() error {
+	u.close()
+	return u.w.Abort()
+}
+
+func (u *stateUpload) close() {
+	u.m.Lock()
+	u.closed = true
+	u.m.Unlock()
+	u.done()
+}
+
+type stateUploads struct {
+	*topologies
+	stateName string
+}
+
+func setUpStateUploadsRouter(prefix string, router *apiRouter) {
+	root := router.Subrouter(stateUploads{}, "/:topologyName/states/:stateName/uploads")
+	root.Middleware((*stateUploads).extractStateName)
+	root.Post("/", (*stateUploads).Create, &apiOperation{
+		ID:      "createStateUpload",
+		Summary: "Start an upload of a saved state",
+		Request: apiObject(map[string]*apiSchema{
+			"tag":    apiString,
+			"size":   apiInteger,
+			"sha256": apiString,
+		}),
+		Response: apiObject(map[string]*apiSchema{"upload": apiRef("StateUpload")}, "upload"),
+	})
+	root.Get("/:uploadID", (*stateUploads).Show, &apiOperation{
+		ID:       "getStateUpload",
+		Summary:  "View the progress of an upload of a saved state",
+		Response: apiObject(map[string]*apiSchema{"upload": apiRef("StateUpload")}, "upload"),
+	})
+	root.Put("/:uploadID", (*stateUploads).Write, &apiOperation{
+		ID:      "writeStateUploadChunk",
+		Summary: "Upload a chunk of a saved state",
+		Query: []*openAPIParameter{
+			apiQuery("offset", apiInteger, "The size of the data uploaded so far"),
+			apiQuery("sha256", apiString, "The hex-encoded SHA-256 digest of the chunk"),
+		},
+		Response: apiObject(map[string]*apiSchema{"upload": apiRef("StateUpload")}, "upload"),
+	})
+	root.Post("/:uploadID/commit", (*stateUploads).Commit, &apiOperation{
+		ID:      "commitStateUpload",
+		Summary: "Save the uploaded state and optionally load it",
+		Request: apiObject(map[string]*apiSchema{
+			"sha256": apiString,
+			"load": apiObject(map[string]*apiSchema{
+				"type":   apiString,
+				"params": apiAnyObject,
+			}, "type"),
+		}),
+		Response: apiObject(map[string]*apiSchema{
+			"upload":    apiRef("StateUpload"),
+			"statement": apiString,
+		}, "upload"),
+	})
+	root.Delete("/:uploadID", (*stateUploads).Abort, &apiOperation{
+		ID:       "abortStateUpload",
+		Summary:  "Abort an upload of a saved state",
+		Response: apiAnyObject,
+	})
+}
+
+func (sc *stateUploads) extractStateName(rw web.ResponseWriter, req *web.Request, next web.NextMiddlewareFunc) {
+	sc.stateName = sc.PathParams().String("stateName", "")
+	sc.AddLogField("state_name", sc.stateName)
+	next(rw, req)
+}
+
+// renderUploadError renders an error returned from stateUploadRegistry or
+// stateUpload. Its HTTP status is chosen from the code of the error.
+func (sc *stateUploads) renderUploadError(msg string, err error) {
+	sc.ErrLog(err).Error(msg)
+	switch core.ErrorCode(err) {
+	case core.ErrCodeNotFound:
+		sc.RenderError(jasco.NewError(requestResourceNotFoundErrorCode, "The upload doesn't exist",
+			http.StatusNotFound, err))
+		return
+	case core.ErrCodeInvalidState:
+		e := jasco.NewError(stateUploadErrorCode, msg, http.StatusConflict, err)
+		e.Meta["error"] = err.Error()
+		sc.RenderError(e)
+		return
+	case core.ErrCodeInvalidArgument:
+		e := jasco.NewError(stateUploadErrorCode, msg, http.StatusBadRequest, err)
+		e.Meta["error"] = err.Error()
+		sc.RenderError(e)
+		return
+	}
+	sc.RenderError(jasco.NewInternalServerError(err))
+}
+
+// parseForm parses the JSON body of the request into v. An empty body is
+// accepted. It renders an error and returns false when the body is invalid.
+func (sc *stateUploads) parseForm(req *web.Request, v interface{}) bool {
+	if req.ContentLength == 0 {
+		return true
+	}
+	var js map[string]interface{}
+	if apiErr := sc.ParseBody(&js); apiErr != nil {
+		sc.ErrLog(apiErr.Err).Error("Cannot parse the request json")
+		sc.RenderError(apiErr)
+		return false
+	}
+	form, err := data.NewMap(js)
+	if err != nil {
+		sc.ErrLog(err).WithField("body", js).Error("The request json may contain invalid value")
+		sc.RenderError(jasco.NewError(formValidationErrorCode, "The request json may contain invalid values.",
+			http.StatusBadRequest, err))
+		return false
+	}
+	if err := data.NewDecoder(nil).Decode(form, v); err != nil {
+		sc.ErrLog(err).Error("Invalid parameters")
+		sc.RenderError(jasco.NewError(formValidationErrorCode, "The request json has invalid parameters.",
+			http.StatusBadRequest, err))
+		return false
+	}
+	return true
+}
+
+func (sc *stateUploads) fetchUpload() *stateUpload {
+	id := sc.PathParams().String("uploadID", "")
+	sc.AddLogField("upload_id", id)
+	u, err := sc.uploads.lookup(sc.qualifiedName(sc.topologyName), sc.stateName, id)
+	if err != nil {
+		sc.renderUploadError("Cannot find the upload", err)
+		return nil
+	}
+	return u
+}
+
+// Create starts a new upload of a saved state of a UDS. The data is saved to
+// the UDS storage of the topology with the tag when the upload is committed.
+// "size" is the total size of the data and "sha256" is the expected SHA-256
+// digest of the data. Both of them are optional.
+func (sc *stateUploads) Create(rw web.ResponseWriter, req *web.Request) {
+	tb := sc.fetchTopology()
+	if tb == nil {
+		return
+	}
+	var form struct {
+		Tag    string
+		Size   *int64 `bql:",weaklytyped"`
+		SHA256 string `bql:"sha256"`
+	}
+	if !sc.parseForm(req, &form) {
+		return
+	}
+	msgs := map[string][]string{}
+	if err := core.ValidateSymbol(sc.stateName); err != nil {
+		msgs["state_name"] = []string{err.Error()}
+	}
+	if form.Tag != "" {
+		if err := core.ValidateSymbol(form.Tag); err != nil {
+			msgs["tag"] = []string{err.Error()}
+		}
+	}
+	size := int64(-1)
+	if form.Size != nil {
+		if *form.Size < 0 {
+			msgs["size"] = []string{"value must not be negative"}
+		}
+		size = *form.Size
+	}
+	if len(msgs) > 0 {
+		sc.Log().WithField("errors", msgs).Error("Invalid parameters")
+		e := jasco.NewError(formValidationErrorCode, "The request body is invalid.",
+			http.StatusBadRequest, nil)
+		for k, v := range msgs {
+			e.Meta[k] = v
+		}
+		sc.RenderError(e)
+		return
+	}
+
+	u, err := sc.uploads.create(sc.qualifiedName(sc.topologyName), tb.UDSStorage, tb.Topology().Name(),
+		sc.stateName, form.Tag, size, form.SHA256)
+	if err != nil {
+		sc.renderUploadError("Cannot start an upload", err)
+		return
+	}
+	sc.AddLogField("upload_id", u.id)
+	sc.Log().Info("Started an upload of the state")
+	sc.Render(map[string]interface{}{
+		"upload": u.status(),
+	})
+}
+
+// Show returns the progress of the upload. A client resumes the upload from
+// "offset" in the response after it lost the connection.
+func (sc *stateUploads) Show(rw web.ResponseWriter, req *web.Request) {
+	u := sc.fetchUpload()
+	if u == nil {
+		return
+	}
+	sc.Render(map[string]interface{}{
+		"upload": u.status(),
+	})
+}
+
+// Write appends a chunk to the upload. The chunk is the raw request body or
+// the "chunk" part of a multipart/form-data body. "offset" query parameter
+// must be the size of the data uploaded so far, and "sha256" query parameter
+// is the optional SHA-256 digest of the chunk.
+func (sc *stateUploads) Write(rw web.ResponseWriter, req *web.Request) {
+	u := sc.fetchUpload()
+	if u == nil {
+		return
+	}
+	q := req.URL.Query()
+	offset, err := strconv.ParseInt(q.Get("offset"), 10, 64)
+	if err != nil || offset < 0 {
+		if err == nil {
+			err = errors.New("'offset' must not be negative")
+		}
+		sc.ErrLog(err).WithField("offset", q.Get("offset")).Error("Invalid query parameter")
+		e := jasco.NewError(formValidationErrorCode, "The request parameter is invalid.",
+			http.StatusBadRequest, err)
+		e.Meta["offset"] = []string{"value must be a non-negative integer"}
+		sc.RenderError(e)
+		return
+	}
+
+	var body io.Reader = req.Body
+	if mt, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type")); mt == "multipart/form-data" {
+		mr, err := req.MultipartReader()
+		if err != nil {
+			sc.ErrLog(err).Error("Cannot read the multipart body")
+			sc.RenderError(jasco.NewError(formValidationErrorCode, "The request body is invalid.",
+				http.StatusBadRequest, err))
+			return
+		}
+		for {
+			p, err := mr.NextPart()
+			if err != nil {
+				if err == io.EOF {
+					err = errors.New("the body doesn't have the 'chunk' part")
+				}
+				sc.ErrLog(err).Error("Cannot find the chunk in the multipart body")
+				e := jasco.NewError(formValidationErrorCode, "The request body is invalid.",
+					http.StatusBadRequest, err)
+				e.Meta["chunk"] = []string{"part is missing"}
+				sc.RenderError(e)
+				return
+			}
+			if p.FormName() == "chunk" {
+				body = p
+				break
+			}
+		}
+	}
+
+	if err := u.write(offset, body, q.Get("sha256")); err != nil {
+		sc.renderUploadError("Cannot write the chunk", err)
+		return
+	}
+	sc.Render(map[string]interface{}{
+		"upload": u.status(),
+	})
+}
+
+// Commit saves the uploaded data to the UDS storage. When "load" is given,
+// the state is loaded by LOAD STATE statement having the type and the
+// parameters in "load" after it's saved.
+func (sc *stateUploads) Commit(rw web.ResponseWriter, req *web.Request) {
+	u := sc.fetchUpload()
+	if u == nil {
+		return
+	}
+	var form struct {
+		SHA256 string `bql:"sha256"`
+		Load   *struct {
+			Type   string `bql:",required"`
+			Params data.Map
+		}
+	}
+	if !sc.parseForm(req, &form) {
+		return
+	}
+	if err := u.commit(form.SHA256); err != nil {
+		sc.renderUploadError("Cannot commit the upload", err)
+		return
+	}
+	sc.Log().Info("Saved the uploaded state")
+
+	res := map[string]interface{}{
+		"upload": u.status(),
+	}
+	if form.Load != nil {
+		tb := sc.fetchTopology()
+		if tb == nil {
+			return
+		}
+		stmt := parser.LoadStateStmt{
+			Name: parser.StreamIdentifier(u.state),
+			Type: parser.SourceSinkType(form.Load.Type),
+			Tag:  u.tag,
+		}
+		keys := make([]string, 0, len(form.Load.Params))
+		for k := range form.Load.Params {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			stmt.Params = append(stmt.Params, parser.SourceSinkParamAST{
+				Key:   parser.SourceSinkParamKey(k),
+				Value: form.Load.Params[k],
+			})
+		}
+		stmtStr := stmt.String()
+		if _, err := tb.AddStmt(stmt); err != nil {
+			sc.ErrLog(err).WithField("statement", stmtStr).Error("Cannot load the uploaded state")
+			e := newBQLStmtError("Cannot load the uploaded state", err)
+			e.Meta["statement"] = stmtStr
+			sc.RenderError(e)
+			return
+		}
+		sc.recordQueries(newAuditActor(req), []string{stmtStr}, nil)
+		res["statement"] = stmtStr
+	}
+	sc.Render(res)
+}
+
+// Abort discards the uploaded data.
+func (sc *stateUploads) Abort(rw web.ResponseWriter, req *web.Request) {
+	u := sc.fetchUpload()
+	if u == nil {
+		return
+	}
+	if err := u.abort(); err != nil {
+		sc.renderUploadError("Cannot abort the upload", err)
+		return
+	}
+	sc.Render(map[string]interface{}{})
+}
@@ -0,0 +1,168 @@
+package server
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/udf"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+)
+
+// brokenReader returns the data and then fails as if the client
+// disconnected.
+type brokenReader struct {
+	r io.Reader
+}
+
+func (b *brokenReader) Read(p []byte) (int, error) {
+	n, err := b.r.Read(p)
+	if err == io.EOF {
+		return n, errors.New("connection reset")
+	}
+	return n, err
+}
+
+func sha256Hex(s string) string {
+	h := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(h[:])
+}
+
+func TestStateUploads(t *testing.T) {
+	Convey("Given a registry of uploads of states", t, func() {
+		reg := newStateUploadRegistry(time.Hour)
+		storage := udf.NewInMemoryUDSStorage()
+		load := func() string {
+			r, err := storage.Load("test", "s", "v1")
+			So(err, ShouldBeNil)
+			defer r.Close()
+			b, err := ioutil.ReadAll(r)
+			So(err, ShouldBeNil)
+			return string(b)
+		}
+
+		Convey("When uploading a state having the size and the checksum", func() {
+			u, err := reg.create("ns/test", storage, "test", "s", "v1", 10, sha256Hex("0123456789"))
+			So(err, ShouldBeNil)
+
+			Convey("Then it should be looked up by its owner and state", func() {
+				u2, err := reg.lookup("ns/test", "S", u.id)
+				So(err, ShouldBeNil)
+				So(u2, ShouldPointTo, u)
+
+				_, err = reg.lookup("test", "s", u.id)
+				So(core.IsNotExist(err), ShouldBeTrue)
+				_, err = reg.lookup("ns/test", "t", u.id)
+				So(core.IsNotExist(err), ShouldBeTrue)
+			})
+
+			Convey("Then chunks should be saved in order when committed", func() {
+				So(u.write(0, strings.NewReader("0123"), sha256Hex("0123")), ShouldBeNil)
+				So(u.status().Offset, ShouldEqual, 4)
+				So(u.write(4, strings.NewReader("456789"), ""), ShouldBeNil)
+				So(*u.status().Size, ShouldEqual, 10)
+				So(u.commit(""), ShouldBeNil)
+				So(load(), ShouldEqual, "0123456789")
+
+				_, err := reg.lookup("ns/test", "s", u.id)
+				So(core.IsNotExist(err), ShouldBeTrue)
+			})
+
+			Convey("Then a chunk at a wrong offset should be rejected", func() {
+				So(u.write(0, strings.NewReader("0123"), ""), ShouldBeNil)
+				err := u.write(0, strings.NewReader("0123"), "")
+				So(core.ErrorCode(err), ShouldEqual, core.ErrCodeInvalidState)
+				So(u.status().Offset, ShouldEqual, 4)
+			})
+
+			Convey("Then the upload should be resumed after a chunk is interrupted", func() {
+				So(u.write(0, &brokenReader{strings.NewReader("0123")}, ""), ShouldNotBeNil)
+				offset := u.status().Offset
+				So(offset, ShouldEqual, 4)
+				So(u.write(offset, strings.NewReader("456789"), ""), ShouldBeNil)
+				So(u.commit(""), ShouldBeNil)
+				So(load(), ShouldEqual, "0123456789")
+			})
+
+			Convey("Then it cannot be committed before all data is uploaded", func() {
+				So(u.write(0, strings.NewReader("0123"), ""), ShouldBeNil)
+				err := u.commit("")
+				So(core.ErrorCode(err), ShouldEqual, core.ErrCodeInvalidState)
+			})
+
+			Convey("Then data exceeding the size should abort it", func() {
+				err := u.write(0, strings.NewReader("0123456789a"), "")
+				So(core.ErrorCode(err), ShouldEqual, core.ErrCodeInvalidArgument)
+				_, err = reg.lookup("ns/test", "s", u.id)
+				So(core.IsNotExist(err), ShouldBeTrue)
+			})
+
+			Convey("Then a chunk not matching its checksum should abort it", func() {
+				err := u.write(0, strings.NewReader("0123"), sha256Hex("0124"))
+				So(core.ErrorCode(err), ShouldEqual, core.ErrCodeInvalidArgument)
+				So(core.IsNotExist(u.write(4, strings.NewReader("4"), "")), ShouldBeTrue)
+			})
+
+			Convey("Then data not matching the checksum should not be saved", func() {
+				So(u.write(0, strings.NewReader("0123456780"), ""), ShouldBeNil)
+				err := u.commit("")
+				So(core.ErrorCode(err), ShouldEqual, core.ErrCodeInvalidArgument)
+				_, err = storage.Load("test", "s", "v1")
+				So(core.IsNotExist(err), ShouldBeTrue)
+			})
+
+			Convey("Then aborting it should discard the data", func() {
+				So(u.write(0, strings.NewReader("0123"), ""), ShouldBeNil)
+				So(u.abort(), ShouldBeNil)
+				_, err := storage.Load("test", "s", "v1")
+				So(core.IsNotExist(err), ShouldBeTrue)
+				So(core.IsNotExist(u.abort()), ShouldBeTrue)
+			})
+		})
+
+		Convey("When uploading a state without its size", func() {
+			u, err := reg.create("test", storage, "test", "s", "v1", -1, "")
+			So(err, ShouldBeNil)
+			So(u.status().Size, ShouldBeNil)
+
+			Convey("Then it should be committed with the checksum given at last", func() {
+				data := bytes.Repeat([]byte("abc"), stateUploadChunkBufferSize)
+				So(u.write(0, bytes.NewReader(data), ""), ShouldBeNil)
+				So(u.commit(strings.Repeat("0", 64)), ShouldNotBeNil)
+
+				u, err := reg.create("test", storage, "test", "s", "v1", -1, "")
+				So(err, ShouldBeNil)
+				So(u.write(0, bytes.NewReader(data), ""), ShouldBeNil)
+				So(u.commit(sha256Hex(string(data))), ShouldBeNil)
+				So(load(), ShouldEqual, string(data))
+			})
+		})
+
+		Convey("When an upload isn't updated within the timeout", func() {
+			reg.timeout = time.Millisecond
+			u, err := reg.create("test", storage, "test", "s", "v1", -1, "")
+			So(err, ShouldBeNil)
+			time.Sleep(10 * time.Millisecond)
+
+			Convey("Then it should be aborted", func() {
+				_, err := reg.lookup("test", "s", u.id)
+				So(core.IsNotExist(err), ShouldBeTrue)
+			})
+		})
+
+		Convey("When creating an upload with an invalid checksum", func() {
+			_, err := reg.create("test", storage, "test", "s", "v1", -1, "ABC")
+
+			Convey("Then it should fail", func() {
+				So(core.ErrorCode(err), ShouldEqual, core.ErrCodeInvalidArgument)
+			})
+		})
+	})
+}
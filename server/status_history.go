@@ -0,0 +1,287 @@
+package server
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+const (
+	// statusHistoryInterval is the interval between samples of statuses of
+	// nodes.
+	statusHistoryInterval = 10 * time.Second
+
+	// statusHistoryRetention is how long samples are kept in memory.
+	statusHistoryRetention = time.Hour
+)
+
+// nodeStatusSample is a sample of the status of a node.
+type nodeStatusSample struct {
+	Time  time.Time `json:"time"`
+	State string    `json:"state"`
+
+	// NumReceived and NumErrors are the total numbers of tuples received
+	// and tuples which caused errors. They're always 0 for sources.
+	NumReceived int64 `json:"num_received_total"`
+	NumErrors   int64 `json:"num_errors"`
+
+	// NumSent and NumDropped are the total numbers of tuples sent and
+	// dropped. They're always 0 for sinks.
+	NumSent    int64 `json:"num_sent_total"`
+	NumDropped int64 `json:"num_dropped"`
+
+	// NumQueued is the number of tuples queued in the input pipes and
+	// QueueSize is the total capacity of them.
+	NumQueued int64 `json:"num_queued"`
+	QueueSize int64 `json:"queue_size"`
+
+	// InputRate and OutputRate are the numbers of tuples received and sent
+	// per second since the previous sample.
+	InputRate  float64 `json:"input_rate"`
+	OutputRate float64 `json:"output_rate"`
+}
+
+// newNodeStatusSample creates a sample from the status of a node. prev is
+// the previous sample of the node and can be nil.
+func newNodeStatusSample(now time.Time, st data.Map, prev *nodeStatusSample) *nodeStatusSample {
+	s := &nodeStatusSample{
+		Time: now,
+	}
+	s.State, _ = data.AsString(st["state"])
+	if in, err := data.AsMap(st["input_stats"]); err == nil {
+		s.NumReceived, _ = data.AsInt(in["num_received_total"])
+		s.NumErrors, _ = data.AsInt(in["num_errors"])
+		inputs, _ := data.AsMap(in["inputs"])
+		for _, v := range inputs {
+			i, err := data.AsMap(v)
+			if err != nil {
+				continue
+			}
+			q, _ := data.AsInt(i["num_queued"])
+			c, _ := data.AsInt(i["queue_size"])
+			s.NumQueued += q
+			s.QueueSize += c
+		}
+	}
+	if out, err := data.AsMap(st["output_stats"]); err == nil {
+		s.NumSent, _ = data.AsInt(out["num_sent_total"])
+		s.NumDropped, _ = data.AsInt(out["num_dropped"])
+	}
+
+	if prev != nil {
+		if d := now.Sub(prev.Time).Seconds(); d > 0 {
+			s.InputRate = rate(prev.NumReceived, s.NumReceived, d)
+			s.OutputRate = rate(prev.NumSent, s.NumSent, d)
+		}
+	}
+	return s
+}
+
+// rate returns the rate of the counter. It returns 0 when the counter has
+// been reset.
+func rate(prev, cur int64, seconds float64) float64 {
+	if cur < prev {
+		return 0
+	}
+	return float64(cur-prev) / seconds
+}
+
+// nodeHistory keeps samples of a node in a ring buffer.
+type nodeHistory struct {
+	nodeType core.NodeType
+	name     string
+
+	samples []*nodeStatusSample
+	start   int
+	n       int
+}
+
+func newNodeHistory(nodeType core.NodeType, name string, capacity int) *nodeHistory {
+	return &nodeHistory{
+		nodeType: nodeType,
+		name:     name,
+		samples:  make([]*nodeStatusSample, capacity),
+	}
+}
+
+// add adds a sample. The oldest sample is discarded when the buffer is full.
+func (h *nodeHistory) add(s *nodeStatusSample) {
+	if h.n < len(h.samples) {
+		h.samples[(h.start+h.n)%len(h.samples)] = s
+		h.n++
+		return
+	}
+	h.samples[h.start] = s
+	h.start = (h.start + 1) % len(h.samples)
+}
+
+// last returns the latest sample. It returns nil when there's no sample.
+func (h *nodeHistory) last() *nodeStatusSample {
+	if h.n == 0 {
+		return nil
+	}
+	return h.samples[(h.start+h.n-1)%len(h.samples)]
+}
+
+// since returns samples taken at or after the time in chronological order.
+func (h *nodeHistory) since(t time.Time) []*nodeStatusSample {
+	res := []*nodeStatusSample{}
+	for i := 0; i < h.n; i++ {
+		s := h.samples[(h.start+i)%len(h.samples)]
+		if !s.Time.Before(t) {
+			res = append(res, s)
+		}
+	}
+	return res
+}
+
+// nodeHistoryResponse is samples of a node returned by the API.
+type nodeHistoryResponse struct {
+	NodeType string              `json:"node_type"`
+	NodeName string              `json:"node_name"`
+	Samples  []*nodeStatusSample `json:"samples"`
+}
+
+// statusHistory periodically samples statuses of nodes in all topologies of
+// the server and keeps them for statusHistoryRetention. Histories of
+// topologies and nodes are removed when they don't exist at the time of
+// sampling. All methods can be called on nil, which doesn't keep anything.
+type statusHistory struct {
+	interval time.Duration
+	capacity int
+
+	m sync.RWMutex
+	// topologies has histories of nodes of each topology. Keys are lower
+	// case qualified names of topologies and names of nodes.
+	topologies map[string]map[string]*nodeHistory
+
+	stopOnce sync.Once
+	stopCh   chan struct{}
+	done     chan struct{}
+}
+
+func newStatusHistory(interval, retention time.Duration) *statusHistory {
+	capacity := int(retention / interval)
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &statusHistory{
+		interval:   interval,
+		capacity:   capacity,
+		topologies: map[string]map[string]*nodeHistory{},
+		stopCh:     make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+}
+
+// start starts sampling statuses of nodes of topologies in the namespaces.
+func (h *statusHistory) start(namespaces *NamespaceRegistry) {
+	if h == nil {
+		return
+	}
+	go func() {
+		defer close(h.done)
+		t := time.NewTicker(h.interval)
+		defer t.Stop()
+		for {
+			select {
+			case <-h.stopCh:
+				return
+			case now := <-t.C:
+				h.sample(namespaces, now)
+			}
+		}
+	}()
+}
+
+// stop stops sampling. It must not be called when start hasn't been called.
+func (h *statusHistory) stop() {
+	if h == nil {
+		return
+	}
+	h.stopOnce.Do(func() {
+		close(h.stopCh)
+	})
+	<-h.done
+}
+
+// sample takes samples of statuses of all nodes.
+func (h *statusHistory) sample(namespaces *NamespaceRegistry, now time.Time) {
+	type nodeStatus struct {
+		nodeType core.NodeType
+		name     string
+		status   data.Map
+	}
+
+	// Statuses are obtained without the lock because it can take time.
+	statuses := map[string][]*nodeStatus{}
+	for _, ns := range namespaces.List() {
+		ts, err := ns.Topologies.List()
+		if err != nil {
+			continue
+		}
+		for tn, tb := range ts {
+			var sts []*nodeStatus
+			for name, n := range tb.Topology().Nodes() {
+				sts = append(sts, &nodeStatus{
+					nodeType: n.Type(),
+					name:     name,
+					status:   n.Status(),
+				})
+			}
+			statuses[strings.ToLower(ns.qualifiedName(tn))] = sts
+		}
+	}
+
+	h.m.Lock()
+	defer h.m.Unlock()
+	for tn := range h.topologies {
+		if _, ok := statuses[tn]; !ok {
+			delete(h.topologies, tn)
+		}
+	}
+	for tn, sts := range statuses {
+		old := h.topologies[tn]
+		nodes := make(map[string]*nodeHistory, len(sts))
+		for _, st := range sts {
+			key := strings.ToLower(st.name)
+			nh, ok := old[key]
+			if !ok || nh.nodeType != st.nodeType {
+				nh = newNodeHistory(st.nodeType, st.name, h.capacity)
+			}
+			nh.add(newNodeStatusSample(now, st.status, nh.last()))
+			nodes[key] = nh
+		}
+		h.topologies[tn] = nodes
+	}
+}
+
+// read returns samples of nodes of the topology taken at or after since. When
+// node isn't empty, only samples of the node are returned. Nodes are sorted
+// by their names.
+func (h *statusHistory) read(topology, node string, since time.Time) []*nodeHistoryResponse {
+	res := []*nodeHistoryResponse{}
+	if h == nil {
+		return res
+	}
+	h.m.RLock()
+	defer h.m.RUnlock()
+	for key, nh := range h.topologies[strings.ToLower(topology)] {
+		if node != "" && key != strings.ToLower(node) {
+			continue
+		}
+		res = append(res, &nodeHistoryResponse{
+			NodeType: nh.nodeType.String(),
+			NodeName: nh.name,
+			Samples:  nh.since(since),
+		})
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].NodeName < res[j].NodeName
+	})
+	return res
+}
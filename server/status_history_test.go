@@ -0,0 +1,177 @@
+package server
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/bql"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// releasedSource emits n tuples after start is closed and waits until it's
+// stopped.
+type releasedSource struct {
+	n     int
+	start chan struct{}
+	stop  chan struct{}
+	once  sync.Once
+}
+
+func (s *releasedSource) GenerateStream(ctx *core.Context, w core.Writer) error {
+	select {
+	case <-s.start:
+	case <-s.stop:
+		return nil
+	}
+	for i := 0; i < s.n; i++ {
+		if err := w.Write(ctx, core.NewTuple(data.Map{"n": data.Int(i)})); err != nil {
+			return err
+		}
+	}
+	<-s.stop
+	return nil
+}
+
+func (s *releasedSource) Stop(ctx *core.Context) error {
+	s.once.Do(func() {
+		close(s.stop)
+	})
+	return nil
+}
+
+type countingSink struct {
+	m sync.Mutex
+	n int
+}
+
+func (s *countingSink) Write(ctx *core.Context, t *core.Tuple) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.n++
+	return nil
+}
+
+func (s *countingSink) Close(ctx *core.Context) error {
+	return nil
+}
+
+func (s *countingSink) count() int {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.n
+}
+
+func TestStatusHistory(t *testing.T) {
+	Convey("Given a topology having a source and a sink", t, func() {
+		reg := NewDefaultTopologyRegistry()
+		namespaces := NewNamespaceRegistry(reg)
+		tp, err := core.NewDefaultTopology(core.NewContext(nil), "test")
+		So(err, ShouldBeNil)
+		Reset(func() {
+			tp.Stop()
+		})
+		tb, err := bql.NewTopologyBuilder(tp)
+		So(err, ShouldBeNil)
+		So(reg.Register("test", tb), ShouldBeNil)
+
+		so := &releasedSource{
+			n:     5,
+			start: make(chan struct{}),
+			stop:  make(chan struct{}),
+		}
+		_, err = tp.AddSource("source", so, nil)
+		So(err, ShouldBeNil)
+		si := &countingSink{}
+		sn, err := tp.AddSink("sink", si, nil)
+		So(err, ShouldBeNil)
+		So(sn.Input("source", nil), ShouldBeNil)
+
+		h := newStatusHistory(time.Second, 3*time.Second)
+		t0 := time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		Convey("When sampling statuses before and after tuples flow", func() {
+			h.sample(namespaces, t0)
+			close(so.start)
+			for si.count() < 5 {
+				time.Sleep(time.Millisecond)
+			}
+			h.sample(namespaces, t0.Add(2*time.Second))
+			res := h.read("TEST", "", time.Time{})
+
+			Convey("Then samples of all nodes should be returned", func() {
+				So(res, ShouldHaveLength, 2)
+				So(res[0].NodeName, ShouldEqual, "sink")
+				So(res[0].NodeType, ShouldEqual, "sink")
+				So(res[1].NodeName, ShouldEqual, "source")
+				So(res[1].NodeType, ShouldEqual, "source")
+				So(res[0].Samples, ShouldHaveLength, 2)
+				So(res[1].Samples, ShouldHaveLength, 2)
+			})
+
+			Convey("Then samples should have counters and rates", func() {
+				sink, source := res[0].Samples, res[1].Samples
+				So(sink[0].NumReceived, ShouldEqual, 0)
+				So(sink[1].NumReceived, ShouldEqual, 5)
+				So(sink[1].InputRate, ShouldEqual, 2.5)
+				So(sink[1].QueueSize, ShouldBeGreaterThan, 0)
+				So(source[1].NumSent, ShouldEqual, 5)
+				So(source[1].OutputRate, ShouldEqual, 2.5)
+				So(source[1].State, ShouldEqual, "running")
+			})
+
+			Convey("Then samples can be filtered by the node and the time", func() {
+				res := h.read("test", "SOURCE", t0.Add(time.Second))
+				So(res, ShouldHaveLength, 1)
+				So(res[0].NodeName, ShouldEqual, "source")
+				So(res[0].Samples, ShouldHaveLength, 1)
+				So(res[0].Samples[0].Time, ShouldResemble, t0.Add(2*time.Second))
+			})
+
+			Convey("And sampling more than the capacity", func() {
+				h.sample(namespaces, t0.Add(3*time.Second))
+				h.sample(namespaces, t0.Add(4*time.Second))
+
+				Convey("Then old samples should be discarded", func() {
+					res := h.read("test", "source", time.Time{})
+					So(res, ShouldHaveLength, 1)
+					ss := res[0].Samples
+					So(ss, ShouldHaveLength, 3)
+					So(ss[0].Time, ShouldResemble, t0.Add(2*time.Second))
+					So(ss[2].Time, ShouldResemble, t0.Add(4*time.Second))
+				})
+			})
+
+			Convey("And removing the node", func() {
+				So(tp.Remove("sink"), ShouldBeNil)
+				h.sample(namespaces, t0.Add(3*time.Second))
+
+				Convey("Then its samples should be removed", func() {
+					res := h.read("test", "", time.Time{})
+					So(res, ShouldHaveLength, 1)
+					So(res[0].NodeName, ShouldEqual, "source")
+				})
+			})
+
+			Convey("And unregistering the topology", func() {
+				_, err := reg.Unregister("test")
+				So(err, ShouldBeNil)
+				h.sample(namespaces, t0.Add(3*time.Second))
+
+				Convey("Then its samples should be removed", func() {
+					So(h.read("test", "", time.Time{}), ShouldBeEmpty)
+				})
+			})
+		})
+	})
+
+	Convey("Given a nil status history", t, func() {
+		var h *statusHistory
+
+		Convey("Then it should return nothing", func() {
+			So(h.read("test", "", time.Time{}), ShouldBeEmpty)
+		})
+	})
+}
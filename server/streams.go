@@ -13,11 +13,29 @@ type streams struct {
 	stream core.BoxNode
 }
 
-func setUpStreamsRouter(prefix string, router *web.Router) {
+func setUpStreamsRouter(prefix string, router *apiRouter) {
 	root := router.Subrouter(streams{}, "/:topologyName/streams")
 	root.Middleware((*streams).fetchStream)
-	root.Get("/", (*streams).Index)
-	root.Get("/:streamName", (*streams).Show)
+	root.Get("/", (*streams).Index, &apiOperation{
+		ID:      "listStreams",
+		Summary: "List streams of a topology",
+		Query: []*openAPIParameter{
+			apiQuery("labels", apiString, "Comma separated labels which streams must have. A label prefixed with '!' must not be attached"),
+		},
+		Response: apiObject(map[string]*apiSchema{
+			"topology": apiString,
+			"count":    apiInteger,
+			"streams":  apiArray(apiRef("Node")),
+		}, "topology", "count", "streams"),
+	})
+	root.Get("/:streamName", (*streams).Show, &apiOperation{
+		ID:      "getStream",
+		Summary: "View a stream detail",
+		Response: apiObject(map[string]*apiSchema{
+			"topology": apiString,
+			"stream":   apiRef("Node"),
+		}, "topology", "stream"),
+	})
 }
 
 func (sc *streams) fetchStream(rw web.ResponseWriter, req *web.Request, next web.NextMiddlewareFunc) {
@@ -44,9 +62,17 @@ func (sc *streams) fetchStream(rw web.ResponseWriter, req *web.Request, next web
 func (sc *streams) Index(rw web.ResponseWriter, req *web.Request) {
 	// TODO: support pagination
 
+	sel := sc.labelSelector(req)
+	if sel == nil {
+		return
+	}
+
 	strms := sc.topology.Topology().Boxes()
 	res := make([]*response.Stream, 0, len(strms))
 	for _, s := range strms {
+		if !sel.Matches(s.Labels()) {
+			continue
+		}
 		res = append(res, response.NewStream(s, false))
 	}
 	sc.Render(map[string]interface{}{
@@ -0,0 +1,85 @@
+package server
+
+import (
+	"github.com/gocraft/web"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/pfnet/jasco.v1"
+	"gopkg.in/sensorbee/sensorbee.v0/bql"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"gopkg.in/sensorbee/sensorbee.v0/server/config"
+	"net/http"
+)
+
+type taps struct {
+	*topologies
+}
+
+func setUpTapsRouter(prefix string, router *apiRouter) {
+	root := router.Subrouter(taps{}, "/:topologyName/nodes")
+	root.Post("/:nodeName/tap", (*taps).Create, &apiOperation{
+		ID:      "tapNode",
+		Summary: "Tap a node",
+		Request: apiObject(map[string]*apiSchema{
+			"max_rate":      apiNumber,
+			"sampling_rate": apiNumber,
+		}),
+		Stream: "multipart/mixed",
+	})
+}
+
+// Create attaches a tap to the node and streams tuples sampled from the
+// node's output as a multipart response. The tap is detached when the client
+// disconnects or the node is dropped.
+func (tc *taps) Create(rw web.ResponseWriter, req *web.Request) {
+	tb := tc.fetchTopology()
+	if tb == nil {
+		return
+	}
+	nodeName := tc.PathParams().String("nodeName", "")
+	tc.AddLogField("node_name", nodeName)
+
+	tapConf := &bql.TapConfig{}
+	if req.ContentLength != 0 {
+		var js map[string]interface{}
+		if apiErr := tc.ParseBody(&js); apiErr != nil {
+			tc.ErrLog(apiErr.Err).Error("Cannot parse the request json")
+			tc.RenderError(apiErr)
+			return
+		}
+		form, err := data.NewMap(js)
+		if err != nil {
+			tc.ErrLog(err).WithField("body", js).
+				Error("The request json may contain invalid value")
+			tc.RenderError(jasco.NewError(formValidationErrorCode, "The request json may contain invalid values.",
+				http.StatusBadRequest, err))
+			return
+		}
+		if err := data.NewDecoder(nil).Decode(form, tapConf); err != nil {
+			tc.ErrLog(err).Error("Invalid tap parameters")
+			tc.RenderError(jasco.NewError(formValidationErrorCode, "The request json has invalid parameters.",
+				http.StatusBadRequest, err))
+			return
+		}
+	}
+
+	if _, err := tb.Topology().Node(nodeName); err != nil {
+		tc.ErrLog(err).Error("Cannot find the node")
+		tc.RenderError(jasco.NewError(requestResourceNotFoundErrorCode,
+			"The node was not found", http.StatusNotFound, err))
+		return
+	}
+
+	sn, ch, err := tb.AddTap(nodeName, tapConf)
+	if err != nil {
+		tc.ErrLog(err).Error("Cannot attach a tap")
+		e := jasco.NewError(formValidationErrorCode, "Cannot attach a tap to the node", http.StatusBadRequest, err)
+		e.Meta["error"] = err.Error()
+		tc.RenderError(e)
+		return
+	}
+	// Tuples are skipped rather than disconnecting the client because a tap
+	// is a best-effort observer.
+	netConf := *tc.config.Network
+	netConf.SlowClientPolicy = config.SlowClientDrop
+	tc.streamTuples(rw, sn, ch, &netConf, nil, logrus.Fields{"tap": sn.Name()}, "tapped tuples")
+}
@@ -3,10 +3,7 @@ package testutil
 import (
 	"bytes"
 	"github.com/mattn/go-scan"
-	"gopkg.in/pfnet/jasco.v1"
-	"gopkg.in/sensorbee/sensorbee.v0/data"
 	"gopkg.in/sensorbee/sensorbee.v0/server"
-	"gopkg.in/sensorbee/sensorbee.v0/server/config"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
@@ -28,6 +25,7 @@ type Server struct {
 		router     http.Handler
 		url        string
 	}
+	srv *server.Server
 }
 
 // Close closes the server.
@@ -35,6 +33,7 @@ func (s *Server) Close() {
 	if s.server.realServer != nil {
 		s.server.realServer.Close()
 	}
+	s.srv.Stop()
 }
 
 // URL returns the URL of the server.
@@ -75,30 +74,22 @@ func (t *testTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	}, nil
 }
 
-// NewServer returns a temporary running server.
-func NewServer() *Server {
+// NewServer returns a temporary running server. Options are passed to
+// server.New.
+func NewServer(opts ...server.Option) *Server {
 	s := &Server{}
 
-	c, err := config.New(data.Map{})
+	srv, err := server.New(opts...)
 	if err != nil {
 		panic(err)
 	}
-	gvars, err := server.SetUpContextGlobalVariables(c)
-	if err != nil {
-		panic(err)
-	}
-	jascoRoot := jasco.New("/", nil)
-	root, err := server.SetUpContextAndRouter("/", jascoRoot, gvars)
-	if err != nil {
-		panic(err)
-	}
-	server.SetUpAPIRouter("/", root, nil)
+	s.srv = srv
 
 	if TestAPIWithRealHTTPServer {
-		s.server.realServer = httptest.NewServer(jascoRoot)
+		s.server.realServer = httptest.NewServer(srv.Handler())
 		s.server.url = s.server.realServer.URL
 	} else {
-		s.server.router = jascoRoot
+		s.server.router = srv.Handler()
 		s.server.url = "http://172.0.0.1:0602"
 	}
 	return s
@@ -7,6 +7,8 @@ import (
 	"net/http"
 	"net/textproto"
 	"os"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -18,29 +20,199 @@ import (
 	"gopkg.in/sensorbee/sensorbee.v0/bql/parser"
 	"gopkg.in/sensorbee/sensorbee.v0/core"
 	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"gopkg.in/sensorbee/sensorbee.v0/server/config"
 	"gopkg.in/sensorbee/sensorbee.v0/server/response"
 )
 
 type topologies struct {
 	*APIContext
+	namespace    *Namespace
 	topologyName string
 	topology     *bql.TopologyBuilder
 }
 
-func setUpTopologiesRouter(prefix string, router *web.Router) {
-	root := router.Subrouter(topologies{}, "/topologies")
+func setUpTopologiesRouter(prefix string, router *apiRouter) {
+	// Topologies in the default namespace are accessed by paths without
+	// a namespace segment.
+	setUpTopologiesRoutes(prefix, router.Subrouter(topologies{}, "/topologies"))
+	ns := router.Subrouter(topologies{}, "/namespaces/:namespace/topologies")
+	ns.idSuffix = "InNamespace"
+	setUpTopologiesRoutes(prefix, ns)
+}
+
+func setUpTopologiesRoutes(prefix string, root *apiRouter) {
+	root.Middleware((*topologies).extractNamespace)
 	root.Middleware((*topologies).extractName)
 	// TODO validation (root can validate with regex like "\w+")
-	root.Post("/", (*topologies).Create)
-	root.Get("/", (*topologies).Index)
-	root.Get(`/:topologyName`, (*topologies).Show)
-	root.Delete(`/:topologyName`, (*topologies).Destroy)
-	root.Post(`/:topologyName/queries`, (*topologies).Queries)
-	root.Get(`/:topologyName/wsqueries`, (*topologies).WebSocketQueries)
+	root.Post("/", (*topologies).Create, &apiOperation{
+		ID:      "createTopology",
+		Summary: "Create a new topology",
+		Request: apiObject(map[string]*apiSchema{
+			"name":      apiString,
+			"resources": apiRef("Resources"),
+			"constants": apiAnyObject,
+		}, "name"),
+		Response: apiObject(map[string]*apiSchema{"topology": apiRef("Topology")}, "topology"),
+	})
+	root.Get("/", (*topologies).Index, &apiOperation{
+		ID:       "listTopologies",
+		Summary:  "List all topologies",
+		Response: apiObject(map[string]*apiSchema{"topologies": apiArray(apiRef("Topology"))}, "topologies"),
+	})
+	root.Get(`/:topologyName`, (*topologies).Show, &apiOperation{
+		ID:       "getTopology",
+		Summary:  "View a topology detail",
+		Response: apiObject(map[string]*apiSchema{"topology": apiRef("Topology")}, "topology"),
+	})
+	root.Delete(`/:topologyName`, (*topologies).Destroy, &apiOperation{
+		ID:       "destroyTopology",
+		Summary:  "Destroy a topology",
+		Response: apiAnyObject,
+	})
+	root.Post(`/:topologyName/queries`, (*topologies).Queries, &apiOperation{
+		ID:       "sendQueries",
+		Summary:  "Send BQL queries",
+		Request:  apiRef("QueriesRequest"),
+		Response: apiRef("QueriesResponse"),
+		Stream:   "multipart/mixed",
+	})
+	root.Post(`/:topologyName/apply`, (*topologies).Apply, &apiOperation{
+		ID:      "applyQueries",
+		Summary: "Apply BQL queries defining the topology",
+		Request: apiObject(map[string]*apiSchema{
+			"queries":    apiString,
+			"parameters": apiArray(apiAny),
+			"prune":      apiBoolean,
+			"dry_run":    apiBoolean,
+		}, "queries"),
+		Response: apiObject(map[string]*apiSchema{
+			"topology_name": apiString,
+			"operations":    apiArray(apiRef("ApplyOperation")),
+			"applied":       apiBoolean,
+		}, "topology_name", "operations", "applied"),
+	})
+	root.Get(`/:topologyName/wsqueries`, (*topologies).WebSocketQueries, &apiOperation{
+		ID:      "webSocketQueries",
+		Summary: "Send BQL queries over WebSocket",
+	})
+	root.Get(`/:topologyName/audit`, (*topologies).Audit, &apiOperation{
+		ID:      "getAuditLog",
+		Summary: "Get the audit log",
+		Query: []*openAPIParameter{
+			apiQuery("since", apiInteger, "Only return entries whose versions are greater than this value"),
+			apiQuery("limit", apiInteger, "The maximum number of entries to be returned"),
+		},
+		Response: apiObject(map[string]*apiSchema{
+			"topology_name": apiString,
+			"version":       apiInteger,
+			"entries":       apiArray(apiRef("AuditEntry")),
+		}, "topology_name", "version", "entries"),
+	})
+	root.Get(`/:topologyName/logs`, (*topologies).Logs, &apiOperation{
+		ID:      "getLogs",
+		Summary: "Get logs",
+		Query: []*openAPIParameter{
+			apiQuery("node", apiString, "Only return entries written by the node"),
+			apiQuery("since", apiInteger, "Only return entries whose sequence numbers are greater than this value"),
+			apiQuery("limit", apiInteger, "The maximum number of entries to be returned"),
+			apiQuery("wait", apiString, "How long to wait for new entries"),
+		},
+		Response: apiObject(map[string]*apiSchema{
+			"topology_name": apiString,
+			"seq":           apiInteger,
+			"entries":       apiArray(apiRef("LogEntry")),
+		}, "topology_name", "seq", "entries"),
+	})
+	root.Get(`/:topologyName/status_history`, (*topologies).StatusHistory, &apiOperation{
+		ID:      "getStatusHistory",
+		Summary: "Get the status history",
+		Query: []*openAPIParameter{
+			apiQuery("minutes", apiInteger, "Only return samples taken in the last N minutes"),
+			apiQuery("node", apiString, "Only return samples of the node"),
+		},
+		Response: apiObject(map[string]*apiSchema{
+			"topology_name": apiString,
+			"interval":      apiNumber,
+			"nodes":         apiArray(apiRef("NodeStatusHistory")),
+		}, "topology_name", "interval", "nodes"),
+	})
+	root.Get(`/:topologyName/lineage/:tupleID`, (*topologies).Lineage, &apiOperation{
+		ID:      "getLineage",
+		Summary: "Get the lineage of a tuple",
+		Query: []*openAPIParameter{
+			apiQuery("depth", apiInteger, "How many steps to trace the lineage back"),
+		},
+		Response: apiObject(map[string]*apiSchema{
+			"topology_name": apiString,
+			"tuple_id":      apiString,
+			"enabled":       apiBoolean,
+			"records":       apiArray(apiRef("LineageRecord")),
+		}, "topology_name", "tuple_id", "enabled", "records"),
+	})
+	root.Post(`/:topologyName/functions/:functionName/reload`, (*topologies).ReloadFunction, &apiOperation{
+		ID:      "reloadFunction",
+		Summary: "Reload a function",
+		Response: apiObject(map[string]*apiSchema{
+			"topology_name": apiString,
+			"function":      apiString,
+			"version":       apiInteger,
+		}, "topology_name", "function", "version"),
+	})
 
 	setUpSourcesRouter(prefix, root)
 	setUpStreamsRouter(prefix, root)
 	setUpSinksRouter(prefix, root)
+	setUpTapsRouter(prefix, root)
+	setUpStateUploadsRouter(prefix, root)
+}
+
+// extractNamespace looks up the namespace given in the path and checks the
+// token of the request. Following actions access topologies in the
+// namespace through tc.topologies.
+func (tc *topologies) extractNamespace(rw web.ResponseWriter, req *web.Request, next web.NextMiddlewareFunc) {
+	name := tc.PathParams().String("namespace", DefaultNamespace)
+	ns, err := tc.namespaces.Lookup(name)
+	if err != nil {
+		if core.IsNotExist(err) {
+			tc.Log().WithField("namespace", name).Error("The namespace doesn't exist")
+			tc.RenderError(jasco.NewError(requestResourceNotFoundErrorCode, "The namespace doesn't exist",
+				http.StatusNotFound, err))
+			return
+		}
+		tc.ErrLog(err).Error("Cannot lookup the namespace")
+		tc.RenderError(jasco.NewInternalServerError(err))
+		return
+	}
+	if !ns.isDefault() {
+		tc.AddLogField("namespace", ns.Name)
+	}
+
+	token := ""
+	if a := req.Header.Get("Authorization"); len(a) > 7 && strings.EqualFold(a[:7], "Bearer ") {
+		token = a[7:]
+	}
+	if !ns.authorize(token) {
+		err := fmt.Errorf("the request doesn't have a valid token for the namespace '%v'", ns.Name)
+		tc.ErrLog(err).Error("Unauthorized access to the namespace")
+		rw.Header().Set("WWW-Authenticate", `Bearer realm="sensorbee"`)
+		tc.RenderError(jasco.NewError(unauthorizedErrorCode, "The request doesn't have a valid token for the namespace.",
+			http.StatusUnauthorized, err))
+		return
+	}
+
+	tc.namespace = ns
+	if !ns.isDefault() {
+		// The registry of the default namespace is already set by the
+		// middleware of Context, which may be customized by the application.
+		tc.topologies = ns.Topologies
+	}
+	next(rw, req)
+}
+
+// qualifiedName returns the name of the topology unique in the server. It's
+// used by the admission controller and the audit log.
+func (tc *topologies) qualifiedName(name string) string {
+	return tc.namespace.qualifiedName(name)
 }
 
 func (tc *topologies) extractName(rw web.ResponseWriter, req *web.Request, next web.NextMiddlewareFunc) {
@@ -70,6 +242,22 @@ func (tc *topologies) fetchTopology() *bql.TopologyBuilder {
 	return tb
 }
 
+// labelSelector parses the label selector given as "labels" query parameter.
+// It returns nil and renders an error when the selector is invalid.
+func (tc *topologies) labelSelector(req *web.Request) *core.LabelSelector {
+	s := req.URL.Query().Get("labels")
+	sel, err := core.ParseLabelSelector(s)
+	if err != nil {
+		tc.ErrLog(err).WithField("labels", s).Error("Invalid label selector")
+		e := jasco.NewError(formValidationErrorCode, "The request parameter is invalid.",
+			http.StatusBadRequest, err)
+		e.Meta["labels"] = []string{err.Error()}
+		tc.RenderError(e)
+		return nil
+	}
+	return sel
+}
+
 // Create creates a new topology.
 func (tc *topologies) Create(rw web.ResponseWriter, req *web.Request) {
 	var js map[string]interface{}
@@ -117,32 +305,79 @@ func (tc *topologies) Create(rw web.ResponseWriter, req *web.Request) {
 		return
 	}
 
-	// TODO: support other parameters
+	res := &config.Resources{}
+	if r, ok := form["resources"]; ok {
+		m, err := data.AsMap(r)
+		if err != nil {
+			tc.ErrLog(err).Error("'resources' field isn't an object")
+			e := jasco.NewError(formValidationErrorCode, "The request body is invalid.",
+				http.StatusBadRequest, nil)
+			e.Meta["resources"] = []string{"value must be an object"}
+			tc.RenderError(e)
+			return
+		}
+		res, err = config.NewResources(m)
+		if err != nil {
+			tc.ErrLog(err).Error("'resources' field has invalid values")
+			e := jasco.NewError(formValidationErrorCode, "The request body is invalid.",
+				http.StatusBadRequest, nil)
+			e.Meta["resources"] = []string{err.Error()}
+			tc.RenderError(e)
+			return
+		}
+	}
 
-	cc := &core.ContextConfig{
-		Logger: tc.logger,
+	var constants data.Map
+	if c, ok := form["constants"]; ok {
+		m, err := data.AsMap(c)
+		if err != nil {
+			tc.ErrLog(err).Error("'constants' field isn't an object")
+			e := jasco.NewError(formValidationErrorCode, "The request body is invalid.",
+				http.StatusBadRequest, nil)
+			e.Meta["constants"] = []string{"value must be an object"}
+			tc.RenderError(e)
+			return
+		}
+		var msgs []string
+		for n := range m {
+			if err := core.ValidateConstantName(n); err != nil {
+				msgs = append(msgs, err.Error())
+			}
+		}
+		if len(msgs) > 0 {
+			tc.Log().WithField("constants", msgs).Error("'constants' field has invalid names")
+			e := jasco.NewError(formValidationErrorCode, "The request body is invalid.",
+				http.StatusBadRequest, nil)
+			sort.Strings(msgs)
+			e.Meta["constants"] = msgs
+			tc.RenderError(e)
+			return
+		}
+		constants = m
 	}
-	// TODO: Be careful of race conditions on these fields.
-	cc.Flags.DroppedTupleLog.Set(tc.config.Logging.LogDroppedTuples)
-	cc.Flags.DestinationlessTupleLog.Set(tc.config.Logging.LogDestinationlessTuples)
-	cc.Flags.DroppedTupleSummarization.Set(tc.config.Logging.SummarizeDroppedTuples)
 
-	tp, err := core.NewDefaultTopology(core.NewContext(cc), name)
-	if err != nil {
-		tc.ErrLog(err).Error("Cannot create a new topology")
-		tc.RenderError(jasco.NewInternalServerError(err))
+	// TODO: support other parameters
+
+	if err := tc.admission.admit(tc.qualifiedName(name), *res); err != nil {
+		tc.renderAdmissionError(err)
 		return
 	}
-	tb, err := bql.NewTopologyBuilder(tp)
+	admitted := false
+	defer func() {
+		if !admitted {
+			tc.admission.release(tc.qualifiedName(name))
+		}
+	}()
+
+	tb, logs, err := newTopology(tc.logger, tc.namespace, name, tc.config, tc.scheduler, tc.logs, tc.udsStorage, res, constants)
 	if err != nil {
-		tc.ErrLog(err).Error("Cannot create a new topology builder")
+		tc.ErrLog(err).Error("Cannot create a new topology")
 		tc.RenderError(jasco.NewInternalServerError(err))
 		return
 	}
-	tb.UDSStorage = tc.udsStorage
 
 	if err := tc.topologies.Register(name, tb); err != nil {
-		if err := tp.Stop(); err != nil {
+		if err := tb.Topology().Stop(); err != nil {
 			tc.ErrLog(err).Error("Cannot stop the created topology")
 		}
 
@@ -159,12 +394,192 @@ func (tc *topologies) Create(rw web.ResponseWriter, req *web.Request) {
 		return
 	}
 
+	admitted = true
+	tc.logs.add(tc.qualifiedName(name), logs)
+	tc.audit.record(tc.qualifiedName(name), newAuditActor(req), "create", nil, nil)
+
 	// TODO: return 201
 	tc.Render(map[string]interface{}{
-		"topology": response.NewTopology(tb.Topology()),
+		"topology": tc.newTopologyResponse(tb),
+	})
+}
+
+// maxLogsWait is the maximum duration for which Logs waits for new entries.
+const maxLogsWait = time.Minute
+
+// Logs returns log entries written by the topology and its nodes. Entries
+// can be filtered by "node" query parameter. Only entries whose sequence
+// numbers are greater than "since" are returned. When "wait" is given and
+// there's no such entry, it waits for new entries up to the duration so that
+// clients can follow the log without polling frequently.
+func (tc *topologies) Logs(rw web.ResponseWriter, req *web.Request) {
+	var since, limit int64
+	q := req.URL.Query()
+	for _, p := range []struct {
+		name string
+		v    *int64
+	}{{"since", &since}, {"limit", &limit}} {
+		s := q.Get(p.name)
+		if s == "" {
+			continue
+		}
+		i, err := strconv.ParseInt(s, 10, 64)
+		if err != nil || i < 0 {
+			if err == nil {
+				err = fmt.Errorf("'%v' must not be negative", p.name)
+			}
+			tc.ErrLog(err).WithField(p.name, s).Error("Invalid query parameter")
+			e := jasco.NewError(formValidationErrorCode, "The request parameter is invalid.",
+				http.StatusBadRequest, err)
+			e.Meta[p.name] = []string{"value must be a non-negative integer"}
+			tc.RenderError(e)
+			return
+		}
+		*p.v = i
+	}
+	var wait time.Duration
+	if s := q.Get("wait"); s != "" {
+		d, err := time.ParseDuration(s)
+		if err != nil || d < 0 {
+			if err == nil {
+				err = fmt.Errorf("'wait' must not be negative")
+			}
+			tc.ErrLog(err).WithField("wait", s).Error("Invalid query parameter")
+			e := jasco.NewError(formValidationErrorCode, "The request parameter is invalid.",
+				http.StatusBadRequest, err)
+			e.Meta["wait"] = []string{"value must be a non-negative duration such as '30s'"}
+			tc.RenderError(e)
+			return
+		}
+		if d > maxLogsWait {
+			d = maxLogsWait
+		}
+		wait = d
+	}
+	node := q.Get("node")
+
+	b := tc.logs.buffer(tc.qualifiedName(tc.topologyName))
+	if b == nil {
+		// The topology may exist when logs aren't kept.
+		if tc.fetchTopology() == nil {
+			return
+		}
+		tc.Render(map[string]interface{}{
+			"topology_name": tc.topologyName,
+			"seq":           since,
+			"entries":       []*logEntry{},
+		})
+		return
+	}
+
+	entries, seq, updated := b.read(since, node, int(limit))
+	if len(entries) == 0 && wait > 0 {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+	waitLoop:
+		for len(entries) == 0 {
+			select {
+			case <-updated:
+				entries, seq, updated = b.read(since, node, int(limit))
+			case <-timer.C:
+				break waitLoop
+			case <-req.Context().Done():
+				break waitLoop
+			}
+		}
+	}
+	tc.Render(map[string]interface{}{
+		"topology_name": tc.topologyName,
+		"seq":           seq,
+		"entries":       entries,
+	})
+}
+
+// StatusHistory returns samples of statuses of nodes in the topology, which
+// are taken every statusHistoryInterval. It accepts the following optional
+// query parameters:
+//
+//	- minutes: only samples taken in the last N minutes are returned. All
+//	  samples kept in memory are returned when it's 0 or omitted
+//	- node: only samples of the node are returned
+func (tc *topologies) StatusHistory(rw web.ResponseWriter, req *web.Request) {
+	if tc.fetchTopology() == nil {
+		return
+	}
+
+	q := req.URL.Query()
+	var since time.Time
+	if s := q.Get("minutes"); s != "" {
+		m, err := strconv.ParseInt(s, 10, 64)
+		if err != nil || m < 0 {
+			if err == nil {
+				err = fmt.Errorf("'minutes' must not be negative")
+			}
+			tc.ErrLog(err).WithField("minutes", s).Error("Invalid query parameter")
+			e := jasco.NewError(formValidationErrorCode, "The request parameter is invalid.",
+				http.StatusBadRequest, err)
+			e.Meta["minutes"] = []string{"value must be a non-negative integer"}
+			tc.RenderError(e)
+			return
+		}
+		if m > 0 {
+			since = time.Now().Add(-time.Duration(m) * time.Minute)
+		}
+	}
+
+	tc.Render(map[string]interface{}{
+		"topology_name": tc.topologyName,
+		"interval":      statusHistoryInterval.Seconds(),
+		"nodes":         tc.history.read(tc.qualifiedName(tc.topologyName), q.Get("node"), since),
 	})
 }
 
+// newTopologyResponse creates a response of the topology having its current
+// version.
+func (tc *topologies) newTopologyResponse(tb *bql.TopologyBuilder) *response.Topology {
+	res := response.NewTopology(tb.Topology())
+	res.Constants = tb.Topology().Context().Constants()
+	res.Version = tc.audit.version(tc.qualifiedName(res.Name))
+	return res
+}
+
+// renderAdmissionError renders an error returned from admissionController.
+func (tc *topologies) renderAdmissionError(err error) {
+	if os.IsExist(err) {
+		tc.Log().Error("the name is already registered")
+		e := jasco.NewError(formValidationErrorCode, "The request body is invalid.",
+			http.StatusBadRequest, nil)
+		e.Meta["name"] = []string{"already taken"}
+		tc.RenderError(e)
+		return
+	}
+
+	ae, ok := err.(*admissionError)
+	if !ok {
+		tc.ErrLog(err).Error("Cannot admit the topology")
+		tc.RenderError(jasco.NewInternalServerError(err))
+		return
+	}
+	if len(ae.Undeclared) > 0 {
+		tc.ErrLog(err).Error("The topology doesn't declare required resources")
+		e := jasco.NewError(formValidationErrorCode, "The request body is invalid.",
+			http.StatusBadRequest, nil)
+		msgs := make([]string, len(ae.Undeclared))
+		for i, r := range ae.Undeclared {
+			msgs[i] = fmt.Sprintf("%v must be declared", r)
+		}
+		e.Meta["resources"] = msgs
+		tc.RenderError(e)
+		return
+	}
+	tc.ErrLog(err).Error("The topology exceeds resource limits")
+	e := jasco.NewError(resourceLimitExceededErrorCode,
+		"The topology cannot be created because it would exceed resource limits.",
+		http.StatusConflict, nil)
+	e.Meta["violations"] = ae.Violations
+	tc.RenderError(e)
+}
+
 // Index returned a list of registered topologies.
 func (tc *topologies) Index(rw web.ResponseWriter, req *web.Request) {
 	ts, err := tc.topologies.List()
@@ -176,7 +591,7 @@ func (tc *topologies) Index(rw web.ResponseWriter, req *web.Request) {
 
 	res := []*response.Topology{}
 	for _, tb := range ts {
-		res = append(res, response.NewTopology(tb.Topology()))
+		res = append(res, tc.newTopologyResponse(tb))
 	}
 	tc.Render(map[string]interface{}{
 		"topologies": res,
@@ -190,7 +605,7 @@ func (tc *topologies) Show(rw web.ResponseWriter, req *web.Request) {
 		return
 	}
 	tc.Render(map[string]interface{}{
-		"topology": response.NewTopology(tb.Topology()),
+		"topology": tc.newTopologyResponse(tb),
 	})
 }
 
@@ -206,10 +621,14 @@ func (tc *topologies) Destroy(rw web.ResponseWriter, req *web.Request) {
 	}
 	stopped := true
 	if tb != nil {
-		if err := tb.Topology().Stop(); err != nil {
+		tc.admission.release(tc.qualifiedName(tc.topologyName))
+		err := tb.Topology().Stop()
+		if err != nil {
 			stopped = false
 			tc.ErrLog(err).Error("Cannot stop the topology")
 		}
+		tc.audit.record(tc.qualifiedName(tc.topologyName), newAuditActor(req), "destroy", nil, err)
+		tc.logs.remove(tc.qualifiedName(tc.topologyName))
 	}
 
 	if stopped {
@@ -262,32 +681,81 @@ func (tc *topologies) Queries(rw web.ResponseWriter, req *web.Request) {
 		stmts = ss
 	}
 
+	shape, apiErr := parseResultShape(form)
+	if apiErr != nil {
+		tc.Log().WithField("meta", apiErr.Meta).Error("Cannot parse the format of results")
+		tc.RenderError(apiErr)
+		return
+	}
+
 	if len(stmts) == 1 {
 		stmtStr := fmt.Sprint(stmts[0])
 		if stmt, ok := stmts[0].(parser.SelectStmt); ok {
-			tc.handleSelectStmt(rw, stmt, stmtStr)
+			tc.handleSelectStmt(rw, stmt, stmtStr, shape)
 			return
 		} else if stmt, ok := stmts[0].(parser.SelectUnionStmt); ok {
-			tc.handleSelectUnionStmt(rw, stmt, stmtStr)
+			tc.handleSelectUnionStmt(rw, stmt, stmtStr, shape)
+			return
+		} else if stmt, ok := stmts[0].(parser.SelectStartingStmt); ok {
+			tc.handleSelectStartingStmt(rw, stmt, stmtStr, shape)
 			return
 		} else if stmt, ok := stmts[0].(parser.EvalStmt); ok {
 			tc.handleEvalStmt(rw, stmt, stmtStr)
 			return
+		} else if stmt, ok := stmts[0].(parser.ExplainAnalyzeStmt); ok {
+			tc.handleExplainAnalyzeStmt(rw, stmt, stmtStr)
+			return
+		} else if stmt, ok := stmts[0].(parser.ShowFunctionsStmt); ok {
+			tc.handleShowFunctionsStmt(rw, stmt, stmtStr)
+			return
 		}
 	}
 
+	// Statements are validated before any of them is executed so that the
+	// topology isn't left half-built when one of them has an error.
+	stmts, warnings, err := tb.ValidateStmts(stmts)
+	if err != nil {
+		tc.ErrLog(err).Error("Cannot validate statements")
+		e := newBQLStmtError("Cannot process a statement", err)
+		if pe, ok := err.(*bql.PlanError); ok {
+			e.Meta["statement"] = fmt.Sprint(pe.Stmt)
+		}
+		tc.RenderError(e)
+		return
+	}
+	for _, f := range tb.OptimizeStmts(stmts) {
+		tc.Log().WithFields(logrus.Fields{
+			"input":     f.Input,
+			"predicate": f.Predicate,
+			"streams":   f.Streams,
+		}).Info("Streams share a filter")
+	}
+
 	// TODO: handle this atomically
+	actor := newAuditActor(req)
+	var executed []string
+	funcs := map[string]int64{}
 	for _, stmt := range stmts {
 		// TODO: change the return value of AddStmt to support the new response format.
-		_, err := tb.AddStmt(stmt)
-		if err != nil {
+		if err := addStmt(tb, stmt, funcs); err != nil {
+			tc.recordQueries(actor, executed, funcs)
 			tc.ErrLog(err).Error("Cannot process a statement")
-			e := jasco.NewError(bqlStmtProcessingErrorCode, "Cannot process a statement", http.StatusBadRequest, err)
-			e.Meta["error"] = err.Error()
+			e := newBQLStmtError("Cannot process a statement", err)
 			e.Meta["statement"] = fmt.Sprint(stmt)
 			tc.RenderError(e)
 			return
 		}
+		executed = append(executed, fmt.Sprint(stmt))
+	}
+	tc.recordQueries(actor, executed, funcs)
+
+	ws := make([]map[string]interface{}, len(warnings))
+	for i, w := range warnings {
+		ws[i] = map[string]interface{}{
+			"kind":    w.Kind,
+			"node":    w.Node,
+			"message": w.Message,
+		}
 	}
 
 	// TODO: support the new format
@@ -295,6 +763,257 @@ func (tc *topologies) Queries(rw web.ResponseWriter, req *web.Request) {
 		"topology_name": tc.topologyName,
 		"status":        "running",
 		"queries":       stmts,
+		"warnings":      ws,
+	})
+}
+
+// Apply makes the topology have nodes and states defined by the queries.
+// Only necessary CREATE, DROP, UPDATE, and INSERT INTO statements are
+// executed. When dry_run is true, it only returns the plan.
+func (tc *topologies) Apply(rw web.ResponseWriter, req *web.Request) {
+	tb := tc.fetchTopology()
+	if tb == nil {
+		return
+	}
+
+	var js map[string]interface{}
+	if apiErr := tc.ParseBody(&js); apiErr != nil {
+		tc.ErrLog(apiErr.Err).Error("Cannot parse the request json")
+		tc.RenderError(apiErr)
+		return
+	}
+
+	form, err := data.NewMap(js)
+	if err != nil {
+		tc.ErrLog(err).WithField("body", js).
+			Error("The request json may contain invalid value")
+		tc.RenderError(jasco.NewError(formValidationErrorCode, "The request json may contain invalid values.",
+			http.StatusBadRequest, err))
+		return
+	}
+
+	var prune, dryRun bool
+	for _, f := range []struct {
+		name string
+		v    *bool
+	}{{"prune", &prune}, {"dry_run", &dryRun}} {
+		v, ok := form[f.name]
+		if !ok {
+			continue
+		}
+		b, err := data.AsBool(v)
+		if err != nil {
+			tc.ErrLog(err).Errorf("'%v' must be a boolean", f.name)
+			e := jasco.NewError(formValidationErrorCode, fmt.Sprintf("'%v' field must be a boolean", f.name),
+				http.StatusBadRequest, err)
+			tc.RenderError(e)
+			return
+		}
+		*f.v = b
+	}
+
+	stmts, apiErr := tc.parseQueries(form)
+	if apiErr != nil {
+		tc.RenderError(apiErr)
+		return
+	}
+
+	plan, err := tb.PlanApply(stmts, prune)
+	if err != nil {
+		tc.ErrLog(err).Error("Cannot plan the statements")
+		e := newBQLStmtError("Cannot plan the statements", err)
+		tc.RenderError(e)
+		return
+	}
+
+	ops := make([]map[string]interface{}, len(plan.Operations))
+	for i, op := range plan.Operations {
+		ops[i] = map[string]interface{}{
+			"action":    string(op.Action),
+			"kind":      op.Kind,
+			"name":      op.Name,
+			"statement": fmt.Sprint(op.Stmt),
+			"reason":    op.Reason,
+		}
+	}
+
+	if !dryRun {
+		err := tb.Apply(plan)
+		if len(ops) > 0 {
+			stmtStrs := make([]string, len(ops))
+			for i, op := range ops {
+				stmtStrs[i] = op["statement"].(string)
+			}
+			tc.audit.record(tc.qualifiedName(tc.topologyName), newAuditActor(req), "apply", stmtStrs, err)
+		}
+		if err != nil {
+			tc.ErrLog(err).Error("Cannot apply the statements")
+			e := newBQLStmtError("Cannot apply the statements", err)
+			e.Meta["operations"] = ops
+			tc.RenderError(e)
+			return
+		}
+	}
+
+	tc.Render(map[string]interface{}{
+		"topology_name": tc.topologyName,
+		"operations":    ops,
+		"applied":       !dryRun,
+	})
+}
+
+// addStmt adds a statement to the topology. When the statement reloads a
+// function, the new version of the function is set to funcs.
+func addStmt(tb *bql.TopologyBuilder, stmt interface{}, funcs map[string]int64) error {
+	if s, ok := stmt.(parser.ReloadFunctionStmt); ok {
+		ver, err := tb.RunReloadFunctionStmt(&s)
+		if err != nil {
+			return err
+		}
+		funcs[strings.ToLower(string(s.Function))] = ver
+		return nil
+	}
+	_, err := tb.AddStmt(stmt)
+	return err
+}
+
+// recordQueries records statements executed by addStmt to the audit log
+// with versions of reloaded functions. Nothing is recorded when no statement
+// has been executed.
+func (tc *topologies) recordQueries(actor auditActor, stmts []string, funcs map[string]int64) {
+	if len(stmts) == 0 {
+		return
+	}
+	tc.audit.recordFunctions(tc.qualifiedName(tc.topologyName), actor, "queries", stmts, funcs, nil)
+}
+
+// ReloadFunction reloads the implementation of a function which can be
+// reloaded, e.g. a function defined by a script. The new version of the
+// function is recorded in the audit log.
+func (tc *topologies) ReloadFunction(rw web.ResponseWriter, req *web.Request) {
+	tb := tc.fetchTopology()
+	if tb == nil {
+		return
+	}
+	name := tc.PathParams().String("functionName", "")
+	stmt := parser.ReloadFunctionStmt{Function: parser.FuncName(name)}
+	ver, err := tb.RunReloadFunctionStmt(&stmt)
+	if err != nil {
+		tc.ErrLog(err).WithField("function", name).Error("Cannot reload the function")
+		e := newBQLStmtError("Cannot reload the function", err)
+		e.Meta["statement"] = stmt.String()
+		tc.RenderError(e)
+		return
+	}
+	tc.audit.recordFunctions(tc.qualifiedName(tc.topologyName), newAuditActor(req), "reload_function",
+		[]string{stmt.String()}, map[string]int64{strings.ToLower(name): ver}, nil)
+	tc.Render(map[string]interface{}{
+		"topology_name": tc.topologyName,
+		"function":      name,
+		"version":       ver,
+	})
+}
+
+// Audit returns the audit log of the topology. Entries whose versions are
+// greater than "since" query parameter are returned up to "limit" entries.
+// The log is still available after the topology is destroyed.
+func (tc *topologies) Audit(rw web.ResponseWriter, req *web.Request) {
+	var since, limit int64
+	q := req.URL.Query()
+	for _, p := range []struct {
+		name string
+		v    *int64
+	}{{"since", &since}, {"limit", &limit}} {
+		s := q.Get(p.name)
+		if s == "" {
+			continue
+		}
+		i, err := strconv.ParseInt(s, 10, 64)
+		if err != nil || i < 0 {
+			if err == nil {
+				err = fmt.Errorf("'%v' must not be negative", p.name)
+			}
+			tc.ErrLog(err).WithField(p.name, s).Error("Invalid query parameter")
+			e := jasco.NewError(formValidationErrorCode, "The request parameter is invalid.",
+				http.StatusBadRequest, err)
+			e.Meta[p.name] = []string{"value must be a non-negative integer"}
+			tc.RenderError(e)
+			return
+		}
+		*p.v = i
+	}
+
+	entries, version, ok := tc.audit.entries(tc.qualifiedName(tc.topologyName), since, int(limit))
+	if !ok {
+		// The topology may exist when it was created without being recorded.
+		if tc.fetchTopology() == nil {
+			return
+		}
+		entries = []*auditEntry{}
+	}
+	tc.Render(map[string]interface{}{
+		"topology_name": tc.topologyName,
+		"version":       version,
+		"entries":       entries,
+	})
+}
+
+// maxLineageDepth is the maximum depth of the lineage which Lineage action
+// traces back.
+const maxLineageDepth = 16
+
+// Lineage returns lineage records of the tuple. Records of input tuples are
+// also returned up to the depth given by "depth" query parameter.
+func (tc *topologies) Lineage(rw web.ResponseWriter, req *web.Request) {
+	depth := 1
+	if s := req.URL.Query().Get("depth"); s != "" {
+		d, err := strconv.Atoi(s)
+		if err != nil || d < 1 || d > maxLineageDepth {
+			if err == nil {
+				err = fmt.Errorf("'depth' must be in [1, %v]", maxLineageDepth)
+			}
+			tc.ErrLog(err).WithField("depth", s).Error("Invalid query parameter")
+			e := jasco.NewError(formValidationErrorCode, "The request parameter is invalid.",
+				http.StatusBadRequest, err)
+			e.Meta["depth"] = []string{fmt.Sprintf("value must be an integer in [1, %v]", maxLineageDepth)}
+			tc.RenderError(e)
+			return
+		}
+		depth = d
+	}
+
+	tb := tc.fetchTopology()
+	if tb == nil {
+		return
+	}
+	id := tc.PathParams().String("tupleID", "")
+	store := tb.Topology().Context().Lineage()
+
+	// Trace the lineage back in breadth-first order. A tuple is visited
+	// only once even if it contributed to multiple tuples.
+	records := []*response.LineageRecord{}
+	visited := map[string]bool{id: true}
+	ids := []string{id}
+	for d := 0; store != nil && d < depth && len(ids) > 0; d++ {
+		var next []string
+		for _, i := range ids {
+			for _, r := range store.Lookup(i) {
+				records = append(records, response.NewLineageRecord(r))
+				for _, in := range r.Inputs {
+					if !visited[in] {
+						visited[in] = true
+						next = append(next, in)
+					}
+				}
+			}
+		}
+		ids = next
+	}
+	tc.Render(map[string]interface{}{
+		"topology_name": tc.topologyName,
+		"tuple_id":      id,
+		"enabled":       store != nil,
+		"records":       records,
 	})
 }
 
@@ -317,6 +1036,25 @@ func (tc *topologies) parseQueries(form data.Map) ([]interface{}, *jasco.Error)
 		queries = f
 	}
 
+	if v, ok := form["parameters"]; ok {
+		params, err := data.AsArray(v)
+		if err != nil {
+			tc.ErrLog(err).Error("'parameters' must be an array")
+			e := jasco.NewError(formValidationErrorCode, "'parameters' field must be an array",
+				http.StatusBadRequest, err)
+			return nil, e
+		}
+		q, err := parser.BindParameters(queries, params)
+		if err != nil {
+			tc.ErrLog(err).Error("Cannot bind parameters")
+			e := jasco.NewError(formValidationErrorCode, "Cannot bind parameters to placeholders",
+				http.StatusBadRequest, err)
+			e.Meta["parameters"] = []string{err.Error()}
+			return nil, e
+		}
+		queries = q
+	}
+
 	bp := parser.New()
 	stmts := []interface{}{}
 	dataReturningStmtIndex := -1
@@ -328,14 +1066,25 @@ func (tc *topologies) parseQueries(form data.Map) ([]interface{}, *jasco.Error)
 			e := jasco.NewError(bqlStmtParseErrorCode, "Cannot parse a BQL statement", http.StatusBadRequest, err)
 			e.Meta["parse_errors"] = strings.Split(err.Error(), "\n") // FIXME: too ad hoc
 			e.Meta["statement"] = queries
+			e.Meta["error_code"] = core.ErrCodeParse
+			if line, col, ok := parser.ErrorPosition(err); ok {
+				e.Meta["line"] = line
+				e.Meta["column"] = col
+			}
 			return nil, e
 		}
 		if _, ok := stmt.(parser.SelectStmt); ok {
 			dataReturningStmtIndex = len(stmts)
 		} else if _, ok := stmt.(parser.SelectUnionStmt); ok {
 			dataReturningStmtIndex = len(stmts)
+		} else if _, ok := stmt.(parser.SelectStartingStmt); ok {
+			dataReturningStmtIndex = len(stmts)
 		} else if _, ok := stmt.(parser.EvalStmt); ok {
 			dataReturningStmtIndex = len(stmts)
+		} else if _, ok := stmt.(parser.ExplainAnalyzeStmt); ok {
+			dataReturningStmtIndex = len(stmts)
+		} else if _, ok := stmt.(parser.ShowFunctionsStmt); ok {
+			dataReturningStmtIndex = len(stmts)
 		}
 
 		stmts = append(stmts, stmt)
@@ -344,10 +1093,10 @@ func (tc *topologies) parseQueries(form data.Map) ([]interface{}, *jasco.Error)
 
 	if dataReturningStmtIndex >= 0 {
 		if len(stmts) != 1 {
-			errMsg := "A SELECT or EVAL statement cannot be issued with other statements"
+			errMsg := "A SELECT, EVAL, EXPLAIN, or SHOW statement cannot be issued with other statements"
 			tc.Log().Error(errMsg)
 			e := jasco.NewError(bqlStmtProcessingErrorCode, "Cannot process a statement", http.StatusBadRequest, nil)
-			e.Meta["error"] = "a SELECT or EVAL statement cannot be issued with other statements"
+			e.Meta["error"] = "a SELECT, EVAL, EXPLAIN, or SHOW statement cannot be issued with other statements"
 			e.Meta["statement"] = fmt.Sprint(stmts[dataReturningStmtIndex])
 			return nil, e
 		}
@@ -355,12 +1104,12 @@ func (tc *topologies) parseQueries(form data.Map) ([]interface{}, *jasco.Error)
 	return stmts, nil
 }
 
-func (tc *topologies) handleSelectStmt(rw web.ResponseWriter, stmt parser.SelectStmt, stmtStr string) {
-	tmpStmt := parser.SelectUnionStmt{[]parser.SelectStmt{stmt}}
-	tc.handleSelectUnionStmt(rw, tmpStmt, stmtStr)
+func (tc *topologies) handleSelectStmt(rw web.ResponseWriter, stmt parser.SelectStmt, stmtStr string, shape *resultShape) {
+	tmpStmt := parser.SelectUnionStmt{Selects: []parser.SelectStmt{stmt}}
+	tc.handleSelectUnionStmt(rw, tmpStmt, stmtStr, shape)
 }
 
-func (tc *topologies) handleSelectUnionStmt(rw web.ResponseWriter, stmt parser.SelectUnionStmt, stmtStr string) {
+func (tc *topologies) handleSelectUnionStmt(rw web.ResponseWriter, stmt parser.SelectUnionStmt, stmtStr string, shape *resultShape) {
 	tb := tc.fetchTopology()
 	if tb == nil { // just in case
 		return
@@ -369,12 +1118,42 @@ func (tc *topologies) handleSelectUnionStmt(rw web.ResponseWriter, stmt parser.S
 	sn, ch, err := tb.AddSelectUnionStmt(&stmt)
 	if err != nil {
 		tc.ErrLog(err).Error("Cannot process a statement")
-		e := jasco.NewError(bqlStmtProcessingErrorCode, "Cannot process a statement", http.StatusBadRequest, err)
-		e.Meta["error"] = err.Error()
+		e := newBQLStmtError("Cannot process a statement", err)
+		e.Meta["statement"] = stmtStr
+		tc.RenderError(e)
+		return
+	}
+	tc.streamTuples(rw, sn, ch, tc.config.Network, shape, logrus.Fields{"statement": stmtStr}, "SELECT responses")
+}
+
+func (tc *topologies) handleSelectStartingStmt(rw web.ResponseWriter, stmt parser.SelectStartingStmt, stmtStr string, shape *resultShape) {
+	tb := tc.fetchTopology()
+	if tb == nil { // just in case
+		return
+	}
+
+	sn, ch, err := tb.AddSelectStartingStmt(&stmt)
+	if err != nil {
+		tc.ErrLog(err).Error("Cannot process a statement")
+		e := newBQLStmtError("Cannot process a statement", err)
 		e.Meta["statement"] = stmtStr
 		tc.RenderError(e)
 		return
 	}
+	tc.streamTuples(rw, sn, ch, tc.config.Network, shape, logrus.Fields{"statement": stmtStr}, "SELECT responses")
+}
+
+// streamTuples writes tuples received from ch as a multipart response until
+// ch is closed or the client disconnects. sn is stopped at the end. netConf
+// has the parameters of the stream. shape controls the encoding of tuples and
+// can be nil. what describes the tuples in logs.
+//
+// Tuples are buffered for the client so that a slow client doesn't block the
+// topology. When the buffer is full, the client is disconnected or the oldest
+// tuples are dropped depending on the slow client policy. In either case, a
+// notice part having "X-Sensorbee-Notice" header is written.
+func (tc *topologies) streamTuples(rw web.ResponseWriter, sn core.SinkNode, ch <-chan *core.Tuple,
+	netConf *config.Network, shape *resultShape, fields logrus.Fields, what string) {
 	defer func() {
 		go func() {
 			// vacuum all tuples to avoid blocking the sink.
@@ -396,6 +1175,18 @@ func (tc *topologies) handleSelectUnionStmt(rw web.ResponseWriter, stmt parser.S
 		return
 	}
 
+	var m *core.NodeMetrics
+	if tc.topology != nil {
+		m = tc.topology.Topology().Context().Metrics().Node(sn.Name())
+	}
+	stream, err := newSelectStream(ch, netConf, m, conn.RemoteAddr().String())
+	if err != nil {
+		tc.ErrLog(err).Error("Cannot set up a stream of results")
+		conn.Close()
+		return
+	}
+	defer stream.close()
+
 	var (
 		writeErr error
 		readErr  error
@@ -406,6 +1197,7 @@ func (tc *topologies) handleSelectUnionStmt(rw web.ResponseWriter, stmt parser.S
 			tc.ErrLog(writeErr).Info("Cannot write contents to the hijacked connection")
 		}
 
+		conn.SetWriteDeadline(streamWriteDeadline(netConf))
 		if err := mw.Close(); err != nil {
 			if writeErr == nil && readErr == nil { // log it only when the write err hasn't happend
 				tc.ErrLog(err).Info("Cannot finish the multipart response")
@@ -414,7 +1206,7 @@ func (tc *topologies) handleSelectUnionStmt(rw web.ResponseWriter, stmt parser.S
 		bufrw.Flush()
 		conn.Close()
 
-		tc.Log().WithField("statement", stmtStr).Info("Finish streaming SELECT responses")
+		tc.Log().WithFields(fields).Infof("Finish streaming %v", what)
 	}()
 
 	res := []string{
@@ -422,30 +1214,58 @@ func (tc *topologies) handleSelectUnionStmt(rw web.ResponseWriter, stmt parser.S
 		fmt.Sprintf(`Content-Type: multipart/mixed; boundary="%v"`, mw.Boundary()),
 		"\r\n",
 	}
+	conn.SetWriteDeadline(streamWriteDeadline(netConf))
 	if _, err := bufrw.WriteString(strings.Join(res, "\r\n")); err != nil {
 		tc.ErrLog(err).Error("Cannot write a header to the hijacked connection")
 		return
 	}
-	bufrw.Flush()
+	if err := bufrw.Flush(); err != nil {
+		writeErr = err
+		return
+	}
 
-	tc.Log().WithField("statement", stmtStr).Info("Start streaming SELECT responses")
+	tc.Log().WithFields(fields).Infof("Start streaming %v", what)
 
 	// All error reporting logs after this is info level because they might be
 	// caused by the client closing the connection.
-	header := textproto.MIMEHeader{}
-	header.Add("Content-Type", "application/json")
+	// writePart writes a part having js. When name isn't empty, the part has
+	// a header of the name and the value.
+	writePart := func(name, value string, js string) error {
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Type", "application/json")
+		// TODO: don't forget to convert \n to \r\n when returning
+		// pretty-printed JSON objects.
+		header.Set("Content-Length", fmt.Sprint(len(js)))
+		if name != "" {
+			header.Set(name, value)
+		}
+
+		conn.SetWriteDeadline(streamWriteDeadline(netConf))
+		w, err := mw.CreatePart(header)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(w, js); err != nil {
+			return err
+		}
+		return bufrw.Flush()
+	}
 
 	readPoll := time.After(1 * time.Minute)
 	sent := false
 	dummyReadBuf := make([]byte, 1024)
 	for {
-		var t *core.Tuple
+		var r selectResult
 		select {
-		case v, ok := <-ch:
+		case v, ok := <-stream.results():
 			if !ok {
+				if err := stream.err(); err != nil {
+					tc.ErrLog(err).WithFields(fields).Info("Disconnecting the client")
+					writeErr = writePart("X-Sensorbee-Notice", "disconnected", newStreamNotice("disconnected", err.Error(), 0).String())
+				}
 				return
 			}
-			t = v
+			r = v
 			sent = true
 		case <-readPoll:
 			if sent {
@@ -481,21 +1301,21 @@ func (tc *topologies) handleSelectUnionStmt(rw web.ResponseWriter, stmt parser.S
 			continue
 		}
 
-		js := t.Data.String()
-		// TODO: don't forget to convert \n to \r\n when returning
-		// pretty-printed JSON objects.
-		header.Set("Content-Length", fmt.Sprint(len(js)))
-
-		w, err := mw.CreatePart(header)
-		if err != nil {
-			writeErr = err
-			return
+		if r.dropped > 0 {
+			n := newStreamNotice("dropped", "tuples were dropped because the client was too slow", r.dropped)
+			if err := writePart("X-Sensorbee-Notice", "dropped", n.String()); err != nil {
+				writeErr = err
+				return
+			}
 		}
-		if _, err := io.WriteString(w, js); err != nil {
-			writeErr = err
-			return
+		columns, v := shape.shape(r.tuple.Data)
+		if columns != nil {
+			if err := writePart("X-Sensorbee-Header", "columns", columnsHeader(columns).String()); err != nil {
+				writeErr = err
+				return
+			}
 		}
-		if err := bufrw.Flush(); err != nil {
+		if err := writePart("", "", v.String()); err != nil {
 			writeErr = err
 			return
 		}
@@ -511,8 +1331,7 @@ func (tc *topologies) handleEvalStmt(rw web.ResponseWriter, stmt parser.EvalStmt
 	result, err := tb.RunEvalStmt(&stmt)
 	if err != nil {
 		tc.ErrLog(err).Error("Cannot process a statement")
-		e := jasco.NewError(bqlStmtProcessingErrorCode, "Cannot process a statement", http.StatusBadRequest, err)
-		e.Meta["error"] = err.Error()
+		e := newBQLStmtError("Cannot process a statement", err)
 		e.Meta["statement"] = stmtStr
 		tc.RenderError(e)
 		return
@@ -524,6 +1343,46 @@ func (tc *topologies) handleEvalStmt(rw web.ResponseWriter, stmt parser.EvalStmt
 	})
 }
 
+func (tc *topologies) handleExplainAnalyzeStmt(rw web.ResponseWriter, stmt parser.ExplainAnalyzeStmt, stmtStr string) {
+	tb := tc.fetchTopology()
+	if tb == nil { // just in case
+		return
+	}
+
+	result, err := tb.RunExplainAnalyzeStmt(&stmt)
+	if err != nil {
+		tc.ErrLog(err).Error("Cannot process a statement")
+		e := newBQLStmtError("Cannot process a statement", err)
+		e.Meta["statement"] = stmtStr
+		tc.RenderError(e)
+		return
+	}
+
+	tc.Render(map[string]interface{}{
+		"result": result,
+	})
+}
+
+func (tc *topologies) handleShowFunctionsStmt(rw web.ResponseWriter, stmt parser.ShowFunctionsStmt, stmtStr string) {
+	tb := tc.fetchTopology()
+	if tb == nil { // just in case
+		return
+	}
+
+	result, err := tb.RunShowFunctionsStmt(&stmt)
+	if err != nil {
+		tc.ErrLog(err).Error("Cannot process a statement")
+		e := newBQLStmtError("Cannot process a statement", err)
+		e.Meta["statement"] = stmtStr
+		tc.RenderError(e)
+		return
+	}
+
+	tc.Render(map[string]interface{}{
+		"result": result,
+	})
+}
+
 // WebSocketQueries handles requests using WebSocket. A single WebSocket
 // connection can concurrently issue multiple requests including requests
 // containing a SELECT statement.
@@ -571,6 +1430,7 @@ func (tc *topologies) handleEvalStmt(rw web.ResponseWriter, stmt parser.EvalStmt
 //	* "sos"
 //	* "ping"
 //	* "eos"
+//	* "notice"
 //
 // When the type is "result", "payload" field contains the result obtained by
 // executing the query. The form of response depends on the type of a statement
@@ -583,7 +1443,11 @@ func (tc *topologies) handleEvalStmt(rw web.ResponseWriter, stmt parser.EvalStmt
 // always null. SELECT statements send "ping" responses on a regular basis.
 // "eos", end of stream, responses are sent when SELECT statements has sent all
 // tuples. "payload" of "eos" is always null. "eos" isn't sent when an error
-// occurred.
+// occurred. "notice" responses are sent by SELECT statements when the client
+// cannot keep up with results. Its "payload" has "type" field, which is
+// "dropped" when some results were dropped or "disconnected" when the
+// connection is about to be closed, "message" field, and "dropped" field
+// having the number of dropped results.
 func (tc *topologies) WebSocketQueries(rw web.ResponseWriter, req *web.Request) {
 	// TODO: add a document describing which BQL statement returns which result.
 	if !strings.EqualFold(req.Header.Get("Upgrade"), "WebSocket") {
@@ -603,7 +1467,8 @@ func (tc *topologies) WebSocketQueries(rw web.ResponseWriter, req *web.Request)
 	defer tc.Log().Info("End WebSocket connection")
 
 	websocket.Handler(func(conn *websocket.Conn) {
-		for tc.processWebSocketMessage(conn, tb) {
+		actor := newAuditActor(req)
+		for tc.processWebSocketMessage(conn, tb, actor) {
 		}
 	}).ServeHTTP(rw, req.Request)
 }
@@ -611,7 +1476,7 @@ func (tc *topologies) WebSocketQueries(rw web.ResponseWriter, req *web.Request)
 // processWebSocketMessage processes a request from the client. It returns true
 // if the caller can call this method again, in other words, the connection is
 // still alive.
-func (tc *topologies) processWebSocketMessage(conn *websocket.Conn, tb *bql.TopologyBuilder) bool {
+func (tc *topologies) processWebSocketMessage(conn *websocket.Conn, tb *bql.TopologyBuilder, actor auditActor) bool {
 	w := &webSocketTopologyQueryHandler{
 		tc:   tc,
 		conn: conn,
@@ -696,6 +1561,12 @@ func (tc *topologies) processWebSocketMessage(conn *websocket.Conn, tb *bql.Topo
 		stmts = ss
 	}
 
+	shape, apiErr := parseResultShape(payload)
+	if apiErr != nil {
+		w.Log().WithField("meta", apiErr.Meta).Error("Cannot parse the format of results")
+		return w.sendErr(apiErr)
+	}
+
 	// Although these requests may fail asynchronously, the connect is probably
 	// still alive and next processWebSocketMessage can detect disconnection.
 	// So, the following code block always returns true.
@@ -703,30 +1574,43 @@ func (tc *topologies) processWebSocketMessage(conn *websocket.Conn, tb *bql.Topo
 		if len(stmts) == 1 {
 			stmtStr := fmt.Sprint(stmts[0])
 			if stmt, ok := stmts[0].(parser.SelectStmt); ok {
-				w.handleSelectStmtWebSocket(conn, stmt, stmtStr)
+				w.handleSelectStmtWebSocket(conn, stmt, stmtStr, shape)
 				return
 			} else if stmt, ok := stmts[0].(parser.SelectUnionStmt); ok {
-				w.handleSelectUnionStmtWebSocket(conn, stmt, stmtStr)
+				w.handleSelectUnionStmtWebSocket(conn, stmt, stmtStr, shape)
+				return
+			} else if stmt, ok := stmts[0].(parser.SelectStartingStmt); ok {
+				w.handleSelectStartingStmtWebSocket(conn, stmt, stmtStr, shape)
 				return
 			} else if stmt, ok := stmts[0].(parser.EvalStmt); ok {
 				w.handleEvalStmtWebSocket(conn, stmt, stmtStr)
 				return
+			} else if stmt, ok := stmts[0].(parser.ExplainAnalyzeStmt); ok {
+				w.handleExplainAnalyzeStmtWebSocket(conn, stmt, stmtStr)
+				return
+			} else if stmt, ok := stmts[0].(parser.ShowFunctionsStmt); ok {
+				w.handleShowFunctionsStmtWebSocket(conn, stmt, stmtStr)
+				return
 			}
 		}
 
 		// TODO: handle this atomically
+		var executed []string
+		funcs := map[string]int64{}
 		for _, stmt := range stmts {
 			// TODO: change the return value of AddStmt to support the new response format.
-			_, err = tb.AddStmt(stmt)
+			err = addStmt(tb, stmt, funcs)
 			if err != nil {
+				tc.recordQueries(actor, executed, funcs)
 				w.ErrLog(err).Error("Cannot process a statement")
-				e := jasco.NewError(bqlStmtProcessingErrorCode, "Cannot process a statement", http.StatusBadRequest, err)
-				e.Meta["error"] = err.Error()
+				e := newBQLStmtError("Cannot process a statement", err)
 				e.Meta["statement"] = fmt.Sprint(stmt)
 				w.sendErr(e)
 				return
 			}
+			executed = append(executed, fmt.Sprint(stmt))
 		}
+		tc.recordQueries(actor, executed, funcs)
 
 		// TODO: define a proper response format
 		if err := w.send("result", map[string]interface{}{}); err != nil {
@@ -755,6 +1639,7 @@ func (w *webSocketTopologyQueryHandler) receive(v interface{}) error {
 }
 
 func (w *webSocketTopologyQueryHandler) send(msgType string, v interface{}) error {
+	w.conn.SetWriteDeadline(streamWriteDeadline(w.tc.config.Network))
 	return websocket.JSON.Send(w.conn, map[string]interface{}{
 		"rid":     w.rid,
 		"type":    msgType,
@@ -773,23 +1658,37 @@ func (w *webSocketTopologyQueryHandler) sendErr(e *jasco.Error) bool {
 	return true
 }
 
-func (w *webSocketTopologyQueryHandler) handleSelectStmtWebSocket(conn *websocket.Conn, stmt parser.SelectStmt, stmtStr string) {
-	tmpStmt := parser.SelectUnionStmt{[]parser.SelectStmt{stmt}}
-	w.handleSelectUnionStmtWebSocket(conn, tmpStmt, stmtStr)
+func (w *webSocketTopologyQueryHandler) handleSelectStmtWebSocket(conn *websocket.Conn, stmt parser.SelectStmt, stmtStr string, shape *resultShape) {
+	tmpStmt := parser.SelectUnionStmt{Selects: []parser.SelectStmt{stmt}}
+	w.handleSelectUnionStmtWebSocket(conn, tmpStmt, stmtStr, shape)
+}
+
+func (w *webSocketTopologyQueryHandler) handleSelectUnionStmtWebSocket(conn *websocket.Conn, stmt parser.SelectUnionStmt, stmtStr string, shape *resultShape) {
+	w.handleSelectWebSocket(stmtStr, shape, func(tb *bql.TopologyBuilder) (core.SinkNode, <-chan *core.Tuple, error) {
+		return tb.AddSelectUnionStmt(&stmt)
+	})
+}
+
+func (w *webSocketTopologyQueryHandler) handleSelectStartingStmtWebSocket(conn *websocket.Conn, stmt parser.SelectStartingStmt, stmtStr string, shape *resultShape) {
+	w.handleSelectWebSocket(stmtStr, shape, func(tb *bql.TopologyBuilder) (core.SinkNode, <-chan *core.Tuple, error) {
+		return tb.AddSelectStartingStmt(&stmt)
+	})
 }
 
-func (w *webSocketTopologyQueryHandler) handleSelectUnionStmtWebSocket(conn *websocket.Conn, stmt parser.SelectUnionStmt, stmtStr string) {
+// handleSelectWebSocket sends results of a SELECT statement whose nodes are
+// created by add. Results are encoded according to shape, which can be nil.
+func (w *webSocketTopologyQueryHandler) handleSelectWebSocket(stmtStr string, shape *resultShape,
+	add func(tb *bql.TopologyBuilder) (core.SinkNode, <-chan *core.Tuple, error)) {
 	// TODO: merge this function with handleSelectUnionStmt if possible
 	tb := w.tc.fetchTopology()
 	if tb == nil { // just in case
 		return
 	}
 
-	sn, ch, err := tb.AddSelectUnionStmt(&stmt)
+	sn, ch, err := add(tb)
 	if err != nil {
 		w.ErrLog(err).Error("Cannot process a statement")
-		e := jasco.NewError(bqlStmtProcessingErrorCode, "Cannot process a statement", http.StatusBadRequest, err)
-		e.Meta["error"] = err.Error()
+		e := newBQLStmtError("Cannot process a statement", err)
 		e.Meta["statement"] = stmtStr
 		w.sendErr(e)
 		return
@@ -810,6 +1709,18 @@ func (w *webSocketTopologyQueryHandler) handleSelectUnionStmtWebSocket(conn *web
 		}
 	}()
 
+	var m *core.NodeMetrics
+	if w.tc.topology != nil {
+		m = w.tc.topology.Topology().Context().Metrics().Node(sn.Name())
+	}
+	stream, err := newSelectStream(ch, w.tc.config.Network, m, w.conn.Request().RemoteAddr)
+	if err != nil {
+		w.ErrLog(err).Error("Cannot set up a stream of results")
+		w.sendErr(jasco.NewInternalServerError(err))
+		return
+	}
+	defer stream.close()
+
 	w.Log().WithField("statement", stmtStr).Info("Start streaming SELECT responses")
 
 	if err := w.send("sos", nil); err != nil {
@@ -820,16 +1731,24 @@ func (w *webSocketTopologyQueryHandler) handleSelectUnionStmtWebSocket(conn *web
 	ping := time.After(1 * time.Minute)
 	sent := false
 	for {
-		var t *core.Tuple
+		var r selectResult
 		select {
-		case v, ok := <-ch:
+		case v, ok := <-stream.results():
 			if !ok {
+				if err := stream.err(); err != nil {
+					w.ErrLog(err).WithField("statement", stmtStr).Info("Disconnecting the client")
+					if err := w.send("notice", newStreamNotice("disconnected", err.Error(), 0)["notice"]); err != nil {
+						w.ErrLog(err).Error("Cannot send a notice to the WebSocket client")
+					}
+					w.conn.Close()
+					return
+				}
 				if err := w.send("eos", nil); err != nil {
 					w.ErrLog(err).Error("Cannot send an EOS message to the WebSocket client")
 				}
 				return
 			}
-			t = v
+			r = v
 			sent = true
 		case <-ping:
 			if sent {
@@ -846,7 +1765,21 @@ func (w *webSocketTopologyQueryHandler) handleSelectUnionStmtWebSocket(conn *web
 			continue
 		}
 
-		if err := w.send("result", t.Data); err != nil {
+		if r.dropped > 0 {
+			n := newStreamNotice("dropped", "tuples were dropped because the client was too slow", r.dropped)
+			if err := w.send("notice", n["notice"]); err != nil {
+				w.ErrLog(err).Error("Cannot send a notice to the WebSocket client")
+				return
+			}
+		}
+		columns, v := shape.shape(r.tuple.Data)
+		if columns != nil {
+			if err := w.send("columns", columnsHeader(columns)["columns"]); err != nil {
+				w.ErrLog(err).Error("Cannot send columns to the WebSocket client")
+				return
+			}
+		}
+		if err := w.send("result", v); err != nil {
 			w.ErrLog(err).Error("Cannot send an error response to the WebSocket client")
 			return
 		}
@@ -862,8 +1795,53 @@ func (w *webSocketTopologyQueryHandler) handleEvalStmtWebSocket(conn *websocket.
 	result, err := tb.RunEvalStmt(&stmt)
 	if err != nil {
 		w.ErrLog(err).Error("Cannot process a statement")
-		e := jasco.NewError(bqlStmtProcessingErrorCode, "Cannot process a statement", http.StatusBadRequest, err)
-		e.Meta["error"] = err.Error()
+		e := newBQLStmtError("Cannot process a statement", err)
+		e.Meta["statement"] = stmtStr
+		w.sendErr(e)
+		return
+	}
+
+	if err := w.send("result", map[string]interface{}{
+		"result": result,
+	}); err != nil {
+		w.ErrLog(err).Error("Cannot send data to the WebSocket client")
+		return
+	}
+}
+
+func (w *webSocketTopologyQueryHandler) handleExplainAnalyzeStmtWebSocket(conn *websocket.Conn, stmt parser.ExplainAnalyzeStmt, stmtStr string) {
+	tb := w.tc.fetchTopology()
+	if tb == nil { // just in case
+		return
+	}
+
+	result, err := tb.RunExplainAnalyzeStmt(&stmt)
+	if err != nil {
+		w.ErrLog(err).Error("Cannot process a statement")
+		e := newBQLStmtError("Cannot process a statement", err)
+		e.Meta["statement"] = stmtStr
+		w.sendErr(e)
+		return
+	}
+
+	if err := w.send("result", map[string]interface{}{
+		"result": result,
+	}); err != nil {
+		w.ErrLog(err).Error("Cannot send data to the WebSocket client")
+		return
+	}
+}
+
+func (w *webSocketTopologyQueryHandler) handleShowFunctionsStmtWebSocket(conn *websocket.Conn, stmt parser.ShowFunctionsStmt, stmtStr string) {
+	tb := w.tc.fetchTopology()
+	if tb == nil { // just in case
+		return
+	}
+
+	result, err := tb.RunShowFunctionsStmt(&stmt)
+	if err != nil {
+		w.ErrLog(err).Error("Cannot process a statement")
+		e := newBQLStmtError("Cannot process a statement", err)
 		e.Meta["statement"] = stmtStr
 		w.sendErr(e)
 		return
@@ -0,0 +1,465 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gopkg.in/sensorbee/sensorbee.v0/server/config"
+)
+
+const (
+	// workerTopologyEnv is the environment variable having the name of the
+	// topology hosted by a worker process.
+	workerTopologyEnv = "SENSORBEE_WORKER_TOPOLOGY"
+
+	// workerParentFDEnv is the environment variable having the file
+	// descriptor of the pipe which is closed when the parent process stops
+	// the worker process or exits.
+	workerParentFDEnv = "SENSORBEE_WORKER_PARENT_FD"
+
+	// isolationProcess is the value of config.Topology.Isolation which runs
+	// the topology in a worker process.
+	isolationProcess = "process"
+
+	// minWorkerRestartInterval and maxWorkerRestartInterval are bounds of
+	// the interval between restarts of a crashed worker process. The
+	// interval is doubled every time the process crashes shortly after it's
+	// started.
+	minWorkerRestartInterval = time.Second
+	maxWorkerRestartInterval = 30 * time.Second
+
+	// workerStopTimeout is the time given to a worker process to stop its
+	// topology before it's killed.
+	workerStopTimeout = 10 * time.Second
+)
+
+// Worker has information which a worker process receives from the parent
+// server. A worker process hosts one topology isolated by the "process"
+// isolation mode and serves the API of the topology to the parent server
+// through a unix socket.
+type Worker struct {
+	// Topology is the name of the topology hosted by the worker process.
+	Topology string
+
+	parent *os.File
+	done   chan struct{}
+}
+
+// InheritedWorker returns the Worker when the process is started by the
+// parent server as a worker process. It returns nil when the process isn't a
+// worker process. The returned Worker should be passed to New by AsWorker
+// with the listener returned from InheritedListener.
+func InheritedWorker() (*Worker, error) {
+	name := os.Getenv(workerTopologyEnv)
+	if name == "" {
+		return nil, nil
+	}
+	// The variables must not be inherited by processes started from this
+	// process.
+	defer os.Unsetenv(workerTopologyEnv)
+	defer os.Unsetenv(workerParentFDEnv)
+
+	v := os.Getenv(workerParentFDEnv)
+	fd, err := strconv.Atoi(v)
+	if err != nil {
+		return nil, fmt.Errorf("%v has an invalid file descriptor: %v", workerParentFDEnv, v)
+	}
+	w := &Worker{
+		Topology: name,
+		parent:   os.NewFile(uintptr(fd), "parent"),
+		done:     make(chan struct{}),
+	}
+	go w.watchParent()
+	return w, nil
+}
+
+func (w *Worker) watchParent() {
+	defer close(w.done)
+	defer w.parent.Close()
+	// The parent process never writes to the pipe, so Read only returns
+	// when the write end is closed.
+	b := make([]byte, 1)
+	for {
+		if _, err := w.parent.Read(b); err != nil {
+			return
+		}
+	}
+}
+
+// ParentDone returns a channel which is closed when the parent server stops
+// the worker process or exits.
+func (w *Worker) ParentDone() <-chan struct{} {
+	return w.done
+}
+
+// WorkerStatus is the status of a worker process hosting an isolated
+// topology.
+type WorkerStatus struct {
+	// Topology is the name of the topology hosted by the worker process.
+	Topology string
+
+	// PID is the process ID of the worker process. It's 0 while the process
+	// isn't running.
+	PID int
+
+	// Restarts is the number of times the worker process has been
+	// restarted after it exited unexpectedly.
+	Restarts int
+
+	// LastError is the error with which the worker process exited last
+	// time. It's nil when the process has never exited.
+	LastError error
+}
+
+// worker supervises a worker process hosting an isolated topology. The
+// listener of the unix socket is kept by the supervisor so that requests
+// sent while the process is being restarted wait in the backlog.
+type worker struct {
+	topology string
+	path     string
+	args     []string
+	logger   *logrus.Logger
+	listener *net.UnixListener
+	proxy    *httputil.ReverseProxy
+
+	minRestartInterval time.Duration
+	maxRestartInterval time.Duration
+
+	m        sync.Mutex
+	cmd      *exec.Cmd
+	parent   *os.File // the write end of the pipe watched by the process
+	restarts int
+	lastErr  error
+	stopping chan struct{}
+	done     chan struct{}
+}
+
+func newWorker(topology, socket, path string, args []string, logger *logrus.Logger) (*worker, error) {
+	l, err := net.ListenUnix("unix", &net.UnixAddr{Name: socket, Net: "unix"})
+	if err != nil {
+		return nil, fmt.Errorf("cannot listen on %v: %v", socket, err)
+	}
+	w := &worker{
+		topology:           topology,
+		path:               path,
+		args:               args,
+		logger:             logger,
+		listener:           l,
+		minRestartInterval: minWorkerRestartInterval,
+		maxRestartInterval: maxWorkerRestartInterval,
+		stopping:           make(chan struct{}),
+		done:               make(chan struct{}),
+	}
+	w.proxy = &httputil.ReverseProxy{
+		Director: func(req *http.Request) {
+			req.URL.Scheme = "http"
+			req.URL.Host = "worker"
+		},
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+				d := net.Dialer{}
+				return d.DialContext(ctx, "unix", socket)
+			},
+		},
+		FlushInterval: -1, // SELECT statements stream their results
+		ErrorHandler:  w.renderProxyError,
+	}
+	return w, nil
+}
+
+// start starts the worker process and keeps restarting it until stop is
+// called.
+func (w *worker) start() {
+	go func() {
+		defer close(w.done)
+		interval := w.minRestartInterval
+		for {
+			started := time.Now()
+			err := w.run()
+			select {
+			case <-w.stopping:
+				return
+			default:
+			}
+
+			if time.Since(started) > w.maxRestartInterval {
+				interval = w.minRestartInterval
+			}
+			w.logger.WithFields(logrus.Fields{
+				"err":      err,
+				"topology": w.topology,
+				"restart":  interval.String(),
+			}).Error("The worker process of the topology exited unexpectedly")
+			select {
+			case <-w.stopping:
+				return
+			case <-time.After(interval):
+			}
+			if interval *= 2; interval > w.maxRestartInterval {
+				interval = w.maxRestartInterval
+			}
+			w.m.Lock()
+			w.restarts++
+			w.m.Unlock()
+		}
+	}()
+}
+
+// run runs the worker process and waits until it exits.
+func (w *worker) run() error {
+	lf, err := w.listener.File()
+	if err != nil {
+		return fmt.Errorf("cannot get the file of the listener: %v", err)
+	}
+	defer lf.Close()
+	r, pw, err := os.Pipe()
+	if err != nil {
+		return fmt.Errorf("cannot create a pipe: %v", err)
+	}
+	defer r.Close()
+
+	cmd := exec.Command(w.path, w.args...)
+	// ExtraFiles[i] becomes the file descriptor 3+i in the new process.
+	cmd.Env = append(os.Environ(), listenerFDEnv+"=3",
+		workerTopologyEnv+"="+w.topology, workerParentFDEnv+"=4")
+	cmd.ExtraFiles = []*os.File{lf, r}
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.SysProcAttr = workerSysProcAttr()
+
+	w.m.Lock()
+	select {
+	case <-w.stopping:
+		w.m.Unlock()
+		pw.Close()
+		return nil
+	default:
+	}
+	err = cmd.Start()
+	if err != nil {
+		w.lastErr = err
+		w.m.Unlock()
+		pw.Close()
+		return fmt.Errorf("cannot start a worker process: %v", err)
+	}
+	w.cmd = cmd
+	w.parent = pw
+	w.m.Unlock()
+	w.logger.WithFields(logrus.Fields{
+		"topology": w.topology,
+		"pid":      cmd.Process.Pid,
+	}).Info("Started the worker process of the topology")
+
+	err = cmd.Wait()
+	if err == nil {
+		err = fmt.Errorf("the process exited")
+	}
+	w.m.Lock()
+	w.cmd = nil
+	w.parent = nil
+	w.lastErr = err
+	w.m.Unlock()
+	pw.Close()
+	return err
+}
+
+// stop stops the worker process. The process stops its topology when the
+// pipe to it is closed, and it's killed when it doesn't exit within the
+// timeout.
+func (w *worker) stop(timeout time.Duration) {
+	w.m.Lock()
+	select {
+	case <-w.stopping:
+		w.m.Unlock()
+		<-w.done
+		return
+	default:
+	}
+	close(w.stopping)
+	cmd := w.cmd
+	if w.parent != nil {
+		w.parent.Close()
+		w.parent = nil
+	}
+	w.m.Unlock()
+
+	if cmd != nil {
+		select {
+		case <-w.done:
+		case <-time.After(timeout):
+			w.logger.WithField("topology", w.topology).Warning("Killing the worker process of the topology")
+			cmd.Process.Kill()
+		}
+	}
+	<-w.done
+	w.listener.Close()
+}
+
+func (w *worker) status() *WorkerStatus {
+	w.m.Lock()
+	defer w.m.Unlock()
+	s := &WorkerStatus{
+		Topology:  w.topology,
+		Restarts:  w.restarts,
+		LastError: w.lastErr,
+	}
+	if w.cmd != nil {
+		s.PID = w.cmd.Process.Pid
+	}
+	return s
+}
+
+// renderProxyError responds to a request which couldn't be forwarded to the
+// worker process in the same JSON format as other errors.
+func (w *worker) renderProxyError(rw http.ResponseWriter, req *http.Request, err error) {
+	id := RequestIDFromContext(req.Context())
+	w.logger.WithFields(logrus.Fields{
+		"err":        err,
+		"topology":   w.topology,
+		"request_id": id,
+		"path":       req.URL.RequestURI(),
+	}).Error("Cannot forward the request to the worker process of the topology")
+	body, e := json.Marshal(map[string]interface{}{
+		"error": newErrorResponse(req, workerUnavailableErrorCode, "The worker process of the topology is unavailable."),
+	})
+	if e != nil {
+		rw.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(http.StatusServiceUnavailable)
+	rw.Write(body)
+}
+
+// workerSupervisor supervises worker processes of isolated topologies in
+// the default namespace.
+type workerSupervisor struct {
+	dir     string
+	workers map[string]*worker
+}
+
+// newWorkerSupervisor creates a supervisor of worker processes of topologies
+// whose isolation is "process". It returns nil when there's no such
+// topology. A worker process is started from the program at path with args
+// when start is called.
+func newWorkerSupervisor(conf *config.Config, path string, args []string, logger *logrus.Logger) (*workerSupervisor, error) {
+	var names []string
+	for name, t := range conf.Topologies {
+		if t.Isolation == isolationProcess {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return nil, nil
+	}
+	sort.Strings(names)
+
+	dir, err := ioutil.TempDir("", "sensorbee-workers")
+	if err != nil {
+		return nil, fmt.Errorf("cannot create a directory for sockets of worker processes: %v", err)
+	}
+	s := &workerSupervisor{
+		dir:     dir,
+		workers: map[string]*worker{},
+	}
+	for _, name := range names {
+		w, err := newWorker(name, filepath.Join(dir, name+".sock"), path, args, logger)
+		if err != nil {
+			for _, w := range s.workers {
+				w.listener.Close()
+			}
+			os.RemoveAll(dir)
+			return nil, err
+		}
+		s.workers[strings.ToLower(name)] = w
+	}
+	return s, nil
+}
+
+// start starts all worker processes.
+func (s *workerSupervisor) start() {
+	if s == nil {
+		return
+	}
+	for _, w := range s.workers {
+		w.start()
+	}
+}
+
+// lookup returns the worker of the topology. It returns nil when the
+// topology isn't isolated.
+func (s *workerSupervisor) lookup(topology string) *worker {
+	if s == nil {
+		return nil
+	}
+	return s.workers[strings.ToLower(topology)]
+}
+
+// middleware forwards requests to topologies hosted by worker processes.
+// Other requests are processed by the server. Requests to all versions of
+// the API are forwarded.
+func (s *workerSupervisor) middleware() Middleware {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			for _, v := range apiVersions {
+				prefix := "/api/" + v + "/topologies/"
+				if !strings.HasPrefix(req.URL.Path, prefix) {
+					continue
+				}
+				name := strings.SplitN(req.URL.Path[len(prefix):], "/", 2)[0]
+				if w := s.lookup(name); w != nil {
+					w.proxy.ServeHTTP(rw, req)
+					return
+				}
+				break
+			}
+			h.ServeHTTP(rw, req)
+		})
+	}
+}
+
+// stop stops all worker processes concurrently.
+func (s *workerSupervisor) stop(timeout time.Duration) {
+	if s == nil {
+		return
+	}
+	var wg sync.WaitGroup
+	for _, w := range s.workers {
+		w := w
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.stop(timeout)
+		}()
+	}
+	wg.Wait()
+	os.RemoveAll(s.dir)
+}
+
+func (s *workerSupervisor) statuses() []*WorkerStatus {
+	if s == nil {
+		return nil
+	}
+	var ss []*WorkerStatus
+	for _, w := range s.workers {
+		ss = append(ss, w.status())
+	}
+	sort.Slice(ss, func(i, j int) bool {
+		return ss[i].Topology < ss[j].Topology
+	})
+	return ss
+}
@@ -0,0 +1,14 @@
+//go:build !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd
+// +build !darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd
+
+package server
+
+import (
+	"syscall"
+)
+
+// workerSysProcAttr returns nil because process groups aren't supported on
+// this platform.
+func workerSysProcAttr() *syscall.SysProcAttr {
+	return nil
+}
@@ -0,0 +1,142 @@
+package server
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"gopkg.in/sensorbee/sensorbee.v0/server/config"
+)
+
+// TestWorkerHelperProcess isn't a real test. It runs as a worker process
+// started by tests of workerSupervisor. It responds with the name of the
+// topology and its PID, and exits with an error on ".../crash".
+func TestWorkerHelperProcess(t *testing.T) {
+	if os.Getenv(workerTopologyEnv) == "" {
+		return
+	}
+	l, err := InheritedListener()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	w, err := InheritedWorker()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	go http.Serve(l, http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if strings.HasSuffix(req.URL.Path, "/crash") {
+			os.Exit(1)
+		}
+		fmt.Fprintf(rw, "%v:%v", w.Topology, os.Getpid())
+	}))
+	<-w.ParentDone()
+	os.Exit(0)
+}
+
+func TestWorkerSupervisor(t *testing.T) {
+	Convey("Given a config having an isolated topology", t, func() {
+		conf, err := config.New(data.Map{
+			"topologies": data.Map{
+				"iso":   data.Map{"isolation": data.String("process")},
+				"local": data.Map{},
+			},
+		})
+		So(err, ShouldBeNil)
+		logger := logrus.New()
+		logger.Out = ioutil.Discard
+
+		Convey("When checking which process hosts topologies", func() {
+			Convey("Then the server should only host the topology which isn't isolated", func() {
+				So(hostsTopology(conf, "iso", ""), ShouldBeFalse)
+				So(hostsTopology(conf, "local", ""), ShouldBeTrue)
+			})
+
+			Convey("Then a worker process should only host its topology", func() {
+				So(hostsTopology(conf, "iso", "iso"), ShouldBeTrue)
+				So(hostsTopology(conf, "local", "iso"), ShouldBeFalse)
+			})
+		})
+
+		Convey("When starting a worker process", func() {
+			s, err := newWorkerSupervisor(conf, os.Args[0], []string{"-test.run=TestWorkerHelperProcess"}, logger)
+			So(err, ShouldBeNil)
+			So(s, ShouldNotBeNil)
+			w := s.lookup("ISO")
+			So(w, ShouldNotBeNil)
+			w.minRestartInterval = 10 * time.Millisecond
+			s.start()
+			Reset(func() {
+				s.stop(workerStopTimeout)
+			})
+
+			local := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				fmt.Fprint(rw, "local")
+			})
+			ts := httptest.NewServer(s.middleware()(local))
+			Reset(ts.Close)
+			// do sends a request to the server. Requests to crash the worker
+			// process use POST so that the transport doesn't retry them on
+			// the restarted process.
+			do := func(method, path string) (int, string) {
+				req, err := http.NewRequest(method, ts.URL+path, nil)
+				So(err, ShouldBeNil)
+				res, err := http.DefaultClient.Do(req)
+				So(err, ShouldBeNil)
+				defer res.Body.Close()
+				b, err := ioutil.ReadAll(res.Body)
+				So(err, ShouldBeNil)
+				return res.StatusCode, string(b)
+			}
+			get := func(path string) (int, string) {
+				return do("GET", path)
+			}
+
+			Convey("Then requests to the topology should be forwarded to the process", func() {
+				status, body := get("/api/v1/topologies/iso/queries")
+				So(status, ShouldEqual, http.StatusOK)
+				So(body, ShouldStartWith, "iso:")
+				So(body, ShouldNotEqual, fmt.Sprintf("iso:%v", os.Getpid()))
+				So(s.statuses()[0].PID, ShouldBeGreaterThan, 0)
+			})
+
+			Convey("Then other requests should be processed by the server", func() {
+				for _, p := range []string{"/api/v1/topologies", "/api/v1/topologies/local", "/api/v1/topologies/isolated"} {
+					_, body := get(p)
+					So(body, ShouldEqual, "local")
+				}
+			})
+
+			Convey("Then the process should be restarted after it crashes", func() {
+				_, body := get("/api/v1/topologies/iso")
+				status, _ := do("POST", "/api/v1/topologies/iso/crash")
+				So(status, ShouldEqual, http.StatusServiceUnavailable)
+
+				_, restarted := get("/api/v1/topologies/iso")
+				So(restarted, ShouldStartWith, "iso:")
+				So(restarted, ShouldNotEqual, body)
+				st := s.statuses()
+				So(st, ShouldHaveLength, 1)
+				So(st[0].Restarts, ShouldEqual, 1)
+				So(st[0].LastError, ShouldNotBeNil)
+			})
+
+			Convey("Then the process should exit when it's stopped", func() {
+				get("/api/v1/topologies/iso")
+				s.stop(workerStopTimeout)
+				So(s.statuses()[0].PID, ShouldEqual, 0)
+				_, err := os.Stat(s.dir)
+				So(os.IsNotExist(err), ShouldBeTrue)
+			})
+		})
+	})
+}
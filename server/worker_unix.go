@@ -0,0 +1,17 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+// +build darwin dragonfly freebsd linux netbsd openbsd
+
+package server
+
+import (
+	"syscall"
+)
+
+// workerSysProcAttr puts a worker process in its own process group so that
+// signals sent to the server's process group from a terminal don't stop it
+// before the server does.
+func workerSysProcAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{
+		Setpgid: true,
+	}
+}